@@ -27,6 +27,8 @@ import (
 	"encoding/pem"
 	"flag"
 	"fmt"
+	"io"
+	"net"
 	"os"
 	"strings"
 	"time"
@@ -35,8 +37,12 @@ import (
 	ownershipvoucher "github.com/openconfig/bootz/common/ownership_voucher"
 	"github.com/openconfig/bootz/common/signature"
 
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 
 	bpb "github.com/openconfig/bootz/proto/bootz"
@@ -45,19 +51,107 @@ import (
 // Represents a 128 bit nonce.
 const nonceLength = 16
 
+// maxRedirects bounds the number of server redirects followed, breaking redirect loops.
+const maxRedirects = 3
+
+// redirectDialTimeout bounds the time spent connecting to each server of a redirect.
+const redirectDialTimeout = 10 * time.Second
+
 var (
 	verifyTLSCert = flag.Bool("verify_tls_cert", false, "Whether to verify the TLS certificate presented by the Bootz server. If false, all TLS connections are implicitly trusted.")
 	insecureBoot  = flag.Bool("insecure_boot", false, "Whether to start the emulated device in non-secure mode. This informs Bootz server to not provide ownership certificates or vouchers.")
 	port          = flag.String("port", "", "The port to listen to on localhost for the bootz server.")
+	serverHost    = flag.String("server_host", "localhost", "The host of the bootz server, as a DHCP server would hand out.")
+	serverName    = flag.String("server_name", "", "The name the TLS certificate of the bootz server is verified against once its trust cert is received. Defaults to server_host.")
 	rootCA        = flag.String("root_ca_cert_path", "../testdata/vendorca_pub.pem", "The relative path to a file containing a PEM encoded certificate for the manufacturer CA.")
+	maxMsgSize    = flag.Uint64("max_message_size", 0, "The largest bootstrap response in bytes the emulated device advertises it can receive. 0 means no limit.")
+	stagingBundle = flag.String("staging_bundle", "", "The path to a binary encoded StagingBundle to bootstrap from instead of calling GetBootstrapData, emulating an offline USB or console load.")
+	streamData    = flag.Bool("stream_bootstrap_data", false, "Whether to receive the bootstrap data in chunks with GetBootstrapDataStream, for responses exceeding the maximum message size.")
+	compress      = flag.Bool("compress", false, "Whether to gzip compress the RPCs to the Bootz server, which then compresses its responses too.")
+	swVersion     = flag.String("software_version", "", "The software version the emulated control cards report running. The server omits the intended image if it has this version.")
+	sigAlgs       = flag.String("signature_algorithms", "", "A comma separated list of the algorithms the emulated device accepts response signatures in, in order of preference, e.g. RSA_PSS_SHA256,ECDSA_SHA256. If empty, the server signs with the default algorithm of its ownership certificate.")
 	urlImageMap   = map[string]string{
 		"https://path/to/image": "../testdata/image.txt",
 	}
 )
 
-// pemEncodeCert adds the correct PEM headers and footers to a raw certificate block.
-func pemEncodeCert(contents string) string {
-	return strings.Join([]string{"-----BEGIN CERTIFICATE-----", contents, "-----END CERTIFICATE-----"}, "\n")
+// dialOptions returns the options to dial the Bootz server with.
+func dialOptions(tlsConfig *tls.Config) []grpc.DialOption {
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig))}
+	if *compress {
+		opts = append(opts, grpc.WithDefaultCallOptions(grpc.UseCompressor(gzip.Name)))
+	}
+	return opts
+}
+
+// parseSignatureAlgorithms parses a comma separated list of signature algorithm names, with or
+// without their SIGNATURE_ALGORITHM_ prefix.
+func parseSignatureAlgorithms(list string) ([]bpb.SignatureAlgorithm, error) {
+	var algs []bpb.SignatureAlgorithm
+	for _, name := range strings.Split(list, ",") {
+		name = strings.ToUpper(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		alg, ok := bpb.SignatureAlgorithm_value["SIGNATURE_ALGORITHM_"+strings.TrimPrefix(name, "SIGNATURE_ALGORITHM_")]
+		if !ok || alg == int32(bpb.SignatureAlgorithm_SIGNATURE_ALGORITHM_UNSPECIFIED) {
+			return nil, fmt.Errorf("unknown signature algorithm %q", name)
+		}
+		algs = append(algs, bpb.SignatureAlgorithm(alg))
+	}
+	return algs, nil
+}
+
+// redirectTLSConfig returns the TLS config validating a redirect server: its trust anchor if it
+// has one, or the TLS config of the redirecting server otherwise.
+func redirectTLSConfig(server *bpb.RedirectServer, current *tls.Config) (*tls.Config, error) {
+	if server.GetTrustAnchor() == "" {
+		return current, nil
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM([]byte(server.GetTrustAnchor())) {
+		return nil, fmt.Errorf("invalid trust anchor for %v", server.GetAddress())
+	}
+	return &tls.Config{RootCAs: pool}, nil
+}
+
+// dialRedirect connects to the first reachable redirect server, returning its address and TLS config.
+func dialRedirect(servers []*bpb.RedirectServer, current *tls.Config) (string, *tls.Config, *grpc.ClientConn, error) {
+	var errs []string
+	for _, server := range servers {
+		tlsConfig, err := redirectTLSConfig(server, current)
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), redirectDialTimeout)
+		conn, err := grpc.DialContext(ctx, server.GetAddress(), append(dialOptions(tlsConfig), grpc.WithBlock())...)
+		cancel()
+		if err != nil {
+			log.Warningf("Redirect server %v unreachable: %v", server.GetAddress(), err)
+			errs = append(errs, fmt.Sprintf("%v: %v", server.GetAddress(), err))
+			continue
+		}
+		return server.GetAddress(), tlsConfig, conn, nil
+	}
+	return "", nil, nil, fmt.Errorf("no redirect server reachable: %s", strings.Join(errs, "; "))
+}
+
+// validateControlCardOV checks that the OV of a control card is signed by the manufacturer
+// and was issued for the control card's serial number.
+func validateControlCardOV(serialNumber string, ov []byte, rootCA []byte) error {
+	vendorCAPool := x509.NewCertPool()
+	if !vendorCAPool.AppendCertsFromPEM(rootCA) {
+		return fmt.Errorf("unable to add vendor root CA to pool")
+	}
+	parsedOV, err := ownershipvoucher.VerifyAndUnmarshal(ov, vendorCAPool)
+	if err != nil {
+		return fmt.Errorf("unable to verify ownership voucher: %v", err)
+	}
+	if parsedOV.OV.SerialNumber != serialNumber {
+		return fmt.Errorf("serial number from OV does not match control card")
+	}
+	return nil
 }
 
 // validateArtifacts checks the signed artifacts in a GetBootstrapDataResponse. Specifically, it:
@@ -73,6 +167,9 @@ func validateArtifacts(serialNumber string, resp *bpb.GetBootstrapDataResponse,
 	}
 
 	parsedOV, err := ownershipvoucher.VerifyAndUnmarshal(resp.GetOwnershipVoucher(), vendorCAPool)
+	if err != nil {
+		return fmt.Errorf("unable to verify ownership voucher: %v", err)
+	}
 	log.Infof("=============================================================================")
 	log.Infof("Validated ownership voucher signed by vendor")
 	log.Infof("=============================================================================")
@@ -89,30 +186,20 @@ func validateArtifacts(serialNumber string, resp *bpb.GetBootstrapDataResponse,
 	}
 	log.Infof("Verified serial number is %v", serialNumber)
 
-	log.Infof("Adding PEM headers and footers to OV")
-	pdCPEM := pemEncodeCert(parsedOV.OV.PinnedDomainCert)
+	pdc, err := parsedOV.PinnedDomainCertificate()
+	if err != nil {
+		return err
+	}
 
 	// Create a new pool with this PDC.
 	log.Infof("Creating a new pool with the PDC")
 	pdcPool := x509.NewCertPool()
-	if !pdcPool.AppendCertsFromPEM([]byte(pdCPEM)) {
-		return err
-	}
-
-	// Parse the Ownership Certificate.
-	log.Infof("Parsing the OC")
-	ocCert, err := certFromPemBlock(oc)
-	if err != nil {
-		return fmt.Errorf("failed to parse certificate: %v", err)
-	}
+	pdcPool.AddCert(pdc)
 
-	// Verify that the OC is signed by the PDC.
+	// Verify that the OC chains to the PDC.
 	log.Infof("Verifying that the OC is signed by the PDC")
-	opts := x509.VerifyOptions{
-		Roots:         pdcPool,
-		Intermediates: x509.NewCertPool(),
-	}
-	if _, err := ocCert.Verify(opts); err != nil {
+	ocCert, err := verifyOwnershipCertificate(oc, pdcPool)
+	if err != nil {
 		return err
 	}
 	log.Infof("Validated ownership certificate with OV PDC")
@@ -121,13 +208,64 @@ func validateArtifacts(serialNumber string, resp *bpb.GetBootstrapDataResponse,
 	log.Infof("=============================================================================")
 	log.Infof("===================== Validating the response signature =====================")
 	log.Infof("=============================================================================")
-	if err := signature.Verify(ocCert, resp.GetSerializedBootstrapData(), resp.GetResponseSignature()); err != nil {
+	if err := signature.VerifyWithAlgorithm(ocCert, resp.GetSerializedBootstrapData(), resp.GetResponseSignature(), resp.GetSignatureAlgorithm()); err != nil {
 		return err
 	}
-	log.Infof("Successfully validated the response")
+	log.Infof("Successfully validated the response signed with %v", resp.GetSignatureAlgorithm())
+
+	// During an ownership rotation the response is also signed by the previous OC, for devices
+	// which still trust the previous PDC.
+	if prev := resp.GetPreviousOwnershipCertificate(); len(prev) > 0 {
+		block, _ := pem.Decode(prev)
+		if block == nil {
+			return fmt.Errorf("failed to parse previous ownership certificate PEM")
+		}
+		prevCert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return fmt.Errorf("failed to parse previous ownership certificate: %v", err)
+		}
+		if err := signature.VerifyWithAlgorithm(prevCert, resp.GetSerializedBootstrapData(), resp.GetPreviousResponseSignature(), resp.GetPreviousSignatureAlgorithm()); err != nil {
+			return fmt.Errorf("failed to validate the previous response signature: %v", err)
+		}
+		log.Infof("Validated the response signature of the previous OC %q", prevCert.Subject.CommonName)
+	}
 	return nil
 }
 
+// verifyOwnershipCertificate parses the PEM encoded OC, which may be followed by the intermediate
+// certificates chaining it to the PDC, and verifies that it chains to a PDC of the pool.
+// It returns the OC.
+func verifyOwnershipCertificate(oc []byte, pdcPool *x509.CertPool) (*x509.Certificate, error) {
+	var chain []*x509.Certificate
+	for rest := oc; ; {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse certificate: %v", err)
+		}
+		chain = append(chain, cert)
+	}
+	if len(chain) == 0 {
+		return nil, fmt.Errorf("failed to parse certificate PEM")
+	}
+	intermediates := x509.NewCertPool()
+	for _, c := range chain[1:] {
+		intermediates.AddCert(c)
+	}
+	opts := x509.VerifyOptions{
+		Roots:         pdcPool,
+		Intermediates: intermediates,
+	}
+	if _, err := chain[0].Verify(opts); err != nil {
+		return nil, err
+	}
+	return chain[0], nil
+}
+
 // validateImage validates if the hash of the downloaded OS image matches the received image hash.
 func validateImage(image []byte, softwareImage *bpb.SoftwareImage) error {
 	log.Info("Start to validate the downloaded image")
@@ -149,6 +287,12 @@ func validateImage(image []byte, softwareImage *bpb.SoftwareImage) error {
 	return nil
 }
 
+// sha256Hex returns the hex encoded SHA-256 hash of data.
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
 // downloadImage downloads image from the given URL.
 // This is a mock implementation.
 func downloadImage(url string) ([]byte, error) {
@@ -181,6 +325,181 @@ func generateNonce() (string, error) {
 	return base64.StdEncoding.EncodeToString(b), nil
 }
 
+// getBootstrapData advertises the device capabilities and requests bootstrap data from the server.
+// It returns the response and the nonce used to request it.
+func getBootstrapData(ctx context.Context, c bpb.BootstrapClient, chassis *bpb.ChassisDescriptor, activeControlCard *bpb.ControlCard) (*bpb.GetBootstrapDataResponse, string) {
+	// Advertise the device capabilities before requesting bootstrap data.
+	log.Infof("Reporting device capabilities")
+	capsReq := &bpb.ReportCapabilitiesRequest{
+		ChassisDescriptor: chassis,
+		Capabilities: &bpb.DeviceCapabilities{
+			MaxMessageSize: *maxMsgSize,
+		},
+	}
+	if !*insecureBoot {
+		capsReq.Capabilities.Features = append(capsReq.Capabilities.Features, "ownership-voucher")
+	}
+	if _, err := c.ReportCapabilities(ctx, capsReq); err != nil {
+		switch status.Code(err) {
+		case codes.Unimplemented:
+			log.Infof("Server does not support capability reporting, continuing")
+		case codes.Unauthenticated:
+			log.Infof("Server requires capabilities to be reported with a bootstrap token or client certificate, continuing without")
+		default:
+			log.Exitf("Error reporting capabilities: %v", err)
+		}
+	}
+
+	nonce := ""
+	var err error
+	if !*insecureBoot {
+		log.Infof("Device in secure boot mode, generating a nonce that the Bootz server will use to sign the response")
+		// Generate a nonce that the Bootz server will use to sign the response.
+		nonce, err = generateNonce()
+		if err != nil {
+			log.Exitf("Error generating nonce: %v", err)
+		}
+		log.Infof("Nonce of %v generated successfully", nonce)
+	}
+
+	log.Infof("=============================================================================")
+	log.Infof("======================== Retrieving bootstrap data ==========================")
+	log.Infof("=============================================================================")
+	log.Infof("Building bootstrap data request")
+	req := &bpb.GetBootstrapDataRequest{
+		ChassisDescriptor: chassis,
+		// This is the active control card, e.g. the one making the bootz request.
+		ControlCardState: &bpb.ControlCardState{
+			SerialNumber: activeControlCard.GetSerialNumber(),
+			Status:       bpb.ControlCardState_CONTROL_CARD_STATUS_NOT_INITIALIZED,
+		},
+		Nonce: nonce,
+	}
+	if nonce != "" {
+		algs, err := parseSignatureAlgorithms(*sigAlgs)
+		if err != nil {
+			log.Exitf("Error parsing signature algorithms: %v", err)
+		}
+		req.SignatureAlgorithms = algs
+	}
+	log.Infof("Built bootstrap data request with %v chassis %v and control card %v with status %v and nonce %v",
+		req.ChassisDescriptor.Manufacturer, req.ChassisDescriptor.SerialNumber, req.ControlCardState.SerialNumber, req.ControlCardState.Status, req.Nonce)
+
+	// Get bootstrapping data from Bootz server
+	// TODO: Extract and parse response.
+	log.Infof("Requesting Bootstrap Data from Bootz server")
+	fetch := func(ctx context.Context, req *bpb.GetBootstrapDataRequest) (*bpb.GetBootstrapDataResponse, error) {
+		return c.GetBootstrapData(ctx, req)
+	}
+	if *streamData {
+		log.Infof("Streaming the bootstrap data in chunks")
+		fetch = func(ctx context.Context, req *bpb.GetBootstrapDataRequest) (*bpb.GetBootstrapDataResponse, error) {
+			stream, err := c.GetBootstrapDataStream(ctx, req)
+			if err != nil {
+				return nil, err
+			}
+			return receiveChunks(stream)
+		}
+	}
+	resp, err := fetch(ctx, req)
+	for err != nil {
+		delay, ok := retryDelay(err)
+		if !ok {
+			log.Exitf("Error calling GetBootstrapData: %v", err)
+		}
+		log.Infof("Server asked to retry in %v: %v", delay, status.Convert(err).Message())
+		select {
+		case <-ctx.Done():
+			log.Exitf("Gave up retrying GetBootstrapData: %v", ctx.Err())
+		case <-time.After(delay):
+		}
+		resp, err = fetch(ctx, req)
+	}
+	log.Infof("Successfully retrieved Bootstrap Data from server")
+	return resp, nonce
+}
+
+// chunkReceiver receives the chunks of a streamed bootstrap response.
+type chunkReceiver interface {
+	Recv() (*bpb.BootstrapDataChunk, error)
+}
+
+// receiveChunks reassembles and parses a bootstrap response streamed in chunks.
+func receiveChunks(stream chunkReceiver) (*bpb.GetBootstrapDataResponse, error) {
+	var data []byte
+	var total uint64
+	chunks := 0
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if chunks > 0 && chunk.GetTotalSize() != total {
+			return nil, fmt.Errorf("chunk %d announces a total size of %d bytes, want %d", chunks, chunk.GetTotalSize(), total)
+		}
+		total = chunk.GetTotalSize()
+		data = append(data, chunk.GetData()...)
+		chunks++
+	}
+	if uint64(len(data)) != total {
+		return nil, fmt.Errorf("received %d bytes of a %d bytes bootstrap response", len(data), total)
+	}
+	resp := &bpb.GetBootstrapDataResponse{}
+	if err := proto.Unmarshal(data, resp); err != nil {
+		return nil, fmt.Errorf("unable to parse streamed bootstrap response: %v", err)
+	}
+	log.Infof("Received bootstrap response of %d bytes in %d chunks", total, chunks)
+	return resp, nil
+}
+
+// retryDelay returns the delay the server asks to wait before retrying a failed request, if any.
+func retryDelay(err error) (time.Duration, bool) {
+	if status.Code(err) != codes.Unavailable {
+		return 0, false
+	}
+	for _, d := range status.Convert(err).Details() {
+		if ri, ok := d.(*errdetails.RetryInfo); ok {
+			return ri.GetRetryDelay().AsDuration(), true
+		}
+	}
+	return 0, false
+}
+
+// loadStagingBundle reads a binary encoded StagingBundle from path.
+// credentialsSummary returns the accounts with authorized keys and the number of host keys of the
+// credentials, without exposing any key material.
+func credentialsSummary(creds *bpb.Credentials) ([]string, int) {
+	var accounts []string
+	for _, req := range creds.GetCredentials() {
+		for _, c := range req.GetCredentials() {
+			accounts = append(accounts, c.GetAccount())
+		}
+	}
+	hostKeys := 0
+	for _, req := range creds.GetServerKeys() {
+		hostKeys += len(req.GetAuthArtifacts())
+	}
+	return accounts, hostKeys
+}
+
+func loadStagingBundle(path string) (*bpb.StagingBundle, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	bundle := &bpb.StagingBundle{}
+	if err := proto.Unmarshal(data, bundle); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal staging bundle: %v", err)
+	}
+	if bundle.GetBundleId() == "" || bundle.GetResponse() == nil {
+		return nil, fmt.Errorf("staging bundle is missing its ID or response")
+	}
+	return bundle, nil
+}
+
 func main() {
 	ctx := context.Background()
 	flag.Parse()
@@ -219,14 +538,16 @@ func main() {
 		SerialNumber: "123",
 		ControlCards: []*bpb.ControlCard{
 			{
-				SerialNumber: "123A",
-				Slot:         1,
-				PartNumber:   "123A",
+				SerialNumber:    "123A",
+				Slot:            1,
+				PartNumber:      "123A",
+				SoftwareVersion: *swVersion,
 			},
 			{
-				SerialNumber: "123B",
-				Slot:         2,
-				PartNumber:   "123B",
+				SerialNumber:    "123B",
+				Slot:            2,
+				PartNumber:      "123B",
+				SoftwareVersion: *swVersion,
 			},
 		},
 	}
@@ -235,20 +556,37 @@ func main() {
 
 	// 1. DHCP Discovery of Bootstrap Server
 	// This step emulates the retrieval of the bootz server IP
-	// address from a DHCP server. In this case we always connect to localhost.
+	// address from a DHCP server. In this case we connect to server_host.
 	log.Infof("=============================================================================")
 	log.Infof("================ Starting DHCP discovery of bootstrap server ================")
 	log.Infof("=============================================================================")
 	if *port == "" {
 		log.Exitf("No port provided.")
 	}
-	bootzAddress := fmt.Sprintf("localhost:%v", *port)
+	bootzAddress := net.JoinHostPort(*serverHost, *port)
 	log.Infof("Connecting to bootz server at address %q", bootzAddress)
 
 	// 2. Bootstrapping Service
 	// Device initiates a TLS-secured gRPC connection with the Bootz server.
 	tlsConfig := &tls.Config{InsecureSkipVerify: !*verifyTLSCert}
-	conn, err := grpc.Dial(bootzAddress, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+	if *loadTestDevices > 0 {
+		log.Infof("Starting load test with %d devices, %d at a time", *loadTestDevices, *loadTestConcurrency)
+		runLoadTest(ctx, bootzAddress, tlsConfig, *loadTestDevices, *loadTestConcurrency).log()
+		return
+	}
+	if *fleetTopologyFile != "" {
+		topo, err := loadFleetTopology(*fleetTopologyFile)
+		if err != nil {
+			log.Exitf("Error loading fleet topology: %v", err)
+		}
+		if *insecureBoot && topo.hasFaults() {
+			log.Exitf("Faults can only be injected in secure boot mode")
+		}
+		log.Infof("Starting fleet simulation of %v", *fleetTopologyFile)
+		runFleet(ctx, bootzAddress, tlsConfig, rootCABytes, topo).log()
+		return
+	}
+	conn, err := grpc.Dial(bootzAddress, dialOptions(tlsConfig)...)
 	if err != nil {
 		log.Exitf("Client unable to connect to Bootstrap Server: %v", err)
 	}
@@ -263,59 +601,64 @@ func main() {
 		chassis.ControlCards[0].SerialNumber, chassis.ControlCards[0].Slot, chassis.ControlCards[0].PartNumber)
 	activeControlCard := chassis.ControlCards[0]
 
+	var resp *bpb.GetBootstrapDataResponse
+	var signedResp *bpb.BootstrapDataSigned
 	nonce := ""
-	if !*insecureBoot {
-		log.Infof("Device in secure boot mode, generating a nonce that the Bootz server will use to sign the response")
-		// Generate a nonce that the Bootz server will use to sign the response.
-		nonce, err = generateNonce()
-		if err != nil {
-			log.Exitf("Error generating nonce: %v", err)
+	bundleID := ""
+	for redirects := 0; ; redirects++ {
+		if *stagingBundle != "" && redirects == 0 {
+			log.Infof("=============================================================================")
+			log.Infof("========================= Loading staging bundle ============================")
+			log.Infof("=============================================================================")
+			bundle, err := loadStagingBundle(*stagingBundle)
+			if err != nil {
+				log.Exitf("Error loading staging bundle: %v", err)
+			}
+			// The bundle was signed offline using its ID as the nonce.
+			bundleID = bundle.GetBundleId()
+			nonce = bundleID
+			resp = bundle.GetResponse()
+			log.Infof("Loaded staging bundle %v", bundleID)
+		} else {
+			resp, nonce = getBootstrapData(ctx, c, &chassis, activeControlCard)
 		}
-		log.Infof("Nonce of %v generated successfully", nonce)
-	}
 
-	log.Infof("=============================================================================")
-	log.Infof("======================== Retrieving bootstrap data ==========================")
-	log.Infof("=============================================================================")
-	log.Infof("Building bootstrap data request")
-	req := &bpb.GetBootstrapDataRequest{
-		ChassisDescriptor: &chassis,
-		// This is the active control card, e.g. the one making the bootz request.
-		ControlCardState: &bpb.ControlCardState{
-			SerialNumber: activeControlCard.GetSerialNumber(),
-			Status:       bpb.ControlCardState_CONTROL_CARD_STATUS_NOT_INITIALIZED,
-		},
-		Nonce: nonce,
-	}
-	log.Infof("Built bootstrap data request with %v chassis %v and control card %v with status %v and nonce %v",
-		req.ChassisDescriptor.Manufacturer, req.ChassisDescriptor.SerialNumber, req.ControlCardState.SerialNumber, req.ControlCardState.Status, req.Nonce)
+		// Only check OC, OV and response signature if SecureOnly is set.
+		if !*insecureBoot {
+			log.Infof("=============================================================================")
+			log.Infof("====================== Validating response signature ========================")
+			log.Infof("=============================================================================")
+			if err := validateArtifacts(activeControlCard.GetSerialNumber(), resp, rootCABytes); err != nil {
+				log.Exitf("Error validating signed data: %v", err)
+			}
+		}
 
-	// Get bootstrapping data from Bootz server
-	// TODO: Extract and parse response.
-	log.Infof("Requesting Bootstrap Data from Bootz server")
-	resp, err := c.GetBootstrapData(ctx, req)
-	if err != nil {
-		log.Exitf("Error calling GetBootstrapData: %v", err)
-	}
-	log.Infof("Successfully retrieved Bootstrap Data from server")
+		signedResp = &bpb.BootstrapDataSigned{}
+		if err := proto.Unmarshal(resp.GetSerializedBootstrapData(), signedResp); err != nil {
+			log.Exitf("unable to unmarshal serialized bootstrap data: %v", err)
+		}
 
-	// Only check OC, OV and response signature if SecureOnly is set.
-	if !*insecureBoot {
+		if !*insecureBoot && signedResp.GetNonce() != nonce {
+			log.Exitf("GetBootstrapDataResponse nonce does not match")
+		}
+
+		servers := signedResp.GetRedirect().GetServers()
+		if len(servers) == 0 {
+			break
+		}
+		if redirects >= maxRedirects {
+			log.Exitf("Gave up after %d redirects", redirects)
+		}
 		log.Infof("=============================================================================")
-		log.Infof("====================== Validating response signature ========================")
+		log.Infof("========================= Following server redirect =========================")
 		log.Infof("=============================================================================")
-		if err := validateArtifacts(activeControlCard.GetSerialNumber(), resp, rootCABytes); err != nil {
-			log.Exitf("Error validating signed data: %v", err)
+		conn.Close()
+		bootzAddress, tlsConfig, conn, err = dialRedirect(servers, tlsConfig)
+		if err != nil {
+			log.Exitf("Unable to follow redirect: %v", err)
 		}
-	}
-
-	var signedResp bpb.BootstrapDataSigned
-	if err := proto.Unmarshal(resp.GetSerializedBootstrapData(), &signedResp); err != nil {
-		log.Exitf("unable to unmarshal serialized bootstrap data: %v", err)
-	}
-
-	if !*insecureBoot && signedResp.GetNonce() != nonce {
-		log.Exitf("GetBootstrapDataResponse nonce does not match")
+		c = bpb.NewBootstrapClient(conn)
+		log.Infof("Redirected to bootz server at address %q", bootzAddress)
 	}
 
 	// TODO: Verify the hash of the intended image.
@@ -326,22 +669,44 @@ func main() {
 	if len(signedResp.GetResponses()) == 0 {
 		log.Exitf("response contained no bootstrap responses")
 	}
+	// The hashes of the image and vendor config applied by each control card, reported back to
+	// the server to detect drift from the artifacts it served.
+	applied := map[string]*bpb.ControlCardState{}
 	for _, data := range signedResp.GetResponses() {
+		state := &bpb.ControlCardState{}
+		applied[data.GetSerialNum()] = state
 		log.Infof("Received config for control card %v", data.GetSerialNum())
-		log.Infof("Start to download and validate image, received: %+v...", data.GetIntendedImage())
-		image, err := downloadImage(data.GetIntendedImage().GetUrl())
-		if err != nil {
-			log.Exitf("unable to download image (url: %q): %v", data.GetIntendedImage().GetUrl(), err)
+		if !*insecureBoot && len(data.GetOwnershipVoucher()) > 0 {
+			if err := validateControlCardOV(data.GetSerialNum(), data.GetOwnershipVoucher(), rootCABytes); err != nil {
+				log.Exitf("Error validating ownership voucher for control card %v: %v", data.GetSerialNum(), err)
+			}
+			log.Infof("Validated ownership voucher for control card %v", data.GetSerialNum())
 		}
-		err = validateImage(image, data.GetIntendedImage())
-		if err != nil {
-			log.Exitf("Error validating intended image: %v", err)
+		if data.GetIntendedImage() == nil {
+			log.Infof("No intended image received, keeping the running software version %q", *swVersion)
+		} else {
+			log.Infof("Start to download and validate image, received: %+v...", data.GetIntendedImage())
+			image, err := downloadImage(data.GetIntendedImage().GetUrl())
+			if err != nil {
+				log.Exitf("unable to download image (url: %q): %v", data.GetIntendedImage().GetUrl(), err)
+			}
+			err = validateImage(image, data.GetIntendedImage())
+			if err != nil {
+				log.Exitf("Error validating intended image: %v", err)
+			}
+			state.AppliedImageHash = sha256Hex(image)
+			time.Sleep(time.Second * 5)
+			log.Infof("Done")
 		}
-		time.Sleep(time.Second * 5)
-		log.Infof("Done")
 		log.Infof("Installing boot config %+v...", data.GetBootConfig())
+		if cfg := data.GetBootConfig().GetVendorConfig(); len(cfg) > 0 {
+			state.AppliedConfigHash = sha256Hex(cfg)
+		}
 		time.Sleep(time.Second * 5)
 		log.Infof("Done")
+		if accounts, hostKeys := credentialsSummary(data.GetCredentials()); len(accounts) > 0 || hostKeys > 0 {
+			log.Infof("Provisioning SSH credentials for accounts %v and %d host keys", accounts, hostKeys)
+		}
 		log.Infof("=============================================================================")
 	}
 
@@ -359,9 +724,10 @@ func main() {
 	tlsConfig = &tls.Config{
 		InsecureSkipVerify: false,
 		RootCAs:            trustCertPool,
+		ServerName:         *serverName,
 	}
 	conn.Close()
-	conn, err = grpc.Dial(bootzAddress, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+	conn, err = grpc.Dial(bootzAddress, dialOptions(tlsConfig)...)
 	if err != nil {
 		log.Exitf("Client unable to re-connect to Bootstrap Server: %v", err)
 	}
@@ -372,8 +738,9 @@ func main() {
 	log.Infof("=========================== Sending Status Report ===========================")
 	log.Infof("=============================================================================")
 	statusReq := &bpb.ReportStatusRequest{
-		Status:        bpb.ReportStatusRequest_BOOTSTRAP_STATUS_SUCCESS,
-		StatusMessage: "Bootstrap Success",
+		Status:          bpb.ReportStatusRequest_BOOTSTRAP_STATUS_SUCCESS,
+		StatusMessage:   "Bootstrap Success",
+		StagingBundleId: bundleID,
 		States: []*bpb.ControlCardState{
 			{
 				Status:       bpb.ControlCardState_CONTROL_CARD_STATUS_INITIALIZED,
@@ -386,6 +753,11 @@ func main() {
 		},
 	}
 
+	for _, st := range statusReq.GetStates() {
+		st.AppliedImageHash = applied[st.GetSerialNumber()].GetAppliedImageHash()
+		st.AppliedConfigHash = applied[st.GetSerialNumber()].GetAppliedConfigHash()
+	}
+
 	_, err = c.ReportStatus(ctx, statusReq)
 	if err != nil {
 		log.Exitf("Error reporting status: %v", err)
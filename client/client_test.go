@@ -0,0 +1,245 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	bpb "github.com/openconfig/bootz/proto/bootz"
+)
+
+// newCert returns a certificate signed by parent, or self-signed if parent is nil.
+func newCert(t *testing.T, name string, isCA bool, parent *x509.Certificate, parentKey crypto.Signer) (*x509.Certificate, crypto.Signer) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  isCA,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+	}
+	if parent == nil {
+		parent, parentKey = tmpl, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, key.Public(), parentKey)
+	if err != nil {
+		t.Fatalf("unable to create certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("unable to parse certificate: %v", err)
+	}
+	return cert, key
+}
+
+func pemChain(certs ...*x509.Certificate) []byte {
+	var out []byte
+	for _, c := range certs {
+		out = append(out, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.Raw})...)
+	}
+	return out
+}
+
+func TestVerifyOwnershipCertificate(t *testing.T) {
+	pdc, pdcKey := newCert(t, "PDC", true, nil, nil)
+	intermediate, intermediateKey := newCert(t, "Intermediate", true, pdc, pdcKey)
+	chainedOC, _ := newCert(t, "Chained OC", false, intermediate, intermediateKey)
+	directOC, _ := newCert(t, "Direct OC", false, pdc, pdcKey)
+	other, _ := newCert(t, "Other PDC", true, nil, nil)
+	pdcPool := x509.NewCertPool()
+	pdcPool.AddCert(pdc)
+
+	tests := []struct {
+		desc    string
+		oc      []byte
+		want    *x509.Certificate
+		wantErr bool
+	}{{
+		desc: "OC issued by PDC",
+		oc:   pemChain(directOC),
+		want: directOC,
+	}, {
+		desc: "OC with intermediate",
+		oc:   pemChain(chainedOC, intermediate),
+		want: chainedOC,
+	}, {
+		desc:    "OC without its intermediate",
+		oc:      pemChain(chainedOC),
+		wantErr: true,
+	}, {
+		desc:    "OC of another PDC",
+		oc:      pemChain(other),
+		wantErr: true,
+	}, {
+		desc:    "No certificate",
+		oc:      []byte("not a certificate"),
+		wantErr: true,
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			got, err := verifyOwnershipCertificate(test.oc, pdcPool)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("verifyOwnershipCertificate() err = %v, want error %v", err, test.wantErr)
+			}
+			if test.want != nil && !got.Equal(test.want) {
+				t.Errorf("verifyOwnershipCertificate() = %v, want %v", got.Subject, test.want.Subject)
+			}
+		})
+	}
+}
+
+// fakeChunkReceiver returns the chunks, followed by err or io.EOF.
+type fakeChunkReceiver struct {
+	chunks []*bpb.BootstrapDataChunk
+	err    error
+}
+
+func (f *fakeChunkReceiver) Recv() (*bpb.BootstrapDataChunk, error) {
+	if len(f.chunks) == 0 {
+		if f.err != nil {
+			return nil, f.err
+		}
+		return nil, io.EOF
+	}
+	c := f.chunks[0]
+	f.chunks = f.chunks[1:]
+	return c, nil
+}
+
+func TestReceiveChunks(t *testing.T) {
+	want := &bpb.GetBootstrapDataResponse{ResponseSignature: strings.Repeat("signature", 100)}
+	data, err := proto.Marshal(want)
+	if err != nil {
+		t.Fatalf("Marshal() err = %v, want nil", err)
+	}
+	total := uint64(len(data))
+	chunk := func(b []byte, total uint64) *bpb.BootstrapDataChunk {
+		return &bpb.BootstrapDataChunk{Data: b, TotalSize: total}
+	}
+	tests := []struct {
+		desc    string
+		stream  *fakeChunkReceiver
+		wantErr bool
+	}{{
+		desc:   "Single chunk",
+		stream: &fakeChunkReceiver{chunks: []*bpb.BootstrapDataChunk{chunk(data, total)}},
+	}, {
+		desc:   "Several chunks",
+		stream: &fakeChunkReceiver{chunks: []*bpb.BootstrapDataChunk{chunk(data[:100], total), chunk(data[100:200], total), chunk(data[200:], total)}},
+	}, {
+		desc:    "Missing chunk",
+		stream:  &fakeChunkReceiver{chunks: []*bpb.BootstrapDataChunk{chunk(data[:100], total)}},
+		wantErr: true,
+	}, {
+		desc:    "Inconsistent total size",
+		stream:  &fakeChunkReceiver{chunks: []*bpb.BootstrapDataChunk{chunk(data[:100], total), chunk(data[100:], total+1)}},
+		wantErr: true,
+	}, {
+		desc:    "Stream error",
+		stream:  &fakeChunkReceiver{chunks: []*bpb.BootstrapDataChunk{chunk(data[:100], total)}, err: fmt.Errorf("connection reset")},
+		wantErr: true,
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			got, err := receiveChunks(test.stream)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("receiveChunks() err = %v, want error %v", err, test.wantErr)
+			}
+			if err == nil && !proto.Equal(got, want) {
+				t.Errorf("receiveChunks() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestRedirectTLSConfig(t *testing.T) {
+	ca, _ := newCert(t, "Regional CA", true, nil, nil)
+	current := &tls.Config{InsecureSkipVerify: true}
+
+	got, err := redirectTLSConfig(&bpb.RedirectServer{Address: "10.0.0.1:15006"}, current)
+	if err != nil {
+		t.Fatalf("redirectTLSConfig() without trust anchor err = %v, want nil", err)
+	}
+	if got != current {
+		t.Errorf("redirectTLSConfig() without trust anchor = %v, want the current TLS config", got)
+	}
+
+	got, err = redirectTLSConfig(&bpb.RedirectServer{Address: "10.0.0.1:15006", TrustAnchor: string(pemChain(ca))}, current)
+	if err != nil {
+		t.Fatalf("redirectTLSConfig() with trust anchor err = %v, want nil", err)
+	}
+	if got.InsecureSkipVerify || got.RootCAs == nil {
+		t.Errorf("redirectTLSConfig() with trust anchor = %+v, want verification against the trust anchor", got)
+	}
+
+	if _, err := redirectTLSConfig(&bpb.RedirectServer{Address: "10.0.0.1:15006", TrustAnchor: "not a certificate"}, current); err == nil {
+		t.Errorf("redirectTLSConfig() with invalid trust anchor err = nil, want error")
+	}
+}
+
+func TestParseSignatureAlgorithms(t *testing.T) {
+	tests := []struct {
+		desc    string
+		list    string
+		want    []bpb.SignatureAlgorithm
+		wantErr bool
+	}{{
+		desc: "Empty list",
+	}, {
+		desc: "Short and full names",
+		list: "rsa_pss_sha256, SIGNATURE_ALGORITHM_ECDSA_SHA256,",
+		want: []bpb.SignatureAlgorithm{bpb.SignatureAlgorithm_SIGNATURE_ALGORITHM_RSA_PSS_SHA256, bpb.SignatureAlgorithm_SIGNATURE_ALGORITHM_ECDSA_SHA256},
+	}, {
+		desc:    "Unknown algorithm",
+		list:    "RSA_PSS_SHA256,DSA",
+		wantErr: true,
+	}, {
+		desc:    "Unspecified algorithm",
+		list:    "UNSPECIFIED",
+		wantErr: true,
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			got, err := parseSignatureAlgorithms(test.list)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("parseSignatureAlgorithms(%q) err = %v, want error %v", test.list, err, test.wantErr)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(test.want) {
+				t.Errorf("parseSignatureAlgorithms(%q) = %v, want %v", test.list, got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,344 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/proto"
+
+	log "github.com/golang/glog"
+	bpb "github.com/openconfig/bootz/proto/bootz"
+)
+
+var fleetTopologyFile = flag.String("fleet_topology", "", "The path to a JSON fleet topology to simulate. Enables the fleet simulation mode if set.")
+
+const (
+	// faultBadNonce makes a device expect another nonce than the one it sent, as if the server
+	// replayed the response of another request.
+	faultBadNonce = "bad_nonce"
+	// faultWrongOV makes a device validate the ownership voucher against another serial number, as
+	// if the server served the voucher of another device.
+	faultWrongOV = "wrong_ov"
+)
+
+// fleetTopology describes the devices of a simulated fleet.
+type fleetTopology struct {
+	// Concurrency is the number of devices bootstrapping at the same time, 100 if unset.
+	Concurrency int `json:"concurrency"`
+	// Seed seeds the boot jitter and the failure injection, so runs are reproducible.
+	Seed   int64        `json:"seed"`
+	Groups []fleetGroup `json:"groups"`
+}
+
+// fleetGroup is a group of devices of the same model, booting at a steady pace.
+type fleetGroup struct {
+	Manufacturer string `json:"manufacturer"`
+	PartNumber   string `json:"part_number"`
+	// SerialPrefix prefixes the serial numbers of the devices, which are numbered from 0.
+	SerialPrefix string `json:"serial_prefix"`
+	Devices      int    `json:"devices"`
+	// ControlCards is the number of control cards of modular chassis, suffixed A, B and so on to
+	// the chassis serial number. Devices are fixed form factor if unset.
+	ControlCards int `json:"control_cards"`
+	// BootInterval is the time between the boot of consecutive devices, e.g. "100ms".
+	BootInterval string `json:"boot_interval"`
+	// BootJitter is the maximum random delay added to the boot of each device.
+	BootJitter string `json:"boot_jitter"`
+	// Faults maps the injected failures to the share of the devices they are injected in.
+	Faults map[string]float64 `json:"faults"`
+}
+
+// fleetDevice is a simulated device.
+type fleetDevice struct {
+	chassis *bpb.ChassisDescriptor
+	// bootAt is the time after the start of the simulation the device boots at.
+	bootAt time.Duration
+	// fault is the failure injected in the device, if any.
+	fault string
+}
+
+// loadFleetTopology reads and validates the fleet topology at path.
+func loadFleetTopology(path string) (*fleetTopology, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read fleet topology: %v", err)
+	}
+	topo := &fleetTopology{}
+	if err := json.Unmarshal(data, topo); err != nil {
+		return nil, fmt.Errorf("unable to parse fleet topology %s: %v", path, err)
+	}
+	if len(topo.Groups) == 0 {
+		return nil, fmt.Errorf("fleet topology %s has no groups", path)
+	}
+	for i, g := range topo.Groups {
+		if g.Manufacturer == "" || g.SerialPrefix == "" || g.Devices < 1 {
+			return nil, fmt.Errorf("group %d needs a manufacturer, a serial prefix and devices", i)
+		}
+		for _, d := range []string{g.BootInterval, g.BootJitter} {
+			if d == "" {
+				continue
+			}
+			if _, err := time.ParseDuration(d); err != nil {
+				return nil, fmt.Errorf("group %d: %v", i, err)
+			}
+		}
+		total := 0.0
+		for fault, rate := range g.Faults {
+			if fault != faultBadNonce && fault != faultWrongOV {
+				return nil, fmt.Errorf("group %d: unknown fault %q, want %q or %q", i, fault, faultBadNonce, faultWrongOV)
+			}
+			total += rate
+		}
+		if total > 1 {
+			return nil, fmt.Errorf("group %d: faults are injected in more than every device", i)
+		}
+	}
+	return topo, nil
+}
+
+// hasFaults reports whether failures are injected in any device.
+func (t *fleetTopology) hasFaults() bool {
+	for _, g := range t.Groups {
+		for _, rate := range g.Faults {
+			if rate > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// devices returns the devices of the topology in the order they boot.
+func (t *fleetTopology) devices() []fleetDevice {
+	rng := rand.New(rand.NewSource(t.Seed))
+	var out []fleetDevice
+	for _, g := range t.Groups {
+		// The durations are validated by loadFleetTopology.
+		interval, _ := time.ParseDuration(g.BootInterval)
+		jitter, _ := time.ParseDuration(g.BootJitter)
+		// Sorted so the same seed injects the same faults.
+		var faults []string
+		for f := range g.Faults {
+			faults = append(faults, f)
+		}
+		sort.Strings(faults)
+		for i := 0; i < g.Devices; i++ {
+			serial := fmt.Sprintf("%s%06d", g.SerialPrefix, i)
+			chassis := &bpb.ChassisDescriptor{
+				Manufacturer: g.Manufacturer,
+				PartNumber:   g.PartNumber,
+				SerialNumber: serial,
+			}
+			for c := 0; c < g.ControlCards; c++ {
+				chassis.ControlCards = append(chassis.ControlCards, &bpb.ControlCard{
+					SerialNumber: fmt.Sprintf("%s%c", serial, 'A'+c),
+					Slot:         int32(c + 1),
+				})
+			}
+			d := fleetDevice{chassis: chassis, bootAt: time.Duration(i) * interval}
+			if jitter > 0 {
+				d.bootAt += time.Duration(rng.Int63n(int64(jitter)))
+			}
+			p := rng.Float64()
+			for _, f := range faults {
+				if p < g.Faults[f] {
+					d.fault = f
+					break
+				}
+				p -= g.Faults[f]
+			}
+			out = append(out, d)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].bootAt < out[j].bootAt
+	})
+	return out
+}
+
+// controlCardSerials returns the serial numbers of the control cards of a device, or of the
+// chassis of fixed form factor devices.
+func controlCardSerials(chassis *bpb.ChassisDescriptor) []string {
+	if len(chassis.GetControlCards()) == 0 {
+		return []string{chassis.GetSerialNumber()}
+	}
+	var serials []string
+	for _, cc := range chassis.GetControlCards() {
+		serials = append(serials, cc.GetSerialNumber())
+	}
+	return serials
+}
+
+// checkResponse checks the nonce and the ownership voucher of a response the way a device does,
+// after injecting the fault of the device.
+func checkResponse(d fleetDevice, serial, nonce string, resp *bpb.GetBootstrapDataResponse, rootCA []byte) error {
+	switch d.fault {
+	case faultBadNonce:
+		nonce = "injected-" + nonce
+	case faultWrongOV:
+		serial = "injected-" + serial
+	}
+	signed := &bpb.BootstrapDataSigned{}
+	if err := proto.Unmarshal(resp.GetSerializedBootstrapData(), signed); err != nil {
+		return fmt.Errorf("unable to unmarshal serialized bootstrap data: %v", err)
+	}
+	if signed.GetNonce() != nonce {
+		return fmt.Errorf("bootstrap data nonce does not match")
+	}
+	return validateControlCardOV(serial, resp.GetOwnershipVoucher(), rootCA)
+}
+
+// simulateDevice bootstraps a simulated device over its own TLS connection, retrying when the
+// server asks to. The device reports a failure if it rejects its bootstrap data, in which case
+// the rejection is returned with a nil error.
+func simulateDevice(ctx context.Context, address string, tlsConfig *tls.Config, rootCA []byte, d fleetDevice) (rejection, err error) {
+	conn, err := grpc.DialContext(ctx, address, dialOptions(tlsConfig)...)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	c := bpb.NewBootstrapClient(conn)
+	serials := controlCardSerials(d.chassis)
+	req := &bpb.GetBootstrapDataRequest{
+		ChassisDescriptor: d.chassis,
+		ControlCardState: &bpb.ControlCardState{
+			SerialNumber: serials[0],
+			Status:       bpb.ControlCardState_CONTROL_CARD_STATUS_NOT_INITIALIZED,
+		},
+	}
+	if !*insecureBoot {
+		if req.Nonce, err = generateNonce(); err != nil {
+			return nil, err
+		}
+	}
+	resp, err := c.GetBootstrapData(ctx, req)
+	for err != nil {
+		delay, ok := retryDelay(err)
+		if !ok {
+			return nil, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, err
+		case <-time.After(delay):
+		}
+		resp, err = c.GetBootstrapData(ctx, req)
+	}
+	report := &bpb.ReportStatusRequest{
+		Status:        bpb.ReportStatusRequest_BOOTSTRAP_STATUS_SUCCESS,
+		StatusMessage: "Bootstrap Success",
+	}
+	cardStatus := bpb.ControlCardState_CONTROL_CARD_STATUS_INITIALIZED
+	if !*insecureBoot {
+		if rejection = checkResponse(d, serials[0], req.GetNonce(), resp, rootCA); rejection != nil {
+			report.Status = bpb.ReportStatusRequest_BOOTSTRAP_STATUS_FAILURE
+			report.StatusMessage = rejection.Error()
+			cardStatus = bpb.ControlCardState_CONTROL_CARD_STATUS_NOT_INITIALIZED
+		}
+	}
+	for _, s := range serials {
+		report.States = append(report.States, &bpb.ControlCardState{SerialNumber: s, Status: cardStatus})
+	}
+	if _, err := c.ReportStatus(ctx, report); err != nil {
+		return rejection, err
+	}
+	return rejection, nil
+}
+
+// fleetReport summarizes a fleet simulation.
+type fleetReport struct {
+	*loadTestReport
+	// rejected counts the devices which rejected their bootstrap data, by injected fault.
+	rejected map[string]int
+	// unexpected counts the devices which rejected their bootstrap data without an injected fault.
+	unexpected int
+}
+
+func (r *fleetReport) log() {
+	r.loadTestReport.log()
+	var faults []string
+	for f := range r.rejected {
+		faults = append(faults, f)
+	}
+	sort.Strings(faults)
+	for _, f := range faults {
+		log.Infof("Rejected bootstrap data with injected %v: %d", f, r.rejected[f])
+	}
+	if r.unexpected > 0 {
+		log.Warningf("Rejected bootstrap data without injected fault: %d", r.unexpected)
+	}
+}
+
+// runFleet boots the devices of the topology at their boot times, at most the concurrency of the
+// topology at the same time.
+func runFleet(ctx context.Context, address string, tlsConfig *tls.Config, rootCA []byte, topo *fleetTopology) *fleetReport {
+	concurrency := topo.Concurrency
+	if concurrency < 1 {
+		concurrency = 100
+	}
+	report := &fleetReport{
+		loadTestReport: &loadTestReport{errors: map[codes.Code]int{}},
+		rejected:       map[string]int{},
+	}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+	start := time.Now()
+	for _, d := range topo.devices() {
+		select {
+		case <-ctx.Done():
+		case <-time.After(time.Until(start.Add(d.bootAt))):
+		}
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(d fleetDevice) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			deviceStart := time.Now()
+			rejection, err := simulateDevice(ctx, address, tlsConfig, rootCA, d)
+			if err != nil {
+				log.V(1).Infof("Device %v failed to bootstrap: %v", d.chassis.GetSerialNumber(), err)
+			}
+			if rejection != nil {
+				log.V(1).Infof("Device %v rejected its bootstrap data: %v", d.chassis.GetSerialNumber(), rejection)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			report.add(time.Since(deviceStart), err)
+			switch {
+			case err != nil || rejection == nil:
+			case d.fault != "":
+				report.rejected[d.fault]++
+			default:
+				report.unexpected++
+			}
+		}(d)
+	}
+	wg.Wait()
+	report.duration = time.Since(start)
+	return report
+}
@@ -0,0 +1,124 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/h-fam/errdiff"
+)
+
+func TestLoadFleetTopology(t *testing.T) {
+	tests := []struct {
+		desc     string
+		topology string
+		wantErr  string
+	}{{
+		desc:     "Valid topology",
+		topology: `{"groups": [{"manufacturer": "Cisco", "serial_prefix": "SIM", "devices": 10, "boot_interval": "1s", "faults": {"bad_nonce": 0.1}}]}`,
+	}, {
+		desc:     "No groups",
+		topology: `{"concurrency": 10}`,
+		wantErr:  "no groups",
+	}, {
+		desc:     "No devices",
+		topology: `{"groups": [{"manufacturer": "Cisco", "serial_prefix": "SIM"}]}`,
+		wantErr:  "needs a manufacturer",
+	}, {
+		desc:     "Invalid boot interval",
+		topology: `{"groups": [{"manufacturer": "Cisco", "serial_prefix": "SIM", "devices": 1, "boot_interval": "soon"}]}`,
+		wantErr:  "soon",
+	}, {
+		desc:     "Unknown fault",
+		topology: `{"groups": [{"manufacturer": "Cisco", "serial_prefix": "SIM", "devices": 1, "faults": {"power_loss": 0.1}}]}`,
+		wantErr:  "unknown fault",
+	}, {
+		desc:     "Faults exceeding every device",
+		topology: `{"groups": [{"manufacturer": "Cisco", "serial_prefix": "SIM", "devices": 1, "faults": {"bad_nonce": 0.6, "wrong_ov": 0.6}}]}`,
+		wantErr:  "more than every device",
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "topology.json")
+			if err := os.WriteFile(path, []byte(test.topology), 0600); err != nil {
+				t.Fatalf("os.WriteFile() err = %v", err)
+			}
+			_, err := loadFleetTopology(path)
+			if diff := errdiff.Substring(err, test.wantErr); diff != "" {
+				t.Errorf("loadFleetTopology() %s", diff)
+			}
+		})
+	}
+}
+
+func TestFleetDevices(t *testing.T) {
+	topo := &fleetTopology{
+		Seed: 1,
+		Groups: []fleetGroup{{
+			Manufacturer: "Cisco",
+			SerialPrefix: "FIX",
+			Devices:      100,
+			BootInterval: "1s",
+			BootJitter:   "500ms",
+			Faults:       map[string]float64{faultBadNonce: 0.2, faultWrongOV: 0.1},
+		}, {
+			Manufacturer: "Arista",
+			SerialPrefix: "MOD",
+			Devices:      3,
+			ControlCards: 2,
+		}},
+	}
+	devices := topo.devices()
+	if len(devices) != 103 {
+		t.Fatalf("devices() returned %d devices, want 103", len(devices))
+	}
+	serials := map[string]bool{}
+	faults := map[string]int{}
+	for i, d := range devices {
+		if serials[d.chassis.GetSerialNumber()] {
+			t.Errorf("devices() returned serial %v twice", d.chassis.GetSerialNumber())
+		}
+		serials[d.chassis.GetSerialNumber()] = true
+		faults[d.fault]++
+		if i > 0 && d.bootAt < devices[i-1].bootAt {
+			t.Errorf("devices() returned device %d booting before the previous one", i)
+		}
+	}
+	if faults[faultBadNonce] == 0 || faults[faultWrongOV] == 0 || faults[""] < 50 {
+		t.Errorf("devices() injected faults %v, want about 20 bad nonces, 10 wrong OVs and 70 healthy devices", faults)
+	}
+	last := devices[len(devices)-1]
+	if last.bootAt < 99*time.Second || last.bootAt >= 100*time.Second {
+		t.Errorf("devices() last boot at %v, want between 99s and 100s", last.bootAt)
+	}
+	for _, d := range devices {
+		if d.chassis.GetSerialNumber() != "MOD000000" {
+			continue
+		}
+		if got := controlCardSerials(d.chassis); len(got) != 2 || got[0] != "MOD000000A" || got[1] != "MOD000000B" {
+			t.Errorf("controlCardSerials() of modular chassis = %v, want [MOD000000A MOD000000B]", got)
+		}
+	}
+
+	again := topo.devices()
+	for i := range devices {
+		if devices[i].bootAt != again[i].bootAt || devices[i].fault != again[i].fault {
+			t.Fatalf("devices() with the same seed returned different devices")
+		}
+	}
+}
@@ -0,0 +1,174 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"flag"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	log "github.com/golang/glog"
+	bpb "github.com/openconfig/bootz/proto/bootz"
+)
+
+var (
+	loadTestDevices      = flag.Int("load_test_devices", 0, "The number of fixed form factor devices to emulate bootstrapping concurrently. Enables the load test mode if set.")
+	loadTestConcurrency  = flag.Int("load_test_concurrency", 100, "The number of devices bootstrapping at the same time in the load test mode.")
+	loadTestManufacturer = flag.String("load_test_manufacturer", "Cisco", "The manufacturer of the devices emulated in the load test mode.")
+	loadTestPartNumber   = flag.String("load_test_part_number", "", "The part number of the devices emulated in the load test mode.")
+	loadTestSerialPrefix = flag.String("load_test_serial_prefix", "LT", "The prefix of the serial numbers of the devices emulated in the load test mode.")
+)
+
+// loadTestReport summarizes a load test run.
+type loadTestReport struct {
+	devices  int
+	duration time.Duration
+	// latencies of the devices which bootstrapped successfully.
+	latencies []time.Duration
+	// errors counts the failed devices by gRPC code.
+	errors map[codes.Code]int
+}
+
+// add records the outcome of a device.
+func (r *loadTestReport) add(latency time.Duration, err error) {
+	r.devices++
+	if err != nil {
+		r.errors[status.Code(err)]++
+		return
+	}
+	r.latencies = append(r.latencies, latency)
+}
+
+// percentile returns the latency below which the fraction p of the successful devices bootstrapped.
+func (r *loadTestReport) percentile(p float64) time.Duration {
+	if len(r.latencies) == 0 {
+		return 0
+	}
+	sorted := append([]time.Duration(nil), r.latencies...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+	i := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if i < 0 {
+		i = 0
+	}
+	return sorted[i]
+}
+
+// errorRate returns the share of devices which failed to bootstrap.
+func (r *loadTestReport) errorRate() float64 {
+	if r.devices == 0 {
+		return 0
+	}
+	return float64(r.devices-len(r.latencies)) / float64(r.devices)
+}
+
+func (r *loadTestReport) log() {
+	log.Infof("=============================================================================")
+	log.Infof("============================ Load test results ==============================")
+	log.Infof("=============================================================================")
+	log.Infof("Bootstrapped %d devices in %v (%.1f devices/s)", r.devices, r.duration, float64(r.devices)/r.duration.Seconds())
+	log.Infof("Latency p50: %v, p90: %v, p99: %v, max: %v", r.percentile(0.5), r.percentile(0.9), r.percentile(0.99), r.percentile(1))
+	log.Infof("Error rate: %.2f%%", r.errorRate()*100)
+	var codeList []codes.Code
+	for c := range r.errors {
+		codeList = append(codeList, c)
+	}
+	sort.Slice(codeList, func(i, j int) bool {
+		return codeList[i] < codeList[j]
+	})
+	for _, c := range codeList {
+		log.Infof("  %v: %d", c, r.errors[c])
+	}
+}
+
+// bootstrapDevice emulates a fixed form factor device bootstrapping over its own TLS connection.
+func bootstrapDevice(ctx context.Context, address string, tlsConfig *tls.Config, chassis *bpb.ChassisDescriptor) error {
+	conn, err := grpc.DialContext(ctx, address, dialOptions(tlsConfig)...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	c := bpb.NewBootstrapClient(conn)
+	req := &bpb.GetBootstrapDataRequest{
+		ChassisDescriptor: chassis,
+		ControlCardState: &bpb.ControlCardState{
+			SerialNumber: chassis.GetSerialNumber(),
+			Status:       bpb.ControlCardState_CONTROL_CARD_STATUS_NOT_INITIALIZED,
+		},
+	}
+	if !*insecureBoot {
+		if req.Nonce, err = generateNonce(); err != nil {
+			return err
+		}
+	}
+	if _, err := c.GetBootstrapData(ctx, req); err != nil {
+		return err
+	}
+	_, err = c.ReportStatus(ctx, &bpb.ReportStatusRequest{
+		Status:        bpb.ReportStatusRequest_BOOTSTRAP_STATUS_SUCCESS,
+		StatusMessage: "Bootstrap Success",
+		States: []*bpb.ControlCardState{{
+			Status:       bpb.ControlCardState_CONTROL_CARD_STATUS_INITIALIZED,
+			SerialNumber: chassis.GetSerialNumber(),
+		}},
+	})
+	return err
+}
+
+// runLoadTest bootstraps the given number of devices, at most concurrency of them at the same time.
+func runLoadTest(ctx context.Context, address string, tlsConfig *tls.Config, devices, concurrency int) *loadTestReport {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	report := &loadTestReport{errors: map[codes.Code]int{}}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+	start := time.Now()
+	for i := 0; i < devices; i++ {
+		chassis := &bpb.ChassisDescriptor{
+			Manufacturer: *loadTestManufacturer,
+			PartNumber:   *loadTestPartNumber,
+			SerialNumber: fmt.Sprintf("%s%06d", *loadTestSerialPrefix, i),
+		}
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			deviceStart := time.Now()
+			err := bootstrapDevice(ctx, address, tlsConfig, chassis)
+			if err != nil {
+				log.V(1).Infof("Device %v failed to bootstrap: %v", chassis.GetSerialNumber(), err)
+			}
+			mu.Lock()
+			report.add(time.Since(deviceStart), err)
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	report.duration = time.Since(start)
+	return report
+}
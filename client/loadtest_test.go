@@ -0,0 +1,74 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestLoadTestReport(t *testing.T) {
+	r := &loadTestReport{errors: map[codes.Code]int{}}
+	for i := 100; i > 0; i-- {
+		r.add(time.Duration(i)*time.Millisecond, nil)
+	}
+	for i := 0; i < 20; i++ {
+		r.add(time.Second, status.Errorf(codes.ResourceExhausted, "rate limit exceeded"))
+	}
+	for i := 0; i < 5; i++ {
+		r.add(time.Second, status.Errorf(codes.NotFound, "chassis not found"))
+	}
+
+	tests := []struct {
+		desc string
+		p    float64
+		want time.Duration
+	}{{
+		desc: "p50",
+		p:    0.5,
+		want: 50 * time.Millisecond,
+	}, {
+		desc: "p99",
+		p:    0.99,
+		want: 99 * time.Millisecond,
+	}, {
+		desc: "max",
+		p:    1,
+		want: 100 * time.Millisecond,
+	}, {
+		desc: "min",
+		p:    0,
+		want: time.Millisecond,
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			if got := r.percentile(test.p); got != test.want {
+				t.Errorf("percentile(%v) = %v, want %v", test.p, got, test.want)
+			}
+		})
+	}
+	if got, want := r.errorRate(), 0.2; got != want {
+		t.Errorf("errorRate() = %v, want %v", got, want)
+	}
+	if got := r.errors[codes.ResourceExhausted]; got != 20 {
+		t.Errorf("errors[ResourceExhausted] = %d, want 20", got)
+	}
+	if got := (&loadTestReport{}).percentile(0.5); got != 0 {
+		t.Errorf("percentile() without successful devices = %v, want 0", got)
+	}
+}
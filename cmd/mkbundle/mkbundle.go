@@ -0,0 +1,108 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// mkbundle builds a provisioning bundle for servers of air-gapped sites: a
+// signed tarball of an inventory, the configs and other files it references,
+// its ownership vouchers and a manifest of the software images it serves.
+//
+//	mkbundle --key bundle_priv.pem --out site.bundle inventory.prototxt
+//
+// Relative paths of the inventory are resolved against --base, the working
+// directory of the server the inventory was written for. Servers load the
+// bundle with --bundle, verifying it with --bundle_signer_cert.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/openconfig/bootz/server/bundle"
+	"github.com/openconfig/bootz/server/keystore"
+)
+
+const usage = `Usage: mkbundle [flags] <inventory file>
+
+Builds a signed provisioning bundle of an inventory.
+
+Flags:
+`
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout, os.Stderr); err != nil {
+		fmt.Fprintf(os.Stderr, "mkbundle: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run builds the bundle of the inventory named by args, and writes a summary of it to w.
+func run(args []string, w, errW io.Writer) error {
+	fs := flag.NewFlagSet("mkbundle", flag.ContinueOnError)
+	fs.SetOutput(errW)
+	fs.Usage = func() {
+		fmt.Fprint(errW, usage)
+		fs.PrintDefaults()
+	}
+	keyFile := fs.String("key", "", "PEM private key to sign the bundle with.")
+	out := fs.String("out", "", "File to write the bundle to.")
+	base := fs.String("base", ".", "Directory the relative paths of the inventory are resolved against.")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return fmt.Errorf("expected one inventory file, got %d arguments", fs.NArg())
+	}
+	if *keyFile == "" || *out == "" {
+		return fmt.Errorf("--key and --out must be set")
+	}
+	data, err := os.ReadFile(*keyFile)
+	if err != nil {
+		return err
+	}
+	key, err := keystore.ParsePrivateKey(data)
+	if err != nil {
+		return err
+	}
+	f, err := os.Create(*out)
+	if err != nil {
+		return err
+	}
+	m, err := bundle.Build(f, fs.Arg(0), *base, key)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(*out)
+		return err
+	}
+	names := make([]string, 0, len(m.Files))
+	for name := range m.Files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fmt.Fprintf(w, "Wrote %s with %d files:\n", *out, len(names))
+	for _, name := range names {
+		fmt.Fprintf(w, "  %s\n", name)
+	}
+	if len(m.Images) > 0 {
+		fmt.Fprintf(w, "Images expected in the image directory:\n")
+		for _, img := range m.Images {
+			fmt.Fprintf(w, "  %s %s: %s\n", img.Name, img.Version, img.URL)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/h-fam/errdiff"
+	"github.com/openconfig/bootz/server/bundle"
+)
+
+func TestRun(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("ecdsa.GenerateKey() err = %v", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "Bundle Signer"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, key.Public(), key)
+	if err != nil {
+		t.Fatalf("x509.CreateCertificate() err = %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("x509.ParseCertificate() err = %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("x509.MarshalECPrivateKey() err = %v", err)
+	}
+
+	dir := t.TempDir()
+	for name, content := range map[string][]byte{
+		"bundle_priv.pem": pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+		"vendor.cfg":      []byte("hostname device"),
+		"inventory.prototxt": []byte(`chassis {
+  serial_number: "123"
+  software_image { name: "os" version: "1.0" url: "https://images.example.com/os-1.0.img" }
+  config { boot_config { vendor_config_file: "vendor.cfg" } }
+}`),
+		"missing.prototxt": []byte(`chassis { config { boot_config { vendor_config_file: "missing.cfg" } } }`),
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), content, 0600); err != nil {
+			t.Fatalf("os.WriteFile() err = %v", err)
+		}
+	}
+	keyFile := filepath.Join(dir, "bundle_priv.pem")
+	out := filepath.Join(dir, "site.bundle")
+
+	tests := []struct {
+		desc    string
+		args    []string
+		want    []string
+		wantErr string
+	}{{
+		desc: "Inventory with a config and an image",
+		args: []string{"--key", keyFile, "--out", out, "--base", dir, filepath.Join(dir, "inventory.prototxt")},
+		want: []string{
+			"with 2 files:\n  files/vendor.cfg\n  inventory.prototxt\n",
+			"Images expected in the image directory:\n  os 1.0: https://images.example.com/os-1.0.img\n",
+		},
+	}, {
+		desc:    "Missing inventory argument",
+		args:    []string{"--key", keyFile, "--out", out},
+		wantErr: "expected one inventory file",
+	}, {
+		desc:    "Missing key",
+		args:    []string{"--out", out, filepath.Join(dir, "inventory.prototxt")},
+		wantErr: "--key and --out must be set",
+	}, {
+		desc:    "Missing referenced file",
+		args:    []string{"--key", keyFile, "--out", filepath.Join(dir, "missing.bundle"), "--base", dir, filepath.Join(dir, "missing.prototxt")},
+		wantErr: "unable to read missing.cfg",
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			var w bytes.Buffer
+			err := run(test.args, &w, io.Discard)
+			if diff := errdiff.Substring(err, test.wantErr); diff != "" {
+				t.Fatalf("run() %s", diff)
+			}
+			for _, want := range test.want {
+				if !strings.Contains(w.String(), want) {
+					t.Errorf("run() output missing %q, got:\n%s", want, w.String())
+				}
+			}
+		})
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "missing.bundle")); err == nil {
+		t.Errorf("run() kept the bundle of an inventory it could not build")
+	}
+	if _, err := bundle.Open(out, filepath.Join(dir, "extracted"), cert); err != nil {
+		t.Errorf("bundle.Open() of the built bundle err = %v", err)
+	}
+}
@@ -0,0 +1,244 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// ovdump prints the fields of an ownership voucher and verifies it, to
+// troubleshoot the vouchers supplied by vendors:
+//
+//	ovdump --ca vendorca_pub.pem --pdc pdc_pub.pem ov_123A.txt
+//
+// The voucher may be DER encoded, base64 encoded as in inventory files, or PEM
+// encoded. Without --ca, only the signature of the voucher is checked against
+// its embedded signer certificate.
+package main
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	ownershipvoucher "github.com/openconfig/bootz/common/ownership_voucher"
+)
+
+const usage = `Usage: ovdump [flags] <ownership voucher file>
+
+Prints the fields of an ownership voucher and verifies it.
+
+Flags:
+`
+
+// pdcMatches maps the values of the pdc_match flag to the PDC match modes.
+var pdcMatches = map[string]ownershipvoucher.PDCMatch{
+	"exact": ownershipvoucher.PDCMatchExact,
+	"spki":  ownershipvoucher.PDCMatchSPKI,
+}
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout, os.Stderr); err != nil {
+		fmt.Fprintf(os.Stderr, "ovdump: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run dumps the ownership voucher named by args to w, and returns an error if it can not be
+// parsed or fails verification.
+func run(args []string, w, errW io.Writer) error {
+	fs := flag.NewFlagSet("ovdump", flag.ContinueOnError)
+	fs.SetOutput(errW)
+	fs.Usage = func() {
+		fmt.Fprint(errW, usage)
+		fs.PrintDefaults()
+	}
+	caBundle := fs.String("ca", "", "PEM bundle of the vendor CAs the voucher must chain to.")
+	pdcFile := fs.String("pdc", "", "PEM certificate of the PDC the voucher must pin. Requires --ca.")
+	pdcMatch := fs.String("pdc_match", "exact", "How the pinned-domain-cert is compared with --pdc: exact or spki.")
+	at := fs.String("at", "", "RFC 3339 time to verify the signer chain at. Defaults to now.")
+	atSigningTime := fs.Bool("at_signing_time", false, "Verify the signer chain at the signing time of the voucher unless --at is set. The signing time is chosen by the signer and may be backdated.")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return fmt.Errorf("expected one ownership voucher file, got %d arguments", fs.NArg())
+	}
+	data, err := os.ReadFile(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	der, err := decode(data)
+	if err != nil {
+		return err
+	}
+	ov, err := ownershipvoucher.Parse(der)
+	if err != nil {
+		return err
+	}
+	dump(w, ov)
+
+	if *caBundle == "" {
+		if *pdcFile != "" {
+			return fmt.Errorf("--pdc requires --ca")
+		}
+		fmt.Fprintln(w, "Verification: skipped, no --ca given")
+		return nil
+	}
+	opts := ownershipvoucher.VerifyOptions{}
+	if opts.TrustAnchors, err = readCABundle(*caBundle); err != nil {
+		return err
+	}
+	if *pdcFile != "" {
+		match, ok := pdcMatches[*pdcMatch]
+		if !ok {
+			return fmt.Errorf("unknown --pdc_match %q, want exact or spki", *pdcMatch)
+		}
+		if opts.PinnedDomainCert, err = readCert(*pdcFile); err != nil {
+			return err
+		}
+		opts.PDCMatch = match
+	}
+	opts.AtSigningTime = *atSigningTime
+	if *at != "" {
+		if opts.CurrentTime, err = time.Parse(time.RFC3339, *at); err != nil {
+			return fmt.Errorf("invalid --at: %v", err)
+		}
+	}
+	verified, err := ownershipvoucher.VerifyAndUnmarshalWithOptions(der, opts)
+	if err != nil {
+		fmt.Fprintf(w, "Verification: FAILED: %v\n", err)
+		return fmt.Errorf("ownership voucher %s failed verification", fs.Arg(0))
+	}
+	var subjects []string
+	for _, c := range verified.SignerChain() {
+		subjects = append(subjects, fmt.Sprintf("%q", c.Subject.CommonName))
+	}
+	fmt.Fprintf(w, "Verified chain:    %s\n", strings.Join(subjects, " -> "))
+	if opts.PinnedDomainCert != nil {
+		fmt.Fprintf(w, "Verification: OK, signed by %s and pinning %s\n", *caBundle, *pdcFile)
+	} else {
+		fmt.Fprintf(w, "Verification: OK, signed by %s\n", *caBundle)
+	}
+	return nil
+}
+
+// decode returns the DER encoding of a PEM, base64 or DER encoded ownership voucher.
+func decode(data []byte) ([]byte, error) {
+	if block, _ := pem.Decode(data); block != nil {
+		return block.Bytes, nil
+	}
+	trimmed := strings.Join(strings.Fields(string(data)), "")
+	if der, err := base64.StdEncoding.DecodeString(trimmed); err == nil {
+		return der, nil
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("ownership voucher is empty")
+	}
+	return data, nil
+}
+
+// dump writes the fields of the voucher, its pinned-domain-cert and the certificates embedded
+// in it to w.
+func dump(w io.Writer, ov *ownershipvoucher.OwnershipVoucher) {
+	fmt.Fprintf(w, "Serial number:     %s\n", ov.OV.SerialNumber)
+	fmt.Fprintf(w, "Created on:        %s\n", timestamp(ov.OV.CreatedOn, ov.CreatedOnTime))
+	expires := timestamp(ov.OV.ExpiresOn, ov.ExpiresOnTime)
+	if t, err := ov.ExpiresOnTime(); err == nil && !t.IsZero() && t.Before(time.Now()) {
+		expires += " (expired)"
+	}
+	fmt.Fprintf(w, "Expires on:        %s\n", expires)
+	fmt.Fprintf(w, "Assertion:         %s\n", ov.OV.Assertion)
+	fmt.Fprintf(w, "Revocation checks: %t\n", ov.OV.DomainCertRevocationChecks)
+	if t := ov.SigningTime(); !t.IsZero() {
+		fmt.Fprintf(w, "Signing time:      %s\n", t.UTC().Format(time.RFC3339))
+	}
+	fmt.Fprintln(w, "Pinned domain cert:")
+	if pdc, err := ov.PinnedDomainCertificate(); err != nil {
+		fmt.Fprintf(w, "  invalid: %v\n", err)
+	} else {
+		dumpCert(w, pdc)
+	}
+	fmt.Fprintln(w, "Signer chain:")
+	for _, c := range ov.SignerChain() {
+		dumpCert(w, c)
+	}
+}
+
+// timestamp formats a voucher timestamp as RFC 3339, noting the original when the voucher does
+// not use RFC 3339.
+func timestamp(raw string, parse func() (time.Time, error)) string {
+	if raw == "" {
+		return "unset"
+	}
+	t, err := parse()
+	if err != nil {
+		return fmt.Sprintf("%q (invalid)", raw)
+	}
+	formatted := t.Format(time.RFC3339Nano)
+	if _, err := time.Parse(time.RFC3339Nano, raw); err != nil {
+		return fmt.Sprintf("%s (not RFC 3339: %q)", formatted, raw)
+	}
+	return formatted
+}
+
+// dumpCert writes the subject, issuer, validity and fingerprints of cert to w.
+func dumpCert(w io.Writer, cert *x509.Certificate) {
+	fmt.Fprintf(w, "  - Subject:        %s\n", cert.Subject)
+	fmt.Fprintf(w, "    Issuer:         %s\n", cert.Issuer)
+	fmt.Fprintf(w, "    Serial:         %s\n", cert.SerialNumber)
+	fmt.Fprintf(w, "    Not before:     %s\n", cert.NotBefore.UTC().Format(time.RFC3339))
+	fmt.Fprintf(w, "    Not after:      %s\n", cert.NotAfter.UTC().Format(time.RFC3339))
+	fmt.Fprintf(w, "    SHA-256:        %s\n", fingerprint(cert.Raw))
+	fmt.Fprintf(w, "    SPKI SHA-256:   %s\n", fingerprint(cert.RawSubjectPublicKeyInfo))
+}
+
+// fingerprint returns the colon separated SHA-256 digest of data, as printed by openssl.
+func fingerprint(data []byte) string {
+	sum := sha256.Sum256(data)
+	parts := make([]string, len(sum))
+	for i, b := range sum {
+		parts[i] = fmt.Sprintf("%02X", b)
+	}
+	return strings.Join(parts, ":")
+}
+
+// readCert reads a PEM certificate.
+func readCert(path string) (*x509.Certificate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM certificate in %s", path)
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// readCABundle reads the certificates of a PEM bundle as trust anchors.
+func readCABundle(path string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no PEM certificate in %s", path)
+	}
+	return pool, nil
+}
@@ -0,0 +1,106 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/pem"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/h-fam/errdiff"
+)
+
+func TestRun(t *testing.T) {
+	b64, err := os.ReadFile("../../testdata/ov_123A.txt")
+	if err != nil {
+		t.Fatalf("unable to read voucher: %v", err)
+	}
+	der, err := base64.StdEncoding.DecodeString(string(b64))
+	if err != nil {
+		t.Fatalf("unable to decode voucher: %v", err)
+	}
+	dir := t.TempDir()
+	derFile := filepath.Join(dir, "ov.der")
+	if err := os.WriteFile(derFile, der, 0600); err != nil {
+		t.Fatalf("unable to write voucher: %v", err)
+	}
+	pemFile := filepath.Join(dir, "ov.pem")
+	if err := os.WriteFile(pemFile, pem.EncodeToMemory(&pem.Block{Type: "PKCS7", Bytes: der}), 0600); err != nil {
+		t.Fatalf("unable to write voucher: %v", err)
+	}
+	tests := []struct {
+		desc    string
+		args    []string
+		want    []string
+		wantErr string
+	}{{
+		desc: "Base64 voucher without CA",
+		args: []string{"../../testdata/ov_123A.txt"},
+		want: []string{
+			"Serial number:     123A",
+			"Pinned domain cert:\n  - Subject:        CN=Device Owner PDC",
+			"Signer chain:\n  - Subject:        CN=Manufacturer Root CA",
+			"Verification: skipped",
+		},
+	}, {
+		desc: "DER voucher verified with CA and PDC",
+		args: []string{"--ca", "../../testdata/vendorca_pub.pem", "--pdc", "../../testdata/pdc_pub.pem", derFile},
+		want: []string{"Serial number:     123A", `Verified chain:    "Manufacturer Root CA"`, "Verification: OK"},
+	}, {
+		desc: "PEM voucher verified with CA and SPKI match",
+		args: []string{"--ca", "../../testdata/vendorca_pub.pem", "--pdc", "../../testdata/pdc_pub.pem", "--pdc_match", "spki", pemFile},
+		want: []string{"Verification: OK"},
+	}, {
+		desc:    "Wrong CA",
+		args:    []string{"--ca", "../../testdata/pdc_pub.pem", "../../testdata/ov_123A.txt"},
+		want:    []string{"Serial number:     123A", "Verification: FAILED"},
+		wantErr: "failed verification",
+	}, {
+		desc:    "Wrong PDC",
+		args:    []string{"--ca", "../../testdata/vendorca_pub.pem", "--pdc", "../../testdata/vendorca_pub.pem", "../../testdata/ov_123A.txt"},
+		want:    []string{"Verification: FAILED", "does not match"},
+		wantErr: "failed verification",
+	}, {
+		desc:    "PDC without CA",
+		args:    []string{"--pdc", "../../testdata/pdc_pub.pem", "../../testdata/ov_123A.txt"},
+		wantErr: "--pdc requires --ca",
+	}, {
+		desc:    "Not a voucher",
+		args:    []string{"../../testdata/pdc_pub.pem"},
+		wantErr: "failed to verify OV",
+	}, {
+		desc:    "No voucher",
+		wantErr: "expected one ownership voucher file",
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			var out bytes.Buffer
+			err := run(test.args, &out, io.Discard)
+			if s := errdiff.Substring(err, test.wantErr); s != "" {
+				t.Fatalf("run() %s", s)
+			}
+			for _, want := range test.want {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("run() output = %q, want it to contain %q", out.String(), want)
+				}
+			}
+		})
+	}
+}
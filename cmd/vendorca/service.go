@@ -0,0 +1,213 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	ownershipvoucher "github.com/openconfig/bootz/common/ownership_voucher"
+	"github.com/openconfig/bootz/server/masa"
+
+	log "github.com/golang/glog"
+)
+
+// maxRequestSize bounds the size of request bodies.
+const maxRequestSize = 1 << 20
+
+// issueRequest is the JSON body of a voucher request.
+type issueRequest struct {
+	// SerialNumber is the serial number of the emulated device.
+	SerialNumber string `json:"serial_number"`
+	// PinnedDomainCert is the PEM encoded PDC of the owner the device is transferred to.
+	PinnedDomainCert string `json:"pinned_domain_cert"`
+}
+
+// issueResponse is the JSON body of an issued voucher.
+type issueResponse struct {
+	SerialNumber string `json:"serial_number"`
+	// OwnershipVoucher is the base64 encoded voucher, as set in bootz inventory files.
+	OwnershipVoucher string `json:"ownership_voucher"`
+}
+
+// errorBody is the JSON body of an error response.
+type errorBody struct {
+	Message string `json:"message"`
+}
+
+// vendorCA issues ownership vouchers for emulated devices, as the MASA of their vendor would.
+type vendorCA struct {
+	cert *x509.Certificate
+	key  *rsa.PrivateKey
+	// parents are the issuers of cert, embedded in the vouchers.
+	parents []*x509.Certificate
+	// serials are the serial numbers vouchers are issued for. Any serial number is allowed if
+	// empty.
+	serials map[string]bool
+
+	mu sync.Mutex
+	// issued counts the vouchers issued per serial number.
+	issued map[string]int
+}
+
+// handler returns the HTTP handler of the vendor CA:
+//
+//	POST /v1/vouchers                      issueRequest -> issueResponse, or the signed
+//	                                       voucher if application/voucher-cms+json is accepted
+//	POST /.well-known/brski/requestvoucher signed voucher request of RFC 8995 -> signed voucher
+//	GET  /v1/ca                            PEM encoded certificate of the vendor CA
+func (v *vendorCA) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/vouchers", v.issue)
+	mux.HandleFunc(masa.RequestVoucherPath, v.requestVoucher)
+	mux.HandleFunc("/v1/ca", v.caCert)
+	return mux
+}
+
+// issue issues a voucher pinning the PDC of the request to its serial number.
+func (v *vendorCA) issue(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeError(w, http.StatusMethodNotAllowed, "method %s is not supported", r.Method)
+		return
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestSize))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "unable to read request: %v", err)
+		return
+	}
+	req := issueRequest{}
+	if err := json.Unmarshal(body, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "unable to parse request: %v", err)
+		return
+	}
+	if req.SerialNumber == "" {
+		writeError(w, http.StatusBadRequest, "serial_number is required")
+		return
+	}
+	block, _ := pem.Decode([]byte(req.PinnedDomainCert))
+	if block == nil || block.Type != "CERTIFICATE" {
+		writeError(w, http.StatusBadRequest, "pinned_domain_cert is not a PEM encoded certificate")
+		return
+	}
+	pdc, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "unable to parse pinned_domain_cert: %v", err)
+		return
+	}
+	ov, httpStatus, err := v.sign(req.SerialNumber, pdc, r.RemoteAddr)
+	if err != nil {
+		writeError(w, httpStatus, "%v", err)
+		return
+	}
+	if strings.Contains(r.Header.Get("Accept"), masa.VoucherMediaType) {
+		writeVoucher(w, ov)
+		return
+	}
+	writeJSON(w, http.StatusOK, issueResponse{
+		SerialNumber:     req.SerialNumber,
+		OwnershipVoucher: base64.StdEncoding.EncodeToString(ov),
+	})
+}
+
+// requestVoucher issues a voucher for the registrar voucher request of RFC 8995, pinning the
+// certificate of the registrar which signed it. As in a lab, the registrar is not authenticated.
+func (v *vendorCA) requestVoucher(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeError(w, http.StatusMethodNotAllowed, "method %s is not supported", r.Method)
+		return
+	}
+	if ct := r.Header.Get("Content-Type"); ct != masa.VoucherMediaType {
+		writeError(w, http.StatusUnsupportedMediaType, "content type %q is not supported, want %s", ct, masa.VoucherMediaType)
+		return
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestSize))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "unable to read request: %v", err)
+		return
+	}
+	vr, registrar, err := masa.ParseVoucherRequest(body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "%v", err)
+		return
+	}
+	ov, httpStatus, err := v.sign(vr.SerialNumber, registrar, r.RemoteAddr)
+	if err != nil {
+		writeError(w, httpStatus, "%v", err)
+		return
+	}
+	writeVoucher(w, ov)
+}
+
+// sign issues a voucher pinning the PDC to the serial number, for the client at remote. If the
+// voucher is refused, it returns the HTTP status to respond with.
+func (v *vendorCA) sign(serial string, pdc *x509.Certificate, remote string) ([]byte, int, error) {
+	if len(v.serials) > 0 && !v.serials[serial] {
+		return nil, http.StatusForbidden, fmt.Errorf("serial# %s is not a device of this vendor", serial)
+	}
+	// Vouchers carry the PDC re-encoded, so that PEM headers and comments of requests are dropped.
+	pdcPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: pdc.Raw})
+	ov, err := ownershipvoucher.NewWithChain(serial, pdcPEM, v.cert, v.key, v.parents)
+	if err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("unable to issue voucher: %v", err)
+	}
+	v.mu.Lock()
+	v.issued[serial]++
+	n := v.issued[serial]
+	v.mu.Unlock()
+	log.Infof("Issued voucher #%d of serial# %s pinning %q to %s", n, serial, pdc.Subject.CommonName, remote)
+	return ov, http.StatusOK, nil
+}
+
+// caCert serves the certificate of the vendor CA, which bootz servers and devices trust.
+func (v *vendorCA) caCert(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		writeError(w, http.StatusMethodNotAllowed, "method %s is not supported", r.Method)
+		return
+	}
+	w.Header().Set("Content-Type", "application/x-pem-file")
+	if err := pem.Encode(w, &pem.Block{Type: "CERTIFICATE", Bytes: v.cert.Raw}); err != nil {
+		log.Errorf("Unable to write HTTP response: %v", err)
+	}
+}
+
+func writeVoucher(w http.ResponseWriter, ov []byte) {
+	w.Header().Set("Content-Type", masa.VoucherMediaType)
+	if _, err := w.Write(ov); err != nil {
+		log.Errorf("Unable to write HTTP response: %v", err)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, httpStatus int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(httpStatus)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		log.Errorf("Unable to write HTTP response: %v", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, httpStatus int, format string, args ...any) {
+	writeJSON(w, httpStatus, errorBody{Message: fmt.Sprintf(format, args...)})
+}
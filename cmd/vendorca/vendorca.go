@@ -0,0 +1,130 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// vendorca emulates the vendor CA of devices in a lab, issuing ownership
+// vouchers on demand as the MASA of a vendor would, so that the ownership
+// transfer workflow can be rehearsed end to end:
+//
+//	vendorca --ca_cert vendorca_pub.pem --ca_key vendorca_priv.pem --serials 123A,123B
+//	curl -d '{"serial_number": "123A", "pinned_domain_cert": "..."}' localhost:15010/v1/vouchers
+//
+// Without --ca_cert, an ephemeral vendor CA is generated, whose certificate is
+// served on /v1/ca. It is a lab tool: requests are not authenticated.
+package main
+
+import (
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/openconfig/bootz/server/artifacts"
+	"github.com/openconfig/bootz/server/keystore"
+
+	log "github.com/golang/glog"
+)
+
+var (
+	addr    = flag.String("addr", "localhost:15010", "The address to serve the vendor CA on.")
+	caCert  = flag.String("ca_cert", "", "PEM file holding the certificate of the vendor CA, optionally followed by its issuers up to the root. An ephemeral vendor CA is generated if unset.")
+	caKey   = flag.String("ca_key", "", "PEM file holding the RSA private key of the vendor CA.")
+	serials = flag.String("serials", "", "Comma separated serial numbers of the emulated devices. Vouchers are issued for any serial number if unset.")
+	tlsCert = flag.String("tls_cert", "", "PEM file holding the TLS certificate to serve with. Plain HTTP is served if unset.")
+	tlsKey  = flag.String("tls_key", "", "PEM file holding the private key of tls_cert.")
+)
+
+func main() {
+	flag.Parse()
+	v, err := newVendorCA()
+	if err != nil {
+		log.Exitf("Unable to set up the vendor CA: %v", err)
+	}
+	srv := &http.Server{Addr: *addr, Handler: v.handler()}
+	log.Infof("Vendor CA %q issuing ownership vouchers on %s", v.cert.Subject.CommonName, *addr)
+	if *tlsCert != "" {
+		srv.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+		err = srv.ListenAndServeTLS(*tlsCert, *tlsKey)
+	} else {
+		err = srv.ListenAndServe()
+	}
+	log.Exitf("Vendor CA stopped: %v", err)
+}
+
+// newVendorCA returns the vendor CA configured by flags.
+func newVendorCA() (*vendorCA, error) {
+	v := &vendorCA{serials: map[string]bool{}, issued: map[string]int{}}
+	for _, s := range strings.Split(*serials, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			v.serials[s] = true
+		}
+	}
+	if *caCert == "" {
+		sa, err := artifacts.Generate(artifacts.Options{})
+		if err != nil {
+			return nil, err
+		}
+		log.Warningf("No ca_cert set, issuing vouchers with an ephemeral vendor CA")
+		return v, v.load([]byte(sa.VendorCA.Cert), []byte(sa.VendorCA.PrivateKey))
+	}
+	certPEM, err := os.ReadFile(*caCert)
+	if err != nil {
+		return nil, err
+	}
+	keyPEM, err := os.ReadFile(*caKey)
+	if err != nil {
+		return nil, err
+	}
+	return v, v.load(certPEM, keyPEM)
+}
+
+// load sets the certificate chain and private key of the vendor CA.
+func (v *vendorCA) load(certPEM, keyPEM []byte) error {
+	var chain []*x509.Certificate
+	for rest := certPEM; ; {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		c, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return fmt.Errorf("unable to parse vendor CA certificate: %v", err)
+		}
+		chain = append(chain, c)
+	}
+	if len(chain) == 0 {
+		return fmt.Errorf("no vendor CA certificate found")
+	}
+	signer, err := keystore.ParsePrivateKey(keyPEM)
+	if err != nil {
+		return fmt.Errorf("unable to parse vendor CA private key: %v", err)
+	}
+	key, ok := signer.(*rsa.PrivateKey)
+	if !ok {
+		return fmt.Errorf("vendor CA private key is a %T, want an RSA key", signer)
+	}
+	if !key.PublicKey.Equal(chain[0].PublicKey) {
+		return fmt.Errorf("vendor CA private key does not match its certificate %q", chain[0].Subject.CommonName)
+	}
+	v.cert, v.key, v.parents = chain[0], key, chain[1:]
+	return nil
+}
@@ -0,0 +1,215 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/h-fam/errdiff"
+	ownershipvoucher "github.com/openconfig/bootz/common/ownership_voucher"
+	"github.com/openconfig/bootz/server/masa"
+)
+
+func readFile(t *testing.T, name string) []byte {
+	t.Helper()
+	b, err := os.ReadFile(name)
+	if err != nil {
+		t.Fatalf("unable to read %s: %v", name, err)
+	}
+	return b
+}
+
+func TestIssue(t *testing.T) {
+	v := &vendorCA{serials: map[string]bool{"123A": true}, issued: map[string]int{}}
+	if err := v.load(readFile(t, "../../testdata/vendorca_pub.pem"), readFile(t, "../../testdata/vendorca_priv.pem")); err != nil {
+		t.Fatalf("load() err = %v", err)
+	}
+	srv := httptest.NewServer(v.handler())
+	defer srv.Close()
+	pool := x509.NewCertPool()
+	pool.AddCert(v.cert)
+	pdc := readFile(t, "../../testdata/pdc_pub.pem")
+	body := func(serial, pdc string) string {
+		b, err := json.Marshal(issueRequest{SerialNumber: serial, PinnedDomainCert: pdc})
+		if err != nil {
+			t.Fatalf("json.Marshal() err = %v", err)
+		}
+		return string(b)
+	}
+	tests := []struct {
+		desc       string
+		method     string
+		path       string
+		accept     string
+		body       string
+		wantStatus int
+		// wantOV is set if the response holds a voucher, wantBody if it holds the substring.
+		wantOV   bool
+		wantBody string
+	}{{
+		desc:       "JSON voucher",
+		method:     http.MethodPost,
+		path:       "/v1/vouchers",
+		body:       body("123A", string(pdc)),
+		wantStatus: http.StatusOK,
+		wantOV:     true,
+	}, {
+		desc:       "CMS voucher",
+		method:     http.MethodPost,
+		path:       "/v1/vouchers",
+		accept:     masa.VoucherMediaType,
+		body:       body("123A", string(pdc)),
+		wantStatus: http.StatusOK,
+		wantOV:     true,
+	}, {
+		desc:       "Serial number of another vendor",
+		method:     http.MethodPost,
+		path:       "/v1/vouchers",
+		body:       body("999Z", string(pdc)),
+		wantStatus: http.StatusForbidden,
+		wantBody:   "not a device of this vendor",
+	}, {
+		desc:       "Missing serial number",
+		method:     http.MethodPost,
+		path:       "/v1/vouchers",
+		body:       body("", string(pdc)),
+		wantStatus: http.StatusBadRequest,
+		wantBody:   "serial_number is required",
+	}, {
+		desc:       "PDC is not a certificate",
+		method:     http.MethodPost,
+		path:       "/v1/vouchers",
+		body:       body("123A", "not a cert"),
+		wantStatus: http.StatusBadRequest,
+		wantBody:   "pinned_domain_cert",
+	}, {
+		desc:       "Request is not JSON",
+		method:     http.MethodPost,
+		path:       "/v1/vouchers",
+		body:       "serial=123A",
+		wantStatus: http.StatusBadRequest,
+		wantBody:   "unable to parse request",
+	}, {
+		desc:       "Vouchers are only issued on POST",
+		method:     http.MethodGet,
+		path:       "/v1/vouchers",
+		wantStatus: http.StatusMethodNotAllowed,
+	}, {
+		desc:       "CA certificate",
+		method:     http.MethodGet,
+		path:       "/v1/ca",
+		wantStatus: http.StatusOK,
+		wantBody:   "-----BEGIN CERTIFICATE-----",
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			req, err := http.NewRequest(test.method, srv.URL+test.path, strings.NewReader(test.body))
+			if err != nil {
+				t.Fatalf("http.NewRequest() err = %v", err)
+			}
+			if test.accept != "" {
+				req.Header.Set("Accept", test.accept)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("http.Do() err = %v", err)
+			}
+			defer resp.Body.Close()
+			got, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("unable to read response: %v", err)
+			}
+			if resp.StatusCode != test.wantStatus {
+				t.Fatalf("status = %d, want %d, body %q", resp.StatusCode, test.wantStatus, got)
+			}
+			if !strings.Contains(string(got), test.wantBody) {
+				t.Errorf("body = %q, want it to contain %q", got, test.wantBody)
+			}
+			if !test.wantOV {
+				return
+			}
+			ov := got
+			if test.accept == "" {
+				issued := issueResponse{}
+				if err := json.Unmarshal(got, &issued); err != nil {
+					t.Fatalf("unable to parse response %q: %v", got, err)
+				}
+				if ov, err = base64.StdEncoding.DecodeString(issued.OwnershipVoucher); err != nil {
+					t.Fatalf("unable to decode voucher: %v", err)
+				}
+			}
+			parsed, err := ownershipvoucher.VerifyAndUnmarshalWithOptions(ov, ownershipvoucher.VerifyOptions{TrustAnchors: pool, Validation: ownershipvoucher.ValidationStrict})
+			if err != nil {
+				t.Fatalf("VerifyAndUnmarshalWithOptions() err = %v", err)
+			}
+			if parsed.OV.SerialNumber != "123A" {
+				t.Errorf("voucher serial number = %q, want 123A", parsed.OV.SerialNumber)
+			}
+			block, _ := pem.Decode(pdc)
+			cert, err := parsed.PinnedDomainCertificate()
+			if err != nil {
+				t.Fatalf("PinnedDomainCertificate() err = %v", err)
+			}
+			if string(cert.Raw) != string(block.Bytes) {
+				t.Errorf("voucher pins %q, want the requested PDC", cert.Subject.CommonName)
+			}
+		})
+	}
+	if got := v.issued["123A"]; got != 2 {
+		t.Errorf("issued vouchers of 123A = %d, want 2", got)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	caPub := readFile(t, "../../testdata/vendorca_pub.pem")
+	caPriv := readFile(t, "../../testdata/vendorca_priv.pem")
+	tests := []struct {
+		desc    string
+		cert    []byte
+		key     []byte
+		wantErr string
+	}{{
+		desc: "Matching key",
+		cert: caPub,
+		key:  caPriv,
+	}, {
+		desc:    "Key of another certificate",
+		cert:    readFile(t, "../../testdata/pdc_pub.pem"),
+		key:     caPriv,
+		wantErr: "does not match",
+	}, {
+		desc:    "No certificate",
+		cert:    caPriv,
+		key:     caPriv,
+		wantErr: "no vendor CA certificate",
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			err := (&vendorCA{}).load(test.cert, test.key)
+			if s := errdiff.Substring(err, test.wantErr); s != "" {
+				t.Errorf("load() %s", s)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cms signs and verifies the CMS SignedData structures (RFC 5652) of
+// ownership vouchers and detached response signatures.
+//
+// The operations are behind the Codec interface, so the CMS library they rely
+// on can be replaced without changing its users. Default is used by the
+// package level functions.
+package cms
+
+import (
+	"crypto"
+	"crypto/x509"
+	"time"
+)
+
+// SignedData is a verified CMS SignedData with a single signer.
+type SignedData struct {
+	// Content is the signed content: the embedded content, or the content passed to Verify for
+	// detached signatures.
+	Content []byte
+	// Signer is the certificate of the signer.
+	Signer *x509.Certificate
+	// Certificates holds the certificates embedded in the SignedData, including the signer's.
+	Certificates []*x509.Certificate
+	// SigningTime is the signing time attribute of the signer, or the zero time if it has none.
+	SigningTime time.Time
+}
+
+// Codec signs and verifies CMS SignedData.
+type Codec interface {
+	// Sign returns the DER encoded SignedData of content, signed with a SHA-256 digest by key,
+	// the private key of the first certificate of chain. The rest of chain, ordered from the
+	// issuer of the signer towards the root, is embedded with it. The content itself is only
+	// embedded if detached is false.
+	Sign(content []byte, chain []*x509.Certificate, key crypto.PrivateKey, detached bool) ([]byte, error)
+	// Verify parses the DER encoded SignedData and verifies the signature of its only signer,
+	// over detachedContent if the content is not embedded. The certificate chain of the signer
+	// is not validated.
+	Verify(der, detachedContent []byte) (*SignedData, error)
+}
+
+// Default is the codec of the package level functions.
+var Default Codec = smallstepCodec{}
+
+// Sign signs content with Default. See Codec.Sign.
+func Sign(content []byte, chain []*x509.Certificate, key crypto.PrivateKey, detached bool) ([]byte, error) {
+	return Default.Sign(content, chain, key, detached)
+}
+
+// Verify verifies a SignedData with Default. See Codec.Verify.
+func Verify(der, detachedContent []byte) (*SignedData, error) {
+	return Default.Verify(der, detachedContent)
+}
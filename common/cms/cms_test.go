@@ -0,0 +1,208 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cms
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"testing"
+	"time"
+)
+
+// readCert reads a PEM encoded certificate of the testdata directory.
+func readCert(t *testing.T, path string) *x509.Certificate {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("unable to read %s: %v", path, err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		t.Fatalf("unable to decode %s", path)
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		t.Fatalf("unable to parse %s: %v", path, err)
+	}
+	return cert
+}
+
+// TestVerifyExistingVouchers checks that the vouchers of the testdata, signed with the archived
+// go.mozilla.org/pkcs7, verify with the default codec.
+func TestVerifyExistingVouchers(t *testing.T) {
+	vendorCA := readCert(t, "../../testdata/vendorca_pub.pem")
+	tests := []struct {
+		desc   string
+		path   string
+		serial string
+		signer *x509.Certificate
+	}{{
+		desc:   "Control card 123A",
+		path:   "../../testdata/ov_123A.txt",
+		serial: "123A",
+		signer: vendorCA,
+	}, {
+		desc:   "Control card 123B",
+		path:   "../../testdata/ov_123B.txt",
+		serial: "123B",
+		signer: vendorCA,
+	}, {
+		desc:   "Ownership voucher package testdata",
+		path:   "../ownership_voucher/testdata/ov_123A.txt",
+		serial: "123A",
+		signer: readCert(t, "../ownership_voucher/testdata/vendorca_pub.pem"),
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			data, err := os.ReadFile(test.path)
+			if err != nil {
+				t.Fatalf("unable to read %s: %v", test.path, err)
+			}
+			der, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(data)))
+			if err != nil {
+				t.Fatalf("unable to decode %s: %v", test.path, err)
+			}
+			sd, err := Verify(der, nil)
+			if err != nil {
+				t.Fatalf("Verify() err = %v, want nil", err)
+			}
+			if !sd.Signer.Equal(test.signer) {
+				t.Errorf("Verify() signer = %q, want %q", sd.Signer.Subject, test.signer.Subject)
+			}
+			if sd.SigningTime.IsZero() {
+				t.Errorf("Verify() signing time is unset")
+			}
+			ov := struct {
+				OV struct {
+					SerialNumber string `json:"serial-number"`
+				} `json:"ietf-voucher:voucher"`
+			}{}
+			if err := json.Unmarshal(sd.Content, &ov); err != nil {
+				t.Fatalf("unable to unmarshal voucher: %v", err)
+			}
+			if ov.OV.SerialNumber != test.serial {
+				t.Errorf("Verify() voucher serial = %q, want %q", ov.OV.SerialNumber, test.serial)
+			}
+			// Flipping a bit of the signed content must break the signature.
+			tampered := bytes.Replace(der, sd.Content, append([]byte{sd.Content[0] ^ 1}, sd.Content[1:]...), 1)
+			if _, err := Verify(tampered, nil); err == nil {
+				t.Errorf("Verify() of a tampered voucher err = nil, want error")
+			}
+		})
+	}
+}
+
+// opaqueSigner hides the private key behind crypto.Signer, as KMS and HSM backed keys do.
+type opaqueSigner struct {
+	crypto.Signer
+}
+
+func TestSignAndVerify(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unable to create ECDSA private key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		t.Fatalf("unable to create certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("unable to parse certificate: %v", err)
+	}
+	content := []byte("content")
+	tests := []struct {
+		desc     string
+		key      crypto.PrivateKey
+		detached bool
+	}{{
+		desc: "Embedded content",
+		key:  key,
+	}, {
+		desc:     "Detached content",
+		key:      key,
+		detached: true,
+	}, {
+		desc:     "Opaque signer",
+		key:      opaqueSigner{key},
+		detached: true,
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			signed, err := Sign(content, []*x509.Certificate{cert}, test.key, test.detached)
+			if err != nil {
+				t.Fatalf("Sign() err = %v, want nil", err)
+			}
+			if got := bytes.Contains(signed, content); got == test.detached {
+				t.Errorf("Sign() embeds the content: %v, want %v", got, !test.detached)
+			}
+			var detachedContent []byte
+			if test.detached {
+				detachedContent = content
+			}
+			sd, err := Verify(signed, detachedContent)
+			if err != nil {
+				t.Fatalf("Verify() err = %v, want nil", err)
+			}
+			if !bytes.Equal(sd.Content, content) || !sd.Signer.Equal(cert) {
+				t.Errorf("Verify() = %+v, want the content signed by the certificate", sd)
+			}
+			if test.detached {
+				if _, err := Verify(signed, []byte("other content")); err == nil {
+					t.Errorf("Verify() of other content err = nil, want error")
+				}
+			}
+		})
+	}
+	if _, err := Sign(content, nil, key, false); err == nil {
+		t.Errorf("Sign() without certificate err = nil, want error")
+	}
+}
+
+func TestVerifyMalformed(t *testing.T) {
+	tests := []struct {
+		desc string
+		der  []byte
+	}{{
+		desc: "Empty",
+	}, {
+		desc: "Truncated BER length",
+		der:  []byte("\xff0"),
+	}, {
+		desc: "Not DER",
+		der:  []byte("{}"),
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			if _, err := Verify(test.der, nil); err == nil {
+				t.Errorf("Verify() err = nil, want error")
+			}
+		})
+	}
+}
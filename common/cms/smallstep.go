@@ -0,0 +1,81 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cms
+
+import (
+	"crypto"
+	"crypto/x509"
+	"fmt"
+	"time"
+
+	"github.com/smallstep/pkcs7"
+)
+
+// smallstepCodec implements Codec with github.com/smallstep/pkcs7, the maintained fork of the
+// archived go.mozilla.org/pkcs7. It signs with crypto.Signer keys held by key stores too.
+type smallstepCodec struct{}
+
+// Sign implements Codec.
+func (smallstepCodec) Sign(content []byte, chain []*x509.Certificate, key crypto.PrivateKey, detached bool) ([]byte, error) {
+	if len(chain) == 0 {
+		return nil, fmt.Errorf("no signer certificate provided")
+	}
+	sd, err := pkcs7.NewSignedData(content)
+	if err != nil {
+		return nil, err
+	}
+	sd.SetDigestAlgorithm(pkcs7.OIDDigestAlgorithmSHA256)
+	if err := sd.AddSignerChain(chain[0], key, chain[1:], pkcs7.SignerInfoConfig{}); err != nil {
+		return nil, err
+	}
+	if detached {
+		sd.Detach()
+	}
+	return sd.Finish()
+}
+
+// Verify implements Codec. The BER decoder of pkcs7 panics on some truncated inputs, which are
+// reported as errors as the SignedData comes from the network.
+func (smallstepCodec) Verify(der, detachedContent []byte) (sd *SignedData, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			sd, err = nil, fmt.Errorf("unable to parse CMS SignedData: malformed encoding: %v", r)
+		}
+	}()
+	p7, err := pkcs7.Parse(der)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse CMS SignedData: %v", err)
+	}
+	if len(p7.Content) == 0 {
+		p7.Content = detachedContent
+	}
+	signer := p7.GetOnlySigner()
+	if signer == nil {
+		return nil, fmt.Errorf("expected exactly one signer, got %d", len(p7.Signers))
+	}
+	if err := p7.Verify(); err != nil {
+		return nil, err
+	}
+	sd = &SignedData{
+		Content:      p7.Content,
+		Signer:       signer,
+		Certificates: p7.Certificates,
+	}
+	var signingTime time.Time
+	if err := p7.UnmarshalSignedAttribute(pkcs7.OIDAttributeSigningTime, &signingTime); err == nil {
+		sd.SigningTime = signingTime
+	}
+	return sd, nil
+}
@@ -0,0 +1,94 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package fips restricts the cryptography of bootz to FIPS 140 approved algorithms.
+//
+// FIPS mode is on in binaries built with GOEXPERIMENT=boringcrypto, which also restricts
+// crypto/tls to approved settings, or once Enable is called, e.g. by a server configured
+// with fips_mode.
+package fips
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"sync/atomic"
+)
+
+// minRSABits is the smallest approved RSA modulus.
+const minRSABits = 2048
+
+var enabled atomic.Bool
+
+// Enabled reports whether FIPS mode is on.
+func Enabled() bool {
+	return boringCrypto || enabled.Load()
+}
+
+// Enable turns FIPS mode on for the rest of the life of the process.
+func Enable() {
+	enabled.Store(true)
+}
+
+// CheckPublicKey returns an error unless pub is an RSA key of at least 2048 bits or an ECDSA
+// key on the P-256, P-384 or P-521 curve.
+func CheckPublicKey(pub crypto.PublicKey) error {
+	switch k := pub.(type) {
+	case *rsa.PublicKey:
+		if bits := k.N.BitLen(); bits < minRSABits {
+			return fmt.Errorf("%d bit RSA keys are not FIPS approved, want at least %d bits", bits, minRSABits)
+		}
+		return nil
+	case *ecdsa.PublicKey:
+		switch k.Curve {
+		case elliptic.P256(), elliptic.P384(), elliptic.P521():
+			return nil
+		}
+		return fmt.Errorf("ECDSA keys on curve %s are not FIPS approved", k.Curve.Params().Name)
+	}
+	return fmt.Errorf("%T keys are not FIPS approved", pub)
+}
+
+// CheckCertificate returns an error if the key of the certificate is not approved, or if it is
+// not signed with an approved algorithm.
+func CheckCertificate(cert *x509.Certificate) error {
+	if err := CheckPublicKey(cert.PublicKey); err != nil {
+		return err
+	}
+	switch cert.SignatureAlgorithm {
+	case x509.SHA256WithRSA, x509.SHA384WithRSA, x509.SHA512WithRSA,
+		x509.SHA256WithRSAPSS, x509.SHA384WithRSAPSS, x509.SHA512WithRSAPSS,
+		x509.ECDSAWithSHA256, x509.ECDSAWithSHA384, x509.ECDSAWithSHA512:
+		return nil
+	}
+	return fmt.Errorf("%v signatures are not FIPS approved", cert.SignatureAlgorithm)
+}
+
+// ConfigureTLS restricts cfg to TLS 1.2 with approved cipher suites and curves, mirroring the
+// settings enforced by crypto/tls/fipsonly, as TLS 1.3 cipher suites can not be restricted.
+func ConfigureTLS(cfg *tls.Config) {
+	cfg.MinVersion = tls.VersionTLS12
+	cfg.MaxVersion = tls.VersionTLS12
+	cfg.CipherSuites = []uint16{
+		tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+		tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+		tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+		tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+	}
+	cfg.CurvePreferences = []tls.CurveID{tls.CurveP256, tls.CurveP384, tls.CurveP521}
+}
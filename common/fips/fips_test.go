@@ -0,0 +1,124 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fips
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestCheckPublicKey(t *testing.T) {
+	rsa1024, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("rsa.GenerateKey() err = %v", err)
+	}
+	rsa2048, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("rsa.GenerateKey() err = %v", err)
+	}
+	p256, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("ecdsa.GenerateKey() err = %v", err)
+	}
+	p224, err := ecdsa.GenerateKey(elliptic.P224(), rand.Reader)
+	if err != nil {
+		t.Fatalf("ecdsa.GenerateKey() err = %v", err)
+	}
+	edPub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("ed25519.GenerateKey() err = %v", err)
+	}
+	tests := []struct {
+		desc    string
+		pub     crypto.PublicKey
+		wantErr bool
+	}{{
+		desc: "2048 bit RSA",
+		pub:  &rsa2048.PublicKey,
+	}, {
+		desc:    "1024 bit RSA",
+		pub:     &rsa1024.PublicKey,
+		wantErr: true,
+	}, {
+		desc: "ECDSA P-256",
+		pub:  &p256.PublicKey,
+	}, {
+		desc:    "ECDSA P-224",
+		pub:     &p224.PublicKey,
+		wantErr: true,
+	}, {
+		desc:    "Ed25519",
+		pub:     edPub,
+		wantErr: true,
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			if err := CheckPublicKey(test.pub); (err != nil) != test.wantErr {
+				t.Errorf("CheckPublicKey() err = %v, want error: %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckCertificate(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("ecdsa.GenerateKey() err = %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		t.Fatalf("x509.CreateCertificate() err = %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("x509.ParseCertificate() err = %v", err)
+	}
+	if err := CheckCertificate(cert); err != nil {
+		t.Errorf("CheckCertificate() err = %v, want nil", err)
+	}
+	cert.SignatureAlgorithm = x509.SHA1WithRSA
+	if err := CheckCertificate(cert); err == nil {
+		t.Errorf("CheckCertificate() of a SHA-1 signed certificate err = nil, want error")
+	}
+}
+
+func TestConfigureTLS(t *testing.T) {
+	cfg := &tls.Config{}
+	ConfigureTLS(cfg)
+	if cfg.MinVersion != tls.VersionTLS12 || cfg.MaxVersion != tls.VersionTLS12 {
+		t.Errorf("ConfigureTLS() versions = %x-%x, want TLS 1.2", cfg.MinVersion, cfg.MaxVersion)
+	}
+	for _, id := range cfg.CipherSuites {
+		if s := tls.CipherSuiteName(id); s == "" {
+			t.Errorf("ConfigureTLS() set unknown cipher suite %x", id)
+		}
+	}
+}
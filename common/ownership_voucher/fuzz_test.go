@@ -0,0 +1,184 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ownershipvoucher
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/openconfig/bootz/common/cms"
+)
+
+var updateCorpus = flag.Bool("update_corpus", false, "Whether to regenerate the malformed inputs of the fuzz corpus in testdata/fuzz.")
+
+// malformedContents are voucher contents malformed at the JSON layer.
+var malformedContents = map[string]string{
+	"json_truncated":      `{"ietf-voucher:voucher": {"serial-number": "123A"`,
+	"json_null":           `null`,
+	"json_array":          `[{"ietf-voucher:voucher": {}}]`,
+	"json_nested":         `{"ietf-voucher:voucher": ` + strings.Repeat("[", 10000),
+	"json_wrong_types":    `{"ietf-voucher:voucher": {"serial-number": 123, "created-on": true, "domain-cert-revocation-checks": "yes"}}`,
+	"json_unknown_leaves": `{"ietf-voucher:voucher": {"serial-number": "123A", "owner": "evil"}, "extra": {}}`,
+	"json_bad_timestamps": `{"ietf-voucher:voucher": {"serial-number": "123A", "assertion": "verified", "created-on": "yesterday", "expires-on": "2000-01-01T00:00:00Z"}}`,
+	"json_bad_pdc":        `{"ietf-voucher:voucher": {"serial-number": "123A", "assertion": "verified", "created-on": "2023-10-24T05:19:57Z", "pinned-domain-cert": "!!not base64!!"}}`,
+	"json_control_chars":  `{"ietf-voucher:voucher": {"serial-number": "12\u00003A", "assertion": "verified\n", "created-on": "2023-10-24T05:19:57Z"}}`,
+	"json_duplicate_keys": `{"ietf-voucher:voucher": {"serial-number": "123A", "serial-number": "456A"}}`,
+}
+
+// vendorCA returns the vendor CA of the testdata.
+func vendorCA(t testing.TB) (*x509.Certificate, any) {
+	t.Helper()
+	certPEM, _ := pem.Decode(vendorCAPub)
+	keyPEM, _ := pem.Decode(vendorCAPriv)
+	if certPEM == nil || keyPEM == nil {
+		t.Fatalf("unable to decode the vendor CA")
+	}
+	cert, err := x509.ParseCertificate(certPEM.Bytes)
+	if err != nil {
+		t.Fatalf("unable to parse the vendor CA certificate: %v", err)
+	}
+	key, err := x509.ParsePKCS1PrivateKey(keyPEM.Bytes)
+	if err != nil {
+		t.Fatalf("unable to parse the vendor CA key: %v", err)
+	}
+	return cert, key
+}
+
+// malformedVouchers returns vouchers malformed at the PKCS7 layer, and the malformed contents
+// signed by the vendor CA of the testdata, so they reach the JSON parser.
+func malformedVouchers(t *testing.T) map[string][]byte {
+	t.Helper()
+	valid, err := base64.StdEncoding.DecodeString(testOV)
+	if err != nil {
+		t.Fatalf("unable to decode ownership voucher: %v", err)
+	}
+	cert, key := vendorCA(t)
+	sign := func(content []byte, chain []*x509.Certificate, key any, detached bool) []byte {
+		der, err := cms.Sign(content, chain, key, detached)
+		if err != nil {
+			t.Fatalf("cms.Sign() err = %v", err)
+		}
+		return der
+	}
+	badSignature := append([]byte(nil), valid...)
+	badSignature[len(badSignature)-1] ^= 0xff
+	badLength := append([]byte(nil), valid...)
+	badLength[2], badLength[3] = 0xff, 0xff
+	otherCA, otherKey := newCA(t, "Other CA", nil, nil)
+
+	out := map[string][]byte{
+		"pkcs7_empty":            {},
+		"pkcs7_not_der":          []byte("ietf-voucher:voucher"),
+		"pkcs7_truncated":        valid[:len(valid)/2],
+		"pkcs7_bad_signature":    badSignature,
+		"pkcs7_bad_length":       badLength,
+		"pkcs7_detached":         sign(valid, []*x509.Certificate{cert}, key, true),
+		"pkcs7_untrusted_signer": sign([]byte(`{"ietf-voucher:voucher": {"serial-number": "123A"}}`), []*x509.Certificate{otherCA}, otherKey, false),
+	}
+	for name, content := range malformedContents {
+		out["signed_"+name] = sign([]byte(content), []*x509.Certificate{cert}, key, false)
+	}
+	return out
+}
+
+// writeCorpus writes the inputs to the corpus of the fuzz target in testdata/fuzz.
+func writeCorpus(t *testing.T, target string, inputs map[string][]byte) {
+	t.Helper()
+	dir := filepath.Join("testdata", "fuzz", target)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatalf("os.MkdirAll() err = %v", err)
+	}
+	for name, in := range inputs {
+		data := fmt.Sprintf("go test fuzz v1\n[]byte(%q)\n", in)
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0644); err != nil {
+			t.Fatalf("os.WriteFile() err = %v", err)
+		}
+	}
+}
+
+// TestGenerateCorpus regenerates the malformed inputs of the fuzz corpus, which are run as
+// regular tests by the fuzz targets.
+func TestGenerateCorpus(t *testing.T) {
+	if !*updateCorpus {
+		t.Skip("set -update_corpus to regenerate the fuzz corpus")
+	}
+	writeCorpus(t, "FuzzVerifyAndUnmarshal", malformedVouchers(t))
+	contents := map[string][]byte{}
+	for name, c := range malformedContents {
+		contents[name] = []byte(c)
+	}
+	writeCorpus(t, "FuzzValidateContent", contents)
+}
+
+// FuzzVerifyAndUnmarshal checks that parsing and verifying arbitrary vouchers fails cleanly.
+func FuzzVerifyAndUnmarshal(f *testing.F) {
+	valid, err := base64.StdEncoding.DecodeString(testOV)
+	if err != nil {
+		f.Fatalf("unable to decode ownership voucher: %v", err)
+	}
+	f.Add(valid)
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(vendorCAPub) {
+		f.Fatalf("unable to add vendor root CA to pool")
+	}
+	f.Fuzz(func(t *testing.T, in []byte) {
+		if ov, err := Parse(in); err == nil {
+			ov.SignerChain()
+			ov.CreatedOnTime()
+			ov.PinnedDomainCertificate()
+		}
+		ov, err := VerifyAndUnmarshalWithOptions(in, VerifyOptions{TrustAnchors: pool, Validation: ValidationStrict})
+		if err != nil {
+			return
+		}
+		if ov.OV.SerialNumber == "" || len(ov.SignerChain()) == 0 {
+			t.Errorf("VerifyAndUnmarshalWithOptions() = %+v, want a serial number and a signer chain", ov.OV)
+		}
+	})
+}
+
+// FuzzValidateContent checks the validation of arbitrary voucher contents, which is reached once
+// the signature of a voucher is verified.
+func FuzzValidateContent(f *testing.F) {
+	valid, err := base64.StdEncoding.DecodeString(testOV)
+	if err != nil {
+		f.Fatalf("unable to decode ownership voucher: %v", err)
+	}
+	ov, err := Parse(valid)
+	if err != nil {
+		f.Fatalf("Parse() err = %v", err)
+	}
+	f.Add(ov.signedData.Content)
+	f.Fuzz(func(t *testing.T, content []byte) {
+		ov := &OwnershipVoucher{}
+		if err := json.Unmarshal(content, ov); err != nil {
+			return
+		}
+		for _, level := range []Validation{ValidationNone, ValidationStrict, ValidationSchema} {
+			err := ov.validate(content, level)
+			if err == nil && level >= ValidationStrict && ov.Validate() != nil {
+				t.Errorf("validate(%v) = nil for a voucher failing Validate()", level)
+			}
+		}
+	})
+}
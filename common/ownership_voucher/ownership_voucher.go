@@ -16,23 +16,98 @@
 package ownershipvoucher
 
 import (
+	"bytes"
 	"crypto/rsa"
+	"crypto/sha256"
 	"crypto/x509"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
-	"go.mozilla.org/pkcs7"
+	"github.com/openconfig/bootz/common/cms"
 )
 
 const (
 	ovExpiry = time.Hour * 24 * 365
 )
 
+// ErrPinnedDomainCertMismatch is returned when the pinned-domain-cert of an Ownership Voucher
+// does not match the expected PDC, i.e. ownership has been transferred to a different domain.
+var ErrPinnedDomainCertMismatch = errors.New("pinned-domain-cert does not match the expected PDC")
+
+// ErrSignerRevoked is returned when a certificate in the signer's chain of an Ownership Voucher
+// is listed in the revocation list of its issuer.
+var ErrSignerRevoked = errors.New("ownership voucher signer certificate has been revoked")
+
+// PDCMatch defines how the pinned-domain-cert of an Ownership Voucher is compared with a PDC.
+type PDCMatch int
+
+const (
+	// PDCMatchNone skips the pinned-domain-cert comparison.
+	PDCMatchNone PDCMatch = iota
+	// PDCMatchExact requires the pinned-domain-cert to be byte-for-byte identical to the PDC.
+	PDCMatchExact
+	// PDCMatchSPKI requires the pinned-domain-cert to have the same SubjectPublicKeyInfo hash as the PDC.
+	PDCMatchSPKI
+)
+
 // OwnershipVoucher wraps Inner.
 type OwnershipVoucher struct {
 	OV Inner `json:"ietf-voucher:voucher"`
+
+	// signedData is the CMS SignedData the voucher was parsed from.
+	signedData *cms.SignedData
+	// chain is the verified chain of the signer, from the signer to its trust anchor.
+	chain []*x509.Certificate
+}
+
+// Signer returns the certificate of the signer of the Ownership Voucher, or nil if it was not
+// parsed from a signed voucher.
+func (ov *OwnershipVoucher) Signer() *x509.Certificate {
+	if ov.signedData == nil {
+		return nil
+	}
+	return ov.signedData.Signer
+}
+
+// Certificates returns the certificates embedded in the signed Ownership Voucher.
+func (ov *OwnershipVoucher) Certificates() []*x509.Certificate {
+	if ov.signedData == nil {
+		return nil
+	}
+	return ov.signedData.Certificates
+}
+
+// SignerChain returns the chain of the signer of the Ownership Voucher, starting with the signer.
+// For verified vouchers, it is the chain verified up to the trust anchor. Otherwise, it is the
+// signer followed by the other certificates embedded in the voucher.
+func (ov *OwnershipVoucher) SignerChain() []*x509.Certificate {
+	if ov.chain != nil {
+		return ov.chain
+	}
+	signer := ov.Signer()
+	if signer == nil {
+		return nil
+	}
+	chain := []*x509.Certificate{signer}
+	for _, c := range ov.Certificates() {
+		if !c.Equal(signer) {
+			chain = append(chain, c)
+		}
+	}
+	return chain
+}
+
+// SigningTime returns the signing time of the Ownership Voucher, or the zero time if it is not
+// recorded in the voucher.
+func (ov *OwnershipVoucher) SigningTime() time.Time {
+	if ov.signedData == nil {
+		return time.Time{}
+	}
+	return ov.signedData.SigningTime
 }
 
 // Inner defines the Ownership Voucher format. See https://www.rfc-editor.org/rfc/rfc8366.html.
@@ -45,6 +120,77 @@ type Inner struct {
 	DomainCertRevocationChecks bool   `json:"domain-cert-revocation-checks"`
 }
 
+// timeLayouts are the layouts of the created-on and expires-on timestamps accepted from vendors.
+// RFC 8366 requires RFC 3339 timestamps, but some vendors omit the time zone offset colon or the
+// time zone, separate the date and time with a space, or serialize them with Go's time.String.
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999Z0700",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999 -0700 MST",
+	"2006-01-02 15:04:05.999999999 -0700",
+}
+
+// ParseTime parses an Ownership Voucher timestamp. RFC 3339 timestamps are accepted with a
+// lowercase "t" or "z", as allowed by the RFC, as are the variants listed in timeLayouts.
+// Timestamps without a time zone are in UTC.
+func ParseTime(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	// Go's time.String appends the monotonic clock reading, e.g. "m=+4.079786739".
+	if i := strings.Index(s, " m="); i >= 0 {
+		s = s[:i]
+	}
+	upper := strings.ToUpper(s)
+	for _, layout := range timeLayouts {
+		for _, v := range []string{s, upper} {
+			if t, err := time.Parse(layout, v); err == nil {
+				return t, nil
+			}
+		}
+	}
+	return time.Time{}, fmt.Errorf("unable to parse timestamp %q, want RFC 3339", s)
+}
+
+// formatTime formats an Ownership Voucher timestamp as RFC 3339 in UTC.
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+// CreatedOnTime parses the created-on timestamp of the Ownership Voucher.
+func (ov *OwnershipVoucher) CreatedOnTime() (time.Time, error) {
+	return ParseTime(ov.OV.CreatedOn)
+}
+
+// ExpiresOnTime parses the expires-on timestamp of the Ownership Voucher. It returns the zero
+// time if the voucher does not expire.
+func (ov *OwnershipVoucher) ExpiresOnTime() (time.Time, error) {
+	if ov.OV.ExpiresOn == "" {
+		return time.Time{}, nil
+	}
+	return ParseTime(ov.OV.ExpiresOn)
+}
+
+// checkTimestamps checks that the timestamps of the Ownership Voucher can be parsed, and that it
+// does not expire before it is created.
+func (ov *OwnershipVoucher) checkTimestamps() error {
+	var created time.Time
+	if ov.OV.CreatedOn != "" {
+		var err error
+		if created, err = ov.CreatedOnTime(); err != nil {
+			return fmt.Errorf("invalid created-on: %v", err)
+		}
+	}
+	expires, err := ov.ExpiresOnTime()
+	if err != nil {
+		return fmt.Errorf("invalid expires-on: %v", err)
+	}
+	if !created.IsZero() && !expires.IsZero() && expires.Before(created) {
+		return fmt.Errorf("expires-on %v is before created-on %v", formatTime(expires), formatTime(created))
+	}
+	return nil
+}
+
 // RemovePemHeaders strips the PEM headers from a certificate so it can be used in an Ownership Voucher.
 func RemovePemHeaders(pemBlock string) string {
 	pemBlock = strings.TrimPrefix(pemBlock, "-----BEGIN CERTIFICATE-----\n")
@@ -52,34 +198,190 @@ func RemovePemHeaders(pemBlock string) string {
 	return pemBlock
 }
 
+// PinnedDomainCertificate parses the pinned-domain-cert of the Ownership Voucher.
+func (ov *OwnershipVoucher) PinnedDomainCertificate() (*x509.Certificate, error) {
+	// The pinned-domain-cert is base64 encoded DER, possibly wrapped across multiple lines.
+	encoded := strings.Join(strings.Fields(ov.OV.PinnedDomainCert), "")
+	der, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("unable to base64 decode pinned-domain-cert: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse pinned-domain-cert: %v", err)
+	}
+	return cert, nil
+}
+
+// VerifyPinnedDomainCert checks that the pinned-domain-cert of the Ownership Voucher matches pdc
+// according to the match mode. ErrPinnedDomainCertMismatch is returned if the certificates differ.
+func (ov *OwnershipVoucher) VerifyPinnedDomainCert(pdc *x509.Certificate, match PDCMatch) error {
+	if match == PDCMatchNone {
+		return nil
+	}
+	if pdc == nil {
+		return fmt.Errorf("no PDC provided to compare pinned-domain-cert against")
+	}
+	pinned, err := ov.PinnedDomainCertificate()
+	if err != nil {
+		return err
+	}
+	switch match {
+	case PDCMatchExact:
+		if !bytes.Equal(pinned.Raw, pdc.Raw) {
+			return fmt.Errorf("%w: voucher pins %q", ErrPinnedDomainCertMismatch, pinned.Subject)
+		}
+	case PDCMatchSPKI:
+		if sha256.Sum256(pinned.RawSubjectPublicKeyInfo) != sha256.Sum256(pdc.RawSubjectPublicKeyInfo) {
+			return fmt.Errorf("%w: voucher pins a different public key for %q", ErrPinnedDomainCertMismatch, pinned.Subject)
+		}
+	default:
+		return fmt.Errorf("unknown pinned-domain-cert match mode %d", match)
+	}
+	return nil
+}
+
+// VerifyOptions configures how the signer of an Ownership Voucher is validated.
+type VerifyOptions struct {
+	// TrustAnchors holds the vendor root CAs. The signer's chain must terminate at one of these.
+	TrustAnchors *x509.CertPool
+	// Intermediates holds additional vendor intermediate CAs which are not embedded in the PKCS7 blob.
+	Intermediates *x509.CertPool
+	// CurrentTime is the time at which the chain is validated. If unset, the current time is
+	// used, unless AtSigningTime is set.
+	CurrentTime time.Time
+	// AtSigningTime validates the chain at the signing time of the voucher, when present and
+	// CurrentTime is unset, e.g. to inspect vouchers whose signer has expired since. The signing
+	// time is chosen by the signer and may be backdated, so it must not be used to accept
+	// vouchers.
+	AtSigningTime bool
+	// PinnedDomainCert is the PDC the voucher is expected to pin. It is only checked
+	// when PDCMatch is not PDCMatchNone.
+	PinnedDomainCert *x509.Certificate
+	// PDCMatch selects how the pinned-domain-cert is compared with PinnedDomainCert.
+	PDCMatch PDCMatch
+	// RevocationList is an optional CRL of a vendor CA. Vouchers signed by a chain
+	// containing a certificate revoked by it are rejected.
+	RevocationList *x509.RevocationList
+	// Validation selects how strictly the content of the voucher is validated.
+	Validation Validation
+}
+
 // VerifyAndUnmarshal unmarshals the contents of an Ownership Voucher
 // and verifies that it has been signed by a signer in the given cert pool.
 func VerifyAndUnmarshal(in []byte, certPool *x509.CertPool) (*OwnershipVoucher, error) {
+	return VerifyAndUnmarshalWithOptions(in, VerifyOptions{TrustAnchors: certPool})
+}
+
+// VerifyAndUnmarshalWithOptions unmarshals the contents of an Ownership Voucher and verifies
+// its signature. The signer certificate must chain to one of opts.TrustAnchors, using any
+// intermediate CAs embedded in the PKCS7 blob or provided in opts.Intermediates. Certificates
+// embedded in the voucher are never trusted as anchors.
+func VerifyAndUnmarshalWithOptions(in []byte, opts VerifyOptions) (*OwnershipVoucher, error) {
 	if len(in) == 0 {
 		return nil, fmt.Errorf("ownership voucher is empty")
 	}
-	p7, err := pkcs7.Parse(in)
+	if opts.TrustAnchors == nil {
+		return nil, fmt.Errorf("no trust anchors provided to verify ownership voucher")
+	}
+	ov, err := Parse(in)
 	if err != nil {
-		return nil, fmt.Errorf("unable to parse into pkcs7 format: %v", err)
+		return nil, err
+	}
+	if err := ov.validate(ov.signedData.Content, opts.Validation); err != nil {
+		return nil, fmt.Errorf("failed to verify OV: %w", err)
 	}
-	ov := OwnershipVoucher{}
-	err = json.Unmarshal(p7.Content, &ov)
+	chains, err := verifySignerChain(ov.signedData, opts)
 	if err != nil {
-		return nil, fmt.Errorf("failed unmarshalling ownership voucher: %v", err)
+		return nil, fmt.Errorf("failed to verify OV signer chain: %v", err)
 	}
-	if err = p7.VerifyWithChain(certPool); err != nil {
+	if err := checkRevocation(chains, opts.RevocationList); err != nil {
+		return nil, fmt.Errorf("failed to verify OV: %w", err)
+	}
+	if err := ov.VerifyPinnedDomainCert(opts.PinnedDomainCert, opts.PDCMatch); err != nil {
+		return nil, fmt.Errorf("failed to verify OV: %w", err)
+	}
+	ov.chain = chains[0]
+	return ov, nil
+}
+
+// Parse unmarshals the contents of an Ownership Voucher and checks that it is signed by the
+// certificate embedded as its signer, without verifying the chain of the signer. The signer and
+// the certificates embedded in the voucher are available through its accessors.
+func Parse(in []byte) (*OwnershipVoucher, error) {
+	sd, err := cms.Verify(in, nil)
+	if err != nil {
 		return nil, fmt.Errorf("failed to verify OV: %v", err)
 	}
-	return &ov, nil
+	ov := &OwnershipVoucher{signedData: sd}
+	if err := json.Unmarshal(sd.Content, ov); err != nil {
+		return nil, fmt.Errorf("failed unmarshalling ownership voucher: %v", err)
+	}
+	return ov, nil
+}
+
+// verifySignerChain validates the chain of trust from the signer to a trust anchor.
+func verifySignerChain(sd *cms.SignedData, opts VerifyOptions) ([][]*x509.Certificate, error) {
+	intermediates := x509.NewCertPool()
+	if opts.Intermediates != nil {
+		intermediates = opts.Intermediates.Clone()
+	}
+	for _, c := range sd.Certificates {
+		if c.Equal(sd.Signer) {
+			continue
+		}
+		intermediates.AddCert(c)
+	}
+	currentTime := opts.CurrentTime
+	if currentTime.IsZero() && opts.AtSigningTime {
+		currentTime = sd.SigningTime
+	}
+	if currentTime.IsZero() {
+		currentTime = time.Now()
+	}
+	return sd.Signer.Verify(x509.VerifyOptions{
+		Roots:         opts.TrustAnchors,
+		Intermediates: intermediates,
+		CurrentTime:   currentTime,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+}
+
+// checkRevocation returns ErrSignerRevoked if any certificate of the verified chains is revoked by crl.
+func checkRevocation(chains [][]*x509.Certificate, crl *x509.RevocationList) error {
+	if crl == nil {
+		return nil
+	}
+	for _, chain := range chains {
+		for _, c := range chain {
+			if !bytes.Equal(c.RawIssuer, crl.RawIssuer) {
+				continue
+			}
+			for _, rc := range crl.RevokedCertificateEntries {
+				if rc.SerialNumber.Cmp(c.SerialNumber) == 0 {
+					return fmt.Errorf("%w: serial %v issued by %q", ErrSignerRevoked, c.SerialNumber, c.Issuer)
+				}
+			}
+		}
+	}
+	return nil
 }
 
 // New generates an Ownership Voucher which is signed by the vendor's CA.
 func New(serial string, pdcPem []byte, vendorCACert *x509.Certificate, vendorCAPriv *rsa.PrivateKey) ([]byte, error) {
+	return NewWithChain(serial, pdcPem, vendorCACert, vendorCAPriv, nil)
+}
+
+// NewWithChain generates an Ownership Voucher signed by a vendor CA which is issued by a hierarchy
+// of intermediates. The parents, ordered from the signer's issuer towards the root, are embedded in
+// the PKCS7 blob so devices can build the chain to their trust anchor.
+func NewWithChain(serial string, pdcPem []byte, vendorCACert *x509.Certificate, vendorCAPriv *rsa.PrivateKey, parents []*x509.Certificate) ([]byte, error) {
 	currentTime := time.Now()
 	ov := OwnershipVoucher{
 		OV: Inner{
-			CreatedOn:        currentTime.String(),
-			ExpiresOn:        currentTime.Add(ovExpiry).String(),
+			CreatedOn:        formatTime(currentTime),
+			ExpiresOn:        formatTime(currentTime.Add(ovExpiry)),
+			Assertion:        "verified",
 			SerialNumber:     serial,
 			PinnedDomainCert: RemovePemHeaders(string(pdcPem)),
 		},
@@ -90,17 +392,5 @@ func New(serial string, pdcPem []byte, vendorCACert *x509.Certificate, vendorCAP
 		return nil, err
 	}
 
-	signedMessage, err := pkcs7.NewSignedData(ovBytes)
-	if err != nil {
-		return nil, err
-	}
-	signedMessage.SetDigestAlgorithm(pkcs7.OIDDigestAlgorithmSHA256)
-	signedMessage.SetEncryptionAlgorithm(pkcs7.OIDEncryptionAlgorithmRSA)
-
-	err = signedMessage.AddSigner(vendorCACert, vendorCAPriv, pkcs7.SignerInfoConfig{})
-	if err != nil {
-		return nil, err
-	}
-
-	return signedMessage.Finish()
+	return cms.Sign(ovBytes, append([]*x509.Certificate{vendorCACert}, parents...), vendorCAPriv, false)
 }
@@ -15,10 +15,21 @@
 package ownershipvoucher
 
 import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
 	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/base64"
+	"encoding/json"
 	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
 	"testing"
+	"time"
+
+	"github.com/openconfig/bootz/common/cms"
 
 	_ "embed"
 )
@@ -64,9 +75,15 @@ func TestNew(t *testing.T) {
 		t.Fatalf("unable to add vendor root CA to pool")
 	}
 
-	_, err = VerifyAndUnmarshal(got, vendorCAPool)
+	ov, err := VerifyAndUnmarshal(got, vendorCAPool)
 	if err != nil {
-		t.Errorf("VerifyAndUnmarshal err = %v, want nil", err)
+		t.Fatalf("VerifyAndUnmarshal err = %v, want nil", err)
+	}
+	// RFC 8366 requires RFC 3339 timestamps.
+	for name, ts := range map[string]string{"created-on": ov.OV.CreatedOn, "expires-on": ov.OV.ExpiresOn} {
+		if _, err := time.Parse(time.RFC3339, ts); err != nil {
+			t.Errorf("%s = %q, want an RFC 3339 timestamp: %v", name, ts, err)
+		}
 	}
 }
 
@@ -92,3 +109,536 @@ func TestVerifyAndUnmarshal(t *testing.T) {
 		t.Errorf("got serial = %v, want %v", gotSerial, wantSerial)
 	}
 }
+
+// newCA creates a CA certificate signed by the parent, or a self-signed root if parent is nil.
+func newCA(t testing.TB, name string, parent *x509.Certificate, parentKey *rsa.PrivateKey) (*x509.Certificate, *rsa.PrivateKey) {
+	t.Helper()
+	return newCAWithBits(t, name, 2048, parent, parentKey)
+}
+
+// newCAWithBits returns a CA certificate with an RSA key of the given size, issued by parent or
+// self-signed if parent is nil.
+func newCAWithBits(t testing.TB, name string, bits int, parent *x509.Certificate, parentKey *rsa.PrivateKey) (*x509.Certificate, *rsa.PrivateKey) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, bits)
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+	}
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatalf("unable to create certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("unable to parse certificate: %v", err)
+	}
+	return cert, key
+}
+
+// Tests that OVs signed by a vendor CA hierarchy are validated up to the trust anchor.
+func TestVerifyAndUnmarshalWithOptions(t *testing.T) {
+	root, rootKey := newCA(t, "Vendor Root CA", nil, nil)
+	intermediate, intermediateKey := newCA(t, "Vendor Intermediate CA", root, rootKey)
+	signer, signerKey := newCA(t, "Vendor OV Signer", intermediate, intermediateKey)
+	rogueRoot, rogueRootKey := newCA(t, "Rogue Root CA", nil, nil)
+	rogueIntermediate, rogueIntermediateKey := newCA(t, "Vendor Intermediate CA", rogueRoot, rogueRootKey)
+	rogueSigner, rogueSignerKey := newCA(t, "Vendor OV Signer", rogueIntermediate, rogueIntermediateKey)
+
+	withChain, err := NewWithChain(wantSerial, pdcPub, signer, signerKey, []*x509.Certificate{intermediate})
+	if err != nil {
+		t.Fatalf("NewWithChain err = %v, want nil", err)
+	}
+	withoutChain, err := NewWithChain(wantSerial, pdcPub, signer, signerKey, nil)
+	if err != nil {
+		t.Fatalf("NewWithChain err = %v, want nil", err)
+	}
+	// The rogue OV embeds its whole chain, including a self-signed root.
+	rogue, err := NewWithChain(wantSerial, pdcPub, rogueSigner, rogueSignerKey, []*x509.Certificate{rogueIntermediate, rogueRoot})
+	if err != nil {
+		t.Fatalf("NewWithChain err = %v, want nil", err)
+	}
+
+	pool := func(certs ...*x509.Certificate) *x509.CertPool {
+		p := x509.NewCertPool()
+		for _, c := range certs {
+			p.AddCert(c)
+		}
+		return p
+	}
+	tests := []struct {
+		desc    string
+		ov      []byte
+		opts    VerifyOptions
+		wantErr bool
+	}{{
+		desc: "Intermediate embedded in OV",
+		ov:   withChain,
+		opts: VerifyOptions{TrustAnchors: pool(root)},
+	}, {
+		desc: "Intermediate provided out of band",
+		ov:   withoutChain,
+		opts: VerifyOptions{TrustAnchors: pool(root), Intermediates: pool(intermediate)},
+	}, {
+		desc:    "Missing intermediate",
+		ov:      withoutChain,
+		opts:    VerifyOptions{TrustAnchors: pool(root)},
+		wantErr: true,
+	}, {
+		desc:    "Embedded chain bypasses trust anchor",
+		ov:      rogue,
+		opts:    VerifyOptions{TrustAnchors: pool(root)},
+		wantErr: true,
+	}, {
+		desc:    "No trust anchors",
+		ov:      withChain,
+		wantErr: true,
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			got, err := VerifyAndUnmarshalWithOptions(test.ov, test.opts)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("VerifyAndUnmarshalWithOptions() err = %v, want error %v", err, test.wantErr)
+			}
+			if err == nil && got.OV.SerialNumber != wantSerial {
+				t.Errorf("got serial = %v, want %v", got.OV.SerialNumber, wantSerial)
+			}
+		})
+	}
+}
+
+// Tests that OVs signed by a certificate revoked by the vendor CA are rejected.
+func TestVerifyRevokedSigner(t *testing.T) {
+	root, rootKey := newCA(t, "Vendor Root CA", nil, nil)
+	signer, signerKey := newCA(t, "Vendor OV Signer", root, rootKey)
+	otherSigner, otherSignerKey := newCA(t, "Other Vendor OV Signer", root, rootKey)
+	ov, err := NewWithChain(wantSerial, pdcPub, signer, signerKey, nil)
+	if err != nil {
+		t.Fatalf("NewWithChain err = %v, want nil", err)
+	}
+	otherOV, err := NewWithChain(wantSerial, pdcPub, otherSigner, otherSignerKey, nil)
+	if err != nil {
+		t.Fatalf("NewWithChain err = %v, want nil", err)
+	}
+	der, err := x509.CreateRevocationList(rand.Reader, &x509.RevocationList{
+		Number: big.NewInt(1),
+		RevokedCertificateEntries: []x509.RevocationListEntry{{
+			SerialNumber:   signer.SerialNumber,
+			RevocationTime: time.Now(),
+		}},
+	}, root, rootKey)
+	if err != nil {
+		t.Fatalf("unable to create CRL: %v", err)
+	}
+	crl, err := x509.ParseRevocationList(der)
+	if err != nil {
+		t.Fatalf("unable to parse CRL: %v", err)
+	}
+	anchors := x509.NewCertPool()
+	anchors.AddCert(root)
+
+	tests := []struct {
+		desc    string
+		ov      []byte
+		crl     *x509.RevocationList
+		wantErr error
+	}{{
+		desc: "No CRL",
+		ov:   ov,
+	}, {
+		desc:    "Revoked signer",
+		ov:      ov,
+		crl:     crl,
+		wantErr: ErrSignerRevoked,
+	}, {
+		desc: "Signer not revoked",
+		ov:   otherOV,
+		crl:  crl,
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			_, err := VerifyAndUnmarshalWithOptions(test.ov, VerifyOptions{
+				TrustAnchors:   anchors,
+				RevocationList: test.crl,
+			})
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("VerifyAndUnmarshalWithOptions() err = %v, want %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+// backdatedCodec verifies SignedData with cms.Default, reporting the given signing time.
+type backdatedCodec struct {
+	cms.Codec
+	signingTime time.Time
+}
+
+func (c backdatedCodec) Verify(der, detachedContent []byte) (*cms.SignedData, error) {
+	sd, err := c.Codec.Verify(der, detachedContent)
+	if err == nil {
+		sd.SigningTime = c.signingTime
+	}
+	return sd, err
+}
+
+// Tests that the signer chain is only validated at the signing time of the OV on request.
+func TestVerifyAtSigningTime(t *testing.T) {
+	rootKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+	// The root expired half an hour ago, but the OV claims to be signed before.
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: "Expired Vendor Root CA"},
+		NotBefore:             time.Now().Add(-3 * time.Hour),
+		NotAfter:              time.Now().Add(-30 * time.Minute),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &rootKey.PublicKey, rootKey)
+	if err != nil {
+		t.Fatalf("unable to create certificate: %v", err)
+	}
+	root, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("unable to parse certificate: %v", err)
+	}
+	signer, signerKey := newCA(t, "Vendor OV Signer", root, rootKey)
+	ov, err := NewWithChain(wantSerial, pdcPub, signer, signerKey, nil)
+	if err != nil {
+		t.Fatalf("NewWithChain err = %v, want nil", err)
+	}
+	defer func(c cms.Codec) { cms.Default = c }(cms.Default)
+	cms.Default = backdatedCodec{Codec: cms.Default, signingTime: time.Now().Add(-45 * time.Minute)}
+	anchors := x509.NewCertPool()
+	anchors.AddCert(root)
+
+	if _, err := VerifyAndUnmarshalWithOptions(ov, VerifyOptions{TrustAnchors: anchors}); err == nil {
+		t.Errorf("VerifyAndUnmarshalWithOptions() of an OV with an expired root err = nil, want error")
+	}
+	if _, err := VerifyAndUnmarshalWithOptions(ov, VerifyOptions{TrustAnchors: anchors, AtSigningTime: true}); err != nil {
+		t.Errorf("VerifyAndUnmarshalWithOptions() at the signing time err = %v, want nil", err)
+	}
+}
+
+// Tests that the pinned-domain-cert of a known good OV is compared with the expected PDC.
+func TestVerifyPinnedDomainCert(t *testing.T) {
+	decodedOV, err := base64.StdEncoding.DecodeString(testOV)
+	if err != nil {
+		t.Fatalf("unable to decode ownership voucher to bytes: %v", err)
+	}
+	vendorCAPool := x509.NewCertPool()
+	if !vendorCAPool.AppendCertsFromPEM(vendorCAPub) {
+		t.Fatalf("unable to add vendor root CA to pool")
+	}
+	ov, err := VerifyAndUnmarshal(decodedOV, vendorCAPool)
+	if err != nil {
+		t.Fatalf("VerifyAndUnmarshal err = %v, want nil", err)
+	}
+	parse := func(data []byte) *x509.Certificate {
+		block, _ := pem.Decode(data)
+		if block == nil {
+			t.Fatal("unable to decode certificate PEM")
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return cert
+	}
+	pdc := parse(pdcPub)
+	otherDomain := parse(vendorCAPub)
+	// Re-issue a certificate for the PDC public key with a different signer.
+	_, otherKey := newCA(t, "Other", nil, nil)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(42),
+		Subject:      pkix.Name{CommonName: "Re-issued PDC"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, pdc.PublicKey, otherKey)
+	if err != nil {
+		t.Fatalf("unable to create certificate: %v", err)
+	}
+	reissued, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		desc         string
+		pdc          *x509.Certificate
+		match        PDCMatch
+		wantMismatch bool
+	}{
+		{desc: "No comparison", pdc: otherDomain, match: PDCMatchNone},
+		{desc: "Exact match", pdc: pdc, match: PDCMatchExact},
+		{desc: "Exact mismatch", pdc: otherDomain, match: PDCMatchExact, wantMismatch: true},
+		{desc: "Exact mismatch on re-issued PDC", pdc: reissued, match: PDCMatchExact, wantMismatch: true},
+		{desc: "SPKI match", pdc: pdc, match: PDCMatchSPKI},
+		{desc: "SPKI match on re-issued PDC", pdc: reissued, match: PDCMatchSPKI},
+		{desc: "SPKI mismatch", pdc: otherDomain, match: PDCMatchSPKI, wantMismatch: true},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			err := ov.VerifyPinnedDomainCert(test.pdc, test.match)
+			if got := errors.Is(err, ErrPinnedDomainCertMismatch); got != test.wantMismatch {
+				t.Errorf("VerifyPinnedDomainCert() err = %v, want mismatch %v", err, test.wantMismatch)
+			}
+		})
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	want := time.Date(2023, 10, 24, 5, 19, 57, 0, time.UTC)
+	tests := []struct {
+		desc    string
+		in      string
+		want    time.Time
+		wantErr bool
+	}{{
+		desc: "RFC 3339",
+		in:   "2023-10-24T05:19:57Z",
+		want: want,
+	}, {
+		desc: "RFC 3339 with fractional seconds and offset",
+		in:   "2023-10-24T07:19:57.5+02:00",
+		want: want.Add(500 * time.Millisecond),
+	}, {
+		desc: "RFC 3339 in lowercase",
+		in:   "2023-10-24t05:19:57z",
+		want: want,
+	}, {
+		desc: "Offset without colon",
+		in:   "2023-10-24T07:19:57+0200",
+		want: want,
+	}, {
+		desc: "No time zone",
+		in:   "2023-10-24T05:19:57",
+		want: want,
+	}, {
+		desc: "Space separated",
+		in:   "2023-10-24 05:19:57Z",
+		want: want,
+	}, {
+		desc: "Go time.String",
+		in:   "2023-10-24 05:19:57.174393633 +0000 UTC m=+4.079786739",
+		want: want.Add(174393633 * time.Nanosecond),
+	}, {
+		desc:    "Date only",
+		in:      "2023-10-24",
+		wantErr: true,
+	}, {
+		desc:    "Garbage",
+		in:      "yesterday",
+		wantErr: true,
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			got, err := ParseTime(test.in)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("ParseTime(%q) err = %v, want error %v", test.in, err, test.wantErr)
+			}
+			if err == nil && !got.Equal(test.want) {
+				t.Errorf("ParseTime(%q) = %v, want %v", test.in, got, test.want)
+			}
+		})
+	}
+}
+
+// Tests that OVs with malformed timestamps are rejected.
+func TestVerifyTimestamps(t *testing.T) {
+	root, rootKey := newCA(t, "Vendor Root CA", nil, nil)
+	pool := x509.NewCertPool()
+	pool.AddCert(root)
+	tests := []struct {
+		desc      string
+		createdOn string
+		expiresOn string
+		wantErr   bool
+	}{{
+		desc:      "RFC 3339",
+		createdOn: "2023-10-24T05:19:57Z",
+		expiresOn: "2024-10-24T05:19:57Z",
+	}, {
+		desc:      "Go time.String",
+		createdOn: "2023-10-24 05:19:57.174393633 +0000 UTC m=+4.079786739",
+		expiresOn: "2024-10-23 05:19:57.174393633 +0000 UTC m=+31536004.079786739",
+	}, {
+		desc:      "No expiry",
+		createdOn: "2023-10-24T05:19:57Z",
+	}, {
+		desc:      "Malformed created-on",
+		createdOn: "yesterday",
+		wantErr:   true,
+	}, {
+		desc:      "Malformed expires-on",
+		createdOn: "2023-10-24T05:19:57Z",
+		expiresOn: "tomorrow",
+		wantErr:   true,
+	}, {
+		desc:      "Expires before created",
+		createdOn: "2023-10-24T05:19:57Z",
+		expiresOn: "2022-10-24T05:19:57Z",
+		wantErr:   true,
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			content, err := json.Marshal(OwnershipVoucher{OV: Inner{
+				CreatedOn:        test.createdOn,
+				ExpiresOn:        test.expiresOn,
+				SerialNumber:     wantSerial,
+				PinnedDomainCert: RemovePemHeaders(string(pdcPub)),
+			}})
+			if err != nil {
+				t.Fatalf("json.Marshal() err = %v", err)
+			}
+			ov, err := cms.Sign(content, []*x509.Certificate{root}, rootKey, false)
+			if err != nil {
+				t.Fatalf("cms.Sign() err = %v", err)
+			}
+			if _, err := VerifyAndUnmarshal(ov, pool); (err != nil) != test.wantErr {
+				t.Errorf("VerifyAndUnmarshal() err = %v, want error %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+// Tests the accessors of the signer chain and metadata of parsed and verified OVs.
+func TestAccessors(t *testing.T) {
+	root, rootKey := newCA(t, "Vendor Root CA", nil, nil)
+	intermediate, intermediateKey := newCA(t, "Vendor Intermediate CA", root, rootKey)
+	signer, signerKey := newCA(t, "Vendor OV Signer", intermediate, intermediateKey)
+	before := time.Now().Add(-time.Second)
+	in, err := NewWithChain(wantSerial, pdcPub, signer, signerKey, []*x509.Certificate{intermediate})
+	if err != nil {
+		t.Fatalf("NewWithChain err = %v, want nil", err)
+	}
+	pool := x509.NewCertPool()
+	pool.AddCert(root)
+
+	parsed, err := Parse(in)
+	if err != nil {
+		t.Fatalf("Parse() err = %v, want nil", err)
+	}
+	verified, err := VerifyAndUnmarshalWithOptions(in, VerifyOptions{TrustAnchors: pool})
+	if err != nil {
+		t.Fatalf("VerifyAndUnmarshalWithOptions() err = %v, want nil", err)
+	}
+	tests := []struct {
+		desc      string
+		ov        *OwnershipVoucher
+		wantChain []*x509.Certificate
+	}{{
+		desc:      "Parsed",
+		ov:        parsed,
+		wantChain: []*x509.Certificate{signer, intermediate},
+	}, {
+		desc:      "Verified",
+		ov:        verified,
+		wantChain: []*x509.Certificate{signer, intermediate, root},
+	}}
+	pdcBlock, _ := pem.Decode(pdcPub)
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			if got := test.ov.Signer(); got == nil || !got.Equal(signer) {
+				t.Errorf("Signer() = %v, want %v", got, signer.Subject)
+			}
+			if got := len(test.ov.Certificates()); got != 2 {
+				t.Errorf("Certificates() returned %d certificates, want 2", got)
+			}
+			chain := test.ov.SignerChain()
+			if len(chain) != len(test.wantChain) {
+				t.Fatalf("SignerChain() returned %d certificates, want %d", len(chain), len(test.wantChain))
+			}
+			for i, c := range chain {
+				if !c.Equal(test.wantChain[i]) {
+					t.Errorf("SignerChain()[%d] = %v, want %v", i, c.Subject, test.wantChain[i].Subject)
+				}
+			}
+			pdc, err := test.ov.PinnedDomainCertificate()
+			if err != nil || !bytes.Equal(pdc.Raw, pdcBlock.Bytes) {
+				t.Errorf("PinnedDomainCertificate() = %v, %v, want the PDC", pdc, err)
+			}
+			if got := test.ov.SigningTime(); got.Before(before.Truncate(time.Second)) {
+				t.Errorf("SigningTime() = %v, want after %v", got, before)
+			}
+			expires, err := test.ov.ExpiresOnTime()
+			if err != nil || expires.Before(before.Add(ovExpiry)) {
+				t.Errorf("ExpiresOnTime() = %v, %v, want after %v", expires, err, before.Add(ovExpiry))
+			}
+		})
+	}
+	if got := (&OwnershipVoucher{}).SignerChain(); got != nil {
+		t.Errorf("SignerChain() of an unsigned voucher = %v, want nil", got)
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	in, err := base64.StdEncoding.DecodeString(testOV)
+	if err != nil {
+		b.Fatalf("unable to decode ownership voucher to bytes: %v", err)
+	}
+	for i := 0; i < b.N; i++ {
+		if _, err := Parse(in); err != nil {
+			b.Fatalf("Parse() err = %v", err)
+		}
+	}
+}
+
+func BenchmarkVerifyAndUnmarshal(b *testing.B) {
+	for _, bits := range []int{2048, 3072, 4096} {
+		root, rootKey := newCAWithBits(b, "Vendor Root CA", bits, nil, nil)
+		intermediate, intermediateKey := newCAWithBits(b, "Vendor Intermediate CA", bits, root, rootKey)
+		pool := x509.NewCertPool()
+		pool.AddCert(root)
+		direct, err := New(wantSerial, pdcPub, root, rootKey)
+		if err != nil {
+			b.Fatalf("New() err = %v", err)
+		}
+		withChain, err := NewWithChain(wantSerial, pdcPub, intermediate, intermediateKey, []*x509.Certificate{root})
+		if err != nil {
+			b.Fatalf("NewWithChain() err = %v", err)
+		}
+		tests := []struct {
+			desc string
+			ov   []byte
+			opts VerifyOptions
+		}{{
+			desc: "Signed by root",
+			ov:   direct,
+			opts: VerifyOptions{TrustAnchors: pool},
+		}, {
+			desc: "Signed by intermediate",
+			ov:   withChain,
+			opts: VerifyOptions{TrustAnchors: pool},
+		}, {
+			desc: "Strict validation",
+			ov:   direct,
+			opts: VerifyOptions{TrustAnchors: pool, Validation: ValidationStrict},
+		}}
+		for _, test := range tests {
+			b.Run(fmt.Sprintf("RSA%d/%s", bits, test.desc), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					if _, err := VerifyAndUnmarshalWithOptions(test.ov, test.opts); err != nil {
+						b.Fatalf("VerifyAndUnmarshalWithOptions() err = %v", err)
+					}
+				}
+			})
+		}
+	}
+}
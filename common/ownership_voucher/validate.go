@@ -0,0 +1,257 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ownershipvoucher
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Validation selects how strictly the content of an Ownership Voucher is validated.
+type Validation int
+
+const (
+	// ValidationNone only checks that the timestamps of the voucher can be parsed.
+	ValidationNone Validation = iota
+	// ValidationStrict checks the leaves of the voucher with Validate.
+	ValidationStrict
+	// ValidationSchema additionally checks the voucher against the YANG schema of RFC 8366
+	// with ValidateSchema, rejecting unknown leaves.
+	ValidationSchema
+)
+
+// voucherContainer is the name of the voucher container in the JSON encoding of RFC 8366.
+const voucherContainer = "ietf-voucher:voucher"
+
+// assertions are the values of the assertion enumeration of RFC 8366.
+var assertions = map[string]bool{"verified": true, "logged": true, "proximity": true}
+
+// leafType is the YANG type of a leaf of the voucher container.
+type leafType int
+
+const (
+	leafString leafType = iota
+	leafDateAndTime
+	leafBinary
+	leafBoolean
+	leafAssertion
+)
+
+// schema maps the leaves of the voucher container of RFC 8366 to their type.
+var schema = map[string]leafType{
+	"created-on":                    leafDateAndTime,
+	"expires-on":                    leafDateAndTime,
+	"assertion":                     leafAssertion,
+	"serial-number":                 leafString,
+	"idevid-issuer":                 leafBinary,
+	"pinned-domain-cert":            leafBinary,
+	"domain-cert-revocation-checks": leafBoolean,
+	"nonce":                         leafBinary,
+	"last-renewal-date":             leafDateAndTime,
+}
+
+// FieldError is a problem with a leaf of an Ownership Voucher.
+type FieldError struct {
+	// Field is the name of the leaf.
+	Field string
+	// Reason describes the problem.
+	Reason string
+}
+
+// Error implements the error interface.
+func (e *FieldError) Error() string {
+	return e.Field + ": " + e.Reason
+}
+
+// ValidationError lists the problems found when validating an Ownership Voucher.
+type ValidationError struct {
+	Fields []*FieldError
+}
+
+// Error implements the error interface.
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		msgs[i] = f.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// add records a problem with a field.
+func (e *ValidationError) add(field, format string, args ...any) {
+	e.Fields = append(e.Fields, &FieldError{Field: field, Reason: fmt.Sprintf(format, args...)})
+}
+
+// err returns e, or nil if no problem was recorded.
+func (e *ValidationError) err() error {
+	if len(e.Fields) == 0 {
+		return nil
+	}
+	return e
+}
+
+// Validate checks that the mandatory leaves of the Ownership Voucher are set, that its assertion
+// is one of those of RFC 8366, that its serial number is printable ASCII without whitespace, that
+// its timestamps are RFC 3339, with expires-on not before created-on, and that its
+// pinned-domain-cert is a certificate. All problems are returned at once in a *ValidationError.
+func (ov *OwnershipVoucher) Validate() error {
+	verr := &ValidationError{}
+	checkTime := func(field, value string, mandatory bool) time.Time {
+		if value == "" {
+			if mandatory {
+				verr.add(field, "mandatory leaf is missing")
+			}
+			return time.Time{}
+		}
+		t, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			verr.add(field, "%q is not an RFC 3339 timestamp", value)
+		}
+		return t
+	}
+	created := checkTime("created-on", ov.OV.CreatedOn, true)
+	expires := checkTime("expires-on", ov.OV.ExpiresOn, false)
+	if !created.IsZero() && !expires.IsZero() && expires.Before(created) {
+		verr.add("expires-on", "%v is before created-on %v", formatTime(expires), formatTime(created))
+	}
+	switch a := ov.OV.Assertion; {
+	case a == "":
+		verr.add("assertion", "mandatory leaf is missing")
+	case !assertions[a]:
+		verr.add("assertion", "unknown assertion %q, want verified, logged or proximity", a)
+	}
+	if s := ov.OV.SerialNumber; s == "" {
+		verr.add("serial-number", "mandatory leaf is missing")
+	} else if i := strings.IndexFunc(s, func(r rune) bool { return r <= ' ' || r > '~' }); i >= 0 {
+		verr.add("serial-number", "%q has a whitespace, control or non-ASCII character at offset %d", s, i)
+	}
+	if ov.OV.PinnedDomainCert == "" {
+		verr.add("pinned-domain-cert", "mandatory leaf is missing")
+	} else if _, err := ov.PinnedDomainCertificate(); err != nil {
+		verr.add("pinned-domain-cert", "%v", err)
+	}
+	return verr.err()
+}
+
+// ValidateSchema checks the JSON encoded content of an Ownership Voucher against the YANG schema
+// of RFC 8366: the voucher container must be the only top-level member, its leaves must be known
+// and their values must match the type of the leaf. All problems are returned at once in a
+// *ValidationError.
+func ValidateSchema(content []byte) error {
+	verr := &ValidationError{}
+	var top map[string]json.RawMessage
+	if err := json.Unmarshal(content, &top); err != nil {
+		verr.add(voucherContainer, "content is not a JSON object: %v", err)
+		return verr
+	}
+	for _, name := range sortedKeys(top) {
+		if name != voucherContainer {
+			verr.add(name, "unknown top-level member")
+		}
+	}
+	raw, ok := top[voucherContainer]
+	if !ok {
+		verr.add(voucherContainer, "voucher container is missing")
+		return verr
+	}
+	var leaves map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &leaves); err != nil {
+		verr.add(voucherContainer, "voucher container is not a JSON object: %v", err)
+		return verr
+	}
+	for _, name := range sortedKeys(leaves) {
+		typ, ok := schema[name]
+		if !ok {
+			verr.add(name, "unknown leaf")
+			continue
+		}
+		if typ == leafBoolean {
+			var b bool
+			if err := json.Unmarshal(leaves[name], &b); err != nil {
+				verr.add(name, "%s is not a boolean", leaves[name])
+			}
+			continue
+		}
+		var s string
+		if err := json.Unmarshal(leaves[name], &s); err != nil {
+			verr.add(name, "%s is not a string", leaves[name])
+			continue
+		}
+		switch typ {
+		case leafDateAndTime:
+			if _, err := time.Parse(time.RFC3339Nano, s); err != nil {
+				verr.add(name, "%q is not a date-and-time", s)
+			}
+		case leafBinary:
+			if _, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(s), "")); err != nil {
+				verr.add(name, "value is not base64 encoded binary: %v", err)
+			}
+		case leafAssertion:
+			if !assertions[s] {
+				verr.add(name, "unknown assertion %q, want verified, logged or proximity", s)
+			}
+		}
+	}
+	if _, ok := leaves["last-renewal-date"]; ok {
+		if _, ok := leaves["expires-on"]; !ok {
+			verr.add("last-renewal-date", "requires expires-on to be set")
+		}
+	}
+	return verr.err()
+}
+
+// validate checks the voucher and its JSON encoded content according to the validation level.
+func (ov *OwnershipVoucher) validate(content []byte, level Validation) error {
+	switch level {
+	case ValidationNone:
+		return ov.checkTimestamps()
+	case ValidationStrict:
+		return ov.Validate()
+	case ValidationSchema:
+		// Leaves of the wrong type are reported once, by the schema.
+		verr := &ValidationError{}
+		reported := map[string]bool{}
+		for _, err := range []error{ValidateSchema(content), ov.Validate()} {
+			e, ok := err.(*ValidationError)
+			if !ok {
+				continue
+			}
+			for _, f := range e.Fields {
+				if !reported[f.Field] {
+					verr.Fields = append(verr.Fields, f)
+				}
+			}
+			for _, f := range e.Fields {
+				reported[f.Field] = true
+			}
+		}
+		return verr.err()
+	}
+	return fmt.Errorf("unknown validation level %d", level)
+}
+
+// sortedKeys returns the keys of m in order, so problems are reported deterministically.
+func sortedKeys(m map[string]json.RawMessage) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
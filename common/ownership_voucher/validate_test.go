@@ -0,0 +1,195 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ownershipvoucher
+
+import (
+	"crypto/x509"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/bootz/common/cms"
+)
+
+// validInner returns the leaves of a voucher passing strict validation.
+func validInner() Inner {
+	return Inner{
+		CreatedOn:        "2023-10-24T05:19:57Z",
+		ExpiresOn:        "2024-10-24T05:19:57Z",
+		Assertion:        "verified",
+		SerialNumber:     wantSerial,
+		PinnedDomainCert: RemovePemHeaders(string(pdcPub)),
+	}
+}
+
+// fields returns the fields of the problems of a *ValidationError.
+func fields(t *testing.T, err error) []string {
+	t.Helper()
+	if err == nil {
+		return nil
+	}
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("err = %v, want a *ValidationError", err)
+	}
+	var got []string
+	for _, f := range verr.Fields {
+		got = append(got, f.Field)
+	}
+	return got
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		desc   string
+		modify func(*Inner)
+		want   []string
+	}{{
+		desc:   "Valid",
+		modify: func(*Inner) {},
+	}, {
+		desc:   "Missing mandatory leaves",
+		modify: func(in *Inner) { *in = Inner{} },
+		want:   []string{"created-on", "assertion", "serial-number", "pinned-domain-cert"},
+	}, {
+		desc:   "Unknown assertion",
+		modify: func(in *Inner) { in.Assertion = "trusted" },
+		want:   []string{"assertion"},
+	}, {
+		desc:   "Serial number with whitespace",
+		modify: func(in *Inner) { in.SerialNumber = "123 A" },
+		want:   []string{"serial-number"},
+	}, {
+		desc: "Timestamps in Go format",
+		modify: func(in *Inner) {
+			in.CreatedOn = "2023-10-24 05:19:57.174393633 +0000 UTC"
+			in.ExpiresOn = "2024-10-24 05:19:57.174393633 +0000 UTC"
+		},
+		want: []string{"created-on", "expires-on"},
+	}, {
+		desc:   "Expires before created",
+		modify: func(in *Inner) { in.ExpiresOn = "2022-10-24T05:19:57Z" },
+		want:   []string{"expires-on"},
+	}, {
+		desc:   "Pinned domain cert is not a certificate",
+		modify: func(in *Inner) { in.PinnedDomainCert = "bm90IGEgY2VydA==" },
+		want:   []string{"pinned-domain-cert"},
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			ov := &OwnershipVoucher{OV: validInner()}
+			test.modify(&ov.OV)
+			got := fields(t, ov.Validate())
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("Validate() fields with problems diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateSchema(t *testing.T) {
+	tests := []struct {
+		desc    string
+		content string
+		want    []string
+	}{{
+		desc:    "Valid",
+		content: `{"ietf-voucher:voucher": {"created-on": "2023-10-24T05:19:57Z", "assertion": "logged", "serial-number": "123A", "pinned-domain-cert": "AAAA", "domain-cert-revocation-checks": false, "nonce": "AAAAAAAAAAA="}}`,
+	}, {
+		desc:    "Unknown leaf and top-level member",
+		content: `{"ietf-voucher:voucher": {"serial-number": "123A", "owner": "me"}, "extra": 1}`,
+		want:    []string{"extra", "owner"},
+	}, {
+		desc:    "Leaves of the wrong type",
+		content: `{"ietf-voucher:voucher": {"created-on": "yesterday", "assertion": "trusted", "serial-number": 123, "pinned-domain-cert": "!!", "domain-cert-revocation-checks": "yes"}}`,
+		want:    []string{"assertion", "created-on", "domain-cert-revocation-checks", "pinned-domain-cert", "serial-number"},
+	}, {
+		desc:    "Last renewal date without expiry",
+		content: `{"ietf-voucher:voucher": {"last-renewal-date": "2023-10-24T05:19:57Z"}}`,
+		want:    []string{"last-renewal-date"},
+	}, {
+		desc:    "Missing voucher container",
+		content: `{}`,
+		want:    []string{"ietf-voucher:voucher"},
+	}, {
+		desc:    "Not JSON",
+		content: `voucher`,
+		want:    []string{"ietf-voucher:voucher"},
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			got := fields(t, ValidateSchema([]byte(test.content)))
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("ValidateSchema() fields with problems diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// Tests that OVs are validated according to the validation level when verified.
+func TestVerifyValidation(t *testing.T) {
+	root, rootKey := newCA(t, "Vendor Root CA", nil, nil)
+	pool := x509.NewCertPool()
+	pool.AddCert(root)
+	sign := func(content string) []byte {
+		ov, err := cms.Sign([]byte(content), []*x509.Certificate{root}, rootKey, false)
+		if err != nil {
+			t.Fatalf("cms.Sign() err = %v", err)
+		}
+		return ov
+	}
+	pdc := strings.Join(strings.Fields(RemovePemHeaders(string(pdcPub))), "")
+	// The legacy voucher has no assertion and Go formatted timestamps, as vouchers made by New did.
+	legacy := sign(`{"ietf-voucher:voucher": {"created-on": "2023-10-24 05:19:57 +0000 UTC", "serial-number": "123A", "pinned-domain-cert": "` + pdc + `"}}`)
+	extraLeaf := sign(`{"ietf-voucher:voucher": {"created-on": "2023-10-24T05:19:57Z", "assertion": "verified", "serial-number": "123A", "pinned-domain-cert": "` + pdc + `", "owner": "me"}}`)
+	tests := []struct {
+		desc       string
+		ov         []byte
+		validation Validation
+		want       []string
+	}{{
+		desc: "Legacy voucher without validation",
+		ov:   legacy,
+	}, {
+		desc:       "Legacy voucher with strict validation",
+		ov:         legacy,
+		validation: ValidationStrict,
+		want:       []string{"created-on", "assertion"},
+	}, {
+		desc:       "Unknown leaf with strict validation",
+		ov:         extraLeaf,
+		validation: ValidationStrict,
+	}, {
+		desc:       "Unknown leaf with schema validation",
+		ov:         extraLeaf,
+		validation: ValidationSchema,
+		want:       []string{"owner"},
+	}, {
+		desc:       "Legacy voucher with schema validation",
+		ov:         legacy,
+		validation: ValidationSchema,
+		want:       []string{"created-on", "assertion"},
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			_, err := VerifyAndUnmarshalWithOptions(test.ov, VerifyOptions{TrustAnchors: pool, Validation: test.validation})
+			got := fields(t, err)
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("VerifyAndUnmarshalWithOptions() fields with problems diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -16,49 +16,187 @@
 package signature
 
 import (
+	"bytes"
 	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
 	"crypto/rsa"
 	"crypto/sha256"
 	"crypto/x509"
 	"encoding/base64"
 	"fmt"
+
+	"github.com/openconfig/bootz/common/cms"
+	"github.com/openconfig/bootz/common/fips"
+
+	bpb "github.com/openconfig/bootz/proto/bootz"
 )
 
-// Sign generates a base64-encoded signature of the input data using the provided private key.
-// The private key must be RSA.
+// Algorithms returns the signature algorithms a public key supports, its default algorithm first.
+// Ed25519 keys support none in FIPS mode, as boringcrypto does not implement Ed25519.
+func Algorithms(pub crypto.PublicKey) []bpb.SignatureAlgorithm {
+	switch pub.(type) {
+	case *rsa.PublicKey:
+		return []bpb.SignatureAlgorithm{bpb.SignatureAlgorithm_SIGNATURE_ALGORITHM_RSA_PKCS1V15_SHA256, bpb.SignatureAlgorithm_SIGNATURE_ALGORITHM_RSA_PSS_SHA256, bpb.SignatureAlgorithm_SIGNATURE_ALGORITHM_CMS_DETACHED}
+	case *ecdsa.PublicKey:
+		return []bpb.SignatureAlgorithm{bpb.SignatureAlgorithm_SIGNATURE_ALGORITHM_ECDSA_SHA256, bpb.SignatureAlgorithm_SIGNATURE_ALGORITHM_CMS_DETACHED}
+	case ed25519.PublicKey:
+		if fips.Enabled() {
+			return nil
+		}
+		return []bpb.SignatureAlgorithm{bpb.SignatureAlgorithm_SIGNATURE_ALGORITHM_ED25519}
+	}
+	return nil
+}
+
+// Negotiate returns the first of the accepted algorithms the private key supports, or its default
+// algorithm if no algorithms are listed.
+func Negotiate(signer crypto.Signer, accepted []bpb.SignatureAlgorithm) (bpb.SignatureAlgorithm, error) {
+	supported := Algorithms(signer.Public())
+	if len(supported) == 0 {
+		return bpb.SignatureAlgorithm_SIGNATURE_ALGORITHM_UNSPECIFIED, fmt.Errorf("Negotiate(): unsupported public key type: %T", signer.Public())
+	}
+	if len(accepted) == 0 {
+		return supported[0], nil
+	}
+	for _, a := range accepted {
+		for _, s := range supported {
+			if a == s {
+				return a, nil
+			}
+		}
+	}
+	return bpb.SignatureAlgorithm_SIGNATURE_ALGORITHM_UNSPECIFIED, fmt.Errorf("Negotiate(): %T keys support none of the accepted algorithms %v", signer, accepted)
+}
+
+// resolve returns alg, or the default algorithm of the public key if alg is unspecified. It returns
+// an error if the key does not support the algorithm.
+func resolve(pub crypto.PublicKey, alg bpb.SignatureAlgorithm) (bpb.SignatureAlgorithm, error) {
+	supported := Algorithms(pub)
+	if len(supported) == 0 {
+		return alg, fmt.Errorf("unsupported public key type: %T", pub)
+	}
+	if alg == bpb.SignatureAlgorithm_SIGNATURE_ALGORITHM_UNSPECIFIED {
+		return supported[0], nil
+	}
+	for _, s := range supported {
+		if alg == s {
+			return alg, nil
+		}
+	}
+	return alg, fmt.Errorf("%T keys do not support %v", pub, alg)
+}
+
+// Sign generates a base64-encoded signature of the input data using the provided private key,
+// with the default algorithm of the key. See SignWithAlgorithm.
 func Sign(privateKey crypto.PrivateKey, input []byte) (string, error) {
+	return SignWithAlgorithm(privateKey, input, bpb.SignatureAlgorithm_SIGNATURE_ALGORITHM_UNSPECIFIED)
+}
+
+// SignWithAlgorithm generates a base64-encoded signature of the input data using the provided
+// private key and algorithm, or the default algorithm of the key if alg is unspecified. The
+// private key must be an RSA, ECDSA or Ed25519 key implementing crypto.Signer, such as an
+// *rsa.PrivateKey or a key held by a KMS or HSM, in which case the key material never leaves
+// the key store.
+func SignWithAlgorithm(privateKey crypto.PrivateKey, input []byte, alg bpb.SignatureAlgorithm) (string, error) {
+	signer, ok := privateKey.(crypto.Signer)
+	if !ok {
+		return "", fmt.Errorf("Sign(): unsupported private key type: %T", privateKey)
+	}
+	alg, err := resolve(signer.Public(), alg)
+	if err != nil {
+		return "", fmt.Errorf("Sign(): %v", err)
+	}
 	hashed := sha256.Sum256(input)
 	var sig []byte
-	var err error
-	switch priv := privateKey.(type) {
-	case *rsa.PrivateKey:
-		sig, err = rsa.SignPKCS1v15(nil, priv, crypto.SHA256, hashed[:])
-		if err != nil {
-			return "", fmt.Errorf("Sign(): unable to sign signature: %w", err)
-		}
+	switch alg {
+	case bpb.SignatureAlgorithm_SIGNATURE_ALGORITHM_CMS_DETACHED:
+		return "", fmt.Errorf("Sign(): CMS signatures embed the certificate chain of the key, use SignDetached")
+	case bpb.SignatureAlgorithm_SIGNATURE_ALGORITHM_RSA_PSS_SHA256:
+		sig, err = signer.Sign(rand.Reader, hashed[:], &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash, Hash: crypto.SHA256})
+	case bpb.SignatureAlgorithm_SIGNATURE_ALGORITHM_ED25519:
+		// Ed25519 signs the message itself rather than its digest.
+		sig, err = signer.Sign(rand.Reader, input, crypto.Hash(0))
 	default:
-		return "", fmt.Errorf("Verify(): unsupported private key type: %T", priv)
+		// RSA signers produce PKCS #1 v1.5 signatures unless PSS options are passed, and ECDSA
+		// signers ASN.1 DER encoded signatures.
+		sig, err = signer.Sign(rand.Reader, hashed[:], crypto.SHA256)
+	}
+	if err != nil {
+		return "", fmt.Errorf("Sign(): unable to sign signature: %w", err)
 	}
+	return base64.StdEncoding.EncodeToString(sig), nil
+}
 
+// SignDetached generates a base64-encoded detached CMS signature of the input data using the
+// provided RSA or ECDSA private key, which may be held by a KMS or HSM. The signature embeds the
+// certificate chain of the key, starting with its certificate followed by its intermediates. Only
+// the SHA-256 digest of the data is signed, so the signature does not grow with the data.
+func SignDetached(chain []*x509.Certificate, privateKey crypto.PrivateKey, input []byte) (string, error) {
+	if len(chain) == 0 {
+		return "", fmt.Errorf("SignDetached(): no certificate provided")
+	}
+	sig, err := cms.Sign(input, chain, privateKey, true)
+	if err != nil {
+		return "", fmt.Errorf("SignDetached(): unable to sign signature: %w", err)
+	}
 	return base64.StdEncoding.EncodeToString(sig), nil
 }
 
-// Verify verifies a base64-encoded signature of the input data using the provided certificate.
-// The certificate's public key must be RSA.
+// verifyDetached verifies a DER encoded detached CMS signature of the input data, which must be
+// signed by cert.
+func verifyDetached(cert *x509.Certificate, input, sig []byte) error {
+	sd, err := cms.Verify(sig, input)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(sd.Content, input) {
+		return fmt.Errorf("CMS signature embeds different content")
+	}
+	if !sd.Signer.Equal(cert) {
+		return fmt.Errorf("CMS signature is not signed by %q", cert.Subject.CommonName)
+	}
+	return nil
+}
+
+// Verify verifies a base64-encoded signature of the input data using the provided certificate,
+// with the default algorithm of its public key. See VerifyWithAlgorithm.
 func Verify(cert *x509.Certificate, input []byte, signature string) error {
+	return VerifyWithAlgorithm(cert, input, signature, bpb.SignatureAlgorithm_SIGNATURE_ALGORITHM_UNSPECIFIED)
+}
+
+// VerifyWithAlgorithm verifies a base64-encoded signature of the input data using the provided
+// certificate and algorithm, or the default algorithm of its public key if alg is unspecified.
+// The certificate's public key must be RSA, ECDSA or Ed25519.
+func VerifyWithAlgorithm(cert *x509.Certificate, input []byte, signature string, alg bpb.SignatureAlgorithm) error {
 	decodedSig, err := base64.StdEncoding.DecodeString(signature)
 	if err != nil {
 		return fmt.Errorf("Verify(): unable to base64 decode: %w", err)
 	}
+	alg, err = resolve(cert.PublicKey, alg)
+	if err != nil {
+		return fmt.Errorf("Verify(): %v", err)
+	}
 	hashed := sha256.Sum256(input)
-	switch pub := cert.PublicKey.(type) {
-	case *rsa.PublicKey:
-		err = rsa.VerifyPKCS1v15(pub, crypto.SHA256, hashed[:], decodedSig)
-		if err != nil {
-			return fmt.Errorf("Verify(): signature not verified: %w", err)
+	switch alg {
+	case bpb.SignatureAlgorithm_SIGNATURE_ALGORITHM_CMS_DETACHED:
+		err = verifyDetached(cert, input, decodedSig)
+	case bpb.SignatureAlgorithm_SIGNATURE_ALGORITHM_RSA_PKCS1V15_SHA256:
+		err = rsa.VerifyPKCS1v15(cert.PublicKey.(*rsa.PublicKey), crypto.SHA256, hashed[:], decodedSig)
+	case bpb.SignatureAlgorithm_SIGNATURE_ALGORITHM_RSA_PSS_SHA256:
+		err = rsa.VerifyPSS(cert.PublicKey.(*rsa.PublicKey), crypto.SHA256, hashed[:], decodedSig, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+	case bpb.SignatureAlgorithm_SIGNATURE_ALGORITHM_ECDSA_SHA256:
+		if !ecdsa.VerifyASN1(cert.PublicKey.(*ecdsa.PublicKey), hashed[:], decodedSig) {
+			err = fmt.Errorf("invalid ECDSA signature")
 		}
-	default:
-		return fmt.Errorf("Verify(): unsupported public key type: %T", pub)
+	case bpb.SignatureAlgorithm_SIGNATURE_ALGORITHM_ED25519:
+		if !ed25519.Verify(cert.PublicKey.(ed25519.PublicKey), input, decodedSig) {
+			err = fmt.Errorf("invalid Ed25519 signature")
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("Verify(): signature not verified: %w", err)
 	}
 	return nil
 }
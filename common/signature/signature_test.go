@@ -15,11 +15,21 @@
 package signature
 
 import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
+	"fmt"
+	"io"
 	"math/big"
+	"strings"
 	"testing"
+	"time"
+
+	bpb "github.com/openconfig/bootz/proto/bootz"
 )
 
 func keyPair(t *testing.T) (certificate *x509.Certificate, privateKey *rsa.PrivateKey) {
@@ -28,11 +38,19 @@ func keyPair(t *testing.T) (certificate *x509.Certificate, privateKey *rsa.Priva
 	if err != nil {
 		t.Fatalf("unable to create RSA private key: %v", err)
 	}
+	return selfSigned(t, privateKey), privateKey
+}
+
+// selfSigned returns a self-signed certificate of the key.
+func selfSigned(t testing.TB, key crypto.Signer) *x509.Certificate {
+	t.Helper()
 	template := x509.Certificate{
 		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
 	}
 
-	certDER, err := x509.CreateCertificate(rand.Reader, &template, &template, &privateKey.PublicKey, privateKey)
+	certDER, err := x509.CreateCertificate(rand.Reader, &template, &template, key.Public(), key)
 	if err != nil {
 		t.Fatalf("unable to create x509 certificate: %v", err)
 	}
@@ -40,7 +58,7 @@ func keyPair(t *testing.T) (certificate *x509.Certificate, privateKey *rsa.Priva
 	if err != nil {
 		t.Fatalf("unable to parse DER certificate: %v", err)
 	}
-	return cert, privateKey
+	return cert
 }
 
 func TestCreateAndVerify(t *testing.T) {
@@ -58,3 +76,336 @@ func TestCreateAndVerify(t *testing.T) {
 		t.Errorf("unable to verify signature: %v", err)
 	}
 }
+
+// opaqueSigner hides the private key behind crypto.Signer, as KMS and HSM backed keys do.
+type opaqueSigner struct {
+	key *rsa.PrivateKey
+}
+
+func (s opaqueSigner) Public() crypto.PublicKey {
+	return s.key.Public()
+}
+
+func (s opaqueSigner) Sign(rand io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	return s.key.Sign(rand, digest, opts)
+}
+
+func TestSignWithSigner(t *testing.T) {
+	cert, privateKey := keyPair(t)
+	input := []byte("input_data")
+	tests := []struct {
+		desc    string
+		key     crypto.PrivateKey
+		wantErr bool
+	}{{
+		desc: "Opaque RSA signer",
+		key:  opaqueSigner{key: privateKey},
+	}, {
+		desc:    "Not a signer",
+		key:     "private key",
+		wantErr: true,
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			sig, err := Sign(test.key, input)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("Sign() err = %v, want error %v", err, test.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if err := Verify(cert, input, sig); err != nil {
+				t.Errorf("Verify() err = %v, want nil", err)
+			}
+		})
+	}
+}
+
+func TestSignWithAlgorithm(t *testing.T) {
+	rsaCert, rsaKey := keyPair(t)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unable to create ECDSA private key: %v", err)
+	}
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("unable to create Ed25519 private key: %v", err)
+	}
+	ecCert := selfSigned(t, ecKey)
+	edCert := selfSigned(t, edKey)
+	input := []byte("input_data")
+	tests := []struct {
+		desc    string
+		key     crypto.Signer
+		cert    *x509.Certificate
+		alg     bpb.SignatureAlgorithm
+		wantErr bool
+	}{{
+		desc: "RSA PKCS #1 v1.5",
+		key:  rsaKey,
+		cert: rsaCert,
+		alg:  bpb.SignatureAlgorithm_SIGNATURE_ALGORITHM_RSA_PKCS1V15_SHA256,
+	}, {
+		desc: "RSA PSS",
+		key:  opaqueSigner{key: rsaKey},
+		cert: rsaCert,
+		alg:  bpb.SignatureAlgorithm_SIGNATURE_ALGORITHM_RSA_PSS_SHA256,
+	}, {
+		desc: "ECDSA",
+		key:  ecKey,
+		cert: ecCert,
+		alg:  bpb.SignatureAlgorithm_SIGNATURE_ALGORITHM_ECDSA_SHA256,
+	}, {
+		desc: "Default ECDSA algorithm",
+		key:  ecKey,
+		cert: ecCert,
+	}, {
+		desc: "Ed25519",
+		key:  edKey,
+		cert: edCert,
+		alg:  bpb.SignatureAlgorithm_SIGNATURE_ALGORITHM_ED25519,
+	}, {
+		desc:    "Algorithm of another key type",
+		key:     rsaKey,
+		cert:    rsaCert,
+		alg:     bpb.SignatureAlgorithm_SIGNATURE_ALGORITHM_ECDSA_SHA256,
+		wantErr: true,
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			sig, err := SignWithAlgorithm(test.key, input, test.alg)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("SignWithAlgorithm() err = %v, want error %v", err, test.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if err := VerifyWithAlgorithm(test.cert, input, sig, test.alg); err != nil {
+				t.Errorf("VerifyWithAlgorithm() err = %v, want nil", err)
+			}
+			if err := VerifyWithAlgorithm(test.cert, []byte("other_data"), sig, test.alg); err == nil {
+				t.Errorf("VerifyWithAlgorithm() of other data err = nil, want error")
+			}
+		})
+	}
+	// PSS signatures do not verify as PKCS #1 v1.5 signatures.
+	sig, err := SignWithAlgorithm(rsaKey, input, bpb.SignatureAlgorithm_SIGNATURE_ALGORITHM_RSA_PSS_SHA256)
+	if err != nil {
+		t.Fatalf("SignWithAlgorithm() err = %v, want nil", err)
+	}
+	if err := Verify(rsaCert, input, sig); err == nil {
+		t.Errorf("Verify() of a PSS signature err = nil, want error")
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	_, rsaKey := keyPair(t)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unable to create ECDSA private key: %v", err)
+	}
+	tests := []struct {
+		desc     string
+		signer   crypto.Signer
+		accepted []bpb.SignatureAlgorithm
+		want     bpb.SignatureAlgorithm
+		wantErr  bool
+	}{{
+		desc:   "Default RSA algorithm",
+		signer: rsaKey,
+		want:   bpb.SignatureAlgorithm_SIGNATURE_ALGORITHM_RSA_PKCS1V15_SHA256,
+	}, {
+		desc:   "First supported algorithm",
+		signer: rsaKey,
+		accepted: []bpb.SignatureAlgorithm{
+			bpb.SignatureAlgorithm_SIGNATURE_ALGORITHM_ED25519,
+			bpb.SignatureAlgorithm_SIGNATURE_ALGORITHM_RSA_PSS_SHA256,
+			bpb.SignatureAlgorithm_SIGNATURE_ALGORITHM_RSA_PKCS1V15_SHA256,
+		},
+		want: bpb.SignatureAlgorithm_SIGNATURE_ALGORITHM_RSA_PSS_SHA256,
+	}, {
+		desc:   "Default ECDSA algorithm",
+		signer: ecKey,
+		want:   bpb.SignatureAlgorithm_SIGNATURE_ALGORITHM_ECDSA_SHA256,
+	}, {
+		desc:     "No supported algorithm",
+		signer:   ecKey,
+		accepted: []bpb.SignatureAlgorithm{bpb.SignatureAlgorithm_SIGNATURE_ALGORITHM_RSA_PSS_SHA256},
+		wantErr:  true,
+	}, {
+		desc:     "Detached CMS",
+		signer:   ecKey,
+		accepted: []bpb.SignatureAlgorithm{bpb.SignatureAlgorithm_SIGNATURE_ALGORITHM_CMS_DETACHED},
+		want:     bpb.SignatureAlgorithm_SIGNATURE_ALGORITHM_CMS_DETACHED,
+	}, {
+		desc:     "Detached CMS with an opaque signer",
+		signer:   opaqueSigner{key: rsaKey},
+		accepted: []bpb.SignatureAlgorithm{bpb.SignatureAlgorithm_SIGNATURE_ALGORITHM_CMS_DETACHED, bpb.SignatureAlgorithm_SIGNATURE_ALGORITHM_RSA_PSS_SHA256},
+		want:     bpb.SignatureAlgorithm_SIGNATURE_ALGORITHM_CMS_DETACHED,
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			got, err := Negotiate(test.signer, test.accepted)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("Negotiate() err = %v, want error %v", err, test.wantErr)
+			}
+			if got != test.want {
+				t.Errorf("Negotiate() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestSignDetached(t *testing.T) {
+	rsaCert, rsaKey := keyPair(t)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unable to create ECDSA private key: %v", err)
+	}
+	ecCert := selfSigned(t, ecKey)
+	input := []byte("input_data")
+	cms := bpb.SignatureAlgorithm_SIGNATURE_ALGORITHM_CMS_DETACHED
+	tests := []struct {
+		desc    string
+		key     crypto.PrivateKey
+		cert    *x509.Certificate
+		wantErr bool
+	}{{
+		desc: "RSA key",
+		key:  rsaKey,
+		cert: rsaCert,
+	}, {
+		desc: "ECDSA key",
+		key:  ecKey,
+		cert: ecCert,
+	}, {
+		desc: "Opaque signer",
+		key:  opaqueSigner{key: rsaKey},
+		cert: rsaCert,
+	}, {
+		desc:    "Not a signer",
+		key:     "private key",
+		cert:    rsaCert,
+		wantErr: true,
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			sig, err := SignDetached([]*x509.Certificate{test.cert}, test.key, input)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("SignDetached() err = %v, want error %v", err, test.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if err := VerifyWithAlgorithm(test.cert, input, sig, cms); err != nil {
+				t.Errorf("VerifyWithAlgorithm() err = %v, want nil", err)
+			}
+			if err := VerifyWithAlgorithm(test.cert, []byte("other_data"), sig, cms); err == nil {
+				t.Errorf("VerifyWithAlgorithm() of other data err = nil, want error")
+			}
+		})
+	}
+
+	// The signature must be made by the expected certificate, not only by the one it embeds.
+	sig, err := SignDetached([]*x509.Certificate{ecCert}, ecKey, input)
+	if err != nil {
+		t.Fatalf("SignDetached() err = %v, want nil", err)
+	}
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unable to create ECDSA private key: %v", err)
+	}
+	if err := VerifyWithAlgorithm(selfSigned(t, otherKey), input, sig, cms); err == nil {
+		t.Errorf("VerifyWithAlgorithm() with another certificate err = nil, want error")
+	}
+	if _, err := SignWithAlgorithm(ecKey, input, cms); err == nil {
+		t.Errorf("SignWithAlgorithm() of a CMS signature err = nil, want error")
+	}
+}
+
+// benchmarkKey is a key signing with an algorithm in the benchmarks.
+type benchmarkKey struct {
+	desc string
+	key  crypto.Signer
+	cert *x509.Certificate
+	alg  bpb.SignatureAlgorithm
+}
+
+// sign signs the input with the key and algorithm.
+func (k benchmarkKey) sign(input []byte) (string, error) {
+	if k.alg == bpb.SignatureAlgorithm_SIGNATURE_ALGORITHM_CMS_DETACHED {
+		return SignDetached([]*x509.Certificate{k.cert}, k.key, input)
+	}
+	return SignWithAlgorithm(k.key, input, k.alg)
+}
+
+// benchmarkKeys returns keys of every supported type and size, with each of their algorithms.
+func benchmarkKeys(b *testing.B) []benchmarkKey {
+	b.Helper()
+	var keys []benchmarkKey
+	add := func(desc string, key crypto.Signer, err error) {
+		if err != nil {
+			b.Fatalf("unable to create %s key: %v", desc, err)
+		}
+		cert := selfSigned(b, key)
+		for _, alg := range Algorithms(key.Public()) {
+			keys = append(keys, benchmarkKey{
+				desc: desc + "/" + strings.TrimPrefix(alg.String(), "SIGNATURE_ALGORITHM_"),
+				key:  key,
+				cert: cert,
+				alg:  alg,
+			})
+		}
+	}
+	for _, bits := range []int{2048, 3072, 4096} {
+		key, err := rsa.GenerateKey(rand.Reader, bits)
+		add(fmt.Sprintf("RSA%d", bits), key, err)
+	}
+	for _, curve := range []elliptic.Curve{elliptic.P256(), elliptic.P384()} {
+		key, err := ecdsa.GenerateKey(curve, rand.Reader)
+		add("ECDSA_"+curve.Params().Name, key, err)
+	}
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	add("Ed25519", key, err)
+	return keys
+}
+
+// benchmarkSizes are the sizes of the signed data in the benchmarks, from a bare response to one
+// carrying a large vendor configuration.
+var benchmarkSizes = []int{1 << 10, 64 << 10, 1 << 20}
+
+func BenchmarkSign(b *testing.B) {
+	for _, k := range benchmarkKeys(b) {
+		for _, size := range benchmarkSizes {
+			input := make([]byte, size)
+			b.Run(fmt.Sprintf("%s/%dKiB", k.desc, size>>10), func(b *testing.B) {
+				b.SetBytes(int64(size))
+				for i := 0; i < b.N; i++ {
+					if _, err := k.sign(input); err != nil {
+						b.Fatalf("sign() err = %v", err)
+					}
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkVerify(b *testing.B) {
+	for _, k := range benchmarkKeys(b) {
+		for _, size := range benchmarkSizes {
+			input := make([]byte, size)
+			sig, err := k.sign(input)
+			if err != nil {
+				b.Fatalf("sign() err = %v", err)
+			}
+			b.Run(fmt.Sprintf("%s/%dKiB", k.desc, size>>10), func(b *testing.B) {
+				b.SetBytes(int64(size))
+				for i := 0; i < b.N; i++ {
+					if err := VerifyWithAlgorithm(k.cert, input, sig, k.alg); err != nil {
+						b.Fatalf("VerifyWithAlgorithm() err = %v", err)
+					}
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"crypto/tls"
+	"flag"
+	"fmt"
+	"os/exec"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	apb "github.com/openconfig/bootz/server/admin/proto/admin"
+)
+
+var (
+	topology  = flag.String("topology", "testdata/topology.textproto", "The KNE topology of the bootz server and the emulated devices.")
+	namespace = flag.String("namespace", "bootz", "The namespace KNE deploys the topology in, its name.")
+	kneBin    = flag.String("kne", "kne", "The path of the KNE CLI.")
+	kubectl   = flag.String("kubectl", "kubectl", "The path of kubectl.")
+	localPort = flag.Int("local_port", 15106, "The local port the admin port of the server is forwarded to.")
+	timeout   = flag.Duration("timeout", 10*time.Minute, "How long the devices have to bootstrap, including the creation of the topology.")
+	keep      = flag.Bool("keep_topology", false, "Whether to keep the topology once the test is done, for debugging.")
+)
+
+// command runs a command, failing the test if it fails.
+func command(ctx context.Context, t *testing.T, name string, args ...string) string {
+	t.Helper()
+	out, err := exec.CommandContext(ctx, name, args...).CombinedOutput()
+	if err != nil {
+		t.Fatalf("%s %v failed: %v\n%s", name, args, err, out)
+	}
+	return string(out)
+}
+
+// createTopology creates the topology, and deletes it once the test is done.
+func createTopology(ctx context.Context, t *testing.T) {
+	t.Helper()
+	if _, err := exec.LookPath(*kneBin); err != nil {
+		t.Skipf("KNE CLI %q not found: %v", *kneBin, err)
+	}
+	t.Logf("Creating topology %s", *topology)
+	command(ctx, t, *kneBin, "create", *topology)
+	if !*keep {
+		t.Cleanup(func() {
+			if out, err := exec.Command(*kneBin, "delete", *topology).CombinedOutput(); err != nil {
+				t.Errorf("kne delete failed: %v\n%s", err, out)
+			}
+		})
+	}
+	command(ctx, t, *kubectl, "wait", "-n", *namespace, "--for=condition=Ready", "pod/bootz", "--timeout=5m")
+}
+
+// adminClient forwards the admin port of the server to the local port, and returns a client of
+// the admin API served on it.
+func adminClient(ctx context.Context, t *testing.T) apb.AdminClient {
+	t.Helper()
+	forward := exec.CommandContext(ctx, *kubectl, "port-forward", "-n", *namespace, "pod/bootz", fmt.Sprintf("%d:15008", *localPort))
+	if err := forward.Start(); err != nil {
+		t.Fatalf("kubectl port-forward failed: %v", err)
+	}
+	t.Cleanup(func() {
+		forward.Process.Kill()
+		forward.Wait()
+	})
+	dialCtx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+	// The certificate of the test artifacts is not verified, as devices don't verify it either
+	// before they receive their trust cert.
+	creds := credentials.NewTLS(&tls.Config{InsecureSkipVerify: true})
+	conn, err := grpc.DialContext(dialCtx, fmt.Sprintf("localhost:%d", *localPort), grpc.WithTransportCredentials(creds), grpc.WithBlock())
+	if err != nil {
+		t.Fatalf("Unable to connect to the forwarded admin API: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return apb.NewAdminClient(conn)
+}
+
+// TestBootstrap waits for the emulated device to complete the sZTP workflow: requesting its
+// bootstrap data, validating and applying it, then reporting a successful bootstrap.
+func TestBootstrap(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+	createTopology(ctx, t)
+	c := adminClient(ctx, t)
+
+	want := []string{"123A", "123B"}
+	var states []*apb.DeviceState
+	for {
+		resp, err := c.ListDeviceStates(ctx, &apb.ListDeviceStatesRequest{SerialNumbers: want})
+		if err != nil {
+			t.Logf("ListDeviceStates() err = %v", err)
+		}
+		states = resp.GetDevices()
+		ok := len(states) == len(want)
+		for _, s := range states {
+			ok = ok && s.GetState() == apb.DeviceBootstrapState_DEVICE_BOOTSTRAP_STATE_STATUS_OK
+		}
+		if ok {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			logs, _ := exec.Command(*kubectl, "logs", "-n", *namespace, "device", "--tail=50").CombinedOutput()
+			t.Fatalf("Devices did not bootstrap within %v, last states: %v\nLogs of the device:\n%s", *timeout, states, logs)
+		case <-time.After(5 * time.Second):
+		}
+	}
+}
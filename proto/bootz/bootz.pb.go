@@ -29,6 +29,180 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type SignatureAlgorithm int32
+
+const (
+	SignatureAlgorithm_SIGNATURE_ALGORITHM_UNSPECIFIED         SignatureAlgorithm = 0
+	SignatureAlgorithm_SIGNATURE_ALGORITHM_RSA_PKCS1V15_SHA256 SignatureAlgorithm = 1
+	SignatureAlgorithm_SIGNATURE_ALGORITHM_RSA_PSS_SHA256      SignatureAlgorithm = 2
+	SignatureAlgorithm_SIGNATURE_ALGORITHM_ECDSA_SHA256        SignatureAlgorithm = 3
+	SignatureAlgorithm_SIGNATURE_ALGORITHM_ED25519             SignatureAlgorithm = 4
+	SignatureAlgorithm_SIGNATURE_ALGORITHM_CMS_DETACHED        SignatureAlgorithm = 5
+)
+
+// Enum value maps for SignatureAlgorithm.
+var (
+	SignatureAlgorithm_name = map[int32]string{
+		0: "SIGNATURE_ALGORITHM_UNSPECIFIED",
+		1: "SIGNATURE_ALGORITHM_RSA_PKCS1V15_SHA256",
+		2: "SIGNATURE_ALGORITHM_RSA_PSS_SHA256",
+		3: "SIGNATURE_ALGORITHM_ECDSA_SHA256",
+		4: "SIGNATURE_ALGORITHM_ED25519",
+		5: "SIGNATURE_ALGORITHM_CMS_DETACHED",
+	}
+	SignatureAlgorithm_value = map[string]int32{
+		"SIGNATURE_ALGORITHM_UNSPECIFIED":         0,
+		"SIGNATURE_ALGORITHM_RSA_PKCS1V15_SHA256": 1,
+		"SIGNATURE_ALGORITHM_RSA_PSS_SHA256":      2,
+		"SIGNATURE_ALGORITHM_ECDSA_SHA256":        3,
+		"SIGNATURE_ALGORITHM_ED25519":             4,
+		"SIGNATURE_ALGORITHM_CMS_DETACHED":        5,
+	}
+)
+
+func (x SignatureAlgorithm) Enum() *SignatureAlgorithm {
+	p := new(SignatureAlgorithm)
+	*p = x
+	return p
+}
+
+func (x SignatureAlgorithm) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SignatureAlgorithm) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_bootz_proto_enumTypes[0].Descriptor()
+}
+
+func (SignatureAlgorithm) Type() protoreflect.EnumType {
+	return &file_proto_bootz_proto_enumTypes[0]
+}
+
+func (x SignatureAlgorithm) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SignatureAlgorithm.Descriptor instead.
+func (SignatureAlgorithm) EnumDescriptor() ([]byte, []int) {
+	return file_proto_bootz_proto_rawDescGZIP(), []int{0}
+}
+
+type ConfigEncoding int32
+
+const (
+	ConfigEncoding_CONFIG_ENCODING_UNSPECIFIED      ConfigEncoding = 0
+	ConfigEncoding_CONFIG_ENCODING_VENDOR_CLI       ConfigEncoding = 1
+	ConfigEncoding_CONFIG_ENCODING_OPENCONFIG_JSON  ConfigEncoding = 2
+	ConfigEncoding_CONFIG_ENCODING_GNMI_SET_REQUEST ConfigEncoding = 3
+)
+
+// Enum value maps for ConfigEncoding.
+var (
+	ConfigEncoding_name = map[int32]string{
+		0: "CONFIG_ENCODING_UNSPECIFIED",
+		1: "CONFIG_ENCODING_VENDOR_CLI",
+		2: "CONFIG_ENCODING_OPENCONFIG_JSON",
+		3: "CONFIG_ENCODING_GNMI_SET_REQUEST",
+	}
+	ConfigEncoding_value = map[string]int32{
+		"CONFIG_ENCODING_UNSPECIFIED":      0,
+		"CONFIG_ENCODING_VENDOR_CLI":       1,
+		"CONFIG_ENCODING_OPENCONFIG_JSON":  2,
+		"CONFIG_ENCODING_GNMI_SET_REQUEST": 3,
+	}
+)
+
+func (x ConfigEncoding) Enum() *ConfigEncoding {
+	p := new(ConfigEncoding)
+	*p = x
+	return p
+}
+
+func (x ConfigEncoding) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ConfigEncoding) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_bootz_proto_enumTypes[1].Descriptor()
+}
+
+func (ConfigEncoding) Type() protoreflect.EnumType {
+	return &file_proto_bootz_proto_enumTypes[1]
+}
+
+func (x ConfigEncoding) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ConfigEncoding.Descriptor instead.
+func (ConfigEncoding) EnumDescriptor() ([]byte, []int) {
+	return file_proto_bootz_proto_rawDescGZIP(), []int{1}
+}
+
+type ArtifactType int32
+
+const (
+	ArtifactType_ARTIFACT_TYPE_UNSPECIFIED      ArtifactType = 0
+	ArtifactType_ARTIFACT_TYPE_VENDOR_CONFIG    ArtifactType = 1
+	ArtifactType_ARTIFACT_TYPE_OC_CONFIG        ArtifactType = 2
+	ArtifactType_ARTIFACT_TYPE_CREDENTIALS      ArtifactType = 3
+	ArtifactType_ARTIFACT_TYPE_PATHZ            ArtifactType = 4
+	ArtifactType_ARTIFACT_TYPE_AUTHZ            ArtifactType = 5
+	ArtifactType_ARTIFACT_TYPE_CERTZ            ArtifactType = 6
+	ArtifactType_ARTIFACT_TYPE_GNMI_SET_REQUEST ArtifactType = 7
+)
+
+// Enum value maps for ArtifactType.
+var (
+	ArtifactType_name = map[int32]string{
+		0: "ARTIFACT_TYPE_UNSPECIFIED",
+		1: "ARTIFACT_TYPE_VENDOR_CONFIG",
+		2: "ARTIFACT_TYPE_OC_CONFIG",
+		3: "ARTIFACT_TYPE_CREDENTIALS",
+		4: "ARTIFACT_TYPE_PATHZ",
+		5: "ARTIFACT_TYPE_AUTHZ",
+		6: "ARTIFACT_TYPE_CERTZ",
+		7: "ARTIFACT_TYPE_GNMI_SET_REQUEST",
+	}
+	ArtifactType_value = map[string]int32{
+		"ARTIFACT_TYPE_UNSPECIFIED":      0,
+		"ARTIFACT_TYPE_VENDOR_CONFIG":    1,
+		"ARTIFACT_TYPE_OC_CONFIG":        2,
+		"ARTIFACT_TYPE_CREDENTIALS":      3,
+		"ARTIFACT_TYPE_PATHZ":            4,
+		"ARTIFACT_TYPE_AUTHZ":            5,
+		"ARTIFACT_TYPE_CERTZ":            6,
+		"ARTIFACT_TYPE_GNMI_SET_REQUEST": 7,
+	}
+)
+
+func (x ArtifactType) Enum() *ArtifactType {
+	p := new(ArtifactType)
+	*p = x
+	return p
+}
+
+func (x ArtifactType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ArtifactType) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_bootz_proto_enumTypes[2].Descriptor()
+}
+
+func (ArtifactType) Type() protoreflect.EnumType {
+	return &file_proto_bootz_proto_enumTypes[2]
+}
+
+func (x ArtifactType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ArtifactType.Descriptor instead.
+func (ArtifactType) EnumDescriptor() ([]byte, []int) {
+	return file_proto_bootz_proto_rawDescGZIP(), []int{2}
+}
+
 type BootMode int32
 
 const (
@@ -62,11 +236,11 @@ func (x BootMode) String() string {
 }
 
 func (BootMode) Descriptor() protoreflect.EnumDescriptor {
-	return file_proto_bootz_proto_enumTypes[0].Descriptor()
+	return file_proto_bootz_proto_enumTypes[3].Descriptor()
 }
 
 func (BootMode) Type() protoreflect.EnumType {
-	return &file_proto_bootz_proto_enumTypes[0]
+	return &file_proto_bootz_proto_enumTypes[3]
 }
 
 func (x BootMode) Number() protoreflect.EnumNumber {
@@ -75,7 +249,7 @@ func (x BootMode) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use BootMode.Descriptor instead.
 func (BootMode) EnumDescriptor() ([]byte, []int) {
-	return file_proto_bootz_proto_rawDescGZIP(), []int{0}
+	return file_proto_bootz_proto_rawDescGZIP(), []int{3}
 }
 
 type ControlCardState_ControlCardStatus int32
@@ -111,11 +285,11 @@ func (x ControlCardState_ControlCardStatus) String() string {
 }
 
 func (ControlCardState_ControlCardStatus) Descriptor() protoreflect.EnumDescriptor {
-	return file_proto_bootz_proto_enumTypes[1].Descriptor()
+	return file_proto_bootz_proto_enumTypes[4].Descriptor()
 }
 
 func (ControlCardState_ControlCardStatus) Type() protoreflect.EnumType {
-	return &file_proto_bootz_proto_enumTypes[1]
+	return &file_proto_bootz_proto_enumTypes[4]
 }
 
 func (x ControlCardState_ControlCardStatus) Number() protoreflect.EnumNumber {
@@ -124,7 +298,7 @@ func (x ControlCardState_ControlCardStatus) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use ControlCardState_ControlCardStatus.Descriptor instead.
 func (ControlCardState_ControlCardStatus) EnumDescriptor() ([]byte, []int) {
-	return file_proto_bootz_proto_rawDescGZIP(), []int{3, 0}
+	return file_proto_bootz_proto_rawDescGZIP(), []int{5, 0}
 }
 
 type ReportStatusRequest_BootstrapStatus int32
@@ -163,11 +337,11 @@ func (x ReportStatusRequest_BootstrapStatus) String() string {
 }
 
 func (ReportStatusRequest_BootstrapStatus) Descriptor() protoreflect.EnumDescriptor {
-	return file_proto_bootz_proto_enumTypes[2].Descriptor()
+	return file_proto_bootz_proto_enumTypes[5].Descriptor()
 }
 
 func (ReportStatusRequest_BootstrapStatus) Type() protoreflect.EnumType {
-	return &file_proto_bootz_proto_enumTypes[2]
+	return &file_proto_bootz_proto_enumTypes[5]
 }
 
 func (x ReportStatusRequest_BootstrapStatus) Number() protoreflect.EnumNumber {
@@ -176,7 +350,62 @@ func (x ReportStatusRequest_BootstrapStatus) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use ReportStatusRequest_BootstrapStatus.Descriptor instead.
 func (ReportStatusRequest_BootstrapStatus) EnumDescriptor() ([]byte, []int) {
-	return file_proto_bootz_proto_rawDescGZIP(), []int{10, 0}
+	return file_proto_bootz_proto_rawDescGZIP(), []int{15, 0}
+}
+
+type BootstrapDataChunk struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Data      []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	TotalSize uint64 `protobuf:"varint,2,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
+}
+
+func (x *BootstrapDataChunk) Reset() {
+	*x = BootstrapDataChunk{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_bootz_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BootstrapDataChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BootstrapDataChunk) ProtoMessage() {}
+
+func (x *BootstrapDataChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bootz_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BootstrapDataChunk.ProtoReflect.Descriptor instead.
+func (*BootstrapDataChunk) Descriptor() ([]byte, []int) {
+	return file_proto_bootz_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *BootstrapDataChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *BootstrapDataChunk) GetTotalSize() uint64 {
+	if x != nil {
+		return x.TotalSize
+	}
+	return 0
 }
 
 type GetBootstrapDataRequest struct {
@@ -184,15 +413,18 @@ type GetBootstrapDataRequest struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ChassisDescriptor *ChassisDescriptor `protobuf:"bytes,1,opt,name=chassis_descriptor,json=chassisDescriptor,proto3" json:"chassis_descriptor,omitempty"`
-	ControlCardState  *ControlCardState  `protobuf:"bytes,2,opt,name=control_card_state,json=controlCardState,proto3" json:"control_card_state,omitempty"`
-	Nonce             string             `protobuf:"bytes,1001,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	ChassisDescriptor   *ChassisDescriptor   `protobuf:"bytes,1,opt,name=chassis_descriptor,json=chassisDescriptor,proto3" json:"chassis_descriptor,omitempty"`
+	ControlCardState    *ControlCardState    `protobuf:"bytes,2,opt,name=control_card_state,json=controlCardState,proto3" json:"control_card_state,omitempty"`
+	Nonce               string               `protobuf:"bytes,1001,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	SignatureAlgorithms []SignatureAlgorithm `protobuf:"varint,1002,rep,packed,name=signature_algorithms,json=signatureAlgorithms,proto3,enum=bootz.proto.SignatureAlgorithm" json:"signature_algorithms,omitempty"`
+	RequestCounter      uint64               `protobuf:"varint,1003,opt,name=request_counter,json=requestCounter,proto3" json:"request_counter,omitempty"`
+	BootstrapToken      string               `protobuf:"bytes,1004,opt,name=bootstrap_token,json=bootstrapToken,proto3" json:"bootstrap_token,omitempty"`
 }
 
 func (x *GetBootstrapDataRequest) Reset() {
 	*x = GetBootstrapDataRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_bootz_proto_msgTypes[0]
+		mi := &file_proto_bootz_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -205,7 +437,7 @@ func (x *GetBootstrapDataRequest) String() string {
 func (*GetBootstrapDataRequest) ProtoMessage() {}
 
 func (x *GetBootstrapDataRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_bootz_proto_msgTypes[0]
+	mi := &file_proto_bootz_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -218,7 +450,7 @@ func (x *GetBootstrapDataRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetBootstrapDataRequest.ProtoReflect.Descriptor instead.
 func (*GetBootstrapDataRequest) Descriptor() ([]byte, []int) {
-	return file_proto_bootz_proto_rawDescGZIP(), []int{0}
+	return file_proto_bootz_proto_rawDescGZIP(), []int{1}
 }
 
 func (x *GetBootstrapDataRequest) GetChassisDescriptor() *ChassisDescriptor {
@@ -242,21 +474,45 @@ func (x *GetBootstrapDataRequest) GetNonce() string {
 	return ""
 }
 
+func (x *GetBootstrapDataRequest) GetSignatureAlgorithms() []SignatureAlgorithm {
+	if x != nil {
+		return x.SignatureAlgorithms
+	}
+	return nil
+}
+
+func (x *GetBootstrapDataRequest) GetRequestCounter() uint64 {
+	if x != nil {
+		return x.RequestCounter
+	}
+	return 0
+}
+
+func (x *GetBootstrapDataRequest) GetBootstrapToken() string {
+	if x != nil {
+		return x.BootstrapToken
+	}
+	return ""
+}
+
 type ChassisDescriptor struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Manufacturer string         `protobuf:"bytes,1,opt,name=manufacturer,proto3" json:"manufacturer,omitempty"`
-	PartNumber   string         `protobuf:"bytes,2,opt,name=part_number,json=partNumber,proto3" json:"part_number,omitempty"`
-	SerialNumber string         `protobuf:"bytes,3,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
-	ControlCards []*ControlCard `protobuf:"bytes,4,rep,name=control_cards,json=controlCards,proto3" json:"control_cards,omitempty"`
+	Manufacturer    string         `protobuf:"bytes,1,opt,name=manufacturer,proto3" json:"manufacturer,omitempty"`
+	PartNumber      string         `protobuf:"bytes,2,opt,name=part_number,json=partNumber,proto3" json:"part_number,omitempty"`
+	SerialNumber    string         `protobuf:"bytes,3,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+	ControlCards    []*ControlCard `protobuf:"bytes,4,rep,name=control_cards,json=controlCards,proto3" json:"control_cards,omitempty"`
+	SoftwareVersion string         `protobuf:"bytes,5,opt,name=software_version,json=softwareVersion,proto3" json:"software_version,omitempty"`
+	LineCards       []*Module      `protobuf:"bytes,6,rep,name=line_cards,json=lineCards,proto3" json:"line_cards,omitempty"`
+	FabricModules   []*Module      `protobuf:"bytes,7,rep,name=fabric_modules,json=fabricModules,proto3" json:"fabric_modules,omitempty"`
 }
 
 func (x *ChassisDescriptor) Reset() {
 	*x = ChassisDescriptor{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_bootz_proto_msgTypes[1]
+		mi := &file_proto_bootz_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -269,7 +525,7 @@ func (x *ChassisDescriptor) String() string {
 func (*ChassisDescriptor) ProtoMessage() {}
 
 func (x *ChassisDescriptor) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_bootz_proto_msgTypes[1]
+	mi := &file_proto_bootz_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -282,7 +538,7 @@ func (x *ChassisDescriptor) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ChassisDescriptor.ProtoReflect.Descriptor instead.
 func (*ChassisDescriptor) Descriptor() ([]byte, []int) {
-	return file_proto_bootz_proto_rawDescGZIP(), []int{1}
+	return file_proto_bootz_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *ChassisDescriptor) GetManufacturer() string {
@@ -313,7 +569,28 @@ func (x *ChassisDescriptor) GetControlCards() []*ControlCard {
 	return nil
 }
 
-type ControlCard struct {
+func (x *ChassisDescriptor) GetSoftwareVersion() string {
+	if x != nil {
+		return x.SoftwareVersion
+	}
+	return ""
+}
+
+func (x *ChassisDescriptor) GetLineCards() []*Module {
+	if x != nil {
+		return x.LineCards
+	}
+	return nil
+}
+
+func (x *ChassisDescriptor) GetFabricModules() []*Module {
+	if x != nil {
+		return x.FabricModules
+	}
+	return nil
+}
+
+type Module struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
@@ -323,10 +600,74 @@ type ControlCard struct {
 	Slot         int32  `protobuf:"varint,3,opt,name=slot,proto3" json:"slot,omitempty"`
 }
 
+func (x *Module) Reset() {
+	*x = Module{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_bootz_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Module) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Module) ProtoMessage() {}
+
+func (x *Module) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bootz_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Module.ProtoReflect.Descriptor instead.
+func (*Module) Descriptor() ([]byte, []int) {
+	return file_proto_bootz_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Module) GetPartNumber() string {
+	if x != nil {
+		return x.PartNumber
+	}
+	return ""
+}
+
+func (x *Module) GetSerialNumber() string {
+	if x != nil {
+		return x.SerialNumber
+	}
+	return ""
+}
+
+func (x *Module) GetSlot() int32 {
+	if x != nil {
+		return x.Slot
+	}
+	return 0
+}
+
+type ControlCard struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PartNumber      string `protobuf:"bytes,1,opt,name=part_number,json=partNumber,proto3" json:"part_number,omitempty"`
+	SerialNumber    string `protobuf:"bytes,2,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+	Slot            int32  `protobuf:"varint,3,opt,name=slot,proto3" json:"slot,omitempty"`
+	SoftwareVersion string `protobuf:"bytes,4,opt,name=software_version,json=softwareVersion,proto3" json:"software_version,omitempty"`
+}
+
 func (x *ControlCard) Reset() {
 	*x = ControlCard{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_bootz_proto_msgTypes[2]
+		mi := &file_proto_bootz_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -339,7 +680,7 @@ func (x *ControlCard) String() string {
 func (*ControlCard) ProtoMessage() {}
 
 func (x *ControlCard) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_bootz_proto_msgTypes[2]
+	mi := &file_proto_bootz_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -352,7 +693,7 @@ func (x *ControlCard) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ControlCard.ProtoReflect.Descriptor instead.
 func (*ControlCard) Descriptor() ([]byte, []int) {
-	return file_proto_bootz_proto_rawDescGZIP(), []int{2}
+	return file_proto_bootz_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *ControlCard) GetPartNumber() string {
@@ -376,19 +717,28 @@ func (x *ControlCard) GetSlot() int32 {
 	return 0
 }
 
+func (x *ControlCard) GetSoftwareVersion() string {
+	if x != nil {
+		return x.SoftwareVersion
+	}
+	return ""
+}
+
 type ControlCardState struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SerialNumber string                             `protobuf:"bytes,1,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
-	Status       ControlCardState_ControlCardStatus `protobuf:"varint,2,opt,name=status,proto3,enum=bootz.proto.ControlCardState_ControlCardStatus" json:"status,omitempty"`
+	SerialNumber      string                             `protobuf:"bytes,1,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+	Status            ControlCardState_ControlCardStatus `protobuf:"varint,2,opt,name=status,proto3,enum=bootz.proto.ControlCardState_ControlCardStatus" json:"status,omitempty"`
+	AppliedImageHash  string                             `protobuf:"bytes,3,opt,name=applied_image_hash,json=appliedImageHash,proto3" json:"applied_image_hash,omitempty"`
+	AppliedConfigHash string                             `protobuf:"bytes,4,opt,name=applied_config_hash,json=appliedConfigHash,proto3" json:"applied_config_hash,omitempty"`
 }
 
 func (x *ControlCardState) Reset() {
 	*x = ControlCardState{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_bootz_proto_msgTypes[3]
+		mi := &file_proto_bootz_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -401,7 +751,7 @@ func (x *ControlCardState) String() string {
 func (*ControlCardState) ProtoMessage() {}
 
 func (x *ControlCardState) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_bootz_proto_msgTypes[3]
+	mi := &file_proto_bootz_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -414,7 +764,7 @@ func (x *ControlCardState) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ControlCardState.ProtoReflect.Descriptor instead.
 func (*ControlCardState) Descriptor() ([]byte, []int) {
-	return file_proto_bootz_proto_rawDescGZIP(), []int{3}
+	return file_proto_bootz_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *ControlCardState) GetSerialNumber() string {
@@ -431,6 +781,20 @@ func (x *ControlCardState) GetStatus() ControlCardState_ControlCardStatus {
 	return ControlCardState_CONTROL_CARD_STATUS_UNSPECIFIED
 }
 
+func (x *ControlCardState) GetAppliedImageHash() string {
+	if x != nil {
+		return x.AppliedImageHash
+	}
+	return ""
+}
+
+func (x *ControlCardState) GetAppliedConfigHash() string {
+	if x != nil {
+		return x.AppliedConfigHash
+	}
+	return ""
+}
+
 type BootstrapDataResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -445,12 +809,13 @@ type BootstrapDataResponse struct {
 	Pathz            *pathz.UploadRequest `protobuf:"bytes,7,opt,name=pathz,proto3" json:"pathz,omitempty"`
 	Authz            *authz.UploadRequest `protobuf:"bytes,8,opt,name=authz,proto3" json:"authz,omitempty"`
 	Certificates     *certz.UploadRequest `protobuf:"bytes,9,opt,name=certificates,proto3" json:"certificates,omitempty"`
+	OwnershipVoucher []byte               `protobuf:"bytes,10,opt,name=ownership_voucher,json=ownershipVoucher,proto3" json:"ownership_voucher,omitempty"`
 }
 
 func (x *BootstrapDataResponse) Reset() {
 	*x = BootstrapDataResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_bootz_proto_msgTypes[4]
+		mi := &file_proto_bootz_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -463,7 +828,7 @@ func (x *BootstrapDataResponse) String() string {
 func (*BootstrapDataResponse) ProtoMessage() {}
 
 func (x *BootstrapDataResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_bootz_proto_msgTypes[4]
+	mi := &file_proto_bootz_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -476,7 +841,7 @@ func (x *BootstrapDataResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BootstrapDataResponse.ProtoReflect.Descriptor instead.
 func (*BootstrapDataResponse) Descriptor() ([]byte, []int) {
-	return file_proto_bootz_proto_rawDescGZIP(), []int{4}
+	return file_proto_bootz_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *BootstrapDataResponse) GetSerialNum() string {
@@ -542,6 +907,13 @@ func (x *BootstrapDataResponse) GetCertificates() *certz.UploadRequest {
 	return nil
 }
 
+func (x *BootstrapDataResponse) GetOwnershipVoucher() []byte {
+	if x != nil {
+		return x.OwnershipVoucher
+	}
+	return nil
+}
+
 type BootstrapDataSigned struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -549,12 +921,13 @@ type BootstrapDataSigned struct {
 
 	Responses []*BootstrapDataResponse `protobuf:"bytes,1,rep,name=responses,proto3" json:"responses,omitempty"`
 	Nonce     string                   `protobuf:"bytes,2,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	Redirect  *RedirectInfo            `protobuf:"bytes,3,opt,name=redirect,proto3" json:"redirect,omitempty"`
 }
 
 func (x *BootstrapDataSigned) Reset() {
 	*x = BootstrapDataSigned{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_bootz_proto_msgTypes[5]
+		mi := &file_proto_bootz_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -567,7 +940,7 @@ func (x *BootstrapDataSigned) String() string {
 func (*BootstrapDataSigned) ProtoMessage() {}
 
 func (x *BootstrapDataSigned) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_bootz_proto_msgTypes[5]
+	mi := &file_proto_bootz_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -580,7 +953,7 @@ func (x *BootstrapDataSigned) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BootstrapDataSigned.ProtoReflect.Descriptor instead.
 func (*BootstrapDataSigned) Descriptor() ([]byte, []int) {
-	return file_proto_bootz_proto_rawDescGZIP(), []int{5}
+	return file_proto_bootz_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *BootstrapDataSigned) GetResponses() []*BootstrapDataResponse {
@@ -597,23 +970,199 @@ func (x *BootstrapDataSigned) GetNonce() string {
 	return ""
 }
 
+func (x *BootstrapDataSigned) GetRedirect() *RedirectInfo {
+	if x != nil {
+		return x.Redirect
+	}
+	return nil
+}
+
+type RedirectInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Servers []*RedirectServer `protobuf:"bytes,1,rep,name=servers,proto3" json:"servers,omitempty"`
+}
+
+func (x *RedirectInfo) Reset() {
+	*x = RedirectInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_bootz_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RedirectInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RedirectInfo) ProtoMessage() {}
+
+func (x *RedirectInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bootz_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RedirectInfo.ProtoReflect.Descriptor instead.
+func (*RedirectInfo) Descriptor() ([]byte, []int) {
+	return file_proto_bootz_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *RedirectInfo) GetServers() []*RedirectServer {
+	if x != nil {
+		return x.Servers
+	}
+	return nil
+}
+
+type RedirectServer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address     string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	TrustAnchor string `protobuf:"bytes,2,opt,name=trust_anchor,json=trustAnchor,proto3" json:"trust_anchor,omitempty"`
+}
+
+func (x *RedirectServer) Reset() {
+	*x = RedirectServer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_bootz_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RedirectServer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RedirectServer) ProtoMessage() {}
+
+func (x *RedirectServer) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bootz_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RedirectServer.ProtoReflect.Descriptor instead.
+func (*RedirectServer) Descriptor() ([]byte, []int) {
+	return file_proto_bootz_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *RedirectServer) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *RedirectServer) GetTrustAnchor() string {
+	if x != nil {
+		return x.TrustAnchor
+	}
+	return ""
+}
+
+type StagingBundle struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BundleId          string                    `protobuf:"bytes,1,opt,name=bundle_id,json=bundleId,proto3" json:"bundle_id,omitempty"`
+	ChassisDescriptor *ChassisDescriptor        `protobuf:"bytes,2,opt,name=chassis_descriptor,json=chassisDescriptor,proto3" json:"chassis_descriptor,omitempty"`
+	Response          *GetBootstrapDataResponse `protobuf:"bytes,3,opt,name=response,proto3" json:"response,omitempty"`
+}
+
+func (x *StagingBundle) Reset() {
+	*x = StagingBundle{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_bootz_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StagingBundle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StagingBundle) ProtoMessage() {}
+
+func (x *StagingBundle) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bootz_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StagingBundle.ProtoReflect.Descriptor instead.
+func (*StagingBundle) Descriptor() ([]byte, []int) {
+	return file_proto_bootz_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *StagingBundle) GetBundleId() string {
+	if x != nil {
+		return x.BundleId
+	}
+	return ""
+}
+
+func (x *StagingBundle) GetChassisDescriptor() *ChassisDescriptor {
+	if x != nil {
+		return x.ChassisDescriptor
+	}
+	return nil
+}
+
+func (x *StagingBundle) GetResponse() *GetBootstrapDataResponse {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
 type GetBootstrapDataResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	// Deprecated: Do not use.
-	SignedResponse          *BootstrapDataSigned `protobuf:"bytes,1,opt,name=signed_response,json=signedResponse,proto3" json:"signed_response,omitempty"`
-	OwnershipVoucher        []byte               `protobuf:"bytes,101,opt,name=ownership_voucher,json=ownershipVoucher,proto3" json:"ownership_voucher,omitempty"`
-	OwnershipCertificate    []byte               `protobuf:"bytes,102,opt,name=ownership_certificate,json=ownershipCertificate,proto3" json:"ownership_certificate,omitempty"`
-	ResponseSignature       string               `protobuf:"bytes,103,opt,name=response_signature,json=responseSignature,proto3" json:"response_signature,omitempty"`
-	SerializedBootstrapData []byte               `protobuf:"bytes,104,opt,name=serialized_bootstrap_data,json=serializedBootstrapData,proto3" json:"serialized_bootstrap_data,omitempty"`
+	SignedResponse               *BootstrapDataSigned `protobuf:"bytes,1,opt,name=signed_response,json=signedResponse,proto3" json:"signed_response,omitempty"`
+	OwnershipVoucher             []byte               `protobuf:"bytes,101,opt,name=ownership_voucher,json=ownershipVoucher,proto3" json:"ownership_voucher,omitempty"`
+	OwnershipCertificate         []byte               `protobuf:"bytes,102,opt,name=ownership_certificate,json=ownershipCertificate,proto3" json:"ownership_certificate,omitempty"`
+	ResponseSignature            string               `protobuf:"bytes,103,opt,name=response_signature,json=responseSignature,proto3" json:"response_signature,omitempty"`
+	SerializedBootstrapData      []byte               `protobuf:"bytes,104,opt,name=serialized_bootstrap_data,json=serializedBootstrapData,proto3" json:"serialized_bootstrap_data,omitempty"`
+	PreviousOwnershipCertificate []byte               `protobuf:"bytes,105,opt,name=previous_ownership_certificate,json=previousOwnershipCertificate,proto3" json:"previous_ownership_certificate,omitempty"`
+	PreviousResponseSignature    string               `protobuf:"bytes,106,opt,name=previous_response_signature,json=previousResponseSignature,proto3" json:"previous_response_signature,omitempty"`
+	SignatureAlgorithm           SignatureAlgorithm   `protobuf:"varint,107,opt,name=signature_algorithm,json=signatureAlgorithm,proto3,enum=bootz.proto.SignatureAlgorithm" json:"signature_algorithm,omitempty"`
+	PreviousSignatureAlgorithm   SignatureAlgorithm   `protobuf:"varint,108,opt,name=previous_signature_algorithm,json=previousSignatureAlgorithm,proto3,enum=bootz.proto.SignatureAlgorithm" json:"previous_signature_algorithm,omitempty"`
 }
 
 func (x *GetBootstrapDataResponse) Reset() {
 	*x = GetBootstrapDataResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_bootz_proto_msgTypes[6]
+		mi := &file_proto_bootz_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -626,7 +1175,7 @@ func (x *GetBootstrapDataResponse) String() string {
 func (*GetBootstrapDataResponse) ProtoMessage() {}
 
 func (x *GetBootstrapDataResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_bootz_proto_msgTypes[6]
+	mi := &file_proto_bootz_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -639,7 +1188,7 @@ func (x *GetBootstrapDataResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetBootstrapDataResponse.ProtoReflect.Descriptor instead.
 func (*GetBootstrapDataResponse) Descriptor() ([]byte, []int) {
-	return file_proto_bootz_proto_rawDescGZIP(), []int{6}
+	return file_proto_bootz_proto_rawDescGZIP(), []int{11}
 }
 
 // Deprecated: Do not use.
@@ -678,6 +1227,34 @@ func (x *GetBootstrapDataResponse) GetSerializedBootstrapData() []byte {
 	return nil
 }
 
+func (x *GetBootstrapDataResponse) GetPreviousOwnershipCertificate() []byte {
+	if x != nil {
+		return x.PreviousOwnershipCertificate
+	}
+	return nil
+}
+
+func (x *GetBootstrapDataResponse) GetPreviousResponseSignature() string {
+	if x != nil {
+		return x.PreviousResponseSignature
+	}
+	return ""
+}
+
+func (x *GetBootstrapDataResponse) GetSignatureAlgorithm() SignatureAlgorithm {
+	if x != nil {
+		return x.SignatureAlgorithm
+	}
+	return SignatureAlgorithm_SIGNATURE_ALGORITHM_UNSPECIFIED
+}
+
+func (x *GetBootstrapDataResponse) GetPreviousSignatureAlgorithm() SignatureAlgorithm {
+	if x != nil {
+		return x.PreviousSignatureAlgorithm
+	}
+	return SignatureAlgorithm_SIGNATURE_ALGORITHM_UNSPECIFIED
+}
+
 type SoftwareImage struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -693,7 +1270,7 @@ type SoftwareImage struct {
 func (x *SoftwareImage) Reset() {
 	*x = SoftwareImage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_bootz_proto_msgTypes[7]
+		mi := &file_proto_bootz_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -706,7 +1283,7 @@ func (x *SoftwareImage) String() string {
 func (*SoftwareImage) ProtoMessage() {}
 
 func (x *SoftwareImage) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_bootz_proto_msgTypes[7]
+	mi := &file_proto_bootz_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -719,7 +1296,7 @@ func (x *SoftwareImage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SoftwareImage.ProtoReflect.Descriptor instead.
 func (*SoftwareImage) Descriptor() ([]byte, []int) {
-	return file_proto_bootz_proto_rawDescGZIP(), []int{7}
+	return file_proto_bootz_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *SoftwareImage) GetName() string {
@@ -765,12 +1342,13 @@ type Credentials struct {
 	Credentials []*credentialz.AuthorizedKeysRequest  `protobuf:"bytes,1,rep,name=credentials,proto3" json:"credentials,omitempty"`
 	Users       []*credentialz.AuthorizedUsersRequest `protobuf:"bytes,2,rep,name=users,proto3" json:"users,omitempty"`
 	Passwords   []*credentialz.PasswordRequest        `protobuf:"bytes,3,rep,name=passwords,proto3" json:"passwords,omitempty"`
+	ServerKeys  []*credentialz.ServerKeysRequest      `protobuf:"bytes,4,rep,name=server_keys,json=serverKeys,proto3" json:"server_keys,omitempty"`
 }
 
 func (x *Credentials) Reset() {
 	*x = Credentials{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_bootz_proto_msgTypes[8]
+		mi := &file_proto_bootz_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -783,7 +1361,7 @@ func (x *Credentials) String() string {
 func (*Credentials) ProtoMessage() {}
 
 func (x *Credentials) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_bootz_proto_msgTypes[8]
+	mi := &file_proto_bootz_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -796,7 +1374,7 @@ func (x *Credentials) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Credentials.ProtoReflect.Descriptor instead.
 func (*Credentials) Descriptor() ([]byte, []int) {
-	return file_proto_bootz_proto_rawDescGZIP(), []int{8}
+	return file_proto_bootz_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *Credentials) GetCredentials() []*credentialz.AuthorizedKeysRequest {
@@ -820,6 +1398,13 @@ func (x *Credentials) GetPasswords() []*credentialz.PasswordRequest {
 	return nil
 }
 
+func (x *Credentials) GetServerKeys() []*credentialz.ServerKeysRequest {
+	if x != nil {
+		return x.ServerKeys
+	}
+	return nil
+}
+
 type BootConfig struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -829,12 +1414,14 @@ type BootConfig struct {
 	VendorConfig     []byte           `protobuf:"bytes,2,opt,name=vendor_config,json=vendorConfig,proto3" json:"vendor_config,omitempty"`
 	OcConfig         []byte           `protobuf:"bytes,3,opt,name=oc_config,json=ocConfig,proto3" json:"oc_config,omitempty"`
 	BootloaderConfig *structpb.Struct `protobuf:"bytes,4,opt,name=bootloader_config,json=bootloaderConfig,proto3" json:"bootloader_config,omitempty"`
+	GnmiSetRequest   []byte           `protobuf:"bytes,5,opt,name=gnmi_set_request,json=gnmiSetRequest,proto3" json:"gnmi_set_request,omitempty"`
+	Encoding         ConfigEncoding   `protobuf:"varint,6,opt,name=encoding,proto3,enum=bootz.proto.ConfigEncoding" json:"encoding,omitempty"`
 }
 
 func (x *BootConfig) Reset() {
 	*x = BootConfig{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_bootz_proto_msgTypes[9]
+		mi := &file_proto_bootz_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -847,7 +1434,7 @@ func (x *BootConfig) String() string {
 func (*BootConfig) ProtoMessage() {}
 
 func (x *BootConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_bootz_proto_msgTypes[9]
+	mi := &file_proto_bootz_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -860,7 +1447,7 @@ func (x *BootConfig) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BootConfig.ProtoReflect.Descriptor instead.
 func (*BootConfig) Descriptor() ([]byte, []int) {
-	return file_proto_bootz_proto_rawDescGZIP(), []int{9}
+	return file_proto_bootz_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *BootConfig) GetMetadata() *structpb.Struct {
@@ -891,20 +1478,35 @@ func (x *BootConfig) GetBootloaderConfig() *structpb.Struct {
 	return nil
 }
 
+func (x *BootConfig) GetGnmiSetRequest() []byte {
+	if x != nil {
+		return x.GnmiSetRequest
+	}
+	return nil
+}
+
+func (x *BootConfig) GetEncoding() ConfigEncoding {
+	if x != nil {
+		return x.Encoding
+	}
+	return ConfigEncoding_CONFIG_ENCODING_UNSPECIFIED
+}
+
 type ReportStatusRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Status        ReportStatusRequest_BootstrapStatus `protobuf:"varint,1,opt,name=status,proto3,enum=bootz.proto.ReportStatusRequest_BootstrapStatus" json:"status,omitempty"`
-	StatusMessage string                              `protobuf:"bytes,2,opt,name=status_message,json=statusMessage,proto3" json:"status_message,omitempty"`
-	States        []*ControlCardState                 `protobuf:"bytes,3,rep,name=states,proto3" json:"states,omitempty"`
+	Status          ReportStatusRequest_BootstrapStatus `protobuf:"varint,1,opt,name=status,proto3,enum=bootz.proto.ReportStatusRequest_BootstrapStatus" json:"status,omitempty"`
+	StatusMessage   string                              `protobuf:"bytes,2,opt,name=status_message,json=statusMessage,proto3" json:"status_message,omitempty"`
+	States          []*ControlCardState                 `protobuf:"bytes,3,rep,name=states,proto3" json:"states,omitempty"`
+	StagingBundleId string                              `protobuf:"bytes,4,opt,name=staging_bundle_id,json=stagingBundleId,proto3" json:"staging_bundle_id,omitempty"`
 }
 
 func (x *ReportStatusRequest) Reset() {
 	*x = ReportStatusRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_bootz_proto_msgTypes[10]
+		mi := &file_proto_bootz_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -917,7 +1519,7 @@ func (x *ReportStatusRequest) String() string {
 func (*ReportStatusRequest) ProtoMessage() {}
 
 func (x *ReportStatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_bootz_proto_msgTypes[10]
+	mi := &file_proto_bootz_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -930,7 +1532,7 @@ func (x *ReportStatusRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReportStatusRequest.ProtoReflect.Descriptor instead.
 func (*ReportStatusRequest) Descriptor() ([]byte, []int) {
-	return file_proto_bootz_proto_rawDescGZIP(), []int{10}
+	return file_proto_bootz_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *ReportStatusRequest) GetStatus() ReportStatusRequest_BootstrapStatus {
@@ -954,6 +1556,131 @@ func (x *ReportStatusRequest) GetStates() []*ControlCardState {
 	return nil
 }
 
+func (x *ReportStatusRequest) GetStagingBundleId() string {
+	if x != nil {
+		return x.StagingBundleId
+	}
+	return ""
+}
+
+type ReportCapabilitiesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ChassisDescriptor *ChassisDescriptor  `protobuf:"bytes,1,opt,name=chassis_descriptor,json=chassisDescriptor,proto3" json:"chassis_descriptor,omitempty"`
+	Capabilities      *DeviceCapabilities `protobuf:"bytes,2,opt,name=capabilities,proto3" json:"capabilities,omitempty"`
+}
+
+func (x *ReportCapabilitiesRequest) Reset() {
+	*x = ReportCapabilitiesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_bootz_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReportCapabilitiesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportCapabilitiesRequest) ProtoMessage() {}
+
+func (x *ReportCapabilitiesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bootz_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportCapabilitiesRequest.ProtoReflect.Descriptor instead.
+func (*ReportCapabilitiesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_bootz_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ReportCapabilitiesRequest) GetChassisDescriptor() *ChassisDescriptor {
+	if x != nil {
+		return x.ChassisDescriptor
+	}
+	return nil
+}
+
+func (x *ReportCapabilitiesRequest) GetCapabilities() *DeviceCapabilities {
+	if x != nil {
+		return x.Capabilities
+	}
+	return nil
+}
+
+type DeviceCapabilities struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Features       []string       `protobuf:"bytes,1,rep,name=features,proto3" json:"features,omitempty"`
+	ArtifactTypes  []ArtifactType `protobuf:"varint,2,rep,packed,name=artifact_types,json=artifactTypes,proto3,enum=bootz.proto.ArtifactType" json:"artifact_types,omitempty"`
+	MaxMessageSize uint64         `protobuf:"varint,3,opt,name=max_message_size,json=maxMessageSize,proto3" json:"max_message_size,omitempty"`
+}
+
+func (x *DeviceCapabilities) Reset() {
+	*x = DeviceCapabilities{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_bootz_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeviceCapabilities) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeviceCapabilities) ProtoMessage() {}
+
+func (x *DeviceCapabilities) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_bootz_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeviceCapabilities.ProtoReflect.Descriptor instead.
+func (*DeviceCapabilities) Descriptor() ([]byte, []int) {
+	return file_proto_bootz_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *DeviceCapabilities) GetFeatures() []string {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+func (x *DeviceCapabilities) GetArtifactTypes() []ArtifactType {
+	if x != nil {
+		return x.ArtifactTypes
+	}
+	return nil
+}
+
+func (x *DeviceCapabilities) GetMaxMessageSize() uint64 {
+	if x != nil {
+		return x.MaxMessageSize
+	}
+	return 0
+}
+
 type EmptyResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -963,7 +1690,7 @@ type EmptyResponse struct {
 func (x *EmptyResponse) Reset() {
 	*x = EmptyResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_bootz_proto_msgTypes[11]
+		mi := &file_proto_bootz_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -976,7 +1703,7 @@ func (x *EmptyResponse) String() string {
 func (*EmptyResponse) ProtoMessage() {}
 
 func (x *EmptyResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_bootz_proto_msgTypes[11]
+	mi := &file_proto_bootz_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -989,7 +1716,7 @@ func (x *EmptyResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EmptyResponse.ProtoReflect.Descriptor instead.
 func (*EmptyResponse) Descriptor() ([]byte, []int) {
-	return file_proto_bootz_proto_rawDescGZIP(), []int{11}
+	return file_proto_bootz_proto_rawDescGZIP(), []int{18}
 }
 
 var File_proto_bootz_proto protoreflect.FileDescriptor
@@ -1011,192 +1738,368 @@ var file_proto_bootz_proto_rawDesc = []byte{
 	0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x2c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
 	0x2f, 0x6f, 0x70, 0x65, 0x6e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2f, 0x67, 0x6e, 0x73, 0x69,
 	0x2f, 0x70, 0x61, 0x74, 0x68, 0x7a, 0x2f, 0x70, 0x61, 0x74, 0x68, 0x7a, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x22, 0xcc, 0x01, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x42, 0x6f, 0x6f, 0x74, 0x73, 0x74,
-	0x72, 0x61, 0x70, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x4d,
-	0x0a, 0x12, 0x63, 0x68, 0x61, 0x73, 0x73, 0x69, 0x73, 0x5f, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69,
-	0x70, 0x74, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x62, 0x6f, 0x6f,
-	0x74, 0x7a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x68, 0x61, 0x73, 0x73, 0x69, 0x73,
-	0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x52, 0x11, 0x63, 0x68, 0x61, 0x73,
-	0x73, 0x69, 0x73, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x12, 0x4b, 0x0a,
-	0x12, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x63, 0x61, 0x72, 0x64, 0x5f, 0x73, 0x74,
-	0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x62, 0x6f, 0x6f, 0x74,
-	0x7a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x43,
-	0x61, 0x72, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x10, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f,
-	0x6c, 0x43, 0x61, 0x72, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x15, 0x0a, 0x05, 0x6e, 0x6f,
-	0x6e, 0x63, 0x65, 0x18, 0xe9, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6e, 0x6f, 0x6e, 0x63,
-	0x65, 0x22, 0xbc, 0x01, 0x0a, 0x11, 0x43, 0x68, 0x61, 0x73, 0x73, 0x69, 0x73, 0x44, 0x65, 0x73,
-	0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x12, 0x22, 0x0a, 0x0c, 0x6d, 0x61, 0x6e, 0x75, 0x66,
-	0x61, 0x63, 0x74, 0x75, 0x72, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6d,
-	0x61, 0x6e, 0x75, 0x66, 0x61, 0x63, 0x74, 0x75, 0x72, 0x65, 0x72, 0x12, 0x1f, 0x0a, 0x0b, 0x70,
-	0x61, 0x72, 0x74, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0a, 0x70, 0x61, 0x72, 0x74, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x23, 0x0a, 0x0d,
-	0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x4e, 0x75, 0x6d, 0x62, 0x65,
-	0x72, 0x12, 0x3d, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x63, 0x61, 0x72,
-	0x64, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x7a,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x43, 0x61,
-	0x72, 0x64, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x43, 0x61, 0x72, 0x64, 0x73,
-	0x22, 0x67, 0x0a, 0x0b, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x43, 0x61, 0x72, 0x64, 0x12,
-	0x1f, 0x0a, 0x0b, 0x70, 0x61, 0x72, 0x74, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x61, 0x72, 0x74, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72,
-	0x12, 0x23, 0x0a, 0x0d, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65,
-	0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x4e,
-	0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x6c, 0x6f, 0x74, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x04, 0x73, 0x6c, 0x6f, 0x74, 0x22, 0x89, 0x02, 0x0a, 0x10, 0x43, 0x6f,
-	0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x43, 0x61, 0x72, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x23,
+	0x74, 0x6f, 0x22, 0x47, 0x0a, 0x12, 0x42, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x44,
+	0x61, 0x74, 0x61, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x1d, 0x0a, 0x0a,
+	0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x09, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x53, 0x69, 0x7a, 0x65, 0x22, 0xf5, 0x02, 0x0a, 0x17,
+	0x47, 0x65, 0x74, 0x42, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x44, 0x61, 0x74, 0x61,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x4d, 0x0a, 0x12, 0x63, 0x68, 0x61, 0x73, 0x73,
+	0x69, 0x73, 0x5f, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x7a, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x43, 0x68, 0x61, 0x73, 0x73, 0x69, 0x73, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x6f, 0x72, 0x52, 0x11, 0x63, 0x68, 0x61, 0x73, 0x73, 0x69, 0x73, 0x44, 0x65, 0x73, 0x63,
+	0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x12, 0x4b, 0x0a, 0x12, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f,
+	0x6c, 0x5f, 0x63, 0x61, 0x72, 0x64, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x7a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x43, 0x61, 0x72, 0x64, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x52, 0x10, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x43, 0x61, 0x72, 0x64, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x12, 0x15, 0x0a, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x18, 0xe9, 0x07, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x53, 0x0a, 0x14, 0x73, 0x69,
+	0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x5f, 0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68,
+	0x6d, 0x73, 0x18, 0xea, 0x07, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x1f, 0x2e, 0x62, 0x6f, 0x6f, 0x74,
+	0x7a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x52, 0x13, 0x73, 0x69, 0x67, 0x6e,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x73, 0x12,
+	0x28, 0x0a, 0x0f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x65, 0x72, 0x18, 0xeb, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x72, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x12, 0x28, 0x0a, 0x0f, 0x62, 0x6f, 0x6f,
+	0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0xec, 0x07, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0e, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x54, 0x6f,
+	0x6b, 0x65, 0x6e, 0x22, 0xd7, 0x02, 0x0a, 0x11, 0x43, 0x68, 0x61, 0x73, 0x73, 0x69, 0x73, 0x44,
+	0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x12, 0x22, 0x0a, 0x0c, 0x6d, 0x61, 0x6e,
+	0x75, 0x66, 0x61, 0x63, 0x74, 0x75, 0x72, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0c, 0x6d, 0x61, 0x6e, 0x75, 0x66, 0x61, 0x63, 0x74, 0x75, 0x72, 0x65, 0x72, 0x12, 0x1f, 0x0a,
+	0x0b, 0x70, 0x61, 0x72, 0x74, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x70, 0x61, 0x72, 0x74, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x23,
 	0x0a, 0x0d, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x4e, 0x75, 0x6d,
-	0x62, 0x65, 0x72, 0x12, 0x47, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x0e, 0x32, 0x2f, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x7a, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x43, 0x61, 0x72, 0x64, 0x53, 0x74, 0x61,
-	0x74, 0x65, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x43, 0x61, 0x72, 0x64, 0x53, 0x74,
-	0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x86, 0x01, 0x0a,
-	0x11, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x43, 0x61, 0x72, 0x64, 0x53, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x12, 0x23, 0x0a, 0x1f, 0x43, 0x4f, 0x4e, 0x54, 0x52, 0x4f, 0x4c, 0x5f, 0x43, 0x41,
-	0x52, 0x44, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43,
-	0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x27, 0x0a, 0x23, 0x43, 0x4f, 0x4e, 0x54, 0x52,
-	0x4f, 0x4c, 0x5f, 0x43, 0x41, 0x52, 0x44, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x4e,
-	0x4f, 0x54, 0x5f, 0x49, 0x4e, 0x49, 0x54, 0x49, 0x41, 0x4c, 0x49, 0x5a, 0x45, 0x44, 0x10, 0x01,
-	0x12, 0x23, 0x0a, 0x1f, 0x43, 0x4f, 0x4e, 0x54, 0x52, 0x4f, 0x4c, 0x5f, 0x43, 0x41, 0x52, 0x44,
-	0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x49, 0x4e, 0x49, 0x54, 0x49, 0x41, 0x4c, 0x49,
-	0x5a, 0x45, 0x44, 0x10, 0x02, 0x22, 0xf3, 0x03, 0x0a, 0x15, 0x42, 0x6f, 0x6f, 0x74, 0x73, 0x74,
-	0x72, 0x61, 0x70, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x5f, 0x6e, 0x75, 0x6d, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x4e, 0x75, 0x6d, 0x12, 0x41,
-	0x0a, 0x0e, 0x69, 0x6e, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x5f, 0x69, 0x6d, 0x61, 0x67, 0x65,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x7a, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x6f, 0x66, 0x74, 0x77, 0x61, 0x72, 0x65, 0x49, 0x6d, 0x61,
-	0x67, 0x65, 0x52, 0x0d, 0x69, 0x6e, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x49, 0x6d, 0x61, 0x67,
-	0x65, 0x12, 0x2c, 0x0a, 0x12, 0x62, 0x6f, 0x6f, 0x74, 0x5f, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f,
-	0x72, 0x64, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x62,
-	0x6f, 0x6f, 0x74, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x48, 0x61, 0x73, 0x68, 0x12,
-	0x2a, 0x0a, 0x11, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x74, 0x72, 0x75, 0x73, 0x74, 0x5f,
-	0x63, 0x65, 0x72, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x73, 0x65, 0x72, 0x76,
-	0x65, 0x72, 0x54, 0x72, 0x75, 0x73, 0x74, 0x43, 0x65, 0x72, 0x74, 0x12, 0x38, 0x0a, 0x0b, 0x62,
-	0x6f, 0x6f, 0x74, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x17, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x7a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x42,
-	0x6f, 0x6f, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x0a, 0x62, 0x6f, 0x6f, 0x74, 0x43,
-	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x3a, 0x0a, 0x0b, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74,
-	0x69, 0x61, 0x6c, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x62, 0x6f, 0x6f,
-	0x74, 0x7a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74,
-	0x69, 0x61, 0x6c, 0x73, 0x52, 0x0b, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c,
-	0x73, 0x12, 0x32, 0x0a, 0x05, 0x70, 0x61, 0x74, 0x68, 0x7a, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x1c, 0x2e, 0x67, 0x6e, 0x73, 0x69, 0x2e, 0x70, 0x61, 0x74, 0x68, 0x7a, 0x2e, 0x76, 0x31,
-	0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x05,
-	0x70, 0x61, 0x74, 0x68, 0x7a, 0x12, 0x32, 0x0a, 0x05, 0x61, 0x75, 0x74, 0x68, 0x7a, 0x18, 0x08,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6e, 0x73, 0x69, 0x2e, 0x61, 0x75, 0x74, 0x68,
-	0x7a, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x52, 0x05, 0x61, 0x75, 0x74, 0x68, 0x7a, 0x12, 0x40, 0x0a, 0x0c, 0x63, 0x65, 0x72,
-	0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x1c, 0x2e, 0x67, 0x6e, 0x73, 0x69, 0x2e, 0x63, 0x65, 0x72, 0x74, 0x7a, 0x2e, 0x76, 0x31, 0x2e,
-	0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x0c, 0x63,
-	0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x73, 0x22, 0x6d, 0x0a, 0x13, 0x42,
-	0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x44, 0x61, 0x74, 0x61, 0x53, 0x69, 0x67, 0x6e,
-	0x65, 0x64, 0x12, 0x40, 0x0a, 0x09, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x73, 0x18,
-	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x7a, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x42, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x44, 0x61, 0x74,
-	0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x09, 0x72, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x22, 0xb6, 0x02, 0x0a, 0x18, 0x47,
-	0x65, 0x74, 0x42, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x44, 0x61, 0x74, 0x61, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4d, 0x0a, 0x0f, 0x73, 0x69, 0x67, 0x6e, 0x65,
-	0x64, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x20, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x7a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x42,
-	0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x44, 0x61, 0x74, 0x61, 0x53, 0x69, 0x67, 0x6e,
-	0x65, 0x64, 0x42, 0x02, 0x18, 0x01, 0x52, 0x0e, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2b, 0x0a, 0x11, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x73,
-	0x68, 0x69, 0x70, 0x5f, 0x76, 0x6f, 0x75, 0x63, 0x68, 0x65, 0x72, 0x18, 0x65, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x10, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x56, 0x6f, 0x75, 0x63,
-	0x68, 0x65, 0x72, 0x12, 0x33, 0x0a, 0x15, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70,
-	0x5f, 0x63, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x18, 0x66, 0x20, 0x01,
-	0x28, 0x0c, 0x52, 0x14, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x43, 0x65, 0x72,
-	0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x12, 0x2d, 0x0a, 0x12, 0x72, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x67,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x53, 0x69,
-	0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x3a, 0x0a, 0x19, 0x73, 0x65, 0x72, 0x69, 0x61,
-	0x6c, 0x69, 0x7a, 0x65, 0x64, 0x5f, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x5f,
-	0x64, 0x61, 0x74, 0x61, 0x18, 0x68, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x17, 0x73, 0x65, 0x72, 0x69,
-	0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x42, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x44,
-	0x61, 0x74, 0x61, 0x22, 0x9a, 0x01, 0x0a, 0x0d, 0x53, 0x6f, 0x66, 0x74, 0x77, 0x61, 0x72, 0x65,
-	0x49, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72,
-	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73,
-	0x69, 0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x22, 0x0a, 0x0d, 0x6f, 0x73, 0x5f, 0x69, 0x6d, 0x61, 0x67,
-	0x65, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6f, 0x73,
-	0x49, 0x6d, 0x61, 0x67, 0x65, 0x48, 0x61, 0x73, 0x68, 0x12, 0x25, 0x0a, 0x0e, 0x68, 0x61, 0x73,
-	0x68, 0x5f, 0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x18, 0x05, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x0d, 0x68, 0x61, 0x73, 0x68, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d,
-	0x22, 0xe2, 0x01, 0x0a, 0x0b, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73,
-	0x12, 0x4c, 0x0a, 0x0b, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x18,
-	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x67, 0x6e, 0x73, 0x69, 0x2e, 0x63, 0x72, 0x65,
-	0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x7a, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x75, 0x74, 0x68,
-	0x6f, 0x72, 0x69, 0x7a, 0x65, 0x64, 0x4b, 0x65, 0x79, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x52, 0x0b, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x12, 0x41,
-	0x0a, 0x05, 0x75, 0x73, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2b, 0x2e,
-	0x67, 0x6e, 0x73, 0x69, 0x2e, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x7a,
-	0x2e, 0x76, 0x31, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x65, 0x64, 0x55, 0x73,
-	0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x05, 0x75, 0x73, 0x65, 0x72,
-	0x73, 0x12, 0x42, 0x0a, 0x09, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x18, 0x03,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x67, 0x6e, 0x73, 0x69, 0x2e, 0x63, 0x72, 0x65, 0x64,
-	0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x7a, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x73, 0x73, 0x77,
-	0x6f, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x09, 0x70, 0x61, 0x73, 0x73,
-	0x77, 0x6f, 0x72, 0x64, 0x73, 0x22, 0xc9, 0x01, 0x0a, 0x0a, 0x42, 0x6f, 0x6f, 0x74, 0x43, 0x6f,
-	0x6e, 0x66, 0x69, 0x67, 0x12, 0x33, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52,
-	0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x23, 0x0a, 0x0d, 0x76, 0x65, 0x6e,
-	0x64, 0x6f, 0x72, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
-	0x52, 0x0c, 0x76, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x1b,
-	0x0a, 0x09, 0x6f, 0x63, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x08, 0x6f, 0x63, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x44, 0x0a, 0x11, 0x62,
-	0x6f, 0x6f, 0x74, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52,
-	0x10, 0x62, 0x6f, 0x6f, 0x74, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69,
-	0x67, 0x22, 0xcf, 0x02, 0x0a, 0x13, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x48, 0x0a, 0x06, 0x73, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x30, 0x2e, 0x62, 0x6f, 0x6f, 0x74,
-	0x7a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x74,
-	0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x42, 0x6f, 0x6f, 0x74,
-	0x73, 0x74, 0x72, 0x61, 0x70, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x6d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x73, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x35, 0x0a, 0x06, 0x73, 0x74,
-	0x61, 0x74, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x62, 0x6f, 0x6f,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x4e, 0x75, 0x6d,
+	0x62, 0x65, 0x72, 0x12, 0x3d, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x63,
+	0x61, 0x72, 0x64, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x62, 0x6f, 0x6f,
 	0x74, 0x7a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c,
-	0x43, 0x61, 0x72, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x65,
-	0x73, 0x22, 0x8f, 0x01, 0x0a, 0x0f, 0x42, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x53,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x20, 0x0a, 0x1c, 0x42, 0x4f, 0x4f, 0x54, 0x53, 0x54, 0x52,
-	0x41, 0x50, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43,
-	0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1c, 0x0a, 0x18, 0x42, 0x4f, 0x4f, 0x54, 0x53,
-	0x54, 0x52, 0x41, 0x50, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x53, 0x55, 0x43, 0x43,
-	0x45, 0x53, 0x53, 0x10, 0x01, 0x12, 0x1c, 0x0a, 0x18, 0x42, 0x4f, 0x4f, 0x54, 0x53, 0x54, 0x52,
-	0x41, 0x50, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x55, 0x52,
-	0x45, 0x10, 0x02, 0x12, 0x1e, 0x0a, 0x1a, 0x42, 0x4f, 0x4f, 0x54, 0x53, 0x54, 0x52, 0x41, 0x50,
-	0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x49, 0x4e, 0x49, 0x54, 0x49, 0x41, 0x54, 0x45,
-	0x44, 0x10, 0x03, 0x22, 0x0f, 0x0a, 0x0d, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x2a, 0x53, 0x0a, 0x08, 0x42, 0x6f, 0x6f, 0x74, 0x4d, 0x6f, 0x64, 0x65,
-	0x12, 0x19, 0x0a, 0x15, 0x42, 0x4f, 0x4f, 0x54, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x55, 0x4e,
-	0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x16, 0x0a, 0x12, 0x42,
-	0x4f, 0x4f, 0x54, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x49, 0x4e, 0x53, 0x45, 0x43, 0x55, 0x52,
-	0x45, 0x10, 0x01, 0x12, 0x14, 0x0a, 0x10, 0x42, 0x4f, 0x4f, 0x54, 0x5f, 0x4d, 0x4f, 0x44, 0x45,
-	0x5f, 0x53, 0x45, 0x43, 0x55, 0x52, 0x45, 0x10, 0x02, 0x32, 0xbe, 0x01, 0x0a, 0x09, 0x42, 0x6f,
-	0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x12, 0x61, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x42, 0x6f,
-	0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x44, 0x61, 0x74, 0x61, 0x12, 0x24, 0x2e, 0x62, 0x6f,
-	0x6f, 0x74, 0x7a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x6f, 0x6f,
-	0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x25, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x7a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
-	0x47, 0x65, 0x74, 0x42, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x44, 0x61, 0x74, 0x61,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4e, 0x0a, 0x0c, 0x52, 0x65,
-	0x70, 0x6f, 0x72, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x20, 0x2e, 0x62, 0x6f, 0x6f,
-	0x74, 0x7a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x53,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x62,
+	0x43, 0x61, 0x72, 0x64, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x43, 0x61, 0x72,
+	0x64, 0x73, 0x12, 0x29, 0x0a, 0x10, 0x73, 0x6f, 0x66, 0x74, 0x77, 0x61, 0x72, 0x65, 0x5f, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x73, 0x6f,
+	0x66, 0x74, 0x77, 0x61, 0x72, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x32, 0x0a,
+	0x0a, 0x6c, 0x69, 0x6e, 0x65, 0x5f, 0x63, 0x61, 0x72, 0x64, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x13, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x7a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x52, 0x09, 0x6c, 0x69, 0x6e, 0x65, 0x43, 0x61, 0x72, 0x64,
+	0x73, 0x12, 0x3a, 0x0a, 0x0e, 0x66, 0x61, 0x62, 0x72, 0x69, 0x63, 0x5f, 0x6d, 0x6f, 0x64, 0x75,
+	0x6c, 0x65, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x62, 0x6f, 0x6f, 0x74,
+	0x7a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x52, 0x0d,
+	0x66, 0x61, 0x62, 0x72, 0x69, 0x63, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x22, 0x62, 0x0a,
+	0x06, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x61, 0x72, 0x74, 0x5f,
+	0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x61,
+	0x72, 0x74, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x65, 0x72, 0x69,
+	0x61, 0x6c, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0c, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x12, 0x0a,
+	0x04, 0x73, 0x6c, 0x6f, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x73, 0x6c, 0x6f,
+	0x74, 0x22, 0x92, 0x01, 0x0a, 0x0b, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x43, 0x61, 0x72,
+	0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x61, 0x72, 0x74, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x61, 0x72, 0x74, 0x4e, 0x75, 0x6d, 0x62,
+	0x65, 0x72, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x5f, 0x6e, 0x75, 0x6d,
+	0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x65, 0x72, 0x69, 0x61,
+	0x6c, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x6c, 0x6f, 0x74, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x73, 0x6c, 0x6f, 0x74, 0x12, 0x29, 0x0a, 0x10, 0x73,
+	0x6f, 0x66, 0x74, 0x77, 0x61, 0x72, 0x65, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x73, 0x6f, 0x66, 0x74, 0x77, 0x61, 0x72, 0x65, 0x56,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0xe7, 0x02, 0x0a, 0x10, 0x43, 0x6f, 0x6e, 0x74, 0x72,
+	0x6f, 0x6c, 0x43, 0x61, 0x72, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x73,
+	0x65, 0x72, 0x69, 0x61, 0x6c, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0c, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72,
+	0x12, 0x47, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x2f, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x7a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43,
+	0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x43, 0x61, 0x72, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x2e,
+	0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x43, 0x61, 0x72, 0x64, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x2c, 0x0a, 0x12, 0x61, 0x70, 0x70,
+	0x6c, 0x69, 0x65, 0x64, 0x5f, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x65, 0x64, 0x49, 0x6d,
+	0x61, 0x67, 0x65, 0x48, 0x61, 0x73, 0x68, 0x12, 0x2e, 0x0a, 0x13, 0x61, 0x70, 0x70, 0x6c, 0x69,
+	0x65, 0x64, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x65, 0x64, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x48, 0x61, 0x73, 0x68, 0x22, 0x86, 0x01, 0x0a, 0x11, 0x43, 0x6f, 0x6e, 0x74,
+	0x72, 0x6f, 0x6c, 0x43, 0x61, 0x72, 0x64, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x23, 0x0a,
+	0x1f, 0x43, 0x4f, 0x4e, 0x54, 0x52, 0x4f, 0x4c, 0x5f, 0x43, 0x41, 0x52, 0x44, 0x5f, 0x53, 0x54,
+	0x41, 0x54, 0x55, 0x53, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44,
+	0x10, 0x00, 0x12, 0x27, 0x0a, 0x23, 0x43, 0x4f, 0x4e, 0x54, 0x52, 0x4f, 0x4c, 0x5f, 0x43, 0x41,
+	0x52, 0x44, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x49, 0x4e,
+	0x49, 0x54, 0x49, 0x41, 0x4c, 0x49, 0x5a, 0x45, 0x44, 0x10, 0x01, 0x12, 0x23, 0x0a, 0x1f, 0x43,
+	0x4f, 0x4e, 0x54, 0x52, 0x4f, 0x4c, 0x5f, 0x43, 0x41, 0x52, 0x44, 0x5f, 0x53, 0x54, 0x41, 0x54,
+	0x55, 0x53, 0x5f, 0x49, 0x4e, 0x49, 0x54, 0x49, 0x41, 0x4c, 0x49, 0x5a, 0x45, 0x44, 0x10, 0x02,
+	0x22, 0xa0, 0x04, 0x0a, 0x15, 0x42, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x44, 0x61,
+	0x74, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65,
+	0x72, 0x69, 0x61, 0x6c, 0x5f, 0x6e, 0x75, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x4e, 0x75, 0x6d, 0x12, 0x41, 0x0a, 0x0e, 0x69, 0x6e, 0x74,
+	0x65, 0x6e, 0x64, 0x65, 0x64, 0x5f, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x7a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x53, 0x6f, 0x66, 0x74, 0x77, 0x61, 0x72, 0x65, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x0d, 0x69,
+	0x6e, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x2c, 0x0a, 0x12,
+	0x62, 0x6f, 0x6f, 0x74, 0x5f, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x5f, 0x68, 0x61,
+	0x73, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x62, 0x6f, 0x6f, 0x74, 0x50, 0x61,
+	0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x48, 0x61, 0x73, 0x68, 0x12, 0x2a, 0x0a, 0x11, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x5f, 0x74, 0x72, 0x75, 0x73, 0x74, 0x5f, 0x63, 0x65, 0x72, 0x74, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x54, 0x72, 0x75,
+	0x73, 0x74, 0x43, 0x65, 0x72, 0x74, 0x12, 0x38, 0x0a, 0x0b, 0x62, 0x6f, 0x6f, 0x74, 0x5f, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x62, 0x6f,
+	0x6f, 0x74, 0x7a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x42, 0x6f, 0x6f, 0x74, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x52, 0x0a, 0x62, 0x6f, 0x6f, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x12, 0x3a, 0x0a, 0x0b, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x7a, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x52,
+	0x0b, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x12, 0x32, 0x0a, 0x05,
+	0x70, 0x61, 0x74, 0x68, 0x7a, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6e,
+	0x73, 0x69, 0x2e, 0x70, 0x61, 0x74, 0x68, 0x7a, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x6c, 0x6f,
+	0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x05, 0x70, 0x61, 0x74, 0x68, 0x7a,
+	0x12, 0x32, 0x0a, 0x05, 0x61, 0x75, 0x74, 0x68, 0x7a, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1c, 0x2e, 0x67, 0x6e, 0x73, 0x69, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x7a, 0x2e, 0x76, 0x31, 0x2e,
+	0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x05, 0x61,
+	0x75, 0x74, 0x68, 0x7a, 0x12, 0x40, 0x0a, 0x0c, 0x63, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63,
+	0x61, 0x74, 0x65, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6e, 0x73,
+	0x69, 0x2e, 0x63, 0x65, 0x72, 0x74, 0x7a, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61,
+	0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x0c, 0x63, 0x65, 0x72, 0x74, 0x69, 0x66,
+	0x69, 0x63, 0x61, 0x74, 0x65, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x73,
+	0x68, 0x69, 0x70, 0x5f, 0x76, 0x6f, 0x75, 0x63, 0x68, 0x65, 0x72, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x10, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x56, 0x6f, 0x75, 0x63,
+	0x68, 0x65, 0x72, 0x22, 0xa4, 0x01, 0x0a, 0x13, 0x42, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61,
+	0x70, 0x44, 0x61, 0x74, 0x61, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x12, 0x40, 0x0a, 0x09, 0x72,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22,
+	0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x7a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x42, 0x6f, 0x6f,
+	0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x52, 0x09, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x73, 0x12, 0x14, 0x0a,
+	0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6e, 0x6f,
+	0x6e, 0x63, 0x65, 0x12, 0x35, 0x0a, 0x08, 0x72, 0x65, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x7a, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x49, 0x6e, 0x66, 0x6f,
+	0x52, 0x08, 0x72, 0x65, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x22, 0x45, 0x0a, 0x0c, 0x52, 0x65,
+	0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x35, 0x0a, 0x07, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x62, 0x6f,
+	0x6f, 0x74, 0x7a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x64, 0x69, 0x72, 0x65,
+	0x63, 0x74, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x52, 0x07, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x73, 0x22, 0x4d, 0x0a, 0x0e, 0x52, 0x65, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x53, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x21, 0x0a,
+	0x0c, 0x74, 0x72, 0x75, 0x73, 0x74, 0x5f, 0x61, 0x6e, 0x63, 0x68, 0x6f, 0x72, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x74, 0x72, 0x75, 0x73, 0x74, 0x41, 0x6e, 0x63, 0x68, 0x6f, 0x72,
+	0x22, 0xbe, 0x01, 0x0a, 0x0d, 0x53, 0x74, 0x61, 0x67, 0x69, 0x6e, 0x67, 0x42, 0x75, 0x6e, 0x64,
+	0x6c, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x49, 0x64, 0x12,
+	0x4d, 0x0a, 0x12, 0x63, 0x68, 0x61, 0x73, 0x73, 0x69, 0x73, 0x5f, 0x64, 0x65, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x62, 0x6f,
+	0x6f, 0x74, 0x7a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x68, 0x61, 0x73, 0x73, 0x69,
+	0x73, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x52, 0x11, 0x63, 0x68, 0x61,
+	0x73, 0x73, 0x69, 0x73, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x12, 0x41,
+	0x0a, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x25, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x7a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47,
+	0x65, 0x74, 0x42, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x44, 0x61, 0x74, 0x61, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0xf1, 0x04, 0x0a, 0x18, 0x47, 0x65, 0x74, 0x42, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72,
+	0x61, 0x70, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4d,
+	0x0a, 0x0f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x7a, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x42, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x44,
+	0x61, 0x74, 0x61, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x42, 0x02, 0x18, 0x01, 0x52, 0x0e, 0x73,
+	0x69, 0x67, 0x6e, 0x65, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2b, 0x0a,
+	0x11, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x5f, 0x76, 0x6f, 0x75, 0x63, 0x68,
+	0x65, 0x72, 0x18, 0x65, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x10, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x73,
+	0x68, 0x69, 0x70, 0x56, 0x6f, 0x75, 0x63, 0x68, 0x65, 0x72, 0x12, 0x33, 0x0a, 0x15, 0x6f, 0x77,
+	0x6e, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x5f, 0x63, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63,
+	0x61, 0x74, 0x65, 0x18, 0x66, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x14, 0x6f, 0x77, 0x6e, 0x65, 0x72,
+	0x73, 0x68, 0x69, 0x70, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x12,
+	0x2d, 0x0a, 0x12, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x73, 0x69, 0x67, 0x6e,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x67, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x72, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x3a,
+	0x0a, 0x19, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x5f, 0x62, 0x6f, 0x6f,
+	0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x68, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x17, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x42, 0x6f, 0x6f,
+	0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x44, 0x61, 0x74, 0x61, 0x12, 0x44, 0x0a, 0x1e, 0x70, 0x72,
+	0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x5f, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70,
+	0x5f, 0x63, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x18, 0x69, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x1c, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x4f, 0x77, 0x6e, 0x65,
+	0x72, 0x73, 0x68, 0x69, 0x70, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65,
+	0x12, 0x3e, 0x0a, 0x1b, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x5f, 0x72, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18,
+	0x6a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x19, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65,
+	0x12, 0x50, 0x0a, 0x13, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x5f, 0x61, 0x6c,
+	0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x18, 0x6b, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1f, 0x2e,
+	0x62, 0x6f, 0x6f, 0x74, 0x7a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x69, 0x67, 0x6e,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x52, 0x12,
+	0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74,
+	0x68, 0x6d, 0x12, 0x61, 0x0a, 0x1c, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x5f, 0x73,
+	0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x5f, 0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74,
+	0x68, 0x6d, 0x18, 0x6c, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1f, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x7a,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65,
+	0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x52, 0x1a, 0x70, 0x72, 0x65, 0x76, 0x69,
+	0x6f, 0x75, 0x73, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x41, 0x6c, 0x67, 0x6f,
+	0x72, 0x69, 0x74, 0x68, 0x6d, 0x22, 0x9a, 0x01, 0x0a, 0x0d, 0x53, 0x6f, 0x66, 0x74, 0x77, 0x61,
+	0x72, 0x65, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x22, 0x0a, 0x0d, 0x6f, 0x73, 0x5f, 0x69, 0x6d,
+	0x61, 0x67, 0x65, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x6f, 0x73, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x48, 0x61, 0x73, 0x68, 0x12, 0x25, 0x0a, 0x0e, 0x68,
+	0x61, 0x73, 0x68, 0x5f, 0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0d, 0x68, 0x61, 0x73, 0x68, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74,
+	0x68, 0x6d, 0x22, 0xab, 0x02, 0x0a, 0x0b, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61,
+	0x6c, 0x73, 0x12, 0x4c, 0x0a, 0x0b, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x67, 0x6e, 0x73, 0x69, 0x2e, 0x63,
+	0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x7a, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x75,
+	0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x65, 0x64, 0x4b, 0x65, 0x79, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x52, 0x0b, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73,
+	0x12, 0x41, 0x0a, 0x05, 0x75, 0x73, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x2b, 0x2e, 0x67, 0x6e, 0x73, 0x69, 0x2e, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61,
+	0x6c, 0x7a, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x65, 0x64,
+	0x55, 0x73, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x05, 0x75, 0x73,
+	0x65, 0x72, 0x73, 0x12, 0x42, 0x0a, 0x09, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x73,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x67, 0x6e, 0x73, 0x69, 0x2e, 0x63, 0x72,
+	0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x7a, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x73,
+	0x73, 0x77, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x09, 0x70, 0x61,
+	0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x12, 0x47, 0x0a, 0x0b, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x5f, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x67,
+	0x6e, 0x73, 0x69, 0x2e, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x7a, 0x2e,
+	0x76, 0x31, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4b, 0x65, 0x79, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x52, 0x0a, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4b, 0x65, 0x79, 0x73,
+	0x22, 0xac, 0x02, 0x0a, 0x0a, 0x42, 0x6f, 0x6f, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12,
+	0x33, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x12, 0x23, 0x0a, 0x0d, 0x76, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x5f, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x76, 0x65, 0x6e,
+	0x64, 0x6f, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x1b, 0x0a, 0x09, 0x6f, 0x63, 0x5f,
+	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x6f, 0x63,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x44, 0x0a, 0x11, 0x62, 0x6f, 0x6f, 0x74, 0x6c, 0x6f,
+	0x61, 0x64, 0x65, 0x72, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x10, 0x62, 0x6f, 0x6f, 0x74,
+	0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x28, 0x0a, 0x10,
+	0x67, 0x6e, 0x6d, 0x69, 0x5f, 0x73, 0x65, 0x74, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e, 0x67, 0x6e, 0x6d, 0x69, 0x53, 0x65, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x37, 0x0a, 0x08, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x69,
+	0x6e, 0x67, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x7a,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x45, 0x6e, 0x63,
+	0x6f, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x08, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x22,
+	0xfb, 0x02, 0x0a, 0x13, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x48, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x30, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x7a, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x42, 0x6f, 0x6f, 0x74, 0x73, 0x74,
+	0x72, 0x61, 0x70, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x35, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x7a,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x43, 0x61,
+	0x72, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x65, 0x73, 0x12,
+	0x2a, 0x0a, 0x11, 0x73, 0x74, 0x61, 0x67, 0x69, 0x6e, 0x67, 0x5f, 0x62, 0x75, 0x6e, 0x64, 0x6c,
+	0x65, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x73, 0x74, 0x61, 0x67,
+	0x69, 0x6e, 0x67, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x49, 0x64, 0x22, 0x8f, 0x01, 0x0a, 0x0f,
+	0x42, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
+	0x20, 0x0a, 0x1c, 0x42, 0x4f, 0x4f, 0x54, 0x53, 0x54, 0x52, 0x41, 0x50, 0x5f, 0x53, 0x54, 0x41,
+	0x54, 0x55, 0x53, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10,
+	0x00, 0x12, 0x1c, 0x0a, 0x18, 0x42, 0x4f, 0x4f, 0x54, 0x53, 0x54, 0x52, 0x41, 0x50, 0x5f, 0x53,
+	0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x53, 0x55, 0x43, 0x43, 0x45, 0x53, 0x53, 0x10, 0x01, 0x12,
+	0x1c, 0x0a, 0x18, 0x42, 0x4f, 0x4f, 0x54, 0x53, 0x54, 0x52, 0x41, 0x50, 0x5f, 0x53, 0x54, 0x41,
+	0x54, 0x55, 0x53, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x55, 0x52, 0x45, 0x10, 0x02, 0x12, 0x1e, 0x0a,
+	0x1a, 0x42, 0x4f, 0x4f, 0x54, 0x53, 0x54, 0x52, 0x41, 0x50, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55,
+	0x53, 0x5f, 0x49, 0x4e, 0x49, 0x54, 0x49, 0x41, 0x54, 0x45, 0x44, 0x10, 0x03, 0x22, 0xaf, 0x01,
+	0x0a, 0x19, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69,
+	0x74, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x4d, 0x0a, 0x12, 0x63,
+	0x68, 0x61, 0x73, 0x73, 0x69, 0x73, 0x5f, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f,
+	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x7a, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x68, 0x61, 0x73, 0x73, 0x69, 0x73, 0x44, 0x65, 0x73,
+	0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x52, 0x11, 0x63, 0x68, 0x61, 0x73, 0x73, 0x69, 0x73,
+	0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x12, 0x43, 0x0a, 0x0c, 0x63, 0x61,
+	0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1f, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x7a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44,
+	0x65, 0x76, 0x69, 0x63, 0x65, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65,
+	0x73, 0x52, 0x0c, 0x63, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x22,
+	0x9c, 0x01, 0x0a, 0x12, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69,
+	0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x65, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x66, 0x65, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x73, 0x12, 0x40, 0x0a, 0x0e, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x5f, 0x74,
+	0x79, 0x70, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x62, 0x6f, 0x6f,
+	0x74, 0x7a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63,
+	0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0d, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x54,
+	0x79, 0x70, 0x65, 0x73, 0x12, 0x28, 0x0a, 0x10, 0x6d, 0x61, 0x78, 0x5f, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e,
+	0x6d, 0x61, 0x78, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x22, 0x0f,
+	0x0a, 0x0d, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2a,
+	0xfb, 0x01, 0x0a, 0x12, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x41, 0x6c, 0x67,
+	0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x12, 0x23, 0x0a, 0x1f, 0x53, 0x49, 0x47, 0x4e, 0x41, 0x54,
+	0x55, 0x52, 0x45, 0x5f, 0x41, 0x4c, 0x47, 0x4f, 0x52, 0x49, 0x54, 0x48, 0x4d, 0x5f, 0x55, 0x4e,
+	0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x2b, 0x0a, 0x27, 0x53,
+	0x49, 0x47, 0x4e, 0x41, 0x54, 0x55, 0x52, 0x45, 0x5f, 0x41, 0x4c, 0x47, 0x4f, 0x52, 0x49, 0x54,
+	0x48, 0x4d, 0x5f, 0x52, 0x53, 0x41, 0x5f, 0x50, 0x4b, 0x43, 0x53, 0x31, 0x56, 0x31, 0x35, 0x5f,
+	0x53, 0x48, 0x41, 0x32, 0x35, 0x36, 0x10, 0x01, 0x12, 0x26, 0x0a, 0x22, 0x53, 0x49, 0x47, 0x4e,
+	0x41, 0x54, 0x55, 0x52, 0x45, 0x5f, 0x41, 0x4c, 0x47, 0x4f, 0x52, 0x49, 0x54, 0x48, 0x4d, 0x5f,
+	0x52, 0x53, 0x41, 0x5f, 0x50, 0x53, 0x53, 0x5f, 0x53, 0x48, 0x41, 0x32, 0x35, 0x36, 0x10, 0x02,
+	0x12, 0x24, 0x0a, 0x20, 0x53, 0x49, 0x47, 0x4e, 0x41, 0x54, 0x55, 0x52, 0x45, 0x5f, 0x41, 0x4c,
+	0x47, 0x4f, 0x52, 0x49, 0x54, 0x48, 0x4d, 0x5f, 0x45, 0x43, 0x44, 0x53, 0x41, 0x5f, 0x53, 0x48,
+	0x41, 0x32, 0x35, 0x36, 0x10, 0x03, 0x12, 0x1f, 0x0a, 0x1b, 0x53, 0x49, 0x47, 0x4e, 0x41, 0x54,
+	0x55, 0x52, 0x45, 0x5f, 0x41, 0x4c, 0x47, 0x4f, 0x52, 0x49, 0x54, 0x48, 0x4d, 0x5f, 0x45, 0x44,
+	0x32, 0x35, 0x35, 0x31, 0x39, 0x10, 0x04, 0x12, 0x24, 0x0a, 0x20, 0x53, 0x49, 0x47, 0x4e, 0x41,
+	0x54, 0x55, 0x52, 0x45, 0x5f, 0x41, 0x4c, 0x47, 0x4f, 0x52, 0x49, 0x54, 0x48, 0x4d, 0x5f, 0x43,
+	0x4d, 0x53, 0x5f, 0x44, 0x45, 0x54, 0x41, 0x43, 0x48, 0x45, 0x44, 0x10, 0x05, 0x2a, 0x9c, 0x01,
+	0x0a, 0x0e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67,
+	0x12, 0x1f, 0x0a, 0x1b, 0x43, 0x4f, 0x4e, 0x46, 0x49, 0x47, 0x5f, 0x45, 0x4e, 0x43, 0x4f, 0x44,
+	0x49, 0x4e, 0x47, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10,
+	0x00, 0x12, 0x1e, 0x0a, 0x1a, 0x43, 0x4f, 0x4e, 0x46, 0x49, 0x47, 0x5f, 0x45, 0x4e, 0x43, 0x4f,
+	0x44, 0x49, 0x4e, 0x47, 0x5f, 0x56, 0x45, 0x4e, 0x44, 0x4f, 0x52, 0x5f, 0x43, 0x4c, 0x49, 0x10,
+	0x01, 0x12, 0x23, 0x0a, 0x1f, 0x43, 0x4f, 0x4e, 0x46, 0x49, 0x47, 0x5f, 0x45, 0x4e, 0x43, 0x4f,
+	0x44, 0x49, 0x4e, 0x47, 0x5f, 0x4f, 0x50, 0x45, 0x4e, 0x43, 0x4f, 0x4e, 0x46, 0x49, 0x47, 0x5f,
+	0x4a, 0x53, 0x4f, 0x4e, 0x10, 0x02, 0x12, 0x24, 0x0a, 0x20, 0x43, 0x4f, 0x4e, 0x46, 0x49, 0x47,
+	0x5f, 0x45, 0x4e, 0x43, 0x4f, 0x44, 0x49, 0x4e, 0x47, 0x5f, 0x47, 0x4e, 0x4d, 0x49, 0x5f, 0x53,
+	0x45, 0x54, 0x5f, 0x52, 0x45, 0x51, 0x55, 0x45, 0x53, 0x54, 0x10, 0x03, 0x2a, 0xf9, 0x01, 0x0a,
+	0x0c, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1d, 0x0a,
+	0x19, 0x41, 0x52, 0x54, 0x49, 0x46, 0x41, 0x43, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55,
+	0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1f, 0x0a, 0x1b,
+	0x41, 0x52, 0x54, 0x49, 0x46, 0x41, 0x43, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x56, 0x45,
+	0x4e, 0x44, 0x4f, 0x52, 0x5f, 0x43, 0x4f, 0x4e, 0x46, 0x49, 0x47, 0x10, 0x01, 0x12, 0x1b, 0x0a,
+	0x17, 0x41, 0x52, 0x54, 0x49, 0x46, 0x41, 0x43, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4f,
+	0x43, 0x5f, 0x43, 0x4f, 0x4e, 0x46, 0x49, 0x47, 0x10, 0x02, 0x12, 0x1d, 0x0a, 0x19, 0x41, 0x52,
+	0x54, 0x49, 0x46, 0x41, 0x43, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x43, 0x52, 0x45, 0x44,
+	0x45, 0x4e, 0x54, 0x49, 0x41, 0x4c, 0x53, 0x10, 0x03, 0x12, 0x17, 0x0a, 0x13, 0x41, 0x52, 0x54,
+	0x49, 0x46, 0x41, 0x43, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x50, 0x41, 0x54, 0x48, 0x5a,
+	0x10, 0x04, 0x12, 0x17, 0x0a, 0x13, 0x41, 0x52, 0x54, 0x49, 0x46, 0x41, 0x43, 0x54, 0x5f, 0x54,
+	0x59, 0x50, 0x45, 0x5f, 0x41, 0x55, 0x54, 0x48, 0x5a, 0x10, 0x05, 0x12, 0x17, 0x0a, 0x13, 0x41,
+	0x52, 0x54, 0x49, 0x46, 0x41, 0x43, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x43, 0x45, 0x52,
+	0x54, 0x5a, 0x10, 0x06, 0x12, 0x22, 0x0a, 0x1e, 0x41, 0x52, 0x54, 0x49, 0x46, 0x41, 0x43, 0x54,
+	0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x47, 0x4e, 0x4d, 0x49, 0x5f, 0x53, 0x45, 0x54, 0x5f, 0x52,
+	0x45, 0x51, 0x55, 0x45, 0x53, 0x54, 0x10, 0x07, 0x2a, 0x53, 0x0a, 0x08, 0x42, 0x6f, 0x6f, 0x74,
+	0x4d, 0x6f, 0x64, 0x65, 0x12, 0x19, 0x0a, 0x15, 0x42, 0x4f, 0x4f, 0x54, 0x5f, 0x4d, 0x4f, 0x44,
+	0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12,
+	0x16, 0x0a, 0x12, 0x42, 0x4f, 0x4f, 0x54, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x49, 0x4e, 0x53,
+	0x45, 0x43, 0x55, 0x52, 0x45, 0x10, 0x01, 0x12, 0x14, 0x0a, 0x10, 0x42, 0x4f, 0x4f, 0x54, 0x5f,
+	0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x53, 0x45, 0x43, 0x55, 0x52, 0x45, 0x10, 0x02, 0x32, 0xff, 0x02,
+	0x0a, 0x09, 0x42, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x12, 0x61, 0x0a, 0x10, 0x47,
+	0x65, 0x74, 0x42, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x44, 0x61, 0x74, 0x61, 0x12,
+	0x24, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x7a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65,
+	0x74, 0x42, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x7a, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70,
+	0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4e,
+	0x0a, 0x0c, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x20,
+	0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x7a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x70,
+	0x6f, 0x72, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1a, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x7a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45,
+	0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x5a,
+	0x0a, 0x12, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69,
+	0x74, 0x69, 0x65, 0x73, 0x12, 0x26, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x7a, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c,
+	0x69, 0x74, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x62,
 	0x6f, 0x6f, 0x74, 0x7a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x29, 0x5a, 0x27, 0x67, 0x69,
-	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6f, 0x70, 0x65, 0x6e, 0x63, 0x6f, 0x6e,
-	0x66, 0x69, 0x67, 0x2f, 0x62, 0x6f, 0x6f, 0x74, 0x7a, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f,
-	0x62, 0x6f, 0x6f, 0x74, 0x7a, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x63, 0x0a, 0x16, 0x47, 0x65,
+	0x74, 0x42, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x44, 0x61, 0x74, 0x61, 0x53, 0x74,
+	0x72, 0x65, 0x61, 0x6d, 0x12, 0x24, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x7a, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x44,
+	0x61, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x62, 0x6f, 0x6f,
+	0x74, 0x7a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x42, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72,
+	0x61, 0x70, 0x44, 0x61, 0x74, 0x61, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x22, 0x00, 0x30, 0x01, 0x42,
+	0x29, 0x5a, 0x27, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6f, 0x70,
+	0x65, 0x6e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2f, 0x62, 0x6f, 0x6f, 0x74, 0x7a, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x62, 0x6f, 0x6f, 0x74, 0x7a, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
 }
 
 var (
@@ -1211,61 +2114,90 @@ func file_proto_bootz_proto_rawDescGZIP() []byte {
 	return file_proto_bootz_proto_rawDescData
 }
 
-var file_proto_bootz_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_proto_bootz_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_proto_bootz_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
+var file_proto_bootz_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
 var file_proto_bootz_proto_goTypes = []interface{}{
-	(BootMode)(0),                              // 0: bootz.proto.BootMode
-	(ControlCardState_ControlCardStatus)(0),    // 1: bootz.proto.ControlCardState.ControlCardStatus
-	(ReportStatusRequest_BootstrapStatus)(0),   // 2: bootz.proto.ReportStatusRequest.BootstrapStatus
-	(*GetBootstrapDataRequest)(nil),            // 3: bootz.proto.GetBootstrapDataRequest
-	(*ChassisDescriptor)(nil),                  // 4: bootz.proto.ChassisDescriptor
-	(*ControlCard)(nil),                        // 5: bootz.proto.ControlCard
-	(*ControlCardState)(nil),                   // 6: bootz.proto.ControlCardState
-	(*BootstrapDataResponse)(nil),              // 7: bootz.proto.BootstrapDataResponse
-	(*BootstrapDataSigned)(nil),                // 8: bootz.proto.BootstrapDataSigned
-	(*GetBootstrapDataResponse)(nil),           // 9: bootz.proto.GetBootstrapDataResponse
-	(*SoftwareImage)(nil),                      // 10: bootz.proto.SoftwareImage
-	(*Credentials)(nil),                        // 11: bootz.proto.Credentials
-	(*BootConfig)(nil),                         // 12: bootz.proto.BootConfig
-	(*ReportStatusRequest)(nil),                // 13: bootz.proto.ReportStatusRequest
-	(*EmptyResponse)(nil),                      // 14: bootz.proto.EmptyResponse
-	(*pathz.UploadRequest)(nil),                // 15: gnsi.pathz.v1.UploadRequest
-	(*authz.UploadRequest)(nil),                // 16: gnsi.authz.v1.UploadRequest
-	(*certz.UploadRequest)(nil),                // 17: gnsi.certz.v1.UploadRequest
-	(*credentialz.AuthorizedKeysRequest)(nil),  // 18: gnsi.credentialz.v1.AuthorizedKeysRequest
-	(*credentialz.AuthorizedUsersRequest)(nil), // 19: gnsi.credentialz.v1.AuthorizedUsersRequest
-	(*credentialz.PasswordRequest)(nil),        // 20: gnsi.credentialz.v1.PasswordRequest
-	(*structpb.Struct)(nil),                    // 21: google.protobuf.Struct
+	(SignatureAlgorithm)(0),                    // 0: bootz.proto.SignatureAlgorithm
+	(ConfigEncoding)(0),                        // 1: bootz.proto.ConfigEncoding
+	(ArtifactType)(0),                          // 2: bootz.proto.ArtifactType
+	(BootMode)(0),                              // 3: bootz.proto.BootMode
+	(ControlCardState_ControlCardStatus)(0),    // 4: bootz.proto.ControlCardState.ControlCardStatus
+	(ReportStatusRequest_BootstrapStatus)(0),   // 5: bootz.proto.ReportStatusRequest.BootstrapStatus
+	(*BootstrapDataChunk)(nil),                 // 6: bootz.proto.BootstrapDataChunk
+	(*GetBootstrapDataRequest)(nil),            // 7: bootz.proto.GetBootstrapDataRequest
+	(*ChassisDescriptor)(nil),                  // 8: bootz.proto.ChassisDescriptor
+	(*Module)(nil),                             // 9: bootz.proto.Module
+	(*ControlCard)(nil),                        // 10: bootz.proto.ControlCard
+	(*ControlCardState)(nil),                   // 11: bootz.proto.ControlCardState
+	(*BootstrapDataResponse)(nil),              // 12: bootz.proto.BootstrapDataResponse
+	(*BootstrapDataSigned)(nil),                // 13: bootz.proto.BootstrapDataSigned
+	(*RedirectInfo)(nil),                       // 14: bootz.proto.RedirectInfo
+	(*RedirectServer)(nil),                     // 15: bootz.proto.RedirectServer
+	(*StagingBundle)(nil),                      // 16: bootz.proto.StagingBundle
+	(*GetBootstrapDataResponse)(nil),           // 17: bootz.proto.GetBootstrapDataResponse
+	(*SoftwareImage)(nil),                      // 18: bootz.proto.SoftwareImage
+	(*Credentials)(nil),                        // 19: bootz.proto.Credentials
+	(*BootConfig)(nil),                         // 20: bootz.proto.BootConfig
+	(*ReportStatusRequest)(nil),                // 21: bootz.proto.ReportStatusRequest
+	(*ReportCapabilitiesRequest)(nil),          // 22: bootz.proto.ReportCapabilitiesRequest
+	(*DeviceCapabilities)(nil),                 // 23: bootz.proto.DeviceCapabilities
+	(*EmptyResponse)(nil),                      // 24: bootz.proto.EmptyResponse
+	(*pathz.UploadRequest)(nil),                // 25: gnsi.pathz.v1.UploadRequest
+	(*authz.UploadRequest)(nil),                // 26: gnsi.authz.v1.UploadRequest
+	(*certz.UploadRequest)(nil),                // 27: gnsi.certz.v1.UploadRequest
+	(*credentialz.AuthorizedKeysRequest)(nil),  // 28: gnsi.credentialz.v1.AuthorizedKeysRequest
+	(*credentialz.AuthorizedUsersRequest)(nil), // 29: gnsi.credentialz.v1.AuthorizedUsersRequest
+	(*credentialz.PasswordRequest)(nil),        // 30: gnsi.credentialz.v1.PasswordRequest
+	(*credentialz.ServerKeysRequest)(nil),      // 31: gnsi.credentialz.v1.ServerKeysRequest
+	(*structpb.Struct)(nil),                    // 32: google.protobuf.Struct
 }
 var file_proto_bootz_proto_depIdxs = []int32{
-	4,  // 0: bootz.proto.GetBootstrapDataRequest.chassis_descriptor:type_name -> bootz.proto.ChassisDescriptor
-	6,  // 1: bootz.proto.GetBootstrapDataRequest.control_card_state:type_name -> bootz.proto.ControlCardState
-	5,  // 2: bootz.proto.ChassisDescriptor.control_cards:type_name -> bootz.proto.ControlCard
-	1,  // 3: bootz.proto.ControlCardState.status:type_name -> bootz.proto.ControlCardState.ControlCardStatus
-	10, // 4: bootz.proto.BootstrapDataResponse.intended_image:type_name -> bootz.proto.SoftwareImage
-	12, // 5: bootz.proto.BootstrapDataResponse.boot_config:type_name -> bootz.proto.BootConfig
-	11, // 6: bootz.proto.BootstrapDataResponse.credentials:type_name -> bootz.proto.Credentials
-	15, // 7: bootz.proto.BootstrapDataResponse.pathz:type_name -> gnsi.pathz.v1.UploadRequest
-	16, // 8: bootz.proto.BootstrapDataResponse.authz:type_name -> gnsi.authz.v1.UploadRequest
-	17, // 9: bootz.proto.BootstrapDataResponse.certificates:type_name -> gnsi.certz.v1.UploadRequest
-	7,  // 10: bootz.proto.BootstrapDataSigned.responses:type_name -> bootz.proto.BootstrapDataResponse
-	8,  // 11: bootz.proto.GetBootstrapDataResponse.signed_response:type_name -> bootz.proto.BootstrapDataSigned
-	18, // 12: bootz.proto.Credentials.credentials:type_name -> gnsi.credentialz.v1.AuthorizedKeysRequest
-	19, // 13: bootz.proto.Credentials.users:type_name -> gnsi.credentialz.v1.AuthorizedUsersRequest
-	20, // 14: bootz.proto.Credentials.passwords:type_name -> gnsi.credentialz.v1.PasswordRequest
-	21, // 15: bootz.proto.BootConfig.metadata:type_name -> google.protobuf.Struct
-	21, // 16: bootz.proto.BootConfig.bootloader_config:type_name -> google.protobuf.Struct
-	2,  // 17: bootz.proto.ReportStatusRequest.status:type_name -> bootz.proto.ReportStatusRequest.BootstrapStatus
-	6,  // 18: bootz.proto.ReportStatusRequest.states:type_name -> bootz.proto.ControlCardState
-	3,  // 19: bootz.proto.Bootstrap.GetBootstrapData:input_type -> bootz.proto.GetBootstrapDataRequest
-	13, // 20: bootz.proto.Bootstrap.ReportStatus:input_type -> bootz.proto.ReportStatusRequest
-	9,  // 21: bootz.proto.Bootstrap.GetBootstrapData:output_type -> bootz.proto.GetBootstrapDataResponse
-	14, // 22: bootz.proto.Bootstrap.ReportStatus:output_type -> bootz.proto.EmptyResponse
-	21, // [21:23] is the sub-list for method output_type
-	19, // [19:21] is the sub-list for method input_type
-	19, // [19:19] is the sub-list for extension type_name
-	19, // [19:19] is the sub-list for extension extendee
-	0,  // [0:19] is the sub-list for field type_name
+	8,  // 0: bootz.proto.GetBootstrapDataRequest.chassis_descriptor:type_name -> bootz.proto.ChassisDescriptor
+	11, // 1: bootz.proto.GetBootstrapDataRequest.control_card_state:type_name -> bootz.proto.ControlCardState
+	0,  // 2: bootz.proto.GetBootstrapDataRequest.signature_algorithms:type_name -> bootz.proto.SignatureAlgorithm
+	10, // 3: bootz.proto.ChassisDescriptor.control_cards:type_name -> bootz.proto.ControlCard
+	9,  // 4: bootz.proto.ChassisDescriptor.line_cards:type_name -> bootz.proto.Module
+	9,  // 5: bootz.proto.ChassisDescriptor.fabric_modules:type_name -> bootz.proto.Module
+	4,  // 6: bootz.proto.ControlCardState.status:type_name -> bootz.proto.ControlCardState.ControlCardStatus
+	18, // 7: bootz.proto.BootstrapDataResponse.intended_image:type_name -> bootz.proto.SoftwareImage
+	20, // 8: bootz.proto.BootstrapDataResponse.boot_config:type_name -> bootz.proto.BootConfig
+	19, // 9: bootz.proto.BootstrapDataResponse.credentials:type_name -> bootz.proto.Credentials
+	25, // 10: bootz.proto.BootstrapDataResponse.pathz:type_name -> gnsi.pathz.v1.UploadRequest
+	26, // 11: bootz.proto.BootstrapDataResponse.authz:type_name -> gnsi.authz.v1.UploadRequest
+	27, // 12: bootz.proto.BootstrapDataResponse.certificates:type_name -> gnsi.certz.v1.UploadRequest
+	12, // 13: bootz.proto.BootstrapDataSigned.responses:type_name -> bootz.proto.BootstrapDataResponse
+	14, // 14: bootz.proto.BootstrapDataSigned.redirect:type_name -> bootz.proto.RedirectInfo
+	15, // 15: bootz.proto.RedirectInfo.servers:type_name -> bootz.proto.RedirectServer
+	8,  // 16: bootz.proto.StagingBundle.chassis_descriptor:type_name -> bootz.proto.ChassisDescriptor
+	17, // 17: bootz.proto.StagingBundle.response:type_name -> bootz.proto.GetBootstrapDataResponse
+	13, // 18: bootz.proto.GetBootstrapDataResponse.signed_response:type_name -> bootz.proto.BootstrapDataSigned
+	0,  // 19: bootz.proto.GetBootstrapDataResponse.signature_algorithm:type_name -> bootz.proto.SignatureAlgorithm
+	0,  // 20: bootz.proto.GetBootstrapDataResponse.previous_signature_algorithm:type_name -> bootz.proto.SignatureAlgorithm
+	28, // 21: bootz.proto.Credentials.credentials:type_name -> gnsi.credentialz.v1.AuthorizedKeysRequest
+	29, // 22: bootz.proto.Credentials.users:type_name -> gnsi.credentialz.v1.AuthorizedUsersRequest
+	30, // 23: bootz.proto.Credentials.passwords:type_name -> gnsi.credentialz.v1.PasswordRequest
+	31, // 24: bootz.proto.Credentials.server_keys:type_name -> gnsi.credentialz.v1.ServerKeysRequest
+	32, // 25: bootz.proto.BootConfig.metadata:type_name -> google.protobuf.Struct
+	32, // 26: bootz.proto.BootConfig.bootloader_config:type_name -> google.protobuf.Struct
+	1,  // 27: bootz.proto.BootConfig.encoding:type_name -> bootz.proto.ConfigEncoding
+	5,  // 28: bootz.proto.ReportStatusRequest.status:type_name -> bootz.proto.ReportStatusRequest.BootstrapStatus
+	11, // 29: bootz.proto.ReportStatusRequest.states:type_name -> bootz.proto.ControlCardState
+	8,  // 30: bootz.proto.ReportCapabilitiesRequest.chassis_descriptor:type_name -> bootz.proto.ChassisDescriptor
+	23, // 31: bootz.proto.ReportCapabilitiesRequest.capabilities:type_name -> bootz.proto.DeviceCapabilities
+	2,  // 32: bootz.proto.DeviceCapabilities.artifact_types:type_name -> bootz.proto.ArtifactType
+	7,  // 33: bootz.proto.Bootstrap.GetBootstrapData:input_type -> bootz.proto.GetBootstrapDataRequest
+	21, // 34: bootz.proto.Bootstrap.ReportStatus:input_type -> bootz.proto.ReportStatusRequest
+	22, // 35: bootz.proto.Bootstrap.ReportCapabilities:input_type -> bootz.proto.ReportCapabilitiesRequest
+	7,  // 36: bootz.proto.Bootstrap.GetBootstrapDataStream:input_type -> bootz.proto.GetBootstrapDataRequest
+	17, // 37: bootz.proto.Bootstrap.GetBootstrapData:output_type -> bootz.proto.GetBootstrapDataResponse
+	24, // 38: bootz.proto.Bootstrap.ReportStatus:output_type -> bootz.proto.EmptyResponse
+	24, // 39: bootz.proto.Bootstrap.ReportCapabilities:output_type -> bootz.proto.EmptyResponse
+	6,  // 40: bootz.proto.Bootstrap.GetBootstrapDataStream:output_type -> bootz.proto.BootstrapDataChunk
+	37, // [37:41] is the sub-list for method output_type
+	33, // [33:37] is the sub-list for method input_type
+	33, // [33:33] is the sub-list for extension type_name
+	33, // [33:33] is the sub-list for extension extendee
+	0,  // [0:33] is the sub-list for field type_name
 }
 
 func init() { file_proto_bootz_proto_init() }
@@ -1275,7 +2207,7 @@ func file_proto_bootz_proto_init() {
 	}
 	if !protoimpl.UnsafeEnabled {
 		file_proto_bootz_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetBootstrapDataRequest); i {
+			switch v := v.(*BootstrapDataChunk); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1287,7 +2219,7 @@ func file_proto_bootz_proto_init() {
 			}
 		}
 		file_proto_bootz_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ChassisDescriptor); i {
+			switch v := v.(*GetBootstrapDataRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1299,7 +2231,7 @@ func file_proto_bootz_proto_init() {
 			}
 		}
 		file_proto_bootz_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ControlCard); i {
+			switch v := v.(*ChassisDescriptor); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1311,7 +2243,7 @@ func file_proto_bootz_proto_init() {
 			}
 		}
 		file_proto_bootz_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ControlCardState); i {
+			switch v := v.(*Module); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1323,7 +2255,7 @@ func file_proto_bootz_proto_init() {
 			}
 		}
 		file_proto_bootz_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BootstrapDataResponse); i {
+			switch v := v.(*ControlCard); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1335,7 +2267,7 @@ func file_proto_bootz_proto_init() {
 			}
 		}
 		file_proto_bootz_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BootstrapDataSigned); i {
+			switch v := v.(*ControlCardState); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1347,7 +2279,7 @@ func file_proto_bootz_proto_init() {
 			}
 		}
 		file_proto_bootz_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetBootstrapDataResponse); i {
+			switch v := v.(*BootstrapDataResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1359,7 +2291,7 @@ func file_proto_bootz_proto_init() {
 			}
 		}
 		file_proto_bootz_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SoftwareImage); i {
+			switch v := v.(*BootstrapDataSigned); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1371,7 +2303,7 @@ func file_proto_bootz_proto_init() {
 			}
 		}
 		file_proto_bootz_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Credentials); i {
+			switch v := v.(*RedirectInfo); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1383,7 +2315,7 @@ func file_proto_bootz_proto_init() {
 			}
 		}
 		file_proto_bootz_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BootConfig); i {
+			switch v := v.(*RedirectServer); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1395,7 +2327,7 @@ func file_proto_bootz_proto_init() {
 			}
 		}
 		file_proto_bootz_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ReportStatusRequest); i {
+			switch v := v.(*StagingBundle); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1407,6 +2339,90 @@ func file_proto_bootz_proto_init() {
 			}
 		}
 		file_proto_bootz_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetBootstrapDataResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_bootz_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SoftwareImage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_bootz_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Credentials); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_bootz_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BootConfig); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_bootz_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReportStatusRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_bootz_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReportCapabilitiesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_bootz_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeviceCapabilities); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_bootz_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*EmptyResponse); i {
 			case 0:
 				return &v.state
@@ -1424,8 +2440,8 @@ func file_proto_bootz_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_proto_bootz_proto_rawDesc,
-			NumEnums:      3,
-			NumMessages:   12,
+			NumEnums:      6,
+			NumMessages:   19,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -1454,6 +2470,8 @@ const _ = grpc.SupportPackageIsVersion6
 type BootstrapClient interface {
 	GetBootstrapData(ctx context.Context, in *GetBootstrapDataRequest, opts ...grpc.CallOption) (*GetBootstrapDataResponse, error)
 	ReportStatus(ctx context.Context, in *ReportStatusRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	ReportCapabilities(ctx context.Context, in *ReportCapabilitiesRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	GetBootstrapDataStream(ctx context.Context, in *GetBootstrapDataRequest, opts ...grpc.CallOption) (Bootstrap_GetBootstrapDataStreamClient, error)
 }
 
 type bootstrapClient struct {
@@ -1482,10 +2500,53 @@ func (c *bootstrapClient) ReportStatus(ctx context.Context, in *ReportStatusRequ
 	return out, nil
 }
 
+func (c *bootstrapClient) ReportCapabilities(ctx context.Context, in *ReportCapabilitiesRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, "/bootz.proto.Bootstrap/ReportCapabilities", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bootstrapClient) GetBootstrapDataStream(ctx context.Context, in *GetBootstrapDataRequest, opts ...grpc.CallOption) (Bootstrap_GetBootstrapDataStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Bootstrap_serviceDesc.Streams[0], "/bootz.proto.Bootstrap/GetBootstrapDataStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &bootstrapGetBootstrapDataStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Bootstrap_GetBootstrapDataStreamClient interface {
+	Recv() (*BootstrapDataChunk, error)
+	grpc.ClientStream
+}
+
+type bootstrapGetBootstrapDataStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *bootstrapGetBootstrapDataStreamClient) Recv() (*BootstrapDataChunk, error) {
+	m := new(BootstrapDataChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // BootstrapServer is the server API for Bootstrap service.
 type BootstrapServer interface {
 	GetBootstrapData(context.Context, *GetBootstrapDataRequest) (*GetBootstrapDataResponse, error)
 	ReportStatus(context.Context, *ReportStatusRequest) (*EmptyResponse, error)
+	ReportCapabilities(context.Context, *ReportCapabilitiesRequest) (*EmptyResponse, error)
+	GetBootstrapDataStream(*GetBootstrapDataRequest, Bootstrap_GetBootstrapDataStreamServer) error
 }
 
 // UnimplementedBootstrapServer can be embedded to have forward compatible implementations.
@@ -1498,6 +2559,12 @@ func (*UnimplementedBootstrapServer) GetBootstrapData(context.Context, *GetBoots
 func (*UnimplementedBootstrapServer) ReportStatus(context.Context, *ReportStatusRequest) (*EmptyResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ReportStatus not implemented")
 }
+func (*UnimplementedBootstrapServer) ReportCapabilities(context.Context, *ReportCapabilitiesRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportCapabilities not implemented")
+}
+func (*UnimplementedBootstrapServer) GetBootstrapDataStream(*GetBootstrapDataRequest, Bootstrap_GetBootstrapDataStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetBootstrapDataStream not implemented")
+}
 
 func RegisterBootstrapServer(s *grpc.Server, srv BootstrapServer) {
 	s.RegisterService(&_Bootstrap_serviceDesc, srv)
@@ -1539,6 +2606,45 @@ func _Bootstrap_ReportStatus_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Bootstrap_ReportCapabilities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportCapabilitiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BootstrapServer).ReportCapabilities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bootz.proto.Bootstrap/ReportCapabilities",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BootstrapServer).ReportCapabilities(ctx, req.(*ReportCapabilitiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bootstrap_GetBootstrapDataStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetBootstrapDataRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BootstrapServer).GetBootstrapDataStream(m, &bootstrapGetBootstrapDataStreamServer{stream})
+}
+
+type Bootstrap_GetBootstrapDataStreamServer interface {
+	Send(*BootstrapDataChunk) error
+	grpc.ServerStream
+}
+
+type bootstrapGetBootstrapDataStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *bootstrapGetBootstrapDataStreamServer) Send(m *BootstrapDataChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _Bootstrap_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "bootz.proto.Bootstrap",
 	HandlerType: (*BootstrapServer)(nil),
@@ -1551,7 +2657,17 @@ var _Bootstrap_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ReportStatus",
 			Handler:    _Bootstrap_ReportStatus_Handler,
 		},
+		{
+			MethodName: "ReportCapabilities",
+			Handler:    _Bootstrap_ReportCapabilities_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "GetBootstrapDataStream",
+			Handler:       _Bootstrap_GetBootstrapDataStream_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/bootz.proto",
 }
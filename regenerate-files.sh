@@ -4,6 +4,7 @@ set -euo pipefail
 BASE=$(bazel  info bazel-genfiles)
 BOOTZ_NS='github.com/openconfig/bootz/proto'
 ENTITY_NS='github.com/openconfig/bootz/server/entitymanager/proto'
+ADMIN_NS='github.com/openconfig/bootz/server/admin/proto'
 
 copy_generated() {
   pkg="$1"
@@ -19,7 +20,8 @@ copy_generated() {
 
 bazel build //proto:all
 bazel build //server/entitymanager/proto:all
+bazel build //server/admin/proto:all
 # first arg is the package name, second arg is namespace for the package, and thrid is the location where the generated code will be saved. 
 copy_generated "bootz"  ${BOOTZ_NS}   "proto/"
-copy_generated "entity"  ${ENTITY_NS} "server/entitymanager/proto/"  
-
+copy_generated "entity"  ${ENTITY_NS} "server/entitymanager/proto/"
+copy_generated "admin"  ${ADMIN_NS} "server/admin/proto/"
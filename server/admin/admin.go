@@ -0,0 +1,429 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package admin implements the operator facing Admin service of the bootz server.
+package admin
+
+import (
+	"context"
+	"time"
+
+	"github.com/openconfig/bootz/server/devicestate"
+	"github.com/openconfig/bootz/server/events"
+	"github.com/openconfig/bootz/server/experiment"
+	"github.com/openconfig/bootz/server/features"
+	"github.com/openconfig/bootz/server/revocation"
+	"github.com/openconfig/bootz/server/service"
+	"github.com/openconfig/bootz/server/token"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	apb "github.com/openconfig/bootz/server/admin/proto/admin"
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+	log "github.com/openconfig/bootz/server/logging"
+)
+
+// Server implements the Admin service.
+type Server struct {
+	apb.UnimplementedAdminServer
+	service     *service.Service
+	features    *features.Set
+	revocations *revocation.List
+	experiments *experiment.Tracker
+	states      *devicestate.Machine
+	snapshots   Snapshotter
+	inventory   Inventory
+	// domains and domainAdmins restrict domain admins to the devices of their ownership domain.
+	domains      Domains
+	domainAdmins map[string]string
+	// leadership restricts the inventory mutations to the leader of a cluster, if set.
+	leadership Leadership
+	// tokens are the one-time bootstrap tokens, if supported.
+	tokens *token.List
+	// rebooter reboots devices triggered to bootstrap again, if set.
+	rebooter Rebooter
+	// retirements retires devices from the inventory, if supported.
+	retirements Retirements
+}
+
+// Snapshotter dumps and restores the inventory and the state of the devices of the server.
+type Snapshotter interface {
+	Snapshot() *epb.Snapshot
+	RestoreSnapshot(*epb.Snapshot) error
+}
+
+// ListFeatureFlags returns the global value of every feature flag and all scoped overrides.
+// Domain admins only see the overrides of their tenant.
+func (s *Server) ListFeatureFlags(ctx context.Context, req *apb.ListFeatureFlagsRequest) (*apb.ListFeatureFlagsResponse, error) {
+	domain, err := s.callerDomain(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp := &apb.ListFeatureFlagsResponse{}
+	for _, setting := range s.features.Settings() {
+		if domain != "" && setting.Scope.Tenant != "" && setting.Scope.Tenant != domain {
+			continue
+		}
+		resp.Flags = append(resp.Flags, features.ToProto(setting))
+	}
+	return resp, nil
+}
+
+// SetFeatureFlag sets or clears a feature flag for a scope. Domain admins may only set the
+// flags of their tenant.
+func (s *Server) SetFeatureFlag(ctx context.Context, req *apb.SetFeatureFlagRequest) (*apb.SetFeatureFlagResponse, error) {
+	setting := features.FromProto(req.GetFlag())
+	domain, err := s.callerDomain(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if domain != "" && setting.Scope.Tenant != domain {
+		return nil, status.Errorf(codes.PermissionDenied, "admins of ownership domain %q may only set feature flags of tenant %q", domain, domain)
+	}
+	if !features.Known(setting.Flag) {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown feature flag %q", setting.Flag)
+	}
+	if req.GetClear() {
+		s.features.Clear(setting.Flag, setting.Scope)
+		log.Infof("Cleared feature flag %v for scope %+v", setting.Flag, setting.Scope)
+		return &apb.SetFeatureFlagResponse{}, nil
+	}
+	if err := s.features.Set(setting); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "unable to set feature flag: %v", err)
+	}
+	log.Infof("Set feature flag %v to %v for scope %+v", setting.Flag, setting.Enabled, setting.Scope)
+	return &apb.SetFeatureFlagResponse{}, nil
+}
+
+// ListRevokedOwnershipVouchers returns all revoked ownership vouchers.
+func (s *Server) ListRevokedOwnershipVouchers(ctx context.Context, req *apb.ListRevokedOwnershipVouchersRequest) (*apb.ListRevokedOwnershipVouchersResponse, error) {
+	domain, err := s.callerDomain(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp := &apb.ListRevokedOwnershipVouchersResponse{}
+	for _, e := range s.revocations.Entries() {
+		if !s.inDomain(domain, e.SerialNumber) {
+			continue
+		}
+		resp.Vouchers = append(resp.Vouchers, &apb.RevokedOwnershipVoucher{
+			SerialNumber: e.SerialNumber,
+			Reason:       e.Reason,
+			RevokedAt:    timestamppb.New(e.RevokedAt),
+		})
+	}
+	return resp, nil
+}
+
+// RevokeOwnershipVoucher revokes the ownership voucher issued for a serial number.
+func (s *Server) RevokeOwnershipVoucher(ctx context.Context, req *apb.RevokeOwnershipVoucherRequest) (*apb.RevokeOwnershipVoucherResponse, error) {
+	if err := s.authorizeDevices(ctx, req.GetSerialNumber()); err != nil {
+		return nil, err
+	}
+	if err := s.requireLeader(ctx); err != nil {
+		return nil, err
+	}
+	if err := s.revocations.Revoke(req.GetSerialNumber(), req.GetReason()); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "unable to revoke ownership voucher: %v", err)
+	}
+	s.publish(ctx)
+	log.Infof("Revoked ownership voucher for serial# %s: %s", req.GetSerialNumber(), req.GetReason())
+	return &apb.RevokeOwnershipVoucherResponse{}, nil
+}
+
+// GenerateStagingBundle generates a signed staging bundle for a chassis to be bootstrapped offline.
+func (s *Server) GenerateStagingBundle(ctx context.Context, req *apb.GenerateStagingBundleRequest) (*apb.GenerateStagingBundleResponse, error) {
+	if req.GetChassisDescriptor() == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "no chassis descriptor provided")
+	}
+	if err := s.authorizeDevices(ctx, descriptorSerials(req.GetChassisDescriptor())...); err != nil {
+		return nil, err
+	}
+	bundle, err := s.service.GenerateStagingBundle(ctx, req.GetChassisDescriptor())
+	if err != nil {
+		return nil, err
+	}
+	return &apb.GenerateStagingBundleResponse{Bundle: bundle}, nil
+}
+
+// ListStagingBundles returns the generated staging bundles and the status reported for them.
+func (s *Server) ListStagingBundles(ctx context.Context, req *apb.ListStagingBundlesRequest) (*apb.ListStagingBundlesResponse, error) {
+	domain, err := s.callerDomain(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp := &apb.ListStagingBundlesResponse{}
+	for _, rec := range s.service.StagingBundles() {
+		if !s.inDomain(domain, descriptorSerials(rec.ChassisDescriptor)...) {
+			continue
+		}
+		r := &apb.StagingBundleRecord{
+			BundleId:          rec.BundleID,
+			ChassisDescriptor: rec.ChassisDescriptor,
+			CreatedAt:         timestamppb.New(rec.CreatedAt),
+			Status:            rec.Status,
+		}
+		if !rec.ReportedAt.IsZero() {
+			r.ReportedAt = timestamppb.New(rec.ReportedAt)
+		}
+		resp.Bundles = append(resp.Bundles, r)
+	}
+	return resp, nil
+}
+
+// ListExperimentResults returns the outcome of each arm of the configured experiments.
+func (s *Server) ListExperimentResults(ctx context.Context, req *apb.ListExperimentResultsRequest) (*apb.ListExperimentResultsResponse, error) {
+	if err := s.authorizeServer(ctx); err != nil {
+		return nil, err
+	}
+	resp := &apb.ListExperimentResultsResponse{}
+	for _, r := range s.experiments.Results() {
+		resp.Experiments = append(resp.Experiments, &apb.ExperimentResult{
+			Name: r.Experiment,
+			Arms: []*apb.ExperimentArmResult{armResult(r.Control), armResult(r.Treatment)},
+		})
+	}
+	return resp, nil
+}
+
+// ListPlatformCapabilities returns the capabilities reported by devices to this instance,
+// summarized per platform.
+func (s *Server) ListPlatformCapabilities(ctx context.Context, req *apb.ListPlatformCapabilitiesRequest) (*apb.ListPlatformCapabilitiesResponse, error) {
+	if err := s.authorizeServer(ctx); err != nil {
+		return nil, err
+	}
+	resp := &apb.ListPlatformCapabilitiesResponse{}
+	for _, pc := range s.service.CapabilityStats() {
+		p := &apb.PlatformCapabilities{
+			Manufacturer:  pc.Manufacturer,
+			PartNumber:    pc.PartNumber,
+			Devices:       uint32(pc.Devices),
+			Features:      map[string]uint32{},
+			ArtifactTypes: map[string]uint32{},
+		}
+		for f, n := range pc.Features {
+			p.Features[f] = uint32(n)
+		}
+		for t, n := range pc.ArtifactTypes {
+			p.ArtifactTypes[t.String()] = uint32(n)
+		}
+		resp.Platforms = append(resp.Platforms, p)
+	}
+	return resp, nil
+}
+
+func armResult(r experiment.ArmResult) *apb.ExperimentArmResult {
+	return &apb.ExperimentArmResult{
+		Arm:                   string(r.Arm),
+		Assigned:              uint64(r.Assigned),
+		Succeeded:             uint64(r.Succeeded),
+		Failed:                uint64(r.Failed),
+		SuccessRate:           r.SuccessRate(),
+		MeanBootstrapDuration: durationpb.New(r.MeanDuration()),
+	}
+}
+
+// stages maps the bootstrap stages to their proto representation.
+var stages = map[events.Stage]apb.BootstrapStage{
+	events.Requested: apb.BootstrapStage_BOOTSTRAP_STAGE_REQUESTED,
+	events.Served:    apb.BootstrapStage_BOOTSTRAP_STAGE_SERVED,
+	events.Rejected:  apb.BootstrapStage_BOOTSTRAP_STAGE_REJECTED,
+	events.Initiated: apb.BootstrapStage_BOOTSTRAP_STAGE_INITIATED,
+	events.Succeeded: apb.BootstrapStage_BOOTSTRAP_STAGE_SUCCEEDED,
+	events.Failed:    apb.BootstrapStage_BOOTSTRAP_STAGE_FAILED,
+}
+
+// StreamBootstrapStatus streams the bootstrap status transitions of the requested devices until
+// the client cancels the stream. Domain admins must request the devices of their domain.
+func (s *Server) StreamBootstrapStatus(req *apb.StreamBootstrapStatusRequest, stream apb.Admin_StreamBootstrapStatusServer) error {
+	if len(req.GetSerialNumbers()) == 0 {
+		if err := s.authorizeServer(stream.Context()); err != nil {
+			return err
+		}
+	}
+	if err := s.authorizeDevices(stream.Context(), req.GetSerialNumbers()...); err != nil {
+		return err
+	}
+	ch, cancel := s.service.Events().Subscribe(req.GetSerialNumbers())
+	defer cancel()
+	log.Infof("Streaming bootstrap status of serial# %v", req.GetSerialNumbers())
+	for {
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case e := <-ch:
+			err := stream.Send(&apb.BootstrapStatusEvent{
+				SerialNumber:        e.SerialNumber,
+				ChassisSerialNumber: e.ChassisSerialNumber,
+				Manufacturer:        e.Manufacturer,
+				Stage:               stages[e.Stage],
+				Message:             e.Message,
+				Time:                timestamppb.New(e.Time),
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// deviceStates maps the device bootstrap states to their proto representation.
+var deviceStates = map[devicestate.State]apb.DeviceBootstrapState{
+	devicestate.Idle:          apb.DeviceBootstrapState_DEVICE_BOOTSTRAP_STATE_IDLE,
+	devicestate.BootstrapSent: apb.DeviceBootstrapState_DEVICE_BOOTSTRAP_STATE_BOOTSTRAP_SENT,
+	devicestate.StatusOK:      apb.DeviceBootstrapState_DEVICE_BOOTSTRAP_STATE_STATUS_OK,
+	devicestate.StatusFailed:  apb.DeviceBootstrapState_DEVICE_BOOTSTRAP_STATE_STATUS_FAILED,
+}
+
+var configStages = map[devicestate.ConfigStage]apb.DeviceConfigStage{
+	devicestate.SingleStage:  apb.DeviceConfigStage_DEVICE_CONFIG_STAGE_UNSPECIFIED,
+	devicestate.InitialStage: apb.DeviceConfigStage_DEVICE_CONFIG_STAGE_INITIAL,
+	devicestate.FinalStage:   apb.DeviceConfigStage_DEVICE_CONFIG_STAGE_FINAL,
+}
+
+// ListDeviceStates returns the bootstrap state of the requested devices, or of every device
+// the caller manages, optionally only of the devices silent since they were sent bootstrap data
+// or of those which applied artifacts other than served.
+func (s *Server) ListDeviceStates(ctx context.Context, req *apb.ListDeviceStatesRequest) (*apb.ListDeviceStatesResponse, error) {
+	if err := s.authorizeDevices(ctx, req.GetSerialNumbers()...); err != nil {
+		return nil, err
+	}
+	if req.GetSilentFor() != nil {
+		if err := req.GetSilentFor().CheckValid(); err != nil || req.GetSilentFor().AsDuration() <= 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "silent_for must be a positive duration")
+		}
+	}
+	var devices []devicestate.Device
+	if len(req.GetSerialNumbers()) == 0 {
+		domain, err := s.callerDomain(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range s.states.Devices() {
+			if s.inDomain(domain, d.SerialNumber) {
+				devices = append(devices, d)
+			}
+		}
+	}
+	for _, serial := range req.GetSerialNumbers() {
+		d, ok := s.states.Get(serial)
+		if !ok {
+			return nil, status.Errorf(codes.NotFound, "no bootstrap state for serial# %s", serial)
+		}
+		devices = append(devices, d)
+	}
+	resp := &apb.ListDeviceStatesResponse{}
+	now := time.Now()
+	for _, d := range devices {
+		if req.GetSilentFor() != nil && !d.Silent(now, req.GetSilentFor().AsDuration()) {
+			continue
+		}
+		if req.GetDrifted() && len(d.Drift()) == 0 {
+			continue
+		}
+		resp.Devices = append(resp.Devices, deviceState(d))
+	}
+	return resp, nil
+}
+
+func deviceState(d devicestate.Device) *apb.DeviceState {
+	st := &apb.DeviceState{
+		SerialNumber:      d.SerialNumber,
+		State:             deviceStates[d.State],
+		Retries:           uint32(d.Retries),
+		ChangedAt:         timestamppb.New(d.Changed),
+		Stage:             configStages[d.Stage],
+		ServedImageHash:   d.Served.ImageHash,
+		ServedConfigHash:  d.Served.ConfigHash,
+		AppliedImageHash:  d.Applied.ImageHash,
+		AppliedConfigHash: d.Applied.ConfigHash,
+		DriftedArtifacts:  d.Drift(),
+	}
+	if !d.SentAt.IsZero() {
+		st.SentAt = timestamppb.New(d.SentAt)
+	}
+	if !d.ReportedAt.IsZero() {
+		st.ReportedAt = timestamppb.New(d.ReportedAt)
+	}
+	if !d.LastSeen.IsZero() {
+		st.LastSeenAt = timestamppb.New(d.LastSeen)
+		st.LastAddress = d.LastAddress
+	}
+	return st
+}
+
+// ResetDeviceState resets the bootstrap state of a device to idle.
+func (s *Server) ResetDeviceState(ctx context.Context, req *apb.ResetDeviceStateRequest) (*apb.ResetDeviceStateResponse, error) {
+	if err := s.authorizeDevices(ctx, req.GetSerialNumber()); err != nil {
+		return nil, err
+	}
+	if err := s.states.Reset(req.GetSerialNumber()); err != nil {
+		return nil, err
+	}
+	log.Infof("Reset bootstrap state of serial# %s", req.GetSerialNumber())
+	return &apb.ResetDeviceStateResponse{}, nil
+}
+
+// ExportSnapshot dumps the inventory and the state of the devices to a snapshot.
+func (s *Server) ExportSnapshot(ctx context.Context, req *apb.ExportSnapshotRequest) (*apb.ExportSnapshotResponse, error) {
+	if err := s.authorizeServer(ctx); err != nil {
+		return nil, err
+	}
+	if s.snapshots == nil {
+		return nil, status.Errorf(codes.Unimplemented, "snapshots are not supported by the entity manager")
+	}
+	return &apb.ExportSnapshotResponse{Snapshot: s.snapshots.Snapshot()}, nil
+}
+
+// RestoreSnapshot replaces the inventory and the state of the devices with a snapshot.
+func (s *Server) RestoreSnapshot(ctx context.Context, req *apb.RestoreSnapshotRequest) (*apb.RestoreSnapshotResponse, error) {
+	if err := s.authorizeServer(ctx); err != nil {
+		return nil, err
+	}
+	if s.snapshots == nil {
+		return nil, status.Errorf(codes.Unimplemented, "snapshots are not supported by the entity manager")
+	}
+	if req.GetSnapshot() == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "no snapshot provided")
+	}
+	if err := s.requireLeader(ctx); err != nil {
+		return nil, err
+	}
+	if err := s.snapshots.RestoreSnapshot(req.GetSnapshot()); err != nil {
+		return nil, err
+	}
+	s.publish(ctx)
+	log.Infof("Restored snapshot of %d chassis", len(req.GetSnapshot().GetChassis()))
+	return &apb.RestoreSnapshotResponse{}, nil
+}
+
+// SetSnapshotter enables the snapshot RPCs, dumping and restoring the state of sn. It must be
+// called before the service serves requests.
+func (s *Server) SetSnapshotter(sn Snapshotter) {
+	s.snapshots = sn
+}
+
+// New creates a new Admin service managing the given bootstrap service, revocation list,
+// experiments and device states.
+func New(svc *service.Service, r *revocation.List, e *experiment.Tracker, st *devicestate.Machine) *Server {
+	return &Server{
+		service:     svc,
+		features:    svc.Features(),
+		revocations: r,
+		experiments: e,
+		states:      st,
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	bpb "github.com/openconfig/bootz/proto/bootz"
+)
+
+// Domains maps the devices of the server to their ownership domain.
+type Domains interface {
+	// Domain returns the ownership domain of the device with the serial number, or "" if it
+	// belongs to no domain.
+	Domain(serial string) string
+}
+
+// SetDomains restricts the admins identified in admins to the devices of their ownership
+// domain, as resolved by d. Admins mapped to "" manage every device. Once admins are set, callers
+// which are not listed are refused. It must be called before the service serves requests.
+func (s *Server) SetDomains(d Domains, admins map[string]string) {
+	s.domains = d
+	s.domainAdmins = admins
+}
+
+// callerDomain returns the ownership domain the caller is restricted to, or "" if the caller
+// may manage every device. Once admins are set, callers are identified by the common name of
+// their verified client certificate, and callers not listed as admins are refused.
+func (s *Server) callerDomain(ctx context.Context) (string, error) {
+	if len(s.domainAdmins) == 0 {
+		return "", nil
+	}
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return "", status.Errorf(codes.PermissionDenied, "admins must authenticate with a client certificate")
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.VerifiedChains) == 0 {
+		return "", status.Errorf(codes.PermissionDenied, "admins must authenticate with a verified client certificate")
+	}
+	cn := info.State.VerifiedChains[0][0].Subject.CommonName
+	domain, ok := s.domainAdmins[cn]
+	if !ok {
+		return "", status.Errorf(codes.PermissionDenied, "%q is not an admin of the server or of an ownership domain", cn)
+	}
+	return domain, nil
+}
+
+// inDomain reports whether the devices with the serial numbers all belong to the domain. Every
+// device belongs to the unrestricted domain "".
+func (s *Server) inDomain(domain string, serials ...string) bool {
+	if domain == "" {
+		return true
+	}
+	for _, serial := range serials {
+		if s.domains.Domain(serial) != domain {
+			return false
+		}
+	}
+	return true
+}
+
+// authorizeDevices returns an error unless the caller may manage every device with the serial
+// numbers.
+func (s *Server) authorizeDevices(ctx context.Context, serials ...string) error {
+	domain, err := s.callerDomain(ctx)
+	if err != nil {
+		return err
+	}
+	for _, serial := range serials {
+		if !s.inDomain(domain, serial) {
+			return status.Errorf(codes.PermissionDenied, "serial# %s does not belong to ownership domain %q", serial, domain)
+		}
+	}
+	return nil
+}
+
+// authorizeServer returns an error if the caller is restricted to an ownership domain, as the
+// RPC applies to the whole server.
+func (s *Server) authorizeServer(ctx context.Context) error {
+	domain, err := s.callerDomain(ctx)
+	if err != nil {
+		return err
+	}
+	if domain != "" {
+		return status.Errorf(codes.PermissionDenied, "admins of ownership domain %q may not manage the whole server", domain)
+	}
+	return nil
+}
+
+// descriptorSerials returns the serial numbers of the chassis and control cards of desc.
+func descriptorSerials(desc *bpb.ChassisDescriptor) []string {
+	var serials []string
+	if sn := desc.GetSerialNumber(); sn != "" {
+		serials = append(serials, sn)
+	}
+	for _, cc := range desc.GetControlCards() {
+		if sn := cc.GetSerialNumber(); sn != "" {
+			serials = append(serials, sn)
+		}
+	}
+	return serials
+}
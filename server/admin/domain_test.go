@@ -0,0 +1,107 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net"
+	"testing"
+
+	"github.com/h-fam/errdiff"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// fakeDomains assigns serial numbers to ownership domains.
+type fakeDomains map[string]string
+
+func (f fakeDomains) Domain(serial string) string {
+	return f[serial]
+}
+
+// callerContext returns the context of a call from a caller with the client certificate of the
+// common name, verified if set.
+func callerContext(cn string, verified bool) context.Context {
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: cn}}
+	state := tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}
+	if verified {
+		state.VerifiedChains = [][]*x509.Certificate{{cert}}
+	}
+	return peer.NewContext(context.Background(), &peer.Peer{
+		Addr:     &net.TCPAddr{IP: net.IPv4(192, 0, 2, 1)},
+		AuthInfo: credentials.TLSInfo{State: state},
+	})
+}
+
+func TestAuthorizeDevices(t *testing.T) {
+	tests := []struct {
+		desc    string
+		admins  map[string]string
+		ctx     context.Context
+		serial  string
+		wantErr string
+	}{{
+		desc:   "No admins set",
+		ctx:    context.Background(),
+		serial: "123A",
+	}, {
+		desc:   "Server admin",
+		admins: map[string]string{"netops": "", "blue-admin": "blue"},
+		ctx:    callerContext("netops", true),
+		serial: "456A",
+	}, {
+		desc:   "Domain admin",
+		admins: map[string]string{"netops": "", "blue-admin": "blue"},
+		ctx:    callerContext("blue-admin", true),
+		serial: "123A",
+	}, {
+		desc:    "Domain admin outside of the domain",
+		admins:  map[string]string{"netops": "", "blue-admin": "blue"},
+		ctx:     callerContext("blue-admin", true),
+		serial:  "456A",
+		wantErr: `does not belong to ownership domain "blue"`,
+	}, {
+		desc:    "Unknown admin",
+		admins:  map[string]string{"blue-admin": "blue"},
+		ctx:     callerContext("netops", true),
+		serial:  "123A",
+		wantErr: "is not an admin",
+	}, {
+		desc:    "Unverified certificate",
+		admins:  map[string]string{"netops": "", "blue-admin": "blue"},
+		ctx:     callerContext("netops", false),
+		serial:  "123A",
+		wantErr: "verified client certificate",
+	}, {
+		desc:    "No peer",
+		admins:  map[string]string{"netops": "", "blue-admin": "blue"},
+		ctx:     context.Background(),
+		serial:  "123A",
+		wantErr: "client certificate",
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := &Server{}
+			s.SetDomains(fakeDomains{"123A": "blue"}, test.admins)
+			err := s.authorizeDevices(test.ctx, test.serial)
+			if diff := errdiff.Substring(err, test.wantErr); diff != "" {
+				t.Errorf("authorizeDevices() %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+	"sort"
+
+	"github.com/openconfig/bootz/server/entitymanager"
+	"github.com/openconfig/bootz/server/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	apb "github.com/openconfig/bootz/server/admin/proto/admin"
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+	log "github.com/openconfig/bootz/server/logging"
+)
+
+// Inventory lists and updates the chassis of the server.
+type Inventory interface {
+	GetAll() map[service.EntityLookup]*epb.Chassis
+	PutChassis(ch *epb.Chassis, replace bool) error
+	SetOwnershipVoucher(serial string, ov []byte, rotated bool) error
+	ParseOwnershipVoucher(ov []byte) (string, error)
+	Modules() []entitymanager.ChassisModules
+}
+
+// SetInventory enables the inventory RPCs, listing and updating the chassis of inv. It must be
+// called before the service serves requests.
+func (s *Server) SetInventory(inv Inventory) {
+	s.inventory = inv
+}
+
+// chassisSerials returns the serial numbers of a chassis and its control cards.
+func chassisSerials(ch *epb.Chassis) []string {
+	serials := []string{ch.GetSerialNumber()}
+	for _, c := range ch.GetControllerCards() {
+		serials = append(serials, c.GetSerialNumber())
+	}
+	return serials
+}
+
+// ListChassis returns the chassis of the inventory the caller manages.
+func (s *Server) ListChassis(ctx context.Context, req *apb.ListChassisRequest) (*apb.ListChassisResponse, error) {
+	if s.inventory == nil {
+		return nil, status.Errorf(codes.Unimplemented, "the inventory is not supported by the entity manager")
+	}
+	domain, err := s.callerDomain(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp := &apb.ListChassisResponse{}
+	for _, ch := range s.inventory.GetAll() {
+		if domain != "" && ch.GetTenant() != domain {
+			continue
+		}
+		resp.Chassis = append(resp.Chassis, ch)
+	}
+	sort.Slice(resp.Chassis, func(i, j int) bool {
+		if resp.Chassis[i].GetManufacturer() != resp.Chassis[j].GetManufacturer() {
+			return resp.Chassis[i].GetManufacturer() < resp.Chassis[j].GetManufacturer()
+		}
+		return resp.Chassis[i].GetSerialNumber() < resp.Chassis[j].GetSerialNumber()
+	})
+	return resp, nil
+}
+
+// AddChassis adds a chassis to the inventory, or replaces an existing one. Domain admins may
+// only add chassis of their tenant.
+func (s *Server) AddChassis(ctx context.Context, req *apb.AddChassisRequest) (*apb.AddChassisResponse, error) {
+	if s.inventory == nil {
+		return nil, status.Errorf(codes.Unimplemented, "the inventory is not supported by the entity manager")
+	}
+	ch := req.GetChassis()
+	if ch == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "no chassis provided")
+	}
+	domain, err := s.callerDomain(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if domain != "" {
+		if ch.GetTenant() != domain {
+			return nil, status.Errorf(codes.PermissionDenied, "admins of ownership domain %q may only add chassis of tenant %q", domain, domain)
+		}
+		// Devices of the inventory must already belong to the domain.
+		for _, serial := range chassisSerials(ch) {
+			if d := s.domains.Domain(serial); d != "" && d != domain {
+				return nil, status.Errorf(codes.PermissionDenied, "serial# %s does not belong to ownership domain %q", serial, domain)
+			}
+		}
+	}
+	if err := s.requireLeader(ctx); err != nil {
+		return nil, err
+	}
+	if err := s.inventory.PutChassis(ch, req.GetReplace()); err != nil {
+		return nil, err
+	}
+	s.publish(ctx)
+	return &apb.AddChassisResponse{}, nil
+}
+
+// UploadOwnershipVoucher sets the ownership voucher, or the rotated ownership voucher, of a
+// control card or fixed chassis.
+func (s *Server) UploadOwnershipVoucher(ctx context.Context, req *apb.UploadOwnershipVoucherRequest) (*apb.UploadOwnershipVoucherResponse, error) {
+	if s.inventory == nil {
+		return nil, status.Errorf(codes.Unimplemented, "the inventory is not supported by the entity manager")
+	}
+	if err := s.authorizeDevices(ctx, req.GetSerialNumber()); err != nil {
+		return nil, err
+	}
+	if err := s.requireLeader(ctx); err != nil {
+		return nil, err
+	}
+	if err := s.inventory.SetOwnershipVoucher(req.GetSerialNumber(), req.GetOwnershipVoucher(), req.GetRotated()); err != nil {
+		return nil, err
+	}
+	s.publish(ctx)
+	log.Infof("Uploaded ownership voucher for serial# %s", req.GetSerialNumber())
+	return &apb.UploadOwnershipVoucherResponse{}, nil
+}
+
+// ListChassisModules returns the line cards and fabric modules last reported by the requested
+// chassis, or by every chassis the caller manages, reconciled with the inventory.
+func (s *Server) ListChassisModules(ctx context.Context, req *apb.ListChassisModulesRequest) (*apb.ListChassisModulesResponse, error) {
+	if s.inventory == nil {
+		return nil, status.Errorf(codes.Unimplemented, "the inventory is not supported by the entity manager")
+	}
+	if err := s.authorizeDevices(ctx, req.GetSerialNumbers()...); err != nil {
+		return nil, err
+	}
+	requested := map[string]bool{}
+	for _, serial := range req.GetSerialNumbers() {
+		requested[serial] = true
+	}
+	// Chassis are requested by their serial number or the serial number of a control card.
+	bySerial := map[service.EntityLookup][]string{}
+	for lookup, ch := range s.inventory.GetAll() {
+		bySerial[lookup] = chassisSerials(ch)
+	}
+	domain, err := s.callerDomain(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp := &apb.ListChassisModulesResponse{}
+	for _, m := range s.inventory.Modules() {
+		lookup := service.EntityLookup{Manufacturer: m.Manufacturer, SerialNumber: m.SerialNumber}
+		serials := append([]string{m.SerialNumber}, bySerial[lookup]...)
+		if len(requested) > 0 && !anyRequested(requested, serials) {
+			continue
+		}
+		if len(requested) == 0 && !s.inDomain(domain, serials...) {
+			continue
+		}
+		resp.Chassis = append(resp.Chassis, &apb.ChassisModules{
+			Manufacturer:  m.Manufacturer,
+			SerialNumber:  m.SerialNumber,
+			ReportedAt:    timestamppb.New(m.ReportedAt),
+			LineCards:     moduleInventory(m.LineCards),
+			FabricModules: moduleInventory(m.FabricModules),
+		})
+	}
+	return resp, nil
+}
+
+func anyRequested(requested map[string]bool, serials []string) bool {
+	for _, serial := range serials {
+		if requested[serial] {
+			return true
+		}
+	}
+	return false
+}
+
+func moduleInventory(m entitymanager.Modules) *apb.ModuleInventory {
+	return &apb.ModuleInventory{
+		Reported:   m.Reported,
+		Missing:    m.Missing,
+		Unexpected: m.Unexpected,
+	}
+}
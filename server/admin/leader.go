@@ -0,0 +1,67 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	log "github.com/openconfig/bootz/server/logging"
+)
+
+// Leadership serializes the inventory mutations of a cluster through its leader.
+type Leadership interface {
+	// IsLeader reports whether the instance leads the cluster.
+	IsLeader() bool
+	// Leader returns the ID of the leader of the cluster, or "" if there is none.
+	Leader(ctx context.Context) (string, error)
+	// PublishInventory shares the inventory of the leader with the other instances.
+	PublishInventory(ctx context.Context) error
+}
+
+// SetLeadership only accepts the inventory mutations of the admin API while l leads the cluster.
+// It must be called before the service serves requests.
+func (s *Server) SetLeadership(l Leadership) {
+	s.leadership = l
+}
+
+// requireLeader returns a FailedPrecondition error naming the leader, unless the instance leads
+// the cluster.
+func (s *Server) requireLeader(ctx context.Context) error {
+	if s.leadership == nil || s.leadership.IsLeader() {
+		return nil
+	}
+	leader, err := s.leadership.Leader(ctx)
+	if err != nil {
+		return status.Errorf(codes.Unavailable, "this instance does not lead the cluster, and the leader is unknown: %v", err)
+	}
+	if leader == "" {
+		return status.Errorf(codes.Unavailable, "the cluster has no leader, retry once one is elected")
+	}
+	return status.Errorf(codes.FailedPrecondition, "this instance does not lead the cluster, send inventory changes to the leader %s", leader)
+}
+
+// publish shares the inventory mutated by the leader with the other instances of the cluster.
+// The leader retries failures in the background, so they don't fail the mutation.
+func (s *Server) publish(ctx context.Context) {
+	if s.leadership == nil {
+		return
+	}
+	if err := s.leadership.PublishInventory(ctx); err != nil {
+		log.Warningf("Unable to share the inventory change with the cluster, retrying in the background: %v", err)
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	apb "github.com/openconfig/bootz/server/admin/proto/admin"
+	log "github.com/openconfig/bootz/server/logging"
+)
+
+// GetLogLevels returns the log levels of the instance.
+func (s *Server) GetLogLevels(ctx context.Context, req *apb.GetLogLevelsRequest) (*apb.GetLogLevelsResponse, error) {
+	if err := s.authorizeServer(ctx); err != nil {
+		return nil, err
+	}
+	def, levels := log.Levels()
+	return &apb.GetLogLevelsResponse{
+		DefaultLevel: def,
+		Levels:       levels,
+		Modules:      log.Modules(),
+	}, nil
+}
+
+// SetLogLevel sets the log level of a module, or the default log level, of the instance. Log
+// levels are local to the instance, so followers of a cluster accept them too.
+func (s *Server) SetLogLevel(ctx context.Context, req *apb.SetLogLevelRequest) (*apb.SetLogLevelResponse, error) {
+	if err := s.authorizeServer(ctx); err != nil {
+		return nil, err
+	}
+	if err := log.SetLevel(req.GetModule(), req.GetLevel()); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "unable to set log level: %v", err)
+	}
+	switch {
+	case req.GetModule() == "":
+		log.Infof("Set the default log level to %s", req.GetLevel())
+	case req.GetLevel() == "":
+		log.Infof("Cleared the log level of module %s", req.GetModule())
+	default:
+		log.Infof("Set the log level of module %s to %s", req.GetModule(), req.GetLevel())
+	}
+	return &apb.SetLogLevelResponse{}, nil
+}
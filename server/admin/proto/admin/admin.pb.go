@@ -0,0 +1,6892 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.21.10
+// source: server/admin/proto/admin.proto
+
+package admin
+
+import (
+	context "context"
+	bootz "github.com/openconfig/bootz/proto/bootz"
+	entity "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type BootstrapStage int32
+
+const (
+	BootstrapStage_BOOTSTRAP_STAGE_UNSPECIFIED BootstrapStage = 0
+	BootstrapStage_BOOTSTRAP_STAGE_REQUESTED   BootstrapStage = 1
+	BootstrapStage_BOOTSTRAP_STAGE_SERVED      BootstrapStage = 2
+	BootstrapStage_BOOTSTRAP_STAGE_REJECTED    BootstrapStage = 3
+	BootstrapStage_BOOTSTRAP_STAGE_INITIATED   BootstrapStage = 4
+	BootstrapStage_BOOTSTRAP_STAGE_SUCCEEDED   BootstrapStage = 5
+	BootstrapStage_BOOTSTRAP_STAGE_FAILED      BootstrapStage = 6
+)
+
+// Enum value maps for BootstrapStage.
+var (
+	BootstrapStage_name = map[int32]string{
+		0: "BOOTSTRAP_STAGE_UNSPECIFIED",
+		1: "BOOTSTRAP_STAGE_REQUESTED",
+		2: "BOOTSTRAP_STAGE_SERVED",
+		3: "BOOTSTRAP_STAGE_REJECTED",
+		4: "BOOTSTRAP_STAGE_INITIATED",
+		5: "BOOTSTRAP_STAGE_SUCCEEDED",
+		6: "BOOTSTRAP_STAGE_FAILED",
+	}
+	BootstrapStage_value = map[string]int32{
+		"BOOTSTRAP_STAGE_UNSPECIFIED": 0,
+		"BOOTSTRAP_STAGE_REQUESTED":   1,
+		"BOOTSTRAP_STAGE_SERVED":      2,
+		"BOOTSTRAP_STAGE_REJECTED":    3,
+		"BOOTSTRAP_STAGE_INITIATED":   4,
+		"BOOTSTRAP_STAGE_SUCCEEDED":   5,
+		"BOOTSTRAP_STAGE_FAILED":      6,
+	}
+)
+
+func (x BootstrapStage) Enum() *BootstrapStage {
+	p := new(BootstrapStage)
+	*p = x
+	return p
+}
+
+func (x BootstrapStage) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BootstrapStage) Descriptor() protoreflect.EnumDescriptor {
+	return file_server_admin_proto_admin_proto_enumTypes[0].Descriptor()
+}
+
+func (BootstrapStage) Type() protoreflect.EnumType {
+	return &file_server_admin_proto_admin_proto_enumTypes[0]
+}
+
+func (x BootstrapStage) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BootstrapStage.Descriptor instead.
+func (BootstrapStage) EnumDescriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{0}
+}
+
+type DeviceBootstrapState int32
+
+const (
+	DeviceBootstrapState_DEVICE_BOOTSTRAP_STATE_UNSPECIFIED    DeviceBootstrapState = 0
+	DeviceBootstrapState_DEVICE_BOOTSTRAP_STATE_IDLE           DeviceBootstrapState = 1
+	DeviceBootstrapState_DEVICE_BOOTSTRAP_STATE_BOOTSTRAP_SENT DeviceBootstrapState = 2
+	DeviceBootstrapState_DEVICE_BOOTSTRAP_STATE_STATUS_OK      DeviceBootstrapState = 3
+	DeviceBootstrapState_DEVICE_BOOTSTRAP_STATE_STATUS_FAILED  DeviceBootstrapState = 4
+)
+
+// Enum value maps for DeviceBootstrapState.
+var (
+	DeviceBootstrapState_name = map[int32]string{
+		0: "DEVICE_BOOTSTRAP_STATE_UNSPECIFIED",
+		1: "DEVICE_BOOTSTRAP_STATE_IDLE",
+		2: "DEVICE_BOOTSTRAP_STATE_BOOTSTRAP_SENT",
+		3: "DEVICE_BOOTSTRAP_STATE_STATUS_OK",
+		4: "DEVICE_BOOTSTRAP_STATE_STATUS_FAILED",
+	}
+	DeviceBootstrapState_value = map[string]int32{
+		"DEVICE_BOOTSTRAP_STATE_UNSPECIFIED":    0,
+		"DEVICE_BOOTSTRAP_STATE_IDLE":           1,
+		"DEVICE_BOOTSTRAP_STATE_BOOTSTRAP_SENT": 2,
+		"DEVICE_BOOTSTRAP_STATE_STATUS_OK":      3,
+		"DEVICE_BOOTSTRAP_STATE_STATUS_FAILED":  4,
+	}
+)
+
+func (x DeviceBootstrapState) Enum() *DeviceBootstrapState {
+	p := new(DeviceBootstrapState)
+	*p = x
+	return p
+}
+
+func (x DeviceBootstrapState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DeviceBootstrapState) Descriptor() protoreflect.EnumDescriptor {
+	return file_server_admin_proto_admin_proto_enumTypes[1].Descriptor()
+}
+
+func (DeviceBootstrapState) Type() protoreflect.EnumType {
+	return &file_server_admin_proto_admin_proto_enumTypes[1]
+}
+
+func (x DeviceBootstrapState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DeviceBootstrapState.Descriptor instead.
+func (DeviceBootstrapState) EnumDescriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{1}
+}
+
+type DeviceConfigStage int32
+
+const (
+	DeviceConfigStage_DEVICE_CONFIG_STAGE_UNSPECIFIED DeviceConfigStage = 0
+	DeviceConfigStage_DEVICE_CONFIG_STAGE_INITIAL     DeviceConfigStage = 1
+	DeviceConfigStage_DEVICE_CONFIG_STAGE_FINAL       DeviceConfigStage = 2
+)
+
+// Enum value maps for DeviceConfigStage.
+var (
+	DeviceConfigStage_name = map[int32]string{
+		0: "DEVICE_CONFIG_STAGE_UNSPECIFIED",
+		1: "DEVICE_CONFIG_STAGE_INITIAL",
+		2: "DEVICE_CONFIG_STAGE_FINAL",
+	}
+	DeviceConfigStage_value = map[string]int32{
+		"DEVICE_CONFIG_STAGE_UNSPECIFIED": 0,
+		"DEVICE_CONFIG_STAGE_INITIAL":     1,
+		"DEVICE_CONFIG_STAGE_FINAL":       2,
+	}
+)
+
+func (x DeviceConfigStage) Enum() *DeviceConfigStage {
+	p := new(DeviceConfigStage)
+	*p = x
+	return p
+}
+
+func (x DeviceConfigStage) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DeviceConfigStage) Descriptor() protoreflect.EnumDescriptor {
+	return file_server_admin_proto_admin_proto_enumTypes[2].Descriptor()
+}
+
+func (DeviceConfigStage) Type() protoreflect.EnumType {
+	return &file_server_admin_proto_admin_proto_enumTypes[2]
+}
+
+func (x DeviceConfigStage) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DeviceConfigStage.Descriptor instead.
+func (DeviceConfigStage) EnumDescriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{2}
+}
+
+type ListFeatureFlagsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListFeatureFlagsRequest) Reset() {
+	*x = ListFeatureFlagsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_admin_proto_admin_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListFeatureFlagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFeatureFlagsRequest) ProtoMessage() {}
+
+func (x *ListFeatureFlagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_server_admin_proto_admin_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFeatureFlagsRequest.ProtoReflect.Descriptor instead.
+func (*ListFeatureFlagsRequest) Descriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{0}
+}
+
+type ListFeatureFlagsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Flags []*entity.FeatureFlag `protobuf:"bytes,1,rep,name=flags,proto3" json:"flags,omitempty"`
+}
+
+func (x *ListFeatureFlagsResponse) Reset() {
+	*x = ListFeatureFlagsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_admin_proto_admin_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListFeatureFlagsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFeatureFlagsResponse) ProtoMessage() {}
+
+func (x *ListFeatureFlagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_server_admin_proto_admin_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFeatureFlagsResponse.ProtoReflect.Descriptor instead.
+func (*ListFeatureFlagsResponse) Descriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListFeatureFlagsResponse) GetFlags() []*entity.FeatureFlag {
+	if x != nil {
+		return x.Flags
+	}
+	return nil
+}
+
+type SetFeatureFlagRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Flag  *entity.FeatureFlag `protobuf:"bytes,1,opt,name=flag,proto3" json:"flag,omitempty"`
+	Clear bool                `protobuf:"varint,2,opt,name=clear,proto3" json:"clear,omitempty"`
+}
+
+func (x *SetFeatureFlagRequest) Reset() {
+	*x = SetFeatureFlagRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_admin_proto_admin_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetFeatureFlagRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetFeatureFlagRequest) ProtoMessage() {}
+
+func (x *SetFeatureFlagRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_server_admin_proto_admin_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetFeatureFlagRequest.ProtoReflect.Descriptor instead.
+func (*SetFeatureFlagRequest) Descriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SetFeatureFlagRequest) GetFlag() *entity.FeatureFlag {
+	if x != nil {
+		return x.Flag
+	}
+	return nil
+}
+
+func (x *SetFeatureFlagRequest) GetClear() bool {
+	if x != nil {
+		return x.Clear
+	}
+	return false
+}
+
+type SetFeatureFlagResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *SetFeatureFlagResponse) Reset() {
+	*x = SetFeatureFlagResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_admin_proto_admin_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetFeatureFlagResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetFeatureFlagResponse) ProtoMessage() {}
+
+func (x *SetFeatureFlagResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_server_admin_proto_admin_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetFeatureFlagResponse.ProtoReflect.Descriptor instead.
+func (*SetFeatureFlagResponse) Descriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{3}
+}
+
+type RevokedOwnershipVoucher struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SerialNumber string                 `protobuf:"bytes,1,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+	Reason       string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	RevokedAt    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=revoked_at,json=revokedAt,proto3" json:"revoked_at,omitempty"`
+}
+
+func (x *RevokedOwnershipVoucher) Reset() {
+	*x = RevokedOwnershipVoucher{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_admin_proto_admin_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RevokedOwnershipVoucher) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokedOwnershipVoucher) ProtoMessage() {}
+
+func (x *RevokedOwnershipVoucher) ProtoReflect() protoreflect.Message {
+	mi := &file_server_admin_proto_admin_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokedOwnershipVoucher.ProtoReflect.Descriptor instead.
+func (*RevokedOwnershipVoucher) Descriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RevokedOwnershipVoucher) GetSerialNumber() string {
+	if x != nil {
+		return x.SerialNumber
+	}
+	return ""
+}
+
+func (x *RevokedOwnershipVoucher) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *RevokedOwnershipVoucher) GetRevokedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RevokedAt
+	}
+	return nil
+}
+
+type ListRevokedOwnershipVouchersRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListRevokedOwnershipVouchersRequest) Reset() {
+	*x = ListRevokedOwnershipVouchersRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_admin_proto_admin_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListRevokedOwnershipVouchersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRevokedOwnershipVouchersRequest) ProtoMessage() {}
+
+func (x *ListRevokedOwnershipVouchersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_server_admin_proto_admin_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRevokedOwnershipVouchersRequest.ProtoReflect.Descriptor instead.
+func (*ListRevokedOwnershipVouchersRequest) Descriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{5}
+}
+
+type ListRevokedOwnershipVouchersResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Vouchers []*RevokedOwnershipVoucher `protobuf:"bytes,1,rep,name=vouchers,proto3" json:"vouchers,omitempty"`
+}
+
+func (x *ListRevokedOwnershipVouchersResponse) Reset() {
+	*x = ListRevokedOwnershipVouchersResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_admin_proto_admin_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListRevokedOwnershipVouchersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRevokedOwnershipVouchersResponse) ProtoMessage() {}
+
+func (x *ListRevokedOwnershipVouchersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_server_admin_proto_admin_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRevokedOwnershipVouchersResponse.ProtoReflect.Descriptor instead.
+func (*ListRevokedOwnershipVouchersResponse) Descriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListRevokedOwnershipVouchersResponse) GetVouchers() []*RevokedOwnershipVoucher {
+	if x != nil {
+		return x.Vouchers
+	}
+	return nil
+}
+
+type RevokeOwnershipVoucherRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SerialNumber string `protobuf:"bytes,1,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+	Reason       string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *RevokeOwnershipVoucherRequest) Reset() {
+	*x = RevokeOwnershipVoucherRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_admin_proto_admin_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RevokeOwnershipVoucherRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeOwnershipVoucherRequest) ProtoMessage() {}
+
+func (x *RevokeOwnershipVoucherRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_server_admin_proto_admin_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeOwnershipVoucherRequest.ProtoReflect.Descriptor instead.
+func (*RevokeOwnershipVoucherRequest) Descriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *RevokeOwnershipVoucherRequest) GetSerialNumber() string {
+	if x != nil {
+		return x.SerialNumber
+	}
+	return ""
+}
+
+func (x *RevokeOwnershipVoucherRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type RevokeOwnershipVoucherResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *RevokeOwnershipVoucherResponse) Reset() {
+	*x = RevokeOwnershipVoucherResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_admin_proto_admin_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RevokeOwnershipVoucherResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeOwnershipVoucherResponse) ProtoMessage() {}
+
+func (x *RevokeOwnershipVoucherResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_server_admin_proto_admin_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeOwnershipVoucherResponse.ProtoReflect.Descriptor instead.
+func (*RevokeOwnershipVoucherResponse) Descriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{8}
+}
+
+type GenerateStagingBundleRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ChassisDescriptor *bootz.ChassisDescriptor `protobuf:"bytes,1,opt,name=chassis_descriptor,json=chassisDescriptor,proto3" json:"chassis_descriptor,omitempty"`
+}
+
+func (x *GenerateStagingBundleRequest) Reset() {
+	*x = GenerateStagingBundleRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_admin_proto_admin_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GenerateStagingBundleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateStagingBundleRequest) ProtoMessage() {}
+
+func (x *GenerateStagingBundleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_server_admin_proto_admin_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateStagingBundleRequest.ProtoReflect.Descriptor instead.
+func (*GenerateStagingBundleRequest) Descriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GenerateStagingBundleRequest) GetChassisDescriptor() *bootz.ChassisDescriptor {
+	if x != nil {
+		return x.ChassisDescriptor
+	}
+	return nil
+}
+
+type GenerateStagingBundleResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Bundle *bootz.StagingBundle `protobuf:"bytes,1,opt,name=bundle,proto3" json:"bundle,omitempty"`
+}
+
+func (x *GenerateStagingBundleResponse) Reset() {
+	*x = GenerateStagingBundleResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_admin_proto_admin_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GenerateStagingBundleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateStagingBundleResponse) ProtoMessage() {}
+
+func (x *GenerateStagingBundleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_server_admin_proto_admin_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateStagingBundleResponse.ProtoReflect.Descriptor instead.
+func (*GenerateStagingBundleResponse) Descriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GenerateStagingBundleResponse) GetBundle() *bootz.StagingBundle {
+	if x != nil {
+		return x.Bundle
+	}
+	return nil
+}
+
+type StagingBundleRecord struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BundleId          string                                    `protobuf:"bytes,1,opt,name=bundle_id,json=bundleId,proto3" json:"bundle_id,omitempty"`
+	ChassisDescriptor *bootz.ChassisDescriptor                  `protobuf:"bytes,2,opt,name=chassis_descriptor,json=chassisDescriptor,proto3" json:"chassis_descriptor,omitempty"`
+	CreatedAt         *timestamppb.Timestamp                    `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Status            bootz.ReportStatusRequest_BootstrapStatus `protobuf:"varint,4,opt,name=status,proto3,enum=bootz.proto.ReportStatusRequest_BootstrapStatus" json:"status,omitempty"`
+	ReportedAt        *timestamppb.Timestamp                    `protobuf:"bytes,5,opt,name=reported_at,json=reportedAt,proto3" json:"reported_at,omitempty"`
+}
+
+func (x *StagingBundleRecord) Reset() {
+	*x = StagingBundleRecord{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_admin_proto_admin_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StagingBundleRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StagingBundleRecord) ProtoMessage() {}
+
+func (x *StagingBundleRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_server_admin_proto_admin_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StagingBundleRecord.ProtoReflect.Descriptor instead.
+func (*StagingBundleRecord) Descriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *StagingBundleRecord) GetBundleId() string {
+	if x != nil {
+		return x.BundleId
+	}
+	return ""
+}
+
+func (x *StagingBundleRecord) GetChassisDescriptor() *bootz.ChassisDescriptor {
+	if x != nil {
+		return x.ChassisDescriptor
+	}
+	return nil
+}
+
+func (x *StagingBundleRecord) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *StagingBundleRecord) GetStatus() bootz.ReportStatusRequest_BootstrapStatus {
+	if x != nil {
+		return x.Status
+	}
+	return bootz.ReportStatusRequest_BootstrapStatus(0)
+}
+
+func (x *StagingBundleRecord) GetReportedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReportedAt
+	}
+	return nil
+}
+
+type ListStagingBundlesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListStagingBundlesRequest) Reset() {
+	*x = ListStagingBundlesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_admin_proto_admin_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListStagingBundlesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStagingBundlesRequest) ProtoMessage() {}
+
+func (x *ListStagingBundlesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_server_admin_proto_admin_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStagingBundlesRequest.ProtoReflect.Descriptor instead.
+func (*ListStagingBundlesRequest) Descriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{12}
+}
+
+type ListStagingBundlesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Bundles []*StagingBundleRecord `protobuf:"bytes,1,rep,name=bundles,proto3" json:"bundles,omitempty"`
+}
+
+func (x *ListStagingBundlesResponse) Reset() {
+	*x = ListStagingBundlesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_admin_proto_admin_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListStagingBundlesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStagingBundlesResponse) ProtoMessage() {}
+
+func (x *ListStagingBundlesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_server_admin_proto_admin_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStagingBundlesResponse.ProtoReflect.Descriptor instead.
+func (*ListStagingBundlesResponse) Descriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListStagingBundlesResponse) GetBundles() []*StagingBundleRecord {
+	if x != nil {
+		return x.Bundles
+	}
+	return nil
+}
+
+type ExperimentArmResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Arm                   string               `protobuf:"bytes,1,opt,name=arm,proto3" json:"arm,omitempty"`
+	Assigned              uint64               `protobuf:"varint,2,opt,name=assigned,proto3" json:"assigned,omitempty"`
+	Succeeded             uint64               `protobuf:"varint,3,opt,name=succeeded,proto3" json:"succeeded,omitempty"`
+	Failed                uint64               `protobuf:"varint,4,opt,name=failed,proto3" json:"failed,omitempty"`
+	SuccessRate           float64              `protobuf:"fixed64,5,opt,name=success_rate,json=successRate,proto3" json:"success_rate,omitempty"`
+	MeanBootstrapDuration *durationpb.Duration `protobuf:"bytes,6,opt,name=mean_bootstrap_duration,json=meanBootstrapDuration,proto3" json:"mean_bootstrap_duration,omitempty"`
+}
+
+func (x *ExperimentArmResult) Reset() {
+	*x = ExperimentArmResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_admin_proto_admin_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExperimentArmResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExperimentArmResult) ProtoMessage() {}
+
+func (x *ExperimentArmResult) ProtoReflect() protoreflect.Message {
+	mi := &file_server_admin_proto_admin_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExperimentArmResult.ProtoReflect.Descriptor instead.
+func (*ExperimentArmResult) Descriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ExperimentArmResult) GetArm() string {
+	if x != nil {
+		return x.Arm
+	}
+	return ""
+}
+
+func (x *ExperimentArmResult) GetAssigned() uint64 {
+	if x != nil {
+		return x.Assigned
+	}
+	return 0
+}
+
+func (x *ExperimentArmResult) GetSucceeded() uint64 {
+	if x != nil {
+		return x.Succeeded
+	}
+	return 0
+}
+
+func (x *ExperimentArmResult) GetFailed() uint64 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *ExperimentArmResult) GetSuccessRate() float64 {
+	if x != nil {
+		return x.SuccessRate
+	}
+	return 0
+}
+
+func (x *ExperimentArmResult) GetMeanBootstrapDuration() *durationpb.Duration {
+	if x != nil {
+		return x.MeanBootstrapDuration
+	}
+	return nil
+}
+
+type ExperimentResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Arms []*ExperimentArmResult `protobuf:"bytes,2,rep,name=arms,proto3" json:"arms,omitempty"`
+}
+
+func (x *ExperimentResult) Reset() {
+	*x = ExperimentResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_admin_proto_admin_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExperimentResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExperimentResult) ProtoMessage() {}
+
+func (x *ExperimentResult) ProtoReflect() protoreflect.Message {
+	mi := &file_server_admin_proto_admin_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExperimentResult.ProtoReflect.Descriptor instead.
+func (*ExperimentResult) Descriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ExperimentResult) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ExperimentResult) GetArms() []*ExperimentArmResult {
+	if x != nil {
+		return x.Arms
+	}
+	return nil
+}
+
+type ListExperimentResultsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListExperimentResultsRequest) Reset() {
+	*x = ListExperimentResultsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_admin_proto_admin_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListExperimentResultsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListExperimentResultsRequest) ProtoMessage() {}
+
+func (x *ListExperimentResultsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_server_admin_proto_admin_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListExperimentResultsRequest.ProtoReflect.Descriptor instead.
+func (*ListExperimentResultsRequest) Descriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{16}
+}
+
+type ListExperimentResultsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Experiments []*ExperimentResult `protobuf:"bytes,1,rep,name=experiments,proto3" json:"experiments,omitempty"`
+}
+
+func (x *ListExperimentResultsResponse) Reset() {
+	*x = ListExperimentResultsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_admin_proto_admin_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListExperimentResultsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListExperimentResultsResponse) ProtoMessage() {}
+
+func (x *ListExperimentResultsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_server_admin_proto_admin_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListExperimentResultsResponse.ProtoReflect.Descriptor instead.
+func (*ListExperimentResultsResponse) Descriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListExperimentResultsResponse) GetExperiments() []*ExperimentResult {
+	if x != nil {
+		return x.Experiments
+	}
+	return nil
+}
+
+type StreamBootstrapStatusRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SerialNumbers []string `protobuf:"bytes,1,rep,name=serial_numbers,json=serialNumbers,proto3" json:"serial_numbers,omitempty"`
+}
+
+func (x *StreamBootstrapStatusRequest) Reset() {
+	*x = StreamBootstrapStatusRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_admin_proto_admin_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StreamBootstrapStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamBootstrapStatusRequest) ProtoMessage() {}
+
+func (x *StreamBootstrapStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_server_admin_proto_admin_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamBootstrapStatusRequest.ProtoReflect.Descriptor instead.
+func (*StreamBootstrapStatusRequest) Descriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *StreamBootstrapStatusRequest) GetSerialNumbers() []string {
+	if x != nil {
+		return x.SerialNumbers
+	}
+	return nil
+}
+
+type BootstrapStatusEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SerialNumber        string                 `protobuf:"bytes,1,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+	ChassisSerialNumber string                 `protobuf:"bytes,2,opt,name=chassis_serial_number,json=chassisSerialNumber,proto3" json:"chassis_serial_number,omitempty"`
+	Manufacturer        string                 `protobuf:"bytes,3,opt,name=manufacturer,proto3" json:"manufacturer,omitempty"`
+	Stage               BootstrapStage         `protobuf:"varint,4,opt,name=stage,proto3,enum=admin.BootstrapStage" json:"stage,omitempty"`
+	Message             string                 `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+	Time                *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=time,proto3" json:"time,omitempty"`
+}
+
+func (x *BootstrapStatusEvent) Reset() {
+	*x = BootstrapStatusEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_admin_proto_admin_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BootstrapStatusEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BootstrapStatusEvent) ProtoMessage() {}
+
+func (x *BootstrapStatusEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_server_admin_proto_admin_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BootstrapStatusEvent.ProtoReflect.Descriptor instead.
+func (*BootstrapStatusEvent) Descriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *BootstrapStatusEvent) GetSerialNumber() string {
+	if x != nil {
+		return x.SerialNumber
+	}
+	return ""
+}
+
+func (x *BootstrapStatusEvent) GetChassisSerialNumber() string {
+	if x != nil {
+		return x.ChassisSerialNumber
+	}
+	return ""
+}
+
+func (x *BootstrapStatusEvent) GetManufacturer() string {
+	if x != nil {
+		return x.Manufacturer
+	}
+	return ""
+}
+
+func (x *BootstrapStatusEvent) GetStage() BootstrapStage {
+	if x != nil {
+		return x.Stage
+	}
+	return BootstrapStage_BOOTSTRAP_STAGE_UNSPECIFIED
+}
+
+func (x *BootstrapStatusEvent) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *BootstrapStatusEvent) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+type DeviceState struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SerialNumber      string                 `protobuf:"bytes,1,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+	State             DeviceBootstrapState   `protobuf:"varint,2,opt,name=state,proto3,enum=admin.DeviceBootstrapState" json:"state,omitempty"`
+	Retries           uint32                 `protobuf:"varint,3,opt,name=retries,proto3" json:"retries,omitempty"`
+	ChangedAt         *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=changed_at,json=changedAt,proto3" json:"changed_at,omitempty"`
+	SentAt            *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=sent_at,json=sentAt,proto3" json:"sent_at,omitempty"`
+	ReportedAt        *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=reported_at,json=reportedAt,proto3" json:"reported_at,omitempty"`
+	Stage             DeviceConfigStage      `protobuf:"varint,7,opt,name=stage,proto3,enum=admin.DeviceConfigStage" json:"stage,omitempty"`
+	LastSeenAt        *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=last_seen_at,json=lastSeenAt,proto3" json:"last_seen_at,omitempty"`
+	LastAddress       string                 `protobuf:"bytes,9,opt,name=last_address,json=lastAddress,proto3" json:"last_address,omitempty"`
+	ServedImageHash   string                 `protobuf:"bytes,10,opt,name=served_image_hash,json=servedImageHash,proto3" json:"served_image_hash,omitempty"`
+	ServedConfigHash  string                 `protobuf:"bytes,11,opt,name=served_config_hash,json=servedConfigHash,proto3" json:"served_config_hash,omitempty"`
+	AppliedImageHash  string                 `protobuf:"bytes,12,opt,name=applied_image_hash,json=appliedImageHash,proto3" json:"applied_image_hash,omitempty"`
+	AppliedConfigHash string                 `protobuf:"bytes,13,opt,name=applied_config_hash,json=appliedConfigHash,proto3" json:"applied_config_hash,omitempty"`
+	DriftedArtifacts  []string               `protobuf:"bytes,14,rep,name=drifted_artifacts,json=driftedArtifacts,proto3" json:"drifted_artifacts,omitempty"`
+}
+
+func (x *DeviceState) Reset() {
+	*x = DeviceState{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_admin_proto_admin_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeviceState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeviceState) ProtoMessage() {}
+
+func (x *DeviceState) ProtoReflect() protoreflect.Message {
+	mi := &file_server_admin_proto_admin_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeviceState.ProtoReflect.Descriptor instead.
+func (*DeviceState) Descriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *DeviceState) GetSerialNumber() string {
+	if x != nil {
+		return x.SerialNumber
+	}
+	return ""
+}
+
+func (x *DeviceState) GetState() DeviceBootstrapState {
+	if x != nil {
+		return x.State
+	}
+	return DeviceBootstrapState_DEVICE_BOOTSTRAP_STATE_UNSPECIFIED
+}
+
+func (x *DeviceState) GetRetries() uint32 {
+	if x != nil {
+		return x.Retries
+	}
+	return 0
+}
+
+func (x *DeviceState) GetChangedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ChangedAt
+	}
+	return nil
+}
+
+func (x *DeviceState) GetSentAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SentAt
+	}
+	return nil
+}
+
+func (x *DeviceState) GetReportedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReportedAt
+	}
+	return nil
+}
+
+func (x *DeviceState) GetStage() DeviceConfigStage {
+	if x != nil {
+		return x.Stage
+	}
+	return DeviceConfigStage_DEVICE_CONFIG_STAGE_UNSPECIFIED
+}
+
+func (x *DeviceState) GetLastSeenAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSeenAt
+	}
+	return nil
+}
+
+func (x *DeviceState) GetLastAddress() string {
+	if x != nil {
+		return x.LastAddress
+	}
+	return ""
+}
+
+func (x *DeviceState) GetServedImageHash() string {
+	if x != nil {
+		return x.ServedImageHash
+	}
+	return ""
+}
+
+func (x *DeviceState) GetServedConfigHash() string {
+	if x != nil {
+		return x.ServedConfigHash
+	}
+	return ""
+}
+
+func (x *DeviceState) GetAppliedImageHash() string {
+	if x != nil {
+		return x.AppliedImageHash
+	}
+	return ""
+}
+
+func (x *DeviceState) GetAppliedConfigHash() string {
+	if x != nil {
+		return x.AppliedConfigHash
+	}
+	return ""
+}
+
+func (x *DeviceState) GetDriftedArtifacts() []string {
+	if x != nil {
+		return x.DriftedArtifacts
+	}
+	return nil
+}
+
+type ListDeviceStatesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SerialNumbers []string             `protobuf:"bytes,1,rep,name=serial_numbers,json=serialNumbers,proto3" json:"serial_numbers,omitempty"`
+	SilentFor     *durationpb.Duration `protobuf:"bytes,2,opt,name=silent_for,json=silentFor,proto3" json:"silent_for,omitempty"`
+	Drifted       bool                 `protobuf:"varint,3,opt,name=drifted,proto3" json:"drifted,omitempty"`
+}
+
+func (x *ListDeviceStatesRequest) Reset() {
+	*x = ListDeviceStatesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_admin_proto_admin_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListDeviceStatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeviceStatesRequest) ProtoMessage() {}
+
+func (x *ListDeviceStatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_server_admin_proto_admin_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeviceStatesRequest.ProtoReflect.Descriptor instead.
+func (*ListDeviceStatesRequest) Descriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ListDeviceStatesRequest) GetSerialNumbers() []string {
+	if x != nil {
+		return x.SerialNumbers
+	}
+	return nil
+}
+
+func (x *ListDeviceStatesRequest) GetSilentFor() *durationpb.Duration {
+	if x != nil {
+		return x.SilentFor
+	}
+	return nil
+}
+
+func (x *ListDeviceStatesRequest) GetDrifted() bool {
+	if x != nil {
+		return x.Drifted
+	}
+	return false
+}
+
+type ListDeviceStatesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Devices []*DeviceState `protobuf:"bytes,1,rep,name=devices,proto3" json:"devices,omitempty"`
+}
+
+func (x *ListDeviceStatesResponse) Reset() {
+	*x = ListDeviceStatesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_admin_proto_admin_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListDeviceStatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeviceStatesResponse) ProtoMessage() {}
+
+func (x *ListDeviceStatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_server_admin_proto_admin_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeviceStatesResponse.ProtoReflect.Descriptor instead.
+func (*ListDeviceStatesResponse) Descriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListDeviceStatesResponse) GetDevices() []*DeviceState {
+	if x != nil {
+		return x.Devices
+	}
+	return nil
+}
+
+type ResetDeviceStateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SerialNumber string `protobuf:"bytes,1,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+}
+
+func (x *ResetDeviceStateRequest) Reset() {
+	*x = ResetDeviceStateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_admin_proto_admin_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResetDeviceStateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResetDeviceStateRequest) ProtoMessage() {}
+
+func (x *ResetDeviceStateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_server_admin_proto_admin_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResetDeviceStateRequest.ProtoReflect.Descriptor instead.
+func (*ResetDeviceStateRequest) Descriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ResetDeviceStateRequest) GetSerialNumber() string {
+	if x != nil {
+		return x.SerialNumber
+	}
+	return ""
+}
+
+type ResetDeviceStateResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ResetDeviceStateResponse) Reset() {
+	*x = ResetDeviceStateResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_admin_proto_admin_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResetDeviceStateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResetDeviceStateResponse) ProtoMessage() {}
+
+func (x *ResetDeviceStateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_server_admin_proto_admin_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResetDeviceStateResponse.ProtoReflect.Descriptor instead.
+func (*ResetDeviceStateResponse) Descriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{24}
+}
+
+type TriggerRebootstrapRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SerialNumber string `protobuf:"bytes,1,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+	Reboot       bool   `protobuf:"varint,2,opt,name=reboot,proto3" json:"reboot,omitempty"`
+	Reason       string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *TriggerRebootstrapRequest) Reset() {
+	*x = TriggerRebootstrapRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_admin_proto_admin_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TriggerRebootstrapRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TriggerRebootstrapRequest) ProtoMessage() {}
+
+func (x *TriggerRebootstrapRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_server_admin_proto_admin_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TriggerRebootstrapRequest.ProtoReflect.Descriptor instead.
+func (*TriggerRebootstrapRequest) Descriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *TriggerRebootstrapRequest) GetSerialNumber() string {
+	if x != nil {
+		return x.SerialNumber
+	}
+	return ""
+}
+
+func (x *TriggerRebootstrapRequest) GetReboot() bool {
+	if x != nil {
+		return x.Reboot
+	}
+	return false
+}
+
+func (x *TriggerRebootstrapRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type TriggerRebootstrapResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rebooted bool   `protobuf:"varint,1,opt,name=rebooted,proto3" json:"rebooted,omitempty"`
+	Address  string `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+}
+
+func (x *TriggerRebootstrapResponse) Reset() {
+	*x = TriggerRebootstrapResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_admin_proto_admin_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TriggerRebootstrapResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TriggerRebootstrapResponse) ProtoMessage() {}
+
+func (x *TriggerRebootstrapResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_server_admin_proto_admin_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TriggerRebootstrapResponse.ProtoReflect.Descriptor instead.
+func (*TriggerRebootstrapResponse) Descriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *TriggerRebootstrapResponse) GetRebooted() bool {
+	if x != nil {
+		return x.Rebooted
+	}
+	return false
+}
+
+func (x *TriggerRebootstrapResponse) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+type ReplacementDevice struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SerialNumber     string `protobuf:"bytes,1,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+	PartNumber       string `protobuf:"bytes,2,opt,name=part_number,json=partNumber,proto3" json:"part_number,omitempty"`
+	OwnershipVoucher []byte `protobuf:"bytes,3,opt,name=ownership_voucher,json=ownershipVoucher,proto3" json:"ownership_voucher,omitempty"`
+}
+
+func (x *ReplacementDevice) Reset() {
+	*x = ReplacementDevice{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_admin_proto_admin_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReplacementDevice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplacementDevice) ProtoMessage() {}
+
+func (x *ReplacementDevice) ProtoReflect() protoreflect.Message {
+	mi := &file_server_admin_proto_admin_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplacementDevice.ProtoReflect.Descriptor instead.
+func (*ReplacementDevice) Descriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ReplacementDevice) GetSerialNumber() string {
+	if x != nil {
+		return x.SerialNumber
+	}
+	return ""
+}
+
+func (x *ReplacementDevice) GetPartNumber() string {
+	if x != nil {
+		return x.PartNumber
+	}
+	return ""
+}
+
+func (x *ReplacementDevice) GetOwnershipVoucher() []byte {
+	if x != nil {
+		return x.OwnershipVoucher
+	}
+	return nil
+}
+
+type RetireDeviceRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SerialNumber string             `protobuf:"bytes,1,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+	Reason       string             `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	Replacement  *ReplacementDevice `protobuf:"bytes,3,opt,name=replacement,proto3" json:"replacement,omitempty"`
+}
+
+func (x *RetireDeviceRequest) Reset() {
+	*x = RetireDeviceRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_admin_proto_admin_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RetireDeviceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetireDeviceRequest) ProtoMessage() {}
+
+func (x *RetireDeviceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_server_admin_proto_admin_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetireDeviceRequest.ProtoReflect.Descriptor instead.
+func (*RetireDeviceRequest) Descriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *RetireDeviceRequest) GetSerialNumber() string {
+	if x != nil {
+		return x.SerialNumber
+	}
+	return ""
+}
+
+func (x *RetireDeviceRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *RetireDeviceRequest) GetReplacement() *ReplacementDevice {
+	if x != nil {
+		return x.Replacement
+	}
+	return nil
+}
+
+type RetiredDevice struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SerialNumber string                                   `protobuf:"bytes,1,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+	Reason       string                                   `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	RetiredAt    *timestamppb.Timestamp                   `protobuf:"bytes,3,opt,name=retired_at,json=retiredAt,proto3" json:"retired_at,omitempty"`
+	ReplacedBy   string                                   `protobuf:"bytes,4,opt,name=replaced_by,json=replacedBy,proto3" json:"replaced_by,omitempty"`
+	Chassis      *entity.Chassis                          `protobuf:"bytes,5,opt,name=chassis,proto3" json:"chassis,omitempty"`
+	State        *DeviceState                             `protobuf:"bytes,6,opt,name=state,proto3" json:"state,omitempty"`
+	Status       bootz.ControlCardState_ControlCardStatus `protobuf:"varint,7,opt,name=status,proto3,enum=bootz.proto.ControlCardState_ControlCardStatus" json:"status,omitempty"`
+}
+
+func (x *RetiredDevice) Reset() {
+	*x = RetiredDevice{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_admin_proto_admin_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RetiredDevice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetiredDevice) ProtoMessage() {}
+
+func (x *RetiredDevice) ProtoReflect() protoreflect.Message {
+	mi := &file_server_admin_proto_admin_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetiredDevice.ProtoReflect.Descriptor instead.
+func (*RetiredDevice) Descriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *RetiredDevice) GetSerialNumber() string {
+	if x != nil {
+		return x.SerialNumber
+	}
+	return ""
+}
+
+func (x *RetiredDevice) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *RetiredDevice) GetRetiredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RetiredAt
+	}
+	return nil
+}
+
+func (x *RetiredDevice) GetReplacedBy() string {
+	if x != nil {
+		return x.ReplacedBy
+	}
+	return ""
+}
+
+func (x *RetiredDevice) GetChassis() *entity.Chassis {
+	if x != nil {
+		return x.Chassis
+	}
+	return nil
+}
+
+func (x *RetiredDevice) GetState() *DeviceState {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+func (x *RetiredDevice) GetStatus() bootz.ControlCardState_ControlCardStatus {
+	if x != nil {
+		return x.Status
+	}
+	return bootz.ControlCardState_ControlCardStatus(0)
+}
+
+type RetireDeviceResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Retired *RetiredDevice `protobuf:"bytes,1,opt,name=retired,proto3" json:"retired,omitempty"`
+}
+
+func (x *RetireDeviceResponse) Reset() {
+	*x = RetireDeviceResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_admin_proto_admin_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RetireDeviceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetireDeviceResponse) ProtoMessage() {}
+
+func (x *RetireDeviceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_server_admin_proto_admin_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetireDeviceResponse.ProtoReflect.Descriptor instead.
+func (*RetireDeviceResponse) Descriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *RetireDeviceResponse) GetRetired() *RetiredDevice {
+	if x != nil {
+		return x.Retired
+	}
+	return nil
+}
+
+type ListRetiredDevicesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListRetiredDevicesRequest) Reset() {
+	*x = ListRetiredDevicesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_admin_proto_admin_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListRetiredDevicesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRetiredDevicesRequest) ProtoMessage() {}
+
+func (x *ListRetiredDevicesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_server_admin_proto_admin_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRetiredDevicesRequest.ProtoReflect.Descriptor instead.
+func (*ListRetiredDevicesRequest) Descriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{31}
+}
+
+type ListRetiredDevicesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Devices []*RetiredDevice `protobuf:"bytes,1,rep,name=devices,proto3" json:"devices,omitempty"`
+}
+
+func (x *ListRetiredDevicesResponse) Reset() {
+	*x = ListRetiredDevicesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_admin_proto_admin_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListRetiredDevicesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRetiredDevicesResponse) ProtoMessage() {}
+
+func (x *ListRetiredDevicesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_server_admin_proto_admin_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRetiredDevicesResponse.ProtoReflect.Descriptor instead.
+func (*ListRetiredDevicesResponse) Descriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ListRetiredDevicesResponse) GetDevices() []*RetiredDevice {
+	if x != nil {
+		return x.Devices
+	}
+	return nil
+}
+
+type ExportSnapshotRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ExportSnapshotRequest) Reset() {
+	*x = ExportSnapshotRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_admin_proto_admin_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExportSnapshotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportSnapshotRequest) ProtoMessage() {}
+
+func (x *ExportSnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_server_admin_proto_admin_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportSnapshotRequest.ProtoReflect.Descriptor instead.
+func (*ExportSnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{33}
+}
+
+type ExportSnapshotResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Snapshot *entity.Snapshot `protobuf:"bytes,1,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+}
+
+func (x *ExportSnapshotResponse) Reset() {
+	*x = ExportSnapshotResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_admin_proto_admin_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExportSnapshotResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportSnapshotResponse) ProtoMessage() {}
+
+func (x *ExportSnapshotResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_server_admin_proto_admin_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportSnapshotResponse.ProtoReflect.Descriptor instead.
+func (*ExportSnapshotResponse) Descriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ExportSnapshotResponse) GetSnapshot() *entity.Snapshot {
+	if x != nil {
+		return x.Snapshot
+	}
+	return nil
+}
+
+type RestoreSnapshotRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Snapshot *entity.Snapshot `protobuf:"bytes,1,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+}
+
+func (x *RestoreSnapshotRequest) Reset() {
+	*x = RestoreSnapshotRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_admin_proto_admin_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RestoreSnapshotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreSnapshotRequest) ProtoMessage() {}
+
+func (x *RestoreSnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_server_admin_proto_admin_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreSnapshotRequest.ProtoReflect.Descriptor instead.
+func (*RestoreSnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *RestoreSnapshotRequest) GetSnapshot() *entity.Snapshot {
+	if x != nil {
+		return x.Snapshot
+	}
+	return nil
+}
+
+type RestoreSnapshotResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *RestoreSnapshotResponse) Reset() {
+	*x = RestoreSnapshotResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_admin_proto_admin_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RestoreSnapshotResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreSnapshotResponse) ProtoMessage() {}
+
+func (x *RestoreSnapshotResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_server_admin_proto_admin_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreSnapshotResponse.ProtoReflect.Descriptor instead.
+func (*RestoreSnapshotResponse) Descriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{36}
+}
+
+type ListChassisRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListChassisRequest) Reset() {
+	*x = ListChassisRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_admin_proto_admin_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListChassisRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListChassisRequest) ProtoMessage() {}
+
+func (x *ListChassisRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_server_admin_proto_admin_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListChassisRequest.ProtoReflect.Descriptor instead.
+func (*ListChassisRequest) Descriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{37}
+}
+
+type ListChassisResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Chassis []*entity.Chassis `protobuf:"bytes,1,rep,name=chassis,proto3" json:"chassis,omitempty"`
+}
+
+func (x *ListChassisResponse) Reset() {
+	*x = ListChassisResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_admin_proto_admin_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListChassisResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListChassisResponse) ProtoMessage() {}
+
+func (x *ListChassisResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_server_admin_proto_admin_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListChassisResponse.ProtoReflect.Descriptor instead.
+func (*ListChassisResponse) Descriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ListChassisResponse) GetChassis() []*entity.Chassis {
+	if x != nil {
+		return x.Chassis
+	}
+	return nil
+}
+
+type AddChassisRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Chassis *entity.Chassis `protobuf:"bytes,1,opt,name=chassis,proto3" json:"chassis,omitempty"`
+	Replace bool            `protobuf:"varint,2,opt,name=replace,proto3" json:"replace,omitempty"`
+}
+
+func (x *AddChassisRequest) Reset() {
+	*x = AddChassisRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_admin_proto_admin_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddChassisRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddChassisRequest) ProtoMessage() {}
+
+func (x *AddChassisRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_server_admin_proto_admin_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddChassisRequest.ProtoReflect.Descriptor instead.
+func (*AddChassisRequest) Descriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *AddChassisRequest) GetChassis() *entity.Chassis {
+	if x != nil {
+		return x.Chassis
+	}
+	return nil
+}
+
+func (x *AddChassisRequest) GetReplace() bool {
+	if x != nil {
+		return x.Replace
+	}
+	return false
+}
+
+type AddChassisResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *AddChassisResponse) Reset() {
+	*x = AddChassisResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_admin_proto_admin_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddChassisResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddChassisResponse) ProtoMessage() {}
+
+func (x *AddChassisResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_server_admin_proto_admin_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddChassisResponse.ProtoReflect.Descriptor instead.
+func (*AddChassisResponse) Descriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{40}
+}
+
+type UploadOwnershipVoucherRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SerialNumber     string `protobuf:"bytes,1,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+	OwnershipVoucher []byte `protobuf:"bytes,2,opt,name=ownership_voucher,json=ownershipVoucher,proto3" json:"ownership_voucher,omitempty"`
+	Rotated          bool   `protobuf:"varint,3,opt,name=rotated,proto3" json:"rotated,omitempty"`
+}
+
+func (x *UploadOwnershipVoucherRequest) Reset() {
+	*x = UploadOwnershipVoucherRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_admin_proto_admin_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UploadOwnershipVoucherRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadOwnershipVoucherRequest) ProtoMessage() {}
+
+func (x *UploadOwnershipVoucherRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_server_admin_proto_admin_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadOwnershipVoucherRequest.ProtoReflect.Descriptor instead.
+func (*UploadOwnershipVoucherRequest) Descriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *UploadOwnershipVoucherRequest) GetSerialNumber() string {
+	if x != nil {
+		return x.SerialNumber
+	}
+	return ""
+}
+
+func (x *UploadOwnershipVoucherRequest) GetOwnershipVoucher() []byte {
+	if x != nil {
+		return x.OwnershipVoucher
+	}
+	return nil
+}
+
+func (x *UploadOwnershipVoucherRequest) GetRotated() bool {
+	if x != nil {
+		return x.Rotated
+	}
+	return false
+}
+
+type UploadOwnershipVoucherResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *UploadOwnershipVoucherResponse) Reset() {
+	*x = UploadOwnershipVoucherResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_admin_proto_admin_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UploadOwnershipVoucherResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadOwnershipVoucherResponse) ProtoMessage() {}
+
+func (x *UploadOwnershipVoucherResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_server_admin_proto_admin_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadOwnershipVoucherResponse.ProtoReflect.Descriptor instead.
+func (*UploadOwnershipVoucherResponse) Descriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{42}
+}
+
+type UploadOwnershipVoucherBundleRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Bundle  []byte `protobuf:"bytes,1,opt,name=bundle,proto3" json:"bundle,omitempty"`
+	Rotated bool   `protobuf:"varint,2,opt,name=rotated,proto3" json:"rotated,omitempty"`
+}
+
+func (x *UploadOwnershipVoucherBundleRequest) Reset() {
+	*x = UploadOwnershipVoucherBundleRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_admin_proto_admin_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UploadOwnershipVoucherBundleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadOwnershipVoucherBundleRequest) ProtoMessage() {}
+
+func (x *UploadOwnershipVoucherBundleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_server_admin_proto_admin_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadOwnershipVoucherBundleRequest.ProtoReflect.Descriptor instead.
+func (*UploadOwnershipVoucherBundleRequest) Descriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *UploadOwnershipVoucherBundleRequest) GetBundle() []byte {
+	if x != nil {
+		return x.Bundle
+	}
+	return nil
+}
+
+func (x *UploadOwnershipVoucherBundleRequest) GetRotated() bool {
+	if x != nil {
+		return x.Rotated
+	}
+	return false
+}
+
+type OwnershipVoucherUploadResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Source       string `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	SerialNumber string `protobuf:"bytes,2,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+	Error        string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *OwnershipVoucherUploadResult) Reset() {
+	*x = OwnershipVoucherUploadResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_admin_proto_admin_proto_msgTypes[44]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OwnershipVoucherUploadResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OwnershipVoucherUploadResult) ProtoMessage() {}
+
+func (x *OwnershipVoucherUploadResult) ProtoReflect() protoreflect.Message {
+	mi := &file_server_admin_proto_admin_proto_msgTypes[44]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OwnershipVoucherUploadResult.ProtoReflect.Descriptor instead.
+func (*OwnershipVoucherUploadResult) Descriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *OwnershipVoucherUploadResult) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *OwnershipVoucherUploadResult) GetSerialNumber() string {
+	if x != nil {
+		return x.SerialNumber
+	}
+	return ""
+}
+
+func (x *OwnershipVoucherUploadResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type UploadOwnershipVoucherBundleResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Results []*OwnershipVoucherUploadResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+}
+
+func (x *UploadOwnershipVoucherBundleResponse) Reset() {
+	*x = UploadOwnershipVoucherBundleResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_admin_proto_admin_proto_msgTypes[45]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UploadOwnershipVoucherBundleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadOwnershipVoucherBundleResponse) ProtoMessage() {}
+
+func (x *UploadOwnershipVoucherBundleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_server_admin_proto_admin_proto_msgTypes[45]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadOwnershipVoucherBundleResponse.ProtoReflect.Descriptor instead.
+func (*UploadOwnershipVoucherBundleResponse) Descriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *UploadOwnershipVoucherBundleResponse) GetResults() []*OwnershipVoucherUploadResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type ListChassisModulesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SerialNumbers []string `protobuf:"bytes,1,rep,name=serial_numbers,json=serialNumbers,proto3" json:"serial_numbers,omitempty"`
+}
+
+func (x *ListChassisModulesRequest) Reset() {
+	*x = ListChassisModulesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_admin_proto_admin_proto_msgTypes[46]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListChassisModulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListChassisModulesRequest) ProtoMessage() {}
+
+func (x *ListChassisModulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_server_admin_proto_admin_proto_msgTypes[46]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListChassisModulesRequest.ProtoReflect.Descriptor instead.
+func (*ListChassisModulesRequest) Descriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ListChassisModulesRequest) GetSerialNumbers() []string {
+	if x != nil {
+		return x.SerialNumbers
+	}
+	return nil
+}
+
+type ModuleInventory struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Reported   []*bootz.Module `protobuf:"bytes,1,rep,name=reported,proto3" json:"reported,omitempty"`
+	Missing    []*bootz.Module `protobuf:"bytes,2,rep,name=missing,proto3" json:"missing,omitempty"`
+	Unexpected []*bootz.Module `protobuf:"bytes,3,rep,name=unexpected,proto3" json:"unexpected,omitempty"`
+}
+
+func (x *ModuleInventory) Reset() {
+	*x = ModuleInventory{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_admin_proto_admin_proto_msgTypes[47]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ModuleInventory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModuleInventory) ProtoMessage() {}
+
+func (x *ModuleInventory) ProtoReflect() protoreflect.Message {
+	mi := &file_server_admin_proto_admin_proto_msgTypes[47]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModuleInventory.ProtoReflect.Descriptor instead.
+func (*ModuleInventory) Descriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *ModuleInventory) GetReported() []*bootz.Module {
+	if x != nil {
+		return x.Reported
+	}
+	return nil
+}
+
+func (x *ModuleInventory) GetMissing() []*bootz.Module {
+	if x != nil {
+		return x.Missing
+	}
+	return nil
+}
+
+func (x *ModuleInventory) GetUnexpected() []*bootz.Module {
+	if x != nil {
+		return x.Unexpected
+	}
+	return nil
+}
+
+type ChassisModules struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Manufacturer  string                 `protobuf:"bytes,1,opt,name=manufacturer,proto3" json:"manufacturer,omitempty"`
+	SerialNumber  string                 `protobuf:"bytes,2,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+	ReportedAt    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=reported_at,json=reportedAt,proto3" json:"reported_at,omitempty"`
+	LineCards     *ModuleInventory       `protobuf:"bytes,4,opt,name=line_cards,json=lineCards,proto3" json:"line_cards,omitempty"`
+	FabricModules *ModuleInventory       `protobuf:"bytes,5,opt,name=fabric_modules,json=fabricModules,proto3" json:"fabric_modules,omitempty"`
+}
+
+func (x *ChassisModules) Reset() {
+	*x = ChassisModules{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_admin_proto_admin_proto_msgTypes[48]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChassisModules) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChassisModules) ProtoMessage() {}
+
+func (x *ChassisModules) ProtoReflect() protoreflect.Message {
+	mi := &file_server_admin_proto_admin_proto_msgTypes[48]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChassisModules.ProtoReflect.Descriptor instead.
+func (*ChassisModules) Descriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *ChassisModules) GetManufacturer() string {
+	if x != nil {
+		return x.Manufacturer
+	}
+	return ""
+}
+
+func (x *ChassisModules) GetSerialNumber() string {
+	if x != nil {
+		return x.SerialNumber
+	}
+	return ""
+}
+
+func (x *ChassisModules) GetReportedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReportedAt
+	}
+	return nil
+}
+
+func (x *ChassisModules) GetLineCards() *ModuleInventory {
+	if x != nil {
+		return x.LineCards
+	}
+	return nil
+}
+
+func (x *ChassisModules) GetFabricModules() *ModuleInventory {
+	if x != nil {
+		return x.FabricModules
+	}
+	return nil
+}
+
+type ListChassisModulesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Chassis []*ChassisModules `protobuf:"bytes,1,rep,name=chassis,proto3" json:"chassis,omitempty"`
+}
+
+func (x *ListChassisModulesResponse) Reset() {
+	*x = ListChassisModulesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_admin_proto_admin_proto_msgTypes[49]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListChassisModulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListChassisModulesResponse) ProtoMessage() {}
+
+func (x *ListChassisModulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_server_admin_proto_admin_proto_msgTypes[49]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListChassisModulesResponse.ProtoReflect.Descriptor instead.
+func (*ListChassisModulesResponse) Descriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *ListChassisModulesResponse) GetChassis() []*ChassisModules {
+	if x != nil {
+		return x.Chassis
+	}
+	return nil
+}
+
+type ListPlatformCapabilitiesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListPlatformCapabilitiesRequest) Reset() {
+	*x = ListPlatformCapabilitiesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_admin_proto_admin_proto_msgTypes[50]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListPlatformCapabilitiesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPlatformCapabilitiesRequest) ProtoMessage() {}
+
+func (x *ListPlatformCapabilitiesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_server_admin_proto_admin_proto_msgTypes[50]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPlatformCapabilitiesRequest.ProtoReflect.Descriptor instead.
+func (*ListPlatformCapabilitiesRequest) Descriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{50}
+}
+
+type PlatformCapabilities struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Manufacturer  string            `protobuf:"bytes,1,opt,name=manufacturer,proto3" json:"manufacturer,omitempty"`
+	PartNumber    string            `protobuf:"bytes,2,opt,name=part_number,json=partNumber,proto3" json:"part_number,omitempty"`
+	Devices       uint32            `protobuf:"varint,3,opt,name=devices,proto3" json:"devices,omitempty"`
+	Features      map[string]uint32 `protobuf:"bytes,4,rep,name=features,proto3" json:"features,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	ArtifactTypes map[string]uint32 `protobuf:"bytes,5,rep,name=artifact_types,json=artifactTypes,proto3" json:"artifact_types,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+}
+
+func (x *PlatformCapabilities) Reset() {
+	*x = PlatformCapabilities{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_admin_proto_admin_proto_msgTypes[51]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PlatformCapabilities) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlatformCapabilities) ProtoMessage() {}
+
+func (x *PlatformCapabilities) ProtoReflect() protoreflect.Message {
+	mi := &file_server_admin_proto_admin_proto_msgTypes[51]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlatformCapabilities.ProtoReflect.Descriptor instead.
+func (*PlatformCapabilities) Descriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *PlatformCapabilities) GetManufacturer() string {
+	if x != nil {
+		return x.Manufacturer
+	}
+	return ""
+}
+
+func (x *PlatformCapabilities) GetPartNumber() string {
+	if x != nil {
+		return x.PartNumber
+	}
+	return ""
+}
+
+func (x *PlatformCapabilities) GetDevices() uint32 {
+	if x != nil {
+		return x.Devices
+	}
+	return 0
+}
+
+func (x *PlatformCapabilities) GetFeatures() map[string]uint32 {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+func (x *PlatformCapabilities) GetArtifactTypes() map[string]uint32 {
+	if x != nil {
+		return x.ArtifactTypes
+	}
+	return nil
+}
+
+type ListPlatformCapabilitiesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Platforms []*PlatformCapabilities `protobuf:"bytes,1,rep,name=platforms,proto3" json:"platforms,omitempty"`
+}
+
+func (x *ListPlatformCapabilitiesResponse) Reset() {
+	*x = ListPlatformCapabilitiesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_admin_proto_admin_proto_msgTypes[52]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListPlatformCapabilitiesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPlatformCapabilitiesResponse) ProtoMessage() {}
+
+func (x *ListPlatformCapabilitiesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_server_admin_proto_admin_proto_msgTypes[52]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPlatformCapabilitiesResponse.ProtoReflect.Descriptor instead.
+func (*ListPlatformCapabilitiesResponse) Descriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *ListPlatformCapabilitiesResponse) GetPlatforms() []*PlatformCapabilities {
+	if x != nil {
+		return x.Platforms
+	}
+	return nil
+}
+
+type IssueBootstrapTokenRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SerialNumber string               `protobuf:"bytes,1,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+	Ttl          *durationpb.Duration `protobuf:"bytes,2,opt,name=ttl,proto3" json:"ttl,omitempty"`
+}
+
+func (x *IssueBootstrapTokenRequest) Reset() {
+	*x = IssueBootstrapTokenRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_admin_proto_admin_proto_msgTypes[53]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IssueBootstrapTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IssueBootstrapTokenRequest) ProtoMessage() {}
+
+func (x *IssueBootstrapTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_server_admin_proto_admin_proto_msgTypes[53]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IssueBootstrapTokenRequest.ProtoReflect.Descriptor instead.
+func (*IssueBootstrapTokenRequest) Descriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *IssueBootstrapTokenRequest) GetSerialNumber() string {
+	if x != nil {
+		return x.SerialNumber
+	}
+	return ""
+}
+
+func (x *IssueBootstrapTokenRequest) GetTtl() *durationpb.Duration {
+	if x != nil {
+		return x.Ttl
+	}
+	return nil
+}
+
+type IssueBootstrapTokenResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Token     string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	ExpiresAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+}
+
+func (x *IssueBootstrapTokenResponse) Reset() {
+	*x = IssueBootstrapTokenResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_admin_proto_admin_proto_msgTypes[54]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IssueBootstrapTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IssueBootstrapTokenResponse) ProtoMessage() {}
+
+func (x *IssueBootstrapTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_server_admin_proto_admin_proto_msgTypes[54]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IssueBootstrapTokenResponse.ProtoReflect.Descriptor instead.
+func (*IssueBootstrapTokenResponse) Descriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *IssueBootstrapTokenResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *IssueBootstrapTokenResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type ListBootstrapTokensRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListBootstrapTokensRequest) Reset() {
+	*x = ListBootstrapTokensRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_admin_proto_admin_proto_msgTypes[55]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListBootstrapTokensRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBootstrapTokensRequest) ProtoMessage() {}
+
+func (x *ListBootstrapTokensRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_server_admin_proto_admin_proto_msgTypes[55]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBootstrapTokensRequest.ProtoReflect.Descriptor instead.
+func (*ListBootstrapTokensRequest) Descriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{55}
+}
+
+type BootstrapToken struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SerialNumber string                 `protobuf:"bytes,1,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+	IssuedAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=issued_at,json=issuedAt,proto3" json:"issued_at,omitempty"`
+	ExpiresAt    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+}
+
+func (x *BootstrapToken) Reset() {
+	*x = BootstrapToken{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_admin_proto_admin_proto_msgTypes[56]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BootstrapToken) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BootstrapToken) ProtoMessage() {}
+
+func (x *BootstrapToken) ProtoReflect() protoreflect.Message {
+	mi := &file_server_admin_proto_admin_proto_msgTypes[56]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BootstrapToken.ProtoReflect.Descriptor instead.
+func (*BootstrapToken) Descriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *BootstrapToken) GetSerialNumber() string {
+	if x != nil {
+		return x.SerialNumber
+	}
+	return ""
+}
+
+func (x *BootstrapToken) GetIssuedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.IssuedAt
+	}
+	return nil
+}
+
+func (x *BootstrapToken) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type ListBootstrapTokensResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tokens []*BootstrapToken `protobuf:"bytes,1,rep,name=tokens,proto3" json:"tokens,omitempty"`
+}
+
+func (x *ListBootstrapTokensResponse) Reset() {
+	*x = ListBootstrapTokensResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_admin_proto_admin_proto_msgTypes[57]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListBootstrapTokensResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBootstrapTokensResponse) ProtoMessage() {}
+
+func (x *ListBootstrapTokensResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_server_admin_proto_admin_proto_msgTypes[57]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBootstrapTokensResponse.ProtoReflect.Descriptor instead.
+func (*ListBootstrapTokensResponse) Descriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *ListBootstrapTokensResponse) GetTokens() []*BootstrapToken {
+	if x != nil {
+		return x.Tokens
+	}
+	return nil
+}
+
+type RevokeBootstrapTokenRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SerialNumber string `protobuf:"bytes,1,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+}
+
+func (x *RevokeBootstrapTokenRequest) Reset() {
+	*x = RevokeBootstrapTokenRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_admin_proto_admin_proto_msgTypes[58]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RevokeBootstrapTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeBootstrapTokenRequest) ProtoMessage() {}
+
+func (x *RevokeBootstrapTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_server_admin_proto_admin_proto_msgTypes[58]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeBootstrapTokenRequest.ProtoReflect.Descriptor instead.
+func (*RevokeBootstrapTokenRequest) Descriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *RevokeBootstrapTokenRequest) GetSerialNumber() string {
+	if x != nil {
+		return x.SerialNumber
+	}
+	return ""
+}
+
+type RevokeBootstrapTokenResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *RevokeBootstrapTokenResponse) Reset() {
+	*x = RevokeBootstrapTokenResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_admin_proto_admin_proto_msgTypes[59]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RevokeBootstrapTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeBootstrapTokenResponse) ProtoMessage() {}
+
+func (x *RevokeBootstrapTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_server_admin_proto_admin_proto_msgTypes[59]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeBootstrapTokenResponse.ProtoReflect.Descriptor instead.
+func (*RevokeBootstrapTokenResponse) Descriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{59}
+}
+
+type ListPendingDevicesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListPendingDevicesRequest) Reset() {
+	*x = ListPendingDevicesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_admin_proto_admin_proto_msgTypes[60]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListPendingDevicesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPendingDevicesRequest) ProtoMessage() {}
+
+func (x *ListPendingDevicesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_server_admin_proto_admin_proto_msgTypes[60]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPendingDevicesRequest.ProtoReflect.Descriptor instead.
+func (*ListPendingDevicesRequest) Descriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{60}
+}
+
+type PendingDevice struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ChassisDescriptor *bootz.ChassisDescriptor `protobuf:"bytes,1,opt,name=chassis_descriptor,json=chassisDescriptor,proto3" json:"chassis_descriptor,omitempty"`
+	SourceAddress     string                   `protobuf:"bytes,2,opt,name=source_address,json=sourceAddress,proto3" json:"source_address,omitempty"`
+	FirstSeen         *timestamppb.Timestamp   `protobuf:"bytes,3,opt,name=first_seen,json=firstSeen,proto3" json:"first_seen,omitempty"`
+	LastSeen          *timestamppb.Timestamp   `protobuf:"bytes,4,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`
+	Requests          uint64                   `protobuf:"varint,5,opt,name=requests,proto3" json:"requests,omitempty"`
+}
+
+func (x *PendingDevice) Reset() {
+	*x = PendingDevice{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_admin_proto_admin_proto_msgTypes[61]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PendingDevice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PendingDevice) ProtoMessage() {}
+
+func (x *PendingDevice) ProtoReflect() protoreflect.Message {
+	mi := &file_server_admin_proto_admin_proto_msgTypes[61]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PendingDevice.ProtoReflect.Descriptor instead.
+func (*PendingDevice) Descriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *PendingDevice) GetChassisDescriptor() *bootz.ChassisDescriptor {
+	if x != nil {
+		return x.ChassisDescriptor
+	}
+	return nil
+}
+
+func (x *PendingDevice) GetSourceAddress() string {
+	if x != nil {
+		return x.SourceAddress
+	}
+	return ""
+}
+
+func (x *PendingDevice) GetFirstSeen() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FirstSeen
+	}
+	return nil
+}
+
+func (x *PendingDevice) GetLastSeen() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSeen
+	}
+	return nil
+}
+
+func (x *PendingDevice) GetRequests() uint64 {
+	if x != nil {
+		return x.Requests
+	}
+	return 0
+}
+
+type ListPendingDevicesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Devices []*PendingDevice `protobuf:"bytes,1,rep,name=devices,proto3" json:"devices,omitempty"`
+}
+
+func (x *ListPendingDevicesResponse) Reset() {
+	*x = ListPendingDevicesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_admin_proto_admin_proto_msgTypes[62]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListPendingDevicesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPendingDevicesResponse) ProtoMessage() {}
+
+func (x *ListPendingDevicesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_server_admin_proto_admin_proto_msgTypes[62]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPendingDevicesResponse.ProtoReflect.Descriptor instead.
+func (*ListPendingDevicesResponse) Descriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *ListPendingDevicesResponse) GetDevices() []*PendingDevice {
+	if x != nil {
+		return x.Devices
+	}
+	return nil
+}
+
+type ApproveDeviceRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Manufacturer string          `protobuf:"bytes,1,opt,name=manufacturer,proto3" json:"manufacturer,omitempty"`
+	SerialNumber string          `protobuf:"bytes,2,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+	Profile      *entity.Chassis `protobuf:"bytes,3,opt,name=profile,proto3" json:"profile,omitempty"`
+}
+
+func (x *ApproveDeviceRequest) Reset() {
+	*x = ApproveDeviceRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_admin_proto_admin_proto_msgTypes[63]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ApproveDeviceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveDeviceRequest) ProtoMessage() {}
+
+func (x *ApproveDeviceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_server_admin_proto_admin_proto_msgTypes[63]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveDeviceRequest.ProtoReflect.Descriptor instead.
+func (*ApproveDeviceRequest) Descriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *ApproveDeviceRequest) GetManufacturer() string {
+	if x != nil {
+		return x.Manufacturer
+	}
+	return ""
+}
+
+func (x *ApproveDeviceRequest) GetSerialNumber() string {
+	if x != nil {
+		return x.SerialNumber
+	}
+	return ""
+}
+
+func (x *ApproveDeviceRequest) GetProfile() *entity.Chassis {
+	if x != nil {
+		return x.Profile
+	}
+	return nil
+}
+
+type ApproveDeviceResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Chassis *entity.Chassis `protobuf:"bytes,1,opt,name=chassis,proto3" json:"chassis,omitempty"`
+}
+
+func (x *ApproveDeviceResponse) Reset() {
+	*x = ApproveDeviceResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_admin_proto_admin_proto_msgTypes[64]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ApproveDeviceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveDeviceResponse) ProtoMessage() {}
+
+func (x *ApproveDeviceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_server_admin_proto_admin_proto_msgTypes[64]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveDeviceResponse.ProtoReflect.Descriptor instead.
+func (*ApproveDeviceResponse) Descriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *ApproveDeviceResponse) GetChassis() *entity.Chassis {
+	if x != nil {
+		return x.Chassis
+	}
+	return nil
+}
+
+type RejectDeviceRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Manufacturer string `protobuf:"bytes,1,opt,name=manufacturer,proto3" json:"manufacturer,omitempty"`
+	SerialNumber string `protobuf:"bytes,2,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+}
+
+func (x *RejectDeviceRequest) Reset() {
+	*x = RejectDeviceRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_admin_proto_admin_proto_msgTypes[65]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RejectDeviceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RejectDeviceRequest) ProtoMessage() {}
+
+func (x *RejectDeviceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_server_admin_proto_admin_proto_msgTypes[65]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RejectDeviceRequest.ProtoReflect.Descriptor instead.
+func (*RejectDeviceRequest) Descriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *RejectDeviceRequest) GetManufacturer() string {
+	if x != nil {
+		return x.Manufacturer
+	}
+	return ""
+}
+
+func (x *RejectDeviceRequest) GetSerialNumber() string {
+	if x != nil {
+		return x.SerialNumber
+	}
+	return ""
+}
+
+type RejectDeviceResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *RejectDeviceResponse) Reset() {
+	*x = RejectDeviceResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_admin_proto_admin_proto_msgTypes[66]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RejectDeviceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RejectDeviceResponse) ProtoMessage() {}
+
+func (x *RejectDeviceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_server_admin_proto_admin_proto_msgTypes[66]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RejectDeviceResponse.ProtoReflect.Descriptor instead.
+func (*RejectDeviceResponse) Descriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{66}
+}
+
+type GetLogLevelsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetLogLevelsRequest) Reset() {
+	*x = GetLogLevelsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_admin_proto_admin_proto_msgTypes[67]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetLogLevelsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLogLevelsRequest) ProtoMessage() {}
+
+func (x *GetLogLevelsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_server_admin_proto_admin_proto_msgTypes[67]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLogLevelsRequest.ProtoReflect.Descriptor instead.
+func (*GetLogLevelsRequest) Descriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{67}
+}
+
+type GetLogLevelsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DefaultLevel string            `protobuf:"bytes,1,opt,name=default_level,json=defaultLevel,proto3" json:"default_level,omitempty"`
+	Levels       map[string]string `protobuf:"bytes,2,rep,name=levels,proto3" json:"levels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Modules      []string          `protobuf:"bytes,3,rep,name=modules,proto3" json:"modules,omitempty"`
+}
+
+func (x *GetLogLevelsResponse) Reset() {
+	*x = GetLogLevelsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_admin_proto_admin_proto_msgTypes[68]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetLogLevelsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLogLevelsResponse) ProtoMessage() {}
+
+func (x *GetLogLevelsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_server_admin_proto_admin_proto_msgTypes[68]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLogLevelsResponse.ProtoReflect.Descriptor instead.
+func (*GetLogLevelsResponse) Descriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *GetLogLevelsResponse) GetDefaultLevel() string {
+	if x != nil {
+		return x.DefaultLevel
+	}
+	return ""
+}
+
+func (x *GetLogLevelsResponse) GetLevels() map[string]string {
+	if x != nil {
+		return x.Levels
+	}
+	return nil
+}
+
+func (x *GetLogLevelsResponse) GetModules() []string {
+	if x != nil {
+		return x.Modules
+	}
+	return nil
+}
+
+type SetLogLevelRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Module string `protobuf:"bytes,1,opt,name=module,proto3" json:"module,omitempty"`
+	Level  string `protobuf:"bytes,2,opt,name=level,proto3" json:"level,omitempty"`
+}
+
+func (x *SetLogLevelRequest) Reset() {
+	*x = SetLogLevelRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_admin_proto_admin_proto_msgTypes[69]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetLogLevelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetLogLevelRequest) ProtoMessage() {}
+
+func (x *SetLogLevelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_server_admin_proto_admin_proto_msgTypes[69]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetLogLevelRequest.ProtoReflect.Descriptor instead.
+func (*SetLogLevelRequest) Descriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *SetLogLevelRequest) GetModule() string {
+	if x != nil {
+		return x.Module
+	}
+	return ""
+}
+
+func (x *SetLogLevelRequest) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+type SetLogLevelResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *SetLogLevelResponse) Reset() {
+	*x = SetLogLevelResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_admin_proto_admin_proto_msgTypes[70]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetLogLevelResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetLogLevelResponse) ProtoMessage() {}
+
+func (x *SetLogLevelResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_server_admin_proto_admin_proto_msgTypes[70]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetLogLevelResponse.ProtoReflect.Descriptor instead.
+func (*SetLogLevelResponse) Descriptor() ([]byte, []int) {
+	return file_server_admin_proto_admin_proto_rawDescGZIP(), []int{70}
+}
+
+var File_server_admin_proto_admin_proto protoreflect.FileDescriptor
+
+var file_server_admin_proto_admin_proto_rawDesc = []byte{
+	0x0a, 0x1e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x12, 0x05, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x11, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f,
+	0x62, 0x6f, 0x6f, 0x74, 0x7a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x27, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x2f, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65,
+	0x72, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x22, 0x19, 0x0a, 0x17, 0x4c, 0x69, 0x73, 0x74, 0x46, 0x65, 0x61, 0x74,
+	0x75, 0x72, 0x65, 0x46, 0x6c, 0x61, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22,
+	0x45, 0x0a, 0x18, 0x4c, 0x69, 0x73, 0x74, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x46, 0x6c,
+	0x61, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x29, 0x0a, 0x05, 0x66,
+	0x6c, 0x61, 0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x65, 0x6e, 0x74,
+	0x69, 0x74, 0x79, 0x2e, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x46, 0x6c, 0x61, 0x67, 0x52,
+	0x05, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x22, 0x56, 0x0a, 0x15, 0x53, 0x65, 0x74, 0x46, 0x65, 0x61,
+	0x74, 0x75, 0x72, 0x65, 0x46, 0x6c, 0x61, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x27, 0x0a, 0x04, 0x66, 0x6c, 0x61, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e,
+	0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x2e, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x46, 0x6c,
+	0x61, 0x67, 0x52, 0x04, 0x66, 0x6c, 0x61, 0x67, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6c, 0x65, 0x61,
+	0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x63, 0x6c, 0x65, 0x61, 0x72, 0x22, 0x18,
+	0x0a, 0x16, 0x53, 0x65, 0x74, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x46, 0x6c, 0x61, 0x67,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x91, 0x01, 0x0a, 0x17, 0x52, 0x65, 0x76,
+	0x6f, 0x6b, 0x65, 0x64, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x56, 0x6f, 0x75,
+	0x63, 0x68, 0x65, 0x72, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x5f, 0x6e,
+	0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x65, 0x72,
+	0x69, 0x61, 0x6c, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61,
+	0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f,
+	0x6e, 0x12, 0x39, 0x0a, 0x0a, 0x72, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x09, 0x72, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x64, 0x41, 0x74, 0x22, 0x25, 0x0a, 0x23,
+	0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x64, 0x4f, 0x77, 0x6e, 0x65, 0x72,
+	0x73, 0x68, 0x69, 0x70, 0x56, 0x6f, 0x75, 0x63, 0x68, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x22, 0x62, 0x0a, 0x24, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x76, 0x6f, 0x6b,
+	0x65, 0x64, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x56, 0x6f, 0x75, 0x63, 0x68,
+	0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3a, 0x0a, 0x08, 0x76,
+	0x6f, 0x75, 0x63, 0x68, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e,
+	0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x64, 0x4f, 0x77, 0x6e,
+	0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x56, 0x6f, 0x75, 0x63, 0x68, 0x65, 0x72, 0x52, 0x08, 0x76,
+	0x6f, 0x75, 0x63, 0x68, 0x65, 0x72, 0x73, 0x22, 0x5c, 0x0a, 0x1d, 0x52, 0x65, 0x76, 0x6f, 0x6b,
+	0x65, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x56, 0x6f, 0x75, 0x63, 0x68, 0x65,
+	0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x65, 0x72, 0x69,
+	0x61, 0x6c, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0c, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x16, 0x0a,
+	0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72,
+	0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x20, 0x0a, 0x1e, 0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x4f,
+	0x77, 0x6e, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x56, 0x6f, 0x75, 0x63, 0x68, 0x65, 0x72, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x6d, 0x0a, 0x1c, 0x47, 0x65, 0x6e, 0x65, 0x72,
+	0x61, 0x74, 0x65, 0x53, 0x74, 0x61, 0x67, 0x69, 0x6e, 0x67, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x4d, 0x0a, 0x12, 0x63, 0x68, 0x61, 0x73, 0x73,
+	0x69, 0x73, 0x5f, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x7a, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x43, 0x68, 0x61, 0x73, 0x73, 0x69, 0x73, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x6f, 0x72, 0x52, 0x11, 0x63, 0x68, 0x61, 0x73, 0x73, 0x69, 0x73, 0x44, 0x65, 0x73, 0x63,
+	0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x22, 0x53, 0x0a, 0x1d, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61,
+	0x74, 0x65, 0x53, 0x74, 0x61, 0x67, 0x69, 0x6e, 0x67, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x32, 0x0a, 0x06, 0x62, 0x75, 0x6e, 0x64, 0x6c,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x7a, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x74, 0x61, 0x67, 0x69, 0x6e, 0x67, 0x42, 0x75, 0x6e,
+	0x64, 0x6c, 0x65, 0x52, 0x06, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x22, 0xc3, 0x02, 0x0a, 0x13,
+	0x53, 0x74, 0x61, 0x67, 0x69, 0x6e, 0x67, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x49, 0x64,
+	0x12, 0x4d, 0x0a, 0x12, 0x63, 0x68, 0x61, 0x73, 0x73, 0x69, 0x73, 0x5f, 0x64, 0x65, 0x73, 0x63,
+	0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x62,
+	0x6f, 0x6f, 0x74, 0x7a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x68, 0x61, 0x73, 0x73,
+	0x69, 0x73, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x52, 0x11, 0x63, 0x68,
+	0x61, 0x73, 0x73, 0x69, 0x73, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x12,
+	0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x48, 0x0a, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x30, 0x2e, 0x62, 0x6f, 0x6f,
+	0x74, 0x7a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x42, 0x6f, 0x6f,
+	0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x3b, 0x0a, 0x0b, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64,
+	0x5f, 0x61, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x41,
+	0x74, 0x22, 0x1b, 0x0a, 0x19, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x74, 0x61, 0x67, 0x69, 0x6e, 0x67,
+	0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x52,
+	0x0a, 0x1a, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x74, 0x61, 0x67, 0x69, 0x6e, 0x67, 0x42, 0x75, 0x6e,
+	0x64, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x34, 0x0a, 0x07,
+	0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x53, 0x74, 0x61, 0x67, 0x69, 0x6e, 0x67, 0x42, 0x75, 0x6e,
+	0x64, 0x6c, 0x65, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x07, 0x62, 0x75, 0x6e, 0x64, 0x6c,
+	0x65, 0x73, 0x22, 0xef, 0x01, 0x0a, 0x13, 0x45, 0x78, 0x70, 0x65, 0x72, 0x69, 0x6d, 0x65, 0x6e,
+	0x74, 0x41, 0x72, 0x6d, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x72,
+	0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x61, 0x72, 0x6d, 0x12, 0x1a, 0x0a, 0x08,
+	0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08,
+	0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x75, 0x63, 0x63,
+	0x65, 0x65, 0x64, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x73, 0x75, 0x63,
+	0x63, 0x65, 0x65, 0x64, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x12, 0x21,
+	0x0a, 0x0c, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x52, 0x61, 0x74,
+	0x65, 0x12, 0x51, 0x0a, 0x17, 0x6d, 0x65, 0x61, 0x6e, 0x5f, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74,
+	0x72, 0x61, 0x70, 0x5f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x15, 0x6d,
+	0x65, 0x61, 0x6e, 0x42, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x44, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x22, 0x56, 0x0a, 0x10, 0x45, 0x78, 0x70, 0x65, 0x72, 0x69, 0x6d, 0x65,
+	0x6e, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x2e, 0x0a, 0x04,
+	0x61, 0x72, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x61, 0x64, 0x6d,
+	0x69, 0x6e, 0x2e, 0x45, 0x78, 0x70, 0x65, 0x72, 0x69, 0x6d, 0x65, 0x6e, 0x74, 0x41, 0x72, 0x6d,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x04, 0x61, 0x72, 0x6d, 0x73, 0x22, 0x1e, 0x0a, 0x1c,
+	0x4c, 0x69, 0x73, 0x74, 0x45, 0x78, 0x70, 0x65, 0x72, 0x69, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x5a, 0x0a, 0x1d,
+	0x4c, 0x69, 0x73, 0x74, 0x45, 0x78, 0x70, 0x65, 0x72, 0x69, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x39, 0x0a,
+	0x0b, 0x65, 0x78, 0x70, 0x65, 0x72, 0x69, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x17, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x45, 0x78, 0x70, 0x65, 0x72,
+	0x69, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x0b, 0x65, 0x78, 0x70,
+	0x65, 0x72, 0x69, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x22, 0x45, 0x0a, 0x1c, 0x53, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x42, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x65, 0x72, 0x69,
+	0x61, 0x6c, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x0d, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x22,
+	0x8a, 0x02, 0x0a, 0x14, 0x42, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x65, 0x72, 0x69,
+	0x61, 0x6c, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0c, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x32, 0x0a,
+	0x15, 0x63, 0x68, 0x61, 0x73, 0x73, 0x69, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x5f,
+	0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x13, 0x63, 0x68,
+	0x61, 0x73, 0x73, 0x69, 0x73, 0x53, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x4e, 0x75, 0x6d, 0x62, 0x65,
+	0x72, 0x12, 0x22, 0x0a, 0x0c, 0x6d, 0x61, 0x6e, 0x75, 0x66, 0x61, 0x63, 0x74, 0x75, 0x72, 0x65,
+	0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6d, 0x61, 0x6e, 0x75, 0x66, 0x61, 0x63,
+	0x74, 0x75, 0x72, 0x65, 0x72, 0x12, 0x2b, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x67, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x42, 0x6f, 0x6f,
+	0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x53, 0x74, 0x61, 0x67, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61,
+	0x67, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x2e, 0x0a, 0x04,
+	0x74, 0x69, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x22, 0xa2, 0x05, 0x0a,
+	0x0b, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x23, 0x0a, 0x0d,
+	0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x4e, 0x75, 0x6d, 0x62, 0x65,
+	0x72, 0x12, 0x31, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x1b, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x42,
+	0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73,
+	0x74, 0x61, 0x74, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x72, 0x65, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x39,
+	0x0a, 0x0a, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09,
+	0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x41, 0x74, 0x12, 0x33, 0x0a, 0x07, 0x73, 0x65, 0x6e,
+	0x74, 0x5f, 0x61, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x06, 0x73, 0x65, 0x6e, 0x74, 0x41, 0x74, 0x12, 0x3b,
+	0x0a, 0x0b, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x0a, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x2e, 0x0a, 0x05, 0x73,
+	0x74, 0x61, 0x67, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x61, 0x64, 0x6d,
+	0x69, 0x6e, 0x2e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x53,
+	0x74, 0x61, 0x67, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x67, 0x65, 0x12, 0x3c, 0x0a, 0x0c, 0x6c,
+	0x61, 0x73, 0x74, 0x5f, 0x73, 0x65, 0x65, 0x6e, 0x5f, 0x61, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x6c,
+	0x61, 0x73, 0x74, 0x53, 0x65, 0x65, 0x6e, 0x41, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x6c, 0x61, 0x73,
+	0x74, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x6c, 0x61, 0x73, 0x74, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x2a, 0x0a, 0x11,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x5f, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x68, 0x61, 0x73,
+	0x68, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x49,
+	0x6d, 0x61, 0x67, 0x65, 0x48, 0x61, 0x73, 0x68, 0x12, 0x2c, 0x0a, 0x12, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x64, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x0b,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x48, 0x61, 0x73, 0x68, 0x12, 0x2c, 0x0a, 0x12, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x65,
+	0x64, 0x5f, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x0c, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x10, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x65, 0x64, 0x49, 0x6d, 0x61, 0x67, 0x65,
+	0x48, 0x61, 0x73, 0x68, 0x12, 0x2e, 0x0a, 0x13, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x65, 0x64, 0x5f,
+	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x0d, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x11, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x65, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x48, 0x61, 0x73, 0x68, 0x12, 0x2b, 0x0a, 0x11, 0x64, 0x72, 0x69, 0x66, 0x74, 0x65, 0x64, 0x5f,
+	0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x10, 0x64, 0x72, 0x69, 0x66, 0x74, 0x65, 0x64, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74,
+	0x73, 0x22, 0x94, 0x01, 0x0a, 0x17, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x25, 0x0a,
+	0x0e, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x4e, 0x75, 0x6d,
+	0x62, 0x65, 0x72, 0x73, 0x12, 0x38, 0x0a, 0x0a, 0x73, 0x69, 0x6c, 0x65, 0x6e, 0x74, 0x5f, 0x66,
+	0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x09, 0x73, 0x69, 0x6c, 0x65, 0x6e, 0x74, 0x46, 0x6f, 0x72, 0x12, 0x18,
+	0x0a, 0x07, 0x64, 0x72, 0x69, 0x66, 0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x07, 0x64, 0x72, 0x69, 0x66, 0x74, 0x65, 0x64, 0x22, 0x48, 0x0a, 0x18, 0x4c, 0x69, 0x73, 0x74,
+	0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x07, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x44, 0x65,
+	0x76, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x07, 0x64, 0x65, 0x76, 0x69, 0x63,
+	0x65, 0x73, 0x22, 0x3e, 0x0a, 0x17, 0x52, 0x65, 0x73, 0x65, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63,
+	0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x23, 0x0a,
+	0x0d, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x4e, 0x75, 0x6d, 0x62,
+	0x65, 0x72, 0x22, 0x1a, 0x0a, 0x18, 0x52, 0x65, 0x73, 0x65, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63,
+	0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x70,
+	0x0a, 0x19, 0x54, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x52, 0x65, 0x62, 0x6f, 0x6f, 0x74, 0x73,
+	0x74, 0x72, 0x61, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x73,
+	0x65, 0x72, 0x69, 0x61, 0x6c, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0c, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72,
+	0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x62, 0x6f, 0x6f, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x06, 0x72, 0x65, 0x62, 0x6f, 0x6f, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73,
+	0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e,
+	0x22, 0x52, 0x0a, 0x1a, 0x54, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x52, 0x65, 0x62, 0x6f, 0x6f,
+	0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a,
+	0x0a, 0x08, 0x72, 0x65, 0x62, 0x6f, 0x6f, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x08, 0x72, 0x65, 0x62, 0x6f, 0x6f, 0x74, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64,
+	0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x22, 0x86, 0x01, 0x0a, 0x11, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65,
+	0x6d, 0x65, 0x6e, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x65,
+	0x72, 0x69, 0x61, 0x6c, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0c, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12,
+	0x1f, 0x0a, 0x0b, 0x70, 0x61, 0x72, 0x74, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x61, 0x72, 0x74, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72,
+	0x12, 0x2b, 0x0a, 0x11, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x5f, 0x76, 0x6f,
+	0x75, 0x63, 0x68, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x10, 0x6f, 0x77, 0x6e,
+	0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x56, 0x6f, 0x75, 0x63, 0x68, 0x65, 0x72, 0x22, 0x8e, 0x01,
+	0x0a, 0x13, 0x52, 0x65, 0x74, 0x69, 0x72, 0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x5f,
+	0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x65,
+	0x72, 0x69, 0x61, 0x6c, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65,
+	0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73,
+	0x6f, 0x6e, 0x12, 0x3a, 0x0a, 0x0b, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e,
+	0x52, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63,
+	0x65, 0x52, 0x0b, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x22, 0xc6,
+	0x02, 0x0a, 0x0d, 0x52, 0x65, 0x74, 0x69, 0x72, 0x65, 0x64, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65,
+	0x12, 0x23, 0x0a, 0x0d, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65,
+	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x4e,
+	0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x39, 0x0a,
+	0x0a, 0x72, 0x65, 0x74, 0x69, 0x72, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x72,
+	0x65, 0x74, 0x69, 0x72, 0x65, 0x64, 0x41, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x70, 0x6c,
+	0x61, 0x63, 0x65, 0x64, 0x5f, 0x62, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72,
+	0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x64, 0x42, 0x79, 0x12, 0x29, 0x0a, 0x07, 0x63, 0x68, 0x61,
+	0x73, 0x73, 0x69, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x65, 0x6e, 0x74,
+	0x69, 0x74, 0x79, 0x2e, 0x43, 0x68, 0x61, 0x73, 0x73, 0x69, 0x73, 0x52, 0x07, 0x63, 0x68, 0x61,
+	0x73, 0x73, 0x69, 0x73, 0x12, 0x28, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x44, 0x65, 0x76, 0x69,
+	0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x47,
+	0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2f,
+	0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x7a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6e,
+	0x74, 0x72, 0x6f, 0x6c, 0x43, 0x61, 0x72, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x43, 0x6f,
+	0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x43, 0x61, 0x72, 0x64, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x46, 0x0a, 0x14, 0x52, 0x65, 0x74, 0x69, 0x72,
+	0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x2e, 0x0a, 0x07, 0x72, 0x65, 0x74, 0x69, 0x72, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x14, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x52, 0x65, 0x74, 0x69, 0x72, 0x65, 0x64,
+	0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x07, 0x72, 0x65, 0x74, 0x69, 0x72, 0x65, 0x64, 0x22,
+	0x1b, 0x0a, 0x19, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x74, 0x69, 0x72, 0x65, 0x64, 0x44, 0x65,
+	0x76, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x4c, 0x0a, 0x1a,
+	0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x74, 0x69, 0x72, 0x65, 0x64, 0x44, 0x65, 0x76, 0x69, 0x63,
+	0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a, 0x07, 0x64, 0x65,
+	0x76, 0x69, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x61, 0x64,
+	0x6d, 0x69, 0x6e, 0x2e, 0x52, 0x65, 0x74, 0x69, 0x72, 0x65, 0x64, 0x44, 0x65, 0x76, 0x69, 0x63,
+	0x65, 0x52, 0x07, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x22, 0x17, 0x0a, 0x15, 0x45, 0x78,
+	0x70, 0x6f, 0x72, 0x74, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x22, 0x46, 0x0a, 0x16, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x6e, 0x61,
+	0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a,
+	0x08, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x10, 0x2e, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f,
+	0x74, 0x52, 0x08, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x22, 0x46, 0x0a, 0x16, 0x52,
+	0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2c, 0x0a, 0x08, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79,
+	0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x08, 0x73, 0x6e, 0x61, 0x70, 0x73,
+	0x68, 0x6f, 0x74, 0x22, 0x19, 0x0a, 0x17, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x6e,
+	0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x14,
+	0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x68, 0x61, 0x73, 0x73, 0x69, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x22, 0x40, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x68, 0x61, 0x73,
+	0x73, 0x69, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x29, 0x0a, 0x07, 0x63,
+	0x68, 0x61, 0x73, 0x73, 0x69, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x65,
+	0x6e, 0x74, 0x69, 0x74, 0x79, 0x2e, 0x43, 0x68, 0x61, 0x73, 0x73, 0x69, 0x73, 0x52, 0x07, 0x63,
+	0x68, 0x61, 0x73, 0x73, 0x69, 0x73, 0x22, 0x58, 0x0a, 0x11, 0x41, 0x64, 0x64, 0x43, 0x68, 0x61,
+	0x73, 0x73, 0x69, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x29, 0x0a, 0x07, 0x63,
+	0x68, 0x61, 0x73, 0x73, 0x69, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x65,
+	0x6e, 0x74, 0x69, 0x74, 0x79, 0x2e, 0x43, 0x68, 0x61, 0x73, 0x73, 0x69, 0x73, 0x52, 0x07, 0x63,
+	0x68, 0x61, 0x73, 0x73, 0x69, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x63,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65,
+	0x22, 0x14, 0x0a, 0x12, 0x41, 0x64, 0x64, 0x43, 0x68, 0x61, 0x73, 0x73, 0x69, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x8b, 0x01, 0x0a, 0x1d, 0x55, 0x70, 0x6c, 0x6f, 0x61,
+	0x64, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x56, 0x6f, 0x75, 0x63, 0x68, 0x65,
+	0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x65, 0x72, 0x69,
+	0x61, 0x6c, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0c, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x2b, 0x0a,
+	0x11, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x5f, 0x76, 0x6f, 0x75, 0x63, 0x68,
+	0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x10, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x73,
+	0x68, 0x69, 0x70, 0x56, 0x6f, 0x75, 0x63, 0x68, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x6f,
+	0x74, 0x61, 0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x72, 0x6f, 0x74,
+	0x61, 0x74, 0x65, 0x64, 0x22, 0x20, 0x0a, 0x1e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x4f, 0x77,
+	0x6e, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x56, 0x6f, 0x75, 0x63, 0x68, 0x65, 0x72, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x57, 0x0a, 0x23, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64,
+	0x4f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x56, 0x6f, 0x75, 0x63, 0x68, 0x65, 0x72,
+	0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a,
+	0x06, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x62,
+	0x75, 0x6e, 0x64, 0x6c, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x72, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x64, 0x22,
+	0x71, 0x0a, 0x1c, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x56, 0x6f, 0x75, 0x63,
+	0x68, 0x65, 0x72, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12,
+	0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x65, 0x72, 0x69, 0x61,
+	0x6c, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
+	0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72,
+	0x6f, 0x72, 0x22, 0x65, 0x0a, 0x24, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x4f, 0x77, 0x6e, 0x65,
+	0x72, 0x73, 0x68, 0x69, 0x70, 0x56, 0x6f, 0x75, 0x63, 0x68, 0x65, 0x72, 0x42, 0x75, 0x6e, 0x64,
+	0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3d, 0x0a, 0x07, 0x72, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x61, 0x64,
+	0x6d, 0x69, 0x6e, 0x2e, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x56, 0x6f, 0x75,
+	0x63, 0x68, 0x65, 0x72, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x22, 0x42, 0x0a, 0x19, 0x4c, 0x69, 0x73,
+	0x74, 0x43, 0x68, 0x61, 0x73, 0x73, 0x69, 0x73, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c,
+	0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d,
+	0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x22, 0xa6, 0x01,
+	0x0a, 0x0f, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x49, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72,
+	0x79, 0x12, 0x2f, 0x0a, 0x08, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x7a, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x52, 0x08, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74,
+	0x65, 0x64, 0x12, 0x2d, 0x0a, 0x07, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x7a, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x52, 0x07, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6e,
+	0x67, 0x12, 0x33, 0x0a, 0x0a, 0x75, 0x6e, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x7a, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x52, 0x0a, 0x75, 0x6e, 0x65, 0x78,
+	0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x22, 0x8c, 0x02, 0x0a, 0x0e, 0x43, 0x68, 0x61, 0x73, 0x73,
+	0x69, 0x73, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x6d, 0x61, 0x6e,
+	0x75, 0x66, 0x61, 0x63, 0x74, 0x75, 0x72, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0c, 0x6d, 0x61, 0x6e, 0x75, 0x66, 0x61, 0x63, 0x74, 0x75, 0x72, 0x65, 0x72, 0x12, 0x23, 0x0a,
+	0x0d, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x4e, 0x75, 0x6d, 0x62,
+	0x65, 0x72, 0x12, 0x3b, 0x0a, 0x0b, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x5f, 0x61,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x52, 0x0a, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12,
+	0x35, 0x0a, 0x0a, 0x6c, 0x69, 0x6e, 0x65, 0x5f, 0x63, 0x61, 0x72, 0x64, 0x73, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x4d, 0x6f, 0x64, 0x75,
+	0x6c, 0x65, 0x49, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x09, 0x6c, 0x69, 0x6e,
+	0x65, 0x43, 0x61, 0x72, 0x64, 0x73, 0x12, 0x3d, 0x0a, 0x0e, 0x66, 0x61, 0x62, 0x72, 0x69, 0x63,
+	0x5f, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16,
+	0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x49, 0x6e, 0x76,
+	0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x0d, 0x66, 0x61, 0x62, 0x72, 0x69, 0x63, 0x4d, 0x6f,
+	0x64, 0x75, 0x6c, 0x65, 0x73, 0x22, 0x4d, 0x0a, 0x1a, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x68, 0x61,
+	0x73, 0x73, 0x69, 0x73, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x73, 0x73, 0x69, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x43, 0x68, 0x61,
+	0x73, 0x73, 0x69, 0x73, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x52, 0x07, 0x63, 0x68, 0x61,
+	0x73, 0x73, 0x69, 0x73, 0x22, 0x21, 0x0a, 0x1f, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x6c, 0x61, 0x74,
+	0x66, 0x6f, 0x72, 0x6d, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x92, 0x03, 0x0a, 0x14, 0x50, 0x6c, 0x61, 0x74,
+	0x66, 0x6f, 0x72, 0x6d, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73,
+	0x12, 0x22, 0x0a, 0x0c, 0x6d, 0x61, 0x6e, 0x75, 0x66, 0x61, 0x63, 0x74, 0x75, 0x72, 0x65, 0x72,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6d, 0x61, 0x6e, 0x75, 0x66, 0x61, 0x63, 0x74,
+	0x75, 0x72, 0x65, 0x72, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x61, 0x72, 0x74, 0x5f, 0x6e, 0x75, 0x6d,
+	0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x61, 0x72, 0x74, 0x4e,
+	0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x12,
+	0x45, 0x0a, 0x08, 0x66, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x29, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x50, 0x6c, 0x61, 0x74, 0x66, 0x6f,
+	0x72, 0x6d, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x2e, 0x46,
+	0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x66, 0x65,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x12, 0x55, 0x0a, 0x0e, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61,
+	0x63, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2e,
+	0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x50, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x43,
+	0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x2e, 0x41, 0x72, 0x74, 0x69,
+	0x66, 0x61, 0x63, 0x74, 0x54, 0x79, 0x70, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0d,
+	0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x54, 0x79, 0x70, 0x65, 0x73, 0x1a, 0x3b, 0x0a,
+	0x0d, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x40, 0x0a, 0x12, 0x41, 0x72,
+	0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x54, 0x79, 0x70, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x5d, 0x0a, 0x20,
+	0x4c, 0x69, 0x73, 0x74, 0x50, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x43, 0x61, 0x70, 0x61,
+	0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x39, 0x0a, 0x09, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x50, 0x6c, 0x61, 0x74,
+	0x66, 0x6f, 0x72, 0x6d, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73,
+	0x52, 0x09, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x73, 0x22, 0x6e, 0x0a, 0x1a, 0x49,
+	0x73, 0x73, 0x75, 0x65, 0x42, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x54, 0x6f, 0x6b,
+	0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x65, 0x72,
+	0x69, 0x61, 0x6c, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0c, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x2b,
+	0x0a, 0x03, 0x74, 0x74, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x03, 0x74, 0x74, 0x6c, 0x22, 0x6e, 0x0a, 0x1b, 0x49,
+	0x73, 0x73, 0x75, 0x65, 0x42, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x54, 0x6f, 0x6b,
+	0x65, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f,
+	0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
+	0x12, 0x39, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x5f, 0x61, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x22, 0x1c, 0x0a, 0x1a, 0x4c,
+	0x69, 0x73, 0x74, 0x42, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xa9, 0x01, 0x0a, 0x0e, 0x42, 0x6f,
+	0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x23, 0x0a, 0x0d,
+	0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x4e, 0x75, 0x6d, 0x62, 0x65,
+	0x72, 0x12, 0x37, 0x0a, 0x09, 0x69, 0x73, 0x73, 0x75, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x08, 0x69, 0x73, 0x73, 0x75, 0x65, 0x64, 0x41, 0x74, 0x12, 0x39, 0x0a, 0x0a, 0x65, 0x78,
+	0x70, 0x69, 0x72, 0x65, 0x73, 0x5f, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x65, 0x78, 0x70, 0x69,
+	0x72, 0x65, 0x73, 0x41, 0x74, 0x22, 0x4c, 0x0a, 0x1b, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x6f, 0x6f,
+	0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2d, 0x0a, 0x06, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x42, 0x6f, 0x6f,
+	0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x06, 0x74, 0x6f, 0x6b,
+	0x65, 0x6e, 0x73, 0x22, 0x42, 0x0a, 0x1b, 0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x42, 0x6f, 0x6f,
+	0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x5f, 0x6e, 0x75, 0x6d,
+	0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x65, 0x72, 0x69, 0x61,
+	0x6c, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x22, 0x1e, 0x0a, 0x1c, 0x52, 0x65, 0x76, 0x6f, 0x6b,
+	0x65, 0x42, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1b, 0x0a, 0x19, 0x4c, 0x69, 0x73, 0x74, 0x50,
+	0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x22, 0x95, 0x02, 0x0a, 0x0d, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67,
+	0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x12, 0x4d, 0x0a, 0x12, 0x63, 0x68, 0x61, 0x73, 0x73, 0x69,
+	0x73, 0x5f, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x7a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x43, 0x68, 0x61, 0x73, 0x73, 0x69, 0x73, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
+	0x6f, 0x72, 0x52, 0x11, 0x63, 0x68, 0x61, 0x73, 0x73, 0x69, 0x73, 0x44, 0x65, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x6f, 0x72, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f,
+	0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x39, 0x0a, 0x0a,
+	0x66, 0x69, 0x72, 0x73, 0x74, 0x5f, 0x73, 0x65, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x66, 0x69,
+	0x72, 0x73, 0x74, 0x53, 0x65, 0x65, 0x6e, 0x12, 0x37, 0x0a, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x5f,
+	0x73, 0x65, 0x65, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x08, 0x6c, 0x61, 0x73, 0x74, 0x53, 0x65, 0x65, 0x6e,
+	0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x08, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x22, 0x4c, 0x0a, 0x1a,
+	0x4c, 0x69, 0x73, 0x74, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x44, 0x65, 0x76, 0x69, 0x63,
+	0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a, 0x07, 0x64, 0x65,
+	0x76, 0x69, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x61, 0x64,
+	0x6d, 0x69, 0x6e, 0x2e, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x44, 0x65, 0x76, 0x69, 0x63,
+	0x65, 0x52, 0x07, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x22, 0x8a, 0x01, 0x0a, 0x14, 0x41,
+	0x70, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x6d, 0x61, 0x6e, 0x75, 0x66, 0x61, 0x63, 0x74, 0x75,
+	0x72, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6d, 0x61, 0x6e, 0x75, 0x66,
+	0x61, 0x63, 0x74, 0x75, 0x72, 0x65, 0x72, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x65, 0x72, 0x69, 0x61,
+	0x6c, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
+	0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x29, 0x0a, 0x07,
+	0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e,
+	0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x2e, 0x43, 0x68, 0x61, 0x73, 0x73, 0x69, 0x73, 0x52, 0x07,
+	0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x22, 0x42, 0x0a, 0x15, 0x41, 0x70, 0x70, 0x72, 0x6f,
+	0x76, 0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x29, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x73, 0x73, 0x69, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0f, 0x2e, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x2e, 0x43, 0x68, 0x61, 0x73, 0x73,
+	0x69, 0x73, 0x52, 0x07, 0x63, 0x68, 0x61, 0x73, 0x73, 0x69, 0x73, 0x22, 0x5e, 0x0a, 0x13, 0x52,
+	0x65, 0x6a, 0x65, 0x63, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x6d, 0x61, 0x6e, 0x75, 0x66, 0x61, 0x63, 0x74, 0x75, 0x72,
+	0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6d, 0x61, 0x6e, 0x75, 0x66, 0x61,
+	0x63, 0x74, 0x75, 0x72, 0x65, 0x72, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c,
+	0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73,
+	0x65, 0x72, 0x69, 0x61, 0x6c, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x22, 0x16, 0x0a, 0x14, 0x52,
+	0x65, 0x6a, 0x65, 0x63, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x15, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x4c, 0x6f, 0x67, 0x4c, 0x65, 0x76,
+	0x65, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xd1, 0x01, 0x0a, 0x14, 0x47,
+	0x65, 0x74, 0x4c, 0x6f, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x5f, 0x6c,
+	0x65, 0x76, 0x65, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x64, 0x65, 0x66, 0x61,
+	0x75, 0x6c, 0x74, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x3f, 0x0a, 0x06, 0x6c, 0x65, 0x76, 0x65,
+	0x6c, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e,
+	0x2e, 0x47, 0x65, 0x74, 0x4c, 0x6f, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x06, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x6f, 0x64,
+	0x75, 0x6c, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x6f, 0x64, 0x75,
+	0x6c, 0x65, 0x73, 0x1a, 0x39, 0x0a, 0x0b, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x42,
+	0x0a, 0x12, 0x53, 0x65, 0x74, 0x4c, 0x6f, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x12, 0x14, 0x0a, 0x05,
+	0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x65, 0x76,
+	0x65, 0x6c, 0x22, 0x15, 0x0a, 0x13, 0x53, 0x65, 0x74, 0x4c, 0x6f, 0x67, 0x4c, 0x65, 0x76, 0x65,
+	0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2a, 0xe4, 0x01, 0x0a, 0x0e, 0x42, 0x6f,
+	0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x53, 0x74, 0x61, 0x67, 0x65, 0x12, 0x1f, 0x0a, 0x1b,
+	0x42, 0x4f, 0x4f, 0x54, 0x53, 0x54, 0x52, 0x41, 0x50, 0x5f, 0x53, 0x54, 0x41, 0x47, 0x45, 0x5f,
+	0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1d, 0x0a,
+	0x19, 0x42, 0x4f, 0x4f, 0x54, 0x53, 0x54, 0x52, 0x41, 0x50, 0x5f, 0x53, 0x54, 0x41, 0x47, 0x45,
+	0x5f, 0x52, 0x45, 0x51, 0x55, 0x45, 0x53, 0x54, 0x45, 0x44, 0x10, 0x01, 0x12, 0x1a, 0x0a, 0x16,
+	0x42, 0x4f, 0x4f, 0x54, 0x53, 0x54, 0x52, 0x41, 0x50, 0x5f, 0x53, 0x54, 0x41, 0x47, 0x45, 0x5f,
+	0x53, 0x45, 0x52, 0x56, 0x45, 0x44, 0x10, 0x02, 0x12, 0x1c, 0x0a, 0x18, 0x42, 0x4f, 0x4f, 0x54,
+	0x53, 0x54, 0x52, 0x41, 0x50, 0x5f, 0x53, 0x54, 0x41, 0x47, 0x45, 0x5f, 0x52, 0x45, 0x4a, 0x45,
+	0x43, 0x54, 0x45, 0x44, 0x10, 0x03, 0x12, 0x1d, 0x0a, 0x19, 0x42, 0x4f, 0x4f, 0x54, 0x53, 0x54,
+	0x52, 0x41, 0x50, 0x5f, 0x53, 0x54, 0x41, 0x47, 0x45, 0x5f, 0x49, 0x4e, 0x49, 0x54, 0x49, 0x41,
+	0x54, 0x45, 0x44, 0x10, 0x04, 0x12, 0x1d, 0x0a, 0x19, 0x42, 0x4f, 0x4f, 0x54, 0x53, 0x54, 0x52,
+	0x41, 0x50, 0x5f, 0x53, 0x54, 0x41, 0x47, 0x45, 0x5f, 0x53, 0x55, 0x43, 0x43, 0x45, 0x45, 0x44,
+	0x45, 0x44, 0x10, 0x05, 0x12, 0x1a, 0x0a, 0x16, 0x42, 0x4f, 0x4f, 0x54, 0x53, 0x54, 0x52, 0x41,
+	0x50, 0x5f, 0x53, 0x54, 0x41, 0x47, 0x45, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x06,
+	0x2a, 0xda, 0x01, 0x0a, 0x14, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x42, 0x6f, 0x6f, 0x74, 0x73,
+	0x74, 0x72, 0x61, 0x70, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x26, 0x0a, 0x22, 0x44, 0x45, 0x56,
+	0x49, 0x43, 0x45, 0x5f, 0x42, 0x4f, 0x4f, 0x54, 0x53, 0x54, 0x52, 0x41, 0x50, 0x5f, 0x53, 0x54,
+	0x41, 0x54, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10,
+	0x00, 0x12, 0x1f, 0x0a, 0x1b, 0x44, 0x45, 0x56, 0x49, 0x43, 0x45, 0x5f, 0x42, 0x4f, 0x4f, 0x54,
+	0x53, 0x54, 0x52, 0x41, 0x50, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x49, 0x44, 0x4c, 0x45,
+	0x10, 0x01, 0x12, 0x29, 0x0a, 0x25, 0x44, 0x45, 0x56, 0x49, 0x43, 0x45, 0x5f, 0x42, 0x4f, 0x4f,
+	0x54, 0x53, 0x54, 0x52, 0x41, 0x50, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x42, 0x4f, 0x4f,
+	0x54, 0x53, 0x54, 0x52, 0x41, 0x50, 0x5f, 0x53, 0x45, 0x4e, 0x54, 0x10, 0x02, 0x12, 0x24, 0x0a,
+	0x20, 0x44, 0x45, 0x56, 0x49, 0x43, 0x45, 0x5f, 0x42, 0x4f, 0x4f, 0x54, 0x53, 0x54, 0x52, 0x41,
+	0x50, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x4f,
+	0x4b, 0x10, 0x03, 0x12, 0x28, 0x0a, 0x24, 0x44, 0x45, 0x56, 0x49, 0x43, 0x45, 0x5f, 0x42, 0x4f,
+	0x4f, 0x54, 0x53, 0x54, 0x52, 0x41, 0x50, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x53, 0x54,
+	0x41, 0x54, 0x55, 0x53, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x04, 0x2a, 0x78, 0x0a,
+	0x11, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x53, 0x74, 0x61,
+	0x67, 0x65, 0x12, 0x23, 0x0a, 0x1f, 0x44, 0x45, 0x56, 0x49, 0x43, 0x45, 0x5f, 0x43, 0x4f, 0x4e,
+	0x46, 0x49, 0x47, 0x5f, 0x53, 0x54, 0x41, 0x47, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43,
+	0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1f, 0x0a, 0x1b, 0x44, 0x45, 0x56, 0x49, 0x43,
+	0x45, 0x5f, 0x43, 0x4f, 0x4e, 0x46, 0x49, 0x47, 0x5f, 0x53, 0x54, 0x41, 0x47, 0x45, 0x5f, 0x49,
+	0x4e, 0x49, 0x54, 0x49, 0x41, 0x4c, 0x10, 0x01, 0x12, 0x1d, 0x0a, 0x19, 0x44, 0x45, 0x56, 0x49,
+	0x43, 0x45, 0x5f, 0x43, 0x4f, 0x4e, 0x46, 0x49, 0x47, 0x5f, 0x53, 0x54, 0x41, 0x47, 0x45, 0x5f,
+	0x46, 0x49, 0x4e, 0x41, 0x4c, 0x10, 0x02, 0x32, 0xdc, 0x14, 0x0a, 0x05, 0x41, 0x64, 0x6d, 0x69,
+	0x6e, 0x12, 0x55, 0x0a, 0x10, 0x4c, 0x69, 0x73, 0x74, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65,
+	0x46, 0x6c, 0x61, 0x67, 0x73, 0x12, 0x1e, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x46, 0x6c, 0x61, 0x67, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x46, 0x6c, 0x61, 0x67, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4f, 0x0a, 0x0e, 0x53, 0x65, 0x74, 0x46,
+	0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x46, 0x6c, 0x61, 0x67, 0x12, 0x1c, 0x2e, 0x61, 0x64, 0x6d,
+	0x69, 0x6e, 0x2e, 0x53, 0x65, 0x74, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x46, 0x6c, 0x61,
+	0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e,
+	0x2e, 0x53, 0x65, 0x74, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x46, 0x6c, 0x61, 0x67, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x79, 0x0a, 0x1c, 0x4c, 0x69, 0x73,
+	0x74, 0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x64, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x68, 0x69,
+	0x70, 0x56, 0x6f, 0x75, 0x63, 0x68, 0x65, 0x72, 0x73, 0x12, 0x2a, 0x2e, 0x61, 0x64, 0x6d, 0x69,
+	0x6e, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x64, 0x4f, 0x77, 0x6e,
+	0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x56, 0x6f, 0x75, 0x63, 0x68, 0x65, 0x72, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2b, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x64, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x68,
+	0x69, 0x70, 0x56, 0x6f, 0x75, 0x63, 0x68, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x12, 0x67, 0x0a, 0x16, 0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x4f, 0x77,
+	0x6e, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x56, 0x6f, 0x75, 0x63, 0x68, 0x65, 0x72, 0x12, 0x24,
+	0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x4f, 0x77, 0x6e,
+	0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x56, 0x6f, 0x75, 0x63, 0x68, 0x65, 0x72, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x52, 0x65, 0x76,
+	0x6f, 0x6b, 0x65, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x56, 0x6f, 0x75, 0x63,
+	0x68, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x64, 0x0a,
+	0x15, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x53, 0x74, 0x61, 0x67, 0x69, 0x6e, 0x67,
+	0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x12, 0x23, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x47,
+	0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x53, 0x74, 0x61, 0x67, 0x69, 0x6e, 0x67, 0x42, 0x75,
+	0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x61, 0x64,
+	0x6d, 0x69, 0x6e, 0x2e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x53, 0x74, 0x61, 0x67,
+	0x69, 0x6e, 0x67, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x5b, 0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x74, 0x61, 0x67, 0x69,
+	0x6e, 0x67, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x73, 0x12, 0x20, 0x2e, 0x61, 0x64, 0x6d, 0x69,
+	0x6e, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x74, 0x61, 0x67, 0x69, 0x6e, 0x67, 0x42, 0x75, 0x6e,
+	0x64, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x61, 0x64,
+	0x6d, 0x69, 0x6e, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x74, 0x61, 0x67, 0x69, 0x6e, 0x67, 0x42,
+	0x75, 0x6e, 0x64, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x12, 0x64, 0x0a, 0x15, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x78, 0x70, 0x65, 0x72, 0x69, 0x6d, 0x65,
+	0x6e, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x23, 0x2e, 0x61, 0x64, 0x6d, 0x69,
+	0x6e, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x78, 0x70, 0x65, 0x72, 0x69, 0x6d, 0x65, 0x6e, 0x74,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24,
+	0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x78, 0x70, 0x65, 0x72,
+	0x69, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x5d, 0x0a, 0x15, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d,
+	0x42, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
+	0x23, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x42, 0x6f,
+	0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x42, 0x6f, 0x6f,
+	0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x22, 0x00, 0x30, 0x01, 0x12, 0x55, 0x0a, 0x10, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x65, 0x76,
+	0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x73, 0x12, 0x1e, 0x2e, 0x61, 0x64, 0x6d, 0x69,
+	0x6e, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x61, 0x64, 0x6d, 0x69,
+	0x6e, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x55, 0x0a, 0x10,
+	0x52, 0x65, 0x73, 0x65, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x12, 0x1e, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x52, 0x65, 0x73, 0x65, 0x74, 0x44, 0x65,
+	0x76, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1f, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x52, 0x65, 0x73, 0x65, 0x74, 0x44, 0x65,
+	0x76, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x5b, 0x0a, 0x12, 0x54, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x52, 0x65,
+	0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x12, 0x20, 0x2e, 0x61, 0x64, 0x6d, 0x69,
+	0x6e, 0x2e, 0x54, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x52, 0x65, 0x62, 0x6f, 0x6f, 0x74, 0x73,
+	0x74, 0x72, 0x61, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x61, 0x64,
+	0x6d, 0x69, 0x6e, 0x2e, 0x54, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x52, 0x65, 0x62, 0x6f, 0x6f,
+	0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x12, 0x49, 0x0a, 0x0c, 0x52, 0x65, 0x74, 0x69, 0x72, 0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65,
+	0x12, 0x1a, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x52, 0x65, 0x74, 0x69, 0x72, 0x65, 0x44,
+	0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x61,
+	0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x52, 0x65, 0x74, 0x69, 0x72, 0x65, 0x44, 0x65, 0x76, 0x69, 0x63,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x5b, 0x0a, 0x12, 0x4c,
+	0x69, 0x73, 0x74, 0x52, 0x65, 0x74, 0x69, 0x72, 0x65, 0x64, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65,
+	0x73, 0x12, 0x20, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65,
+	0x74, 0x69, 0x72, 0x65, 0x64, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x52, 0x65, 0x74, 0x69, 0x72, 0x65, 0x64, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4f, 0x0a, 0x0e, 0x45, 0x78, 0x70, 0x6f,
+	0x72, 0x74, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x1c, 0x2e, 0x61, 0x64, 0x6d,
+	0x69, 0x6e, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e,
+	0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x52, 0x0a, 0x0f, 0x52, 0x65, 0x73,
+	0x74, 0x6f, 0x72, 0x65, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x1d, 0x2e, 0x61,
+	0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x6e, 0x61, 0x70,
+	0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x61, 0x64,
+	0x6d, 0x69, 0x6e, 0x2e, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x6e, 0x61, 0x70, 0x73,
+	0x68, 0x6f, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x46, 0x0a,
+	0x0b, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x68, 0x61, 0x73, 0x73, 0x69, 0x73, 0x12, 0x19, 0x2e, 0x61,
+	0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x68, 0x61, 0x73, 0x73, 0x69, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x43, 0x68, 0x61, 0x73, 0x73, 0x69, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x43, 0x0a, 0x0a, 0x41, 0x64, 0x64, 0x43, 0x68, 0x61, 0x73,
+	0x73, 0x69, 0x73, 0x12, 0x18, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x41, 0x64, 0x64, 0x43,
+	0x68, 0x61, 0x73, 0x73, 0x69, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e,
+	0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x41, 0x64, 0x64, 0x43, 0x68, 0x61, 0x73, 0x73, 0x69, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x67, 0x0a, 0x16, 0x55, 0x70,
+	0x6c, 0x6f, 0x61, 0x64, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x56, 0x6f, 0x75,
+	0x63, 0x68, 0x65, 0x72, 0x12, 0x24, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x55, 0x70, 0x6c,
+	0x6f, 0x61, 0x64, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x56, 0x6f, 0x75, 0x63,
+	0x68, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x61, 0x64, 0x6d,
+	0x69, 0x6e, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x68,
+	0x69, 0x70, 0x56, 0x6f, 0x75, 0x63, 0x68, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x79, 0x0a, 0x1c, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x4f, 0x77, 0x6e,
+	0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x56, 0x6f, 0x75, 0x63, 0x68, 0x65, 0x72, 0x42, 0x75, 0x6e,
+	0x64, 0x6c, 0x65, 0x12, 0x2a, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x55, 0x70, 0x6c, 0x6f,
+	0x61, 0x64, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x56, 0x6f, 0x75, 0x63, 0x68,
+	0x65, 0x72, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x2b, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x4f, 0x77,
+	0x6e, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x56, 0x6f, 0x75, 0x63, 0x68, 0x65, 0x72, 0x42, 0x75,
+	0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x5b,
+	0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x68, 0x61, 0x73, 0x73, 0x69, 0x73, 0x4d, 0x6f, 0x64,
+	0x75, 0x6c, 0x65, 0x73, 0x12, 0x20, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x4c, 0x69, 0x73,
+	0x74, 0x43, 0x68, 0x61, 0x73, 0x73, 0x69, 0x73, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x4c,
+	0x69, 0x73, 0x74, 0x43, 0x68, 0x61, 0x73, 0x73, 0x69, 0x73, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x6d, 0x0a, 0x18, 0x4c,
+	0x69, 0x73, 0x74, 0x50, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x43, 0x61, 0x70, 0x61, 0x62,
+	0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x26, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x50, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x43, 0x61, 0x70, 0x61,
+	0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x27, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x6c, 0x61, 0x74,
+	0x66, 0x6f, 0x72, 0x6d, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x5e, 0x0a, 0x13, 0x49, 0x73,
+	0x73, 0x75, 0x65, 0x42, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x12, 0x21, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x49, 0x73, 0x73, 0x75, 0x65, 0x42,
+	0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x49, 0x73, 0x73,
+	0x75, 0x65, 0x42, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x5e, 0x0a, 0x13, 0x4c, 0x69,
+	0x73, 0x74, 0x42, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
+	0x73, 0x12, 0x21, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x6f,
+	0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x4c, 0x69, 0x73,
+	0x74, 0x42, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x61, 0x0a, 0x14, 0x52, 0x65,
+	0x76, 0x6f, 0x6b, 0x65, 0x42, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x54, 0x6f, 0x6b,
+	0x65, 0x6e, 0x12, 0x22, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x52, 0x65, 0x76, 0x6f, 0x6b,
+	0x65, 0x42, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x52,
+	0x65, 0x76, 0x6f, 0x6b, 0x65, 0x42, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x54, 0x6f,
+	0x6b, 0x65, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x5b, 0x0a,
+	0x12, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x44, 0x65, 0x76, 0x69,
+	0x63, 0x65, 0x73, 0x12, 0x20, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4c, 0x0a, 0x0d, 0x41, 0x70,
+	0x70, 0x72, 0x6f, 0x76, 0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x12, 0x1b, 0x2e, 0x61, 0x64,
+	0x6d, 0x69, 0x6e, 0x2e, 0x41, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x44, 0x65, 0x76, 0x69, 0x63,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e,
+	0x2e, 0x41, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x49, 0x0a, 0x0c, 0x52, 0x65, 0x6a, 0x65,
+	0x63, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x12, 0x1a, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e,
+	0x2e, 0x52, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x52, 0x65, 0x6a,
+	0x65, 0x63, 0x74, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x49, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x4c, 0x6f, 0x67, 0x4c, 0x65, 0x76,
+	0x65, 0x6c, 0x73, 0x12, 0x1a, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x47, 0x65, 0x74, 0x4c,
+	0x6f, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1b, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x47, 0x65, 0x74, 0x4c, 0x6f, 0x67, 0x4c, 0x65,
+	0x76, 0x65, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x46,
+	0x0a, 0x0b, 0x53, 0x65, 0x74, 0x4c, 0x6f, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x19, 0x2e,
+	0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x53, 0x65, 0x74, 0x4c, 0x6f, 0x67, 0x4c, 0x65, 0x76, 0x65,
+	0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x61, 0x64, 0x6d, 0x69, 0x6e,
+	0x2e, 0x53, 0x65, 0x74, 0x4c, 0x6f, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x36, 0x5a, 0x34, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6f, 0x70, 0x65, 0x6e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2f,
+	0x62, 0x6f, 0x6f, 0x74, 0x7a, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x61, 0x64, 0x6d,
+	0x69, 0x6e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_server_admin_proto_admin_proto_rawDescOnce sync.Once
+	file_server_admin_proto_admin_proto_rawDescData = file_server_admin_proto_admin_proto_rawDesc
+)
+
+func file_server_admin_proto_admin_proto_rawDescGZIP() []byte {
+	file_server_admin_proto_admin_proto_rawDescOnce.Do(func() {
+		file_server_admin_proto_admin_proto_rawDescData = protoimpl.X.CompressGZIP(file_server_admin_proto_admin_proto_rawDescData)
+	})
+	return file_server_admin_proto_admin_proto_rawDescData
+}
+
+var file_server_admin_proto_admin_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_server_admin_proto_admin_proto_msgTypes = make([]protoimpl.MessageInfo, 74)
+var file_server_admin_proto_admin_proto_goTypes = []interface{}{
+	(BootstrapStage)(0),                            // 0: admin.BootstrapStage
+	(DeviceBootstrapState)(0),                      // 1: admin.DeviceBootstrapState
+	(DeviceConfigStage)(0),                         // 2: admin.DeviceConfigStage
+	(*ListFeatureFlagsRequest)(nil),                // 3: admin.ListFeatureFlagsRequest
+	(*ListFeatureFlagsResponse)(nil),               // 4: admin.ListFeatureFlagsResponse
+	(*SetFeatureFlagRequest)(nil),                  // 5: admin.SetFeatureFlagRequest
+	(*SetFeatureFlagResponse)(nil),                 // 6: admin.SetFeatureFlagResponse
+	(*RevokedOwnershipVoucher)(nil),                // 7: admin.RevokedOwnershipVoucher
+	(*ListRevokedOwnershipVouchersRequest)(nil),    // 8: admin.ListRevokedOwnershipVouchersRequest
+	(*ListRevokedOwnershipVouchersResponse)(nil),   // 9: admin.ListRevokedOwnershipVouchersResponse
+	(*RevokeOwnershipVoucherRequest)(nil),          // 10: admin.RevokeOwnershipVoucherRequest
+	(*RevokeOwnershipVoucherResponse)(nil),         // 11: admin.RevokeOwnershipVoucherResponse
+	(*GenerateStagingBundleRequest)(nil),           // 12: admin.GenerateStagingBundleRequest
+	(*GenerateStagingBundleResponse)(nil),          // 13: admin.GenerateStagingBundleResponse
+	(*StagingBundleRecord)(nil),                    // 14: admin.StagingBundleRecord
+	(*ListStagingBundlesRequest)(nil),              // 15: admin.ListStagingBundlesRequest
+	(*ListStagingBundlesResponse)(nil),             // 16: admin.ListStagingBundlesResponse
+	(*ExperimentArmResult)(nil),                    // 17: admin.ExperimentArmResult
+	(*ExperimentResult)(nil),                       // 18: admin.ExperimentResult
+	(*ListExperimentResultsRequest)(nil),           // 19: admin.ListExperimentResultsRequest
+	(*ListExperimentResultsResponse)(nil),          // 20: admin.ListExperimentResultsResponse
+	(*StreamBootstrapStatusRequest)(nil),           // 21: admin.StreamBootstrapStatusRequest
+	(*BootstrapStatusEvent)(nil),                   // 22: admin.BootstrapStatusEvent
+	(*DeviceState)(nil),                            // 23: admin.DeviceState
+	(*ListDeviceStatesRequest)(nil),                // 24: admin.ListDeviceStatesRequest
+	(*ListDeviceStatesResponse)(nil),               // 25: admin.ListDeviceStatesResponse
+	(*ResetDeviceStateRequest)(nil),                // 26: admin.ResetDeviceStateRequest
+	(*ResetDeviceStateResponse)(nil),               // 27: admin.ResetDeviceStateResponse
+	(*TriggerRebootstrapRequest)(nil),              // 28: admin.TriggerRebootstrapRequest
+	(*TriggerRebootstrapResponse)(nil),             // 29: admin.TriggerRebootstrapResponse
+	(*ReplacementDevice)(nil),                      // 30: admin.ReplacementDevice
+	(*RetireDeviceRequest)(nil),                    // 31: admin.RetireDeviceRequest
+	(*RetiredDevice)(nil),                          // 32: admin.RetiredDevice
+	(*RetireDeviceResponse)(nil),                   // 33: admin.RetireDeviceResponse
+	(*ListRetiredDevicesRequest)(nil),              // 34: admin.ListRetiredDevicesRequest
+	(*ListRetiredDevicesResponse)(nil),             // 35: admin.ListRetiredDevicesResponse
+	(*ExportSnapshotRequest)(nil),                  // 36: admin.ExportSnapshotRequest
+	(*ExportSnapshotResponse)(nil),                 // 37: admin.ExportSnapshotResponse
+	(*RestoreSnapshotRequest)(nil),                 // 38: admin.RestoreSnapshotRequest
+	(*RestoreSnapshotResponse)(nil),                // 39: admin.RestoreSnapshotResponse
+	(*ListChassisRequest)(nil),                     // 40: admin.ListChassisRequest
+	(*ListChassisResponse)(nil),                    // 41: admin.ListChassisResponse
+	(*AddChassisRequest)(nil),                      // 42: admin.AddChassisRequest
+	(*AddChassisResponse)(nil),                     // 43: admin.AddChassisResponse
+	(*UploadOwnershipVoucherRequest)(nil),          // 44: admin.UploadOwnershipVoucherRequest
+	(*UploadOwnershipVoucherResponse)(nil),         // 45: admin.UploadOwnershipVoucherResponse
+	(*UploadOwnershipVoucherBundleRequest)(nil),    // 46: admin.UploadOwnershipVoucherBundleRequest
+	(*OwnershipVoucherUploadResult)(nil),           // 47: admin.OwnershipVoucherUploadResult
+	(*UploadOwnershipVoucherBundleResponse)(nil),   // 48: admin.UploadOwnershipVoucherBundleResponse
+	(*ListChassisModulesRequest)(nil),              // 49: admin.ListChassisModulesRequest
+	(*ModuleInventory)(nil),                        // 50: admin.ModuleInventory
+	(*ChassisModules)(nil),                         // 51: admin.ChassisModules
+	(*ListChassisModulesResponse)(nil),             // 52: admin.ListChassisModulesResponse
+	(*ListPlatformCapabilitiesRequest)(nil),        // 53: admin.ListPlatformCapabilitiesRequest
+	(*PlatformCapabilities)(nil),                   // 54: admin.PlatformCapabilities
+	(*ListPlatformCapabilitiesResponse)(nil),       // 55: admin.ListPlatformCapabilitiesResponse
+	(*IssueBootstrapTokenRequest)(nil),             // 56: admin.IssueBootstrapTokenRequest
+	(*IssueBootstrapTokenResponse)(nil),            // 57: admin.IssueBootstrapTokenResponse
+	(*ListBootstrapTokensRequest)(nil),             // 58: admin.ListBootstrapTokensRequest
+	(*BootstrapToken)(nil),                         // 59: admin.BootstrapToken
+	(*ListBootstrapTokensResponse)(nil),            // 60: admin.ListBootstrapTokensResponse
+	(*RevokeBootstrapTokenRequest)(nil),            // 61: admin.RevokeBootstrapTokenRequest
+	(*RevokeBootstrapTokenResponse)(nil),           // 62: admin.RevokeBootstrapTokenResponse
+	(*ListPendingDevicesRequest)(nil),              // 63: admin.ListPendingDevicesRequest
+	(*PendingDevice)(nil),                          // 64: admin.PendingDevice
+	(*ListPendingDevicesResponse)(nil),             // 65: admin.ListPendingDevicesResponse
+	(*ApproveDeviceRequest)(nil),                   // 66: admin.ApproveDeviceRequest
+	(*ApproveDeviceResponse)(nil),                  // 67: admin.ApproveDeviceResponse
+	(*RejectDeviceRequest)(nil),                    // 68: admin.RejectDeviceRequest
+	(*RejectDeviceResponse)(nil),                   // 69: admin.RejectDeviceResponse
+	(*GetLogLevelsRequest)(nil),                    // 70: admin.GetLogLevelsRequest
+	(*GetLogLevelsResponse)(nil),                   // 71: admin.GetLogLevelsResponse
+	(*SetLogLevelRequest)(nil),                     // 72: admin.SetLogLevelRequest
+	(*SetLogLevelResponse)(nil),                    // 73: admin.SetLogLevelResponse
+	nil,                                            // 74: admin.PlatformCapabilities.FeaturesEntry
+	nil,                                            // 75: admin.PlatformCapabilities.ArtifactTypesEntry
+	nil,                                            // 76: admin.GetLogLevelsResponse.LevelsEntry
+	(*entity.FeatureFlag)(nil),                     // 77: entity.FeatureFlag
+	(*timestamppb.Timestamp)(nil),                  // 78: google.protobuf.Timestamp
+	(*bootz.ChassisDescriptor)(nil),                // 79: bootz.proto.ChassisDescriptor
+	(*bootz.StagingBundle)(nil),                    // 80: bootz.proto.StagingBundle
+	(bootz.ReportStatusRequest_BootstrapStatus)(0), // 81: bootz.proto.ReportStatusRequest.BootstrapStatus
+	(*durationpb.Duration)(nil),                    // 82: google.protobuf.Duration
+	(*entity.Chassis)(nil),                         // 83: entity.Chassis
+	(bootz.ControlCardState_ControlCardStatus)(0),  // 84: bootz.proto.ControlCardState.ControlCardStatus
+	(*entity.Snapshot)(nil),                        // 85: entity.Snapshot
+	(*bootz.Module)(nil),                           // 86: bootz.proto.Module
+}
+var file_server_admin_proto_admin_proto_depIdxs = []int32{
+	77, // 0: admin.ListFeatureFlagsResponse.flags:type_name -> entity.FeatureFlag
+	77, // 1: admin.SetFeatureFlagRequest.flag:type_name -> entity.FeatureFlag
+	78, // 2: admin.RevokedOwnershipVoucher.revoked_at:type_name -> google.protobuf.Timestamp
+	7,  // 3: admin.ListRevokedOwnershipVouchersResponse.vouchers:type_name -> admin.RevokedOwnershipVoucher
+	79, // 4: admin.GenerateStagingBundleRequest.chassis_descriptor:type_name -> bootz.proto.ChassisDescriptor
+	80, // 5: admin.GenerateStagingBundleResponse.bundle:type_name -> bootz.proto.StagingBundle
+	79, // 6: admin.StagingBundleRecord.chassis_descriptor:type_name -> bootz.proto.ChassisDescriptor
+	78, // 7: admin.StagingBundleRecord.created_at:type_name -> google.protobuf.Timestamp
+	81, // 8: admin.StagingBundleRecord.status:type_name -> bootz.proto.ReportStatusRequest.BootstrapStatus
+	78, // 9: admin.StagingBundleRecord.reported_at:type_name -> google.protobuf.Timestamp
+	14, // 10: admin.ListStagingBundlesResponse.bundles:type_name -> admin.StagingBundleRecord
+	82, // 11: admin.ExperimentArmResult.mean_bootstrap_duration:type_name -> google.protobuf.Duration
+	17, // 12: admin.ExperimentResult.arms:type_name -> admin.ExperimentArmResult
+	18, // 13: admin.ListExperimentResultsResponse.experiments:type_name -> admin.ExperimentResult
+	0,  // 14: admin.BootstrapStatusEvent.stage:type_name -> admin.BootstrapStage
+	78, // 15: admin.BootstrapStatusEvent.time:type_name -> google.protobuf.Timestamp
+	1,  // 16: admin.DeviceState.state:type_name -> admin.DeviceBootstrapState
+	78, // 17: admin.DeviceState.changed_at:type_name -> google.protobuf.Timestamp
+	78, // 18: admin.DeviceState.sent_at:type_name -> google.protobuf.Timestamp
+	78, // 19: admin.DeviceState.reported_at:type_name -> google.protobuf.Timestamp
+	2,  // 20: admin.DeviceState.stage:type_name -> admin.DeviceConfigStage
+	78, // 21: admin.DeviceState.last_seen_at:type_name -> google.protobuf.Timestamp
+	82, // 22: admin.ListDeviceStatesRequest.silent_for:type_name -> google.protobuf.Duration
+	23, // 23: admin.ListDeviceStatesResponse.devices:type_name -> admin.DeviceState
+	30, // 24: admin.RetireDeviceRequest.replacement:type_name -> admin.ReplacementDevice
+	78, // 25: admin.RetiredDevice.retired_at:type_name -> google.protobuf.Timestamp
+	83, // 26: admin.RetiredDevice.chassis:type_name -> entity.Chassis
+	23, // 27: admin.RetiredDevice.state:type_name -> admin.DeviceState
+	84, // 28: admin.RetiredDevice.status:type_name -> bootz.proto.ControlCardState.ControlCardStatus
+	32, // 29: admin.RetireDeviceResponse.retired:type_name -> admin.RetiredDevice
+	32, // 30: admin.ListRetiredDevicesResponse.devices:type_name -> admin.RetiredDevice
+	85, // 31: admin.ExportSnapshotResponse.snapshot:type_name -> entity.Snapshot
+	85, // 32: admin.RestoreSnapshotRequest.snapshot:type_name -> entity.Snapshot
+	83, // 33: admin.ListChassisResponse.chassis:type_name -> entity.Chassis
+	83, // 34: admin.AddChassisRequest.chassis:type_name -> entity.Chassis
+	47, // 35: admin.UploadOwnershipVoucherBundleResponse.results:type_name -> admin.OwnershipVoucherUploadResult
+	86, // 36: admin.ModuleInventory.reported:type_name -> bootz.proto.Module
+	86, // 37: admin.ModuleInventory.missing:type_name -> bootz.proto.Module
+	86, // 38: admin.ModuleInventory.unexpected:type_name -> bootz.proto.Module
+	78, // 39: admin.ChassisModules.reported_at:type_name -> google.protobuf.Timestamp
+	50, // 40: admin.ChassisModules.line_cards:type_name -> admin.ModuleInventory
+	50, // 41: admin.ChassisModules.fabric_modules:type_name -> admin.ModuleInventory
+	51, // 42: admin.ListChassisModulesResponse.chassis:type_name -> admin.ChassisModules
+	74, // 43: admin.PlatformCapabilities.features:type_name -> admin.PlatformCapabilities.FeaturesEntry
+	75, // 44: admin.PlatformCapabilities.artifact_types:type_name -> admin.PlatformCapabilities.ArtifactTypesEntry
+	54, // 45: admin.ListPlatformCapabilitiesResponse.platforms:type_name -> admin.PlatformCapabilities
+	82, // 46: admin.IssueBootstrapTokenRequest.ttl:type_name -> google.protobuf.Duration
+	78, // 47: admin.IssueBootstrapTokenResponse.expires_at:type_name -> google.protobuf.Timestamp
+	78, // 48: admin.BootstrapToken.issued_at:type_name -> google.protobuf.Timestamp
+	78, // 49: admin.BootstrapToken.expires_at:type_name -> google.protobuf.Timestamp
+	59, // 50: admin.ListBootstrapTokensResponse.tokens:type_name -> admin.BootstrapToken
+	79, // 51: admin.PendingDevice.chassis_descriptor:type_name -> bootz.proto.ChassisDescriptor
+	78, // 52: admin.PendingDevice.first_seen:type_name -> google.protobuf.Timestamp
+	78, // 53: admin.PendingDevice.last_seen:type_name -> google.protobuf.Timestamp
+	64, // 54: admin.ListPendingDevicesResponse.devices:type_name -> admin.PendingDevice
+	83, // 55: admin.ApproveDeviceRequest.profile:type_name -> entity.Chassis
+	83, // 56: admin.ApproveDeviceResponse.chassis:type_name -> entity.Chassis
+	76, // 57: admin.GetLogLevelsResponse.levels:type_name -> admin.GetLogLevelsResponse.LevelsEntry
+	3,  // 58: admin.Admin.ListFeatureFlags:input_type -> admin.ListFeatureFlagsRequest
+	5,  // 59: admin.Admin.SetFeatureFlag:input_type -> admin.SetFeatureFlagRequest
+	8,  // 60: admin.Admin.ListRevokedOwnershipVouchers:input_type -> admin.ListRevokedOwnershipVouchersRequest
+	10, // 61: admin.Admin.RevokeOwnershipVoucher:input_type -> admin.RevokeOwnershipVoucherRequest
+	12, // 62: admin.Admin.GenerateStagingBundle:input_type -> admin.GenerateStagingBundleRequest
+	15, // 63: admin.Admin.ListStagingBundles:input_type -> admin.ListStagingBundlesRequest
+	19, // 64: admin.Admin.ListExperimentResults:input_type -> admin.ListExperimentResultsRequest
+	21, // 65: admin.Admin.StreamBootstrapStatus:input_type -> admin.StreamBootstrapStatusRequest
+	24, // 66: admin.Admin.ListDeviceStates:input_type -> admin.ListDeviceStatesRequest
+	26, // 67: admin.Admin.ResetDeviceState:input_type -> admin.ResetDeviceStateRequest
+	28, // 68: admin.Admin.TriggerRebootstrap:input_type -> admin.TriggerRebootstrapRequest
+	31, // 69: admin.Admin.RetireDevice:input_type -> admin.RetireDeviceRequest
+	34, // 70: admin.Admin.ListRetiredDevices:input_type -> admin.ListRetiredDevicesRequest
+	36, // 71: admin.Admin.ExportSnapshot:input_type -> admin.ExportSnapshotRequest
+	38, // 72: admin.Admin.RestoreSnapshot:input_type -> admin.RestoreSnapshotRequest
+	40, // 73: admin.Admin.ListChassis:input_type -> admin.ListChassisRequest
+	42, // 74: admin.Admin.AddChassis:input_type -> admin.AddChassisRequest
+	44, // 75: admin.Admin.UploadOwnershipVoucher:input_type -> admin.UploadOwnershipVoucherRequest
+	46, // 76: admin.Admin.UploadOwnershipVoucherBundle:input_type -> admin.UploadOwnershipVoucherBundleRequest
+	49, // 77: admin.Admin.ListChassisModules:input_type -> admin.ListChassisModulesRequest
+	53, // 78: admin.Admin.ListPlatformCapabilities:input_type -> admin.ListPlatformCapabilitiesRequest
+	56, // 79: admin.Admin.IssueBootstrapToken:input_type -> admin.IssueBootstrapTokenRequest
+	58, // 80: admin.Admin.ListBootstrapTokens:input_type -> admin.ListBootstrapTokensRequest
+	61, // 81: admin.Admin.RevokeBootstrapToken:input_type -> admin.RevokeBootstrapTokenRequest
+	63, // 82: admin.Admin.ListPendingDevices:input_type -> admin.ListPendingDevicesRequest
+	66, // 83: admin.Admin.ApproveDevice:input_type -> admin.ApproveDeviceRequest
+	68, // 84: admin.Admin.RejectDevice:input_type -> admin.RejectDeviceRequest
+	70, // 85: admin.Admin.GetLogLevels:input_type -> admin.GetLogLevelsRequest
+	72, // 86: admin.Admin.SetLogLevel:input_type -> admin.SetLogLevelRequest
+	4,  // 87: admin.Admin.ListFeatureFlags:output_type -> admin.ListFeatureFlagsResponse
+	6,  // 88: admin.Admin.SetFeatureFlag:output_type -> admin.SetFeatureFlagResponse
+	9,  // 89: admin.Admin.ListRevokedOwnershipVouchers:output_type -> admin.ListRevokedOwnershipVouchersResponse
+	11, // 90: admin.Admin.RevokeOwnershipVoucher:output_type -> admin.RevokeOwnershipVoucherResponse
+	13, // 91: admin.Admin.GenerateStagingBundle:output_type -> admin.GenerateStagingBundleResponse
+	16, // 92: admin.Admin.ListStagingBundles:output_type -> admin.ListStagingBundlesResponse
+	20, // 93: admin.Admin.ListExperimentResults:output_type -> admin.ListExperimentResultsResponse
+	22, // 94: admin.Admin.StreamBootstrapStatus:output_type -> admin.BootstrapStatusEvent
+	25, // 95: admin.Admin.ListDeviceStates:output_type -> admin.ListDeviceStatesResponse
+	27, // 96: admin.Admin.ResetDeviceState:output_type -> admin.ResetDeviceStateResponse
+	29, // 97: admin.Admin.TriggerRebootstrap:output_type -> admin.TriggerRebootstrapResponse
+	33, // 98: admin.Admin.RetireDevice:output_type -> admin.RetireDeviceResponse
+	35, // 99: admin.Admin.ListRetiredDevices:output_type -> admin.ListRetiredDevicesResponse
+	37, // 100: admin.Admin.ExportSnapshot:output_type -> admin.ExportSnapshotResponse
+	39, // 101: admin.Admin.RestoreSnapshot:output_type -> admin.RestoreSnapshotResponse
+	41, // 102: admin.Admin.ListChassis:output_type -> admin.ListChassisResponse
+	43, // 103: admin.Admin.AddChassis:output_type -> admin.AddChassisResponse
+	45, // 104: admin.Admin.UploadOwnershipVoucher:output_type -> admin.UploadOwnershipVoucherResponse
+	48, // 105: admin.Admin.UploadOwnershipVoucherBundle:output_type -> admin.UploadOwnershipVoucherBundleResponse
+	52, // 106: admin.Admin.ListChassisModules:output_type -> admin.ListChassisModulesResponse
+	55, // 107: admin.Admin.ListPlatformCapabilities:output_type -> admin.ListPlatformCapabilitiesResponse
+	57, // 108: admin.Admin.IssueBootstrapToken:output_type -> admin.IssueBootstrapTokenResponse
+	60, // 109: admin.Admin.ListBootstrapTokens:output_type -> admin.ListBootstrapTokensResponse
+	62, // 110: admin.Admin.RevokeBootstrapToken:output_type -> admin.RevokeBootstrapTokenResponse
+	65, // 111: admin.Admin.ListPendingDevices:output_type -> admin.ListPendingDevicesResponse
+	67, // 112: admin.Admin.ApproveDevice:output_type -> admin.ApproveDeviceResponse
+	69, // 113: admin.Admin.RejectDevice:output_type -> admin.RejectDeviceResponse
+	71, // 114: admin.Admin.GetLogLevels:output_type -> admin.GetLogLevelsResponse
+	73, // 115: admin.Admin.SetLogLevel:output_type -> admin.SetLogLevelResponse
+	87, // [87:116] is the sub-list for method output_type
+	58, // [58:87] is the sub-list for method input_type
+	58, // [58:58] is the sub-list for extension type_name
+	58, // [58:58] is the sub-list for extension extendee
+	0,  // [0:58] is the sub-list for field type_name
+}
+
+func init() { file_server_admin_proto_admin_proto_init() }
+func file_server_admin_proto_admin_proto_init() {
+	if File_server_admin_proto_admin_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_server_admin_proto_admin_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListFeatureFlagsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_admin_proto_admin_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListFeatureFlagsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_admin_proto_admin_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetFeatureFlagRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_admin_proto_admin_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetFeatureFlagResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_admin_proto_admin_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RevokedOwnershipVoucher); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_admin_proto_admin_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListRevokedOwnershipVouchersRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_admin_proto_admin_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListRevokedOwnershipVouchersResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_admin_proto_admin_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RevokeOwnershipVoucherRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_admin_proto_admin_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RevokeOwnershipVoucherResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_admin_proto_admin_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GenerateStagingBundleRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_admin_proto_admin_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GenerateStagingBundleResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_admin_proto_admin_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StagingBundleRecord); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_admin_proto_admin_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListStagingBundlesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_admin_proto_admin_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListStagingBundlesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_admin_proto_admin_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExperimentArmResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_admin_proto_admin_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExperimentResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_admin_proto_admin_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListExperimentResultsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_admin_proto_admin_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListExperimentResultsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_admin_proto_admin_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StreamBootstrapStatusRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_admin_proto_admin_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BootstrapStatusEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_admin_proto_admin_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeviceState); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_admin_proto_admin_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListDeviceStatesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_admin_proto_admin_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListDeviceStatesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_admin_proto_admin_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResetDeviceStateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_admin_proto_admin_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResetDeviceStateResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_admin_proto_admin_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TriggerRebootstrapRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_admin_proto_admin_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TriggerRebootstrapResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_admin_proto_admin_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReplacementDevice); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_admin_proto_admin_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RetireDeviceRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_admin_proto_admin_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RetiredDevice); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_admin_proto_admin_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RetireDeviceResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_admin_proto_admin_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListRetiredDevicesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_admin_proto_admin_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListRetiredDevicesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_admin_proto_admin_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExportSnapshotRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_admin_proto_admin_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExportSnapshotResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_admin_proto_admin_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RestoreSnapshotRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_admin_proto_admin_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RestoreSnapshotResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_admin_proto_admin_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListChassisRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_admin_proto_admin_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListChassisResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_admin_proto_admin_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddChassisRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_admin_proto_admin_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddChassisResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_admin_proto_admin_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UploadOwnershipVoucherRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_admin_proto_admin_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UploadOwnershipVoucherResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_admin_proto_admin_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UploadOwnershipVoucherBundleRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_admin_proto_admin_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OwnershipVoucherUploadResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_admin_proto_admin_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UploadOwnershipVoucherBundleResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_admin_proto_admin_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListChassisModulesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_admin_proto_admin_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ModuleInventory); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_admin_proto_admin_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChassisModules); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_admin_proto_admin_proto_msgTypes[49].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListChassisModulesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_admin_proto_admin_proto_msgTypes[50].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListPlatformCapabilitiesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_admin_proto_admin_proto_msgTypes[51].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PlatformCapabilities); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_admin_proto_admin_proto_msgTypes[52].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListPlatformCapabilitiesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_admin_proto_admin_proto_msgTypes[53].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IssueBootstrapTokenRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_admin_proto_admin_proto_msgTypes[54].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IssueBootstrapTokenResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_admin_proto_admin_proto_msgTypes[55].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListBootstrapTokensRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_admin_proto_admin_proto_msgTypes[56].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BootstrapToken); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_admin_proto_admin_proto_msgTypes[57].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListBootstrapTokensResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_admin_proto_admin_proto_msgTypes[58].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RevokeBootstrapTokenRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_admin_proto_admin_proto_msgTypes[59].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RevokeBootstrapTokenResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_admin_proto_admin_proto_msgTypes[60].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListPendingDevicesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_admin_proto_admin_proto_msgTypes[61].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PendingDevice); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_admin_proto_admin_proto_msgTypes[62].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListPendingDevicesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_admin_proto_admin_proto_msgTypes[63].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ApproveDeviceRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_admin_proto_admin_proto_msgTypes[64].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ApproveDeviceResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_admin_proto_admin_proto_msgTypes[65].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RejectDeviceRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_admin_proto_admin_proto_msgTypes[66].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RejectDeviceResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_admin_proto_admin_proto_msgTypes[67].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetLogLevelsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_admin_proto_admin_proto_msgTypes[68].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetLogLevelsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_admin_proto_admin_proto_msgTypes[69].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetLogLevelRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_admin_proto_admin_proto_msgTypes[70].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetLogLevelResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_server_admin_proto_admin_proto_rawDesc,
+			NumEnums:      3,
+			NumMessages:   74,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_server_admin_proto_admin_proto_goTypes,
+		DependencyIndexes: file_server_admin_proto_admin_proto_depIdxs,
+		EnumInfos:         file_server_admin_proto_admin_proto_enumTypes,
+		MessageInfos:      file_server_admin_proto_admin_proto_msgTypes,
+	}.Build()
+	File_server_admin_proto_admin_proto = out.File
+	file_server_admin_proto_admin_proto_rawDesc = nil
+	file_server_admin_proto_admin_proto_goTypes = nil
+	file_server_admin_proto_admin_proto_depIdxs = nil
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// AdminClient is the client API for Admin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type AdminClient interface {
+	ListFeatureFlags(ctx context.Context, in *ListFeatureFlagsRequest, opts ...grpc.CallOption) (*ListFeatureFlagsResponse, error)
+	SetFeatureFlag(ctx context.Context, in *SetFeatureFlagRequest, opts ...grpc.CallOption) (*SetFeatureFlagResponse, error)
+	ListRevokedOwnershipVouchers(ctx context.Context, in *ListRevokedOwnershipVouchersRequest, opts ...grpc.CallOption) (*ListRevokedOwnershipVouchersResponse, error)
+	RevokeOwnershipVoucher(ctx context.Context, in *RevokeOwnershipVoucherRequest, opts ...grpc.CallOption) (*RevokeOwnershipVoucherResponse, error)
+	GenerateStagingBundle(ctx context.Context, in *GenerateStagingBundleRequest, opts ...grpc.CallOption) (*GenerateStagingBundleResponse, error)
+	ListStagingBundles(ctx context.Context, in *ListStagingBundlesRequest, opts ...grpc.CallOption) (*ListStagingBundlesResponse, error)
+	ListExperimentResults(ctx context.Context, in *ListExperimentResultsRequest, opts ...grpc.CallOption) (*ListExperimentResultsResponse, error)
+	StreamBootstrapStatus(ctx context.Context, in *StreamBootstrapStatusRequest, opts ...grpc.CallOption) (Admin_StreamBootstrapStatusClient, error)
+	ListDeviceStates(ctx context.Context, in *ListDeviceStatesRequest, opts ...grpc.CallOption) (*ListDeviceStatesResponse, error)
+	ResetDeviceState(ctx context.Context, in *ResetDeviceStateRequest, opts ...grpc.CallOption) (*ResetDeviceStateResponse, error)
+	TriggerRebootstrap(ctx context.Context, in *TriggerRebootstrapRequest, opts ...grpc.CallOption) (*TriggerRebootstrapResponse, error)
+	RetireDevice(ctx context.Context, in *RetireDeviceRequest, opts ...grpc.CallOption) (*RetireDeviceResponse, error)
+	ListRetiredDevices(ctx context.Context, in *ListRetiredDevicesRequest, opts ...grpc.CallOption) (*ListRetiredDevicesResponse, error)
+	ExportSnapshot(ctx context.Context, in *ExportSnapshotRequest, opts ...grpc.CallOption) (*ExportSnapshotResponse, error)
+	RestoreSnapshot(ctx context.Context, in *RestoreSnapshotRequest, opts ...grpc.CallOption) (*RestoreSnapshotResponse, error)
+	ListChassis(ctx context.Context, in *ListChassisRequest, opts ...grpc.CallOption) (*ListChassisResponse, error)
+	AddChassis(ctx context.Context, in *AddChassisRequest, opts ...grpc.CallOption) (*AddChassisResponse, error)
+	UploadOwnershipVoucher(ctx context.Context, in *UploadOwnershipVoucherRequest, opts ...grpc.CallOption) (*UploadOwnershipVoucherResponse, error)
+	UploadOwnershipVoucherBundle(ctx context.Context, in *UploadOwnershipVoucherBundleRequest, opts ...grpc.CallOption) (*UploadOwnershipVoucherBundleResponse, error)
+	ListChassisModules(ctx context.Context, in *ListChassisModulesRequest, opts ...grpc.CallOption) (*ListChassisModulesResponse, error)
+	ListPlatformCapabilities(ctx context.Context, in *ListPlatformCapabilitiesRequest, opts ...grpc.CallOption) (*ListPlatformCapabilitiesResponse, error)
+	IssueBootstrapToken(ctx context.Context, in *IssueBootstrapTokenRequest, opts ...grpc.CallOption) (*IssueBootstrapTokenResponse, error)
+	ListBootstrapTokens(ctx context.Context, in *ListBootstrapTokensRequest, opts ...grpc.CallOption) (*ListBootstrapTokensResponse, error)
+	RevokeBootstrapToken(ctx context.Context, in *RevokeBootstrapTokenRequest, opts ...grpc.CallOption) (*RevokeBootstrapTokenResponse, error)
+	ListPendingDevices(ctx context.Context, in *ListPendingDevicesRequest, opts ...grpc.CallOption) (*ListPendingDevicesResponse, error)
+	ApproveDevice(ctx context.Context, in *ApproveDeviceRequest, opts ...grpc.CallOption) (*ApproveDeviceResponse, error)
+	RejectDevice(ctx context.Context, in *RejectDeviceRequest, opts ...grpc.CallOption) (*RejectDeviceResponse, error)
+	GetLogLevels(ctx context.Context, in *GetLogLevelsRequest, opts ...grpc.CallOption) (*GetLogLevelsResponse, error)
+	SetLogLevel(ctx context.Context, in *SetLogLevelRequest, opts ...grpc.CallOption) (*SetLogLevelResponse, error)
+}
+
+type adminClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAdminClient(cc grpc.ClientConnInterface) AdminClient {
+	return &adminClient{cc}
+}
+
+func (c *adminClient) ListFeatureFlags(ctx context.Context, in *ListFeatureFlagsRequest, opts ...grpc.CallOption) (*ListFeatureFlagsResponse, error) {
+	out := new(ListFeatureFlagsResponse)
+	err := c.cc.Invoke(ctx, "/admin.Admin/ListFeatureFlags", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) SetFeatureFlag(ctx context.Context, in *SetFeatureFlagRequest, opts ...grpc.CallOption) (*SetFeatureFlagResponse, error) {
+	out := new(SetFeatureFlagResponse)
+	err := c.cc.Invoke(ctx, "/admin.Admin/SetFeatureFlag", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) ListRevokedOwnershipVouchers(ctx context.Context, in *ListRevokedOwnershipVouchersRequest, opts ...grpc.CallOption) (*ListRevokedOwnershipVouchersResponse, error) {
+	out := new(ListRevokedOwnershipVouchersResponse)
+	err := c.cc.Invoke(ctx, "/admin.Admin/ListRevokedOwnershipVouchers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) RevokeOwnershipVoucher(ctx context.Context, in *RevokeOwnershipVoucherRequest, opts ...grpc.CallOption) (*RevokeOwnershipVoucherResponse, error) {
+	out := new(RevokeOwnershipVoucherResponse)
+	err := c.cc.Invoke(ctx, "/admin.Admin/RevokeOwnershipVoucher", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) GenerateStagingBundle(ctx context.Context, in *GenerateStagingBundleRequest, opts ...grpc.CallOption) (*GenerateStagingBundleResponse, error) {
+	out := new(GenerateStagingBundleResponse)
+	err := c.cc.Invoke(ctx, "/admin.Admin/GenerateStagingBundle", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) ListStagingBundles(ctx context.Context, in *ListStagingBundlesRequest, opts ...grpc.CallOption) (*ListStagingBundlesResponse, error) {
+	out := new(ListStagingBundlesResponse)
+	err := c.cc.Invoke(ctx, "/admin.Admin/ListStagingBundles", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) ListExperimentResults(ctx context.Context, in *ListExperimentResultsRequest, opts ...grpc.CallOption) (*ListExperimentResultsResponse, error) {
+	out := new(ListExperimentResultsResponse)
+	err := c.cc.Invoke(ctx, "/admin.Admin/ListExperimentResults", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) StreamBootstrapStatus(ctx context.Context, in *StreamBootstrapStatusRequest, opts ...grpc.CallOption) (Admin_StreamBootstrapStatusClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Admin_serviceDesc.Streams[0], "/admin.Admin/StreamBootstrapStatus", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &adminStreamBootstrapStatusClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Admin_StreamBootstrapStatusClient interface {
+	Recv() (*BootstrapStatusEvent, error)
+	grpc.ClientStream
+}
+
+type adminStreamBootstrapStatusClient struct {
+	grpc.ClientStream
+}
+
+func (x *adminStreamBootstrapStatusClient) Recv() (*BootstrapStatusEvent, error) {
+	m := new(BootstrapStatusEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *adminClient) ListDeviceStates(ctx context.Context, in *ListDeviceStatesRequest, opts ...grpc.CallOption) (*ListDeviceStatesResponse, error) {
+	out := new(ListDeviceStatesResponse)
+	err := c.cc.Invoke(ctx, "/admin.Admin/ListDeviceStates", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) ResetDeviceState(ctx context.Context, in *ResetDeviceStateRequest, opts ...grpc.CallOption) (*ResetDeviceStateResponse, error) {
+	out := new(ResetDeviceStateResponse)
+	err := c.cc.Invoke(ctx, "/admin.Admin/ResetDeviceState", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) TriggerRebootstrap(ctx context.Context, in *TriggerRebootstrapRequest, opts ...grpc.CallOption) (*TriggerRebootstrapResponse, error) {
+	out := new(TriggerRebootstrapResponse)
+	err := c.cc.Invoke(ctx, "/admin.Admin/TriggerRebootstrap", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) RetireDevice(ctx context.Context, in *RetireDeviceRequest, opts ...grpc.CallOption) (*RetireDeviceResponse, error) {
+	out := new(RetireDeviceResponse)
+	err := c.cc.Invoke(ctx, "/admin.Admin/RetireDevice", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) ListRetiredDevices(ctx context.Context, in *ListRetiredDevicesRequest, opts ...grpc.CallOption) (*ListRetiredDevicesResponse, error) {
+	out := new(ListRetiredDevicesResponse)
+	err := c.cc.Invoke(ctx, "/admin.Admin/ListRetiredDevices", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) ExportSnapshot(ctx context.Context, in *ExportSnapshotRequest, opts ...grpc.CallOption) (*ExportSnapshotResponse, error) {
+	out := new(ExportSnapshotResponse)
+	err := c.cc.Invoke(ctx, "/admin.Admin/ExportSnapshot", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) RestoreSnapshot(ctx context.Context, in *RestoreSnapshotRequest, opts ...grpc.CallOption) (*RestoreSnapshotResponse, error) {
+	out := new(RestoreSnapshotResponse)
+	err := c.cc.Invoke(ctx, "/admin.Admin/RestoreSnapshot", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) ListChassis(ctx context.Context, in *ListChassisRequest, opts ...grpc.CallOption) (*ListChassisResponse, error) {
+	out := new(ListChassisResponse)
+	err := c.cc.Invoke(ctx, "/admin.Admin/ListChassis", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) AddChassis(ctx context.Context, in *AddChassisRequest, opts ...grpc.CallOption) (*AddChassisResponse, error) {
+	out := new(AddChassisResponse)
+	err := c.cc.Invoke(ctx, "/admin.Admin/AddChassis", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) UploadOwnershipVoucher(ctx context.Context, in *UploadOwnershipVoucherRequest, opts ...grpc.CallOption) (*UploadOwnershipVoucherResponse, error) {
+	out := new(UploadOwnershipVoucherResponse)
+	err := c.cc.Invoke(ctx, "/admin.Admin/UploadOwnershipVoucher", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) UploadOwnershipVoucherBundle(ctx context.Context, in *UploadOwnershipVoucherBundleRequest, opts ...grpc.CallOption) (*UploadOwnershipVoucherBundleResponse, error) {
+	out := new(UploadOwnershipVoucherBundleResponse)
+	err := c.cc.Invoke(ctx, "/admin.Admin/UploadOwnershipVoucherBundle", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) ListChassisModules(ctx context.Context, in *ListChassisModulesRequest, opts ...grpc.CallOption) (*ListChassisModulesResponse, error) {
+	out := new(ListChassisModulesResponse)
+	err := c.cc.Invoke(ctx, "/admin.Admin/ListChassisModules", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) ListPlatformCapabilities(ctx context.Context, in *ListPlatformCapabilitiesRequest, opts ...grpc.CallOption) (*ListPlatformCapabilitiesResponse, error) {
+	out := new(ListPlatformCapabilitiesResponse)
+	err := c.cc.Invoke(ctx, "/admin.Admin/ListPlatformCapabilities", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) IssueBootstrapToken(ctx context.Context, in *IssueBootstrapTokenRequest, opts ...grpc.CallOption) (*IssueBootstrapTokenResponse, error) {
+	out := new(IssueBootstrapTokenResponse)
+	err := c.cc.Invoke(ctx, "/admin.Admin/IssueBootstrapToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) ListBootstrapTokens(ctx context.Context, in *ListBootstrapTokensRequest, opts ...grpc.CallOption) (*ListBootstrapTokensResponse, error) {
+	out := new(ListBootstrapTokensResponse)
+	err := c.cc.Invoke(ctx, "/admin.Admin/ListBootstrapTokens", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) RevokeBootstrapToken(ctx context.Context, in *RevokeBootstrapTokenRequest, opts ...grpc.CallOption) (*RevokeBootstrapTokenResponse, error) {
+	out := new(RevokeBootstrapTokenResponse)
+	err := c.cc.Invoke(ctx, "/admin.Admin/RevokeBootstrapToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) ListPendingDevices(ctx context.Context, in *ListPendingDevicesRequest, opts ...grpc.CallOption) (*ListPendingDevicesResponse, error) {
+	out := new(ListPendingDevicesResponse)
+	err := c.cc.Invoke(ctx, "/admin.Admin/ListPendingDevices", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) ApproveDevice(ctx context.Context, in *ApproveDeviceRequest, opts ...grpc.CallOption) (*ApproveDeviceResponse, error) {
+	out := new(ApproveDeviceResponse)
+	err := c.cc.Invoke(ctx, "/admin.Admin/ApproveDevice", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) RejectDevice(ctx context.Context, in *RejectDeviceRequest, opts ...grpc.CallOption) (*RejectDeviceResponse, error) {
+	out := new(RejectDeviceResponse)
+	err := c.cc.Invoke(ctx, "/admin.Admin/RejectDevice", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) GetLogLevels(ctx context.Context, in *GetLogLevelsRequest, opts ...grpc.CallOption) (*GetLogLevelsResponse, error) {
+	out := new(GetLogLevelsResponse)
+	err := c.cc.Invoke(ctx, "/admin.Admin/GetLogLevels", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) SetLogLevel(ctx context.Context, in *SetLogLevelRequest, opts ...grpc.CallOption) (*SetLogLevelResponse, error) {
+	out := new(SetLogLevelResponse)
+	err := c.cc.Invoke(ctx, "/admin.Admin/SetLogLevel", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AdminServer is the server API for Admin service.
+type AdminServer interface {
+	ListFeatureFlags(context.Context, *ListFeatureFlagsRequest) (*ListFeatureFlagsResponse, error)
+	SetFeatureFlag(context.Context, *SetFeatureFlagRequest) (*SetFeatureFlagResponse, error)
+	ListRevokedOwnershipVouchers(context.Context, *ListRevokedOwnershipVouchersRequest) (*ListRevokedOwnershipVouchersResponse, error)
+	RevokeOwnershipVoucher(context.Context, *RevokeOwnershipVoucherRequest) (*RevokeOwnershipVoucherResponse, error)
+	GenerateStagingBundle(context.Context, *GenerateStagingBundleRequest) (*GenerateStagingBundleResponse, error)
+	ListStagingBundles(context.Context, *ListStagingBundlesRequest) (*ListStagingBundlesResponse, error)
+	ListExperimentResults(context.Context, *ListExperimentResultsRequest) (*ListExperimentResultsResponse, error)
+	StreamBootstrapStatus(*StreamBootstrapStatusRequest, Admin_StreamBootstrapStatusServer) error
+	ListDeviceStates(context.Context, *ListDeviceStatesRequest) (*ListDeviceStatesResponse, error)
+	ResetDeviceState(context.Context, *ResetDeviceStateRequest) (*ResetDeviceStateResponse, error)
+	TriggerRebootstrap(context.Context, *TriggerRebootstrapRequest) (*TriggerRebootstrapResponse, error)
+	RetireDevice(context.Context, *RetireDeviceRequest) (*RetireDeviceResponse, error)
+	ListRetiredDevices(context.Context, *ListRetiredDevicesRequest) (*ListRetiredDevicesResponse, error)
+	ExportSnapshot(context.Context, *ExportSnapshotRequest) (*ExportSnapshotResponse, error)
+	RestoreSnapshot(context.Context, *RestoreSnapshotRequest) (*RestoreSnapshotResponse, error)
+	ListChassis(context.Context, *ListChassisRequest) (*ListChassisResponse, error)
+	AddChassis(context.Context, *AddChassisRequest) (*AddChassisResponse, error)
+	UploadOwnershipVoucher(context.Context, *UploadOwnershipVoucherRequest) (*UploadOwnershipVoucherResponse, error)
+	UploadOwnershipVoucherBundle(context.Context, *UploadOwnershipVoucherBundleRequest) (*UploadOwnershipVoucherBundleResponse, error)
+	ListChassisModules(context.Context, *ListChassisModulesRequest) (*ListChassisModulesResponse, error)
+	ListPlatformCapabilities(context.Context, *ListPlatformCapabilitiesRequest) (*ListPlatformCapabilitiesResponse, error)
+	IssueBootstrapToken(context.Context, *IssueBootstrapTokenRequest) (*IssueBootstrapTokenResponse, error)
+	ListBootstrapTokens(context.Context, *ListBootstrapTokensRequest) (*ListBootstrapTokensResponse, error)
+	RevokeBootstrapToken(context.Context, *RevokeBootstrapTokenRequest) (*RevokeBootstrapTokenResponse, error)
+	ListPendingDevices(context.Context, *ListPendingDevicesRequest) (*ListPendingDevicesResponse, error)
+	ApproveDevice(context.Context, *ApproveDeviceRequest) (*ApproveDeviceResponse, error)
+	RejectDevice(context.Context, *RejectDeviceRequest) (*RejectDeviceResponse, error)
+	GetLogLevels(context.Context, *GetLogLevelsRequest) (*GetLogLevelsResponse, error)
+	SetLogLevel(context.Context, *SetLogLevelRequest) (*SetLogLevelResponse, error)
+}
+
+// UnimplementedAdminServer can be embedded to have forward compatible implementations.
+type UnimplementedAdminServer struct {
+}
+
+func (*UnimplementedAdminServer) ListFeatureFlags(context.Context, *ListFeatureFlagsRequest) (*ListFeatureFlagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFeatureFlags not implemented")
+}
+func (*UnimplementedAdminServer) SetFeatureFlag(context.Context, *SetFeatureFlagRequest) (*SetFeatureFlagResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetFeatureFlag not implemented")
+}
+func (*UnimplementedAdminServer) ListRevokedOwnershipVouchers(context.Context, *ListRevokedOwnershipVouchersRequest) (*ListRevokedOwnershipVouchersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRevokedOwnershipVouchers not implemented")
+}
+func (*UnimplementedAdminServer) RevokeOwnershipVoucher(context.Context, *RevokeOwnershipVoucherRequest) (*RevokeOwnershipVoucherResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeOwnershipVoucher not implemented")
+}
+func (*UnimplementedAdminServer) GenerateStagingBundle(context.Context, *GenerateStagingBundleRequest) (*GenerateStagingBundleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GenerateStagingBundle not implemented")
+}
+func (*UnimplementedAdminServer) ListStagingBundles(context.Context, *ListStagingBundlesRequest) (*ListStagingBundlesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListStagingBundles not implemented")
+}
+func (*UnimplementedAdminServer) ListExperimentResults(context.Context, *ListExperimentResultsRequest) (*ListExperimentResultsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListExperimentResults not implemented")
+}
+func (*UnimplementedAdminServer) StreamBootstrapStatus(*StreamBootstrapStatusRequest, Admin_StreamBootstrapStatusServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamBootstrapStatus not implemented")
+}
+func (*UnimplementedAdminServer) ListDeviceStates(context.Context, *ListDeviceStatesRequest) (*ListDeviceStatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeviceStates not implemented")
+}
+func (*UnimplementedAdminServer) ResetDeviceState(context.Context, *ResetDeviceStateRequest) (*ResetDeviceStateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResetDeviceState not implemented")
+}
+func (*UnimplementedAdminServer) TriggerRebootstrap(context.Context, *TriggerRebootstrapRequest) (*TriggerRebootstrapResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TriggerRebootstrap not implemented")
+}
+func (*UnimplementedAdminServer) RetireDevice(context.Context, *RetireDeviceRequest) (*RetireDeviceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RetireDevice not implemented")
+}
+func (*UnimplementedAdminServer) ListRetiredDevices(context.Context, *ListRetiredDevicesRequest) (*ListRetiredDevicesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRetiredDevices not implemented")
+}
+func (*UnimplementedAdminServer) ExportSnapshot(context.Context, *ExportSnapshotRequest) (*ExportSnapshotResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportSnapshot not implemented")
+}
+func (*UnimplementedAdminServer) RestoreSnapshot(context.Context, *RestoreSnapshotRequest) (*RestoreSnapshotResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreSnapshot not implemented")
+}
+func (*UnimplementedAdminServer) ListChassis(context.Context, *ListChassisRequest) (*ListChassisResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListChassis not implemented")
+}
+func (*UnimplementedAdminServer) AddChassis(context.Context, *AddChassisRequest) (*AddChassisResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddChassis not implemented")
+}
+func (*UnimplementedAdminServer) UploadOwnershipVoucher(context.Context, *UploadOwnershipVoucherRequest) (*UploadOwnershipVoucherResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UploadOwnershipVoucher not implemented")
+}
+func (*UnimplementedAdminServer) UploadOwnershipVoucherBundle(context.Context, *UploadOwnershipVoucherBundleRequest) (*UploadOwnershipVoucherBundleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UploadOwnershipVoucherBundle not implemented")
+}
+func (*UnimplementedAdminServer) ListChassisModules(context.Context, *ListChassisModulesRequest) (*ListChassisModulesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListChassisModules not implemented")
+}
+func (*UnimplementedAdminServer) ListPlatformCapabilities(context.Context, *ListPlatformCapabilitiesRequest) (*ListPlatformCapabilitiesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPlatformCapabilities not implemented")
+}
+func (*UnimplementedAdminServer) IssueBootstrapToken(context.Context, *IssueBootstrapTokenRequest) (*IssueBootstrapTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IssueBootstrapToken not implemented")
+}
+func (*UnimplementedAdminServer) ListBootstrapTokens(context.Context, *ListBootstrapTokensRequest) (*ListBootstrapTokensResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBootstrapTokens not implemented")
+}
+func (*UnimplementedAdminServer) RevokeBootstrapToken(context.Context, *RevokeBootstrapTokenRequest) (*RevokeBootstrapTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeBootstrapToken not implemented")
+}
+func (*UnimplementedAdminServer) ListPendingDevices(context.Context, *ListPendingDevicesRequest) (*ListPendingDevicesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPendingDevices not implemented")
+}
+func (*UnimplementedAdminServer) ApproveDevice(context.Context, *ApproveDeviceRequest) (*ApproveDeviceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveDevice not implemented")
+}
+func (*UnimplementedAdminServer) RejectDevice(context.Context, *RejectDeviceRequest) (*RejectDeviceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RejectDevice not implemented")
+}
+func (*UnimplementedAdminServer) GetLogLevels(context.Context, *GetLogLevelsRequest) (*GetLogLevelsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLogLevels not implemented")
+}
+func (*UnimplementedAdminServer) SetLogLevel(context.Context, *SetLogLevelRequest) (*SetLogLevelResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetLogLevel not implemented")
+}
+
+func RegisterAdminServer(s *grpc.Server, srv AdminServer) {
+	s.RegisterService(&_Admin_serviceDesc, srv)
+}
+
+func _Admin_ListFeatureFlags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFeatureFlagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ListFeatureFlags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.Admin/ListFeatureFlags",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ListFeatureFlags(ctx, req.(*ListFeatureFlagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_SetFeatureFlag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetFeatureFlagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).SetFeatureFlag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.Admin/SetFeatureFlag",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).SetFeatureFlag(ctx, req.(*SetFeatureFlagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_ListRevokedOwnershipVouchers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRevokedOwnershipVouchersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ListRevokedOwnershipVouchers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.Admin/ListRevokedOwnershipVouchers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ListRevokedOwnershipVouchers(ctx, req.(*ListRevokedOwnershipVouchersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_RevokeOwnershipVoucher_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeOwnershipVoucherRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).RevokeOwnershipVoucher(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.Admin/RevokeOwnershipVoucher",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).RevokeOwnershipVoucher(ctx, req.(*RevokeOwnershipVoucherRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_GenerateStagingBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenerateStagingBundleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).GenerateStagingBundle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.Admin/GenerateStagingBundle",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).GenerateStagingBundle(ctx, req.(*GenerateStagingBundleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_ListStagingBundles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListStagingBundlesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ListStagingBundles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.Admin/ListStagingBundles",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ListStagingBundles(ctx, req.(*ListStagingBundlesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_ListExperimentResults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListExperimentResultsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ListExperimentResults(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.Admin/ListExperimentResults",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ListExperimentResults(ctx, req.(*ListExperimentResultsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_StreamBootstrapStatus_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamBootstrapStatusRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AdminServer).StreamBootstrapStatus(m, &adminStreamBootstrapStatusServer{stream})
+}
+
+type Admin_StreamBootstrapStatusServer interface {
+	Send(*BootstrapStatusEvent) error
+	grpc.ServerStream
+}
+
+type adminStreamBootstrapStatusServer struct {
+	grpc.ServerStream
+}
+
+func (x *adminStreamBootstrapStatusServer) Send(m *BootstrapStatusEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Admin_ListDeviceStates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeviceStatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ListDeviceStates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.Admin/ListDeviceStates",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ListDeviceStates(ctx, req.(*ListDeviceStatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_ResetDeviceState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResetDeviceStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ResetDeviceState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.Admin/ResetDeviceState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ResetDeviceState(ctx, req.(*ResetDeviceStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_TriggerRebootstrap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TriggerRebootstrapRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).TriggerRebootstrap(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.Admin/TriggerRebootstrap",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).TriggerRebootstrap(ctx, req.(*TriggerRebootstrapRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_RetireDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RetireDeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).RetireDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.Admin/RetireDevice",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).RetireDevice(ctx, req.(*RetireDeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_ListRetiredDevices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRetiredDevicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ListRetiredDevices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.Admin/ListRetiredDevices",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ListRetiredDevices(ctx, req.(*ListRetiredDevicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_ExportSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportSnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ExportSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.Admin/ExportSnapshot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ExportSnapshot(ctx, req.(*ExportSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_RestoreSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreSnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).RestoreSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.Admin/RestoreSnapshot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).RestoreSnapshot(ctx, req.(*RestoreSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_ListChassis_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListChassisRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ListChassis(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.Admin/ListChassis",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ListChassis(ctx, req.(*ListChassisRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_AddChassis_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddChassisRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).AddChassis(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.Admin/AddChassis",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).AddChassis(ctx, req.(*AddChassisRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_UploadOwnershipVoucher_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UploadOwnershipVoucherRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).UploadOwnershipVoucher(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.Admin/UploadOwnershipVoucher",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).UploadOwnershipVoucher(ctx, req.(*UploadOwnershipVoucherRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_UploadOwnershipVoucherBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UploadOwnershipVoucherBundleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).UploadOwnershipVoucherBundle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.Admin/UploadOwnershipVoucherBundle",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).UploadOwnershipVoucherBundle(ctx, req.(*UploadOwnershipVoucherBundleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_ListChassisModules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListChassisModulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ListChassisModules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.Admin/ListChassisModules",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ListChassisModules(ctx, req.(*ListChassisModulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_ListPlatformCapabilities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPlatformCapabilitiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ListPlatformCapabilities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.Admin/ListPlatformCapabilities",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ListPlatformCapabilities(ctx, req.(*ListPlatformCapabilitiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_IssueBootstrapToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IssueBootstrapTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).IssueBootstrapToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.Admin/IssueBootstrapToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).IssueBootstrapToken(ctx, req.(*IssueBootstrapTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_ListBootstrapTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBootstrapTokensRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ListBootstrapTokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.Admin/ListBootstrapTokens",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ListBootstrapTokens(ctx, req.(*ListBootstrapTokensRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_RevokeBootstrapToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeBootstrapTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).RevokeBootstrapToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.Admin/RevokeBootstrapToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).RevokeBootstrapToken(ctx, req.(*RevokeBootstrapTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_ListPendingDevices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPendingDevicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ListPendingDevices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.Admin/ListPendingDevices",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ListPendingDevices(ctx, req.(*ListPendingDevicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_ApproveDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproveDeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ApproveDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.Admin/ApproveDevice",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ApproveDevice(ctx, req.(*ApproveDeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_RejectDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RejectDeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).RejectDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.Admin/RejectDevice",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).RejectDevice(ctx, req.(*RejectDeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_GetLogLevels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLogLevelsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).GetLogLevels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.Admin/GetLogLevels",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).GetLogLevels(ctx, req.(*GetLogLevelsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_SetLogLevel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetLogLevelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).SetLogLevel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.Admin/SetLogLevel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).SetLogLevel(ctx, req.(*SetLogLevelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Admin_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "admin.Admin",
+	HandlerType: (*AdminServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListFeatureFlags",
+			Handler:    _Admin_ListFeatureFlags_Handler,
+		},
+		{
+			MethodName: "SetFeatureFlag",
+			Handler:    _Admin_SetFeatureFlag_Handler,
+		},
+		{
+			MethodName: "ListRevokedOwnershipVouchers",
+			Handler:    _Admin_ListRevokedOwnershipVouchers_Handler,
+		},
+		{
+			MethodName: "RevokeOwnershipVoucher",
+			Handler:    _Admin_RevokeOwnershipVoucher_Handler,
+		},
+		{
+			MethodName: "GenerateStagingBundle",
+			Handler:    _Admin_GenerateStagingBundle_Handler,
+		},
+		{
+			MethodName: "ListStagingBundles",
+			Handler:    _Admin_ListStagingBundles_Handler,
+		},
+		{
+			MethodName: "ListExperimentResults",
+			Handler:    _Admin_ListExperimentResults_Handler,
+		},
+		{
+			MethodName: "ListDeviceStates",
+			Handler:    _Admin_ListDeviceStates_Handler,
+		},
+		{
+			MethodName: "ResetDeviceState",
+			Handler:    _Admin_ResetDeviceState_Handler,
+		},
+		{
+			MethodName: "TriggerRebootstrap",
+			Handler:    _Admin_TriggerRebootstrap_Handler,
+		},
+		{
+			MethodName: "RetireDevice",
+			Handler:    _Admin_RetireDevice_Handler,
+		},
+		{
+			MethodName: "ListRetiredDevices",
+			Handler:    _Admin_ListRetiredDevices_Handler,
+		},
+		{
+			MethodName: "ExportSnapshot",
+			Handler:    _Admin_ExportSnapshot_Handler,
+		},
+		{
+			MethodName: "RestoreSnapshot",
+			Handler:    _Admin_RestoreSnapshot_Handler,
+		},
+		{
+			MethodName: "ListChassis",
+			Handler:    _Admin_ListChassis_Handler,
+		},
+		{
+			MethodName: "AddChassis",
+			Handler:    _Admin_AddChassis_Handler,
+		},
+		{
+			MethodName: "UploadOwnershipVoucher",
+			Handler:    _Admin_UploadOwnershipVoucher_Handler,
+		},
+		{
+			MethodName: "UploadOwnershipVoucherBundle",
+			Handler:    _Admin_UploadOwnershipVoucherBundle_Handler,
+		},
+		{
+			MethodName: "ListChassisModules",
+			Handler:    _Admin_ListChassisModules_Handler,
+		},
+		{
+			MethodName: "ListPlatformCapabilities",
+			Handler:    _Admin_ListPlatformCapabilities_Handler,
+		},
+		{
+			MethodName: "IssueBootstrapToken",
+			Handler:    _Admin_IssueBootstrapToken_Handler,
+		},
+		{
+			MethodName: "ListBootstrapTokens",
+			Handler:    _Admin_ListBootstrapTokens_Handler,
+		},
+		{
+			MethodName: "RevokeBootstrapToken",
+			Handler:    _Admin_RevokeBootstrapToken_Handler,
+		},
+		{
+			MethodName: "ListPendingDevices",
+			Handler:    _Admin_ListPendingDevices_Handler,
+		},
+		{
+			MethodName: "ApproveDevice",
+			Handler:    _Admin_ApproveDevice_Handler,
+		},
+		{
+			MethodName: "RejectDevice",
+			Handler:    _Admin_RejectDevice_Handler,
+		},
+		{
+			MethodName: "GetLogLevels",
+			Handler:    _Admin_GetLogLevels_Handler,
+		},
+		{
+			MethodName: "SetLogLevel",
+			Handler:    _Admin_SetLogLevel_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamBootstrapStatus",
+			Handler:       _Admin_StreamBootstrapStatus_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "server/admin/proto/admin.proto",
+}
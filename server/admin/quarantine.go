@@ -0,0 +1,108 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+
+	"github.com/openconfig/bootz/server/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	apb "github.com/openconfig/bootz/server/admin/proto/admin"
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+	log "github.com/openconfig/bootz/server/logging"
+)
+
+// ListPendingDevices returns the unknown devices held in quarantine pending approval. Domain
+// admins may not list them, as unknown devices belong to no domain.
+func (s *Server) ListPendingDevices(ctx context.Context, req *apb.ListPendingDevicesRequest) (*apb.ListPendingDevicesResponse, error) {
+	if err := s.authorizeServer(ctx); err != nil {
+		return nil, err
+	}
+	resp := &apb.ListPendingDevicesResponse{}
+	for _, d := range s.service.PendingDevices() {
+		resp.Devices = append(resp.Devices, &apb.PendingDevice{
+			ChassisDescriptor: d.ChassisDescriptor,
+			SourceAddress:     d.SourceAddress,
+			FirstSeen:         timestamppb.New(d.FirstSeen),
+			LastSeen:          timestamppb.New(d.LastSeen),
+			Requests:          uint64(d.Requests),
+		})
+	}
+	return resp, nil
+}
+
+// approvedChassis returns the chassis a pending device is added to the inventory as.
+func approvedChassis(d service.PendingDevice, manufacturer, serial string, profile *epb.Chassis) *epb.Chassis {
+	ch := &epb.Chassis{}
+	if profile != nil {
+		ch = proto.Clone(profile).(*epb.Chassis)
+	}
+	ch.Manufacturer = manufacturer
+	ch.SerialNumber = serial
+	desc := d.ChassisDescriptor
+	if ch.GetPartNumber() == "" {
+		ch.PartNumber = desc.GetPartNumber()
+	}
+	if len(ch.GetControllerCards()) == 0 {
+		for _, cc := range desc.GetControlCards() {
+			ch.ControllerCards = append(ch.ControllerCards, &epb.ControlCard{
+				SerialNumber: cc.GetSerialNumber(),
+				PartNumber:   cc.GetPartNumber(),
+			})
+		}
+	}
+	return ch
+}
+
+// ApproveDevice adds a device held in quarantine to the inventory with the given profile.
+func (s *Server) ApproveDevice(ctx context.Context, req *apb.ApproveDeviceRequest) (*apb.ApproveDeviceResponse, error) {
+	if s.inventory == nil {
+		return nil, status.Errorf(codes.Unimplemented, "the inventory is not supported by the entity manager")
+	}
+	if err := s.authorizeServer(ctx); err != nil {
+		return nil, err
+	}
+	if err := s.requireLeader(ctx); err != nil {
+		return nil, err
+	}
+	d, ok := s.service.PendingDevice(req.GetManufacturer(), req.GetSerialNumber())
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "no %s chassis with serial# %s is pending approval", req.GetManufacturer(), req.GetSerialNumber())
+	}
+	ch := approvedChassis(d, req.GetManufacturer(), req.GetSerialNumber(), req.GetProfile())
+	if err := s.inventory.PutChassis(ch, false); err != nil {
+		return nil, err
+	}
+	s.service.ReleasePendingDevice(req.GetManufacturer(), req.GetSerialNumber())
+	s.publish(ctx)
+	log.Infof("Approved %s chassis with serial# %s", req.GetManufacturer(), req.GetSerialNumber())
+	return &apb.ApproveDeviceResponse{Chassis: ch}, nil
+}
+
+// RejectDevice removes a device from the quarantine without approving it.
+func (s *Server) RejectDevice(ctx context.Context, req *apb.RejectDeviceRequest) (*apb.RejectDeviceResponse, error) {
+	if err := s.authorizeServer(ctx); err != nil {
+		return nil, err
+	}
+	if !s.service.ReleasePendingDevice(req.GetManufacturer(), req.GetSerialNumber()) {
+		return nil, status.Errorf(codes.NotFound, "no %s chassis with serial# %s is pending approval", req.GetManufacturer(), req.GetSerialNumber())
+	}
+	log.Infof("Rejected %s chassis with serial# %s", req.GetManufacturer(), req.GetSerialNumber())
+	return &apb.RejectDeviceResponse{}, nil
+}
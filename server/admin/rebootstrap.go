@@ -0,0 +1,76 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	apb "github.com/openconfig/bootz/server/admin/proto/admin"
+	log "github.com/openconfig/bootz/server/logging"
+)
+
+// defaultRebootReason is the message of reboot requests not given a reason.
+const defaultRebootReason = "re-bootstrap triggered by the bootz server"
+
+// Rebooter reboots devices, so that they bootstrap again.
+type Rebooter interface {
+	// Reboot reboots the device at addr, with message as the informational reason.
+	Reboot(ctx context.Context, addr, message string) error
+}
+
+// SetRebooter enables rebooting the devices triggered to bootstrap again with r. It must be
+// called before the service serves requests.
+func (s *Server) SetRebooter(r Rebooter) {
+	s.rebooter = r
+}
+
+// TriggerRebootstrap resets the bootstrap state of a control card or fixed chassis to idle, and
+// reboots it over gNOI if requested.
+func (s *Server) TriggerRebootstrap(ctx context.Context, req *apb.TriggerRebootstrapRequest) (*apb.TriggerRebootstrapResponse, error) {
+	serial := req.GetSerialNumber()
+	if serial == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "no serial number provided")
+	}
+	if err := s.authorizeDevices(ctx, serial); err != nil {
+		return nil, err
+	}
+	if req.GetReboot() && s.rebooter == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "the server holds no credentials to reboot devices")
+	}
+	// Devices without a state never requested bootstrap data, so they bootstrap as new ones.
+	if err := s.states.Reset(serial); err != nil && status.Code(err) != codes.NotFound {
+		return nil, err
+	}
+	log.Infof("Triggered re-bootstrap of serial# %s", serial)
+	if !req.GetReboot() {
+		return &apb.TriggerRebootstrapResponse{}, nil
+	}
+	d, _ := s.states.Get(serial)
+	if d.LastAddress == "" {
+		return nil, status.Errorf(codes.FailedPrecondition, "the bootstrap state of serial# %s was reset, but its address is unknown to reboot it", serial)
+	}
+	reason := req.GetReason()
+	if reason == "" {
+		reason = defaultRebootReason
+	}
+	if err := s.rebooter.Reboot(ctx, d.LastAddress, reason); err != nil {
+		return nil, status.Errorf(codes.Unavailable, "the bootstrap state of serial# %s was reset, but rebooting it at %s failed: %v", serial, d.LastAddress, err)
+	}
+	log.Infof("Rebooted serial# %s at %s to bootstrap again", serial, d.LastAddress)
+	return &apb.TriggerRebootstrapResponse{Rebooted: true, Address: d.LastAddress}, nil
+}
@@ -0,0 +1,124 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/h-fam/errdiff"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	bpb "github.com/openconfig/bootz/proto/bootz"
+	apb "github.com/openconfig/bootz/server/admin/proto/admin"
+	"github.com/openconfig/bootz/server/devicestate"
+)
+
+// fakeRebooter records the addresses of the devices it reboots, failing if err is set.
+type fakeRebooter struct {
+	err      error
+	rebooted []string
+}
+
+func (f *fakeRebooter) Reboot(_ context.Context, addr, message string) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.rebooted = append(f.rebooted, addr+": "+message)
+	return nil
+}
+
+func TestTriggerRebootstrap(t *testing.T) {
+	tests := []struct {
+		desc         string
+		req          *apb.TriggerRebootstrapRequest
+		rebooter     *fakeRebooter
+		want         *apb.TriggerRebootstrapResponse
+		wantErr      string
+		wantReset    bool
+		wantRebooted []string
+	}{{
+		desc:      "Reset only",
+		req:       &apb.TriggerRebootstrapRequest{SerialNumber: "123A"},
+		want:      &apb.TriggerRebootstrapResponse{},
+		wantReset: true,
+	}, {
+		desc: "Device without a state",
+		req:  &apb.TriggerRebootstrapRequest{SerialNumber: "456A"},
+		want: &apb.TriggerRebootstrapResponse{},
+	}, {
+		desc:         "Reboot",
+		req:          &apb.TriggerRebootstrapRequest{SerialNumber: "123A", Reboot: true, Reason: "RMA-1"},
+		rebooter:     &fakeRebooter{},
+		want:         &apb.TriggerRebootstrapResponse{Rebooted: true, Address: "10.0.0.1"},
+		wantReset:    true,
+		wantRebooted: []string{"10.0.0.1: RMA-1"},
+	}, {
+		desc:         "Reboot with the default reason",
+		req:          &apb.TriggerRebootstrapRequest{SerialNumber: "123A", Reboot: true},
+		rebooter:     &fakeRebooter{},
+		want:         &apb.TriggerRebootstrapResponse{Rebooted: true, Address: "10.0.0.1"},
+		wantReset:    true,
+		wantRebooted: []string{"10.0.0.1: " + defaultRebootReason},
+	}, {
+		desc:    "Reboot without credentials",
+		req:     &apb.TriggerRebootstrapRequest{SerialNumber: "123A", Reboot: true},
+		wantErr: "no credentials to reboot devices",
+	}, {
+		desc:     "Reboot of a device with an unknown address",
+		req:      &apb.TriggerRebootstrapRequest{SerialNumber: "456A", Reboot: true},
+		rebooter: &fakeRebooter{},
+		wantErr:  "its address is unknown",
+	}, {
+		desc:      "Failed reboot",
+		req:       &apb.TriggerRebootstrapRequest{SerialNumber: "123A", Reboot: true},
+		rebooter:  &fakeRebooter{err: fmt.Errorf("connection refused")},
+		wantErr:   "rebooting it at 10.0.0.1 failed: connection refused",
+		wantReset: true,
+	}, {
+		desc:    "Missing serial number",
+		req:     &apb.TriggerRebootstrapRequest{},
+		wantErr: "no serial number",
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			states := devicestate.New(3)
+			states.Sent("123A", devicestate.SingleStage, devicestate.Artifacts{})
+			states.Seen("123A", "10.0.0.1")
+			states.Report("123A", bpb.ReportStatusRequest_BOOTSTRAP_STATUS_SUCCESS, devicestate.Artifacts{})
+			s := &Server{states: states}
+			if test.rebooter != nil {
+				s.SetRebooter(test.rebooter)
+			}
+			got, err := s.TriggerRebootstrap(context.Background(), test.req)
+			if diff := errdiff.Substring(err, test.wantErr); diff != "" {
+				t.Fatalf("TriggerRebootstrap() %s", diff)
+			}
+			if diff := cmp.Diff(test.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("TriggerRebootstrap() diff (-want +got):\n%s", diff)
+			}
+			if d, _ := states.Get("123A"); (d.State == devicestate.Idle) != test.wantReset {
+				t.Errorf("TriggerRebootstrap() left serial# 123A in state %v, want reset %v", d.State, test.wantReset)
+			}
+			if test.rebooter != nil {
+				if diff := cmp.Diff(test.wantRebooted, test.rebooter.rebooted); diff != "" {
+					t.Errorf("TriggerRebootstrap() reboots diff (-want +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+
+	"github.com/openconfig/bootz/server/entitymanager"
+	"github.com/openconfig/bootz/server/retirement"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	apb "github.com/openconfig/bootz/server/admin/proto/admin"
+)
+
+// Retirements retires devices from the inventory and lists the retired ones.
+type Retirements interface {
+	RetireDevice(serial, reason string, r entitymanager.Replacement) (retirement.Entry, error)
+	RetiredDevices() []retirement.Entry
+}
+
+// SetRetirements enables the device retirement RPCs, managed by r. It must be called before the
+// service serves requests.
+func (s *Server) SetRetirements(r Retirements) {
+	s.retirements = r
+}
+
+// retiredDevice returns the proto of a retired device.
+func retiredDevice(e retirement.Entry) *apb.RetiredDevice {
+	d := &apb.RetiredDevice{
+		SerialNumber: e.SerialNumber,
+		Reason:       e.Reason,
+		RetiredAt:    timestamppb.New(e.RetiredAt),
+		ReplacedBy:   e.ReplacedBy,
+		Chassis:      e.Chassis,
+		Status:       e.Status,
+	}
+	if e.State != nil {
+		d.State = deviceState(*e.State)
+	}
+	return d
+}
+
+// RetireDevice retires a control card or fixed chassis, e.g. returned to its vendor or
+// transferred to another owner, and optionally puts its replacement in its place.
+func (s *Server) RetireDevice(ctx context.Context, req *apb.RetireDeviceRequest) (*apb.RetireDeviceResponse, error) {
+	if s.retirements == nil {
+		return nil, status.Errorf(codes.Unimplemented, "device retirement is not supported by the entity manager")
+	}
+	if err := s.authorizeDevices(ctx, req.GetSerialNumber()); err != nil {
+		return nil, err
+	}
+	if err := s.requireLeader(ctx); err != nil {
+		return nil, err
+	}
+	r := req.GetReplacement()
+	e, err := s.retirements.RetireDevice(req.GetSerialNumber(), req.GetReason(), entitymanager.Replacement{
+		SerialNumber:     r.GetSerialNumber(),
+		PartNumber:       r.GetPartNumber(),
+		OwnershipVoucher: r.GetOwnershipVoucher(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.publish(ctx)
+	return &apb.RetireDeviceResponse{Retired: retiredDevice(e)}, nil
+}
+
+// ListRetiredDevices returns the retired devices. Domain admins only see the devices retired
+// from their domain.
+func (s *Server) ListRetiredDevices(ctx context.Context, req *apb.ListRetiredDevicesRequest) (*apb.ListRetiredDevicesResponse, error) {
+	if s.retirements == nil {
+		return nil, status.Errorf(codes.Unimplemented, "device retirement is not supported by the entity manager")
+	}
+	domain, err := s.callerDomain(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp := &apb.ListRetiredDevicesResponse{}
+	for _, e := range s.retirements.RetiredDevices() {
+		// Retired devices left the inventory, so their domain is the tenant of their archived chassis.
+		if domain != "" && e.Chassis.GetTenant() != domain {
+			continue
+		}
+		resp.Devices = append(resp.Devices, retiredDevice(e))
+	}
+	return resp, nil
+}
@@ -0,0 +1,102 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/bootz/server/entitymanager"
+	"github.com/openconfig/bootz/server/retirement"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	bpb "github.com/openconfig/bootz/proto/bootz"
+	apb "github.com/openconfig/bootz/server/admin/proto/admin"
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+)
+
+// fakeRetirements retires every device but 456A, recording the replacements it was given.
+type fakeRetirements struct {
+	retired      []retirement.Entry
+	replacements []entitymanager.Replacement
+}
+
+func (f *fakeRetirements) RetireDevice(serial, reason string, r entitymanager.Replacement) (retirement.Entry, error) {
+	if serial == "456A" {
+		return retirement.Entry{}, status.Errorf(codes.NotFound, "serial# %s not found", serial)
+	}
+	f.replacements = append(f.replacements, r)
+	e := retirement.Entry{
+		SerialNumber: serial,
+		Reason:       reason,
+		RetiredAt:    time.Unix(100, 0),
+		ReplacedBy:   r.SerialNumber,
+		Chassis:      &epb.Chassis{SerialNumber: "123"},
+		Status:       bpb.ControlCardState_CONTROL_CARD_STATUS_INITIALIZED,
+	}
+	f.retired = append(f.retired, e)
+	return e, nil
+}
+
+func (f *fakeRetirements) RetiredDevices() []retirement.Entry {
+	return f.retired
+}
+
+func TestRetireDevice(t *testing.T) {
+	s := &Server{}
+	if _, err := s.RetireDevice(context.Background(), &apb.RetireDeviceRequest{SerialNumber: "123A"}); status.Code(err) != codes.Unimplemented {
+		t.Errorf("RetireDevice() without retirements err = %v, want code %v", err, codes.Unimplemented)
+	}
+	f := &fakeRetirements{}
+	s.SetRetirements(f)
+	if _, err := s.RetireDevice(context.Background(), &apb.RetireDeviceRequest{SerialNumber: "456A"}); status.Code(err) != codes.NotFound {
+		t.Errorf("RetireDevice() of an unknown device err = %v, want code %v", err, codes.NotFound)
+	}
+	got, err := s.RetireDevice(context.Background(), &apb.RetireDeviceRequest{
+		SerialNumber: "123A",
+		Reason:       "RMA-1",
+		Replacement:  &apb.ReplacementDevice{SerialNumber: "123C", PartNumber: "P2", OwnershipVoucher: []byte("ov")},
+	})
+	if err != nil {
+		t.Fatalf("RetireDevice() err = %v, want nil", err)
+	}
+	want := &apb.RetiredDevice{
+		SerialNumber: "123A",
+		Reason:       "RMA-1",
+		RetiredAt:    timestamppb.New(time.Unix(100, 0)),
+		ReplacedBy:   "123C",
+		Chassis:      &epb.Chassis{SerialNumber: "123"},
+		Status:       bpb.ControlCardState_CONTROL_CARD_STATUS_INITIALIZED,
+	}
+	if diff := cmp.Diff(&apb.RetireDeviceResponse{Retired: want}, got, protocmp.Transform()); diff != "" {
+		t.Errorf("RetireDevice() diff (-want +got):\n%s", diff)
+	}
+	wantReplacements := []entitymanager.Replacement{{SerialNumber: "123C", PartNumber: "P2", OwnershipVoucher: []byte("ov")}}
+	if diff := cmp.Diff(wantReplacements, f.replacements); diff != "" {
+		t.Errorf("RetireDevice() replacements diff (-want +got):\n%s", diff)
+	}
+	list, err := s.ListRetiredDevices(context.Background(), &apb.ListRetiredDevicesRequest{})
+	if err != nil {
+		t.Fatalf("ListRetiredDevices() err = %v, want nil", err)
+	}
+	if diff := cmp.Diff(&apb.ListRetiredDevicesResponse{Devices: []*apb.RetiredDevice{want}}, list, protocmp.Transform()); diff != "" {
+		t.Errorf("ListRetiredDevices() diff (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+
+	"github.com/openconfig/bootz/server/token"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	apb "github.com/openconfig/bootz/server/admin/proto/admin"
+	log "github.com/openconfig/bootz/server/logging"
+)
+
+// SetTokens enables the bootstrap token RPCs, managing the tokens of l. It must be called before
+// the service serves requests.
+func (s *Server) SetTokens(l *token.List) {
+	s.tokens = l
+}
+
+// IssueBootstrapToken issues a one-time bootstrap token for a control card or fixed chassis.
+func (s *Server) IssueBootstrapToken(ctx context.Context, req *apb.IssueBootstrapTokenRequest) (*apb.IssueBootstrapTokenResponse, error) {
+	if s.tokens == nil {
+		return nil, status.Errorf(codes.Unimplemented, "bootstrap tokens are not supported by the entity manager")
+	}
+	if err := s.authorizeDevices(ctx, req.GetSerialNumber()); err != nil {
+		return nil, err
+	}
+	if err := s.requireLeader(ctx); err != nil {
+		return nil, err
+	}
+	t, e, err := s.tokens.Issue(req.GetSerialNumber(), req.GetTtl().AsDuration())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "unable to issue bootstrap token: %v", err)
+	}
+	s.publish(ctx)
+	log.Infof("Issued bootstrap token for serial# %s, expiring at %v", e.SerialNumber, e.ExpiresAt)
+	return &apb.IssueBootstrapTokenResponse{
+		Token:     t,
+		ExpiresAt: timestamppb.New(e.ExpiresAt),
+	}, nil
+}
+
+// ListBootstrapTokens returns the bootstrap tokens not used yet. Domain admins only see the
+// tokens of the devices of their domain.
+func (s *Server) ListBootstrapTokens(ctx context.Context, req *apb.ListBootstrapTokensRequest) (*apb.ListBootstrapTokensResponse, error) {
+	if s.tokens == nil {
+		return nil, status.Errorf(codes.Unimplemented, "bootstrap tokens are not supported by the entity manager")
+	}
+	domain, err := s.callerDomain(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp := &apb.ListBootstrapTokensResponse{}
+	for _, e := range s.tokens.Entries() {
+		if !s.inDomain(domain, e.SerialNumber) {
+			continue
+		}
+		resp.Tokens = append(resp.Tokens, &apb.BootstrapToken{
+			SerialNumber: e.SerialNumber,
+			IssuedAt:     timestamppb.New(e.IssuedAt),
+			ExpiresAt:    timestamppb.New(e.ExpiresAt),
+		})
+	}
+	return resp, nil
+}
+
+// RevokeBootstrapToken revokes the bootstrap token of a control card or fixed chassis.
+func (s *Server) RevokeBootstrapToken(ctx context.Context, req *apb.RevokeBootstrapTokenRequest) (*apb.RevokeBootstrapTokenResponse, error) {
+	if s.tokens == nil {
+		return nil, status.Errorf(codes.Unimplemented, "bootstrap tokens are not supported by the entity manager")
+	}
+	if err := s.authorizeDevices(ctx, req.GetSerialNumber()); err != nil {
+		return nil, err
+	}
+	if err := s.requireLeader(ctx); err != nil {
+		return nil, err
+	}
+	if !s.tokens.Invalidate(req.GetSerialNumber()) {
+		return nil, status.Errorf(codes.NotFound, "no bootstrap token issued for serial# %s", req.GetSerialNumber())
+	}
+	s.publish(ctx)
+	log.Infof("Revoked bootstrap token of serial# %s", req.GetSerialNumber())
+	return &apb.RevokeBootstrapTokenResponse{}, nil
+}
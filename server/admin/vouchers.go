@@ -0,0 +1,167 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	apb "github.com/openconfig/bootz/server/admin/proto/admin"
+	log "github.com/openconfig/bootz/server/logging"
+)
+
+// maxBundleFileSize bounds the size of a file of a zip voucher bundle once decompressed.
+const maxBundleFileSize = 1 << 20
+
+// bundleVoucher is a voucher of a bundle, with the serial number the bundle lists it under, if any.
+type bundleVoucher struct {
+	source string
+	serial string
+	ov     []byte
+	err    error
+}
+
+// decodeVoucher returns the DER encoding of a DER or base64 encoded voucher.
+func decodeVoucher(data []byte) []byte {
+	if der, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(string(data)), "")); err == nil {
+		return der
+	}
+	return data
+}
+
+// parseBundle returns the vouchers of a zip or CSV voucher bundle.
+func parseBundle(bundle []byte) ([]bundleVoucher, error) {
+	if bytes.HasPrefix(bundle, []byte("PK\x03\x04")) {
+		return parseZipBundle(bundle)
+	}
+	return parseCSVBundle(bundle)
+}
+
+// parseZipBundle returns the vouchers of the files of a zip archive.
+func parseZipBundle(bundle []byte) ([]bundleVoucher, error) {
+	r, err := zip.NewReader(bytes.NewReader(bundle), int64(len(bundle)))
+	if err != nil {
+		return nil, fmt.Errorf("unable to open zip archive: %v", err)
+	}
+	var vouchers []bundleVoucher
+	for _, f := range r.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		v := bundleVoucher{source: f.Name}
+		if rc, err := f.Open(); err != nil {
+			v.err = err
+		} else {
+			var data []byte
+			data, v.err = io.ReadAll(io.LimitReader(rc, maxBundleFileSize+1))
+			rc.Close()
+			if v.err == nil && len(data) > maxBundleFileSize {
+				v.err = fmt.Errorf("file exceeds %d bytes", maxBundleFileSize)
+			}
+			v.ov = decodeVoucher(data)
+		}
+		vouchers = append(vouchers, v)
+	}
+	return vouchers, nil
+}
+
+// parseCSVBundle returns the vouchers of the rows of a CSV file, holding a serial number and a
+// base64 encoded voucher. A header row, whose voucher is not base64 encoded, is skipped.
+func parseCSVBundle(bundle []byte) ([]bundleVoucher, error) {
+	r := csv.NewReader(bytes.NewReader(bundle))
+	r.FieldsPerRecord = 2
+	r.TrimLeadingSpace = true
+	rows, err := r.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse bundle as a zip archive or a CSV file: %v", err)
+	}
+	var vouchers []bundleVoucher
+	for i, row := range rows {
+		v := bundleVoucher{source: fmt.Sprintf("row %d", i+1), serial: strings.TrimSpace(row[0])}
+		v.ov, v.err = base64.StdEncoding.DecodeString(strings.TrimSpace(row[1]))
+		if v.err != nil {
+			if i == 0 {
+				continue
+			}
+			v.err = fmt.Errorf("voucher is not base64 encoded: %v", v.err)
+		}
+		vouchers = append(vouchers, v)
+	}
+	return vouchers, nil
+}
+
+// UploadOwnershipVoucherBundle associates each voucher of a bundle with the control card or
+// fixed chassis of its serial number.
+func (s *Server) UploadOwnershipVoucherBundle(ctx context.Context, req *apb.UploadOwnershipVoucherBundleRequest) (*apb.UploadOwnershipVoucherBundleResponse, error) {
+	if s.inventory == nil {
+		return nil, status.Errorf(codes.Unimplemented, "the inventory is not supported by the entity manager")
+	}
+	// Vouchers are authorized one by one, but unidentified callers are refused upfront.
+	if _, err := s.callerDomain(ctx); err != nil {
+		return nil, err
+	}
+	if err := s.requireLeader(ctx); err != nil {
+		return nil, err
+	}
+	vouchers, err := parseBundle(req.GetBundle())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	resp := &apb.UploadOwnershipVoucherBundleResponse{}
+	associated := 0
+	for _, v := range vouchers {
+		result := &apb.OwnershipVoucherUploadResult{Source: v.source}
+		if err := s.associateVoucher(ctx, v, req.GetRotated(), result); err != nil {
+			result.Error = err.Error()
+		} else {
+			associated++
+		}
+		resp.Results = append(resp.Results, result)
+	}
+	log.Infof("Associated %d of the %d ownership vouchers of a bundle", associated, len(vouchers))
+	if associated > 0 {
+		s.publish(ctx)
+	}
+	return resp, nil
+}
+
+// associateVoucher associates a voucher of a bundle with the control card or fixed chassis of
+// its serial number, recording the serial number in result.
+func (s *Server) associateVoucher(ctx context.Context, v bundleVoucher, rotated bool, result *apb.OwnershipVoucherUploadResult) error {
+	if v.err != nil {
+		return v.err
+	}
+	serial, err := s.inventory.ParseOwnershipVoucher(v.ov)
+	if err != nil {
+		return err
+	}
+	result.SerialNumber = serial
+	if v.serial != "" && v.serial != serial {
+		return fmt.Errorf("voucher is listed for serial# %s, but issued for serial# %s", v.serial, serial)
+	}
+	if err := s.authorizeDevices(ctx, serial); err != nil {
+		return err
+	}
+	return s.inventory.SetOwnershipVoucher(serial, v.ov, rotated)
+}
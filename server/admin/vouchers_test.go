@@ -0,0 +1,144 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	apb "github.com/openconfig/bootz/server/admin/proto/admin"
+)
+
+// fakeInventory parses vouchers of the form "ov:<serial>" and knows the serial numbers of known.
+type fakeInventory struct {
+	Inventory
+	known map[string]bool
+	set   map[string]string
+}
+
+func (f *fakeInventory) ParseOwnershipVoucher(ov []byte) (string, error) {
+	serial, ok := strings.CutPrefix(string(ov), "ov:")
+	if !ok {
+		return "", fmt.Errorf("invalid ownership voucher")
+	}
+	return serial, nil
+}
+
+func (f *fakeInventory) SetOwnershipVoucher(serial string, ov []byte, rotated bool) error {
+	if !f.known[serial] {
+		return fmt.Errorf("unknown serial# %s", serial)
+	}
+	f.set[serial] = string(ov)
+	return nil
+}
+
+func zipBundle(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	var names []string
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		content := files[name]
+		f, err := w.Create(name)
+		if err != nil {
+			t.Fatalf("unable to create %s: %v", name, err)
+		}
+		if _, err := f.Write([]byte(content)); err != nil {
+			t.Fatalf("unable to write %s: %v", name, err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("unable to close zip archive: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestUploadOwnershipVoucherBundle(t *testing.T) {
+	b64 := func(s string) string { return base64.StdEncoding.EncodeToString([]byte(s)) }
+	tests := []struct {
+		desc    string
+		bundle  []byte
+		want    []*apb.OwnershipVoucherUploadResult
+		wantSet map[string]string
+		wantErr bool
+	}{{
+		desc:   "Zip archive",
+		bundle: zipBundle(t, map[string]string{"123A.vcj": "ov:123A", "123B.b64": b64("ov:123B") + "\n"}),
+		want: []*apb.OwnershipVoucherUploadResult{
+			{Source: "123A.vcj", SerialNumber: "123A"},
+			{Source: "123B.b64", SerialNumber: "123B"},
+		},
+		wantSet: map[string]string{"123A": "ov:123A", "123B": "ov:123B"},
+	}, {
+		desc:   "CSV file with header",
+		bundle: []byte("serial,voucher\n123A," + b64("ov:123A") + "\n456A," + b64("ov:456A") + "\n"),
+		want: []*apb.OwnershipVoucherUploadResult{
+			{Source: "row 2", SerialNumber: "123A"},
+			{Source: "row 3", SerialNumber: "456A", Error: "unknown serial# 456A"},
+		},
+		wantSet: map[string]string{"123A": "ov:123A"},
+	}, {
+		desc:   "CSV file with mismatched serial",
+		bundle: []byte("123B," + b64("ov:123A") + "\n123B,not base64\n"),
+		want: []*apb.OwnershipVoucherUploadResult{
+			{Source: "row 1", SerialNumber: "123A", Error: "voucher is listed for serial# 123B, but issued for serial# 123A"},
+			{Source: "row 2", Error: "voucher is not base64 encoded: illegal base64 data at input byte 3"},
+		},
+		wantSet: map[string]string{},
+	}, {
+		desc:   "Invalid voucher",
+		bundle: zipBundle(t, map[string]string{"bad": "junk"}),
+		want: []*apb.OwnershipVoucherUploadResult{
+			{Source: "bad", Error: "invalid ownership voucher"},
+		},
+		wantSet: map[string]string{},
+	}, {
+		desc:    "Invalid bundle",
+		bundle:  []byte("a,b,c\n"),
+		wantErr: true,
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			inv := &fakeInventory{known: map[string]bool{"123A": true, "123B": true}, set: map[string]string{}}
+			s := &Server{inventory: inv}
+			resp, err := s.UploadOwnershipVoucherBundle(context.Background(), &apb.UploadOwnershipVoucherBundleRequest{Bundle: test.bundle})
+			if (err != nil) != test.wantErr {
+				t.Fatalf("UploadOwnershipVoucherBundle() err = %v, want error %v", err, test.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(test.want, resp.GetResults(), protocmp.Transform()); diff != "" {
+				t.Errorf("UploadOwnershipVoucherBundle() results diff (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(test.wantSet, inv.set); diff != "" {
+				t.Errorf("UploadOwnershipVoucherBundle() vouchers diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
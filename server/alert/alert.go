@@ -0,0 +1,324 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package alert emails or pages operators when devices keep failing to
+// bootstrap, as configured by the alerting rules of the inventory options.
+package alert
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/smtp"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openconfig/bootz/server/events"
+
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+	log "github.com/openconfig/bootz/server/logging"
+)
+
+const (
+	// maxInFlight bounds the number of emails being delivered at the same time.
+	maxInFlight = 16
+	// pageBuffer is the number of pages queued for delivery before alerting blocks.
+	pageBuffer = 64
+	// timeout bounds the delivery of a page.
+	timeout = 10 * time.Second
+	// defaultPagerDutyURL is the endpoint of the Events API v2 of PagerDuty.
+	defaultPagerDutyURL = "https://events.pagerduty.com/v2/enqueue"
+)
+
+// Alert is raised by a device failing more times than a rule tolerates.
+type Alert struct {
+	SerialNumber        string
+	ChassisSerialNumber string
+	Manufacturer        string
+	Trigger             epb.AlertTrigger
+	Severity            epb.AlertSeverity
+	// Failures counts the failures of the device since its last successful bootstrap.
+	Failures int
+	// Message is the status message or rejection reason of the last failure.
+	Message string
+	Time    time.Time
+}
+
+// Summary describes the alert in a line.
+func (a Alert) Summary() string {
+	what := "failed to bootstrap"
+	if a.Trigger == epb.AlertTrigger_ALERT_TRIGGER_VOUCHER_FAILURE {
+		what = "failed ownership voucher verification"
+	}
+	times := fmt.Sprintf("%d times", a.Failures)
+	if a.Failures == 1 {
+		times = "once"
+	}
+	s := fmt.Sprintf("bootz: device %s %s %s", a.SerialNumber, what, times)
+	if a.Message != "" {
+		s += ": " + a.Message
+	}
+	return s
+}
+
+// severity returns the name of the severity of the alert, as known to PagerDuty.
+func (a Alert) severity() string {
+	return strings.ToLower(strings.TrimPrefix(a.Severity.String(), "ALERT_SEVERITY_"))
+}
+
+// triggers returns the triggers matched by the event.
+func triggers(e events.Event) []epb.AlertTrigger {
+	var out []epb.AlertTrigger
+	if e.Stage == events.Failed {
+		out = append(out, epb.AlertTrigger_ALERT_TRIGGER_BOOTSTRAP_FAILURE)
+	}
+	if (e.Stage == events.Failed || e.Stage == events.Rejected) && strings.Contains(strings.ToLower(e.Message), "ownership voucher") {
+		out = append(out, epb.AlertTrigger_ALERT_TRIGGER_VOUCHER_FAILURE)
+	}
+	return out
+}
+
+// key identifies the failures of a device counted for a trigger.
+type key struct {
+	serial  string
+	trigger epb.AlertTrigger
+}
+
+// Notifier raises the alerts of the failures of devices.
+type Notifier struct {
+	rules []*epb.AlertRule
+	// failures counts the failures of each device since its last successful bootstrap.
+	failures map[key]int
+	// paged holds the devices with open pages, resolved once they succeed.
+	paged map[key]bool
+
+	smtp         *epb.SmtpServer
+	smtpPassword string
+	// sendMail sends emails, replaced by tests.
+	sendMail func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+
+	pagerDutyURL string
+	routingKey   string
+	client       *http.Client
+}
+
+// New returns a notifier raising the alerts of the rules of cfg. It fails if a rule is invalid,
+// or uses a channel which is not configured.
+func New(cfg *epb.Alerting) (*Notifier, error) {
+	n := &Notifier{
+		rules:        cfg.GetRules(),
+		failures:     map[key]int{},
+		paged:        map[key]bool{},
+		smtp:         cfg.GetSmtp(),
+		sendMail:     smtp.SendMail,
+		pagerDutyURL: cfg.GetPagerduty().GetUrl(),
+		client:       &http.Client{Timeout: timeout},
+	}
+	if n.pagerDutyURL == "" {
+		n.pagerDutyURL = defaultPagerDutyURL
+	}
+	if s := cfg.GetSmtp(); s != nil {
+		if s.GetAddress() == "" || s.GetFrom() == "" {
+			return nil, fmt.Errorf("smtp requires an address and a sender")
+		}
+		if f := s.GetPasswordFile(); f != "" {
+			data, err := os.ReadFile(f)
+			if err != nil {
+				return nil, fmt.Errorf("unable to read SMTP password: %v", err)
+			}
+			n.smtpPassword = strings.TrimSpace(string(data))
+		}
+	}
+	if p := cfg.GetPagerduty(); p != nil {
+		data, err := os.ReadFile(p.GetRoutingKeyFile())
+		if err != nil {
+			return nil, fmt.Errorf("unable to read PagerDuty routing key: %v", err)
+		}
+		n.routingKey = strings.TrimSpace(string(data))
+	}
+	for i, r := range n.rules {
+		switch {
+		case r.GetTrigger() == epb.AlertTrigger_ALERT_TRIGGER_UNSPECIFIED:
+			return nil, fmt.Errorf("alert rule %d has no trigger", i)
+		case len(r.GetEmail()) == 0 && !r.GetPage():
+			return nil, fmt.Errorf("alert rule %d neither emails nor pages", i)
+		case len(r.GetEmail()) > 0 && n.smtp == nil:
+			return nil, fmt.Errorf("alert rule %d emails without an SMTP server", i)
+		case r.GetPage() && n.routingKey == "":
+			return nil, fmt.Errorf("alert rule %d pages without a PagerDuty service", i)
+		}
+	}
+	return n, nil
+}
+
+// pageRequest triggers or resolves the page of the failures of a device.
+type pageRequest struct {
+	action string
+	key    key
+	alert  Alert
+}
+
+// Run raises the alerts of the events received from ch until it is closed. Emails are sent
+// concurrently, while pages are sent in order so that a page is never resolved before it is
+// triggered.
+func (n *Notifier) Run(ch <-chan events.Event) {
+	sem := make(chan struct{}, maxInFlight)
+	var wg sync.WaitGroup
+	pages := make(chan pageRequest, pageBuffer)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for p := range pages {
+			if err := n.page(p.action, p.key, p.alert); err != nil {
+				log.Warningf("Unable to %s the page of serial# %v: %v", p.action, p.key.serial, err)
+			}
+		}
+	}()
+	for e := range ch {
+		if e.Stage == events.Succeeded {
+			for k := range n.failures {
+				if k.serial != e.SerialNumber {
+					continue
+				}
+				delete(n.failures, k)
+				if n.paged[k] {
+					delete(n.paged, k)
+					pages <- pageRequest{action: "resolve", key: k}
+				}
+			}
+			continue
+		}
+		for _, t := range triggers(e) {
+			k := key{serial: e.SerialNumber, trigger: t}
+			n.failures[k]++
+			for _, r := range n.rules {
+				if r.GetTrigger() != t || uint32(n.failures[k]) != r.GetMaxFailures()+1 {
+					continue
+				}
+				a := Alert{
+					SerialNumber:        e.SerialNumber,
+					ChassisSerialNumber: e.ChassisSerialNumber,
+					Manufacturer:        e.Manufacturer,
+					Trigger:             t,
+					Severity:            r.GetSeverity(),
+					Failures:            n.failures[k],
+					Message:             e.Message,
+					Time:                e.Time,
+				}
+				if a.Severity == epb.AlertSeverity_ALERT_SEVERITY_UNSPECIFIED {
+					a.Severity = epb.AlertSeverity_ALERT_SEVERITY_WARNING
+				}
+				log.Warningf("Alerting: %s", a.Summary())
+				if to := r.GetEmail(); len(to) > 0 {
+					sem <- struct{}{}
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						defer func() { <-sem }()
+						if err := n.email(to, a); err != nil {
+							log.Warningf("Unable to email the alert of serial# %v: %v", a.SerialNumber, err)
+						}
+					}()
+				}
+				if r.GetPage() {
+					n.paged[k] = true
+					pages <- pageRequest{action: "trigger", key: k, alert: a}
+				}
+			}
+		}
+	}
+	close(pages)
+	wg.Wait()
+}
+
+// email sends the alert to the recipients.
+func (n *Notifier) email(to []string, a Alert) error {
+	var auth smtp.Auth
+	if u := n.smtp.GetUsername(); u != "" {
+		host, _, err := net.SplitHostPort(n.smtp.GetAddress())
+		if err != nil {
+			return fmt.Errorf("invalid SMTP address %q: %v", n.smtp.GetAddress(), err)
+		}
+		auth = smtp.PlainAuth("", u, n.smtpPassword, host)
+	}
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", n.smtp.GetFrom())
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&msg, "Subject: [%s] %s\r\n", strings.ToUpper(a.severity()), a.Summary())
+	fmt.Fprintf(&msg, "Date: %s\r\n\r\n", a.Time.Format(time.RFC1123Z))
+	fmt.Fprintf(&msg, "Serial number: %s\r\n", a.SerialNumber)
+	fmt.Fprintf(&msg, "Chassis serial number: %s\r\n", a.ChassisSerialNumber)
+	fmt.Fprintf(&msg, "Vendor: %s\r\n", a.Manufacturer)
+	fmt.Fprintf(&msg, "Failures: %d\r\n", a.Failures)
+	fmt.Fprintf(&msg, "Last failure: %s\r\n", a.Message)
+	return n.sendMail(n.smtp.GetAddress(), auth, n.smtp.GetFrom(), to, msg.Bytes())
+}
+
+// pagerDutyEvent is an event of the Events API v2 of PagerDuty.
+type pagerDutyEvent struct {
+	RoutingKey  string            `json:"routing_key"`
+	EventAction string            `json:"event_action"`
+	DedupKey    string            `json:"dedup_key"`
+	Payload     *pagerDutyPayload `json:"payload,omitempty"`
+}
+
+type pagerDutyPayload struct {
+	Summary       string            `json:"summary"`
+	Source        string            `json:"source"`
+	Severity      string            `json:"severity"`
+	Timestamp     time.Time         `json:"timestamp"`
+	Component     string            `json:"component"`
+	CustomDetails map[string]string `json:"custom_details"`
+}
+
+// page triggers or resolves the page of the failures of a device.
+func (n *Notifier) page(action string, k key, a Alert) error {
+	ev := pagerDutyEvent{
+		RoutingKey:  n.routingKey,
+		EventAction: action,
+		DedupKey:    fmt.Sprintf("bootz/%s/%s", k.serial, strings.ToLower(strings.TrimPrefix(k.trigger.String(), "ALERT_TRIGGER_"))),
+	}
+	if action == "trigger" {
+		ev.Payload = &pagerDutyPayload{
+			Summary:   a.Summary(),
+			Source:    "bootz",
+			Severity:  a.severity(),
+			Timestamp: a.Time,
+			Component: a.SerialNumber,
+			CustomDetails: map[string]string{
+				"chassis_serial_number": a.ChassisSerialNumber,
+				"vendor":                a.Manufacturer,
+				"failures":              fmt.Sprint(a.Failures),
+				"last_failure":          a.Message,
+			},
+		}
+	}
+	body, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	resp, err := n.client.Post(n.pagerDutyURL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("unexpected HTTP status %s", resp.Status)
+	}
+	return nil
+}
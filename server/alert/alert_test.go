@@ -0,0 +1,187 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alert
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/h-fam/errdiff"
+	"github.com/openconfig/bootz/server/events"
+
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+)
+
+func writeFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "secret")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("os.WriteFile() err = %v", err)
+	}
+	return path
+}
+
+func TestNew(t *testing.T) {
+	smtpServer := &epb.SmtpServer{Address: "smtp.example.com:587", From: "bootz@example.com"}
+	tests := []struct {
+		desc    string
+		cfg     *epb.Alerting
+		wantErr string
+	}{{
+		desc: "Email and page",
+		cfg: &epb.Alerting{
+			Rules: []*epb.AlertRule{
+				{Trigger: epb.AlertTrigger_ALERT_TRIGGER_BOOTSTRAP_FAILURE, Email: []string{"netops@example.com"}},
+				{Trigger: epb.AlertTrigger_ALERT_TRIGGER_VOUCHER_FAILURE, Page: true},
+			},
+			Smtp:      smtpServer,
+			Pagerduty: &epb.PagerDuty{RoutingKeyFile: writeFile(t, "key\n")},
+		},
+	}, {
+		desc:    "Rule without trigger",
+		cfg:     &epb.Alerting{Rules: []*epb.AlertRule{{Email: []string{"netops@example.com"}}}, Smtp: smtpServer},
+		wantErr: "has no trigger",
+	}, {
+		desc:    "Rule without channel",
+		cfg:     &epb.Alerting{Rules: []*epb.AlertRule{{Trigger: epb.AlertTrigger_ALERT_TRIGGER_BOOTSTRAP_FAILURE}}},
+		wantErr: "neither emails nor pages",
+	}, {
+		desc:    "Email without SMTP server",
+		cfg:     &epb.Alerting{Rules: []*epb.AlertRule{{Trigger: epb.AlertTrigger_ALERT_TRIGGER_BOOTSTRAP_FAILURE, Email: []string{"netops@example.com"}}}},
+		wantErr: "without an SMTP server",
+	}, {
+		desc:    "Page without PagerDuty",
+		cfg:     &epb.Alerting{Rules: []*epb.AlertRule{{Trigger: epb.AlertTrigger_ALERT_TRIGGER_BOOTSTRAP_FAILURE, Page: true}}},
+		wantErr: "without a PagerDuty service",
+	}, {
+		desc:    "Missing routing key",
+		cfg:     &epb.Alerting{Pagerduty: &epb.PagerDuty{RoutingKeyFile: filepath.Join(t.TempDir(), "missing")}},
+		wantErr: "unable to read PagerDuty routing key",
+	}, {
+		desc:    "SMTP server without sender",
+		cfg:     &epb.Alerting{Smtp: &epb.SmtpServer{Address: "smtp.example.com:587"}},
+		wantErr: "requires an address and a sender",
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			_, err := New(test.cfg)
+			if diff := errdiff.Substring(err, test.wantErr); diff != "" {
+				t.Errorf("New() %s", diff)
+			}
+		})
+	}
+}
+
+// email is an email sent by the notifier.
+type email struct {
+	To      []string
+	Subject string
+}
+
+func TestRun(t *testing.T) {
+	var mu sync.Mutex
+	var pages []pagerDutyEvent
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var ev pagerDutyEvent
+		if err := json.NewDecoder(r.Body).Decode(&ev); err != nil {
+			t.Errorf("PagerDuty received an invalid event: %v", err)
+		}
+		ev.Payload = nil
+		mu.Lock()
+		pages = append(pages, ev)
+		mu.Unlock()
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	n, err := New(&epb.Alerting{
+		Rules: []*epb.AlertRule{{
+			Trigger:     epb.AlertTrigger_ALERT_TRIGGER_BOOTSTRAP_FAILURE,
+			MaxFailures: 1,
+			Email:       []string{"netops@example.com"},
+		}, {
+			Trigger:     epb.AlertTrigger_ALERT_TRIGGER_BOOTSTRAP_FAILURE,
+			MaxFailures: 2,
+			Severity:    epb.AlertSeverity_ALERT_SEVERITY_CRITICAL,
+			Page:        true,
+		}, {
+			Trigger: epb.AlertTrigger_ALERT_TRIGGER_VOUCHER_FAILURE,
+			Email:   []string{"security@example.com"},
+		}},
+		Smtp:      &epb.SmtpServer{Address: "smtp.example.com:587", From: "bootz@example.com"},
+		Pagerduty: &epb.PagerDuty{RoutingKeyFile: writeFile(t, "key"), Url: srv.URL},
+	})
+	if err != nil {
+		t.Fatalf("New() err = %v", err)
+	}
+	var emails []email
+	n.sendMail = func(_ string, _ smtp.Auth, _ string, to []string, msg []byte) error {
+		subject := ""
+		for _, line := range strings.Split(string(msg), "\r\n") {
+			if s, ok := strings.CutPrefix(line, "Subject: "); ok {
+				subject = s
+			}
+		}
+		mu.Lock()
+		emails = append(emails, email{To: to, Subject: subject})
+		mu.Unlock()
+		return nil
+	}
+
+	now := time.Unix(0, 0)
+	failed := events.Event{SerialNumber: "123A", Stage: events.Failed, Message: "config rejected", Time: now}
+	ch := make(chan events.Event, 10)
+	ch <- failed
+	ch <- failed
+	ch <- failed
+	ch <- failed
+	ch <- events.Event{SerialNumber: "123A", Stage: events.Succeeded, Time: now}
+	ch <- events.Event{SerialNumber: "456", Stage: events.Rejected, Message: "ownership voucher for serial# 456 has been revoked", Time: now}
+	ch <- events.Event{SerialNumber: "456", Stage: events.Rejected, Message: "rate limit exceeded", Time: now}
+	close(ch)
+	n.Run(ch)
+
+	wantEmails := []email{{
+		To:      []string{"netops@example.com"},
+		Subject: "[WARNING] bootz: device 123A failed to bootstrap 2 times: config rejected",
+	}, {
+		To:      []string{"security@example.com"},
+		Subject: "[WARNING] bootz: device 456 failed ownership voucher verification once: ownership voucher for serial# 456 has been revoked",
+	}}
+	wantPages := []pagerDutyEvent{
+		{RoutingKey: "key", EventAction: "trigger", DedupKey: "bootz/123A/bootstrap_failure"},
+		{RoutingKey: "key", EventAction: "resolve", DedupKey: "bootz/123A/bootstrap_failure"},
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	// Emails are delivered concurrently.
+	sortEmails := cmpopts.SortSlices(func(a, b email) bool { return a.Subject < b.Subject })
+	if diff := cmp.Diff(wantEmails, emails, sortEmails); diff != "" {
+		t.Errorf("Run() sent unexpected emails (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(wantPages, pages); diff != "" {
+		t.Errorf("Run() sent unexpected pages (-want +got):\n%s", diff)
+	}
+}
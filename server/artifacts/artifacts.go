@@ -0,0 +1,140 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package artifacts generates ephemeral security artifacts, so the server can run in tests and demos
+// without any certificates, keys or ownership vouchers on disk.
+package artifacts
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"time"
+
+	ownershipvoucher "github.com/openconfig/bootz/common/ownership_voucher"
+	"github.com/openconfig/bootz/server/service"
+)
+
+const (
+	// keyBits is the size of the generated RSA keys. The keys only live as long as the server,
+	// so they are smaller than the keys of the artifact generator.
+	keyBits = 2048
+	// validity is how long the generated certificates are valid.
+	validity = 365 * 24 * time.Hour
+)
+
+// Options configures the generated artifacts.
+type Options struct {
+	// Vendor is the organization of the vendor CA. Defaults to "Bootz Vendor".
+	Vendor string
+	// Owner is the organization of the PDC. Defaults to "Bootz Owner".
+	Owner string
+	// ServerName is the DNS name of the server's TLS certificate. Defaults to "localhost".
+	ServerName string
+	// Serials are the control card serials to issue ownership vouchers for.
+	Serials []string
+}
+
+// Generate creates a vendor CA, a PDC which also serves as the OC and the server's TLS certificate,
+// and an ownership voucher pinning the PDC for each serial. The ownership vouchers are base64 encoded.
+func Generate(opts Options) (*service.SecurityArtifacts, error) {
+	if opts.Vendor == "" {
+		opts.Vendor = "Bootz Vendor"
+	}
+	if opts.Owner == "" {
+		opts.Owner = "Bootz Owner"
+	}
+	if opts.ServerName == "" {
+		opts.ServerName = "localhost"
+	}
+	vendorCA, vendorCAKey, err := newCertificateAuthority("Manufacturer Root CA", opts.Vendor, opts.ServerName)
+	if err != nil {
+		return nil, fmt.Errorf("unable to generate vendor CA: %v", err)
+	}
+	pdc, pdcKey, err := newCertificateAuthority("Device Owner PDC", opts.Owner, opts.ServerName)
+	if err != nil {
+		return nil, fmt.Errorf("unable to generate PDC: %v", err)
+	}
+	pdcPair := keyPair(pdc, pdcKey)
+	tlsCert, err := pdcPair.TLSCertificate()
+	if err != nil {
+		return nil, fmt.Errorf("unable to generate server TLS certificate: %v", err)
+	}
+	ovs := service.OVList{}
+	for _, s := range opts.Serials {
+		ov, err := ownershipvoucher.New(s, []byte(pdcPair.Cert), vendorCA, vendorCAKey)
+		if err != nil {
+			return nil, fmt.Errorf("unable to generate OV for serial %v: %v", s, err)
+		}
+		ovs[s] = base64.StdEncoding.EncodeToString(ov)
+	}
+	// As with the artifact generator, the OC is the same as the PDC.
+	return &service.SecurityArtifacts{
+		OC:         pdcPair,
+		PDC:        pdcPair,
+		VendorCA:   keyPair(vendorCA, vendorCAKey),
+		OV:         ovs,
+		TLSKeypair: tlsCert,
+	}, nil
+}
+
+// newCertificateAuthority creates a self-signed CA for the organization, which may also serve TLS for serverName.
+func newCertificateAuthority(commonName, org, serverName string) (*x509.Certificate, *rsa.PrivateKey, error) {
+	key, err := rsa.GenerateKey(rand.Reader, keyBits)
+	if err != nil {
+		return nil, nil, err
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, err
+	}
+	now := time.Now()
+	tmpl := &x509.Certificate{
+		DNSNames:     []string{serverName},
+		SerialNumber: serial,
+		Subject: pkix.Name{
+			CommonName:   commonName,
+			Organization: []string{org},
+		},
+		// Allow for clock skew between the server and the devices.
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(validity),
+		IsCA:                  true,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth},
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cert, key, nil
+}
+
+// keyPair PEM encodes the certificate and its private key.
+func keyPair(cert *x509.Certificate, key *rsa.PrivateKey) *service.KeyPair {
+	return &service.KeyPair{
+		Cert:       string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})),
+		PrivateKey: string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})),
+	}
+}
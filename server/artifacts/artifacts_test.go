@@ -0,0 +1,91 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package artifacts
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"testing"
+
+	"github.com/openconfig/bootz/common/signature"
+
+	ownershipvoucher "github.com/openconfig/bootz/common/ownership_voucher"
+)
+
+func TestGenerate(t *testing.T) {
+	sa, err := Generate(Options{Serials: []string{"123A", "123B"}})
+	if err != nil {
+		t.Fatalf("Generate() err = %v, want nil", err)
+	}
+	parse := func(name, certPEM string) *x509.Certificate {
+		t.Helper()
+		block, _ := pem.Decode([]byte(certPEM))
+		if block == nil {
+			t.Fatalf("unable to decode %s certificate", name)
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			t.Fatalf("unable to parse %s certificate: %v", name, err)
+		}
+		return cert
+	}
+	vendorCA := parse("vendor CA", sa.VendorCA.Cert)
+	pdc := parse("PDC", sa.PDC.Cert)
+	oc := parse("OC", sa.OC.Cert)
+
+	if sa.TLSKeypair == nil || len(sa.TLSKeypair.Certificate) == 0 {
+		t.Errorf("Generate() returned no TLS certificate")
+	}
+	if err := pdc.VerifyHostname("localhost"); err != nil {
+		t.Errorf("PDC VerifyHostname() err = %v, want nil", err)
+	}
+
+	signer, err := sa.OC.PrivateKeySigner()
+	if err != nil {
+		t.Fatalf("PrivateKeySigner() err = %v, want nil", err)
+	}
+	sig, err := signature.Sign(signer, []byte("data"))
+	if err != nil {
+		t.Fatalf("Sign() err = %v, want nil", err)
+	}
+	if err := signature.Verify(oc, []byte("data"), sig); err != nil {
+		t.Errorf("Verify() err = %v, want nil", err)
+	}
+
+	trustAnchors := x509.NewCertPool()
+	trustAnchors.AddCert(vendorCA)
+	for _, serial := range []string{"123A", "123B"} {
+		ov64, ok := sa.OV[serial]
+		if !ok {
+			t.Fatalf("Generate() returned no OV for %s", serial)
+		}
+		ov, err := base64.StdEncoding.DecodeString(ov64)
+		if err != nil {
+			t.Fatalf("OV of %s is not base64 encoded: %v", serial, err)
+		}
+		parsed, err := ownershipvoucher.VerifyAndUnmarshalWithOptions(ov, ownershipvoucher.VerifyOptions{
+			TrustAnchors:     trustAnchors,
+			PinnedDomainCert: pdc,
+			PDCMatch:         ownershipvoucher.PDCMatchExact,
+		})
+		if err != nil {
+			t.Fatalf("VerifyAndUnmarshalWithOptions() of %s err = %v, want nil", serial, err)
+		}
+		if got := parsed.OV.SerialNumber; got != serial {
+			t.Errorf("OV serial number = %s, want %s", got, serial)
+		}
+	}
+}
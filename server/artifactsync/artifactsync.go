@@ -0,0 +1,293 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package artifactsync mirrors buckets of S3 and GCS to local directories, so
+// regional servers pull their ownership vouchers, images and configs from a
+// central artifact store. Objects are only downloaded again once their ETag
+// changes.
+package artifactsync
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/openconfig/gnmi/errlist"
+	"google.golang.org/protobuf/encoding/prototext"
+
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+	log "github.com/openconfig/bootz/server/logging"
+)
+
+const (
+	// manifestFile is the file of a directory recording the ETags of the objects mirrored to it.
+	manifestFile = ".bootz-sync.json"
+	// tempPrefix is the prefix of the temporary files objects are downloaded to.
+	tempPrefix = ".bootz-sync-"
+	// listTimeout bounds each request listing the objects of a bucket.
+	listTimeout = 30 * time.Second
+)
+
+// object is an object of a bucket.
+type object struct {
+	key  string
+	etag string
+}
+
+// bucket lists and downloads the objects of a bucket of object storage.
+type bucket interface {
+	// list returns the objects whose key starts with prefix.
+	list(ctx context.Context, prefix string) ([]object, error)
+	// get returns the content of the object with the key.
+	get(ctx context.Context, key string) (io.ReadCloser, error)
+}
+
+// mirror is a bucket mirrored to a directory.
+type mirror struct {
+	url    string
+	bucket bucket
+	prefix string
+	dir    string
+}
+
+// Syncer mirrors buckets to local directories.
+type Syncer struct {
+	mirrors  []*mirror
+	interval time.Duration
+}
+
+// New returns a syncer of the buckets of cfg.
+func New(cfg *epb.ArtifactSync) (*Syncer, error) {
+	s := &Syncer{interval: cfg.GetRefreshInterval().AsDuration()}
+	client := &http.Client{}
+	for _, b := range cfg.GetBuckets() {
+		if b.GetDirectory() == "" {
+			return nil, fmt.Errorf("bucket %s has no directory to mirror to", b.GetUrl())
+		}
+		u, err := url.Parse(b.GetUrl())
+		if err != nil || u.Host == "" {
+			return nil, fmt.Errorf("invalid bucket URL %q", b.GetUrl())
+		}
+		m := &mirror{url: b.GetUrl(), prefix: strings.TrimPrefix(u.Path, "/"), dir: b.GetDirectory()}
+		if m.prefix != "" && !strings.HasSuffix(m.prefix, "/") {
+			m.prefix += "/"
+		}
+		switch u.Scheme {
+		case "s3":
+			m.bucket = newS3Bucket(client, u.Host, b)
+		case "gs":
+			m.bucket = newGCSBucket(client, u.Host, b)
+		default:
+			return nil, fmt.Errorf("unsupported bucket URL %q, want s3:// or gs://", b.GetUrl())
+		}
+		s.mirrors = append(s.mirrors, m)
+	}
+	return s, nil
+}
+
+// Load returns a syncer of the buckets of the text encoded entity.ArtifactSync at path, or nil if
+// path is empty.
+func Load(path string) (*Syncer, error) {
+	if path == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read artifact sync config: %v", err)
+	}
+	cfg := &epb.ArtifactSync{}
+	if err := prototext.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("unable to parse artifact sync config %s: %v", path, err)
+	}
+	return New(cfg)
+}
+
+// Sync mirrors every bucket once, and reports whether any file was written or removed. A bucket
+// failing to sync leaves its directory as it was, and doesn't stop the others from syncing.
+func (s *Syncer) Sync(ctx context.Context) (bool, error) {
+	var errs errlist.List
+	changed := false
+	for _, m := range s.mirrors {
+		n, err := m.sync(ctx)
+		if err != nil {
+			errs.Add(fmt.Errorf("unable to sync %s: %v", m.url, err))
+		}
+		if n > 0 {
+			log.Infof("Synced %d changed objects of %s to %s", n, m.url, m.dir)
+			changed = true
+		}
+	}
+	return changed, errs.Err()
+}
+
+// Interval returns the interval the buckets are synced again at, or 0 if they are only synced at
+// startup.
+func (s *Syncer) Interval() time.Duration {
+	if s == nil {
+		return 0
+	}
+	return s.interval
+}
+
+// sync mirrors the bucket to the directory, and returns the number of files written or removed.
+func (m *mirror) sync(ctx context.Context) (int, error) {
+	lctx, cancel := context.WithTimeout(ctx, listTimeout)
+	objects, err := m.bucket.list(lctx, m.prefix)
+	cancel()
+	if err != nil {
+		return 0, err
+	}
+	if err := os.MkdirAll(m.dir, 0755); err != nil {
+		return 0, err
+	}
+	manifest, err := m.readManifest()
+	if err != nil {
+		return 0, err
+	}
+
+	var errs errlist.List
+	changes := 0
+	listed := map[string]bool{}
+	for _, o := range objects {
+		name := strings.TrimPrefix(o.key, m.prefix)
+		// Folder placeholders carry no content.
+		if name == "" || strings.HasSuffix(name, "/") {
+			continue
+		}
+		if !filepath.IsLocal(name) || name == manifestFile {
+			log.Warningf("Skipping object %s of %s, as its key is not a valid file name", o.key, m.url)
+			continue
+		}
+		listed[name] = true
+		if etag, ok := manifest[name]; ok && etag == o.etag && m.exists(name) {
+			continue
+		}
+		if err := m.download(ctx, o.key, name); err != nil {
+			errs.Add(err)
+			continue
+		}
+		manifest[name] = o.etag
+		changes++
+	}
+	for name := range manifest {
+		if listed[name] {
+			continue
+		}
+		// Only files mirrored before are removed, never files added by other means.
+		if err := os.Remove(filepath.Join(m.dir, filepath.FromSlash(name))); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			errs.Add(err)
+			continue
+		}
+		delete(manifest, name)
+		changes++
+	}
+	if changes > 0 {
+		errs.Add(m.writeManifest(manifest))
+	}
+	return changes, errs.Err()
+}
+
+// exists reports whether the file of an object exists in the directory.
+func (m *mirror) exists(name string) bool {
+	_, err := os.Stat(filepath.Join(m.dir, filepath.FromSlash(name)))
+	return err == nil
+}
+
+// download writes the content of the object with the key to the file name of the directory,
+// replacing the previous file atomically.
+func (m *mirror) download(ctx context.Context, key, name string) error {
+	file := filepath.Join(m.dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return err
+	}
+	body, err := m.bucket.get(ctx, key)
+	if err != nil {
+		return fmt.Errorf("unable to download %s: %v", key, err)
+	}
+	defer body.Close()
+	f, err := os.CreateTemp(filepath.Dir(file), tempPrefix+"*")
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(f, body)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), file)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return fmt.Errorf("unable to download %s: %v", key, err)
+	}
+	return nil
+}
+
+// readManifest returns the ETags of the objects mirrored to the directory by file name.
+func (m *mirror) readManifest() (map[string]string, error) {
+	manifest := map[string]string{}
+	data, err := os.ReadFile(filepath.Join(m.dir, manifestFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return manifest, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		// The objects are downloaded again, as a new manifest is written.
+		log.Warningf("Ignoring the invalid sync manifest of %s: %v", m.dir, err)
+		return map[string]string{}, nil
+	}
+	return manifest, nil
+}
+
+// writeManifest records the ETags of the objects mirrored to the directory.
+func (m *mirror) writeManifest(manifest map[string]string) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := filepath.Join(m.dir, tempPrefix+manifestFile)
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, filepath.Join(m.dir, manifestFile))
+}
+
+// readCredentials returns the lines of the credentials file, or nil if file is empty.
+func readCredentials(file string) ([]string, error) {
+	if file == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read credentials: %v", err)
+	}
+	return strings.Fields(string(data)), nil
+}
+
+// httpError returns an error describing the unexpected response of a storage API.
+func httpError(resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	return fmt.Errorf("unexpected status %s: %s", resp.Status, strings.TrimSpace(string(body)))
+}
@@ -0,0 +1,121 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package artifactsync
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+)
+
+// defaultGCSEndpoint is the endpoint of the GCS JSON API.
+const defaultGCSEndpoint = "https://storage.googleapis.com"
+
+// gcsBucket is a bucket of GCS, accessed through its JSON API.
+type gcsBucket struct {
+	client      *http.Client
+	endpoint    string
+	name        string
+	credentials string
+}
+
+func newGCSBucket(client *http.Client, name string, cfg *epb.ArtifactBucket) *gcsBucket {
+	b := &gcsBucket{
+		client:      client,
+		endpoint:    strings.TrimSuffix(cfg.GetEndpoint(), "/"),
+		name:        name,
+		credentials: cfg.GetCredentialsFile(),
+	}
+	if b.endpoint == "" {
+		b.endpoint = defaultGCSEndpoint
+	}
+	return b
+}
+
+// gcsObjects is a page of the response of objects.list.
+type gcsObjects struct {
+	Items []struct {
+		Name string `json:"name"`
+		ETag string `json:"etag"`
+	} `json:"items"`
+	NextPageToken string `json:"nextPageToken"`
+}
+
+func (b *gcsBucket) list(ctx context.Context, prefix string) ([]object, error) {
+	var objects []object
+	token := ""
+	for {
+		query := url.Values{"prefix": {prefix}, "fields": {"items(name,etag),nextPageToken"}}
+		if token != "" {
+			query.Set("pageToken", token)
+		}
+		resp, err := b.do(ctx, "/storage/v1/b/"+url.PathEscape(b.name)+"/o?"+query.Encode())
+		if err != nil {
+			return nil, err
+		}
+		var page gcsObjects
+		err = json.NewDecoder(resp.Body).Decode(&page)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode object list: %v", err)
+		}
+		for _, item := range page.Items {
+			objects = append(objects, object{key: item.Name, etag: item.ETag})
+		}
+		if page.NextPageToken == "" {
+			return objects, nil
+		}
+		token = page.NextPageToken
+	}
+}
+
+func (b *gcsBucket) get(ctx context.Context, key string) (io.ReadCloser, error) {
+	resp, err := b.do(ctx, "/storage/v1/b/"+url.PathEscape(b.name)+"/o/"+url.PathEscape(key)+"?alt=media")
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// do sends a GET request for the path of the JSON API, authenticated with the access token of the
+// credentials file if set.
+func (b *gcsBucket) do(ctx context.Context, path string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, b.endpoint+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	creds, err := readCredentials(b.credentials)
+	if err != nil {
+		return nil, err
+	}
+	if len(creds) > 0 {
+		req.Header.Set("Authorization", "Bearer "+creds[0])
+	}
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		return nil, httpError(resp)
+	}
+	return resp, nil
+}
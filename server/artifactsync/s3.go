@@ -0,0 +1,234 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package artifactsync
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+)
+
+const (
+	// defaultS3Region is the region of S3 buckets without one.
+	defaultS3Region = "us-east-1"
+	// emptyPayloadHash is the SHA-256 digest of an empty request body.
+	emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	// amzDateFormat is the format of the timestamps of AWS signatures.
+	amzDateFormat = "20060102T150405Z"
+)
+
+// s3Bucket is a bucket of S3, or of an S3 compatible store, addressed in the path style.
+type s3Bucket struct {
+	client      *http.Client
+	endpoint    string
+	name        string
+	region      string
+	credentials string
+	now         func() time.Time
+}
+
+func newS3Bucket(client *http.Client, name string, cfg *epb.ArtifactBucket) *s3Bucket {
+	b := &s3Bucket{
+		client:      client,
+		endpoint:    strings.TrimSuffix(cfg.GetEndpoint(), "/"),
+		name:        name,
+		region:      cfg.GetRegion(),
+		credentials: cfg.GetCredentialsFile(),
+		now:         time.Now,
+	}
+	if b.region == "" {
+		b.region = defaultS3Region
+	}
+	if b.endpoint == "" {
+		b.endpoint = "https://s3." + b.region + ".amazonaws.com"
+	}
+	return b
+}
+
+// listBucketResult is a page of the response of ListObjectsV2.
+type listBucketResult struct {
+	Contents []struct {
+		Key  string `xml:"Key"`
+		ETag string `xml:"ETag"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+func (b *s3Bucket) list(ctx context.Context, prefix string) ([]object, error) {
+	var objects []object
+	token := ""
+	for {
+		query := url.Values{"list-type": {"2"}, "prefix": {prefix}}
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+		resp, err := b.do(ctx, "", query)
+		if err != nil {
+			return nil, err
+		}
+		var page listBucketResult
+		err = xml.NewDecoder(resp.Body).Decode(&page)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode object list: %v", err)
+		}
+		for _, c := range page.Contents {
+			objects = append(objects, object{key: c.Key, etag: c.ETag})
+		}
+		if !page.IsTruncated || page.NextContinuationToken == "" {
+			return objects, nil
+		}
+		token = page.NextContinuationToken
+	}
+}
+
+func (b *s3Bucket) get(ctx context.Context, key string) (io.ReadCloser, error) {
+	resp, err := b.do(ctx, key, nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// do sends a signed GET request for the object with the key, or for the bucket if key is empty.
+func (b *s3Bucket) do(ctx context.Context, key string, query url.Values) (*http.Response, error) {
+	u := b.endpoint + "/" + awsEscape(b.name, false) + "/" + awsEscape(key, true)
+	if len(query) > 0 {
+		u += "?" + canonicalQuery(query)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	creds, err := readCredentials(b.credentials)
+	if err != nil {
+		return nil, err
+	}
+	if len(creds) > 0 {
+		if len(creds) < 2 {
+			return nil, fmt.Errorf("S3 credentials must hold an access key ID and a secret access key")
+		}
+		if len(creds) > 2 {
+			req.Header.Set("X-Amz-Security-Token", creds[2])
+		}
+		signV4(req, creds[0], creds[1], b.region, b.now())
+	}
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		return nil, httpError(resp)
+	}
+	return resp, nil
+}
+
+// signV4 signs a request without body to S3 with AWS Signature Version 4, covering its host and
+// every header it carries.
+func signV4(req *http.Request, accessKeyID, secretKey, region string, now time.Time) {
+	amzDate := now.UTC().Format(amzDateFormat)
+	date := amzDate[:8]
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", emptyPayloadHash)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for k, v := range req.Header {
+		headers[strings.ToLower(k)] = strings.TrimSpace(strings.Join(v, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for k := range headers {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, k := range names {
+		canonicalHeaders.WriteString(k + ":" + headers[k] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		emptyPayloadHash,
+	}, "\n")
+	scope := date + "/" + region + "/s3/aws4_request"
+	digest := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(digest[:])
+
+	key := hmacSHA256([]byte("AWS4"+secretKey), date)
+	for _, part := range []string{region, "s3", "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s", accessKeyID, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+// canonicalQuery encodes the query sorted by key, escaped as AWS signatures require.
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var parts []string
+	for _, k := range keys {
+		values := append([]string(nil), query[k]...)
+		sort.Strings(values)
+		for _, v := range values {
+			parts = append(parts, awsEscape(k, false)+"="+awsEscape(v, false))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// awsEscape percent-encodes every byte of s but the unreserved characters, and slashes if
+// keepSlash is set, as AWS signatures require.
+func awsEscape(s string, keepSlash bool) string {
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9', c == '-', c == '_', c == '.', c == '~':
+			sb.WriteByte(c)
+		case c == '/' && keepSlash:
+			sb.WriteByte(c)
+		default:
+			fmt.Fprintf(&sb, "%%%02X", c)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,235 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package auth authenticates the callers of the bootstrap RPCs with a chain of
+// pluggable authenticators, configured in the inventory options.
+//
+// Source subnet allow-lists and token headers are built in. Other
+// authenticators are added by registering a Factory, and are then configured
+// by name with their parameters:
+//
+//	auth.Register("relay", func(params map[string]string) (auth.Authenticator, error) {
+//		return newRelayAuthenticator(params["issuer"])
+//	})
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+	log "github.com/openconfig/bootz/server/logging"
+)
+
+// bootstrapService prefixes the full method names of the authenticated service.
+const bootstrapService = "/bootz.proto.Bootstrap/"
+
+// Authenticator decides whether the caller of a bootstrap RPC is allowed.
+type Authenticator interface {
+	// Authenticate returns an error if the caller of method is not allowed. Errors should
+	// carry codes.Unauthenticated or codes.PermissionDenied.
+	Authenticate(ctx context.Context, method string) error
+}
+
+// Factory returns an authenticator configured with params.
+type Factory func(params map[string]string) (Authenticator, error)
+
+var (
+	mu        sync.RWMutex
+	factories = map[string]Factory{}
+)
+
+// Register makes an authenticator available by name. Registering a name twice replaces the
+// previous authenticator.
+func Register(name string, f Factory) {
+	mu.Lock()
+	defer mu.Unlock()
+	factories[name] = f
+}
+
+// Chain is a list of authenticators a caller must all pass, in order.
+type Chain []Authenticator
+
+// New returns the chain of the configured authenticators.
+func New(cfgs []*epb.Authenticator) (Chain, error) {
+	var c Chain
+	for i, cfg := range cfgs {
+		a, err := newAuthenticator(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("authenticator %d: %v", i, err)
+		}
+		c = append(c, a)
+	}
+	return c, nil
+}
+
+// newAuthenticator returns the authenticator of cfg.
+func newAuthenticator(cfg *epb.Authenticator) (Authenticator, error) {
+	set := 0
+	for _, ok := range []bool{len(cfg.GetSourceSubnets()) > 0, cfg.GetTokenHeader() != nil, cfg.GetCustom() != ""} {
+		if ok {
+			set++
+		}
+	}
+	if set != 1 {
+		return nil, fmt.Errorf("exactly one of source_subnets, token_header and custom must be set")
+	}
+	switch {
+	case len(cfg.GetSourceSubnets()) > 0:
+		return SourceSubnets(cfg.GetSourceSubnets())
+	case cfg.GetTokenHeader() != nil:
+		th := cfg.GetTokenHeader()
+		data, err := os.ReadFile(th.GetTokenFile())
+		if err != nil {
+			return nil, fmt.Errorf("unable to read token file: %v", err)
+		}
+		var tokens []string
+		for _, line := range strings.Split(string(data), "\n") {
+			if t := strings.TrimSpace(line); t != "" {
+				tokens = append(tokens, t)
+			}
+		}
+		return TokenHeader(th.GetHeader(), tokens)
+	}
+	mu.RLock()
+	f, ok := factories[cfg.GetCustom()]
+	mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("no authenticator registered as %q", cfg.GetCustom())
+	}
+	return f(cfg.GetParams())
+}
+
+// Authenticate runs the authenticators of the chain, returning the first error.
+func (c Chain) Authenticate(ctx context.Context, method string) error {
+	for _, a := range c {
+		if err := a.Authenticate(ctx, method); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UnaryServerInterceptor rejects bootstrap requests failing the chain. Requests to other
+// services are not authenticated.
+func (c Chain) UnaryServerInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if !strings.HasPrefix(info.FullMethod, bootstrapService) {
+		return handler(ctx, req)
+	}
+	if err := c.Authenticate(ctx, info.FullMethod); err != nil {
+		log.Warningf("Rejected %v from %v: %v", info.FullMethod, clientIP(ctx), err)
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// StreamServerInterceptor rejects bootstrap streams failing the chain before they start.
+// Streams of other services are not authenticated.
+func (c Chain) StreamServerInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if !strings.HasPrefix(info.FullMethod, bootstrapService) {
+		return handler(srv, ss)
+	}
+	if err := c.Authenticate(ss.Context(), info.FullMethod); err != nil {
+		log.Warningf("Rejected %v from %v: %v", info.FullMethod, clientIP(ss.Context()), err)
+		return err
+	}
+	return handler(srv, ss)
+}
+
+// subnets allows callers connecting from a list of prefixes.
+type subnets []*net.IPNet
+
+// SourceSubnets returns an authenticator allowing callers connecting from one of the CIDR prefixes.
+func SourceSubnets(cidrs []string) (Authenticator, error) {
+	var s subnets
+	for _, c := range cidrs {
+		_, n, err := net.ParseCIDR(c)
+		if err != nil {
+			return nil, fmt.Errorf("invalid source subnet %q: %v", c, err)
+		}
+		s = append(s, n)
+	}
+	return s, nil
+}
+
+// Authenticate checks the address of the caller against the prefixes.
+func (s subnets) Authenticate(ctx context.Context, _ string) error {
+	ip := net.ParseIP(clientIP(ctx))
+	if ip == nil {
+		return status.Errorf(codes.PermissionDenied, "unknown source address")
+	}
+	for _, n := range s {
+		if n.Contains(ip) {
+			return nil
+		}
+	}
+	return status.Errorf(codes.PermissionDenied, "source address %v is not allowed", ip)
+}
+
+// tokenHeader allows callers presenting one of the tokens in a metadata header.
+type tokenHeader struct {
+	header string
+	tokens []string
+}
+
+// TokenHeader returns an authenticator allowing callers presenting one of the tokens in the
+// metadata header.
+func TokenHeader(header string, tokens []string) (Authenticator, error) {
+	if header == "" {
+		return nil, fmt.Errorf("token header has no name")
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("token header %q has no tokens", header)
+	}
+	return &tokenHeader{header: strings.ToLower(header), tokens: tokens}, nil
+}
+
+// Authenticate checks the token of the caller against the accepted ones.
+func (t *tokenHeader) Authenticate(ctx context.Context, _ string) error {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(t.header)
+	if len(values) == 0 {
+		return status.Errorf(codes.Unauthenticated, "missing %s header", t.header)
+	}
+	for _, want := range t.tokens {
+		if subtle.ConstantTimeCompare([]byte(values[0]), []byte(want)) == 1 {
+			return nil
+		}
+	}
+	return status.Errorf(codes.Unauthenticated, "invalid %s header", t.header)
+}
+
+// clientIP returns the IP address of the peer of the request.
+func clientIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
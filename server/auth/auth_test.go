@@ -0,0 +1,149 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+)
+
+// denyMethod rejects calls to a single method.
+type denyMethod string
+
+func (d denyMethod) Authenticate(_ context.Context, method string) error {
+	if method == string(d) {
+		return status.Errorf(codes.PermissionDenied, "%s is denied", method)
+	}
+	return nil
+}
+
+func callerContext(ip string, md ...string) context.Context {
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(ip), Port: 12345}})
+	return metadata.NewIncomingContext(ctx, metadata.Pairs(md...))
+}
+
+func TestChain(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "tokens")
+	if err := os.WriteFile(tokenFile, []byte("secret1\n\nsecret2\n"), 0600); err != nil {
+		t.Fatalf("unable to write token file: %v", err)
+	}
+	Register("deny_report_status", func(params map[string]string) (Authenticator, error) {
+		return denyMethod(params["method"]), nil
+	})
+	c, err := New([]*epb.Authenticator{{
+		SourceSubnets: []string{"10.0.0.0/8", "2001:db8::/32"},
+	}, {
+		TokenHeader: &epb.TokenHeader{Header: "X-Bootz-Token", TokenFile: tokenFile},
+	}, {
+		Custom: "deny_report_status",
+		Params: map[string]string{"method": "/bootz.proto.Bootstrap/ReportStatus"},
+	}})
+	if err != nil {
+		t.Fatalf("New() err = %v, want nil", err)
+	}
+
+	tests := []struct {
+		desc     string
+		ctx      context.Context
+		method   string
+		wantCode codes.Code
+	}{{
+		desc:     "Allowed caller",
+		ctx:      callerContext("10.1.2.3", "x-bootz-token", "secret2"),
+		method:   "/bootz.proto.Bootstrap/GetBootstrapData",
+		wantCode: codes.OK,
+	}, {
+		desc:     "Allowed IPv6 caller",
+		ctx:      callerContext("2001:db8::1", "x-bootz-token", "secret1"),
+		method:   "/bootz.proto.Bootstrap/GetBootstrapData",
+		wantCode: codes.OK,
+	}, {
+		desc:     "Source outside the subnets",
+		ctx:      callerContext("192.168.0.1", "x-bootz-token", "secret1"),
+		method:   "/bootz.proto.Bootstrap/GetBootstrapData",
+		wantCode: codes.PermissionDenied,
+	}, {
+		desc:     "Missing token",
+		ctx:      callerContext("10.1.2.3"),
+		method:   "/bootz.proto.Bootstrap/GetBootstrapData",
+		wantCode: codes.Unauthenticated,
+	}, {
+		desc:     "Invalid token",
+		ctx:      callerContext("10.1.2.3", "x-bootz-token", "secret3"),
+		method:   "/bootz.proto.Bootstrap/GetBootstrapData",
+		wantCode: codes.Unauthenticated,
+	}, {
+		desc:     "Denied by custom authenticator",
+		ctx:      callerContext("10.1.2.3", "x-bootz-token", "secret1"),
+		method:   "/bootz.proto.Bootstrap/ReportStatus",
+		wantCode: codes.PermissionDenied,
+	}, {
+		desc:     "Other service",
+		ctx:      callerContext("192.168.0.1"),
+		method:   "/admin.Admin/ListDevices",
+		wantCode: codes.OK,
+	}}
+	handler := func(context.Context, any) (any, error) {
+		return "ok", nil
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			_, err := c.UnaryServerInterceptor(test.ctx, nil, &grpc.UnaryServerInfo{FullMethod: test.method}, handler)
+			if got := status.Code(err); got != test.wantCode {
+				t.Errorf("UnaryServerInterceptor() code = %v, want %v, err: %v", got, test.wantCode, err)
+			}
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		desc string
+		cfg  *epb.Authenticator
+	}{{
+		desc: "Nothing set",
+		cfg:  &epb.Authenticator{},
+	}, {
+		desc: "Several set",
+		cfg:  &epb.Authenticator{SourceSubnets: []string{"10.0.0.0/8"}, Custom: "other"},
+	}, {
+		desc: "Invalid subnet",
+		cfg:  &epb.Authenticator{SourceSubnets: []string{"10.0.0.0"}},
+	}, {
+		desc: "Missing token file",
+		cfg:  &epb.Authenticator{TokenHeader: &epb.TokenHeader{Header: "x-bootz-token", TokenFile: "/does/not/exist"}},
+	}, {
+		desc: "Unregistered custom authenticator",
+		cfg:  &epb.Authenticator{Custom: "unregistered"},
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			if _, err := New([]*epb.Authenticator{test.cfg}); err == nil {
+				t.Errorf("New() err = nil, want error")
+			}
+		})
+	}
+}
@@ -0,0 +1,542 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	apb "github.com/openconfig/bootz/server/admin/proto/admin"
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+)
+
+// adminCommand registers the flags of a command calling the admin API on fs, and returns the
+// function running it once the flags are parsed.
+type adminCommand func(fs *flag.FlagSet) func(ctx context.Context, c apb.AdminClient, w io.Writer) error
+
+// run parses the flags of the command, connects to the admin API and runs the command.
+func (cmd adminCommand) run(name string, args []string, w io.Writer) error {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	server := fs.String("server", "", "The address of the admin API, the admin_port of the server.")
+	caCert := fs.String("ca_cert", "", "The path to a PEM encoded CA certificate to verify the server with. The certificate of the server is not verified if unset.")
+	cert := fs.String("cert", "", "The path to a PEM encoded client certificate, required if the server sets admin_client_ca.")
+	key := fs.String("key", "", "The path to the PEM encoded private key of cert.")
+	timeout := fs.Duration("timeout", 30*time.Second, "The deadline of the call to the admin API.")
+	run := cmd(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *server == "" {
+		return fmt.Errorf("--server must be set")
+	}
+	tlsConfig := &tls.Config{InsecureSkipVerify: *caCert == ""}
+	if *caCert != "" {
+		caPEM, err := os.ReadFile(*caCert)
+		if err != nil {
+			return fmt.Errorf("unable to read CA cert: %v", err)
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(caPEM) {
+			return fmt.Errorf("unable to parse CA cert %s", *caCert)
+		}
+	}
+	if *cert != "" || *key != "" {
+		c, err := tls.LoadX509KeyPair(*cert, *key)
+		if err != nil {
+			return fmt.Errorf("unable to load client certificate: %v", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{c}
+	}
+	conn, err := grpc.Dial(*server, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+	if err != nil {
+		return fmt.Errorf("unable to connect to %s: %v", *server, err)
+	}
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+	return run(ctx, apb.NewAdminClient(conn), w)
+}
+
+// devices lists the chassis of the inventory.
+func devices(fs *flag.FlagSet) func(context.Context, apb.AdminClient, io.Writer) error {
+	return func(ctx context.Context, c apb.AdminClient, w io.Writer) error {
+		resp, err := c.ListChassis(ctx, &apb.ListChassisRequest{})
+		if err != nil {
+			return err
+		}
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "MANUFACTURER\tSERIAL\tPART\tBOOT MODE\tTENANT\tCONTROL CARDS")
+		for _, ch := range resp.GetChassis() {
+			var cards []string
+			for _, cc := range ch.GetControllerCards() {
+				cards = append(cards, cc.GetSerialNumber())
+			}
+			mode := strings.TrimPrefix(ch.GetBootMode().String(), "BOOT_MODE_")
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", ch.GetManufacturer(), ch.GetSerialNumber(), ch.GetPartNumber(), mode, ch.GetTenant(), strings.Join(cards, ","))
+		}
+		return tw.Flush()
+	}
+}
+
+// deviceStatus shows the bootstrap state of devices.
+func deviceStatus(fs *flag.FlagSet) func(context.Context, apb.AdminClient, io.Writer) error {
+	serials := fs.String("serial", "", "A comma separated list of the serial numbers of the control cards or fixed chassis to show. Every device is shown if unset.")
+	silentFor := fs.Duration("silent_for", 0, "Show only the devices which were sent bootstrap data and have sent no request since, for at least this long, e.g. 4h.")
+	drifted := fs.Bool("drifted", false, "Show only the devices which reported to have applied an image or vendor config other than served.")
+	return func(ctx context.Context, c apb.AdminClient, w io.Writer) error {
+		req := &apb.ListDeviceStatesRequest{Drifted: *drifted}
+		if *serials != "" {
+			req.SerialNumbers = strings.Split(*serials, ",")
+		}
+		if *silentFor != 0 {
+			req.SilentFor = durationpb.New(*silentFor)
+		}
+		resp, err := c.ListDeviceStates(ctx, req)
+		if err != nil {
+			return err
+		}
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "SERIAL\tSTATE\tSTAGE\tRETRIES\tCHANGED\tREPORTED\tSEEN\tADDRESS\tDRIFT")
+		for _, d := range resp.GetDevices() {
+			reported := "-"
+			if d.GetReportedAt() != nil {
+				reported = d.GetReportedAt().AsTime().Format(time.RFC3339)
+			}
+			seen, addr := "-", "-"
+			if d.GetLastSeenAt() != nil {
+				seen = d.GetLastSeenAt().AsTime().Format(time.RFC3339)
+				addr = d.GetLastAddress()
+			}
+			state := strings.TrimPrefix(d.GetState().String(), "DEVICE_BOOTSTRAP_STATE_")
+			stage := "-"
+			if d.GetStage() != apb.DeviceConfigStage_DEVICE_CONFIG_STAGE_UNSPECIFIED {
+				stage = strings.TrimPrefix(d.GetStage().String(), "DEVICE_CONFIG_STAGE_")
+			}
+			drift := "-"
+			if len(d.GetDriftedArtifacts()) > 0 {
+				drift = strings.Join(d.GetDriftedArtifacts(), ",")
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%s\t%s\t%s\t%s\t%s\n", d.GetSerialNumber(), state, stage, d.GetRetries(), d.GetChangedAt().AsTime().Format(time.RFC3339), reported, seen, addr, drift)
+		}
+		return tw.Flush()
+	}
+}
+
+// modules shows the line cards and fabric modules reported by modular chassis, and those missing
+// from or unexpected by the inventory.
+func modules(fs *flag.FlagSet) func(context.Context, apb.AdminClient, io.Writer) error {
+	serials := fs.String("serial", "", "A comma separated list of the serial numbers of the chassis, or of their control cards, to show. Every chassis is shown if unset.")
+	return func(ctx context.Context, c apb.AdminClient, w io.Writer) error {
+		req := &apb.ListChassisModulesRequest{}
+		if *serials != "" {
+			req.SerialNumbers = strings.Split(*serials, ",")
+		}
+		resp, err := c.ListChassisModules(ctx, req)
+		if err != nil {
+			return err
+		}
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "MANUFACTURER\tSERIAL\tKIND\tSLOT\tMODULE\tINVENTORY")
+		for _, ch := range resp.GetChassis() {
+			for _, kind := range []struct {
+				name string
+				inv  *apb.ModuleInventory
+			}{{"line-card", ch.GetLineCards()}, {"fabric", ch.GetFabricModules()}} {
+				unexpected := map[string]bool{}
+				for _, m := range kind.inv.GetUnexpected() {
+					unexpected[m.GetSerialNumber()] = true
+				}
+				for _, m := range kind.inv.GetReported() {
+					inv := "ok"
+					if unexpected[m.GetSerialNumber()] {
+						inv = "unexpected"
+					}
+					fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%s\t%s\n", ch.GetManufacturer(), ch.GetSerialNumber(), kind.name, m.GetSlot(), m.GetSerialNumber(), inv)
+				}
+				for _, m := range kind.inv.GetMissing() {
+					fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%s\tmissing\n", ch.GetManufacturer(), ch.GetSerialNumber(), kind.name, m.GetSlot(), m.GetSerialNumber())
+				}
+			}
+		}
+		return tw.Flush()
+	}
+}
+
+// capabilities shows the capabilities reported by devices, summarized per platform.
+func capabilities(fs *flag.FlagSet) func(context.Context, apb.AdminClient, io.Writer) error {
+	return func(ctx context.Context, c apb.AdminClient, w io.Writer) error {
+		resp, err := c.ListPlatformCapabilities(ctx, &apb.ListPlatformCapabilitiesRequest{})
+		if err != nil {
+			return err
+		}
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "MANUFACTURER\tPART\tDEVICES\tFEATURES\tARTIFACT TYPES")
+		for _, p := range resp.GetPlatforms() {
+			fmt.Fprintf(tw, "%s\t%s\t%d\t%s\t%s\n", p.GetManufacturer(), p.GetPartNumber(), p.GetDevices(), deviceCounts(p.GetFeatures()), deviceCounts(p.GetArtifactTypes()))
+		}
+		return tw.Flush()
+	}
+}
+
+// deviceCounts formats the number of devices of each key, sorted by key.
+func deviceCounts(counts map[string]uint32) string {
+	if len(counts) == 0 {
+		return "-"
+	}
+	var out []string
+	for k, n := range counts {
+		out = append(out, fmt.Sprintf("%s=%d", k, n))
+	}
+	sort.Strings(out)
+	return strings.Join(out, ",")
+}
+
+// addChassis adds the chassis of a text encoded file to the inventory.
+func addChassis(fs *flag.FlagSet) func(context.Context, apb.AdminClient, io.Writer) error {
+	file := fs.String("file", "", "The path to a text encoded entity.Chassis, as found in the chassis of an inventory file.")
+	replace := fs.Bool("replace", false, "Whether to replace an existing chassis with the same manufacturer and serial number.")
+	return func(ctx context.Context, c apb.AdminClient, w io.Writer) error {
+		if *file == "" {
+			return fmt.Errorf("--file must be set")
+		}
+		data, err := os.ReadFile(*file)
+		if err != nil {
+			return fmt.Errorf("unable to read chassis: %v", err)
+		}
+		ch := &epb.Chassis{}
+		if err := prototext.Unmarshal(data, ch); err != nil {
+			return fmt.Errorf("unable to parse chassis %s: %v", *file, err)
+		}
+		if _, err := c.AddChassis(ctx, &apb.AddChassisRequest{Chassis: ch, Replace: *replace}); err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "Added chassis %s of %s\n", ch.GetSerialNumber(), ch.GetManufacturer())
+		return nil
+	}
+}
+
+// readOV returns the ownership voucher of the file, DER or base64 encoded.
+func readOV(file string) ([]byte, error) {
+	ov, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read ownership voucher: %v", err)
+	}
+	// Inventory files carry base64 encoded vouchers, which are accepted as is.
+	if decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(ov))); err == nil {
+		ov = decoded
+	}
+	return ov, nil
+}
+
+// uploadOV uploads an ownership voucher, or the rotated ownership voucher if rotated is set.
+func uploadOV(rotated bool) adminCommand {
+	return func(fs *flag.FlagSet) func(context.Context, apb.AdminClient, io.Writer) error {
+		serial := fs.String("serial", "", "The serial number of the control card or fixed chassis.")
+		file := fs.String("file", "", "The path to the ownership voucher, DER or base64 encoded.")
+		return func(ctx context.Context, c apb.AdminClient, w io.Writer) error {
+			if *serial == "" || *file == "" {
+				return fmt.Errorf("--serial and --file must be set")
+			}
+			ov, err := readOV(*file)
+			if err != nil {
+				return err
+			}
+			_, err = c.UploadOwnershipVoucher(ctx, &apb.UploadOwnershipVoucherRequest{
+				SerialNumber:     *serial,
+				OwnershipVoucher: ov,
+				Rotated:          rotated,
+			})
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(w, "Uploaded ownership voucher of %s\n", *serial)
+			return nil
+		}
+	}
+}
+
+// uploadBundle uploads a bundle of ownership vouchers and reports the vouchers which could not
+// be associated with a device.
+func uploadBundle(fs *flag.FlagSet) func(context.Context, apb.AdminClient, io.Writer) error {
+	file := fs.String("file", "", "The path to a zip archive with a voucher per file, or a CSV file with the serial number and base64 encoded voucher of a device per row.")
+	rotated := fs.Bool("rotated", false, "Whether the vouchers pin the new PDC of an ownership rotation.")
+	return func(ctx context.Context, c apb.AdminClient, w io.Writer) error {
+		if *file == "" {
+			return fmt.Errorf("--file must be set")
+		}
+		bundle, err := os.ReadFile(*file)
+		if err != nil {
+			return fmt.Errorf("unable to read bundle: %v", err)
+		}
+		resp, err := c.UploadOwnershipVoucherBundle(ctx, &apb.UploadOwnershipVoucherBundleRequest{Bundle: bundle, Rotated: *rotated})
+		if err != nil {
+			return err
+		}
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "SOURCE\tSERIAL\tRESULT")
+		failed := 0
+		for _, r := range resp.GetResults() {
+			result := "associated"
+			if r.GetError() != "" {
+				result = r.GetError()
+				failed++
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\n", r.GetSource(), r.GetSerialNumber(), result)
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+		if failed > 0 {
+			return fmt.Errorf("%d of %d vouchers were not associated", failed, len(resp.GetResults()))
+		}
+		return nil
+	}
+}
+
+// rebootstrap resets the bootstrap state of a device, and optionally reboots it.
+func rebootstrap(fs *flag.FlagSet) func(context.Context, apb.AdminClient, io.Writer) error {
+	serial := fs.String("serial", "", "The serial number of the control card or fixed chassis.")
+	reboot := fs.Bool("reboot", false, "Whether to reboot the device over gNOI, so it bootstraps again right away. The server must set reboot_gnoi_port.")
+	reason := fs.String("reason", "", "The message of the reboot request, e.g. the RMA ticket.")
+	return func(ctx context.Context, c apb.AdminClient, w io.Writer) error {
+		if *serial == "" {
+			return fmt.Errorf("--serial must be set")
+		}
+		resp, err := c.TriggerRebootstrap(ctx, &apb.TriggerRebootstrapRequest{SerialNumber: *serial, Reboot: *reboot, Reason: *reason})
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "Reset bootstrap state of %s\n", *serial)
+		if resp.GetRebooted() {
+			fmt.Fprintf(w, "Rebooted %s at %s\n", *serial, resp.GetAddress())
+		}
+		return nil
+	}
+}
+
+// retire retires a device from the inventory, and optionally puts its replacement in its place.
+func retire(fs *flag.FlagSet) func(context.Context, apb.AdminClient, io.Writer) error {
+	serial := fs.String("serial", "", "The serial number of the control card or fixed chassis to retire.")
+	reason := fs.String("reason", "", "Why the device is retired, e.g. the RMA ticket.")
+	replacement := fs.String("replacement", "", "The serial number of the device taking its place. Read from the ownership voucher if unset and --ov is set.")
+	part := fs.String("part", "", "The part number of the replacement, if it differs from the retired device.")
+	ovFile := fs.String("ov", "", "The path to the ownership voucher of the replacement, DER or base64 encoded.")
+	return func(ctx context.Context, c apb.AdminClient, w io.Writer) error {
+		if *serial == "" {
+			return fmt.Errorf("--serial must be set")
+		}
+		req := &apb.RetireDeviceRequest{SerialNumber: *serial, Reason: *reason}
+		if *replacement != "" || *part != "" || *ovFile != "" {
+			req.Replacement = &apb.ReplacementDevice{SerialNumber: *replacement, PartNumber: *part}
+		}
+		if *ovFile != "" {
+			ov, err := readOV(*ovFile)
+			if err != nil {
+				return err
+			}
+			req.Replacement.OwnershipVoucher = ov
+		}
+		resp, err := c.RetireDevice(ctx, req)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "Retired %s\n", *serial)
+		if r := resp.GetRetired().GetReplacedBy(); r != "" {
+			fmt.Fprintf(w, "Replaced %s by %s\n", *serial, r)
+		}
+		return nil
+	}
+}
+
+// retired lists the devices retired from the inventory.
+func retired(fs *flag.FlagSet) func(context.Context, apb.AdminClient, io.Writer) error {
+	return func(ctx context.Context, c apb.AdminClient, w io.Writer) error {
+		resp, err := c.ListRetiredDevices(ctx, &apb.ListRetiredDevicesRequest{})
+		if err != nil {
+			return err
+		}
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "SERIAL\tCHASSIS\tREPLACED BY\tRETIRED AT\tLAST STATE\tREASON")
+		for _, d := range resp.GetDevices() {
+			replacedBy, state := "-", "-"
+			if d.GetReplacedBy() != "" {
+				replacedBy = d.GetReplacedBy()
+			}
+			if d.GetState() != nil {
+				state = strings.TrimPrefix(d.GetState().GetState().String(), "DEVICE_BOOTSTRAP_STATE_")
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", d.GetSerialNumber(), d.GetChassis().GetSerialNumber(), replacedBy, d.GetRetiredAt().AsTime().Format(time.RFC3339), state, d.GetReason())
+		}
+		return tw.Flush()
+	}
+}
+
+// issueToken issues a one-time bootstrap token for a device.
+func issueToken(fs *flag.FlagSet) func(context.Context, apb.AdminClient, io.Writer) error {
+	serial := fs.String("serial", "", "The serial number of the control card or fixed chassis.")
+	ttl := fs.Duration("ttl", 0, "How long the token is valid. The server default of 7 days applies if 0.")
+	return func(ctx context.Context, c apb.AdminClient, w io.Writer) error {
+		if *serial == "" {
+			return fmt.Errorf("--serial must be set")
+		}
+		req := &apb.IssueBootstrapTokenRequest{SerialNumber: *serial}
+		if *ttl != 0 {
+			req.Ttl = durationpb.New(*ttl)
+		}
+		resp, err := c.IssueBootstrapToken(ctx, req)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "Bootstrap token of %s, valid until %s:\n%s\n", *serial, resp.GetExpiresAt().AsTime().Format(time.RFC3339), resp.GetToken())
+		return nil
+	}
+}
+
+// pending lists the unknown devices held in quarantine pending approval.
+func pending(fs *flag.FlagSet) func(context.Context, apb.AdminClient, io.Writer) error {
+	return func(ctx context.Context, c apb.AdminClient, w io.Writer) error {
+		resp, err := c.ListPendingDevices(ctx, &apb.ListPendingDevicesRequest{})
+		if err != nil {
+			return err
+		}
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "MANUFACTURER\tSERIAL\tPART\tCONTROL CARDS\tADDRESS\tREQUESTS\tFIRST SEEN\tLAST SEEN")
+		for _, d := range resp.GetDevices() {
+			desc := d.GetChassisDescriptor()
+			var cards []string
+			for _, cc := range desc.GetControlCards() {
+				cards = append(cards, cc.GetSerialNumber())
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%d\t%s\t%s\n", desc.GetManufacturer(), desc.GetSerialNumber(), desc.GetPartNumber(), strings.Join(cards, ","), d.GetSourceAddress(), d.GetRequests(), d.GetFirstSeen().AsTime().Format(time.RFC3339), d.GetLastSeen().AsTime().Format(time.RFC3339))
+		}
+		return tw.Flush()
+	}
+}
+
+// approve adds a device held in quarantine to the inventory.
+func approve(fs *flag.FlagSet) func(context.Context, apb.AdminClient, io.Writer) error {
+	manufacturer := fs.String("manufacturer", "", "The manufacturer of the pending device.")
+	serial := fs.String("serial", "", "The serial number of the pending device, as listed by the pending command.")
+	file := fs.String("file", "", "The path to a text encoded entity.Chassis the device is added with, e.g. its boot mode and config. Its manufacturer and serial number are ignored.")
+	return func(ctx context.Context, c apb.AdminClient, w io.Writer) error {
+		if *manufacturer == "" || *serial == "" {
+			return fmt.Errorf("--manufacturer and --serial must be set")
+		}
+		req := &apb.ApproveDeviceRequest{Manufacturer: *manufacturer, SerialNumber: *serial}
+		if *file != "" {
+			data, err := os.ReadFile(*file)
+			if err != nil {
+				return fmt.Errorf("unable to read chassis: %v", err)
+			}
+			req.Profile = &epb.Chassis{}
+			if err := prototext.Unmarshal(data, req.Profile); err != nil {
+				return fmt.Errorf("unable to parse chassis %s: %v", *file, err)
+			}
+		}
+		if _, err := c.ApproveDevice(ctx, req); err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "Approved chassis %s of %s\n", *serial, *manufacturer)
+		return nil
+	}
+}
+
+// reject removes a device from the quarantine without approving it.
+func reject(fs *flag.FlagSet) func(context.Context, apb.AdminClient, io.Writer) error {
+	manufacturer := fs.String("manufacturer", "", "The manufacturer of the pending device.")
+	serial := fs.String("serial", "", "The serial number of the pending device, as listed by the pending command.")
+	return func(ctx context.Context, c apb.AdminClient, w io.Writer) error {
+		if *manufacturer == "" || *serial == "" {
+			return fmt.Errorf("--manufacturer and --serial must be set")
+		}
+		if _, err := c.RejectDevice(ctx, &apb.RejectDeviceRequest{Manufacturer: *manufacturer, SerialNumber: *serial}); err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "Rejected chassis %s of %s\n", *serial, *manufacturer)
+		return nil
+	}
+}
+
+// logLevel shows the log levels of the server, or sets the level of a module.
+func logLevel(fs *flag.FlagSet) func(context.Context, apb.AdminClient, io.Writer) error {
+	module := fs.String("module", "", "The module to set the level of, e.g. entitymanager. The default level is set if unset.")
+	level := fs.String("level", "", "The level to set, out of debug, info, warn, error and vN. The levels are shown if neither --level nor --clear is set.")
+	clear := fs.Bool("clear", false, "Whether to clear the level of the module, so that it logs at the default level.")
+	return func(ctx context.Context, c apb.AdminClient, w io.Writer) error {
+		if *clear {
+			if *module == "" || *level != "" {
+				return fmt.Errorf("--clear requires --module and no --level")
+			}
+			if _, err := c.SetLogLevel(ctx, &apb.SetLogLevelRequest{Module: *module}); err != nil {
+				return err
+			}
+			fmt.Fprintf(w, "Cleared the log level of %s\n", *module)
+			return nil
+		}
+		if *level != "" {
+			if _, err := c.SetLogLevel(ctx, &apb.SetLogLevelRequest{Module: *module, Level: *level}); err != nil {
+				return err
+			}
+			if *module == "" {
+				fmt.Fprintf(w, "Set the default log level to %s\n", *level)
+			} else {
+				fmt.Fprintf(w, "Set the log level of %s to %s\n", *module, *level)
+			}
+			return nil
+		}
+		resp, err := c.GetLogLevels(ctx, &apb.GetLogLevelsRequest{})
+		if err != nil {
+			return err
+		}
+		modules := map[string]bool{}
+		for _, m := range resp.GetModules() {
+			modules[m] = true
+		}
+		for m := range resp.GetLevels() {
+			modules[m] = true
+		}
+		var names []string
+		for m := range modules {
+			names = append(names, m)
+		}
+		sort.Strings(names)
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "MODULE\tLEVEL")
+		fmt.Fprintf(tw, "(default)\t%s\n", resp.GetDefaultLevel())
+		for _, m := range names {
+			lvl, ok := resp.GetLevels()[m]
+			if !ok {
+				lvl = resp.GetDefaultLevel() + " (default)"
+			}
+			fmt.Fprintf(tw, "%s\t%s\n", m, lvl)
+		}
+		return tw.Flush()
+	}
+}
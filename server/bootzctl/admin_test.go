@@ -0,0 +1,370 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/h-fam/errdiff"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	bpb "github.com/openconfig/bootz/proto/bootz"
+	apb "github.com/openconfig/bootz/server/admin/proto/admin"
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+)
+
+// fakeAdmin records the requests of the admin RPCs called by the commands.
+type fakeAdmin struct {
+	apb.AdminClient
+	requests []proto.Message
+}
+
+func (f *fakeAdmin) ListChassis(_ context.Context, req *apb.ListChassisRequest, _ ...grpc.CallOption) (*apb.ListChassisResponse, error) {
+	f.requests = append(f.requests, req)
+	return &apb.ListChassisResponse{Chassis: []*epb.Chassis{{
+		Manufacturer:    "Cisco",
+		SerialNumber:    "123",
+		BootMode:        bpb.BootMode_BOOT_MODE_SECURE,
+		ControllerCards: []*epb.ControlCard{{SerialNumber: "123A"}, {SerialNumber: "123B"}},
+	}}}, nil
+}
+
+func (f *fakeAdmin) ListDeviceStates(_ context.Context, req *apb.ListDeviceStatesRequest, _ ...grpc.CallOption) (*apb.ListDeviceStatesResponse, error) {
+	f.requests = append(f.requests, req)
+	return &apb.ListDeviceStatesResponse{Devices: []*apb.DeviceState{{
+		SerialNumber:     "123A",
+		State:            apb.DeviceBootstrapState_DEVICE_BOOTSTRAP_STATE_STATUS_OK,
+		ChangedAt:        timestamppb.Now(),
+		LastSeenAt:       timestamppb.Now(),
+		LastAddress:      "10.0.0.1",
+		DriftedArtifacts: []string{"image", "config"},
+	}}}, nil
+}
+
+func (f *fakeAdmin) ListChassisModules(_ context.Context, req *apb.ListChassisModulesRequest, _ ...grpc.CallOption) (*apb.ListChassisModulesResponse, error) {
+	f.requests = append(f.requests, req)
+	return &apb.ListChassisModulesResponse{Chassis: []*apb.ChassisModules{{
+		Manufacturer: "Cisco",
+		SerialNumber: "123",
+		LineCards: &apb.ModuleInventory{
+			Reported: []*bpb.Module{{SerialNumber: "LC1", Slot: 1}},
+			Missing:  []*bpb.Module{{SerialNumber: "LC2", Slot: 2}},
+		},
+	}}}, nil
+}
+
+func (f *fakeAdmin) ListPlatformCapabilities(_ context.Context, req *apb.ListPlatformCapabilitiesRequest, _ ...grpc.CallOption) (*apb.ListPlatformCapabilitiesResponse, error) {
+	f.requests = append(f.requests, req)
+	return &apb.ListPlatformCapabilitiesResponse{Platforms: []*apb.PlatformCapabilities{{
+		Manufacturer:  "Cisco",
+		PartNumber:    "8808",
+		Devices:       2,
+		Features:      map[string]uint32{"ownership-voucher": 2},
+		ArtifactTypes: map[string]uint32{"ARTIFACT_TYPE_OC_CONFIG": 1},
+	}}}, nil
+}
+
+func (f *fakeAdmin) AddChassis(_ context.Context, req *apb.AddChassisRequest, _ ...grpc.CallOption) (*apb.AddChassisResponse, error) {
+	f.requests = append(f.requests, req)
+	return &apb.AddChassisResponse{}, nil
+}
+
+func (f *fakeAdmin) UploadOwnershipVoucher(_ context.Context, req *apb.UploadOwnershipVoucherRequest, _ ...grpc.CallOption) (*apb.UploadOwnershipVoucherResponse, error) {
+	f.requests = append(f.requests, req)
+	return &apb.UploadOwnershipVoucherResponse{}, nil
+}
+
+func (f *fakeAdmin) UploadOwnershipVoucherBundle(_ context.Context, req *apb.UploadOwnershipVoucherBundleRequest, _ ...grpc.CallOption) (*apb.UploadOwnershipVoucherBundleResponse, error) {
+	f.requests = append(f.requests, req)
+	resp := &apb.UploadOwnershipVoucherBundleResponse{Results: []*apb.OwnershipVoucherUploadResult{{Source: "row 1", SerialNumber: "123A"}}}
+	if req.GetRotated() {
+		resp.Results = append(resp.Results, &apb.OwnershipVoucherUploadResult{Source: "row 2", SerialNumber: "456A", Error: "not found"})
+	}
+	return resp, nil
+}
+
+func (f *fakeAdmin) TriggerRebootstrap(_ context.Context, req *apb.TriggerRebootstrapRequest, _ ...grpc.CallOption) (*apb.TriggerRebootstrapResponse, error) {
+	f.requests = append(f.requests, req)
+	if !req.GetReboot() {
+		return &apb.TriggerRebootstrapResponse{}, nil
+	}
+	return &apb.TriggerRebootstrapResponse{Rebooted: true, Address: "10.0.0.1"}, nil
+}
+
+func (f *fakeAdmin) RetireDevice(_ context.Context, req *apb.RetireDeviceRequest, _ ...grpc.CallOption) (*apb.RetireDeviceResponse, error) {
+	f.requests = append(f.requests, req)
+	replacedBy := req.GetReplacement().GetSerialNumber()
+	if replacedBy == "" && len(req.GetReplacement().GetOwnershipVoucher()) > 0 {
+		replacedBy = "123C"
+	}
+	return &apb.RetireDeviceResponse{Retired: &apb.RetiredDevice{SerialNumber: req.GetSerialNumber(), ReplacedBy: replacedBy}}, nil
+}
+
+func (f *fakeAdmin) ListRetiredDevices(_ context.Context, req *apb.ListRetiredDevicesRequest, _ ...grpc.CallOption) (*apb.ListRetiredDevicesResponse, error) {
+	f.requests = append(f.requests, req)
+	return &apb.ListRetiredDevicesResponse{Devices: []*apb.RetiredDevice{{
+		SerialNumber: "123A",
+		Reason:       "RMA-1",
+		RetiredAt:    timestamppb.Now(),
+		ReplacedBy:   "123C",
+		Chassis:      &epb.Chassis{SerialNumber: "123"},
+		State:        &apb.DeviceState{State: apb.DeviceBootstrapState_DEVICE_BOOTSTRAP_STATE_STATUS_OK},
+	}}}, nil
+}
+
+func (f *fakeAdmin) IssueBootstrapToken(_ context.Context, req *apb.IssueBootstrapTokenRequest, _ ...grpc.CallOption) (*apb.IssueBootstrapTokenResponse, error) {
+	f.requests = append(f.requests, req)
+	return &apb.IssueBootstrapTokenResponse{Token: "ABCDEFGH", ExpiresAt: timestamppb.Now()}, nil
+}
+
+func (f *fakeAdmin) ListPendingDevices(_ context.Context, req *apb.ListPendingDevicesRequest, _ ...grpc.CallOption) (*apb.ListPendingDevicesResponse, error) {
+	f.requests = append(f.requests, req)
+	return &apb.ListPendingDevicesResponse{Devices: []*apb.PendingDevice{{
+		ChassisDescriptor: &bpb.ChassisDescriptor{Manufacturer: "Cisco", SerialNumber: "789", PartNumber: "8808"},
+		SourceAddress:     "192.0.2.1",
+		FirstSeen:         timestamppb.Now(),
+		LastSeen:          timestamppb.Now(),
+		Requests:          3,
+	}}}, nil
+}
+
+func (f *fakeAdmin) ApproveDevice(_ context.Context, req *apb.ApproveDeviceRequest, _ ...grpc.CallOption) (*apb.ApproveDeviceResponse, error) {
+	f.requests = append(f.requests, req)
+	return &apb.ApproveDeviceResponse{}, nil
+}
+
+func (f *fakeAdmin) RejectDevice(_ context.Context, req *apb.RejectDeviceRequest, _ ...grpc.CallOption) (*apb.RejectDeviceResponse, error) {
+	f.requests = append(f.requests, req)
+	return &apb.RejectDeviceResponse{}, nil
+}
+
+func (f *fakeAdmin) GetLogLevels(_ context.Context, req *apb.GetLogLevelsRequest, _ ...grpc.CallOption) (*apb.GetLogLevelsResponse, error) {
+	f.requests = append(f.requests, req)
+	return &apb.GetLogLevelsResponse{
+		DefaultLevel: "info",
+		Levels:       map[string]string{"service": "debug"},
+		Modules:      []string{"entitymanager", "service"},
+	}, nil
+}
+
+func (f *fakeAdmin) SetLogLevel(_ context.Context, req *apb.SetLogLevelRequest, _ ...grpc.CallOption) (*apb.SetLogLevelResponse, error) {
+	f.requests = append(f.requests, req)
+	return &apb.SetLogLevelResponse{}, nil
+}
+
+func TestAdminCommands(t *testing.T) {
+	dir := t.TempDir()
+	chassisFile := filepath.Join(dir, "chassis.prototxt")
+	if err := os.WriteFile(chassisFile, []byte(`serial_number: "456" manufacturer: "Cisco"`), 0600); err != nil {
+		t.Fatalf("unable to write chassis: %v", err)
+	}
+	ovFile := filepath.Join(dir, "ov.txt")
+	if err := os.WriteFile(ovFile, []byte("b3Y=\n"), 0600); err != nil {
+		t.Fatalf("unable to write ownership voucher: %v", err)
+	}
+
+	tests := []struct {
+		desc        string
+		cmd         adminCommand
+		args        []string
+		wantRequest proto.Message
+		wantOutput  []string
+		wantErr     string
+	}{{
+		desc:        "List chassis",
+		cmd:         devices,
+		wantRequest: &apb.ListChassisRequest{},
+		wantOutput:  []string{"Cisco", "123", "SECURE", "123A,123B"},
+	}, {
+		desc:        "Show status",
+		cmd:         deviceStatus,
+		args:        []string{"--serial", "123A,123B"},
+		wantRequest: &apb.ListDeviceStatesRequest{SerialNumbers: []string{"123A", "123B"}},
+		wantOutput:  []string{"123A", "STATUS_OK", "10.0.0.1"},
+	}, {
+		desc:        "Show silent devices",
+		cmd:         deviceStatus,
+		args:        []string{"--silent_for", "4h"},
+		wantRequest: &apb.ListDeviceStatesRequest{SilentFor: durationpb.New(4 * time.Hour)},
+		wantOutput:  []string{"123A"},
+	}, {
+		desc:        "Show drifted devices",
+		cmd:         deviceStatus,
+		args:        []string{"--drifted"},
+		wantRequest: &apb.ListDeviceStatesRequest{Drifted: true},
+		wantOutput:  []string{"123A", "image,config"},
+	}, {
+		desc:        "Show modules",
+		cmd:         modules,
+		args:        []string{"--serial", "123"},
+		wantRequest: &apb.ListChassisModulesRequest{SerialNumbers: []string{"123"}},
+		wantOutput:  []string{"LC1", "LC2", "missing"},
+	}, {
+		desc:        "Show capabilities",
+		cmd:         capabilities,
+		wantRequest: &apb.ListPlatformCapabilitiesRequest{},
+		wantOutput:  []string{"8808", "ownership-voucher=2", "ARTIFACT_TYPE_OC_CONFIG=1"},
+	}, {
+		desc:        "Add chassis",
+		cmd:         addChassis,
+		args:        []string{"--file", chassisFile, "--replace"},
+		wantRequest: &apb.AddChassisRequest{Chassis: &epb.Chassis{SerialNumber: "456", Manufacturer: "Cisco"}, Replace: true},
+	}, {
+		desc:    "Add chassis without file",
+		cmd:     addChassis,
+		wantErr: "--file must be set",
+	}, {
+		desc:        "Upload ownership voucher",
+		cmd:         uploadOV(false),
+		args:        []string{"--serial", "123A", "--file", ovFile},
+		wantRequest: &apb.UploadOwnershipVoucherRequest{SerialNumber: "123A", OwnershipVoucher: []byte("ov")},
+	}, {
+		desc:        "Upload rotated ownership voucher",
+		cmd:         uploadOV(true),
+		args:        []string{"--serial", "123A", "--file", ovFile},
+		wantRequest: &apb.UploadOwnershipVoucherRequest{SerialNumber: "123A", OwnershipVoucher: []byte("ov"), Rotated: true},
+	}, {
+		desc:        "Upload voucher bundle",
+		cmd:         uploadBundle,
+		args:        []string{"--file", ovFile},
+		wantRequest: &apb.UploadOwnershipVoucherBundleRequest{Bundle: []byte("b3Y=\n")},
+		wantOutput:  []string{"row 1", "123A", "associated"},
+	}, {
+		desc:        "Upload voucher bundle with failures",
+		cmd:         uploadBundle,
+		args:        []string{"--file", ovFile, "--rotated"},
+		wantRequest: &apb.UploadOwnershipVoucherBundleRequest{Bundle: []byte("b3Y=\n"), Rotated: true},
+		wantOutput:  []string{"456A", "not found"},
+		wantErr:     "1 of 2 vouchers were not associated",
+	}, {
+		desc:        "Rebootstrap",
+		cmd:         rebootstrap,
+		args:        []string{"--serial", "123A"},
+		wantRequest: &apb.TriggerRebootstrapRequest{SerialNumber: "123A"},
+		wantOutput:  []string{"Reset bootstrap state of 123A"},
+	}, {
+		desc:        "Rebootstrap with reboot",
+		cmd:         rebootstrap,
+		args:        []string{"--serial", "123A", "--reboot", "--reason", "RMA-1"},
+		wantRequest: &apb.TriggerRebootstrapRequest{SerialNumber: "123A", Reboot: true, Reason: "RMA-1"},
+		wantOutput:  []string{"Rebooted 123A at 10.0.0.1"},
+	}, {
+		desc:    "Rebootstrap without serial",
+		cmd:     rebootstrap,
+		wantErr: "--serial must be set",
+	}, {
+		desc:        "Retire device",
+		cmd:         retire,
+		args:        []string{"--serial", "123A", "--reason", "RMA-1"},
+		wantRequest: &apb.RetireDeviceRequest{SerialNumber: "123A", Reason: "RMA-1"},
+		wantOutput:  []string{"Retired 123A"},
+	}, {
+		desc:        "Retire device with replacement",
+		cmd:         retire,
+		args:        []string{"--serial", "123A", "--ov", ovFile, "--part", "P2"},
+		wantRequest: &apb.RetireDeviceRequest{SerialNumber: "123A", Replacement: &apb.ReplacementDevice{PartNumber: "P2", OwnershipVoucher: []byte("ov")}},
+		wantOutput:  []string{"Replaced 123A by 123C"},
+	}, {
+		desc:    "Retire without serial",
+		cmd:     retire,
+		wantErr: "--serial must be set",
+	}, {
+		desc:        "List retired devices",
+		cmd:         retired,
+		wantRequest: &apb.ListRetiredDevicesRequest{},
+		wantOutput:  []string{"123A", "123C", "STATUS_OK", "RMA-1"},
+	}, {
+		desc:        "Issue token",
+		cmd:         issueToken,
+		args:        []string{"--serial", "123A", "--ttl", "1h"},
+		wantRequest: &apb.IssueBootstrapTokenRequest{SerialNumber: "123A", Ttl: durationpb.New(time.Hour)},
+		wantOutput:  []string{"ABCDEFGH"},
+	}, {
+		desc:        "List pending devices",
+		cmd:         pending,
+		wantRequest: &apb.ListPendingDevicesRequest{},
+		wantOutput:  []string{"Cisco", "789", "8808", "192.0.2.1"},
+	}, {
+		desc:        "Approve device",
+		cmd:         approve,
+		args:        []string{"--manufacturer", "Cisco", "--serial", "789", "--file", chassisFile},
+		wantRequest: &apb.ApproveDeviceRequest{Manufacturer: "Cisco", SerialNumber: "789", Profile: &epb.Chassis{SerialNumber: "456", Manufacturer: "Cisco"}},
+	}, {
+		desc:    "Approve device without serial",
+		cmd:     approve,
+		args:    []string{"--manufacturer", "Cisco"},
+		wantErr: "--manufacturer and --serial must be set",
+	}, {
+		desc:        "Reject device",
+		cmd:         reject,
+		args:        []string{"--manufacturer", "Cisco", "--serial", "789"},
+		wantRequest: &apb.RejectDeviceRequest{Manufacturer: "Cisco", SerialNumber: "789"},
+	}, {
+		desc:        "Show log levels",
+		cmd:         logLevel,
+		wantRequest: &apb.GetLogLevelsRequest{},
+		wantOutput:  []string{"entitymanager", "info (default)", "debug"},
+	}, {
+		desc:        "Set log level",
+		cmd:         logLevel,
+		args:        []string{"--module", "service", "--level", "v2"},
+		wantRequest: &apb.SetLogLevelRequest{Module: "service", Level: "v2"},
+	}, {
+		desc:        "Clear log level",
+		cmd:         logLevel,
+		args:        []string{"--module", "service", "--clear"},
+		wantRequest: &apb.SetLogLevelRequest{Module: "service"},
+	}, {
+		desc:    "Clear log level without module",
+		cmd:     logLevel,
+		args:    []string{"--clear"},
+		wantErr: "--clear requires --module",
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			fs := flag.NewFlagSet(test.desc, flag.ContinueOnError)
+			run := test.cmd(fs)
+			if err := fs.Parse(test.args); err != nil {
+				t.Fatalf("Parse() err = %v, want nil", err)
+			}
+			admin := &fakeAdmin{}
+			var out bytes.Buffer
+			err := run(context.Background(), admin, &out)
+			if s := errdiff.Substring(err, test.wantErr); s != "" {
+				t.Fatalf("run() %s", s)
+			}
+			if test.wantRequest != nil {
+				if len(admin.requests) != 1 || !proto.Equal(admin.requests[0], test.wantRequest) {
+					t.Errorf("run() sent %v, want %v", admin.requests, test.wantRequest)
+				}
+			}
+			for _, w := range test.wantOutput {
+				if !strings.Contains(out.String(), w) {
+					t.Errorf("run() output does not contain %q:\n%s", w, out.String())
+				}
+			}
+		})
+	}
+}
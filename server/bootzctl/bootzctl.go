@@ -0,0 +1,223 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// bootzctl is a command line tool for the operators of a bootz server.
+//
+// The preview command renders the bootstrap data the server would return to a
+// device, as configured in an inventory file, without contacting the device
+// or the server:
+//
+//	bootzctl preview --inv_config inventory.prototxt --serial 123
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/openconfig/bootz/server/entitymanager"
+	"github.com/openconfig/bootz/server/service"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+
+	bpb "github.com/openconfig/bootz/proto/bootz"
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+)
+
+const usage = `Usage: bootzctl <command> [flags]
+
+Commands:
+  preview       Render the bootstrap data served to a device.
+  devices       List the chassis of the inventory.
+  status        Show the bootstrap state of devices.
+  modules       Show the line cards and fabric modules reported by modular chassis.
+  capabilities  Show the capabilities reported by devices, per platform.
+  add-chassis   Add a chassis to the inventory, or replace one.
+  upload-ov     Upload the ownership voucher of a control card or fixed chassis.
+  upload-ovs    Upload a bundle of ownership vouchers sent by a vendor.
+  rotate        Upload the ownership voucher pinning the new PDC of an ownership rotation.
+  rebootstrap   Reset the bootstrap state of a device, so it is served bootstrap data again.
+  retire        Retire a device, e.g. returned to its vendor, and optionally add its replacement.
+  retired       List the retired devices.
+  issue-token   Issue a one-time bootstrap token for a device.
+  pending       List the unknown devices held in quarantine.
+  approve       Add a device held in quarantine to the inventory.
+  reject        Remove a device from the quarantine without approving it.
+  log-level     Show the log levels of the server, or change the level of a module.
+
+Run "bootzctl <command> -help" for the flags of a command.
+`
+
+// commands maps the command names to their implementation.
+var commands = map[string]func(name string, args []string, w io.Writer) error{
+	"preview":      func(_ string, args []string, w io.Writer) error { return preview(args, w) },
+	"devices":      adminCommand(devices).run,
+	"status":       adminCommand(deviceStatus).run,
+	"modules":      adminCommand(modules).run,
+	"capabilities": adminCommand(capabilities).run,
+	"add-chassis":  adminCommand(addChassis).run,
+	"upload-ov":    adminCommand(uploadOV(false)).run,
+	"upload-ovs":   adminCommand(uploadBundle).run,
+	"rotate":       adminCommand(uploadOV(true)).run,
+	"rebootstrap":  adminCommand(rebootstrap).run,
+	"retire":       adminCommand(retire).run,
+	"retired":      adminCommand(retired).run,
+	"issue-token":  adminCommand(issueToken).run,
+	"pending":      adminCommand(pending).run,
+	"approve":      adminCommand(approve).run,
+	"reject":       adminCommand(reject).run,
+	"log-level":    adminCommand(logLevel).run,
+}
+
+func main() {
+	flag.Usage = func() { fmt.Fprint(flag.CommandLine.Output(), usage) }
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	name := flag.Arg(0)
+	cmd, ok := commands[name]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "bootzctl: unknown command %q\n%s", name, usage)
+		os.Exit(2)
+	}
+	if err := cmd(name, flag.Args()[1:], os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "bootzctl %s: %v\n", name, err)
+		os.Exit(1)
+	}
+}
+
+// preview writes the bootstrap response the server would return to a device, and the bootstrap
+// data it carries, to w.
+func preview(args []string, w io.Writer) error {
+	fs := flag.NewFlagSet("preview", flag.ContinueOnError)
+	invConfig := fs.String("inv_config", "", "The inventory file of the server.")
+	serial := fs.String("serial", "", "The serial number of the chassis, or of one of its control cards.")
+	manufacturer := fs.String("manufacturer", "", "The manufacturer of the chassis, if several chassis have the serial number.")
+	controlCard := fs.String("control_card", "", "The serial number of the control card making the request. Defaults to the first control card of the chassis.")
+	nonce := fs.String("nonce", "preview", "The nonce of the request. The response is signed unless the nonce is empty.")
+	generate := fs.Bool("generate_artifacts", false, "Whether to generate ephemeral security artifacts instead of reading them from the artifact_dir of the inventory.")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *invConfig == "" || *serial == "" {
+		return fmt.Errorf("--inv_config and --serial must be set")
+	}
+
+	newManager := entitymanager.New
+	if *generate {
+		newManager = entitymanager.NewWithGeneratedArtifacts
+	}
+	em, err := newManager(*invConfig)
+	if err != nil {
+		return fmt.Errorf("unable to load inventory: %v", err)
+	}
+	desc, err := chassisDescriptor(em.GetAll(), *manufacturer, *serial)
+	if err != nil {
+		return err
+	}
+	state := &bpb.ControlCardState{SerialNumber: *controlCard}
+	if state.SerialNumber == "" {
+		state.SerialNumber = desc.GetSerialNumber()
+		if len(desc.GetControlCards()) > 0 {
+			state.SerialNumber = desc.GetControlCards()[0].GetSerialNumber()
+		}
+	}
+
+	// The service is configured as the server configures it, except for the checks of the
+	// peer, which is not a device.
+	s := service.New(em)
+	if err := s.Features().Load(em.GetFeatureFlags()); err != nil {
+		return fmt.Errorf("unable to load feature flags: %v", err)
+	}
+	if err := s.SetRedirects(em.GetRedirects()); err != nil {
+		return fmt.Errorf("unable to load redirects: %v", err)
+	}
+	if err := s.SetURLRewrites(em.GetURLRewrites()); err != nil {
+		return fmt.Errorf("unable to load URL rewrites: %v", err)
+	}
+	if err := s.SetPolicies(em.GetBootstrapPolicies()); err != nil {
+		return fmt.Errorf("unable to load bootstrap policies: %v", err)
+	}
+	s.SetChassisDescriptorValidation(em.GetChassisDescriptorValidation())
+	if err := s.SetVendorQuirks(em.GetVendorQuirks()); err != nil {
+		return fmt.Errorf("unable to load vendor quirks: %v", err)
+	}
+	resp, err := s.GetBootstrapData(context.Background(), &bpb.GetBootstrapDataRequest{
+		ChassisDescriptor: desc,
+		ControlCardState:  state,
+		Nonce:             *nonce,
+	})
+	if err != nil {
+		return fmt.Errorf("the server would fail the request: %v", err)
+	}
+	data := &bpb.BootstrapDataSigned{}
+	if err := proto.Unmarshal(resp.GetSerializedBootstrapData(), data); err != nil {
+		return fmt.Errorf("unable to parse bootstrap data: %v", err)
+	}
+	// The bootstrap data is printed once, decoded.
+	resp.SignedResponse = nil
+	resp.SerializedBootstrapData = nil
+	fmt.Fprintf(w, "# Request\n%s\n", prototext.Format(&bpb.GetBootstrapDataRequest{ChassisDescriptor: desc, ControlCardState: state, Nonce: *nonce}))
+	fmt.Fprintf(w, "# GetBootstrapDataResponse\n%s\n", prototext.Format(resp))
+	fmt.Fprintf(w, "# BootstrapDataSigned\n%s", prototext.Format(data))
+	return nil
+}
+
+// chassisDescriptor returns the descriptor a device of the inventory identified by the serial
+// number of its chassis, or of one of its control cards, would send.
+func chassisDescriptor(inventory map[service.EntityLookup]*epb.Chassis, manufacturer, serial string) (*bpb.ChassisDescriptor, error) {
+	var matches []*epb.Chassis
+	for _, ch := range inventory {
+		if manufacturer != "" && ch.GetManufacturer() != manufacturer {
+			continue
+		}
+		found := ch.GetSerialNumber() == serial
+		for _, c := range ch.GetControllerCards() {
+			found = found || c.GetSerialNumber() == serial
+		}
+		if found {
+			matches = append(matches, ch)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no chassis or control card with serial# %s in the inventory", serial)
+	case 1:
+	default:
+		var manufacturers []string
+		for _, ch := range matches {
+			manufacturers = append(manufacturers, ch.GetManufacturer())
+		}
+		sort.Strings(manufacturers)
+		return nil, fmt.Errorf("serial# %s belongs to chassis of %v, set --manufacturer", serial, manufacturers)
+	}
+	ch := matches[0]
+	desc := &bpb.ChassisDescriptor{
+		Manufacturer: ch.GetManufacturer(),
+		PartNumber:   ch.GetPartNumber(),
+		SerialNumber: ch.GetSerialNumber(),
+	}
+	for _, c := range ch.GetControllerCards() {
+		desc.ControlCards = append(desc.ControlCards, &bpb.ControlCard{
+			SerialNumber: c.GetSerialNumber(),
+			PartNumber:   c.GetPartNumber(),
+		})
+	}
+	return desc, nil
+}
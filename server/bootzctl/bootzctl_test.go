@@ -0,0 +1,72 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/h-fam/errdiff"
+)
+
+func TestPreview(t *testing.T) {
+	tests := []struct {
+		desc    string
+		args    []string
+		want    []string
+		wantErr string
+	}{{
+		desc: "Chassis serial",
+		args: []string{"--inv_config", "../../testdata/inventory.prototxt", "--serial", "123"},
+		want: []string{`serial_number: "123A"`, `serial_num: "123B"`, "response_signature:", `nonce: "preview"`, `boot_password_hash: "ABCD123"`},
+	}, {
+		desc: "Control card serial",
+		args: []string{"--inv_config", "../../testdata/inventory.prototxt", "--serial", "123B", "--control_card", "123B"},
+		want: []string{`control_card_state: {`, `serial_num: "123A"`, "ownership_voucher:"},
+	}, {
+		desc: "Unsigned",
+		args: []string{"--inv_config", "../../testdata/inventory.prototxt", "--serial", "123", "--nonce", ""},
+		want: []string{`serial_num: "123A"`},
+	}, {
+		desc:    "Unknown serial",
+		args:    []string{"--inv_config", "../../testdata/inventory.prototxt", "--serial", "456"},
+		wantErr: "no chassis or control card",
+	}, {
+		desc:    "Unknown manufacturer",
+		args:    []string{"--inv_config", "../../testdata/inventory.prototxt", "--serial", "123", "--manufacturer", "Arista"},
+		wantErr: "no chassis or control card",
+	}, {
+		desc:    "Missing serial",
+		args:    []string{"--inv_config", "../../testdata/inventory.prototxt"},
+		wantErr: "must be set",
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			var out bytes.Buffer
+			err := preview(test.args, &out)
+			if s := errdiff.Substring(err, test.wantErr); s != "" {
+				t.Fatalf("preview() %s", s)
+			}
+			// prototext randomizes its whitespace, so it is collapsed before matching.
+			got := strings.Join(strings.Fields(out.String()), " ")
+			for _, w := range test.want {
+				if !strings.Contains(got, w) {
+					t.Errorf("preview() output does not contain %q:\n%s", w, out.String())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,484 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bundle builds and opens provisioning bundles: signed tarballs of an inventory, the
+// files it references, its ownership vouchers and a manifest of the software images it serves,
+// so servers of air-gapped sites are provisioned from a single file verified before use.
+package bundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/openconfig/bootz/common/signature"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	bpb "github.com/openconfig/bootz/proto/bootz"
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+)
+
+const (
+	// Version is the version of the bundle format.
+	Version = 1
+	// ManifestFile is the file of a bundle holding its JSON encoded manifest.
+	ManifestFile = "manifest.json"
+	// SignatureFile is the file of a bundle holding the base64 encoded signature of its manifest.
+	SignatureFile = "manifest.sig"
+	// InventoryFile is the file of a bundle holding its text encoded inventory.
+	InventoryFile = "inventory.prototxt"
+
+	// filesDir is the directory of a bundle holding the files referenced by its inventory.
+	filesDir = "files"
+	// vouchersDir is the directory of a bundle holding the files of its voucher store.
+	vouchersDir = "vouchers"
+	// rotatedVouchersDir is the directory of a voucher store holding its rotated vouchers.
+	rotatedVouchersDir = "rotated"
+	// voucherExt is the extension of the files of a voucher store.
+	voucherExt = ".ov"
+	// maxSize bounds the uncompressed size of the files of a bundle, which carries no images.
+	maxSize = 256 << 20
+)
+
+// voucherStoreDirectory is the field of the inventory naming the directory of its voucher store.
+const voucherStoreDirectory protoreflect.FullName = "entity.VoucherStore.directory"
+
+// siteFields are the file fields of the inventory left to each site, as they hold the state or
+// the secrets of the server rather than provisioning data.
+var siteFields = map[protoreflect.FullName]bool{
+	"entity.Options.device_state_file":   true,
+	"entity.Options.retired_device_file": true,
+	"entity.SmtpServer.password_file":    true,
+	"entity.PagerDuty.routing_key_file":  true,
+	"entity.RedisStore.password_file":    true,
+	"entity.NetBoxSource.token_file":     true,
+	"entity.TokenHeader.token_file":      true,
+}
+
+// Image is a software image served by the inventory of a bundle. Images are not bundled, and
+// are expected in the image directory of the server.
+type Image struct {
+	Name          string `json:"name,omitempty"`
+	Version       string `json:"version,omitempty"`
+	URL           string `json:"url,omitempty"`
+	Hash          string `json:"hash,omitempty"`
+	HashAlgorithm string `json:"hash_algorithm,omitempty"`
+}
+
+// Manifest describes the content of a bundle.
+type Manifest struct {
+	Version int       `json:"version"`
+	Created time.Time `json:"created"`
+	// Inventory is the file of the bundle holding its inventory.
+	Inventory string `json:"inventory"`
+	// Files are the hex encoded SHA-256 digests of the files of the bundle by name, but for the
+	// manifest and its signature.
+	Files map[string]string `json:"files"`
+	// Images are the software images served by the inventory.
+	Images []Image `json:"images,omitempty"`
+}
+
+// MissingImages returns the images whose URL starts with baseURL, as served by the image
+// server, that the images directory is missing.
+func (m *Manifest) MissingImages(images fs.FS, baseURL string) []Image {
+	var missing []Image
+	base := strings.TrimSuffix(baseURL, "/") + "/"
+	for _, img := range m.Images {
+		name, ok := strings.CutPrefix(img.URL, base)
+		if !ok {
+			continue
+		}
+		if i := strings.IndexAny(name, "?#"); i >= 0 {
+			name = name[:i]
+		}
+		if _, err := fs.Stat(images, name); err != nil {
+			missing = append(missing, img)
+		}
+	}
+	return missing
+}
+
+// builder collects the files of a bundle.
+type builder struct {
+	base  string
+	files map[string][]byte
+	// names are the names in the bundle of the files added by their path.
+	names map[string]string
+}
+
+// Build writes a bundle of the inventory at the path to w, signed with key. The relative paths
+// of the inventory are resolved against base, the working directory of the server it was
+// written for. Files referenced by the inventory are bundled, but for the state and the
+// secrets of the server, and the private keys and certificates of the artifact directory.
+func Build(w io.Writer, inventory, base string, key crypto.Signer) (*Manifest, error) {
+	data, err := os.ReadFile(inventory)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read inventory: %v", err)
+	}
+	entities := &epb.Entities{}
+	if err := prototext.Unmarshal(data, entities); err != nil {
+		return nil, fmt.Errorf("unable to parse inventory %s: %v", inventory, err)
+	}
+	b := &builder{base: base, files: map[string][]byte{}, names: map[string]string{}}
+	if err := rewritePaths(entities.ProtoReflect(), b.add); err != nil {
+		return nil, err
+	}
+	if b.files[InventoryFile], err = (prototext.MarshalOptions{Multiline: true}).Marshal(entities); err != nil {
+		return nil, fmt.Errorf("unable to encode inventory: %v", err)
+	}
+
+	m := &Manifest{
+		Version:   Version,
+		Created:   time.Now().UTC().Truncate(time.Second),
+		Inventory: InventoryFile,
+		Files:     map[string]string{},
+		Images:    images(entities),
+	}
+	for name, content := range b.files {
+		m.Files[name] = digest(content)
+	}
+	manifest, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	sig, err := signature.Sign(key, manifest)
+	if err != nil {
+		return nil, fmt.Errorf("unable to sign manifest: %v", err)
+	}
+
+	names := make([]string, 0, len(b.files))
+	for name := range b.files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	write := func(name string, content []byte) error {
+		hdr := &tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), ModTime: m.Created, Typeflag: tar.TypeReg}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		_, err := tw.Write(content)
+		return err
+	}
+	// The manifest and its signature come first, so bundles can be inspected from their head.
+	if err := write(ManifestFile, manifest); err != nil {
+		return nil, err
+	}
+	if err := write(SignatureFile, []byte(sig+"\n")); err != nil {
+		return nil, err
+	}
+	for _, name := range names {
+		if err := write(name, b.files[name]); err != nil {
+			return nil, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// resolve returns the path of a file referenced by the inventory.
+func (b *builder) resolve(p string) string {
+	if filepath.IsAbs(p) {
+		return p
+	}
+	return filepath.Join(b.base, p)
+}
+
+// add bundles the file or voucher store referenced by the field, and returns its bundle path.
+func (b *builder) add(fd protoreflect.FieldDescriptor, p string) (string, error) {
+	if fd.FullName() == voucherStoreDirectory {
+		return vouchersDir, b.addVouchers(b.resolve(p))
+	}
+	file := b.resolve(p)
+	if name, ok := b.names[file]; ok {
+		return name, nil
+	}
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return "", fmt.Errorf("unable to read %s of %s: %v", p, fd.FullName(), err)
+	}
+	name := path.Join(filesDir, filepath.Base(file))
+	for i := 1; b.files[name] != nil; i++ {
+		name = path.Join(filesDir, fmt.Sprintf("%d-%s", i, filepath.Base(file)))
+	}
+	b.files[name] = content
+	b.names[file] = name
+	return name, nil
+}
+
+// addVouchers bundles the vouchers and rotated vouchers of the voucher store directory.
+func (b *builder) addVouchers(dir string) error {
+	for _, sub := range []string{"", rotatedVouchersDir} {
+		entries, err := os.ReadDir(filepath.Join(dir, sub))
+		if errors.Is(err, fs.ErrNotExist) && sub != "" {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("unable to read voucher store: %v", err)
+		}
+		for _, e := range entries {
+			// Hidden files are partially written vouchers.
+			if !e.Type().IsRegular() || !strings.HasSuffix(e.Name(), voucherExt) || strings.HasPrefix(e.Name(), ".") {
+				continue
+			}
+			content, err := os.ReadFile(filepath.Join(dir, sub, e.Name()))
+			if err != nil {
+				return fmt.Errorf("unable to read voucher store: %v", err)
+			}
+			b.files[path.Join(vouchersDir, sub, e.Name())] = content
+		}
+	}
+	return nil
+}
+
+// Open verifies the bundle at the path with the certificate of its signer, and extracts it to
+// dir, which must be empty or missing. The paths of the extracted inventory are rebased to dir.
+// Nothing is written to dir unless the signature of the manifest and the digest of every file
+// verify.
+func Open(p, dir string, cert *x509.Certificate) (*Manifest, error) {
+	files, err := read(p)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read bundle %s: %v", p, err)
+	}
+	manifest, ok := files[ManifestFile]
+	if !ok {
+		return nil, fmt.Errorf("bundle %s has no manifest", p)
+	}
+	sig, ok := files[SignatureFile]
+	if !ok {
+		return nil, fmt.Errorf("bundle %s is not signed", p)
+	}
+	if err := signature.Verify(cert, manifest, strings.TrimSpace(string(sig))); err != nil {
+		return nil, fmt.Errorf("signature of bundle %s does not verify: %v", p, err)
+	}
+	m := &Manifest{}
+	if err := json.Unmarshal(manifest, m); err != nil {
+		return nil, fmt.Errorf("unable to parse manifest of bundle %s: %v", p, err)
+	}
+	if m.Version != Version {
+		return nil, fmt.Errorf("bundle %s has unsupported version %d, want %d", p, m.Version, Version)
+	}
+	delete(files, ManifestFile)
+	delete(files, SignatureFile)
+	for name, content := range files {
+		want, ok := m.Files[name]
+		if !ok {
+			return nil, fmt.Errorf("file %s of bundle %s is not in its manifest", name, p)
+		}
+		if got := digest(content); got != want {
+			return nil, fmt.Errorf("digest of file %s of bundle %s is %s, want %s", name, p, got, want)
+		}
+	}
+	for name := range m.Files {
+		if _, ok := files[name]; !ok {
+			return nil, fmt.Errorf("bundle %s is missing file %s of its manifest", p, name)
+		}
+	}
+
+	entities := &epb.Entities{}
+	if err := prototext.Unmarshal(files[m.Inventory], entities); err != nil {
+		return nil, fmt.Errorf("unable to parse inventory of bundle %s: %v", p, err)
+	}
+	rebase := func(_ protoreflect.FieldDescriptor, file string) (string, error) {
+		if filepath.IsAbs(file) {
+			return file, nil
+		}
+		return filepath.Join(dir, filepath.FromSlash(file)), nil
+	}
+	if err := rewritePaths(entities.ProtoReflect(), rebase); err != nil {
+		return nil, err
+	}
+	if files[m.Inventory], err = (prototext.MarshalOptions{Multiline: true}).Marshal(entities); err != nil {
+		return nil, fmt.Errorf("unable to encode inventory: %v", err)
+	}
+
+	if entries, err := os.ReadDir(dir); err == nil && len(entries) > 0 {
+		return nil, fmt.Errorf("unable to extract bundle %s: directory %s is not empty", p, dir)
+	}
+	// Vouchers issued or uploaded later are written to the voucher store, even if none were bundled.
+	if d := entities.GetOptions().GetVoucherStore().GetDirectory(); d != "" {
+		if err := os.MkdirAll(d, 0755); err != nil {
+			return nil, err
+		}
+	}
+	for name, content := range files {
+		file := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(file, content, 0644); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// read returns the content of the files of the bundle at the path by name.
+func read(p string) (map[string][]byte, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, err
+	}
+	tr := tar.NewReader(gz)
+	files := map[string][]byte{}
+	size := int64(0)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return files, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			continue
+		case tar.TypeReg:
+		default:
+			return nil, fmt.Errorf("entry %s is not a regular file", hdr.Name)
+		}
+		if !filepath.IsLocal(filepath.FromSlash(hdr.Name)) || path.Clean(hdr.Name) != hdr.Name {
+			return nil, fmt.Errorf("entry %s is not a valid file name", hdr.Name)
+		}
+		if _, ok := files[hdr.Name]; ok {
+			return nil, fmt.Errorf("entry %s is duplicated", hdr.Name)
+		}
+		if size += hdr.Size; size > maxSize {
+			return nil, fmt.Errorf("files exceed %d bytes", maxSize)
+		}
+		if files[hdr.Name], err = io.ReadAll(tr); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// rewritePaths replaces the non-empty paths of the file fields of m and its messages, and of the
+// directory of its voucher store, with those returned by fn. Site fields are left as they are.
+func rewritePaths(m protoreflect.Message, fn func(protoreflect.FieldDescriptor, string) (string, error)) error {
+	var err error
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.IsList():
+			if fd.Message() != nil {
+				list := v.List()
+				for i := 0; i < list.Len() && err == nil; i++ {
+					err = rewritePaths(list.Get(i).Message(), fn)
+				}
+			}
+		case fd.IsMap():
+			if fd.MapValue().Message() != nil {
+				v.Map().Range(func(_ protoreflect.MapKey, v protoreflect.Value) bool {
+					err = rewritePaths(v.Message(), fn)
+					return err == nil
+				})
+			}
+		case fd.Message() != nil:
+			err = rewritePaths(v.Message(), fn)
+		case fd.Kind() != protoreflect.StringKind || siteFields[fd.FullName()]:
+		case strings.HasSuffix(string(fd.Name()), "_file") || fd.FullName() == voucherStoreDirectory:
+			var p string
+			if p, err = fn(fd, v.String()); err == nil {
+				m.Set(fd, protoreflect.ValueOfString(p))
+			}
+		}
+		return err == nil
+	})
+	return err
+}
+
+// images returns the distinct software images of the inventory.
+func images(entities *epb.Entities) []Image {
+	seen := map[Image]bool{}
+	var out []Image
+	var walk func(m protoreflect.Message)
+	walk = func(m protoreflect.Message) {
+		if img, ok := m.Interface().(*bpb.SoftwareImage); ok {
+			i := Image{
+				Name:          img.GetName(),
+				Version:       img.GetVersion(),
+				URL:           img.GetUrl(),
+				Hash:          img.GetOsImageHash(),
+				HashAlgorithm: img.GetHashAlgorithm(),
+			}
+			if !seen[i] {
+				seen[i] = true
+				out = append(out, i)
+			}
+			return
+		}
+		m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+			switch {
+			case fd.IsList():
+				if fd.Message() != nil {
+					for i := 0; i < v.List().Len(); i++ {
+						walk(v.List().Get(i).Message())
+					}
+				}
+			case fd.IsMap():
+				if fd.MapValue().Message() != nil {
+					v.Map().Range(func(_ protoreflect.MapKey, v protoreflect.Value) bool {
+						walk(v.Message())
+						return true
+					})
+				}
+			case fd.Message() != nil:
+				walk(v.Message())
+			}
+			return true
+		})
+	}
+	walk(entities.ProtoReflect())
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Name != out[j].Name {
+			return out[i].Name < out[j].Name
+		}
+		if out[i].Version != out[j].Version {
+			return out[i].Version < out[j].Version
+		}
+		return out[i].URL < out[j].URL
+	})
+	return out
+}
+
+// digest returns the hex encoded SHA-256 digest of content.
+func digest(content []byte) string {
+	d := sha256.Sum256(content)
+	return hex.EncodeToString(d[:])
+}
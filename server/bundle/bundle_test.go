@@ -0,0 +1,287 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bundle
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/h-fam/errdiff"
+	"google.golang.org/protobuf/encoding/prototext"
+
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+)
+
+const testInventory = `
+options {
+  device_state_file: "state.json"
+  voucher_store { directory: "vouchers" }
+}
+chassis {
+  serial_number: "123"
+  manufacturer: "Cisco"
+  software_image { name: "os" version: "1.0" url: "https://images.example.com/os-1.0.img" }
+  config {
+    boot_config {
+      vendor_config_file: "configs/vendor.cfg"
+      oc_config_file: "configs/oc.cfg"
+    }
+  }
+}
+chassis {
+  serial_number: "456"
+  manufacturer: "Cisco"
+  software_image { name: "os" version: "1.0" url: "https://images.example.com/os-1.0.img" }
+  config {
+    boot_config { vendor_config_file: "configs/vendor.cfg" }
+  }
+}
+`
+
+// newSigner returns a key and a self-signed certificate of it.
+func newSigner(t *testing.T) (crypto.Signer, *x509.Certificate) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("ecdsa.GenerateKey() err = %v", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "Bundle Signer"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, key.Public(), key)
+	if err != nil {
+		t.Fatalf("x509.CreateCertificate() err = %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("x509.ParseCertificate() err = %v", err)
+	}
+	return key, cert
+}
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		file := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(file), 0700); err != nil {
+			t.Fatalf("os.MkdirAll() err = %v", err)
+		}
+		if err := os.WriteFile(file, []byte(content), 0600); err != nil {
+			t.Fatalf("os.WriteFile() err = %v", err)
+		}
+	}
+}
+
+// buildBundle returns a bundle of the test inventory signed with key.
+func buildBundle(t *testing.T, key crypto.Signer) []byte {
+	t.Helper()
+	base := t.TempDir()
+	writeFiles(t, base, map[string]string{
+		"inventory.prototxt":       testInventory,
+		"configs/vendor.cfg":       "hostname device",
+		"configs/oc.cfg":           "{}",
+		"vouchers/123A.ov":         "voucher of 123A",
+		"vouchers/rotated/123B.ov": "rotated voucher of 123B",
+		"vouchers/.tmp-1.ov":       "partially written",
+	})
+	var buf bytes.Buffer
+	if _, err := Build(&buf, filepath.Join(base, "inventory.prototxt"), base, key); err != nil {
+		t.Fatalf("Build() err = %v", err)
+	}
+	return buf.Bytes()
+}
+
+// writeTar writes the files as a bundle to a temporary file, and returns its path.
+func writeTar(t *testing.T, files map[string][]byte) string {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatalf("WriteHeader() err = %v", err)
+		}
+		if _, err := tw.Write(content); err != nil {
+			t.Fatalf("Write() err = %v", err)
+		}
+	}
+	tw.Close()
+	gz.Close()
+	file := filepath.Join(t.TempDir(), "bundle.tar.gz")
+	if err := os.WriteFile(file, buf.Bytes(), 0600); err != nil {
+		t.Fatalf("os.WriteFile() err = %v", err)
+	}
+	return file
+}
+
+func TestBuildAndOpen(t *testing.T) {
+	key, cert := newSigner(t)
+	file := filepath.Join(t.TempDir(), "bundle.tar.gz")
+	if err := os.WriteFile(file, buildBundle(t, key), 0600); err != nil {
+		t.Fatalf("os.WriteFile() err = %v", err)
+	}
+	dir := filepath.Join(t.TempDir(), "bundle")
+	m, err := Open(file, dir, cert)
+	if err != nil {
+		t.Fatalf("Open() err = %v", err)
+	}
+	wantFiles := []string{"files/oc.cfg", "files/vendor.cfg", InventoryFile, "vouchers/123A.ov", "vouchers/rotated/123B.ov"}
+	var gotFiles []string
+	for name := range m.Files {
+		gotFiles = append(gotFiles, name)
+	}
+	sort.Strings(gotFiles)
+	if diff := cmp.Diff(wantFiles, gotFiles); diff != "" {
+		t.Errorf("Open() files diff (-want +got):\n%s", diff)
+	}
+	wantImages := []Image{{Name: "os", Version: "1.0", URL: "https://images.example.com/os-1.0.img"}}
+	if diff := cmp.Diff(wantImages, m.Images); diff != "" {
+		t.Errorf("Open() images diff (-want +got):\n%s", diff)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, m.Inventory))
+	if err != nil {
+		t.Fatalf("unable to read extracted inventory: %v", err)
+	}
+	entities := &epb.Entities{}
+	if err := prototext.Unmarshal(data, entities); err != nil {
+		t.Fatalf("unable to parse extracted inventory: %v", err)
+	}
+	if got, want := entities.GetOptions().GetDeviceStateFile(), "state.json"; got != want {
+		t.Errorf("Open() device_state_file = %q, want %q left to the site", got, want)
+	}
+	if got, want := entities.GetOptions().GetVoucherStore().GetDirectory(), filepath.Join(dir, "vouchers"); got != want {
+		t.Errorf("Open() voucher store directory = %q, want %q", got, want)
+	}
+	for _, c := range entities.GetChassis() {
+		f := c.GetConfig().GetBootConfig().GetVendorConfigFile()
+		if got, err := os.ReadFile(f); err != nil || string(got) != "hostname device" {
+			t.Errorf("Open() vendor_config_file %s of chassis %s = %q, %v, want the bundled config", f, c.GetSerialNumber(), got, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "vouchers", ".tmp-1.ov")); err == nil {
+		t.Errorf("Build() bundled a partially written voucher")
+	}
+
+	if _, err := Open(file, dir, cert); err == nil {
+		t.Errorf("Open() to a non-empty directory err = nil, want error")
+	}
+}
+
+func TestOpenInvalid(t *testing.T) {
+	key, cert := newSigner(t)
+	_, otherCert := newSigner(t)
+	file := filepath.Join(t.TempDir(), "bundle.tar.gz")
+	if err := os.WriteFile(file, buildBundle(t, key), 0600); err != nil {
+		t.Fatalf("os.WriteFile() err = %v", err)
+	}
+	files, err := read(file)
+	if err != nil {
+		t.Fatalf("read() err = %v", err)
+	}
+	tests := []struct {
+		desc    string
+		cert    *x509.Certificate
+		modify  func(files map[string][]byte)
+		wantErr string
+	}{{
+		desc: "Valid bundle",
+		cert: cert,
+	}, {
+		desc:    "Another signer",
+		cert:    otherCert,
+		wantErr: "does not verify",
+	}, {
+		desc:    "Modified manifest",
+		cert:    cert,
+		modify:  func(files map[string][]byte) { files[ManifestFile] = append(files[ManifestFile], ' ') },
+		wantErr: "does not verify",
+	}, {
+		desc:    "Unsigned",
+		cert:    cert,
+		modify:  func(files map[string][]byte) { delete(files, SignatureFile) },
+		wantErr: "is not signed",
+	}, {
+		desc:    "Modified file",
+		cert:    cert,
+		modify:  func(files map[string][]byte) { files["files/vendor.cfg"] = []byte("hostname attacker") },
+		wantErr: "digest of file files/vendor.cfg",
+	}, {
+		desc:    "Added file",
+		cert:    cert,
+		modify:  func(files map[string][]byte) { files["files/extra.cfg"] = []byte("extra") },
+		wantErr: "is not in its manifest",
+	}, {
+		desc:    "Removed file",
+		cert:    cert,
+		modify:  func(files map[string][]byte) { delete(files, "vouchers/123A.ov") },
+		wantErr: "missing file vouchers/123A.ov",
+	}, {
+		desc:    "Entry outside of the bundle",
+		cert:    cert,
+		modify:  func(files map[string][]byte) { files["../escape"] = []byte("escape") },
+		wantErr: "not a valid file name",
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			modified := map[string][]byte{}
+			for name, content := range files {
+				modified[name] = append([]byte(nil), content...)
+			}
+			if test.modify != nil {
+				test.modify(modified)
+			}
+			dir := filepath.Join(t.TempDir(), "bundle")
+			_, err := Open(writeTar(t, modified), dir, test.cert)
+			if diff := errdiff.Substring(err, test.wantErr); diff != "" {
+				t.Errorf("Open() %s", diff)
+			}
+			if _, statErr := os.Stat(dir); err != nil && statErr == nil {
+				t.Errorf("Open() of an invalid bundle extracted files")
+			}
+		})
+	}
+}
+
+func TestMissingImages(t *testing.T) {
+	m := &Manifest{Images: []Image{
+		{Name: "present", URL: "https://images.example.com/os/1.0.img"},
+		{Name: "missing", URL: "https://images.example.com/os/2.0.img?v=2"},
+		{Name: "elsewhere", URL: "https://cdn.example.com/os/3.0.img"},
+	}}
+	images := fstest.MapFS{"os/1.0.img": {Data: []byte("image")}}
+	want := []Image{{Name: "missing", URL: "https://images.example.com/os/2.0.img?v=2"}}
+	if diff := cmp.Diff(want, m.MissingImages(images, "https://images.example.com/")); diff != "" {
+		t.Errorf("MissingImages() diff (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,286 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cluster shares the state of bootz server instances running
+// active-active behind a load balancer through a distributed key-value store,
+// so each instance serves devices as if it had handled all their previous
+// requests.
+package cluster
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+)
+
+// Store is a key-value store shared by the instances of a cluster. Implementations must be safe
+// for concurrent use.
+type Store interface {
+	// Get returns the value of key, and whether it exists.
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// Put sets the value of key, expiring it after ttl unless ttl is 0.
+	Put(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// Delete removes key, if it exists.
+	Delete(ctx context.Context, key string) error
+	// List returns the values of every key with the prefix, by key.
+	List(ctx context.Context, prefix string) (map[string][]byte, error)
+	// Swap atomically sets the value of key, without expiry, if its value is old, or if it does
+	// not exist when old is nil. It returns whether it set the value. Values must not be empty.
+	Swap(ctx context.Context, key string, old, value []byte) (bool, error)
+	// Acquire takes the lease of key for holder for ttl if it is free, or renews it if holder
+	// already holds it. It returns the fencing token of the lease if holder holds it, or 0. The
+	// token increases whenever the lease is taken, and is kept when it is renewed.
+	Acquire(ctx context.Context, key, holder string, ttl time.Duration) (uint64, error)
+	// PutFenced sets the value of key, without expiry, if the fencing token of the lease is still
+	// token, so that a holder which lost the lease can't overwrite the values of the next one. It
+	// returns whether it set the value.
+	PutFenced(ctx context.Context, key string, value []byte, lease string, token uint64) (bool, error)
+	// Release frees the lease of key if holder holds it.
+	Release(ctx context.Context, key, holder string) error
+}
+
+// New returns the store configured by cfg, prefixing every key with its key prefix.
+func New(cfg *epb.Cluster) (Store, error) {
+	var s Store
+	switch b := cfg.GetStore().(type) {
+	case *epb.Cluster_Redis:
+		if b.Redis.GetAddress() == "" {
+			return nil, fmt.Errorf("the Redis store of the cluster must set an address")
+		}
+		opts := RedisOptions{DB: int(b.Redis.GetDb()), PoolSize: int(b.Redis.GetPoolSize())}
+		if f := b.Redis.GetPasswordFile(); f != "" {
+			data, err := os.ReadFile(f)
+			if err != nil {
+				return nil, fmt.Errorf("unable to read Redis password: %v", err)
+			}
+			opts.Password = strings.TrimSpace(string(data))
+		}
+		if b.Redis.GetTls() {
+			var err error
+			if opts.TLS, err = redisTLSConfig(b.Redis); err != nil {
+				return nil, err
+			}
+		}
+		s = NewRedisWithOptions(b.Redis.GetAddress(), opts)
+	default:
+		return nil, fmt.Errorf("the cluster must configure a store")
+	}
+	if p := cfg.GetKeyPrefix(); p != "" {
+		s = &prefixed{prefix: p, Store: s}
+	}
+	return s, nil
+}
+
+// redisTLSConfig returns the TLS config of the connections to the Redis server of cfg.
+func redisTLSConfig(cfg *epb.RedisStore) (*tls.Config, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if f := cfg.GetTlsCaCertFile(); f != "" {
+		caPEM, err := os.ReadFile(f)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read Redis CA cert: %v", err)
+		}
+		roots := x509.NewCertPool()
+		if !roots.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("unable to parse Redis CA cert %s", f)
+		}
+		tlsConfig.RootCAs = roots
+	}
+	if cfg.GetTlsCertFile() != "" || cfg.GetTlsKeyFile() != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.GetTlsCertFile(), cfg.GetTlsKeyFile())
+		if err != nil {
+			return nil, fmt.Errorf("unable to load Redis client certificate: %v", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
+
+// prefixed namespaces the keys of a store, so several clusters can share it.
+type prefixed struct {
+	prefix string
+	Store
+}
+
+func (p *prefixed) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	return p.Store.Get(ctx, p.prefix+key)
+}
+
+func (p *prefixed) Put(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return p.Store.Put(ctx, p.prefix+key, value, ttl)
+}
+
+func (p *prefixed) Delete(ctx context.Context, key string) error {
+	return p.Store.Delete(ctx, p.prefix+key)
+}
+
+func (p *prefixed) List(ctx context.Context, prefix string) (map[string][]byte, error) {
+	values, err := p.Store.List(ctx, p.prefix+prefix)
+	if err != nil {
+		return nil, err
+	}
+	out := map[string][]byte{}
+	for k, v := range values {
+		out[strings.TrimPrefix(k, p.prefix)] = v
+	}
+	return out, nil
+}
+
+func (p *prefixed) Swap(ctx context.Context, key string, old, value []byte) (bool, error) {
+	return p.Store.Swap(ctx, p.prefix+key, old, value)
+}
+
+func (p *prefixed) Acquire(ctx context.Context, key, holder string, ttl time.Duration) (uint64, error) {
+	return p.Store.Acquire(ctx, p.prefix+key, holder, ttl)
+}
+
+func (p *prefixed) PutFenced(ctx context.Context, key string, value []byte, lease string, token uint64) (bool, error) {
+	return p.Store.PutFenced(ctx, p.prefix+key, value, p.prefix+lease, token)
+}
+
+func (p *prefixed) Release(ctx context.Context, key, holder string) error {
+	return p.Store.Release(ctx, p.prefix+key, holder)
+}
+
+// memEntry is a value of a Memory store.
+type memEntry struct {
+	value   []byte
+	expires time.Time
+}
+
+// Memory is a Store held in the memory of a single process, e.g. to run a cluster of servers
+// in tests. It is safe for concurrent use.
+type Memory struct {
+	mu      sync.Mutex
+	entries map[string]memEntry
+	// tokens holds the fencing token of each lease.
+	tokens map[string]uint64
+	now    func() time.Time
+}
+
+// NewMemory returns an empty memory store.
+func NewMemory() *Memory {
+	return &Memory{entries: map[string]memEntry{}, tokens: map[string]uint64{}, now: time.Now}
+}
+
+// get returns the unexpired entry of key. The caller must hold m.mu.
+func (m *Memory) get(key string) ([]byte, bool) {
+	e, ok := m.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !e.expires.IsZero() && m.now().After(e.expires) {
+		delete(m.entries, key)
+		return nil, false
+	}
+	return e.value, true
+}
+
+// Get implements Store.
+func (m *Memory) Get(_ context.Context, key string) ([]byte, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	v, ok := m.get(key)
+	return append([]byte(nil), v...), ok, nil
+}
+
+// Put implements Store.
+func (m *Memory) Put(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e := memEntry{value: append([]byte(nil), value...)}
+	if ttl > 0 {
+		e.expires = m.now().Add(ttl)
+	}
+	m.entries[key] = e
+	return nil
+}
+
+// Delete implements Store.
+func (m *Memory) Delete(_ context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.entries, key)
+	return nil
+}
+
+// List implements Store.
+func (m *Memory) List(_ context.Context, prefix string) (map[string][]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	out := map[string][]byte{}
+	for k := range m.entries {
+		if !strings.HasPrefix(k, prefix) {
+			continue
+		}
+		if v, ok := m.get(k); ok {
+			out[k] = append([]byte(nil), v...)
+		}
+	}
+	return out, nil
+}
+
+// Swap implements Store.
+func (m *Memory) Swap(_ context.Context, key string, old, value []byte) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	v, ok := m.get(key)
+	if ok != (old != nil) || string(v) != string(old) {
+		return false, nil
+	}
+	m.entries[key] = memEntry{value: append([]byte(nil), value...)}
+	return true, nil
+}
+
+// Acquire implements Store.
+func (m *Memory) Acquire(_ context.Context, key, holder string, ttl time.Duration) (uint64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	v, held := m.get(key)
+	if held && string(v) != holder {
+		return 0, nil
+	}
+	if !held {
+		m.tokens[key]++
+	}
+	m.entries[key] = memEntry{value: []byte(holder), expires: m.now().Add(ttl)}
+	return m.tokens[key], nil
+}
+
+// PutFenced implements Store.
+func (m *Memory) PutFenced(_ context.Context, key string, value []byte, lease string, token uint64) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.tokens[lease] != token {
+		return false, nil
+	}
+	m.entries[key] = memEntry{value: append([]byte(nil), value...)}
+	return true, nil
+}
+
+// Release implements Store.
+func (m *Memory) Release(_ context.Context, key, holder string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if v, ok := m.get(key); ok && string(v) == holder {
+		delete(m.entries, key)
+	}
+	return nil
+}
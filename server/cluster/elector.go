@@ -0,0 +1,136 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	log "github.com/openconfig/bootz/server/logging"
+)
+
+const (
+	// leaderKey is the key of the lease of the leader.
+	leaderKey = "leader"
+	// DefaultLeaseDuration is how long the leader holds its lease without renewing it by default.
+	DefaultLeaseDuration = 15 * time.Second
+)
+
+// Elector elects the leader of a cluster with a lease of the store: the instance holding the
+// lease leads until it fails to renew it. It is safe for concurrent use.
+type Elector struct {
+	store Store
+	id    string
+	ttl   time.Duration
+	// leader reports whether the instance held the lease at its last renewal.
+	leader atomic.Bool
+	// token is the fencing token of the lease at its last renewal, if the instance held it.
+	token atomic.Uint64
+}
+
+// ErrFenced is returned by Put when the instance no longer holds the lease it wrote under.
+var ErrFenced = errors.New("the instance no longer leads the cluster")
+
+// NewElector returns an elector campaigning for the instance with the given ID, e.g. the address
+// of its admin API, with leases of ttl, or the default if 0.
+func NewElector(store Store, id string, ttl time.Duration) *Elector {
+	if ttl == 0 {
+		ttl = DefaultLeaseDuration
+	}
+	return &Elector{store: store, id: id, ttl: ttl}
+}
+
+// ID returns the ID the instance campaigns with.
+func (e *Elector) ID() string {
+	return e.id
+}
+
+// IsLeader reports whether the instance leads the cluster.
+func (e *Elector) IsLeader() bool {
+	return e.leader.Load()
+}
+
+// Leader returns the ID of the leader of the cluster, or "" if there is none.
+func (e *Elector) Leader(ctx context.Context) (string, error) {
+	v, _, err := e.store.Get(ctx, leaderKey)
+	return string(v), err
+}
+
+// Put sets the value of key, without expiry, unless another instance took the lease since the
+// instance last renewed it, so a leader which lost the lease, e.g. while paused, can't overwrite
+// what the next leader wrote. It returns ErrFenced and steps down otherwise.
+func (e *Elector) Put(ctx context.Context, key string, value []byte) error {
+	token := e.token.Load()
+	if token == 0 {
+		return ErrFenced
+	}
+	ok, err := e.store.PutFenced(ctx, key, value, leaderKey, token)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		if e.leader.CompareAndSwap(true, false) {
+			log.Infof("Instance %s is no longer the leader of the cluster", e.id)
+		}
+		e.token.CompareAndSwap(token, 0)
+		return ErrFenced
+	}
+	return nil
+}
+
+// campaign takes or renews the lease, and returns whether the instance leads the cluster. Leaders
+// failing to reach the store step down, as their lease may expire meanwhile.
+func (e *Elector) campaign(ctx context.Context) bool {
+	ctx, cancel := context.WithTimeout(ctx, e.ttl/3)
+	defer cancel()
+	token, err := e.store.Acquire(ctx, leaderKey, e.id, e.ttl)
+	if err != nil {
+		log.Warningf("Unable to campaign for the leadership of the cluster: %v", err)
+	}
+	leader := token != 0
+	e.token.Store(token)
+	if was := e.leader.Swap(leader); was != leader {
+		if leader {
+			log.Infof("Instance %s is now the leader of the cluster", e.id)
+		} else {
+			log.Infof("Instance %s is no longer the leader of the cluster", e.id)
+		}
+	}
+	return leader
+}
+
+// Run campaigns until ctx is done, renewing the lease three times per lease duration, then
+// releases the lease. After each campaign, tick is called with whether the instance leads.
+func (e *Elector) Run(ctx context.Context, tick func(ctx context.Context, leader bool)) {
+	ticker := time.NewTicker(e.ttl / 3)
+	defer ticker.Stop()
+	for {
+		tick(ctx, e.campaign(ctx))
+		select {
+		case <-ctx.Done():
+			e.leader.Store(false)
+			e.token.Store(0)
+			releaseCtx, cancel := context.WithTimeout(context.Background(), e.ttl/3)
+			defer cancel()
+			if err := e.store.Release(releaseCtx, leaderKey, e.id); err != nil {
+				log.Warningf("Unable to release the leadership of the cluster: %v", err)
+			}
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestElector(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	store := NewMemory()
+	store.now = func() time.Time { return now }
+	a := NewElector(store, "a", time.Minute)
+	b := NewElector(store, "b", time.Minute)
+	if !a.campaign(ctx) || !a.IsLeader() {
+		t.Fatalf("campaign() of a = false, want true")
+	}
+	if b.campaign(ctx) || b.IsLeader() {
+		t.Errorf("campaign() of b while a leads = true, want false")
+	}
+	if got, err := b.Leader(ctx); err != nil || got != "a" {
+		t.Errorf("Leader() = %q, %v, want %q, nil", got, err, "a")
+	}
+	// a renews its lease, and keeps leading past the initial lease.
+	now = now.Add(50 * time.Second)
+	if !a.campaign(ctx) {
+		t.Errorf("campaign() renewing the lease of a = false, want true")
+	}
+	now = now.Add(50 * time.Second)
+	if b.campaign(ctx) {
+		t.Errorf("campaign() of b after a renewed its lease = true, want false")
+	}
+	// b takes over once the lease of a expires.
+	now = now.Add(time.Minute)
+	if !b.campaign(ctx) {
+		t.Errorf("campaign() of b after the lease of a expired = false, want true")
+	}
+	if a.campaign(ctx) || a.IsLeader() {
+		t.Errorf("campaign() of a after b took over = true, want false")
+	}
+}
+
+func TestElectorPut(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	store := NewMemory()
+	store.now = func() time.Time { return now }
+	a := NewElector(store, "a", time.Minute)
+	b := NewElector(store, "b", time.Minute)
+	if err := a.Put(ctx, "published", []byte("a")); !errors.Is(err, ErrFenced) {
+		t.Errorf("Put() before campaigning err = %v, want %v", err, ErrFenced)
+	}
+	if !a.campaign(ctx) {
+		t.Fatalf("campaign() of a = false, want true")
+	}
+	if err := a.Put(ctx, "published", []byte("a")); err != nil {
+		t.Errorf("Put() of the leader err = %v, want nil", err)
+	}
+	// b takes over while a, e.g. paused, still believes it leads.
+	now = now.Add(2 * time.Minute)
+	if !b.campaign(ctx) {
+		t.Fatalf("campaign() of b after the lease of a expired = false, want true")
+	}
+	if err := b.Put(ctx, "published", []byte("b")); err != nil {
+		t.Errorf("Put() of the new leader err = %v, want nil", err)
+	}
+	if err := a.Put(ctx, "published", []byte("stale")); !errors.Is(err, ErrFenced) {
+		t.Errorf("Put() of the former leader err = %v, want %v", err, ErrFenced)
+	}
+	if a.IsLeader() {
+		t.Errorf("IsLeader() of the fenced out leader = true, want false")
+	}
+	if v, _, _ := store.Get(ctx, "published"); string(v) != "b" {
+		t.Errorf("Get() = %q, want the value of the new leader %q", v, "b")
+	}
+}
+
+func TestRunReleases(t *testing.T) {
+	store := NewMemory()
+	e := NewElector(store, "a", time.Minute)
+	ctx, cancel := context.WithCancel(context.Background())
+	ticks := make(chan bool)
+	done := make(chan struct{})
+	go func() {
+		e.Run(ctx, func(_ context.Context, leader bool) { ticks <- leader })
+		close(done)
+	}()
+	if leader := <-ticks; !leader {
+		t.Errorf("Run() ticked with leader = false, want true")
+	}
+	cancel()
+	<-done
+	if e.IsLeader() {
+		t.Errorf("IsLeader() after Run() returned = true, want false")
+	}
+	if got, _ := e.Leader(context.Background()); got != "" {
+		t.Errorf("Leader() after Run() returned = %q, want none", got)
+	}
+}
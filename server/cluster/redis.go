@@ -0,0 +1,407 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// dialTimeout bounds connecting to Redis when the context of the request has no deadline.
+	dialTimeout = 5 * time.Second
+	// defaultPoolSize is the number of connections to Redis if the options don't set it.
+	defaultPoolSize = 8
+	// minBackoff and maxBackoff bound how long connecting to Redis is not retried after it failed,
+	// doubling with every consecutive failure.
+	minBackoff = 100 * time.Millisecond
+	maxBackoff = 10 * time.Second
+)
+
+// redisError is an error reply of Redis.
+type redisError string
+
+func (e redisError) Error() string { return "redis: " + string(e) }
+
+// RedisOptions configures the connections of a Redis store.
+type RedisOptions struct {
+	// Password authenticates the connections, if set.
+	Password string
+	// DB is the index of the database to select.
+	DB int
+	// TLS configures TLS for the connections, which are in plain text if it is nil.
+	TLS *tls.Config
+	// PoolSize bounds the number of connections. Defaults to 8.
+	PoolSize int
+}
+
+// Redis is a Store backed by a Redis server, speaking RESP over a pool of connections. Connections
+// are dropped after any I/O failure, and connecting again after a failure is backed off
+// exponentially, failing commands fast in between. It is safe for concurrent use.
+type Redis struct {
+	addr  string
+	opts  RedisOptions
+	now   func() time.Time
+	slots chan struct{}
+
+	mu      sync.Mutex
+	idle    []*redisConn
+	backoff time.Duration
+	retryAt time.Time
+	dialErr error
+}
+
+// redisConn is a connection of a Redis store.
+type redisConn struct {
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+// NewRedis returns a store backed by the Redis server at addr, authenticating with the password
+// if set and selecting the database db. It connects on first use.
+func NewRedis(addr, password string, db int) *Redis {
+	return NewRedisWithOptions(addr, RedisOptions{Password: password, DB: db})
+}
+
+// NewRedisWithOptions returns a store backed by the Redis server at addr, connecting as configured
+// by opts on first use.
+func NewRedisWithOptions(addr string, opts RedisOptions) *Redis {
+	if opts.PoolSize <= 0 {
+		opts.PoolSize = defaultPoolSize
+	}
+	return &Redis{
+		addr:  addr,
+		opts:  opts,
+		now:   time.Now,
+		slots: make(chan struct{}, opts.PoolSize),
+	}
+}
+
+// conn returns an idle connection, or a new one unless connecting is backed off.
+func (r *Redis) conn(ctx context.Context) (*redisConn, error) {
+	r.mu.Lock()
+	if n := len(r.idle); n > 0 {
+		c := r.idle[n-1]
+		r.idle = r.idle[:n-1]
+		r.mu.Unlock()
+		return c, nil
+	}
+	if wait := r.retryAt.Sub(r.now()); wait > 0 {
+		err := r.dialErr
+		r.mu.Unlock()
+		return nil, fmt.Errorf("unable to connect to Redis at %s, retrying in %v: %v", r.addr, wait.Round(time.Millisecond), err)
+	}
+	r.mu.Unlock()
+	c, err := r.dial(ctx)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err != nil {
+		r.backoff = min(max(2*r.backoff, minBackoff), maxBackoff)
+		r.retryAt, r.dialErr = r.now().Add(r.backoff), err
+		return nil, err
+	}
+	r.backoff, r.retryAt, r.dialErr = 0, time.Time{}, nil
+	return c, nil
+}
+
+// dial opens a connection, authenticating and selecting the database.
+func (r *Redis) dial(ctx context.Context) (*redisConn, error) {
+	d := &net.Dialer{Timeout: dialTimeout}
+	var conn net.Conn
+	var err error
+	if r.opts.TLS != nil {
+		conn, err = (&tls.Dialer{NetDialer: d, Config: r.opts.TLS}).DialContext(ctx, "tcp", r.addr)
+	} else {
+		conn, err = d.DialContext(ctx, "tcp", r.addr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to Redis at %s: %v", r.addr, err)
+	}
+	c := &redisConn{conn: conn, r: bufio.NewReader(conn)}
+	if r.opts.Password != "" {
+		if _, err := c.roundTrip(ctx, "AUTH", r.opts.Password); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	if r.opts.DB != 0 {
+		if _, err := c.roundTrip(ctx, "SELECT", strconv.Itoa(r.opts.DB)); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// do sends a command over a connection of the pool and returns its reply, waiting for a
+// connection if all of them are busy.
+func (r *Redis) do(ctx context.Context, args ...string) (any, error) {
+	select {
+	case r.slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	defer func() { <-r.slots }()
+	c, err := r.conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	reply, err := c.roundTrip(ctx, args...)
+	var rerr redisError
+	if err != nil && !errors.As(err, &rerr) {
+		// The connection is in an unknown state after I/O errors.
+		c.conn.Close()
+		return nil, err
+	}
+	r.mu.Lock()
+	r.idle = append(r.idle, c)
+	r.mu.Unlock()
+	return reply, err
+}
+
+// roundTrip writes a command and reads its reply.
+func (c *redisConn) roundTrip(ctx context.Context, args ...string) (any, error) {
+	// Without a deadline, the zero time clears the deadline of the previous command.
+	deadline, _ := ctx.Deadline()
+	if err := c.conn.SetDeadline(deadline); err != nil {
+		return nil, err
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "*%d\r\n", len(args))
+	for _, a := range args {
+		fmt.Fprintf(&b, "$%d\r\n%s\r\n", len(a), a)
+	}
+	if _, err := io.WriteString(c.conn, b.String()); err != nil {
+		return nil, err
+	}
+	return readReply(c.r)
+}
+
+// readReply reads a RESP reply: a string, an int64, nil, a []any or a redisError.
+func readReply(r *bufio.Reader) (any, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	line = strings.TrimSuffix(line, "\r\n")
+	if line == "" {
+		return nil, fmt.Errorf("redis: empty reply")
+	}
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return nil, redisError(line[1:])
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+	case '$':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, fmt.Errorf("redis: invalid bulk string length %q", line[1:])
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		buf := make([]byte, n+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		return string(buf[:n]), nil
+	case '*':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, fmt.Errorf("redis: invalid array length %q", line[1:])
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		out := make([]any, n)
+		for i := range out {
+			if out[i], err = readReply(r); err != nil {
+				return nil, err
+			}
+		}
+		return out, nil
+	}
+	return nil, fmt.Errorf("redis: unexpected reply %q", line)
+}
+
+// Get implements Store.
+func (r *Redis) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	reply, err := r.do(ctx, "GET", key)
+	if err != nil || reply == nil {
+		return nil, false, err
+	}
+	v, ok := reply.(string)
+	if !ok {
+		return nil, false, fmt.Errorf("redis: unexpected reply %v to GET", reply)
+	}
+	return []byte(v), true, nil
+}
+
+// Put implements Store.
+func (r *Redis) Put(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	args := []string{"SET", key, string(value)}
+	if ttl > 0 {
+		args = append(args, "PX", strconv.FormatInt(ttl.Milliseconds(), 10))
+	}
+	_, err := r.do(ctx, args...)
+	return err
+}
+
+// Delete implements Store.
+func (r *Redis) Delete(ctx context.Context, key string) error {
+	_, err := r.do(ctx, "DEL", key)
+	return err
+}
+
+// List implements Store, scanning the keys with the prefix and reading them one at a time. Keys
+// expiring or deleted during the scan are skipped.
+func (r *Redis) List(ctx context.Context, prefix string) (map[string][]byte, error) {
+	pattern := redisGlobEscaper.Replace(prefix) + "*"
+	var keys []string
+	cursor := "0"
+	for {
+		reply, err := r.do(ctx, "SCAN", cursor, "MATCH", pattern, "COUNT", "100")
+		if err != nil {
+			return nil, err
+		}
+		page, ok := reply.([]any)
+		if !ok || len(page) != 2 {
+			return nil, fmt.Errorf("redis: unexpected reply %v to SCAN", reply)
+		}
+		if cursor, ok = page[0].(string); !ok {
+			return nil, fmt.Errorf("redis: unexpected cursor %v", page[0])
+		}
+		batch, _ := page[1].([]any)
+		for _, k := range batch {
+			if k, ok := k.(string); ok {
+				keys = append(keys, k)
+			}
+		}
+		if cursor == "0" {
+			break
+		}
+	}
+	out := map[string][]byte{}
+	for _, k := range keys {
+		v, ok, err := r.Get(ctx, k)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			out[k] = v
+		}
+	}
+	return out, nil
+}
+
+const (
+	// swapScript sets KEYS[1] to ARGV[3] if it does not exist and ARGV[1] is 0, or if its value is
+	// ARGV[2] and ARGV[1] is 1, returning 1 if it set the value.
+	swapScript = `local v = redis.call('GET', KEYS[1])
+if (ARGV[1] == '0' and v == false) or (ARGV[1] == '1' and v == ARGV[2]) then
+  redis.call('SET', KEYS[1], ARGV[3])
+  return 1
+end
+return 0`
+	// acquireScript takes the lease of KEYS[1] for the holder ARGV[1] for ARGV[2] milliseconds if it
+	// is free, incrementing its fencing token KEYS[2], or renews it if the holder holds it. It
+	// returns the fencing token if the holder holds the lease, else 0.
+	acquireScript = `local v = redis.call('GET', KEYS[1])
+if v == false then
+  redis.call('SET', KEYS[1], ARGV[1], 'PX', ARGV[2])
+  return redis.call('INCR', KEYS[2])
+end
+if v == ARGV[1] then
+  redis.call('PEXPIRE', KEYS[1], ARGV[2])
+  local t = tonumber(redis.call('GET', KEYS[2]) or '0')
+  if t == 0 then
+    t = redis.call('INCR', KEYS[2])
+  end
+  return t
+end
+return 0`
+	// fencedPutScript sets KEYS[2] to ARGV[2] if the fencing token KEYS[1] is ARGV[1], returning 1
+	// if it set the value.
+	fencedPutScript = `if redis.call('GET', KEYS[1]) == ARGV[1] then
+  redis.call('SET', KEYS[2], ARGV[2])
+  return 1
+end
+return 0`
+	// releaseScript deletes KEYS[1] if the holder ARGV[1] holds it.
+	releaseScript = `if redis.call('GET', KEYS[1]) == ARGV[1] then
+  return redis.call('DEL', KEYS[1])
+end
+return 0`
+)
+
+// Swap implements Store, comparing and setting the value atomically with a Lua script.
+func (r *Redis) Swap(ctx context.Context, key string, old, value []byte) (bool, error) {
+	exists := "0"
+	if old != nil {
+		exists = "1"
+	}
+	reply, err := r.do(ctx, "EVAL", swapScript, "1", key, exists, string(old), string(value))
+	if err != nil {
+		return false, err
+	}
+	return reply == int64(1), nil
+}
+
+// Acquire implements Store, checking and setting the lease atomically with a Lua script. The
+// fencing token of the lease is kept under the key of the lease with a "/token" suffix.
+func (r *Redis) Acquire(ctx context.Context, key, holder string, ttl time.Duration) (uint64, error) {
+	reply, err := r.do(ctx, "EVAL", acquireScript, "2", key, key+tokenSuffix, holder, strconv.FormatInt(ttl.Milliseconds(), 10))
+	if err != nil {
+		return 0, err
+	}
+	token, ok := reply.(int64)
+	if !ok || token < 0 {
+		return 0, fmt.Errorf("redis: unexpected reply %v to acquiring a lease", reply)
+	}
+	return uint64(token), nil
+}
+
+// PutFenced implements Store, checking the fencing token and setting the value atomically with a
+// Lua script.
+func (r *Redis) PutFenced(ctx context.Context, key string, value []byte, lease string, token uint64) (bool, error) {
+	reply, err := r.do(ctx, "EVAL", fencedPutScript, "2", lease+tokenSuffix, key, strconv.FormatUint(token, 10), string(value))
+	if err != nil {
+		return false, err
+	}
+	return reply == int64(1), nil
+}
+
+// Release implements Store.
+func (r *Redis) Release(ctx context.Context, key, holder string) error {
+	_, err := r.do(ctx, "EVAL", releaseScript, "1", key, holder)
+	return err
+}
+
+// tokenSuffix suffixes the key of a lease to form the key of its fencing token.
+const tokenSuffix = "/token"
+
+// redisGlobEscaper escapes the special characters of Redis glob patterns.
+var redisGlobEscaper = strings.NewReplacer(`\`, `\\`, `*`, `\*`, `?`, `\?`, `[`, `\[`, `]`, `\]`)
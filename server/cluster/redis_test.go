@@ -0,0 +1,425 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"bufio"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"flag"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/h-fam/errdiff"
+
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+)
+
+var redisAddr = flag.String("redis_addr", "", "Address of a Redis server to also run the tests of the Redis store against, e.g. localhost:6379.")
+
+// fakeRedisServer serves the commands used by the Redis store from the memory store, requiring
+// the password if set.
+type fakeRedisServer struct {
+	addr string
+
+	mu    sync.Mutex
+	conns []net.Conn
+	dials int
+}
+
+// newFakeRedis starts a fake Redis server, serving TLS if tlsConfig is set.
+func newFakeRedis(t *testing.T, password string, tlsConfig *tls.Config) *fakeRedisServer {
+	t.Helper()
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("net.Listen() err = %v", err)
+	}
+	t.Cleanup(func() { lis.Close() })
+	if tlsConfig != nil {
+		lis = tls.NewListener(lis, tlsConfig)
+	}
+	f := &fakeRedisServer{addr: lis.Addr().String()}
+	mem := NewMemory()
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			f.mu.Lock()
+			f.conns = append(f.conns, conn)
+			f.dials++
+			f.mu.Unlock()
+			go serveRedis(conn, mem, password)
+		}
+	}()
+	return f
+}
+
+// fakeRedis starts a fake Redis server and returns its address.
+func fakeRedis(t *testing.T, password string) string {
+	return newFakeRedis(t, password, nil).addr
+}
+
+// dropConns closes the connections of the clients, as a restart of the server would.
+func (f *fakeRedisServer) dropConns() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, c := range f.conns {
+		c.Close()
+	}
+	f.conns = nil
+}
+
+// dialed returns how many connections the clients opened.
+func (f *fakeRedisServer) dialed() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.dials
+}
+
+func serveRedis(conn net.Conn, mem *Memory, password string) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	authenticated := password == ""
+	for {
+		req, err := readReply(r)
+		if err != nil {
+			return
+		}
+		var args []string
+		for _, a := range req.([]any) {
+			args = append(args, a.(string))
+		}
+		ctx := context.Background()
+		var reply string
+		switch cmd := strings.ToUpper(args[0]); {
+		case cmd == "AUTH":
+			if args[1] != password {
+				reply = "-WRONGPASS invalid password\r\n"
+				break
+			}
+			authenticated = true
+			reply = "+OK\r\n"
+		case !authenticated:
+			reply = "-NOAUTH Authentication required.\r\n"
+		case cmd == "GET":
+			v, ok, _ := mem.Get(ctx, args[1])
+			reply = "$-1\r\n"
+			if ok {
+				reply = fmt.Sprintf("$%d\r\n%s\r\n", len(v), v)
+			}
+		case cmd == "SET":
+			var ttl time.Duration
+			if len(args) == 5 {
+				fmt.Sscan(args[4], &ttl)
+				ttl *= time.Millisecond
+			}
+			mem.Put(ctx, args[1], []byte(args[2]), ttl)
+			reply = "+OK\r\n"
+		case cmd == "DEL":
+			mem.Delete(ctx, args[1])
+			reply = ":1\r\n"
+		case cmd == "EVAL" && args[1] == swapScript:
+			var old []byte
+			if args[4] == "1" {
+				old = []byte(args[5])
+			}
+			ok, _ := mem.Swap(ctx, args[3], old, []byte(args[6]))
+			reply = ":0\r\n"
+			if ok {
+				reply = ":1\r\n"
+			}
+		case cmd == "EVAL" && args[1] == acquireScript:
+			var ttl time.Duration
+			fmt.Sscan(args[6], &ttl)
+			token, _ := mem.Acquire(ctx, args[3], args[5], ttl*time.Millisecond)
+			reply = fmt.Sprintf(":%d\r\n", token)
+		case cmd == "EVAL" && args[1] == fencedPutScript:
+			var token uint64
+			fmt.Sscan(args[5], &token)
+			ok, _ := mem.PutFenced(ctx, args[4], []byte(args[6]), strings.TrimSuffix(args[3], tokenSuffix), token)
+			reply = ":0\r\n"
+			if ok {
+				reply = ":1\r\n"
+			}
+		case cmd == "EVAL" && args[1] == releaseScript:
+			mem.Release(ctx, args[3], args[4])
+			reply = ":1\r\n"
+		case cmd == "SCAN":
+			values, _ := mem.List(ctx, strings.TrimSuffix(args[3], "*"))
+			reply = fmt.Sprintf("*2\r\n$1\r\n0\r\n*%d\r\n", len(values))
+			for k := range values {
+				reply += fmt.Sprintf("$%d\r\n%s\r\n", len(k), k)
+			}
+		default:
+			reply = "-ERR unknown command\r\n"
+		}
+		if _, err := conn.Write([]byte(reply)); err != nil {
+			return
+		}
+	}
+}
+
+func TestRedis(t *testing.T) {
+	testRedis(t, NewRedis(fakeRedis(t, "secret"), "secret", 0))
+
+	_, _, err := NewRedis(fakeRedis(t, "secret"), "wrong", 0).Get(context.Background(), "devices/123B")
+	if diff := errdiff.Substring(err, "WRONGPASS"); diff != "" {
+		t.Errorf("Get() with wrong password %s", diff)
+	}
+}
+
+// TestRealRedis runs the tests of the Redis store against the server of --redis_addr.
+func TestRealRedis(t *testing.T) {
+	if *redisAddr == "" {
+		t.Skip("set --redis_addr to test against a Redis server")
+	}
+	testRedis(t, NewRedis(*redisAddr, "", 0))
+}
+
+// testRedis tests the Redis store r, keeping its keys under a prefix of its own.
+func testRedis(t *testing.T, redis *Redis) {
+	ctx := context.Background()
+	r := &prefixed{prefix: fmt.Sprintf("bootz-test-%d/", time.Now().UnixNano()), Store: redis}
+	t.Cleanup(func() {
+		for _, k := range []string{"devices/123A", "devices/123B", "signature/1", "counter", "leader", "leader" + tokenSuffix, "published"} {
+			r.Delete(ctx, k)
+		}
+	})
+	if err := r.Put(ctx, "devices/123A", []byte("a"), 0); err != nil {
+		t.Fatalf("Put() err = %v", err)
+	}
+	if err := r.Put(ctx, "devices/123B", []byte("b\r\nb"), time.Hour); err != nil {
+		t.Fatalf("Put() err = %v", err)
+	}
+	if err := r.Put(ctx, "signature/1", []byte("sig"), time.Hour); err != nil {
+		t.Fatalf("Put() err = %v", err)
+	}
+	if v, ok, err := r.Get(ctx, "devices/123B"); err != nil || !ok || string(v) != "b\r\nb" {
+		t.Errorf("Get() = %q, %v, %v, want %q, true, nil", v, ok, err, "b\r\nb")
+	}
+	if err := r.Delete(ctx, "devices/123A"); err != nil {
+		t.Fatalf("Delete() err = %v", err)
+	}
+	if _, ok, err := r.Get(ctx, "devices/123A"); err != nil || ok {
+		t.Errorf("Get() of deleted key = %v, %v, want false, nil", ok, err)
+	}
+	got, err := r.List(ctx, "devices/")
+	if err != nil {
+		t.Fatalf("List() err = %v", err)
+	}
+	if diff := cmp.Diff(map[string][]byte{"devices/123B": []byte("b\r\nb")}, got); diff != "" {
+		t.Errorf("List() diff (-want +got):\n%s", diff)
+	}
+
+	swaps := []struct {
+		desc     string
+		old, new string
+		want     bool
+	}{
+		{desc: "Swap of an absent key", new: "1", want: true},
+		{desc: "Swap of an existing key as absent", new: "2", want: false},
+		{desc: "Swap of a stale value", old: "0", new: "2", want: false},
+		{desc: "Swap of the current value", old: "1", new: "2", want: true},
+	}
+	for _, swap := range swaps {
+		var old []byte
+		if swap.old != "" {
+			old = []byte(swap.old)
+		}
+		if ok, err := r.Swap(ctx, "counter", old, []byte(swap.new)); err != nil || ok != swap.want {
+			t.Errorf("%s: Swap() = %v, %v, want %v, nil", swap.desc, ok, err, swap.want)
+		}
+	}
+	if v, _, err := r.Get(ctx, "counter"); err != nil || string(v) != "2" {
+		t.Errorf("Get() after Swap() = %q, %v, want %q, nil", v, err, "2")
+	}
+
+	first, err := r.Acquire(ctx, "leader", "a", time.Minute)
+	if err != nil || first == 0 {
+		t.Errorf("Acquire() of free lease = %v, %v, want a token, nil", first, err)
+	}
+	if token, err := r.Acquire(ctx, "leader", "a", time.Minute); err != nil || token != first {
+		t.Errorf("Acquire() renewing the lease = %v, %v, want %v, nil", token, err, first)
+	}
+	if token, err := r.Acquire(ctx, "leader", "b", time.Minute); err != nil || token != 0 {
+		t.Errorf("Acquire() of held lease = %v, %v, want 0, nil", token, err)
+	}
+	if ok, err := r.PutFenced(ctx, "published", []byte("a"), "leader", first); err != nil || !ok {
+		t.Errorf("PutFenced() of the holder = %v, %v, want true, nil", ok, err)
+	}
+	if err := r.Release(ctx, "leader", "a"); err != nil {
+		t.Fatalf("Release() err = %v", err)
+	}
+	second, err := r.Acquire(ctx, "leader", "b", time.Minute)
+	if err != nil || second <= first {
+		t.Errorf("Acquire() of released lease = %v, %v, want a token above %v, nil", second, err, first)
+	}
+	if ok, err := r.PutFenced(ctx, "published", []byte("stale"), "leader", first); err != nil || ok {
+		t.Errorf("PutFenced() of the previous holder = %v, %v, want false, nil", ok, err)
+	}
+	if v, _, err := r.Get(ctx, "published"); err != nil || string(v) != "a" {
+		t.Errorf("Get() after a fenced out PutFenced() = %q, %v, want %q, nil", v, err, "a")
+	}
+}
+
+func TestRedisTLS(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "redis"},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, key.Public(), key)
+	if err != nil {
+		t.Fatalf("x509.CreateCertificate() err = %v", err)
+	}
+	f := newFakeRedis(t, "", &tls.Config{Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}}})
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600); err != nil {
+		t.Fatalf("unable to write CA cert: %v", err)
+	}
+	addr := strings.Replace(f.addr, "127.0.0.1", "localhost", 1)
+
+	tests := []struct {
+		desc    string
+		cfg     *epb.RedisStore
+		wantErr string
+	}{{
+		desc: "Trusted server",
+		cfg:  &epb.RedisStore{Address: addr, Tls: true, TlsCaCertFile: caFile},
+	}, {
+		desc:    "Untrusted server",
+		cfg:     &epb.RedisStore{Address: addr, Tls: true},
+		wantErr: "certificate",
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s, err := New(&epb.Cluster{Store: &epb.Cluster_Redis{Redis: test.cfg}})
+			if err != nil {
+				t.Fatalf("New() err = %v", err)
+			}
+			err = s.Put(context.Background(), "a", []byte("a"), 0)
+			if diff := errdiff.Substring(err, test.wantErr); diff != "" {
+				t.Errorf("Put() over TLS %s", diff)
+			}
+		})
+	}
+}
+
+func TestRedisPool(t *testing.T) {
+	ctx := context.Background()
+	f := newFakeRedis(t, "", nil)
+	r := NewRedisWithOptions(f.addr, RedisOptions{PoolSize: 2})
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if err := r.Put(ctx, fmt.Sprintf("key/%d", i), []byte("v"), 0); err != nil {
+				t.Errorf("Put() err = %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+	if got := f.dialed(); got < 1 || got > 2 {
+		t.Errorf("Put() opened %d connections, want 1 or 2", got)
+	}
+	got, err := r.List(ctx, "key/")
+	if err != nil || len(got) != 20 {
+		t.Errorf("List() = %d keys, %v, want 20, nil", len(got), err)
+	}
+}
+
+func TestRedisReconnect(t *testing.T) {
+	ctx := context.Background()
+	f := newFakeRedis(t, "", nil)
+	r := NewRedis(f.addr, "", 0)
+	if err := r.Put(ctx, "a", []byte("a"), 0); err != nil {
+		t.Fatalf("Put() err = %v", err)
+	}
+	// The idle connection fails once the server drops it, and the next command reconnects.
+	f.dropConns()
+	r.Get(ctx, "a")
+	if v, ok, err := r.Get(ctx, "a"); err != nil || !ok || string(v) != "a" {
+		t.Errorf("Get() after a dropped connection = %q, %v, %v, want %q, true, nil", v, ok, err, "a")
+	}
+
+	// Connecting to an unreachable server is backed off.
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("net.Listen() err = %v", err)
+	}
+	addr := lis.Addr().String()
+	lis.Close()
+	now := time.Now()
+	down := NewRedis(addr, "", 0)
+	down.now = func() time.Time { return now }
+	if _, _, err := down.Get(ctx, "a"); err == nil {
+		t.Fatalf("Get() of an unreachable server err = nil, want error")
+	}
+	_, _, err = down.Get(ctx, "a")
+	if diff := errdiff.Substring(err, "retrying in 100ms"); diff != "" {
+		t.Errorf("Get() during the backoff %s", diff)
+	}
+	now = now.Add(minBackoff)
+	_, _, err = down.Get(ctx, "a")
+	if diff := errdiff.Substring(err, "unable to connect"); diff != "" {
+		t.Errorf("Get() after the backoff %s", diff)
+	}
+	_, _, err = down.Get(ctx, "a")
+	if diff := errdiff.Substring(err, "retrying in 200ms"); diff != "" {
+		t.Errorf("Get() during the doubled backoff %s", diff)
+	}
+}
+
+func TestMemoryExpiry(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	m := NewMemory()
+	m.now = func() time.Time { return now }
+	m.Put(ctx, "a", []byte("a"), time.Minute)
+	m.Put(ctx, "b", []byte("b"), 0)
+	now = now.Add(2 * time.Minute)
+	if _, ok, _ := m.Get(ctx, "a"); ok {
+		t.Errorf("Get() of expired key = true, want false")
+	}
+	got, _ := m.List(ctx, "")
+	if diff := cmp.Diff(map[string][]byte{"b": []byte("b")}, got); diff != "" {
+		t.Errorf("List() diff (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,698 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package devicestate tracks the bootstrap state of each control card and
+// fixed chassis, gating devices which keep requesting bootstrap data without
+// ever reporting a successful bootstrap.
+package devicestate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openconfig/bootz/server/cluster"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	bpb "github.com/openconfig/bootz/proto/bootz"
+	log "github.com/openconfig/bootz/server/logging"
+)
+
+// State is the bootstrap state of a device.
+type State string
+
+const (
+	// Idle devices have not been sent bootstrap data, or had their state reset.
+	Idle State = "idle"
+	// BootstrapSent devices were sent bootstrap data and have not reported its outcome yet.
+	BootstrapSent State = "bootstrap_sent"
+	// StatusOK devices reported a successful bootstrap.
+	StatusOK State = "status_ok"
+	// StatusFailed devices reported a failed bootstrap.
+	StatusFailed State = "status_failed"
+)
+
+// ConfigStage is the config stage of a device of a chassis bootstrapped in two stages.
+type ConfigStage string
+
+const (
+	// SingleStage devices are bootstrapped with a single config.
+	SingleStage ConfigStage = ""
+	// InitialStage devices are served the initial (day-0) config until they report a successful
+	// bootstrap.
+	InitialStage ConfigStage = "initial"
+	// FinalStage devices reported a successful bootstrap with the initial config, and are served
+	// the final (day-1) config.
+	FinalStage ConfigStage = "final"
+)
+
+const (
+	// storePrefix prefixes the keys of the device states in the store of a cluster.
+	storePrefix = "devices/"
+	// storeTimeout bounds each operation on the store of a cluster.
+	storeTimeout = 5 * time.Second
+	// seenInterval bounds how often the last contact of a device is saved and shared, so that
+	// devices polling the server don't write their state on every request.
+	seenInterval = time.Minute
+	// swapAttempts bounds how often a transition is retried when other instances of a cluster
+	// change the state of the device concurrently.
+	swapAttempts = 10
+	// timestampCounters is the smallest request counter taken for a timestamp in milliseconds
+	// since the epoch (September 2001), smaller counters are sequence numbers.
+	timestampCounters = uint64(1e12)
+	// maxCounterSkew bounds how far a timestamp request counter may be ahead of the current time.
+	maxCounterSkew = uint64(5 * time.Minute / time.Millisecond)
+	// maxCounterLead bounds how far a sequence request counter may be ahead of the last accepted
+	// one, so a single request can't move the counter of a device out of its reach.
+	maxCounterLead = uint64(1000)
+)
+
+// Artifacts are the hashes of the intended image and of the vendor config of bootstrap data, each
+// empty if unknown.
+type Artifacts struct {
+	// ImageHash is the os_image_hash of the intended image.
+	ImageHash string `json:"image_hash,omitempty"`
+	// ConfigHash is the hex encoded SHA-256 hash of the vendor config.
+	ConfigHash string `json:"config_hash,omitempty"`
+}
+
+// Drift returns the artifacts, out of image and config, whose hashes differ from those served.
+// Artifacts with an unknown hash are not compared.
+func (a Artifacts) Drift(served Artifacts) []string {
+	var drift []string
+	if a.ImageHash != "" && served.ImageHash != "" && !strings.EqualFold(a.ImageHash, served.ImageHash) {
+		drift = append(drift, "image")
+	}
+	if a.ConfigHash != "" && served.ConfigHash != "" && !strings.EqualFold(a.ConfigHash, served.ConfigHash) {
+		drift = append(drift, "config")
+	}
+	return drift
+}
+
+// Device is the bootstrap state of a control card, or of a fixed chassis.
+type Device struct {
+	SerialNumber string `json:"serial_number"`
+	State        State  `json:"state"`
+	// Retries counts the bootstrap data sent since the last successful bootstrap, beyond the first.
+	Retries int `json:"retries"`
+	// Changed is the time of the last transition.
+	Changed time.Time `json:"changed"`
+	// SentAt is the time bootstrap data was last sent, if ever.
+	SentAt time.Time `json:"sent_at,omitempty"`
+	// ReportedAt is the time a status was last reported, if ever.
+	ReportedAt time.Time `json:"reported_at,omitempty"`
+	// Stage is the config stage of the bootstrap data last sent, or the final stage once a
+	// successful bootstrap of the initial stage was reported.
+	Stage ConfigStage `json:"stage,omitempty"`
+	// RequestCounter is the last request counter accepted from the device under replay
+	// protection, if any.
+	RequestCounter uint64 `json:"request_counter,omitempty"`
+	// LastSeen is the time of the last request of the device, if any was recorded.
+	LastSeen time.Time `json:"last_seen,omitempty"`
+	// LastAddress is the address the last request of the device came from.
+	LastAddress string `json:"last_address,omitempty"`
+	// Served are the artifacts of the bootstrap data last sent to the device.
+	Served Artifacts `json:"served"`
+	// Applied are the artifacts the device last reported to have applied.
+	Applied Artifacts `json:"applied"`
+}
+
+// Drift returns the artifacts the device reported to have applied other than those served.
+func (d Device) Drift() []string {
+	return d.Applied.Drift(d.Served)
+}
+
+// LastContact returns the time of the last request of the device, or the time bootstrap data was
+// last sent to it for states recorded before requests were.
+func (d Device) LastContact() time.Time {
+	if d.LastSeen.After(d.SentAt) {
+		return d.LastSeen
+	}
+	return d.SentAt
+}
+
+// Silent returns whether the device was sent bootstrap data and has neither reported its outcome
+// nor contacted the server since, for at least the given duration.
+func (d Device) Silent(now time.Time, since time.Duration) bool {
+	return d.State == BootstrapSent && !now.Before(d.LastContact().Add(since))
+}
+
+// Machine holds the state of every device which requested bootstrap data. It is safe for
+// concurrent use.
+type Machine struct {
+	mu      sync.Mutex
+	devices map[string]*Device
+	// maxRetries bounds the retries of a device before it is refused bootstrap data. 0 disables the limit.
+	maxRetries int
+	// path of the file the states are persisted to, if any.
+	path string
+	// store shares the states with the other instances of a cluster, if set.
+	store cluster.Store
+	now   func() time.Time
+}
+
+// New returns a machine without devices, refusing bootstrap data to devices which retried more
+// than maxRetries times without reporting a successful bootstrap. A maxRetries of 0 disables the limit.
+func New(maxRetries int) *Machine {
+	return &Machine{
+		devices:    map[string]*Device{},
+		maxRetries: maxRetries,
+		now:        time.Now,
+	}
+}
+
+// Persist loads the states saved to path, if it exists, and saves the states to it on every transition.
+func (m *Machine) Persist(path string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	data, err := os.ReadFile(path)
+	switch {
+	case os.IsNotExist(err):
+	case err != nil:
+		return fmt.Errorf("unable to read device state file: %v", err)
+	default:
+		var devices []*Device
+		if err := json.Unmarshal(data, &devices); err != nil {
+			return fmt.Errorf("unable to parse device state file %s: %v", path, err)
+		}
+		for _, d := range devices {
+			m.devices[d.SerialNumber] = d
+		}
+		log.Infof("Loaded the bootstrap state of %d devices from %s", len(devices), path)
+	}
+	m.path = path
+	return nil
+}
+
+// Share shares the states with the other instances of a cluster through the store, replacing the
+// states of the machine with those of the store. The state of a device is read from the store
+// before each use, and every transition is written to it with a compare-and-set of the state it
+// was applied to, so concurrent transitions of several instances are applied one after the
+// other. The store is never accessed while holding the lock of the machine. The local states
+// serve as a fallback while the store is unreachable.
+func (m *Machine) Share(store cluster.Store) error {
+	ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+	defer cancel()
+	devices, err := loadShared(ctx, store)
+	if err != nil {
+		return fmt.Errorf("unable to load the device states of the cluster: %v", err)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.devices = devices
+	m.store = store
+	log.Infof("Loaded the bootstrap state of %d devices from the cluster", len(devices))
+	return nil
+}
+
+// loadShared returns the states of every device of the store.
+func loadShared(ctx context.Context, store cluster.Store) (map[string]*Device, error) {
+	values, err := store.List(ctx, storePrefix)
+	if err != nil {
+		return nil, err
+	}
+	devices := map[string]*Device{}
+	for key, v := range values {
+		d := &Device{}
+		if err := json.Unmarshal(v, d); err != nil {
+			return nil, fmt.Errorf("unable to parse the state of %s: %v", key, err)
+		}
+		devices[d.SerialNumber] = d
+	}
+	return devices, nil
+}
+
+// shared returns the store of the cluster, if any.
+func (m *Machine) shared() cluster.Store {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.store
+}
+
+// pull reads the state of the device with the given serial from the store of the cluster, if
+// any, into the local states. The caller must not hold m.mu.
+func (m *Machine) pull(serial string) {
+	store := m.shared()
+	if store == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+	defer cancel()
+	v, ok, err := store.Get(ctx, storePrefix+serial)
+	if err != nil {
+		log.Warningf("Unable to read the state of device %v from the cluster, using the local state: %v", serial, err)
+		return
+	}
+	if !ok {
+		m.cache(serial, nil, false)
+		return
+	}
+	d := &Device{}
+	if err := json.Unmarshal(v, d); err != nil {
+		log.Errorf("Unable to parse the state of device %v from the cluster: %v", serial, err)
+		return
+	}
+	m.cache(serial, d, false)
+}
+
+// pullAll reads the states of every device from the store of the cluster, if any, replacing the
+// local states. The caller must not hold m.mu.
+func (m *Machine) pullAll() {
+	store := m.shared()
+	if store == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+	defer cancel()
+	devices, err := loadShared(ctx, store)
+	if err != nil {
+		log.Warningf("Unable to read the device states from the cluster, using the local states: %v", err)
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.devices = devices
+}
+
+// update applies the transition fn to the state of the device with the given serial, which is
+// idle if the device is unknown, and saves and shares the state if fn returns true. fn is told
+// whether the device is known, and unknown devices are only added if fn returns true. Errors of fn
+// are returned without changing the state. In a cluster, the state is read from the store and
+// written back with a compare-and-set outside of m.mu, running fn again on the newer state if
+// another instance changed it in between, so no transition is lost. The local state serves as a
+// fallback while the store is unreachable.
+func (m *Machine) update(serial string, fn func(d *Device, known bool) (bool, error)) error {
+	return m.apply(serial, false, fn)
+}
+
+// updateStrict is like update, but fails with an Unavailable error while the store of the cluster
+// is unreachable instead of falling back to the local state, for transitions which mustn't diverge
+// between the instances.
+func (m *Machine) updateStrict(serial string, fn func(d *Device, known bool) (bool, error)) error {
+	return m.apply(serial, true, fn)
+}
+
+// apply implements update and updateStrict.
+func (m *Machine) apply(serial string, strict bool, fn func(d *Device, known bool) (bool, error)) error {
+	if store := m.shared(); store != nil {
+		done, err := m.updateShared(store, serial, fn)
+		if done {
+			return err
+		}
+		if strict {
+			return status.Errorf(codes.Unavailable, "the state of serial# %s can't be shared with the cluster, try again: %v", serial, err)
+		}
+		log.Warningf("Using the local state of device %v: %v", serial, err)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	d, known := m.devices[serial]
+	if !known {
+		d = m.idle(serial)
+	}
+	next := *d
+	changed, err := fn(&next, known)
+	if err != nil {
+		return err
+	}
+	if known || changed {
+		m.devices[serial] = &next
+	}
+	if changed {
+		m.save()
+	}
+	return nil
+}
+
+// updateShared applies the transition fn to the state of the device with the given serial in the
+// store of the cluster, returning false and the reason if the store is unreachable.
+func (m *Machine) updateShared(store cluster.Store, serial string, fn func(d *Device, known bool) (bool, error)) (bool, error) {
+	key := storePrefix + serial
+	for i := 0; i < swapAttempts; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+		old, known, err := store.Get(ctx, key)
+		cancel()
+		if err != nil {
+			return false, fmt.Errorf("unable to read the state from the cluster: %v", err)
+		}
+		current := m.idle(serial)
+		if known {
+			if err := json.Unmarshal(old, current); err != nil {
+				return false, fmt.Errorf("unable to parse the state from the cluster: %v", err)
+			}
+		} else {
+			old = nil
+		}
+		next := *current
+		changed, err := fn(&next, known)
+		if !changed || err != nil {
+			switch {
+			case !known:
+				m.cache(serial, nil, false)
+			case err != nil:
+				m.cache(serial, current, false)
+			default:
+				m.cache(serial, &next, false)
+			}
+			return true, err
+		}
+		data, err := json.Marshal(&next)
+		if err != nil {
+			return true, status.Errorf(codes.Internal, "unable to encode the state of device %v: %v", serial, err)
+		}
+		ctx, cancel = context.WithTimeout(context.Background(), storeTimeout)
+		swapped, err := store.Swap(ctx, key, old, data)
+		cancel()
+		if err != nil {
+			return false, fmt.Errorf("unable to share the state with the cluster: %v", err)
+		}
+		if swapped {
+			m.cache(serial, &next, true)
+			return true, nil
+		}
+	}
+	return true, status.Errorf(codes.Aborted, "the state of serial# %s kept changing concurrently, try again", serial)
+}
+
+// cache replaces the local state of the device with the given serial by d, removing it if d is
+// nil, and saves the states to the persistence file if save is set.
+func (m *Machine) cache(serial string, d *Device, save bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if d == nil {
+		delete(m.devices, serial)
+	} else {
+		m.devices[serial] = d
+	}
+	if save {
+		m.save()
+	}
+}
+
+// save writes the states to the persistence file. The caller must hold m.mu.
+func (m *Machine) save() {
+	if m.path == "" {
+		return
+	}
+	data, err := json.MarshalIndent(m.list(), "", "  ")
+	if err != nil {
+		log.Errorf("Unable to encode device states: %v", err)
+		return
+	}
+	// Write to a temporary file first so a crash never leaves a truncated state file.
+	tmp, err := os.CreateTemp(filepath.Dir(m.path), filepath.Base(m.path)+".*")
+	if err != nil {
+		log.Errorf("Unable to save device states: %v", err)
+		return
+	}
+	defer os.Remove(tmp.Name())
+	_, err = tmp.Write(data)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), m.path)
+	}
+	if err != nil {
+		log.Errorf("Unable to save device states: %v", err)
+	}
+}
+
+// idle returns the state of a new device with the given serial.
+func (m *Machine) idle(serial string) *Device {
+	return &Device{SerialNumber: serial, State: Idle, Changed: m.now()}
+}
+
+// transition moves the device to the state.
+func (m *Machine) transition(d *Device, s State) {
+	if d.State != s {
+		log.Infof("Device %v changed bootstrap state from %v to %v", d.SerialNumber, d.State, s)
+		d.State = s
+		d.Changed = m.now()
+	}
+}
+
+// Check returns a ResourceExhausted error if the device with the given serial retried more times
+// than allowed without reporting a successful bootstrap.
+func (m *Machine) Check(serial string) error {
+	if m.maxRetries <= 0 {
+		return nil
+	}
+	m.pull(serial)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	d, ok := m.devices[serial]
+	if !ok {
+		return nil
+	}
+	if (d.State == BootstrapSent || d.State == StatusFailed) && d.Retries >= m.maxRetries {
+		return status.Errorf(codes.ResourceExhausted, "serial# %s retried bootstrapping %d times without success, reset its state to allow further attempts", serial, d.Retries)
+	}
+	return nil
+}
+
+// AcceptCounter records the request counter of an authenticated bootstrap request of the device
+// with the given serial, after checking it like CheckCounter. The counter is persisted, so that
+// restarts reject the replays too, and set with a compare-and-set in the store of a cluster, so
+// that of concurrent requests with the same counter to several instances only one is accepted.
+func (m *Machine) AcceptCounter(serial string, counter uint64) error {
+	return m.checkCounter(serial, counter, true)
+}
+
+// CheckCounter checks the request counter of a bootstrap request of the device with the given
+// serial without recording it, for requests which aren't authenticated and thus mustn't move the
+// counter of the device. Replayed requests, whose counter does not exceed the last one accepted,
+// are rejected with a PermissionDenied error. Counters too far ahead are rejected with an
+// InvalidArgument error: timestamps more than maxCounterSkew ahead of the current time, and
+// sequence numbers more than maxCounterLead ahead of the last accepted one. In a cluster, counters
+// are rejected with an Unavailable error while the store is unreachable, since the local state may
+// miss counters accepted by other instances.
+func (m *Machine) CheckCounter(serial string, counter uint64) error {
+	return m.checkCounter(serial, counter, false)
+}
+
+// checkCounter checks the request counter of the device with the given serial, recording it if
+// accept is set.
+func (m *Machine) checkCounter(serial string, counter uint64, accept bool) error {
+	if counter == 0 {
+		return status.Errorf(codes.InvalidArgument, "replay protection requires a request counter for serial# %s", serial)
+	}
+	return m.updateStrict(serial, func(d *Device, _ bool) (bool, error) {
+		if counter <= d.RequestCounter {
+			return false, status.Errorf(codes.PermissionDenied, "replayed request for serial# %s: request counter %d does not exceed the last accepted counter %d", serial, counter, d.RequestCounter)
+		}
+		var limit uint64
+		switch {
+		case counter >= timestampCounters:
+			limit = uint64(m.now().UnixMilli()) + maxCounterSkew
+		case d.RequestCounter != 0:
+			limit = d.RequestCounter + maxCounterLead
+		default:
+			// The first counter of a device sets the base of its sequence.
+			limit = timestampCounters - 1
+		}
+		if counter > limit {
+			return false, status.Errorf(codes.InvalidArgument, "request counter %d of serial# %s is too far ahead, it may be at most %d", counter, serial, limit)
+		}
+		if !accept {
+			return false, nil
+		}
+		d.RequestCounter = counter
+		return true, nil
+	})
+}
+
+// Stage returns the config stage to serve the device with the given serial, for a chassis
+// bootstrapped in two stages: the final stage once the device reported a successful bootstrap of
+// the initial stage, else the initial stage.
+func (m *Machine) Stage(serial string) ConfigStage {
+	m.pull(serial)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if d, ok := m.devices[serial]; ok && d.Stage == FinalStage {
+		return FinalStage
+	}
+	return InitialStage
+}
+
+// Sent records that bootstrap data of the config stage, with the served artifacts, was sent to
+// the device with the given serial.
+func (m *Machine) Sent(serial string, stage ConfigStage, served Artifacts) {
+	err := m.update(serial, func(d *Device, _ bool) (bool, error) {
+		switch d.State {
+		case BootstrapSent, StatusFailed:
+			d.Retries++
+		default:
+			d.Retries = 0
+		}
+		d.SentAt = m.now()
+		d.Stage = stage
+		d.Served = served
+		m.transition(d, BootstrapSent)
+		return true, nil
+	})
+	if err != nil {
+		log.Errorf("Unable to record the bootstrap data sent to device %v: %v", serial, err)
+	}
+}
+
+// Report records the bootstrap status reported by the device with the given serial, and the
+// artifacts it applied if it reported any. Devices reporting the bootstrap as initiated keep their
+// state. Devices reporting a successful bootstrap of the initial config stage move to the final
+// stage.
+func (m *Machine) Report(serial string, st bpb.ReportStatusRequest_BootstrapStatus, applied Artifacts) {
+	err := m.update(serial, func(d *Device, _ bool) (bool, error) {
+		d.ReportedAt = m.now()
+		if applied != (Artifacts{}) {
+			d.Applied = applied
+			if drift := d.Drift(); len(drift) > 0 {
+				log.Warningf("Device %v applied %v other than served: served %+v, applied %+v", d.SerialNumber, strings.Join(drift, " and "), d.Served, d.Applied)
+			}
+		}
+		switch st {
+		case bpb.ReportStatusRequest_BOOTSTRAP_STATUS_SUCCESS:
+			m.transition(d, StatusOK)
+			if d.Stage == InitialStage {
+				log.Infof("Device %v completed the initial config stage, serving the final config from now on", d.SerialNumber)
+				d.Stage = FinalStage
+			}
+		case bpb.ReportStatusRequest_BOOTSTRAP_STATUS_FAILURE:
+			m.transition(d, StatusFailed)
+		}
+		return true, nil
+	})
+	if err != nil {
+		log.Errorf("Unable to record the bootstrap status reported by device %v: %v", serial, err)
+	}
+}
+
+// Seen records a request of the device with the given serial from addr. Requests of devices
+// without a state are ignored, so that unknown devices don't add states.
+func (m *Machine) Seen(serial, addr string) {
+	err := m.update(serial, func(d *Device, known bool) (bool, error) {
+		if !known {
+			return false, nil
+		}
+		now := m.now()
+		changed := d.LastAddress != addr || now.Sub(d.LastSeen) >= seenInterval
+		d.LastSeen = now
+		d.LastAddress = addr
+		return changed, nil
+	})
+	if err != nil {
+		log.Errorf("Unable to record a request of device %v: %v", serial, err)
+	}
+}
+
+// Reset moves the device with the given serial back to the idle state, clearing its retries,
+// config stage, request counter and artifacts, e.g. after the device was factory reset.
+func (m *Machine) Reset(serial string) error {
+	return m.update(serial, func(d *Device, known bool) (bool, error) {
+		if !known {
+			return false, status.Errorf(codes.NotFound, "no bootstrap state for serial# %s", serial)
+		}
+		d.Retries = 0
+		d.Stage = SingleStage
+		d.RequestCounter = 0
+		d.Served, d.Applied = Artifacts{}, Artifacts{}
+		m.transition(d, Idle)
+		return true, nil
+	})
+}
+
+// Get returns the state of the device with the given serial.
+func (m *Machine) Get(serial string) (Device, bool) {
+	m.pull(serial)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	d, ok := m.devices[serial]
+	if !ok {
+		return Device{}, false
+	}
+	return *d, true
+}
+
+// Devices returns the state of every device sorted by serial number.
+func (m *Machine) Devices() []Device {
+	m.pullAll()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var out []Device
+	for _, d := range m.list() {
+		out = append(out, *d)
+	}
+	return out
+}
+
+// Restore replaces the state of every device with devices, e.g. restored from a backup. In a
+// cluster, the restored states overwrite those of the store.
+func (m *Machine) Restore(devices []Device) {
+	restored := map[string]*Device{}
+	for _, d := range devices {
+		d := d
+		restored[d.SerialNumber] = &d
+	}
+	m.mu.Lock()
+	m.devices = restored
+	m.save()
+	store := m.store
+	m.mu.Unlock()
+	if store == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+	defer cancel()
+	shared, err := loadShared(ctx, store)
+	if err != nil {
+		log.Errorf("Unable to restore the device states of the cluster: %v", err)
+		return
+	}
+	for serial := range shared {
+		if _, ok := restored[serial]; ok {
+			continue
+		}
+		if err := store.Delete(ctx, storePrefix+serial); err != nil {
+			log.Errorf("Unable to remove the state of device %v from the cluster: %v", serial, err)
+		}
+	}
+	for serial, d := range restored {
+		data, err := json.Marshal(d)
+		if err != nil {
+			log.Errorf("Unable to encode the state of device %v: %v", serial, err)
+			continue
+		}
+		if err := store.Put(ctx, storePrefix+serial, data, 0); err != nil {
+			log.Errorf("Unable to share the state of device %v with the cluster: %v", serial, err)
+		}
+	}
+}
+
+// list returns the devices sorted by serial number. The caller must hold m.mu.
+func (m *Machine) list() []*Device {
+	out := make([]*Device, 0, len(m.devices))
+	for _, d := range m.devices {
+		out = append(out, d)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].SerialNumber < out[j].SerialNumber
+	})
+	return out
+}
@@ -0,0 +1,505 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package devicestate
+
+import (
+	"context"
+	"errors"
+	"math"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/bootz/server/cluster"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	bpb "github.com/openconfig/bootz/proto/bootz"
+)
+
+func TestTransitions(t *testing.T) {
+	m := New(2)
+	now := time.Unix(0, 0)
+	m.now = func() time.Time { return now }
+	report := func(st bpb.ReportStatusRequest_BootstrapStatus) func() {
+		return func() { m.Report("123A", st, Artifacts{}) }
+	}
+	tests := []struct {
+		desc        string
+		do          func()
+		wantState   State
+		wantRetries int
+		wantCode    codes.Code
+	}{{
+		desc:      "First request",
+		do:        func() { m.Sent("123A", SingleStage, Artifacts{}) },
+		wantState: BootstrapSent,
+	}, {
+		desc:      "Initiated keeps the state",
+		do:        report(bpb.ReportStatusRequest_BOOTSTRAP_STATUS_INITIATED),
+		wantState: BootstrapSent,
+	}, {
+		desc:        "Retry without status",
+		do:          func() { m.Sent("123A", SingleStage, Artifacts{}) },
+		wantState:   BootstrapSent,
+		wantRetries: 1,
+	}, {
+		desc:        "Failure",
+		do:          report(bpb.ReportStatusRequest_BOOTSTRAP_STATUS_FAILURE),
+		wantState:   StatusFailed,
+		wantRetries: 1,
+	}, {
+		desc:        "Retry after failure exhausts retries",
+		do:          func() { m.Sent("123A", SingleStage, Artifacts{}) },
+		wantState:   BootstrapSent,
+		wantRetries: 2,
+		wantCode:    codes.ResourceExhausted,
+	}, {
+		desc:        "Success",
+		do:          report(bpb.ReportStatusRequest_BOOTSTRAP_STATUS_SUCCESS),
+		wantState:   StatusOK,
+		wantRetries: 2,
+	}, {
+		desc:      "Request after success starts over",
+		do:        func() { m.Sent("123A", SingleStage, Artifacts{}) },
+		wantState: BootstrapSent,
+	}, {
+		desc: "Reset",
+		do: func() {
+			m.Sent("123A", SingleStage, Artifacts{})
+			m.Sent("123A", SingleStage, Artifacts{})
+			if err := m.Reset("123A"); err != nil {
+				t.Fatalf("Reset() err = %v, want nil", err)
+			}
+		},
+		wantState: Idle,
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			now = now.Add(time.Minute)
+			test.do()
+			d, ok := m.Get("123A")
+			if !ok {
+				t.Fatalf("Get() found no state, want one")
+			}
+			if d.State != test.wantState || d.Retries != test.wantRetries {
+				t.Errorf("Get() = %v with %d retries, want %v with %d retries", d.State, d.Retries, test.wantState, test.wantRetries)
+			}
+			if err := m.Check("123A"); status.Code(err) != test.wantCode {
+				t.Errorf("Check() err = %v, want code %v", err, test.wantCode)
+			}
+		})
+	}
+	if err := m.Reset("456"); status.Code(err) != codes.NotFound {
+		t.Errorf("Reset() of an unknown device err = %v, want code %v", err, codes.NotFound)
+	}
+	if err := m.Check("456"); err != nil {
+		t.Errorf("Check() of an unknown device err = %v, want nil", err)
+	}
+}
+
+func TestUnlimitedRetries(t *testing.T) {
+	m := New(0)
+	for i := 0; i < 10; i++ {
+		m.Sent("123A", SingleStage, Artifacts{})
+	}
+	if err := m.Check("123A"); err != nil {
+		t.Errorf("Check() without retry limit err = %v, want nil", err)
+	}
+}
+
+func TestStage(t *testing.T) {
+	m := New(0)
+	if got := m.Stage("123A"); got != InitialStage {
+		t.Errorf("Stage() of an unknown device = %q, want %q", got, InitialStage)
+	}
+	m.Sent("123A", InitialStage, Artifacts{})
+	m.Report("123A", bpb.ReportStatusRequest_BOOTSTRAP_STATUS_FAILURE, Artifacts{})
+	if got := m.Stage("123A"); got != InitialStage {
+		t.Errorf("Stage() after a failure = %q, want %q", got, InitialStage)
+	}
+	m.Sent("123A", InitialStage, Artifacts{})
+	m.Report("123A", bpb.ReportStatusRequest_BOOTSTRAP_STATUS_SUCCESS, Artifacts{})
+	if got := m.Stage("123A"); got != FinalStage {
+		t.Errorf("Stage() after a success = %q, want %q", got, FinalStage)
+	}
+	m.Sent("123A", FinalStage, Artifacts{})
+	m.Report("123A", bpb.ReportStatusRequest_BOOTSTRAP_STATUS_SUCCESS, Artifacts{})
+	if got := m.Stage("123A"); got != FinalStage {
+		t.Errorf("Stage() after a final success = %q, want %q", got, FinalStage)
+	}
+	if err := m.Reset("123A"); err != nil {
+		t.Fatalf("Reset() err = %v, want nil", err)
+	}
+	if got := m.Stage("123A"); got != InitialStage {
+		t.Errorf("Stage() after Reset() = %q, want %q", got, InitialStage)
+	}
+}
+
+func TestSeen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "states.json")
+	m := New(0)
+	if err := m.Persist(path); err != nil {
+		t.Fatalf("Persist() err = %v, want nil", err)
+	}
+	start := time.Unix(0, 0)
+	now := start
+	m.now = func() time.Time { return now }
+
+	m.Seen("123A", "10.0.0.1")
+	if _, ok := m.Get("123A"); ok {
+		t.Fatalf("Seen() added a state for an unknown device")
+	}
+	m.Sent("123A", SingleStage, Artifacts{})
+	now = now.Add(time.Second)
+	m.Seen("123A", "10.0.0.1")
+	d, _ := m.Get("123A")
+	if !d.LastSeen.Equal(now) || d.LastAddress != "10.0.0.1" {
+		t.Errorf("Get() last seen at %v from %q, want %v from 10.0.0.1", d.LastSeen, d.LastAddress, now)
+	}
+
+	tests := []struct {
+		desc  string
+		after time.Duration
+		since time.Duration
+		want  bool
+	}{
+		{desc: "Seen recently", after: time.Hour, since: 2 * time.Hour, want: false},
+		{desc: "Silent for long", after: 3 * time.Hour, since: 2 * time.Hour, want: true},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			if got := d.Silent(d.LastSeen.Add(test.after), test.since); got != test.want {
+				t.Errorf("Silent(+%v, %v) = %v, want %v", test.after, test.since, got, test.want)
+			}
+		})
+	}
+	m.Report("123A", bpb.ReportStatusRequest_BOOTSTRAP_STATUS_SUCCESS, Artifacts{})
+	d, _ = m.Get("123A")
+	if d.Silent(now.Add(24*time.Hour), time.Hour) {
+		t.Errorf("Silent() = true for a device which reported its status, want false")
+	}
+
+	// Requests within seenInterval from the same address are not saved.
+	now = now.Add(time.Second)
+	m.Seen("123A", "10.0.0.1")
+	restored := New(0)
+	if err := restored.Persist(path); err != nil {
+		t.Fatalf("Persist() err = %v, want nil", err)
+	}
+	if d, _ := restored.Get("123A"); !d.LastSeen.Equal(start.Add(time.Second)) {
+		t.Errorf("saved last seen = %v, want %v", d.LastSeen, start.Add(time.Second))
+	}
+	m.Seen("123A", "10.0.0.2")
+	if err := restored.Persist(path); err != nil {
+		t.Fatalf("Persist() err = %v, want nil", err)
+	}
+	if d, _ := restored.Get("123A"); d.LastAddress != "10.0.0.2" {
+		t.Errorf("saved last address = %q after a new address, want 10.0.0.2", d.LastAddress)
+	}
+}
+
+func TestAcceptCounter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "states.json")
+	m := New(0)
+	now := time.Now()
+	m.now = func() time.Time { return now }
+	if err := m.Persist(path); err != nil {
+		t.Fatalf("Persist() err = %v, want nil", err)
+	}
+	timestamp := uint64(now.UnixMilli())
+	tests := []struct {
+		desc     string
+		counter  uint64
+		wantCode codes.Code
+	}{
+		{desc: "No counter", counter: 0, wantCode: codes.InvalidArgument},
+		{desc: "First counter", counter: 5, wantCode: codes.OK},
+		{desc: "Replayed counter", counter: 5, wantCode: codes.PermissionDenied},
+		{desc: "Stale counter", counter: 4, wantCode: codes.PermissionDenied},
+		{desc: "Next counter", counter: 7, wantCode: codes.OK},
+		{desc: "Sequence counter too far ahead", counter: 7 + maxCounterLead + 1, wantCode: codes.InvalidArgument},
+		{desc: "Sequence counter at the lead", counter: 7 + maxCounterLead, wantCode: codes.OK},
+		{desc: "Counter too far ahead", counter: math.MaxUint64, wantCode: codes.InvalidArgument},
+		{desc: "Timestamp counter too far ahead", counter: timestamp + uint64(time.Hour/time.Millisecond), wantCode: codes.InvalidArgument},
+		{desc: "Timestamp counter", counter: timestamp, wantCode: codes.OK},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			if err := m.AcceptCounter("123A", test.counter); status.Code(err) != test.wantCode {
+				t.Errorf("AcceptCounter(%d) err = %v, want code %v", test.counter, err, test.wantCode)
+			}
+		})
+	}
+	// A restart must not reopen the replay window.
+	restarted := New(0)
+	if err := restarted.Persist(path); err != nil {
+		t.Fatalf("Persist() err = %v, want nil", err)
+	}
+	if err := restarted.AcceptCounter("123A", 8); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("AcceptCounter() of a replay after a restart err = %v, want code %v", err, codes.PermissionDenied)
+	}
+	if err := m.Reset("123A"); err != nil {
+		t.Fatalf("Reset() err = %v, want nil", err)
+	}
+	if err := m.AcceptCounter("123A", 1); err != nil {
+		t.Errorf("AcceptCounter() after Reset() err = %v, want nil", err)
+	}
+}
+
+func TestCheckCounter(t *testing.T) {
+	m := New(0)
+	if err := m.AcceptCounter("123A", 5); err != nil {
+		t.Fatalf("AcceptCounter() err = %v, want nil", err)
+	}
+	tests := []struct {
+		desc     string
+		counter  uint64
+		wantCode codes.Code
+	}{
+		{desc: "Replayed counter", counter: 5, wantCode: codes.PermissionDenied},
+		{desc: "Next counter", counter: 6, wantCode: codes.OK},
+		{desc: "Counter too far ahead", counter: 5 + maxCounterLead + 1, wantCode: codes.InvalidArgument},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			if err := m.CheckCounter("123A", test.counter); status.Code(err) != test.wantCode {
+				t.Errorf("CheckCounter(%d) err = %v, want code %v", test.counter, err, test.wantCode)
+			}
+		})
+	}
+	// Checked counters are not recorded, so they can't lock the device out.
+	if d, _ := m.Get("123A"); d.RequestCounter != 5 {
+		t.Errorf("RequestCounter = %d after CheckCounter(), want 5", d.RequestCounter)
+	}
+	if err := m.AcceptCounter("123A", 6); err != nil {
+		t.Errorf("AcceptCounter(6) after CheckCounter(6) err = %v, want nil", err)
+	}
+}
+
+func TestAcceptCounterShared(t *testing.T) {
+	store := cluster.NewMemory()
+	a, b := New(0), New(0)
+	for _, m := range []*Machine{a, b} {
+		if err := m.Share(store); err != nil {
+			t.Fatalf("Share() err = %v, want nil", err)
+		}
+	}
+	// Of the same request replayed to both instances at once, only one may be accepted.
+	for counter := uint64(1); counter <= 20; counter++ {
+		errs := make(chan error, 2)
+		for _, m := range []*Machine{a, b} {
+			go func(m *Machine) { errs <- m.AcceptCounter("123A", counter) }(m)
+		}
+		var accepted int
+		for i := 0; i < 2; i++ {
+			if err := <-errs; err == nil {
+				accepted++
+			} else if status.Code(err) != codes.PermissionDenied {
+				t.Fatalf("AcceptCounter(%d) err = %v, want nil or code %v", counter, err, codes.PermissionDenied)
+			}
+		}
+		if accepted != 1 {
+			t.Fatalf("AcceptCounter(%d) was accepted by %d instances, want 1", counter, accepted)
+		}
+	}
+}
+
+// downStore is a store of a cluster which fails reads and writes of device states while down.
+type downStore struct {
+	cluster.Store
+	mu   sync.Mutex
+	down bool
+}
+
+func (s *downStore) setDown(down bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.down = down
+}
+
+func (s *downStore) err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.down {
+		return errors.New("store unreachable")
+	}
+	return nil
+}
+
+func (s *downStore) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	if err := s.err(); err != nil {
+		return nil, false, err
+	}
+	return s.Store.Get(ctx, key)
+}
+
+func (s *downStore) Swap(ctx context.Context, key string, old, value []byte) (bool, error) {
+	if err := s.err(); err != nil {
+		return false, err
+	}
+	return s.Store.Swap(ctx, key, old, value)
+}
+
+func TestAcceptCounterStoreDown(t *testing.T) {
+	store := &downStore{Store: cluster.NewMemory()}
+	m := New(0)
+	if err := m.Share(store); err != nil {
+		t.Fatalf("Share() err = %v, want nil", err)
+	}
+	if err := m.AcceptCounter("123A", 1); err != nil {
+		t.Fatalf("AcceptCounter(1) err = %v, want nil", err)
+	}
+	store.setDown(true)
+	// The local state may miss counters accepted by other instances, so counters fail closed.
+	if err := m.AcceptCounter("123A", 2); status.Code(err) != codes.Unavailable {
+		t.Errorf("AcceptCounter(2) with the store down err = %v, want code %v", err, codes.Unavailable)
+	}
+	if err := m.CheckCounter("123A", 2); status.Code(err) != codes.Unavailable {
+		t.Errorf("CheckCounter(2) with the store down err = %v, want code %v", err, codes.Unavailable)
+	}
+	// Other transitions fall back to the local state.
+	m.Report("123A", bpb.ReportStatusRequest_BOOTSTRAP_STATUS_SUCCESS, Artifacts{})
+	if d, _ := m.Get("123A"); d.State != StatusOK {
+		t.Errorf("state after Report() with the store down = %v, want %v", d.State, StatusOK)
+	}
+	store.setDown(false)
+	if err := m.AcceptCounter("123A", 2); err != nil {
+		t.Errorf("AcceptCounter(2) with the store back err = %v, want nil", err)
+	}
+}
+
+func TestShareConcurrent(t *testing.T) {
+	store := cluster.NewMemory()
+	a, b := New(0), New(0)
+	for _, m := range []*Machine{a, b} {
+		if err := m.Share(store); err != nil {
+			t.Fatalf("Share() err = %v, want nil", err)
+		}
+	}
+	// Concurrent transitions of both instances must all be counted.
+	var wg sync.WaitGroup
+	for _, m := range []*Machine{a, b} {
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func(m *Machine) {
+				defer wg.Done()
+				m.Sent("123A", SingleStage, Artifacts{})
+			}(m)
+		}
+	}
+	wg.Wait()
+	if d, _ := b.Get("123A"); d.Retries != 9 {
+		t.Errorf("Get() retries = %d after 10 concurrent sends, want 9", d.Retries)
+	}
+}
+
+func TestPersist(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "states.json")
+	m := New(0)
+	if err := m.Persist(path); err != nil {
+		t.Fatalf("Persist() err = %v, want nil", err)
+	}
+	m.Sent("123A", SingleStage, Artifacts{})
+	m.Sent("456", SingleStage, Artifacts{})
+	m.Report("456", bpb.ReportStatusRequest_BOOTSTRAP_STATUS_SUCCESS, Artifacts{})
+
+	restored := New(0)
+	if err := restored.Persist(path); err != nil {
+		t.Fatalf("Persist() of the saved states err = %v, want nil", err)
+	}
+	got, want := restored.Devices(), m.Devices()
+	if len(got) != len(want) {
+		t.Fatalf("Devices() after restore returned %d devices, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i].SerialNumber != want[i].SerialNumber || got[i].State != want[i].State || !got[i].Changed.Equal(want[i].Changed) {
+			t.Errorf("Devices() after restore = %+v, want %+v", got[i], want[i])
+		}
+	}
+}
+
+func TestShare(t *testing.T) {
+	store := cluster.NewMemory()
+	a, b := New(1), New(1)
+	if err := a.Share(store); err != nil {
+		t.Fatalf("Share() err = %v, want nil", err)
+	}
+	a.Sent("123A", InitialStage, Artifacts{})
+	a.Sent("123A", InitialStage, Artifacts{})
+	if err := b.Share(store); err != nil {
+		t.Fatalf("Share() err = %v, want nil", err)
+	}
+	if err := b.Check("123A"); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("Check() err = %v, want code %v", err, codes.ResourceExhausted)
+	}
+	// Transitions of one instance are seen by the others.
+	b.Report("123A", bpb.ReportStatusRequest_BOOTSTRAP_STATUS_SUCCESS, Artifacts{})
+	if got := a.Stage("123A"); got != FinalStage {
+		t.Errorf("Stage() = %v, want %v", got, FinalStage)
+	}
+	a.Restore(nil)
+	if got := b.Devices(); len(got) != 0 {
+		t.Errorf("Devices() = %v after restoring no devices, want none", got)
+	}
+}
+
+func TestDrift(t *testing.T) {
+	served := Artifacts{ImageHash: "AB12", ConfigHash: "cd34"}
+	tests := []struct {
+		desc    string
+		applied Artifacts
+		want    []string
+	}{
+		{desc: "Served artifacts", applied: Artifacts{ImageHash: "ab12", ConfigHash: "CD34"}},
+		{desc: "Other image", applied: Artifacts{ImageHash: "ef56", ConfigHash: "cd34"}, want: []string{"image"}},
+		{desc: "Other image and config", applied: Artifacts{ImageHash: "ef56", ConfigHash: "ef56"}, want: []string{"image", "config"}},
+		{desc: "Unknown image", applied: Artifacts{ConfigHash: "ef56"}, want: []string{"config"}},
+		{desc: "Nothing reported"},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			if diff := cmp.Diff(test.want, test.applied.Drift(served)); diff != "" {
+				t.Errorf("Drift() diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	path := filepath.Join(t.TempDir(), "states.json")
+	m := New(0)
+	if err := m.Persist(path); err != nil {
+		t.Fatalf("Persist() err = %v, want nil", err)
+	}
+	m.Sent("123A", SingleStage, served)
+	m.Report("123A", bpb.ReportStatusRequest_BOOTSTRAP_STATUS_SUCCESS, Artifacts{ImageHash: "ab12", ConfigHash: "ef56"})
+	// Reports without artifacts keep those reported before.
+	m.Report("123A", bpb.ReportStatusRequest_BOOTSTRAP_STATUS_SUCCESS, Artifacts{})
+	restored := New(0)
+	if err := restored.Persist(path); err != nil {
+		t.Fatalf("Persist() err = %v, want nil", err)
+	}
+	d, _ := restored.Get("123A")
+	if diff := cmp.Diff([]string{"config"}, d.Drift()); diff != "" {
+		t.Errorf("Drift() of the saved device diff (-want +got):\n%s", diff)
+	}
+	if err := m.Reset("123A"); err != nil {
+		t.Fatalf("Reset() err = %v, want nil", err)
+	}
+	if d, _ := m.Get("123A"); d.Served != (Artifacts{}) || d.Applied != (Artifacts{}) {
+		t.Errorf("Reset() kept artifacts served %+v and applied %+v", d.Served, d.Applied)
+	}
+}
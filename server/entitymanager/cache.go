@@ -0,0 +1,229 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entitymanager
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openconfig/bootz/common/signature"
+	"github.com/openconfig/bootz/server/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	bpb "github.com/openconfig/bootz/proto/bootz"
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+	log "github.com/openconfig/bootz/server/logging"
+)
+
+// cacheEntry is an entry of a responseCache.
+type cacheEntry struct {
+	key     string
+	value   any
+	expires time.Time
+}
+
+// responseCache is an LRU cache of the bootstrap data assembled for devices and of response
+// signatures. A nil cache caches nothing. It is safe for concurrent use.
+type responseCache struct {
+	size int
+	ttl  time.Duration
+	now  func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List
+}
+
+// newResponseCache returns the cache configured by cfg, or nil if it is disabled.
+func newResponseCache(cfg *epb.ResponseCache) *responseCache {
+	if cfg.GetSize() == 0 {
+		return nil
+	}
+	return &responseCache{
+		size:    int(cfg.GetSize()),
+		ttl:     cfg.GetTtl().AsDuration(),
+		now:     time.Now,
+		entries: map[string]*list.Element{},
+		lru:     list.New(),
+	}
+}
+
+// get returns the unexpired value cached under key.
+func (c *responseCache) get(key string) (any, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := e.Value.(*cacheEntry)
+	if !entry.expires.IsZero() && c.now().After(entry.expires) {
+		c.lru.Remove(e)
+		delete(c.entries, key)
+		return nil, false
+	}
+	c.lru.MoveToFront(e)
+	return entry.value, true
+}
+
+// put caches value under key, evicting the least recently used entry if the cache is full.
+func (c *responseCache) put(key string, value any) {
+	if c == nil {
+		return
+	}
+	entry := &cacheEntry{key: key, value: value}
+	if c.ttl > 0 {
+		entry.expires = c.now().Add(c.ttl)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[key]; ok {
+		e.Value = entry
+		c.lru.MoveToFront(e)
+		return
+	}
+	c.entries[key] = c.lru.PushFront(entry)
+	if c.lru.Len() > c.size {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
+
+// invalidate empties the cache.
+func (c *responseCache) invalidate() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = map[string]*list.Element{}
+	c.lru.Init()
+}
+
+// digest returns a hex encoded SHA-256 digest of the concatenated parts.
+func digest(parts ...[]byte) string {
+	h := sha256.New()
+	for _, p := range parts {
+		h.Write(p)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// artifactFiles returns the files the bootstrap data of a device of the chassis is read from.
+func (m *InMemoryEntityManager) artifactFiles(chassis *epb.Chassis) []string {
+	var files []string
+	for _, bc := range []*epb.BootConfig{chassis.GetConfig().GetBootConfig(), chassis.GetConfig().GetFinalBootConfig()} {
+		files = append(files, bc.GetOcConfigFile(), bc.GetVendorConfigFile(), bc.GetGnmiSetRequestFile())
+	}
+	for _, conf := range []*epb.GNSIConfig{chassis.GetConfig().GetGnsiConfig(), m.defaults.GetGnsiGlobalConfig()} {
+		files = append(files, conf.GetAuthzUploadFile(), conf.GetCredentialsFile())
+	}
+	return files
+}
+
+// fileStamps returns the path, size and modification time of each of the files, so that they
+// change when a file is rewritten. Files which can't be read are stamped with the error.
+func fileStamps(files []string) []byte {
+	var b strings.Builder
+	for _, f := range files {
+		if f == "" {
+			continue
+		}
+		if fi, err := os.Stat(f); err != nil {
+			fmt.Fprintf(&b, "%q:%v\n", f, err)
+		} else {
+			fmt.Fprintf(&b, "%q:%d:%d\n", f, fi.Size(), fi.ModTime().UnixNano())
+		}
+	}
+	return []byte(b.String())
+}
+
+// cachedArtifacts returns the bootstrap data of a device of the chassis, assembling it unless a
+// copy assembled from an identical chassis entry and unchanged artifact files is cached.
+func (m *InMemoryEntityManager) cachedArtifacts(serial string, chassis *epb.Chassis) (*bpb.BootstrapDataResponse, error) {
+	if m.cache == nil {
+		return m.populateArtifacts(chassis)
+	}
+	// The entry is part of the key, so edits of the chassis, or experiment arms altering it, miss,
+	// and so are the stamps of the files it references, so edits of the configs, credentials or
+	// authz policies miss too.
+	entry, err := proto.MarshalOptions{Deterministic: true}.Marshal(chassis)
+	if err != nil {
+		return m.populateArtifacts(chassis)
+	}
+	key := "data/" + serial + "/" + digest(entry, fileStamps(m.artifactFiles(chassis)))
+	if v, ok := m.cache.get(key); ok {
+		log.Infof("Serving cached bootstrap data of serial# %s", serial)
+		return proto.Clone(v.(*bpb.BootstrapDataResponse)).(*bpb.BootstrapDataResponse), nil
+	}
+	resp, err := m.populateArtifacts(chassis)
+	if err != nil {
+		return nil, err
+	}
+	m.cache.put(key, proto.Clone(resp))
+	return resp, nil
+}
+
+// cachedSignature signs the serialized bootstrap data with the private key of the OC and the first
+// of the accepted algorithms its key supports, unless the same data was already signed by the OC
+// with that algorithm, as when a device retries a request with its nonce. Signatures are shared
+// with the other instances of the cluster, if any.
+func (m *InMemoryEntityManager) cachedSignature(ctx context.Context, oc *service.KeyPair, data []byte, accepted []bpb.SignatureAlgorithm) (string, bpb.SignatureAlgorithm, error) {
+	signer, err := oc.PrivateKeySigner()
+	if err != nil {
+		return "", bpb.SignatureAlgorithm_SIGNATURE_ALGORITHM_UNSPECIFIED, err
+	}
+	alg, err := signature.Negotiate(signer, accepted)
+	if err != nil {
+		return "", alg, status.Errorf(codes.InvalidArgument, "unable to negotiate a signature algorithm: %v", err)
+	}
+	key := "signature/" + alg.String() + "/" + digest([]byte(oc.Cert), data)
+	if v, ok := m.cache.get(key); ok {
+		return v.(string), alg, nil
+	}
+	if sig, ok := m.sharedSignature(ctx, key); ok {
+		m.cache.put(key, sig)
+		return sig, alg, nil
+	}
+	var sig string
+	if alg == bpb.SignatureAlgorithm_SIGNATURE_ALGORITHM_CMS_DETACHED {
+		chain, chainErr := oc.CertChain()
+		if chainErr != nil {
+			return "", alg, chainErr
+		}
+		sig, err = signature.SignDetached(chain, signer, data)
+	} else {
+		sig, err = signature.SignWithAlgorithm(signer, data, alg)
+	}
+	if err != nil {
+		return "", alg, err
+	}
+	m.cache.put(key, sig)
+	m.shareSignature(ctx, key, sig)
+	return sig, alg, nil
+}
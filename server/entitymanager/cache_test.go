@@ -0,0 +1,167 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entitymanager
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/openconfig/bootz/server/service"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	bpb "github.com/openconfig/bootz/proto/bootz"
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+)
+
+func TestResponseCache(t *testing.T) {
+	if c := newResponseCache(&epb.ResponseCache{}); c != nil {
+		t.Errorf("newResponseCache() of size 0 = %v, want nil", c)
+	}
+	var disabled *responseCache
+	disabled.put("a", 1)
+	if _, ok := disabled.get("a"); ok {
+		t.Errorf("get() of a disabled cache found an entry")
+	}
+
+	now := time.Now()
+	c := newResponseCache(&epb.ResponseCache{Size: 2, Ttl: durationpb.New(time.Minute)})
+	c.now = func() time.Time { return now }
+	c.put("a", 1)
+	c.put("b", 2)
+	// Reading a makes b the least recently used entry.
+	if v, ok := c.get("a"); !ok || v != 1 {
+		t.Errorf("get(a) = %v, %v, want 1, true", v, ok)
+	}
+	c.put("c", 3)
+	if _, ok := c.get("b"); ok {
+		t.Errorf("get(b) found the least recently used entry, want it evicted")
+	}
+	if v, ok := c.get("c"); !ok || v != 3 {
+		t.Errorf("get(c) = %v, %v, want 3, true", v, ok)
+	}
+	now = now.Add(2 * time.Minute)
+	if _, ok := c.get("a"); ok {
+		t.Errorf("get(a) found an expired entry")
+	}
+	c.put("d", 4)
+	c.invalidate()
+	if _, ok := c.get("d"); ok {
+		t.Errorf("get(d) found an entry after invalidate()")
+	}
+}
+
+func TestCachedBootstrapData(t *testing.T) {
+	em, err := New("../../testdata/inventory.prototxt")
+	if err != nil {
+		t.Fatalf("New() err = %v, want nil", err)
+	}
+	em.cache = newResponseCache(&epb.ResponseCache{Size: 10})
+	lookup := &service.EntityLookup{Manufacturer: "Cisco", SerialNumber: "123"}
+	card := &bpb.ControlCard{SerialNumber: "123A", PartNumber: "123A"}
+
+	first, err := em.GetBootstrapData(context.Background(), lookup, card)
+	if err != nil {
+		t.Fatalf("GetBootstrapData() err = %v, want nil", err)
+	}
+	want := proto.Clone(first)
+	// Callers own the returned data, which must not alter the cached copy.
+	first.BootConfig = nil
+	second, err := em.GetBootstrapData(context.Background(), lookup, card)
+	if err != nil {
+		t.Fatalf("GetBootstrapData() err = %v, want nil", err)
+	}
+	if !proto.Equal(second, want) {
+		t.Errorf("GetBootstrapData() from the cache = %v, want %v", second, want)
+	}
+	if got := em.cache.lru.Len(); got != 1 {
+		t.Errorf("cache holds %d entries, want 1", got)
+	}
+
+	resp := &bpb.GetBootstrapDataResponse{SerializedBootstrapData: MustMarshalBootstrapDataSigned(t, &bpb.BootstrapDataSigned{
+		Responses: []*bpb.BootstrapDataResponse{want.(*bpb.BootstrapDataResponse)},
+		Nonce:     "nonce",
+	})}
+	if err := em.Sign(context.Background(), resp, lookup, "123A", nil); err != nil {
+		t.Fatalf("Sign() err = %v, want nil", err)
+	}
+	retry := proto.Clone(resp).(*bpb.GetBootstrapDataResponse)
+	retry.ResponseSignature = ""
+	if err := em.Sign(context.Background(), retry, lookup, "123A", nil); err != nil {
+		t.Fatalf("Sign() err = %v, want nil", err)
+	}
+	if retry.GetResponseSignature() != resp.GetResponseSignature() {
+		t.Errorf("Sign() of a retry = %q, want %q", retry.GetResponseSignature(), resp.GetResponseSignature())
+	}
+	if got := em.cache.lru.Len(); got != 2 {
+		t.Errorf("cache holds %d entries, want 2", got)
+	}
+
+	ch, err := em.GetDevice(lookup)
+	if err != nil {
+		t.Fatalf("GetDevice() err = %v, want nil", err)
+	}
+	if err := em.PutChassis(ch, true); err != nil {
+		t.Fatalf("PutChassis() err = %v, want nil", err)
+	}
+	if got := em.cache.lru.Len(); got != 0 {
+		t.Errorf("cache holds %d entries after an inventory change, want 0", got)
+	}
+}
+
+func TestCachedBootstrapDataFileChange(t *testing.T) {
+	em, err := New("../../testdata/inventory.prototxt")
+	if err != nil {
+		t.Fatalf("New() err = %v, want nil", err)
+	}
+	em.cache = newResponseCache(&epb.ResponseCache{Size: 10})
+	lookup := &service.EntityLookup{Manufacturer: "Cisco", SerialNumber: "123"}
+	card := &bpb.ControlCard{SerialNumber: "123A", PartNumber: "123A"}
+	path := filepath.Join(t.TempDir(), "123.cfg")
+	if err := os.WriteFile(path, []byte("hostname old"), 0600); err != nil {
+		t.Fatalf("unable to write config: %v", err)
+	}
+	ch, err := em.GetDevice(lookup)
+	if err != nil {
+		t.Fatalf("GetDevice() err = %v, want nil", err)
+	}
+	ch.Config.BootConfig = &epb.BootConfig{VendorConfigFile: path}
+	if err := em.PutChassis(ch, true); err != nil {
+		t.Fatalf("PutChassis() err = %v, want nil", err)
+	}
+	start := time.Now()
+	for i, want := range []string{"hostname old", "hostname new"} {
+		if err := os.WriteFile(path, []byte(want), 0600); err != nil {
+			t.Fatalf("unable to write config: %v", err)
+		}
+		// Set the modification time explicitly, in case the file system keeps it coarsely.
+		mtime := start.Add(time.Duration(i) * time.Second)
+		if err := os.Chtimes(path, mtime, mtime); err != nil {
+			t.Fatalf("unable to set modification time: %v", err)
+		}
+		for j := 0; j < 2; j++ {
+			resp, err := em.GetBootstrapData(context.Background(), lookup, card)
+			if err != nil {
+				t.Fatalf("GetBootstrapData() err = %v, want nil", err)
+			}
+			if got := string(resp.GetBootConfig().GetVendorConfig()); got != want {
+				t.Errorf("GetBootstrapData() vendor config = %q, want %q", got, want)
+			}
+		}
+	}
+}
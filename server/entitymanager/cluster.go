@@ -0,0 +1,206 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entitymanager
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/openconfig/bootz/server/cluster"
+	"github.com/openconfig/gnmi/errlist"
+	"google.golang.org/protobuf/proto"
+
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+	log "github.com/openconfig/bootz/server/logging"
+)
+
+const (
+	// defaultSignatureTTL is how long response signatures are shared with the cluster by default.
+	defaultSignatureTTL = 10 * time.Minute
+	// publishedKey and revisionKey are the keys of the inventory published by the leader of the
+	// cluster, and of its revision.
+	publishedKey = "inventory/snapshot"
+	revisionKey  = "inventory/revision"
+)
+
+// joinCluster shares the device states and response signatures through the store of the
+// cluster.
+func (m *InMemoryEntityManager) joinCluster(cfg *epb.Cluster) error {
+	store, err := cluster.New(cfg)
+	if err != nil {
+		return err
+	}
+	m.leaseDuration = cfg.GetLeaseDuration().AsDuration()
+	return m.shareWith(store, cfg.GetSignatureTtl().AsDuration())
+}
+
+// shareWith shares the device states and response signatures through the store, the latter for
+// ttl or the default if 0.
+func (m *InMemoryEntityManager) shareWith(store cluster.Store, ttl time.Duration) error {
+	if err := m.states.Share(store); err != nil {
+		return err
+	}
+	if ttl == 0 {
+		ttl = defaultSignatureTTL
+	}
+	m.cluster = store
+	m.signatureTTL = ttl
+	return nil
+}
+
+// sharedSignature returns the response signature shared under key by an instance of the cluster.
+func (m *InMemoryEntityManager) sharedSignature(ctx context.Context, key string) (string, bool) {
+	if m.cluster == nil {
+		return "", false
+	}
+	v, ok, err := m.cluster.Get(ctx, key)
+	if err != nil {
+		log.Warningf("Unable to read the shared response signature, signing again: %v", err)
+		return "", false
+	}
+	return string(v), ok
+}
+
+// shareSignature shares the response signature under key with the other instances of the
+// cluster.
+func (m *InMemoryEntityManager) shareSignature(ctx context.Context, key, sig string) {
+	if m.cluster == nil {
+		return
+	}
+	if err := m.cluster.Put(ctx, key, []byte(sig), m.signatureTTL); err != nil {
+		log.Warningf("Unable to share the response signature with the cluster: %v", err)
+	}
+}
+
+// StartLeaderElection campaigns for the leadership of the cluster with the given instance ID,
+// after applying the inventory published by the leader, if any. Followers apply the inventory
+// published by the leader after each campaign. It returns a function stepping down and stopping
+// the election, or nil if no cluster is configured.
+func (m *InMemoryEntityManager) StartLeaderElection(id string) func() {
+	if m.cluster == nil {
+		return nil
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	if err := m.pullInventory(ctx); err != nil {
+		log.Warningf("Unable to apply the inventory of the cluster: %v", err)
+	}
+	m.elector = cluster.NewElector(m.cluster, id, m.leaseDuration)
+	go m.elector.Run(ctx, func(ctx context.Context, leader bool) {
+		if !leader {
+			if err := m.pullInventory(ctx); err != nil {
+				log.Warningf("Unable to apply the inventory of the cluster: %v", err)
+			}
+			return
+		}
+		m.publishMu.Lock()
+		unpublished := m.unpublished
+		m.publishMu.Unlock()
+		if unpublished {
+			if err := m.PublishInventory(ctx); err != nil {
+				log.Warningf("Unable to publish the inventory to the cluster: %v", err)
+			}
+		}
+	})
+	log.Infof("Campaigning for the leadership of the cluster as %s", id)
+	return cancel
+}
+
+// IsLeader reports whether the instance leads the cluster. Instances outside of a cluster always
+// lead.
+func (m *InMemoryEntityManager) IsLeader() bool {
+	return m.elector == nil || m.elector.IsLeader()
+}
+
+// Leader returns the ID of the leader of the cluster, or "" if there is none.
+func (m *InMemoryEntityManager) Leader(ctx context.Context) (string, error) {
+	if m.elector == nil {
+		return "", nil
+	}
+	return m.elector.Leader(ctx)
+}
+
+// PublishInventory shares the chassis, revoked ownership vouchers and bootstrap tokens of the
+// leader with the other instances of the cluster. Failures are retried by the leader until it succeeds.
+func (m *InMemoryEntityManager) PublishInventory(ctx context.Context) error {
+	if m.elector == nil {
+		return nil
+	}
+	s := m.Snapshot()
+	// Device states are shared as they change, and control card statuses are reported to every
+	// instance, so only the inventory is published.
+	s.Options, s.DeviceStates, s.ControlCardStatuses, s.CreatedAt = nil, nil, nil, nil
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(s)
+	if err != nil {
+		return err
+	}
+	sum := sha256.Sum256(data)
+	revision := hex.EncodeToString(sum[:])
+	m.publishMu.Lock()
+	defer m.publishMu.Unlock()
+	m.unpublished = true
+	// The writes are fenced by the lease of the leader, so a former leader can't overwrite the
+	// inventory published by its successor.
+	if err := m.elector.Put(ctx, publishedKey, data); err != nil {
+		return err
+	}
+	if err := m.elector.Put(ctx, revisionKey, []byte(revision)); err != nil {
+		return err
+	}
+	m.revision = revision
+	m.unpublished = false
+	return nil
+}
+
+// pullInventory applies the inventory published by the leader of the cluster, if it changed
+// since it was last published or applied.
+func (m *InMemoryEntityManager) pullInventory(ctx context.Context) error {
+	revision, ok, err := m.cluster.Get(ctx, revisionKey)
+	if err != nil || !ok {
+		return err
+	}
+	m.publishMu.Lock()
+	defer m.publishMu.Unlock()
+	if string(revision) == m.revision {
+		return nil
+	}
+	data, ok, err := m.cluster.Get(ctx, publishedKey)
+	if err != nil || !ok {
+		return err
+	}
+	s := &epb.Snapshot{}
+	if err := proto.Unmarshal(data, s); err != nil {
+		return fmt.Errorf("unable to parse the inventory of the cluster: %v", err)
+	}
+	var errs errlist.List
+	inventory := m.validInventory(s.GetChassis(), &errs)
+	retired := snapshotRetirements(s, &errs)
+	if err := errs.Err(); err != nil {
+		return fmt.Errorf("invalid inventory: %v", err)
+	}
+	m.mu.Lock()
+	m.chassisInventory = inventory
+	m.imported = nil
+	m.cache.invalidate()
+	m.mu.Unlock()
+	m.revocations.Restore(snapshotRevocations(s))
+	m.tokens.Restore(snapshotTokens(s))
+	m.retirements.Restore(retired)
+	m.revision = string(revision)
+	log.Infof("Applied the inventory of the cluster with %d chassis", len(inventory))
+	return nil
+}
@@ -0,0 +1,76 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entitymanager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/openconfig/bootz/server/cluster"
+	"github.com/openconfig/bootz/server/service"
+
+	bpb "github.com/openconfig/bootz/proto/bootz"
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+)
+
+func TestPublishInventory(t *testing.T) {
+	ctx := context.Background()
+	store := cluster.NewMemory()
+	var ems []*InMemoryEntityManager
+	for i := 0; i < 2; i++ {
+		em, err := New("../../testdata/inventory.prototxt")
+		if err != nil {
+			t.Fatalf("New() err = %v, want nil", err)
+		}
+		if err := em.shareWith(store, 0); err != nil {
+			t.Fatalf("shareWith() err = %v, want nil", err)
+		}
+		ems = append(ems, em)
+	}
+	leader, follower := ems[0], ems[1]
+	leader.elector = cluster.NewElector(store, "leader", 0)
+	electionCtx, stop := context.WithCancel(ctx)
+	defer stop()
+	campaigned := make(chan bool, 1)
+	go leader.elector.Run(electionCtx, func(_ context.Context, leads bool) {
+		select {
+		case campaigned <- leads:
+		default:
+		}
+	})
+	if !<-campaigned {
+		t.Fatalf("Run() of the only instance did not lead the cluster")
+	}
+
+	ch := &epb.Chassis{SerialNumber: "456", Manufacturer: "Cisco", BootMode: bpb.BootMode_BOOT_MODE_INSECURE}
+	if err := leader.PutChassis(ch, false); err != nil {
+		t.Fatalf("PutChassis() err = %v, want nil", err)
+	}
+	if err := leader.Revocations().Revoke("123A", "compromised"); err != nil {
+		t.Fatalf("Revoke() err = %v, want nil", err)
+	}
+	if err := leader.PublishInventory(ctx); err != nil {
+		t.Fatalf("PublishInventory() err = %v, want nil", err)
+	}
+	if err := follower.pullInventory(ctx); err != nil {
+		t.Fatalf("pullInventory() err = %v, want nil", err)
+	}
+	if _, err := follower.GetDevice(&service.EntityLookup{Manufacturer: "Cisco", SerialNumber: "456"}); err != nil {
+		t.Errorf("GetDevice() of the published chassis err = %v, want nil", err)
+	}
+	if !follower.Revocations().IsRevoked("123A") {
+		t.Errorf("IsRevoked() of the published revocation = false, want true")
+	}
+}
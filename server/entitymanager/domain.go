@@ -0,0 +1,104 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entitymanager
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/openconfig/bootz/server/service"
+
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+	log "github.com/openconfig/bootz/server/logging"
+)
+
+// loadDomains reads the owner artifacts of the configured ownership domains.
+func (m *InMemoryEntityManager) loadDomains() error {
+	if len(m.defaults.GetDomains()) == 0 {
+		return nil
+	}
+	if m.secArtifacts == nil {
+		return fmt.Errorf("ownership domains require artifact_dir to be set")
+	}
+	m.domains = map[string]*service.SecurityArtifacts{}
+	admins := map[string]string{}
+	for i, d := range m.defaults.GetDomains() {
+		if d.GetName() == "" {
+			return fmt.Errorf("ownership domain %d has no name", i)
+		}
+		if _, ok := m.domains[d.GetName()]; ok {
+			return fmt.Errorf("ownership domain %q is configured twice", d.GetName())
+		}
+		if d.GetArtifactDir() == "" {
+			return fmt.Errorf("ownership domain %q has no artifact_dir", d.GetName())
+		}
+		sa, err := parseOwnerArtifacts(os.DirFS(d.GetArtifactDir()), d.GetOcKeyUri(), d.GetPdcKeyUri())
+		if err != nil {
+			return fmt.Errorf("error in parsing artifacts of ownership domain %q: %v", d.GetName(), err)
+		}
+		sa.VendorCA = m.secArtifacts.VendorCA
+		m.domains[d.GetName()] = sa
+		for _, id := range d.GetAdminIdentities() {
+			if other, ok := admins[id]; ok {
+				return fmt.Errorf("admin %q belongs to ownership domains %q and %q", id, other, d.GetName())
+			}
+			admins[id] = d.GetName()
+		}
+		log.Infof("Ownership domain %q uses the artifacts in %s", d.GetName(), d.GetArtifactDir())
+	}
+	return nil
+}
+
+// domainArtifacts returns the security artifacts of the ownership domain of the chassis, or nil
+// if it belongs to no domain.
+func (m *InMemoryEntityManager) domainArtifacts(ch *epb.Chassis) *service.SecurityArtifacts {
+	return m.domains[ch.GetTenant()]
+}
+
+// Domain returns the ownership domain of the chassis or control card with the serial number,
+// or "" if it belongs to no domain.
+func (m *InMemoryEntityManager) Domain(serial string) string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, ch := range m.chassisInventory {
+		if _, ok := m.domains[ch.GetTenant()]; !ok {
+			continue
+		}
+		if m.serialMatches(ch.GetSerialNumber(), ch.GetSerialAliases(), serial) {
+			return ch.GetTenant()
+		}
+		for _, c := range ch.GetControllerCards() {
+			if m.serialMatches(c.GetSerialNumber(), c.GetSerialAliases(), serial) {
+				return ch.GetTenant()
+			}
+		}
+	}
+	return ""
+}
+
+// DomainAdmins returns the ownership domain of each admin identity, "" for the admins of the
+// whole server. Identities listed by both are restricted to their domain.
+func (m *InMemoryEntityManager) DomainAdmins() map[string]string {
+	admins := map[string]string{}
+	for _, id := range m.defaults.GetAdminIdentities() {
+		admins[id] = ""
+	}
+	for _, d := range m.defaults.GetDomains() {
+		for _, id := range d.GetAdminIdentities() {
+			admins[id] = d.GetName()
+		}
+	}
+	return admins
+}
@@ -16,39 +16,69 @@
 package entitymanager
 
 import (
+	"context"
+	"crypto/sha256"
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"encoding/pem"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
-	"path/filepath"
 	"regexp"
+	"sort"
+	"strings"
 	"sync"
+	"time"
 
-	"github.com/openconfig/bootz/common/signature"
+	"github.com/openconfig/bootz/common/fips"
+	ownershipvoucher "github.com/openconfig/bootz/common/ownership_voucher"
+	"github.com/openconfig/bootz/server/artifacts"
+	"github.com/openconfig/bootz/server/cluster"
+	"github.com/openconfig/bootz/server/devicestate"
+	"github.com/openconfig/bootz/server/experiment"
+	"github.com/openconfig/bootz/server/keystore"
+	"github.com/openconfig/bootz/server/masa"
+	"github.com/openconfig/bootz/server/netbox"
+	"github.com/openconfig/bootz/server/retirement"
+	"github.com/openconfig/bootz/server/revocation"
 	"github.com/openconfig/bootz/server/service"
+	"github.com/openconfig/bootz/server/token"
+	"github.com/openconfig/bootz/server/validator"
+	"github.com/openconfig/gnmi/errlist"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/encoding/prototext"
 	"google.golang.org/protobuf/proto"
 
-	log "github.com/golang/glog"
+	log "github.com/openconfig/bootz/server/logging"
 
 	bpb "github.com/openconfig/bootz/proto/bootz"
 	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
 	apb "github.com/openconfig/gnsi/authz"
 )
 
+// wildcard matches any manufacturer, part number or serial number in a chassis entry.
+const wildcard = "*"
+
+// defaultRetryInterval is the interval devices are asked to wait before retrying when their artifacts are not staged.
+const defaultRetryInterval = 5 * time.Minute
+
 var (
 	rxBase64 = regexp.MustCompile(`^(?:[A-Za-z0-9+\\/]{4})*(?:[A-Za-z0-9+\\/]{2}==|[A-Za-z0-9+\\/]{3}=|[A-Za-z0-9+\\/]{4})$`)
 )
 
 // InMemoryEntityManager provides a simple in memory handler
-// for Entities.
+// for Entities. It is safe for concurrent use.
 type InMemoryEntityManager struct {
-	mu sync.Mutex
+	// mu guards chassisInventory, controlCardStatuses and modules. Chassis entries are
+	// never modified in place, only replaced, so an entry read under mu may be
+	// used after releasing it. The remaining fields are set by New only.
+	mu sync.RWMutex
 	// inventory represents an organization's inventory of owned chassis.
 	chassisInventory map[service.EntityLookup]*epb.Chassis
 	// represents the current status of known control cards
@@ -58,35 +88,254 @@ type InMemoryEntityManager struct {
 	// security artifacts  (OVs, OC and PDC).
 	// TODO: handle mutlti-vendor case
 	secArtifacts *service.SecurityArtifacts
+	// generated reports whether secArtifacts were generated in memory rather than read.
+	generated bool
+	// rotation holds the new OC and PDC of an ownership rotation, if one is configured.
+	rotation *service.SecurityArtifacts
+	// domains holds the OC and PDC of each ownership domain, by name.
+	domains map[string]*service.SecurityArtifacts
+	// revoked ownership vouchers, which are not served.
+	revocations *revocation.List
+	// tokens are the one-time bootstrap tokens devices must present.
+	tokens *token.List
+	// retirements are the devices retired from the inventory, which are not served.
+	retirements *retirement.List
+	// validators check the artifacts of every chassis on load and before serving.
+	validators *validator.Registry
+	// experiments serve alternative artifacts to a cohort of chassis.
+	experiments *experiment.Tracker
+	// states tracks the bootstrap state of each control card and fixed chassis.
+	states *devicestate.Machine
+	// netbox imports chassis from NetBox, if configured.
+	netbox *netbox.Importer
+	// masa issues the ownership vouchers missing from the inventory, if configured.
+	masa *masa.Client
+	// vouchers holds the ownership vouchers kept on disk instead of in the inventory, if configured.
+	vouchers *voucherStore
+	// imported holds the keys of the chassis imported by ImportChassis. It is guarded by mu.
+	imported map[service.EntityLookup]bool
+	// modules holds the line cards and fabric modules last reported by each chassis, keyed by
+	// manufacturer and inventory serial number. It is guarded by mu.
+	modules map[service.EntityLookup]*reportedModules
+	// cache holds assembled bootstrap data and response signatures, if enabled.
+	cache *responseCache
+	// cluster shares the response signatures with the other instances of the cluster, if set.
+	cluster cluster.Store
+	// signatureTTL is how long response signatures are shared with the cluster.
+	signatureTTL time.Duration
+	// leaseDuration is the lease duration of the leader of the cluster.
+	leaseDuration time.Duration
+	// elector elects the leader of the cluster, once leader election started.
+	elector *cluster.Elector
+	// publishMu guards revision and unpublished.
+	publishMu sync.Mutex
+	// revision is the revision of the inventory of the cluster last published or applied.
+	revision string
+	// unpublished reports whether the leader failed to publish its last inventory mutation.
+	unpublished bool
 }
 
 // ResolveChassis returns an entity based on the provided lookup.
 // In cases when the serial for modular chassis is not set, it uses the controller card to find the chassis.
-func (m *InMemoryEntityManager) ResolveChassis(lookup *service.EntityLookup, ccSerial string) (*service.ChassisEntity, error) {
-	m.mu.Lock()
-	defer m.mu.Unlock()
-	chassis, found := m.chassisInventory[*lookup]
+func (m *InMemoryEntityManager) ResolveChassis(ctx context.Context, lookup *service.EntityLookup, ccSerial string) (*service.ChassisEntity, error) {
+	if err := contextError(ctx); err != nil {
+		return nil, err
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	chassis, found := m.lookupChassis(lookup)
+	if !found && lookup.SerialNumber == "" && ccSerial != "" {
+		chassis, _ = m.resolveChassisViaControllerCard(lookup, ccSerial)
+		found = chassis != nil
+	}
 	if !found {
-		if lookup.SerialNumber == "" && ccSerial != "" {
-			ch, err := m.resolveChassisViaControllerCard(lookup, ccSerial)
-			if err != nil {
-				return nil, status.Errorf(codes.NotFound, "Could not find chassis with serial#: %s and manufacturer: %s and controller card %s",
-					lookup.SerialNumber, lookup.Manufacturer, ccSerial)
-			}
-			chassis = ch
-		} else {
+		chassis, found = m.defaultProfile(lookup)
+		if !found {
 			return nil, status.Errorf(codes.NotFound, "Could not find chassis with serial#: %s and manufacturer: %s and controller card %s",
 				lookup.SerialNumber, lookup.Manufacturer, ccSerial)
 		}
+		log.Infof("Serving default profile to %v chassis with serial# %v and part# %v", lookup.Manufacturer, lookup.SerialNumber, lookup.PartNumber)
+	}
+	return &service.ChassisEntity{
+		BootMode: chassis.GetBootMode(),
+		Site:     chassis.GetSite(),
+		Tenant:   chassis.GetTenant(),
+	}, nil
+}
+
+// ValidateControlCards checks that every control card in a request belongs to the chassis
+// and that no control card is listed twice.
+func (m *InMemoryEntityManager) ValidateControlCards(ctx context.Context, lookup *service.EntityLookup, controlCards []*bpb.ControlCard) error {
+	if err := contextError(ctx); err != nil {
+		return err
+	}
+	if len(controlCards) == 0 {
+		return nil
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	chassis, found := m.lookupChassis(lookup)
+	if !found {
+		ch, err := m.resolveChassisViaControllerCard(lookup, controlCards[0].GetSerialNumber())
+		if err != nil {
+			if _, ok := m.defaultProfile(lookup); ok {
+				// Control cards of chassis served the default profile are not known in advance.
+				return nil
+			}
+			return err
+		}
+		chassis = ch
+	}
+	known := map[string]string{}
+	for _, c := range chassis.GetControllerCards() {
+		known[c.GetSerialNumber()] = c.GetPartNumber()
+	}
+	seen := map[string]bool{}
+	var unknown []string
+	for _, cc := range controlCards {
+		serial := m.deviceSerial(chassis, cc.GetSerialNumber())
+		if seen[serial] {
+			return status.Errorf(codes.InvalidArgument, "control card with serial# %s is listed more than once", cc.GetSerialNumber())
+		}
+		seen[serial] = true
+		if pn, ok := known[serial]; !ok || pn != cc.GetPartNumber() {
+			unknown = append(unknown, cc.GetSerialNumber())
+		}
+	}
+	if len(unknown) > 0 {
+		return status.Errorf(codes.NotFound, "control cards %v are not part of chassis with serial#: %s and manufacturer: %s",
+			unknown, chassis.GetSerialNumber(), chassis.GetManufacturer())
+	}
+	return nil
+}
+
+// inventoryKey returns the key a chassis is stored under. Entries matching any serial number are
+// also keyed by part number so that per part number profiles do not collide.
+func inventoryKey(ch *epb.Chassis) service.EntityLookup {
+	l := service.EntityLookup{
+		Manufacturer: ch.GetManufacturer(),
+		SerialNumber: ch.GetSerialNumber(),
+	}
+	if l.SerialNumber == wildcard {
+		l.PartNumber = ch.GetPartNumber()
+	}
+	return l
+}
+
+// lookupChassis returns the chassis matching the manufacturer, part number and serial of the lookup.
+// An entry stored for the exact manufacturer and serial is preferred, provided its part number matches
+// when both are set. Otherwise the most specific wildcard entry is returned.
+func (m *InMemoryEntityManager) lookupChassis(lookup *service.EntityLookup) (*epb.Chassis, bool) {
+	chassis, found := m.chassisInventory[service.EntityLookup{
+		Manufacturer: lookup.Manufacturer,
+		SerialNumber: lookup.SerialNumber,
+	}]
+	if found && (lookup.PartNumber == "" || chassis.GetPartNumber() == "" || lookup.PartNumber == chassis.GetPartNumber()) {
+		return chassis, true
+	}
+	if lookup.SerialNumber == "" {
+		// Modular chassis without serial are resolved via their control cards.
+		return nil, false
+	}
+	if chassis, found := m.lookupSerial(lookup); found {
+		return chassis, true
+	}
+	var best *epb.Chassis
+	bestScore := -1
+	for _, ch := range m.chassisInventory {
+		score, ok := wildcardMatch(ch, lookup)
+		if !ok {
+			continue
+		}
+		if score > bestScore || score == bestScore && inventoryKeyLess(ch, best) {
+			best, bestScore = ch, score
+		}
+	}
+	return best, best != nil
+}
+
+// lookupSerial returns the chassis whose normalized serial number or alias matches the lookup,
+// for chassis reporting their serial number in another format than the inventory.
+func (m *InMemoryEntityManager) lookupSerial(lookup *service.EntityLookup) (*epb.Chassis, bool) {
+	for _, ch := range m.chassisInventory {
+		if ch.GetManufacturer() != lookup.Manufacturer || !m.serialMatches(ch.GetSerialNumber(), ch.GetSerialAliases(), lookup.SerialNumber) {
+			continue
+		}
+		if lookup.PartNumber == "" || ch.GetPartNumber() == "" || lookup.PartNumber == ch.GetPartNumber() {
+			return ch, true
+		}
+	}
+	return nil, false
+}
+
+// wildcardMatch reports whether a chassis entry with at least one wildcard matches the lookup,
+// and how specific the match is. A matching serial number outweighs a matching part number,
+// which outweighs a matching manufacturer.
+func wildcardMatch(ch *epb.Chassis, lookup *service.EntityLookup) (int, bool) {
+	score, wildcards := 0, 0
+	match := func(entry, value string, weight int) bool {
+		switch entry {
+		case wildcard:
+			wildcards++
+			return true
+		case value:
+			score += weight
+			return true
+		}
+		return false
+	}
+	// An entry without part number matches any part number.
+	partMatch := ch.GetPartNumber() == "" || match(ch.GetPartNumber(), lookup.PartNumber, 2)
+	if !match(ch.GetSerialNumber(), lookup.SerialNumber, 4) || !partMatch || !match(ch.GetManufacturer(), lookup.Manufacturer, 1) {
+		return 0, false
+	}
+	return score, wildcards > 0
+}
+
+// inventoryKeyLess orders chassis entries to break ties between equally specific wildcard matches.
+func inventoryKeyLess(a, b *epb.Chassis) bool {
+	if a.GetManufacturer() != b.GetManufacturer() {
+		return a.GetManufacturer() < b.GetManufacturer()
+	}
+	if a.GetPartNumber() != b.GetPartNumber() {
+		return a.GetPartNumber() < b.GetPartNumber()
+	}
+	return a.GetSerialNumber() < b.GetSerialNumber()
+}
+
+// defaultProfile returns a chassis entry built from the default profile for a chassis missing from
+// the inventory, if the chassis matches the allowlist of the profile.
+func (m *InMemoryEntityManager) defaultProfile(lookup *service.EntityLookup) (*epb.Chassis, bool) {
+	profile := m.defaults.GetDefaultProfile()
+	matches := func(entry, value string) bool {
+		return entry == wildcard || entry == value
+	}
+	for _, d := range profile.GetAllowlist() {
+		if matches(d.GetManufacturer(), lookup.Manufacturer) && matches(d.GetPartNumber(), lookup.PartNumber) {
+			return profileChassis(profile, lookup), true
+		}
+	}
+	return nil, false
+}
+
+// profileChassis returns the chassis entry of the default profile for the looked up chassis.
+func profileChassis(profile *epb.DefaultProfile, lookup *service.EntityLookup) *epb.Chassis {
+	return &epb.Chassis{
+		Name:          "default-profile",
+		SerialNumber:  lookup.SerialNumber,
+		PartNumber:    lookup.PartNumber,
+		Manufacturer:  lookup.Manufacturer,
+		BootMode:      profile.GetBootMode(),
+		SoftwareImage: profile.GetSoftwareImage(),
+		Config:        profile.GetConfig(),
 	}
-	return &service.ChassisEntity{BootMode: chassis.GetBootMode()}, nil
 }
 
 // resolveChassisViaControllerCard resolves a chassis based on controller card serial.
 func (m *InMemoryEntityManager) resolveChassisViaControllerCard(lookup *service.EntityLookup, ccSerial string) (*epb.Chassis, error) {
 	for _, ch := range m.chassisInventory {
 		for _, c := range ch.GetControllerCards() {
-			if c.GetSerialNumber() == ccSerial {
+			if m.serialMatches(c.GetSerialNumber(), c.GetSerialAliases(), ccSerial) {
 				if ch.Manufacturer != lookup.Manufacturer {
 					continue
 				}
@@ -97,10 +346,19 @@ func (m *InMemoryEntityManager) resolveChassisViaControllerCard(lookup *service.
 	return nil, status.Errorf(codes.NotFound, "could not find chassis for controller card with serial# %s", ccSerial)
 }
 
+// fileError returns the error of reading an artifact file. Missing files are reported as
+// Unavailable, since they may not be staged yet.
+func fileError(path string, err error) error {
+	if errors.Is(err, fs.ErrNotExist) {
+		return status.Errorf(codes.Unavailable, "artifact file %s is not staged: %v", path, err)
+	}
+	return status.Errorf(codes.Internal, "Error opening file %s: %v", path, err)
+}
+
 func readOCConfig(path string) ([]byte, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Error opening file %s: %v", path, err)
+		return nil, fileError(path, err)
 	}
 	var v any
 	err = json.Unmarshal(data, &v)
@@ -118,7 +376,7 @@ func (m *InMemoryEntityManager) populateAuthzConfig(ch *epb.Chassis) (*apb.Uploa
 	gnsiAuthzReq := gnsiConf.GetAuthzUpload()
 	gnsiAuthzReqFile := gnsiConf.GetAuthzUploadFile()
 	if gnsiAuthzReqFile == "" {
-		gnsiAuthzReqFile = m.defaults.GnsiGlobalConfig.GetAuthzUploadFile()
+		gnsiAuthzReqFile = m.defaults.GetGnsiGlobalConfig().GetAuthzUploadFile()
 	}
 	if gnsiAuthzReq.GetPolicy() != "" && gnsiAuthzReq.GetVersion() != "" {
 		return gnsiAuthzReq, nil
@@ -128,7 +386,7 @@ func (m *InMemoryEntityManager) populateAuthzConfig(ch *epb.Chassis) (*apb.Uploa
 	}
 	data, err := os.ReadFile(gnsiAuthzReqFile)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Error opening file %s: %v", gnsiAuthzReqFile, err)
+		return nil, fileError(gnsiAuthzReqFile, err)
 	}
 	gnsiAuthzReq = &apb.UploadRequest{}
 	if err := prototext.Unmarshal(data, gnsiAuthzReq); err != nil {
@@ -142,6 +400,101 @@ func (m *InMemoryEntityManager) populateAuthzConfig(ch *epb.Chassis) (*apb.Uploa
 	return gnsiAuthzReq, nil
 }
 
+// populateCredentials returns the initial credentials of the chassis. Credentials set for the chassis
+// take precedence over the global ones, and credentials set inline over a credentials file. No
+// credentials are provisioned if none are configured.
+func (m *InMemoryEntityManager) populateCredentials(ch *epb.Chassis) (*bpb.Credentials, error) {
+	for _, conf := range []*epb.GNSIConfig{ch.GetConfig().GetGnsiConfig(), m.defaults.GetGnsiGlobalConfig()} {
+		if creds := conf.GetCredentials(); creds != nil {
+			return creds, nil
+		}
+		f := conf.GetCredentialsFile()
+		if f == "" {
+			continue
+		}
+		data, err := os.ReadFile(f)
+		if err != nil {
+			return nil, fileError(f, err)
+		}
+		creds := &bpb.Credentials{}
+		if err := prototext.Unmarshal(data, creds); err != nil {
+			return nil, status.Errorf(codes.Internal, "File %s is not a valid credentials config: %v", f, err)
+		}
+		return creds, nil
+	}
+	return &bpb.Credentials{}, nil
+}
+
+// readSetRequest reads a prototext encoded gNMI SetRequest from path.
+func readSetRequest(path string) (*gpb.SetRequest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fileError(path, err)
+	}
+	req := &gpb.SetRequest{}
+	if err := prototext.Unmarshal(data, req); err != nil {
+		return nil, status.Errorf(codes.Internal, "File %s is not a valid gNMI SetRequest: %v", path, err)
+	}
+	return req, nil
+}
+
+// SecondStageConfig returns the gNMI SetRequest to apply to the chassis of the control card or
+// fixed chassis with the given serial once it is bootstrapped, or nil if none is configured.
+func (m *InMemoryEntityManager) SecondStageConfig(serial string) (*gpb.SetRequest, error) {
+	chassis, err := m.serialChassis(serial)
+	if err != nil {
+		return nil, err
+	}
+	path := chassis.GetConfig().GetSecondStageConfigFile()
+	if path == "" {
+		return nil, nil
+	}
+	return readSetRequest(path)
+}
+
+// IntendedImage returns the software image the chassis of the control card or fixed chassis with
+// the given serial is intended to run, or nil if none is configured.
+func (m *InMemoryEntityManager) IntendedImage(serial string) (*bpb.SoftwareImage, error) {
+	chassis, err := m.serialChassis(serial)
+	if err != nil {
+		return nil, err
+	}
+	return m.softwareImage(chassis, &service.EntityLookup{Manufacturer: chassis.GetManufacturer()}), nil
+}
+
+// serialChassis returns the chassis of the control card or fixed chassis with the given serial.
+// Serials are matched after normalization and against aliases, and fixed chassis are resolved
+// through lookupChassis so that chassis bootstrapped from a wildcard entry are found as well.
+func (m *InMemoryEntityManager) serialChassis(serial string) (*epb.Chassis, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	manufacturers := map[string]bool{}
+	for _, ch := range m.chassisInventory {
+		if len(ch.GetControllerCards()) == 0 {
+			manufacturers[ch.GetManufacturer()] = true
+			continue
+		}
+		for _, c := range ch.GetControllerCards() {
+			if m.serialMatches(c.GetSerialNumber(), c.GetSerialAliases(), serial) {
+				return ch, nil
+			}
+		}
+	}
+	// The reported serial carries no manufacturer, so try each one in a stable order.
+	names := make([]string, 0, len(manufacturers))
+	for name := range manufacturers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		ch, found := m.lookupChassis(&service.EntityLookup{Manufacturer: name, SerialNumber: serial})
+		if found && len(ch.GetControllerCards()) == 0 {
+			return ch, nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "could not find controller card or fixed chassis with serial#: %s", serial)
+}
+
 func populateBootConfig(conf *epb.BootConfig) (*bpb.BootConfig, error) {
 	bootConfig := &bpb.BootConfig{}
 	if conf.GetOcConfigFile() != "" {
@@ -154,18 +507,59 @@ func populateBootConfig(conf *epb.BootConfig) (*bpb.BootConfig, error) {
 	if conf.GetVendorConfigFile() != "" {
 		cliConf, err := os.ReadFile(string(conf.VendorConfigFile))
 		if err != nil {
-			return nil, status.Errorf(codes.Internal, "Could not populate vendor config %v", err)
+			return nil, fileError(conf.GetVendorConfigFile(), err)
 		}
 		bootConfig.VendorConfig = cliConf
 	}
+	if conf.GetGnmiSetRequestFile() != "" {
+		setReq, err := readSetRequest(conf.GetGnmiSetRequestFile())
+		if err != nil {
+			return nil, err
+		}
+		if bootConfig.GnmiSetRequest, err = proto.Marshal(setReq); err != nil {
+			return nil, status.Errorf(codes.Internal, "unable to serialize gNMI SetRequest: %v", err)
+		}
+	}
+	if err := selectEncoding(bootConfig, conf.GetEncoding()); err != nil {
+		return nil, err
+	}
 	// TODO: validate OC and CLI may be added. However, this may prevent negative testing
 	bootConfig.Metadata = conf.GetMetadata()
 	bootConfig.BootloaderConfig = conf.GetBootloaderConfig()
 	return bootConfig, nil
 }
 
+// selectEncoding keeps only the configuration of the given encoding, which must be set.
+// Every configuration is kept if the encoding is unspecified.
+func selectEncoding(bootConfig *bpb.BootConfig, enc bpb.ConfigEncoding) error {
+	var selected []byte
+	switch enc {
+	case bpb.ConfigEncoding_CONFIG_ENCODING_UNSPECIFIED:
+		return nil
+	case bpb.ConfigEncoding_CONFIG_ENCODING_VENDOR_CLI:
+		selected = bootConfig.GetVendorConfig()
+		bootConfig.OcConfig, bootConfig.GnmiSetRequest = nil, nil
+	case bpb.ConfigEncoding_CONFIG_ENCODING_OPENCONFIG_JSON:
+		selected = bootConfig.GetOcConfig()
+		bootConfig.VendorConfig, bootConfig.GnmiSetRequest = nil, nil
+	case bpb.ConfigEncoding_CONFIG_ENCODING_GNMI_SET_REQUEST:
+		selected = bootConfig.GetGnmiSetRequest()
+		bootConfig.VendorConfig, bootConfig.OcConfig = nil, nil
+	default:
+		return status.Errorf(codes.InvalidArgument, "unknown config encoding %v", enc)
+	}
+	if len(selected) == 0 {
+		return status.Errorf(codes.FailedPrecondition, "config encoding %v is selected, but no such config is set", enc)
+	}
+	bootConfig.Encoding = enc
+	return nil
+}
+
 // GetBootstrapData fetches and returns the bootstrap data response from the server.
-func (m *InMemoryEntityManager) GetBootstrapData(el *service.EntityLookup, controllerCard *bpb.ControlCard) (*bpb.BootstrapDataResponse, error) {
+func (m *InMemoryEntityManager) GetBootstrapData(ctx context.Context, el *service.EntityLookup, controllerCard *bpb.ControlCard) (*bpb.BootstrapDataResponse, error) {
+	if err := contextError(ctx); err != nil {
+		return nil, err
+	}
 	// First check if we are expecting this control card.
 	serial := ""
 	fixedChassis := false
@@ -179,23 +573,146 @@ func (m *InMemoryEntityManager) GetBootstrapData(el *service.EntityLookup, contr
 	if !fixedChassis {
 		serial = controllerCard.SerialNumber
 	}
-	// Check if the controller card and related chassis can be solved.
+	log.Infof("Fetching data for controller card/chassis %v", serial)
+	// Retired devices are refused even if listed again, e.g. by the next import from NetBox.
+	if m.isRetired(serial) {
+		return nil, status.Errorf(codes.PermissionDenied, "serial# %s was retired", serial)
+	}
+	chassis, configured, err := m.locateChassis(el, controllerCard)
+	if err != nil {
+		return nil, err
+	}
+	log.Infof("Control card located in inventory")
+	// Device state is kept under the inventory serial, whatever format the device reports.
+	reported := serial
+	if serial = m.deviceSerial(chassis, reported); serial != reported {
+		log.Infof("Serial# %v is known as %v in the inventory", reported, serial)
+	}
+	if m.revocations.IsRevoked(serial) {
+		return nil, status.Errorf(codes.PermissionDenied, "ownership voucher for serial# %s has been revoked", serial)
+	}
+	if err := m.states.Check(serial); err != nil {
+		return nil, err
+	}
+	if chassis.GetArtifactsPending() {
+		return nil, service.RetryLater(m.retryInterval(), "artifacts of chassis %s are not staged yet", chassis.GetSerialNumber())
+	}
+	if err := m.checkProvisioningWindow(chassis, time.Now()); err != nil {
+		return nil, err
+	}
+	// TODO: for now add status for the controller card. We may need to move all runtime info to bootz service.
+	// Chassis served the default profile are not tracked, as any number of unknown serials may match it.
+	if configured {
+		m.mu.Lock()
+		m.controlCardStatuses[serial] = bpb.ControlCardState_CONTROL_CARD_STATUS_UNSPECIFIED
+		m.mu.Unlock()
+	}
+	chassisSerial := el.SerialNumber
+	if chassisSerial == "" {
+		chassisSerial = chassis.GetSerialNumber()
+	}
+	chassis = m.experiments.Apply(chassis, chassisSerial, serial)
+	chassis, stage := m.configStage(chassis, serial)
+	resp, err := m.cachedArtifacts(serial, chassis)
+	if status.Code(err) == codes.Unavailable {
+		return nil, service.RetryLater(m.retryInterval(), "%v", status.Convert(err).Message())
+	}
+	if err != nil {
+		return nil, err
+	}
+	resp.SerialNum = reported
+	resp.IntendedImage = m.softwareImage(chassis, el)
+	resp.BootPasswordHash = chassis.BootloaderPasswordHash
+	resp.ServerTrustCert = m.serverTrustCert(chassis)
+	m.states.Sent(serial, stage, servedArtifacts(resp))
+	return resp, nil
+}
+
+// servedArtifacts returns the hashes of the intended image and of the vendor config of resp, to
+// compare with those the device reports to have applied.
+func servedArtifacts(resp *bpb.BootstrapDataResponse) devicestate.Artifacts {
+	a := devicestate.Artifacts{ImageHash: resp.GetIntendedImage().GetOsImageHash()}
+	if cfg := resp.GetBootConfig().GetVendorConfig(); len(cfg) > 0 {
+		sum := sha256.Sum256(cfg)
+		a.ConfigHash = hex.EncodeToString(sum[:])
+	}
+	return a
+}
+
+// configStage returns the config stage to serve the device with the given serial, and the chassis
+// to serve it. Chassis with a final boot config are served their boot config until the device
+// reports a successful bootstrap, and their final boot config from then on.
+func (m *InMemoryEntityManager) configStage(chassis *epb.Chassis, serial string) (*epb.Chassis, devicestate.ConfigStage) {
+	final := chassis.GetConfig().GetFinalBootConfig()
+	if final == nil {
+		return chassis, devicestate.SingleStage
+	}
+	if m.states.Stage(serial) == devicestate.InitialStage {
+		return chassis, devicestate.InitialStage
+	}
+	chassis = proto.Clone(chassis).(*epb.Chassis)
+	chassis.Config.BootConfig = final
+	chassis.Config.FinalBootConfig = nil
+	return chassis, devicestate.FinalStage
+}
+
+// retryInterval returns the interval devices are asked to wait before retrying when their artifacts are not staged.
+func (m *InMemoryEntityManager) retryInterval() time.Duration {
+	if d := m.defaults.GetRetryInterval(); d != nil {
+		return d.AsDuration()
+	}
+	return defaultRetryInterval
+}
+
+// softwareImage returns the software image of the chassis, falling back to the image of its model.
+func (m *InMemoryEntityManager) softwareImage(chassis *epb.Chassis, el *service.EntityLookup) *bpb.SoftwareImage {
+	if img := chassis.GetSoftwareImage(); img != nil {
+		return img
+	}
+	partNumber := chassis.GetPartNumber()
+	if partNumber == "" || partNumber == wildcard {
+		partNumber = el.PartNumber
+	}
+	var fallback *bpb.SoftwareImage
+	for _, mi := range m.defaults.GetModelImages() {
+		if mi.GetManufacturer() != chassis.GetManufacturer() {
+			continue
+		}
+		switch mi.GetPartNumber() {
+		case partNumber:
+			return mi.GetSoftwareImage()
+		case wildcard:
+			if fallback == nil {
+				fallback = mi.GetSoftwareImage()
+			}
+		}
+	}
+	return fallback
+}
+
+// locateChassis returns the inventory entry of a fixed chassis, or of the modular chassis
+// the control card belongs to, falling back to the default profile. It also reports whether the
+// chassis is configured in the inventory rather than served the default profile.
+func (m *InMemoryEntityManager) locateChassis(el *service.EntityLookup, controllerCard *bpb.ControlCard) (*epb.Chassis, bool, error) {
 	var chassis *epb.Chassis
 	found := false
-	m.mu.Lock()
-	defer m.mu.Unlock()
-	log.Infof("Fetching data for controller card/chassis %v", serial)
-	if fixedChassis {
-		chassis, found = m.chassisInventory[*el]
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if controllerCard == nil {
+		chassis, found = m.lookupChassis(el)
+		if found {
+			return chassis, true, nil
+		}
+		chassis, found = m.defaultProfile(el)
 		if !found { // fixed chassis must have serial
-			return nil, status.Errorf(codes.NotFound, "could not find fixed chassis with serial#: %s and manufacturer: %s", chassis.SerialNumber, chassis.Manufacturer)
+			return nil, false, status.Errorf(codes.NotFound, "could not find fixed chassis with serial#: %s, part#: %s and manufacturer: %s", el.SerialNumber, el.PartNumber, el.Manufacturer)
 		}
 	} else {
 		found = false
 	out:
 		for _, ch := range m.chassisInventory {
 			for _, c := range ch.GetControllerCards() {
-				if c.GetSerialNumber() == controllerCard.GetSerialNumber() && c.GetPartNumber() == controllerCard.PartNumber {
+				if m.serialMatches(c.GetSerialNumber(), c.GetSerialAliases(), controllerCard.GetSerialNumber()) && c.GetPartNumber() == controllerCard.PartNumber {
 					if ch.Manufacturer != el.Manufacturer {
 						continue
 					}
@@ -205,13 +722,20 @@ func (m *InMemoryEntityManager) GetBootstrapData(el *service.EntityLookup, contr
 				}
 			}
 		}
+		if found {
+			return chassis, true, nil
+		}
+		chassis, found = m.defaultProfile(el)
 		if !found {
-			return nil, status.Errorf(codes.NotFound, "could not find controller card with serial# %s", serial)
+			return nil, false, status.Errorf(codes.NotFound, "could not find controller card with serial# %s", controllerCard.GetSerialNumber())
 		}
 	}
-	log.Infof("Control card located in inventory")
-	// TODO: for now add status for the controller card. We may need to move all runtime info to bootz service.
-	m.controlCardStatuses[serial] = bpb.ControlCardState_CONTROL_CARD_STATUS_UNSPECIFIED
+	return chassis, false, nil
+}
+
+// populateArtifacts reads the artifacts configured for the chassis and checks them
+// with the registered validators.
+func (m *InMemoryEntityManager) populateArtifacts(chassis *epb.Chassis) (*bpb.BootstrapDataResponse, error) {
 	bootCfg, err := populateBootConfig(chassis.GetConfig().GetBootConfig())
 	if err != nil {
 		return nil, err
@@ -220,22 +744,36 @@ func (m *InMemoryEntityManager) GetBootstrapData(el *service.EntityLookup, contr
 	if err != nil {
 		return nil, err
 	}
-
-	// TODO: Populate gnsi config
-	return &bpb.BootstrapDataResponse{
-		SerialNum:        serial,
-		IntendedImage:    chassis.GetSoftwareImage(),
-		BootPasswordHash: chassis.BootloaderPasswordHash,
-		ServerTrustCert:  m.secArtifacts.OC.Cert,
-		BootConfig:       bootCfg,
-		Credentials:      &bpb.Credentials{},
-		// TODO: Populate pathz, authz and certificates.
-		Authz: authzConf,
-	}, nil
+	creds, err := m.populateCredentials(chassis)
+	if err != nil {
+		return nil, err
+	}
+	// TODO: Populate pathz and certificates.
+	resp := &bpb.BootstrapDataResponse{
+		BootConfig:  bootCfg,
+		Credentials: creds,
+		Authz:       authzConf,
+	}
+	if err := m.validators.Validate(resp); err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "invalid artifacts for chassis %s: %v", chassis.GetSerialNumber(), err)
+	}
+	if final := chassis.GetConfig().GetFinalBootConfig(); final != nil {
+		finalCfg, err := populateBootConfig(final)
+		if err != nil {
+			return nil, err
+		}
+		if err := m.validators.Validate(&bpb.BootstrapDataResponse{BootConfig: finalCfg, Credentials: creds, Authz: authzConf}); err != nil {
+			return nil, status.Errorf(codes.FailedPrecondition, "invalid final boot config for chassis %s: %v", chassis.GetSerialNumber(), err)
+		}
+	}
+	return resp, nil
 }
 
 // SetStatus updates the status for each control card on the chassis.
-func (m *InMemoryEntityManager) SetStatus(req *bpb.ReportStatusRequest) error {
+func (m *InMemoryEntityManager) SetStatus(ctx context.Context, req *bpb.ReportStatusRequest) error {
+	if err := contextError(ctx); err != nil {
+		return err
+	}
 	if len(req.GetStates()) == 0 {
 		return status.Errorf(codes.InvalidArgument, "no control card or fixed chassis states provided")
 	}
@@ -244,24 +782,53 @@ func (m *InMemoryEntityManager) SetStatus(req *bpb.ReportStatusRequest) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	for _, c := range req.GetStates() {
-		previousStatus, ok := m.controlCardStatuses[c.GetSerialNumber()]
+		serial := m.inventorySerial(c.GetSerialNumber())
+		previousStatus, ok := m.controlCardStatuses[serial]
 		if !ok {
 			return status.Errorf(codes.NotFound, "control card %v not found in inventory", c.GetSerialNumber())
 		}
-		log.Infof("control card %v changed status from %v to %v", c.GetSerialNumber(), previousStatus, c.GetStatus())
-		m.controlCardStatuses[c.GetSerialNumber()] = c.GetStatus()
+		log.Infof("control card %v changed status from %v to %v", serial, previousStatus, c.GetStatus())
+		m.controlCardStatuses[serial] = c.GetStatus()
+		m.experiments.Record(serial, req.GetStatus())
+		m.states.Report(serial, req.GetStatus(), devicestate.Artifacts{
+			ImageHash:  c.GetAppliedImageHash(),
+			ConfigHash: c.GetAppliedConfigHash(),
+		})
+		if req.GetStatus() == bpb.ReportStatusRequest_BOOTSTRAP_STATUS_SUCCESS {
+			m.useToken(serial)
+		}
+	}
+	return nil
+}
+
+// contextError returns the status of the error of ctx if it is done, so that requests abandoned by
+// the device or past the timeout of their stage are not processed further.
+func contextError(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return status.FromContextError(err).Err()
 	}
 	return nil
 }
 
-// // readKeyPair reads the cert/key pair from the specified directory.
+// readKeyPair reads the cert/key pair from the root of the artifacts file system.
 // Certs must have the format {name}_pub.pem and keys must have the format {name}_priv.pem
-func readKeypair(dir, name string) (*service.KeyPair, error) {
-	cert, err := os.ReadFile(filepath.Join(dir, fmt.Sprintf("%v_pub.pem", name)))
+// If keyURI is set, the private key is kept in the key store it identifies instead.
+func readKeypair(fsys fs.FS, name, keyURI string) (*service.KeyPair, error) {
+	cert, err := fs.ReadFile(fsys, fmt.Sprintf("%v_pub.pem", name))
 	if err != nil {
 		return nil, fmt.Errorf("unable to read %v cert: %v", name, err)
 	}
-	privateKey, err := os.ReadFile(filepath.Join(dir, fmt.Sprintf("%v_priv.pem", name)))
+	if keyURI != "" {
+		signer, err := keystore.Open(keyURI)
+		if err != nil {
+			return nil, fmt.Errorf("unable to open %v key: %v", name, err)
+		}
+		return &service.KeyPair{
+			Cert:   string(cert),
+			Signer: signer,
+		}, nil
+	}
+	privateKey, err := fs.ReadFile(fsys, fmt.Sprintf("%v_priv.pem", name))
 	if err != nil {
 		return nil, fmt.Errorf("unable to read %v key: %v", name, err)
 	}
@@ -273,24 +840,38 @@ func readKeypair(dir, name string) (*service.KeyPair, error) {
 
 // loadServerTLSCert uses the PDC key as the server certificate.
 func loadServerTLSCert(pdc *service.KeyPair) (*tls.Certificate, error) {
-	tlsCert, err := tls.X509KeyPair([]byte(pdc.Cert), []byte(pdc.PrivateKey))
+	tlsCert, err := pdc.TLSCertificate()
 	if err != nil {
 		return nil, fmt.Errorf("unable to load PDC keys %v", err)
 	}
-	return &tlsCert, err
+	return tlsCert, err
 }
 
-// parseSecurityArtifacts reads from the specified directory to find the required keypairs and ownership vouchers.
-func parseSecurityArtifacts(artifactDir string) (*service.SecurityArtifacts, error) {
-	oc, err := readKeypair(artifactDir, "oc")
+// parseSecurityArtifacts reads from the artifacts file system to find the required keypairs.
+// The OC and PDC private keys are opened from the key store URIs instead, if set.
+func parseSecurityArtifacts(fsys fs.FS, ocKeyURI, pdcKeyURI string) (*service.SecurityArtifacts, error) {
+	sa, err := parseOwnerArtifacts(fsys, ocKeyURI, pdcKeyURI)
 	if err != nil {
 		return nil, err
 	}
-	pdc, err := readKeypair(artifactDir, "pdc")
+	if sa.VendorCA, err = readKeypair(fsys, "vendorca", ""); err != nil {
+		return nil, err
+	}
+	return sa, nil
+}
+
+// parseOwnerArtifacts reads the OC and PDC keypairs from the artifacts file system.
+func parseOwnerArtifacts(fsys fs.FS, ocKeyURI, pdcKeyURI string) (*service.SecurityArtifacts, error) {
+	oc, err := readKeypair(fsys, "oc", ocKeyURI)
 	if err != nil {
 		return nil, err
 	}
-	vendorCA, err := readKeypair(artifactDir, "vendorca")
+	ocChain, err := oc.CertChain()
+	if err != nil {
+		return nil, fmt.Errorf("invalid OC certificate chain: %v", err)
+	}
+	log.Infof("Loaded OC %q with %d intermediate certificates", ocChain[0].Subject.CommonName, len(ocChain)-1)
+	pdc, err := readKeypair(fsys, "pdc", pdcKeyURI)
 	if err != nil {
 		return nil, err
 	}
@@ -302,84 +883,486 @@ func parseSecurityArtifacts(artifactDir string) (*service.SecurityArtifacts, err
 	return &service.SecurityArtifacts{
 		OC:         oc,
 		PDC:        pdc,
-		VendorCA:   vendorCA,
 		TLSKeypair: tlsCert,
 	}, nil
 }
 
-// isBase64 check if a string is base64 encoded.
-func isBase64(str string) bool {
-	return rxBase64.MatchString(str)
-}
-
-// Sign unmarshals the SignedResponse bytes then generates a signature from its Ownership Certificate private key.
-func (m *InMemoryEntityManager) Sign(resp *bpb.GetBootstrapDataResponse, chassis *service.EntityLookup, controllerCard string) error {
-	m.mu.Lock()
-	defer m.mu.Unlock()
-	// Check if security artifacts are provided for signing.
+// validateSecurityArtifacts checks that the certificates of the security artifacts are valid at
+// now, and that the OCs chain to their PDCs. In FIPS mode, it also checks that the certificates use
+// approved key types and signature algorithms. Unless vouchers are served unverified, it also checks
+// that the ownership vouchers of the chassis are signed by the vendor CA and pin the PDC they are
+// served with. All problems found are reported at once.
+func (m *InMemoryEntityManager) validateSecurityArtifacts(chassis []*epb.Chassis, now time.Time) error {
 	if m.secArtifacts == nil {
-		return status.Errorf(codes.Internal, "security artifact is missing")
+		return nil
 	}
-	if len(resp.GetSerializedBootstrapData()) == 0 {
-		return status.Errorf(codes.InvalidArgument, "empty serialized bootstrap data")
+	var problems []string
+	check := func(format string, args ...any) {
+		problems = append(problems, fmt.Sprintf(format, args...))
 	}
-
-	block, _ := pem.Decode([]byte(m.secArtifacts.OC.PrivateKey))
-	if block == nil {
-		return fmt.Errorf("unable to decode private key")
+	fipsMode := m.fipsMode()
+	checkValidity := func(name string, cert *x509.Certificate) bool {
+		if fipsMode {
+			if err := fips.CheckCertificate(cert); err != nil {
+				check("%s %q is not allowed in FIPS mode: %v", name, cert.Subject.CommonName, err)
+			}
+		}
+		switch {
+		case now.After(cert.NotAfter):
+			check("%s %q expired on %v", name, cert.Subject.CommonName, cert.NotAfter)
+		case now.Before(cert.NotBefore):
+			check("%s %q is not valid before %v", name, cert.Subject.CommonName, cert.NotBefore)
+		default:
+			return true
+		}
+		return false
 	}
-	priv, err := x509.ParsePKCS1PrivateKey(block.Bytes)
-	if err != nil {
-		return err
+	// checkOwner returns the PDC of the artifacts, or nil if it is unusable.
+	checkOwner := func(prefix string, sa *service.SecurityArtifacts) *x509.Certificate {
+		pdc, err := certFromPEM(sa.PDC.Cert)
+		if err != nil {
+			check("%sPDC is not a valid certificate: %v", prefix, err)
+			return nil
+		}
+		pdcValid := checkValidity(prefix+"PDC", pdc)
+		chain, err := sa.OC.CertChain()
+		if err != nil {
+			check("%sOC is not a valid certificate chain: %v", prefix, err)
+			return pdc
+		}
+		chainValid := checkValidity(prefix+"OC", chain[0])
+		intermediates := x509.NewCertPool()
+		for _, c := range chain[1:] {
+			chainValid = checkValidity(prefix+"OC intermediate", c) && chainValid
+			intermediates.AddCert(c)
+		}
+		if !pdcValid || !chainValid {
+			return pdc
+		}
+		roots := x509.NewCertPool()
+		roots.AddCert(pdc)
+		if _, err := chain[0].Verify(x509.VerifyOptions{
+			Roots:         roots,
+			Intermediates: intermediates,
+			CurrentTime:   now,
+			KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+		}); err != nil {
+			check("%sOC %q does not chain to the PDC %q: %v", prefix, chain[0].Subject.CommonName, pdc.Subject.CommonName, err)
+		}
+		return pdc
 	}
-	sig, err := signature.Sign(priv, resp.GetSerializedBootstrapData())
+
+	vendorCA, err := certFromPEM(m.secArtifacts.VendorCA.Cert)
 	if err != nil {
-		return err
+		check("vendor CA is not a valid certificate: %v", err)
+	} else {
+		checkValidity("vendor CA", vendorCA)
+	}
+	pdc := checkOwner("", m.secArtifacts)
+	var rotatedPDC *x509.Certificate
+	if m.rotation != nil {
+		rotatedPDC = checkOwner("rotated ", m.rotation)
+	}
+	domainPDCs := map[string]*x509.Certificate{}
+	for name, sa := range m.domains {
+		domainPDCs[name] = checkOwner(fmt.Sprintf("domain %s ", name), sa)
 	}
-	resp.ResponseSignature = sig
 
-	// Populate the OV
-	ov, err := m.fetchOwnershipVoucher(chassis, controllerCard)
+	match, err := m.pdcMatch()
 	if err != nil {
-		return err
+		check("%v", err)
 	}
-	ovByte := []byte(ov)
-	if isBase64(ov) {
-		ovByte, err = base64.StdEncoding.DecodeString(ov)
-		if err != nil {
-			return status.Errorf(codes.Internal, "unable to decode ov from base64")
+	validation, err := m.voucherValidation()
+	if err != nil {
+		check("%v", err)
+	}
+	// Vouchers are only verified when served if they pin a PDC, may be revoked or are validated.
+	verifyVouchers := match != ownershipvoucher.PDCMatchNone || m.revocations.CRL() != nil || validation != ownershipvoucher.ValidationNone
+	// Vouchers are collected first and verified concurrently, as large fleets carry hundreds of them.
+	type voucherCheck struct {
+		name, serial, ov string
+		pdc              *x509.Certificate
+	}
+	var vouchers []voucherCheck
+	checkVoucher := func(name, serial, ov string, pdc *x509.Certificate) {
+		if !verifyVouchers || ov == "" || vendorCA == nil || pdc == nil {
+			return
 		}
+		vouchers = append(vouchers, voucherCheck{name: name, serial: serial, ov: ov, pdc: pdc})
 	}
-	resp.OwnershipVoucher = ovByte
-	log.Infof("OV populated")
-
-	// Populate the OC
-	resp.OwnershipCertificate = []byte(m.secArtifacts.OC.Cert)
-	log.Infof("OC populated")
-	return nil
-}
-
-// fetchOwnershipVoucher retrieves the ownership voucher for a control card
-func (m *InMemoryEntityManager) fetchOwnershipVoucher(lookup *service.EntityLookup, ccSerial string) (string, error) {
-	chassis, ok := m.chassisInventory[*lookup]
-	if !ok {
-		if lookup.SerialNumber == "" {
-			chassis, _ = m.resolveChassisViaControllerCard(lookup, ccSerial)
-			if chassis == nil {
-				return "", status.Errorf(codes.NotFound, "could not find chassis for controller car #: %s", ccSerial)
+	for _, ch := range chassis {
+		if domainPDC, ok := domainPDCs[ch.GetTenant()]; ok {
+			if len(ch.GetControllerCards()) == 0 {
+				checkVoucher("ownership voucher", ch.GetSerialNumber(), ch.GetOwnershipVoucher(), domainPDC)
 			}
+			for _, c := range ch.GetControllerCards() {
+				checkVoucher("ownership voucher", c.GetSerialNumber(), c.GetOwnershipVoucher(), domainPDC)
+			}
+			continue
 		}
-	}
-	for _, c := range chassis.GetControllerCards() {
-		if c.GetSerialNumber() == ccSerial {
-			return c.GetOwnershipVoucher(), nil
+		if len(ch.GetControllerCards()) == 0 {
+			checkVoucher("ownership voucher", ch.GetSerialNumber(), ch.GetOwnershipVoucher(), pdc)
+			checkVoucher("rotated ownership voucher", ch.GetSerialNumber(), ch.GetRotatedOwnershipVoucher(), rotatedPDC)
+		}
+		for _, c := range ch.GetControllerCards() {
+			checkVoucher("ownership voucher", c.GetSerialNumber(), c.GetOwnershipVoucher(), pdc)
+			checkVoucher("rotated ownership voucher", c.GetSerialNumber(), c.GetRotatedOwnershipVoucher(), rotatedPDC)
 		}
 	}
-	// Handle fixed chassis.
-	if len(chassis.GetControllerCards()) == 0 {
-		return chassis.GetOwnershipVoucher(), nil
+	voucherProblems := make([]string, len(vouchers))
+	forEach(len(vouchers), m.loadWorkers(), func(i int) {
+		v := vouchers[i]
+		ovByte := []byte(v.ov)
+		if isBase64(v.ov) {
+			var err error
+			if ovByte, err = base64.StdEncoding.DecodeString(v.ov); err != nil {
+				voucherProblems[i] = fmt.Sprintf("%s of serial#: %s is not valid base64: %v", v.name, v.serial, err)
+				return
+			}
+		}
+		trustAnchors := x509.NewCertPool()
+		trustAnchors.AddCert(vendorCA)
+		_, err := ownershipvoucher.VerifyAndUnmarshalWithOptions(ovByte, ownershipvoucher.VerifyOptions{
+			TrustAnchors:     trustAnchors,
+			PinnedDomainCert: v.pdc,
+			PDCMatch:         match,
+			Validation:       validation,
+		})
+		var verr *ownershipvoucher.ValidationError
+		switch {
+		case errors.As(err, &verr):
+			voucherProblems[i] = fmt.Sprintf("%s of serial#: %s is invalid: %v", v.name, v.serial, verr)
+		case errors.Is(err, ownershipvoucher.ErrPinnedDomainCertMismatch):
+			voucherProblems[i] = fmt.Sprintf("%s of serial#: %s does not pin the PDC %q: %v", v.name, v.serial, v.pdc.Subject.CommonName, err)
+		case err != nil:
+			voucherProblems[i] = fmt.Sprintf("%s of serial#: %s can not be verified with the vendor CA: %v", v.name, v.serial, err)
+		}
+	})
+	for _, p := range voucherProblems {
+		if p != "" {
+			problems = append(problems, p)
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	for _, p := range problems {
+		log.Errorf("Invalid security artifacts: %s", p)
+	}
+	return fmt.Errorf("found %d problems with the security artifacts:\n\t%s", len(problems), strings.Join(problems, "\n\t"))
+}
+
+// voucherValidation returns how strictly ownership vouchers are validated under the configured
+// voucher validation mode.
+func (m *InMemoryEntityManager) voucherValidation() (ownershipvoucher.Validation, error) {
+	switch mode := m.defaults.GetVoucherValidation(); mode {
+	case epb.VoucherValidation_VOUCHER_VALIDATION_NONE:
+		return ownershipvoucher.ValidationNone, nil
+	case epb.VoucherValidation_VOUCHER_VALIDATION_STRICT:
+		return ownershipvoucher.ValidationStrict, nil
+	case epb.VoucherValidation_VOUCHER_VALIDATION_SCHEMA:
+		return ownershipvoucher.ValidationSchema, nil
+	default:
+		return ownershipvoucher.ValidationNone, fmt.Errorf("unknown voucher validation mode %v", mode)
+	}
+}
+
+// fipsMode reports whether the cryptography of the server is restricted to FIPS approved algorithms.
+func (m *InMemoryEntityManager) fipsMode() bool {
+	return fips.Enabled() || m.defaults.GetFipsMode()
+}
+
+// pdcMatch returns how pinned-domain-certs are compared with the PDC under the configured PDC verification mode.
+func (m *InMemoryEntityManager) pdcMatch() (ownershipvoucher.PDCMatch, error) {
+	switch mode := m.defaults.GetPdcVerification(); mode {
+	case epb.PDCVerification_PDC_VERIFICATION_NONE:
+		return ownershipvoucher.PDCMatchNone, nil
+	case epb.PDCVerification_PDC_VERIFICATION_EXACT:
+		return ownershipvoucher.PDCMatchExact, nil
+	case epb.PDCVerification_PDC_VERIFICATION_SPKI:
+		return ownershipvoucher.PDCMatchSPKI, nil
+	default:
+		return ownershipvoucher.PDCMatchNone, fmt.Errorf("unknown PDC verification mode %v", mode)
+	}
+}
+
+// inTransition reports whether the transition window of an ownership rotation is open.
+func (m *InMemoryEntityManager) inTransition() bool {
+	if m.rotation == nil {
+		return false
+	}
+	end := m.defaults.GetOwnershipRotation().GetTransitionEnd()
+	return end == nil || time.Now().Before(end.AsTime())
+}
+
+// serverTrustCert returns the OCs devices of the chassis trust the server with: the OC of its
+// ownership domain, or else the current OC, the new OC of an ownership rotation, or both during its
+// transition window.
+func (m *InMemoryEntityManager) serverTrustCert(ch *epb.Chassis) string {
+	if sa := m.domainArtifacts(ch); sa != nil {
+		return sa.OC.Cert
+	}
+	switch {
+	case m.rotation == nil:
+		return m.secArtifacts.OC.Cert
+	case m.inTransition():
+		return m.rotation.OC.Cert + m.secArtifacts.OC.Cert
+	default:
+		return m.rotation.OC.Cert
+	}
+}
+
+// rotatedVouchers returns the rotated ownership vouchers of the chassis by control card serial, or
+// nil unless an ownership rotation is configured and every control card, or the fixed chassis, has one.
+func (m *InMemoryEntityManager) rotatedVouchers(ch *epb.Chassis) (map[string]string, error) {
+	if m.rotation == nil {
+		return nil, nil
+	}
+	if len(ch.GetControllerCards()) == 0 {
+		ov, err := m.voucher(ch.GetSerialNumber(), ch.GetRotatedOwnershipVoucher(), true)
+		if ov == "" || err != nil {
+			return nil, err
+		}
+		return map[string]string{ch.GetSerialNumber(): ov}, nil
+	}
+	ovs := map[string]string{}
+	for _, c := range ch.GetControllerCards() {
+		ov, err := m.voucher(c.GetSerialNumber(), c.GetRotatedOwnershipVoucher(), true)
+		if ov == "" || err != nil {
+			return nil, err
+		}
+		ovs[c.GetSerialNumber()] = ov
+	}
+	return ovs, nil
+}
+
+// isBase64 check if a string is base64 encoded.
+func isBase64(str string) bool {
+	return rxBase64.MatchString(str)
+}
+
+// Sign unmarshals the SignedResponse bytes then generates a signature from its Ownership Certificate private key,
+// with the first of the accepted signature algorithms the key supports, or its default algorithm if none are listed.
+func (m *InMemoryEntityManager) Sign(ctx context.Context, resp *bpb.GetBootstrapDataResponse, chassis *service.EntityLookup, controllerCard string, accepted []bpb.SignatureAlgorithm) error {
+	if err := contextError(ctx); err != nil {
+		return err
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	// Check if security artifacts are provided for signing.
+	if m.secArtifacts == nil {
+		return status.Errorf(codes.FailedPrecondition, "signing requires security artifacts, but none are configured")
+	}
+	if len(resp.GetSerializedBootstrapData()) == 0 {
+		return status.Errorf(codes.InvalidArgument, "empty serialized bootstrap data")
+	}
+
+	// Populate the OV
+	ovByte, sa, err := m.ownershipVoucher(chassis, controllerCard)
+	if err != nil {
+		return err
+	}
+	resp.OwnershipVoucher = ovByte
+	log.Infof("OV populated")
+
+	if resp.ResponseSignature, resp.SignatureAlgorithm, err = m.cachedSignature(ctx, sa.OC, resp.GetSerializedBootstrapData(), accepted); err != nil {
+		return err
+	}
+	// Signing with a remote key store may take long enough for the request to be abandoned.
+	if err := contextError(ctx); err != nil {
+		return err
+	}
+	// Populate the OC, followed by the intermediates chaining it to the PDC.
+	resp.OwnershipCertificate = []byte(sa.OC.Cert)
+	log.Infof("OC populated")
+
+	// Devices served a rotated OC may still trust the previous PDC during the transition window.
+	if sa == m.rotation && m.inTransition() {
+		if resp.PreviousResponseSignature, resp.PreviousSignatureAlgorithm, err = m.cachedSignature(ctx, m.secArtifacts.OC, resp.GetSerializedBootstrapData(), accepted); err != nil {
+			return err
+		}
+		resp.PreviousOwnershipCertificate = []byte(m.secArtifacts.OC.Cert)
+		log.Infof("Response signed by the rotated and the previous OC")
+	}
+	return nil
+}
+
+// OwnershipVoucher returns the verified ownership voucher of a control card, or of the chassis itself
+// for fixed form factor devices.
+func (m *InMemoryEntityManager) OwnershipVoucher(ctx context.Context, chassis *service.EntityLookup, controllerCard string) ([]byte, error) {
+	if err := contextError(ctx); err != nil {
+		return nil, err
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	ov, _, err := m.ownershipVoucher(chassis, controllerCard)
+	return ov, err
+}
+
+// ownershipVoucher fetches, decodes and verifies an ownership voucher. It also returns the security
+// artifacts whose PDC the voucher pins. The caller must hold m.mu for reading.
+func (m *InMemoryEntityManager) ownershipVoucher(chassis *service.EntityLookup, controllerCard string) ([]byte, *service.SecurityArtifacts, error) {
+	ov, sa, err := m.selectOwnershipVoucher(chassis, controllerCard)
+	if err != nil {
+		return nil, nil, err
+	}
+	ovByte := []byte(ov)
+	if isBase64(ov) {
+		ovByte, err = base64.StdEncoding.DecodeString(ov)
+		if err != nil {
+			return nil, nil, status.Errorf(codes.Internal, "unable to decode ov from base64")
+		}
+	}
+	if err := m.verifyOwnershipVoucher(ovByte, sa); err != nil {
+		return nil, nil, err
+	}
+	return ovByte, sa, nil
+}
+
+// selectOwnershipVoucher returns the rotated ownership voucher of a chassis under an ownership
+// rotation and the rotation's artifacts, or its ownership voucher and the current artifacts.
+// Chassis without rotated vouchers are refused once the transition window has ended. Chassis of an
+// ownership domain are served their ownership voucher with the artifacts of the domain instead.
+// The caller must hold m.mu for reading.
+func (m *InMemoryEntityManager) selectOwnershipVoucher(lookup *service.EntityLookup, ccSerial string) (string, *service.SecurityArtifacts, error) {
+	if len(m.domains) > 0 {
+		chassis, err := m.ownershipChassis(lookup, ccSerial)
+		if err != nil {
+			return "", nil, err
+		}
+		if sa := m.domainArtifacts(chassis); sa != nil {
+			ov, err := m.fetchOwnershipVoucher(lookup, ccSerial)
+			return ov, sa, err
+		}
+	}
+	if m.rotation != nil {
+		chassis, err := m.ownershipChassis(lookup, ccSerial)
+		if err != nil {
+			return "", nil, err
+		}
+		ovs, err := m.rotatedVouchers(chassis)
+		if err != nil {
+			return "", nil, err
+		}
+		if ovs != nil {
+			if ov, ok := ovs[m.deviceSerial(chassis, ccSerial)]; ok {
+				return ov, m.rotation, nil
+			}
+		}
+		if !m.inTransition() {
+			return "", nil, status.Errorf(codes.FailedPrecondition, "the ownership rotation has ended, but chassis with serial#: %s has no rotated ownership voucher for serial#: %s", chassis.GetSerialNumber(), ccSerial)
+		}
+	}
+	ov, err := m.fetchOwnershipVoucher(lookup, ccSerial)
+	return ov, m.secArtifacts, err
+}
+
+// certFromPEM parses the first certificate in a PEM block.
+func certFromPEM(data string) (*x509.Certificate, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil {
+		return nil, fmt.Errorf("unable to decode certificate PEM")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// verifyOwnershipVoucher checks that the voucher is signed by the vendor CA and pins the PDC of sa,
+// according to the configured PDC verification mode.
+func (m *InMemoryEntityManager) verifyOwnershipVoucher(ov []byte, sa *service.SecurityArtifacts) error {
+	crl := m.revocations.CRL()
+	match, err := m.pdcMatch()
+	if err != nil {
+		return status.Errorf(codes.Internal, "%v", err)
+	}
+	validation, err := m.voucherValidation()
+	if err != nil {
+		return status.Errorf(codes.Internal, "%v", err)
+	}
+	if match == ownershipvoucher.PDCMatchNone && crl == nil && validation == ownershipvoucher.ValidationNone {
+		return nil
+	}
+	if sa == nil {
+		return status.Errorf(codes.Internal, "security artifact is missing")
+	}
+	vendorCA, err := certFromPEM(sa.VendorCA.Cert)
+	if err != nil {
+		return status.Errorf(codes.Internal, "unable to parse vendor CA cert: %v", err)
+	}
+	pdc, err := certFromPEM(sa.PDC.Cert)
+	if err != nil {
+		return status.Errorf(codes.Internal, "unable to parse PDC cert: %v", err)
+	}
+	trustAnchors := x509.NewCertPool()
+	trustAnchors.AddCert(vendorCA)
+	_, err = ownershipvoucher.VerifyAndUnmarshalWithOptions(ov, ownershipvoucher.VerifyOptions{
+		TrustAnchors:     trustAnchors,
+		PinnedDomainCert: pdc,
+		PDCMatch:         match,
+		RevocationList:   crl,
+		Validation:       validation,
+	})
+	var verr *ownershipvoucher.ValidationError
+	switch {
+	case errors.As(err, &verr):
+		return status.Errorf(codes.FailedPrecondition, "invalid ownership voucher: %v", verr)
+	case errors.Is(err, ownershipvoucher.ErrPinnedDomainCertMismatch):
+		return status.Errorf(codes.FailedPrecondition, "ownership voucher is pinned to a different domain: %v", err)
+	case errors.Is(err, ownershipvoucher.ErrSignerRevoked):
+		return status.Errorf(codes.PermissionDenied, "ownership voucher has been revoked: %v", err)
+	case err != nil:
+		return status.Errorf(codes.Internal, "unable to verify ownership voucher: %v", err)
+	}
+	log.Infof("Verified ownership voucher")
+	return nil
+}
+
+// fetchOwnershipVoucher retrieves the ownership voucher for a control card
+func (m *InMemoryEntityManager) fetchOwnershipVoucher(lookup *service.EntityLookup, ccSerial string) (string, error) {
+	chassis, err := m.ownershipChassis(lookup, ccSerial)
+	if err != nil {
+		return "", err
+	}
+	ov, serial, found := "", "", false
+	for _, c := range chassis.GetControllerCards() {
+		if m.serialMatches(c.GetSerialNumber(), c.GetSerialAliases(), ccSerial) {
+			ov, serial, found = c.GetOwnershipVoucher(), c.GetSerialNumber(), true
+		}
+	}
+	// Handle fixed chassis.
+	if len(chassis.GetControllerCards()) == 0 {
+		ov, serial, found = chassis.GetOwnershipVoucher(), chassis.GetSerialNumber(), true
 	}
-	return "", status.Errorf(codes.NotFound, "could not find controller card or fixed chassis with serial#: %s", ccSerial)
+	if !found {
+		return "", status.Errorf(codes.NotFound, "could not find controller card or fixed chassis with serial#: %s", ccSerial)
+	}
+	if ov, err = m.voucher(serial, ov, false); err != nil {
+		return "", err
+	}
+	if ov == "" && chassis.GetBootMode() == bpb.BootMode_BOOT_MODE_SECURE {
+		return "", status.Errorf(codes.FailedPrecondition, "chassis with serial#: %s requires secure boot, but has no ownership voucher for serial#: %s", chassis.GetSerialNumber(), ccSerial)
+	}
+	return ov, nil
+}
+
+// ownershipChassis returns the inventory entry of the chassis whose ownership voucher is requested.
+// The caller must hold m.mu for reading.
+func (m *InMemoryEntityManager) ownershipChassis(lookup *service.EntityLookup, ccSerial string) (*epb.Chassis, error) {
+	chassis, ok := m.lookupChassis(lookup)
+	if !ok && lookup.SerialNumber == "" {
+		chassis, _ = m.resolveChassisViaControllerCard(lookup, ccSerial)
+		ok = chassis != nil
+	}
+	if !ok {
+		if _, isDefault := m.defaultProfile(lookup); isDefault {
+			return nil, status.Errorf(codes.FailedPrecondition, "chassis with serial#: %s is served the default profile, which has no ownership voucher", lookup.SerialNumber)
+		}
+		if lookup.SerialNumber != "" {
+			return nil, status.Errorf(codes.NotFound, "could not find chassis with serial#: %s", lookup.SerialNumber)
+		}
+		return nil, status.Errorf(codes.NotFound, "could not find chassis for controller car #: %s", ccSerial)
+	}
+	return chassis, nil
 }
 
 // AddControlCard adds a new control card to the entity manager.
@@ -408,19 +1391,214 @@ func (m *InMemoryEntityManager) AddChassis(bootMode bpb.BootMode, manufacturer s
 	return m
 }
 
-// GetChassisInventory returns the chassis inventory
+// GetFeatureFlags returns the feature flag settings from the inventory options.
+func (m *InMemoryEntityManager) GetFeatureFlags() []*epb.FeatureFlag {
+	return m.defaults.GetFeatureFlags()
+}
+
+// GetVendorQuirks returns the quirks normalizing the chassis descriptors of vendors deviating
+// from the specification.
+func (m *InMemoryEntityManager) GetVendorQuirks() []*epb.VendorQuirks {
+	return m.defaults.GetVendorQuirks()
+}
+
+// GetChassisDescriptorValidation returns how strictly the chassis descriptors of requests are
+// validated.
+func (m *InMemoryEntityManager) GetChassisDescriptorValidation() epb.ChassisDescriptorValidation {
+	return m.defaults.GetChassisDescriptorValidation()
+}
+
+// GetPeerIdentityCheck returns how TLS client certificates are checked against chassis descriptors.
+func (m *InMemoryEntityManager) GetPeerIdentityCheck() epb.PeerIdentityCheck {
+	return m.defaults.GetPeerIdentityCheck()
+}
+
+// GetAuthenticators returns the authenticators of the bootstrap RPCs from the inventory options.
+func (m *InMemoryEntityManager) GetAuthenticators() []*epb.Authenticator {
+	return m.defaults.GetAuthenticators()
+}
+
+// GetTimeouts returns the timeouts of the stages of the bootstrap RPCs.
+func (m *InMemoryEntityManager) GetTimeouts() *epb.Timeouts {
+	return m.defaults.GetTimeouts()
+}
+
+// GetArtifactDir returns the directory the security artifacts are read from, or "" if they are
+// generated or not configured.
+func (m *InMemoryEntityManager) GetArtifactDir() string {
+	if m.generated {
+		return ""
+	}
+	return m.defaults.GetArtifactDir()
+}
+
+// GetRequestLogging returns the redaction of the requests and responses logged at debug level.
+func (m *InMemoryEntityManager) GetRequestLogging() *epb.RequestLogging {
+	return m.defaults.GetRequestLogging()
+}
+
+// GetRedirects returns the rules redirecting devices to other bootz servers from the inventory options.
+func (m *InMemoryEntityManager) GetRedirects() []*epb.RedirectRule {
+	return m.defaults.GetRedirects()
+}
+
+// GetBootstrapPolicies returns the policies bootstrap requests must be allowed by from the
+// inventory options.
+func (m *InMemoryEntityManager) GetBootstrapPolicies() []*epb.BootstrapPolicy {
+	return m.defaults.GetBootstrapPolicies()
+}
+
+// GetQuarantine returns the quarantine of unknown devices pending approval from the inventory
+// options, or nil if unknown devices are rejected.
+func (m *InMemoryEntityManager) GetQuarantine() *epb.Quarantine {
+	return m.defaults.GetQuarantine()
+}
+
+// GetAdmissionControl returns the limits of the bootstrap requests served at the same time from
+// the inventory options, or nil if they are unlimited.
+func (m *InMemoryEntityManager) GetAdmissionControl() *epb.AdmissionControl {
+	return m.defaults.GetAdmissionControl()
+}
+
+// GetSigningWorkers returns the number of workers signing bootstrap responses from the inventory
+// options, or 0 for the default.
+func (m *InMemoryEntityManager) GetSigningWorkers() uint32 {
+	return m.defaults.GetSigningWorkers()
+}
+
+// GetAlerting returns the alerting rules and channels of the options, or nil if alerting is disabled.
+func (m *InMemoryEntityManager) GetAlerting() *epb.Alerting {
+	return m.defaults.GetAlerting()
+}
+
+// GetURLRewrites returns the rules rewriting the software image URLs served to devices from the
+// inventory options.
+func (m *InMemoryEntityManager) GetURLRewrites() []*epb.UrlRewriteRule {
+	return m.defaults.GetUrlRewrites()
+}
+
+// Revocations returns the list of revoked ownership vouchers.
+func (m *InMemoryEntityManager) Revocations() *revocation.List {
+	return m.revocations
+}
+
+// Validators returns the artifact validators, allowing custom validators to be registered.
+func (m *InMemoryEntityManager) Validators() *validator.Registry {
+	return m.validators
+}
+
+// Experiments returns the tracker of the configured experiments.
+func (m *InMemoryEntityManager) Experiments() *experiment.Tracker {
+	return m.experiments
+}
+
+// States returns the bootstrap state of each device.
+func (m *InMemoryEntityManager) States() *devicestate.Machine {
+	return m.states
+}
+
+// AcceptRequestCounter records the request counter of an authenticated bootstrap request of the
+// device with the given serial, rejecting replayed requests.
+func (m *InMemoryEntityManager) AcceptRequestCounter(_ context.Context, serial string, counter uint64) error {
+	return m.states.AcceptCounter(serial, counter)
+}
+
+// CheckRequestCounter rejects replayed bootstrap requests of the device with the given serial
+// without recording their counter.
+func (m *InMemoryEntityManager) CheckRequestCounter(_ context.Context, serial string, counter uint64) error {
+	return m.states.CheckCounter(serial, counter)
+}
+
+// RecordSeen records a request of the device with the given serial from addr as its last contact.
+func (m *InMemoryEntityManager) RecordSeen(_ context.Context, serial, addr string) {
+	m.mu.RLock()
+	serial = m.inventorySerial(serial)
+	m.mu.RUnlock()
+	m.states.Seen(serial, addr)
+}
+
+// GetChassisInventory returns a snapshot of the chassis inventory. The chassis entries are
+// shared with the entity manager and must not be modified.
 func (m *InMemoryEntityManager) GetChassisInventory() map[service.EntityLookup]*epb.Chassis {
-	return m.chassisInventory
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	inventory := make(map[service.EntityLookup]*epb.Chassis, len(m.chassisInventory))
+	for l, ch := range m.chassisInventory {
+		inventory[l] = ch
+	}
+	return inventory
+}
+
+// SecurityArtifacts returns the security artifacts the entity manager signs responses with, or nil if none are configured.
+func (m *InMemoryEntityManager) SecurityArtifacts() *service.SecurityArtifacts {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.secArtifacts
 }
 
 // New returns a new in-memory entity manager.
 func New(chassisConfigFile string) (*InMemoryEntityManager, error) {
+	return newEntityManager(chassisConfigFile, false)
+}
+
+// NewWithGeneratedArtifacts returns a new in-memory entity manager serving ephemeral security
+// artifacts generated in memory instead of the artifacts of artifact_dir. Each control card and
+// fixed chassis of the inventory is issued an ownership voucher pinning the generated PDC.
+func NewWithGeneratedArtifacts(chassisConfigFile string) (*InMemoryEntityManager, error) {
+	return newEntityManager(chassisConfigFile, true)
+}
+
+// generateArtifacts generates security artifacts and replaces the ownership vouchers of the chassis
+// with vouchers pinning the generated PDC.
+func generateArtifacts(chassis []*epb.Chassis) (*service.SecurityArtifacts, error) {
+	var serials []string
+	for _, ch := range chassis {
+		for _, c := range ch.GetControllerCards() {
+			serials = append(serials, c.GetSerialNumber())
+		}
+		if len(ch.GetControllerCards()) == 0 && ch.GetSerialNumber() != wildcard {
+			serials = append(serials, ch.GetSerialNumber())
+		}
+	}
+	sa, err := artifacts.Generate(artifacts.Options{Serials: serials})
+	if err != nil {
+		return nil, fmt.Errorf("error in generating security artifacts : %v", err)
+	}
+	for _, ch := range chassis {
+		for _, c := range ch.GetControllerCards() {
+			c.OwnershipVoucher = sa.OV[c.GetSerialNumber()]
+		}
+		if len(ch.GetControllerCards()) == 0 && ch.GetSerialNumber() != wildcard {
+			ch.OwnershipVoucher = sa.OV[ch.GetSerialNumber()]
+		}
+	}
+	log.Infof("Generated security artifacts with %d ownership vouchers", len(sa.OV))
+	return sa, nil
+}
+
+// newEntityManager returns a new in-memory entity manager, generating its security artifacts if generate is set.
+func newEntityManager(chassisConfigFile string, generate bool) (*InMemoryEntityManager, error) {
 	newManager := &InMemoryEntityManager{
 		chassisInventory:    map[service.EntityLookup]*epb.Chassis{},
 		controlCardStatuses: map[string]bpb.ControlCardState_ControlCardStatus{},
+		modules:             map[service.EntityLookup]*reportedModules{},
 		defaults:            &epb.Options{GnsiGlobalConfig: &epb.GNSIConfig{}},
+		revocations:         revocation.New(),
+		tokens:              token.New(),
+		retirements:         retirement.New(),
+		validators:          validator.NewRegistry(),
+		experiments:         experiment.New(),
+		states:              devicestate.New(0),
+		generated:           generate,
 	}
 	if chassisConfigFile == "" {
+		if generate {
+			sa, err := generateArtifacts(nil)
+			if err != nil {
+				return nil, err
+			}
+			newManager.secArtifacts = sa
+		}
 		return newManager, nil
 	}
 	protoTextFile, err := os.ReadFile(chassisConfigFile)
@@ -436,24 +1614,316 @@ func New(chassisConfigFile string) (*InMemoryEntityManager, error) {
 	}
 	log.Infof("New entity manager is initialized successfully from chassis config file %s", chassisConfigFile)
 	for _, ch := range entities.Chassis {
-		lookup := service.EntityLookup{
-			Manufacturer: ch.GetManufacturer(),
-			SerialNumber: ch.GetSerialNumber(),
-		}
-		newManager.chassisInventory[lookup] = ch
+		newManager.chassisInventory[inventoryKey(ch)] = ch
 	}
 	newManager.defaults = entities.GetOptions()
-	if newManager.defaults.ArtifactDir != "" {
-		newManager.secArtifacts, err = parseSecurityArtifacts(entities.Options.ArtifactDir)
+	if newManager.defaults.GetFipsMode() {
+		fips.Enable()
+	}
+	if fips.Enabled() {
+		log.Infof("FIPS mode is on, restricting cryptography to FIPS approved algorithms")
+	}
+	if generate {
+		if newManager.secArtifacts, err = generateArtifacts(entities.GetChassis()); err != nil {
+			return nil, err
+		}
+	} else if dir := newManager.defaults.GetArtifactDir(); dir != "" {
+		newManager.secArtifacts, err = parseSecurityArtifacts(os.DirFS(dir), newManager.defaults.GetOcKeyUri(), newManager.defaults.GetPdcKeyUri())
 		if err != nil {
 			log.Errorf("Error in parsing security artifacts : %v", err)
 			return nil, fmt.Errorf("error in parsing security artifacts : %v", err)
 		}
 	}
+	if rot := newManager.defaults.GetOwnershipRotation(); rot != nil {
+		if newManager.secArtifacts == nil {
+			return nil, fmt.Errorf("an ownership rotation requires artifact_dir to be set")
+		}
+		if rot.GetArtifactDir() == "" {
+			return nil, fmt.Errorf("ownership rotation has no artifact_dir")
+		}
+		newManager.rotation, err = parseOwnerArtifacts(os.DirFS(rot.GetArtifactDir()), rot.GetOcKeyUri(), rot.GetPdcKeyUri())
+		if err != nil {
+			return nil, fmt.Errorf("error in parsing ownership rotation artifacts : %v", err)
+		}
+		newManager.rotation.VendorCA = newManager.secArtifacts.VendorCA
+		log.Infof("Ownership rotation to the artifacts in %s is configured", rot.GetArtifactDir())
+	}
+	if err := newManager.loadDomains(); err != nil {
+		return nil, err
+	}
+	if f := newManager.defaults.GetOvRevocationFile(); f != "" {
+		if err := newManager.revocations.LoadFile(f); err != nil {
+			return nil, err
+		}
+	}
+	if f := newManager.defaults.GetVendorCaCrlFile(); f != "" {
+		if newManager.secArtifacts == nil {
+			return nil, fmt.Errorf("a vendor CA CRL requires artifact_dir to be set")
+		}
+		vendorCA, err := certFromPEM(newManager.secArtifacts.VendorCA.Cert)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse vendor CA cert: %v", err)
+		}
+		if err := newManager.revocations.LoadCRL(f, vendorCA); err != nil {
+			return nil, err
+		}
+	}
+	if newManager.vouchers, err = newVoucherStore(newManager.defaults.GetVoucherStore()); err != nil {
+		return nil, err
+	}
+	if src := newManager.defaults.GetMasa(); src != nil {
+		if newManager.secArtifacts == nil {
+			return nil, fmt.Errorf("a MASA requires artifact_dir to be set")
+		}
+		if newManager.masa, err = masa.New(src); err != nil {
+			return nil, err
+		}
+		newManager.requestMissingVouchers(context.Background(), entities.GetChassis())
+	}
+	newManager.states = devicestate.New(int(newManager.defaults.GetMaxBootstrapRetries()))
+	newManager.cache = newResponseCache(newManager.defaults.GetResponseCache())
+	if f := newManager.defaults.GetDeviceStateFile(); f != "" {
+		if err := newManager.states.Persist(f); err != nil {
+			return nil, err
+		}
+	}
+	if f := retiredDeviceFile(newManager.defaults); f != "" {
+		if err := newManager.retirements.Persist(f); err != nil {
+			return nil, err
+		}
+	}
+	if cfg := newManager.defaults.GetCluster(); cfg != nil {
+		if err := newManager.joinCluster(cfg); err != nil {
+			return nil, err
+		}
+	}
+	if err := newManager.validateSecurityArtifacts(entities.GetChassis(), time.Now()); err != nil {
+		return nil, err
+	}
+	if err := newManager.checkBootModes(); err != nil {
+		return nil, err
+	}
+	if err := newManager.registerConfigValidators(); err != nil {
+		return nil, err
+	}
+	if err := newManager.checkWindows(entities.GetChassis()); err != nil {
+		return nil, err
+	}
+	for _, mi := range newManager.defaults.GetModelImages() {
+		if mi.GetManufacturer() == "" || mi.GetPartNumber() == "" {
+			return nil, fmt.Errorf("model image must set a manufacturer and part number")
+		}
+		if mi.GetSoftwareImage().GetVersion() == "" || mi.GetSoftwareImage().GetUrl() == "" {
+			return nil, fmt.Errorf("model image of %v part# %v must set the version and URL of its software image", mi.GetManufacturer(), mi.GetPartNumber())
+		}
+	}
+	if err := newManager.loadArtifacts(entities.GetChassis()); err != nil {
+		return nil, err
+	}
+	if err := newManager.experiments.Load(newManager.defaults.GetExperiments()); err != nil {
+		return nil, err
+	}
+	for _, e := range newManager.experiments.Experiments() {
+		if e.GetConfig() == nil {
+			continue
+		}
+		if _, err := newManager.populateArtifacts(experiment.Treat(&epb.Chassis{}, e)); err != nil {
+			log.Errorf("Error in validating artifacts of experiment %v : %v", e.GetName(), err)
+			return nil, err
+		}
+	}
+	if profile := newManager.defaults.GetDefaultProfile(); profile != nil {
+		if profile.GetBootMode() == bpb.BootMode_BOOT_MODE_SECURE {
+			return nil, fmt.Errorf("default profile can not use secure boot mode")
+		}
+		if _, err := newManager.populateArtifacts(profileChassis(profile, &service.EntityLookup{})); err != nil {
+			log.Errorf("Error in validating default profile artifacts : %v", err)
+			return nil, err
+		}
+	}
+	if src := newManager.defaults.GetNetbox(); src != nil {
+		if newManager.netbox, err = netbox.New(src); err != nil {
+			return nil, err
+		}
+		chassis, err := newManager.netbox.Chassis(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("unable to import chassis from NetBox: %v", err)
+		}
+		if err := newManager.ImportChassis(chassis); err != nil {
+			log.Warningf("Skipped chassis imported from NetBox: %v", err)
+		}
+	}
 
 	return newManager, nil
 }
 
+// loadArtifacts reads and validates the artifacts of the chassis concurrently, reporting the
+// errors of every chassis.
+func (m *InMemoryEntityManager) loadArtifacts(chassis []*epb.Chassis) error {
+	errs := make([]error, len(chassis))
+	forEach(len(chassis), m.loadWorkers(), func(i int) {
+		ch := chassis[i]
+		if ch.GetArtifactsPending() {
+			log.Infof("Artifacts of chassis %s are pending, devices will be asked to retry in %v", ch.GetSerialNumber(), m.retryInterval())
+			return
+		}
+		if _, err := m.populateArtifacts(ch); err != nil {
+			log.Errorf("Error in validating artifacts : %v", err)
+			errs[i] = err
+			return
+		}
+		if f := ch.GetConfig().GetSecondStageConfigFile(); f != "" {
+			if _, err := readSetRequest(f); err != nil {
+				errs[i] = err
+			}
+		}
+	})
+	var errList errlist.List
+	errList.Add(errs...)
+	return errList.Err()
+}
+
+// registerConfigValidators registers the config validators of the inventory options.
+func (m *InMemoryEntityManager) registerConfigValidators() error {
+	for i, cv := range m.defaults.GetConfigValidators() {
+		var v validator.Validator
+		var err error
+		switch {
+		case cv.GetJsonSchemaFile() != "" && len(cv.GetCommand()) > 0:
+			return fmt.Errorf("config validator %d sets both a JSON schema and a command", i)
+		case cv.GetJsonSchemaFile() != "":
+			schema, readErr := os.ReadFile(cv.GetJsonSchemaFile())
+			if readErr != nil {
+				return fmt.Errorf("unable to read JSON schema of config validator %d: %v", i, readErr)
+			}
+			v, err = validator.JSONSchema(cv.GetArtifactType(), schema)
+		case len(cv.GetCommand()) > 0:
+			v, err = validator.Command(cv.GetArtifactType(), cv.GetCommand()...)
+		default:
+			return fmt.Errorf("config validator %d sets neither a JSON schema nor a command", i)
+		}
+		if err != nil {
+			return fmt.Errorf("config validator %d: %v", i, err)
+		}
+		m.validators.Register(cv.GetArtifactType(), v)
+	}
+	return nil
+}
+
+// checkBootModes applies the default boot mode to the chassis which don't set one, and checks
+// that the artifacts needed to bootstrap the chassis in secure boot mode are configured.
+func (m *InMemoryEntityManager) checkBootModes() error {
+	var insecure []string
+	for _, ch := range m.chassisInventory {
+		if ch.GetBootMode() == bpb.BootMode_BOOT_MODE_UNSPECIFIED {
+			ch.BootMode = m.defaults.GetDefaultBootMode()
+		}
+		if ch.GetBootMode() != bpb.BootMode_BOOT_MODE_SECURE {
+			insecure = append(insecure, ch.GetSerialNumber())
+			continue
+		}
+		if err := m.checkSecureBoot(ch); err != nil {
+			return err
+		}
+	}
+	if len(insecure) > 0 {
+		sort.Strings(insecure)
+		log.Warningf("%d chassis bootstrap in INSECURE mode, without nonce or ownership voucher: %v", len(insecure), insecure)
+	}
+	return nil
+}
+
+// checkSecureBoot checks that the artifacts needed to bootstrap a chassis in secure boot mode are configured.
+func (m *InMemoryEntityManager) checkSecureBoot(ch *epb.Chassis) error {
+	if m.secArtifacts == nil {
+		return fmt.Errorf("chassis with serial# %s requires secure boot, but no artifact_dir is set", ch.GetSerialNumber())
+	}
+	if ch.GetSerialNumber() == wildcard || ch.GetArtifactsPending() {
+		// Vouchers are issued per serial number, so wildcard entries can't carry them.
+		return nil
+	}
+	var missing []string
+	for _, c := range ch.GetControllerCards() {
+		if !m.hasVoucher(c.GetSerialNumber(), c.GetOwnershipVoucher(), false) {
+			missing = append(missing, c.GetSerialNumber())
+		}
+	}
+	if len(ch.GetControllerCards()) == 0 && !m.hasVoucher(ch.GetSerialNumber(), ch.GetOwnershipVoucher(), false) {
+		missing = append(missing, ch.GetSerialNumber())
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("chassis with serial# %s requires secure boot, but has no ownership voucher for %v", ch.GetSerialNumber(), missing)
+	}
+	return nil
+}
+
+// ImportChassis replaces the chassis previously imported from an external inventory, such as
+// NetBox, with chassis. Chassis of the inventory file take precedence over imported chassis with
+// the same manufacturer and serial number. Ownership vouchers missing from imported chassis are
+// requested from the MASA, if configured. Imported chassis failing validation are skipped and
+// reported in the returned error.
+func (m *InMemoryEntityManager) ImportChassis(chassis []*epb.Chassis) error {
+	var errs errlist.List
+	if m.masa != nil {
+		m.requestMissingVouchers(context.Background(), chassis)
+	}
+	valid := map[service.EntityLookup]*epb.Chassis{}
+	for _, ch := range chassis {
+		if ch.GetBootMode() == bpb.BootMode_BOOT_MODE_UNSPECIFIED {
+			ch.BootMode = m.defaults.GetDefaultBootMode()
+		}
+		if ch.GetBootMode() == bpb.BootMode_BOOT_MODE_SECURE {
+			if err := m.checkSecureBoot(ch); err != nil {
+				errs.Add(err)
+				continue
+			}
+		}
+		if _, err := m.populateArtifacts(ch); err != nil {
+			errs.Add(fmt.Errorf("chassis with serial# %s: %v", ch.GetSerialNumber(), err))
+			continue
+		}
+		valid[inventoryKey(ch)] = ch
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for k := range m.imported {
+		delete(m.chassisInventory, k)
+	}
+	m.imported = map[service.EntityLookup]bool{}
+	m.cache.invalidate()
+	for k, ch := range valid {
+		if _, ok := m.chassisInventory[k]; ok {
+			continue
+		}
+		m.chassisInventory[k] = ch
+		m.imported[k] = true
+	}
+	log.Infof("Imported %d chassis", len(m.imported))
+	return errs.Err()
+}
+
+// NetBoxSyncInterval returns the interval the chassis are imported from NetBox at, or 0 if they
+// are only imported when the inventory is loaded.
+func (m *InMemoryEntityManager) NetBoxSyncInterval() time.Duration {
+	if m.netbox == nil {
+		return 0
+	}
+	return m.defaults.GetNetbox().GetSyncInterval().AsDuration()
+}
+
+// SyncNetBox imports the chassis of the NetBox instance of the inventory options again.
+func (m *InMemoryEntityManager) SyncNetBox(ctx context.Context) error {
+	if m.netbox == nil {
+		return fmt.Errorf("no NetBox instance is configured")
+	}
+	chassis, err := m.netbox.Chassis(ctx)
+	if err != nil {
+		return err
+	}
+	return m.ImportChassis(chassis)
+}
+
 // ReplaceDevice replaces an existing chassis with a new chassis object.
 func (m *InMemoryEntityManager) ReplaceDevice(chassis *service.EntityLookup, newChassis *epb.Chassis) error {
 	// Chassis: old device lookup, newChassis: new device
@@ -466,12 +1936,8 @@ func (m *InMemoryEntityManager) ReplaceDevice(chassis *service.EntityLookup, new
 
 	delete(m.chassisInventory, *chassis)
 
-	lookup := service.EntityLookup{
-		Manufacturer: newChassis.GetManufacturer(),
-		SerialNumber: newChassis.GetSerialNumber(),
-	}
-
-	m.chassisInventory[lookup] = newChassis
+	m.chassisInventory[inventoryKey(newChassis)] = newChassis
+	m.cache.invalidate()
 
 	// This method will be able to return an error when validation is added.
 	return nil
@@ -483,12 +1949,13 @@ func (m *InMemoryEntityManager) DeleteDevice(chassis *service.EntityLookup) {
 	defer m.mu.Unlock()
 
 	delete(m.chassisInventory, *chassis)
+	m.cache.invalidate()
 }
 
 // GetDevice returns a copy of the chassis at the provided lookup.
 func (m *InMemoryEntityManager) GetDevice(chassis *service.EntityLookup) (*epb.Chassis, error) {
-	m.mu.Lock()
-	defer m.mu.Unlock()
+	m.mu.RLock()
+	defer m.mu.RUnlock()
 
 	if val, exists := m.chassisInventory[*chassis]; exists {
 		return proto.Clone(val).(*epb.Chassis), nil
@@ -499,8 +1966,8 @@ func (m *InMemoryEntityManager) GetDevice(chassis *service.EntityLookup) (*epb.C
 
 // GetAll returns a copy of the chassisInventory field.
 func (m *InMemoryEntityManager) GetAll() map[service.EntityLookup]*epb.Chassis {
-	m.mu.Lock()
-	defer m.mu.Unlock()
+	m.mu.RLock()
+	defer m.mu.RUnlock()
 
 	chassisMapClone := make(map[service.EntityLookup]*epb.Chassis)
 
@@ -15,23 +15,51 @@
 package entitymanager
 
 import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
 	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/base64"
 	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
 	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
+	"testing/fstest"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/h-fam/errdiff"
+	ownershipvoucher "github.com/openconfig/bootz/common/ownership_voucher"
 	"github.com/openconfig/bootz/common/signature"
+	"github.com/openconfig/bootz/server/devicestate"
+	"github.com/openconfig/bootz/server/events"
+	"github.com/openconfig/bootz/server/features"
 	"github.com/openconfig/bootz/server/service"
+	"github.com/openconfig/bootz/server/validator"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/durationpb"
 
 	bpb "github.com/openconfig/bootz/proto/bootz"
 	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
 	apb "github.com/openconfig/gnsi/authz"
+	credpb "github.com/openconfig/gnsi/credentialz"
 )
 
 // MustMarshalBootstrapDataSigned is a helper function that marshals a BootstrapDataSigned message.
@@ -247,7 +275,7 @@ func TestResolveChassis(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.desc, func(t *testing.T) {
-			got, err := em.ResolveChassis(test.input, "")
+			got, err := em.ResolveChassis(context.Background(), test.input, "")
 			if (err != nil) != test.wantErr {
 				t.Fatalf("ResolveChassis(%v) err = %v, want %v", test.input, err, test.wantErr)
 			}
@@ -293,7 +321,7 @@ func TestSign(t *testing.T) {
 	}
 
 	em, _ := New("../../testdata/inventory.prototxt")
-	artifacts, err := parseSecurityArtifacts(em.defaults.GetArtifactDir())
+	artifacts, err := parseSecurityArtifacts(os.DirFS(em.defaults.GetArtifactDir()), "", "")
 	if err != nil {
 		t.Fatalf("Could not load security artifacts: %v", err)
 	}
@@ -301,7 +329,7 @@ func TestSign(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.desc, func(t *testing.T) {
 
-			err = em.Sign(test.resp, &test.chassis, test.serial)
+			err = em.Sign(context.Background(), test.resp, &test.chassis, test.serial, nil)
 			if err != nil {
 				if test.wantErr {
 					t.Skip()
@@ -338,6 +366,264 @@ func TestSign(t *testing.T) {
 	}
 }
 
+func TestSignAlgorithms(t *testing.T) {
+	em, err := New("../../testdata/inventory.prototxt")
+	if err != nil {
+		t.Fatalf("New() err = %v, want nil", err)
+	}
+	cert, err := certFromPEM(em.secArtifacts.OC.Cert)
+	if err != nil {
+		t.Fatalf("unable to parse OC: %v", err)
+	}
+	tests := []struct {
+		desc     string
+		accepted []bpb.SignatureAlgorithm
+		want     bpb.SignatureAlgorithm
+		wantCode codes.Code
+	}{{
+		desc: "Default algorithm",
+		want: bpb.SignatureAlgorithm_SIGNATURE_ALGORITHM_RSA_PKCS1V15_SHA256,
+	}, {
+		desc:     "First algorithm supported by the OC",
+		accepted: []bpb.SignatureAlgorithm{bpb.SignatureAlgorithm_SIGNATURE_ALGORITHM_ED25519, bpb.SignatureAlgorithm_SIGNATURE_ALGORITHM_RSA_PSS_SHA256},
+		want:     bpb.SignatureAlgorithm_SIGNATURE_ALGORITHM_RSA_PSS_SHA256,
+	}, {
+		desc:     "Detached CMS signature",
+		accepted: []bpb.SignatureAlgorithm{bpb.SignatureAlgorithm_SIGNATURE_ALGORITHM_CMS_DETACHED},
+		want:     bpb.SignatureAlgorithm_SIGNATURE_ALGORITHM_CMS_DETACHED,
+	}, {
+		desc:     "No algorithm supported by the OC",
+		accepted: []bpb.SignatureAlgorithm{bpb.SignatureAlgorithm_SIGNATURE_ALGORITHM_ECDSA_SHA256},
+		wantCode: codes.InvalidArgument,
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			resp := &bpb.GetBootstrapDataResponse{
+				SerializedBootstrapData: MustMarshalBootstrapDataSigned(t, &bpb.BootstrapDataSigned{
+					Responses: []*bpb.BootstrapDataResponse{{SerialNum: "123A"}},
+					Nonce:     "nonce",
+				}),
+			}
+			err := em.Sign(context.Background(), resp, &service.EntityLookup{Manufacturer: "Cisco", SerialNumber: "123"}, "123A", test.accepted)
+			if status.Code(err) != test.wantCode {
+				t.Fatalf("Sign() err = %v, want code %v", err, test.wantCode)
+			}
+			if err != nil {
+				return
+			}
+			if got := resp.GetSignatureAlgorithm(); got != test.want {
+				t.Errorf("Sign() signature algorithm = %v, want %v", got, test.want)
+			}
+			if err := signature.VerifyWithAlgorithm(cert, resp.GetSerializedBootstrapData(), resp.GetResponseSignature(), resp.GetSignatureAlgorithm()); err != nil {
+				t.Errorf("VerifyWithAlgorithm() err = %v, want nil", err)
+			}
+		})
+	}
+}
+
+func TestSignPDCVerification(t *testing.T) {
+	lookup := service.EntityLookup{
+		Manufacturer: "Cisco",
+		SerialNumber: "123",
+	}
+	// The vendor CA stands in for the PDC of another domain.
+	tests := []struct {
+		desc     string
+		mode     epb.PDCVerification
+		pdc      func(*service.SecurityArtifacts) *service.KeyPair
+		wantCode codes.Code
+	}{{
+		desc: "Verification disabled",
+		mode: epb.PDCVerification_PDC_VERIFICATION_NONE,
+	}, {
+		desc: "Verification disabled with other domain PDC",
+		mode: epb.PDCVerification_PDC_VERIFICATION_NONE,
+		pdc:  func(sa *service.SecurityArtifacts) *service.KeyPair { return sa.VendorCA },
+	}, {
+		desc: "Exact match",
+		mode: epb.PDCVerification_PDC_VERIFICATION_EXACT,
+	}, {
+		desc: "SPKI match",
+		mode: epb.PDCVerification_PDC_VERIFICATION_SPKI,
+	}, {
+		desc:     "Ownership transferred, exact",
+		mode:     epb.PDCVerification_PDC_VERIFICATION_EXACT,
+		pdc:      func(sa *service.SecurityArtifacts) *service.KeyPair { return sa.VendorCA },
+		wantCode: codes.FailedPrecondition,
+	}, {
+		desc:     "Ownership transferred, SPKI",
+		mode:     epb.PDCVerification_PDC_VERIFICATION_SPKI,
+		pdc:      func(sa *service.SecurityArtifacts) *service.KeyPair { return sa.VendorCA },
+		wantCode: codes.FailedPrecondition,
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			em, err := New("../../testdata/inventory.prototxt")
+			if err != nil {
+				t.Fatalf("New() err = %v, want nil", err)
+			}
+			em.defaults.PdcVerification = test.mode
+			if test.pdc != nil {
+				em.secArtifacts.PDC = test.pdc(em.secArtifacts)
+			}
+			resp := &bpb.GetBootstrapDataResponse{
+				SerializedBootstrapData: MustMarshalBootstrapDataSigned(t, &bpb.BootstrapDataSigned{
+					Responses: []*bpb.BootstrapDataResponse{{SerialNum: "123A"}},
+				}),
+			}
+			err = em.Sign(context.Background(), resp, &lookup, "123A", nil)
+			if got := status.Code(err); got != test.wantCode {
+				t.Errorf("Sign() err = %v, want code %v", err, test.wantCode)
+			}
+		})
+	}
+}
+
+func TestRevokedOwnershipVoucher(t *testing.T) {
+	lookup := service.EntityLookup{
+		Manufacturer: "Cisco",
+		SerialNumber: "123",
+	}
+	cc := &bpb.ControlCard{SerialNumber: "123A", PartNumber: "123A"}
+	em, err := New("../../testdata/inventory.prototxt")
+	if err != nil {
+		t.Fatalf("New() err = %v, want nil", err)
+	}
+	if _, err := em.GetBootstrapData(context.Background(), &lookup, cc); err != nil {
+		t.Fatalf("GetBootstrapData() err = %v, want nil", err)
+	}
+	if err := em.Revocations().Revoke("123A", "lost in transit"); err != nil {
+		t.Fatalf("Revoke() err = %v, want nil", err)
+	}
+	_, err = em.GetBootstrapData(context.Background(), &lookup, cc)
+	if got, want := status.Code(err), codes.PermissionDenied; got != want {
+		t.Errorf("GetBootstrapData() err = %v, want code %v", err, want)
+	}
+
+	if _, err := em.GetBootstrapData(context.Background(), &lookup, &bpb.ControlCard{SerialNumber: "123B", PartNumber: "123B"}); err != nil {
+		t.Errorf("GetBootstrapData() for other control card err = %v, want nil", err)
+	}
+}
+
+func TestValidateControlCards(t *testing.T) {
+	em, err := New("../../testdata/inventory.prototxt")
+	if err != nil {
+		t.Fatalf("New() err = %v, want nil", err)
+	}
+	cc1 := &bpb.ControlCard{SerialNumber: "123A", PartNumber: "123A"}
+	cc2 := &bpb.ControlCard{SerialNumber: "123B", PartNumber: "123B"}
+	tests := []struct {
+		desc         string
+		lookup       service.EntityLookup
+		controlCards []*bpb.ControlCard
+		wantCode     codes.Code
+	}{{
+		desc:         "All control cards",
+		lookup:       service.EntityLookup{Manufacturer: "Cisco", SerialNumber: "123"},
+		controlCards: []*bpb.ControlCard{cc1, cc2},
+	}, {
+		desc:         "Chassis resolved via control card",
+		lookup:       service.EntityLookup{Manufacturer: "Cisco"},
+		controlCards: []*bpb.ControlCard{cc2, cc1},
+	}, {
+		desc:   "Fixed chassis",
+		lookup: service.EntityLookup{Manufacturer: "Cisco", SerialNumber: "123"},
+	}, {
+		desc:         "Unknown control card",
+		lookup:       service.EntityLookup{Manufacturer: "Cisco", SerialNumber: "123"},
+		controlCards: []*bpb.ControlCard{cc1, {SerialNumber: "123C", PartNumber: "123C"}},
+		wantCode:     codes.NotFound,
+	}, {
+		desc:         "Part number mismatch",
+		lookup:       service.EntityLookup{Manufacturer: "Cisco", SerialNumber: "123"},
+		controlCards: []*bpb.ControlCard{cc1, {SerialNumber: "123B", PartNumber: "123A"}},
+		wantCode:     codes.NotFound,
+	}, {
+		desc:         "Duplicate control card",
+		lookup:       service.EntityLookup{Manufacturer: "Cisco", SerialNumber: "123"},
+		controlCards: []*bpb.ControlCard{cc1, cc1},
+		wantCode:     codes.InvalidArgument,
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			err := em.ValidateControlCards(context.Background(), &test.lookup, test.controlCards)
+			if got := status.Code(err); got != test.wantCode {
+				t.Errorf("ValidateControlCards() err = %v, want code %v", err, test.wantCode)
+			}
+		})
+	}
+}
+
+func TestOwnershipVoucher(t *testing.T) {
+	em, err := New("../../testdata/inventory.prototxt")
+	if err != nil {
+		t.Fatalf("New() err = %v, want nil", err)
+	}
+	lookup := service.EntityLookup{Manufacturer: "Cisco", SerialNumber: "123"}
+	for _, serial := range []string{"123A", "123B"} {
+		want, err := base64.StdEncoding.DecodeString(readTextFromFile(t, "../../testdata/ov_"+serial+".txt"))
+		if err != nil {
+			t.Fatalf("unable to decode OV: %v", err)
+		}
+		got, err := em.OwnershipVoucher(context.Background(), &lookup, serial)
+		if err != nil {
+			t.Fatalf("OwnershipVoucher(%q) err = %v, want nil", serial, err)
+		}
+		if !cmp.Equal(got, want) {
+			t.Errorf("OwnershipVoucher(%q) did not return the OV of the control card", serial)
+		}
+	}
+	if _, err := em.OwnershipVoucher(context.Background(), &lookup, "123C"); status.Code(err) != codes.NotFound {
+		t.Errorf("OwnershipVoucher(%q) err = %v, want code %v", "123C", err, codes.NotFound)
+	}
+}
+
+func TestStagingBundle(t *testing.T) {
+	em, err := New("../../testdata/inventory.prototxt")
+	if err != nil {
+		t.Fatalf("New() err = %v, want nil", err)
+	}
+	s := service.New(em)
+	ctx := context.Background()
+	desc := &bpb.ChassisDescriptor{
+		Manufacturer: "Cisco",
+		SerialNumber: "123",
+		ControlCards: []*bpb.ControlCard{
+			{SerialNumber: "123A", PartNumber: "123A"},
+			{SerialNumber: "123B", PartNumber: "123B"},
+		},
+	}
+	bundle, err := s.GenerateStagingBundle(ctx, desc)
+	if err != nil {
+		t.Fatalf("GenerateStagingBundle() err = %v, want nil", err)
+	}
+	signed := &bpb.BootstrapDataSigned{}
+	if err := proto.Unmarshal(bundle.GetResponse().GetSerializedBootstrapData(), signed); err != nil {
+		t.Fatalf("unable to unmarshal bootstrap data: %v", err)
+	}
+	if signed.GetNonce() != bundle.GetBundleId() {
+		t.Errorf("bundle response nonce = %q, want bundle ID %q", signed.GetNonce(), bundle.GetBundleId())
+	}
+	if len(bundle.GetResponse().GetOwnershipVoucher()) == 0 || bundle.GetResponse().GetResponseSignature() == "" {
+		t.Errorf("bundle response is not signed")
+	}
+	_, err = s.ReportStatus(ctx, &bpb.ReportStatusRequest{
+		Status:          bpb.ReportStatusRequest_BOOTSTRAP_STATUS_SUCCESS,
+		StagingBundleId: bundle.GetBundleId(),
+		States: []*bpb.ControlCardState{
+			{SerialNumber: "123A", Status: bpb.ControlCardState_CONTROL_CARD_STATUS_INITIALIZED},
+			{SerialNumber: "123B", Status: bpb.ControlCardState_CONTROL_CARD_STATUS_INITIALIZED},
+		},
+	})
+	if err != nil {
+		t.Fatalf("ReportStatus() err = %v, want nil", err)
+	}
+	records := s.StagingBundles()
+	if len(records) != 1 || records[0].Status != bpb.ReportStatusRequest_BOOTSTRAP_STATUS_SUCCESS {
+		t.Errorf("StagingBundles() = %+v, want a single bundle reported successful", records)
+	}
+}
+
 func TestSetStatus(t *testing.T) {
 	tests := []struct {
 		desc    string
@@ -383,7 +669,7 @@ func TestSetStatus(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.desc, func(t *testing.T) {
-			err := em.SetStatus(test.input)
+			err := em.SetStatus(context.Background(), test.input)
 			if (err != nil) != test.wantErr {
 				t.Errorf("SetStatus(%v) err = %v, want %v", test.input, err, test.wantErr)
 			}
@@ -547,26 +833,1478 @@ func TestGetBootstrapData(t *testing.T) {
 	},
 	}
 
-	em, err := New("")
+	em, err := New("")
+	if err != nil {
+		t.Fatalf("unable to create entitymanager: %v", err)
+	}
+	em.secArtifacts = &service.SecurityArtifacts{
+		OC: &service.KeyPair{
+			PrivateKey: "FakeOCPrivateKey",
+			Cert:       "FakeOCCert",
+		},
+	}
+	em.chassisInventory[service.EntityLookup{Manufacturer: "Cisco", SerialNumber: "123"}] = &chassis
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			got, err := em.GetBootstrapData(context.Background(), &service.EntityLookup{SerialNumber: test.chassisSerial, Manufacturer: test.chassisManufacturer}, test.input)
+			if (err != nil) != test.wantErr {
+				t.Errorf("GetBootstrapData(%v) err = %v, want %v", test.input, err, test.wantErr)
+			}
+			if !proto.Equal(got, test.want) {
+				t.Errorf("GetBootstrapData(%v) \n got: %v, \n want: %v", test.input, got, test.want)
+			}
+		})
+	}
+}
+
+func TestGetBootstrapDataFixedChassis(t *testing.T) {
+	em, err := New("")
+	if err != nil {
+		t.Fatalf("unable to create entitymanager: %v", err)
+	}
+	em.secArtifacts = &service.SecurityArtifacts{
+		OC: &service.KeyPair{
+			PrivateKey: "FakeOCPrivateKey",
+			Cert:       "FakeOCCert",
+		},
+	}
+	em.chassisInventory[service.EntityLookup{Manufacturer: "Cisco", SerialNumber: "456"}] = &epb.Chassis{
+		SerialNumber: "456",
+		PartNumber:   "FX-1",
+		Manufacturer: "Cisco",
+		BootMode:     bpb.BootMode_BOOT_MODE_INSECURE,
+		Config: &epb.Config{
+			GnsiConfig: &epb.GNSIConfig{
+				AuthzUploadFile: "../../testdata/authz.prototext",
+			},
+		},
+	}
+	tests := []struct {
+		desc     string
+		lookup   service.EntityLookup
+		wantCode codes.Code
+	}{{
+		desc:   "Serial and part number",
+		lookup: service.EntityLookup{Manufacturer: "Cisco", SerialNumber: "456", PartNumber: "FX-1"},
+	}, {
+		desc:   "Serial only",
+		lookup: service.EntityLookup{Manufacturer: "Cisco", SerialNumber: "456"},
+	}, {
+		desc:     "Part number mismatch",
+		lookup:   service.EntityLookup{Manufacturer: "Cisco", SerialNumber: "456", PartNumber: "FX-2"},
+		wantCode: codes.NotFound,
+	}, {
+		desc:     "Unknown serial",
+		lookup:   service.EntityLookup{Manufacturer: "Cisco", SerialNumber: "789"},
+		wantCode: codes.NotFound,
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			got, err := em.GetBootstrapData(context.Background(), &test.lookup, nil)
+			if status.Code(err) != test.wantCode {
+				t.Fatalf("GetBootstrapData(%+v) err = %v, want code %v", test.lookup, err, test.wantCode)
+			}
+			if err == nil && got.GetSerialNum() != test.lookup.SerialNumber {
+				t.Errorf("GetBootstrapData(%+v) serial = %q, want %q", test.lookup, got.GetSerialNum(), test.lookup.SerialNumber)
+			}
+		})
+	}
+
+	// The service emits a single response for a fixed chassis.
+	resp, err := service.New(em).GetBootstrapData(context.Background(), &bpb.GetBootstrapDataRequest{
+		ChassisDescriptor: &bpb.ChassisDescriptor{Manufacturer: "Cisco", SerialNumber: "456", PartNumber: "FX-1"},
+	})
+	if err != nil {
+		t.Fatalf("GetBootstrapData() err = %v, want nil", err)
+	}
+	if got := resp.GetSignedResponse().GetResponses(); len(got) != 1 || got[0].GetSerialNum() != "456" {
+		t.Errorf("GetBootstrapData() responses = %v, want a single response for serial 456", got)
+	}
+}
+
+// fakeChunkStream collects the chunks of a streamed bootstrap response.
+type fakeChunkStream struct {
+	grpc.ServerStream
+	chunks []*bpb.BootstrapDataChunk
+}
+
+func (f *fakeChunkStream) Context() context.Context {
+	return context.Background()
+}
+
+func (f *fakeChunkStream) Send(c *bpb.BootstrapDataChunk) error {
+	f.chunks = append(f.chunks, c)
+	return nil
+}
+
+func TestGetBootstrapDataStream(t *testing.T) {
+	em, err := New("../../testdata/inventory.prototxt")
+	if err != nil {
+		t.Fatalf("New() err = %v, want nil", err)
+	}
+	s := service.New(em)
+	desc := &bpb.ChassisDescriptor{
+		Manufacturer: "Cisco",
+		SerialNumber: "123",
+		ControlCards: []*bpb.ControlCard{{SerialNumber: "123A", PartNumber: "123A"}, {SerialNumber: "123B", PartNumber: "123B"}},
+	}
+	req := &bpb.GetBootstrapDataRequest{ChassisDescriptor: desc}
+	const limit = 512
+	// Capabilities only count if the device authenticated their report.
+	if _, err := s.ReportCapabilities(context.Background(), &bpb.ReportCapabilitiesRequest{
+		ChassisDescriptor: desc,
+		Capabilities:      &bpb.DeviceCapabilities{MaxMessageSize: 16},
+	}); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("ReportCapabilities() without a token err = %v, want code %v", err, codes.Unauthenticated)
+	}
+	token, _, err := em.Tokens().Issue("123A", time.Hour)
+	if err != nil {
+		t.Fatalf("Issue() err = %v, want nil", err)
+	}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(service.TokenMetadata, token))
+	req.BootstrapToken = token
+	if _, err := s.ReportCapabilities(ctx, &bpb.ReportCapabilitiesRequest{
+		ChassisDescriptor: desc,
+		Capabilities:      &bpb.DeviceCapabilities{MaxMessageSize: limit},
+	}); err != nil {
+		t.Fatalf("ReportCapabilities() err = %v, want nil", err)
+	}
+	if _, err := s.GetBootstrapData(context.Background(), req); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("GetBootstrapData() err = %v, want code %v", err, codes.ResourceExhausted)
+	}
+
+	stream := &fakeChunkStream{}
+	if err := s.GetBootstrapDataStream(req, stream); err != nil {
+		t.Fatalf("GetBootstrapDataStream() err = %v, want nil", err)
+	}
+	if len(stream.chunks) < 2 {
+		t.Fatalf("GetBootstrapDataStream() sent %d chunks, want several", len(stream.chunks))
+	}
+	var data []byte
+	for i, c := range stream.chunks {
+		if size := proto.Size(c); size > limit {
+			t.Errorf("chunk %d is %d bytes, want at most %d", i, size, limit)
+		}
+		data = append(data, c.GetData()...)
+	}
+	if got, want := uint64(len(data)), stream.chunks[0].GetTotalSize(); got != want {
+		t.Fatalf("GetBootstrapDataStream() sent %d bytes, want %d", got, want)
+	}
+	resp := &bpb.GetBootstrapDataResponse{}
+	if err := proto.Unmarshal(data, resp); err != nil {
+		t.Fatalf("unable to parse streamed response: %v", err)
+	}
+	if got := resp.GetSignedResponse().GetResponses(); len(got) != 2 || got[0].GetSerialNum() != "123A" {
+		t.Errorf("GetBootstrapDataStream() responses = %v, want responses for 123A and 123B", got)
+	}
+
+	if _, err := s.ReportCapabilities(ctx, &bpb.ReportCapabilitiesRequest{
+		ChassisDescriptor: desc,
+		Capabilities:      &bpb.DeviceCapabilities{MaxMessageSize: 16},
+	}); err != nil {
+		t.Fatalf("ReportCapabilities() err = %v, want nil", err)
+	}
+	if err := s.GetBootstrapDataStream(req, &fakeChunkStream{}); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("GetBootstrapDataStream() with a tiny message size err = %v, want code %v", err, codes.ResourceExhausted)
+	}
+}
+
+func TestArtifactValidation(t *testing.T) {
+	em, err := New("../../testdata/inventory.prototxt")
+	if err != nil {
+		t.Fatalf("unable to create entitymanager: %v", err)
+	}
+	lookup := &service.EntityLookup{Manufacturer: "Cisco", SerialNumber: "123"}
+	cc := &bpb.ControlCard{SerialNumber: "123A", PartNumber: "123A"}
+	if _, err := em.GetBootstrapData(context.Background(), lookup, cc); err != nil {
+		t.Fatalf("GetBootstrapData() err = %v, want nil", err)
+	}
+	em.Validators().Register(bpb.ArtifactType_ARTIFACT_TYPE_VENDOR_CONFIG, validator.Func(func(resp *bpb.BootstrapDataResponse) error {
+		if len(resp.GetBootConfig().GetVendorConfig()) == 0 {
+			return fmt.Errorf("vendor config is required")
+		}
+		return nil
+	}))
+	_, err = em.GetBootstrapData(context.Background(), lookup, cc)
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("GetBootstrapData() err = %v, want code %v", err, codes.FailedPrecondition)
+	}
+}
+
+func TestConfigValidators(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatalf("unable to write %s: %v", name, err)
+		}
+		return path
+	}
+	schema := write("schema.json", `{"type": "object", "required": ["system"]}`)
+	goodConfig := write("good.json", `{"system": {}}`)
+	badConfig := write("bad.json", `{"interfaces": {}}`)
+	inventories := 0
+	inventory := func(validators, ocConfig string) string {
+		inventories++
+		return write(fmt.Sprintf("inventory_%d.prototxt", inventories), fmt.Sprintf(`
+options {
+  gnsi_global_config {
+    authz_upload_file: "../../testdata/authz.prototext"
+  }
+  %s
+}
+chassis {
+  serial_number: "123"
+  manufacturer: "Cisco"
+  boot_mode: BOOT_MODE_INSECURE
+  config {
+    boot_config {
+      oc_config_file: %q
+    }
+  }
+}`, validators, ocConfig))
+	}
+
+	tests := []struct {
+		desc      string
+		inventory string
+		wantErr   string
+	}{{
+		desc:      "Config matches schema",
+		inventory: inventory(fmt.Sprintf(`config_validators { artifact_type: ARTIFACT_TYPE_OC_CONFIG json_schema_file: %q }`, schema), goodConfig),
+	}, {
+		desc:      "Broken config rejected at load",
+		inventory: inventory(fmt.Sprintf(`config_validators { artifact_type: ARTIFACT_TYPE_OC_CONFIG json_schema_file: %q }`, schema), badConfig),
+		wantErr:   `missing required property "system"`,
+	}, {
+		desc:      "Config rejected by command",
+		inventory: inventory(`config_validators { artifact_type: ARTIFACT_TYPE_OC_CONFIG command: "false" }`, goodConfig),
+		wantErr:   "false rejected the artifact",
+	}, {
+		desc:      "Validator without check",
+		inventory: inventory(`config_validators { artifact_type: ARTIFACT_TYPE_OC_CONFIG }`, goodConfig),
+		wantErr:   "neither a JSON schema nor a command",
+	}, {
+		desc:      "Validator with schema and command",
+		inventory: inventory(fmt.Sprintf(`config_validators { artifact_type: ARTIFACT_TYPE_OC_CONFIG json_schema_file: %q command: "true" }`, schema), goodConfig),
+		wantErr:   "both a JSON schema and a command",
+	}, {
+		desc:      "Unsupported artifact type",
+		inventory: inventory(`config_validators { artifact_type: ARTIFACT_TYPE_CERTZ command: "true" }`, goodConfig),
+		wantErr:   "can not be validated",
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			_, err := New(test.inventory)
+			if s := errdiff.Substring(err, test.wantErr); s != "" {
+				t.Errorf("New() %s", s)
+			}
+		})
+	}
+}
+
+func TestPopulateCredentials(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials.prototext")
+	if err := os.WriteFile(path, []byte(`credentials { credentials { account: "admin" authorized_keys { authorized_key: "ssh-ed25519 AAAA" } } }`), 0600); err != nil {
+		t.Fatalf("unable to write credentials: %v", err)
+	}
+	fileCreds := &bpb.Credentials{
+		Credentials: []*credpb.AuthorizedKeysRequest{{
+			Credentials: []*credpb.AccountCredentials{{
+				Account:        "admin",
+				AuthorizedKeys: []*credpb.AccountCredentials_AuthorizedKey{{AuthorizedKey: []byte("ssh-ed25519 AAAA")}},
+			}},
+		}},
+	}
+	inlineCreds := &bpb.Credentials{
+		ServerKeys: []*credpb.ServerKeysRequest{{
+			Version:       "1",
+			AuthArtifacts: []*credpb.ServerKeysRequest_AuthenticationArtifacts{{PrivateKey: []byte("key")}},
+		}},
+	}
+
+	tests := []struct {
+		desc     string
+		chassis  *epb.GNSIConfig
+		global   *epb.GNSIConfig
+		want     *bpb.Credentials
+		wantCode codes.Code
+	}{{
+		desc: "No credentials configured",
+		want: &bpb.Credentials{},
+	}, {
+		desc:   "Global credentials file",
+		global: &epb.GNSIConfig{CredentialsFile: path},
+		want:   fileCreds,
+	}, {
+		desc:    "Chassis credentials override global ones",
+		chassis: &epb.GNSIConfig{Credentials: inlineCreds},
+		global:  &epb.GNSIConfig{CredentialsFile: path},
+		want:    inlineCreds,
+	}, {
+		desc:    "Inline credentials override credentials file",
+		chassis: &epb.GNSIConfig{Credentials: inlineCreds, CredentialsFile: path},
+		want:    inlineCreds,
+	}, {
+		desc:     "Missing credentials file",
+		chassis:  &epb.GNSIConfig{CredentialsFile: filepath.Join(t.TempDir(), "missing.prototext")},
+		wantCode: codes.Unavailable,
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			em, err := New("")
+			if err != nil {
+				t.Fatalf("New() err = %v, want nil", err)
+			}
+			em.defaults.GnsiGlobalConfig = test.global
+			got, err := em.populateCredentials(&epb.Chassis{Config: &epb.Config{GnsiConfig: test.chassis}})
+			if status.Code(err) != test.wantCode {
+				t.Fatalf("populateCredentials() err = %v, want code %v", err, test.wantCode)
+			}
+			if diff := cmp.Diff(test.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("populateCredentials() diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDefaultProfile(t *testing.T) {
+	em, err := New("")
+	if err != nil {
+		t.Fatalf("unable to create entitymanager: %v", err)
+	}
+	em.secArtifacts = &service.SecurityArtifacts{
+		OC: &service.KeyPair{
+			PrivateKey: "FakeOCPrivateKey",
+			Cert:       "FakeOCCert",
+		},
+	}
+	image := &bpb.SoftwareImage{Name: "Quarantine Image", Version: "1.0"}
+	em.defaults.DefaultProfile = &epb.DefaultProfile{
+		Allowlist: []*epb.AllowedDevice{
+			{Manufacturer: "Cisco", PartNumber: "FX-1"},
+			{Manufacturer: "Arista", PartNumber: "*"},
+		},
+		BootMode:      bpb.BootMode_BOOT_MODE_INSECURE,
+		SoftwareImage: image,
+		Config: &epb.Config{
+			GnsiConfig: &epb.GNSIConfig{
+				AuthzUploadFile: "../../testdata/authz.prototext",
+			},
+		},
+	}
+	s := service.New(em)
+	tests := []struct {
+		desc     string
+		req      *bpb.GetBootstrapDataRequest
+		wantCode codes.Code
+	}{{
+		desc: "Allowed fixed chassis",
+		req: &bpb.GetBootstrapDataRequest{
+			ChassisDescriptor: &bpb.ChassisDescriptor{Manufacturer: "Cisco", SerialNumber: "456", PartNumber: "FX-1"},
+		},
+	}, {
+		desc: "Allowed modular chassis",
+		req: &bpb.GetBootstrapDataRequest{
+			ChassisDescriptor: &bpb.ChassisDescriptor{
+				Manufacturer: "Arista",
+				PartNumber:   "MX-1",
+				ControlCards: []*bpb.ControlCard{{SerialNumber: "789A"}, {SerialNumber: "789B"}},
+			},
+			ControlCardState: &bpb.ControlCardState{SerialNumber: "789A"},
+		},
+	}, {
+		desc: "Chassis not in allowlist",
+		req: &bpb.GetBootstrapDataRequest{
+			ChassisDescriptor: &bpb.ChassisDescriptor{Manufacturer: "Cisco", SerialNumber: "456", PartNumber: "FX-2"},
+		},
+		wantCode: codes.InvalidArgument,
+	}, {
+		desc: "Signed response",
+		req: &bpb.GetBootstrapDataRequest{
+			ChassisDescriptor: &bpb.ChassisDescriptor{Manufacturer: "Cisco", SerialNumber: "456", PartNumber: "FX-1"},
+			Nonce:             "nonce",
+		},
+		wantCode: codes.FailedPrecondition,
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			resp, err := s.GetBootstrapData(context.Background(), test.req)
+			if status.Code(err) != test.wantCode {
+				t.Fatalf("GetBootstrapData() err = %v, want code %v", err, test.wantCode)
+			}
+			for _, r := range resp.GetSignedResponse().GetResponses() {
+				if !proto.Equal(r.GetIntendedImage(), image) {
+					t.Errorf("GetBootstrapData() image = %v, want %v", r.GetIntendedImage(), image)
+				}
+			}
+		})
+	}
+	if len(em.controlCardStatuses) != 0 {
+		t.Errorf("control card statuses = %v, want none for chassis served the default profile", em.controlCardStatuses)
+	}
+}
+
+func TestNewSecureDefaultProfile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "inventory.prototxt")
+	inv := `options { default_profile { allowlist { manufacturer: "*" part_number: "*" } boot_mode: BOOT_MODE_SECURE } }`
+	if err := os.WriteFile(path, []byte(inv), 0600); err != nil {
+		t.Fatalf("unable to write inventory: %v", err)
+	}
+	if _, err := New(path); err == nil {
+		t.Errorf("New() err = nil, want error for a default profile with secure boot mode")
+	}
+}
+
+func TestBootMode(t *testing.T) {
+	testdata, err := filepath.Abs("../../testdata")
+	if err != nil {
+		t.Fatalf("unable to resolve testdata dir: %v", err)
+	}
+	authz := fmt.Sprintf(`gnsi_global_config { authz_upload_file: %q }`, filepath.Join(testdata, "authz.prototext"))
+	artifacts := fmt.Sprintf(`artifact_dir: %q`, testdata)
+	tests := []struct {
+		desc     string
+		options  string
+		chassis  string
+		wantMode bpb.BootMode
+		wantErr  string
+	}{{
+		desc:     "Insecure chassis without artifacts",
+		chassis:  `boot_mode: BOOT_MODE_INSECURE`,
+		wantMode: bpb.BootMode_BOOT_MODE_INSECURE,
+	}, {
+		desc:    "Secure chassis without artifacts",
+		chassis: `boot_mode: BOOT_MODE_SECURE ownership_voucher: "ov"`,
+		wantErr: "no artifact_dir is set",
+	}, {
+		desc:    "Secure chassis without ownership voucher",
+		options: artifacts,
+		chassis: `boot_mode: BOOT_MODE_SECURE controller_cards { serial_number: "1A" ownership_voucher: "ov" } controller_cards { serial_number: "1B" }`,
+		wantErr: "no ownership voucher for [1B]",
+	}, {
+		desc:     "Default boot mode",
+		options:  artifacts + ` default_boot_mode: BOOT_MODE_SECURE`,
+		chassis:  `ownership_voucher: "ov"`,
+		wantMode: bpb.BootMode_BOOT_MODE_SECURE,
+	}, {
+		desc:     "Chassis boot mode overrides the default",
+		options:  `default_boot_mode: BOOT_MODE_SECURE`,
+		chassis:  `boot_mode: BOOT_MODE_INSECURE`,
+		wantMode: bpb.BootMode_BOOT_MODE_INSECURE,
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			inv := fmt.Sprintf(`options { %s %s } chassis { manufacturer: "Cisco" serial_number: "1" %s }`, authz, test.options, test.chassis)
+			path := filepath.Join(t.TempDir(), "inventory.prototxt")
+			if err := os.WriteFile(path, []byte(inv), 0600); err != nil {
+				t.Fatalf("unable to write inventory: %v", err)
+			}
+			em, err := New(path)
+			if s := errdiff.Substring(err, test.wantErr); s != "" {
+				t.Fatalf("New() %s", s)
+			}
+			if err != nil {
+				return
+			}
+			got, err := em.ResolveChassis(context.Background(), &service.EntityLookup{Manufacturer: "Cisco", SerialNumber: "1"}, "")
+			if err != nil {
+				t.Fatalf("ResolveChassis() err = %v, want nil", err)
+			}
+			if got.BootMode != test.wantMode {
+				t.Errorf("ResolveChassis() boot mode = %v, want %v", got.BootMode, test.wantMode)
+			}
+		})
+	}
+}
+
+func TestValidateSecurityArtifacts(t *testing.T) {
+	em, err := New("../../testdata/inventory.prototxt")
+	if err != nil {
+		t.Fatalf("New() err = %v, want nil", err)
+	}
+	chassis := []*epb.Chassis{}
+	for _, ch := range em.chassisInventory {
+		chassis = append(chassis, ch)
+	}
+	current := em.secArtifacts
+	// A P-224 OC issued by the PDC is valid, but not FIPS approved.
+	pdcCert, err := certFromPEM(current.PDC.Cert)
+	if err != nil {
+		t.Fatalf("certFromPEM() err = %v", err)
+	}
+	pdcKey, err := current.PDC.PrivateKeySigner()
+	if err != nil {
+		t.Fatalf("PrivateKeySigner() err = %v", err)
+	}
+	ocKey, err := ecdsa.GenerateKey(elliptic.P224(), rand.Reader)
+	if err != nil {
+		t.Fatalf("ecdsa.GenerateKey() err = %v", err)
+	}
+	ocDER, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "P-224 OC"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}, pdcCert, ocKey.Public(), pdcKey)
+	if err != nil {
+		t.Fatalf("x509.CreateCertificate() err = %v", err)
+	}
+	p224OC := &service.KeyPair{Cert: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ocDER}))}
+	// The vendor CA stands in for the PDC and OC of another domain.
+	tests := []struct {
+		desc       string
+		mode       epb.PDCVerification
+		validation epb.VoucherValidation
+		fipsMode   bool
+		artifacts  func(service.SecurityArtifacts) *service.SecurityArtifacts
+		now        time.Time
+		wantErr    []string
+	}{{
+		desc: "Valid artifacts",
+		mode: epb.PDCVerification_PDC_VERIFICATION_EXACT,
+		now:  time.Now(),
+	}, {
+		desc:    "Expired certificates",
+		mode:    epb.PDCVerification_PDC_VERIFICATION_EXACT,
+		now:     time.Now().AddDate(100, 0, 0),
+		wantErr: []string{"found 3 problems", `vendor CA "Manufacturer Root CA" expired`, "PDC", "OC"},
+	}, {
+		desc: "OC of another domain",
+		mode: epb.PDCVerification_PDC_VERIFICATION_EXACT,
+		artifacts: func(sa service.SecurityArtifacts) *service.SecurityArtifacts {
+			sa.OC = sa.VendorCA
+			return &sa
+		},
+		now:     time.Now(),
+		wantErr: []string{"found 1 problems", "does not chain to the PDC"},
+	}, {
+		desc: "Vouchers pinning another PDC",
+		mode: epb.PDCVerification_PDC_VERIFICATION_SPKI,
+		artifacts: func(sa service.SecurityArtifacts) *service.SecurityArtifacts {
+			sa.PDC = sa.VendorCA
+			return &sa
+		},
+		now:     time.Now(),
+		wantErr: []string{"found 3 problems", "does not chain to the PDC", "ownership voucher of serial#: 123A does not pin the PDC", "ownership voucher of serial#: 123B does not pin the PDC"},
+	}, {
+		desc: "Vouchers served unverified",
+		mode: epb.PDCVerification_PDC_VERIFICATION_NONE,
+		artifacts: func(sa service.SecurityArtifacts) *service.SecurityArtifacts {
+			sa.PDC = sa.VendorCA
+			return &sa
+		},
+		now:     time.Now(),
+		wantErr: []string{"found 1 problems", "does not chain to the PDC"},
+	}, {
+		desc: "P-224 OC",
+		mode: epb.PDCVerification_PDC_VERIFICATION_EXACT,
+		artifacts: func(sa service.SecurityArtifacts) *service.SecurityArtifacts {
+			sa.OC = p224OC
+			return &sa
+		},
+		now: time.Now(),
+	}, {
+		desc:     "P-224 OC in FIPS mode",
+		mode:     epb.PDCVerification_PDC_VERIFICATION_EXACT,
+		fipsMode: true,
+		artifacts: func(sa service.SecurityArtifacts) *service.SecurityArtifacts {
+			sa.OC = p224OC
+			return &sa
+		},
+		now:     time.Now(),
+		wantErr: []string{"found 1 problems", `OC "P-224 OC" is not allowed in FIPS mode`},
+	}, {
+		desc:       "Legacy vouchers with strict validation",
+		mode:       epb.PDCVerification_PDC_VERIFICATION_NONE,
+		validation: epb.VoucherValidation_VOUCHER_VALIDATION_STRICT,
+		now:        time.Now(),
+		wantErr:    []string{"found 2 problems", "ownership voucher of serial#: 123A is invalid: created-on", "assertion: mandatory leaf is missing"},
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			em.defaults.PdcVerification = test.mode
+			em.defaults.FipsMode = test.fipsMode
+			em.defaults.VoucherValidation = test.validation
+			em.secArtifacts = current
+			if test.artifacts != nil {
+				em.secArtifacts = test.artifacts(*current)
+			}
+			err := em.validateSecurityArtifacts(chassis, test.now)
+			if len(test.wantErr) == 0 {
+				if err != nil {
+					t.Errorf("validateSecurityArtifacts() err = %v, want nil", err)
+				}
+				return
+			}
+			for _, want := range test.wantErr {
+				if s := errdiff.Substring(err, want); s != "" {
+					t.Errorf("validateSecurityArtifacts() %s", s)
+				}
+			}
+		})
+	}
+}
+
+func TestNewWithGeneratedArtifacts(t *testing.T) {
+	inv := filepath.Join(t.TempDir(), "inventory.prototxt")
+	if err := os.WriteFile(inv, []byte(`
+options {
+  pdc_verification: PDC_VERIFICATION_EXACT
+  gnsi_global_config { authz_upload_file: "../../testdata/authz.prototext" }
+}
+chassis {
+  serial_number: "123"
+  manufacturer: "Cisco"
+  boot_mode: BOOT_MODE_SECURE
+  controller_cards { serial_number: "123A" ownership_voucher: "stale" }
+  controller_cards { serial_number: "123B" }
+}
+chassis {
+  serial_number: "456"
+  manufacturer: "Cisco"
+  boot_mode: BOOT_MODE_SECURE
+}`), 0600); err != nil {
+		t.Fatalf("unable to write inventory: %v", err)
+	}
+	if _, err := New(inv); err == nil {
+		t.Fatalf("New() of a secure inventory without artifacts err = nil, want error")
+	}
+	em, err := NewWithGeneratedArtifacts(inv)
+	if err != nil {
+		t.Fatalf("NewWithGeneratedArtifacts() err = %v, want nil", err)
+	}
+	if em.SecurityArtifacts() == nil {
+		t.Fatalf("SecurityArtifacts() = nil, want generated artifacts")
+	}
+	for _, test := range []struct {
+		lookup service.EntityLookup
+		serial string
+	}{
+		{service.EntityLookup{Manufacturer: "Cisco", SerialNumber: "123"}, "123A"},
+		{service.EntityLookup{Manufacturer: "Cisco", SerialNumber: "123"}, "123B"},
+		{service.EntityLookup{Manufacturer: "Cisco", SerialNumber: "456"}, "456"},
+	} {
+		resp := &bpb.GetBootstrapDataResponse{
+			SerializedBootstrapData: MustMarshalBootstrapDataSigned(t, &bpb.BootstrapDataSigned{
+				Responses: []*bpb.BootstrapDataResponse{{SerialNum: test.serial}},
+			}),
+		}
+		if err := em.Sign(context.Background(), resp, &test.lookup, test.serial, nil); err != nil {
+			t.Errorf("Sign() of %s err = %v, want nil", test.serial, err)
+		}
+	}
+
+	em, err = NewWithGeneratedArtifacts("")
+	if err != nil {
+		t.Fatalf("NewWithGeneratedArtifacts() without inventory err = %v, want nil", err)
+	}
+	if em.SecurityArtifacts() == nil {
+		t.Errorf("SecurityArtifacts() without inventory = nil, want generated artifacts")
+	}
+}
+
+func TestSecureBootMissingArtifacts(t *testing.T) {
+	em, _ := New("")
+	em.AddChassis(bpb.BootMode_BOOT_MODE_SECURE, "Cisco", "123")
+	lookup := &service.EntityLookup{Manufacturer: "Cisco", SerialNumber: "123"}
+	if _, err := em.OwnershipVoucher(context.Background(), lookup, "123"); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("OwnershipVoucher() of a secure chassis without voucher err = %v, want code %v", err, codes.FailedPrecondition)
+	}
+	resp := &bpb.GetBootstrapDataResponse{SerializedBootstrapData: []byte("data")}
+	if err := em.Sign(context.Background(), resp, lookup, "123", nil); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Sign() without security artifacts err = %v, want code %v", err, codes.FailedPrecondition)
+	}
+}
+
+func TestModelImage(t *testing.T) {
+	em, err := New("")
+	if err != nil {
+		t.Fatalf("New() err = %v, want nil", err)
+	}
+	exact := &bpb.SoftwareImage{Name: "exact", Version: "2.0", Url: "https://path/to/exact"}
+	anyModel := &bpb.SoftwareImage{Name: "any", Version: "1.0", Url: "https://path/to/any"}
+	own := &bpb.SoftwareImage{Name: "own", Version: "3.0", Url: "https://path/to/own"}
+	em.defaults.ModelImages = []*epb.ModelImage{
+		{Manufacturer: "Cisco", PartNumber: "*", SoftwareImage: anyModel},
+		{Manufacturer: "Cisco", PartNumber: "8808", SoftwareImage: exact},
+	}
+	tests := []struct {
+		desc    string
+		chassis *epb.Chassis
+		lookup  *service.EntityLookup
+		want    *bpb.SoftwareImage
+	}{{
+		desc:    "Chassis image",
+		chassis: &epb.Chassis{Manufacturer: "Cisco", PartNumber: "8808", SoftwareImage: own},
+		lookup:  &service.EntityLookup{Manufacturer: "Cisco"},
+		want:    own,
+	}, {
+		desc:    "Exact part number",
+		chassis: &epb.Chassis{Manufacturer: "Cisco", PartNumber: "8808"},
+		lookup:  &service.EntityLookup{Manufacturer: "Cisco"},
+		want:    exact,
+	}, {
+		desc:    "Part number of the request",
+		chassis: &epb.Chassis{Manufacturer: "Cisco"},
+		lookup:  &service.EntityLookup{Manufacturer: "Cisco", PartNumber: "8808"},
+		want:    exact,
+	}, {
+		desc:    "Wildcard part number",
+		chassis: &epb.Chassis{Manufacturer: "Cisco", PartNumber: "8201"},
+		lookup:  &service.EntityLookup{Manufacturer: "Cisco"},
+		want:    anyModel,
+	}, {
+		desc:    "Other manufacturer",
+		chassis: &epb.Chassis{Manufacturer: "Arista", PartNumber: "8808"},
+		lookup:  &service.EntityLookup{Manufacturer: "Arista"},
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			if got := em.softwareImage(test.chassis, test.lookup); got != test.want {
+				t.Errorf("softwareImage() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestOmitRunningImage(t *testing.T) {
+	em, err := New("../../testdata/inventory.prototxt")
+	if err != nil {
+		t.Fatalf("New() err = %v, want nil", err)
+	}
+	s := service.New(em)
+	resp, err := s.GetBootstrapData(context.Background(), &bpb.GetBootstrapDataRequest{
+		ChassisDescriptor: &bpb.ChassisDescriptor{
+			Manufacturer: "Cisco",
+			SerialNumber: "123",
+			ControlCards: []*bpb.ControlCard{
+				{SerialNumber: "123A", PartNumber: "123A", SoftwareVersion: "1.0"},
+				{SerialNumber: "123B", PartNumber: "123B", SoftwareVersion: "0.9"},
+			},
+		},
+		ControlCardState: &bpb.ControlCardState{SerialNumber: "123A"},
+	})
+	if err != nil {
+		t.Fatalf("GetBootstrapData() err = %v, want nil", err)
+	}
+	signed := &bpb.BootstrapDataSigned{}
+	if err := proto.Unmarshal(resp.GetSerializedBootstrapData(), signed); err != nil {
+		t.Fatalf("unable to unmarshal bootstrap data: %v", err)
+	}
+	for _, r := range signed.GetResponses() {
+		wantImage := r.GetSerialNum() == "123B"
+		if got := r.GetIntendedImage() != nil; got != wantImage {
+			t.Errorf("GetBootstrapData() response for %s has an intended image: %v, want %v", r.GetSerialNum(), got, wantImage)
+		}
+	}
+}
+
+func TestStatusEvents(t *testing.T) {
+	em, err := New("../../testdata/inventory.prototxt")
+	if err != nil {
+		t.Fatalf("New() err = %v, want nil", err)
+	}
+	s := service.New(em)
+	ch, cancel := s.Events().Subscribe([]string{"123A"})
+	defer cancel()
+
+	desc := &bpb.ChassisDescriptor{
+		Manufacturer: "Cisco",
+		SerialNumber: "123",
+		ControlCards: []*bpb.ControlCard{
+			{SerialNumber: "123A", PartNumber: "123A"},
+			{SerialNumber: "123B", PartNumber: "123B"},
+		},
+	}
+	if _, err := s.GetBootstrapData(context.Background(), &bpb.GetBootstrapDataRequest{
+		ChassisDescriptor: desc,
+		ControlCardState:  &bpb.ControlCardState{SerialNumber: "123A"},
+	}); err != nil {
+		t.Fatalf("GetBootstrapData() err = %v, want nil", err)
+	}
+	if _, err := s.GetBootstrapData(context.Background(), &bpb.GetBootstrapDataRequest{
+		ChassisDescriptor: &bpb.ChassisDescriptor{
+			Manufacturer: "Cisco",
+			SerialNumber: "123",
+			ControlCards: []*bpb.ControlCard{{SerialNumber: "123A", PartNumber: "123A"}, {SerialNumber: "789"}},
+		},
+	}); err == nil {
+		t.Fatalf("GetBootstrapData() with an unknown control card err = nil, want error")
+	}
+	for _, st := range []bpb.ReportStatusRequest_BootstrapStatus{
+		bpb.ReportStatusRequest_BOOTSTRAP_STATUS_INITIATED,
+		bpb.ReportStatusRequest_BOOTSTRAP_STATUS_SUCCESS,
+	} {
+		if _, err := s.ReportStatus(context.Background(), &bpb.ReportStatusRequest{
+			Status: st,
+			States: []*bpb.ControlCardState{{SerialNumber: "123A", Status: bpb.ControlCardState_CONTROL_CARD_STATUS_INITIALIZED}},
+		}); err != nil {
+			t.Fatalf("ReportStatus(%v) err = %v, want nil", st, err)
+		}
+	}
+
+	want := []events.Stage{events.Requested, events.Served, events.Requested, events.Rejected, events.Initiated, events.Succeeded}
+	for _, w := range want {
+		select {
+		case e := <-ch:
+			if e.SerialNumber != "123A" || e.Stage != w {
+				t.Errorf("Events() received %v for serial# %v, want %v for serial# 123A", e.Stage, e.SerialNumber, w)
+			}
+		default:
+			t.Fatalf("Events() received no event, want %v", w)
+		}
+	}
+	select {
+	case e := <-ch:
+		t.Errorf("Events() received unexpected event %+v", e)
+	default:
+	}
+}
+
+func TestBootstrapRetries(t *testing.T) {
+	em, err := New("../../testdata/inventory.prototxt")
+	if err != nil {
+		t.Fatalf("New() err = %v, want nil", err)
+	}
+	em.states = devicestate.New(1)
+	lookup := &service.EntityLookup{Manufacturer: "Cisco", SerialNumber: "123"}
+	cc := &bpb.ControlCard{SerialNumber: "123A", PartNumber: "123A"}
+	for i, wantCode := range []codes.Code{codes.OK, codes.OK, codes.ResourceExhausted} {
+		if _, err := em.GetBootstrapData(context.Background(), lookup, cc); status.Code(err) != wantCode {
+			t.Fatalf("GetBootstrapData() attempt %d err = %v, want code %v", i, err, wantCode)
+		}
+	}
+	if err := em.SetStatus(context.Background(), &bpb.ReportStatusRequest{
+		Status: bpb.ReportStatusRequest_BOOTSTRAP_STATUS_SUCCESS,
+		States: []*bpb.ControlCardState{{SerialNumber: "123A", Status: bpb.ControlCardState_CONTROL_CARD_STATUS_INITIALIZED}},
+	}); err != nil {
+		t.Fatalf("SetStatus() err = %v, want nil", err)
+	}
+	if d, _ := em.States().Get("123A"); d.State != devicestate.StatusOK {
+		t.Errorf("States().Get() = %v, want %v", d.State, devicestate.StatusOK)
+	}
+	if _, err := em.GetBootstrapData(context.Background(), lookup, cc); err != nil {
+		t.Errorf("GetBootstrapData() after success err = %v, want nil", err)
+	}
+}
+
+func TestAppliedArtifacts(t *testing.T) {
+	em, err := New("../../testdata/inventory.prototxt")
+	if err != nil {
+		t.Fatalf("New() err = %v, want nil", err)
+	}
+	lookup := &service.EntityLookup{Manufacturer: "Cisco", SerialNumber: "123"}
+	em.chassisInventory[*lookup].GetConfig().GetBootConfig().VendorConfigFile = "../../testdata/cisco.cfg"
+	resp, err := em.GetBootstrapData(context.Background(), lookup, &bpb.ControlCard{SerialNumber: "123A", PartNumber: "123A"})
+	if err != nil {
+		t.Fatalf("GetBootstrapData() err = %v, want nil", err)
+	}
+	served := servedArtifacts(resp)
+	if served.ImageHash == "" || served.ConfigHash == "" {
+		t.Fatalf("servedArtifacts() = %+v, want the hashes of the image and vendor config", served)
+	}
+	if d, _ := em.States().Get("123A"); d.Served != served {
+		t.Errorf("States().Get() served %+v, want %+v", d.Served, served)
+	}
+	if err := em.SetStatus(context.Background(), &bpb.ReportStatusRequest{
+		Status: bpb.ReportStatusRequest_BOOTSTRAP_STATUS_SUCCESS,
+		States: []*bpb.ControlCardState{{
+			SerialNumber:      "123A",
+			Status:            bpb.ControlCardState_CONTROL_CARD_STATUS_INITIALIZED,
+			AppliedImageHash:  served.ImageHash,
+			AppliedConfigHash: "0000",
+		}},
+	}); err != nil {
+		t.Fatalf("SetStatus() err = %v, want nil", err)
+	}
+	d, _ := em.States().Get("123A")
+	if diff := cmp.Diff([]string{"config"}, d.Drift()); diff != "" {
+		t.Errorf("Drift() diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestReplayProtection(t *testing.T) {
+	em, err := New("../../testdata/inventory.prototxt")
+	if err != nil {
+		t.Fatalf("New() err = %v, want nil", err)
+	}
+	s := service.New(em)
+	if err := s.Features().Load([]*epb.FeatureFlag{{Name: string(features.ReplayProtection), Enabled: true}}); err != nil {
+		t.Fatalf("Load() err = %v, want nil", err)
+	}
+	token, _, err := em.Tokens().Issue("123A", time.Hour)
+	if err != nil {
+		t.Fatalf("Issue() err = %v, want nil", err)
+	}
+	for _, test := range []struct {
+		desc     string
+		serial   string
+		token    string
+		counter  uint64
+		wantCode codes.Code
+	}{
+		{desc: "No counter", serial: "123A", token: token, counter: 0, wantCode: codes.InvalidArgument},
+		{desc: "First counter", serial: "123A", token: token, counter: 1, wantCode: codes.OK},
+		{desc: "Replayed counter", serial: "123A", token: token, counter: 1, wantCode: codes.PermissionDenied},
+		{desc: "Next counter", serial: "123A", token: token, counter: 2, wantCode: codes.OK},
+		{desc: "Counter too far ahead", serial: "123A", token: token, counter: 1 << 32, wantCode: codes.InvalidArgument},
+		// Requests without a token are checked, but don't record their counter.
+		{desc: "Unauthenticated counter", serial: "123B", counter: 5, wantCode: codes.OK},
+		{desc: "Unauthenticated counter again", serial: "123B", counter: 5, wantCode: codes.OK},
+	} {
+		t.Run(test.desc, func(t *testing.T) {
+			_, err := s.GetBootstrapData(context.Background(), &bpb.GetBootstrapDataRequest{
+				ChassisDescriptor: &bpb.ChassisDescriptor{
+					Manufacturer: "Cisco",
+					SerialNumber: "123",
+					ControlCards: []*bpb.ControlCard{{SerialNumber: "123A", PartNumber: "123A"}, {SerialNumber: "123B", PartNumber: "123B"}},
+				},
+				ControlCardState: &bpb.ControlCardState{SerialNumber: test.serial},
+				RequestCounter:   test.counter,
+				BootstrapToken:   test.token,
+			})
+			if status.Code(err) != test.wantCode {
+				t.Errorf("GetBootstrapData() with request counter %d err = %v, want code %v", test.counter, err, test.wantCode)
+			}
+		})
+	}
+	if d, _ := em.States().Get("123A"); d.RequestCounter != 2 {
+		t.Errorf("States().Get() request counter of 123A = %d, want 2", d.RequestCounter)
+	}
+	if d, _ := em.States().Get("123B"); d.RequestCounter != 0 {
+		t.Errorf("States().Get() request counter of 123B = %d, want 0", d.RequestCounter)
+	}
+}
+
+func TestBootstrapPolicies(t *testing.T) {
+	em, err := New("../../testdata/inventory.prototxt")
+	if err != nil {
+		t.Fatalf("New() err = %v, want nil", err)
+	}
+	s := service.New(em)
+	if err := s.SetPolicies([]*epb.BootstrapPolicy{{Name: "no 123B", Expression: `serial_number != "123B"`}}); err != nil {
+		t.Fatalf("SetPolicies() err = %v, want nil", err)
+	}
+	for _, test := range []struct {
+		serial   string
+		wantCode codes.Code
+	}{
+		{serial: "123A", wantCode: codes.OK},
+		{serial: "123B", wantCode: codes.PermissionDenied},
+	} {
+		_, err := s.GetBootstrapData(context.Background(), &bpb.GetBootstrapDataRequest{
+			ChassisDescriptor: &bpb.ChassisDescriptor{
+				Manufacturer: "Cisco",
+				SerialNumber: "123",
+				ControlCards: []*bpb.ControlCard{{SerialNumber: "123A", PartNumber: "123A"}, {SerialNumber: "123B", PartNumber: "123B"}},
+			},
+			ControlCardState: &bpb.ControlCardState{SerialNumber: test.serial},
+		})
+		if status.Code(err) != test.wantCode {
+			t.Errorf("GetBootstrapData() from control card %s err = %v, want code %v", test.serial, err, test.wantCode)
+		}
+	}
+}
+
+func TestBootstrapTokens(t *testing.T) {
+	em, err := New("../../testdata/inventory.prototxt")
+	if err != nil {
+		t.Fatalf("New() err = %v, want nil", err)
+	}
+	s := service.New(em)
+	token, _, err := em.Tokens().Issue("123A", time.Hour)
+	if err != nil {
+		t.Fatalf("Issue() err = %v, want nil", err)
+	}
+	request := func(serial, token string) *bpb.GetBootstrapDataRequest {
+		return &bpb.GetBootstrapDataRequest{
+			ChassisDescriptor: &bpb.ChassisDescriptor{
+				Manufacturer: "Cisco",
+				SerialNumber: "123",
+				ControlCards: []*bpb.ControlCard{{SerialNumber: "123A", PartNumber: "123A"}, {SerialNumber: "123B", PartNumber: "123B"}},
+			},
+			ControlCardState: &bpb.ControlCardState{SerialNumber: serial},
+			BootstrapToken:   token,
+		}
+	}
+	withMetadata := metadata.NewIncomingContext(context.Background(), metadata.Pairs(service.TokenMetadata, token))
+
+	tests := []struct {
+		desc     string
+		ctx      context.Context
+		req      *bpb.GetBootstrapDataRequest
+		require  bool
+		wantCode codes.Code
+	}{{
+		desc:     "Token in request",
+		req:      request("123A", token),
+		wantCode: codes.OK,
+	}, {
+		desc:     "Token in metadata",
+		ctx:      withMetadata,
+		req:      request("123A", ""),
+		wantCode: codes.OK,
+	}, {
+		desc:     "Missing token",
+		req:      request("123A", ""),
+		wantCode: codes.PermissionDenied,
+	}, {
+		desc:     "Wrong token",
+		req:      request("123A", "WRONG"),
+		wantCode: codes.PermissionDenied,
+	}, {
+		desc:     "Device without token",
+		req:      request("123B", ""),
+		wantCode: codes.OK,
+	}, {
+		desc:     "Device without token when required",
+		req:      request("123B", ""),
+		require:  true,
+		wantCode: codes.PermissionDenied,
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			ctx := test.ctx
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			if err := s.Features().Set(features.Setting{Flag: features.RequireBootstrapToken, Enabled: test.require}); err != nil {
+				t.Fatalf("Set() err = %v, want nil", err)
+			}
+			_, err := s.GetBootstrapData(ctx, test.req)
+			if status.Code(err) != test.wantCode {
+				t.Errorf("GetBootstrapData() err = %v, want code %v", err, test.wantCode)
+			}
+		})
+	}
+
+	// The token is used once the device reports a successful bootstrap.
+	s.Features().Clear(features.RequireBootstrapToken, features.Scope{})
+	if err := em.SetStatus(context.Background(), &bpb.ReportStatusRequest{
+		Status: bpb.ReportStatusRequest_BOOTSTRAP_STATUS_SUCCESS,
+		States: []*bpb.ControlCardState{{SerialNumber: "123A"}},
+	}); err != nil {
+		t.Fatalf("SetStatus() err = %v, want nil", err)
+	}
+	if em.Tokens().Issued("123A") {
+		t.Errorf("Issued() after successful bootstrap = true, want false")
+	}
+	if _, err := s.GetBootstrapData(context.Background(), request("123A", token)); status.Code(err) != codes.OK {
+		t.Errorf("GetBootstrapData() with used token err = %v, want nil, as no token is required", err)
+	}
+	if err := s.Features().Set(features.Setting{Flag: features.RequireBootstrapToken, Enabled: true}); err != nil {
+		t.Fatalf("Set() err = %v, want nil", err)
+	}
+	if _, err := s.GetBootstrapData(context.Background(), request("123A", token)); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("GetBootstrapData() with used token when required err = %v, want code %v", err, codes.PermissionDenied)
+	}
+}
+
+func TestArtifactsPending(t *testing.T) {
+	em, err := New("../../testdata/inventory.prototxt")
+	if err != nil {
+		t.Fatalf("New() err = %v, want nil", err)
+	}
+	em.defaults.RetryInterval = durationpb.New(time.Minute)
+	lookup := &service.EntityLookup{Manufacturer: "Cisco", SerialNumber: "123"}
+	cc := &bpb.ControlCard{SerialNumber: "123A", PartNumber: "123A"}
+	entry := em.GetChassisInventory()[*lookup]
+
+	pending := proto.Clone(entry).(*epb.Chassis)
+	pending.ArtifactsPending = true
+	missingFile := proto.Clone(entry).(*epb.Chassis)
+	missingFile.Config.GnsiConfig.AuthzUploadFile = filepath.Join(t.TempDir(), "authz.prototext")
+	tests := []struct {
+		desc    string
+		chassis *epb.Chassis
+	}{{
+		desc:    "Chassis marked pending",
+		chassis: pending,
+	}, {
+		desc:    "Artifact file missing",
+		chassis: missingFile,
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			if err := em.ReplaceDevice(lookup, test.chassis); err != nil {
+				t.Fatalf("ReplaceDevice() err = %v, want nil", err)
+			}
+			_, err := em.GetBootstrapData(context.Background(), lookup, cc)
+			if status.Code(err) != codes.Unavailable {
+				t.Fatalf("GetBootstrapData() err = %v, want code %v", err, codes.Unavailable)
+			}
+			if got, ok := service.RetryDelay(err); !ok || got != time.Minute {
+				t.Errorf("GetBootstrapData() retry delay = %v, %v, want %v, true", got, ok, time.Minute)
+			}
+		})
+	}
+	if _, ok := em.States().Get("123A"); ok {
+		t.Errorf("States().Get() found a state for a device asked to retry, want none")
+	}
+}
+
+func TestSecondStageConfig(t *testing.T) {
+	em, err := New("../../testdata/inventory.prototxt")
+	if err != nil {
+		t.Fatalf("New() err = %v, want nil", err)
+	}
+	path := filepath.Join(t.TempDir(), "second_stage.prototext")
+	if err := os.WriteFile(path, []byte(`update { path { elem { name: "system" } } val { json_ietf_val: "{}" } }`), 0600); err != nil {
+		t.Fatalf("unable to write second stage config: %v", err)
+	}
+	lookup := &service.EntityLookup{Manufacturer: "Cisco", SerialNumber: "123"}
+	ch := proto.Clone(em.GetChassisInventory()[*lookup]).(*epb.Chassis)
+	ch.Config.SecondStageConfigFile = path
+	ch.GetControllerCards()[1].SerialAliases = []string{"FOX123B"}
+	if err := em.ReplaceDevice(lookup, ch); err != nil {
+		t.Fatalf("ReplaceDevice() err = %v, want nil", err)
+	}
+	em.AddChassis(bpb.BootMode_BOOT_MODE_INSECURE, "Cisco", "456")
+	em.defaults.SerialNormalization = &epb.SerialNormalization{IgnoreCase: true, StripPrefixes: []string{"SN"}}
+
+	tests := []struct {
+		desc     string
+		serial   string
+		want     bool
+		wantCode codes.Code
+	}{{
+		desc:   "Configured control card",
+		serial: "123B",
+		want:   true,
+	}, {
+		desc:   "Control card reported under its alias",
+		serial: "fox123b",
+		want:   true,
+	}, {
+		desc:   "Control card reported under a normalized serial",
+		serial: "sn123b",
+		want:   true,
+	}, {
+		desc:   "Chassis without second stage config",
+		serial: "456",
+	}, {
+		desc:     "Unknown serial",
+		serial:   "789",
+		wantCode: codes.NotFound,
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			got, err := em.SecondStageConfig(test.serial)
+			if status.Code(err) != test.wantCode {
+				t.Fatalf("SecondStageConfig(%q) err = %v, want code %v", test.serial, err, test.wantCode)
+			}
+			if (len(got.GetUpdate()) == 1) != test.want {
+				t.Errorf("SecondStageConfig(%q) = %v, want a config: %v", test.serial, got, test.want)
+			}
+		})
+	}
+}
+
+func TestIntendedImage(t *testing.T) {
+	em, err := New("../../testdata/inventory.prototxt")
+	if err != nil {
+		t.Fatalf("New() err = %v, want nil", err)
+	}
+	em.AddChassis(bpb.BootMode_BOOT_MODE_INSECURE, "Cisco", "456")
+	em.defaults.SerialNormalization = &epb.SerialNormalization{IgnoreCase: true, StripPrefixes: []string{"SN"}}
+	tests := []struct {
+		desc        string
+		serial      string
+		wantVersion string
+		wantCode    codes.Code
+	}{{
+		desc:        "Control card of a chassis with an image",
+		serial:      "123B",
+		wantVersion: "1.0",
+	}, {
+		desc:        "Control card reported under a normalized serial",
+		serial:      "sn123b",
+		wantVersion: "1.0",
+	}, {
+		desc:   "Chassis without image",
+		serial: "456",
+	}, {
+		desc:   "Fixed chassis reported under a normalized serial",
+		serial: "SN456",
+	}, {
+		desc:     "Unknown serial",
+		serial:   "789",
+		wantCode: codes.NotFound,
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			got, err := em.IntendedImage(test.serial)
+			if status.Code(err) != test.wantCode {
+				t.Fatalf("IntendedImage(%q) err = %v, want code %v", test.serial, err, test.wantCode)
+			}
+			if got.GetVersion() != test.wantVersion {
+				t.Errorf("IntendedImage(%q) version = %q, want %q", test.serial, got.GetVersion(), test.wantVersion)
+			}
+		})
+	}
+}
+
+func TestFinalBootConfig(t *testing.T) {
+	em, err := New("../../testdata/inventory.prototxt")
+	if err != nil {
+		t.Fatalf("New() err = %v, want nil", err)
+	}
+	dir := t.TempDir()
+	initialPath := filepath.Join(dir, "initial.cfg")
+	finalPath := filepath.Join(dir, "final.cfg")
+	if err := os.WriteFile(initialPath, []byte("hostname initial"), 0600); err != nil {
+		t.Fatalf("unable to write initial config: %v", err)
+	}
+	if err := os.WriteFile(finalPath, []byte("hostname final"), 0600); err != nil {
+		t.Fatalf("unable to write final config: %v", err)
+	}
+	lookup := &service.EntityLookup{Manufacturer: "Cisco", SerialNumber: "123"}
+	cc := &bpb.ControlCard{SerialNumber: "123A", PartNumber: "123A"}
+	ch := proto.Clone(em.GetChassisInventory()[*lookup]).(*epb.Chassis)
+	ch.Config.BootConfig = &epb.BootConfig{VendorConfigFile: initialPath}
+	ch.Config.FinalBootConfig = &epb.BootConfig{VendorConfigFile: finalPath}
+	if err := em.ReplaceDevice(lookup, ch); err != nil {
+		t.Fatalf("ReplaceDevice() err = %v, want nil", err)
+	}
+	report := func(st bpb.ReportStatusRequest_BootstrapStatus) {
+		t.Helper()
+		if err := em.SetStatus(context.Background(), &bpb.ReportStatusRequest{
+			Status: st,
+			States: []*bpb.ControlCardState{{SerialNumber: "123A", Status: bpb.ControlCardState_CONTROL_CARD_STATUS_INITIALIZED}},
+		}); err != nil {
+			t.Fatalf("SetStatus() err = %v, want nil", err)
+		}
+	}
+	tests := []struct {
+		desc      string
+		before    func()
+		want      string
+		wantStage devicestate.ConfigStage
+	}{{
+		desc:      "First request",
+		before:    func() {},
+		want:      "hostname initial",
+		wantStage: devicestate.InitialStage,
+	}, {
+		desc:      "Retry after failure",
+		before:    func() { report(bpb.ReportStatusRequest_BOOTSTRAP_STATUS_FAILURE) },
+		want:      "hostname initial",
+		wantStage: devicestate.InitialStage,
+	}, {
+		desc:      "Request after success",
+		before:    func() { report(bpb.ReportStatusRequest_BOOTSTRAP_STATUS_SUCCESS) },
+		want:      "hostname final",
+		wantStage: devicestate.FinalStage,
+	}, {
+		desc:      "Request after final success",
+		before:    func() { report(bpb.ReportStatusRequest_BOOTSTRAP_STATUS_SUCCESS) },
+		want:      "hostname final",
+		wantStage: devicestate.FinalStage,
+	}, {
+		desc: "Request after reset",
+		before: func() {
+			if err := em.States().Reset("123A"); err != nil {
+				t.Fatalf("Reset() err = %v, want nil", err)
+			}
+		},
+		want:      "hostname initial",
+		wantStage: devicestate.InitialStage,
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			test.before()
+			resp, err := em.GetBootstrapData(context.Background(), lookup, cc)
+			if err != nil {
+				t.Fatalf("GetBootstrapData() err = %v, want nil", err)
+			}
+			if got := string(resp.GetBootConfig().GetVendorConfig()); got != test.want {
+				t.Errorf("GetBootstrapData() vendor config = %q, want %q", got, test.want)
+			}
+			if d, _ := em.States().Get("123A"); d.Stage != test.wantStage {
+				t.Errorf("States().Get() stage = %q, want %q", d.Stage, test.wantStage)
+			}
+		})
+	}
+
+	ch.Config.FinalBootConfig.VendorConfigFile = filepath.Join(dir, "missing.cfg")
+	if err := em.ReplaceDevice(lookup, ch); err != nil {
+		t.Fatalf("ReplaceDevice() err = %v, want nil", err)
+	}
+	if _, err := em.GetBootstrapData(context.Background(), lookup, cc); status.Code(err) != codes.Unavailable {
+		t.Errorf("GetBootstrapData() with the final config not staged err = %v, want code %v", err, codes.Unavailable)
+	}
+}
+
+func TestPopulateBootConfigEncoding(t *testing.T) {
+	dir := t.TempDir()
+	cliPath := filepath.Join(dir, "vendor.cfg")
+	if err := os.WriteFile(cliPath, []byte("hostname device"), 0600); err != nil {
+		t.Fatalf("unable to write vendor config: %v", err)
+	}
+	setReqPath := filepath.Join(dir, "set_request.prototext")
+	if err := os.WriteFile(setReqPath, []byte(`update { path { elem { name: "system" } } val { json_ietf_val: "{}" } }`), 0600); err != nil {
+		t.Fatalf("unable to write gNMI SetRequest: %v", err)
+	}
+
+	tests := []struct {
+		desc       string
+		conf       *epb.BootConfig
+		wantCLI    bool
+		wantSetReq bool
+		wantCode   codes.Code
+	}{{
+		desc:       "Unspecified encoding serves every config",
+		conf:       &epb.BootConfig{VendorConfigFile: cliPath, GnmiSetRequestFile: setReqPath},
+		wantCLI:    true,
+		wantSetReq: true,
+	}, {
+		desc:    "Vendor CLI encoding",
+		conf:    &epb.BootConfig{VendorConfigFile: cliPath, GnmiSetRequestFile: setReqPath, Encoding: bpb.ConfigEncoding_CONFIG_ENCODING_VENDOR_CLI},
+		wantCLI: true,
+	}, {
+		desc:       "gNMI SetRequest encoding",
+		conf:       &epb.BootConfig{VendorConfigFile: cliPath, GnmiSetRequestFile: setReqPath, Encoding: bpb.ConfigEncoding_CONFIG_ENCODING_GNMI_SET_REQUEST},
+		wantSetReq: true,
+	}, {
+		desc:     "Selected encoding without config",
+		conf:     &epb.BootConfig{VendorConfigFile: cliPath, Encoding: bpb.ConfigEncoding_CONFIG_ENCODING_GNMI_SET_REQUEST},
+		wantCode: codes.FailedPrecondition,
+	}, {
+		desc:     "Missing gNMI SetRequest file",
+		conf:     &epb.BootConfig{GnmiSetRequestFile: filepath.Join(dir, "missing.prototext")},
+		wantCode: codes.Unavailable,
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			got, err := populateBootConfig(test.conf)
+			if status.Code(err) != test.wantCode {
+				t.Fatalf("populateBootConfig() err = %v, want code %v", err, test.wantCode)
+			}
+			if err != nil {
+				return
+			}
+			if gotCLI := len(got.GetVendorConfig()) > 0; gotCLI != test.wantCLI {
+				t.Errorf("populateBootConfig() served vendor config: %v, want %v", gotCLI, test.wantCLI)
+			}
+			if !test.wantSetReq {
+				if len(got.GetGnmiSetRequest()) > 0 {
+					t.Errorf("populateBootConfig() served gNMI SetRequest, want none")
+				}
+				return
+			}
+			setReq := &gpb.SetRequest{}
+			if err := proto.Unmarshal(got.GetGnmiSetRequest(), setReq); err != nil {
+				t.Fatalf("unable to unmarshal served gNMI SetRequest: %v", err)
+			}
+			if len(setReq.GetUpdate()) != 1 {
+				t.Errorf("populateBootConfig() served gNMI SetRequest %v, want 1 update", setReq)
+			}
+		})
+	}
+}
+
+func TestExperiment(t *testing.T) {
+	em, err := New("../../testdata/inventory.prototxt")
 	if err != nil {
 		t.Fatalf("unable to create entitymanager: %v", err)
 	}
-	em.secArtifacts = &service.SecurityArtifacts{
-		OC: &service.KeyPair{
-			PrivateKey: "FakeOCPrivateKey",
-			Cert:       "FakeOCCert",
-		},
+	image := &bpb.SoftwareImage{Name: "New Image", Version: "2.0"}
+	if err := em.Experiments().Load([]*epb.Experiment{{
+		Name:                "new-image",
+		Devices:             []*epb.AllowedDevice{{Manufacturer: "Cisco", PartNumber: "*"}},
+		TreatmentPercentage: 100,
+		SoftwareImage:       image,
+	}}); err != nil {
+		t.Fatalf("Load() err = %v, want nil", err)
 	}
-	em.chassisInventory[service.EntityLookup{Manufacturer: "Cisco", SerialNumber: "123"}] = &chassis
+	lookup := &service.EntityLookup{Manufacturer: "Cisco", SerialNumber: "123"}
+	em.chassisInventory[*lookup].GetConfig().GetBootConfig().VendorConfigFile = "../../testdata/cisco.cfg"
+	resp, err := em.GetBootstrapData(context.Background(), lookup, &bpb.ControlCard{SerialNumber: "123A", PartNumber: "123A"})
+	if err != nil {
+		t.Fatalf("GetBootstrapData() err = %v, want nil", err)
+	}
+	if !proto.Equal(resp.GetIntendedImage(), image) {
+		t.Errorf("GetBootstrapData() image = %v, want %v", resp.GetIntendedImage(), image)
+	}
+	if err := em.SetStatus(context.Background(), &bpb.ReportStatusRequest{
+		Status: bpb.ReportStatusRequest_BOOTSTRAP_STATUS_SUCCESS,
+		States: []*bpb.ControlCardState{{SerialNumber: "123A"}},
+	}); err != nil {
+		t.Fatalf("SetStatus() err = %v, want nil", err)
+	}
+	if got := em.Experiments().Results()[0].Treatment; got.Assigned != 1 || got.Succeeded != 1 {
+		t.Errorf("Results() treatment arm = %+v, want a single success", got)
+	}
+}
 
+func TestLookupChassis(t *testing.T) {
+	em, err := New("")
+	if err != nil {
+		t.Fatalf("unable to create entitymanager: %v", err)
+	}
+	for _, ch := range []*epb.Chassis{
+		{Name: "cisco-123", Manufacturer: "Cisco", PartNumber: "8808", SerialNumber: "123"},
+		{Name: "arista-123", Manufacturer: "Arista", PartNumber: "7280", SerialNumber: "123"},
+		{Name: "cisco-8808", Manufacturer: "Cisco", PartNumber: "8808", SerialNumber: "*"},
+		{Name: "cisco-8201", Manufacturer: "Cisco", PartNumber: "8201", SerialNumber: "*"},
+		{Name: "cisco-any", Manufacturer: "Cisco", PartNumber: "*", SerialNumber: "*"},
+		{Name: "any-456", Manufacturer: "*", SerialNumber: "456"},
+	} {
+		em.chassisInventory[inventoryKey(ch)] = ch
+	}
+	tests := []struct {
+		desc     string
+		lookup   service.EntityLookup
+		wantName string
+	}{{
+		desc:     "Exact match",
+		lookup:   service.EntityLookup{Manufacturer: "Cisco", PartNumber: "8808", SerialNumber: "123"},
+		wantName: "cisco-123",
+	}, {
+		desc:     "Same serial from another vendor",
+		lookup:   service.EntityLookup{Manufacturer: "Arista", PartNumber: "7280", SerialNumber: "123"},
+		wantName: "arista-123",
+	}, {
+		desc:     "Exact match without part number",
+		lookup:   service.EntityLookup{Manufacturer: "Cisco", SerialNumber: "123"},
+		wantName: "cisco-123",
+	}, {
+		desc:     "Part number profile",
+		lookup:   service.EntityLookup{Manufacturer: "Cisco", PartNumber: "8201", SerialNumber: "789"},
+		wantName: "cisco-8201",
+	}, {
+		desc:     "Part number mismatch falls back to profile",
+		lookup:   service.EntityLookup{Manufacturer: "Cisco", PartNumber: "8201", SerialNumber: "123"},
+		wantName: "cisco-8201",
+	}, {
+		desc:     "Manufacturer profile",
+		lookup:   service.EntityLookup{Manufacturer: "Cisco", PartNumber: "8000", SerialNumber: "789"},
+		wantName: "cisco-any",
+	}, {
+		desc:     "Serial from any manufacturer",
+		lookup:   service.EntityLookup{Manufacturer: "Juniper", PartNumber: "PTX", SerialNumber: "456"},
+		wantName: "any-456",
+	}, {
+		desc:     "Serial takes precedence over part number",
+		lookup:   service.EntityLookup{Manufacturer: "Cisco", PartNumber: "8808", SerialNumber: "456"},
+		wantName: "any-456",
+	}, {
+		desc:   "No match",
+		lookup: service.EntityLookup{Manufacturer: "Juniper", PartNumber: "PTX", SerialNumber: "789"},
+	}, {
+		desc:   "Wildcards do not match chassis without serial",
+		lookup: service.EntityLookup{Manufacturer: "Cisco", PartNumber: "8808"},
+	}}
 	for _, test := range tests {
 		t.Run(test.desc, func(t *testing.T) {
-			got, err := em.GetBootstrapData(&service.EntityLookup{SerialNumber: test.chassisSerial, Manufacturer: test.chassisManufacturer}, test.input)
-			if (err != nil) != test.wantErr {
-				t.Errorf("GetBootstrapData(%v) err = %v, want %v", test.input, err, test.wantErr)
+			got, found := em.lookupChassis(&test.lookup)
+			if found != (test.wantName != "") {
+				t.Fatalf("lookupChassis(%+v) found = %v, want %v", test.lookup, found, test.wantName != "")
 			}
-			if !proto.Equal(got, test.want) {
-				t.Errorf("GetBootstrapData(%v) \n got: %v, \n want: %v", test.input, got, test.want)
+			if got.GetName() != test.wantName {
+				t.Errorf("lookupChassis(%+v) = %q, want %q", test.lookup, got.GetName(), test.wantName)
 			}
 		})
 	}
@@ -878,3 +2616,718 @@ func TestDeleteDevice(t *testing.T) {
 		})
 	}
 }
+
+// TestConcurrentAccess exercises the bootstrap and inventory management paths concurrently.
+// Run it with the race detector to check the locking of the entity manager.
+func TestConcurrentAccess(t *testing.T) {
+	em, err := New("../../testdata/inventory.prototxt")
+	if err != nil {
+		t.Fatalf("New() err = %v, want nil", err)
+	}
+	lookup := &service.EntityLookup{Manufacturer: "Cisco", SerialNumber: "123"}
+	cc := &bpb.ControlCard{SerialNumber: "123A", PartNumber: "123A"}
+	const workers, iterations = 8, 20
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				if _, err := em.ResolveChassis(context.Background(), lookup, cc.GetSerialNumber()); err != nil {
+					t.Errorf("ResolveChassis() err = %v, want nil", err)
+				}
+				if err := em.ValidateControlCards(context.Background(), lookup, []*bpb.ControlCard{cc}); err != nil {
+					t.Errorf("ValidateControlCards() err = %v, want nil", err)
+				}
+				if _, err := em.GetBootstrapData(context.Background(), lookup, cc); err != nil {
+					t.Errorf("GetBootstrapData() err = %v, want nil", err)
+				}
+				if _, err := em.OwnershipVoucher(context.Background(), lookup, cc.GetSerialNumber()); err != nil {
+					t.Errorf("OwnershipVoucher() err = %v, want nil", err)
+				}
+				err := em.SetStatus(context.Background(), &bpb.ReportStatusRequest{
+					Status: bpb.ReportStatusRequest_BOOTSTRAP_STATUS_SUCCESS,
+					States: []*bpb.ControlCardState{{SerialNumber: cc.GetSerialNumber(), Status: bpb.ControlCardState_CONTROL_CARD_STATUS_INITIALIZED}},
+				})
+				if err != nil {
+					t.Errorf("SetStatus() err = %v, want nil", err)
+				}
+			}
+		}()
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				serial := fmt.Sprintf("%d-%d", w, i)
+				l := &service.EntityLookup{Manufacturer: "Cisco", SerialNumber: serial}
+				em.AddChassis(bpb.BootMode_BOOT_MODE_INSECURE, "Cisco", serial)
+				if _, err := em.GetDevice(l); err != nil {
+					t.Errorf("GetDevice() err = %v, want nil", err)
+				}
+				replaced := &epb.Chassis{Manufacturer: "Cisco", SerialNumber: serial + "-new"}
+				if err := em.ReplaceDevice(l, replaced); err != nil {
+					t.Errorf("ReplaceDevice() err = %v, want nil", err)
+				}
+				em.GetAll()
+				em.GetChassisInventory()
+				em.DeleteDevice(&service.EntityLookup{Manufacturer: "Cisco", SerialNumber: replaced.GetSerialNumber()})
+			}
+		}(w)
+	}
+	wg.Wait()
+	if got, want := len(em.GetAll()), 1; got != want {
+		t.Errorf("GetAll() returned %d chassis after the concurrent updates, want %d", got, want)
+	}
+}
+
+func TestReadKeypair(t *testing.T) {
+	fsys := fstest.MapFS{
+		"oc_pub.pem":  {Data: []byte("cert")},
+		"oc_priv.pem": {Data: []byte("key")},
+		"pdc_pub.pem": {Data: []byte("cert")},
+	}
+	tests := []struct {
+		desc       string
+		name       string
+		keyURI     string
+		want       *service.KeyPair
+		wantSigner bool
+		wantErr    string
+	}{{
+		desc: "Success",
+		name: "oc",
+		want: &service.KeyPair{Cert: "cert", PrivateKey: "key"},
+	}, {
+		desc:       "Key in key store",
+		name:       "pdc",
+		keyURI:     "file:../../testdata/pdc_priv.pem",
+		wantSigner: true,
+	}, {
+		desc:    "Unknown key store",
+		name:    "oc",
+		keyURI:  "hsm://slot/0",
+		wantErr: "unable to open oc key",
+	}, {
+		desc:    "Missing key",
+		name:    "pdc",
+		wantErr: "unable to read pdc key",
+	}, {
+		desc:    "Missing cert",
+		name:    "vendorca",
+		wantErr: "unable to read vendorca cert",
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			got, err := readKeypair(fsys, test.name, test.keyURI)
+			if s := errdiff.Substring(err, test.wantErr); s != "" {
+				t.Fatalf("readKeypair() %s", s)
+			}
+			if test.wantSigner {
+				if got.Signer == nil || got.PrivateKey != "" {
+					t.Errorf("readKeypair() = %+v, want a key pair with a signer and no private key", got)
+				}
+				return
+			}
+			if !cmp.Equal(got, test.want) {
+				t.Errorf("readKeypair() = %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
+
+// newRotationArtifacts writes a new PDC, an OC issued by it and vouchers pinning the new PDC for
+// the given control cards to dir. It returns the vouchers by control card serial.
+func newRotationArtifacts(t *testing.T, dir string, serials ...string) map[string]string {
+	t.Helper()
+	newKeyPair := func(name string, parent *x509.Certificate, parentKey *rsa.PrivateKey) (*x509.Certificate, *rsa.PrivateKey) {
+		// Responses are signed with RSA keys only.
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			t.Fatalf("unable to generate %s key: %v", name, err)
+		}
+		tmpl := &x509.Certificate{
+			SerialNumber:          big.NewInt(time.Now().UnixNano()),
+			Subject:               pkix.Name{CommonName: "Rotated " + name},
+			NotBefore:             time.Now().Add(-time.Hour),
+			NotAfter:              time.Now().Add(time.Hour),
+			IsCA:                  parent == nil,
+			BasicConstraintsValid: true,
+			KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+			ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		}
+		if parent == nil {
+			parent, parentKey = tmpl, key
+		}
+		der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, key.Public(), parentKey)
+		if err != nil {
+			t.Fatalf("unable to create %s certificate: %v", name, err)
+		}
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			t.Fatalf("unable to parse %s certificate: %v", name, err)
+		}
+		lower := map[string]string{"PDC": "pdc", "OC": "oc"}[name]
+		if err := os.WriteFile(filepath.Join(dir, lower+"_pub.pem"), pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600); err != nil {
+			t.Fatalf("unable to write %s certificate: %v", name, err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, lower+"_priv.pem"), pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}), 0600); err != nil {
+			t.Fatalf("unable to write %s key: %v", name, err)
+		}
+		return cert, key
+	}
+	pdc, pdcKey := newKeyPair("PDC", nil, nil)
+	newKeyPair("OC", pdc, pdcKey)
+
+	vendorCA, err := os.ReadFile("../../testdata/vendorca_pub.pem")
+	if err != nil {
+		t.Fatalf("unable to read vendor CA: %v", err)
+	}
+	vendorCAKey, err := os.ReadFile("../../testdata/vendorca_priv.pem")
+	if err != nil {
+		t.Fatalf("unable to read vendor CA key: %v", err)
+	}
+	caBlock, _ := pem.Decode(vendorCA)
+	caCert, err := x509.ParseCertificate(caBlock.Bytes)
+	if err != nil {
+		t.Fatalf("unable to parse vendor CA: %v", err)
+	}
+	keyBlock, _ := pem.Decode(vendorCAKey)
+	caKey, err := x509.ParsePKCS1PrivateKey(keyBlock.Bytes)
+	if err != nil {
+		t.Fatalf("unable to parse vendor CA key: %v", err)
+	}
+	pdcPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: pdc.Raw})
+	ovs := map[string]string{}
+	for _, serial := range serials {
+		ov, err := ownershipvoucher.New(serial, pdcPEM, caCert, caKey)
+		if err != nil {
+			t.Fatalf("unable to create ownership voucher for %s: %v", serial, err)
+		}
+		ovs[serial] = base64.StdEncoding.EncodeToString(ov)
+	}
+	return ovs
+}
+
+func TestOwnershipRotation(t *testing.T) {
+	dir := t.TempDir()
+	ovs := newRotationArtifacts(t, dir, "123A", "123B")
+	inventory, err := os.ReadFile("../../testdata/inventory.prototxt")
+	if err != nil {
+		t.Fatalf("unable to read inventory: %v", err)
+	}
+	newInventory := func(name string, transitionEnd time.Time, rotated ...string) string {
+		inv := strings.Replace(string(inventory), `artifact_dir: "../../testdata/"`, fmt.Sprintf(`artifact_dir: "../../testdata/"
+    pdc_verification: PDC_VERIFICATION_EXACT
+    ownership_rotation {
+        artifact_dir: %q
+        transition_end { seconds: %d }
+    }`, dir, transitionEnd.Unix()), 1)
+		for _, serial := range rotated {
+			inv = strings.Replace(inv, fmt.Sprintf(`part_number: "%s"`, serial), fmt.Sprintf(`part_number: "%s"
+        rotated_ownership_voucher: %q`, serial, ovs[serial]), 1)
+		}
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(inv), 0600); err != nil {
+			t.Fatalf("unable to write inventory: %v", err)
+		}
+		return path
+	}
+	oldOC, err := os.ReadFile("../../testdata/oc_pub.pem")
+	if err != nil {
+		t.Fatalf("unable to read OC: %v", err)
+	}
+	newOC, err := os.ReadFile(filepath.Join(dir, "oc_pub.pem"))
+	if err != nil {
+		t.Fatalf("unable to read rotated OC: %v", err)
+	}
+
+	tests := []struct {
+		desc          string
+		inventory     string
+		wantOC        []byte
+		wantPrevious  bool
+		wantTrustCert string
+		wantCode      codes.Code
+	}{{
+		desc:          "Rotated chassis during transition",
+		inventory:     newInventory("rotated.prototxt", time.Now().Add(time.Hour), "123A", "123B"),
+		wantOC:        newOC,
+		wantPrevious:  true,
+		wantTrustCert: string(newOC) + string(oldOC),
+	}, {
+		desc:          "Chassis not rotated during transition",
+		inventory:     newInventory("not_rotated.prototxt", time.Now().Add(time.Hour)),
+		wantOC:        oldOC,
+		wantTrustCert: string(newOC) + string(oldOC),
+	}, {
+		desc:          "Partially rotated chassis during transition",
+		inventory:     newInventory("partially_rotated.prototxt", time.Now().Add(time.Hour), "123A"),
+		wantOC:        oldOC,
+		wantTrustCert: string(newOC) + string(oldOC),
+	}, {
+		desc:          "Rotated chassis after transition",
+		inventory:     newInventory("rotated_ended.prototxt", time.Now().Add(-time.Hour), "123A", "123B"),
+		wantOC:        newOC,
+		wantTrustCert: string(newOC),
+	}, {
+		desc:      "Chassis not rotated after transition",
+		inventory: newInventory("not_rotated_ended.prototxt", time.Now().Add(-time.Hour)),
+		wantCode:  codes.FailedPrecondition,
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			em, err := New(test.inventory)
+			if err != nil {
+				t.Fatalf("New() err = %v, want nil", err)
+			}
+			if got := em.serverTrustCert(nil); test.wantTrustCert != "" && got != test.wantTrustCert {
+				t.Errorf("serverTrustCert() = %v, want %v", got, test.wantTrustCert)
+			}
+			resp := &bpb.GetBootstrapDataResponse{
+				SerializedBootstrapData: MustMarshalBootstrapDataSigned(t, &bpb.BootstrapDataSigned{
+					Responses: []*bpb.BootstrapDataResponse{{SerialNum: "123A"}},
+				}),
+			}
+			err = em.Sign(context.Background(), resp, &service.EntityLookup{Manufacturer: "Cisco", SerialNumber: "123"}, "123A", nil)
+			if got := status.Code(err); got != test.wantCode {
+				t.Fatalf("Sign() err = %v, want code %v", err, test.wantCode)
+			}
+			if err != nil {
+				return
+			}
+			if got := resp.GetOwnershipCertificate(); string(got) != string(test.wantOC) {
+				t.Errorf("Sign() oc = %s, want %s", got, test.wantOC)
+			}
+			verify := func(oc []byte, sig string) {
+				t.Helper()
+				block, _ := pem.Decode(oc)
+				if block == nil {
+					t.Fatalf("unable to decode OC %s", oc)
+				}
+				cert, err := x509.ParseCertificate(block.Bytes)
+				if err != nil {
+					t.Fatalf("unable to parse OC: %v", err)
+				}
+				if err := signature.Verify(cert, resp.GetSerializedBootstrapData(), sig); err != nil {
+					t.Errorf("Verify() err = %v, want nil", err)
+				}
+			}
+			verify(resp.GetOwnershipCertificate(), resp.GetResponseSignature())
+			if got := len(resp.GetPreviousOwnershipCertificate()) > 0; got != test.wantPrevious {
+				t.Fatalf("Sign() previous oc set = %v, want %v", got, test.wantPrevious)
+			}
+			if test.wantPrevious {
+				if got := resp.GetPreviousOwnershipCertificate(); string(got) != string(oldOC) {
+					t.Errorf("Sign() previous oc = %s, want %s", got, oldOC)
+				}
+				verify(resp.GetPreviousOwnershipCertificate(), resp.GetPreviousResponseSignature())
+			}
+		})
+	}
+}
+
+func TestRedirect(t *testing.T) {
+	em, err := New("../../testdata/inventory.prototxt")
+	if err != nil {
+		t.Fatalf("New() err = %v, want nil", err)
+	}
+	s := service.New(em)
+	regional := []*bpb.RedirectServer{{Address: "bootz.eu.example.com:15006"}, {Address: "10.2.0.1:15006"}}
+	if err := s.SetRedirects([]*epb.RedirectRule{{
+		SourceSubnets:  []string{"10.1.0.0/16"},
+		SerialPrefixes: []string{"12"},
+		Servers:        regional,
+	}}); err != nil {
+		t.Fatalf("SetRedirects() err = %v, want nil", err)
+	}
+	desc := &bpb.ChassisDescriptor{
+		Manufacturer: "Cisco",
+		SerialNumber: "123",
+		ControlCards: []*bpb.ControlCard{{SerialNumber: "123A", PartNumber: "123A"}, {SerialNumber: "123B", PartNumber: "123B"}},
+	}
+	from := func(ip string) context.Context {
+		return peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(ip), Port: 12345}})
+	}
+	tests := []struct {
+		desc         string
+		ctx          context.Context
+		nonce        string
+		wantRedirect bool
+	}{{
+		desc:         "Matching device",
+		ctx:          from("10.1.2.3"),
+		wantRedirect: true,
+	}, {
+		desc:         "Signed redirect",
+		ctx:          from("10.1.2.3"),
+		nonce:        "nonce",
+		wantRedirect: true,
+	}, {
+		desc: "Source outside the subnets",
+		ctx:  from("10.2.2.3"),
+	}, {
+		desc: "Unknown source",
+		ctx:  context.Background(),
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			resp, err := s.GetBootstrapData(test.ctx, &bpb.GetBootstrapDataRequest{
+				ChassisDescriptor: desc,
+				ControlCardState:  &bpb.ControlCardState{SerialNumber: "123A"},
+				Nonce:             test.nonce,
+			})
+			if err != nil {
+				t.Fatalf("GetBootstrapData() err = %v, want nil", err)
+			}
+			signed := &bpb.BootstrapDataSigned{}
+			if err := proto.Unmarshal(resp.GetSerializedBootstrapData(), signed); err != nil {
+				t.Fatalf("unable to parse serialized bootstrap data: %v", err)
+			}
+			if !test.wantRedirect {
+				if signed.GetRedirect() != nil || len(signed.GetResponses()) != 2 {
+					t.Errorf("GetBootstrapData() = %v, want bootstrap data for 123A and 123B", signed)
+				}
+				return
+			}
+			if len(signed.GetResponses()) != 0 {
+				t.Errorf("GetBootstrapData() responses = %v, want none", signed.GetResponses())
+			}
+			if diff := cmp.Diff(regional, signed.GetRedirect().GetServers(), protocmp.Transform()); diff != "" {
+				t.Errorf("GetBootstrapData() redirect servers diff (-want, +got):\n%s", diff)
+			}
+			if test.nonce != "" && (resp.GetResponseSignature() == "" || signed.GetNonce() != test.nonce) {
+				t.Errorf("GetBootstrapData() redirect is not signed with the nonce")
+			}
+		})
+	}
+
+	if err := s.SetRedirects([]*epb.RedirectRule{{Servers: regional}}); err == nil {
+		t.Errorf("SetRedirects() of a rule without criteria err = nil, want error")
+	}
+	if err := s.SetRedirects([]*epb.RedirectRule{{SerialPrefixes: []string{"12"}, Servers: []*bpb.RedirectServer{{Address: "no-port"}}}}); err == nil {
+		t.Errorf("SetRedirects() of a server without port err = nil, want error")
+	}
+}
+
+func TestImportChassis(t *testing.T) {
+	em, err := New("../../testdata/inventory.prototxt")
+	if err != nil {
+		t.Fatalf("New() err = %v, want nil", err)
+	}
+	fileChassis, err := em.GetDevice(&service.EntityLookup{Manufacturer: "Cisco", SerialNumber: "123"})
+	if err != nil {
+		t.Fatalf("GetDevice() err = %v, want nil", err)
+	}
+	imported := []*epb.Chassis{
+		{Manufacturer: "Cisco", SerialNumber: "123", Name: "imported"},
+		{Manufacturer: "Arista", SerialNumber: "NB1", BootMode: bpb.BootMode_BOOT_MODE_INSECURE},
+		{Manufacturer: "Arista", SerialNumber: "NB2", BootMode: bpb.BootMode_BOOT_MODE_SECURE},
+	}
+	if err := em.ImportChassis(imported); err == nil {
+		t.Errorf("ImportChassis() err = nil, want error for the secure chassis without voucher")
+	}
+	got, err := em.GetDevice(&service.EntityLookup{Manufacturer: "Cisco", SerialNumber: "123"})
+	if err != nil || got.GetName() != fileChassis.GetName() {
+		t.Errorf("GetDevice() of a chassis of the inventory file = %v, %v, want the inventory file entry", got, err)
+	}
+	if _, err := em.GetDevice(&service.EntityLookup{Manufacturer: "Arista", SerialNumber: "NB1"}); err != nil {
+		t.Errorf("GetDevice() of an imported chassis err = %v, want nil", err)
+	}
+	if _, err := em.GetDevice(&service.EntityLookup{Manufacturer: "Arista", SerialNumber: "NB2"}); err == nil {
+		t.Errorf("GetDevice() of an invalid imported chassis err = nil, want error")
+	}
+
+	// A new import replaces the previous one.
+	if err := em.ImportChassis([]*epb.Chassis{{Manufacturer: "Arista", SerialNumber: "NB3", BootMode: bpb.BootMode_BOOT_MODE_INSECURE}}); err != nil {
+		t.Fatalf("ImportChassis() err = %v, want nil", err)
+	}
+	if _, err := em.GetDevice(&service.EntityLookup{Manufacturer: "Arista", SerialNumber: "NB1"}); err == nil {
+		t.Errorf("GetDevice() of a chassis missing from the last import err = nil, want error")
+	}
+	if _, err := em.GetDevice(&service.EntityLookup{Manufacturer: "Arista", SerialNumber: "NB3"}); err != nil {
+		t.Errorf("GetDevice() of a newly imported chassis err = %v, want nil", err)
+	}
+	if _, err := em.GetDevice(&service.EntityLookup{Manufacturer: "Cisco", SerialNumber: "123"}); err != nil {
+		t.Errorf("GetDevice() of a chassis of the inventory file err = %v, want nil", err)
+	}
+}
+
+func TestOwnershipDomains(t *testing.T) {
+	dir := t.TempDir()
+	ovs := newRotationArtifacts(t, dir, "123A", "123B")
+	inventory, err := os.ReadFile("../../testdata/inventory.prototxt")
+	if err != nil {
+		t.Fatalf("unable to read inventory: %v", err)
+	}
+	newInventory := func(name, tenant string, domainVouchers bool) string {
+		inv := strings.Replace(string(inventory), `artifact_dir: "../../testdata/"`, fmt.Sprintf(`artifact_dir: "../../testdata/"
+    pdc_verification: PDC_VERIFICATION_EXACT
+    admin_identities: "netops"
+    domains {
+        name: "blue"
+        artifact_dir: %q
+        admin_identities: "blue-admin"
+    }`, dir), 1)
+		inv = strings.Replace(inv, `manufacturer: "Cisco"`, fmt.Sprintf(`manufacturer: "Cisco"
+    tenant: %q`, tenant), 1)
+		if domainVouchers {
+			for _, serial := range []string{"123A", "123B"} {
+				start := strings.Index(inv, fmt.Sprintf(`serial_number: "%s"`, serial))
+				ov := strings.Index(inv[start:], "ownership_voucher") + start
+				open := strings.Index(inv[ov:], `"`) + ov
+				end := strings.Index(inv[open+1:], `"`) + open + 1
+				inv = inv[:open] + fmt.Sprintf("%q", ovs[serial]) + inv[end+1:]
+			}
+		}
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(inv), 0600); err != nil {
+			t.Fatalf("unable to write inventory: %v", err)
+		}
+		return path
+	}
+	defaultOC, err := os.ReadFile("../../testdata/oc_pub.pem")
+	if err != nil {
+		t.Fatalf("unable to read OC: %v", err)
+	}
+	domainOC, err := os.ReadFile(filepath.Join(dir, "oc_pub.pem"))
+	if err != nil {
+		t.Fatalf("unable to read domain OC: %v", err)
+	}
+
+	tests := []struct {
+		desc       string
+		inventory  string
+		wantOC     []byte
+		wantDomain string
+		wantErr    string
+	}{{
+		desc:       "Chassis of a domain",
+		inventory:  newInventory("domain.prototxt", "blue", true),
+		wantOC:     domainOC,
+		wantDomain: "blue",
+	}, {
+		desc:      "Chassis of no domain",
+		inventory: newInventory("no_domain.prototxt", "red", false),
+		wantOC:    defaultOC,
+	}, {
+		desc:      "Chassis of a domain with vouchers of another domain",
+		inventory: newInventory("cross_domain.prototxt", "blue", false),
+		wantErr:   "does not pin the PDC",
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			em, err := New(test.inventory)
+			if s := errdiff.Substring(err, test.wantErr); s != "" {
+				t.Fatalf("New() %s", s)
+			}
+			if err != nil {
+				return
+			}
+			lookup := &service.EntityLookup{Manufacturer: "Cisco", SerialNumber: "123"}
+			resp := &bpb.GetBootstrapDataResponse{
+				SerializedBootstrapData: MustMarshalBootstrapDataSigned(t, &bpb.BootstrapDataSigned{
+					Responses: []*bpb.BootstrapDataResponse{{SerialNum: "123A"}},
+				}),
+			}
+			if err := em.Sign(context.Background(), resp, lookup, "123A", nil); err != nil {
+				t.Fatalf("Sign() err = %v, want nil", err)
+			}
+			if got := resp.GetOwnershipCertificate(); string(got) != string(test.wantOC) {
+				t.Errorf("Sign() oc = %s, want %s", got, test.wantOC)
+			}
+			data, err := em.GetBootstrapData(context.Background(), lookup, &bpb.ControlCard{SerialNumber: "123A", PartNumber: "123A"})
+			if err != nil {
+				t.Fatalf("GetBootstrapData() err = %v, want nil", err)
+			}
+			if got := data.GetServerTrustCert(); got != string(test.wantOC) {
+				t.Errorf("GetBootstrapData() server trust cert = %s, want %s", got, test.wantOC)
+			}
+			for _, serial := range []string{"123", "123B"} {
+				if got := em.Domain(serial); got != test.wantDomain {
+					t.Errorf("Domain(%q) = %q, want %q", serial, got, test.wantDomain)
+				}
+			}
+			if got, want := em.DomainAdmins(), map[string]string{"netops": "", "blue-admin": "blue"}; !cmp.Equal(got, want) {
+				t.Errorf("DomainAdmins() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	em, err := New("../../testdata/inventory.prototxt")
+	if err != nil {
+		t.Fatalf("New() err = %v, want nil", err)
+	}
+	s := service.New(em)
+	s.SetDryRun(true)
+	resp, err := s.GetBootstrapData(context.Background(), &bpb.GetBootstrapDataRequest{
+		ChassisDescriptor: &bpb.ChassisDescriptor{
+			Manufacturer: "Cisco",
+			SerialNumber: "123",
+			ControlCards: []*bpb.ControlCard{{SerialNumber: "123A", PartNumber: "123A"}, {SerialNumber: "123B", PartNumber: "123B"}},
+		},
+		ControlCardState: &bpb.ControlCardState{SerialNumber: "123A"},
+		Nonce:            "nonce",
+	})
+	if err != nil {
+		t.Fatalf("GetBootstrapData() err = %v, want nil", err)
+	}
+	if resp.GetResponseSignature() == "" || len(resp.GetOwnershipVoucher()) == 0 {
+		t.Errorf("GetBootstrapData() in dry run is not signed")
+	}
+	signed := &bpb.BootstrapDataSigned{}
+	if err := proto.Unmarshal(resp.GetSerializedBootstrapData(), signed); err != nil {
+		t.Fatalf("unable to parse serialized bootstrap data: %v", err)
+	}
+	if len(signed.GetResponses()) != 2 {
+		t.Fatalf("GetBootstrapData() = %v, want bootstrap data for 123A and 123B", signed)
+	}
+	for _, r := range signed.GetResponses() {
+		if len(r.GetOwnershipVoucher()) == 0 {
+			t.Errorf("bootstrap data of %v has no ownership voucher", r.GetSerialNum())
+		}
+		if got := r.GetBootPasswordHash(); got != "bootz-dry-run" {
+			t.Errorf("bootstrap data of %v boot password hash = %q, want placeholder", r.GetSerialNum(), got)
+		}
+		if got := r.GetIntendedImage(); got.GetUrl() != "" || got.GetOsImageHash() != "" {
+			t.Errorf("bootstrap data of %v intended image = %v, want placeholder", r.GetSerialNum(), got)
+		}
+		if got := r.GetBootConfig().GetMetadata().GetFields()["dry_run"].GetBoolValue(); !got {
+			t.Errorf("bootstrap data of %v boot config = %v, want dry run metadata", r.GetSerialNum(), r.GetBootConfig())
+		}
+		if got := r.GetCredentials(); len(got.GetPasswords()) != 0 || len(got.GetCredentials()) != 0 {
+			t.Errorf("bootstrap data of %v credentials = %v, want none", r.GetSerialNum(), got)
+		}
+	}
+}
+
+// benchmarkEntityManager returns the entity manager of the testdata inventory, serving a vendor
+// configuration of size bytes to chassis 123 and caching responses if cached is set.
+func benchmarkEntityManager(b *testing.B, size int, cached bool) *InMemoryEntityManager {
+	b.Helper()
+	em, err := New("../../testdata/inventory.prototxt")
+	if err != nil {
+		b.Fatalf("New() err = %v", err)
+	}
+	path := filepath.Join(b.TempDir(), "vendor.cfg")
+	if err := os.WriteFile(path, []byte(strings.Repeat("x", size)), 0600); err != nil {
+		b.Fatalf("os.WriteFile() err = %v", err)
+	}
+	lookup := service.EntityLookup{Manufacturer: "Cisco", SerialNumber: "123"}
+	ch := proto.Clone(em.chassisInventory[lookup]).(*epb.Chassis)
+	ch.GetConfig().GetBootConfig().VendorConfigFile = path
+	em.chassisInventory[lookup] = ch
+	if cached {
+		em.cache = newResponseCache(&epb.ResponseCache{Size: 100})
+	}
+	return em
+}
+
+// benchmarkOC returns a self-signed owner certificate of the key.
+func benchmarkOC(b *testing.B, key crypto.Signer) *service.KeyPair {
+	b.Helper()
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "Benchmark OC"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, key.Public(), key)
+	if err != nil {
+		b.Fatalf("x509.CreateCertificate() err = %v", err)
+	}
+	return &service.KeyPair{
+		Cert:   string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		Signer: key,
+	}
+}
+
+// benchmarkConfigSizes are the sizes of the vendor configurations served in the benchmarks.
+var benchmarkConfigSizes = []int{1 << 10, 64 << 10, 1 << 20}
+
+// benchmarkSigner is an owner certificate signing with an algorithm in the benchmarks.
+type benchmarkSigner struct {
+	desc string
+	oc   *service.KeyPair
+	alg  bpb.SignatureAlgorithm
+}
+
+func BenchmarkSign(b *testing.B) {
+	var ocs []benchmarkSigner
+	addOC := func(desc string, key crypto.Signer, err error, algs ...bpb.SignatureAlgorithm) {
+		if err != nil {
+			b.Fatalf("unable to generate %s key: %v", desc, err)
+		}
+		oc := benchmarkOC(b, key)
+		for _, alg := range algs {
+			ocs = append(ocs, benchmarkSigner{
+				desc: desc + "/" + strings.TrimPrefix(alg.String(), "SIGNATURE_ALGORITHM_"),
+				oc:   oc,
+				alg:  alg,
+			})
+		}
+	}
+	for _, bits := range []int{2048, 3072, 4096} {
+		key, err := rsa.GenerateKey(rand.Reader, bits)
+		addOC(fmt.Sprintf("RSA%d", bits), key, err, bpb.SignatureAlgorithm_SIGNATURE_ALGORITHM_RSA_PKCS1V15_SHA256, bpb.SignatureAlgorithm_SIGNATURE_ALGORITHM_CMS_DETACHED)
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	addOC("ECDSA_P-256", key, err, bpb.SignatureAlgorithm_SIGNATURE_ALGORITHM_ECDSA_SHA256)
+
+	lookup := &service.EntityLookup{Manufacturer: "Cisco", SerialNumber: "123"}
+	for _, size := range benchmarkConfigSizes {
+		data, err := proto.Marshal(&bpb.BootstrapDataSigned{
+			Responses: []*bpb.BootstrapDataResponse{{
+				SerialNum:  "123A",
+				BootConfig: &bpb.BootConfig{VendorConfig: []byte(strings.Repeat("x", size))},
+			}},
+			Nonce: "nonce",
+		})
+		if err != nil {
+			b.Fatalf("proto.Marshal() err = %v", err)
+		}
+		for _, cached := range []bool{false, true} {
+			em := benchmarkEntityManager(b, size, cached)
+			for _, oc := range ocs {
+				em.secArtifacts.OC = oc.oc
+				accepted := []bpb.SignatureAlgorithm{oc.alg}
+				b.Run(fmt.Sprintf("%s/%dKiB/cached=%v", oc.desc, size>>10, cached), func(b *testing.B) {
+					b.SetBytes(int64(len(data)))
+					for i := 0; i < b.N; i++ {
+						resp := &bpb.GetBootstrapDataResponse{SerializedBootstrapData: data}
+						if err := em.Sign(context.Background(), resp, lookup, "123A", accepted); err != nil {
+							b.Fatalf("Sign() err = %v", err)
+						}
+					}
+				})
+			}
+		}
+	}
+}
+
+func BenchmarkGetBootstrapData(b *testing.B) {
+	req := &bpb.GetBootstrapDataRequest{
+		ChassisDescriptor: &bpb.ChassisDescriptor{
+			Manufacturer: "Cisco",
+			SerialNumber: "123",
+			ControlCards: []*bpb.ControlCard{
+				{SerialNumber: "123A", PartNumber: "123A"},
+				{SerialNumber: "123B", PartNumber: "123B"},
+			},
+		},
+		ControlCardState: &bpb.ControlCardState{SerialNumber: "123A"},
+		Nonce:            "nonce",
+	}
+	for _, size := range benchmarkConfigSizes {
+		for _, cached := range []bool{false, true} {
+			s := service.New(benchmarkEntityManager(b, size, cached))
+			b.Run(fmt.Sprintf("%dKiB/cached=%v", size>>10, cached), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					if _, err := s.GetBootstrapData(context.Background(), req); err != nil {
+						b.Fatalf("GetBootstrapData() err = %v", err)
+					}
+				}
+			})
+		}
+	}
+}
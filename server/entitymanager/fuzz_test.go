@@ -0,0 +1,98 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entitymanager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/openconfig/bootz/server/service"
+	"google.golang.org/protobuf/proto"
+
+	bpb "github.com/openconfig/bootz/proto/bootz"
+)
+
+// fuzzService returns a service backed by the inventory of the testdata.
+func fuzzService(f *testing.F) *service.Service {
+	f.Helper()
+	em, err := New("../../testdata/inventory.prototxt")
+	if err != nil {
+		f.Fatalf("New() err = %v, want nil", err)
+	}
+	return service.New(em)
+}
+
+// addSeeds adds the wire encoding of the messages to the corpus.
+func addSeeds(f *testing.F, msgs ...proto.Message) {
+	f.Helper()
+	for _, m := range msgs {
+		b, err := proto.Marshal(m)
+		if err != nil {
+			f.Fatalf("proto.Marshal() err = %v", err)
+		}
+		f.Add(b)
+	}
+}
+
+// FuzzGetBootstrapData checks that the service handles arbitrary bootstrap requests without
+// panicking. Only requests decoding as GetBootstrapDataRequest reach the service, as with gRPC.
+func FuzzGetBootstrapData(f *testing.F) {
+	s := fuzzService(f)
+	desc := &bpb.ChassisDescriptor{
+		Manufacturer: "Cisco",
+		SerialNumber: "123",
+		ControlCards: []*bpb.ControlCard{
+			{SerialNumber: "123A", PartNumber: "123A"},
+			{SerialNumber: "123B", PartNumber: "123B"},
+		},
+	}
+	addSeeds(f,
+		&bpb.GetBootstrapDataRequest{ChassisDescriptor: desc, Nonce: "nonce"},
+		&bpb.GetBootstrapDataRequest{ChassisDescriptor: desc, ControlCardState: &bpb.ControlCardState{SerialNumber: "123A"}},
+		&bpb.GetBootstrapDataRequest{ChassisDescriptor: &bpb.ChassisDescriptor{Manufacturer: "Cisco", SerialNumber: "unknown"}},
+		&bpb.GetBootstrapDataRequest{},
+	)
+	f.Fuzz(func(t *testing.T, in []byte) {
+		req := &bpb.GetBootstrapDataRequest{}
+		if err := proto.Unmarshal(in, req); err != nil {
+			return
+		}
+		s.GetBootstrapData(context.Background(), req)
+	})
+}
+
+// FuzzReportStatus checks that the service handles arbitrary status reports without panicking.
+func FuzzReportStatus(f *testing.F) {
+	s := fuzzService(f)
+	addSeeds(f,
+		&bpb.ReportStatusRequest{
+			Status: bpb.ReportStatusRequest_BOOTSTRAP_STATUS_SUCCESS,
+			States: []*bpb.ControlCardState{
+				{SerialNumber: "123A", Status: bpb.ControlCardState_CONTROL_CARD_STATUS_INITIALIZED},
+				{SerialNumber: "123B", Status: bpb.ControlCardState_CONTROL_CARD_STATUS_INITIALIZED},
+			},
+		},
+		&bpb.ReportStatusRequest{Status: bpb.ReportStatusRequest_BOOTSTRAP_STATUS_FAILURE, StatusMessage: "failure"},
+		&bpb.ReportStatusRequest{StagingBundleId: "unknown"},
+		&bpb.ReportStatusRequest{},
+	)
+	f.Fuzz(func(t *testing.T, in []byte) {
+		req := &bpb.ReportStatusRequest{}
+		if err := proto.Unmarshal(in, req); err != nil {
+			return
+		}
+		s.ReportStatus(context.Background(), req)
+	})
+}
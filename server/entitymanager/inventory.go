@@ -0,0 +1,204 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entitymanager
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	ownershipvoucher "github.com/openconfig/bootz/common/ownership_voucher"
+	bpb "github.com/openconfig/bootz/proto/bootz"
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+	log "github.com/openconfig/bootz/server/logging"
+)
+
+// PutChassis adds a chassis to the inventory, after validating it as the chassis of the
+// inventory file are validated. An existing chassis with the same manufacturer and serial number
+// is only replaced if replace is set.
+func (m *InMemoryEntityManager) PutChassis(ch *epb.Chassis, replace bool) error {
+	ch = proto.Clone(ch).(*epb.Chassis)
+	if ch.GetSerialNumber() == "" || ch.GetManufacturer() == "" {
+		return status.Errorf(codes.InvalidArgument, "chassis must have a serial number and a manufacturer")
+	}
+	if ch.GetBootMode() == bpb.BootMode_BOOT_MODE_UNSPECIFIED {
+		ch.BootMode = m.defaults.GetDefaultBootMode()
+	}
+	if ch.GetBootMode() == bpb.BootMode_BOOT_MODE_SECURE {
+		if err := m.checkSecureBoot(ch); err != nil {
+			return status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+	}
+	if _, err := parseWindows(ch.GetProvisioningWindows()); err != nil {
+		return status.Errorf(codes.InvalidArgument, "chassis with serial# %s: %v", ch.GetSerialNumber(), err)
+	}
+	if !ch.GetArtifactsPending() {
+		if _, err := m.populateArtifacts(ch); err != nil {
+			return status.Errorf(codes.InvalidArgument, "chassis with serial# %s: %v", ch.GetSerialNumber(), err)
+		}
+	}
+
+	key := inventoryKey(ch)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.chassisInventory[key]; ok && !replace {
+		return status.Errorf(codes.AlreadyExists, "chassis with serial# %s and manufacturer %s already exists", key.SerialNumber, key.Manufacturer)
+	}
+	m.chassisInventory[key] = ch
+	// The chassis is no longer replaced by the next import.
+	delete(m.imported, key)
+	m.cache.invalidate()
+	log.Infof("Added chassis with serial# %s and manufacturer %s", key.SerialNumber, key.Manufacturer)
+	return nil
+}
+
+// SetOwnershipVoucher sets the ownership voucher of the control card or fixed chassis with the
+// serial number, or its rotated ownership voucher if rotated is set. The voucher must pin the PDC
+// it is served with.
+func (m *InMemoryEntityManager) SetOwnershipVoucher(serial string, ov []byte, rotated bool) error {
+	if len(ov) == 0 {
+		return status.Errorf(codes.InvalidArgument, "no ownership voucher provided")
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	chassis, card := m.findDevice(serial)
+	if chassis == nil {
+		return status.Errorf(codes.NotFound, "could not find controller card or fixed chassis with serial#: %s", serial)
+	}
+	updated, err := m.withVoucher(chassis, card, ov, rotated)
+	if err != nil {
+		return err
+	}
+	if err := m.storeVoucher(updated, card, ov, rotated); err != nil {
+		return err
+	}
+	m.chassisInventory[inventoryKey(chassis)] = updated
+	log.Infof("Set the ownership voucher of serial# %s (rotated: %v)", serial, rotated)
+	return nil
+}
+
+// findDevice returns the chassis of the control card or fixed chassis with the serial number,
+// and the index of the control card, or -1 for a fixed chassis. It returns a nil chassis if none
+// matches. The caller must hold m.mu for reading.
+func (m *InMemoryEntityManager) findDevice(serial string) (*epb.Chassis, int) {
+	var chassis *epb.Chassis
+	card := -1
+	for _, ch := range m.chassisInventory {
+		for i, c := range ch.GetControllerCards() {
+			if m.serialMatches(c.GetSerialNumber(), c.GetSerialAliases(), serial) {
+				chassis, card = ch, i
+			}
+		}
+		if len(ch.GetControllerCards()) == 0 && m.serialMatches(ch.GetSerialNumber(), ch.GetSerialAliases(), serial) {
+			chassis, card = ch, -1
+		}
+	}
+	return chassis, card
+}
+
+// withVoucher returns a copy of the chassis with the ownership voucher of its control card at
+// index card, or of the fixed chassis if card is negative, set to ov, or its rotated ownership
+// voucher if rotated is set. The voucher must pin the PDC it is served with. The voucher is set
+// in the inventory entry, until storeVoucher moves it to the voucher store.
+func (m *InMemoryEntityManager) withVoucher(chassis *epb.Chassis, card int, ov []byte, rotated bool) (*epb.Chassis, error) {
+	sa := m.secArtifacts
+	switch {
+	case m.domainArtifacts(chassis) != nil:
+		if rotated {
+			return nil, status.Errorf(codes.FailedPrecondition, "chassis with serial# %s belongs to ownership domain %q, which is not rotated", chassis.GetSerialNumber(), chassis.GetTenant())
+		}
+		sa = m.domainArtifacts(chassis)
+	case rotated:
+		if m.rotation == nil {
+			return nil, status.Errorf(codes.FailedPrecondition, "no ownership rotation is configured")
+		}
+		sa = m.rotation
+	}
+	if err := m.verifyOwnershipVoucher(ov, sa); err != nil {
+		return nil, err
+	}
+
+	// Chassis entries may be used after releasing m.mu, so the entry is replaced instead of modified.
+	updated := proto.Clone(chassis).(*epb.Chassis)
+	setVoucher(updated, card, base64.StdEncoding.EncodeToString(ov), rotated)
+	return updated, nil
+}
+
+// storeVoucher writes the ownership voucher ov, set on the chassis by withVoucher, to the voucher
+// store if configured, clearing the voucher of the inventory entry as it takes precedence. The
+// chassis must not be in the inventory yet.
+func (m *InMemoryEntityManager) storeVoucher(chassis *epb.Chassis, card int, ov []byte, rotated bool) error {
+	if m.vouchers == nil {
+		return nil
+	}
+	serial := chassis.GetSerialNumber()
+	if card >= 0 {
+		serial = chassis.GetControllerCards()[card].GetSerialNumber()
+	}
+	if err := m.vouchers.put(serial, rotated, ov); err != nil {
+		return err
+	}
+	setVoucher(chassis, card, "", rotated)
+	return nil
+}
+
+// setVoucher sets the base64 encoded ownership voucher of the control card at index card of the
+// chassis, or of the fixed chassis if card is negative, or its rotated ownership voucher if
+// rotated is set.
+func setVoucher(chassis *epb.Chassis, card int, encoded string, rotated bool) {
+	switch {
+	case card >= 0 && rotated:
+		chassis.ControllerCards[card].RotatedOwnershipVoucher = encoded
+	case card >= 0:
+		chassis.ControllerCards[card].OwnershipVoucher = encoded
+	case rotated:
+		chassis.RotatedOwnershipVoucher = encoded
+	default:
+		chassis.OwnershipVoucher = encoded
+	}
+}
+
+// ParseOwnershipVoucher verifies that the ownership voucher is signed by the vendor CA and
+// returns its serial number.
+func (m *InMemoryEntityManager) ParseOwnershipVoucher(ov []byte) (string, error) {
+	if m.secArtifacts == nil {
+		return "", status.Errorf(codes.FailedPrecondition, "no vendor CA to verify ownership vouchers with, as no artifact_dir is set")
+	}
+	vendorCA, err := certFromPEM(m.secArtifacts.VendorCA.Cert)
+	if err != nil {
+		return "", status.Errorf(codes.Internal, "unable to parse vendor CA cert: %v", err)
+	}
+	validation, err := m.voucherValidation()
+	if err != nil {
+		return "", status.Errorf(codes.Internal, "%v", err)
+	}
+	trustAnchors := x509.NewCertPool()
+	trustAnchors.AddCert(vendorCA)
+	parsed, err := ownershipvoucher.VerifyAndUnmarshalWithOptions(ov, ownershipvoucher.VerifyOptions{
+		TrustAnchors:   trustAnchors,
+		RevocationList: m.revocations.CRL(),
+		Validation:     validation,
+	})
+	if err != nil {
+		return "", status.Errorf(codes.InvalidArgument, "invalid ownership voucher: %v", err)
+	}
+	if parsed.OV.SerialNumber == "" {
+		return "", status.Errorf(codes.InvalidArgument, "ownership voucher has no serial number")
+	}
+	return parsed.OV.SerialNumber, nil
+}
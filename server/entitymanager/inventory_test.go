@@ -0,0 +1,223 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entitymanager
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openconfig/bootz/server/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	bpb "github.com/openconfig/bootz/proto/bootz"
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+)
+
+func TestPutChassis(t *testing.T) {
+	em, err := New("../../testdata/inventory.prototxt")
+	if err != nil {
+		t.Fatalf("New() err = %v, want nil", err)
+	}
+	tests := []struct {
+		desc     string
+		chassis  *epb.Chassis
+		replace  bool
+		wantCode codes.Code
+	}{{
+		desc:    "New chassis",
+		chassis: &epb.Chassis{SerialNumber: "456", Manufacturer: "Cisco", BootMode: bpb.BootMode_BOOT_MODE_INSECURE},
+	}, {
+		desc:     "Existing chassis",
+		chassis:  &epb.Chassis{SerialNumber: "456", Manufacturer: "Cisco", BootMode: bpb.BootMode_BOOT_MODE_INSECURE},
+		wantCode: codes.AlreadyExists,
+	}, {
+		desc:    "Replaced chassis",
+		chassis: &epb.Chassis{SerialNumber: "456", Manufacturer: "Cisco", BootMode: bpb.BootMode_BOOT_MODE_INSECURE, Name: "replaced"},
+		replace: true,
+	}, {
+		desc:     "No manufacturer",
+		chassis:  &epb.Chassis{SerialNumber: "789"},
+		wantCode: codes.InvalidArgument,
+	}, {
+		desc:     "Secure chassis without ownership voucher",
+		chassis:  &epb.Chassis{SerialNumber: "789", Manufacturer: "Cisco", BootMode: bpb.BootMode_BOOT_MODE_SECURE},
+		wantCode: codes.InvalidArgument,
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			err := em.PutChassis(test.chassis, test.replace)
+			if got := status.Code(err); got != test.wantCode {
+				t.Fatalf("PutChassis() err = %v, want code %v", err, test.wantCode)
+			}
+			if err != nil {
+				return
+			}
+			got, err := em.GetDevice(&service.EntityLookup{Manufacturer: "Cisco", SerialNumber: test.chassis.GetSerialNumber()})
+			if err != nil {
+				t.Fatalf("GetDevice() err = %v, want nil", err)
+			}
+			if got.GetName() != test.chassis.GetName() {
+				t.Errorf("GetDevice() name = %q, want %q", got.GetName(), test.chassis.GetName())
+			}
+		})
+	}
+}
+
+func TestSetOwnershipVoucher(t *testing.T) {
+	dir := t.TempDir()
+	ovs := newRotationArtifacts(t, dir, "123A")
+	inventory, err := os.ReadFile("../../testdata/inventory.prototxt")
+	if err != nil {
+		t.Fatalf("unable to read inventory: %v", err)
+	}
+	inv := strings.Replace(string(inventory), `artifact_dir: "../../testdata/"`, fmt.Sprintf(`artifact_dir: "../../testdata/"
+    pdc_verification: PDC_VERIFICATION_EXACT
+    ownership_rotation {
+        artifact_dir: %q
+        transition_end { seconds: %d }
+    }`, dir, time.Now().Add(time.Hour).Unix()), 1)
+	path := filepath.Join(dir, "inventory.prototxt")
+	if err := os.WriteFile(path, []byte(inv), 0600); err != nil {
+		t.Fatalf("unable to write inventory: %v", err)
+	}
+	rotatedOV, err := base64.StdEncoding.DecodeString(ovs["123A"])
+	if err != nil {
+		t.Fatalf("unable to decode rotated ownership voucher: %v", err)
+	}
+	current, err := os.ReadFile("../../testdata/ov_123A.txt")
+	if err != nil {
+		t.Fatalf("unable to read ownership voucher: %v", err)
+	}
+	currentOV, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(current)))
+	if err != nil {
+		t.Fatalf("unable to decode ownership voucher: %v", err)
+	}
+
+	tests := []struct {
+		desc     string
+		serial   string
+		ov       []byte
+		rotated  bool
+		wantCode codes.Code
+	}{{
+		desc:   "Ownership voucher",
+		serial: "123A",
+		ov:     currentOV,
+	}, {
+		desc:    "Rotated ownership voucher",
+		serial:  "123A",
+		ov:      rotatedOV,
+		rotated: true,
+	}, {
+		desc:     "Voucher pinning another PDC",
+		serial:   "123A",
+		ov:       rotatedOV,
+		wantCode: codes.FailedPrecondition,
+	}, {
+		desc:     "Unknown serial",
+		serial:   "456A",
+		ov:       currentOV,
+		wantCode: codes.NotFound,
+	}, {
+		desc:     "No voucher",
+		serial:   "123A",
+		wantCode: codes.InvalidArgument,
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			em, err := New(path)
+			if err != nil {
+				t.Fatalf("New() err = %v, want nil", err)
+			}
+			err = em.SetOwnershipVoucher(test.serial, test.ov, test.rotated)
+			if got := status.Code(err); got != test.wantCode {
+				t.Fatalf("SetOwnershipVoucher() err = %v, want code %v", err, test.wantCode)
+			}
+			if err != nil {
+				return
+			}
+			ch, err := em.GetDevice(&service.EntityLookup{Manufacturer: "Cisco", SerialNumber: "123"})
+			if err != nil {
+				t.Fatalf("GetDevice() err = %v, want nil", err)
+			}
+			got := ch.GetControllerCards()[0].GetOwnershipVoucher()
+			if test.rotated {
+				got = ch.GetControllerCards()[0].GetRotatedOwnershipVoucher()
+			}
+			if want := base64.StdEncoding.EncodeToString(test.ov); got != want {
+				t.Errorf("SetOwnershipVoucher() stored %q, want %q", got, want)
+			}
+		})
+	}
+
+	t.Run("No ownership rotation", func(t *testing.T) {
+		em, err := New("../../testdata/inventory.prototxt")
+		if err != nil {
+			t.Fatalf("New() err = %v, want nil", err)
+		}
+		if err := em.SetOwnershipVoucher("123A", rotatedOV, true); status.Code(err) != codes.FailedPrecondition {
+			t.Errorf("SetOwnershipVoucher() err = %v, want code %v", err, codes.FailedPrecondition)
+		}
+	})
+}
+
+func TestParseOwnershipVoucher(t *testing.T) {
+	em, err := New("../../testdata/inventory.prototxt")
+	if err != nil {
+		t.Fatalf("New() err = %v, want nil", err)
+	}
+	data, err := os.ReadFile("../../testdata/ov_123B.txt")
+	if err != nil {
+		t.Fatalf("unable to read ownership voucher: %v", err)
+	}
+	ov, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil {
+		t.Fatalf("unable to decode ownership voucher: %v", err)
+	}
+	tests := []struct {
+		desc       string
+		ov         []byte
+		wantSerial string
+		wantCode   codes.Code
+	}{{
+		desc:       "Valid voucher",
+		ov:         ov,
+		wantSerial: "123B",
+	}, {
+		desc:     "Corrupt voucher",
+		ov:       ov[:len(ov)/2],
+		wantCode: codes.InvalidArgument,
+	}, {
+		desc:     "Empty voucher",
+		wantCode: codes.InvalidArgument,
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			got, err := em.ParseOwnershipVoucher(test.ov)
+			if code := status.Code(err); code != test.wantCode {
+				t.Fatalf("ParseOwnershipVoucher() err = %v, want code %v", err, test.wantCode)
+			}
+			if got != test.wantSerial {
+				t.Errorf("ParseOwnershipVoucher() = %q, want %q", got, test.wantSerial)
+			}
+		})
+	}
+}
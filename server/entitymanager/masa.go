@@ -0,0 +1,145 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entitymanager
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"github.com/openconfig/gnmi/errlist"
+
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+	log "github.com/openconfig/bootz/server/logging"
+)
+
+// missingVoucher is a control card or fixed chassis without an ownership voucher.
+type missingVoucher struct {
+	chassis *epb.Chassis
+	// card is the index of the control card, or -1 for a fixed chassis.
+	card int
+}
+
+// serial returns the serial number of the control card or fixed chassis.
+func (v missingVoucher) serial() string {
+	if v.card >= 0 {
+		return v.chassis.GetControllerCards()[v.card].GetSerialNumber()
+	}
+	return v.chassis.GetSerialNumber()
+}
+
+// missingVouchers returns the control cards and fixed chassis of the chassis which have no
+// ownership voucher and whose voucher is issued by the MASA.
+func (m *InMemoryEntityManager) missingVouchers(chassis []*epb.Chassis) []missingVoucher {
+	var missing []missingVoucher
+	for _, ch := range chassis {
+		if ch.GetSerialNumber() == wildcard || ch.GetArtifactsPending() || !m.masa.Issues(ch.GetManufacturer()) {
+			continue
+		}
+		for i, c := range ch.GetControllerCards() {
+			if !m.hasVoucher(c.GetSerialNumber(), c.GetOwnershipVoucher(), false) {
+				missing = append(missing, missingVoucher{chassis: ch, card: i})
+			}
+		}
+		if len(ch.GetControllerCards()) == 0 && !m.hasVoucher(ch.GetSerialNumber(), ch.GetOwnershipVoucher(), false) {
+			missing = append(missing, missingVoucher{chassis: ch, card: -1})
+		}
+	}
+	return missing
+}
+
+// requestVoucher requests the ownership voucher of a control card or fixed chassis from the MASA,
+// pinning the PDC of its ownership domain, and verifies it.
+func (m *InMemoryEntityManager) requestVoucher(ctx context.Context, v missingVoucher) ([]byte, error) {
+	sa := m.secArtifacts
+	if d := m.domainArtifacts(v.chassis); d != nil {
+		sa = d
+	}
+	ov, err := m.masa.RequestVoucher(ctx, v.serial(), sa.PDC)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.verifyOwnershipVoucher(ov, sa); err != nil {
+		return nil, fmt.Errorf("voucher of serial# %s issued by the MASA: %v", v.serial(), err)
+	}
+	return ov, nil
+}
+
+// requestMissingVouchers sets the ownership vouchers missing from chassis, which are not part of
+// the inventory yet, to those issued by the MASA. Failed requests are logged, leaving the voucher
+// missing.
+func (m *InMemoryEntityManager) requestMissingVouchers(ctx context.Context, chassis []*epb.Chassis) {
+	missing := m.missingVouchers(chassis)
+	issued := 0
+	for _, v := range missing {
+		ov, err := m.requestVoucher(ctx, v)
+		if err != nil {
+			log.Warningf("Unable to request ownership voucher from MASA: %v", err)
+			continue
+		}
+		encoded := base64.StdEncoding.EncodeToString(ov)
+		switch {
+		case m.vouchers != nil:
+			if err := m.vouchers.put(v.serial(), false, ov); err != nil {
+				log.Warningf("Unable to store ownership voucher issued by MASA: %v", err)
+				continue
+			}
+		case v.card >= 0:
+			v.chassis.ControllerCards[v.card].OwnershipVoucher = encoded
+		default:
+			v.chassis.OwnershipVoucher = encoded
+		}
+		issued++
+	}
+	if len(missing) > 0 {
+		log.Infof("MASA issued %d of %d missing ownership vouchers", issued, len(missing))
+	}
+}
+
+// MasaSyncInterval returns the interval the missing ownership vouchers are requested from the MASA
+// at, or 0 if they are only requested when the inventory is loaded.
+func (m *InMemoryEntityManager) MasaSyncInterval() time.Duration {
+	if m.masa == nil {
+		return 0
+	}
+	return m.defaults.GetMasa().GetSyncInterval().AsDuration()
+}
+
+// SyncMasa requests the ownership vouchers missing from the inventory from the MASA of the
+// inventory options, and sets them as SetOwnershipVoucher does.
+func (m *InMemoryEntityManager) SyncMasa(ctx context.Context) error {
+	if m.masa == nil {
+		return fmt.Errorf("no MASA is configured")
+	}
+	m.mu.RLock()
+	chassis := make([]*epb.Chassis, 0, len(m.chassisInventory))
+	for _, ch := range m.chassisInventory {
+		chassis = append(chassis, ch)
+	}
+	missing := m.missingVouchers(chassis)
+	m.mu.RUnlock()
+
+	var errs errlist.List
+	for _, v := range missing {
+		ov, err := m.requestVoucher(ctx, v)
+		if err != nil {
+			errs.Add(err)
+			continue
+		}
+		errs.Add(m.SetOwnershipVoucher(v.serial(), ov, false))
+	}
+	return errs.Err()
+}
@@ -0,0 +1,121 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entitymanager
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	ownershipvoucher "github.com/openconfig/bootz/common/ownership_voucher"
+	"github.com/openconfig/bootz/server/keystore"
+	"github.com/openconfig/bootz/server/masa"
+	"github.com/openconfig/bootz/server/service"
+
+	bpb "github.com/openconfig/bootz/proto/bootz"
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+)
+
+// newFakeMasa returns a MASA issuing vouchers with the vendor CA of sa, pinning the registrar's
+// certificate, except for serial number WRONG, whose voucher pins the vendor CA instead.
+func newFakeMasa(t *testing.T, sa *service.SecurityArtifacts) *httptest.Server {
+	t.Helper()
+	vendorCA, err := certFromPEM(sa.VendorCA.Cert)
+	if err != nil {
+		t.Fatalf("unable to parse vendor CA: %v", err)
+	}
+	signer, err := keystore.ParsePrivateKey([]byte(sa.VendorCA.PrivateKey))
+	if err != nil {
+		t.Fatalf("unable to parse vendor CA key: %v", err)
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("unable to read voucher request: %v", err)
+		}
+		vr, pdc, err := masa.ParseVoucherRequest(body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if vr.SerialNumber == "WRONG" {
+			pdc = vendorCA
+		}
+		ov, err := ownershipvoucher.New(vr.SerialNumber, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: pdc.Raw}), vendorCA, signer.(*rsa.PrivateKey))
+		if err != nil {
+			t.Errorf("ownershipvoucher.New() err = %v", err)
+		}
+		w.Write(ov)
+	}))
+}
+
+func TestMasaVouchers(t *testing.T) {
+	em, err := New("../../testdata/inventory.prototxt")
+	if err != nil {
+		t.Fatalf("New() err = %v, want nil", err)
+	}
+	em.defaults.PdcVerification = epb.PDCVerification_PDC_VERIFICATION_EXACT
+	srv := newFakeMasa(t, em.secArtifacts)
+	defer srv.Close()
+	if em.masa, err = masa.New(&epb.MasaSource{Url: srv.URL, Manufacturers: []string{"Arista"}}); err != nil {
+		t.Fatalf("masa.New() err = %v, want nil", err)
+	}
+
+	// Vouchers of imported secure chassis are requested before they are validated.
+	if err := em.ImportChassis([]*epb.Chassis{
+		{Manufacturer: "Arista", SerialNumber: "MASA1", BootMode: bpb.BootMode_BOOT_MODE_SECURE},
+		{Manufacturer: "Arista", SerialNumber: "WRONG", BootMode: bpb.BootMode_BOOT_MODE_SECURE},
+		{Manufacturer: "Juniper", SerialNumber: "OTHER", BootMode: bpb.BootMode_BOOT_MODE_SECURE},
+	}); err == nil {
+		t.Errorf("ImportChassis() err = nil, want error for the chassis without valid voucher")
+	}
+	got, err := em.GetDevice(&service.EntityLookup{Manufacturer: "Arista", SerialNumber: "MASA1"})
+	if err != nil {
+		t.Fatalf("GetDevice() err = %v, want nil", err)
+	}
+	if got.GetOwnershipVoucher() == "" {
+		t.Errorf("GetDevice() of an imported chassis has no ownership voucher, want the voucher of the MASA")
+	}
+	for _, lookup := range []service.EntityLookup{{Manufacturer: "Arista", SerialNumber: "WRONG"}, {Manufacturer: "Juniper", SerialNumber: "OTHER"}} {
+		if _, err := em.GetDevice(&lookup); err == nil {
+			t.Errorf("GetDevice() of chassis %s err = nil, want error", lookup.SerialNumber)
+		}
+	}
+
+	// Vouchers missing from the inventory are requested again on sync.
+	ch := &epb.Chassis{
+		Manufacturer:    "Arista",
+		SerialNumber:    "MASA2",
+		BootMode:        bpb.BootMode_BOOT_MODE_INSECURE,
+		ControllerCards: []*epb.ControlCard{{SerialNumber: "MASA2-CC1"}},
+	}
+	if err := em.PutChassis(ch, false); err != nil {
+		t.Fatalf("PutChassis() err = %v, want nil", err)
+	}
+	if err := em.SyncMasa(context.Background()); err != nil {
+		t.Fatalf("SyncMasa() err = %v, want nil", err)
+	}
+	got, err = em.GetDevice(&service.EntityLookup{Manufacturer: "Arista", SerialNumber: "MASA2"})
+	if err != nil {
+		t.Fatalf("GetDevice() err = %v, want nil", err)
+	}
+	if got.GetControllerCards()[0].GetOwnershipVoucher() == "" {
+		t.Errorf("GetDevice() after SyncMasa() has no voucher for control card MASA2-CC1, want the voucher of the MASA")
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entitymanager
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/openconfig/bootz/server/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	bpb "github.com/openconfig/bootz/proto/bootz"
+)
+
+// reportedModules are the line cards and fabric modules reported by a chassis.
+type reportedModules struct {
+	lineCards     []*bpb.Module
+	fabricModules []*bpb.Module
+	reportedAt    time.Time
+}
+
+// Modules are the line cards, or the fabric modules, reported by a chassis, reconciled with the
+// inventory.
+type Modules struct {
+	Reported []*bpb.Module
+	// Missing are the modules of the inventory the chassis did not report.
+	Missing []*bpb.Module
+	// Unexpected are the reported modules absent from the inventory. It is empty if the inventory
+	// lists no modules of the kind.
+	Unexpected []*bpb.Module
+}
+
+// ChassisModules are the modules last reported by a chassis.
+type ChassisModules struct {
+	Manufacturer string
+	// SerialNumber is the inventory serial number of the chassis.
+	SerialNumber  string
+	ReportedAt    time.Time
+	LineCards     Modules
+	FabricModules Modules
+}
+
+// RecordModules records the line cards and fabric modules reported by a chassis, to reconcile
+// them with the inventory. Reports without modules are ignored, as devices may not report them.
+func (m *InMemoryEntityManager) RecordModules(ctx context.Context, lookup *service.EntityLookup, ccSerial string, lineCards, fabricModules []*bpb.Module) error {
+	if err := contextError(ctx); err != nil {
+		return err
+	}
+	if len(lineCards) == 0 && len(fabricModules) == 0 {
+		return nil
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	chassis, found := m.lookupChassis(lookup)
+	if !found && lookup.SerialNumber == "" && ccSerial != "" {
+		chassis, _ = m.resolveChassisViaControllerCard(lookup, ccSerial)
+		found = chassis != nil
+	}
+	if !found {
+		chassis, found = m.defaultProfile(lookup)
+	}
+	if !found {
+		return status.Errorf(codes.NotFound, "could not find chassis with serial#: %s and manufacturer: %s and controller card %s", lookup.SerialNumber, lookup.Manufacturer, ccSerial)
+	}
+	// Chassis matching a wildcard entry are recorded under the serial number they report.
+	key := service.EntityLookup{Manufacturer: lookup.Manufacturer, SerialNumber: chassis.GetSerialNumber()}
+	if key.SerialNumber == wildcard || key.SerialNumber == "" {
+		key.SerialNumber = lookup.SerialNumber
+	}
+	m.modules[key] = &reportedModules{
+		lineCards:     cloneModules(lineCards),
+		fabricModules: cloneModules(fabricModules),
+		reportedAt:    time.Now(),
+	}
+	return nil
+}
+
+// Modules returns the modules last reported by the chassis, reconciled with the inventory,
+// ordered by manufacturer and serial number.
+func (m *InMemoryEntityManager) Modules() []ChassisModules {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var out []ChassisModules
+	for key, r := range m.modules {
+		k := key
+		chassis, _ := m.lookupChassis(&k)
+		out = append(out, ChassisModules{
+			Manufacturer:  key.Manufacturer,
+			SerialNumber:  key.SerialNumber,
+			ReportedAt:    r.reportedAt,
+			LineCards:     reconcileModules(chassis.GetLineCards(), r.lineCards),
+			FabricModules: reconcileModules(chassis.GetFabricModules(), r.fabricModules),
+		})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Manufacturer != out[j].Manufacturer {
+			return out[i].Manufacturer < out[j].Manufacturer
+		}
+		return out[i].SerialNumber < out[j].SerialNumber
+	})
+	return out
+}
+
+// reconcileModules matches the reported modules with the expected modules of the inventory, by
+// serial number and, if the inventory sets one, by slot.
+func reconcileModules(expected, reported []*bpb.Module) Modules {
+	mods := Modules{Reported: cloneModules(reported)}
+	if len(expected) == 0 {
+		return mods
+	}
+	bySerial := map[string]*bpb.Module{}
+	for _, r := range reported {
+		bySerial[r.GetSerialNumber()] = r
+	}
+	matched := map[*bpb.Module]bool{}
+	for _, e := range expected {
+		r, ok := bySerial[e.GetSerialNumber()]
+		if !ok || e.GetSlot() != 0 && e.GetSlot() != r.GetSlot() {
+			mods.Missing = append(mods.Missing, proto.Clone(e).(*bpb.Module))
+			continue
+		}
+		matched[r] = true
+	}
+	for _, r := range reported {
+		if !matched[r] {
+			mods.Unexpected = append(mods.Unexpected, proto.Clone(r).(*bpb.Module))
+		}
+	}
+	return mods
+}
+
+func cloneModules(mods []*bpb.Module) []*bpb.Module {
+	var out []*bpb.Module
+	for _, mod := range mods {
+		out = append(out, proto.Clone(mod).(*bpb.Module))
+	}
+	return out
+}
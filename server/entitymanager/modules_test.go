@@ -0,0 +1,106 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entitymanager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/bootz/server/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	bpb "github.com/openconfig/bootz/proto/bootz"
+)
+
+func TestReconcileModules(t *testing.T) {
+	lc1 := &bpb.Module{SerialNumber: "LC1", Slot: 1}
+	lc2 := &bpb.Module{SerialNumber: "LC2", Slot: 2}
+	tests := []struct {
+		desc     string
+		expected []*bpb.Module
+		reported []*bpb.Module
+		want     Modules
+	}{{
+		desc:     "No expected modules",
+		reported: []*bpb.Module{lc1},
+		want:     Modules{Reported: []*bpb.Module{lc1}},
+	}, {
+		desc:     "Matching modules",
+		expected: []*bpb.Module{lc1, {SerialNumber: "LC2"}},
+		reported: []*bpb.Module{lc1, lc2},
+		want:     Modules{Reported: []*bpb.Module{lc1, lc2}},
+	}, {
+		desc:     "Missing module",
+		expected: []*bpb.Module{lc1, lc2},
+		reported: []*bpb.Module{lc1},
+		want:     Modules{Reported: []*bpb.Module{lc1}, Missing: []*bpb.Module{lc2}},
+	}, {
+		desc:     "Unexpected module",
+		expected: []*bpb.Module{lc1},
+		reported: []*bpb.Module{lc1, lc2},
+		want:     Modules{Reported: []*bpb.Module{lc1, lc2}, Unexpected: []*bpb.Module{lc2}},
+	}, {
+		desc:     "Module in another slot",
+		expected: []*bpb.Module{lc1},
+		reported: []*bpb.Module{{SerialNumber: "LC1", Slot: 3}},
+		want: Modules{
+			Reported:   []*bpb.Module{{SerialNumber: "LC1", Slot: 3}},
+			Missing:    []*bpb.Module{lc1},
+			Unexpected: []*bpb.Module{{SerialNumber: "LC1", Slot: 3}},
+		},
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			got := reconcileModules(test.expected, test.reported)
+			if diff := cmp.Diff(test.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("reconcileModules() diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRecordModules(t *testing.T) {
+	em, err := New("../../testdata/inventory.prototxt")
+	if err != nil {
+		t.Fatalf("New() err = %v, want nil", err)
+	}
+	lineCards := []*bpb.Module{{SerialNumber: "LC1", Slot: 1}}
+	em.chassisInventory[service.EntityLookup{Manufacturer: "Cisco", SerialNumber: "123"}].LineCards = lineCards
+
+	unknown := &service.EntityLookup{Manufacturer: "Cisco", SerialNumber: "unknown"}
+	if err := em.RecordModules(context.Background(), unknown, "", lineCards, nil); status.Code(err) != codes.NotFound {
+		t.Errorf("RecordModules() err = %v, want code %v", err, codes.NotFound)
+	}
+	// Modular chassis are resolved via their control card.
+	lookup := &service.EntityLookup{Manufacturer: "Cisco"}
+	reported := []*bpb.Module{{SerialNumber: "LC2", Slot: 1}}
+	if err := em.RecordModules(context.Background(), lookup, "123A", reported, nil); err != nil {
+		t.Fatalf("RecordModules() err = %v, want nil", err)
+	}
+	got := em.Modules()
+	if len(got) != 1 {
+		t.Fatalf("Modules() returned %d chassis, want 1", len(got))
+	}
+	want := Modules{Reported: reported, Missing: lineCards, Unexpected: reported}
+	if got[0].SerialNumber != "123" {
+		t.Errorf("Modules() serial = %q, want %q", got[0].SerialNumber, "123")
+	}
+	if diff := cmp.Diff(want, got[0].LineCards, protocmp.Transform()); diff != "" {
+		t.Errorf("Modules() line cards diff (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entitymanager
+
+import (
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/openconfig/bootz/server/service"
+	"github.com/openconfig/gnmi/errdiff"
+	"google.golang.org/protobuf/proto"
+
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+)
+
+func TestForEach(t *testing.T) {
+	tests := []struct {
+		desc    string
+		n       int
+		workers int
+	}{{
+		desc:    "No workers",
+		n:       10,
+		workers: 0,
+	}, {
+		desc:    "Single worker",
+		n:       10,
+		workers: 1,
+	}, {
+		desc:    "Several workers",
+		n:       100,
+		workers: 4,
+	}, {
+		desc:    "More workers than indices",
+		n:       3,
+		workers: 16,
+	}, {
+		desc:    "No indices",
+		workers: 4,
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			calls := make([]int32, test.n)
+			forEach(test.n, test.workers, func(i int) {
+				atomic.AddInt32(&calls[i], 1)
+			})
+			for i, c := range calls {
+				if c != 1 {
+					t.Errorf("forEach() called f(%d) %d times, want 1", i, c)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadArtifacts(t *testing.T) {
+	em, err := New("../../testdata/inventory.prototxt")
+	if err != nil {
+		t.Fatalf("New() err = %v, want nil", err)
+	}
+	em.defaults.LoadWorkers = 4
+	missing := filepath.Join(t.TempDir(), "missing.cfg")
+	valid := em.GetChassisInventory()[service.EntityLookup{Manufacturer: "Cisco", SerialNumber: "123"}]
+	chassis := []*epb.Chassis{}
+	for i := 0; i < 4; i++ {
+		chassis = append(chassis, proto.Clone(valid).(*epb.Chassis))
+	}
+	chassis[1].Config.BootConfig.VendorConfigFile = missing + "1"
+	chassis[2].ArtifactsPending = true
+	chassis[2].Config.BootConfig.VendorConfigFile = missing + "2"
+	chassis[3].Config.SecondStageConfigFile = missing + "3"
+	err = em.loadArtifacts(chassis)
+	// The errors of every chassis are reported, except for those with pending artifacts.
+	for _, want := range []string{missing + "1", missing + "3"} {
+		if s := errdiff.Substring(err, want); s != "" {
+			t.Errorf("loadArtifacts() %s", s)
+		}
+	}
+	if err != nil && strings.Contains(err.Error(), missing+"2") {
+		t.Errorf("loadArtifacts() err = %v, want no error for chassis with pending artifacts", err)
+	}
+	if err := em.loadArtifacts(chassis[:1]); err != nil {
+		t.Errorf("loadArtifacts() err = %v, want nil", err)
+	}
+}
@@ -13,7 +13,9 @@ import (
 	pathz "github.com/openconfig/gnsi/pathz"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
 	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -25,14 +27,355 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type AlertTrigger int32
+
+const (
+	AlertTrigger_ALERT_TRIGGER_UNSPECIFIED       AlertTrigger = 0
+	AlertTrigger_ALERT_TRIGGER_BOOTSTRAP_FAILURE AlertTrigger = 1
+	AlertTrigger_ALERT_TRIGGER_VOUCHER_FAILURE   AlertTrigger = 2
+)
+
+// Enum value maps for AlertTrigger.
+var (
+	AlertTrigger_name = map[int32]string{
+		0: "ALERT_TRIGGER_UNSPECIFIED",
+		1: "ALERT_TRIGGER_BOOTSTRAP_FAILURE",
+		2: "ALERT_TRIGGER_VOUCHER_FAILURE",
+	}
+	AlertTrigger_value = map[string]int32{
+		"ALERT_TRIGGER_UNSPECIFIED":       0,
+		"ALERT_TRIGGER_BOOTSTRAP_FAILURE": 1,
+		"ALERT_TRIGGER_VOUCHER_FAILURE":   2,
+	}
+)
+
+func (x AlertTrigger) Enum() *AlertTrigger {
+	p := new(AlertTrigger)
+	*p = x
+	return p
+}
+
+func (x AlertTrigger) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AlertTrigger) Descriptor() protoreflect.EnumDescriptor {
+	return file_server_entitymanager_proto_entity_proto_enumTypes[0].Descriptor()
+}
+
+func (AlertTrigger) Type() protoreflect.EnumType {
+	return &file_server_entitymanager_proto_entity_proto_enumTypes[0]
+}
+
+func (x AlertTrigger) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AlertTrigger.Descriptor instead.
+func (AlertTrigger) EnumDescriptor() ([]byte, []int) {
+	return file_server_entitymanager_proto_entity_proto_rawDescGZIP(), []int{0}
+}
+
+type AlertSeverity int32
+
+const (
+	AlertSeverity_ALERT_SEVERITY_UNSPECIFIED AlertSeverity = 0
+	AlertSeverity_ALERT_SEVERITY_INFO        AlertSeverity = 1
+	AlertSeverity_ALERT_SEVERITY_WARNING     AlertSeverity = 2
+	AlertSeverity_ALERT_SEVERITY_ERROR       AlertSeverity = 3
+	AlertSeverity_ALERT_SEVERITY_CRITICAL    AlertSeverity = 4
+)
+
+// Enum value maps for AlertSeverity.
+var (
+	AlertSeverity_name = map[int32]string{
+		0: "ALERT_SEVERITY_UNSPECIFIED",
+		1: "ALERT_SEVERITY_INFO",
+		2: "ALERT_SEVERITY_WARNING",
+		3: "ALERT_SEVERITY_ERROR",
+		4: "ALERT_SEVERITY_CRITICAL",
+	}
+	AlertSeverity_value = map[string]int32{
+		"ALERT_SEVERITY_UNSPECIFIED": 0,
+		"ALERT_SEVERITY_INFO":        1,
+		"ALERT_SEVERITY_WARNING":     2,
+		"ALERT_SEVERITY_ERROR":       3,
+		"ALERT_SEVERITY_CRITICAL":    4,
+	}
+)
+
+func (x AlertSeverity) Enum() *AlertSeverity {
+	p := new(AlertSeverity)
+	*p = x
+	return p
+}
+
+func (x AlertSeverity) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AlertSeverity) Descriptor() protoreflect.EnumDescriptor {
+	return file_server_entitymanager_proto_entity_proto_enumTypes[1].Descriptor()
+}
+
+func (AlertSeverity) Type() protoreflect.EnumType {
+	return &file_server_entitymanager_proto_entity_proto_enumTypes[1]
+}
+
+func (x AlertSeverity) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AlertSeverity.Descriptor instead.
+func (AlertSeverity) EnumDescriptor() ([]byte, []int) {
+	return file_server_entitymanager_proto_entity_proto_rawDescGZIP(), []int{1}
+}
+
+type VoucherValidation int32
+
+const (
+	VoucherValidation_VOUCHER_VALIDATION_NONE   VoucherValidation = 0
+	VoucherValidation_VOUCHER_VALIDATION_STRICT VoucherValidation = 1
+	VoucherValidation_VOUCHER_VALIDATION_SCHEMA VoucherValidation = 2
+)
+
+// Enum value maps for VoucherValidation.
+var (
+	VoucherValidation_name = map[int32]string{
+		0: "VOUCHER_VALIDATION_NONE",
+		1: "VOUCHER_VALIDATION_STRICT",
+		2: "VOUCHER_VALIDATION_SCHEMA",
+	}
+	VoucherValidation_value = map[string]int32{
+		"VOUCHER_VALIDATION_NONE":   0,
+		"VOUCHER_VALIDATION_STRICT": 1,
+		"VOUCHER_VALIDATION_SCHEMA": 2,
+	}
+)
+
+func (x VoucherValidation) Enum() *VoucherValidation {
+	p := new(VoucherValidation)
+	*p = x
+	return p
+}
+
+func (x VoucherValidation) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (VoucherValidation) Descriptor() protoreflect.EnumDescriptor {
+	return file_server_entitymanager_proto_entity_proto_enumTypes[2].Descriptor()
+}
+
+func (VoucherValidation) Type() protoreflect.EnumType {
+	return &file_server_entitymanager_proto_entity_proto_enumTypes[2]
+}
+
+func (x VoucherValidation) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use VoucherValidation.Descriptor instead.
+func (VoucherValidation) EnumDescriptor() ([]byte, []int) {
+	return file_server_entitymanager_proto_entity_proto_rawDescGZIP(), []int{2}
+}
+
+type PDCVerification int32
+
+const (
+	PDCVerification_PDC_VERIFICATION_NONE  PDCVerification = 0
+	PDCVerification_PDC_VERIFICATION_EXACT PDCVerification = 1
+	PDCVerification_PDC_VERIFICATION_SPKI  PDCVerification = 2
+)
+
+// Enum value maps for PDCVerification.
+var (
+	PDCVerification_name = map[int32]string{
+		0: "PDC_VERIFICATION_NONE",
+		1: "PDC_VERIFICATION_EXACT",
+		2: "PDC_VERIFICATION_SPKI",
+	}
+	PDCVerification_value = map[string]int32{
+		"PDC_VERIFICATION_NONE":  0,
+		"PDC_VERIFICATION_EXACT": 1,
+		"PDC_VERIFICATION_SPKI":  2,
+	}
+)
+
+func (x PDCVerification) Enum() *PDCVerification {
+	p := new(PDCVerification)
+	*p = x
+	return p
+}
+
+func (x PDCVerification) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PDCVerification) Descriptor() protoreflect.EnumDescriptor {
+	return file_server_entitymanager_proto_entity_proto_enumTypes[3].Descriptor()
+}
+
+func (PDCVerification) Type() protoreflect.EnumType {
+	return &file_server_entitymanager_proto_entity_proto_enumTypes[3]
+}
+
+func (x PDCVerification) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PDCVerification.Descriptor instead.
+func (PDCVerification) EnumDescriptor() ([]byte, []int) {
+	return file_server_entitymanager_proto_entity_proto_rawDescGZIP(), []int{3}
+}
+
+type ChassisDescriptorValidation int32
+
+const (
+	ChassisDescriptorValidation_CHASSIS_DESCRIPTOR_VALIDATION_NONE     ChassisDescriptorValidation = 0
+	ChassisDescriptorValidation_CHASSIS_DESCRIPTOR_VALIDATION_LOG_ONLY ChassisDescriptorValidation = 1
+	ChassisDescriptorValidation_CHASSIS_DESCRIPTOR_VALIDATION_STRICT   ChassisDescriptorValidation = 2
+)
+
+// Enum value maps for ChassisDescriptorValidation.
+var (
+	ChassisDescriptorValidation_name = map[int32]string{
+		0: "CHASSIS_DESCRIPTOR_VALIDATION_NONE",
+		1: "CHASSIS_DESCRIPTOR_VALIDATION_LOG_ONLY",
+		2: "CHASSIS_DESCRIPTOR_VALIDATION_STRICT",
+	}
+	ChassisDescriptorValidation_value = map[string]int32{
+		"CHASSIS_DESCRIPTOR_VALIDATION_NONE":     0,
+		"CHASSIS_DESCRIPTOR_VALIDATION_LOG_ONLY": 1,
+		"CHASSIS_DESCRIPTOR_VALIDATION_STRICT":   2,
+	}
+)
+
+func (x ChassisDescriptorValidation) Enum() *ChassisDescriptorValidation {
+	p := new(ChassisDescriptorValidation)
+	*p = x
+	return p
+}
+
+func (x ChassisDescriptorValidation) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ChassisDescriptorValidation) Descriptor() protoreflect.EnumDescriptor {
+	return file_server_entitymanager_proto_entity_proto_enumTypes[4].Descriptor()
+}
+
+func (ChassisDescriptorValidation) Type() protoreflect.EnumType {
+	return &file_server_entitymanager_proto_entity_proto_enumTypes[4]
+}
+
+func (x ChassisDescriptorValidation) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ChassisDescriptorValidation.Descriptor instead.
+func (ChassisDescriptorValidation) EnumDescriptor() ([]byte, []int) {
+	return file_server_entitymanager_proto_entity_proto_rawDescGZIP(), []int{4}
+}
+
+type PeerIdentityCheck int32
+
+const (
+	PeerIdentityCheck_PEER_IDENTITY_CHECK_NONE     PeerIdentityCheck = 0
+	PeerIdentityCheck_PEER_IDENTITY_CHECK_LOG_ONLY PeerIdentityCheck = 1
+	PeerIdentityCheck_PEER_IDENTITY_CHECK_ENFORCE  PeerIdentityCheck = 2
+)
+
+// Enum value maps for PeerIdentityCheck.
+var (
+	PeerIdentityCheck_name = map[int32]string{
+		0: "PEER_IDENTITY_CHECK_NONE",
+		1: "PEER_IDENTITY_CHECK_LOG_ONLY",
+		2: "PEER_IDENTITY_CHECK_ENFORCE",
+	}
+	PeerIdentityCheck_value = map[string]int32{
+		"PEER_IDENTITY_CHECK_NONE":     0,
+		"PEER_IDENTITY_CHECK_LOG_ONLY": 1,
+		"PEER_IDENTITY_CHECK_ENFORCE":  2,
+	}
+)
+
+func (x PeerIdentityCheck) Enum() *PeerIdentityCheck {
+	p := new(PeerIdentityCheck)
+	*p = x
+	return p
+}
+
+func (x PeerIdentityCheck) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PeerIdentityCheck) Descriptor() protoreflect.EnumDescriptor {
+	return file_server_entitymanager_proto_entity_proto_enumTypes[5].Descriptor()
+}
+
+func (PeerIdentityCheck) Type() protoreflect.EnumType {
+	return &file_server_entitymanager_proto_entity_proto_enumTypes[5]
+}
+
+func (x PeerIdentityCheck) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PeerIdentityCheck.Descriptor instead.
+func (PeerIdentityCheck) EnumDescriptor() ([]byte, []int) {
+	return file_server_entitymanager_proto_entity_proto_rawDescGZIP(), []int{5}
+}
+
 type Options struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	GnsiGlobalConfig *GNSIConfig `protobuf:"bytes,1,opt,name=gnsi_global_config,json=gnsiGlobalConfig,proto3" json:"gnsi_global_config,omitempty"`
-	Bootzserver      string      `protobuf:"bytes,2,opt,name=bootzserver,proto3" json:"bootzserver,omitempty"`
-	ArtifactDir      string      `protobuf:"bytes,3,opt,name=artifact_dir,json=artifactDir,proto3" json:"artifact_dir,omitempty"`
+	GnsiGlobalConfig            *GNSIConfig                 `protobuf:"bytes,1,opt,name=gnsi_global_config,json=gnsiGlobalConfig,proto3" json:"gnsi_global_config,omitempty"`
+	Bootzserver                 string                      `protobuf:"bytes,2,opt,name=bootzserver,proto3" json:"bootzserver,omitempty"`
+	ArtifactDir                 string                      `protobuf:"bytes,3,opt,name=artifact_dir,json=artifactDir,proto3" json:"artifact_dir,omitempty"`
+	PdcVerification             PDCVerification             `protobuf:"varint,4,opt,name=pdc_verification,json=pdcVerification,proto3,enum=entity.PDCVerification" json:"pdc_verification,omitempty"`
+	FeatureFlags                []*FeatureFlag              `protobuf:"bytes,5,rep,name=feature_flags,json=featureFlags,proto3" json:"feature_flags,omitempty"`
+	OvRevocationFile            string                      `protobuf:"bytes,6,opt,name=ov_revocation_file,json=ovRevocationFile,proto3" json:"ov_revocation_file,omitempty"`
+	VendorCaCrlFile             string                      `protobuf:"bytes,7,opt,name=vendor_ca_crl_file,json=vendorCaCrlFile,proto3" json:"vendor_ca_crl_file,omitempty"`
+	DefaultProfile              *DefaultProfile             `protobuf:"bytes,8,opt,name=default_profile,json=defaultProfile,proto3" json:"default_profile,omitempty"`
+	Experiments                 []*Experiment               `protobuf:"bytes,9,rep,name=experiments,proto3" json:"experiments,omitempty"`
+	OcKeyUri                    string                      `protobuf:"bytes,10,opt,name=oc_key_uri,json=ocKeyUri,proto3" json:"oc_key_uri,omitempty"`
+	PdcKeyUri                   string                      `protobuf:"bytes,11,opt,name=pdc_key_uri,json=pdcKeyUri,proto3" json:"pdc_key_uri,omitempty"`
+	DefaultBootMode             bootz.BootMode              `protobuf:"varint,12,opt,name=default_boot_mode,json=defaultBootMode,proto3,enum=bootz.proto.BootMode" json:"default_boot_mode,omitempty"`
+	ModelImages                 []*ModelImage               `protobuf:"bytes,13,rep,name=model_images,json=modelImages,proto3" json:"model_images,omitempty"`
+	MaxBootstrapRetries         uint32                      `protobuf:"varint,14,opt,name=max_bootstrap_retries,json=maxBootstrapRetries,proto3" json:"max_bootstrap_retries,omitempty"`
+	DeviceStateFile             string                      `protobuf:"bytes,15,opt,name=device_state_file,json=deviceStateFile,proto3" json:"device_state_file,omitempty"`
+	RetryInterval               *durationpb.Duration        `protobuf:"bytes,16,opt,name=retry_interval,json=retryInterval,proto3" json:"retry_interval,omitempty"`
+	ConfigValidators            []*ConfigValidator          `protobuf:"bytes,17,rep,name=config_validators,json=configValidators,proto3" json:"config_validators,omitempty"`
+	OwnershipRotation           *OwnershipRotation          `protobuf:"bytes,18,opt,name=ownership_rotation,json=ownershipRotation,proto3" json:"ownership_rotation,omitempty"`
+	PeerIdentityCheck           PeerIdentityCheck           `protobuf:"varint,19,opt,name=peer_identity_check,json=peerIdentityCheck,proto3,enum=entity.PeerIdentityCheck" json:"peer_identity_check,omitempty"`
+	Authenticators              []*Authenticator            `protobuf:"bytes,20,rep,name=authenticators,proto3" json:"authenticators,omitempty"`
+	Redirects                   []*RedirectRule             `protobuf:"bytes,21,rep,name=redirects,proto3" json:"redirects,omitempty"`
+	Netbox                      *NetBoxSource               `protobuf:"bytes,22,opt,name=netbox,proto3" json:"netbox,omitempty"`
+	Domains                     []*OwnershipDomain          `protobuf:"bytes,23,rep,name=domains,proto3" json:"domains,omitempty"`
+	SerialNormalization         *SerialNormalization        `protobuf:"bytes,24,opt,name=serial_normalization,json=serialNormalization,proto3" json:"serial_normalization,omitempty"`
+	RequestLogging              *RequestLogging             `protobuf:"bytes,25,opt,name=request_logging,json=requestLogging,proto3" json:"request_logging,omitempty"`
+	Timeouts                    *Timeouts                   `protobuf:"bytes,26,opt,name=timeouts,proto3" json:"timeouts,omitempty"`
+	ResponseCache               *ResponseCache              `protobuf:"bytes,27,opt,name=response_cache,json=responseCache,proto3" json:"response_cache,omitempty"`
+	LoadWorkers                 uint32                      `protobuf:"varint,28,opt,name=load_workers,json=loadWorkers,proto3" json:"load_workers,omitempty"`
+	FipsMode                    bool                        `protobuf:"varint,29,opt,name=fips_mode,json=fipsMode,proto3" json:"fips_mode,omitempty"`
+	VoucherValidation           VoucherValidation           `protobuf:"varint,30,opt,name=voucher_validation,json=voucherValidation,proto3,enum=entity.VoucherValidation" json:"voucher_validation,omitempty"`
+	Masa                        *MasaSource                 `protobuf:"bytes,31,opt,name=masa,proto3" json:"masa,omitempty"`
+	UrlRewrites                 []*UrlRewriteRule           `protobuf:"bytes,32,rep,name=url_rewrites,json=urlRewrites,proto3" json:"url_rewrites,omitempty"`
+	ChassisDescriptorValidation ChassisDescriptorValidation `protobuf:"varint,33,opt,name=chassis_descriptor_validation,json=chassisDescriptorValidation,proto3,enum=entity.ChassisDescriptorValidation" json:"chassis_descriptor_validation,omitempty"`
+	VendorQuirks                []*VendorQuirks             `protobuf:"bytes,34,rep,name=vendor_quirks,json=vendorQuirks,proto3" json:"vendor_quirks,omitempty"`
+	Cluster                     *Cluster                    `protobuf:"bytes,35,opt,name=cluster,proto3" json:"cluster,omitempty"`
+	BootstrapPolicies           []*BootstrapPolicy          `protobuf:"bytes,36,rep,name=bootstrap_policies,json=bootstrapPolicies,proto3" json:"bootstrap_policies,omitempty"`
+	ProvisioningWindows         []*ProvisioningWindow       `protobuf:"bytes,37,rep,name=provisioning_windows,json=provisioningWindows,proto3" json:"provisioning_windows,omitempty"`
+	Quarantine                  *Quarantine                 `protobuf:"bytes,38,opt,name=quarantine,proto3" json:"quarantine,omitempty"`
+	Alerting                    *Alerting                   `protobuf:"bytes,39,opt,name=alerting,proto3" json:"alerting,omitempty"`
+	AdmissionControl            *AdmissionControl           `protobuf:"bytes,40,opt,name=admission_control,json=admissionControl,proto3" json:"admission_control,omitempty"`
+	SigningWorkers              uint32                      `protobuf:"varint,41,opt,name=signing_workers,json=signingWorkers,proto3" json:"signing_workers,omitempty"`
+	VoucherStore                *VoucherStore               `protobuf:"bytes,42,opt,name=voucher_store,json=voucherStore,proto3" json:"voucher_store,omitempty"`
+	AdminIdentities             []string                    `protobuf:"bytes,43,rep,name=admin_identities,json=adminIdentities,proto3" json:"admin_identities,omitempty"`
+	RetiredDeviceFile           string                      `protobuf:"bytes,44,opt,name=retired_device_file,json=retiredDeviceFile,proto3" json:"retired_device_file,omitempty"`
 }
 
 func (x *Options) Reset() {
@@ -88,318 +431,319 @@ func (x *Options) GetArtifactDir() string {
 	return ""
 }
 
-type Entities struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Options *Options   `protobuf:"bytes,1,opt,name=options,proto3" json:"options,omitempty"`
-	Chassis []*Chassis `protobuf:"bytes,2,rep,name=chassis,proto3" json:"chassis,omitempty"`
-}
-
-func (x *Entities) Reset() {
-	*x = Entities{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_server_entitymanager_proto_entity_proto_msgTypes[1]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *Options) GetPdcVerification() PDCVerification {
+	if x != nil {
+		return x.PdcVerification
 	}
+	return PDCVerification_PDC_VERIFICATION_NONE
 }
 
-func (x *Entities) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Options) GetFeatureFlags() []*FeatureFlag {
+	if x != nil {
+		return x.FeatureFlags
+	}
+	return nil
 }
 
-func (*Entities) ProtoMessage() {}
-
-func (x *Entities) ProtoReflect() protoreflect.Message {
-	mi := &file_server_entitymanager_proto_entity_proto_msgTypes[1]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *Options) GetOvRevocationFile() string {
+	if x != nil {
+		return x.OvRevocationFile
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use Entities.ProtoReflect.Descriptor instead.
-func (*Entities) Descriptor() ([]byte, []int) {
-	return file_server_entitymanager_proto_entity_proto_rawDescGZIP(), []int{1}
+func (x *Options) GetVendorCaCrlFile() string {
+	if x != nil {
+		return x.VendorCaCrlFile
+	}
+	return ""
 }
 
-func (x *Entities) GetOptions() *Options {
+func (x *Options) GetDefaultProfile() *DefaultProfile {
 	if x != nil {
-		return x.Options
+		return x.DefaultProfile
 	}
 	return nil
 }
 
-func (x *Entities) GetChassis() []*Chassis {
+func (x *Options) GetExperiments() []*Experiment {
 	if x != nil {
-		return x.Chassis
+		return x.Experiments
 	}
 	return nil
 }
 
-type Config struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	BootConfig *BootConfig `protobuf:"bytes,1,opt,name=boot_config,json=bootConfig,proto3" json:"boot_config,omitempty"`
-	GnsiConfig *GNSIConfig `protobuf:"bytes,2,opt,name=gnsi_config,json=gnsiConfig,proto3" json:"gnsi_config,omitempty"`
+func (x *Options) GetOcKeyUri() string {
+	if x != nil {
+		return x.OcKeyUri
+	}
+	return ""
 }
 
-func (x *Config) Reset() {
-	*x = Config{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_server_entitymanager_proto_entity_proto_msgTypes[2]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *Options) GetPdcKeyUri() string {
+	if x != nil {
+		return x.PdcKeyUri
 	}
+	return ""
 }
 
-func (x *Config) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Options) GetDefaultBootMode() bootz.BootMode {
+	if x != nil {
+		return x.DefaultBootMode
+	}
+	return bootz.BootMode(0)
 }
 
-func (*Config) ProtoMessage() {}
+func (x *Options) GetModelImages() []*ModelImage {
+	if x != nil {
+		return x.ModelImages
+	}
+	return nil
+}
 
-func (x *Config) ProtoReflect() protoreflect.Message {
-	mi := &file_server_entitymanager_proto_entity_proto_msgTypes[2]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *Options) GetMaxBootstrapRetries() uint32 {
+	if x != nil {
+		return x.MaxBootstrapRetries
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use Config.ProtoReflect.Descriptor instead.
-func (*Config) Descriptor() ([]byte, []int) {
-	return file_server_entitymanager_proto_entity_proto_rawDescGZIP(), []int{2}
+func (x *Options) GetDeviceStateFile() string {
+	if x != nil {
+		return x.DeviceStateFile
+	}
+	return ""
 }
 
-func (x *Config) GetBootConfig() *BootConfig {
+func (x *Options) GetRetryInterval() *durationpb.Duration {
 	if x != nil {
-		return x.BootConfig
+		return x.RetryInterval
 	}
 	return nil
 }
 
-func (x *Config) GetGnsiConfig() *GNSIConfig {
+func (x *Options) GetConfigValidators() []*ConfigValidator {
 	if x != nil {
-		return x.GnsiConfig
+		return x.ConfigValidators
 	}
 	return nil
 }
 
-type BootConfig struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Metadata         *structpb.Struct `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
-	VendorConfigFile string           `protobuf:"bytes,2,opt,name=vendor_config_file,json=vendorConfigFile,proto3" json:"vendor_config_file,omitempty"`
-	OcConfigFile     string           `protobuf:"bytes,3,opt,name=oc_config_file,json=ocConfigFile,proto3" json:"oc_config_file,omitempty"`
-	BootloaderConfig *structpb.Struct `protobuf:"bytes,4,opt,name=bootloader_config,json=bootloaderConfig,proto3" json:"bootloader_config,omitempty"`
+func (x *Options) GetOwnershipRotation() *OwnershipRotation {
+	if x != nil {
+		return x.OwnershipRotation
+	}
+	return nil
 }
 
-func (x *BootConfig) Reset() {
-	*x = BootConfig{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_server_entitymanager_proto_entity_proto_msgTypes[3]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *Options) GetPeerIdentityCheck() PeerIdentityCheck {
+	if x != nil {
+		return x.PeerIdentityCheck
 	}
+	return PeerIdentityCheck_PEER_IDENTITY_CHECK_NONE
 }
 
-func (x *BootConfig) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Options) GetAuthenticators() []*Authenticator {
+	if x != nil {
+		return x.Authenticators
+	}
+	return nil
 }
 
-func (*BootConfig) ProtoMessage() {}
-
-func (x *BootConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_server_entitymanager_proto_entity_proto_msgTypes[3]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *Options) GetRedirects() []*RedirectRule {
+	if x != nil {
+		return x.Redirects
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use BootConfig.ProtoReflect.Descriptor instead.
-func (*BootConfig) Descriptor() ([]byte, []int) {
-	return file_server_entitymanager_proto_entity_proto_rawDescGZIP(), []int{3}
+func (x *Options) GetNetbox() *NetBoxSource {
+	if x != nil {
+		return x.Netbox
+	}
+	return nil
 }
 
-func (x *BootConfig) GetMetadata() *structpb.Struct {
+func (x *Options) GetDomains() []*OwnershipDomain {
 	if x != nil {
-		return x.Metadata
+		return x.Domains
 	}
 	return nil
 }
 
-func (x *BootConfig) GetVendorConfigFile() string {
+func (x *Options) GetSerialNormalization() *SerialNormalization {
 	if x != nil {
-		return x.VendorConfigFile
+		return x.SerialNormalization
 	}
-	return ""
+	return nil
 }
 
-func (x *BootConfig) GetOcConfigFile() string {
+func (x *Options) GetRequestLogging() *RequestLogging {
 	if x != nil {
-		return x.OcConfigFile
+		return x.RequestLogging
 	}
-	return ""
+	return nil
 }
 
-func (x *BootConfig) GetBootloaderConfig() *structpb.Struct {
+func (x *Options) GetTimeouts() *Timeouts {
 	if x != nil {
-		return x.BootloaderConfig
+		return x.Timeouts
 	}
 	return nil
 }
 
-type GNSIConfig struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x *Options) GetResponseCache() *ResponseCache {
+	if x != nil {
+		return x.ResponseCache
+	}
+	return nil
+}
 
-	AuthzUploadFile string               `protobuf:"bytes,1,opt,name=authz_upload_file,json=authzUploadFile,proto3" json:"authz_upload_file,omitempty"`
-	AuthzUpload     *authz.UploadRequest `protobuf:"bytes,2,opt,name=authz_upload,json=authzUpload,proto3" json:"authz_upload,omitempty"`
-	PathzUploadFile string               `protobuf:"bytes,3,opt,name=pathz_upload_file,json=pathzUploadFile,proto3" json:"pathz_upload_file,omitempty"`
-	PathzUpload     *pathz.UploadRequest `protobuf:"bytes,4,opt,name=pathz_upload,json=pathzUpload,proto3" json:"pathz_upload,omitempty"`
-	CertzUpload     *certz.UploadRequest `protobuf:"bytes,5,opt,name=certz_upload,json=certzUpload,proto3" json:"certz_upload,omitempty"`
-	CertzUploadFile string               `protobuf:"bytes,6,opt,name=certz_upload_file,json=certzUploadFile,proto3" json:"certz_upload_file,omitempty"`
-	CredentialsFile string               `protobuf:"bytes,7,opt,name=credentials_file,json=credentialsFile,proto3" json:"credentials_file,omitempty"`
-	Credentials     *bootz.Credentials   `protobuf:"bytes,8,opt,name=credentials,proto3" json:"credentials,omitempty"`
+func (x *Options) GetLoadWorkers() uint32 {
+	if x != nil {
+		return x.LoadWorkers
+	}
+	return 0
 }
 
-func (x *GNSIConfig) Reset() {
-	*x = GNSIConfig{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_server_entitymanager_proto_entity_proto_msgTypes[4]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *Options) GetFipsMode() bool {
+	if x != nil {
+		return x.FipsMode
 	}
+	return false
 }
 
-func (x *GNSIConfig) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Options) GetVoucherValidation() VoucherValidation {
+	if x != nil {
+		return x.VoucherValidation
+	}
+	return VoucherValidation_VOUCHER_VALIDATION_NONE
 }
 
-func (*GNSIConfig) ProtoMessage() {}
+func (x *Options) GetMasa() *MasaSource {
+	if x != nil {
+		return x.Masa
+	}
+	return nil
+}
 
-func (x *GNSIConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_server_entitymanager_proto_entity_proto_msgTypes[4]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *Options) GetUrlRewrites() []*UrlRewriteRule {
+	if x != nil {
+		return x.UrlRewrites
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use GNSIConfig.ProtoReflect.Descriptor instead.
-func (*GNSIConfig) Descriptor() ([]byte, []int) {
-	return file_server_entitymanager_proto_entity_proto_rawDescGZIP(), []int{4}
+func (x *Options) GetChassisDescriptorValidation() ChassisDescriptorValidation {
+	if x != nil {
+		return x.ChassisDescriptorValidation
+	}
+	return ChassisDescriptorValidation_CHASSIS_DESCRIPTOR_VALIDATION_NONE
 }
 
-func (x *GNSIConfig) GetAuthzUploadFile() string {
+func (x *Options) GetVendorQuirks() []*VendorQuirks {
 	if x != nil {
-		return x.AuthzUploadFile
+		return x.VendorQuirks
 	}
-	return ""
+	return nil
 }
 
-func (x *GNSIConfig) GetAuthzUpload() *authz.UploadRequest {
+func (x *Options) GetCluster() *Cluster {
 	if x != nil {
-		return x.AuthzUpload
+		return x.Cluster
 	}
 	return nil
 }
 
-func (x *GNSIConfig) GetPathzUploadFile() string {
+func (x *Options) GetBootstrapPolicies() []*BootstrapPolicy {
 	if x != nil {
-		return x.PathzUploadFile
+		return x.BootstrapPolicies
 	}
-	return ""
+	return nil
 }
 
-func (x *GNSIConfig) GetPathzUpload() *pathz.UploadRequest {
+func (x *Options) GetProvisioningWindows() []*ProvisioningWindow {
 	if x != nil {
-		return x.PathzUpload
+		return x.ProvisioningWindows
 	}
 	return nil
 }
 
-func (x *GNSIConfig) GetCertzUpload() *certz.UploadRequest {
+func (x *Options) GetQuarantine() *Quarantine {
 	if x != nil {
-		return x.CertzUpload
+		return x.Quarantine
 	}
 	return nil
 }
 
-func (x *GNSIConfig) GetCertzUploadFile() string {
+func (x *Options) GetAlerting() *Alerting {
 	if x != nil {
-		return x.CertzUploadFile
+		return x.Alerting
 	}
-	return ""
+	return nil
 }
 
-func (x *GNSIConfig) GetCredentialsFile() string {
+func (x *Options) GetAdmissionControl() *AdmissionControl {
 	if x != nil {
-		return x.CredentialsFile
+		return x.AdmissionControl
 	}
-	return ""
+	return nil
 }
 
-func (x *GNSIConfig) GetCredentials() *bootz.Credentials {
+func (x *Options) GetSigningWorkers() uint32 {
 	if x != nil {
-		return x.Credentials
+		return x.SigningWorkers
+	}
+	return 0
+}
+
+func (x *Options) GetVoucherStore() *VoucherStore {
+	if x != nil {
+		return x.VoucherStore
 	}
 	return nil
 }
 
-type DHCPConfig struct {
+func (x *Options) GetAdminIdentities() []string {
+	if x != nil {
+		return x.AdminIdentities
+	}
+	return nil
+}
+
+func (x *Options) GetRetiredDeviceFile() string {
+	if x != nil {
+		return x.RetiredDeviceFile
+	}
+	return ""
+}
+
+type Quarantine struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	HardwareAddress string `protobuf:"bytes,1,opt,name=hardware_address,json=hardwareAddress,proto3" json:"hardware_address,omitempty"`
-	IpAddress       string `protobuf:"bytes,2,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`
-	Gateway         string `protobuf:"bytes,3,opt,name=gateway,proto3" json:"gateway,omitempty"`
-	Bootzserver     string `protobuf:"bytes,4,opt,name=bootzserver,proto3" json:"bootzserver,omitempty"`
+	RetryInterval *durationpb.Duration `protobuf:"bytes,1,opt,name=retry_interval,json=retryInterval,proto3" json:"retry_interval,omitempty"`
+	MaxPending    uint32               `protobuf:"varint,2,opt,name=max_pending,json=maxPending,proto3" json:"max_pending,omitempty"`
 }
 
-func (x *DHCPConfig) Reset() {
-	*x = DHCPConfig{}
+func (x *Quarantine) Reset() {
+	*x = Quarantine{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_server_entitymanager_proto_entity_proto_msgTypes[5]
+		mi := &file_server_entitymanager_proto_entity_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *DHCPConfig) String() string {
+func (x *Quarantine) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DHCPConfig) ProtoMessage() {}
+func (*Quarantine) ProtoMessage() {}
 
-func (x *DHCPConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_server_entitymanager_proto_entity_proto_msgTypes[5]
+func (x *Quarantine) ProtoReflect() protoreflect.Message {
+	mi := &file_server_entitymanager_proto_entity_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -410,67 +754,53 @@ func (x *DHCPConfig) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DHCPConfig.ProtoReflect.Descriptor instead.
-func (*DHCPConfig) Descriptor() ([]byte, []int) {
-	return file_server_entitymanager_proto_entity_proto_rawDescGZIP(), []int{5}
-}
-
-func (x *DHCPConfig) GetHardwareAddress() string {
-	if x != nil {
-		return x.HardwareAddress
-	}
-	return ""
-}
-
-func (x *DHCPConfig) GetIpAddress() string {
-	if x != nil {
-		return x.IpAddress
-	}
-	return ""
+// Deprecated: Use Quarantine.ProtoReflect.Descriptor instead.
+func (*Quarantine) Descriptor() ([]byte, []int) {
+	return file_server_entitymanager_proto_entity_proto_rawDescGZIP(), []int{1}
 }
 
-func (x *DHCPConfig) GetGateway() string {
+func (x *Quarantine) GetRetryInterval() *durationpb.Duration {
 	if x != nil {
-		return x.Gateway
+		return x.RetryInterval
 	}
-	return ""
+	return nil
 }
 
-func (x *DHCPConfig) GetBootzserver() string {
+func (x *Quarantine) GetMaxPending() uint32 {
 	if x != nil {
-		return x.Bootzserver
+		return x.MaxPending
 	}
-	return ""
+	return 0
 }
 
-type ControlCard struct {
+type AdmissionControl struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	PartNumber       string      `protobuf:"bytes,1,opt,name=part_number,json=partNumber,proto3" json:"part_number,omitempty"`
-	SerialNumber     string      `protobuf:"bytes,2,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
-	OwnershipVoucher string      `protobuf:"bytes,3,opt,name=ownership_voucher,json=ownershipVoucher,proto3" json:"ownership_voucher,omitempty"`
-	DhcpConfig       *DHCPConfig `protobuf:"bytes,4,opt,name=dhcp_config,json=dhcpConfig,proto3" json:"dhcp_config,omitempty"`
+	MaxInFlight        uint32               `protobuf:"varint,1,opt,name=max_in_flight,json=maxInFlight,proto3" json:"max_in_flight,omitempty"`
+	MaxInFlightPerSite uint32               `protobuf:"varint,2,opt,name=max_in_flight_per_site,json=maxInFlightPerSite,proto3" json:"max_in_flight_per_site,omitempty"`
+	QueueTimeout       *durationpb.Duration `protobuf:"bytes,3,opt,name=queue_timeout,json=queueTimeout,proto3" json:"queue_timeout,omitempty"`
+	RetryInterval      *durationpb.Duration `protobuf:"bytes,4,opt,name=retry_interval,json=retryInterval,proto3" json:"retry_interval,omitempty"`
 }
 
-func (x *ControlCard) Reset() {
-	*x = ControlCard{}
+func (x *AdmissionControl) Reset() {
+	*x = AdmissionControl{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_server_entitymanager_proto_entity_proto_msgTypes[6]
+		mi := &file_server_entitymanager_proto_entity_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ControlCard) String() string {
+func (x *AdmissionControl) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ControlCard) ProtoMessage() {}
+func (*AdmissionControl) ProtoMessage() {}
 
-func (x *ControlCard) ProtoReflect() protoreflect.Message {
-	mi := &file_server_entitymanager_proto_entity_proto_msgTypes[6]
+func (x *AdmissionControl) ProtoReflect() protoreflect.Message {
+	mi := &file_server_entitymanager_proto_entity_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -481,75 +811,65 @@ func (x *ControlCard) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ControlCard.ProtoReflect.Descriptor instead.
-func (*ControlCard) Descriptor() ([]byte, []int) {
-	return file_server_entitymanager_proto_entity_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use AdmissionControl.ProtoReflect.Descriptor instead.
+func (*AdmissionControl) Descriptor() ([]byte, []int) {
+	return file_server_entitymanager_proto_entity_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *ControlCard) GetPartNumber() string {
+func (x *AdmissionControl) GetMaxInFlight() uint32 {
 	if x != nil {
-		return x.PartNumber
+		return x.MaxInFlight
 	}
-	return ""
+	return 0
 }
 
-func (x *ControlCard) GetSerialNumber() string {
+func (x *AdmissionControl) GetMaxInFlightPerSite() uint32 {
 	if x != nil {
-		return x.SerialNumber
+		return x.MaxInFlightPerSite
 	}
-	return ""
+	return 0
 }
 
-func (x *ControlCard) GetOwnershipVoucher() string {
+func (x *AdmissionControl) GetQueueTimeout() *durationpb.Duration {
 	if x != nil {
-		return x.OwnershipVoucher
+		return x.QueueTimeout
 	}
-	return ""
+	return nil
 }
 
-func (x *ControlCard) GetDhcpConfig() *DHCPConfig {
+func (x *AdmissionControl) GetRetryInterval() *durationpb.Duration {
 	if x != nil {
-		return x.DhcpConfig
+		return x.RetryInterval
 	}
 	return nil
 }
 
-type Chassis struct {
+type VoucherStore struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SerialNumber           string               `protobuf:"bytes,1,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
-	Name                   string               `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	PartNumber             string               `protobuf:"bytes,3,opt,name=part_number,json=partNumber,proto3" json:"part_number,omitempty"`
-	Manufacturer           string               `protobuf:"bytes,4,opt,name=manufacturer,proto3" json:"manufacturer,omitempty"`
-	BootloaderPasswordHash string               `protobuf:"bytes,5,opt,name=bootloader_password_hash,json=bootloaderPasswordHash,proto3" json:"bootloader_password_hash,omitempty"`
-	BootMode               bootz.BootMode       `protobuf:"varint,6,opt,name=boot_mode,json=bootMode,proto3,enum=bootz.proto.BootMode" json:"boot_mode,omitempty"`
-	SoftwareImage          *bootz.SoftwareImage `protobuf:"bytes,7,opt,name=software_image,json=softwareImage,proto3" json:"software_image,omitempty"`
-	ControllerCards        []*ControlCard       `protobuf:"bytes,8,rep,name=controller_cards,json=controllerCards,proto3" json:"controller_cards,omitempty"`
-	Config                 *Config              `protobuf:"bytes,9,opt,name=config,proto3" json:"config,omitempty"`
-	ArtifactDir            string               `protobuf:"bytes,10,opt,name=artifact_dir,json=artifactDir,proto3" json:"artifact_dir,omitempty"`
-	OwnershipVoucher       string               `protobuf:"bytes,11,opt,name=ownership_voucher,json=ownershipVoucher,proto3" json:"ownership_voucher,omitempty"`
-	DhcpConfig             *DHCPConfig          `protobuf:"bytes,12,opt,name=dhcp_config,json=dhcpConfig,proto3" json:"dhcp_config,omitempty"`
+	Directory string `protobuf:"bytes,1,opt,name=directory,proto3" json:"directory,omitempty"`
+	CacheSize uint32 `protobuf:"varint,2,opt,name=cache_size,json=cacheSize,proto3" json:"cache_size,omitempty"`
 }
 
-func (x *Chassis) Reset() {
-	*x = Chassis{}
+func (x *VoucherStore) Reset() {
+	*x = VoucherStore{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_server_entitymanager_proto_entity_proto_msgTypes[7]
+		mi := &file_server_entitymanager_proto_entity_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Chassis) String() string {
+func (x *VoucherStore) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Chassis) ProtoMessage() {}
+func (*VoucherStore) ProtoMessage() {}
 
-func (x *Chassis) ProtoReflect() protoreflect.Message {
-	mi := &file_server_entitymanager_proto_entity_proto_msgTypes[7]
+func (x *VoucherStore) ProtoReflect() protoreflect.Message {
+	mi := &file_server_entitymanager_proto_entity_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -560,93 +880,3335 @@ func (x *Chassis) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Chassis.ProtoReflect.Descriptor instead.
-func (*Chassis) Descriptor() ([]byte, []int) {
-	return file_server_entitymanager_proto_entity_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use VoucherStore.ProtoReflect.Descriptor instead.
+func (*VoucherStore) Descriptor() ([]byte, []int) {
+	return file_server_entitymanager_proto_entity_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *Chassis) GetSerialNumber() string {
+func (x *VoucherStore) GetDirectory() string {
 	if x != nil {
-		return x.SerialNumber
+		return x.Directory
 	}
 	return ""
 }
 
-func (x *Chassis) GetName() string {
+func (x *VoucherStore) GetCacheSize() uint32 {
 	if x != nil {
-		return x.Name
+		return x.CacheSize
 	}
-	return ""
+	return 0
 }
 
-func (x *Chassis) GetPartNumber() string {
-	if x != nil {
-		return x.PartNumber
-	}
-	return ""
+type Alerting struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rules     []*AlertRule `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"`
+	Smtp      *SmtpServer  `protobuf:"bytes,2,opt,name=smtp,proto3" json:"smtp,omitempty"`
+	Pagerduty *PagerDuty   `protobuf:"bytes,3,opt,name=pagerduty,proto3" json:"pagerduty,omitempty"`
 }
 
-func (x *Chassis) GetManufacturer() string {
-	if x != nil {
-		return x.Manufacturer
+func (x *Alerting) Reset() {
+	*x = Alerting{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_entitymanager_proto_entity_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return ""
 }
 
-func (x *Chassis) GetBootloaderPasswordHash() string {
-	if x != nil {
-		return x.BootloaderPasswordHash
-	}
-	return ""
+func (x *Alerting) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *Chassis) GetBootMode() bootz.BootMode {
-	if x != nil {
-		return x.BootMode
+func (*Alerting) ProtoMessage() {}
+
+func (x *Alerting) ProtoReflect() protoreflect.Message {
+	mi := &file_server_entitymanager_proto_entity_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return bootz.BootMode(0)
+	return mi.MessageOf(x)
 }
 
-func (x *Chassis) GetSoftwareImage() *bootz.SoftwareImage {
-	if x != nil {
-		return x.SoftwareImage
-	}
-	return nil
+// Deprecated: Use Alerting.ProtoReflect.Descriptor instead.
+func (*Alerting) Descriptor() ([]byte, []int) {
+	return file_server_entitymanager_proto_entity_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *Chassis) GetControllerCards() []*ControlCard {
+func (x *Alerting) GetRules() []*AlertRule {
 	if x != nil {
-		return x.ControllerCards
+		return x.Rules
 	}
 	return nil
 }
 
-func (x *Chassis) GetConfig() *Config {
+func (x *Alerting) GetSmtp() *SmtpServer {
 	if x != nil {
-		return x.Config
+		return x.Smtp
 	}
 	return nil
 }
 
-func (x *Chassis) GetArtifactDir() string {
+func (x *Alerting) GetPagerduty() *PagerDuty {
 	if x != nil {
-		return x.ArtifactDir
+		return x.Pagerduty
 	}
-	return ""
+	return nil
 }
 
-func (x *Chassis) GetOwnershipVoucher() string {
-	if x != nil {
-		return x.OwnershipVoucher
-	}
-	return ""
+type AlertRule struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Trigger     AlertTrigger  `protobuf:"varint,1,opt,name=trigger,proto3,enum=entity.AlertTrigger" json:"trigger,omitempty"`
+	MaxFailures uint32        `protobuf:"varint,2,opt,name=max_failures,json=maxFailures,proto3" json:"max_failures,omitempty"`
+	Severity    AlertSeverity `protobuf:"varint,3,opt,name=severity,proto3,enum=entity.AlertSeverity" json:"severity,omitempty"`
+	Email       []string      `protobuf:"bytes,4,rep,name=email,proto3" json:"email,omitempty"`
+	Page        bool          `protobuf:"varint,5,opt,name=page,proto3" json:"page,omitempty"`
 }
 
-func (x *Chassis) GetDhcpConfig() *DHCPConfig {
-	if x != nil {
-		return x.DhcpConfig
+func (x *AlertRule) Reset() {
+	*x = AlertRule{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_entitymanager_proto_entity_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return nil
+}
+
+func (x *AlertRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AlertRule) ProtoMessage() {}
+
+func (x *AlertRule) ProtoReflect() protoreflect.Message {
+	mi := &file_server_entitymanager_proto_entity_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AlertRule.ProtoReflect.Descriptor instead.
+func (*AlertRule) Descriptor() ([]byte, []int) {
+	return file_server_entitymanager_proto_entity_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *AlertRule) GetTrigger() AlertTrigger {
+	if x != nil {
+		return x.Trigger
+	}
+	return AlertTrigger_ALERT_TRIGGER_UNSPECIFIED
+}
+
+func (x *AlertRule) GetMaxFailures() uint32 {
+	if x != nil {
+		return x.MaxFailures
+	}
+	return 0
+}
+
+func (x *AlertRule) GetSeverity() AlertSeverity {
+	if x != nil {
+		return x.Severity
+	}
+	return AlertSeverity_ALERT_SEVERITY_UNSPECIFIED
+}
+
+func (x *AlertRule) GetEmail() []string {
+	if x != nil {
+		return x.Email
+	}
+	return nil
+}
+
+func (x *AlertRule) GetPage() bool {
+	if x != nil {
+		return x.Page
+	}
+	return false
+}
+
+type SmtpServer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address      string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	From         string `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	Username     string `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`
+	PasswordFile string `protobuf:"bytes,4,opt,name=password_file,json=passwordFile,proto3" json:"password_file,omitempty"`
+}
+
+func (x *SmtpServer) Reset() {
+	*x = SmtpServer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_entitymanager_proto_entity_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SmtpServer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SmtpServer) ProtoMessage() {}
+
+func (x *SmtpServer) ProtoReflect() protoreflect.Message {
+	mi := &file_server_entitymanager_proto_entity_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SmtpServer.ProtoReflect.Descriptor instead.
+func (*SmtpServer) Descriptor() ([]byte, []int) {
+	return file_server_entitymanager_proto_entity_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SmtpServer) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *SmtpServer) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *SmtpServer) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *SmtpServer) GetPasswordFile() string {
+	if x != nil {
+		return x.PasswordFile
+	}
+	return ""
+}
+
+type PagerDuty struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RoutingKeyFile string `protobuf:"bytes,1,opt,name=routing_key_file,json=routingKeyFile,proto3" json:"routing_key_file,omitempty"`
+	Url            string `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+}
+
+func (x *PagerDuty) Reset() {
+	*x = PagerDuty{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_entitymanager_proto_entity_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PagerDuty) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PagerDuty) ProtoMessage() {}
+
+func (x *PagerDuty) ProtoReflect() protoreflect.Message {
+	mi := &file_server_entitymanager_proto_entity_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PagerDuty.ProtoReflect.Descriptor instead.
+func (*PagerDuty) Descriptor() ([]byte, []int) {
+	return file_server_entitymanager_proto_entity_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *PagerDuty) GetRoutingKeyFile() string {
+	if x != nil {
+		return x.RoutingKeyFile
+	}
+	return ""
+}
+
+func (x *PagerDuty) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+type ProvisioningWindow struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Days     []string `protobuf:"bytes,1,rep,name=days,proto3" json:"days,omitempty"`
+	Start    string   `protobuf:"bytes,2,opt,name=start,proto3" json:"start,omitempty"`
+	End      string   `protobuf:"bytes,3,opt,name=end,proto3" json:"end,omitempty"`
+	TimeZone string   `protobuf:"bytes,4,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+}
+
+func (x *ProvisioningWindow) Reset() {
+	*x = ProvisioningWindow{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_entitymanager_proto_entity_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProvisioningWindow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProvisioningWindow) ProtoMessage() {}
+
+func (x *ProvisioningWindow) ProtoReflect() protoreflect.Message {
+	mi := &file_server_entitymanager_proto_entity_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProvisioningWindow.ProtoReflect.Descriptor instead.
+func (*ProvisioningWindow) Descriptor() ([]byte, []int) {
+	return file_server_entitymanager_proto_entity_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ProvisioningWindow) GetDays() []string {
+	if x != nil {
+		return x.Days
+	}
+	return nil
+}
+
+func (x *ProvisioningWindow) GetStart() string {
+	if x != nil {
+		return x.Start
+	}
+	return ""
+}
+
+func (x *ProvisioningWindow) GetEnd() string {
+	if x != nil {
+		return x.End
+	}
+	return ""
+}
+
+func (x *ProvisioningWindow) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+type BootstrapPolicy struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name       string            `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Expression string            `protobuf:"bytes,2,opt,name=expression,proto3" json:"expression,omitempty"`
+	Custom     string            `protobuf:"bytes,3,opt,name=custom,proto3" json:"custom,omitempty"`
+	Params     map[string]string `protobuf:"bytes,4,rep,name=params,proto3" json:"params,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *BootstrapPolicy) Reset() {
+	*x = BootstrapPolicy{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_entitymanager_proto_entity_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BootstrapPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BootstrapPolicy) ProtoMessage() {}
+
+func (x *BootstrapPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_server_entitymanager_proto_entity_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BootstrapPolicy.ProtoReflect.Descriptor instead.
+func (*BootstrapPolicy) Descriptor() ([]byte, []int) {
+	return file_server_entitymanager_proto_entity_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *BootstrapPolicy) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *BootstrapPolicy) GetExpression() string {
+	if x != nil {
+		return x.Expression
+	}
+	return ""
+}
+
+func (x *BootstrapPolicy) GetCustom() string {
+	if x != nil {
+		return x.Custom
+	}
+	return ""
+}
+
+func (x *BootstrapPolicy) GetParams() map[string]string {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+type Cluster struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Store:
+	//	*Cluster_Redis
+	Store         isCluster_Store      `protobuf_oneof:"store"`
+	KeyPrefix     string               `protobuf:"bytes,2,opt,name=key_prefix,json=keyPrefix,proto3" json:"key_prefix,omitempty"`
+	SignatureTtl  *durationpb.Duration `protobuf:"bytes,3,opt,name=signature_ttl,json=signatureTtl,proto3" json:"signature_ttl,omitempty"`
+	LeaseDuration *durationpb.Duration `protobuf:"bytes,4,opt,name=lease_duration,json=leaseDuration,proto3" json:"lease_duration,omitempty"`
+}
+
+func (x *Cluster) Reset() {
+	*x = Cluster{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_entitymanager_proto_entity_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Cluster) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Cluster) ProtoMessage() {}
+
+func (x *Cluster) ProtoReflect() protoreflect.Message {
+	mi := &file_server_entitymanager_proto_entity_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Cluster.ProtoReflect.Descriptor instead.
+func (*Cluster) Descriptor() ([]byte, []int) {
+	return file_server_entitymanager_proto_entity_proto_rawDescGZIP(), []int{10}
+}
+
+func (m *Cluster) GetStore() isCluster_Store {
+	if m != nil {
+		return m.Store
+	}
+	return nil
+}
+
+func (x *Cluster) GetRedis() *RedisStore {
+	if x, ok := x.GetStore().(*Cluster_Redis); ok {
+		return x.Redis
+	}
+	return nil
+}
+
+func (x *Cluster) GetKeyPrefix() string {
+	if x != nil {
+		return x.KeyPrefix
+	}
+	return ""
+}
+
+func (x *Cluster) GetSignatureTtl() *durationpb.Duration {
+	if x != nil {
+		return x.SignatureTtl
+	}
+	return nil
+}
+
+func (x *Cluster) GetLeaseDuration() *durationpb.Duration {
+	if x != nil {
+		return x.LeaseDuration
+	}
+	return nil
+}
+
+type isCluster_Store interface {
+	isCluster_Store()
+}
+
+type Cluster_Redis struct {
+	Redis *RedisStore `protobuf:"bytes,1,opt,name=redis,proto3,oneof"`
+}
+
+func (*Cluster_Redis) isCluster_Store() {}
+
+type RedisStore struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address       string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	PasswordFile  string `protobuf:"bytes,2,opt,name=password_file,json=passwordFile,proto3" json:"password_file,omitempty"`
+	Db            uint32 `protobuf:"varint,3,opt,name=db,proto3" json:"db,omitempty"`
+	Tls           bool   `protobuf:"varint,4,opt,name=tls,proto3" json:"tls,omitempty"`
+	TlsCaCertFile string `protobuf:"bytes,5,opt,name=tls_ca_cert_file,json=tlsCaCertFile,proto3" json:"tls_ca_cert_file,omitempty"`
+	TlsCertFile   string `protobuf:"bytes,6,opt,name=tls_cert_file,json=tlsCertFile,proto3" json:"tls_cert_file,omitempty"`
+	TlsKeyFile    string `protobuf:"bytes,7,opt,name=tls_key_file,json=tlsKeyFile,proto3" json:"tls_key_file,omitempty"`
+	PoolSize      uint32 `protobuf:"varint,8,opt,name=pool_size,json=poolSize,proto3" json:"pool_size,omitempty"`
+}
+
+func (x *RedisStore) Reset() {
+	*x = RedisStore{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_entitymanager_proto_entity_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RedisStore) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RedisStore) ProtoMessage() {}
+
+func (x *RedisStore) ProtoReflect() protoreflect.Message {
+	mi := &file_server_entitymanager_proto_entity_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RedisStore.ProtoReflect.Descriptor instead.
+func (*RedisStore) Descriptor() ([]byte, []int) {
+	return file_server_entitymanager_proto_entity_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RedisStore) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *RedisStore) GetPasswordFile() string {
+	if x != nil {
+		return x.PasswordFile
+	}
+	return ""
+}
+
+func (x *RedisStore) GetDb() uint32 {
+	if x != nil {
+		return x.Db
+	}
+	return 0
+}
+
+func (x *RedisStore) GetTls() bool {
+	if x != nil {
+		return x.Tls
+	}
+	return false
+}
+
+func (x *RedisStore) GetTlsCaCertFile() string {
+	if x != nil {
+		return x.TlsCaCertFile
+	}
+	return ""
+}
+
+func (x *RedisStore) GetTlsCertFile() string {
+	if x != nil {
+		return x.TlsCertFile
+	}
+	return ""
+}
+
+func (x *RedisStore) GetTlsKeyFile() string {
+	if x != nil {
+		return x.TlsKeyFile
+	}
+	return ""
+}
+
+func (x *RedisStore) GetPoolSize() uint32 {
+	if x != nil {
+		return x.PoolSize
+	}
+	return 0
+}
+
+type ResponseCache struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Size uint32               `protobuf:"varint,1,opt,name=size,proto3" json:"size,omitempty"`
+	Ttl  *durationpb.Duration `protobuf:"bytes,2,opt,name=ttl,proto3" json:"ttl,omitempty"`
+}
+
+func (x *ResponseCache) Reset() {
+	*x = ResponseCache{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_entitymanager_proto_entity_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResponseCache) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResponseCache) ProtoMessage() {}
+
+func (x *ResponseCache) ProtoReflect() protoreflect.Message {
+	mi := &file_server_entitymanager_proto_entity_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResponseCache.ProtoReflect.Descriptor instead.
+func (*ResponseCache) Descriptor() ([]byte, []int) {
+	return file_server_entitymanager_proto_entity_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ResponseCache) GetSize() uint32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *ResponseCache) GetTtl() *durationpb.Duration {
+	if x != nil {
+		return x.Ttl
+	}
+	return nil
+}
+
+type Timeouts struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ResolveChassis *durationpb.Duration `protobuf:"bytes,1,opt,name=resolve_chassis,json=resolveChassis,proto3" json:"resolve_chassis,omitempty"`
+	BootstrapData  *durationpb.Duration `protobuf:"bytes,2,opt,name=bootstrap_data,json=bootstrapData,proto3" json:"bootstrap_data,omitempty"`
+	Sign           *durationpb.Duration `protobuf:"bytes,3,opt,name=sign,proto3" json:"sign,omitempty"`
+	ReportStatus   *durationpb.Duration `protobuf:"bytes,4,opt,name=report_status,json=reportStatus,proto3" json:"report_status,omitempty"`
+}
+
+func (x *Timeouts) Reset() {
+	*x = Timeouts{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_entitymanager_proto_entity_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Timeouts) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Timeouts) ProtoMessage() {}
+
+func (x *Timeouts) ProtoReflect() protoreflect.Message {
+	mi := &file_server_entitymanager_proto_entity_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Timeouts.ProtoReflect.Descriptor instead.
+func (*Timeouts) Descriptor() ([]byte, []int) {
+	return file_server_entitymanager_proto_entity_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Timeouts) GetResolveChassis() *durationpb.Duration {
+	if x != nil {
+		return x.ResolveChassis
+	}
+	return nil
+}
+
+func (x *Timeouts) GetBootstrapData() *durationpb.Duration {
+	if x != nil {
+		return x.BootstrapData
+	}
+	return nil
+}
+
+func (x *Timeouts) GetSign() *durationpb.Duration {
+	if x != nil {
+		return x.Sign
+	}
+	return nil
+}
+
+func (x *Timeouts) GetReportStatus() *durationpb.Duration {
+	if x != nil {
+		return x.ReportStatus
+	}
+	return nil
+}
+
+type RequestLogging struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Verbosity    uint32   `protobuf:"varint,1,opt,name=verbosity,proto3" json:"verbosity,omitempty"`
+	RedactFields []string `protobuf:"bytes,2,rep,name=redact_fields,json=redactFields,proto3" json:"redact_fields,omitempty"`
+}
+
+func (x *RequestLogging) Reset() {
+	*x = RequestLogging{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_entitymanager_proto_entity_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RequestLogging) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestLogging) ProtoMessage() {}
+
+func (x *RequestLogging) ProtoReflect() protoreflect.Message {
+	mi := &file_server_entitymanager_proto_entity_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestLogging.ProtoReflect.Descriptor instead.
+func (*RequestLogging) Descriptor() ([]byte, []int) {
+	return file_server_entitymanager_proto_entity_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *RequestLogging) GetVerbosity() uint32 {
+	if x != nil {
+		return x.Verbosity
+	}
+	return 0
+}
+
+func (x *RequestLogging) GetRedactFields() []string {
+	if x != nil {
+		return x.RedactFields
+	}
+	return nil
+}
+
+type ArtifactSync struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Buckets         []*ArtifactBucket    `protobuf:"bytes,1,rep,name=buckets,proto3" json:"buckets,omitempty"`
+	RefreshInterval *durationpb.Duration `protobuf:"bytes,2,opt,name=refresh_interval,json=refreshInterval,proto3" json:"refresh_interval,omitempty"`
+}
+
+func (x *ArtifactSync) Reset() {
+	*x = ArtifactSync{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_entitymanager_proto_entity_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ArtifactSync) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArtifactSync) ProtoMessage() {}
+
+func (x *ArtifactSync) ProtoReflect() protoreflect.Message {
+	mi := &file_server_entitymanager_proto_entity_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArtifactSync.ProtoReflect.Descriptor instead.
+func (*ArtifactSync) Descriptor() ([]byte, []int) {
+	return file_server_entitymanager_proto_entity_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ArtifactSync) GetBuckets() []*ArtifactBucket {
+	if x != nil {
+		return x.Buckets
+	}
+	return nil
+}
+
+func (x *ArtifactSync) GetRefreshInterval() *durationpb.Duration {
+	if x != nil {
+		return x.RefreshInterval
+	}
+	return nil
+}
+
+type ArtifactBucket struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Url             string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Directory       string `protobuf:"bytes,2,opt,name=directory,proto3" json:"directory,omitempty"`
+	Endpoint        string `protobuf:"bytes,3,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	Region          string `protobuf:"bytes,4,opt,name=region,proto3" json:"region,omitempty"`
+	CredentialsFile string `protobuf:"bytes,5,opt,name=credentials_file,json=credentialsFile,proto3" json:"credentials_file,omitempty"`
+}
+
+func (x *ArtifactBucket) Reset() {
+	*x = ArtifactBucket{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_entitymanager_proto_entity_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ArtifactBucket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArtifactBucket) ProtoMessage() {}
+
+func (x *ArtifactBucket) ProtoReflect() protoreflect.Message {
+	mi := &file_server_entitymanager_proto_entity_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArtifactBucket.ProtoReflect.Descriptor instead.
+func (*ArtifactBucket) Descriptor() ([]byte, []int) {
+	return file_server_entitymanager_proto_entity_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ArtifactBucket) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *ArtifactBucket) GetDirectory() string {
+	if x != nil {
+		return x.Directory
+	}
+	return ""
+}
+
+func (x *ArtifactBucket) GetEndpoint() string {
+	if x != nil {
+		return x.Endpoint
+	}
+	return ""
+}
+
+func (x *ArtifactBucket) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *ArtifactBucket) GetCredentialsFile() string {
+	if x != nil {
+		return x.CredentialsFile
+	}
+	return ""
+}
+
+type FaultInjection struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Faults []*FaultInjection_Fault `protobuf:"bytes,1,rep,name=faults,proto3" json:"faults,omitempty"`
+}
+
+func (x *FaultInjection) Reset() {
+	*x = FaultInjection{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_entitymanager_proto_entity_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FaultInjection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FaultInjection) ProtoMessage() {}
+
+func (x *FaultInjection) ProtoReflect() protoreflect.Message {
+	mi := &file_server_entitymanager_proto_entity_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FaultInjection.ProtoReflect.Descriptor instead.
+func (*FaultInjection) Descriptor() ([]byte, []int) {
+	return file_server_entitymanager_proto_entity_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *FaultInjection) GetFaults() []*FaultInjection_Fault {
+	if x != nil {
+		return x.Faults
+	}
+	return nil
+}
+
+type SerialNormalization struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	IgnoreCase       bool     `protobuf:"varint,1,opt,name=ignore_case,json=ignoreCase,proto3" json:"ignore_case,omitempty"`
+	StripPrefixes    []string `protobuf:"bytes,2,rep,name=strip_prefixes,json=stripPrefixes,proto3" json:"strip_prefixes,omitempty"`
+	IgnoreSeparators bool     `protobuf:"varint,3,opt,name=ignore_separators,json=ignoreSeparators,proto3" json:"ignore_separators,omitempty"`
+}
+
+func (x *SerialNormalization) Reset() {
+	*x = SerialNormalization{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_entitymanager_proto_entity_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SerialNormalization) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SerialNormalization) ProtoMessage() {}
+
+func (x *SerialNormalization) ProtoReflect() protoreflect.Message {
+	mi := &file_server_entitymanager_proto_entity_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SerialNormalization.ProtoReflect.Descriptor instead.
+func (*SerialNormalization) Descriptor() ([]byte, []int) {
+	return file_server_entitymanager_proto_entity_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *SerialNormalization) GetIgnoreCase() bool {
+	if x != nil {
+		return x.IgnoreCase
+	}
+	return false
+}
+
+func (x *SerialNormalization) GetStripPrefixes() []string {
+	if x != nil {
+		return x.StripPrefixes
+	}
+	return nil
+}
+
+func (x *SerialNormalization) GetIgnoreSeparators() bool {
+	if x != nil {
+		return x.IgnoreSeparators
+	}
+	return false
+}
+
+type OwnershipDomain struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name            string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	ArtifactDir     string   `protobuf:"bytes,2,opt,name=artifact_dir,json=artifactDir,proto3" json:"artifact_dir,omitempty"`
+	OcKeyUri        string   `protobuf:"bytes,3,opt,name=oc_key_uri,json=ocKeyUri,proto3" json:"oc_key_uri,omitempty"`
+	PdcKeyUri       string   `protobuf:"bytes,4,opt,name=pdc_key_uri,json=pdcKeyUri,proto3" json:"pdc_key_uri,omitempty"`
+	AdminIdentities []string `protobuf:"bytes,5,rep,name=admin_identities,json=adminIdentities,proto3" json:"admin_identities,omitempty"`
+}
+
+func (x *OwnershipDomain) Reset() {
+	*x = OwnershipDomain{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_entitymanager_proto_entity_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OwnershipDomain) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OwnershipDomain) ProtoMessage() {}
+
+func (x *OwnershipDomain) ProtoReflect() protoreflect.Message {
+	mi := &file_server_entitymanager_proto_entity_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OwnershipDomain.ProtoReflect.Descriptor instead.
+func (*OwnershipDomain) Descriptor() ([]byte, []int) {
+	return file_server_entitymanager_proto_entity_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *OwnershipDomain) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *OwnershipDomain) GetArtifactDir() string {
+	if x != nil {
+		return x.ArtifactDir
+	}
+	return ""
+}
+
+func (x *OwnershipDomain) GetOcKeyUri() string {
+	if x != nil {
+		return x.OcKeyUri
+	}
+	return ""
+}
+
+func (x *OwnershipDomain) GetPdcKeyUri() string {
+	if x != nil {
+		return x.PdcKeyUri
+	}
+	return ""
+}
+
+func (x *OwnershipDomain) GetAdminIdentities() []string {
+	if x != nil {
+		return x.AdminIdentities
+	}
+	return nil
+}
+
+type NetBoxSource struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Url          string               `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	TokenFile    string               `protobuf:"bytes,2,opt,name=token_file,json=tokenFile,proto3" json:"token_file,omitempty"`
+	Filters      map[string]string    `protobuf:"bytes,3,rep,name=filters,proto3" json:"filters,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	RoleConfigs  map[string]*Config   `protobuf:"bytes,4,rep,name=role_configs,json=roleConfigs,proto3" json:"role_configs,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	SyncInterval *durationpb.Duration `protobuf:"bytes,5,opt,name=sync_interval,json=syncInterval,proto3" json:"sync_interval,omitempty"`
+}
+
+func (x *NetBoxSource) Reset() {
+	*x = NetBoxSource{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_entitymanager_proto_entity_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NetBoxSource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NetBoxSource) ProtoMessage() {}
+
+func (x *NetBoxSource) ProtoReflect() protoreflect.Message {
+	mi := &file_server_entitymanager_proto_entity_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NetBoxSource.ProtoReflect.Descriptor instead.
+func (*NetBoxSource) Descriptor() ([]byte, []int) {
+	return file_server_entitymanager_proto_entity_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *NetBoxSource) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *NetBoxSource) GetTokenFile() string {
+	if x != nil {
+		return x.TokenFile
+	}
+	return ""
+}
+
+func (x *NetBoxSource) GetFilters() map[string]string {
+	if x != nil {
+		return x.Filters
+	}
+	return nil
+}
+
+func (x *NetBoxSource) GetRoleConfigs() map[string]*Config {
+	if x != nil {
+		return x.RoleConfigs
+	}
+	return nil
+}
+
+func (x *NetBoxSource) GetSyncInterval() *durationpb.Duration {
+	if x != nil {
+		return x.SyncInterval
+	}
+	return nil
+}
+
+type MasaSource struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Url           string               `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	CaCertFile    string               `protobuf:"bytes,2,opt,name=ca_cert_file,json=caCertFile,proto3" json:"ca_cert_file,omitempty"`
+	Manufacturers []string             `protobuf:"bytes,3,rep,name=manufacturers,proto3" json:"manufacturers,omitempty"`
+	SyncInterval  *durationpb.Duration `protobuf:"bytes,4,opt,name=sync_interval,json=syncInterval,proto3" json:"sync_interval,omitempty"`
+}
+
+func (x *MasaSource) Reset() {
+	*x = MasaSource{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_entitymanager_proto_entity_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MasaSource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MasaSource) ProtoMessage() {}
+
+func (x *MasaSource) ProtoReflect() protoreflect.Message {
+	mi := &file_server_entitymanager_proto_entity_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MasaSource.ProtoReflect.Descriptor instead.
+func (*MasaSource) Descriptor() ([]byte, []int) {
+	return file_server_entitymanager_proto_entity_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *MasaSource) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *MasaSource) GetCaCertFile() string {
+	if x != nil {
+		return x.CaCertFile
+	}
+	return ""
+}
+
+func (x *MasaSource) GetManufacturers() []string {
+	if x != nil {
+		return x.Manufacturers
+	}
+	return nil
+}
+
+func (x *MasaSource) GetSyncInterval() *durationpb.Duration {
+	if x != nil {
+		return x.SyncInterval
+	}
+	return nil
+}
+
+type RedirectRule struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SourceSubnets  []string                `protobuf:"bytes,1,rep,name=source_subnets,json=sourceSubnets,proto3" json:"source_subnets,omitempty"`
+	SerialPrefixes []string                `protobuf:"bytes,2,rep,name=serial_prefixes,json=serialPrefixes,proto3" json:"serial_prefixes,omitempty"`
+	Servers        []*bootz.RedirectServer `protobuf:"bytes,3,rep,name=servers,proto3" json:"servers,omitempty"`
+}
+
+func (x *RedirectRule) Reset() {
+	*x = RedirectRule{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_entitymanager_proto_entity_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RedirectRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RedirectRule) ProtoMessage() {}
+
+func (x *RedirectRule) ProtoReflect() protoreflect.Message {
+	mi := &file_server_entitymanager_proto_entity_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RedirectRule.ProtoReflect.Descriptor instead.
+func (*RedirectRule) Descriptor() ([]byte, []int) {
+	return file_server_entitymanager_proto_entity_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *RedirectRule) GetSourceSubnets() []string {
+	if x != nil {
+		return x.SourceSubnets
+	}
+	return nil
+}
+
+func (x *RedirectRule) GetSerialPrefixes() []string {
+	if x != nil {
+		return x.SerialPrefixes
+	}
+	return nil
+}
+
+func (x *RedirectRule) GetServers() []*bootz.RedirectServer {
+	if x != nil {
+		return x.Servers
+	}
+	return nil
+}
+
+type UrlRewriteRule struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SourceSubnets []string `protobuf:"bytes,1,rep,name=source_subnets,json=sourceSubnets,proto3" json:"source_subnets,omitempty"`
+	Sites         []string `protobuf:"bytes,2,rep,name=sites,proto3" json:"sites,omitempty"`
+	UrlPrefix     string   `protobuf:"bytes,3,opt,name=url_prefix,json=urlPrefix,proto3" json:"url_prefix,omitempty"`
+	Replacement   string   `protobuf:"bytes,4,opt,name=replacement,proto3" json:"replacement,omitempty"`
+}
+
+func (x *UrlRewriteRule) Reset() {
+	*x = UrlRewriteRule{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_entitymanager_proto_entity_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UrlRewriteRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UrlRewriteRule) ProtoMessage() {}
+
+func (x *UrlRewriteRule) ProtoReflect() protoreflect.Message {
+	mi := &file_server_entitymanager_proto_entity_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UrlRewriteRule.ProtoReflect.Descriptor instead.
+func (*UrlRewriteRule) Descriptor() ([]byte, []int) {
+	return file_server_entitymanager_proto_entity_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *UrlRewriteRule) GetSourceSubnets() []string {
+	if x != nil {
+		return x.SourceSubnets
+	}
+	return nil
+}
+
+func (x *UrlRewriteRule) GetSites() []string {
+	if x != nil {
+		return x.Sites
+	}
+	return nil
+}
+
+func (x *UrlRewriteRule) GetUrlPrefix() string {
+	if x != nil {
+		return x.UrlPrefix
+	}
+	return ""
+}
+
+func (x *UrlRewriteRule) GetReplacement() string {
+	if x != nil {
+		return x.Replacement
+	}
+	return ""
+}
+
+type VendorQuirks struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Manufacturers                []string `protobuf:"bytes,1,rep,name=manufacturers,proto3" json:"manufacturers,omitempty"`
+	CanonicalManufacturer        string   `protobuf:"bytes,2,opt,name=canonical_manufacturer,json=canonicalManufacturer,proto3" json:"canonical_manufacturer,omitempty"`
+	SerialInControlCard          bool     `protobuf:"varint,3,opt,name=serial_in_control_card,json=serialInControlCard,proto3" json:"serial_in_control_card,omitempty"`
+	DefaultPartNumber            string   `protobuf:"bytes,4,opt,name=default_part_number,json=defaultPartNumber,proto3" json:"default_part_number,omitempty"`
+	DefaultControlCardPartNumber string   `protobuf:"bytes,5,opt,name=default_control_card_part_number,json=defaultControlCardPartNumber,proto3" json:"default_control_card_part_number,omitempty"`
+}
+
+func (x *VendorQuirks) Reset() {
+	*x = VendorQuirks{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_entitymanager_proto_entity_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VendorQuirks) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VendorQuirks) ProtoMessage() {}
+
+func (x *VendorQuirks) ProtoReflect() protoreflect.Message {
+	mi := &file_server_entitymanager_proto_entity_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VendorQuirks.ProtoReflect.Descriptor instead.
+func (*VendorQuirks) Descriptor() ([]byte, []int) {
+	return file_server_entitymanager_proto_entity_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *VendorQuirks) GetManufacturers() []string {
+	if x != nil {
+		return x.Manufacturers
+	}
+	return nil
+}
+
+func (x *VendorQuirks) GetCanonicalManufacturer() string {
+	if x != nil {
+		return x.CanonicalManufacturer
+	}
+	return ""
+}
+
+func (x *VendorQuirks) GetSerialInControlCard() bool {
+	if x != nil {
+		return x.SerialInControlCard
+	}
+	return false
+}
+
+func (x *VendorQuirks) GetDefaultPartNumber() string {
+	if x != nil {
+		return x.DefaultPartNumber
+	}
+	return ""
+}
+
+func (x *VendorQuirks) GetDefaultControlCardPartNumber() string {
+	if x != nil {
+		return x.DefaultControlCardPartNumber
+	}
+	return ""
+}
+
+type Authenticator struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SourceSubnets []string          `protobuf:"bytes,1,rep,name=source_subnets,json=sourceSubnets,proto3" json:"source_subnets,omitempty"`
+	TokenHeader   *TokenHeader      `protobuf:"bytes,2,opt,name=token_header,json=tokenHeader,proto3" json:"token_header,omitempty"`
+	Custom        string            `protobuf:"bytes,3,opt,name=custom,proto3" json:"custom,omitempty"`
+	Params        map[string]string `protobuf:"bytes,4,rep,name=params,proto3" json:"params,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *Authenticator) Reset() {
+	*x = Authenticator{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_entitymanager_proto_entity_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Authenticator) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Authenticator) ProtoMessage() {}
+
+func (x *Authenticator) ProtoReflect() protoreflect.Message {
+	mi := &file_server_entitymanager_proto_entity_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Authenticator.ProtoReflect.Descriptor instead.
+func (*Authenticator) Descriptor() ([]byte, []int) {
+	return file_server_entitymanager_proto_entity_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *Authenticator) GetSourceSubnets() []string {
+	if x != nil {
+		return x.SourceSubnets
+	}
+	return nil
+}
+
+func (x *Authenticator) GetTokenHeader() *TokenHeader {
+	if x != nil {
+		return x.TokenHeader
+	}
+	return nil
+}
+
+func (x *Authenticator) GetCustom() string {
+	if x != nil {
+		return x.Custom
+	}
+	return ""
+}
+
+func (x *Authenticator) GetParams() map[string]string {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+type TokenHeader struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Header    string `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	TokenFile string `protobuf:"bytes,2,opt,name=token_file,json=tokenFile,proto3" json:"token_file,omitempty"`
+}
+
+func (x *TokenHeader) Reset() {
+	*x = TokenHeader{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_entitymanager_proto_entity_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TokenHeader) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TokenHeader) ProtoMessage() {}
+
+func (x *TokenHeader) ProtoReflect() protoreflect.Message {
+	mi := &file_server_entitymanager_proto_entity_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TokenHeader.ProtoReflect.Descriptor instead.
+func (*TokenHeader) Descriptor() ([]byte, []int) {
+	return file_server_entitymanager_proto_entity_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *TokenHeader) GetHeader() string {
+	if x != nil {
+		return x.Header
+	}
+	return ""
+}
+
+func (x *TokenHeader) GetTokenFile() string {
+	if x != nil {
+		return x.TokenFile
+	}
+	return ""
+}
+
+type OwnershipRotation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ArtifactDir   string                 `protobuf:"bytes,1,opt,name=artifact_dir,json=artifactDir,proto3" json:"artifact_dir,omitempty"`
+	OcKeyUri      string                 `protobuf:"bytes,2,opt,name=oc_key_uri,json=ocKeyUri,proto3" json:"oc_key_uri,omitempty"`
+	PdcKeyUri     string                 `protobuf:"bytes,3,opt,name=pdc_key_uri,json=pdcKeyUri,proto3" json:"pdc_key_uri,omitempty"`
+	TransitionEnd *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=transition_end,json=transitionEnd,proto3" json:"transition_end,omitempty"`
+}
+
+func (x *OwnershipRotation) Reset() {
+	*x = OwnershipRotation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_entitymanager_proto_entity_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OwnershipRotation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OwnershipRotation) ProtoMessage() {}
+
+func (x *OwnershipRotation) ProtoReflect() protoreflect.Message {
+	mi := &file_server_entitymanager_proto_entity_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OwnershipRotation.ProtoReflect.Descriptor instead.
+func (*OwnershipRotation) Descriptor() ([]byte, []int) {
+	return file_server_entitymanager_proto_entity_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *OwnershipRotation) GetArtifactDir() string {
+	if x != nil {
+		return x.ArtifactDir
+	}
+	return ""
+}
+
+func (x *OwnershipRotation) GetOcKeyUri() string {
+	if x != nil {
+		return x.OcKeyUri
+	}
+	return ""
+}
+
+func (x *OwnershipRotation) GetPdcKeyUri() string {
+	if x != nil {
+		return x.PdcKeyUri
+	}
+	return ""
+}
+
+func (x *OwnershipRotation) GetTransitionEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.TransitionEnd
+	}
+	return nil
+}
+
+type ConfigValidator struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ArtifactType   bootz.ArtifactType `protobuf:"varint,1,opt,name=artifact_type,json=artifactType,proto3,enum=bootz.proto.ArtifactType" json:"artifact_type,omitempty"`
+	JsonSchemaFile string             `protobuf:"bytes,2,opt,name=json_schema_file,json=jsonSchemaFile,proto3" json:"json_schema_file,omitempty"`
+	Command        []string           `protobuf:"bytes,3,rep,name=command,proto3" json:"command,omitempty"`
+}
+
+func (x *ConfigValidator) Reset() {
+	*x = ConfigValidator{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_entitymanager_proto_entity_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConfigValidator) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigValidator) ProtoMessage() {}
+
+func (x *ConfigValidator) ProtoReflect() protoreflect.Message {
+	mi := &file_server_entitymanager_proto_entity_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigValidator.ProtoReflect.Descriptor instead.
+func (*ConfigValidator) Descriptor() ([]byte, []int) {
+	return file_server_entitymanager_proto_entity_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ConfigValidator) GetArtifactType() bootz.ArtifactType {
+	if x != nil {
+		return x.ArtifactType
+	}
+	return bootz.ArtifactType(0)
+}
+
+func (x *ConfigValidator) GetJsonSchemaFile() string {
+	if x != nil {
+		return x.JsonSchemaFile
+	}
+	return ""
+}
+
+func (x *ConfigValidator) GetCommand() []string {
+	if x != nil {
+		return x.Command
+	}
+	return nil
+}
+
+type ModelImage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Manufacturer  string               `protobuf:"bytes,1,opt,name=manufacturer,proto3" json:"manufacturer,omitempty"`
+	PartNumber    string               `protobuf:"bytes,2,opt,name=part_number,json=partNumber,proto3" json:"part_number,omitempty"`
+	SoftwareImage *bootz.SoftwareImage `protobuf:"bytes,3,opt,name=software_image,json=softwareImage,proto3" json:"software_image,omitempty"`
+}
+
+func (x *ModelImage) Reset() {
+	*x = ModelImage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_entitymanager_proto_entity_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ModelImage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModelImage) ProtoMessage() {}
+
+func (x *ModelImage) ProtoReflect() protoreflect.Message {
+	mi := &file_server_entitymanager_proto_entity_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModelImage.ProtoReflect.Descriptor instead.
+func (*ModelImage) Descriptor() ([]byte, []int) {
+	return file_server_entitymanager_proto_entity_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ModelImage) GetManufacturer() string {
+	if x != nil {
+		return x.Manufacturer
+	}
+	return ""
+}
+
+func (x *ModelImage) GetPartNumber() string {
+	if x != nil {
+		return x.PartNumber
+	}
+	return ""
+}
+
+func (x *ModelImage) GetSoftwareImage() *bootz.SoftwareImage {
+	if x != nil {
+		return x.SoftwareImage
+	}
+	return nil
+}
+
+type Experiment struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name                string               `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Devices             []*AllowedDevice     `protobuf:"bytes,2,rep,name=devices,proto3" json:"devices,omitempty"`
+	TreatmentPercentage uint32               `protobuf:"varint,3,opt,name=treatment_percentage,json=treatmentPercentage,proto3" json:"treatment_percentage,omitempty"`
+	SoftwareImage       *bootz.SoftwareImage `protobuf:"bytes,4,opt,name=software_image,json=softwareImage,proto3" json:"software_image,omitempty"`
+	Config              *Config              `protobuf:"bytes,5,opt,name=config,proto3" json:"config,omitempty"`
+}
+
+func (x *Experiment) Reset() {
+	*x = Experiment{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_entitymanager_proto_entity_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Experiment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Experiment) ProtoMessage() {}
+
+func (x *Experiment) ProtoReflect() protoreflect.Message {
+	mi := &file_server_entitymanager_proto_entity_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Experiment.ProtoReflect.Descriptor instead.
+func (*Experiment) Descriptor() ([]byte, []int) {
+	return file_server_entitymanager_proto_entity_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *Experiment) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Experiment) GetDevices() []*AllowedDevice {
+	if x != nil {
+		return x.Devices
+	}
+	return nil
+}
+
+func (x *Experiment) GetTreatmentPercentage() uint32 {
+	if x != nil {
+		return x.TreatmentPercentage
+	}
+	return 0
+}
+
+func (x *Experiment) GetSoftwareImage() *bootz.SoftwareImage {
+	if x != nil {
+		return x.SoftwareImage
+	}
+	return nil
+}
+
+func (x *Experiment) GetConfig() *Config {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+type DefaultProfile struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Allowlist     []*AllowedDevice     `protobuf:"bytes,1,rep,name=allowlist,proto3" json:"allowlist,omitempty"`
+	BootMode      bootz.BootMode       `protobuf:"varint,2,opt,name=boot_mode,json=bootMode,proto3,enum=bootz.proto.BootMode" json:"boot_mode,omitempty"`
+	SoftwareImage *bootz.SoftwareImage `protobuf:"bytes,3,opt,name=software_image,json=softwareImage,proto3" json:"software_image,omitempty"`
+	Config        *Config              `protobuf:"bytes,4,opt,name=config,proto3" json:"config,omitempty"`
+}
+
+func (x *DefaultProfile) Reset() {
+	*x = DefaultProfile{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_entitymanager_proto_entity_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DefaultProfile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DefaultProfile) ProtoMessage() {}
+
+func (x *DefaultProfile) ProtoReflect() protoreflect.Message {
+	mi := &file_server_entitymanager_proto_entity_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DefaultProfile.ProtoReflect.Descriptor instead.
+func (*DefaultProfile) Descriptor() ([]byte, []int) {
+	return file_server_entitymanager_proto_entity_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *DefaultProfile) GetAllowlist() []*AllowedDevice {
+	if x != nil {
+		return x.Allowlist
+	}
+	return nil
+}
+
+func (x *DefaultProfile) GetBootMode() bootz.BootMode {
+	if x != nil {
+		return x.BootMode
+	}
+	return bootz.BootMode(0)
+}
+
+func (x *DefaultProfile) GetSoftwareImage() *bootz.SoftwareImage {
+	if x != nil {
+		return x.SoftwareImage
+	}
+	return nil
+}
+
+func (x *DefaultProfile) GetConfig() *Config {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+type AllowedDevice struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Manufacturer string `protobuf:"bytes,1,opt,name=manufacturer,proto3" json:"manufacturer,omitempty"`
+	PartNumber   string `protobuf:"bytes,2,opt,name=part_number,json=partNumber,proto3" json:"part_number,omitempty"`
+}
+
+func (x *AllowedDevice) Reset() {
+	*x = AllowedDevice{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_entitymanager_proto_entity_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AllowedDevice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AllowedDevice) ProtoMessage() {}
+
+func (x *AllowedDevice) ProtoReflect() protoreflect.Message {
+	mi := &file_server_entitymanager_proto_entity_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AllowedDevice.ProtoReflect.Descriptor instead.
+func (*AllowedDevice) Descriptor() ([]byte, []int) {
+	return file_server_entitymanager_proto_entity_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *AllowedDevice) GetManufacturer() string {
+	if x != nil {
+		return x.Manufacturer
+	}
+	return ""
+}
+
+func (x *AllowedDevice) GetPartNumber() string {
+	if x != nil {
+		return x.PartNumber
+	}
+	return ""
+}
+
+type FeatureFlag struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name    string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Enabled bool   `protobuf:"varint,2,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Tenant  string `protobuf:"bytes,3,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	Site    string `protobuf:"bytes,4,opt,name=site,proto3" json:"site,omitempty"`
+}
+
+func (x *FeatureFlag) Reset() {
+	*x = FeatureFlag{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_entitymanager_proto_entity_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FeatureFlag) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeatureFlag) ProtoMessage() {}
+
+func (x *FeatureFlag) ProtoReflect() protoreflect.Message {
+	mi := &file_server_entitymanager_proto_entity_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeatureFlag.ProtoReflect.Descriptor instead.
+func (*FeatureFlag) Descriptor() ([]byte, []int) {
+	return file_server_entitymanager_proto_entity_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *FeatureFlag) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FeatureFlag) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *FeatureFlag) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+func (x *FeatureFlag) GetSite() string {
+	if x != nil {
+		return x.Site
+	}
+	return ""
+}
+
+type Snapshot struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Version             uint32                                              `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	CreatedAt           *timestamppb.Timestamp                              `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Options             *Options                                            `protobuf:"bytes,3,opt,name=options,proto3" json:"options,omitempty"`
+	Chassis             []*Chassis                                          `protobuf:"bytes,4,rep,name=chassis,proto3" json:"chassis,omitempty"`
+	ControlCardStatuses map[string]bootz.ControlCardState_ControlCardStatus `protobuf:"bytes,5,rep,name=control_card_statuses,json=controlCardStatuses,proto3" json:"control_card_statuses,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3,enum=bootz.proto.ControlCardState_ControlCardStatus"`
+	DeviceStates        []*DeviceStateRecord                                `protobuf:"bytes,6,rep,name=device_states,json=deviceStates,proto3" json:"device_states,omitempty"`
+	RevokedVouchers     []*RevokedVoucherRecord                             `protobuf:"bytes,7,rep,name=revoked_vouchers,json=revokedVouchers,proto3" json:"revoked_vouchers,omitempty"`
+	BootstrapTokens     []*BootstrapTokenRecord                             `protobuf:"bytes,8,rep,name=bootstrap_tokens,json=bootstrapTokens,proto3" json:"bootstrap_tokens,omitempty"`
+	RetiredDevices      []*RetiredDeviceRecord                              `protobuf:"bytes,9,rep,name=retired_devices,json=retiredDevices,proto3" json:"retired_devices,omitempty"`
+}
+
+func (x *Snapshot) Reset() {
+	*x = Snapshot{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_entitymanager_proto_entity_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Snapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Snapshot) ProtoMessage() {}
+
+func (x *Snapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_server_entitymanager_proto_entity_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Snapshot.ProtoReflect.Descriptor instead.
+func (*Snapshot) Descriptor() ([]byte, []int) {
+	return file_server_entitymanager_proto_entity_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *Snapshot) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Snapshot) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Snapshot) GetOptions() *Options {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *Snapshot) GetChassis() []*Chassis {
+	if x != nil {
+		return x.Chassis
+	}
+	return nil
+}
+
+func (x *Snapshot) GetControlCardStatuses() map[string]bootz.ControlCardState_ControlCardStatus {
+	if x != nil {
+		return x.ControlCardStatuses
+	}
+	return nil
+}
+
+func (x *Snapshot) GetDeviceStates() []*DeviceStateRecord {
+	if x != nil {
+		return x.DeviceStates
+	}
+	return nil
+}
+
+func (x *Snapshot) GetRevokedVouchers() []*RevokedVoucherRecord {
+	if x != nil {
+		return x.RevokedVouchers
+	}
+	return nil
+}
+
+func (x *Snapshot) GetBootstrapTokens() []*BootstrapTokenRecord {
+	if x != nil {
+		return x.BootstrapTokens
+	}
+	return nil
+}
+
+func (x *Snapshot) GetRetiredDevices() []*RetiredDeviceRecord {
+	if x != nil {
+		return x.RetiredDevices
+	}
+	return nil
+}
+
+type DeviceStateRecord struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SerialNumber      string                 `protobuf:"bytes,1,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+	State             string                 `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
+	Retries           uint32                 `protobuf:"varint,3,opt,name=retries,proto3" json:"retries,omitempty"`
+	ChangedAt         *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=changed_at,json=changedAt,proto3" json:"changed_at,omitempty"`
+	SentAt            *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=sent_at,json=sentAt,proto3" json:"sent_at,omitempty"`
+	ReportedAt        *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=reported_at,json=reportedAt,proto3" json:"reported_at,omitempty"`
+	Stage             string                 `protobuf:"bytes,7,opt,name=stage,proto3" json:"stage,omitempty"`
+	RequestCounter    uint64                 `protobuf:"varint,8,opt,name=request_counter,json=requestCounter,proto3" json:"request_counter,omitempty"`
+	LastSeenAt        *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=last_seen_at,json=lastSeenAt,proto3" json:"last_seen_at,omitempty"`
+	LastAddress       string                 `protobuf:"bytes,10,opt,name=last_address,json=lastAddress,proto3" json:"last_address,omitempty"`
+	ServedImageHash   string                 `protobuf:"bytes,11,opt,name=served_image_hash,json=servedImageHash,proto3" json:"served_image_hash,omitempty"`
+	ServedConfigHash  string                 `protobuf:"bytes,12,opt,name=served_config_hash,json=servedConfigHash,proto3" json:"served_config_hash,omitempty"`
+	AppliedImageHash  string                 `protobuf:"bytes,13,opt,name=applied_image_hash,json=appliedImageHash,proto3" json:"applied_image_hash,omitempty"`
+	AppliedConfigHash string                 `protobuf:"bytes,14,opt,name=applied_config_hash,json=appliedConfigHash,proto3" json:"applied_config_hash,omitempty"`
+}
+
+func (x *DeviceStateRecord) Reset() {
+	*x = DeviceStateRecord{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_entitymanager_proto_entity_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeviceStateRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeviceStateRecord) ProtoMessage() {}
+
+func (x *DeviceStateRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_server_entitymanager_proto_entity_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeviceStateRecord.ProtoReflect.Descriptor instead.
+func (*DeviceStateRecord) Descriptor() ([]byte, []int) {
+	return file_server_entitymanager_proto_entity_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *DeviceStateRecord) GetSerialNumber() string {
+	if x != nil {
+		return x.SerialNumber
+	}
+	return ""
+}
+
+func (x *DeviceStateRecord) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *DeviceStateRecord) GetRetries() uint32 {
+	if x != nil {
+		return x.Retries
+	}
+	return 0
+}
+
+func (x *DeviceStateRecord) GetChangedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ChangedAt
+	}
+	return nil
+}
+
+func (x *DeviceStateRecord) GetSentAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SentAt
+	}
+	return nil
+}
+
+func (x *DeviceStateRecord) GetReportedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReportedAt
+	}
+	return nil
+}
+
+func (x *DeviceStateRecord) GetStage() string {
+	if x != nil {
+		return x.Stage
+	}
+	return ""
+}
+
+func (x *DeviceStateRecord) GetRequestCounter() uint64 {
+	if x != nil {
+		return x.RequestCounter
+	}
+	return 0
+}
+
+func (x *DeviceStateRecord) GetLastSeenAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSeenAt
+	}
+	return nil
+}
+
+func (x *DeviceStateRecord) GetLastAddress() string {
+	if x != nil {
+		return x.LastAddress
+	}
+	return ""
+}
+
+func (x *DeviceStateRecord) GetServedImageHash() string {
+	if x != nil {
+		return x.ServedImageHash
+	}
+	return ""
+}
+
+func (x *DeviceStateRecord) GetServedConfigHash() string {
+	if x != nil {
+		return x.ServedConfigHash
+	}
+	return ""
+}
+
+func (x *DeviceStateRecord) GetAppliedImageHash() string {
+	if x != nil {
+		return x.AppliedImageHash
+	}
+	return ""
+}
+
+func (x *DeviceStateRecord) GetAppliedConfigHash() string {
+	if x != nil {
+		return x.AppliedConfigHash
+	}
+	return ""
+}
+
+type RevokedVoucherRecord struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SerialNumber string                 `protobuf:"bytes,1,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+	Reason       string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	RevokedAt    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=revoked_at,json=revokedAt,proto3" json:"revoked_at,omitempty"`
+}
+
+func (x *RevokedVoucherRecord) Reset() {
+	*x = RevokedVoucherRecord{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_entitymanager_proto_entity_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RevokedVoucherRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokedVoucherRecord) ProtoMessage() {}
+
+func (x *RevokedVoucherRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_server_entitymanager_proto_entity_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokedVoucherRecord.ProtoReflect.Descriptor instead.
+func (*RevokedVoucherRecord) Descriptor() ([]byte, []int) {
+	return file_server_entitymanager_proto_entity_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *RevokedVoucherRecord) GetSerialNumber() string {
+	if x != nil {
+		return x.SerialNumber
+	}
+	return ""
+}
+
+func (x *RevokedVoucherRecord) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *RevokedVoucherRecord) GetRevokedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RevokedAt
+	}
+	return nil
+}
+
+type BootstrapTokenRecord struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SerialNumber string                 `protobuf:"bytes,1,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+	TokenHash    []byte                 `protobuf:"bytes,2,opt,name=token_hash,json=tokenHash,proto3" json:"token_hash,omitempty"`
+	IssuedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=issued_at,json=issuedAt,proto3" json:"issued_at,omitempty"`
+	ExpiresAt    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+}
+
+func (x *BootstrapTokenRecord) Reset() {
+	*x = BootstrapTokenRecord{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_entitymanager_proto_entity_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BootstrapTokenRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BootstrapTokenRecord) ProtoMessage() {}
+
+func (x *BootstrapTokenRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_server_entitymanager_proto_entity_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BootstrapTokenRecord.ProtoReflect.Descriptor instead.
+func (*BootstrapTokenRecord) Descriptor() ([]byte, []int) {
+	return file_server_entitymanager_proto_entity_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *BootstrapTokenRecord) GetSerialNumber() string {
+	if x != nil {
+		return x.SerialNumber
+	}
+	return ""
+}
+
+func (x *BootstrapTokenRecord) GetTokenHash() []byte {
+	if x != nil {
+		return x.TokenHash
+	}
+	return nil
+}
+
+func (x *BootstrapTokenRecord) GetIssuedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.IssuedAt
+	}
+	return nil
+}
+
+func (x *BootstrapTokenRecord) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type RetiredDeviceRecord struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SerialNumber string                                   `protobuf:"bytes,1,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+	Reason       string                                   `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	RetiredAt    *timestamppb.Timestamp                   `protobuf:"bytes,3,opt,name=retired_at,json=retiredAt,proto3" json:"retired_at,omitempty"`
+	ReplacedBy   string                                   `protobuf:"bytes,4,opt,name=replaced_by,json=replacedBy,proto3" json:"replaced_by,omitempty"`
+	Chassis      *Chassis                                 `protobuf:"bytes,5,opt,name=chassis,proto3" json:"chassis,omitempty"`
+	State        *DeviceStateRecord                       `protobuf:"bytes,6,opt,name=state,proto3" json:"state,omitempty"`
+	Status       bootz.ControlCardState_ControlCardStatus `protobuf:"varint,7,opt,name=status,proto3,enum=bootz.proto.ControlCardState_ControlCardStatus" json:"status,omitempty"`
+}
+
+func (x *RetiredDeviceRecord) Reset() {
+	*x = RetiredDeviceRecord{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_entitymanager_proto_entity_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RetiredDeviceRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetiredDeviceRecord) ProtoMessage() {}
+
+func (x *RetiredDeviceRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_server_entitymanager_proto_entity_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetiredDeviceRecord.ProtoReflect.Descriptor instead.
+func (*RetiredDeviceRecord) Descriptor() ([]byte, []int) {
+	return file_server_entitymanager_proto_entity_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *RetiredDeviceRecord) GetSerialNumber() string {
+	if x != nil {
+		return x.SerialNumber
+	}
+	return ""
+}
+
+func (x *RetiredDeviceRecord) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *RetiredDeviceRecord) GetRetiredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RetiredAt
+	}
+	return nil
+}
+
+func (x *RetiredDeviceRecord) GetReplacedBy() string {
+	if x != nil {
+		return x.ReplacedBy
+	}
+	return ""
+}
+
+func (x *RetiredDeviceRecord) GetChassis() *Chassis {
+	if x != nil {
+		return x.Chassis
+	}
+	return nil
+}
+
+func (x *RetiredDeviceRecord) GetState() *DeviceStateRecord {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+func (x *RetiredDeviceRecord) GetStatus() bootz.ControlCardState_ControlCardStatus {
+	if x != nil {
+		return x.Status
+	}
+	return bootz.ControlCardState_ControlCardStatus(0)
+}
+
+type Entities struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Options *Options   `protobuf:"bytes,1,opt,name=options,proto3" json:"options,omitempty"`
+	Chassis []*Chassis `protobuf:"bytes,2,rep,name=chassis,proto3" json:"chassis,omitempty"`
+}
+
+func (x *Entities) Reset() {
+	*x = Entities{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_entitymanager_proto_entity_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Entities) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Entities) ProtoMessage() {}
+
+func (x *Entities) ProtoReflect() protoreflect.Message {
+	mi := &file_server_entitymanager_proto_entity_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Entities.ProtoReflect.Descriptor instead.
+func (*Entities) Descriptor() ([]byte, []int) {
+	return file_server_entitymanager_proto_entity_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *Entities) GetOptions() *Options {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *Entities) GetChassis() []*Chassis {
+	if x != nil {
+		return x.Chassis
+	}
+	return nil
+}
+
+type Config struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BootConfig            *BootConfig `protobuf:"bytes,1,opt,name=boot_config,json=bootConfig,proto3" json:"boot_config,omitempty"`
+	GnsiConfig            *GNSIConfig `protobuf:"bytes,2,opt,name=gnsi_config,json=gnsiConfig,proto3" json:"gnsi_config,omitempty"`
+	SecondStageConfigFile string      `protobuf:"bytes,3,opt,name=second_stage_config_file,json=secondStageConfigFile,proto3" json:"second_stage_config_file,omitempty"`
+	FinalBootConfig       *BootConfig `protobuf:"bytes,4,opt,name=final_boot_config,json=finalBootConfig,proto3" json:"final_boot_config,omitempty"`
+}
+
+func (x *Config) Reset() {
+	*x = Config{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_entitymanager_proto_entity_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Config) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Config) ProtoMessage() {}
+
+func (x *Config) ProtoReflect() protoreflect.Message {
+	mi := &file_server_entitymanager_proto_entity_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Config.ProtoReflect.Descriptor instead.
+func (*Config) Descriptor() ([]byte, []int) {
+	return file_server_entitymanager_proto_entity_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *Config) GetBootConfig() *BootConfig {
+	if x != nil {
+		return x.BootConfig
+	}
+	return nil
+}
+
+func (x *Config) GetGnsiConfig() *GNSIConfig {
+	if x != nil {
+		return x.GnsiConfig
+	}
+	return nil
+}
+
+func (x *Config) GetSecondStageConfigFile() string {
+	if x != nil {
+		return x.SecondStageConfigFile
+	}
+	return ""
+}
+
+func (x *Config) GetFinalBootConfig() *BootConfig {
+	if x != nil {
+		return x.FinalBootConfig
+	}
+	return nil
+}
+
+type BootConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Metadata           *structpb.Struct     `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	VendorConfigFile   string               `protobuf:"bytes,2,opt,name=vendor_config_file,json=vendorConfigFile,proto3" json:"vendor_config_file,omitempty"`
+	OcConfigFile       string               `protobuf:"bytes,3,opt,name=oc_config_file,json=ocConfigFile,proto3" json:"oc_config_file,omitempty"`
+	BootloaderConfig   *structpb.Struct     `protobuf:"bytes,4,opt,name=bootloader_config,json=bootloaderConfig,proto3" json:"bootloader_config,omitempty"`
+	GnmiSetRequestFile string               `protobuf:"bytes,5,opt,name=gnmi_set_request_file,json=gnmiSetRequestFile,proto3" json:"gnmi_set_request_file,omitempty"`
+	Encoding           bootz.ConfigEncoding `protobuf:"varint,6,opt,name=encoding,proto3,enum=bootz.proto.ConfigEncoding" json:"encoding,omitempty"`
+}
+
+func (x *BootConfig) Reset() {
+	*x = BootConfig{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_entitymanager_proto_entity_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BootConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BootConfig) ProtoMessage() {}
+
+func (x *BootConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_server_entitymanager_proto_entity_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BootConfig.ProtoReflect.Descriptor instead.
+func (*BootConfig) Descriptor() ([]byte, []int) {
+	return file_server_entitymanager_proto_entity_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *BootConfig) GetMetadata() *structpb.Struct {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *BootConfig) GetVendorConfigFile() string {
+	if x != nil {
+		return x.VendorConfigFile
+	}
+	return ""
+}
+
+func (x *BootConfig) GetOcConfigFile() string {
+	if x != nil {
+		return x.OcConfigFile
+	}
+	return ""
+}
+
+func (x *BootConfig) GetBootloaderConfig() *structpb.Struct {
+	if x != nil {
+		return x.BootloaderConfig
+	}
+	return nil
+}
+
+func (x *BootConfig) GetGnmiSetRequestFile() string {
+	if x != nil {
+		return x.GnmiSetRequestFile
+	}
+	return ""
+}
+
+func (x *BootConfig) GetEncoding() bootz.ConfigEncoding {
+	if x != nil {
+		return x.Encoding
+	}
+	return bootz.ConfigEncoding(0)
+}
+
+type GNSIConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AuthzUploadFile string               `protobuf:"bytes,1,opt,name=authz_upload_file,json=authzUploadFile,proto3" json:"authz_upload_file,omitempty"`
+	AuthzUpload     *authz.UploadRequest `protobuf:"bytes,2,opt,name=authz_upload,json=authzUpload,proto3" json:"authz_upload,omitempty"`
+	PathzUploadFile string               `protobuf:"bytes,3,opt,name=pathz_upload_file,json=pathzUploadFile,proto3" json:"pathz_upload_file,omitempty"`
+	PathzUpload     *pathz.UploadRequest `protobuf:"bytes,4,opt,name=pathz_upload,json=pathzUpload,proto3" json:"pathz_upload,omitempty"`
+	CertzUpload     *certz.UploadRequest `protobuf:"bytes,5,opt,name=certz_upload,json=certzUpload,proto3" json:"certz_upload,omitempty"`
+	CertzUploadFile string               `protobuf:"bytes,6,opt,name=certz_upload_file,json=certzUploadFile,proto3" json:"certz_upload_file,omitempty"`
+	CredentialsFile string               `protobuf:"bytes,7,opt,name=credentials_file,json=credentialsFile,proto3" json:"credentials_file,omitempty"`
+	Credentials     *bootz.Credentials   `protobuf:"bytes,8,opt,name=credentials,proto3" json:"credentials,omitempty"`
+}
+
+func (x *GNSIConfig) Reset() {
+	*x = GNSIConfig{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_entitymanager_proto_entity_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GNSIConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GNSIConfig) ProtoMessage() {}
+
+func (x *GNSIConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_server_entitymanager_proto_entity_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GNSIConfig.ProtoReflect.Descriptor instead.
+func (*GNSIConfig) Descriptor() ([]byte, []int) {
+	return file_server_entitymanager_proto_entity_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *GNSIConfig) GetAuthzUploadFile() string {
+	if x != nil {
+		return x.AuthzUploadFile
+	}
+	return ""
+}
+
+func (x *GNSIConfig) GetAuthzUpload() *authz.UploadRequest {
+	if x != nil {
+		return x.AuthzUpload
+	}
+	return nil
+}
+
+func (x *GNSIConfig) GetPathzUploadFile() string {
+	if x != nil {
+		return x.PathzUploadFile
+	}
+	return ""
+}
+
+func (x *GNSIConfig) GetPathzUpload() *pathz.UploadRequest {
+	if x != nil {
+		return x.PathzUpload
+	}
+	return nil
+}
+
+func (x *GNSIConfig) GetCertzUpload() *certz.UploadRequest {
+	if x != nil {
+		return x.CertzUpload
+	}
+	return nil
+}
+
+func (x *GNSIConfig) GetCertzUploadFile() string {
+	if x != nil {
+		return x.CertzUploadFile
+	}
+	return ""
+}
+
+func (x *GNSIConfig) GetCredentialsFile() string {
+	if x != nil {
+		return x.CredentialsFile
+	}
+	return ""
+}
+
+func (x *GNSIConfig) GetCredentials() *bootz.Credentials {
+	if x != nil {
+		return x.Credentials
+	}
+	return nil
+}
+
+type DHCPConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	HardwareAddress string `protobuf:"bytes,1,opt,name=hardware_address,json=hardwareAddress,proto3" json:"hardware_address,omitempty"`
+	IpAddress       string `protobuf:"bytes,2,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`
+	Gateway         string `protobuf:"bytes,3,opt,name=gateway,proto3" json:"gateway,omitempty"`
+	Bootzserver     string `protobuf:"bytes,4,opt,name=bootzserver,proto3" json:"bootzserver,omitempty"`
+}
+
+func (x *DHCPConfig) Reset() {
+	*x = DHCPConfig{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_entitymanager_proto_entity_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DHCPConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DHCPConfig) ProtoMessage() {}
+
+func (x *DHCPConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_server_entitymanager_proto_entity_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DHCPConfig.ProtoReflect.Descriptor instead.
+func (*DHCPConfig) Descriptor() ([]byte, []int) {
+	return file_server_entitymanager_proto_entity_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *DHCPConfig) GetHardwareAddress() string {
+	if x != nil {
+		return x.HardwareAddress
+	}
+	return ""
+}
+
+func (x *DHCPConfig) GetIpAddress() string {
+	if x != nil {
+		return x.IpAddress
+	}
+	return ""
+}
+
+func (x *DHCPConfig) GetGateway() string {
+	if x != nil {
+		return x.Gateway
+	}
+	return ""
+}
+
+func (x *DHCPConfig) GetBootzserver() string {
+	if x != nil {
+		return x.Bootzserver
+	}
+	return ""
+}
+
+type ControlCard struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PartNumber              string      `protobuf:"bytes,1,opt,name=part_number,json=partNumber,proto3" json:"part_number,omitempty"`
+	SerialNumber            string      `protobuf:"bytes,2,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+	OwnershipVoucher        string      `protobuf:"bytes,3,opt,name=ownership_voucher,json=ownershipVoucher,proto3" json:"ownership_voucher,omitempty"`
+	DhcpConfig              *DHCPConfig `protobuf:"bytes,4,opt,name=dhcp_config,json=dhcpConfig,proto3" json:"dhcp_config,omitempty"`
+	RotatedOwnershipVoucher string      `protobuf:"bytes,5,opt,name=rotated_ownership_voucher,json=rotatedOwnershipVoucher,proto3" json:"rotated_ownership_voucher,omitempty"`
+	SerialAliases           []string    `protobuf:"bytes,6,rep,name=serial_aliases,json=serialAliases,proto3" json:"serial_aliases,omitempty"`
+}
+
+func (x *ControlCard) Reset() {
+	*x = ControlCard{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_entitymanager_proto_entity_proto_msgTypes[44]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ControlCard) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ControlCard) ProtoMessage() {}
+
+func (x *ControlCard) ProtoReflect() protoreflect.Message {
+	mi := &file_server_entitymanager_proto_entity_proto_msgTypes[44]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ControlCard.ProtoReflect.Descriptor instead.
+func (*ControlCard) Descriptor() ([]byte, []int) {
+	return file_server_entitymanager_proto_entity_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ControlCard) GetPartNumber() string {
+	if x != nil {
+		return x.PartNumber
+	}
+	return ""
+}
+
+func (x *ControlCard) GetSerialNumber() string {
+	if x != nil {
+		return x.SerialNumber
+	}
+	return ""
+}
+
+func (x *ControlCard) GetOwnershipVoucher() string {
+	if x != nil {
+		return x.OwnershipVoucher
+	}
+	return ""
+}
+
+func (x *ControlCard) GetDhcpConfig() *DHCPConfig {
+	if x != nil {
+		return x.DhcpConfig
+	}
+	return nil
+}
+
+func (x *ControlCard) GetRotatedOwnershipVoucher() string {
+	if x != nil {
+		return x.RotatedOwnershipVoucher
+	}
+	return ""
+}
+
+func (x *ControlCard) GetSerialAliases() []string {
+	if x != nil {
+		return x.SerialAliases
+	}
+	return nil
+}
+
+type Chassis struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SerialNumber            string                `protobuf:"bytes,1,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+	Name                    string                `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	PartNumber              string                `protobuf:"bytes,3,opt,name=part_number,json=partNumber,proto3" json:"part_number,omitempty"`
+	Manufacturer            string                `protobuf:"bytes,4,opt,name=manufacturer,proto3" json:"manufacturer,omitempty"`
+	BootloaderPasswordHash  string                `protobuf:"bytes,5,opt,name=bootloader_password_hash,json=bootloaderPasswordHash,proto3" json:"bootloader_password_hash,omitempty"`
+	BootMode                bootz.BootMode        `protobuf:"varint,6,opt,name=boot_mode,json=bootMode,proto3,enum=bootz.proto.BootMode" json:"boot_mode,omitempty"`
+	SoftwareImage           *bootz.SoftwareImage  `protobuf:"bytes,7,opt,name=software_image,json=softwareImage,proto3" json:"software_image,omitempty"`
+	ControllerCards         []*ControlCard        `protobuf:"bytes,8,rep,name=controller_cards,json=controllerCards,proto3" json:"controller_cards,omitempty"`
+	Config                  *Config               `protobuf:"bytes,9,opt,name=config,proto3" json:"config,omitempty"`
+	ArtifactDir             string                `protobuf:"bytes,10,opt,name=artifact_dir,json=artifactDir,proto3" json:"artifact_dir,omitempty"`
+	OwnershipVoucher        string                `protobuf:"bytes,11,opt,name=ownership_voucher,json=ownershipVoucher,proto3" json:"ownership_voucher,omitempty"`
+	DhcpConfig              *DHCPConfig           `protobuf:"bytes,12,opt,name=dhcp_config,json=dhcpConfig,proto3" json:"dhcp_config,omitempty"`
+	Site                    string                `protobuf:"bytes,13,opt,name=site,proto3" json:"site,omitempty"`
+	Tenant                  string                `protobuf:"bytes,14,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	ArtifactsPending        bool                  `protobuf:"varint,15,opt,name=artifacts_pending,json=artifactsPending,proto3" json:"artifacts_pending,omitempty"`
+	RotatedOwnershipVoucher string                `protobuf:"bytes,16,opt,name=rotated_ownership_voucher,json=rotatedOwnershipVoucher,proto3" json:"rotated_ownership_voucher,omitempty"`
+	SerialAliases           []string              `protobuf:"bytes,17,rep,name=serial_aliases,json=serialAliases,proto3" json:"serial_aliases,omitempty"`
+	LineCards               []*bootz.Module       `protobuf:"bytes,18,rep,name=line_cards,json=lineCards,proto3" json:"line_cards,omitempty"`
+	FabricModules           []*bootz.Module       `protobuf:"bytes,19,rep,name=fabric_modules,json=fabricModules,proto3" json:"fabric_modules,omitempty"`
+	ProvisioningWindows     []*ProvisioningWindow `protobuf:"bytes,20,rep,name=provisioning_windows,json=provisioningWindows,proto3" json:"provisioning_windows,omitempty"`
+}
+
+func (x *Chassis) Reset() {
+	*x = Chassis{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_entitymanager_proto_entity_proto_msgTypes[45]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Chassis) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Chassis) ProtoMessage() {}
+
+func (x *Chassis) ProtoReflect() protoreflect.Message {
+	mi := &file_server_entitymanager_proto_entity_proto_msgTypes[45]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Chassis.ProtoReflect.Descriptor instead.
+func (*Chassis) Descriptor() ([]byte, []int) {
+	return file_server_entitymanager_proto_entity_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *Chassis) GetSerialNumber() string {
+	if x != nil {
+		return x.SerialNumber
+	}
+	return ""
+}
+
+func (x *Chassis) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Chassis) GetPartNumber() string {
+	if x != nil {
+		return x.PartNumber
+	}
+	return ""
+}
+
+func (x *Chassis) GetManufacturer() string {
+	if x != nil {
+		return x.Manufacturer
+	}
+	return ""
+}
+
+func (x *Chassis) GetBootloaderPasswordHash() string {
+	if x != nil {
+		return x.BootloaderPasswordHash
+	}
+	return ""
+}
+
+func (x *Chassis) GetBootMode() bootz.BootMode {
+	if x != nil {
+		return x.BootMode
+	}
+	return bootz.BootMode(0)
+}
+
+func (x *Chassis) GetSoftwareImage() *bootz.SoftwareImage {
+	if x != nil {
+		return x.SoftwareImage
+	}
+	return nil
+}
+
+func (x *Chassis) GetControllerCards() []*ControlCard {
+	if x != nil {
+		return x.ControllerCards
+	}
+	return nil
+}
+
+func (x *Chassis) GetConfig() *Config {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *Chassis) GetArtifactDir() string {
+	if x != nil {
+		return x.ArtifactDir
+	}
+	return ""
+}
+
+func (x *Chassis) GetOwnershipVoucher() string {
+	if x != nil {
+		return x.OwnershipVoucher
+	}
+	return ""
+}
+
+func (x *Chassis) GetDhcpConfig() *DHCPConfig {
+	if x != nil {
+		return x.DhcpConfig
+	}
+	return nil
+}
+
+func (x *Chassis) GetSite() string {
+	if x != nil {
+		return x.Site
+	}
+	return ""
+}
+
+func (x *Chassis) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+func (x *Chassis) GetArtifactsPending() bool {
+	if x != nil {
+		return x.ArtifactsPending
+	}
+	return false
+}
+
+func (x *Chassis) GetRotatedOwnershipVoucher() string {
+	if x != nil {
+		return x.RotatedOwnershipVoucher
+	}
+	return ""
+}
+
+func (x *Chassis) GetSerialAliases() []string {
+	if x != nil {
+		return x.SerialAliases
+	}
+	return nil
+}
+
+func (x *Chassis) GetLineCards() []*bootz.Module {
+	if x != nil {
+		return x.LineCards
+	}
+	return nil
+}
+
+func (x *Chassis) GetFabricModules() []*bootz.Module {
+	if x != nil {
+		return x.FabricModules
+	}
+	return nil
+}
+
+func (x *Chassis) GetProvisioningWindows() []*ProvisioningWindow {
+	if x != nil {
+		return x.ProvisioningWindows
+	}
+	return nil
+}
+
+type FaultInjection_Fault struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SerialNumbers    []string             `protobuf:"bytes,1,rep,name=serial_numbers,json=serialNumbers,proto3" json:"serial_numbers,omitempty"`
+	Methods          []string             `protobuf:"bytes,2,rep,name=methods,proto3" json:"methods,omitempty"`
+	Latency          *durationpb.Duration `protobuf:"bytes,3,opt,name=latency,proto3" json:"latency,omitempty"`
+	DropResponse     bool                 `protobuf:"varint,4,opt,name=drop_response,json=dropResponse,proto3" json:"drop_response,omitempty"`
+	CorruptSignature bool                 `protobuf:"varint,5,opt,name=corrupt_signature,json=corruptSignature,proto3" json:"corrupt_signature,omitempty"`
+}
+
+func (x *FaultInjection_Fault) Reset() {
+	*x = FaultInjection_Fault{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_server_entitymanager_proto_entity_proto_msgTypes[47]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FaultInjection_Fault) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FaultInjection_Fault) ProtoMessage() {}
+
+func (x *FaultInjection_Fault) ProtoReflect() protoreflect.Message {
+	mi := &file_server_entitymanager_proto_entity_proto_msgTypes[47]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FaultInjection_Fault.ProtoReflect.Descriptor instead.
+func (*FaultInjection_Fault) Descriptor() ([]byte, []int) {
+	return file_server_entitymanager_proto_entity_proto_rawDescGZIP(), []int{17, 0}
+}
+
+func (x *FaultInjection_Fault) GetSerialNumbers() []string {
+	if x != nil {
+		return x.SerialNumbers
+	}
+	return nil
+}
+
+func (x *FaultInjection_Fault) GetMethods() []string {
+	if x != nil {
+		return x.Methods
+	}
+	return nil
+}
+
+func (x *FaultInjection_Fault) GetLatency() *durationpb.Duration {
+	if x != nil {
+		return x.Latency
+	}
+	return nil
+}
+
+func (x *FaultInjection_Fault) GetDropResponse() bool {
+	if x != nil {
+		return x.DropResponse
+	}
+	return false
+}
+
+func (x *FaultInjection_Fault) GetCorruptSignature() bool {
+	if x != nil {
+		return x.CorruptSignature
+	}
+	return false
 }
 
 var File_server_entitymanager_proto_entity_proto protoreflect.FileDescriptor
@@ -655,138 +4217,862 @@ var file_server_entitymanager_proto_entity_proto_rawDesc = []byte{
 	0x0a, 0x27, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x6d,
 	0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x65, 0x6e, 0x74,
 	0x69, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06, 0x65, 0x6e, 0x74, 0x69, 0x74,
-	0x79, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x75, 0x66, 0x2f, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a,
-	0x2c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6f, 0x70, 0x65, 0x6e,
-	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2f, 0x67, 0x6e, 0x73, 0x69, 0x2f, 0x61, 0x75, 0x74, 0x68,
-	0x7a, 0x2f, 0x61, 0x75, 0x74, 0x68, 0x7a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x2c, 0x67,
-	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6f, 0x70, 0x65, 0x6e, 0x63, 0x6f,
-	0x6e, 0x66, 0x69, 0x67, 0x2f, 0x67, 0x6e, 0x73, 0x69, 0x2f, 0x63, 0x65, 0x72, 0x74, 0x7a, 0x2f,
-	0x63, 0x65, 0x72, 0x74, 0x7a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x2c, 0x67, 0x69, 0x74,
-	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6f, 0x70, 0x65, 0x6e, 0x63, 0x6f, 0x6e, 0x66,
-	0x69, 0x67, 0x2f, 0x67, 0x6e, 0x73, 0x69, 0x2f, 0x70, 0x61, 0x74, 0x68, 0x7a, 0x2f, 0x70, 0x61,
-	0x74, 0x68, 0x7a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x11, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x2f, 0x62, 0x6f, 0x6f, 0x74, 0x7a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x90, 0x01, 0x0a,
-	0x07, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x40, 0x0a, 0x12, 0x67, 0x6e, 0x73, 0x69,
-	0x5f, 0x67, 0x6c, 0x6f, 0x62, 0x61, 0x6c, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x2e, 0x47, 0x4e,
-	0x53, 0x49, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x10, 0x67, 0x6e, 0x73, 0x69, 0x47, 0x6c,
-	0x6f, 0x62, 0x61, 0x6c, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x20, 0x0a, 0x0b, 0x62, 0x6f,
-	0x6f, 0x74, 0x7a, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0b, 0x62, 0x6f, 0x6f, 0x74, 0x7a, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x21, 0x0a, 0x0c,
-	0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x5f, 0x64, 0x69, 0x72, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x0b, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x44, 0x69, 0x72, 0x22,
-	0x60, 0x0a, 0x08, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x29, 0x0a, 0x07, 0x6f,
-	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x65,
-	0x6e, 0x74, 0x69, 0x74, 0x79, 0x2e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x07, 0x6f,
-	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x29, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x73, 0x73, 0x69,
-	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79,
-	0x2e, 0x43, 0x68, 0x61, 0x73, 0x73, 0x69, 0x73, 0x52, 0x07, 0x63, 0x68, 0x61, 0x73, 0x73, 0x69,
-	0x73, 0x22, 0x72, 0x0a, 0x06, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x33, 0x0a, 0x0b, 0x62,
+	0x79, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2f, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x1a, 0x2c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6f, 0x70, 0x65,
+	0x6e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2f, 0x67, 0x6e, 0x73, 0x69, 0x2f, 0x61, 0x75, 0x74,
+	0x68, 0x7a, 0x2f, 0x61, 0x75, 0x74, 0x68, 0x7a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x2c,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6f, 0x70, 0x65, 0x6e, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2f, 0x67, 0x6e, 0x73, 0x69, 0x2f, 0x63, 0x65, 0x72, 0x74, 0x7a,
+	0x2f, 0x63, 0x65, 0x72, 0x74, 0x7a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x2c, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6f, 0x70, 0x65, 0x6e, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x2f, 0x67, 0x6e, 0x73, 0x69, 0x2f, 0x70, 0x61, 0x74, 0x68, 0x7a, 0x2f, 0x70,
+	0x61, 0x74, 0x68, 0x7a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x11, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2f, 0x62, 0x6f, 0x6f, 0x74, 0x7a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xa1, 0x13,
+	0x0a, 0x07, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x40, 0x0a, 0x12, 0x67, 0x6e, 0x73,
+	0x69, 0x5f, 0x67, 0x6c, 0x6f, 0x62, 0x61, 0x6c, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x2e, 0x47,
+	0x4e, 0x53, 0x49, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x10, 0x67, 0x6e, 0x73, 0x69, 0x47,
+	0x6c, 0x6f, 0x62, 0x61, 0x6c, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x20, 0x0a, 0x0b, 0x62,
+	0x6f, 0x6f, 0x74, 0x7a, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x62, 0x6f, 0x6f, 0x74, 0x7a, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x21, 0x0a,
+	0x0c, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x5f, 0x64, 0x69, 0x72, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x44, 0x69, 0x72,
+	0x12, 0x42, 0x0a, 0x10, 0x70, 0x64, 0x63, 0x5f, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x65, 0x6e, 0x74,
+	0x69, 0x74, 0x79, 0x2e, 0x50, 0x44, 0x43, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x0f, 0x70, 0x64, 0x63, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x38, 0x0a, 0x0d, 0x66, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x5f,
+	0x66, 0x6c, 0x61, 0x67, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x65, 0x6e,
+	0x74, 0x69, 0x74, 0x79, 0x2e, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x46, 0x6c, 0x61, 0x67,
+	0x52, 0x0c, 0x66, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x46, 0x6c, 0x61, 0x67, 0x73, 0x12, 0x2c,
+	0x0a, 0x12, 0x6f, 0x76, 0x5f, 0x72, 0x65, 0x76, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x66, 0x69, 0x6c, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x6f, 0x76, 0x52, 0x65,
+	0x76, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x2b, 0x0a, 0x12,
+	0x76, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x5f, 0x63, 0x61, 0x5f, 0x63, 0x72, 0x6c, 0x5f, 0x66, 0x69,
+	0x6c, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x76, 0x65, 0x6e, 0x64, 0x6f, 0x72,
+	0x43, 0x61, 0x43, 0x72, 0x6c, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x3f, 0x0a, 0x0f, 0x64, 0x65, 0x66,
+	0x61, 0x75, 0x6c, 0x74, 0x5f, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x16, 0x2e, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x2e, 0x44, 0x65, 0x66, 0x61,
+	0x75, 0x6c, 0x74, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x0e, 0x64, 0x65, 0x66, 0x61,
+	0x75, 0x6c, 0x74, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x34, 0x0a, 0x0b, 0x65, 0x78,
+	0x70, 0x65, 0x72, 0x69, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x12, 0x2e, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x2e, 0x45, 0x78, 0x70, 0x65, 0x72, 0x69, 0x6d,
+	0x65, 0x6e, 0x74, 0x52, 0x0b, 0x65, 0x78, 0x70, 0x65, 0x72, 0x69, 0x6d, 0x65, 0x6e, 0x74, 0x73,
+	0x12, 0x1c, 0x0a, 0x0a, 0x6f, 0x63, 0x5f, 0x6b, 0x65, 0x79, 0x5f, 0x75, 0x72, 0x69, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6f, 0x63, 0x4b, 0x65, 0x79, 0x55, 0x72, 0x69, 0x12, 0x1e,
+	0x0a, 0x0b, 0x70, 0x64, 0x63, 0x5f, 0x6b, 0x65, 0x79, 0x5f, 0x75, 0x72, 0x69, 0x18, 0x0b, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x64, 0x63, 0x4b, 0x65, 0x79, 0x55, 0x72, 0x69, 0x12, 0x41,
+	0x0a, 0x11, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x5f, 0x62, 0x6f, 0x6f, 0x74, 0x5f, 0x6d,
+	0x6f, 0x64, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x62, 0x6f, 0x6f, 0x74,
+	0x7a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x42, 0x6f, 0x6f, 0x74, 0x4d, 0x6f, 0x64, 0x65,
+	0x52, 0x0f, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x42, 0x6f, 0x6f, 0x74, 0x4d, 0x6f, 0x64,
+	0x65, 0x12, 0x35, 0x0a, 0x0c, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x5f, 0x69, 0x6d, 0x61, 0x67, 0x65,
+	0x73, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79,
+	0x2e, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x0b, 0x6d, 0x6f, 0x64,
+	0x65, 0x6c, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x73, 0x12, 0x32, 0x0a, 0x15, 0x6d, 0x61, 0x78, 0x5f,
+	0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x5f, 0x72, 0x65, 0x74, 0x72, 0x69, 0x65,
+	0x73, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x13, 0x6d, 0x61, 0x78, 0x42, 0x6f, 0x6f, 0x74,
+	0x73, 0x74, 0x72, 0x61, 0x70, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x2a, 0x0a, 0x11,
+	0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x5f, 0x66, 0x69, 0x6c,
+	0x65, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x40, 0x0a, 0x0e, 0x72, 0x65, 0x74, 0x72,
+	0x79, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0d, 0x72, 0x65, 0x74,
+	0x72, 0x79, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x44, 0x0a, 0x11, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x5f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x18,
+	0x11, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x2e, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x10,
+	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73,
+	0x12, 0x48, 0x0a, 0x12, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x5f, 0x72, 0x6f,
+	0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x12, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x65,
+	0x6e, 0x74, 0x69, 0x74, 0x79, 0x2e, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x52,
+	0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x11, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x68,
+	0x69, 0x70, 0x52, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x49, 0x0a, 0x13, 0x70, 0x65,
+	0x65, 0x72, 0x5f, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x5f, 0x63, 0x68, 0x65, 0x63,
+	0x6b, 0x18, 0x13, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79,
+	0x2e, 0x50, 0x65, 0x65, 0x72, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x43, 0x68, 0x65,
+	0x63, 0x6b, 0x52, 0x11, 0x70, 0x65, 0x65, 0x72, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79,
+	0x43, 0x68, 0x65, 0x63, 0x6b, 0x12, 0x3d, 0x0a, 0x0e, 0x61, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74,
+	0x69, 0x63, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x14, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e,
+	0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63,
+	0x61, 0x74, 0x6f, 0x72, 0x52, 0x0e, 0x61, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61,
+	0x74, 0x6f, 0x72, 0x73, 0x12, 0x32, 0x0a, 0x09, 0x72, 0x65, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74,
+	0x73, 0x18, 0x15, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79,
+	0x2e, 0x52, 0x65, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x52, 0x75, 0x6c, 0x65, 0x52, 0x09, 0x72,
+	0x65, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x73, 0x12, 0x2c, 0x0a, 0x06, 0x6e, 0x65, 0x74, 0x62,
+	0x6f, 0x78, 0x18, 0x16, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x65, 0x6e, 0x74, 0x69, 0x74,
+	0x79, 0x2e, 0x4e, 0x65, 0x74, 0x42, 0x6f, 0x78, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x06,
+	0x6e, 0x65, 0x74, 0x62, 0x6f, 0x78, 0x12, 0x31, 0x0a, 0x07, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e,
+	0x73, 0x18, 0x17, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79,
+	0x2e, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e,
+	0x52, 0x07, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x73, 0x12, 0x4e, 0x0a, 0x14, 0x73, 0x65, 0x72,
+	0x69, 0x61, 0x6c, 0x5f, 0x6e, 0x6f, 0x72, 0x6d, 0x61, 0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x18, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79,
+	0x2e, 0x53, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x4e, 0x6f, 0x72, 0x6d, 0x61, 0x6c, 0x69, 0x7a, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x13, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x4e, 0x6f, 0x72, 0x6d,
+	0x61, 0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3f, 0x0a, 0x0f, 0x72, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x5f, 0x6c, 0x6f, 0x67, 0x67, 0x69, 0x6e, 0x67, 0x18, 0x19, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x16, 0x2e, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x2e, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x4c, 0x6f, 0x67, 0x67, 0x69, 0x6e, 0x67, 0x52, 0x0e, 0x72, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x4c, 0x6f, 0x67, 0x67, 0x69, 0x6e, 0x67, 0x12, 0x2c, 0x0a, 0x08, 0x74, 0x69,
+	0x6d, 0x65, 0x6f, 0x75, 0x74, 0x73, 0x18, 0x1a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x65,
+	0x6e, 0x74, 0x69, 0x74, 0x79, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x73, 0x52, 0x08,
+	0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x73, 0x12, 0x3c, 0x0a, 0x0e, 0x72, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x63, 0x61, 0x63, 0x68, 0x65, 0x18, 0x1b, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x15, 0x2e, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x43, 0x61, 0x63, 0x68, 0x65, 0x52, 0x0d, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x43, 0x61, 0x63, 0x68, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x77,
+	0x6f, 0x72, 0x6b, 0x65, 0x72, 0x73, 0x18, 0x1c, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x6c, 0x6f,
+	0x61, 0x64, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x69, 0x70,
+	0x73, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x1d, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x66, 0x69,
+	0x70, 0x73, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x48, 0x0a, 0x12, 0x76, 0x6f, 0x75, 0x63, 0x68, 0x65,
+	0x72, 0x5f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x1e, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x19, 0x2e, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x2e, 0x56, 0x6f, 0x75, 0x63,
+	0x68, 0x65, 0x72, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x11, 0x76,
+	0x6f, 0x75, 0x63, 0x68, 0x65, 0x72, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x26, 0x0a, 0x04, 0x6d, 0x61, 0x73, 0x61, 0x18, 0x1f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12,
+	0x2e, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x2e, 0x4d, 0x61, 0x73, 0x61, 0x53, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x52, 0x04, 0x6d, 0x61, 0x73, 0x61, 0x12, 0x39, 0x0a, 0x0c, 0x75, 0x72, 0x6c, 0x5f,
+	0x72, 0x65, 0x77, 0x72, 0x69, 0x74, 0x65, 0x73, 0x18, 0x20, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16,
+	0x2e, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x2e, 0x55, 0x72, 0x6c, 0x52, 0x65, 0x77, 0x72, 0x69,
+	0x74, 0x65, 0x52, 0x75, 0x6c, 0x65, 0x52, 0x0b, 0x75, 0x72, 0x6c, 0x52, 0x65, 0x77, 0x72, 0x69,
+	0x74, 0x65, 0x73, 0x12, 0x67, 0x0a, 0x1d, 0x63, 0x68, 0x61, 0x73, 0x73, 0x69, 0x73, 0x5f, 0x64,
+	0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x5f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x21, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x23, 0x2e, 0x65, 0x6e, 0x74,
+	0x69, 0x74, 0x79, 0x2e, 0x43, 0x68, 0x61, 0x73, 0x73, 0x69, 0x73, 0x44, 0x65, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x6f, 0x72, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x1b, 0x63, 0x68, 0x61, 0x73, 0x73, 0x69, 0x73, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
+	0x6f, 0x72, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x39, 0x0a, 0x0d,
+	0x76, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x5f, 0x71, 0x75, 0x69, 0x72, 0x6b, 0x73, 0x18, 0x22, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x2e, 0x56, 0x65, 0x6e,
+	0x64, 0x6f, 0x72, 0x51, 0x75, 0x69, 0x72, 0x6b, 0x73, 0x52, 0x0c, 0x76, 0x65, 0x6e, 0x64, 0x6f,
+	0x72, 0x51, 0x75, 0x69, 0x72, 0x6b, 0x73, 0x12, 0x29, 0x0a, 0x07, 0x63, 0x6c, 0x75, 0x73, 0x74,
+	0x65, 0x72, 0x18, 0x23, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x65, 0x6e, 0x74, 0x69, 0x74,
+	0x79, 0x2e, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x52, 0x07, 0x63, 0x6c, 0x75, 0x73, 0x74,
+	0x65, 0x72, 0x12, 0x46, 0x0a, 0x12, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x5f,
+	0x70, 0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x18, 0x24, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17,
+	0x2e, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x2e, 0x42, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61,
+	0x70, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x11, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72,
+	0x61, 0x70, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x12, 0x4d, 0x0a, 0x14, 0x70, 0x72,
+	0x6f, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x69, 0x6e, 0x67, 0x5f, 0x77, 0x69, 0x6e, 0x64, 0x6f,
+	0x77, 0x73, 0x18, 0x25, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x65, 0x6e, 0x74, 0x69, 0x74,
+	0x79, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x69, 0x6e, 0x67, 0x57, 0x69,
+	0x6e, 0x64, 0x6f, 0x77, 0x52, 0x13, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x69,
+	0x6e, 0x67, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x73, 0x12, 0x32, 0x0a, 0x0a, 0x71, 0x75, 0x61,
+	0x72, 0x61, 0x6e, 0x74, 0x69, 0x6e, 0x65, 0x18, 0x26, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e,
+	0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x2e, 0x51, 0x75, 0x61, 0x72, 0x61, 0x6e, 0x74, 0x69, 0x6e,
+	0x65, 0x52, 0x0a, 0x71, 0x75, 0x61, 0x72, 0x61, 0x6e, 0x74, 0x69, 0x6e, 0x65, 0x12, 0x2c, 0x0a,
+	0x08, 0x61, 0x6c, 0x65, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x18, 0x27, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x10, 0x2e, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x2e, 0x41, 0x6c, 0x65, 0x72, 0x74, 0x69, 0x6e,
+	0x67, 0x52, 0x08, 0x61, 0x6c, 0x65, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x45, 0x0a, 0x11, 0x61,
+	0x64, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c,
+	0x18, 0x28, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x2e,
+	0x41, 0x64, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c,
+	0x52, 0x10, 0x61, 0x64, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x74, 0x72,
+	0x6f, 0x6c, 0x12, 0x27, 0x0a, 0x0f, 0x73, 0x69, 0x67, 0x6e, 0x69, 0x6e, 0x67, 0x5f, 0x77, 0x6f,
+	0x72, 0x6b, 0x65, 0x72, 0x73, 0x18, 0x29, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e, 0x73, 0x69, 0x67,
+	0x6e, 0x69, 0x6e, 0x67, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x73, 0x12, 0x39, 0x0a, 0x0d, 0x76,
+	0x6f, 0x75, 0x63, 0x68, 0x65, 0x72, 0x5f, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x18, 0x2a, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x14, 0x2e, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x2e, 0x56, 0x6f, 0x75, 0x63,
+	0x68, 0x65, 0x72, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x0c, 0x76, 0x6f, 0x75, 0x63, 0x68, 0x65,
+	0x72, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x12, 0x29, 0x0a, 0x10, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x5f,
+	0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x18, 0x2b, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x0f, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65,
+	0x73, 0x12, 0x2e, 0x0a, 0x13, 0x72, 0x65, 0x74, 0x69, 0x72, 0x65, 0x64, 0x5f, 0x64, 0x65, 0x76,
+	0x69, 0x63, 0x65, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x2c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11,
+	0x72, 0x65, 0x74, 0x69, 0x72, 0x65, 0x64, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x46, 0x69, 0x6c,
+	0x65, 0x22, 0x6f, 0x0a, 0x0a, 0x51, 0x75, 0x61, 0x72, 0x61, 0x6e, 0x74, 0x69, 0x6e, 0x65, 0x12,
+	0x40, 0x0a, 0x0e, 0x72, 0x65, 0x74, 0x72, 0x79, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61,
+	0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x0d, 0x72, 0x65, 0x74, 0x72, 0x79, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61,
+	0x6c, 0x12, 0x1f, 0x0a, 0x0b, 0x6d, 0x61, 0x78, 0x5f, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x6d, 0x61, 0x78, 0x50, 0x65, 0x6e, 0x64, 0x69,
+	0x6e, 0x67, 0x22, 0xec, 0x01, 0x0a, 0x10, 0x41, 0x64, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x12, 0x22, 0x0a, 0x0d, 0x6d, 0x61, 0x78, 0x5f, 0x69,
+	0x6e, 0x5f, 0x66, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b,
+	0x6d, 0x61, 0x78, 0x49, 0x6e, 0x46, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x12, 0x32, 0x0a, 0x16, 0x6d,
+	0x61, 0x78, 0x5f, 0x69, 0x6e, 0x5f, 0x66, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x5f, 0x70, 0x65, 0x72,
+	0x5f, 0x73, 0x69, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x12, 0x6d, 0x61, 0x78,
+	0x49, 0x6e, 0x46, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x50, 0x65, 0x72, 0x53, 0x69, 0x74, 0x65, 0x12,
+	0x3e, 0x0a, 0x0d, 0x71, 0x75, 0x65, 0x75, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x0c, 0x71, 0x75, 0x65, 0x75, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12,
+	0x40, 0x0a, 0x0e, 0x72, 0x65, 0x74, 0x72, 0x79, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61,
+	0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x0d, 0x72, 0x65, 0x74, 0x72, 0x79, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61,
+	0x6c, 0x22, 0x4b, 0x0a, 0x0c, 0x56, 0x6f, 0x75, 0x63, 0x68, 0x65, 0x72, 0x53, 0x74, 0x6f, 0x72,
+	0x65, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x12,
+	0x1d, 0x0a, 0x0a, 0x63, 0x61, 0x63, 0x68, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x09, 0x63, 0x61, 0x63, 0x68, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x22, 0x8c,
+	0x01, 0x0a, 0x08, 0x41, 0x6c, 0x65, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x27, 0x0a, 0x05, 0x72,
+	0x75, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x65, 0x6e, 0x74,
+	0x69, 0x74, 0x79, 0x2e, 0x41, 0x6c, 0x65, 0x72, 0x74, 0x52, 0x75, 0x6c, 0x65, 0x52, 0x05, 0x72,
+	0x75, 0x6c, 0x65, 0x73, 0x12, 0x26, 0x0a, 0x04, 0x73, 0x6d, 0x74, 0x70, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x12, 0x2e, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x2e, 0x53, 0x6d, 0x74, 0x70,
+	0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x52, 0x04, 0x73, 0x6d, 0x74, 0x70, 0x12, 0x2f, 0x0a, 0x09,
+	0x70, 0x61, 0x67, 0x65, 0x72, 0x64, 0x75, 0x74, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x11, 0x2e, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x2e, 0x50, 0x61, 0x67, 0x65, 0x72, 0x44, 0x75,
+	0x74, 0x79, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x72, 0x64, 0x75, 0x74, 0x79, 0x22, 0xbb, 0x01,
+	0x0a, 0x09, 0x41, 0x6c, 0x65, 0x72, 0x74, 0x52, 0x75, 0x6c, 0x65, 0x12, 0x2e, 0x0a, 0x07, 0x74,
+	0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x65,
+	0x6e, 0x74, 0x69, 0x74, 0x79, 0x2e, 0x41, 0x6c, 0x65, 0x72, 0x74, 0x54, 0x72, 0x69, 0x67, 0x67,
+	0x65, 0x72, 0x52, 0x07, 0x74, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x12, 0x21, 0x0a, 0x0c, 0x6d,
+	0x61, 0x78, 0x5f, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x0b, 0x6d, 0x61, 0x78, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x12, 0x31,
+	0x0a, 0x08, 0x73, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x15, 0x2e, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x2e, 0x41, 0x6c, 0x65, 0x72, 0x74, 0x53,
+	0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x52, 0x08, 0x73, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x67, 0x65, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x70, 0x61, 0x67, 0x65, 0x22, 0x7b, 0x0a, 0x0a, 0x53,
+	0x6d, 0x74, 0x70, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x5f,
+	0x66, 0x69, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x70, 0x61, 0x73, 0x73,
+	0x77, 0x6f, 0x72, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x22, 0x47, 0x0a, 0x09, 0x50, 0x61, 0x67, 0x65,
+	0x72, 0x44, 0x75, 0x74, 0x79, 0x12, 0x28, 0x0a, 0x10, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67,
+	0x5f, 0x6b, 0x65, 0x79, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x4b, 0x65, 0x79, 0x46, 0x69, 0x6c, 0x65, 0x12,
+	0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72,
+	0x6c, 0x22, 0x6d, 0x0a, 0x12, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x69, 0x6e,
+	0x67, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x79, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x64, 0x61, 0x79, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x73,
+	0x74, 0x61, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x65, 0x6e, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x7a, 0x6f, 0x6e, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x5a, 0x6f, 0x6e, 0x65,
+	0x22, 0xd5, 0x01, 0x0a, 0x0f, 0x42, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x50, 0x6f,
+	0x6c, 0x69, 0x63, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x72,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x65, 0x78,
+	0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x75, 0x73, 0x74,
+	0x6f, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d,
+	0x12, 0x3b, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x23, 0x2e, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x2e, 0x42, 0x6f, 0x6f, 0x74, 0x73, 0x74,
+	0x72, 0x61, 0x70, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x2e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x1a, 0x39, 0x0a,
+	0x0b, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xdf, 0x01, 0x0a, 0x07, 0x43, 0x6c, 0x75,
+	0x73, 0x74, 0x65, 0x72, 0x12, 0x2a, 0x0a, 0x05, 0x72, 0x65, 0x64, 0x69, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x2e, 0x52, 0x65, 0x64,
+	0x69, 0x73, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x48, 0x00, 0x52, 0x05, 0x72, 0x65, 0x64, 0x69, 0x73,
+	0x12, 0x1d, 0x0a, 0x0a, 0x6b, 0x65, 0x79, 0x5f, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6b, 0x65, 0x79, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12,
+	0x3e, 0x0a, 0x0d, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x5f, 0x74, 0x74, 0x6c,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x0c, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x54, 0x74, 0x6c, 0x12,
+	0x40, 0x0a, 0x0e, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x5f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x0d, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x42, 0x07, 0x0a, 0x05, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x22, 0xf9, 0x01, 0x0a, 0x0a, 0x52,
+	0x65, 0x64, 0x69, 0x73, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x5f,
+	0x66, 0x69, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x70, 0x61, 0x73, 0x73,
+	0x77, 0x6f, 0x72, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x64, 0x62, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x02, 0x64, 0x62, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x6c, 0x73, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x74, 0x6c, 0x73, 0x12, 0x27, 0x0a, 0x10, 0x74, 0x6c,
+	0x73, 0x5f, 0x63, 0x61, 0x5f, 0x63, 0x65, 0x72, 0x74, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x74, 0x6c, 0x73, 0x43, 0x61, 0x43, 0x65, 0x72, 0x74, 0x46,
+	0x69, 0x6c, 0x65, 0x12, 0x22, 0x0a, 0x0d, 0x74, 0x6c, 0x73, 0x5f, 0x63, 0x65, 0x72, 0x74, 0x5f,
+	0x66, 0x69, 0x6c, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x74, 0x6c, 0x73, 0x43,
+	0x65, 0x72, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x20, 0x0a, 0x0c, 0x74, 0x6c, 0x73, 0x5f, 0x6b,
+	0x65, 0x79, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74,
+	0x6c, 0x73, 0x4b, 0x65, 0x79, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x6f, 0x6f,
+	0x6c, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x70, 0x6f,
+	0x6f, 0x6c, 0x53, 0x69, 0x7a, 0x65, 0x22, 0x50, 0x0a, 0x0d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x43, 0x61, 0x63, 0x68, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x2b, 0x0a, 0x03, 0x74,
+	0x74, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x03, 0x74, 0x74, 0x6c, 0x22, 0xff, 0x01, 0x0a, 0x08, 0x54, 0x69, 0x6d,
+	0x65, 0x6f, 0x75, 0x74, 0x73, 0x12, 0x42, 0x0a, 0x0f, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65,
+	0x5f, 0x63, 0x68, 0x61, 0x73, 0x73, 0x69, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0e, 0x72, 0x65, 0x73, 0x6f, 0x6c,
+	0x76, 0x65, 0x43, 0x68, 0x61, 0x73, 0x73, 0x69, 0x73, 0x12, 0x40, 0x0a, 0x0e, 0x62, 0x6f, 0x6f,
+	0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0d, 0x62, 0x6f,
+	0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x44, 0x61, 0x74, 0x61, 0x12, 0x2d, 0x0a, 0x04, 0x73,
+	0x69, 0x67, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x04, 0x73, 0x69, 0x67, 0x6e, 0x12, 0x3e, 0x0a, 0x0d, 0x72, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0c, 0x72, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x53, 0x0a, 0x0e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x4c, 0x6f, 0x67, 0x67, 0x69, 0x6e, 0x67, 0x12, 0x1c, 0x0a, 0x09,
+	0x76, 0x65, 0x72, 0x62, 0x6f, 0x73, 0x69, 0x74, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x09, 0x76, 0x65, 0x72, 0x62, 0x6f, 0x73, 0x69, 0x74, 0x79, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65,
+	0x64, 0x61, 0x63, 0x74, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x0c, 0x72, 0x65, 0x64, 0x61, 0x63, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x22,
+	0x86, 0x01, 0x0a, 0x0c, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x53, 0x79, 0x6e, 0x63,
+	0x12, 0x30, 0x0a, 0x07, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x16, 0x2e, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x2e, 0x41, 0x72, 0x74, 0x69, 0x66,
+	0x61, 0x63, 0x74, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x52, 0x07, 0x62, 0x75, 0x63, 0x6b, 0x65,
+	0x74, 0x73, 0x12, 0x44, 0x0a, 0x10, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x5f, 0x69, 0x6e,
+	0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0f, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68,
+	0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x22, 0x9f, 0x01, 0x0a, 0x0e, 0x41, 0x72, 0x74,
+	0x69, 0x66, 0x61, 0x63, 0x74, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x75,
+	0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x1c, 0x0a,
+	0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x65,
+	0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65,
+	0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f,
+	0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x12,
+	0x29, 0x0a, 0x10, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x5f, 0x66,
+	0x69, 0x6c, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x63, 0x72, 0x65, 0x64, 0x65,
+	0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x46, 0x69, 0x6c, 0x65, 0x22, 0x98, 0x02, 0x0a, 0x0e, 0x46,
+	0x61, 0x75, 0x6c, 0x74, 0x49, 0x6e, 0x6a, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x34, 0x0a,
+	0x06, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e,
+	0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x2e, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x49, 0x6e, 0x6a, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x52, 0x06, 0x66, 0x61, 0x75,
+	0x6c, 0x74, 0x73, 0x1a, 0xcf, 0x01, 0x0a, 0x05, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x12, 0x25, 0x0a,
+	0x0e, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x4e, 0x75, 0x6d,
+	0x62, 0x65, 0x72, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x73, 0x12, 0x33,
+	0x0a, 0x07, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x6c, 0x61, 0x74, 0x65,
+	0x6e, 0x63, 0x79, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x72, 0x6f, 0x70, 0x5f, 0x72, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x64, 0x72, 0x6f, 0x70,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2b, 0x0a, 0x11, 0x63, 0x6f, 0x72, 0x72,
+	0x75, 0x70, 0x74, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x10, 0x63, 0x6f, 0x72, 0x72, 0x75, 0x70, 0x74, 0x53, 0x69, 0x67, 0x6e,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0x8a, 0x01, 0x0a, 0x13, 0x53, 0x65, 0x72, 0x69, 0x61, 0x6c,
+	0x4e, 0x6f, 0x72, 0x6d, 0x61, 0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x0a,
+	0x0b, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x5f, 0x63, 0x61, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0a, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x43, 0x61, 0x73, 0x65, 0x12, 0x25,
+	0x0a, 0x0e, 0x73, 0x74, 0x72, 0x69, 0x70, 0x5f, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x65, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x73, 0x74, 0x72, 0x69, 0x70, 0x50, 0x72, 0x65,
+	0x66, 0x69, 0x78, 0x65, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x5f,
+	0x73, 0x65, 0x70, 0x61, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x10, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x53, 0x65, 0x70, 0x61, 0x72, 0x61, 0x74, 0x6f,
+	0x72, 0x73, 0x22, 0xb1, 0x01, 0x0a, 0x0f, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70,
+	0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x61, 0x72,
+	0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x5f, 0x64, 0x69, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x44, 0x69, 0x72, 0x12, 0x1c, 0x0a,
+	0x0a, 0x6f, 0x63, 0x5f, 0x6b, 0x65, 0x79, 0x5f, 0x75, 0x72, 0x69, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x6f, 0x63, 0x4b, 0x65, 0x79, 0x55, 0x72, 0x69, 0x12, 0x1e, 0x0a, 0x0b, 0x70,
+	0x64, 0x63, 0x5f, 0x6b, 0x65, 0x79, 0x5f, 0x75, 0x72, 0x69, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x70, 0x64, 0x63, 0x4b, 0x65, 0x79, 0x55, 0x72, 0x69, 0x12, 0x29, 0x0a, 0x10, 0x61,
+	0x64, 0x6d, 0x69, 0x6e, 0x5f, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x18,
+	0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x49, 0x64, 0x65, 0x6e,
+	0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x22, 0x92, 0x03, 0x0a, 0x0c, 0x4e, 0x65, 0x74, 0x42, 0x6f,
+	0x78, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x6f, 0x6b,
+	0x65, 0x6e, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74,
+	0x6f, 0x6b, 0x65, 0x6e, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x3b, 0x0a, 0x07, 0x66, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x65, 0x6e, 0x74, 0x69,
+	0x74, 0x79, 0x2e, 0x4e, 0x65, 0x74, 0x42, 0x6f, 0x78, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e,
+	0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x66, 0x69,
+	0x6c, 0x74, 0x65, 0x72, 0x73, 0x12, 0x48, 0x0a, 0x0c, 0x72, 0x6f, 0x6c, 0x65, 0x5f, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x65, 0x6e,
+	0x74, 0x69, 0x74, 0x79, 0x2e, 0x4e, 0x65, 0x74, 0x42, 0x6f, 0x78, 0x53, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x2e, 0x52, 0x6f, 0x6c, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x0b, 0x72, 0x6f, 0x6c, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x12,
+	0x3e, 0x0a, 0x0d, 0x73, 0x79, 0x6e, 0x63, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x0c, 0x73, 0x79, 0x6e, 0x63, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x1a,
+	0x3a, 0x0a, 0x0c, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x4e, 0x0a, 0x10, 0x52,
+	0x6f, 0x6c, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x24, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0e, 0x2e, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xa6, 0x01, 0x0a, 0x0a,
+	0x4d, 0x61, 0x73, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72,
+	0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x20, 0x0a, 0x0c,
+	0x63, 0x61, 0x5f, 0x63, 0x65, 0x72, 0x74, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x63, 0x61, 0x43, 0x65, 0x72, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x24,
+	0x0a, 0x0d, 0x6d, 0x61, 0x6e, 0x75, 0x66, 0x61, 0x63, 0x74, 0x75, 0x72, 0x65, 0x72, 0x73, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x6d, 0x61, 0x6e, 0x75, 0x66, 0x61, 0x63, 0x74, 0x75,
+	0x72, 0x65, 0x72, 0x73, 0x12, 0x3e, 0x0a, 0x0d, 0x73, 0x79, 0x6e, 0x63, 0x5f, 0x69, 0x6e, 0x74,
+	0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0c, 0x73, 0x79, 0x6e, 0x63, 0x49, 0x6e, 0x74, 0x65,
+	0x72, 0x76, 0x61, 0x6c, 0x22, 0x95, 0x01, 0x0a, 0x0c, 0x52, 0x65, 0x64, 0x69, 0x72, 0x65, 0x63,
+	0x74, 0x52, 0x75, 0x6c, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f,
+	0x73, 0x75, 0x62, 0x6e, 0x65, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x53, 0x75, 0x62, 0x6e, 0x65, 0x74, 0x73, 0x12, 0x27, 0x0a, 0x0f,
+	0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x5f, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x65, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x50, 0x72, 0x65,
+	0x66, 0x69, 0x78, 0x65, 0x73, 0x12, 0x35, 0x0a, 0x07, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x7a, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x53, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x52, 0x07, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x22, 0x8e, 0x01, 0x0a,
+	0x0e, 0x55, 0x72, 0x6c, 0x52, 0x65, 0x77, 0x72, 0x69, 0x74, 0x65, 0x52, 0x75, 0x6c, 0x65, 0x12,
+	0x25, 0x0a, 0x0e, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x73, 0x75, 0x62, 0x6e, 0x65, 0x74,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x53,
+	0x75, 0x62, 0x6e, 0x65, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x69, 0x74, 0x65, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x73, 0x69, 0x74, 0x65, 0x73, 0x12, 0x1d, 0x0a, 0x0a,
+	0x75, 0x72, 0x6c, 0x5f, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x75, 0x72, 0x6c, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x20, 0x0a, 0x0b, 0x72,
+	0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x22, 0x98, 0x02,
+	0x0a, 0x0c, 0x56, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x51, 0x75, 0x69, 0x72, 0x6b, 0x73, 0x12, 0x24,
+	0x0a, 0x0d, 0x6d, 0x61, 0x6e, 0x75, 0x66, 0x61, 0x63, 0x74, 0x75, 0x72, 0x65, 0x72, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x6d, 0x61, 0x6e, 0x75, 0x66, 0x61, 0x63, 0x74, 0x75,
+	0x72, 0x65, 0x72, 0x73, 0x12, 0x35, 0x0a, 0x16, 0x63, 0x61, 0x6e, 0x6f, 0x6e, 0x69, 0x63, 0x61,
+	0x6c, 0x5f, 0x6d, 0x61, 0x6e, 0x75, 0x66, 0x61, 0x63, 0x74, 0x75, 0x72, 0x65, 0x72, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x15, 0x63, 0x61, 0x6e, 0x6f, 0x6e, 0x69, 0x63, 0x61, 0x6c, 0x4d,
+	0x61, 0x6e, 0x75, 0x66, 0x61, 0x63, 0x74, 0x75, 0x72, 0x65, 0x72, 0x12, 0x33, 0x0a, 0x16, 0x73,
+	0x65, 0x72, 0x69, 0x61, 0x6c, 0x5f, 0x69, 0x6e, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c,
+	0x5f, 0x63, 0x61, 0x72, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x13, 0x73, 0x65, 0x72,
+	0x69, 0x61, 0x6c, 0x49, 0x6e, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x43, 0x61, 0x72, 0x64,
+	0x12, 0x2e, 0x0a, 0x13, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x5f, 0x70, 0x61, 0x72, 0x74,
+	0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x64,
+	0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x50, 0x61, 0x72, 0x74, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72,
+	0x12, 0x46, 0x0a, 0x20, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x5f, 0x63, 0x6f, 0x6e, 0x74,
+	0x72, 0x6f, 0x6c, 0x5f, 0x63, 0x61, 0x72, 0x64, 0x5f, 0x70, 0x61, 0x72, 0x74, 0x5f, 0x6e, 0x75,
+	0x6d, 0x62, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x1c, 0x64, 0x65, 0x66, 0x61,
+	0x75, 0x6c, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x43, 0x61, 0x72, 0x64, 0x50, 0x61,
+	0x72, 0x74, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x22, 0xfc, 0x01, 0x0a, 0x0d, 0x41, 0x75, 0x74,
+	0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x5f, 0x73, 0x75, 0x62, 0x6e, 0x65, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x0d, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x53, 0x75, 0x62, 0x6e, 0x65, 0x74,
+	0x73, 0x12, 0x36, 0x0a, 0x0c, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65,
+	0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79,
+	0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x0b, 0x74, 0x6f,
+	0x6b, 0x65, 0x6e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x75, 0x73,
+	0x74, 0x6f, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x75, 0x73, 0x74, 0x6f,
+	0x6d, 0x12, 0x39, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x21, 0x2e, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x65,
+	0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x1a, 0x39, 0x0a, 0x0b,
+	0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x44, 0x0a, 0x0b, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
+	0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x1d,
+	0x0a, 0x0a, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x46, 0x69, 0x6c, 0x65, 0x22, 0xb7, 0x01,
+	0x0a, 0x11, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x52, 0x6f, 0x74, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x5f,
+	0x64, 0x69, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x72, 0x74, 0x69, 0x66,
+	0x61, 0x63, 0x74, 0x44, 0x69, 0x72, 0x12, 0x1c, 0x0a, 0x0a, 0x6f, 0x63, 0x5f, 0x6b, 0x65, 0x79,
+	0x5f, 0x75, 0x72, 0x69, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6f, 0x63, 0x4b, 0x65,
+	0x79, 0x55, 0x72, 0x69, 0x12, 0x1e, 0x0a, 0x0b, 0x70, 0x64, 0x63, 0x5f, 0x6b, 0x65, 0x79, 0x5f,
+	0x75, 0x72, 0x69, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x64, 0x63, 0x4b, 0x65,
+	0x79, 0x55, 0x72, 0x69, 0x12, 0x41, 0x0a, 0x0e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x65, 0x6e, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0d, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x45, 0x6e, 0x64, 0x22, 0x95, 0x01, 0x0a, 0x0f, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x3e, 0x0a, 0x0d, 0x61,
+	0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x19, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x7a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0c, 0x61,
+	0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x28, 0x0a, 0x10, 0x6a,
+	0x73, 0x6f, 0x6e, 0x5f, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x6a, 0x73, 0x6f, 0x6e, 0x53, 0x63, 0x68, 0x65, 0x6d,
+	0x61, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x22,
+	0x94, 0x01, 0x0a, 0x0a, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x22,
+	0x0a, 0x0c, 0x6d, 0x61, 0x6e, 0x75, 0x66, 0x61, 0x63, 0x74, 0x75, 0x72, 0x65, 0x72, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6d, 0x61, 0x6e, 0x75, 0x66, 0x61, 0x63, 0x74, 0x75, 0x72,
+	0x65, 0x72, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x61, 0x72, 0x74, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65,
+	0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x61, 0x72, 0x74, 0x4e, 0x75, 0x6d,
+	0x62, 0x65, 0x72, 0x12, 0x41, 0x0a, 0x0e, 0x73, 0x6f, 0x66, 0x74, 0x77, 0x61, 0x72, 0x65, 0x5f,
+	0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x62, 0x6f,
+	0x6f, 0x74, 0x7a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x6f, 0x66, 0x74, 0x77, 0x61,
+	0x72, 0x65, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x0d, 0x73, 0x6f, 0x66, 0x74, 0x77, 0x61, 0x72,
+	0x65, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x22, 0xef, 0x01, 0x0a, 0x0a, 0x45, 0x78, 0x70, 0x65, 0x72,
+	0x69, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x2f, 0x0a, 0x07, 0x64, 0x65, 0x76,
+	0x69, 0x63, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x65, 0x6e, 0x74,
+	0x69, 0x74, 0x79, 0x2e, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x44, 0x65, 0x76, 0x69, 0x63,
+	0x65, 0x52, 0x07, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x12, 0x31, 0x0a, 0x14, 0x74, 0x72,
+	0x65, 0x61, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x61,
+	0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x13, 0x74, 0x72, 0x65, 0x61, 0x74, 0x6d,
+	0x65, 0x6e, 0x74, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x61, 0x67, 0x65, 0x12, 0x41, 0x0a,
+	0x0e, 0x73, 0x6f, 0x66, 0x74, 0x77, 0x61, 0x72, 0x65, 0x5f, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x7a, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x6f, 0x66, 0x74, 0x77, 0x61, 0x72, 0x65, 0x49, 0x6d, 0x61, 0x67,
+	0x65, 0x52, 0x0d, 0x73, 0x6f, 0x66, 0x74, 0x77, 0x61, 0x72, 0x65, 0x49, 0x6d, 0x61, 0x67, 0x65,
+	0x12, 0x26, 0x0a, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0e, 0x2e, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x52, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x22, 0xe4, 0x01, 0x0a, 0x0e, 0x44, 0x65, 0x66,
+	0x61, 0x75, 0x6c, 0x74, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x33, 0x0a, 0x09, 0x61,
+	0x6c, 0x6c, 0x6f, 0x77, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15,
+	0x2e, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x2e, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x44,
+	0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x09, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x6c, 0x69, 0x73, 0x74,
+	0x12, 0x32, 0x0a, 0x09, 0x62, 0x6f, 0x6f, 0x74, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x7a, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x42, 0x6f, 0x6f, 0x74, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x08, 0x62, 0x6f, 0x6f, 0x74,
+	0x4d, 0x6f, 0x64, 0x65, 0x12, 0x41, 0x0a, 0x0e, 0x73, 0x6f, 0x66, 0x74, 0x77, 0x61, 0x72, 0x65,
+	0x5f, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x62,
+	0x6f, 0x6f, 0x74, 0x7a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x6f, 0x66, 0x74, 0x77,
+	0x61, 0x72, 0x65, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x0d, 0x73, 0x6f, 0x66, 0x74, 0x77, 0x61,
+	0x72, 0x65, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x26, 0x0a, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79,
+	0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x22,
+	0x54, 0x0a, 0x0d, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65,
+	0x12, 0x22, 0x0a, 0x0c, 0x6d, 0x61, 0x6e, 0x75, 0x66, 0x61, 0x63, 0x74, 0x75, 0x72, 0x65, 0x72,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6d, 0x61, 0x6e, 0x75, 0x66, 0x61, 0x63, 0x74,
+	0x75, 0x72, 0x65, 0x72, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x61, 0x72, 0x74, 0x5f, 0x6e, 0x75, 0x6d,
+	0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x61, 0x72, 0x74, 0x4e,
+	0x75, 0x6d, 0x62, 0x65, 0x72, 0x22, 0x67, 0x0a, 0x0b, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65,
+	0x46, 0x6c, 0x61, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x61, 0x62,
+	0x6c, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c,
+	0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69,
+	0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x73, 0x69, 0x74, 0x65, 0x22, 0xa5,
+	0x05, 0x0a, 0x08, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64,
+	0x5f, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74,
+	0x12, 0x29, 0x0a, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0f, 0x2e, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x2e, 0x4f, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x52, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x29, 0x0a, 0x07, 0x63,
+	0x68, 0x61, 0x73, 0x73, 0x69, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x65,
+	0x6e, 0x74, 0x69, 0x74, 0x79, 0x2e, 0x43, 0x68, 0x61, 0x73, 0x73, 0x69, 0x73, 0x52, 0x07, 0x63,
+	0x68, 0x61, 0x73, 0x73, 0x69, 0x73, 0x12, 0x5d, 0x0a, 0x15, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f,
+	0x6c, 0x5f, 0x63, 0x61, 0x72, 0x64, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x65, 0x73, 0x18,
+	0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x2e, 0x53,
+	0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x43,
+	0x61, 0x72, 0x64, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x52, 0x13, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x43, 0x61, 0x72, 0x64, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x65, 0x73, 0x12, 0x3e, 0x0a, 0x0d, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f,
+	0x73, 0x74, 0x61, 0x74, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x65,
+	0x6e, 0x74, 0x69, 0x74, 0x79, 0x2e, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x0c, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x73, 0x12, 0x47, 0x0a, 0x10, 0x72, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x64,
+	0x5f, 0x76, 0x6f, 0x75, 0x63, 0x68, 0x65, 0x72, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1c, 0x2e, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x2e, 0x52, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x64,
+	0x56, 0x6f, 0x75, 0x63, 0x68, 0x65, 0x72, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x0f, 0x72,
+	0x65, 0x76, 0x6f, 0x6b, 0x65, 0x64, 0x56, 0x6f, 0x75, 0x63, 0x68, 0x65, 0x72, 0x73, 0x12, 0x47,
+	0x0a, 0x10, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x5f, 0x74, 0x6f, 0x6b, 0x65,
+	0x6e, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x65, 0x6e, 0x74, 0x69, 0x74,
+	0x79, 0x2e, 0x42, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
+	0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x0f, 0x62, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61,
+	0x70, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x12, 0x44, 0x0a, 0x0f, 0x72, 0x65, 0x74, 0x69, 0x72,
+	0x65, 0x64, 0x5f, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1b, 0x2e, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x2e, 0x52, 0x65, 0x74, 0x69, 0x72, 0x65,
+	0x64, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x0e, 0x72,
+	0x65, 0x74, 0x69, 0x72, 0x65, 0x64, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x73, 0x1a, 0x77, 0x0a,
+	0x18, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x43, 0x61, 0x72, 0x64, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x45, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2f, 0x2e, 0x62, 0x6f, 0x6f,
+	0x74, 0x7a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c,
+	0x43, 0x61, 0x72, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f,
+	0x6c, 0x43, 0x61, 0x72, 0x64, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xed, 0x04, 0x0a, 0x11, 0x44, 0x65, 0x76, 0x69, 0x63,
+	0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x23, 0x0a, 0x0d,
+	0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x4e, 0x75, 0x6d, 0x62, 0x65,
+	0x72, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x74, 0x72, 0x69,
+	0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x72, 0x65, 0x74, 0x72, 0x69, 0x65,
+	0x73, 0x12, 0x39, 0x0a, 0x0a, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x09, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x41, 0x74, 0x12, 0x33, 0x0a, 0x07,
+	0x73, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x06, 0x73, 0x65, 0x6e, 0x74, 0x41,
+	0x74, 0x12, 0x3b, 0x0a, 0x0b, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x0a, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x14,
+	0x0a, 0x05, 0x73, 0x74, 0x61, 0x67, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73,
+	0x74, 0x61, 0x67, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x72,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x12, 0x3c, 0x0a,
+	0x0c, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x73, 0x65, 0x65, 0x6e, 0x5f, 0x61, 0x74, 0x18, 0x09, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x0a, 0x6c, 0x61, 0x73, 0x74, 0x53, 0x65, 0x65, 0x6e, 0x41, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x6c,
+	0x61, 0x73, 0x74, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0b, 0x6c, 0x61, 0x73, 0x74, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x2a,
+	0x0a, 0x11, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x5f, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x68,
+	0x61, 0x73, 0x68, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x64, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x48, 0x61, 0x73, 0x68, 0x12, 0x2c, 0x0a, 0x12, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x64, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x5f, 0x68, 0x61, 0x73, 0x68,
+	0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x48, 0x61, 0x73, 0x68, 0x12, 0x2c, 0x0a, 0x12, 0x61, 0x70, 0x70, 0x6c,
+	0x69, 0x65, 0x64, 0x5f, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x0d,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x65, 0x64, 0x49, 0x6d, 0x61,
+	0x67, 0x65, 0x48, 0x61, 0x73, 0x68, 0x12, 0x2e, 0x0a, 0x13, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x65,
+	0x64, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x0e, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x11, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x65, 0x64, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x48, 0x61, 0x73, 0x68, 0x22, 0x8e, 0x01, 0x0a, 0x14, 0x52, 0x65, 0x76, 0x6f, 0x6b,
+	0x65, 0x64, 0x56, 0x6f, 0x75, 0x63, 0x68, 0x65, 0x72, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12,
+	0x23, 0x0a, 0x0d, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x4e, 0x75,
+	0x6d, 0x62, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x39, 0x0a, 0x0a,
+	0x72, 0x65, 0x76, 0x6f, 0x6b, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x72, 0x65,
+	0x76, 0x6f, 0x6b, 0x65, 0x64, 0x41, 0x74, 0x22, 0xce, 0x01, 0x0a, 0x14, 0x42, 0x6f, 0x6f, 0x74,
+	0x73, 0x74, 0x72, 0x61, 0x70, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x12, 0x23, 0x0a, 0x0d, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65,
+	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x4e,
+	0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x68,
+	0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
+	0x48, 0x61, 0x73, 0x68, 0x12, 0x37, 0x0a, 0x09, 0x69, 0x73, 0x73, 0x75, 0x65, 0x64, 0x5f, 0x61,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x52, 0x08, 0x69, 0x73, 0x73, 0x75, 0x65, 0x64, 0x41, 0x74, 0x12, 0x39, 0x0a,
+	0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x5f, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x65,
+	0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x22, 0xd3, 0x02, 0x0a, 0x13, 0x52, 0x65, 0x74,
+	0x69, 0x72, 0x65, 0x64, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x12, 0x23, 0x0a, 0x0d, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65,
+	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x4e,
+	0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x39, 0x0a,
+	0x0a, 0x72, 0x65, 0x74, 0x69, 0x72, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x72,
+	0x65, 0x74, 0x69, 0x72, 0x65, 0x64, 0x41, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x70, 0x6c,
+	0x61, 0x63, 0x65, 0x64, 0x5f, 0x62, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72,
+	0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x64, 0x42, 0x79, 0x12, 0x29, 0x0a, 0x07, 0x63, 0x68, 0x61,
+	0x73, 0x73, 0x69, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x65, 0x6e, 0x74,
+	0x69, 0x74, 0x79, 0x2e, 0x43, 0x68, 0x61, 0x73, 0x73, 0x69, 0x73, 0x52, 0x07, 0x63, 0x68, 0x61,
+	0x73, 0x73, 0x69, 0x73, 0x12, 0x2f, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x2e, 0x44, 0x65, 0x76,
+	0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x05,
+	0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x47, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2f, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x7a, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x43, 0x61, 0x72, 0x64, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x43, 0x61, 0x72, 0x64,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x60,
+	0x0a, 0x08, 0x45, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x29, 0x0a, 0x07, 0x6f, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x65, 0x6e,
+	0x74, 0x69, 0x74, 0x79, 0x2e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x07, 0x6f, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x29, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x73, 0x73, 0x69, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x2e,
+	0x43, 0x68, 0x61, 0x73, 0x73, 0x69, 0x73, 0x52, 0x07, 0x63, 0x68, 0x61, 0x73, 0x73, 0x69, 0x73,
+	0x22, 0xeb, 0x01, 0x0a, 0x06, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x33, 0x0a, 0x0b, 0x62,
 	0x6f, 0x6f, 0x74, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
 	0x32, 0x12, 0x2e, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x2e, 0x42, 0x6f, 0x6f, 0x74, 0x43, 0x6f,
 	0x6e, 0x66, 0x69, 0x67, 0x52, 0x0a, 0x62, 0x6f, 0x6f, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
 	0x12, 0x33, 0x0a, 0x0b, 0x67, 0x6e, 0x73, 0x69, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18,
 	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x2e, 0x47,
 	0x4e, 0x53, 0x49, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x0a, 0x67, 0x6e, 0x73, 0x69, 0x43,
-	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x22, 0xdb, 0x01, 0x0a, 0x0a, 0x42, 0x6f, 0x6f, 0x74, 0x43, 0x6f,
-	0x6e, 0x66, 0x69, 0x67, 0x12, 0x33, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52,
-	0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x2c, 0x0a, 0x12, 0x76, 0x65, 0x6e,
-	0x64, 0x6f, 0x72, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x76, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x43, 0x6f, 0x6e,
-	0x66, 0x69, 0x67, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x24, 0x0a, 0x0e, 0x6f, 0x63, 0x5f, 0x63, 0x6f,
-	0x6e, 0x66, 0x69, 0x67, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0c, 0x6f, 0x63, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x44, 0x0a,
-	0x11, 0x62, 0x6f, 0x6f, 0x74, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x5f, 0x63, 0x6f, 0x6e, 0x66,
-	0x69, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
-	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63,
-	0x74, 0x52, 0x10, 0x62, 0x6f, 0x6f, 0x74, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x43, 0x6f, 0x6e,
-	0x66, 0x69, 0x67, 0x22, 0xba, 0x03, 0x0a, 0x0a, 0x47, 0x4e, 0x53, 0x49, 0x43, 0x6f, 0x6e, 0x66,
-	0x69, 0x67, 0x12, 0x2a, 0x0a, 0x11, 0x61, 0x75, 0x74, 0x68, 0x7a, 0x5f, 0x75, 0x70, 0x6c, 0x6f,
-	0x61, 0x64, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x61,
-	0x75, 0x74, 0x68, 0x7a, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x3f,
-	0x0a, 0x0c, 0x61, 0x75, 0x74, 0x68, 0x7a, 0x5f, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6e, 0x73, 0x69, 0x2e, 0x61, 0x75, 0x74, 0x68,
-	0x7a, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x52, 0x0b, 0x61, 0x75, 0x74, 0x68, 0x7a, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x12,
-	0x2a, 0x0a, 0x11, 0x70, 0x61, 0x74, 0x68, 0x7a, 0x5f, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x5f,
-	0x66, 0x69, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x70, 0x61, 0x74, 0x68,
-	0x7a, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x3f, 0x0a, 0x0c, 0x70,
-	0x61, 0x74, 0x68, 0x7a, 0x5f, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6e, 0x73, 0x69, 0x2e, 0x70, 0x61, 0x74, 0x68, 0x7a, 0x2e, 0x76,
-	0x31, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52,
-	0x0b, 0x70, 0x61, 0x74, 0x68, 0x7a, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x3f, 0x0a, 0x0c,
-	0x63, 0x65, 0x72, 0x74, 0x7a, 0x5f, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x05, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6e, 0x73, 0x69, 0x2e, 0x63, 0x65, 0x72, 0x74, 0x7a, 0x2e,
-	0x76, 0x31, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x52, 0x0b, 0x63, 0x65, 0x72, 0x74, 0x7a, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x2a, 0x0a,
-	0x11, 0x63, 0x65, 0x72, 0x74, 0x7a, 0x5f, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x66, 0x69,
-	0x6c, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x63, 0x65, 0x72, 0x74, 0x7a, 0x55,
-	0x70, 0x6c, 0x6f, 0x61, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x29, 0x0a, 0x10, 0x63, 0x72, 0x65,
-	0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x07, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0f, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73,
-	0x46, 0x69, 0x6c, 0x65, 0x12, 0x3a, 0x0a, 0x0b, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69,
-	0x61, 0x6c, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x62, 0x6f, 0x6f, 0x74,
-	0x7a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69,
-	0x61, 0x6c, 0x73, 0x52, 0x0b, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73,
-	0x22, 0x92, 0x01, 0x0a, 0x0a, 0x44, 0x48, 0x43, 0x50, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12,
-	0x29, 0x0a, 0x10, 0x68, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x5f, 0x61, 0x64, 0x64, 0x72,
-	0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x68, 0x61, 0x72, 0x64, 0x77,
-	0x61, 0x72, 0x65, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x70,
-	0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
-	0x69, 0x70, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x67, 0x61, 0x74,
-	0x65, 0x77, 0x61, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x67, 0x61, 0x74, 0x65,
-	0x77, 0x61, 0x79, 0x12, 0x20, 0x0a, 0x0b, 0x62, 0x6f, 0x6f, 0x74, 0x7a, 0x73, 0x65, 0x72, 0x76,
-	0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x62, 0x6f, 0x6f, 0x74, 0x7a, 0x73,
-	0x65, 0x72, 0x76, 0x65, 0x72, 0x22, 0xb5, 0x01, 0x0a, 0x0b, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f,
-	0x6c, 0x43, 0x61, 0x72, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x61, 0x72, 0x74, 0x5f, 0x6e, 0x75,
-	0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x61, 0x72, 0x74,
-	0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c,
-	0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73,
-	0x65, 0x72, 0x69, 0x61, 0x6c, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x2b, 0x0a, 0x11, 0x6f,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x37, 0x0a, 0x18, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x5f,
+	0x73, 0x74, 0x61, 0x67, 0x65, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x5f, 0x66, 0x69, 0x6c,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x15, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x53,
+	0x74, 0x61, 0x67, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x3e,
+	0x0a, 0x11, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x5f, 0x62, 0x6f, 0x6f, 0x74, 0x5f, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x65, 0x6e, 0x74, 0x69,
+	0x74, 0x79, 0x2e, 0x42, 0x6f, 0x6f, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x0f, 0x66,
+	0x69, 0x6e, 0x61, 0x6c, 0x42, 0x6f, 0x6f, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x22, 0xc7,
+	0x02, 0x0a, 0x0a, 0x42, 0x6f, 0x6f, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x33, 0x0a,
+	0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61,
+	0x74, 0x61, 0x12, 0x2c, 0x0a, 0x12, 0x76, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x5f, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10,
+	0x76, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x46, 0x69, 0x6c, 0x65,
+	0x12, 0x24, 0x0a, 0x0e, 0x6f, 0x63, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x5f, 0x66, 0x69,
+	0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6f, 0x63, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x44, 0x0a, 0x11, 0x62, 0x6f, 0x6f, 0x74, 0x6c, 0x6f,
+	0x61, 0x64, 0x65, 0x72, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x10, 0x62, 0x6f, 0x6f, 0x74,
+	0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x31, 0x0a, 0x15,
+	0x67, 0x6e, 0x6d, 0x69, 0x5f, 0x73, 0x65, 0x74, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x67, 0x6e, 0x6d,
+	0x69, 0x53, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x12,
+	0x37, 0x0a, 0x08, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x1b, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x7a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x08,
+	0x65, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x22, 0xba, 0x03, 0x0a, 0x0a, 0x47, 0x4e, 0x53,
+	0x49, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x2a, 0x0a, 0x11, 0x61, 0x75, 0x74, 0x68, 0x7a,
+	0x5f, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0f, 0x61, 0x75, 0x74, 0x68, 0x7a, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x46,
+	0x69, 0x6c, 0x65, 0x12, 0x3f, 0x0a, 0x0c, 0x61, 0x75, 0x74, 0x68, 0x7a, 0x5f, 0x75, 0x70, 0x6c,
+	0x6f, 0x61, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6e, 0x73, 0x69,
+	0x2e, 0x61, 0x75, 0x74, 0x68, 0x7a, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x0b, 0x61, 0x75, 0x74, 0x68, 0x7a, 0x55, 0x70,
+	0x6c, 0x6f, 0x61, 0x64, 0x12, 0x2a, 0x0a, 0x11, 0x70, 0x61, 0x74, 0x68, 0x7a, 0x5f, 0x75, 0x70,
+	0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0f, 0x70, 0x61, 0x74, 0x68, 0x7a, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x46, 0x69, 0x6c, 0x65,
+	0x12, 0x3f, 0x0a, 0x0c, 0x70, 0x61, 0x74, 0x68, 0x7a, 0x5f, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6e, 0x73, 0x69, 0x2e, 0x70, 0x61,
+	0x74, 0x68, 0x7a, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x52, 0x0b, 0x70, 0x61, 0x74, 0x68, 0x7a, 0x55, 0x70, 0x6c, 0x6f, 0x61,
+	0x64, 0x12, 0x3f, 0x0a, 0x0c, 0x63, 0x65, 0x72, 0x74, 0x7a, 0x5f, 0x75, 0x70, 0x6c, 0x6f, 0x61,
+	0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6e, 0x73, 0x69, 0x2e, 0x63,
+	0x65, 0x72, 0x74, 0x7a, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x0b, 0x63, 0x65, 0x72, 0x74, 0x7a, 0x55, 0x70, 0x6c, 0x6f,
+	0x61, 0x64, 0x12, 0x2a, 0x0a, 0x11, 0x63, 0x65, 0x72, 0x74, 0x7a, 0x5f, 0x75, 0x70, 0x6c, 0x6f,
+	0x61, 0x64, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x63,
+	0x65, 0x72, 0x74, 0x7a, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x29,
+	0x0a, 0x10, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x5f, 0x66, 0x69,
+	0x6c, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e,
+	0x74, 0x69, 0x61, 0x6c, 0x73, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x3a, 0x0a, 0x0b, 0x63, 0x72, 0x65,
+	0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18,
+	0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x7a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x72, 0x65,
+	0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x52, 0x0b, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e,
+	0x74, 0x69, 0x61, 0x6c, 0x73, 0x22, 0x92, 0x01, 0x0a, 0x0a, 0x44, 0x48, 0x43, 0x50, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x12, 0x29, 0x0a, 0x10, 0x68, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65,
+	0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f,
+	0x68, 0x61, 0x72, 0x64, 0x77, 0x61, 0x72, 0x65, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12,
+	0x1d, 0x0a, 0x0a, 0x69, 0x70, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x69, 0x70, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x18,
+	0x0a, 0x07, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x12, 0x20, 0x0a, 0x0b, 0x62, 0x6f, 0x6f, 0x74,
+	0x7a, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x62,
+	0x6f, 0x6f, 0x74, 0x7a, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x22, 0x98, 0x02, 0x0a, 0x0b, 0x43,
+	0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x43, 0x61, 0x72, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x61,
+	0x72, 0x74, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x70, 0x61, 0x72, 0x74, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x23, 0x0a, 0x0d, 0x73,
+	0x65, 0x72, 0x69, 0x61, 0x6c, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0c, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72,
+	0x12, 0x2b, 0x0a, 0x11, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x5f, 0x76, 0x6f,
+	0x75, 0x63, 0x68, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x6f, 0x77, 0x6e,
+	0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x56, 0x6f, 0x75, 0x63, 0x68, 0x65, 0x72, 0x12, 0x33, 0x0a,
+	0x0b, 0x64, 0x68, 0x63, 0x70, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x12, 0x2e, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x2e, 0x44, 0x48, 0x43, 0x50,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x0a, 0x64, 0x68, 0x63, 0x70, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x12, 0x3a, 0x0a, 0x19, 0x72, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x6f, 0x77,
+	0x6e, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x5f, 0x76, 0x6f, 0x75, 0x63, 0x68, 0x65, 0x72, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x17, 0x72, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x64, 0x4f, 0x77,
+	0x6e, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x56, 0x6f, 0x75, 0x63, 0x68, 0x65, 0x72, 0x12, 0x25,
+	0x0a, 0x0e, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x5f, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x65, 0x73,
+	0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x41, 0x6c,
+	0x69, 0x61, 0x73, 0x65, 0x73, 0x22, 0xa0, 0x07, 0x0a, 0x07, 0x43, 0x68, 0x61, 0x73, 0x73, 0x69,
+	0x73, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x5f, 0x6e, 0x75, 0x6d, 0x62,
+	0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c,
+	0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x61,
+	0x72, 0x74, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x70, 0x61, 0x72, 0x74, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x22, 0x0a, 0x0c, 0x6d,
+	0x61, 0x6e, 0x75, 0x66, 0x61, 0x63, 0x74, 0x75, 0x72, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0c, 0x6d, 0x61, 0x6e, 0x75, 0x66, 0x61, 0x63, 0x74, 0x75, 0x72, 0x65, 0x72, 0x12,
+	0x38, 0x0a, 0x18, 0x62, 0x6f, 0x6f, 0x74, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x5f, 0x70, 0x61,
+	0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x16, 0x62, 0x6f, 0x6f, 0x74, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x50, 0x61, 0x73,
+	0x73, 0x77, 0x6f, 0x72, 0x64, 0x48, 0x61, 0x73, 0x68, 0x12, 0x32, 0x0a, 0x09, 0x62, 0x6f, 0x6f,
+	0x74, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x62,
+	0x6f, 0x6f, 0x74, 0x7a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x42, 0x6f, 0x6f, 0x74, 0x4d,
+	0x6f, 0x64, 0x65, 0x52, 0x08, 0x62, 0x6f, 0x6f, 0x74, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x41, 0x0a,
+	0x0e, 0x73, 0x6f, 0x66, 0x74, 0x77, 0x61, 0x72, 0x65, 0x5f, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x7a, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x6f, 0x66, 0x74, 0x77, 0x61, 0x72, 0x65, 0x49, 0x6d, 0x61, 0x67,
+	0x65, 0x52, 0x0d, 0x73, 0x6f, 0x66, 0x74, 0x77, 0x61, 0x72, 0x65, 0x49, 0x6d, 0x61, 0x67, 0x65,
+	0x12, 0x3e, 0x0a, 0x10, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x5f, 0x63,
+	0x61, 0x72, 0x64, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x65, 0x6e, 0x74,
+	0x69, 0x74, 0x79, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x43, 0x61, 0x72, 0x64, 0x52,
+	0x0f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x43, 0x61, 0x72, 0x64, 0x73,
+	0x12, 0x26, 0x0a, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0e, 0x2e, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x52, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x21, 0x0a, 0x0c, 0x61, 0x72, 0x74, 0x69,
+	0x66, 0x61, 0x63, 0x74, 0x5f, 0x64, 0x69, 0x72, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x44, 0x69, 0x72, 0x12, 0x2b, 0x0a, 0x11, 0x6f,
 	0x77, 0x6e, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x5f, 0x76, 0x6f, 0x75, 0x63, 0x68, 0x65, 0x72,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x68, 0x69,
+	0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x68, 0x69,
 	0x70, 0x56, 0x6f, 0x75, 0x63, 0x68, 0x65, 0x72, 0x12, 0x33, 0x0a, 0x0b, 0x64, 0x68, 0x63, 0x70,
-	0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e,
+	0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e,
 	0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x2e, 0x44, 0x48, 0x43, 0x50, 0x43, 0x6f, 0x6e, 0x66, 0x69,
-	0x67, 0x52, 0x0a, 0x64, 0x68, 0x63, 0x70, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x22, 0xa5, 0x04,
-	0x0a, 0x07, 0x43, 0x68, 0x61, 0x73, 0x73, 0x69, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x65, 0x72,
-	0x69, 0x61, 0x6c, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0c, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x12,
-	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
-	0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x61, 0x72, 0x74, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65,
-	0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x61, 0x72, 0x74, 0x4e, 0x75, 0x6d,
-	0x62, 0x65, 0x72, 0x12, 0x22, 0x0a, 0x0c, 0x6d, 0x61, 0x6e, 0x75, 0x66, 0x61, 0x63, 0x74, 0x75,
-	0x72, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6d, 0x61, 0x6e, 0x75, 0x66,
-	0x61, 0x63, 0x74, 0x75, 0x72, 0x65, 0x72, 0x12, 0x38, 0x0a, 0x18, 0x62, 0x6f, 0x6f, 0x74, 0x6c,
-	0x6f, 0x61, 0x64, 0x65, 0x72, 0x5f, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x5f, 0x68,
-	0x61, 0x73, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x16, 0x62, 0x6f, 0x6f, 0x74, 0x6c,
-	0x6f, 0x61, 0x64, 0x65, 0x72, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x48, 0x61, 0x73,
-	0x68, 0x12, 0x32, 0x0a, 0x09, 0x62, 0x6f, 0x6f, 0x74, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x06,
-	0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x7a, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x2e, 0x42, 0x6f, 0x6f, 0x74, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x08, 0x62, 0x6f, 0x6f,
-	0x74, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x41, 0x0a, 0x0e, 0x73, 0x6f, 0x66, 0x74, 0x77, 0x61, 0x72,
-	0x65, 0x5f, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
-	0x62, 0x6f, 0x6f, 0x74, 0x7a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x6f, 0x66, 0x74,
-	0x77, 0x61, 0x72, 0x65, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x0d, 0x73, 0x6f, 0x66, 0x74, 0x77,
-	0x61, 0x72, 0x65, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x3e, 0x0a, 0x10, 0x63, 0x6f, 0x6e, 0x74,
-	0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x5f, 0x63, 0x61, 0x72, 0x64, 0x73, 0x18, 0x08, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x13, 0x2e, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x2e, 0x43, 0x6f, 0x6e, 0x74,
-	0x72, 0x6f, 0x6c, 0x43, 0x61, 0x72, 0x64, 0x52, 0x0f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c,
-	0x6c, 0x65, 0x72, 0x43, 0x61, 0x72, 0x64, 0x73, 0x12, 0x26, 0x0a, 0x06, 0x63, 0x6f, 0x6e, 0x66,
-	0x69, 0x67, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x65, 0x6e, 0x74, 0x69, 0x74,
-	0x79, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
-	0x12, 0x21, 0x0a, 0x0c, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x5f, 0x64, 0x69, 0x72,
-	0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74,
-	0x44, 0x69, 0x72, 0x12, 0x2b, 0x0a, 0x11, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70,
-	0x5f, 0x76, 0x6f, 0x75, 0x63, 0x68, 0x65, 0x72, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10,
-	0x6f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x56, 0x6f, 0x75, 0x63, 0x68, 0x65, 0x72,
-	0x12, 0x33, 0x0a, 0x0b, 0x64, 0x68, 0x63, 0x70, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18,
-	0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x2e, 0x44,
-	0x48, 0x43, 0x50, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x0a, 0x64, 0x68, 0x63, 0x70, 0x43,
-	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x67, 0x52, 0x0a, 0x64, 0x68, 0x63, 0x70, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x12, 0x0a,
+	0x04, 0x73, 0x69, 0x74, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x73, 0x69, 0x74,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x18, 0x0e, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x12, 0x2b, 0x0a, 0x11, 0x61, 0x72, 0x74,
+	0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x5f, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x0f,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x50,
+	0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x3a, 0x0a, 0x19, 0x72, 0x6f, 0x74, 0x61, 0x74, 0x65,
+	0x64, 0x5f, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x5f, 0x76, 0x6f, 0x75, 0x63,
+	0x68, 0x65, 0x72, 0x18, 0x10, 0x20, 0x01, 0x28, 0x09, 0x52, 0x17, 0x72, 0x6f, 0x74, 0x61, 0x74,
+	0x65, 0x64, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x56, 0x6f, 0x75, 0x63, 0x68,
+	0x65, 0x72, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x5f, 0x61, 0x6c, 0x69,
+	0x61, 0x73, 0x65, 0x73, 0x18, 0x11, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x73, 0x65, 0x72, 0x69,
+	0x61, 0x6c, 0x41, 0x6c, 0x69, 0x61, 0x73, 0x65, 0x73, 0x12, 0x32, 0x0a, 0x0a, 0x6c, 0x69, 0x6e,
+	0x65, 0x5f, 0x63, 0x61, 0x72, 0x64, 0x73, 0x18, 0x12, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e,
+	0x62, 0x6f, 0x6f, 0x74, 0x7a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4d, 0x6f, 0x64, 0x75,
+	0x6c, 0x65, 0x52, 0x09, 0x6c, 0x69, 0x6e, 0x65, 0x43, 0x61, 0x72, 0x64, 0x73, 0x12, 0x3a, 0x0a,
+	0x0e, 0x66, 0x61, 0x62, 0x72, 0x69, 0x63, 0x5f, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x18,
+	0x13, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x62, 0x6f, 0x6f, 0x74, 0x7a, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x52, 0x0d, 0x66, 0x61, 0x62, 0x72,
+	0x69, 0x63, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x12, 0x4d, 0x0a, 0x14, 0x70, 0x72, 0x6f,
+	0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x69, 0x6e, 0x67, 0x5f, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77,
+	0x73, 0x18, 0x14, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79,
+	0x2e, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x69, 0x6e, 0x67, 0x57, 0x69, 0x6e,
+	0x64, 0x6f, 0x77, 0x52, 0x13, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x69, 0x6e,
+	0x67, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x73, 0x2a, 0x75, 0x0a, 0x0c, 0x41, 0x6c, 0x65, 0x72,
+	0x74, 0x54, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x12, 0x1d, 0x0a, 0x19, 0x41, 0x4c, 0x45, 0x52,
+	0x54, 0x5f, 0x54, 0x52, 0x49, 0x47, 0x47, 0x45, 0x52, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43,
+	0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x23, 0x0a, 0x1f, 0x41, 0x4c, 0x45, 0x52, 0x54,
+	0x5f, 0x54, 0x52, 0x49, 0x47, 0x47, 0x45, 0x52, 0x5f, 0x42, 0x4f, 0x4f, 0x54, 0x53, 0x54, 0x52,
+	0x41, 0x50, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x55, 0x52, 0x45, 0x10, 0x01, 0x12, 0x21, 0x0a, 0x1d,
+	0x41, 0x4c, 0x45, 0x52, 0x54, 0x5f, 0x54, 0x52, 0x49, 0x47, 0x47, 0x45, 0x52, 0x5f, 0x56, 0x4f,
+	0x55, 0x43, 0x48, 0x45, 0x52, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x55, 0x52, 0x45, 0x10, 0x02, 0x2a,
+	0x9b, 0x01, 0x0a, 0x0d, 0x41, 0x6c, 0x65, 0x72, 0x74, 0x53, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74,
+	0x79, 0x12, 0x1e, 0x0a, 0x1a, 0x41, 0x4c, 0x45, 0x52, 0x54, 0x5f, 0x53, 0x45, 0x56, 0x45, 0x52,
+	0x49, 0x54, 0x59, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10,
+	0x00, 0x12, 0x17, 0x0a, 0x13, 0x41, 0x4c, 0x45, 0x52, 0x54, 0x5f, 0x53, 0x45, 0x56, 0x45, 0x52,
+	0x49, 0x54, 0x59, 0x5f, 0x49, 0x4e, 0x46, 0x4f, 0x10, 0x01, 0x12, 0x1a, 0x0a, 0x16, 0x41, 0x4c,
+	0x45, 0x52, 0x54, 0x5f, 0x53, 0x45, 0x56, 0x45, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x57, 0x41, 0x52,
+	0x4e, 0x49, 0x4e, 0x47, 0x10, 0x02, 0x12, 0x18, 0x0a, 0x14, 0x41, 0x4c, 0x45, 0x52, 0x54, 0x5f,
+	0x53, 0x45, 0x56, 0x45, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x03,
+	0x12, 0x1b, 0x0a, 0x17, 0x41, 0x4c, 0x45, 0x52, 0x54, 0x5f, 0x53, 0x45, 0x56, 0x45, 0x52, 0x49,
+	0x54, 0x59, 0x5f, 0x43, 0x52, 0x49, 0x54, 0x49, 0x43, 0x41, 0x4c, 0x10, 0x04, 0x2a, 0x6e, 0x0a,
+	0x11, 0x56, 0x6f, 0x75, 0x63, 0x68, 0x65, 0x72, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x17, 0x56, 0x4f, 0x55, 0x43, 0x48, 0x45, 0x52, 0x5f, 0x56, 0x41,
+	0x4c, 0x49, 0x44, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x4e, 0x4f, 0x4e, 0x45, 0x10, 0x00, 0x12,
+	0x1d, 0x0a, 0x19, 0x56, 0x4f, 0x55, 0x43, 0x48, 0x45, 0x52, 0x5f, 0x56, 0x41, 0x4c, 0x49, 0x44,
+	0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x54, 0x52, 0x49, 0x43, 0x54, 0x10, 0x01, 0x12, 0x1d,
+	0x0a, 0x19, 0x56, 0x4f, 0x55, 0x43, 0x48, 0x45, 0x52, 0x5f, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x41,
+	0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x43, 0x48, 0x45, 0x4d, 0x41, 0x10, 0x02, 0x2a, 0x63, 0x0a,
+	0x0f, 0x50, 0x44, 0x43, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x19, 0x0a, 0x15, 0x50, 0x44, 0x43, 0x5f, 0x56, 0x45, 0x52, 0x49, 0x46, 0x49, 0x43, 0x41,
+	0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x4e, 0x4f, 0x4e, 0x45, 0x10, 0x00, 0x12, 0x1a, 0x0a, 0x16, 0x50,
+	0x44, 0x43, 0x5f, 0x56, 0x45, 0x52, 0x49, 0x46, 0x49, 0x43, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f,
+	0x45, 0x58, 0x41, 0x43, 0x54, 0x10, 0x01, 0x12, 0x19, 0x0a, 0x15, 0x50, 0x44, 0x43, 0x5f, 0x56,
+	0x45, 0x52, 0x49, 0x46, 0x49, 0x43, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x50, 0x4b, 0x49,
+	0x10, 0x02, 0x2a, 0x9b, 0x01, 0x0a, 0x1b, 0x43, 0x68, 0x61, 0x73, 0x73, 0x69, 0x73, 0x44, 0x65,
+	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x26, 0x0a, 0x22, 0x43, 0x48, 0x41, 0x53, 0x53, 0x49, 0x53, 0x5f, 0x44, 0x45,
+	0x53, 0x43, 0x52, 0x49, 0x50, 0x54, 0x4f, 0x52, 0x5f, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x41, 0x54,
+	0x49, 0x4f, 0x4e, 0x5f, 0x4e, 0x4f, 0x4e, 0x45, 0x10, 0x00, 0x12, 0x2a, 0x0a, 0x26, 0x43, 0x48,
+	0x41, 0x53, 0x53, 0x49, 0x53, 0x5f, 0x44, 0x45, 0x53, 0x43, 0x52, 0x49, 0x50, 0x54, 0x4f, 0x52,
+	0x5f, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x4c, 0x4f, 0x47, 0x5f,
+	0x4f, 0x4e, 0x4c, 0x59, 0x10, 0x01, 0x12, 0x28, 0x0a, 0x24, 0x43, 0x48, 0x41, 0x53, 0x53, 0x49,
+	0x53, 0x5f, 0x44, 0x45, 0x53, 0x43, 0x52, 0x49, 0x50, 0x54, 0x4f, 0x52, 0x5f, 0x56, 0x41, 0x4c,
+	0x49, 0x44, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x54, 0x52, 0x49, 0x43, 0x54, 0x10, 0x02,
+	0x2a, 0x74, 0x0a, 0x11, 0x50, 0x65, 0x65, 0x72, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79,
+	0x43, 0x68, 0x65, 0x63, 0x6b, 0x12, 0x1c, 0x0a, 0x18, 0x50, 0x45, 0x45, 0x52, 0x5f, 0x49, 0x44,
+	0x45, 0x4e, 0x54, 0x49, 0x54, 0x59, 0x5f, 0x43, 0x48, 0x45, 0x43, 0x4b, 0x5f, 0x4e, 0x4f, 0x4e,
+	0x45, 0x10, 0x00, 0x12, 0x20, 0x0a, 0x1c, 0x50, 0x45, 0x45, 0x52, 0x5f, 0x49, 0x44, 0x45, 0x4e,
+	0x54, 0x49, 0x54, 0x59, 0x5f, 0x43, 0x48, 0x45, 0x43, 0x4b, 0x5f, 0x4c, 0x4f, 0x47, 0x5f, 0x4f,
+	0x4e, 0x4c, 0x59, 0x10, 0x01, 0x12, 0x1f, 0x0a, 0x1b, 0x50, 0x45, 0x45, 0x52, 0x5f, 0x49, 0x44,
+	0x45, 0x4e, 0x54, 0x49, 0x54, 0x59, 0x5f, 0x43, 0x48, 0x45, 0x43, 0x4b, 0x5f, 0x45, 0x4e, 0x46,
+	0x4f, 0x52, 0x43, 0x45, 0x10, 0x02, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -801,47 +5087,199 @@ func file_server_entitymanager_proto_entity_proto_rawDescGZIP() []byte {
 	return file_server_entitymanager_proto_entity_proto_rawDescData
 }
 
-var file_server_entitymanager_proto_entity_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_server_entitymanager_proto_entity_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
+var file_server_entitymanager_proto_entity_proto_msgTypes = make([]protoimpl.MessageInfo, 52)
 var file_server_entitymanager_proto_entity_proto_goTypes = []interface{}{
-	(*Options)(nil),             // 0: entity.Options
-	(*Entities)(nil),            // 1: entity.Entities
-	(*Config)(nil),              // 2: entity.Config
-	(*BootConfig)(nil),          // 3: entity.BootConfig
-	(*GNSIConfig)(nil),          // 4: entity.GNSIConfig
-	(*DHCPConfig)(nil),          // 5: entity.DHCPConfig
-	(*ControlCard)(nil),         // 6: entity.ControlCard
-	(*Chassis)(nil),             // 7: entity.Chassis
-	(*structpb.Struct)(nil),     // 8: google.protobuf.Struct
-	(*authz.UploadRequest)(nil), // 9: gnsi.authz.v1.UploadRequest
-	(*pathz.UploadRequest)(nil), // 10: gnsi.pathz.v1.UploadRequest
-	(*certz.UploadRequest)(nil), // 11: gnsi.certz.v1.UploadRequest
-	(*bootz.Credentials)(nil),   // 12: bootz.proto.Credentials
-	(bootz.BootMode)(0),         // 13: bootz.proto.BootMode
-	(*bootz.SoftwareImage)(nil), // 14: bootz.proto.SoftwareImage
+	(AlertTrigger)(0),                // 0: entity.AlertTrigger
+	(AlertSeverity)(0),               // 1: entity.AlertSeverity
+	(VoucherValidation)(0),           // 2: entity.VoucherValidation
+	(PDCVerification)(0),             // 3: entity.PDCVerification
+	(ChassisDescriptorValidation)(0), // 4: entity.ChassisDescriptorValidation
+	(PeerIdentityCheck)(0),           // 5: entity.PeerIdentityCheck
+	(*Options)(nil),                  // 6: entity.Options
+	(*Quarantine)(nil),               // 7: entity.Quarantine
+	(*AdmissionControl)(nil),         // 8: entity.AdmissionControl
+	(*VoucherStore)(nil),             // 9: entity.VoucherStore
+	(*Alerting)(nil),                 // 10: entity.Alerting
+	(*AlertRule)(nil),                // 11: entity.AlertRule
+	(*SmtpServer)(nil),               // 12: entity.SmtpServer
+	(*PagerDuty)(nil),                // 13: entity.PagerDuty
+	(*ProvisioningWindow)(nil),       // 14: entity.ProvisioningWindow
+	(*BootstrapPolicy)(nil),          // 15: entity.BootstrapPolicy
+	(*Cluster)(nil),                  // 16: entity.Cluster
+	(*RedisStore)(nil),               // 17: entity.RedisStore
+	(*ResponseCache)(nil),            // 18: entity.ResponseCache
+	(*Timeouts)(nil),                 // 19: entity.Timeouts
+	(*RequestLogging)(nil),           // 20: entity.RequestLogging
+	(*ArtifactSync)(nil),             // 21: entity.ArtifactSync
+	(*ArtifactBucket)(nil),           // 22: entity.ArtifactBucket
+	(*FaultInjection)(nil),           // 23: entity.FaultInjection
+	(*SerialNormalization)(nil),      // 24: entity.SerialNormalization
+	(*OwnershipDomain)(nil),          // 25: entity.OwnershipDomain
+	(*NetBoxSource)(nil),             // 26: entity.NetBoxSource
+	(*MasaSource)(nil),               // 27: entity.MasaSource
+	(*RedirectRule)(nil),             // 28: entity.RedirectRule
+	(*UrlRewriteRule)(nil),           // 29: entity.UrlRewriteRule
+	(*VendorQuirks)(nil),             // 30: entity.VendorQuirks
+	(*Authenticator)(nil),            // 31: entity.Authenticator
+	(*TokenHeader)(nil),              // 32: entity.TokenHeader
+	(*OwnershipRotation)(nil),        // 33: entity.OwnershipRotation
+	(*ConfigValidator)(nil),          // 34: entity.ConfigValidator
+	(*ModelImage)(nil),               // 35: entity.ModelImage
+	(*Experiment)(nil),               // 36: entity.Experiment
+	(*DefaultProfile)(nil),           // 37: entity.DefaultProfile
+	(*AllowedDevice)(nil),            // 38: entity.AllowedDevice
+	(*FeatureFlag)(nil),              // 39: entity.FeatureFlag
+	(*Snapshot)(nil),                 // 40: entity.Snapshot
+	(*DeviceStateRecord)(nil),        // 41: entity.DeviceStateRecord
+	(*RevokedVoucherRecord)(nil),     // 42: entity.RevokedVoucherRecord
+	(*BootstrapTokenRecord)(nil),     // 43: entity.BootstrapTokenRecord
+	(*RetiredDeviceRecord)(nil),      // 44: entity.RetiredDeviceRecord
+	(*Entities)(nil),                 // 45: entity.Entities
+	(*Config)(nil),                   // 46: entity.Config
+	(*BootConfig)(nil),               // 47: entity.BootConfig
+	(*GNSIConfig)(nil),               // 48: entity.GNSIConfig
+	(*DHCPConfig)(nil),               // 49: entity.DHCPConfig
+	(*ControlCard)(nil),              // 50: entity.ControlCard
+	(*Chassis)(nil),                  // 51: entity.Chassis
+	nil,                              // 52: entity.BootstrapPolicy.ParamsEntry
+	(*FaultInjection_Fault)(nil),     // 53: entity.FaultInjection.Fault
+	nil,                              // 54: entity.NetBoxSource.FiltersEntry
+	nil,                              // 55: entity.NetBoxSource.RoleConfigsEntry
+	nil,                              // 56: entity.Authenticator.ParamsEntry
+	nil,                              // 57: entity.Snapshot.ControlCardStatusesEntry
+	(bootz.BootMode)(0),              // 58: bootz.proto.BootMode
+	(*durationpb.Duration)(nil),      // 59: google.protobuf.Duration
+	(*bootz.RedirectServer)(nil),     // 60: bootz.proto.RedirectServer
+	(*timestamppb.Timestamp)(nil),    // 61: google.protobuf.Timestamp
+	(bootz.ArtifactType)(0),          // 62: bootz.proto.ArtifactType
+	(*bootz.SoftwareImage)(nil),      // 63: bootz.proto.SoftwareImage
+	(bootz.ControlCardState_ControlCardStatus)(0), // 64: bootz.proto.ControlCardState.ControlCardStatus
+	(*structpb.Struct)(nil),                       // 65: google.protobuf.Struct
+	(bootz.ConfigEncoding)(0),                     // 66: bootz.proto.ConfigEncoding
+	(*authz.UploadRequest)(nil),                   // 67: gnsi.authz.v1.UploadRequest
+	(*pathz.UploadRequest)(nil),                   // 68: gnsi.pathz.v1.UploadRequest
+	(*certz.UploadRequest)(nil),                   // 69: gnsi.certz.v1.UploadRequest
+	(*bootz.Credentials)(nil),                     // 70: bootz.proto.Credentials
+	(*bootz.Module)(nil),                          // 71: bootz.proto.Module
 }
 var file_server_entitymanager_proto_entity_proto_depIdxs = []int32{
-	4,  // 0: entity.Options.gnsi_global_config:type_name -> entity.GNSIConfig
-	0,  // 1: entity.Entities.options:type_name -> entity.Options
-	7,  // 2: entity.Entities.chassis:type_name -> entity.Chassis
-	3,  // 3: entity.Config.boot_config:type_name -> entity.BootConfig
-	4,  // 4: entity.Config.gnsi_config:type_name -> entity.GNSIConfig
-	8,  // 5: entity.BootConfig.metadata:type_name -> google.protobuf.Struct
-	8,  // 6: entity.BootConfig.bootloader_config:type_name -> google.protobuf.Struct
-	9,  // 7: entity.GNSIConfig.authz_upload:type_name -> gnsi.authz.v1.UploadRequest
-	10, // 8: entity.GNSIConfig.pathz_upload:type_name -> gnsi.pathz.v1.UploadRequest
-	11, // 9: entity.GNSIConfig.certz_upload:type_name -> gnsi.certz.v1.UploadRequest
-	12, // 10: entity.GNSIConfig.credentials:type_name -> bootz.proto.Credentials
-	5,  // 11: entity.ControlCard.dhcp_config:type_name -> entity.DHCPConfig
-	13, // 12: entity.Chassis.boot_mode:type_name -> bootz.proto.BootMode
-	14, // 13: entity.Chassis.software_image:type_name -> bootz.proto.SoftwareImage
-	6,  // 14: entity.Chassis.controller_cards:type_name -> entity.ControlCard
-	2,  // 15: entity.Chassis.config:type_name -> entity.Config
-	5,  // 16: entity.Chassis.dhcp_config:type_name -> entity.DHCPConfig
-	17, // [17:17] is the sub-list for method output_type
-	17, // [17:17] is the sub-list for method input_type
-	17, // [17:17] is the sub-list for extension type_name
-	17, // [17:17] is the sub-list for extension extendee
-	0,  // [0:17] is the sub-list for field type_name
+	48,  // 0: entity.Options.gnsi_global_config:type_name -> entity.GNSIConfig
+	3,   // 1: entity.Options.pdc_verification:type_name -> entity.PDCVerification
+	39,  // 2: entity.Options.feature_flags:type_name -> entity.FeatureFlag
+	37,  // 3: entity.Options.default_profile:type_name -> entity.DefaultProfile
+	36,  // 4: entity.Options.experiments:type_name -> entity.Experiment
+	58,  // 5: entity.Options.default_boot_mode:type_name -> bootz.proto.BootMode
+	35,  // 6: entity.Options.model_images:type_name -> entity.ModelImage
+	59,  // 7: entity.Options.retry_interval:type_name -> google.protobuf.Duration
+	34,  // 8: entity.Options.config_validators:type_name -> entity.ConfigValidator
+	33,  // 9: entity.Options.ownership_rotation:type_name -> entity.OwnershipRotation
+	5,   // 10: entity.Options.peer_identity_check:type_name -> entity.PeerIdentityCheck
+	31,  // 11: entity.Options.authenticators:type_name -> entity.Authenticator
+	28,  // 12: entity.Options.redirects:type_name -> entity.RedirectRule
+	26,  // 13: entity.Options.netbox:type_name -> entity.NetBoxSource
+	25,  // 14: entity.Options.domains:type_name -> entity.OwnershipDomain
+	24,  // 15: entity.Options.serial_normalization:type_name -> entity.SerialNormalization
+	20,  // 16: entity.Options.request_logging:type_name -> entity.RequestLogging
+	19,  // 17: entity.Options.timeouts:type_name -> entity.Timeouts
+	18,  // 18: entity.Options.response_cache:type_name -> entity.ResponseCache
+	2,   // 19: entity.Options.voucher_validation:type_name -> entity.VoucherValidation
+	27,  // 20: entity.Options.masa:type_name -> entity.MasaSource
+	29,  // 21: entity.Options.url_rewrites:type_name -> entity.UrlRewriteRule
+	4,   // 22: entity.Options.chassis_descriptor_validation:type_name -> entity.ChassisDescriptorValidation
+	30,  // 23: entity.Options.vendor_quirks:type_name -> entity.VendorQuirks
+	16,  // 24: entity.Options.cluster:type_name -> entity.Cluster
+	15,  // 25: entity.Options.bootstrap_policies:type_name -> entity.BootstrapPolicy
+	14,  // 26: entity.Options.provisioning_windows:type_name -> entity.ProvisioningWindow
+	7,   // 27: entity.Options.quarantine:type_name -> entity.Quarantine
+	10,  // 28: entity.Options.alerting:type_name -> entity.Alerting
+	8,   // 29: entity.Options.admission_control:type_name -> entity.AdmissionControl
+	9,   // 30: entity.Options.voucher_store:type_name -> entity.VoucherStore
+	59,  // 31: entity.Quarantine.retry_interval:type_name -> google.protobuf.Duration
+	59,  // 32: entity.AdmissionControl.queue_timeout:type_name -> google.protobuf.Duration
+	59,  // 33: entity.AdmissionControl.retry_interval:type_name -> google.protobuf.Duration
+	11,  // 34: entity.Alerting.rules:type_name -> entity.AlertRule
+	12,  // 35: entity.Alerting.smtp:type_name -> entity.SmtpServer
+	13,  // 36: entity.Alerting.pagerduty:type_name -> entity.PagerDuty
+	0,   // 37: entity.AlertRule.trigger:type_name -> entity.AlertTrigger
+	1,   // 38: entity.AlertRule.severity:type_name -> entity.AlertSeverity
+	52,  // 39: entity.BootstrapPolicy.params:type_name -> entity.BootstrapPolicy.ParamsEntry
+	17,  // 40: entity.Cluster.redis:type_name -> entity.RedisStore
+	59,  // 41: entity.Cluster.signature_ttl:type_name -> google.protobuf.Duration
+	59,  // 42: entity.Cluster.lease_duration:type_name -> google.protobuf.Duration
+	59,  // 43: entity.ResponseCache.ttl:type_name -> google.protobuf.Duration
+	59,  // 44: entity.Timeouts.resolve_chassis:type_name -> google.protobuf.Duration
+	59,  // 45: entity.Timeouts.bootstrap_data:type_name -> google.protobuf.Duration
+	59,  // 46: entity.Timeouts.sign:type_name -> google.protobuf.Duration
+	59,  // 47: entity.Timeouts.report_status:type_name -> google.protobuf.Duration
+	22,  // 48: entity.ArtifactSync.buckets:type_name -> entity.ArtifactBucket
+	59,  // 49: entity.ArtifactSync.refresh_interval:type_name -> google.protobuf.Duration
+	53,  // 50: entity.FaultInjection.faults:type_name -> entity.FaultInjection.Fault
+	54,  // 51: entity.NetBoxSource.filters:type_name -> entity.NetBoxSource.FiltersEntry
+	55,  // 52: entity.NetBoxSource.role_configs:type_name -> entity.NetBoxSource.RoleConfigsEntry
+	59,  // 53: entity.NetBoxSource.sync_interval:type_name -> google.protobuf.Duration
+	59,  // 54: entity.MasaSource.sync_interval:type_name -> google.protobuf.Duration
+	60,  // 55: entity.RedirectRule.servers:type_name -> bootz.proto.RedirectServer
+	32,  // 56: entity.Authenticator.token_header:type_name -> entity.TokenHeader
+	56,  // 57: entity.Authenticator.params:type_name -> entity.Authenticator.ParamsEntry
+	61,  // 58: entity.OwnershipRotation.transition_end:type_name -> google.protobuf.Timestamp
+	62,  // 59: entity.ConfigValidator.artifact_type:type_name -> bootz.proto.ArtifactType
+	63,  // 60: entity.ModelImage.software_image:type_name -> bootz.proto.SoftwareImage
+	38,  // 61: entity.Experiment.devices:type_name -> entity.AllowedDevice
+	63,  // 62: entity.Experiment.software_image:type_name -> bootz.proto.SoftwareImage
+	46,  // 63: entity.Experiment.config:type_name -> entity.Config
+	38,  // 64: entity.DefaultProfile.allowlist:type_name -> entity.AllowedDevice
+	58,  // 65: entity.DefaultProfile.boot_mode:type_name -> bootz.proto.BootMode
+	63,  // 66: entity.DefaultProfile.software_image:type_name -> bootz.proto.SoftwareImage
+	46,  // 67: entity.DefaultProfile.config:type_name -> entity.Config
+	61,  // 68: entity.Snapshot.created_at:type_name -> google.protobuf.Timestamp
+	6,   // 69: entity.Snapshot.options:type_name -> entity.Options
+	51,  // 70: entity.Snapshot.chassis:type_name -> entity.Chassis
+	57,  // 71: entity.Snapshot.control_card_statuses:type_name -> entity.Snapshot.ControlCardStatusesEntry
+	41,  // 72: entity.Snapshot.device_states:type_name -> entity.DeviceStateRecord
+	42,  // 73: entity.Snapshot.revoked_vouchers:type_name -> entity.RevokedVoucherRecord
+	43,  // 74: entity.Snapshot.bootstrap_tokens:type_name -> entity.BootstrapTokenRecord
+	44,  // 75: entity.Snapshot.retired_devices:type_name -> entity.RetiredDeviceRecord
+	61,  // 76: entity.DeviceStateRecord.changed_at:type_name -> google.protobuf.Timestamp
+	61,  // 77: entity.DeviceStateRecord.sent_at:type_name -> google.protobuf.Timestamp
+	61,  // 78: entity.DeviceStateRecord.reported_at:type_name -> google.protobuf.Timestamp
+	61,  // 79: entity.DeviceStateRecord.last_seen_at:type_name -> google.protobuf.Timestamp
+	61,  // 80: entity.RevokedVoucherRecord.revoked_at:type_name -> google.protobuf.Timestamp
+	61,  // 81: entity.BootstrapTokenRecord.issued_at:type_name -> google.protobuf.Timestamp
+	61,  // 82: entity.BootstrapTokenRecord.expires_at:type_name -> google.protobuf.Timestamp
+	61,  // 83: entity.RetiredDeviceRecord.retired_at:type_name -> google.protobuf.Timestamp
+	51,  // 84: entity.RetiredDeviceRecord.chassis:type_name -> entity.Chassis
+	41,  // 85: entity.RetiredDeviceRecord.state:type_name -> entity.DeviceStateRecord
+	64,  // 86: entity.RetiredDeviceRecord.status:type_name -> bootz.proto.ControlCardState.ControlCardStatus
+	6,   // 87: entity.Entities.options:type_name -> entity.Options
+	51,  // 88: entity.Entities.chassis:type_name -> entity.Chassis
+	47,  // 89: entity.Config.boot_config:type_name -> entity.BootConfig
+	48,  // 90: entity.Config.gnsi_config:type_name -> entity.GNSIConfig
+	47,  // 91: entity.Config.final_boot_config:type_name -> entity.BootConfig
+	65,  // 92: entity.BootConfig.metadata:type_name -> google.protobuf.Struct
+	65,  // 93: entity.BootConfig.bootloader_config:type_name -> google.protobuf.Struct
+	66,  // 94: entity.BootConfig.encoding:type_name -> bootz.proto.ConfigEncoding
+	67,  // 95: entity.GNSIConfig.authz_upload:type_name -> gnsi.authz.v1.UploadRequest
+	68,  // 96: entity.GNSIConfig.pathz_upload:type_name -> gnsi.pathz.v1.UploadRequest
+	69,  // 97: entity.GNSIConfig.certz_upload:type_name -> gnsi.certz.v1.UploadRequest
+	70,  // 98: entity.GNSIConfig.credentials:type_name -> bootz.proto.Credentials
+	49,  // 99: entity.ControlCard.dhcp_config:type_name -> entity.DHCPConfig
+	58,  // 100: entity.Chassis.boot_mode:type_name -> bootz.proto.BootMode
+	63,  // 101: entity.Chassis.software_image:type_name -> bootz.proto.SoftwareImage
+	50,  // 102: entity.Chassis.controller_cards:type_name -> entity.ControlCard
+	46,  // 103: entity.Chassis.config:type_name -> entity.Config
+	49,  // 104: entity.Chassis.dhcp_config:type_name -> entity.DHCPConfig
+	71,  // 105: entity.Chassis.line_cards:type_name -> bootz.proto.Module
+	71,  // 106: entity.Chassis.fabric_modules:type_name -> bootz.proto.Module
+	14,  // 107: entity.Chassis.provisioning_windows:type_name -> entity.ProvisioningWindow
+	59,  // 108: entity.FaultInjection.Fault.latency:type_name -> google.protobuf.Duration
+	46,  // 109: entity.NetBoxSource.RoleConfigsEntry.value:type_name -> entity.Config
+	64,  // 110: entity.Snapshot.ControlCardStatusesEntry.value:type_name -> bootz.proto.ControlCardState.ControlCardStatus
+	111, // [111:111] is the sub-list for method output_type
+	111, // [111:111] is the sub-list for method input_type
+	111, // [111:111] is the sub-list for extension type_name
+	111, // [111:111] is the sub-list for extension extendee
+	0,   // [0:111] is the sub-list for field type_name
 }
 
 func init() { file_server_entitymanager_proto_entity_proto_init() }
@@ -862,7 +5300,463 @@ func file_server_entitymanager_proto_entity_proto_init() {
 				return nil
 			}
 		}
-		file_server_entitymanager_proto_entity_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+		file_server_entitymanager_proto_entity_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Quarantine); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_entitymanager_proto_entity_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AdmissionControl); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_entitymanager_proto_entity_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VoucherStore); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_entitymanager_proto_entity_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Alerting); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_entitymanager_proto_entity_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AlertRule); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_entitymanager_proto_entity_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SmtpServer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_entitymanager_proto_entity_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PagerDuty); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_entitymanager_proto_entity_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProvisioningWindow); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_entitymanager_proto_entity_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BootstrapPolicy); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_entitymanager_proto_entity_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Cluster); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_entitymanager_proto_entity_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RedisStore); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_entitymanager_proto_entity_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResponseCache); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_entitymanager_proto_entity_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Timeouts); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_entitymanager_proto_entity_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RequestLogging); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_entitymanager_proto_entity_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ArtifactSync); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_entitymanager_proto_entity_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ArtifactBucket); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_entitymanager_proto_entity_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FaultInjection); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_entitymanager_proto_entity_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SerialNormalization); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_entitymanager_proto_entity_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OwnershipDomain); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_entitymanager_proto_entity_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NetBoxSource); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_entitymanager_proto_entity_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MasaSource); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_entitymanager_proto_entity_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RedirectRule); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_entitymanager_proto_entity_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UrlRewriteRule); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_entitymanager_proto_entity_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VendorQuirks); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_entitymanager_proto_entity_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Authenticator); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_entitymanager_proto_entity_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TokenHeader); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_entitymanager_proto_entity_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OwnershipRotation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_entitymanager_proto_entity_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConfigValidator); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_entitymanager_proto_entity_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ModelImage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_entitymanager_proto_entity_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Experiment); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_entitymanager_proto_entity_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DefaultProfile); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_entitymanager_proto_entity_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AllowedDevice); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_entitymanager_proto_entity_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FeatureFlag); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_entitymanager_proto_entity_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Snapshot); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_entitymanager_proto_entity_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeviceStateRecord); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_entitymanager_proto_entity_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RevokedVoucherRecord); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_entitymanager_proto_entity_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BootstrapTokenRecord); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_entitymanager_proto_entity_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RetiredDeviceRecord); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_server_entitymanager_proto_entity_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Entities); i {
 			case 0:
 				return &v.state
@@ -874,7 +5768,7 @@ func file_server_entitymanager_proto_entity_proto_init() {
 				return nil
 			}
 		}
-		file_server_entitymanager_proto_entity_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+		file_server_entitymanager_proto_entity_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Config); i {
 			case 0:
 				return &v.state
@@ -886,7 +5780,7 @@ func file_server_entitymanager_proto_entity_proto_init() {
 				return nil
 			}
 		}
-		file_server_entitymanager_proto_entity_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+		file_server_entitymanager_proto_entity_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*BootConfig); i {
 			case 0:
 				return &v.state
@@ -898,7 +5792,7 @@ func file_server_entitymanager_proto_entity_proto_init() {
 				return nil
 			}
 		}
-		file_server_entitymanager_proto_entity_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+		file_server_entitymanager_proto_entity_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GNSIConfig); i {
 			case 0:
 				return &v.state
@@ -910,7 +5804,7 @@ func file_server_entitymanager_proto_entity_proto_init() {
 				return nil
 			}
 		}
-		file_server_entitymanager_proto_entity_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+		file_server_entitymanager_proto_entity_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*DHCPConfig); i {
 			case 0:
 				return &v.state
@@ -922,7 +5816,7 @@ func file_server_entitymanager_proto_entity_proto_init() {
 				return nil
 			}
 		}
-		file_server_entitymanager_proto_entity_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+		file_server_entitymanager_proto_entity_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ControlCard); i {
 			case 0:
 				return &v.state
@@ -934,7 +5828,7 @@ func file_server_entitymanager_proto_entity_proto_init() {
 				return nil
 			}
 		}
-		file_server_entitymanager_proto_entity_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+		file_server_entitymanager_proto_entity_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Chassis); i {
 			case 0:
 				return &v.state
@@ -946,19 +5840,35 @@ func file_server_entitymanager_proto_entity_proto_init() {
 				return nil
 			}
 		}
+		file_server_entitymanager_proto_entity_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FaultInjection_Fault); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_server_entitymanager_proto_entity_proto_msgTypes[10].OneofWrappers = []interface{}{
+		(*Cluster_Redis)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_server_entitymanager_proto_entity_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   8,
+			NumEnums:      6,
+			NumMessages:   52,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_server_entitymanager_proto_entity_proto_goTypes,
 		DependencyIndexes: file_server_entitymanager_proto_entity_proto_depIdxs,
+		EnumInfos:         file_server_entitymanager_proto_entity_proto_enumTypes,
 		MessageInfos:      file_server_entitymanager_proto_entity_proto_msgTypes,
 	}.Build()
 	File_server_entitymanager_proto_entity_proto = out.File
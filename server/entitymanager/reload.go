@@ -0,0 +1,91 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entitymanager
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/openconfig/gnmi/errlist"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+	log "github.com/openconfig/bootz/server/logging"
+)
+
+// ReloadInventory replaces the chassis of the inventory with those of the inventory file at path,
+// e.g. after the Kubernetes ConfigMap it is mounted from is updated, reading their artifacts again.
+// Chassis imported from NetBox are kept, while chassis added through the admin API are dropped
+// unless the file lists them. The voucher store, if any, is indexed again. Nothing is replaced if a
+// chassis fails validation. Changes to the options of the file only apply after a restart.
+func (m *InMemoryEntityManager) ReloadInventory(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("unable to read inventory: %v", err)
+	}
+	entities := &epb.Entities{}
+	if err := prototext.Unmarshal(data, entities); err != nil {
+		return fmt.Errorf("unable to parse inventory %s: %v", path, err)
+	}
+	if !proto.Equal(entities.GetOptions(), m.defaults) {
+		log.Warningf("The options of inventory %s changed, restart the server to apply them", path)
+	}
+	// Vouchers added to the store along with their chassis are needed to validate them.
+	if err := m.vouchers.reindex(); err != nil {
+		log.Warningf("Unable to pick up the changes of the voucher store: %v", err)
+	}
+	var errs errlist.List
+	inventory := m.validInventory(entities.GetChassis(), &errs)
+	if err := errs.Err(); err != nil {
+		return fmt.Errorf("invalid inventory %s: %v", path, err)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for k := range m.imported {
+		if _, ok := inventory[k]; ok {
+			// The file now lists the chassis itself.
+			delete(m.imported, k)
+			continue
+		}
+		if ch, ok := m.chassisInventory[k]; ok {
+			inventory[k] = ch
+		}
+	}
+	m.chassisInventory = inventory
+	m.cache.invalidate()
+	log.Infof("Reloaded %d chassis from inventory %s", len(entities.GetChassis()), path)
+	return nil
+}
+
+// ReloadArtifacts reads the OC, PDC and vendor CA of the artifact directory of the options again,
+// e.g. after the Kubernetes Secret it is mounted from is updated. The current artifacts are kept
+// if the new ones are unusable. It is a no-op if the artifacts are generated or not configured.
+func (m *InMemoryEntityManager) ReloadArtifacts() error {
+	dir := m.GetArtifactDir()
+	if dir == "" {
+		return nil
+	}
+	sa, err := parseSecurityArtifacts(os.DirFS(dir), m.defaults.GetOcKeyUri(), m.defaults.GetPdcKeyUri())
+	if err != nil {
+		return fmt.Errorf("unable to reload security artifacts: %v", err)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.secArtifacts = sa
+	m.cache.invalidate()
+	log.Infof("Reloaded security artifacts from %s", dir)
+	return nil
+}
@@ -0,0 +1,117 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entitymanager
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/h-fam/errdiff"
+	"github.com/openconfig/bootz/server/service"
+	"google.golang.org/protobuf/encoding/prototext"
+
+	bpb "github.com/openconfig/bootz/proto/bootz"
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+)
+
+func TestReloadInventory(t *testing.T) {
+	em, err := New("../../testdata/inventory.prototxt")
+	if err != nil {
+		t.Fatalf("New() err = %v, want nil", err)
+	}
+	cisco := em.chassisInventory[service.EntityLookup{Manufacturer: "Cisco", SerialNumber: "123"}]
+	// Added through the admin API, and dropped on reload.
+	em.AddChassis(bpb.BootMode_BOOT_MODE_INSECURE, "Arista", "ABC")
+	if err := em.ImportChassis([]*epb.Chassis{{Manufacturer: "Juniper", SerialNumber: "NB1", BootMode: bpb.BootMode_BOOT_MODE_INSECURE}}); err != nil {
+		t.Fatalf("ImportChassis() err = %v, want nil", err)
+	}
+	missingConfig := &epb.Chassis{
+		Manufacturer: "Arista",
+		SerialNumber: "BAD",
+		BootMode:     bpb.BootMode_BOOT_MODE_INSECURE,
+		Config:       &epb.Config{BootConfig: &epb.BootConfig{VendorConfigFile: "missing.cfg"}},
+	}
+
+	tests := []struct {
+		desc    string
+		chassis []*epb.Chassis
+		want    []string
+		wantErr string
+	}{{
+		desc:    "Invalid chassis",
+		chassis: []*epb.Chassis{cisco, missingConfig},
+		want:    []string{"ABC", "123", "NB1"},
+		wantErr: "BAD",
+	}, {
+		desc:    "Added chassis",
+		chassis: []*epb.Chassis{cisco, {Manufacturer: "Arista", SerialNumber: "XYZ", BootMode: bpb.BootMode_BOOT_MODE_INSECURE}},
+		want:    []string{"123", "NB1", "XYZ"},
+	}, {
+		desc:    "Removed chassis",
+		chassis: []*epb.Chassis{cisco},
+		want:    []string{"123", "NB1"},
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			data, err := prototext.Marshal(&epb.Entities{Options: em.defaults, Chassis: test.chassis})
+			if err != nil {
+				t.Fatalf("prototext.Marshal() err = %v", err)
+			}
+			path := filepath.Join(t.TempDir(), "inventory.prototxt")
+			if err := os.WriteFile(path, data, 0600); err != nil {
+				t.Fatalf("os.WriteFile() err = %v", err)
+			}
+			err = em.ReloadInventory(path)
+			if diff := errdiff.Substring(err, test.wantErr); diff != "" {
+				t.Errorf("ReloadInventory() %s", diff)
+			}
+			var got []string
+			for k := range em.GetAll() {
+				got = append(got, k.SerialNumber)
+			}
+			sort.Strings(got)
+			sort.Strings(test.want)
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("ReloadInventory() inventory diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReloadArtifacts(t *testing.T) {
+	em, err := New("../../testdata/inventory.prototxt")
+	if err != nil {
+		t.Fatalf("New() err = %v, want nil", err)
+	}
+	before := em.SecurityArtifacts()
+	if err := em.ReloadArtifacts(); err != nil {
+		t.Fatalf("ReloadArtifacts() err = %v, want nil", err)
+	}
+	after := em.SecurityArtifacts()
+	if after == before || after.OC.Cert != before.OC.Cert {
+		t.Errorf("ReloadArtifacts() did not replace the artifacts with those of the artifact directory")
+	}
+
+	em.defaults.ArtifactDir = t.TempDir()
+	if err := em.ReloadArtifacts(); err == nil {
+		t.Errorf("ReloadArtifacts() of empty directory err = nil, want error")
+	}
+	if em.SecurityArtifacts() != after {
+		t.Errorf("ReloadArtifacts() replaced the artifacts with unusable ones")
+	}
+}
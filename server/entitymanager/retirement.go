@@ -0,0 +1,200 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entitymanager
+
+import (
+	"time"
+
+	"github.com/openconfig/bootz/server/retirement"
+	"github.com/openconfig/bootz/server/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	bpb "github.com/openconfig/bootz/proto/bootz"
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+	log "github.com/openconfig/bootz/server/logging"
+)
+
+// Replacement describes the device taking the place of a retired control card or fixed chassis.
+type Replacement struct {
+	// SerialNumber of the replacement, read from its ownership voucher if empty.
+	SerialNumber string
+	// PartNumber of the replacement, if it differs from that of the retired device.
+	PartNumber string
+	// OwnershipVoucher of the replacement, if any.
+	OwnershipVoucher []byte
+}
+
+// retiredDeviceFile returns the file the retired devices are persisted to, if any.
+func retiredDeviceFile(o *epb.Options) string {
+	if f := o.GetRetiredDeviceFile(); f != "" {
+		return f
+	}
+	if f := o.GetDeviceStateFile(); f != "" {
+		return f + ".retired"
+	}
+	return ""
+}
+
+// RetiredDevices returns the retired devices sorted by serial number.
+func (m *InMemoryEntityManager) RetiredDevices() []retirement.Entry {
+	return m.retirements.Entries()
+}
+
+// RetireDevice retires the control card or fixed chassis with the serial number, archiving its
+// chassis, bootstrap state and last reported status, and refusing it bootstrap data from then
+// on. The device is removed from the inventory, unless a replacement takes its place, inheriting
+// the boot mode, image, config and other bindings of its chassis.
+func (m *InMemoryEntityManager) RetireDevice(serial, reason string, r Replacement) (retirement.Entry, error) {
+	if serial == "" {
+		return retirement.Entry{}, status.Errorf(codes.InvalidArgument, "no serial number provided")
+	}
+	if r.SerialNumber == "" && len(r.OwnershipVoucher) > 0 {
+		s, err := m.ParseOwnershipVoucher(r.OwnershipVoucher)
+		if err != nil {
+			return retirement.Entry{}, err
+		}
+		r.SerialNumber = s
+	}
+	if r.SerialNumber == "" && r.PartNumber != "" {
+		return retirement.Entry{}, status.Errorf(codes.InvalidArgument, "replacement has no serial number")
+	}
+
+	// The checks share the critical section of the retirement, so that concurrent calls can not
+	// both retire the same device or take the same replacement.
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.isRetired(serial) {
+		return retirement.Entry{}, status.Errorf(codes.FailedPrecondition, "serial# %s is already retired", serial)
+	}
+	if r.SerialNumber != "" && m.isRetired(r.SerialNumber) {
+		return retirement.Entry{}, status.Errorf(codes.FailedPrecondition, "replacement serial# %s was retired", r.SerialNumber)
+	}
+	chassis, card := m.findDevice(serial)
+	if chassis == nil {
+		return retirement.Entry{}, status.Errorf(codes.NotFound, "could not find controller card or fixed chassis with serial#: %s", serial)
+	}
+	serial = m.deviceSerial(chassis, serial)
+	if r.SerialNumber != "" {
+		_, taken := m.chassisInventory[service.EntityLookup{Manufacturer: chassis.GetManufacturer(), SerialNumber: r.SerialNumber}]
+		if other, _ := m.findDevice(r.SerialNumber); other != nil || taken {
+			return retirement.Entry{}, status.Errorf(codes.AlreadyExists, "replacement serial# %s is already in the inventory", r.SerialNumber)
+		}
+	}
+
+	// Chassis entries may be used after releasing m.mu, so the entry is replaced instead of modified.
+	var updated *epb.Chassis
+	switch {
+	case r.SerialNumber == "" && card < 0:
+		// The fixed chassis is removed from the inventory.
+	case r.SerialNumber == "":
+		if len(chassis.GetControllerCards()) == 1 {
+			return retirement.Entry{}, status.Errorf(codes.FailedPrecondition, "serial# %s is the last control card of chassis %s, and can only be retired with a replacement", serial, chassis.GetSerialNumber())
+		}
+		updated = proto.Clone(chassis).(*epb.Chassis)
+		updated.ControllerCards = append(updated.ControllerCards[:card], updated.ControllerCards[card+1:]...)
+	case card < 0:
+		updated = proto.Clone(chassis).(*epb.Chassis)
+		updated.SerialNumber = r.SerialNumber
+		if r.PartNumber != "" {
+			updated.PartNumber = r.PartNumber
+		}
+		// Vouchers, aliases and DHCP reservations identify the retired hardware.
+		updated.OwnershipVoucher, updated.RotatedOwnershipVoucher = "", ""
+		updated.SerialAliases = nil
+		updated.DhcpConfig = nil
+	default:
+		updated = proto.Clone(chassis).(*epb.Chassis)
+		partNumber := updated.GetControllerCards()[card].GetPartNumber()
+		if r.PartNumber != "" {
+			partNumber = r.PartNumber
+		}
+		updated.ControllerCards[card] = &epb.ControlCard{PartNumber: partNumber, SerialNumber: r.SerialNumber}
+	}
+	if len(r.OwnershipVoucher) > 0 {
+		var err error
+		if updated, err = m.withVoucher(updated, card, r.OwnershipVoucher, false); err != nil {
+			return retirement.Entry{}, err
+		}
+	}
+	if updated.GetBootMode() == bpb.BootMode_BOOT_MODE_SECURE {
+		if err := m.checkSecureBoot(updated); err != nil {
+			return retirement.Entry{}, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+	}
+
+	e := retirement.Entry{
+		SerialNumber: serial,
+		Reason:       reason,
+		RetiredAt:    time.Now(),
+		ReplacedBy:   r.SerialNumber,
+		Chassis:      chassis,
+		Status:       m.controlCardStatuses[serial],
+	}
+	if d, ok := m.states.Get(serial); ok {
+		e.State = &d
+	}
+	if err := m.retirements.Retire(e); err != nil {
+		if m.retirements.IsRetired(serial) {
+			return retirement.Entry{}, status.Errorf(codes.FailedPrecondition, "%v", err)
+		}
+		return retirement.Entry{}, status.Errorf(codes.Internal, "%v", err)
+	}
+	// The voucher is only written once the device is retired, so a failed retirement leaves no
+	// voucher behind. Should that write fail, the replacement is served the voucher from its
+	// inventory entry instead.
+	if len(r.OwnershipVoucher) > 0 {
+		if err := m.storeVoucher(updated, card, r.OwnershipVoucher, false); err != nil {
+			log.Errorf("Unable to store the ownership voucher of replacement serial# %s, keeping it in the inventory: %v", r.SerialNumber, err)
+		}
+	}
+	key := inventoryKey(chassis)
+	delete(m.chassisInventory, key)
+	// The chassis is no longer replaced by the next import.
+	delete(m.imported, key)
+	if updated != nil {
+		m.chassisInventory[inventoryKey(updated)] = updated
+	}
+	m.cache.invalidate()
+	delete(m.controlCardStatuses, serial)
+	if r.SerialNumber != "" {
+		m.controlCardStatuses[r.SerialNumber] = bpb.ControlCardState_CONTROL_CARD_STATUS_UNSPECIFIED
+		log.Infof("Retired serial# %s of chassis %s, replaced by serial# %s: %s", serial, chassis.GetSerialNumber(), r.SerialNumber, reason)
+	} else {
+		log.Infof("Retired serial# %s of chassis %s: %s", serial, chassis.GetSerialNumber(), reason)
+	}
+	if !m.retirements.Persisted() {
+		log.Warningf("Retirement of serial# %s is kept in memory only, as neither retired_device_file nor device_state_file is set", serial)
+	}
+	m.tokens.Invalidate(serial)
+	return e, nil
+}
+
+// isRetired reports whether the reported serial number identifies a retired device.
+func (m *InMemoryEntityManager) isRetired(reported string) bool {
+	if m.retirements.IsRetired(reported) {
+		return true
+	}
+	if m.defaults.GetSerialNormalization() == nil {
+		return false
+	}
+	for _, e := range m.retirements.Entries() {
+		if m.serialMatches(e.SerialNumber, nil, reported) {
+			return true
+		}
+	}
+	return false
+}
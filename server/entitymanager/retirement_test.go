@@ -0,0 +1,267 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entitymanager
+
+import (
+	"context"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/bootz/server/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	bpb "github.com/openconfig/bootz/proto/bootz"
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+)
+
+// cardSerials returns the serial numbers of the control cards of the chassis with the serial.
+func cardSerials(t *testing.T, em *InMemoryEntityManager, serial string) []string {
+	t.Helper()
+	ch, err := em.GetDevice(&service.EntityLookup{Manufacturer: "Cisco", SerialNumber: serial})
+	if err != nil {
+		t.Fatalf("GetDevice() err = %v, want nil", err)
+	}
+	var serials []string
+	for _, c := range ch.GetControllerCards() {
+		serials = append(serials, c.GetSerialNumber())
+	}
+	return serials
+}
+
+func TestRetireDevice(t *testing.T) {
+	data, err := os.ReadFile("../../testdata/ov_123B.txt")
+	if err != nil {
+		t.Fatalf("unable to read ownership voucher: %v", err)
+	}
+	ov, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil {
+		t.Fatalf("unable to decode ownership voucher: %v", err)
+	}
+	tests := []struct {
+		desc        string
+		serial      string
+		replacement Replacement
+		wantCode    codes.Code
+		wantCards   []string
+	}{{
+		desc:        "Control card with replacement",
+		serial:      "123A",
+		replacement: Replacement{SerialNumber: "123C"},
+		wantCards:   []string{"123C", "123B"},
+	}, {
+		desc:      "Control card without replacement",
+		serial:    "123B",
+		wantCards: []string{"123A"},
+	}, {
+		desc:        "Replacement read from its ownership voucher",
+		serial:      "123A",
+		replacement: Replacement{OwnershipVoucher: ov},
+		wantCode:    codes.AlreadyExists,
+	}, {
+		desc:        "Replacement already in the inventory",
+		serial:      "123A",
+		replacement: Replacement{SerialNumber: "123B"},
+		wantCode:    codes.AlreadyExists,
+	}, {
+		desc:        "Replacement without serial number",
+		serial:      "123A",
+		replacement: Replacement{PartNumber: "123A"},
+		wantCode:    codes.InvalidArgument,
+	}, {
+		desc:     "Unknown serial",
+		serial:   "456A",
+		wantCode: codes.NotFound,
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			em, err := New("../../testdata/inventory.prototxt")
+			if err != nil {
+				t.Fatalf("New() err = %v, want nil", err)
+			}
+			e, err := em.RetireDevice(test.serial, "RMA", test.replacement)
+			if got := status.Code(err); got != test.wantCode {
+				t.Fatalf("RetireDevice() err = %v, want code %v", err, test.wantCode)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(test.wantCards, cardSerials(t, em, "123")); diff != "" {
+				t.Errorf("RetireDevice() control cards diff (-want +got):\n%s", diff)
+			}
+			if got := len(e.Chassis.GetControllerCards()); got != 2 {
+				t.Errorf("RetireDevice() archived chassis with %d control cards, want 2", got)
+			}
+			if got := em.RetiredDevices(); len(got) != 1 || got[0].SerialNumber != test.serial || got[0].ReplacedBy != test.replacement.SerialNumber {
+				t.Errorf("RetiredDevices() = %v, want %s replaced by %q", got, test.serial, test.replacement.SerialNumber)
+			}
+			_, err = em.GetBootstrapData(context.Background(), &service.EntityLookup{Manufacturer: "Cisco", SerialNumber: "123"}, &bpb.ControlCard{SerialNumber: test.serial, PartNumber: test.serial})
+			if status.Code(err) != codes.PermissionDenied {
+				t.Errorf("GetBootstrapData() of a retired device err = %v, want code %v", err, codes.PermissionDenied)
+			}
+			if _, err := em.RetireDevice(test.serial, "RMA", Replacement{}); status.Code(err) != codes.FailedPrecondition {
+				t.Errorf("RetireDevice() of a retired device err = %v, want code %v", err, codes.FailedPrecondition)
+			}
+		})
+	}
+}
+
+func TestRetireDeviceVoucherStore(t *testing.T) {
+	em, err := New("../../testdata/inventory.prototxt")
+	if err != nil {
+		t.Fatalf("New() err = %v, want nil", err)
+	}
+	dir := t.TempDir()
+	if em.vouchers, err = newVoucherStore(&epb.VoucherStore{Directory: dir}); err != nil {
+		t.Fatalf("newVoucherStore() err = %v", err)
+	}
+	// 123B replaces 123A, so it must not be in the inventory yet.
+	lookup := service.EntityLookup{Manufacturer: "Cisco", SerialNumber: "123"}
+	ch := proto.Clone(em.chassisInventory[lookup]).(*epb.Chassis)
+	ch.ControllerCards = ch.ControllerCards[:1]
+	em.chassisInventory[lookup] = ch
+	replacement := Replacement{OwnershipVoucher: testVoucher(t, "123B")}
+
+	// A retirement failing to be saved must not leave the voucher of the replacement behind.
+	if err := em.retirements.Persist(filepath.Join(dir, "missing", "retired.json")); err != nil {
+		t.Fatalf("Persist() err = %v, want nil", err)
+	}
+	if _, err := em.RetireDevice("123A", "RMA", replacement); status.Code(err) != codes.Internal {
+		t.Fatalf("RetireDevice() with an unsaved retirement err = %v, want code %v", err, codes.Internal)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "123B.ov")); !os.IsNotExist(err) {
+		t.Errorf("RetireDevice() wrote the voucher of the replacement of a failed retirement, os.Stat() err = %v", err)
+	}
+	if diff := cmp.Diff([]string{"123A"}, cardSerials(t, em, "123")); diff != "" {
+		t.Errorf("RetireDevice() control cards of a failed retirement diff (-want +got):\n%s", diff)
+	}
+
+	if err := em.retirements.Persist(filepath.Join(dir, "retired.json")); err != nil {
+		t.Fatalf("Persist() err = %v, want nil", err)
+	}
+	if _, err := em.RetireDevice("123A", "RMA", replacement); err != nil {
+		t.Fatalf("RetireDevice() err = %v, want nil", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "123B.ov")); err != nil {
+		t.Errorf("RetireDevice() did not write the voucher of the replacement to the store: %v", err)
+	}
+	if ov := em.chassisInventory[lookup].GetControllerCards()[0].GetOwnershipVoucher(); ov != "" {
+		t.Errorf("RetireDevice() set the voucher of the inventory, want it kept in the store only")
+	}
+}
+
+func TestRetireLastControlCard(t *testing.T) {
+	em, err := New("../../testdata/inventory.prototxt")
+	if err != nil {
+		t.Fatalf("New() err = %v, want nil", err)
+	}
+	if _, err := em.RetireDevice("123A", "RMA", Replacement{}); err != nil {
+		t.Fatalf("RetireDevice() err = %v, want nil", err)
+	}
+	if _, err := em.RetireDevice("123B", "RMA", Replacement{}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("RetireDevice() of the last control card err = %v, want code %v", err, codes.FailedPrecondition)
+	}
+}
+
+func TestRetireDeviceConcurrently(t *testing.T) {
+	em, err := New("../../testdata/inventory.prototxt")
+	if err != nil {
+		t.Fatalf("New() err = %v, want nil", err)
+	}
+	const retries = 64
+	errs := make(chan error, retries)
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < retries; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			_, err := em.RetireDevice("123A", "RMA", Replacement{SerialNumber: "123C"})
+			errs <- err
+		}()
+	}
+	close(start)
+	wg.Wait()
+	close(errs)
+	retired := 0
+	for err := range errs {
+		switch status.Code(err) {
+		case codes.OK:
+			retired++
+		case codes.FailedPrecondition:
+		default:
+			t.Errorf("RetireDevice() of a device retired concurrently err = %v, want code %v", err, codes.FailedPrecondition)
+		}
+	}
+	if retired != 1 {
+		t.Errorf("RetireDevice() retired the device %d times, want 1", retired)
+	}
+}
+
+func TestRetireFixedChassis(t *testing.T) {
+	em, err := New("../../testdata/inventory.prototxt")
+	if err != nil {
+		t.Fatalf("New() err = %v, want nil", err)
+	}
+	ch := &epb.Chassis{
+		SerialNumber:  "456",
+		Manufacturer:  "Cisco",
+		PartNumber:    "P1",
+		Name:          "spine-1",
+		BootMode:      bpb.BootMode_BOOT_MODE_INSECURE,
+		SoftwareImage: &bpb.SoftwareImage{Name: "os", Version: "1.0"},
+		SerialAliases: []string{"SN456"},
+	}
+	if err := em.PutChassis(ch, false); err != nil {
+		t.Fatalf("PutChassis() err = %v, want nil", err)
+	}
+	if _, err := em.RetireDevice("456", "repurposed", Replacement{SerialNumber: "789", PartNumber: "P2"}); err != nil {
+		t.Fatalf("RetireDevice() err = %v, want nil", err)
+	}
+	if _, err := em.GetDevice(&service.EntityLookup{Manufacturer: "Cisco", SerialNumber: "456"}); status.Code(err) != codes.NotFound {
+		t.Errorf("GetDevice() of the retired chassis err = %v, want code %v", err, codes.NotFound)
+	}
+	got, err := em.GetDevice(&service.EntityLookup{Manufacturer: "Cisco", SerialNumber: "789"})
+	if err != nil {
+		t.Fatalf("GetDevice() of the replacement err = %v, want nil", err)
+	}
+	if got.GetName() != "spine-1" || got.GetSoftwareImage().GetVersion() != "1.0" || got.GetPartNumber() != "P2" || len(got.GetSerialAliases()) != 0 {
+		t.Errorf("GetDevice() of the replacement = %v, want the bindings of the retired chassis with part number P2 and no aliases", got)
+	}
+
+	// Retired devices are carried by snapshots.
+	restored, err := New("../../testdata/inventory.prototxt")
+	if err != nil {
+		t.Fatalf("New() err = %v, want nil", err)
+	}
+	if err := restored.RestoreSnapshot(em.Snapshot()); err != nil {
+		t.Fatalf("RestoreSnapshot() err = %v, want nil", err)
+	}
+	retired := restored.RetiredDevices()
+	if len(retired) != 1 || retired[0].ReplacedBy != "789" || retired[0].Chassis.GetName() != "spine-1" {
+		t.Errorf("RestoreSnapshot() retired devices = %v, want 456 replaced by 789", retired)
+	}
+	_, err = restored.GetBootstrapData(context.Background(), &service.EntityLookup{Manufacturer: "Cisco", SerialNumber: "456"}, nil)
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("GetBootstrapData() of a restored retired device err = %v, want code %v", err, codes.PermissionDenied)
+	}
+}
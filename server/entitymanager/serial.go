@@ -0,0 +1,104 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entitymanager
+
+import (
+	"strings"
+
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+)
+
+// serialSeparators are the characters ignored in serial numbers when separators are ignored.
+const serialSeparators = " -_.:"
+
+// normalizeSerial applies the configured serial number normalization to serial.
+func (m *InMemoryEntityManager) normalizeSerial(serial string) string {
+	n := m.defaults.GetSerialNormalization()
+	if n == nil {
+		return serial
+	}
+	for _, p := range n.GetStripPrefixes() {
+		if len(serial) >= len(p) && (serial[:len(p)] == p || n.GetIgnoreCase() && strings.EqualFold(serial[:len(p)], p)) {
+			serial = serial[len(p):]
+			break
+		}
+	}
+	if n.GetIgnoreSeparators() {
+		serial = strings.Map(func(r rune) rune {
+			if strings.ContainsRune(serialSeparators, r) {
+				return -1
+			}
+			return r
+		}, serial)
+	}
+	if n.GetIgnoreCase() {
+		serial = strings.ToUpper(serial)
+	}
+	return serial
+}
+
+// serialMatches reports whether a reported serial number identifies the inventory serial number
+// or one of its aliases.
+func (m *InMemoryEntityManager) serialMatches(serial string, aliases []string, reported string) bool {
+	if serial == reported {
+		return true
+	}
+	if reported == "" || serial == wildcard {
+		return false
+	}
+	normalized := m.normalizeSerial(reported)
+	if m.normalizeSerial(serial) == normalized {
+		return true
+	}
+	for _, a := range aliases {
+		if m.normalizeSerial(a) == normalized {
+			return true
+		}
+	}
+	return false
+}
+
+// deviceSerial returns the inventory serial number of the control card of the chassis, or of
+// the fixed chassis, identified by the reported serial number. The reported serial number is
+// returned if none matches.
+func (m *InMemoryEntityManager) deviceSerial(ch *epb.Chassis, reported string) string {
+	if len(ch.GetControllerCards()) == 0 {
+		if m.serialMatches(ch.GetSerialNumber(), ch.GetSerialAliases(), reported) {
+			return ch.GetSerialNumber()
+		}
+		return reported
+	}
+	for _, c := range ch.GetControllerCards() {
+		if m.serialMatches(c.GetSerialNumber(), c.GetSerialAliases(), reported) {
+			return c.GetSerialNumber()
+		}
+	}
+	return reported
+}
+
+// inventorySerial returns the inventory serial number of the control card or fixed chassis
+// identified by the reported serial number, or the reported serial number if none matches.
+// The caller must hold m.mu for reading.
+func (m *InMemoryEntityManager) inventorySerial(reported string) string {
+	if _, ok := m.controlCardStatuses[reported]; ok {
+		return reported
+	}
+	for _, ch := range m.chassisInventory {
+		if serial := m.deviceSerial(ch, reported); serial != reported {
+			return serial
+		}
+	}
+	return reported
+}
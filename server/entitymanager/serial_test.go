@@ -0,0 +1,127 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entitymanager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/openconfig/bootz/server/devicestate"
+	"github.com/openconfig/bootz/server/service"
+	"google.golang.org/protobuf/proto"
+
+	bpb "github.com/openconfig/bootz/proto/bootz"
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+)
+
+func TestNormalizeSerial(t *testing.T) {
+	tests := []struct {
+		desc          string
+		normalization *epb.SerialNormalization
+		serial        string
+		want          string
+	}{{
+		desc:   "No normalization",
+		serial: "sn:ab-12",
+		want:   "sn:ab-12",
+	}, {
+		desc:          "Ignore case",
+		normalization: &epb.SerialNormalization{IgnoreCase: true},
+		serial:        "ab12",
+		want:          "AB12",
+	}, {
+		desc:          "Strip prefix",
+		normalization: &epb.SerialNormalization{StripPrefixes: []string{"SN:", "S/N "}},
+		serial:        "S/N AB12",
+		want:          "AB12",
+	}, {
+		desc:          "Strip prefix regardless of case",
+		normalization: &epb.SerialNormalization{IgnoreCase: true, StripPrefixes: []string{"SN:"}},
+		serial:        "sn:ab12",
+		want:          "AB12",
+	}, {
+		desc:          "Prefix of another case",
+		normalization: &epb.SerialNormalization{StripPrefixes: []string{"SN:"}},
+		serial:        "sn:AB12",
+		want:          "sn:AB12",
+	}, {
+		desc:          "Ignore separators",
+		normalization: &epb.SerialNormalization{IgnoreSeparators: true},
+		serial:        "AB-12.34 56_7:8",
+		want:          "AB12345678",
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			m := &InMemoryEntityManager{defaults: &epb.Options{SerialNormalization: test.normalization}}
+			if got := m.normalizeSerial(test.serial); got != test.want {
+				t.Errorf("normalizeSerial(%q) = %q, want %q", test.serial, got, test.want)
+			}
+		})
+	}
+}
+
+func TestSerialAliases(t *testing.T) {
+	em, err := New("../../testdata/inventory.prototxt")
+	if err != nil {
+		t.Fatalf("New() err = %v, want nil", err)
+	}
+	em.defaults.SerialNormalization = &epb.SerialNormalization{IgnoreCase: true, StripPrefixes: []string{"SN"}}
+	lookup := service.EntityLookup{Manufacturer: "Cisco", SerialNumber: "123"}
+	ch := proto.Clone(em.chassisInventory[lookup]).(*epb.Chassis)
+	ch.SerialAliases = []string{"chassis-123"}
+	ch.GetControllerCards()[1].SerialAliases = []string{"FOX123B"}
+	em.chassisInventory[lookup] = ch
+
+	for _, serial := range []string{"123", "sn123", "CHASSIS-123"} {
+		got, found := em.lookupChassis(&service.EntityLookup{Manufacturer: "Cisco", SerialNumber: serial})
+		if !found || got.GetSerialNumber() != "123" {
+			t.Errorf("lookupChassis(%q) = %v, %v, want chassis 123", serial, got.GetSerialNumber(), found)
+		}
+	}
+	if _, found := em.lookupChassis(&service.EntityLookup{Manufacturer: "Cisco", SerialNumber: "chassis-1234"}); found {
+		t.Errorf("lookupChassis(%q) found a chassis, want none", "chassis-1234")
+	}
+	cards := []*bpb.ControlCard{{SerialNumber: "sn123a", PartNumber: "123A"}, {SerialNumber: "fox123b", PartNumber: "123B"}}
+	if err := em.ValidateControlCards(context.Background(), &service.EntityLookup{Manufacturer: "Cisco", SerialNumber: "SN123"}, cards); err != nil {
+		t.Errorf("ValidateControlCards() err = %v, want nil", err)
+	}
+	if err := em.ValidateControlCards(context.Background(), &lookup, []*bpb.ControlCard{cards[1], {SerialNumber: "123B", PartNumber: "123B"}}); err == nil {
+		t.Errorf("ValidateControlCards() of a control card listed under its serial# and alias err = nil, want error")
+	}
+
+	resp, err := em.GetBootstrapData(context.Background(), &service.EntityLookup{Manufacturer: "Cisco"}, cards[1])
+	if err != nil {
+		t.Fatalf("GetBootstrapData() err = %v, want nil", err)
+	}
+	if got := resp.GetSerialNum(); got != "fox123b" {
+		t.Errorf("GetBootstrapData() serial# = %q, want the reported %q", got, "fox123b")
+	}
+	if d, ok := em.States().Get("123B"); !ok || d.State != devicestate.BootstrapSent {
+		t.Errorf("state of 123B = %+v, %v, want %v", d, ok, devicestate.BootstrapSent)
+	}
+	if _, err := em.OwnershipVoucher(context.Background(), &service.EntityLookup{Manufacturer: "Cisco"}, "FOX123B"); err != nil {
+		t.Errorf("OwnershipVoucher() err = %v, want nil", err)
+	}
+	err = em.SetStatus(context.Background(), &bpb.ReportStatusRequest{
+		Status: bpb.ReportStatusRequest_BOOTSTRAP_STATUS_SUCCESS,
+		States: []*bpb.ControlCardState{{SerialNumber: "Fox123B", Status: bpb.ControlCardState_CONTROL_CARD_STATUS_INITIALIZED}},
+	})
+	if err != nil {
+		t.Fatalf("SetStatus() err = %v, want nil", err)
+	}
+	if got := em.controlCardStatuses["123B"]; got != bpb.ControlCardState_CONTROL_CARD_STATUS_INITIALIZED {
+		t.Errorf("status of 123B = %v, want %v", got, bpb.ControlCardState_CONTROL_CARD_STATUS_INITIALIZED)
+	}
+}
@@ -0,0 +1,307 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entitymanager
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/openconfig/bootz/server/devicestate"
+	"github.com/openconfig/bootz/server/retirement"
+	"github.com/openconfig/bootz/server/revocation"
+	"github.com/openconfig/bootz/server/service"
+	"github.com/openconfig/bootz/server/token"
+	"github.com/openconfig/gnmi/errlist"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	bpb "github.com/openconfig/bootz/proto/bootz"
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+	log "github.com/openconfig/bootz/server/logging"
+)
+
+// snapshotVersion is the format version of the snapshots taken by Snapshot.
+const snapshotVersion = 1
+
+// timestamp converts t to a timestamp, or nil if t is zero.
+func timestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+// fromTimestamp converts ts to a time, or the zero time if ts is unset.
+func fromTimestamp(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
+
+// Snapshot returns the options, chassis, control card statuses, device states, revoked
+// ownership vouchers, bootstrap tokens and retired devices of the entity manager.
+func (m *InMemoryEntityManager) Snapshot() *epb.Snapshot {
+	s := &epb.Snapshot{
+		Version:             snapshotVersion,
+		CreatedAt:           timestamppb.Now(),
+		Options:             proto.Clone(m.defaults).(*epb.Options),
+		ControlCardStatuses: map[string]bpb.ControlCardState_ControlCardStatus{},
+	}
+	m.mu.RLock()
+	for _, ch := range m.chassisInventory {
+		s.Chassis = append(s.Chassis, proto.Clone(ch).(*epb.Chassis))
+	}
+	for serial, st := range m.controlCardStatuses {
+		s.ControlCardStatuses[serial] = st
+	}
+	m.mu.RUnlock()
+	sort.Slice(s.Chassis, func(i, j int) bool {
+		if s.Chassis[i].GetManufacturer() != s.Chassis[j].GetManufacturer() {
+			return s.Chassis[i].GetManufacturer() < s.Chassis[j].GetManufacturer()
+		}
+		return s.Chassis[i].GetSerialNumber() < s.Chassis[j].GetSerialNumber()
+	})
+	for _, d := range m.states.Devices() {
+		s.DeviceStates = append(s.DeviceStates, deviceRecord(d))
+	}
+	for _, e := range m.revocations.Entries() {
+		s.RevokedVouchers = append(s.RevokedVouchers, &epb.RevokedVoucherRecord{
+			SerialNumber: e.SerialNumber,
+			Reason:       e.Reason,
+			RevokedAt:    timestamp(e.RevokedAt),
+		})
+	}
+	for _, e := range m.tokens.Entries() {
+		s.BootstrapTokens = append(s.BootstrapTokens, &epb.BootstrapTokenRecord{
+			SerialNumber: e.SerialNumber,
+			TokenHash:    e.Hash,
+			IssuedAt:     timestamp(e.IssuedAt),
+			ExpiresAt:    timestamp(e.ExpiresAt),
+		})
+	}
+	for _, e := range m.retirements.Entries() {
+		s.RetiredDevices = append(s.RetiredDevices, retiredRecord(e))
+	}
+	return s
+}
+
+// deviceRecord converts the state of a device to its snapshot record.
+func deviceRecord(d devicestate.Device) *epb.DeviceStateRecord {
+	return &epb.DeviceStateRecord{
+		SerialNumber:      d.SerialNumber,
+		State:             string(d.State),
+		Retries:           uint32(d.Retries),
+		ChangedAt:         timestamp(d.Changed),
+		SentAt:            timestamp(d.SentAt),
+		ReportedAt:        timestamp(d.ReportedAt),
+		Stage:             string(d.Stage),
+		RequestCounter:    d.RequestCounter,
+		LastSeenAt:        timestamp(d.LastSeen),
+		LastAddress:       d.LastAddress,
+		ServedImageHash:   d.Served.ImageHash,
+		ServedConfigHash:  d.Served.ConfigHash,
+		AppliedImageHash:  d.Applied.ImageHash,
+		AppliedConfigHash: d.Applied.ConfigHash,
+	}
+}
+
+// snapshotDevice converts the snapshot record of a device state back to the state.
+func snapshotDevice(d *epb.DeviceStateRecord) (devicestate.Device, error) {
+	st := devicestate.State(d.GetState())
+	switch st {
+	case devicestate.Idle, devicestate.BootstrapSent, devicestate.StatusOK, devicestate.StatusFailed:
+	default:
+		return devicestate.Device{}, fmt.Errorf("device with serial# %s has unknown state %q", d.GetSerialNumber(), d.GetState())
+	}
+	stage := devicestate.ConfigStage(d.GetStage())
+	switch stage {
+	case devicestate.SingleStage, devicestate.InitialStage, devicestate.FinalStage:
+	default:
+		return devicestate.Device{}, fmt.Errorf("device with serial# %s has unknown config stage %q", d.GetSerialNumber(), d.GetStage())
+	}
+	return devicestate.Device{
+		SerialNumber:   d.GetSerialNumber(),
+		State:          st,
+		Retries:        int(d.GetRetries()),
+		Changed:        fromTimestamp(d.GetChangedAt()),
+		SentAt:         fromTimestamp(d.GetSentAt()),
+		ReportedAt:     fromTimestamp(d.GetReportedAt()),
+		Stage:          stage,
+		RequestCounter: d.GetRequestCounter(),
+		LastSeen:       fromTimestamp(d.GetLastSeenAt()),
+		LastAddress:    d.GetLastAddress(),
+		Served:         devicestate.Artifacts{ImageHash: d.GetServedImageHash(), ConfigHash: d.GetServedConfigHash()},
+		Applied:        devicestate.Artifacts{ImageHash: d.GetAppliedImageHash(), ConfigHash: d.GetAppliedConfigHash()},
+	}, nil
+}
+
+// RestoreSnapshot replaces the chassis, control card statuses, device states, revoked
+// ownership vouchers, bootstrap tokens and retired devices of the entity manager with those of the snapshot. The
+// options of the snapshot are not applied, as the security artifacts and listeners of the server
+// depend on them. Nothing is restored if a chassis of the snapshot fails validation.
+func (m *InMemoryEntityManager) RestoreSnapshot(s *epb.Snapshot) error {
+	if v := s.GetVersion(); v == 0 || v > snapshotVersion {
+		return status.Errorf(codes.InvalidArgument, "unsupported snapshot version %d, want at most %d", v, snapshotVersion)
+	}
+	var errs errlist.List
+	inventory := m.validInventory(s.GetChassis(), &errs)
+	var devices []devicestate.Device
+	for _, d := range s.GetDeviceStates() {
+		device, err := snapshotDevice(d)
+		if err != nil {
+			errs.Add(err)
+			continue
+		}
+		devices = append(devices, device)
+	}
+	retired := snapshotRetirements(s, &errs)
+	if err := errs.Err(); err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid snapshot: %v", err)
+	}
+	revoked := snapshotRevocations(s)
+
+	m.mu.Lock()
+	m.chassisInventory = inventory
+	m.imported = nil
+	m.cache.invalidate()
+	m.controlCardStatuses = map[string]bpb.ControlCardState_ControlCardStatus{}
+	for serial, st := range s.GetControlCardStatuses() {
+		m.controlCardStatuses[serial] = st
+	}
+	m.mu.Unlock()
+	m.states.Restore(devices)
+	m.revocations.Restore(revoked)
+	m.tokens.Restore(snapshotTokens(s))
+	m.retirements.Restore(retired)
+	log.Infof("Restored snapshot of %s with %d chassis and %d device states", fromTimestamp(s.GetCreatedAt()), len(inventory), len(devices))
+	return nil
+}
+
+// validInventory returns clones of the chassis keyed by lookup, adding the chassis failing
+// validation to errs.
+func (m *InMemoryEntityManager) validInventory(chassis []*epb.Chassis, errs *errlist.List) map[service.EntityLookup]*epb.Chassis {
+	inventory := map[service.EntityLookup]*epb.Chassis{}
+	for _, ch := range chassis {
+		ch = proto.Clone(ch).(*epb.Chassis)
+		if ch.GetBootMode() == bpb.BootMode_BOOT_MODE_UNSPECIFIED {
+			ch.BootMode = m.defaults.GetDefaultBootMode()
+		}
+		if ch.GetBootMode() == bpb.BootMode_BOOT_MODE_SECURE {
+			if err := m.checkSecureBoot(ch); err != nil {
+				errs.Add(err)
+				continue
+			}
+		}
+		if _, err := parseWindows(ch.GetProvisioningWindows()); err != nil {
+			errs.Add(fmt.Errorf("chassis with serial# %s: %v", ch.GetSerialNumber(), err))
+			continue
+		}
+		if !ch.GetArtifactsPending() {
+			if _, err := m.populateArtifacts(ch); err != nil {
+				errs.Add(fmt.Errorf("chassis with serial# %s: %v", ch.GetSerialNumber(), err))
+				continue
+			}
+		}
+		inventory[inventoryKey(ch)] = ch
+	}
+	return inventory
+}
+
+// snapshotRevocations returns the revoked ownership vouchers of the snapshot.
+func snapshotRevocations(s *epb.Snapshot) []revocation.Entry {
+	var revoked []revocation.Entry
+	for _, r := range s.GetRevokedVouchers() {
+		revoked = append(revoked, revocation.Entry{
+			SerialNumber: r.GetSerialNumber(),
+			Reason:       r.GetReason(),
+			RevokedAt:    fromTimestamp(r.GetRevokedAt()),
+		})
+	}
+	return revoked
+}
+
+// snapshotTokens returns the bootstrap tokens of the snapshot.
+func snapshotTokens(s *epb.Snapshot) []token.Entry {
+	var tokens []token.Entry
+	for _, t := range s.GetBootstrapTokens() {
+		tokens = append(tokens, token.Entry{
+			SerialNumber: t.GetSerialNumber(),
+			Hash:         t.GetTokenHash(),
+			IssuedAt:     fromTimestamp(t.GetIssuedAt()),
+			ExpiresAt:    fromTimestamp(t.GetExpiresAt()),
+		})
+	}
+	return tokens
+}
+
+// retiredRecord converts a retired device to its snapshot record.
+func retiredRecord(e retirement.Entry) *epb.RetiredDeviceRecord {
+	r := &epb.RetiredDeviceRecord{
+		SerialNumber: e.SerialNumber,
+		Reason:       e.Reason,
+		RetiredAt:    timestamp(e.RetiredAt),
+		ReplacedBy:   e.ReplacedBy,
+		Chassis:      proto.Clone(e.Chassis).(*epb.Chassis),
+		Status:       e.Status,
+	}
+	if e.State != nil {
+		r.State = deviceRecord(*e.State)
+	}
+	return r
+}
+
+// snapshotRetirements returns the retired devices of the snapshot, adding those with an invalid
+// state to errs.
+func snapshotRetirements(s *epb.Snapshot, errs *errlist.List) []retirement.Entry {
+	var retired []retirement.Entry
+	for _, r := range s.GetRetiredDevices() {
+		e := retirement.Entry{
+			SerialNumber: r.GetSerialNumber(),
+			Reason:       r.GetReason(),
+			RetiredAt:    fromTimestamp(r.GetRetiredAt()),
+			ReplacedBy:   r.GetReplacedBy(),
+			Chassis:      proto.Clone(r.GetChassis()).(*epb.Chassis),
+			Status:       r.GetStatus(),
+		}
+		if r.GetState() != nil {
+			d, err := snapshotDevice(r.GetState())
+			if err != nil {
+				errs.Add(fmt.Errorf("retired %v", err))
+				continue
+			}
+			e.State = &d
+		}
+		retired = append(retired, e)
+	}
+	return retired
+}
+
+// RestoreSnapshotFile restores the binary encoded snapshot of path.
+func (m *InMemoryEntityManager) RestoreSnapshotFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("unable to read snapshot: %v", err)
+	}
+	s := &epb.Snapshot{}
+	if err := proto.Unmarshal(data, s); err != nil {
+		return fmt.Errorf("unable to parse snapshot %s: %v", path, err)
+	}
+	return m.RestoreSnapshot(s)
+}
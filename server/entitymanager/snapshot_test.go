@@ -0,0 +1,121 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entitymanager
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/bootz/server/devicestate"
+	"github.com/openconfig/bootz/server/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	bpb "github.com/openconfig/bootz/proto/bootz"
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+)
+
+func TestSnapshot(t *testing.T) {
+	src, err := New("../../testdata/inventory.prototxt")
+	if err != nil {
+		t.Fatalf("New() err = %v, want nil", err)
+	}
+	src.AddChassis(bpb.BootMode_BOOT_MODE_INSECURE, "Arista", "ABC")
+	src.controlCardStatuses["123A"] = bpb.ControlCardState_CONTROL_CARD_STATUS_UNSPECIFIED
+	if err := src.SetStatus(context.Background(), &bpb.ReportStatusRequest{
+		Status: bpb.ReportStatusRequest_BOOTSTRAP_STATUS_SUCCESS,
+		States: []*bpb.ControlCardState{{SerialNumber: "123A", Status: bpb.ControlCardState_CONTROL_CARD_STATUS_INITIALIZED, AppliedConfigHash: "cd34"}},
+	}); err != nil {
+		t.Fatalf("SetStatus() err = %v, want nil", err)
+	}
+	src.States().Sent("123A", devicestate.InitialStage, devicestate.Artifacts{})
+	src.States().Sent("123A", devicestate.InitialStage, devicestate.Artifacts{ImageHash: "ab12", ConfigHash: "ef56"})
+	if err := src.Revocations().Revoke("123B", "RMA"); err != nil {
+		t.Fatalf("Revoke() err = %v, want nil", err)
+	}
+	snap := src.Snapshot()
+	if snap.GetVersion() != snapshotVersion {
+		t.Errorf("Snapshot() version = %d, want %d", snap.GetVersion(), snapshotVersion)
+	}
+
+	// Round trip the snapshot through a file, as when migrating to another server.
+	data, err := proto.Marshal(snap)
+	if err != nil {
+		t.Fatalf("Marshal() err = %v, want nil", err)
+	}
+	path := filepath.Join(t.TempDir(), "snapshot.pb")
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatalf("unable to write snapshot: %v", err)
+	}
+	dst, err := New("../../testdata/inventory.prototxt")
+	if err != nil {
+		t.Fatalf("New() err = %v, want nil", err)
+	}
+	dst.DeleteDevice(&service.EntityLookup{Manufacturer: "Cisco", SerialNumber: "123"})
+	if err := dst.RestoreSnapshotFile(path); err != nil {
+		t.Fatalf("RestoreSnapshotFile() err = %v, want nil", err)
+	}
+	if diff := cmp.Diff(src.GetAll(), dst.GetAll(), protocmp.Transform()); diff != "" {
+		t.Errorf("restored chassis diff (-want, +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(src.States().Devices(), dst.States().Devices()); diff != "" {
+		t.Errorf("restored device states diff (-want, +got):\n%s", diff)
+	}
+	if d, _ := dst.States().Get("123A"); d.State != devicestate.BootstrapSent || d.Retries != 1 {
+		t.Errorf("restored state of 123A = %+v, want bootstrap_sent with 1 retry", d)
+	}
+	if !dst.Revocations().IsRevoked("123B") {
+		t.Errorf("restored revocations do not include 123B")
+	}
+	if got := dst.controlCardStatuses["123A"]; got != bpb.ControlCardState_CONTROL_CARD_STATUS_INITIALIZED {
+		t.Errorf("restored status of 123A = %v, want %v", got, bpb.ControlCardState_CONTROL_CARD_STATUS_INITIALIZED)
+	}
+
+	tests := []struct {
+		desc   string
+		modify func(*epb.Snapshot)
+	}{{
+		desc:   "Unknown version",
+		modify: func(s *epb.Snapshot) { s.Version = snapshotVersion + 1 },
+	}, {
+		desc: "Secure chassis without voucher",
+		modify: func(s *epb.Snapshot) {
+			s.Chassis = append(s.Chassis, &epb.Chassis{Manufacturer: "Arista", SerialNumber: "DEF", BootMode: bpb.BootMode_BOOT_MODE_SECURE})
+		},
+	}, {
+		desc: "Unknown device state",
+		modify: func(s *epb.Snapshot) {
+			s.DeviceStates = append(s.DeviceStates, &epb.DeviceStateRecord{SerialNumber: "DEF", State: "lost"})
+		},
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := proto.Clone(snap).(*epb.Snapshot)
+			s.Chassis = s.Chassis[:1]
+			test.modify(s)
+			if err := dst.RestoreSnapshot(s); status.Code(err) != codes.InvalidArgument {
+				t.Fatalf("RestoreSnapshot() err = %v, want code %v", err, codes.InvalidArgument)
+			}
+			if got, want := len(dst.GetAll()), len(snap.GetChassis()); got != want {
+				t.Errorf("RestoreSnapshot() of an invalid snapshot left %d chassis, want %d", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entitymanager
+
+import (
+	"context"
+
+	"github.com/openconfig/bootz/server/devicestate"
+	"github.com/openconfig/bootz/server/token"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	log "github.com/openconfig/bootz/server/logging"
+)
+
+// Tokens returns the one-time bootstrap tokens.
+func (m *InMemoryEntityManager) Tokens() *token.List {
+	return m.tokens
+}
+
+// CheckBootstrapToken checks the bootstrap token presented by the device with the given serial.
+// A token is required if one was issued for the device, or if required is set. It reports whether
+// a token was verified, which authenticates the request.
+func (m *InMemoryEntityManager) CheckBootstrapToken(_ context.Context, serial, presented string, required bool) (bool, error) {
+	m.mu.RLock()
+	serial = m.inventorySerial(serial)
+	m.mu.RUnlock()
+	if !required && !m.tokens.Issued(serial) {
+		return false, nil
+	}
+	e, err := m.tokens.Verify(serial, presented)
+	if err != nil {
+		return false, status.Errorf(codes.PermissionDenied, "invalid bootstrap token: %v", err)
+	}
+	// The success of the device may have been reported to another instance of the cluster,
+	// invalidating the token there only.
+	if d, ok := m.states.Get(serial); ok && d.State == devicestate.StatusOK && d.ReportedAt.After(e.IssuedAt) {
+		m.tokens.Invalidate(serial)
+		return false, status.Errorf(codes.PermissionDenied, "invalid bootstrap token: token of serial# %s was already used", serial)
+	}
+	return true, nil
+}
+
+// useToken invalidates the bootstrap token of the device with the given serial once it reports
+// a successful bootstrap.
+func (m *InMemoryEntityManager) useToken(serial string) {
+	if m.tokens.Invalidate(serial) {
+		log.Infof("Bootstrap token of serial# %s was used", serial)
+	}
+}
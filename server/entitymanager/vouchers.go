@@ -0,0 +1,210 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entitymanager
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+	log "github.com/openconfig/bootz/server/logging"
+)
+
+const (
+	// defaultVoucherCacheSize is the number of decoded vouchers a voucher store caches by default.
+	defaultVoucherCacheSize = 1024
+	// rotatedVoucherDir is the subdirectory of a voucher store holding the rotated vouchers.
+	rotatedVoucherDir = "rotated"
+	// voucherSuffix is the suffix of the names of voucher files.
+	voucherSuffix = ".ov"
+	// voucherTempPrefix is the prefix of the temporary files vouchers are written to.
+	voucherTempPrefix = ".tmp-"
+)
+
+// voucherStore holds ownership vouchers on disk, a file per voucher named after the inventory
+// serial number it is issued for, so large fleets don't keep every voucher in memory. The files
+// are indexed when the store is opened and read when their voucher is served, caching the most
+// recently used vouchers decoded. A nil store holds no vouchers. It is safe for concurrent use.
+type voucherStore struct {
+	dir   string
+	cache *responseCache
+
+	mu sync.RWMutex
+	// index holds the paths of the voucher files relative to dir.
+	index map[string]bool
+}
+
+// newVoucherStore opens the voucher store configured by cfg, or returns nil if none is.
+func newVoucherStore(cfg *epb.VoucherStore) (*voucherStore, error) {
+	if cfg.GetDirectory() == "" {
+		return nil, nil
+	}
+	size := cfg.GetCacheSize()
+	if size == 0 {
+		size = defaultVoucherCacheSize
+	}
+	s := &voucherStore{
+		dir:   cfg.GetDirectory(),
+		cache: newResponseCache(&epb.ResponseCache{Size: size}),
+	}
+	if err := s.reindex(); err != nil {
+		return nil, err
+	}
+	log.Infof("Indexed %d ownership vouchers in %s", len(s.index), s.dir)
+	return s, nil
+}
+
+// voucherFile returns the path of the file of the voucher of a serial number, or of its rotated
+// voucher, relative to the directory of the store.
+func voucherFile(serial string, rotated bool) string {
+	name := url.PathEscape(serial) + voucherSuffix
+	if rotated {
+		return path.Join(rotatedVoucherDir, name)
+	}
+	return name
+}
+
+// reindex lists the voucher files of the directory again, picking up the files added or removed
+// by other means than put, and empties the cache.
+func (s *voucherStore) reindex() error {
+	if s == nil {
+		return nil
+	}
+	index := map[string]bool{}
+	for _, dir := range []string{".", rotatedVoucherDir} {
+		entries, err := os.ReadDir(filepath.Join(s.dir, dir))
+		if errors.Is(err, fs.ErrNotExist) && dir != "." {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("unable to index voucher store: %v", err)
+		}
+		for _, e := range entries {
+			if e.IsDir() || !strings.HasSuffix(e.Name(), voucherSuffix) || strings.HasPrefix(e.Name(), voucherTempPrefix) {
+				continue
+			}
+			index[path.Join(dir, e.Name())] = true
+		}
+	}
+	s.mu.Lock()
+	s.index = index
+	s.mu.Unlock()
+	s.cache.invalidate()
+	return nil
+}
+
+// has reports whether the store holds the voucher of a serial number, or its rotated voucher.
+func (s *voucherStore) has(serial string, rotated bool) bool {
+	if s == nil {
+		return false
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.index[voucherFile(serial, rotated)]
+}
+
+// get returns the decoded voucher of a serial number, or its rotated voucher, or nil if the store
+// doesn't hold it. The returned voucher must not be modified.
+func (s *voucherStore) get(serial string, rotated bool) ([]byte, error) {
+	if !s.has(serial, rotated) {
+		return nil, nil
+	}
+	name := voucherFile(serial, rotated)
+	if v, ok := s.cache.get(name); ok {
+		return v.([]byte), nil
+	}
+	data, err := os.ReadFile(filepath.Join(s.dir, filepath.FromSlash(name)))
+	if errors.Is(err, fs.ErrNotExist) {
+		// The file was removed since the store was indexed.
+		s.mu.Lock()
+		delete(s.index, name)
+		s.mu.Unlock()
+		return nil, nil
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to read ownership voucher of serial# %s: %v", serial, err)
+	}
+	// Vouchers are stored as DER, or base64 encoded as in the inventory.
+	ov := data
+	if encoded := strings.TrimSpace(string(data)); isBase64(encoded) {
+		if ov, err = base64.StdEncoding.DecodeString(encoded); err != nil {
+			return nil, status.Errorf(codes.Internal, "unable to decode ownership voucher of serial# %s from base64", serial)
+		}
+	}
+	s.cache.put(name, ov)
+	return ov, nil
+}
+
+// put stores the DER encoded voucher of a serial number, or its rotated voucher, replacing the
+// file of the previous voucher atomically.
+func (s *voucherStore) put(serial string, rotated bool, ov []byte) error {
+	name := voucherFile(serial, rotated)
+	file := filepath.Join(s.dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(file), 0700); err != nil {
+		return status.Errorf(codes.Internal, "unable to store ownership voucher of serial# %s: %v", serial, err)
+	}
+	f, err := os.CreateTemp(filepath.Dir(file), voucherTempPrefix+"*"+voucherSuffix)
+	if err != nil {
+		return status.Errorf(codes.Internal, "unable to store ownership voucher of serial# %s: %v", serial, err)
+	}
+	_, err = f.Write(ov)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), file)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return status.Errorf(codes.Internal, "unable to store ownership voucher of serial# %s: %v", serial, err)
+	}
+	s.mu.Lock()
+	s.index[name] = true
+	s.mu.Unlock()
+	s.cache.put(name, append([]byte(nil), ov...))
+	return nil
+}
+
+// voucher returns the ownership voucher, or the rotated ownership voucher, of the control card or
+// fixed chassis with the inventory serial number: the voucher set in the inventory, or else the
+// voucher of the store. It returns an empty string if there is neither.
+func (m *InMemoryEntityManager) voucher(serial, inventory string, rotated bool) (string, error) {
+	if inventory != "" {
+		return inventory, nil
+	}
+	ov, err := m.vouchers.get(serial, rotated)
+	if err != nil {
+		return "", err
+	}
+	// DER encoded vouchers are never valid base64, so they are not decoded again when served.
+	return string(ov), nil
+}
+
+// hasVoucher reports whether the control card or fixed chassis with the inventory serial number
+// has an ownership voucher, or a rotated ownership voucher, in the inventory or in the store.
+func (m *InMemoryEntityManager) hasVoucher(serial, inventory string, rotated bool) bool {
+	return inventory != "" || m.vouchers.has(serial, rotated)
+}
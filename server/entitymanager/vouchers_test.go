@@ -0,0 +1,159 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entitymanager
+
+import (
+	"context"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/bootz/server/service"
+
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+)
+
+// testVoucher returns the DER encoded voucher of the test inventory for serial.
+func testVoucher(t *testing.T, serial string) []byte {
+	t.Helper()
+	ov, err := base64.StdEncoding.DecodeString(readTextFromFile(t, "../../testdata/ov_"+serial+".txt"))
+	if err != nil {
+		t.Fatalf("unable to decode OV: %v", err)
+	}
+	return ov
+}
+
+func TestVoucherStore(t *testing.T) {
+	ovA, ovB := testVoucher(t, "123A"), testVoucher(t, "123B")
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, rotatedVoucherDir), 0700); err != nil {
+		t.Fatalf("os.Mkdir() err = %v", err)
+	}
+	for name, content := range map[string][]byte{
+		"123A.ov":         ovA,
+		"rotated/123B.ov": []byte(base64.StdEncoding.EncodeToString(ovB) + "\n"),
+		"notes.txt":       []byte("not a voucher"),
+		".tmp-1.ov":       []byte("partially written"),
+	} {
+		if err := os.WriteFile(filepath.Join(dir, filepath.FromSlash(name)), content, 0600); err != nil {
+			t.Fatalf("os.WriteFile() err = %v", err)
+		}
+	}
+	s, err := newVoucherStore(&epb.VoucherStore{Directory: dir, CacheSize: 1})
+	if err != nil {
+		t.Fatalf("newVoucherStore() err = %v", err)
+	}
+	if got := len(s.index); got != 2 {
+		t.Errorf("newVoucherStore() indexed %d vouchers, want 2", got)
+	}
+
+	tests := []struct {
+		desc    string
+		serial  string
+		rotated bool
+		want    []byte
+	}{
+		{desc: "DER voucher", serial: "123A", want: ovA},
+		{desc: "Base64 rotated voucher", serial: "123B", rotated: true, want: ovB},
+		{desc: "Voucher of another serial", serial: "123B"},
+		{desc: "Rotated voucher of another serial", serial: "123A", rotated: true},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			if got, want := s.has(test.serial, test.rotated), test.want != nil; got != want {
+				t.Errorf("has(%q, %v) = %v, want %v", test.serial, test.rotated, got, want)
+			}
+			got, err := s.get(test.serial, test.rotated)
+			if err != nil {
+				t.Fatalf("get(%q, %v) err = %v", test.serial, test.rotated, err)
+			}
+			if !cmp.Equal(got, test.want) {
+				t.Errorf("get(%q, %v) did not return the voucher of the file", test.serial, test.rotated)
+			}
+		})
+	}
+
+	// Serial numbers are escaped in file names.
+	if err := s.put("FOX/1", false, ovB); err != nil {
+		t.Fatalf("put() err = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "FOX%2F1.ov")); err != nil {
+		t.Errorf("put() did not write the voucher file: %v", err)
+	}
+	if got, err := s.get("FOX/1", false); err != nil || !cmp.Equal(got, ovB) {
+		t.Errorf("get() of a stored voucher = %v, %v, want the stored voucher", len(got), err)
+	}
+
+	// Vouchers evicted from the cache are read again, and dropped once their file is removed.
+	if err := os.Remove(filepath.Join(dir, "123A.ov")); err != nil {
+		t.Fatalf("os.Remove() err = %v", err)
+	}
+	if got, err := s.get("123A", false); err != nil || got != nil {
+		t.Errorf("get() of a removed voucher = %v, %v, want nil, nil", len(got), err)
+	}
+	if s.has("123A", false) {
+		t.Errorf("has() of a removed voucher = true, want false")
+	}
+
+	if s, err := newVoucherStore(nil); s != nil || err != nil {
+		t.Errorf("newVoucherStore(nil) = %v, %v, want nil, nil", s, err)
+	}
+	if _, err := newVoucherStore(&epb.VoucherStore{Directory: filepath.Join(dir, "missing")}); err == nil {
+		t.Errorf("newVoucherStore() of a missing directory err = nil, want error")
+	}
+}
+
+func TestStoredOwnershipVoucher(t *testing.T) {
+	em, err := New("../../testdata/inventory.prototxt")
+	if err != nil {
+		t.Fatalf("New() err = %v, want nil", err)
+	}
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "123A.ov"), testVoucher(t, "123A"), 0600); err != nil {
+		t.Fatalf("os.WriteFile() err = %v", err)
+	}
+	if em.vouchers, err = newVoucherStore(&epb.VoucherStore{Directory: dir}); err != nil {
+		t.Fatalf("newVoucherStore() err = %v", err)
+	}
+	lookup := service.EntityLookup{Manufacturer: "Cisco", SerialNumber: "123"}
+	for _, c := range em.chassisInventory[lookup].GetControllerCards() {
+		c.OwnershipVoucher = ""
+	}
+
+	got, err := em.OwnershipVoucher(context.Background(), &lookup, "123A")
+	if err != nil {
+		t.Fatalf("OwnershipVoucher() err = %v, want nil", err)
+	}
+	if !cmp.Equal(got, testVoucher(t, "123A")) {
+		t.Errorf("OwnershipVoucher() did not return the voucher of the store")
+	}
+
+	// Uploaded vouchers are written to the store.
+	want := testVoucher(t, "123B")
+	if err := em.SetOwnershipVoucher("123B", want, false); err != nil {
+		t.Fatalf("SetOwnershipVoucher() err = %v, want nil", err)
+	}
+	if ov := em.chassisInventory[lookup].GetControllerCards()[1].GetOwnershipVoucher(); ov != "" {
+		t.Errorf("SetOwnershipVoucher() set the voucher of the inventory, want it kept in the store only")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "123B.ov")); err != nil {
+		t.Errorf("SetOwnershipVoucher() did not write the voucher to the store: %v", err)
+	}
+	if got, err = em.OwnershipVoucher(context.Background(), &lookup, "123B"); err != nil || !cmp.Equal(got, want) {
+		t.Errorf("OwnershipVoucher() of an uploaded voucher = %v, %v, want the uploaded voucher", len(got), err)
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entitymanager
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/openconfig/bootz/server/service"
+
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+)
+
+// window is a parsed provisioning window.
+type window struct {
+	// days the window opens on, or every day if nil.
+	days map[time.Weekday]bool
+	// start and end are the minutes of the day the window opens and closes at.
+	start, end int
+	loc        *time.Location
+}
+
+// parseWindows parses provisioning windows.
+func parseWindows(cfgs []*epb.ProvisioningWindow) ([]*window, error) {
+	var windows []*window
+	for i, cfg := range cfgs {
+		w, err := parseWindow(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("provisioning window %d: %v", i, err)
+		}
+		windows = append(windows, w)
+	}
+	return windows, nil
+}
+
+func parseWindow(cfg *epb.ProvisioningWindow) (*window, error) {
+	w := &window{loc: time.UTC}
+	var err error
+	if w.start, err = parseTimeOfDay(cfg.GetStart()); err != nil {
+		return nil, err
+	}
+	if w.end, err = parseTimeOfDay(cfg.GetEnd()); err != nil {
+		return nil, err
+	}
+	if tz := cfg.GetTimeZone(); tz != "" {
+		if w.loc, err = time.LoadLocation(tz); err != nil {
+			return nil, fmt.Errorf("invalid time zone %q: %v", tz, err)
+		}
+	}
+	for _, d := range cfg.GetDays() {
+		wd, ok := parseWeekday(d)
+		if !ok {
+			return nil, fmt.Errorf("invalid day %q", d)
+		}
+		if w.days == nil {
+			w.days = map[time.Weekday]bool{}
+		}
+		w.days[wd] = true
+	}
+	return w, nil
+}
+
+// parseTimeOfDay returns the minute of the day of a "HH:MM" time.
+func parseTimeOfDay(s string) (int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day %q, want HH:MM", s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// parseWeekday parses the full or three-letter name of a day, regardless of case.
+func parseWeekday(s string) (time.Weekday, bool) {
+	for wd := time.Sunday; wd <= time.Saturday; wd++ {
+		if strings.EqualFold(s, wd.String()) || strings.EqualFold(s, wd.String()[:3]) {
+			return wd, true
+		}
+	}
+	return 0, false
+}
+
+// opensOn reports whether the window opens on the day.
+func (w *window) opensOn(wd time.Weekday) bool {
+	return w.days == nil || w.days[wd]
+}
+
+// contains reports whether the window is open at t.
+func (w *window) contains(t time.Time) bool {
+	t = t.In(w.loc)
+	minute := t.Hour()*60 + t.Minute()
+	if w.start < w.end {
+		return w.opensOn(t.Weekday()) && minute >= w.start && minute < w.end
+	}
+	// The window closes the next day.
+	return (w.opensOn(t.Weekday()) && minute >= w.start) || (w.opensOn(t.AddDate(0, 0, -1).Weekday()) && minute < w.end)
+}
+
+// nextOpening returns when the window next opens after t.
+func (w *window) nextOpening(t time.Time) time.Time {
+	t = t.In(w.loc)
+	for d := 0; d <= 7; d++ {
+		day := t.AddDate(0, 0, d)
+		open := time.Date(day.Year(), day.Month(), day.Day(), w.start/60, w.start%60, 0, 0, w.loc)
+		if open.After(t) && w.opensOn(open.Weekday()) {
+			return open
+		}
+	}
+	// Unreachable, as windows open at least once a week.
+	return t.AddDate(0, 0, 7)
+}
+
+// checkWindows returns an error if the provisioning windows of the options or of a chassis are
+// invalid.
+func (m *InMemoryEntityManager) checkWindows(chassis []*epb.Chassis) error {
+	if _, err := parseWindows(m.defaults.GetProvisioningWindows()); err != nil {
+		return fmt.Errorf("options: %v", err)
+	}
+	for _, ch := range chassis {
+		if _, err := parseWindows(ch.GetProvisioningWindows()); err != nil {
+			return fmt.Errorf("chassis with serial# %s: %v", ch.GetSerialNumber(), err)
+		}
+	}
+	return nil
+}
+
+// checkProvisioningWindow returns an error asking the device to retry when the next provisioning
+// window of the chassis opens if none is open at t.
+func (m *InMemoryEntityManager) checkProvisioningWindow(chassis *epb.Chassis, t time.Time) error {
+	cfgs := chassis.GetProvisioningWindows()
+	if len(cfgs) == 0 {
+		cfgs = m.defaults.GetProvisioningWindows()
+	}
+	if len(cfgs) == 0 {
+		return nil
+	}
+	// The windows are validated when the inventory is loaded.
+	windows, err := parseWindows(cfgs)
+	if err != nil {
+		return err
+	}
+	var next time.Time
+	for _, w := range windows {
+		if w.contains(t) {
+			return nil
+		}
+		if open := w.nextOpening(t); next.IsZero() || open.Before(next) {
+			next = open
+		}
+	}
+	return service.RetryLater(next.Sub(t), "chassis %s is outside of its provisioning windows until %s", chassis.GetSerialNumber(), next.UTC().Format(time.RFC3339))
+}
@@ -0,0 +1,150 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entitymanager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/h-fam/errdiff"
+	"github.com/openconfig/bootz/server/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+)
+
+func TestParseWindow(t *testing.T) {
+	tests := []struct {
+		desc    string
+		cfg     *epb.ProvisioningWindow
+		wantErr string
+	}{{
+		desc: "Valid window",
+		cfg:  &epb.ProvisioningWindow{Days: []string{"Sat", "sunday"}, Start: "22:00", End: "06:00", TimeZone: "America/Los_Angeles"},
+	}, {
+		desc:    "Invalid day",
+		cfg:     &epb.ProvisioningWindow{Days: []string{"Someday"}, Start: "22:00", End: "06:00"},
+		wantErr: "invalid day",
+	}, {
+		desc:    "Missing start",
+		cfg:     &epb.ProvisioningWindow{End: "06:00"},
+		wantErr: "invalid time of day",
+	}, {
+		desc:    "Invalid end",
+		cfg:     &epb.ProvisioningWindow{Start: "22:00", End: "25:00"},
+		wantErr: "invalid time of day",
+	}, {
+		desc:    "Invalid time zone",
+		cfg:     &epb.ProvisioningWindow{Start: "22:00", End: "06:00", TimeZone: "Mars/Olympus_Mons"},
+		wantErr: "invalid time zone",
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			_, err := parseWindow(test.cfg)
+			if diff := errdiff.Substring(err, test.wantErr); diff != "" {
+				t.Errorf("parseWindow() %s", diff)
+			}
+		})
+	}
+}
+
+func TestCheckProvisioningWindow(t *testing.T) {
+	em, err := New("")
+	if err != nil {
+		t.Fatalf("New() err = %v, want nil", err)
+	}
+	em.defaults.ProvisioningWindows = []*epb.ProvisioningWindow{
+		// Weekday nights.
+		{Days: []string{"Mon", "Tue", "Wed", "Thu", "Fri"}, Start: "22:00", End: "06:00"},
+	}
+	weekend := &epb.Chassis{SerialNumber: "123", ProvisioningWindows: []*epb.ProvisioningWindow{
+		{Days: []string{"Saturday", "Sunday"}, Start: "00:00", End: "00:00"},
+	}}
+	// 2023-06-05 is a Monday.
+	monday := func(hour, minute int) time.Time { return time.Date(2023, 6, 5, hour, minute, 0, 0, time.UTC) }
+
+	tests := []struct {
+		desc      string
+		chassis   *epb.Chassis
+		t         time.Time
+		wantDelay time.Duration
+	}{{
+		desc: "Open before midnight",
+		t:    monday(23, 0),
+	}, {
+		desc: "Open after midnight",
+		t:    monday(23, 0).Add(6 * time.Hour),
+	}, {
+		desc:      "Closed during the day",
+		t:         monday(12, 0),
+		wantDelay: 10 * time.Hour,
+	}, {
+		desc:      "Closed at the end",
+		t:         monday(6, 0),
+		wantDelay: 16 * time.Hour,
+	}, {
+		desc: "Open on Saturday after a Friday night",
+		t:    monday(2, 0).AddDate(0, 0, 5),
+	}, {
+		desc:      "Closed on the weekend",
+		t:         monday(8, 0).AddDate(0, 0, 5),
+		wantDelay: 16*time.Hour + 24*time.Hour + 22*time.Hour,
+	}, {
+		desc:    "Chassis window",
+		chassis: weekend,
+		t:       monday(12, 0).AddDate(0, 0, -1),
+	}, {
+		desc:      "Chassis window replaces the options",
+		chassis:   weekend,
+		t:         monday(23, 0),
+		wantDelay: 4*24*time.Hour + time.Hour,
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			chassis := test.chassis
+			if chassis == nil {
+				chassis = &epb.Chassis{SerialNumber: "456"}
+			}
+			err := em.checkProvisioningWindow(chassis, test.t)
+			if test.wantDelay == 0 {
+				if err != nil {
+					t.Errorf("checkProvisioningWindow() err = %v, want nil", err)
+				}
+				return
+			}
+			if status.Code(err) != codes.Unavailable {
+				t.Fatalf("checkProvisioningWindow() err = %v, want code %v", err, codes.Unavailable)
+			}
+			if delay, _ := service.RetryDelay(err); delay != test.wantDelay {
+				t.Errorf("checkProvisioningWindow() retry delay = %v, want %v", delay, test.wantDelay)
+			}
+		})
+	}
+}
+
+func TestWindowTimeZone(t *testing.T) {
+	w, err := parseWindow(&epb.ProvisioningWindow{Start: "09:00", End: "17:00", TimeZone: "Asia/Tokyo"})
+	if err != nil {
+		t.Fatalf("parseWindow() err = %v, want nil", err)
+	}
+	// 09:30 in Tokyo.
+	if at := time.Date(2023, 6, 5, 0, 30, 0, 0, time.UTC); !w.contains(at) {
+		t.Errorf("contains(%v) = false, want true", at)
+	}
+	if at := time.Date(2023, 6, 5, 12, 0, 0, 0, time.UTC); w.contains(at) {
+		t.Errorf("contains(%v) = true, want false", at)
+	}
+}
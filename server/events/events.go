@@ -0,0 +1,139 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package events fans out the bootstrap status transitions of devices to
+// subscribers, such as operators' tooling streaming them from the Admin service.
+package events
+
+import (
+	"sync"
+	"time"
+
+	log "github.com/openconfig/bootz/server/logging"
+)
+
+// Stage is a step of the bootstrap process of a control card or fixed chassis.
+type Stage string
+
+const (
+	// Requested devices asked for their bootstrap data.
+	Requested Stage = "requested"
+	// Served devices were sent their bootstrap data.
+	Served Stage = "served"
+	// Rejected devices were refused their bootstrap data.
+	Rejected Stage = "rejected"
+	// Initiated devices reported applying their bootstrap data.
+	Initiated Stage = "initiated"
+	// Succeeded devices reported completing the bootstrap.
+	Succeeded Stage = "succeeded"
+	// Failed devices reported failing the bootstrap.
+	Failed Stage = "failed"
+)
+
+// Known reports whether s is a known stage.
+func Known(s Stage) bool {
+	switch s {
+	case Requested, Served, Rejected, Initiated, Succeeded, Failed:
+		return true
+	}
+	return false
+}
+
+// Event is a status transition of a control card, or of a fixed chassis.
+type Event struct {
+	// SerialNumber of the control card, or of the fixed chassis.
+	SerialNumber string
+	// ChassisSerialNumber is the serial number of the chassis, if known.
+	ChassisSerialNumber string
+	Manufacturer        string
+	Stage               Stage
+	// Message is the status message reported by the device, or the reason of a rejection.
+	Message string
+	Time    time.Time
+}
+
+// subscriptionBuffer is the number of events buffered for a subscriber before
+// further events are dropped.
+const subscriptionBuffer = 64
+
+// subscription receives the events of the serial numbers, or of every device if serials is empty.
+type subscription struct {
+	serials map[string]bool
+	ch      chan Event
+}
+
+func (s *subscription) matches(e Event) bool {
+	return len(s.serials) == 0 || s.serials[e.SerialNumber] || s.serials[e.ChassisSerialNumber]
+}
+
+// Broker publishes events to its subscribers. It is safe for concurrent use.
+type Broker struct {
+	mu   sync.Mutex
+	subs map[*subscription]bool
+	now  func() time.Time
+}
+
+// NewBroker returns a broker without subscribers.
+func NewBroker() *Broker {
+	return &Broker{
+		subs: map[*subscription]bool{},
+		now:  time.Now,
+	}
+}
+
+// Subscribe returns a channel receiving the events of the devices with the given control
+// card or chassis serial numbers, or of every device if none are given. Events are dropped
+// if the subscriber falls behind. The returned function cancels the subscription and
+// closes the channel.
+func (b *Broker) Subscribe(serials []string) (<-chan Event, func()) {
+	s := &subscription{
+		serials: map[string]bool{},
+		ch:      make(chan Event, subscriptionBuffer),
+	}
+	for _, serial := range serials {
+		s.serials[serial] = true
+	}
+	b.mu.Lock()
+	b.subs[s] = true
+	b.mu.Unlock()
+	var once sync.Once
+	return s.ch, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subs, s)
+			b.mu.Unlock()
+			close(s.ch)
+		})
+	}
+}
+
+// Publish sends the event to the matching subscribers without blocking. The time of the
+// event is set if unset.
+func (b *Broker) Publish(e Event) {
+	if e.Time.IsZero() {
+		e.Time = b.now()
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for s := range b.subs {
+		if !s.matches(e) {
+			continue
+		}
+		select {
+		case s.ch <- e:
+		default:
+			log.Warningf("Dropped %v event of serial# %v for a slow subscriber", e.Stage, e.SerialNumber)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSubscribe(t *testing.T) {
+	b := NewBroker()
+	now := time.Unix(0, 0)
+	b.now = func() time.Time { return now }
+
+	all, cancelAll := b.Subscribe(nil)
+	defer cancelAll()
+	filtered, cancelFiltered := b.Subscribe([]string{"123A", "456"})
+
+	published := []Event{
+		{SerialNumber: "123A", ChassisSerialNumber: "123", Stage: Requested},
+		{SerialNumber: "123B", ChassisSerialNumber: "123", Stage: Requested},
+		{SerialNumber: "456", Stage: Served},
+	}
+	for _, e := range published {
+		b.Publish(e)
+	}
+
+	tests := []struct {
+		desc string
+		ch   <-chan Event
+		want []string
+	}{{
+		desc: "All devices",
+		ch:   all,
+		want: []string{"123A", "123B", "456"},
+	}, {
+		desc: "Filtered by control card and chassis serial",
+		ch:   filtered,
+		want: []string{"123A", "456"},
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			for _, want := range test.want {
+				select {
+				case e := <-test.ch:
+					if e.SerialNumber != want {
+						t.Errorf("Subscribe() received serial# %v, want %v", e.SerialNumber, want)
+					}
+					if !e.Time.Equal(now) {
+						t.Errorf("Subscribe() received event at %v, want %v", e.Time, now)
+					}
+				default:
+					t.Fatalf("Subscribe() received no event, want serial# %v", want)
+				}
+			}
+			select {
+			case e := <-test.ch:
+				t.Errorf("Subscribe() received unexpected event %+v", e)
+			default:
+			}
+		})
+	}
+
+	cancelFiltered()
+	cancelFiltered()
+	b.Publish(Event{SerialNumber: "456", Stage: Succeeded})
+	if _, ok := <-filtered; ok {
+		t.Errorf("Subscribe() channel received an event after cancel, want it closed")
+	}
+}
+
+func TestPublishSlowSubscriber(t *testing.T) {
+	b := NewBroker()
+	ch, cancel := b.Subscribe(nil)
+	defer cancel()
+	for i := 0; i < subscriptionBuffer+10; i++ {
+		b.Publish(Event{SerialNumber: "123A", Stage: Initiated})
+	}
+	if got := len(ch); got != subscriptionBuffer {
+		t.Errorf("Publish() buffered %d events, want %d", got, subscriptionBuffer)
+	}
+}
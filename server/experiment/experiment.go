@@ -0,0 +1,236 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package experiment runs A/B experiments serving alternative artifacts to a
+// cohort of chassis, and compares the bootstrap outcomes of each arm.
+package experiment
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	bpb "github.com/openconfig/bootz/proto/bootz"
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+)
+
+// wildcard matches any manufacturer or part number.
+const wildcard = "*"
+
+// Arm is the variant of an experiment a chassis is assigned to.
+type Arm string
+
+const (
+	// Control chassis are served their inventory artifacts.
+	Control Arm = "control"
+	// Treatment chassis are served the artifacts of the experiment.
+	Treatment Arm = "treatment"
+)
+
+// ArmResult holds the outcomes of an experiment arm.
+type ArmResult struct {
+	Arm       Arm
+	Assigned  int
+	Succeeded int
+	Failed    int
+	// TotalDuration is the summed duration of all reported outcomes.
+	TotalDuration time.Duration
+}
+
+// SuccessRate returns the share of reported outcomes which are successes.
+func (r ArmResult) SuccessRate() float64 {
+	if r.Succeeded+r.Failed == 0 {
+		return 0
+	}
+	return float64(r.Succeeded) / float64(r.Succeeded+r.Failed)
+}
+
+// MeanDuration returns the mean time from the first bootstrap request to the reported outcome.
+func (r ArmResult) MeanDuration() time.Duration {
+	if r.Succeeded+r.Failed == 0 {
+		return 0
+	}
+	return r.TotalDuration / time.Duration(r.Succeeded+r.Failed)
+}
+
+// Result holds the outcomes of both arms of an experiment.
+type Result struct {
+	Experiment string
+	Control    ArmResult
+	Treatment  ArmResult
+}
+
+// assignment is a control card served the artifacts of an arm which has not reported its outcome yet.
+type assignment struct {
+	experiment string
+	arm        Arm
+	start      time.Time
+}
+
+// Tracker assigns chassis to experiment arms and records their outcomes. It is safe for concurrent use.
+type Tracker struct {
+	mu          sync.Mutex
+	experiments []*epb.Experiment
+	// pending assignments by control card serial.
+	pending map[string]*assignment
+	results map[string]*Result
+	now     func() time.Time
+}
+
+// New returns a tracker without experiments.
+func New() *Tracker {
+	return &Tracker{
+		pending: map[string]*assignment{},
+		results: map[string]*Result{},
+		now:     time.Now,
+	}
+}
+
+// Load adds the experiments from the inventory configuration.
+func (t *Tracker) Load(experiments []*epb.Experiment) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, e := range experiments {
+		if e.GetName() == "" {
+			return fmt.Errorf("experiment has no name")
+		}
+		if _, ok := t.results[e.GetName()]; ok {
+			return fmt.Errorf("experiment %q is defined more than once", e.GetName())
+		}
+		if e.GetTreatmentPercentage() > 100 {
+			return fmt.Errorf("experiment %q has a treatment percentage of %d, want at most 100", e.GetName(), e.GetTreatmentPercentage())
+		}
+		t.experiments = append(t.experiments, e)
+		t.results[e.GetName()] = &Result{
+			Experiment: e.GetName(),
+			Control:    ArmResult{Arm: Control},
+			Treatment:  ArmResult{Arm: Treatment},
+		}
+	}
+	return nil
+}
+
+// Experiments returns the configured experiments.
+func (t *Tracker) Experiments() []*epb.Experiment {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]*epb.Experiment(nil), t.experiments...)
+}
+
+// match returns the first experiment the chassis takes part in. The caller must hold t.mu.
+func (t *Tracker) match(ch *epb.Chassis) *epb.Experiment {
+	matches := func(entry, value string) bool {
+		return entry == wildcard || entry == value
+	}
+	for _, e := range t.experiments {
+		for _, d := range e.GetDevices() {
+			if matches(d.GetManufacturer(), ch.GetManufacturer()) && matches(d.GetPartNumber(), ch.GetPartNumber()) {
+				return e
+			}
+		}
+	}
+	return nil
+}
+
+// arm returns the arm of the experiment the chassis with the given serial is assigned to.
+func arm(e *epb.Experiment, chassisSerial string) Arm {
+	h := fnv.New32a()
+	h.Write([]byte(e.GetName() + "/" + chassisSerial))
+	if h.Sum32()%100 < e.GetTreatmentPercentage() {
+		return Treatment
+	}
+	return Control
+}
+
+// Apply assigns the chassis to an arm of the first experiment it matches and returns the chassis
+// entry to serve control card ccSerial from. Treatment chassis get a copy of the entry carrying the
+// artifacts of the experiment. Chassis without experiment are returned unchanged.
+func (t *Tracker) Apply(ch *epb.Chassis, chassisSerial, ccSerial string) *epb.Chassis {
+	t.mu.Lock()
+	e := t.match(ch)
+	if e == nil {
+		t.mu.Unlock()
+		return ch
+	}
+	a := arm(e, chassisSerial)
+	if p, ok := t.pending[ccSerial]; !ok || p.experiment != e.GetName() || p.arm != a {
+		t.pending[ccSerial] = &assignment{experiment: e.GetName(), arm: a, start: t.now()}
+		t.result(e.GetName(), a).Assigned++
+	}
+	t.mu.Unlock()
+	if a == Control {
+		return ch
+	}
+	return Treat(ch, e)
+}
+
+// Treat returns a copy of the chassis entry carrying the artifacts of the experiment.
+func Treat(ch *epb.Chassis, e *epb.Experiment) *epb.Chassis {
+	treated := proto.Clone(ch).(*epb.Chassis)
+	if e.GetSoftwareImage() != nil {
+		treated.SoftwareImage = e.GetSoftwareImage()
+	}
+	if e.GetConfig() != nil {
+		treated.Config = e.GetConfig()
+	}
+	return treated
+}
+
+// result returns the result of an arm. The caller must hold t.mu.
+func (t *Tracker) result(experiment string, a Arm) *ArmResult {
+	if a == Treatment {
+		return &t.results[experiment].Treatment
+	}
+	return &t.results[experiment].Control
+}
+
+// Record records the reported outcome for a control card. Reports of control cards without pending
+// assignment and reports which are neither a success nor a failure are ignored.
+func (t *Tracker) Record(ccSerial string, st bpb.ReportStatusRequest_BootstrapStatus) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	p, ok := t.pending[ccSerial]
+	if !ok {
+		return
+	}
+	r := t.result(p.experiment, p.arm)
+	switch st {
+	case bpb.ReportStatusRequest_BOOTSTRAP_STATUS_SUCCESS:
+		r.Succeeded++
+	case bpb.ReportStatusRequest_BOOTSTRAP_STATUS_FAILURE:
+		r.Failed++
+	default:
+		return
+	}
+	r.TotalDuration += t.now().Sub(p.start)
+	delete(t.pending, ccSerial)
+}
+
+// Results returns the results of all experiments sorted by name.
+func (t *Tracker) Results() []Result {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var out []Result
+	for _, r := range t.results {
+		out = append(out, *r)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Experiment < out[j].Experiment
+	})
+	return out
+}
@@ -0,0 +1,131 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package experiment
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	bpb "github.com/openconfig/bootz/proto/bootz"
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+)
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		desc        string
+		experiments []*epb.Experiment
+		wantErr     bool
+	}{{
+		desc:        "Valid experiments",
+		experiments: []*epb.Experiment{{Name: "a", TreatmentPercentage: 10}, {Name: "b", TreatmentPercentage: 100}},
+	}, {
+		desc:        "Missing name",
+		experiments: []*epb.Experiment{{TreatmentPercentage: 10}},
+		wantErr:     true,
+	}, {
+		desc:        "Duplicate name",
+		experiments: []*epb.Experiment{{Name: "a"}, {Name: "a"}},
+		wantErr:     true,
+	}, {
+		desc:        "Percentage out of range",
+		experiments: []*epb.Experiment{{Name: "a", TreatmentPercentage: 101}},
+		wantErr:     true,
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			if err := New().Load(test.experiments); (err != nil) != test.wantErr {
+				t.Errorf("Load() err = %v, want error %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	image := &bpb.SoftwareImage{Name: "New Image", Version: "2.0"}
+	tr := New()
+	if err := tr.Load([]*epb.Experiment{{
+		Name:                "new-image",
+		Devices:             []*epb.AllowedDevice{{Manufacturer: "Cisco", PartNumber: "*"}},
+		TreatmentPercentage: 50,
+		SoftwareImage:       image,
+	}}); err != nil {
+		t.Fatalf("Load() err = %v, want nil", err)
+	}
+	now := time.Unix(0, 0)
+	tr.now = func() time.Time { return now }
+
+	other := &epb.Chassis{Manufacturer: "Arista", SerialNumber: "A1"}
+	if got := tr.Apply(other, "A1", "A1"); got != other {
+		t.Errorf("Apply() of a chassis without experiment = %v, want the chassis unchanged", got)
+	}
+
+	old := &bpb.SoftwareImage{Name: "Old Image", Version: "1.0"}
+	for i := 0; i < 100; i++ {
+		serial := fmt.Sprint(i)
+		ch := &epb.Chassis{Manufacturer: "Cisco", SerialNumber: serial, SoftwareImage: old}
+		got := tr.Apply(ch, serial, serial)
+		treated := got.GetSoftwareImage() == image
+		if want := arm(tr.experiments[0], serial) == Treatment; treated != want {
+			t.Errorf("Apply() of chassis %s served the new image: %v, want %v", serial, treated, want)
+		}
+		if ch.GetSoftwareImage() != old {
+			t.Fatalf("Apply() modified the inventory entry of chassis %s", serial)
+		}
+		// Retries keep the assignment.
+		tr.Apply(ch, serial, serial)
+	}
+
+	now = now.Add(time.Minute)
+	for i := 0; i < 100; i++ {
+		st := bpb.ReportStatusRequest_BOOTSTRAP_STATUS_SUCCESS
+		if i%4 == 0 {
+			st = bpb.ReportStatusRequest_BOOTSTRAP_STATUS_FAILURE
+		}
+		tr.Record(fmt.Sprint(i), bpb.ReportStatusRequest_BOOTSTRAP_STATUS_INITIATED)
+		tr.Record(fmt.Sprint(i), st)
+	}
+	// Outcomes are only recorded once per assignment.
+	tr.Record("0", bpb.ReportStatusRequest_BOOTSTRAP_STATUS_SUCCESS)
+
+	results := tr.Results()
+	if len(results) != 1 {
+		t.Fatalf("Results() returned %d results, want 1", len(results))
+	}
+	c, tm := results[0].Control, results[0].Treatment
+	if c.Assigned+tm.Assigned != 100 || c.Assigned == 0 || tm.Assigned == 0 {
+		t.Errorf("Results() assigned %d control and %d treatment chassis, want 100 split across both arms", c.Assigned, tm.Assigned)
+	}
+	for _, r := range []ArmResult{c, tm} {
+		if r.Succeeded+r.Failed != r.Assigned {
+			t.Errorf("Results() %s arm has %d outcomes for %d assigned chassis", r.Arm, r.Succeeded+r.Failed, r.Assigned)
+		}
+		if r.MeanDuration() != time.Minute {
+			t.Errorf("Results() %s arm mean duration = %v, want %v", r.Arm, r.MeanDuration(), time.Minute)
+		}
+	}
+	if got := float64(c.Failed+tm.Failed) / 100; got != 0.25 {
+		t.Errorf("Results() failure rate across arms = %v, want 0.25", got)
+	}
+}
+
+func TestSuccessRate(t *testing.T) {
+	if got := (ArmResult{}).SuccessRate(); got != 0 {
+		t.Errorf("SuccessRate() without outcomes = %v, want 0", got)
+	}
+	if got := (ArmResult{Succeeded: 3, Failed: 1}).SuccessRate(); got != 0.75 {
+		t.Errorf("SuccessRate() = %v, want 0.75", got)
+	}
+}
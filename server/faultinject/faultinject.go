@@ -0,0 +1,238 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package faultinject injects artificial latency, dropped responses and
+// corrupted signatures in the responses of the bootstrap service, so device
+// vendors can test the error handling of their clients against the reference
+// server. It is for testing only.
+package faultinject
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+
+	bpb "github.com/openconfig/bootz/proto/bootz"
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+	log "github.com/openconfig/bootz/server/logging"
+)
+
+// bootstrapService prefixes the full method names of the service faults are injected in.
+const bootstrapService = "/bootz.proto.Bootstrap/"
+
+// Injector injects the configured faults in bootstrap requests. The zero value injects none.
+type Injector struct {
+	faults []*epb.FaultInjection_Fault
+	// sleep waits for the latency of a fault, returning early if ctx is done.
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// New returns an injector of the faults of cfg, which may be nil.
+func New(cfg *epb.FaultInjection) *Injector {
+	return &Injector{faults: cfg.GetFaults(), sleep: sleep}
+}
+
+// Load returns an injector of the faults of the text encoded entity.FaultInjection at path, or
+// an injector of no faults if path is empty.
+func Load(path string) (*Injector, error) {
+	if path == "" {
+		return New(nil), nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read fault injection config: %v", err)
+	}
+	cfg := &epb.FaultInjection{}
+	if err := prototext.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("unable to parse fault injection config %s: %v", path, err)
+	}
+	log.Warningf("Injecting %d faults from %s in bootstrap responses, do not use in production", len(cfg.GetFaults()), path)
+	return New(cfg), nil
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// requestSerials returns the serial numbers of the chassis and control cards a request is made for.
+func requestSerials(req any) []string {
+	var desc *bpb.ChassisDescriptor
+	var serials []string
+	switch r := req.(type) {
+	case *bpb.GetBootstrapDataRequest:
+		desc = r.GetChassisDescriptor()
+		serials = append(serials, r.GetControlCardState().GetSerialNumber())
+	case *bpb.ReportCapabilitiesRequest:
+		desc = r.GetChassisDescriptor()
+	case *bpb.ReportStatusRequest:
+		for _, s := range r.GetStates() {
+			serials = append(serials, s.GetSerialNumber())
+		}
+	}
+	serials = append(serials, desc.GetSerialNumber())
+	for _, cc := range desc.GetControlCards() {
+		serials = append(serials, cc.GetSerialNumber())
+	}
+	return serials
+}
+
+// match returns the first fault to inject in a request of the full method name, or nil.
+func (i *Injector) match(fullMethod string, req any) *epb.FaultInjection_Fault {
+	method := strings.TrimPrefix(fullMethod, bootstrapService)
+	if method == fullMethod {
+		return nil
+	}
+	serials := requestSerials(req)
+	for _, f := range i.faults {
+		if matches(f.GetMethods(), method) && matchesAny(f.GetSerialNumbers(), serials) {
+			return f
+		}
+	}
+	return nil
+}
+
+// matches reports whether v is in list, or list is empty.
+func matches(list []string, v string) bool {
+	if len(list) == 0 {
+		return true
+	}
+	for _, l := range list {
+		if l == v {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesAny reports whether any of the non-empty values is in list, or list is empty.
+func matchesAny(list, values []string) bool {
+	if len(list) == 0 {
+		return true
+	}
+	for _, v := range values {
+		if v != "" && matches(list, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// corruptSignature returns a copy of a GetBootstrapData response whose signature fails validation.
+// Other responses are returned as is.
+func corruptSignature(resp any) any {
+	r, ok := resp.(*bpb.GetBootstrapDataResponse)
+	if !ok || len(r.GetResponseSignature()) == 0 {
+		return resp
+	}
+	r = proto.Clone(r).(*bpb.GetBootstrapDataResponse)
+	// Flipping the first character keeps the signature base64 encoded.
+	sig := []byte(r.GetResponseSignature())
+	if sig[0] == 'A' {
+		sig[0] = 'B'
+	} else {
+		sig[0] = 'A'
+	}
+	r.ResponseSignature = string(sig)
+	return r
+}
+
+// drop blocks until the device gives up on a dropped response.
+func drop(ctx context.Context, method string) error {
+	<-ctx.Done()
+	log.Infof("Dropped the response of %v", method)
+	return status.FromContextError(ctx.Err()).Err()
+}
+
+// UnaryServerInterceptor injects the first fault matching each bootstrap request. Requests to
+// other services are left alone.
+func (i *Injector) UnaryServerInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	f := i.match(info.FullMethod, req)
+	if f == nil {
+		return handler(ctx, req)
+	}
+	if d := f.GetLatency().AsDuration(); d > 0 {
+		log.Infof("Delaying %v by %v", info.FullMethod, d)
+		if err := i.sleep(ctx, d); err != nil {
+			return nil, status.FromContextError(err).Err()
+		}
+	}
+	resp, err := handler(ctx, req)
+	if f.GetDropResponse() {
+		return nil, drop(ctx, info.FullMethod)
+	}
+	if err == nil && f.GetCorruptSignature() {
+		log.Infof("Corrupting the signature of the response of %v", info.FullMethod)
+		resp = corruptSignature(resp)
+	}
+	return resp, err
+}
+
+// StreamServerInterceptor injects the latency and dropped responses of the first fault matching
+// the request of bootstrap streams. Signatures of streamed responses are not corrupted.
+func (i *Injector) StreamServerInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if len(i.faults) == 0 || !strings.HasPrefix(info.FullMethod, bootstrapService) {
+		return handler(srv, ss)
+	}
+	s := &faultyStream{ServerStream: ss, injector: i, method: info.FullMethod}
+	err := handler(srv, s)
+	if s.fault.GetDropResponse() {
+		return drop(ss.Context(), info.FullMethod)
+	}
+	return err
+}
+
+// faultyStream injects the fault matching the first request received on a stream.
+type faultyStream struct {
+	grpc.ServerStream
+	injector *Injector
+	method   string
+	fault    *epb.FaultInjection_Fault
+}
+
+// RecvMsg receives a request, delaying the first one by the latency of its fault.
+func (s *faultyStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil || s.fault != nil {
+		return err
+	}
+	s.fault = s.injector.match(s.method, m)
+	if d := s.fault.GetLatency().AsDuration(); d > 0 {
+		log.Infof("Delaying %v by %v", s.method, d)
+		if err := s.injector.sleep(s.Context(), d); err != nil {
+			return status.FromContextError(err).Err()
+		}
+	}
+	return nil
+}
+
+// SendMsg sends a response, unless the fault of the stream drops it.
+func (s *faultyStream) SendMsg(m any) error {
+	if s.fault.GetDropResponse() {
+		return nil
+	}
+	return s.ServerStream.SendMsg(m)
+}
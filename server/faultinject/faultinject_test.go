@@ -0,0 +1,110 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package faultinject
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	bpb "github.com/openconfig/bootz/proto/bootz"
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+)
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	i := New(&epb.FaultInjection{Faults: []*epb.FaultInjection_Fault{{
+		SerialNumbers: []string{"123A"},
+		Methods:       []string{"GetBootstrapData"},
+		Latency:       durationpb.New(time.Minute),
+	}, {
+		SerialNumbers:    []string{"456"},
+		CorruptSignature: true,
+	}, {
+		SerialNumbers: []string{"789"},
+		DropResponse:  true,
+	}}})
+	var slept time.Duration
+	i.sleep = func(_ context.Context, d time.Duration) error {
+		slept += d
+		return nil
+	}
+	const signature = "c2lnbmF0dXJl"
+	handler := func(context.Context, any) (any, error) {
+		return &bpb.GetBootstrapDataResponse{ResponseSignature: signature}, nil
+	}
+
+	tests := []struct {
+		desc          string
+		method        string
+		req           proto.Message
+		wantLatency   time.Duration
+		wantCorrupted bool
+		wantCode      codes.Code
+	}{{
+		desc:        "Delayed control card",
+		method:      "/bootz.proto.Bootstrap/GetBootstrapData",
+		req:         &bpb.GetBootstrapDataRequest{ChassisDescriptor: &bpb.ChassisDescriptor{SerialNumber: "123"}, ControlCardState: &bpb.ControlCardState{SerialNumber: "123A"}},
+		wantLatency: time.Minute,
+	}, {
+		desc:   "Other method",
+		method: "/bootz.proto.Bootstrap/ReportStatus",
+		req:    &bpb.ReportStatusRequest{States: []*bpb.ControlCardState{{SerialNumber: "123A"}}},
+	}, {
+		desc:          "Corrupted signature",
+		method:        "/bootz.proto.Bootstrap/GetBootstrapData",
+		req:           &bpb.GetBootstrapDataRequest{ChassisDescriptor: &bpb.ChassisDescriptor{SerialNumber: "456"}},
+		wantCorrupted: true,
+	}, {
+		desc:     "Dropped response",
+		method:   "/bootz.proto.Bootstrap/GetBootstrapData",
+		req:      &bpb.GetBootstrapDataRequest{ChassisDescriptor: &bpb.ChassisDescriptor{SerialNumber: "789"}},
+		wantCode: codes.DeadlineExceeded,
+	}, {
+		desc:   "Other service",
+		method: "/bootz.proto.Admin/ListChassis",
+		req:    &bpb.GetBootstrapDataRequest{ChassisDescriptor: &bpb.ChassisDescriptor{SerialNumber: "789"}},
+	}, {
+		desc:   "Other device",
+		method: "/bootz.proto.Bootstrap/GetBootstrapData",
+		req:    &bpb.GetBootstrapDataRequest{ChassisDescriptor: &bpb.ChassisDescriptor{SerialNumber: "000"}},
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			slept = 0
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+			resp, err := i.UnaryServerInterceptor(ctx, test.req, &grpc.UnaryServerInfo{FullMethod: test.method}, handler)
+			if got := status.Code(err); got != test.wantCode {
+				t.Fatalf("UnaryServerInterceptor() err = %v, want code %v", err, test.wantCode)
+			}
+			if slept != test.wantLatency {
+				t.Errorf("UnaryServerInterceptor() slept %v, want %v", slept, test.wantLatency)
+			}
+			if err != nil {
+				return
+			}
+			sig := resp.(*bpb.GetBootstrapDataResponse).GetResponseSignature()
+			if corrupted := sig != signature; corrupted != test.wantCorrupted {
+				t.Errorf("UnaryServerInterceptor() returned signature %q, want corrupted %v", sig, test.wantCorrupted)
+			}
+		})
+	}
+}
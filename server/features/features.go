@@ -0,0 +1,199 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package features provides feature flags gating optional bootz server behaviors.
+//
+// A flag has a built-in default which can be overridden globally, per tenant,
+// per site, or for a tenant at a specific site. The most specific override wins.
+package features
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+)
+
+// Flag names a gated server behavior.
+type Flag string
+
+const (
+	// RequireSignedResponse rejects bootstrap requests without a nonce,
+	// even for chassis configured with an insecure boot mode.
+	RequireSignedResponse Flag = "require_signed_response"
+	// StrictNonce rejects nonces that are not base64 encoded or shorter than 128 bits.
+	StrictNonce Flag = "strict_nonce"
+	// ReplayProtection rejects bootstrap requests whose request counter does not exceed the last
+	// counter accepted from the control card, or which carry no counter.
+	ReplayProtection Flag = "replay_protection"
+	// RequireBootstrapToken rejects bootstrap requests without a valid one-time bootstrap token,
+	// including those of devices no token was issued for.
+	RequireBootstrapToken Flag = "require_bootstrap_token"
+)
+
+// defaults holds the built-in value of every known flag.
+var defaults = map[Flag]bool{
+	RequireSignedResponse: false,
+	StrictNonce:           false,
+	ReplayProtection:      false,
+	RequireBootstrapToken: false,
+}
+
+// Register adds a flag with its built-in default. It is intended to be called
+// from init functions of packages introducing new gated behaviors.
+func Register(f Flag, enabled bool) {
+	if _, ok := defaults[f]; ok {
+		panic(fmt.Sprintf("feature flag %q registered twice", f))
+	}
+	defaults[f] = enabled
+}
+
+// Known reports whether f is a registered flag.
+func Known(f Flag) bool {
+	_, ok := defaults[f]
+	return ok
+}
+
+// Scope selects the chassis a flag setting applies to. The zero value is the global scope.
+type Scope struct {
+	Tenant string
+	Site   string
+}
+
+// Setting is a flag value applied to a scope.
+type Setting struct {
+	Flag    Flag
+	Scope   Scope
+	Enabled bool
+}
+
+// Set holds the flag settings of a server. It is safe for concurrent use.
+type Set struct {
+	mu       sync.RWMutex
+	settings map[Scope]map[Flag]bool
+}
+
+// NewSet returns a Set with no overrides, so every flag has its built-in default.
+func NewSet() *Set {
+	return &Set{
+		settings: map[Scope]map[Flag]bool{},
+	}
+}
+
+// Enabled reports whether the flag is enabled for a chassis in the given scope.
+// Settings are looked up from the most to the least specific scope: tenant and
+// site, site only, tenant only and finally global.
+func (s *Set) Enabled(f Flag, scope Scope) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	candidates := []Scope{
+		scope,
+		{Site: scope.Site},
+		{Tenant: scope.Tenant},
+		{},
+	}
+	for _, c := range candidates {
+		if v, ok := s.settings[c][f]; ok {
+			return v
+		}
+	}
+	return defaults[f]
+}
+
+// Set applies the setting, replacing any existing value for the same flag and scope.
+func (s *Set) Set(setting Setting) error {
+	if !Known(setting.Flag) {
+		return fmt.Errorf("unknown feature flag %q", setting.Flag)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.settings[setting.Scope] == nil {
+		s.settings[setting.Scope] = map[Flag]bool{}
+	}
+	s.settings[setting.Scope][setting.Flag] = setting.Enabled
+	return nil
+}
+
+// Clear removes the setting of the flag for the scope.
+func (s *Set) Clear(f Flag, scope Scope) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.settings[scope], f)
+	if len(s.settings[scope]) == 0 {
+		delete(s.settings, scope)
+	}
+}
+
+// Settings returns the global value of every known flag followed by all scoped
+// overrides, sorted by flag and scope.
+func (s *Set) Settings() []Setting {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var out []Setting
+	for f, v := range defaults {
+		if gv, ok := s.settings[Scope{}][f]; ok {
+			v = gv
+		}
+		out = append(out, Setting{Flag: f, Enabled: v})
+	}
+	for scope, flags := range s.settings {
+		if scope == (Scope{}) {
+			continue
+		}
+		for f, v := range flags {
+			out = append(out, Setting{Flag: f, Scope: scope, Enabled: v})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		if a.Flag != b.Flag {
+			return a.Flag < b.Flag
+		}
+		if a.Scope.Tenant != b.Scope.Tenant {
+			return a.Scope.Tenant < b.Scope.Tenant
+		}
+		return a.Scope.Site < b.Scope.Site
+	})
+	return out
+}
+
+// Load applies the feature flag settings from the inventory configuration.
+func (s *Set) Load(flags []*epb.FeatureFlag) error {
+	for _, f := range flags {
+		if err := s.Set(FromProto(f)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FromProto converts a feature flag configuration message to a Setting.
+func FromProto(f *epb.FeatureFlag) Setting {
+	return Setting{
+		Flag:    Flag(f.GetName()),
+		Scope:   Scope{Tenant: f.GetTenant(), Site: f.GetSite()},
+		Enabled: f.GetEnabled(),
+	}
+}
+
+// ToProto converts a Setting to a feature flag configuration message.
+func ToProto(s Setting) *epb.FeatureFlag {
+	return &epb.FeatureFlag{
+		Name:    string(s.Flag),
+		Enabled: s.Enabled,
+		Tenant:  s.Scope.Tenant,
+		Site:    s.Scope.Site,
+	}
+}
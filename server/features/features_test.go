@@ -0,0 +1,83 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package features
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+)
+
+func TestEnabled(t *testing.T) {
+	s := NewSet()
+	err := s.Load([]*epb.FeatureFlag{
+		{Name: string(StrictNonce), Enabled: true},
+		{Name: string(StrictNonce), Enabled: false, Tenant: "lab"},
+		{Name: string(StrictNonce), Enabled: true, Site: "sjc"},
+		{Name: string(StrictNonce), Enabled: false, Tenant: "prod", Site: "sjc"},
+	})
+	if err != nil {
+		t.Fatalf("Load() err = %v, want nil", err)
+	}
+	tests := []struct {
+		desc  string
+		flag  Flag
+		scope Scope
+		want  bool
+	}{
+		{desc: "Built-in default", flag: RequireSignedResponse, scope: Scope{Tenant: "prod"}, want: false},
+		{desc: "Global", flag: StrictNonce, scope: Scope{Tenant: "prod", Site: "lhr"}, want: true},
+		{desc: "Tenant override", flag: StrictNonce, scope: Scope{Tenant: "lab", Site: "lhr"}, want: false},
+		{desc: "Site takes precedence over tenant", flag: StrictNonce, scope: Scope{Tenant: "lab", Site: "sjc"}, want: true},
+		{desc: "Tenant and site override", flag: StrictNonce, scope: Scope{Tenant: "prod", Site: "sjc"}, want: false},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			if got := s.Enabled(test.flag, test.scope); got != test.want {
+				t.Errorf("Enabled(%v, %+v) = %v, want %v", test.flag, test.scope, got, test.want)
+			}
+		})
+	}
+}
+
+func TestSetAndClear(t *testing.T) {
+	s := NewSet()
+	if err := s.Set(Setting{Flag: "no_such_flag", Enabled: true}); err == nil {
+		t.Errorf("Set() of unknown flag err = nil, want error")
+	}
+	lab := Scope{Tenant: "lab"}
+	if err := s.Set(Setting{Flag: RequireSignedResponse, Scope: lab, Enabled: true}); err != nil {
+		t.Fatalf("Set() err = %v, want nil", err)
+	}
+	want := []Setting{
+		{Flag: ReplayProtection},
+		{Flag: RequireBootstrapToken},
+		{Flag: RequireSignedResponse},
+		{Flag: RequireSignedResponse, Scope: lab, Enabled: true},
+		{Flag: StrictNonce},
+	}
+	if diff := cmp.Diff(want, s.Settings()); diff != "" {
+		t.Errorf("Settings() diff (-want, +got):\n%s", diff)
+	}
+	if !s.Enabled(RequireSignedResponse, lab) {
+		t.Errorf("Enabled(%v, %+v) = false, want true", RequireSignedResponse, lab)
+	}
+	s.Clear(RequireSignedResponse, lab)
+	if s.Enabled(RequireSignedResponse, lab) {
+		t.Errorf("Enabled(%v, %+v) after Clear() = true, want false", RequireSignedResponse, lab)
+	}
+}
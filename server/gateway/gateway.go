@@ -0,0 +1,231 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package gateway exposes the bootstrap service as JSON over HTTP, for lab
+// tooling and devices which can not speak gRPC.
+//
+// Requests and responses are the JSON mapping of the bootz protos:
+//
+//	POST /v1/bootstrap-data  GetBootstrapDataRequest -> GetBootstrapDataResponse
+//	POST /v1/status          ReportStatusRequest     -> EmptyResponse
+//
+// Errors carry the HTTP status equivalent to their gRPC code and a JSON body
+// holding the gRPC code, message and details. Errors asking the device to retry
+// later also carry their delay in a Retry-After header.
+//
+// Requests go through the same interceptors as gRPC calls of the service, with
+// the HTTP client as their peer and the HTTP headers as their metadata, so they
+// are authenticated, rate limited, logged and measured alike.
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	bpb "github.com/openconfig/bootz/proto/bootz"
+	log "github.com/openconfig/bootz/server/logging"
+)
+
+// maxRequestSize bounds the size of request bodies.
+const maxRequestSize = 1 << 20
+
+// The gRPC methods the requests of the gateway are served as.
+const (
+	getBootstrapDataMethod = "/bootz.proto.Bootstrap/GetBootstrapData"
+	reportStatusMethod     = "/bootz.proto.Bootstrap/ReportStatus"
+)
+
+// New returns a handler serving the bootstrap service s. Requests go through the interceptors in
+// order, as the server chaining them would.
+func New(s bpb.BootstrapServer, interceptors ...grpc.UnaryServerInterceptor) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/bootstrap-data", func(w http.ResponseWriter, r *http.Request) {
+		req := &bpb.GetBootstrapDataRequest{}
+		if !readRequest(w, r, req) {
+			return
+		}
+		resp, err := invoke(r, s, getBootstrapDataMethod, req, interceptors, func(ctx context.Context, req any) (any, error) {
+			return s.GetBootstrapData(ctx, req.(*bpb.GetBootstrapDataRequest))
+		})
+		writeResponse(w, resp, err)
+	})
+	mux.HandleFunc("/v1/status", func(w http.ResponseWriter, r *http.Request) {
+		req := &bpb.ReportStatusRequest{}
+		if !readRequest(w, r, req) {
+			return
+		}
+		resp, err := invoke(r, s, reportStatusMethod, req, interceptors, func(ctx context.Context, req any) (any, error) {
+			return s.ReportStatus(ctx, req.(*bpb.ReportStatusRequest))
+		})
+		writeResponse(w, resp, err)
+	})
+	return mux
+}
+
+// invoke calls handler with req as the gRPC method of the server, through the interceptors.
+func invoke(r *http.Request, srv any, method string, req proto.Message, interceptors []grpc.UnaryServerInterceptor, handler grpc.UnaryHandler) (proto.Message, error) {
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: method}
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, next := interceptors[i], handler
+		handler = func(ctx context.Context, req any) (any, error) {
+			return interceptor(ctx, req, info, next)
+		}
+	}
+	resp, err := handler(callContext(r), req)
+	if err != nil {
+		return nil, err
+	}
+	msg, ok := resp.(proto.Message)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "unexpected response of type %T", resp)
+	}
+	return msg, nil
+}
+
+// callContext returns the context of a request served as a gRPC call: the HTTP client is its peer,
+// with its TLS connection state if any, and the HTTP headers are its incoming metadata.
+func callContext(r *http.Request) context.Context {
+	md := metadata.MD{}
+	for k, v := range r.Header {
+		md.Append(strings.ToLower(k), v...)
+	}
+	ctx := metadata.NewIncomingContext(r.Context(), md)
+	p := &peer.Peer{}
+	if addr, err := net.ResolveTCPAddr("tcp", r.RemoteAddr); err == nil {
+		p.Addr = addr
+	}
+	if r.TLS != nil {
+		p.AuthInfo = credentials.TLSInfo{
+			State:          *r.TLS,
+			CommonAuthInfo: credentials.CommonAuthInfo{SecurityLevel: credentials.PrivacyAndIntegrity},
+		}
+	}
+	return peer.NewContext(ctx, p)
+}
+
+// readRequest decodes the JSON body of r into req. On failure the error is written to w.
+func readRequest(w http.ResponseWriter, r *http.Request, req proto.Message) bool {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeError(w, http.StatusMethodNotAllowed, status.Errorf(codes.Unimplemented, "method %s is not supported", r.Method))
+		return false
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestSize))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, status.Errorf(codes.InvalidArgument, "unable to read request: %v", err))
+		return false
+	}
+	if err := protojson.Unmarshal(body, req); err != nil {
+		writeError(w, http.StatusBadRequest, status.Errorf(codes.InvalidArgument, "unable to parse request: %v", err))
+		return false
+	}
+	return true
+}
+
+func writeResponse(w http.ResponseWriter, resp proto.Message, err error) {
+	if err != nil {
+		writeError(w, HTTPStatus(status.Code(err)), err)
+		return
+	}
+	data, err := protojson.Marshal(resp)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, status.Errorf(codes.Internal, "unable to encode response: %v", err))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if _, err := w.Write(data); err != nil {
+		log.Errorf("Unable to write HTTP response: %v", err)
+	}
+}
+
+// errorBody is the JSON body of an error response.
+type errorBody struct {
+	Code    codes.Code `json:"code"`
+	Message string     `json:"message"`
+	// Details holds the JSON mapping of the details of the gRPC status, e.g. a RetryInfo.
+	Details []json.RawMessage `json:"details,omitempty"`
+}
+
+func writeError(w http.ResponseWriter, httpStatus int, err error) {
+	st := status.Convert(err)
+	body := errorBody{Code: st.Code(), Message: st.Message()}
+	for _, d := range st.Proto().GetDetails() {
+		data, err := protojson.Marshal(d)
+		if err != nil {
+			log.Warningf("Unable to encode error detail %v: %v", d.GetTypeUrl(), err)
+			continue
+		}
+		body.Details = append(body.Details, data)
+	}
+	for _, d := range st.Details() {
+		if ri, ok := d.(*errdetails.RetryInfo); ok {
+			// Retry-After is in whole seconds, so round up to not retry early.
+			secs := math.Ceil(ri.GetRetryDelay().AsDuration().Seconds())
+			w.Header().Set("Retry-After", strconv.Itoa(int(secs)))
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(httpStatus)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		log.Errorf("Unable to write HTTP error response: %v", err)
+	}
+}
+
+// HTTPStatus returns the HTTP status code equivalent to a gRPC code.
+func HTTPStatus(c codes.Code) int {
+	switch c {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return 499
+	case codes.InvalidArgument, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.FailedPrecondition:
+		return http.StatusPreconditionFailed
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
+}
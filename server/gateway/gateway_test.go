@@ -0,0 +1,275 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	bpb "github.com/openconfig/bootz/proto/bootz"
+)
+
+type fakeBootstrap struct {
+	bpb.UnimplementedBootstrapServer
+	statuses []*bpb.ReportStatusRequest
+}
+
+func (f *fakeBootstrap) GetBootstrapData(ctx context.Context, req *bpb.GetBootstrapDataRequest) (*bpb.GetBootstrapDataResponse, error) {
+	if req.GetChassisDescriptor().GetSerialNumber() == "staging" {
+		st, err := status.New(codes.Unavailable, "artifacts are being staged").WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(1500 * time.Millisecond)})
+		if err != nil {
+			return nil, err
+		}
+		return nil, st.Err()
+	}
+	if req.GetChassisDescriptor().GetSerialNumber() != "123" {
+		return nil, status.Errorf(codes.NotFound, "unknown chassis %s", req.GetChassisDescriptor().GetSerialNumber())
+	}
+	return &bpb.GetBootstrapDataResponse{
+		SignedResponse: &bpb.BootstrapDataSigned{
+			Responses: []*bpb.BootstrapDataResponse{{SerialNum: "123"}},
+			Nonce:     req.GetNonce(),
+		},
+	}, nil
+}
+
+func (f *fakeBootstrap) ReportStatus(ctx context.Context, req *bpb.ReportStatusRequest) (*bpb.EmptyResponse, error) {
+	f.statuses = append(f.statuses, req)
+	return &bpb.EmptyResponse{}, nil
+}
+
+func TestGateway(t *testing.T) {
+	fake := &fakeBootstrap{}
+	srv := httptest.NewServer(New(fake))
+	defer srv.Close()
+
+	tests := []struct {
+		desc       string
+		method     string
+		path       string
+		body       string
+		wantStatus int
+		wantCode   codes.Code
+	}{{
+		desc:       "Get bootstrap data",
+		method:     http.MethodPost,
+		path:       "/v1/bootstrap-data",
+		body:       `{"chassisDescriptor": {"manufacturer": "Cisco", "serialNumber": "123"}, "nonce": "abc"}`,
+		wantStatus: http.StatusOK,
+	}, {
+		desc:       "Unknown chassis",
+		method:     http.MethodPost,
+		path:       "/v1/bootstrap-data",
+		body:       `{"chassisDescriptor": {"manufacturer": "Cisco", "serialNumber": "456"}}`,
+		wantStatus: http.StatusNotFound,
+		wantCode:   codes.NotFound,
+	}, {
+		desc:       "Invalid JSON",
+		method:     http.MethodPost,
+		path:       "/v1/bootstrap-data",
+		body:       `{"chassis": 1}`,
+		wantStatus: http.StatusBadRequest,
+		wantCode:   codes.InvalidArgument,
+	}, {
+		desc:       "Wrong method",
+		method:     http.MethodGet,
+		path:       "/v1/bootstrap-data",
+		wantStatus: http.StatusMethodNotAllowed,
+		wantCode:   codes.Unimplemented,
+	}, {
+		desc:       "Report status",
+		method:     http.MethodPost,
+		path:       "/v1/status",
+		body:       `{"status": "BOOTSTRAP_STATUS_SUCCESS", "states": [{"serialNumber": "123"}]}`,
+		wantStatus: http.StatusOK,
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			req, err := http.NewRequest(test.method, srv.URL+test.path, strings.NewReader(test.body))
+			if err != nil {
+				t.Fatalf("unable to create request: %v", err)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("%s %s err = %v, want nil", test.method, test.path, err)
+			}
+			defer resp.Body.Close()
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("unable to read response: %v", err)
+			}
+			if resp.StatusCode != test.wantStatus {
+				t.Fatalf("%s %s status = %d, want %d: %s", test.method, test.path, resp.StatusCode, test.wantStatus, body)
+			}
+			if test.wantStatus != http.StatusOK {
+				var e errorBody
+				if err := json.Unmarshal(body, &e); err != nil {
+					t.Fatalf("unable to parse error body %s: %v", body, err)
+				}
+				if e.Code != test.wantCode {
+					t.Errorf("%s %s error code = %v, want %v", test.method, test.path, e.Code, test.wantCode)
+				}
+			}
+		})
+	}
+
+	resp, err := http.Post(srv.URL+"/v1/bootstrap-data", "application/json",
+		strings.NewReader(`{"chassisDescriptor": {"serialNumber": "123"}, "nonce": "abc"}`))
+	if err != nil {
+		t.Fatalf("POST err = %v, want nil", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("unable to read response: %v", err)
+	}
+	got := &bpb.GetBootstrapDataResponse{}
+	if err := protojson.Unmarshal(body, got); err != nil {
+		t.Fatalf("unable to parse response %s: %v", body, err)
+	}
+	if got.GetSignedResponse().GetNonce() != "abc" {
+		t.Errorf("GetBootstrapData() nonce = %q, want %q", got.GetSignedResponse().GetNonce(), "abc")
+	}
+	if len(fake.statuses) != 1 || fake.statuses[0].GetStatus() != bpb.ReportStatusRequest_BOOTSTRAP_STATUS_SUCCESS {
+		t.Errorf("ReportStatus() received %v, want a single success report", fake.statuses)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	srv := httptest.NewServer(New(&fakeBootstrap{}))
+	defer srv.Close()
+	resp, err := http.Post(srv.URL+"/v1/bootstrap-data", "application/json",
+		strings.NewReader(`{"chassisDescriptor": {"serialNumber": "staging"}}`))
+	if err != nil {
+		t.Fatalf("POST err = %v, want nil", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("POST status = %d, want %d", resp.StatusCode, http.StatusServiceUnavailable)
+	}
+	if got := resp.Header.Get("Retry-After"); got != "2" {
+		t.Errorf("POST Retry-After = %q, want %q", got, "2")
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("unable to read response: %v", err)
+	}
+	var e errorBody
+	if err := json.Unmarshal(body, &e); err != nil {
+		t.Fatalf("unable to parse error body %s: %v", body, err)
+	}
+	if len(e.Details) != 1 {
+		t.Fatalf("error details = %s, want a RetryInfo", body)
+	}
+	var detail struct {
+		Type       string `json:"@type"`
+		RetryDelay string `json:"retryDelay"`
+	}
+	if err := json.Unmarshal(e.Details[0], &detail); err != nil {
+		t.Fatalf("unable to parse error detail %s: %v", e.Details[0], err)
+	}
+	if want := "type.googleapis.com/google.rpc.RetryInfo"; detail.Type != want || detail.RetryDelay != "1.500s" {
+		t.Errorf("error detail = %+v, want a %s with a delay of 1.500s", detail, want)
+	}
+}
+
+func TestInterceptors(t *testing.T) {
+	var calls []string
+	record := func(name string) grpc.UnaryServerInterceptor {
+		return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			host := ""
+			if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+				host, _, _ = net.SplitHostPort(p.Addr.String())
+			}
+			calls = append(calls, fmt.Sprintf("%s %s from %s", name, info.FullMethod, host))
+			return handler(ctx, req)
+		}
+	}
+	authenticate := func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		if v := md.Get("x-token"); len(v) == 0 || v[0] != "secret" {
+			return nil, status.Errorf(codes.Unauthenticated, "invalid x-token header")
+		}
+		return handler(ctx, req)
+	}
+	fake := &fakeBootstrap{}
+	srv := httptest.NewServer(New(fake, record("first"), record("second"), authenticate))
+	defer srv.Close()
+
+	tests := []struct {
+		desc       string
+		token      string
+		wantStatus int
+	}{{
+		desc:       "Authenticated",
+		token:      "secret",
+		wantStatus: http.StatusOK,
+	}, {
+		desc:       "Unauthenticated",
+		token:      "guess",
+		wantStatus: http.StatusUnauthorized,
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			calls = nil
+			fake.statuses = nil
+			req, err := http.NewRequest(http.MethodPost, srv.URL+"/v1/status", strings.NewReader(`{"status": "BOOTSTRAP_STATUS_SUCCESS"}`))
+			if err != nil {
+				t.Fatalf("unable to create request: %v", err)
+			}
+			req.Header.Set("X-Token", test.token)
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("POST err = %v, want nil", err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != test.wantStatus {
+				t.Errorf("POST status = %d, want %d", resp.StatusCode, test.wantStatus)
+			}
+			want := []string{
+				"first /bootz.proto.Bootstrap/ReportStatus from 127.0.0.1",
+				"second /bootz.proto.Bootstrap/ReportStatus from 127.0.0.1",
+			}
+			if diff := cmp.Diff(want, calls); diff != "" {
+				t.Errorf("interceptor calls diff (-want +got):\n%s", diff)
+			}
+			wantReports := 0
+			if test.wantStatus == http.StatusOK {
+				wantReports = 1
+			}
+			if got := len(fake.statuses); got != wantReports {
+				t.Errorf("ReportStatus() called %d times, want %d", got, wantReports)
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package imageserver serves software images to bootstrapping devices only: image URLs handed out
+// in bootstrap data are signed with an HMAC key and expire, and the image server rejects requests
+// whose URL it did not sign.
+package imageserver
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/openconfig/bootz/server/logging"
+)
+
+const (
+	// PathPrefix is the path images are served under.
+	PathPrefix = "/images/"
+	// expiresParam is the query parameter holding the expiry of a signed URL, in seconds since the
+	// Unix epoch.
+	expiresParam = "expires"
+	// tokenParam is the query parameter holding the HMAC of a signed URL.
+	tokenParam = "token"
+	// minKeySize is the minimum size of HMAC keys, in bytes.
+	minKeySize = 32
+)
+
+// NewKey returns a random HMAC key.
+func NewKey() ([]byte, error) {
+	key := make([]byte, minKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// Signer signs the URLs of the images of an image server and verifies them.
+type Signer struct {
+	key []byte
+	// prefix is the URL prefix of the images of the image server.
+	prefix string
+	ttl    time.Duration
+	now    func() time.Time
+}
+
+// NewSigner returns a signer of the URLs of the images served at baseURL, e.g.
+// https://bootz.example.com:15007, which are valid for ttl.
+func NewSigner(key []byte, baseURL string, ttl time.Duration) (*Signer, error) {
+	if len(key) < minKeySize {
+		return nil, fmt.Errorf("image URL key has %d bytes, want at least %d", len(key), minKeySize)
+	}
+	u, err := url.Parse(baseURL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid image server URL %q", baseURL)
+	}
+	if ttl <= 0 {
+		return nil, fmt.Errorf("image URL lifetime must be positive, got %v", ttl)
+	}
+	return &Signer{
+		key:    key,
+		prefix: strings.TrimSuffix(baseURL, "/") + PathPrefix,
+		ttl:    ttl,
+		now:    time.Now,
+	}, nil
+}
+
+// token returns the HMAC of the image name and expiry.
+func (s *Signer) token(name string, expires int64) string {
+	mac := hmac.New(sha256.New, s.key)
+	fmt.Fprintf(mac, "%s\n%d", name, expires)
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// SignURL returns the URL of an image of the image server signed until the lifetime of signed
+// URLs elapses. It returns false for URLs of other servers, which are left as is.
+func (s *Signer) SignURL(u string) (string, bool) {
+	name, ok := strings.CutPrefix(u, s.prefix)
+	if !ok || name == "" || strings.Contains(name, "?") {
+		return u, false
+	}
+	expires := s.now().Add(s.ttl).Unix()
+	q := url.Values{}
+	q.Set(expiresParam, strconv.FormatInt(expires, 10))
+	q.Set(tokenParam, s.token(name, expires))
+	return u + "?" + q.Encode(), true
+}
+
+// verify checks that the query of the request of the image carries a valid, unexpired signature.
+func (s *Signer) verify(name string, query url.Values) error {
+	expires, err := strconv.ParseInt(query.Get(expiresParam), 10, 64)
+	if err != nil {
+		return fmt.Errorf("URL is not signed")
+	}
+	if !hmac.Equal([]byte(query.Get(tokenParam)), []byte(s.token(name, expires))) {
+		return fmt.Errorf("URL signature is invalid")
+	}
+	if s.now().Unix() > expires {
+		return fmt.Errorf("URL expired at %v", time.Unix(expires, 0).UTC())
+	}
+	return nil
+}
+
+// Handler returns an HTTP handler serving the images of the file system at the URLs signed by s.
+func (s *Signer) Handler(images fs.FS) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		name, ok := strings.CutPrefix(r.URL.Path, PathPrefix)
+		if !ok || !fs.ValidPath(name) {
+			http.NotFound(w, r)
+			return
+		}
+		if err := s.verify(name, r.URL.Query()); err != nil {
+			log.Warningf("Refused image %s to %s: %v", name, r.RemoteAddr, err)
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		f, err := images.Open(name)
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		defer f.Close()
+		info, err := f.Stat()
+		if err != nil || info.IsDir() {
+			http.NotFound(w, r)
+			return
+		}
+		content, ok := f.(io.ReadSeeker)
+		if !ok {
+			http.Error(w, "image is not seekable", http.StatusInternalServerError)
+			return
+		}
+		log.Infof("Serving image %s to %s", name, r.RemoteAddr)
+		http.ServeContent(w, r, name, info.ModTime(), content)
+	})
+}
@@ -0,0 +1,152 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package imageserver
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func TestHandler(t *testing.T) {
+	key, err := NewKey()
+	if err != nil {
+		t.Fatalf("NewKey() err = %v", err)
+	}
+	const base = "https://bootz.example.com:15007"
+	s, err := NewSigner(key, base, time.Hour)
+	if err != nil {
+		t.Fatalf("NewSigner() err = %v, want nil", err)
+	}
+	now := time.Unix(1700000000, 0)
+	s.now = func() time.Time { return now }
+	images := fstest.MapFS{
+		"ios/9.1.bin": {Data: []byte("image 9.1")},
+		"ios/9.2.bin": {Data: []byte("image 9.2")},
+	}
+	srv := httptest.NewServer(s.Handler(images))
+	defer srv.Close()
+
+	sign := func(name string) string {
+		signed, ok := s.SignURL(base + PathPrefix + name)
+		if !ok {
+			t.Fatalf("SignURL() of %s = false, want true", name)
+		}
+		return strings.TrimPrefix(signed, base)
+	}
+	valid := sign("ios/9.1.bin")
+	tests := []struct {
+		desc       string
+		method     string
+		path       string
+		elapsed    time.Duration
+		wantStatus int
+		wantBody   string
+	}{{
+		desc:       "Signed URL",
+		path:       valid,
+		wantStatus: http.StatusOK,
+		wantBody:   "image 9.1",
+	}, {
+		desc:       "Expired URL",
+		path:       valid,
+		elapsed:    2 * time.Hour,
+		wantStatus: http.StatusForbidden,
+		wantBody:   "URL expired",
+	}, {
+		desc:       "Signature of another image",
+		path:       strings.Replace(valid, "9.1", "9.2", 1),
+		wantStatus: http.StatusForbidden,
+		wantBody:   "signature is invalid",
+	}, {
+		desc:       "Extended expiry",
+		path:       strings.Replace(valid, "expires=", "expires=9", 1),
+		wantStatus: http.StatusForbidden,
+		wantBody:   "signature is invalid",
+	}, {
+		desc:       "Unsigned URL",
+		path:       PathPrefix + "ios/9.1.bin",
+		wantStatus: http.StatusForbidden,
+		wantBody:   "not signed",
+	}, {
+		desc:       "Missing image",
+		path:       sign("ios/9.3.bin"),
+		wantStatus: http.StatusNotFound,
+	}, {
+		desc:       "Directory",
+		path:       sign("ios"),
+		wantStatus: http.StatusNotFound,
+	}, {
+		desc:       "Outside of the images",
+		path:       "/other/ios/9.1.bin",
+		wantStatus: http.StatusNotFound,
+	}, {
+		desc:       "Upload",
+		method:     http.MethodPut,
+		path:       valid,
+		wantStatus: http.StatusMethodNotAllowed,
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s.now = func() time.Time { return now.Add(test.elapsed) }
+			method := test.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			req, err := http.NewRequest(method, srv.URL+test.path, nil)
+			if err != nil {
+				t.Fatalf("http.NewRequest() err = %v", err)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("http.Do() err = %v", err)
+			}
+			defer resp.Body.Close()
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("unable to read response: %v", err)
+			}
+			if resp.StatusCode != test.wantStatus {
+				t.Errorf("status = %d, want %d, body %q", resp.StatusCode, test.wantStatus, body)
+			}
+			if !strings.Contains(string(body), test.wantBody) {
+				t.Errorf("body = %q, want it to contain %q", body, test.wantBody)
+			}
+		})
+	}
+}
+
+func TestSignURL(t *testing.T) {
+	s, err := NewSigner(make([]byte, minKeySize), "https://bootz.example.com:15007/", time.Hour)
+	if err != nil {
+		t.Fatalf("NewSigner() err = %v, want nil", err)
+	}
+	for _, u := range []string{
+		"https://mirror.example.com/images/ios/9.1.bin",
+		"https://bootz.example.com:15007/ios/9.1.bin",
+		"https://bootz.example.com:15007/images/",
+	} {
+		if got, ok := s.SignURL(u); ok || got != u {
+			t.Errorf("SignURL(%q) = %q, %v, want the URL unsigned", u, got, ok)
+		}
+	}
+	if _, err := NewSigner([]byte("short"), "https://bootz.example.com", time.Hour); err == nil {
+		t.Errorf("NewSigner() with a short key err = nil, want error")
+	}
+}
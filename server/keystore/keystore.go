@@ -0,0 +1,116 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package keystore resolves key URIs to crypto.Signers, so that the OC and PDC
+// private keys can be kept in an external key store such as HashiCorp Vault, a
+// cloud KMS or a PKCS #11 HSM. The server only ever asks the signer to sign and
+// never handles the key material.
+//
+// Key stores are registered by URI scheme. The file scheme, reading a PEM
+// encoded private key from disk, is built in. Other key stores are added by
+// registering an Opener, typically wrapping the crypto.Signer provided by the
+// client library of the key store:
+//
+//	keystore.Register("vault", func(uri string) (crypto.Signer, error) {
+//		return newVaultSigner(vaultClient, uri)
+//	})
+package keystore
+
+import (
+	"crypto"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net/url"
+	"os"
+	"sync"
+)
+
+// Opener returns a signer for the key identified by uri.
+type Opener func(uri string) (crypto.Signer, error)
+
+var (
+	mu      sync.RWMutex
+	openers = map[string]Opener{
+		"file": openFile,
+	}
+)
+
+// Register makes a key store available for the URI scheme. Registering a scheme twice
+// replaces the previous key store.
+func Register(scheme string, o Opener) {
+	mu.Lock()
+	defer mu.Unlock()
+	openers[scheme] = o
+}
+
+// Open returns a signer for the key identified by uri, e.g. file:///etc/bootz/oc_priv.pem.
+func Open(uri string) (crypto.Signer, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key URI %q: %v", uri, err)
+	}
+	mu.RLock()
+	o, ok := openers[u.Scheme]
+	mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("no key store registered for scheme %q of key URI %q", u.Scheme, uri)
+	}
+	s, err := o(uri)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open key %q: %v", uri, err)
+	}
+	return s, nil
+}
+
+// ParsePrivateKey parses a PEM encoded PKCS #1, PKCS #8 or SEC 1 private key.
+func ParsePrivateKey(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("unable to decode private key")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse private key: %v", err)
+	}
+	s, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key type: %T", key)
+	}
+	return s, nil
+}
+
+// openFile reads a PEM encoded private key from the path of a file URI.
+func openFile(uri string) (crypto.Signer, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return nil, err
+	}
+	path := u.Path
+	if u.Opaque != "" {
+		// Relative paths, e.g. file:keys/oc_priv.pem.
+		path = u.Opaque
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePrivateKey(data)
+}
@@ -0,0 +1,117 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keystore
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeKey(t *testing.T, blockType string, der []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "key.pem")
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0600); err != nil {
+		t.Fatalf("unable to write key: %v", err)
+	}
+	return path
+}
+
+func TestOpen(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("unable to generate RSA key: %v", err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unable to generate ECDSA key: %v", err)
+	}
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(rsaKey)
+	if err != nil {
+		t.Fatalf("unable to marshal key: %v", err)
+	}
+	sec1, err := x509.MarshalECPrivateKey(ecKey)
+	if err != nil {
+		t.Fatalf("unable to marshal key: %v", err)
+	}
+	Register("test", func(uri string) (crypto.Signer, error) {
+		if uri != "test://key/1" {
+			return nil, fmt.Errorf("key not found")
+		}
+		return rsaKey, nil
+	})
+
+	tests := []struct {
+		desc    string
+		uri     string
+		want    crypto.PublicKey
+		wantErr bool
+	}{{
+		desc: "PKCS #1 file",
+		uri:  "file://" + writeKey(t, "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaKey)),
+		want: rsaKey.Public(),
+	}, {
+		desc: "PKCS #8 file",
+		uri:  "file://" + writeKey(t, "PRIVATE KEY", pkcs8),
+		want: rsaKey.Public(),
+	}, {
+		desc: "SEC 1 file",
+		uri:  "file://" + writeKey(t, "EC PRIVATE KEY", sec1),
+		want: ecKey.Public(),
+	}, {
+		desc:    "Missing file",
+		uri:     "file:///does/not/exist.pem",
+		wantErr: true,
+	}, {
+		desc:    "Not a key",
+		uri:     "file://" + writeKey(t, "CERTIFICATE", []byte("cert")),
+		wantErr: true,
+	}, {
+		desc: "Registered key store",
+		uri:  "test://key/1",
+		want: rsaKey.Public(),
+	}, {
+		desc:    "Registered key store without the key",
+		uri:     "test://key/2",
+		wantErr: true,
+	}, {
+		desc:    "Unregistered scheme",
+		uri:     "pkcs11:token=bootz",
+		wantErr: true,
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			got, err := Open(test.uri)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("Open(%q) err = %v, want error %v", test.uri, err, test.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			pub, ok := got.Public().(interface{ Equal(crypto.PublicKey) bool })
+			if !ok || !pub.Equal(test.want) {
+				t.Errorf("Open(%q) returned a signer for public key %v, want %v", test.uri, got.Public(), test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package kube runs the bootz server as a Kubernetes workload, reloading the
+// inventory and security artifacts it reads from mounted ConfigMaps and
+// Secrets when they are updated, without restarting the pod.
+package kube
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	log "github.com/openconfig/bootz/server/logging"
+)
+
+// settle is how long Watch waits for the events of an update to stop before reloading.
+var settle = time.Second
+
+// Watch calls reload once the files of the directories change, until ctx is done. Kubernetes
+// updates the files of mounted ConfigMaps and Secrets at once by swapping the ..data symlink of
+// the mount, so the directories are watched rather than the files, and the events of an update
+// are coalesced into a single reload.
+func Watch(ctx context.Context, dirs []string, reload func()) error {
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("unable to watch mounts: %v", err)
+	}
+	for _, d := range dirs {
+		if err := w.Add(d); err != nil {
+			w.Close()
+			return fmt.Errorf("unable to watch %s: %v", d, err)
+		}
+	}
+	go func() {
+		defer w.Close()
+		timer := time.NewTimer(settle)
+		timer.Stop()
+		defer timer.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case ev, ok := <-w.Events:
+				if !ok {
+					return
+				}
+				if ev.Op == fsnotify.Chmod {
+					continue
+				}
+				timer.Reset(settle)
+			case err, ok := <-w.Errors:
+				if !ok {
+					return
+				}
+				log.Warningf("Error watching mounts: %v", err)
+			case <-timer.C:
+				reload()
+			}
+		}
+	}()
+	log.Infof("Reloading on changes of %v", dirs)
+	return nil
+}
+
+// Certificate is a TLS certificate which can be replaced while it is served, e.g. after the
+// Secret it is read from is updated. It is safe for concurrent use.
+type Certificate struct {
+	cert atomic.Pointer[tls.Certificate]
+}
+
+// NewCertificate returns a certificate serving cert until it is replaced.
+func NewCertificate(cert *tls.Certificate) *Certificate {
+	c := &Certificate{}
+	c.Set(cert)
+	return c
+}
+
+// Set replaces the certificate for new handshakes.
+func (c *Certificate) Set(cert *tls.Certificate) {
+	c.cert.Store(cert)
+}
+
+// GetCertificate implements tls.Config.GetCertificate.
+func (c *Certificate) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	return c.cert.Load(), nil
+}
+
+// Serve makes cfg present the certificate, instead of its own certificates.
+func (c *Certificate) Serve(cfg *tls.Config) {
+	// tls.Config ignores GetCertificate if Certificates is set and clients send no SNI.
+	cfg.Certificates = nil
+	cfg.GetCertificate = c.GetCertificate
+}
@@ -0,0 +1,107 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kube
+
+import (
+	"context"
+	"crypto/tls"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// update replaces the files of a mount the way the kubelet does: the new files are written to a
+// timestamped directory, and the ..data symlink is swapped to it.
+func update(t *testing.T, mount, name, content string) {
+	t.Helper()
+	dir, err := os.MkdirTemp(mount, "..data_")
+	if err != nil {
+		t.Fatalf("os.MkdirTemp() err = %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0600); err != nil {
+		t.Fatalf("os.WriteFile() err = %v", err)
+	}
+	tmp := filepath.Join(mount, "..data_tmp")
+	if err := os.Symlink(filepath.Base(dir), tmp); err != nil {
+		t.Fatalf("os.Symlink() err = %v", err)
+	}
+	if err := os.Rename(tmp, filepath.Join(mount, "..data")); err != nil {
+		t.Fatalf("os.Rename() err = %v", err)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	settle = 50 * time.Millisecond
+	mount := t.TempDir()
+	update(t, mount, "inventory.prototxt", "v1")
+	if err := os.Symlink("..data/inventory.prototxt", filepath.Join(mount, "inventory.prototxt")); err != nil {
+		t.Fatalf("os.Symlink() err = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	reloads := make(chan string, 10)
+	err := Watch(ctx, []string{mount}, func() {
+		data, _ := os.ReadFile(filepath.Join(mount, "inventory.prototxt"))
+		reloads <- string(data)
+	})
+	if err != nil {
+		t.Fatalf("Watch() err = %v", err)
+	}
+	update(t, mount, "inventory.prototxt", "v2")
+	select {
+	case got := <-reloads:
+		if got != "v2" {
+			t.Errorf("reload read %q, want %q", got, "v2")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Watch() did not reload after the mount was updated")
+	}
+	select {
+	case <-reloads:
+		t.Errorf("Watch() reloaded more than once for a single update")
+	case <-time.After(4 * settle):
+	}
+
+	if err := Watch(ctx, []string{filepath.Join(mount, "missing")}, func() {}); err == nil {
+		t.Errorf("Watch() of missing directory err = nil, want error")
+	}
+}
+
+func TestCertificate(t *testing.T) {
+	first, err := tls.LoadX509KeyPair("../../testdata/pdc_pub.pem", "../../testdata/pdc_priv.pem")
+	if err != nil {
+		t.Fatalf("tls.LoadX509KeyPair() err = %v", err)
+	}
+	second, err := tls.LoadX509KeyPair("../../testdata/oc_pub.pem", "../../testdata/oc_priv.pem")
+	if err != nil {
+		t.Fatalf("tls.LoadX509KeyPair() err = %v", err)
+	}
+	c := NewCertificate(&first)
+	cfg := &tls.Config{Certificates: []tls.Certificate{first}}
+	c.Serve(cfg)
+	if len(cfg.Certificates) != 0 {
+		t.Errorf("Serve() kept %d certificates, want 0", len(cfg.Certificates))
+	}
+	c.Set(&second)
+	got, err := cfg.GetCertificate(&tls.ClientHelloInfo{})
+	if err != nil {
+		t.Fatalf("GetCertificate() err = %v", err)
+	}
+	if got != &second {
+		t.Errorf("GetCertificate() did not return the replaced certificate")
+	}
+}
@@ -0,0 +1,408 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package logging logs the messages of the bootz server through glog, or as structured text or
+// JSON records of log/slog which log pipelines such as ELK or Loki ingest as is, with a level per
+// module. The module of a message is the name of the package logging it, e.g. entitymanager.
+// Its functions mirror those of glog, so the packages of the server import it as log.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	glog "github.com/golang/glog"
+)
+
+// Formats of the log output.
+const (
+	// FormatGlog logs through glog, configured with its own flags.
+	FormatGlog = "glog"
+	// FormatText logs a line of key=value pairs per message to stderr.
+	FormatText = "text"
+	// FormatJSON logs a JSON object per message to stderr.
+	FormatJSON = "json"
+)
+
+// Level is the verbosity of the messages logged with V, as with glog. Messages of verbosity n are
+// logged by the modules at level vn or below, debug being v4.
+type Level int32
+
+// Verbose reports whether messages of a verbosity are logged, as with glog.
+type Verbose bool
+
+// levels are the levels of the modules. They are replaced rather than modified.
+type levels struct {
+	def     slog.Level
+	modules map[string]slog.Level
+}
+
+// level returns the level of a module.
+func (l *levels) level(module string) slog.Level {
+	if lvl, ok := l.modules[module]; ok {
+		return lvl
+	}
+	return l.def
+}
+
+// logger logs the messages of every module. It is safe for concurrent use.
+type logger struct {
+	levels atomic.Pointer[levels]
+	// handler handles the records in the text and JSON formats. Messages are logged through glog
+	// if it is nil.
+	handler atomic.Pointer[slog.Handler]
+	// modules caches the module of the program counters of the call sites.
+	modules sync.Map
+}
+
+func newLogger() *logger {
+	l := &logger{}
+	l.levels.Store(&levels{def: slog.LevelInfo})
+	return l
+}
+
+// std is the logger of the server.
+var std = newLogger()
+
+// ParseLevel parses a level: debug, info, warn, error, or vn for the verbosity n.
+func ParseLevel(s string) (slog.Level, error) {
+	switch strings.ToLower(s) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "info":
+		return slog.LevelInfo, nil
+	case "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	}
+	if v, ok := strings.CutPrefix(strings.ToLower(s), "v"); ok {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+			return Level(n).slogLevel(), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown log level %q, want debug, info, warn, error or vN", s)
+}
+
+// LevelName returns the name of a level, as parsed by ParseLevel.
+func LevelName(lvl slog.Level) string {
+	switch lvl {
+	case slog.LevelDebug:
+		return "debug"
+	case slog.LevelInfo:
+		return "info"
+	case slog.LevelWarn:
+		return "warn"
+	case slog.LevelError:
+		return "error"
+	}
+	if lvl < slog.LevelInfo {
+		return "v" + strconv.Itoa(int(slog.LevelInfo-lvl))
+	}
+	return lvl.String()
+}
+
+// slogLevel returns the slog level of messages of the verbosity.
+func (v Level) slogLevel() slog.Level {
+	return slog.LevelInfo - slog.Level(v)
+}
+
+// parseLevels parses a comma separated list of levels, each optionally prefixed with a module and
+// =, e.g. "info,service=debug". Levels without a module set the default level.
+func parseLevels(spec string) (*levels, error) {
+	l := &levels{def: slog.LevelInfo, modules: map[string]slog.Level{}}
+	for _, s := range strings.Split(spec, ",") {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+		module, name, ok := strings.Cut(s, "=")
+		if !ok {
+			module, name = "", s
+		}
+		lvl, err := ParseLevel(strings.TrimSpace(name))
+		if err != nil {
+			return nil, err
+		}
+		if module = strings.TrimSpace(module); module == "" {
+			l.def = lvl
+		} else {
+			l.modules[module] = lvl
+		}
+	}
+	return l, nil
+}
+
+// Configure sets the format of the log output and the levels of the modules, as a comma
+// separated list of levels optionally prefixed with a module and =, e.g. "info,service=debug".
+func Configure(format, spec string) error {
+	return std.configure(os.Stderr, format, spec)
+}
+
+func (l *logger) configure(w io.Writer, format, spec string) error {
+	lvls, err := parseLevels(spec)
+	if err != nil {
+		return err
+	}
+	// The levels are applied before records reach the handler.
+	opts := &slog.HandlerOptions{
+		AddSource: true,
+		Level:     slog.Level(-1 << 20),
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.LevelKey && len(groups) == 0 {
+				if lvl, ok := a.Value.Any().(slog.Level); ok {
+					a.Value = slog.StringValue(LevelName(lvl))
+				}
+			}
+			return a
+		},
+	}
+	var h slog.Handler
+	switch format {
+	case FormatGlog, "":
+	case FormatText:
+		h = slog.NewTextHandler(w, opts)
+	case FormatJSON:
+		h = slog.NewJSONHandler(w, opts)
+	default:
+		return fmt.Errorf("unknown log format %q, want %s, %s or %s", format, FormatGlog, FormatText, FormatJSON)
+	}
+	l.levels.Store(lvls)
+	if h == nil {
+		l.handler.Store(nil)
+	} else {
+		l.handler.Store(&h)
+	}
+	return nil
+}
+
+// Levels returns the default level and the levels set for modules, by module.
+func Levels() (string, map[string]string) {
+	return std.getLevels()
+}
+
+func (l *logger) getLevels() (string, map[string]string) {
+	lvls := l.levels.Load()
+	modules := map[string]string{}
+	for m, lvl := range lvls.modules {
+		modules[m] = LevelName(lvl)
+	}
+	return LevelName(lvls.def), modules
+}
+
+// SetLevel sets the level of a module, or the default level if module is empty. The level of the
+// module is cleared if level is empty, so that it logs at the default level.
+func SetLevel(module, level string) error {
+	return std.setLevel(module, level)
+}
+
+func (l *logger) setLevel(module, level string) error {
+	var lvl slog.Level
+	if level != "" || module == "" {
+		var err error
+		if lvl, err = ParseLevel(level); err != nil {
+			return err
+		}
+	}
+	for {
+		old := l.levels.Load()
+		lvls := &levels{def: old.def, modules: map[string]slog.Level{}}
+		for m, v := range old.modules {
+			lvls.modules[m] = v
+		}
+		switch {
+		case module == "":
+			lvls.def = lvl
+		case level == "":
+			delete(lvls.modules, module)
+		default:
+			lvls.modules[module] = lvl
+		}
+		if l.levels.CompareAndSwap(old, lvls) {
+			return nil
+		}
+	}
+}
+
+// Modules returns the modules which logged or checked their verbosity so far, sorted.
+func Modules() []string {
+	return std.seenModules()
+}
+
+func (l *logger) seenModules() []string {
+	seen := map[string]bool{}
+	l.modules.Range(func(_, m any) bool {
+		seen[m.(string)] = true
+		return true
+	})
+	var modules []string
+	for m := range seen {
+		modules = append(modules, m)
+	}
+	sort.Strings(modules)
+	return modules
+}
+
+// caller returns the program counter and module of the function depth frames above the caller
+// of the function calling caller.
+func (l *logger) caller(depth int) (uintptr, string) {
+	var pcs [1]uintptr
+	if runtime.Callers(depth+3, pcs[:]) == 0 {
+		return 0, ""
+	}
+	if m, ok := l.modules.Load(pcs[0]); ok {
+		return pcs[0], m.(string)
+	}
+	frame, _ := runtime.CallersFrames(pcs[:]).Next()
+	module := packageName(frame.Function)
+	l.modules.Store(pcs[0], module)
+	return pcs[0], module
+}
+
+// packageName returns the name of the package of a fully qualified function name, e.g.
+// entitymanager for github.com/openconfig/bootz/server/entitymanager.(*InMemoryEntityManager).Sign.
+func packageName(function string) string {
+	name := function[strings.LastIndex(function, "/")+1:]
+	if i := strings.Index(name, "."); i >= 0 {
+		name = name[:i]
+	}
+	return name
+}
+
+// log logs a message of the function depth frames above the caller of log.
+func (l *logger) log(depth int, lvl slog.Level, msg func() string) {
+	pc, module := l.caller(depth)
+	if lvl < l.levels.Load().level(module) {
+		return
+	}
+	h := l.handler.Load()
+	if h == nil {
+		switch {
+		case lvl >= slog.LevelError:
+			glog.ErrorDepth(depth+1, msg())
+		case lvl >= slog.LevelWarn:
+			glog.WarningDepth(depth+1, msg())
+		default:
+			glog.InfoDepth(depth+1, msg())
+		}
+		return
+	}
+	r := slog.NewRecord(time.Now(), lvl, msg(), pc)
+	r.AddAttrs(slog.String("module", module))
+	(*h).Handle(context.Background(), r)
+}
+
+// exit logs a message of the function depth frames above the caller of exit regardless of the
+// levels, and exits the program with status 1.
+func (l *logger) exit(depth int, msg string) {
+	h := l.handler.Load()
+	if h == nil {
+		glog.ExitDepth(depth+1, msg)
+		return
+	}
+	pc, module := l.caller(depth)
+	r := slog.NewRecord(time.Now(), slog.LevelError, msg, pc)
+	r.AddAttrs(slog.String("module", module))
+	(*h).Handle(context.Background(), r)
+	os.Exit(1)
+}
+
+// v reports whether the function depth frames above the caller of v logs messages of the
+// verbosity. In the glog format, the -v and -vmodule flags of glog enable them too.
+func (l *logger) v(depth int, level Level) Verbose {
+	_, module := l.caller(depth)
+	if level.slogLevel() >= l.levels.Load().level(module) {
+		return true
+	}
+	return Verbose(l.handler.Load() == nil && bool(glog.VDepth(depth+1, glog.Level(level))))
+}
+
+// sprint formats args as fmt.Sprint, lazily.
+func sprint(args []any) func() string {
+	return func() string { return fmt.Sprint(args...) }
+}
+
+// sprintf formats args as fmt.Sprintf, lazily.
+func sprintf(format string, args []any) func() string {
+	return func() string { return fmt.Sprintf(format, args...) }
+}
+
+// V reports whether the module of the caller logs messages of the verbosity.
+func V(level Level) Verbose {
+	return std.v(1, level)
+}
+
+// Info logs a message of the verbosity, formatted as fmt.Sprint, if v is true.
+func (v Verbose) Info(args ...any) {
+	if v {
+		std.log(1, slog.LevelInfo, sprint(args))
+	}
+}
+
+// Infof logs a message of the verbosity, formatted as fmt.Sprintf, if v is true.
+func (v Verbose) Infof(format string, args ...any) {
+	if v {
+		std.log(1, slog.LevelInfo, sprintf(format, args))
+	}
+}
+
+// Info logs a message at level info, formatted as fmt.Sprint.
+func Info(args ...any) {
+	std.log(1, slog.LevelInfo, sprint(args))
+}
+
+// Infof logs a message at level info, formatted as fmt.Sprintf.
+func Infof(format string, args ...any) {
+	std.log(1, slog.LevelInfo, sprintf(format, args))
+}
+
+// Warning logs a message at level warn, formatted as fmt.Sprint.
+func Warning(args ...any) {
+	std.log(1, slog.LevelWarn, sprint(args))
+}
+
+// Warningf logs a message at level warn, formatted as fmt.Sprintf.
+func Warningf(format string, args ...any) {
+	std.log(1, slog.LevelWarn, sprintf(format, args))
+}
+
+// Error logs a message at level error, formatted as fmt.Sprint.
+func Error(args ...any) {
+	std.log(1, slog.LevelError, sprint(args))
+}
+
+// Errorf logs a message at level error, formatted as fmt.Sprintf.
+func Errorf(format string, args ...any) {
+	std.log(1, slog.LevelError, sprintf(format, args))
+}
+
+// Exit logs a message, formatted as fmt.Sprint, and exits with status 1.
+func Exit(args ...any) {
+	std.exit(1, fmt.Sprint(args...))
+}
+
+// Exitf logs a message, formatted as fmt.Sprintf, and exits with status 1.
+func Exitf(format string, args ...any) {
+	std.exit(1, fmt.Sprintf(format, args...))
+}
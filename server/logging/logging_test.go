@@ -0,0 +1,193 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/h-fam/errdiff"
+)
+
+func TestParseLevel(t *testing.T) {
+	tests := []struct {
+		desc     string
+		level    string
+		want     slog.Level
+		wantName string
+		wantErr  string
+	}{{
+		desc:     "Debug",
+		level:    "DEBUG",
+		want:     slog.LevelDebug,
+		wantName: "debug",
+	}, {
+		desc:     "Warning",
+		level:    "warning",
+		want:     slog.LevelWarn,
+		wantName: "warn",
+	}, {
+		desc:     "Verbosity",
+		level:    "v2",
+		want:     slog.LevelInfo - 2,
+		wantName: "v2",
+	}, {
+		desc:     "Verbosity of debug",
+		level:    "v4",
+		want:     slog.LevelDebug,
+		wantName: "debug",
+	}, {
+		desc:    "Unknown level",
+		level:   "verbose",
+		wantErr: "unknown log level",
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			got, err := ParseLevel(test.level)
+			if diff := errdiff.Substring(err, test.wantErr); diff != "" {
+				t.Fatalf("ParseLevel(%q) %s", test.level, diff)
+			}
+			if err != nil {
+				return
+			}
+			if got != test.want {
+				t.Errorf("ParseLevel(%q) = %v, want %v", test.level, got, test.want)
+			}
+			if name := LevelName(got); name != test.wantName {
+				t.Errorf("LevelName(%v) = %q, want %q", got, name, test.wantName)
+			}
+		})
+	}
+}
+
+// record is a JSON log record.
+type record struct {
+	Level  string `json:"level"`
+	Msg    string `json:"msg"`
+	Module string `json:"module"`
+	Source struct {
+		File string `json:"file"`
+	} `json:"source"`
+}
+
+func TestJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := std.configure(&buf, FormatJSON, "warn,logging=info"); err != nil {
+		t.Fatalf("configure() err = %v", err)
+	}
+	defer std.configure(&buf, FormatGlog, "")
+
+	Infof("served %d devices", 2)
+	if V(1) {
+		t.Errorf("V(1) = true at level info, want false")
+	}
+	V(1).Infof("not logged")
+	if err := SetLevel("logging", "v1"); err != nil {
+		t.Fatalf("SetLevel() err = %v", err)
+	}
+	V(1).Infof("verbose")
+	if V(2) {
+		t.Errorf("V(2) = true at level v1, want false")
+	}
+	if err := SetLevel("logging", ""); err != nil {
+		t.Fatalf("SetLevel() err = %v", err)
+	}
+	// The module now logs at the default level.
+	Info("dropped")
+	Warning("slow request")
+	Errorf("failed: %v", "timeout")
+
+	var got []record
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		r := record{}
+		if err := json.Unmarshal([]byte(line), &r); err != nil {
+			t.Fatalf("json.Unmarshal(%q) err = %v", line, err)
+		}
+		if !strings.HasSuffix(r.Source.File, "logging_test.go") {
+			t.Errorf("record %q source = %q, want logging_test.go", r.Msg, r.Source.File)
+		}
+		r.Source.File = ""
+		got = append(got, r)
+	}
+	want := []record{
+		{Level: "info", Msg: "served 2 devices", Module: "logging"},
+		{Level: "info", Msg: "verbose", Module: "logging"},
+		{Level: "warn", Msg: "slow request", Module: "logging"},
+		{Level: "error", Msg: "failed: timeout", Module: "logging"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("logged records diff (-want +got):\n%s", diff)
+	}
+
+	def, modules := Levels()
+	if def != "warn" || len(modules) != 0 {
+		t.Errorf("Levels() = %q, %v, want warn, no module levels", def, modules)
+	}
+	if diff := cmp.Diff([]string{"logging"}, Modules()); diff != "" {
+		t.Errorf("Modules() diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestConfigure(t *testing.T) {
+	tests := []struct {
+		desc        string
+		format      string
+		spec        string
+		wantDefault string
+		wantModules map[string]string
+		wantErr     string
+	}{{
+		desc:        "Default level",
+		wantDefault: "info",
+		wantModules: map[string]string{},
+	}, {
+		desc:        "Module levels",
+		format:      FormatText,
+		spec:        "warn, service=debug,admin=v1",
+		wantDefault: "warn",
+		wantModules: map[string]string{"service": "debug", "admin": "v1"},
+	}, {
+		desc:    "Unknown format",
+		format:  "xml",
+		wantErr: "unknown log format",
+	}, {
+		desc:    "Unknown level",
+		spec:    "service=loud",
+		wantErr: "unknown log level",
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			l := newLogger()
+			err := l.configure(&bytes.Buffer{}, test.format, test.spec)
+			if diff := errdiff.Substring(err, test.wantErr); diff != "" {
+				t.Fatalf("configure() %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			def, modules := l.getLevels()
+			if def != test.wantDefault {
+				t.Errorf("default level = %q, want %q", def, test.wantDefault)
+			}
+			if diff := cmp.Diff(test.wantModules, modules); diff != "" {
+				t.Errorf("module levels diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
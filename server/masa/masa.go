@@ -0,0 +1,168 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package masa requests ownership vouchers from the MASA (Manufacturer Authorized Signing
+// Authority) of a vendor with the registrar voucher request of RFC 8995 (BRSKI), for vendors
+// issuing vouchers online rather than by file transfer.
+package masa
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/openconfig/bootz/common/cms"
+	ownershipvoucher "github.com/openconfig/bootz/common/ownership_voucher"
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+	"github.com/openconfig/bootz/server/service"
+)
+
+const (
+	// RequestVoucherPath is the path of the voucher request endpoint of RFC 8995.
+	RequestVoucherPath = "/.well-known/brski/requestvoucher"
+	// VoucherMediaType is the media type of CMS signed vouchers of RFC 8366, and of the CMS
+	// signed voucher requests of RFC 8995.
+	VoucherMediaType = "application/voucher-cms+json"
+	// voucherRequestContainer is the name of the voucher request container of RFC 8995.
+	voucherRequestContainer = "ietf-voucher-request:voucher"
+	// maxResponseSize bounds the size of the responses of the MASA.
+	maxResponseSize = 1 << 20
+	// timeout bounds each request to the MASA.
+	timeout = 30 * time.Second
+)
+
+// VoucherRequest holds the leaves of a registrar voucher request.
+type VoucherRequest struct {
+	CreatedOn    string `json:"created-on"`
+	SerialNumber string `json:"serial-number"`
+}
+
+// Client requests ownership vouchers from a MASA.
+type Client struct {
+	url           string
+	manufacturers map[string]bool
+	client        *http.Client
+}
+
+// New returns a client of the MASA of src.
+func New(src *epb.MasaSource) (*Client, error) {
+	u, err := url.Parse(src.GetUrl())
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid MASA URL %q", src.GetUrl())
+	}
+	c := &Client{
+		url:           strings.TrimSuffix(src.GetUrl(), "/") + RequestVoucherPath,
+		manufacturers: map[string]bool{},
+		client:        &http.Client{Timeout: timeout},
+	}
+	for _, m := range src.GetManufacturers() {
+		c.manufacturers[m] = true
+	}
+	if f := src.GetCaCertFile(); f != "" {
+		caPEM, err := os.ReadFile(f)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read MASA CA cert: %v", err)
+		}
+		roots := x509.NewCertPool()
+		if !roots.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("unable to parse MASA CA cert %s", f)
+		}
+		c.client.Transport = &http.Transport{TLSClientConfig: &tls.Config{RootCAs: roots}}
+	}
+	return c, nil
+}
+
+// Issues reports whether vouchers of chassis of the manufacturer are requested from the MASA.
+func (c *Client) Issues(manufacturer string) bool {
+	return len(c.manufacturers) == 0 || c.manufacturers[manufacturer]
+}
+
+// RequestVoucher requests the ownership voucher of the control card or fixed chassis with the
+// serial number, signing the voucher request with the registrar key pair, whose certificate the
+// MASA pins in the voucher. It returns the DER encoded voucher, which is not verified.
+func (c *Client) RequestVoucher(ctx context.Context, serial string, registrar *service.KeyPair) ([]byte, error) {
+	chain, err := registrar.CertChain()
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse registrar certificate: %v", err)
+	}
+	key, err := registrar.PrivateKeySigner()
+	if err != nil {
+		return nil, fmt.Errorf("unable to load registrar key: %v", err)
+	}
+	content, err := json.Marshal(map[string]*VoucherRequest{voucherRequestContainer: {
+		CreatedOn:    time.Now().UTC().Format(time.RFC3339),
+		SerialNumber: serial,
+	}})
+	if err != nil {
+		return nil, err
+	}
+	signed, err := cms.Sign(content, chain, key, false)
+	if err != nil {
+		return nil, fmt.Errorf("unable to sign voucher request: %v", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(signed))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", VoucherMediaType)
+	req.Header.Set("Accept", VoucherMediaType)
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("unable to request voucher of serial# %s: %v", serial, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read voucher of serial# %s: %v", serial, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("MASA refused voucher of serial# %s: %s: %s", serial, resp.Status, bytes.TrimSpace(body))
+	}
+	ov, err := ownershipvoucher.Parse(body)
+	if err != nil {
+		return nil, fmt.Errorf("MASA returned an invalid voucher for serial# %s: %v", serial, err)
+	}
+	if ov.OV.SerialNumber != serial {
+		return nil, fmt.Errorf("MASA returned a voucher of serial# %s for serial# %s", ov.OV.SerialNumber, serial)
+	}
+	return body, nil
+}
+
+// ParseVoucherRequest verifies the signature of a CMS signed voucher request and returns its
+// leaves and the certificate of the registrar which signed it. The certificate chain of the
+// registrar is not validated.
+func ParseVoucherRequest(signed []byte) (*VoucherRequest, *x509.Certificate, error) {
+	sd, err := cms.Verify(signed, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to verify voucher request: %v", err)
+	}
+	content := map[string]*VoucherRequest{}
+	if err := json.Unmarshal(sd.Content, &content); err != nil {
+		return nil, nil, fmt.Errorf("unable to parse voucher request: %v", err)
+	}
+	vr := content[voucherRequestContainer]
+	if vr == nil || vr.SerialNumber == "" {
+		return nil, nil, fmt.Errorf("voucher request has no serial number")
+	}
+	return vr, sd.Signer, nil
+}
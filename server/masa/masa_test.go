@@ -0,0 +1,159 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package masa
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/h-fam/errdiff"
+
+	ownershipvoucher "github.com/openconfig/bootz/common/ownership_voucher"
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+	"github.com/openconfig/bootz/server/keystore"
+	"github.com/openconfig/bootz/server/service"
+)
+
+func readFile(t *testing.T, name string) []byte {
+	t.Helper()
+	b, err := os.ReadFile(name)
+	if err != nil {
+		t.Fatalf("unable to read %s: %v", name, err)
+	}
+	return b
+}
+
+func TestRequestVoucher(t *testing.T) {
+	block, _ := pem.Decode(readFile(t, "../../testdata/vendorca_pub.pem"))
+	vendorCA, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		t.Fatalf("unable to parse vendor CA: %v", err)
+	}
+	signer, err := keystore.ParsePrivateKey(readFile(t, "../../testdata/vendorca_priv.pem"))
+	if err != nil {
+		t.Fatalf("unable to parse vendor CA key: %v", err)
+	}
+	vendorCAKey := signer.(*rsa.PrivateKey)
+	registrar := &service.KeyPair{
+		Cert:       string(readFile(t, "../../testdata/pdc_pub.pem")),
+		PrivateKey: string(readFile(t, "../../testdata/pdc_priv.pem")),
+	}
+
+	// The fake MASA issues vouchers of 123A and 123B, but the voucher of 123B is for 123A.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != RequestVoucherPath || r.Header.Get("Content-Type") != VoucherMediaType {
+			http.Error(w, "unexpected request", http.StatusBadRequest)
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("unable to read voucher request: %v", err)
+		}
+		vr, pdc, err := ParseVoucherRequest(body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		serial := vr.SerialNumber
+		switch serial {
+		case "123A":
+		case "123B":
+			serial = "123A"
+		case "garbage":
+			w.Write([]byte("not a voucher"))
+			return
+		default:
+			http.Error(w, "unknown serial number", http.StatusForbidden)
+			return
+		}
+		ov, err := ownershipvoucher.New(serial, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: pdc.Raw}), vendorCA, vendorCAKey)
+		if err != nil {
+			t.Errorf("ownershipvoucher.New() err = %v", err)
+		}
+		w.Header().Set("Content-Type", VoucherMediaType)
+		w.Write(ov)
+	}))
+	defer srv.Close()
+
+	c, err := New(&epb.MasaSource{Url: srv.URL + "/"})
+	if err != nil {
+		t.Fatalf("New() err = %v, want nil", err)
+	}
+	tests := []struct {
+		desc    string
+		serial  string
+		wantErr string
+	}{{
+		desc:   "Issued voucher",
+		serial: "123A",
+	}, {
+		desc:    "Refused serial number",
+		serial:  "999Z",
+		wantErr: "MASA refused voucher of serial# 999Z: 403 Forbidden: unknown serial number",
+	}, {
+		desc:    "Voucher of another serial number",
+		serial:  "123B",
+		wantErr: "MASA returned a voucher of serial# 123A for serial# 123B",
+	}, {
+		desc:    "Invalid voucher",
+		serial:  "garbage",
+		wantErr: "MASA returned an invalid voucher",
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			ov, err := c.RequestVoucher(context.Background(), test.serial, registrar)
+			if s := errdiff.Substring(err, test.wantErr); s != "" {
+				t.Fatalf("RequestVoucher() %s", s)
+			}
+			if err != nil {
+				return
+			}
+			pool := x509.NewCertPool()
+			pool.AddCert(vendorCA)
+			block, _ := pem.Decode([]byte(registrar.Cert))
+			pdc, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				t.Fatalf("unable to parse registrar certificate: %v", err)
+			}
+			if _, err := ownershipvoucher.VerifyAndUnmarshalWithOptions(ov, ownershipvoucher.VerifyOptions{
+				TrustAnchors:     pool,
+				PinnedDomainCert: pdc,
+				PDCMatch:         ownershipvoucher.PDCMatchExact,
+			}); err != nil {
+				t.Errorf("VerifyAndUnmarshalWithOptions() err = %v, want the voucher to pin the registrar certificate", err)
+			}
+		})
+	}
+}
+
+func TestIssues(t *testing.T) {
+	c, err := New(&epb.MasaSource{Url: "https://masa.example.com", Manufacturers: []string{"Cisco"}})
+	if err != nil {
+		t.Fatalf("New() err = %v, want nil", err)
+	}
+	if !c.Issues("Cisco") || c.Issues("Arista") {
+		t.Errorf("Issues() of Cisco, Arista = %v, %v, want true, false", c.Issues("Cisco"), c.Issues("Arista"))
+	}
+	if _, err := New(&epb.MasaSource{Url: "masa.example.com"}); err == nil {
+		t.Errorf("New() of a URL without scheme err = nil, want error")
+	}
+}
@@ -0,0 +1,243 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metrics counts the RPCs of the server and measures their latencies
+// by method, manufacturer of the requesting device and result code, and
+// exposes them in the Prometheus text format.
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+
+	bpb "github.com/openconfig/bootz/proto/bootz"
+)
+
+const (
+	// maxManufacturers bounds the number of distinct manufacturers labeling the metrics, as the
+	// manufacturer is reported by the devices. Further manufacturers are labeled as other.
+	maxManufacturers = 64
+	// unknownManufacturer labels the RPCs of requests without a manufacturer.
+	unknownManufacturer = "unknown"
+	// otherManufacturer labels the RPCs of manufacturers beyond maxManufacturers.
+	otherManufacturer = "other"
+)
+
+// Buckets are the upper bounds in seconds of the buckets of the latency histograms.
+var Buckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+// labels identify the RPCs of a series.
+type labels struct {
+	service      string
+	method       string
+	manufacturer string
+	code         string
+}
+
+// series holds the count and latencies of the RPCs with the same labels.
+type series struct {
+	count uint64
+	sum   float64
+	// buckets counts the RPCs in each bucket of Buckets, not cumulatively.
+	buckets []uint64
+}
+
+// Metrics holds the metrics of the RPCs of a server. It is safe for concurrent use.
+type Metrics struct {
+	mu            sync.Mutex
+	series        map[labels]*series
+	manufacturers map[string]bool
+}
+
+// New returns metrics without RPCs.
+func New() *Metrics {
+	return &Metrics{
+		series:        map[labels]*series{},
+		manufacturers: map[string]bool{},
+	}
+}
+
+// Observe records an RPC of fullMethod, e.g. /bootz.proto.Bootstrap/GetBootstrapData, for a
+// device of the manufacturer which completed with err after d.
+func (m *Metrics) Observe(fullMethod, manufacturer string, err error, d time.Duration) {
+	svc, method := splitMethod(fullMethod)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	l := labels{
+		service:      svc,
+		method:       method,
+		manufacturer: m.manufacturerLabel(manufacturer),
+		code:         status.Code(err).String(),
+	}
+	s, ok := m.series[l]
+	if !ok {
+		s = &series{buckets: make([]uint64, len(Buckets))}
+		m.series[l] = s
+	}
+	s.count++
+	s.sum += d.Seconds()
+	for i, b := range Buckets {
+		if d.Seconds() <= b {
+			s.buckets[i]++
+			break
+		}
+	}
+}
+
+// manufacturerLabel returns the label of the manufacturer. The caller must hold m.mu.
+func (m *Metrics) manufacturerLabel(manufacturer string) string {
+	switch {
+	case manufacturer == "":
+		return unknownManufacturer
+	case m.manufacturers[manufacturer]:
+	case len(m.manufacturers) >= maxManufacturers:
+		return otherManufacturer
+	default:
+		m.manufacturers[manufacturer] = true
+	}
+	return manufacturer
+}
+
+// splitMethod returns the service and the method of a full gRPC method name.
+func splitMethod(fullMethod string) (string, string) {
+	svc, method, ok := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	if !ok {
+		return "unknown", fullMethod
+	}
+	return svc, method
+}
+
+// manufacturer returns the manufacturer of the chassis a request is made for, if any.
+func manufacturer(req any) string {
+	r, ok := req.(interface {
+		GetChassisDescriptor() *bpb.ChassisDescriptor
+	})
+	if !ok {
+		return ""
+	}
+	return r.GetChassisDescriptor().GetManufacturer()
+}
+
+// UnaryServerInterceptor records the unary RPCs.
+func (m *Metrics) UnaryServerInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	m.Observe(info.FullMethod, manufacturer(req), err, time.Since(start))
+	return resp, err
+}
+
+// StreamServerInterceptor records the streaming RPCs, labeled with the manufacturer of their
+// first request.
+func (m *Metrics) StreamServerInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	s := &observedStream{ServerStream: ss}
+	err := handler(srv, s)
+	m.Observe(info.FullMethod, s.manufacturer, err, time.Since(start))
+	return err
+}
+
+// observedStream captures the manufacturer of the first request of a stream.
+type observedStream struct {
+	grpc.ServerStream
+	received     bool
+	manufacturer string
+}
+
+// RecvMsg receives a request, capturing its manufacturer if it is the first.
+func (s *observedStream) RecvMsg(msg any) error {
+	if err := s.ServerStream.RecvMsg(msg); err != nil {
+		return err
+	}
+	if !s.received {
+		s.received = true
+		s.manufacturer = manufacturer(msg)
+	}
+	return nil
+}
+
+// ServeHTTP writes the metrics in the Prometheus text format.
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	m.Write(w)
+}
+
+// Write writes the metrics in the Prometheus text format.
+func (m *Metrics) Write(w io.Writer) error {
+	m.mu.Lock()
+	keys := make([]labels, 0, len(m.series))
+	snapshot := make(map[labels]series, len(m.series))
+	for l, s := range m.series {
+		keys = append(keys, l)
+		snapshot[l] = series{count: s.count, sum: s.sum, buckets: append([]uint64(nil), s.buckets...)}
+	}
+	m.mu.Unlock()
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		if a.service != b.service {
+			return a.service < b.service
+		}
+		if a.method != b.method {
+			return a.method < b.method
+		}
+		if a.manufacturer != b.manufacturer {
+			return a.manufacturer < b.manufacturer
+		}
+		return a.code < b.code
+	})
+
+	var sb strings.Builder
+	sb.WriteString("# HELP bootz_rpc_handled_total Number of RPCs completed by the server.\n")
+	sb.WriteString("# TYPE bootz_rpc_handled_total counter\n")
+	for _, l := range keys {
+		fmt.Fprintf(&sb, "bootz_rpc_handled_total{%s} %d\n", l, snapshot[l].count)
+	}
+	sb.WriteString("# HELP bootz_rpc_handling_seconds Latency of the RPCs completed by the server.\n")
+	sb.WriteString("# TYPE bootz_rpc_handling_seconds histogram\n")
+	for _, l := range keys {
+		s := snapshot[l]
+		var cumulative uint64
+		for i, b := range Buckets {
+			cumulative += s.buckets[i]
+			fmt.Fprintf(&sb, "bootz_rpc_handling_seconds_bucket{%s,le=%q} %d\n", l, strconv.FormatFloat(b, 'g', -1, 64), cumulative)
+		}
+		fmt.Fprintf(&sb, "bootz_rpc_handling_seconds_bucket{%s,le=\"+Inf\"} %d\n", l, s.count)
+		fmt.Fprintf(&sb, "bootz_rpc_handling_seconds_sum{%s} %s\n", l, strconv.FormatFloat(s.sum, 'g', -1, 64))
+		fmt.Fprintf(&sb, "bootz_rpc_handling_seconds_count{%s} %d\n", l, s.count)
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// String formats the labels as in the Prometheus text format.
+func (l labels) String() string {
+	return fmt.Sprintf("service=%s,method=%s,manufacturer=%s,code=%s", quote(l.service), quote(l.method), quote(l.manufacturer), quote(l.code))
+}
+
+// labelEscaper escapes label values as required by the Prometheus text format.
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// quote returns the quoted label value.
+func quote(v string) string {
+	return `"` + labelEscaper.Replace(v) + `"`
+}
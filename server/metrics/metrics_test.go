@@ -0,0 +1,131 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	bpb "github.com/openconfig/bootz/proto/bootz"
+)
+
+// fakeStream receives a single request.
+type fakeStream struct {
+	grpc.ServerStream
+	req *bpb.GetBootstrapDataRequest
+}
+
+func (s *fakeStream) RecvMsg(m any) error {
+	proto.Merge(m.(proto.Message), s.req)
+	return nil
+}
+
+func TestInterceptors(t *testing.T) {
+	m := New()
+	unary := func(method string, req any, err error) {
+		info := &grpc.UnaryServerInfo{FullMethod: method}
+		m.UnaryServerInterceptor(context.Background(), req, info, func(context.Context, any) (any, error) {
+			return nil, err
+		})
+	}
+	cisco := &bpb.GetBootstrapDataRequest{ChassisDescriptor: &bpb.ChassisDescriptor{Manufacturer: "Cisco"}}
+	unary("/bootz.proto.Bootstrap/GetBootstrapData", cisco, nil)
+	unary("/bootz.proto.Bootstrap/GetBootstrapData", cisco, nil)
+	unary("/bootz.proto.Bootstrap/GetBootstrapData", &bpb.GetBootstrapDataRequest{ChassisDescriptor: &bpb.ChassisDescriptor{Manufacturer: `Acme "Networks"`}}, status.Errorf(codes.InvalidArgument, "unknown chassis"))
+	unary("/bootz.proto.Bootstrap/ReportStatus", &bpb.ReportStatusRequest{}, nil)
+	info := &grpc.StreamServerInfo{FullMethod: "/bootz.proto.Bootstrap/GetBootstrapDataStream"}
+	m.StreamServerInterceptor(nil, &fakeStream{req: cisco}, info, func(_ any, ss grpc.ServerStream) error {
+		return ss.RecvMsg(&bpb.GetBootstrapDataRequest{})
+	})
+
+	rec := httptest.NewRecorder()
+	m.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	got := rec.Body.String()
+	for _, want := range []string{
+		`bootz_rpc_handled_total{service="bootz.proto.Bootstrap",method="GetBootstrapData",manufacturer="Cisco",code="OK"} 2`,
+		`bootz_rpc_handled_total{service="bootz.proto.Bootstrap",method="GetBootstrapData",manufacturer="Acme \"Networks\"",code="InvalidArgument"} 1`,
+		`bootz_rpc_handled_total{service="bootz.proto.Bootstrap",method="ReportStatus",manufacturer="unknown",code="OK"} 1`,
+		`bootz_rpc_handled_total{service="bootz.proto.Bootstrap",method="GetBootstrapDataStream",manufacturer="Cisco",code="OK"} 1`,
+		`bootz_rpc_handling_seconds_bucket{service="bootz.proto.Bootstrap",method="GetBootstrapData",manufacturer="Cisco",code="OK",le="0.005"} 2`,
+		`bootz_rpc_handling_seconds_bucket{service="bootz.proto.Bootstrap",method="GetBootstrapData",manufacturer="Cisco",code="OK",le="+Inf"} 2`,
+		`bootz_rpc_handling_seconds_count{service="bootz.proto.Bootstrap",method="GetBootstrapData",manufacturer="Cisco",code="OK"} 2`,
+	} {
+		if !strings.Contains(got, want+"\n") {
+			t.Errorf("ServeHTTP() output is missing %q, got:\n%s", want, got)
+		}
+	}
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+		t.Errorf("ServeHTTP() Content-Type = %q, want the Prometheus text format", ct)
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	m := New()
+	for _, d := range []time.Duration{time.Millisecond, 200 * time.Millisecond, 3 * time.Second, time.Minute} {
+		m.Observe("/bootz.proto.Bootstrap/GetBootstrapData", "Cisco", nil, d)
+	}
+	var sb strings.Builder
+	if err := m.Write(&sb); err != nil {
+		t.Fatalf("Write() err = %v", err)
+	}
+	tests := []struct {
+		le   string
+		want int
+	}{
+		{le: "0.005", want: 1},
+		{le: "0.25", want: 2},
+		{le: "5", want: 3},
+		{le: "30", want: 3},
+		{le: "+Inf", want: 4},
+	}
+	for _, test := range tests {
+		t.Run(test.le, func(t *testing.T) {
+			want := fmt.Sprintf(`bootz_rpc_handling_seconds_bucket{service="bootz.proto.Bootstrap",method="GetBootstrapData",manufacturer="Cisco",code="OK",le=%q} %d`, test.le, test.want)
+			if !strings.Contains(sb.String(), want+"\n") {
+				t.Errorf("Write() output is missing %q", want)
+			}
+		})
+	}
+}
+
+func TestManufacturerCardinality(t *testing.T) {
+	m := New()
+	for i := 0; i < maxManufacturers+10; i++ {
+		m.Observe("/bootz.proto.Bootstrap/ReportCapabilities", fmt.Sprintf("vendor%d", i), nil, time.Millisecond)
+	}
+	// Known manufacturers keep their label.
+	m.Observe("/bootz.proto.Bootstrap/ReportCapabilities", "vendor0", nil, time.Millisecond)
+	var sb strings.Builder
+	if err := m.Write(&sb); err != nil {
+		t.Fatalf("Write() err = %v", err)
+	}
+	for _, want := range []string{
+		`bootz_rpc_handled_total{service="bootz.proto.Bootstrap",method="ReportCapabilities",manufacturer="vendor0",code="OK"} 2`,
+		`bootz_rpc_handled_total{service="bootz.proto.Bootstrap",method="ReportCapabilities",manufacturer="other",code="OK"} 10`,
+	} {
+		if !strings.Contains(sb.String(), want+"\n") {
+			t.Errorf("Write() output is missing %q", want)
+		}
+	}
+}
@@ -0,0 +1,216 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package netbox imports the devices of a NetBox instance as bootz chassis
+// through the NetBox REST API, keeping the bootz inventory in sync with the
+// operator's DCIM.
+package netbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+	log "github.com/openconfig/bootz/server/logging"
+)
+
+const (
+	// devicesPath is the path of the device list endpoint of the NetBox API.
+	devicesPath = "/api/dcim/devices/"
+	// pageSize is the number of devices fetched per request.
+	pageSize = 500
+	// timeout bounds each request to the NetBox API.
+	timeout = 30 * time.Second
+)
+
+// ref is a nested object of the NetBox API.
+type ref struct {
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+// device is a device of the NetBox API, limited to the fields imported.
+type device struct {
+	Name       string `json:"name"`
+	Serial     string `json:"serial"`
+	DeviceType struct {
+		Model        string `json:"model"`
+		PartNumber   string `json:"part_number"`
+		Manufacturer ref    `json:"manufacturer"`
+	} `json:"device_type"`
+	// Role is named device_role before NetBox 3.6.
+	Role       *ref `json:"role"`
+	DeviceRole *ref `json:"device_role"`
+	Site       *ref `json:"site"`
+	Tenant     *ref `json:"tenant"`
+	PrimaryIP4 *struct {
+		Address string `json:"address"`
+	} `json:"primary_ip4"`
+	ConfigContext map[string]any `json:"config_context"`
+}
+
+// page is a page of the device list of the NetBox API.
+type page struct {
+	Next    string   `json:"next"`
+	Results []device `json:"results"`
+}
+
+// Importer imports the devices of a NetBox instance as chassis.
+type Importer struct {
+	baseURL     string
+	token       string
+	filters     map[string]string
+	roleConfigs map[string]*epb.Config
+	client      *http.Client
+}
+
+// New returns an importer of the devices of the NetBox instance of src.
+func New(src *epb.NetBoxSource) (*Importer, error) {
+	u, err := url.Parse(src.GetUrl())
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid NetBox URL %q", src.GetUrl())
+	}
+	i := &Importer{
+		baseURL:     strings.TrimSuffix(src.GetUrl(), "/"),
+		filters:     src.GetFilters(),
+		roleConfigs: src.GetRoleConfigs(),
+		client:      &http.Client{Timeout: timeout},
+	}
+	if f := src.GetTokenFile(); f != "" {
+		data, err := os.ReadFile(f)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read NetBox token: %v", err)
+		}
+		i.token = strings.TrimSpace(string(data))
+	}
+	return i, nil
+}
+
+// Chassis returns the chassis of the devices matching the filters. Devices without a serial
+// number are skipped.
+func (i *Importer) Chassis(ctx context.Context) ([]*epb.Chassis, error) {
+	query := url.Values{}
+	for k, v := range i.filters {
+		query.Set(k, v)
+	}
+	query.Set("limit", fmt.Sprint(pageSize))
+	next := i.baseURL + devicesPath + "?" + query.Encode()
+	var chassis []*epb.Chassis
+	for next != "" {
+		p, err := i.fetch(ctx, next)
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range p.Results {
+			if d.Serial == "" {
+				log.Warningf("Skipping NetBox device %q without a serial number", d.Name)
+				continue
+			}
+			ch, err := i.toChassis(d)
+			if err != nil {
+				return nil, fmt.Errorf("NetBox device %q: %v", d.Name, err)
+			}
+			chassis = append(chassis, ch)
+		}
+		next = p.Next
+	}
+	return chassis, nil
+}
+
+// fetch returns a page of the device list.
+func (i *Importer) fetch(ctx context.Context, u string) (*page, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if i.token != "" {
+		req.Header.Set("Authorization", "Token "+i.token)
+	}
+	resp, err := i.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list NetBox devices: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("unable to list NetBox devices: %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	p := &page{}
+	if err := json.NewDecoder(resp.Body).Decode(p); err != nil {
+		return nil, fmt.Errorf("unable to parse NetBox devices: %v", err)
+	}
+	return p, nil
+}
+
+// toChassis converts a device to a chassis, with the config of its role and its config
+// context as boot config metadata.
+func (i *Importer) toChassis(d device) (*epb.Chassis, error) {
+	ch := &epb.Chassis{
+		SerialNumber: d.Serial,
+		Name:         d.Name,
+		Manufacturer: d.DeviceType.Manufacturer.Name,
+		PartNumber:   d.DeviceType.PartNumber,
+	}
+	if ch.PartNumber == "" {
+		ch.PartNumber = d.DeviceType.Model
+	}
+	if d.Site != nil {
+		ch.Site = d.Site.Slug
+	}
+	if d.Tenant != nil {
+		ch.Tenant = d.Tenant.Slug
+	}
+	if d.PrimaryIP4 != nil && d.PrimaryIP4.Address != "" {
+		ch.DhcpConfig = &epb.DHCPConfig{IpAddress: d.PrimaryIP4.Address}
+	}
+	role := d.Role
+	if role == nil {
+		role = d.DeviceRole
+	}
+	if role != nil {
+		if cfg, ok := i.roleConfigs[role.Slug]; ok {
+			ch.Config = proto.Clone(cfg).(*epb.Config)
+		}
+	}
+	if len(d.ConfigContext) > 0 {
+		metadata, err := structpb.NewStruct(d.ConfigContext)
+		if err != nil {
+			return nil, fmt.Errorf("unable to convert config context: %v", err)
+		}
+		if ch.Config == nil {
+			ch.Config = &epb.Config{}
+		}
+		if ch.Config.BootConfig == nil {
+			ch.Config.BootConfig = &epb.BootConfig{}
+		}
+		if ch.Config.BootConfig.Metadata == nil {
+			ch.Config.BootConfig.Metadata = &structpb.Struct{Fields: map[string]*structpb.Value{}}
+		}
+		for k, v := range metadata.GetFields() {
+			ch.Config.BootConfig.Metadata.Fields[k] = v
+		}
+	}
+	return ch, nil
+}
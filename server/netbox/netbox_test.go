@@ -0,0 +1,118 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netbox
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+)
+
+func TestChassis(t *testing.T) {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Token secret" {
+			http.Error(w, `{"detail": "Invalid token"}`, http.StatusForbidden)
+			return
+		}
+		if got := r.URL.Query().Get("status"); got != "planned" {
+			t.Errorf("status filter = %q, want planned", got)
+		}
+		if r.URL.Query().Get("offset") == "" {
+			fmt.Fprintf(w, `{"next": "%s/api/dcim/devices/?status=planned&limit=500&offset=2", "results": [
+				{"name": "leaf1", "serial": "123", "device_type": {"model": "8201", "part_number": "8201-32FH", "manufacturer": {"name": "Cisco"}},
+				 "role": {"slug": "leaf"}, "site": {"slug": "par1"}, "tenant": {"slug": "core"}, "primary_ip4": {"address": "10.0.0.5/24"},
+				 "config_context": {"ntp": "10.0.0.1", "vlan": 10}},
+				{"name": "unracked", "serial": "", "device_type": {"model": "7280", "manufacturer": {"name": "Arista"}}}
+			]}`, srv.URL)
+			return
+		}
+		fmt.Fprint(w, `{"next": null, "results": [
+			{"name": "spine1", "serial": "456", "device_type": {"model": "7280", "manufacturer": {"name": "Arista"}},
+			 "device_role": {"slug": "spine"}, "site": {"slug": "par1"}, "tenant": null, "primary_ip4": null, "config_context": {}}
+		]}`)
+	}))
+	defer srv.Close()
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenFile, []byte("secret\n"), 0600); err != nil {
+		t.Fatalf("unable to write token: %v", err)
+	}
+	leafConfig := &epb.Config{BootConfig: &epb.BootConfig{
+		VendorConfigFile: "leaf.cfg",
+		Metadata:         &structpb.Struct{Fields: map[string]*structpb.Value{"ntp": structpb.NewStringValue("192.0.2.1"), "role": structpb.NewStringValue("leaf")}},
+	}}
+	src := &epb.NetBoxSource{
+		Url:         srv.URL + "/",
+		TokenFile:   tokenFile,
+		Filters:     map[string]string{"status": "planned"},
+		RoleConfigs: map[string]*epb.Config{"leaf": leafConfig},
+	}
+	i, err := New(src)
+	if err != nil {
+		t.Fatalf("New() err = %v, want nil", err)
+	}
+	got, err := i.Chassis(context.Background())
+	if err != nil {
+		t.Fatalf("Chassis() err = %v, want nil", err)
+	}
+	want := []*epb.Chassis{{
+		SerialNumber: "123",
+		Name:         "leaf1",
+		Manufacturer: "Cisco",
+		PartNumber:   "8201-32FH",
+		Site:         "par1",
+		Tenant:       "core",
+		DhcpConfig:   &epb.DHCPConfig{IpAddress: "10.0.0.5/24"},
+		Config: &epb.Config{BootConfig: &epb.BootConfig{
+			VendorConfigFile: "leaf.cfg",
+			Metadata: &structpb.Struct{Fields: map[string]*structpb.Value{
+				"ntp":  structpb.NewStringValue("10.0.0.1"),
+				"role": structpb.NewStringValue("leaf"),
+				"vlan": structpb.NewNumberValue(10),
+			}},
+		}},
+	}, {
+		SerialNumber: "456",
+		Name:         "spine1",
+		Manufacturer: "Arista",
+		PartNumber:   "7280",
+		Site:         "par1",
+	}}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("Chassis() diff (-want, +got):\n%s", diff)
+	}
+	if leafConfig.GetBootConfig().GetMetadata().GetFields()["ntp"].GetStringValue() != "192.0.2.1" {
+		t.Errorf("Chassis() modified the role config")
+	}
+
+	i.token = "wrong"
+	if _, err := i.Chassis(context.Background()); err == nil {
+		t.Errorf("Chassis() with an invalid token err = nil, want error")
+	}
+	if _, err := New(&epb.NetBoxSource{Url: "netbox"}); err == nil {
+		t.Errorf("New() with a relative URL err = nil, want error")
+	}
+}
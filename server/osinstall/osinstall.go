@@ -0,0 +1,260 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package osinstall converges the software version of devices reporting a
+// successful bootstrap while running another version than their intended
+// image, by installing and activating the image over gNOI OS.
+package osinstall
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"net/url"
+	"path"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+
+	bpb "github.com/openconfig/bootz/proto/bootz"
+	log "github.com/openconfig/bootz/server/logging"
+	ospb "github.com/openconfig/gnoi/os"
+)
+
+const (
+	// attempts is the number of times the running version is verified before giving up, as the
+	// gNOI server of the device may still be starting when it reports its bootstrap status.
+	attempts = 5
+	// timeout bounds each attempt to verify the running version, and activating the image.
+	timeout = 30 * time.Second
+	// installTimeout bounds installing the image, including its transfer.
+	installTimeout = 30 * time.Minute
+	// chunkSize is the size of the chunks the image is transferred in.
+	chunkSize = 64 * 1024
+)
+
+// ImageSource returns the intended images of devices.
+type ImageSource interface {
+	// IntendedImage returns the software image the chassis of the control card or fixed chassis
+	// with the given serial is intended to run, or nil if none is configured.
+	IntendedImage(serial string) (*bpb.SoftwareImage, error)
+}
+
+// Options configures how the installer connects to devices.
+type Options struct {
+	// Port of the gNOI server of the devices.
+	Port string
+	// Credentials secure the connections to the devices.
+	Credentials credentials.TransportCredentials
+	// Username and Password authenticate the gNOI requests, if set.
+	Username string
+	Password string
+	// Images holds the image files transferred to devices lacking them, named after the last
+	// element of the URL of the image. Devices must already hold their image if unset.
+	Images fs.FS
+}
+
+// Installer installs the intended image on devices reporting a successful bootstrap with
+// another running version.
+type Installer struct {
+	src  ImageSource
+	opts Options
+	// backoff is the wait before the first retry, doubled on each further retry.
+	backoff time.Duration
+}
+
+// New returns an installer converging devices to the images of src.
+func New(src ImageSource, opts Options) *Installer {
+	return &Installer{
+		src:     src,
+		opts:    opts,
+		backoff: 10 * time.Second,
+	}
+}
+
+// OnStatus converges the software version of the device in the background if the report is a
+// success. It is meant to be registered as a status hook of the bootstrap service.
+func (i *Installer) OnStatus(req *bpb.ReportStatusRequest, addr string) {
+	if req.GetStatus() != bpb.ReportStatusRequest_BOOTSTRAP_STATUS_SUCCESS || len(req.GetStates()) == 0 {
+		return
+	}
+	// All states of a report belong to the same chassis, whose gNOI server installs the image on
+	// every control card.
+	serial := req.GetStates()[0].GetSerialNumber()
+	img, err := i.src.IntendedImage(serial)
+	if err != nil {
+		log.Errorf("Unable to read intended image of serial# %v: %v", serial, err)
+		return
+	}
+	if img.GetVersion() == "" {
+		return
+	}
+	if addr == "" {
+		log.Errorf("Unable to verify software version of serial# %v: device address unknown", serial)
+		return
+	}
+	go func() {
+		installed, err := i.converge(context.Background(), net.JoinHostPort(addr, i.opts.Port), img)
+		if err != nil {
+			log.Errorf("Unable to install version %v on serial# %v at %v: %v", img.GetVersion(), serial, addr, err)
+			return
+		}
+		if installed {
+			log.Infof("Installed and activated version %v on serial# %v at %v", img.GetVersion(), serial, addr)
+		}
+	}()
+}
+
+// converge verifies the version running on the gNOI server at target, and installs and
+// activates the image if it differs. It returns whether the image was installed.
+func (i *Installer) converge(ctx context.Context, target string, img *bpb.SoftwareImage) (bool, error) {
+	conn, err := grpc.DialContext(ctx, target, grpc.WithTransportCredentials(i.opts.Credentials))
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+	c := ospb.NewOSClient(conn)
+	if i.opts.Username != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "username", i.opts.Username, "password", i.opts.Password)
+	}
+	running, err := i.verify(ctx, c, target)
+	if err != nil {
+		return false, fmt.Errorf("unable to verify the running version: %v", err)
+	}
+	if running == img.GetVersion() {
+		return false, nil
+	}
+	log.Infof("Device at %v runs version %v instead of %v, installing it over gNOI", target, running, img.GetVersion())
+	if err := i.install(ctx, c, img); err != nil {
+		return false, err
+	}
+	if err := activate(ctx, c, img.GetVersion()); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// verify returns the version running on the device, retrying failed attempts with exponential
+// backoff.
+func (i *Installer) verify(ctx context.Context, c ospb.OSClient, target string) (string, error) {
+	var err error
+	backoff := i.backoff
+	for a := 0; a < attempts; a++ {
+		if a > 0 {
+			log.Warningf("Software version verification of %v failed, retrying in %v: %v", target, backoff, err)
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+		attemptCtx, cancel := context.WithTimeout(ctx, timeout)
+		var resp *ospb.VerifyResponse
+		resp, err = c.Verify(attemptCtx, &ospb.VerifyRequest{}, grpc.WaitForReady(true))
+		cancel()
+		if err == nil {
+			return resp.GetVersion(), nil
+		}
+	}
+	return "", err
+}
+
+// install has the device validate the image, transferring it first if the device lacks it.
+func (i *Installer) install(ctx context.Context, c ospb.OSClient, img *bpb.SoftwareImage) error {
+	ctx, cancel := context.WithTimeout(ctx, installTimeout)
+	defer cancel()
+	stream, err := c.Install(ctx)
+	if err != nil {
+		return err
+	}
+	if err := stream.Send(&ospb.InstallRequest{Request: &ospb.InstallRequest_TransferRequest{
+		TransferRequest: &ospb.TransferRequest{Version: img.GetVersion()},
+	}}); err != nil {
+		return err
+	}
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			return fmt.Errorf("install stream failed: %v", err)
+		}
+		switch r := resp.GetResponse().(type) {
+		case *ospb.InstallResponse_TransferReady:
+			if err := i.transfer(stream, img); err != nil {
+				return err
+			}
+		case *ospb.InstallResponse_TransferProgress, *ospb.InstallResponse_SyncProgress:
+		case *ospb.InstallResponse_Validated:
+			if v := r.Validated.GetVersion(); v != img.GetVersion() {
+				return fmt.Errorf("device validated version %v, want %v", v, img.GetVersion())
+			}
+			return stream.CloseSend()
+		case *ospb.InstallResponse_InstallError:
+			return fmt.Errorf("install failed: %v %v", r.InstallError.GetType(), r.InstallError.GetDetail())
+		default:
+			return fmt.Errorf("unexpected install response %v", resp)
+		}
+	}
+}
+
+// transfer streams the image file to the device.
+func (i *Installer) transfer(stream ospb.OS_InstallClient, img *bpb.SoftwareImage) error {
+	if i.opts.Images == nil {
+		return errors.New("device lacks the image, and no image files are configured to transfer it")
+	}
+	u, err := url.Parse(img.GetUrl())
+	if err != nil {
+		return fmt.Errorf("invalid image URL %q: %v", img.GetUrl(), err)
+	}
+	f, err := i.opts.Images.Open(path.Base(u.Path))
+	if err != nil {
+		return fmt.Errorf("unable to open image file: %v", err)
+	}
+	defer f.Close()
+	buf := make([]byte, chunkSize)
+	for {
+		n, err := f.Read(buf)
+		if n > 0 {
+			if err := stream.Send(&ospb.InstallRequest{Request: &ospb.InstallRequest_TransferContent{
+				TransferContent: buf[:n],
+			}}); err != nil {
+				return err
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("unable to read image file: %v", err)
+		}
+	}
+	return stream.Send(&ospb.InstallRequest{Request: &ospb.InstallRequest_TransferEnd{
+		TransferEnd: &ospb.TransferEnd{},
+	}})
+}
+
+// activate has the device boot the installed version.
+func activate(ctx context.Context, c ospb.OSClient, version string) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	resp, err := c.Activate(ctx, &ospb.ActivateRequest{Version: version})
+	if err != nil {
+		return err
+	}
+	if e := resp.GetActivateError(); e != nil {
+		return fmt.Errorf("activation failed: %v %v", e.GetType(), e.GetDetail())
+	}
+	return nil
+}
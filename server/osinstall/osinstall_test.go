@@ -0,0 +1,163 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package osinstall
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/h-fam/errdiff"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	bpb "github.com/openconfig/bootz/proto/bootz"
+	ospb "github.com/openconfig/gnoi/os"
+)
+
+// fakeOS runs version, holding the versions in installed, and failing the first failures
+// Verify requests.
+type fakeOS struct {
+	ospb.UnimplementedOSServer
+	failures    int
+	version     string
+	installed   map[string]bool
+	transferred []byte
+	activated   string
+}
+
+func (f *fakeOS) Verify(context.Context, *ospb.VerifyRequest) (*ospb.VerifyResponse, error) {
+	if f.failures > 0 {
+		f.failures--
+		return nil, status.Errorf(codes.Unavailable, "not ready")
+	}
+	return &ospb.VerifyResponse{Version: f.version}, nil
+}
+
+func (f *fakeOS) Install(stream ospb.OS_InstallServer) error {
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	version := req.GetTransferRequest().GetVersion()
+	if !f.installed[version] {
+		if err := stream.Send(&ospb.InstallResponse{Response: &ospb.InstallResponse_TransferReady{TransferReady: &ospb.TransferReady{}}}); err != nil {
+			return err
+		}
+		for {
+			req, err := stream.Recv()
+			if err != nil {
+				return err
+			}
+			if req.GetTransferEnd() != nil {
+				break
+			}
+			f.transferred = append(f.transferred, req.GetTransferContent()...)
+		}
+		f.installed[version] = true
+	}
+	return stream.Send(&ospb.InstallResponse{Response: &ospb.InstallResponse_Validated{Validated: &ospb.Validated{Version: version}}})
+}
+
+func (f *fakeOS) Activate(_ context.Context, req *ospb.ActivateRequest) (*ospb.ActivateResponse, error) {
+	if !f.installed[req.GetVersion()] {
+		return &ospb.ActivateResponse{Response: &ospb.ActivateResponse_ActivateError{ActivateError: &ospb.ActivateError{
+			Type: ospb.ActivateError_NON_EXISTENT_VERSION,
+		}}}, nil
+	}
+	f.activated = req.GetVersion()
+	return &ospb.ActivateResponse{Response: &ospb.ActivateResponse_ActivateOk{ActivateOk: &ospb.ActivateOK{}}}, nil
+}
+
+type images map[string]*bpb.SoftwareImage
+
+func (i images) IntendedImage(serial string) (*bpb.SoftwareImage, error) {
+	return i[serial], nil
+}
+
+func TestConverge(t *testing.T) {
+	image := bytes.Repeat([]byte("image"), chunkSize)
+	img := &bpb.SoftwareImage{Name: "os", Version: "2.0", Url: "https://images.example.com/os/os-2.0.bin?token=abc"}
+	tests := []struct {
+		desc            string
+		os              *fakeOS
+		images          fstest.MapFS
+		wantInstalled   bool
+		wantTransferred []byte
+		wantErr         string
+	}{{
+		desc: "Running the intended version",
+		os:   &fakeOS{failures: 1, version: "2.0"},
+	}, {
+		desc:          "Image held by the device",
+		os:            &fakeOS{version: "1.0", installed: map[string]bool{"2.0": true}},
+		wantInstalled: true,
+	}, {
+		desc:            "Image transferred",
+		os:              &fakeOS{version: "1.0"},
+		images:          fstest.MapFS{"os-2.0.bin": {Data: image}},
+		wantInstalled:   true,
+		wantTransferred: image,
+	}, {
+		desc:    "Image file missing",
+		os:      &fakeOS{version: "1.0"},
+		images:  fstest.MapFS{},
+		wantErr: "unable to open image file",
+	}, {
+		desc:    "No image files",
+		os:      &fakeOS{version: "1.0"},
+		wantErr: "no image files",
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			if test.os.installed == nil {
+				test.os.installed = map[string]bool{}
+			}
+			lis, err := net.Listen("tcp", "localhost:0")
+			if err != nil {
+				t.Fatalf("net.Listen() err = %v", err)
+			}
+			s := grpc.NewServer()
+			ospb.RegisterOSServer(s, test.os)
+			go s.Serve(lis)
+			defer s.Stop()
+
+			opts := Options{Credentials: insecure.NewCredentials()}
+			if test.images != nil {
+				opts.Images = test.images
+			}
+			i := New(images{}, opts)
+			i.backoff = time.Millisecond
+			installed, err := i.converge(context.Background(), lis.Addr().String(), img)
+			if diff := errdiff.Substring(err, test.wantErr); diff != "" {
+				t.Fatalf("converge() %s", diff)
+			}
+			if installed != test.wantInstalled {
+				t.Errorf("converge() = %v, want %v", installed, test.wantInstalled)
+			}
+			if !bytes.Equal(test.os.transferred, test.wantTransferred) {
+				t.Errorf("converge() transferred %d bytes, want %d", len(test.os.transferred), len(test.wantTransferred))
+			}
+			if want := map[bool]string{true: "2.0"}[test.wantInstalled]; test.os.activated != want {
+				t.Errorf("converge() activated version %q, want %q", test.os.activated, want)
+			}
+		})
+	}
+}
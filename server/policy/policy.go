@@ -0,0 +1,209 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package policy authorizes bootstrap requests with a set of pluggable
+// policies, configured in the inventory options, before bootstrap data is
+// served.
+//
+// CEL expressions are built in. Other policy engines, such as an OPA client,
+// are added by registering a Factory, and are then configured by name with
+// their parameters:
+//
+//	policy.Register("opa", func(params map[string]string) (policy.Policy, error) {
+//		return newOPAClient(params["url"], params["query"])
+//	})
+package policy
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"sync"
+	"time"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+	log "github.com/openconfig/bootz/server/logging"
+)
+
+// Request is a bootstrap request being authorized.
+type Request struct {
+	// SerialNumber is the serial of the requesting control card, or of the fixed chassis.
+	SerialNumber        string
+	ChassisSerialNumber string
+	Manufacturer        string
+	PartNumber          string
+	// SourceIP is the address the request was received from, if known.
+	SourceIP string
+	// Tenant and Site are those of the chassis in the inventory.
+	Tenant string
+	Site   string
+	// BootMode is the boot mode of the chassis, e.g. BOOT_MODE_SECURE.
+	BootMode string
+	// Time is the time the request was received at.
+	Time time.Time
+}
+
+// Policy decides whether a bootstrap request is served.
+type Policy interface {
+	// Allow returns an error if the request must not be served. Errors should carry
+	// codes.PermissionDenied.
+	Allow(ctx context.Context, req *Request) error
+}
+
+// Factory returns a policy configured with params.
+type Factory func(params map[string]string) (Policy, error)
+
+var (
+	mu        sync.RWMutex
+	factories = map[string]Factory{}
+)
+
+// Register makes a policy engine available by name. Registering a name twice replaces the
+// previous engine.
+func Register(name string, f Factory) {
+	mu.Lock()
+	defer mu.Unlock()
+	factories[name] = f
+}
+
+// Set is a list of policies a request must all be allowed by, in order.
+type Set []Policy
+
+// New returns the set of the configured policies.
+func New(cfgs []*epb.BootstrapPolicy) (Set, error) {
+	var s Set
+	for i, cfg := range cfgs {
+		p, err := newPolicy(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("policy %d: %v", i, err)
+		}
+		s = append(s, p)
+	}
+	return s, nil
+}
+
+// newPolicy returns the policy of cfg.
+func newPolicy(cfg *epb.BootstrapPolicy) (Policy, error) {
+	if (cfg.GetExpression() == "") == (cfg.GetCustom() == "") {
+		return nil, fmt.Errorf("exactly one of expression and custom must be set")
+	}
+	if cfg.GetExpression() != "" {
+		return CEL(cfg.GetName(), cfg.GetExpression())
+	}
+	mu.RLock()
+	f, ok := factories[cfg.GetCustom()]
+	mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("no policy engine registered as %q", cfg.GetCustom())
+	}
+	return f(cfg.GetParams())
+}
+
+// Allow implements Policy, returning the error of the first policy denying the request.
+func (s Set) Allow(ctx context.Context, req *Request) error {
+	for _, p := range s {
+		if err := p.Allow(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// celEnv declares the variables and functions of CEL policies.
+var celEnv = sync.OnceValues(func() (*cel.Env, error) {
+	return cel.NewEnv(
+		cel.Variable("serial_number", cel.StringType),
+		cel.Variable("chassis_serial_number", cel.StringType),
+		cel.Variable("manufacturer", cel.StringType),
+		cel.Variable("part_number", cel.StringType),
+		cel.Variable("source_ip", cel.StringType),
+		cel.Variable("tenant", cel.StringType),
+		cel.Variable("site", cel.StringType),
+		cel.Variable("boot_mode", cel.StringType),
+		cel.Variable("time", cel.TimestampType),
+		cel.Function("ip_in",
+			cel.Overload("ip_in_string_string", []*cel.Type{cel.StringType, cel.StringType}, cel.BoolType,
+				cel.BinaryBinding(ipIn))),
+	)
+})
+
+// ipIn reports whether the IP address is in the CIDR prefix. Unparsable addresses, such as the
+// empty source IP of requests received over a unix socket, are in no prefix.
+func ipIn(ip, cidr ref.Val) ref.Val {
+	prefix, err := netip.ParsePrefix(string(cidr.(types.String)))
+	if err != nil {
+		return types.NewErr("invalid CIDR prefix %q: %v", cidr, err)
+	}
+	addr, err := netip.ParseAddr(string(ip.(types.String)))
+	if err != nil {
+		return types.False
+	}
+	return types.Bool(prefix.Contains(addr.Unmap()))
+}
+
+// celPolicy allows the requests its expression evaluates to true for.
+type celPolicy struct {
+	name string
+	prg  cel.Program
+}
+
+// CEL returns a policy allowing the requests the CEL expression evaluates to true for.
+func CEL(name, expr string) (Policy, error) {
+	env, err := celEnv()
+	if err != nil {
+		return nil, err
+	}
+	ast, iss := env.Compile(expr)
+	if err := iss.Err(); err != nil {
+		return nil, fmt.Errorf("invalid expression %q: %v", expr, err)
+	}
+	if ast.OutputType() != cel.BoolType {
+		return nil, fmt.Errorf("expression %q evaluates to %v, want bool", expr, ast.OutputType())
+	}
+	prg, err := env.Program(ast)
+	if err != nil {
+		return nil, fmt.Errorf("invalid expression %q: %v", expr, err)
+	}
+	return &celPolicy{name: name, prg: prg}, nil
+}
+
+// Allow implements Policy.
+func (p *celPolicy) Allow(_ context.Context, req *Request) error {
+	out, _, err := p.prg.Eval(map[string]any{
+		"serial_number":         req.SerialNumber,
+		"chassis_serial_number": req.ChassisSerialNumber,
+		"manufacturer":          req.Manufacturer,
+		"part_number":           req.PartNumber,
+		"source_ip":             req.SourceIP,
+		"tenant":                req.Tenant,
+		"site":                  req.Site,
+		"boot_mode":             req.BootMode,
+		"time":                  req.Time,
+	})
+	if err != nil {
+		// Requests are denied when their policy can't be evaluated.
+		log.Errorf("Unable to evaluate policy %q for serial# %s: %v", p.name, req.SerialNumber, err)
+		return status.Errorf(codes.Internal, "unable to evaluate policy %q: %v", p.name, err)
+	}
+	if allowed, ok := out.Value().(bool); !ok || !allowed {
+		return status.Errorf(codes.PermissionDenied, "bootstrap request of serial# %s denied by policy %q", req.SerialNumber, p.name)
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/h-fam/errdiff"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+)
+
+// denySerial denies requests of a single serial.
+type denySerial string
+
+func (d denySerial) Allow(_ context.Context, req *Request) error {
+	if req.SerialNumber == string(d) {
+		return status.Errorf(codes.PermissionDenied, "%s is denied", req.SerialNumber)
+	}
+	return nil
+}
+
+func TestSet(t *testing.T) {
+	Register("deny_serial", func(params map[string]string) (Policy, error) {
+		return denySerial(params["serial"]), nil
+	})
+	s, err := New([]*epb.BootstrapPolicy{{
+		Name:       "maintenance window",
+		Expression: `ip_in(source_ip, "10.1.0.0/16") && time.getHours("UTC") < 6`,
+	}, {
+		Name:       "lab site",
+		Expression: `tenant != "lab" || site == "sfo"`,
+	}, {
+		Custom: "deny_serial",
+		Params: map[string]string{"serial": "123A"},
+	}})
+	if err != nil {
+		t.Fatalf("New() err = %v, want nil", err)
+	}
+	night := time.Date(2023, 6, 1, 3, 0, 0, 0, time.UTC)
+	day := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		desc     string
+		req      *Request
+		wantCode codes.Code
+	}{{
+		desc:     "Allowed request",
+		req:      &Request{SerialNumber: "123B", SourceIP: "10.1.2.3", Tenant: "lab", Site: "sfo", Time: night},
+		wantCode: codes.OK,
+	}, {
+		desc:     "Allowed IPv4-mapped source",
+		req:      &Request{SerialNumber: "123B", SourceIP: "::ffff:10.1.2.3", Time: night},
+		wantCode: codes.OK,
+	}, {
+		desc:     "Outside of maintenance window",
+		req:      &Request{SerialNumber: "123B", SourceIP: "10.1.2.3", Time: day},
+		wantCode: codes.PermissionDenied,
+	}, {
+		desc:     "Source outside of subnet",
+		req:      &Request{SerialNumber: "123B", SourceIP: "10.2.2.3", Time: night},
+		wantCode: codes.PermissionDenied,
+	}, {
+		desc:     "Unknown source",
+		req:      &Request{SerialNumber: "123B", Time: night},
+		wantCode: codes.PermissionDenied,
+	}, {
+		desc:     "Wrong site",
+		req:      &Request{SerialNumber: "123B", SourceIP: "10.1.2.3", Tenant: "lab", Site: "nyc", Time: night},
+		wantCode: codes.PermissionDenied,
+	}, {
+		desc:     "Denied by custom policy",
+		req:      &Request{SerialNumber: "123A", SourceIP: "10.1.2.3", Time: night},
+		wantCode: codes.PermissionDenied,
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			err := s.Allow(context.Background(), test.req)
+			if got := status.Code(err); got != test.wantCode {
+				t.Errorf("Allow() code = %v, want %v (err = %v)", got, test.wantCode, err)
+			}
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		desc    string
+		cfg     *epb.BootstrapPolicy
+		wantErr string
+	}{{
+		desc:    "Neither expression nor custom",
+		cfg:     &epb.BootstrapPolicy{Name: "empty"},
+		wantErr: "exactly one",
+	}, {
+		desc:    "Both expression and custom",
+		cfg:     &epb.BootstrapPolicy{Expression: "true", Custom: "opa"},
+		wantErr: "exactly one",
+	}, {
+		desc:    "Unregistered engine",
+		cfg:     &epb.BootstrapPolicy{Custom: "unknown"},
+		wantErr: "no policy engine",
+	}, {
+		desc:    "Syntax error",
+		cfg:     &epb.BootstrapPolicy{Expression: "tenant =="},
+		wantErr: "invalid expression",
+	}, {
+		desc:    "Undeclared variable",
+		cfg:     &epb.BootstrapPolicy{Expression: `hostname == "a"`},
+		wantErr: "undeclared reference",
+	}, {
+		desc:    "Non-bool expression",
+		cfg:     &epb.BootstrapPolicy{Expression: "tenant"},
+		wantErr: "want bool",
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			_, err := New([]*epb.BootstrapPolicy{test.cfg})
+			if diff := errdiff.Substring(err, test.wantErr); diff != "" {
+				t.Errorf("New() %s", diff)
+			}
+		})
+	}
+}
+
+func TestInvalidCIDR(t *testing.T) {
+	p, err := CEL("bad cidr", `ip_in(source_ip, "10.1.0.0")`)
+	if err != nil {
+		t.Fatalf("CEL() err = %v, want nil", err)
+	}
+	err = p.Allow(context.Background(), &Request{SourceIP: "10.1.2.3"})
+	if got := status.Code(err); got != codes.Internal {
+		t.Errorf("Allow() code = %v, want %v (err = %v)", got, codes.Internal, err)
+	}
+}
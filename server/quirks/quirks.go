@@ -0,0 +1,93 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package quirks normalizes the chassis descriptors of vendors deviating from
+// the bootz specification, e.g. misspelling their manufacturer or omitting
+// part numbers, so the bootstrap service only handles conforming requests.
+package quirks
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+
+	bpb "github.com/openconfig/bootz/proto/bootz"
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+	log "github.com/openconfig/bootz/server/logging"
+)
+
+// Adapter normalizes chassis descriptors with the quirks of their manufacturer.
+type Adapter struct {
+	// quirks holds the quirks of each manufacturer, keyed by lower case manufacturer.
+	quirks map[string]*epb.VendorQuirks
+}
+
+// New returns an adapter applying the vendor quirks. Each manufacturer may only have one set of
+// quirks.
+func New(quirks []*epb.VendorQuirks) (*Adapter, error) {
+	a := &Adapter{quirks: map[string]*epb.VendorQuirks{}}
+	for i, q := range quirks {
+		if len(q.GetManufacturers()) == 0 {
+			return nil, fmt.Errorf("vendor quirks %d have no manufacturers", i)
+		}
+		for _, m := range q.GetManufacturers() {
+			key := strings.ToLower(m)
+			if _, ok := a.quirks[key]; ok {
+				return nil, fmt.Errorf("vendor quirks %d: manufacturer %q has quirks already", i, m)
+			}
+			a.quirks[key] = q
+		}
+	}
+	return a, nil
+}
+
+// Adapt returns the chassis descriptor normalized with the quirks of its manufacturer. The
+// descriptor itself is returned if its manufacturer has no quirks, and is never modified.
+func (a *Adapter) Adapt(desc *bpb.ChassisDescriptor) *bpb.ChassisDescriptor {
+	if a == nil {
+		return desc
+	}
+	q, ok := a.quirks[strings.ToLower(desc.GetManufacturer())]
+	if !ok {
+		return desc
+	}
+	adapted := proto.Clone(desc).(*bpb.ChassisDescriptor)
+	if m := q.GetCanonicalManufacturer(); m != "" {
+		adapted.Manufacturer = m
+	}
+	if q.GetSerialInControlCard() && adapted.GetSerialNumber() == "" && len(adapted.GetControlCards()) == 1 {
+		cc := adapted.GetControlCards()[0]
+		adapted.SerialNumber = cc.GetSerialNumber()
+		if adapted.GetPartNumber() == "" {
+			adapted.PartNumber = cc.GetPartNumber()
+		}
+		if adapted.GetSoftwareVersion() == "" {
+			adapted.SoftwareVersion = cc.GetSoftwareVersion()
+		}
+		adapted.ControlCards = nil
+	}
+	if adapted.GetPartNumber() == "" {
+		adapted.PartNumber = q.GetDefaultPartNumber()
+	}
+	for _, cc := range adapted.GetControlCards() {
+		if cc.GetPartNumber() == "" {
+			cc.PartNumber = q.GetDefaultControlCardPartNumber()
+		}
+	}
+	if !proto.Equal(desc, adapted) {
+		log.Infof("Adapted chassis descriptor of %v chassis %v to %v", desc.GetManufacturer(), desc.GetSerialNumber(), adapted)
+	}
+	return adapted
+}
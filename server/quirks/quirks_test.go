@@ -0,0 +1,128 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quirks
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/h-fam/errdiff"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	bpb "github.com/openconfig/bootz/proto/bootz"
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		desc    string
+		quirks  []*epb.VendorQuirks
+		wantErr string
+	}{{
+		desc:   "No quirks",
+		quirks: nil,
+	}, {
+		desc: "Distinct manufacturers",
+		quirks: []*epb.VendorQuirks{
+			{Manufacturers: []string{"CISCO SYSTEMS"}, CanonicalManufacturer: "Cisco"},
+			{Manufacturers: []string{"arista networks"}, CanonicalManufacturer: "Arista"},
+		},
+	}, {
+		desc:    "No manufacturers",
+		quirks:  []*epb.VendorQuirks{{CanonicalManufacturer: "Cisco"}},
+		wantErr: "quirks 0 have no manufacturers",
+	}, {
+		desc: "Manufacturer with two sets of quirks",
+		quirks: []*epb.VendorQuirks{
+			{Manufacturers: []string{"Cisco"}, DefaultPartNumber: "8808"},
+			{Manufacturers: []string{"CISCO"}, SerialInControlCard: true},
+		},
+		wantErr: `manufacturer "CISCO" has quirks already`,
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			_, err := New(test.quirks)
+			if diff := errdiff.Substring(err, test.wantErr); diff != "" {
+				t.Errorf("New() %s", diff)
+			}
+		})
+	}
+}
+
+func TestAdapt(t *testing.T) {
+	a, err := New([]*epb.VendorQuirks{{
+		Manufacturers:         []string{"CISCO SYSTEMS"},
+		CanonicalManufacturer: "Cisco",
+		SerialInControlCard:   true,
+	}, {
+		Manufacturers:                []string{"Arista"},
+		DefaultPartNumber:            "DCS-7280",
+		DefaultControlCardPartNumber: "DCS-7500-SUP",
+	}})
+	if err != nil {
+		t.Fatalf("New() err = %v, want nil", err)
+	}
+	tests := []struct {
+		desc  string
+		input *bpb.ChassisDescriptor
+		want  *bpb.ChassisDescriptor
+	}{{
+		desc:  "Manufacturer without quirks",
+		input: &bpb.ChassisDescriptor{Manufacturer: "Juniper", ControlCards: []*bpb.ControlCard{{SerialNumber: "123A"}}},
+		want:  &bpb.ChassisDescriptor{Manufacturer: "Juniper", ControlCards: []*bpb.ControlCard{{SerialNumber: "123A"}}},
+	}, {
+		desc: "Fixed chassis serial reported as control card",
+		input: &bpb.ChassisDescriptor{Manufacturer: "Cisco Systems", ControlCards: []*bpb.ControlCard{
+			{SerialNumber: "123", PartNumber: "8201", SoftwareVersion: "7.9"},
+		}},
+		want: &bpb.ChassisDescriptor{Manufacturer: "Cisco", SerialNumber: "123", PartNumber: "8201", SoftwareVersion: "7.9"},
+	}, {
+		desc: "Modular chassis left as is",
+		input: &bpb.ChassisDescriptor{Manufacturer: "CISCO SYSTEMS", SerialNumber: "123", ControlCards: []*bpb.ControlCard{
+			{SerialNumber: "123A"},
+		}},
+		want: &bpb.ChassisDescriptor{Manufacturer: "Cisco", SerialNumber: "123", ControlCards: []*bpb.ControlCard{
+			{SerialNumber: "123A"},
+		}},
+	}, {
+		desc: "Missing part numbers",
+		input: &bpb.ChassisDescriptor{Manufacturer: "Arista", SerialNumber: "456", ControlCards: []*bpb.ControlCard{
+			{SerialNumber: "456A"},
+			{SerialNumber: "456B", PartNumber: "DCS-7500-SUP2"},
+		}},
+		want: &bpb.ChassisDescriptor{Manufacturer: "Arista", SerialNumber: "456", PartNumber: "DCS-7280", ControlCards: []*bpb.ControlCard{
+			{SerialNumber: "456A", PartNumber: "DCS-7500-SUP"},
+			{SerialNumber: "456B", PartNumber: "DCS-7500-SUP2"},
+		}},
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			input := proto.Clone(test.input)
+			got := a.Adapt(test.input)
+			if diff := cmp.Diff(test.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("Adapt() diff (-want, +got):\n%s", diff)
+			}
+			if !proto.Equal(input, test.input) {
+				t.Errorf("Adapt() modified its input to %v, want %v", test.input, input)
+			}
+		})
+	}
+	var none *Adapter
+	desc := &bpb.ChassisDescriptor{Manufacturer: "Cisco Systems"}
+	if got := none.Adapt(desc); got != desc {
+		t.Errorf("Adapt() of a nil adapter = %v, want its input", got)
+	}
+}
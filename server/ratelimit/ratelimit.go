@@ -0,0 +1,216 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ratelimit throttles bootstrap requests per client IP and per serial
+// number, protecting the server from devices retry-storming it during mass
+// provisioning events.
+package ratelimit
+
+import (
+	"context"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	bpb "github.com/openconfig/bootz/proto/bootz"
+	log "github.com/openconfig/bootz/server/logging"
+)
+
+// bootstrapService prefixes the full method names of the rate limited service.
+const bootstrapService = "/bootz.proto.Bootstrap/"
+
+// maxBuckets bounds the number of tracked clients before idle ones are dropped.
+const maxBuckets = 1 << 16
+
+// Limits configures the rate limits. A rate of 0 disables the limit.
+type Limits struct {
+	// PerIP is the sustained number of requests per second allowed from a client IP.
+	PerIP float64
+	// PerSerial is the sustained number of requests per second allowed for a serial number.
+	PerSerial float64
+	// Burst is the number of requests allowed above the sustained rate. It is at least 1.
+	Burst int
+}
+
+// bucket is a token bucket refilled at a fixed rate.
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// limiter throttles requests per key.
+type limiter struct {
+	rate    float64
+	burst   float64
+	buckets map[string]*bucket
+}
+
+func newLimiter(rate float64, burst int) *limiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &limiter{
+		rate:    rate,
+		burst:   float64(burst),
+		buckets: map[string]*bucket{},
+	}
+}
+
+// allow takes a token from the bucket of key, reporting false if the bucket is empty.
+func (l *limiter) allow(key string, now time.Time) bool {
+	if l.rate <= 0 || key == "" {
+		return true
+	}
+	b, ok := l.buckets[key]
+	if !ok {
+		if len(l.buckets) >= maxBuckets {
+			l.prune(now)
+		}
+		b = &bucket{tokens: l.burst, last: now}
+		l.buckets[key] = b
+	}
+	b.tokens += now.Sub(b.last).Seconds() * l.rate
+	if b.tokens > l.burst {
+		b.tokens = l.burst
+	}
+	b.last = now
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// prune drops the buckets which have refilled completely, as they behave like new ones.
+func (l *limiter) prune(now time.Time) {
+	for k, b := range l.buckets {
+		if b.tokens+now.Sub(b.last).Seconds()*l.rate >= l.burst {
+			delete(l.buckets, k)
+		}
+	}
+}
+
+// Limiter throttles the requests of the bootstrap service. It is safe for concurrent use.
+type Limiter struct {
+	mu        sync.Mutex
+	perIP     *limiter
+	perSerial *limiter
+	now       func() time.Time
+}
+
+// New returns a Limiter enforcing the limits.
+func New(l Limits) *Limiter {
+	return &Limiter{
+		perIP:     newLimiter(l.PerIP, l.Burst),
+		perSerial: newLimiter(l.PerSerial, l.Burst),
+		now:       time.Now,
+	}
+}
+
+// Allow reports whether a request from the client IP for the serial number is within the limits.
+func (l *Limiter) Allow(ip, serial string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	return l.perIP.allow(ip, now) && l.perSerial.allow(serial, now)
+}
+
+// UnaryServerInterceptor rejects bootstrap requests exceeding the limits with ResourceExhausted.
+// Requests to other services are not limited.
+func (l *Limiter) UnaryServerInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if !strings.HasPrefix(info.FullMethod, bootstrapService) {
+		return handler(ctx, req)
+	}
+	ip := clientIP(ctx)
+	serial := requestSerial(req)
+	if !l.Allow(ip, serial) {
+		log.Warningf("Rate limited %v from %v for serial# %v", info.FullMethod, ip, serial)
+		return nil, status.Errorf(codes.ResourceExhausted, "rate limit exceeded, retry later")
+	}
+	return handler(ctx, req)
+}
+
+// StreamServerInterceptor rejects bootstrap streams whose request exceeds the limits with
+// ResourceExhausted. Streams of other services are not limited.
+func (l *Limiter) StreamServerInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if !strings.HasPrefix(info.FullMethod, bootstrapService) {
+		return handler(srv, ss)
+	}
+	return handler(srv, &limitedStream{ServerStream: ss, limiter: l, method: info.FullMethod})
+}
+
+// limitedStream checks the requests received on a stream against the limits.
+type limitedStream struct {
+	grpc.ServerStream
+	limiter *Limiter
+	method  string
+}
+
+// RecvMsg receives a request, rejecting it if it exceeds the limits.
+func (s *limitedStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	ip := clientIP(s.Context())
+	serial := requestSerial(m)
+	if !s.limiter.Allow(ip, serial) {
+		log.Warningf("Rate limited %v from %v for serial# %v", s.method, ip, serial)
+		return status.Errorf(codes.ResourceExhausted, "rate limit exceeded, retry later")
+	}
+	return nil
+}
+
+// clientIP returns the IP address of the peer of the request.
+func clientIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
+
+// requestSerial returns the serial number of the chassis, or of its first control card, a request is made for.
+func requestSerial(req any) string {
+	var desc *bpb.ChassisDescriptor
+	switch r := req.(type) {
+	case *bpb.GetBootstrapDataRequest:
+		desc = r.GetChassisDescriptor()
+	case *bpb.ReportCapabilitiesRequest:
+		desc = r.GetChassisDescriptor()
+	case *bpb.ReportStatusRequest:
+		if len(r.GetStates()) > 0 {
+			return r.GetStates()[0].GetSerialNumber()
+		}
+		return ""
+	default:
+		return ""
+	}
+	if desc.GetSerialNumber() != "" {
+		return desc.GetSerialNumber()
+	}
+	if len(desc.GetControlCards()) > 0 {
+		return desc.GetControlCards()[0].GetSerialNumber()
+	}
+	return ""
+}
@@ -0,0 +1,162 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	bpb "github.com/openconfig/bootz/proto/bootz"
+)
+
+func TestAllow(t *testing.T) {
+	l := New(Limits{PerIP: 1, PerSerial: 0.5, Burst: 2})
+	now := time.Unix(0, 0)
+	l.now = func() time.Time { return now }
+
+	steps := []struct {
+		desc    string
+		advance time.Duration
+		ip      string
+		serial  string
+		want    bool
+	}{
+		{desc: "First request", ip: "10.0.0.1", serial: "123", want: true},
+		{desc: "Burst", ip: "10.0.0.1", serial: "123", want: true},
+		{desc: "IP exhausted", ip: "10.0.0.1", serial: "456", want: false},
+		{desc: "Other IP, serial exhausted", ip: "10.0.0.2", serial: "123", want: false},
+		{desc: "Other IP and serial", ip: "10.0.0.2", serial: "456", want: true},
+		{desc: "IP refilled, serial still exhausted", advance: time.Second, ip: "10.0.0.1", serial: "123", want: false},
+		{desc: "Serial refilled", advance: 2 * time.Second, ip: "10.0.0.1", serial: "123", want: true},
+		{desc: "Unknown serial only limited per IP", ip: "10.0.0.3", want: true},
+	}
+	for _, s := range steps {
+		now = now.Add(s.advance)
+		if got := l.Allow(s.ip, s.serial); got != s.want {
+			t.Errorf("%s: Allow(%q, %q) = %v, want %v", s.desc, s.ip, s.serial, got, s.want)
+		}
+	}
+}
+
+func TestDisabled(t *testing.T) {
+	l := New(Limits{})
+	for i := 0; i < 100; i++ {
+		if !l.Allow("10.0.0.1", "123") {
+			t.Fatalf("Allow() = false after %d requests, want no limit", i)
+		}
+	}
+}
+
+func TestPrune(t *testing.T) {
+	l := newLimiter(1, 1)
+	now := time.Unix(0, 0)
+	l.allow("a", now)
+	l.allow("b", now.Add(time.Second/2))
+	l.prune(now.Add(time.Second))
+	if _, ok := l.buckets["a"]; ok {
+		t.Errorf("prune() kept the refilled bucket")
+	}
+	if _, ok := l.buckets["b"]; !ok {
+		t.Errorf("prune() dropped a bucket which is not refilled")
+	}
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	l := New(Limits{PerSerial: 1, Burst: 1})
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 1234}})
+	handler := func(ctx context.Context, req any) (any, error) {
+		return &bpb.EmptyResponse{}, nil
+	}
+	req := &bpb.GetBootstrapDataRequest{
+		ChassisDescriptor: &bpb.ChassisDescriptor{
+			ControlCards: []*bpb.ControlCard{{SerialNumber: "123A"}},
+		},
+	}
+	tests := []struct {
+		desc     string
+		method   string
+		wantCode codes.Code
+	}{{
+		desc:   "First request",
+		method: "/bootz.proto.Bootstrap/GetBootstrapData",
+	}, {
+		desc:     "Retry",
+		method:   "/bootz.proto.Bootstrap/GetBootstrapData",
+		wantCode: codes.ResourceExhausted,
+	}, {
+		desc:   "Other service",
+		method: "/admin.Admin/ListFeatureFlags",
+	}}
+	for _, test := range tests {
+		_, err := l.UnaryServerInterceptor(ctx, req, &grpc.UnaryServerInfo{FullMethod: test.method}, handler)
+		if status.Code(err) != test.wantCode {
+			t.Errorf("%s: UnaryServerInterceptor() err = %v, want code %v", test.desc, err, test.wantCode)
+		}
+	}
+	if got := clientIP(ctx); got != "10.0.0.1" {
+		t.Errorf("clientIP() = %q, want %q", got, "10.0.0.1")
+	}
+}
+
+// fakeStream receives a single bootstrap request.
+type fakeStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (f *fakeStream) Context() context.Context {
+	return f.ctx
+}
+
+func (f *fakeStream) RecvMsg(m any) error {
+	m.(*bpb.GetBootstrapDataRequest).ChassisDescriptor = &bpb.ChassisDescriptor{SerialNumber: "123"}
+	return nil
+}
+
+func TestStreamServerInterceptor(t *testing.T) {
+	l := New(Limits{PerSerial: 1, Burst: 1})
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 1234}})
+	handler := func(srv any, ss grpc.ServerStream) error {
+		return ss.RecvMsg(&bpb.GetBootstrapDataRequest{})
+	}
+	tests := []struct {
+		desc     string
+		method   string
+		wantCode codes.Code
+	}{{
+		desc:   "First request",
+		method: "/bootz.proto.Bootstrap/GetBootstrapDataStream",
+	}, {
+		desc:     "Retry",
+		method:   "/bootz.proto.Bootstrap/GetBootstrapDataStream",
+		wantCode: codes.ResourceExhausted,
+	}, {
+		desc:   "Other service",
+		method: "/admin.Admin/StreamBootstrapStatus",
+	}}
+	for _, test := range tests {
+		err := l.StreamServerInterceptor(nil, &fakeStream{ctx: ctx}, &grpc.StreamServerInfo{FullMethod: test.method}, handler)
+		if status.Code(err) != test.wantCode {
+			t.Errorf("%s: StreamServerInterceptor() err = %v, want code %v", test.desc, err, test.wantCode)
+		}
+	}
+}
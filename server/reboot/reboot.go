@@ -0,0 +1,72 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package reboot reboots devices over gNOI System.Reboot, so that devices
+// marked for re-provisioning bootstrap again without a visit to the site.
+package reboot
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+
+	spb "github.com/openconfig/gnoi/system"
+)
+
+// timeout bounds each reboot request, including connecting to the device.
+const timeout = 30 * time.Second
+
+// Options configures how the rebooter connects to devices.
+type Options struct {
+	// Port of the gNOI server of the devices.
+	Port string
+	// Credentials secure the connections to the devices.
+	Credentials credentials.TransportCredentials
+	// Username and Password authenticate the gNOI requests, if set.
+	Username string
+	Password string
+}
+
+// Rebooter cold reboots devices over gNOI.
+type Rebooter struct {
+	opts Options
+}
+
+// New returns a rebooter connecting to devices as configured by opts.
+func New(opts Options) *Rebooter {
+	return &Rebooter{opts: opts}
+}
+
+// Reboot cold reboots the device at addr, with message as the informational reason.
+func (r *Rebooter) Reboot(ctx context.Context, addr, message string) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	conn, err := grpc.DialContext(ctx, net.JoinHostPort(addr, r.opts.Port), grpc.WithTransportCredentials(r.opts.Credentials))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if r.opts.Username != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "username", r.opts.Username, "password", r.opts.Password)
+	}
+	_, err = spb.NewSystemClient(conn).Reboot(ctx, &spb.RebootRequest{
+		Method:  spb.RebootMethod_COLD,
+		Message: message,
+	})
+	return err
+}
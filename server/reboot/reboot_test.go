@@ -0,0 +1,92 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reboot
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/h-fam/errdiff"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	spb "github.com/openconfig/gnoi/system"
+)
+
+// fakeSystem records the reboot requests it accepts from the user admin.
+type fakeSystem struct {
+	spb.UnimplementedSystemServer
+	reboots []*spb.RebootRequest
+}
+
+func (f *fakeSystem) Reboot(ctx context.Context, req *spb.RebootRequest) (*spb.RebootResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if u := md.Get("username"); len(u) != 1 || u[0] != "admin" {
+		return nil, status.Errorf(codes.Unauthenticated, "unknown user")
+	}
+	f.reboots = append(f.reboots, req)
+	return &spb.RebootResponse{}, nil
+}
+
+func TestReboot(t *testing.T) {
+	tests := []struct {
+		desc     string
+		username string
+		wantErr  string
+	}{{
+		desc:     "Authenticated",
+		username: "admin",
+	}, {
+		desc:     "Unknown user",
+		username: "operator",
+		wantErr:  "unknown user",
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			lis, err := net.Listen("tcp", "localhost:0")
+			if err != nil {
+				t.Fatalf("net.Listen() err = %v", err)
+			}
+			f := &fakeSystem{}
+			s := grpc.NewServer()
+			spb.RegisterSystemServer(s, f)
+			go s.Serve(lis)
+			defer s.Stop()
+
+			host, port, err := net.SplitHostPort(lis.Addr().String())
+			if err != nil {
+				t.Fatalf("net.SplitHostPort() err = %v", err)
+			}
+			r := New(Options{Port: port, Credentials: insecure.NewCredentials(), Username: test.username, Password: "secret"})
+			err = r.Reboot(context.Background(), host, "RMA-123")
+			if diff := errdiff.Substring(err, test.wantErr); diff != "" {
+				t.Fatalf("Reboot() %s", diff)
+			}
+			if test.wantErr != "" {
+				return
+			}
+			if len(f.reboots) != 1 {
+				t.Fatalf("Reboot() sent %d reboot requests, want 1", len(f.reboots))
+			}
+			if got := f.reboots[0]; got.GetMethod() != spb.RebootMethod_COLD || got.GetMessage() != "RMA-123" {
+				t.Errorf("Reboot() sent method %v with message %q, want COLD with %q", got.GetMethod(), got.GetMessage(), "RMA-123")
+			}
+		})
+	}
+}
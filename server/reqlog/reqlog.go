@@ -0,0 +1,195 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package reqlog logs the full requests and responses of the gRPC services of
+// the server at debug level, to help debugging failed bootstraps. Fields
+// holding secrets, such as credentials and configs, are redacted.
+package reqlog
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	bpb "github.com/openconfig/bootz/proto/bootz"
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+	log "github.com/openconfig/bootz/server/logging"
+)
+
+const (
+	// defaultVerbosity is the glog verbosity requests and responses are logged at by default.
+	defaultVerbosity = 2
+	// redacted replaces the value of redacted string and bytes fields.
+	redacted = "<redacted>"
+)
+
+// DefaultRedactions are the fields always redacted.
+var DefaultRedactions = []protoreflect.FullName{
+	"bootz.proto.BootstrapDataResponse.credentials",
+	"bootz.proto.BootstrapDataResponse.boot_password_hash",
+	"bootz.proto.BootConfig.vendor_config",
+	"bootz.proto.BootConfig.oc_config",
+	"bootz.proto.BootConfig.gnmi_set_request",
+	"bootz.proto.BootstrapDataChunk.data",
+}
+
+// Logger logs redacted requests and responses.
+type Logger struct {
+	level  log.Level
+	redact map[protoreflect.FullName]bool
+}
+
+// New returns a logger redacting the default fields and the fields of cfg.
+func New(cfg *epb.RequestLogging) (*Logger, error) {
+	l := &Logger{
+		level:  log.Level(cfg.GetVerbosity()),
+		redact: map[protoreflect.FullName]bool{},
+	}
+	if l.level == 0 {
+		l.level = defaultVerbosity
+	}
+	for _, f := range DefaultRedactions {
+		l.redact[f] = true
+	}
+	for _, f := range cfg.GetRedactFields() {
+		d, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(f))
+		if err != nil {
+			return nil, fmt.Errorf("unknown redacted field %q: %v", f, err)
+		}
+		if _, ok := d.(protoreflect.FieldDescriptor); !ok {
+			return nil, fmt.Errorf("redacted %q is not a field", f)
+		}
+		l.redact[d.FullName()] = true
+	}
+	return l, nil
+}
+
+// Redact returns a copy of m with the redacted fields replaced. String and bytes fields are
+// replaced with a placeholder, the fields of messages are redacted recursively and other
+// fields are cleared.
+func (l *Logger) Redact(m proto.Message) proto.Message {
+	m = proto.Clone(m)
+	l.redactMessage(m.ProtoReflect(), false)
+	return m
+}
+
+// redactMessage redacts the redacted fields of m, or all of its fields if all is set.
+func (l *Logger) redactMessage(m protoreflect.Message, all bool) {
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		redact := all || l.redact[fd.FullName()]
+		switch {
+		case fd.IsList():
+			if fd.Message() != nil {
+				list := v.List()
+				for i := 0; i < list.Len(); i++ {
+					l.redactMessage(list.Get(i).Message(), redact)
+				}
+			} else if redact {
+				m.Clear(fd)
+			}
+		case fd.IsMap():
+			if fd.MapValue().Message() != nil {
+				v.Map().Range(func(_ protoreflect.MapKey, v protoreflect.Value) bool {
+					l.redactMessage(v.Message(), redact)
+					return true
+				})
+			} else if redact {
+				m.Clear(fd)
+			}
+		case fd.Message() != nil:
+			l.redactMessage(v.Message(), redact)
+		case !redact:
+		case fd.Kind() == protoreflect.StringKind:
+			m.Set(fd, protoreflect.ValueOfString(redacted))
+		case fd.Kind() == protoreflect.BytesKind:
+			m.Set(fd, protoreflect.ValueOfBytes([]byte(redacted)))
+		default:
+			m.Clear(fd)
+		}
+		return true
+	})
+}
+
+// logMessage logs a redacted request or response of method.
+func (l *Logger) logMessage(method, kind string, msg any) {
+	m, ok := msg.(proto.Message)
+	if !ok {
+		return
+	}
+	log.Infof("%s %s: %s", method, kind, prototext.MarshalOptions{}.Format(l.Redact(m)))
+	// The signed bootstrap data is serialized, so it is decoded to be redacted.
+	if resp, ok := m.(*bpb.GetBootstrapDataResponse); ok {
+		data := &bpb.BootstrapDataSigned{}
+		if err := proto.Unmarshal(resp.GetSerializedBootstrapData(), data); err == nil {
+			log.Infof("%s %s bootstrap data: %s", method, kind, prototext.MarshalOptions{}.Format(l.Redact(data)))
+		}
+	}
+}
+
+// UnaryServerInterceptor logs the requests and responses of unary RPCs.
+func (l *Logger) UnaryServerInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if !log.V(l.level) {
+		return handler(ctx, req)
+	}
+	l.logMessage(info.FullMethod, "request", req)
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	if err != nil {
+		log.Infof("%s failed after %v: %v", info.FullMethod, time.Since(start), err)
+		return resp, err
+	}
+	l.logMessage(info.FullMethod, "response", resp)
+	return resp, nil
+}
+
+// StreamServerInterceptor logs the messages received and sent on streams.
+func (l *Logger) StreamServerInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if !log.V(l.level) {
+		return handler(srv, ss)
+	}
+	start := time.Now()
+	err := handler(srv, &loggedStream{ServerStream: ss, logger: l, method: info.FullMethod})
+	if err != nil {
+		log.Infof("%s failed after %v: %v", info.FullMethod, time.Since(start), err)
+	}
+	return err
+}
+
+// loggedStream logs the messages of a stream.
+type loggedStream struct {
+	grpc.ServerStream
+	logger *Logger
+	method string
+}
+
+// RecvMsg logs the messages received.
+func (s *loggedStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	s.logger.logMessage(s.method, "request", m)
+	return nil
+}
+
+// SendMsg logs the messages sent.
+func (s *loggedStream) SendMsg(m any) error {
+	s.logger.logMessage(s.method, "response", m)
+	return s.ServerStream.SendMsg(m)
+}
@@ -0,0 +1,238 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package retirement tracks the control cards and fixed chassis retired from
+// the inventory, e.g. returned to their vendor (RMA) or transferred to another
+// owner.
+//
+// Retired devices are refused bootstrap data. Their inventory entry, bootstrap
+// state and last reported status are archived with them, so their history
+// outlives their place in the inventory.
+package retirement
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/openconfig/bootz/server/devicestate"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	bpb "github.com/openconfig/bootz/proto/bootz"
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+	log "github.com/openconfig/bootz/server/logging"
+)
+
+// Entry describes a retired device.
+type Entry struct {
+	SerialNumber string
+	Reason       string
+	RetiredAt    time.Time
+	// ReplacedBy is the serial number of the device which took its place, if any.
+	ReplacedBy string
+	// Chassis is the chassis of the device as it was in the inventory when retired.
+	Chassis *epb.Chassis
+	// State is the bootstrap state of the device when retired, or nil if it had none.
+	State *devicestate.Device
+	// Status is the status the device last reported.
+	Status bpb.ControlCardState_ControlCardStatus
+}
+
+// record is the persisted form of an entry.
+type record struct {
+	SerialNumber string              `json:"serial_number"`
+	Reason       string              `json:"reason,omitempty"`
+	RetiredAt    time.Time           `json:"retired_at"`
+	ReplacedBy   string              `json:"replaced_by,omitempty"`
+	Chassis      json.RawMessage     `json:"chassis,omitempty"`
+	State        *devicestate.Device `json:"state,omitempty"`
+	Status       string              `json:"status,omitempty"`
+}
+
+// List holds the retired devices. It is safe for concurrent use.
+type List struct {
+	mu      sync.RWMutex
+	devices map[string]*Entry
+	// path of the file the retired devices are persisted to, if any.
+	path string
+}
+
+// New returns an empty list of retired devices.
+func New() *List {
+	return &List{
+		devices: map[string]*Entry{},
+	}
+}
+
+// Persist loads the retired devices saved to path, if it exists, and saves the retired devices to
+// it on every change.
+func (l *List) Persist(path string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	data, err := os.ReadFile(path)
+	switch {
+	case os.IsNotExist(err):
+	case err != nil:
+		return fmt.Errorf("unable to read retired device file: %v", err)
+	default:
+		var records []record
+		if err := json.Unmarshal(data, &records); err != nil {
+			return fmt.Errorf("unable to parse retired device file %s: %v", path, err)
+		}
+		for _, r := range records {
+			e := &Entry{
+				SerialNumber: r.SerialNumber,
+				Reason:       r.Reason,
+				RetiredAt:    r.RetiredAt,
+				ReplacedBy:   r.ReplacedBy,
+				State:        r.State,
+				Status:       bpb.ControlCardState_ControlCardStatus(bpb.ControlCardState_ControlCardStatus_value[r.Status]),
+			}
+			if len(r.Chassis) > 0 {
+				e.Chassis = &epb.Chassis{}
+				if err := protojson.Unmarshal(r.Chassis, e.Chassis); err != nil {
+					return fmt.Errorf("unable to parse the chassis of retired serial# %s in %s: %v", r.SerialNumber, path, err)
+				}
+			}
+			l.devices[e.SerialNumber] = e
+		}
+		log.Infof("Loaded %d retired devices from %s", len(records), path)
+	}
+	l.path = path
+	return nil
+}
+
+// Retire adds the device of e to the list. Devices can only be retired once. The device is not
+// added if the retired devices can't be saved to the persistence file.
+func (l *List) Retire(e Entry) error {
+	if e.SerialNumber == "" {
+		return fmt.Errorf("no serial number provided")
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, ok := l.devices[e.SerialNumber]; ok {
+		return fmt.Errorf("serial# %s is already retired", e.SerialNumber)
+	}
+	l.devices[e.SerialNumber] = &e
+	if err := l.save(); err != nil {
+		delete(l.devices, e.SerialNumber)
+		return err
+	}
+	return nil
+}
+
+// Persisted reports whether the retired devices are persisted to a file.
+func (l *List) Persisted() bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.path != ""
+}
+
+// save writes the retired devices to the persistence file, if any. The caller must hold l.mu.
+func (l *List) save() error {
+	if l.path == "" {
+		return nil
+	}
+	records := []record{}
+	for _, e := range l.list() {
+		r := record{
+			SerialNumber: e.SerialNumber,
+			Reason:       e.Reason,
+			RetiredAt:    e.RetiredAt,
+			ReplacedBy:   e.ReplacedBy,
+			State:        e.State,
+		}
+		if e.Status != bpb.ControlCardState_CONTROL_CARD_STATUS_UNSPECIFIED {
+			r.Status = e.Status.String()
+		}
+		if e.Chassis != nil {
+			chassis, err := protojson.Marshal(e.Chassis)
+			if err != nil {
+				return fmt.Errorf("unable to encode the chassis of retired serial# %s: %v", e.SerialNumber, err)
+			}
+			r.Chassis = chassis
+		}
+		records = append(records, r)
+	}
+	data, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to encode retired devices: %v", err)
+	}
+	// Write to a temporary file first so a crash never leaves a truncated file.
+	tmp, err := os.CreateTemp(filepath.Dir(l.path), filepath.Base(l.path)+".*")
+	if err != nil {
+		return fmt.Errorf("unable to save retired devices: %v", err)
+	}
+	defer os.Remove(tmp.Name())
+	_, err = tmp.Write(data)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), l.path)
+	}
+	if err != nil {
+		return fmt.Errorf("unable to save retired devices: %v", err)
+	}
+	return nil
+}
+
+// IsRetired reports whether the device with serial is retired.
+func (l *List) IsRetired(serial string) bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	_, ok := l.devices[serial]
+	return ok
+}
+
+// Entries returns all retired devices sorted by serial number.
+func (l *List) Entries() []Entry {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	var out []Entry
+	for _, e := range l.list() {
+		out = append(out, *e)
+	}
+	return out
+}
+
+// Restore replaces the retired devices with entries, e.g. restored from a backup.
+func (l *List) Restore(entries []Entry) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.devices = map[string]*Entry{}
+	for _, e := range entries {
+		e := e
+		l.devices[e.SerialNumber] = &e
+	}
+	if err := l.save(); err != nil {
+		log.Errorf("%v", err)
+	}
+}
+
+// list returns the entries sorted by serial number. The caller must hold l.mu.
+func (l *List) list() []*Entry {
+	out := make([]*Entry, 0, len(l.devices))
+	for _, e := range l.devices {
+		out = append(out, e)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].SerialNumber < out[j].SerialNumber
+	})
+	return out
+}
@@ -0,0 +1,119 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retirement
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/h-fam/errdiff"
+	"github.com/openconfig/bootz/server/devicestate"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	bpb "github.com/openconfig/bootz/proto/bootz"
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+)
+
+func TestRetire(t *testing.T) {
+	l := New()
+	tests := []struct {
+		desc    string
+		entry   Entry
+		wantErr string
+	}{{
+		desc:  "Retire",
+		entry: Entry{SerialNumber: "123A", Reason: "RMA", ReplacedBy: "789A"},
+	}, {
+		desc:  "Retire without replacement",
+		entry: Entry{SerialNumber: "456"},
+	}, {
+		desc:    "Already retired",
+		entry:   Entry{SerialNumber: "123A"},
+		wantErr: "already retired",
+	}, {
+		desc:    "Missing serial number",
+		entry:   Entry{},
+		wantErr: "no serial number",
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			err := l.Retire(test.entry)
+			if diff := errdiff.Substring(err, test.wantErr); diff != "" {
+				t.Errorf("Retire() %s", diff)
+			}
+		})
+	}
+	if !l.IsRetired("123A") || l.IsRetired("789A") {
+		t.Errorf("IsRetired() of 123A, 789A = %v, %v, want true, false", l.IsRetired("123A"), l.IsRetired("789A"))
+	}
+	var got []string
+	for _, e := range l.Entries() {
+		got = append(got, e.SerialNumber)
+	}
+	if diff := cmp.Diff([]string{"123A", "456"}, got); diff != "" {
+		t.Errorf("Entries() diff (-want +got):\n%s", diff)
+	}
+	if got := l.Entries()[0].Reason; got != "RMA" {
+		t.Errorf("Retire() replaced the entry of a retired device, reason = %q, want %q", got, "RMA")
+	}
+
+	l.Restore([]Entry{{SerialNumber: "999"}})
+	if l.IsRetired("123A") || !l.IsRetired("999") {
+		t.Errorf("Restore() did not replace the retired devices")
+	}
+}
+
+func TestPersist(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "retired.json")
+	l := New()
+	if err := l.Persist(path); err != nil {
+		t.Fatalf("Persist() err = %v, want nil", err)
+	}
+	retired := Entry{
+		SerialNumber: "123A",
+		Reason:       "RMA",
+		RetiredAt:    time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC),
+		ReplacedBy:   "789A",
+		Chassis:      &epb.Chassis{SerialNumber: "123", Manufacturer: "Cisco"},
+		State:        &devicestate.Device{SerialNumber: "123A", State: devicestate.StatusOK},
+		Status:       bpb.ControlCardState_CONTROL_CARD_STATUS_INITIALIZED,
+	}
+	if err := l.Retire(retired); err != nil {
+		t.Fatalf("Retire() err = %v, want nil", err)
+	}
+	if err := l.Retire(Entry{SerialNumber: "456"}); err != nil {
+		t.Fatalf("Retire() err = %v, want nil", err)
+	}
+
+	// A restart must keep refusing the retired devices.
+	restarted := New()
+	if err := restarted.Persist(path); err != nil {
+		t.Fatalf("Persist() of the saved retired devices err = %v, want nil", err)
+	}
+	if diff := cmp.Diff(l.Entries(), restarted.Entries(), protocmp.Transform()); diff != "" {
+		t.Errorf("Entries() after restart diff (-want +got):\n%s", diff)
+	}
+
+	restarted.Restore([]Entry{{SerialNumber: "999"}})
+	restored := New()
+	if err := restored.Persist(path); err != nil {
+		t.Fatalf("Persist() of the restored retired devices err = %v, want nil", err)
+	}
+	if restored.IsRetired("123A") || !restored.IsRetired("999") {
+		t.Errorf("Persist() did not load the restored retired devices")
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package revocation tracks revoked ownership vouchers.
+//
+// Vouchers can be revoked individually by the serial number they were issued
+// for, or wholesale through a CRL of the vendor CA revoking the signing certificate.
+package revocation
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Entry describes a revoked ownership voucher.
+type Entry struct {
+	SerialNumber string
+	Reason       string
+	RevokedAt    time.Time
+}
+
+// List holds the revoked ownership vouchers. It is safe for concurrent use.
+type List struct {
+	mu      sync.RWMutex
+	serials map[string]*Entry
+	crl     *x509.RevocationList
+}
+
+// New returns an empty revocation list.
+func New() *List {
+	return &List{
+		serials: map[string]*Entry{},
+	}
+}
+
+// Revoke marks the voucher issued for serial as revoked.
+func (l *List) Revoke(serial, reason string) error {
+	if serial == "" {
+		return fmt.Errorf("no serial number provided")
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.serials[serial] = &Entry{
+		SerialNumber: serial,
+		Reason:       reason,
+		RevokedAt:    time.Now(),
+	}
+	return nil
+}
+
+// IsRevoked reports whether the voucher issued for serial is revoked.
+func (l *List) IsRevoked(serial string) bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	_, ok := l.serials[serial]
+	return ok
+}
+
+// Entries returns all revoked vouchers sorted by serial number.
+func (l *List) Entries() []Entry {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	var out []Entry
+	for _, e := range l.serials {
+		out = append(out, *e)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].SerialNumber < out[j].SerialNumber
+	})
+	return out
+}
+
+// Restore replaces the vouchers revoked by serial number with entries, e.g. restored from a
+// backup. The CRL is kept.
+func (l *List) Restore(entries []Entry) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.serials = map[string]*Entry{}
+	for _, e := range entries {
+		e := e
+		l.serials[e.SerialNumber] = &e
+	}
+}
+
+// CRL returns the vendor CA revocation list, or nil if none is loaded.
+func (l *List) CRL() *x509.RevocationList {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.crl
+}
+
+// LoadFile reads revoked serial numbers from path. Each line holds a serial number
+// optionally followed by a reason. Empty lines and lines starting with '#' are ignored.
+func (l *List) LoadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("unable to read revocation file: %v", err)
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		serial, reason, _ := strings.Cut(line, " ")
+		if err := l.Revoke(serial, strings.TrimSpace(reason)); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// LoadCRL reads a PEM or DER encoded CRL from path and checks that it is signed by issuer.
+func (l *List) LoadCRL(path string, issuer *x509.Certificate) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("unable to read CRL file: %v", err)
+	}
+	if block, _ := pem.Decode(data); block != nil {
+		data = block.Bytes
+	}
+	crl, err := x509.ParseRevocationList(data)
+	if err != nil {
+		return fmt.Errorf("unable to parse CRL: %v", err)
+	}
+	if err := crl.CheckSignatureFrom(issuer); err != nil {
+		return fmt.Errorf("CRL is not signed by %q: %v", issuer.Subject, err)
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.crl = crl
+	return nil
+}
@@ -0,0 +1,71 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package revocation
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestLoadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "revoked.txt")
+	content := `# Revoked ownership vouchers.
+123A lost in transit
+
+123B
+`
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("unable to write revocation file: %v", err)
+	}
+	l := New()
+	if err := l.LoadFile(path); err != nil {
+		t.Fatalf("LoadFile() err = %v, want nil", err)
+	}
+	want := []Entry{
+		{SerialNumber: "123A", Reason: "lost in transit"},
+		{SerialNumber: "123B"},
+	}
+	if diff := cmp.Diff(want, l.Entries(), cmpopts.IgnoreFields(Entry{}, "RevokedAt")); diff != "" {
+		t.Errorf("Entries() diff (-want, +got):\n%s", diff)
+	}
+	for serial, want := range map[string]bool{"123A": true, "123B": true, "123C": false} {
+		if got := l.IsRevoked(serial); got != want {
+			t.Errorf("IsRevoked(%q) = %v, want %v", serial, got, want)
+		}
+	}
+	if err := l.LoadFile(filepath.Join(t.TempDir(), "missing.txt")); err == nil {
+		t.Errorf("LoadFile() of missing file err = nil, want error")
+	}
+}
+
+func TestRevoke(t *testing.T) {
+	l := New()
+	if err := l.Revoke("", "no serial"); err == nil {
+		t.Errorf("Revoke() with empty serial err = nil, want error")
+	}
+	if err := l.Revoke("123A", "decommissioned"); err != nil {
+		t.Fatalf("Revoke() err = %v, want nil", err)
+	}
+	if !l.IsRevoked("123A") {
+		t.Errorf("IsRevoked(%q) = false, want true", "123A")
+	}
+	if got := l.Entries(); len(got) != 1 || got[0].RevokedAt.IsZero() {
+		t.Errorf("Entries() = %v, want a single entry with a revocation time", got)
+	}
+}
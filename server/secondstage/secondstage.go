@@ -0,0 +1,133 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package secondstage applies a second stage configuration to devices over
+// gNMI once they report a successful bootstrap, closing the zero touch
+// provisioning loop.
+package secondstage
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+
+	bpb "github.com/openconfig/bootz/proto/bootz"
+	log "github.com/openconfig/bootz/server/logging"
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+const (
+	// attempts is the number of times the config is pushed before giving up, as the gNMI
+	// server of the device may still be starting when it reports its bootstrap status.
+	attempts = 5
+	// timeout bounds each attempt to push the config.
+	timeout = 30 * time.Second
+)
+
+// ConfigSource returns the second stage configs of devices.
+type ConfigSource interface {
+	// SecondStageConfig returns the SetRequest to apply to the chassis of the control card or
+	// fixed chassis with the given serial, or nil if none is configured.
+	SecondStageConfig(serial string) (*gpb.SetRequest, error)
+}
+
+// Options configures how the pusher connects to devices.
+type Options struct {
+	// Port of the gNMI server of the devices.
+	Port string
+	// Credentials secure the connections to the devices.
+	Credentials credentials.TransportCredentials
+	// Username and Password authenticate the Set requests, if set.
+	Username string
+	Password string
+}
+
+// Pusher pushes the second stage config to devices reporting a successful bootstrap.
+type Pusher struct {
+	src  ConfigSource
+	opts Options
+	// backoff is the wait before the first retry, doubled on each further retry.
+	backoff time.Duration
+}
+
+// New returns a pusher applying the configs of src.
+func New(src ConfigSource, opts Options) *Pusher {
+	return &Pusher{
+		src:     src,
+		opts:    opts,
+		backoff: 10 * time.Second,
+	}
+}
+
+// OnStatus pushes the second stage config in the background if the report is a success. It is
+// meant to be registered as a status hook of the bootstrap service.
+func (p *Pusher) OnStatus(req *bpb.ReportStatusRequest, addr string) {
+	if req.GetStatus() != bpb.ReportStatusRequest_BOOTSTRAP_STATUS_SUCCESS || len(req.GetStates()) == 0 {
+		return
+	}
+	// All states of a report belong to the same chassis, which is configured once.
+	serial := req.GetStates()[0].GetSerialNumber()
+	setReq, err := p.src.SecondStageConfig(serial)
+	if err != nil {
+		log.Errorf("Unable to read second stage config of serial# %v: %v", serial, err)
+		return
+	}
+	if setReq == nil {
+		return
+	}
+	if addr == "" {
+		log.Errorf("Unable to push second stage config to serial# %v: device address unknown", serial)
+		return
+	}
+	go func() {
+		if err := p.push(context.Background(), net.JoinHostPort(addr, p.opts.Port), setReq); err != nil {
+			log.Errorf("Unable to push second stage config to serial# %v at %v: %v", serial, addr, err)
+			return
+		}
+		log.Infof("Pushed second stage config to serial# %v at %v", serial, addr)
+	}()
+}
+
+// push applies the SetRequest to the gNMI server at target, retrying failed attempts with
+// exponential backoff.
+func (p *Pusher) push(ctx context.Context, target string, req *gpb.SetRequest) error {
+	conn, err := grpc.DialContext(ctx, target, grpc.WithTransportCredentials(p.opts.Credentials))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	c := gpb.NewGNMIClient(conn)
+	if p.opts.Username != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "username", p.opts.Username, "password", p.opts.Password)
+	}
+	backoff := p.backoff
+	for i := 0; i < attempts; i++ {
+		if i > 0 {
+			log.Warningf("Second stage config push to %v failed, retrying in %v: %v", target, backoff, err)
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+		attemptCtx, cancel := context.WithTimeout(ctx, timeout)
+		_, err = c.Set(attemptCtx, req, grpc.WaitForReady(true))
+		cancel()
+		if err == nil {
+			return nil
+		}
+	}
+	return err
+}
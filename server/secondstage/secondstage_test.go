@@ -0,0 +1,109 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secondstage
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	bpb "github.com/openconfig/bootz/proto/bootz"
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+)
+
+// fakeGNMI records the Set requests it receives, failing the first failures ones.
+type fakeGNMI struct {
+	gpb.UnimplementedGNMIServer
+	failures int
+	sets     chan *gpb.SetRequest
+	users    chan string
+}
+
+func (f *fakeGNMI) Set(ctx context.Context, req *gpb.SetRequest) (*gpb.SetResponse, error) {
+	if f.failures > 0 {
+		f.failures--
+		return nil, status.Errorf(codes.Unavailable, "not ready")
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	f.users <- fmt.Sprint(md.Get("username"))
+	f.sets <- req
+	return &gpb.SetResponse{}, nil
+}
+
+type configs map[string]*gpb.SetRequest
+
+func (c configs) SecondStageConfig(serial string) (*gpb.SetRequest, error) {
+	return c[serial], nil
+}
+
+func TestOnStatus(t *testing.T) {
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("net.Listen() err = %v", err)
+	}
+	fake := &fakeGNMI{failures: 1, sets: make(chan *gpb.SetRequest, 1), users: make(chan string, 1)}
+	s := grpc.NewServer()
+	gpb.RegisterGNMIServer(s, fake)
+	go s.Serve(lis)
+	defer s.Stop()
+
+	host, port, _ := net.SplitHostPort(lis.Addr().String())
+	want := &gpb.SetRequest{Update: []*gpb.Update{{
+		Path: &gpb.Path{Elem: []*gpb.PathElem{{Name: "system"}}},
+		Val:  &gpb.TypedValue{Value: &gpb.TypedValue_JsonIetfVal{JsonIetfVal: []byte(`{}`)}},
+	}}}
+	p := New(configs{"123A": want}, Options{
+		Port:        port,
+		Credentials: insecure.NewCredentials(),
+		Username:    "admin",
+	})
+	p.backoff = time.Millisecond
+
+	report := func(st bpb.ReportStatusRequest_BootstrapStatus, serial string) {
+		p.OnStatus(&bpb.ReportStatusRequest{
+			Status: st,
+			States: []*bpb.ControlCardState{{SerialNumber: serial}},
+		}, host)
+	}
+	report(bpb.ReportStatusRequest_BOOTSTRAP_STATUS_INITIATED, "123A")
+	report(bpb.ReportStatusRequest_BOOTSTRAP_STATUS_SUCCESS, "456")
+	report(bpb.ReportStatusRequest_BOOTSTRAP_STATUS_SUCCESS, "123A")
+
+	select {
+	case got := <-fake.sets:
+		if !proto.Equal(got, want) {
+			t.Errorf("OnStatus() pushed %v, want %v", got, want)
+		}
+		if user := <-fake.users; user != "[admin]" {
+			t.Errorf("OnStatus() authenticated as %v, want [admin]", user)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatalf("OnStatus() pushed no config")
+	}
+	select {
+	case got := <-fake.sets:
+		t.Errorf("OnStatus() pushed unexpected config %v", got)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
@@ -20,45 +20,159 @@
 package main
 
 import (
+	"archive/zip"
+	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/pem"
 	"flag"
 	"fmt"
+	"io"
+	"io/fs"
 	"net"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
-	log "github.com/golang/glog"
+	"github.com/openconfig/bootz/common/fips"
 	"github.com/openconfig/bootz/dhcp"
+	"github.com/openconfig/bootz/server/admin"
+	"github.com/openconfig/bootz/server/alert"
+	"github.com/openconfig/bootz/server/artifactsync"
+	"github.com/openconfig/bootz/server/auth"
+	"github.com/openconfig/bootz/server/bundle"
 	"github.com/openconfig/bootz/server/entitymanager"
+	"github.com/openconfig/bootz/server/events"
+	"github.com/openconfig/bootz/server/faultinject"
+	"github.com/openconfig/bootz/server/gateway"
+	"github.com/openconfig/bootz/server/imageserver"
+	"github.com/openconfig/bootz/server/keystore"
+	"github.com/openconfig/bootz/server/kube"
+	log "github.com/openconfig/bootz/server/logging"
+	"github.com/openconfig/bootz/server/metrics"
+	"github.com/openconfig/bootz/server/osinstall"
+	"github.com/openconfig/bootz/server/ratelimit"
+	"github.com/openconfig/bootz/server/reboot"
+	"github.com/openconfig/bootz/server/reqlog"
+	"github.com/openconfig/bootz/server/secondstage"
 	"github.com/openconfig/bootz/server/service"
+	"github.com/openconfig/bootz/server/webhook"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	// Registers the gzip compressor, so devices can opt in to compressed responses.
+	_ "google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/reflection"
 
 	bpb "github.com/openconfig/bootz/proto/bootz"
+	apb "github.com/openconfig/bootz/server/admin/proto/admin"
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+	hpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
 var (
-	port              = flag.String("port", "15006", "The port to start the Bootz server on localhost")
-	dhcpIntf          = flag.String("dhcp_intf", "", "Network interface to use for dhcp server.")
-	artifactDirectory = flag.String("artifact_dir", "../testdata/", "The relative directory to look into for certificates, private keys and OVs.")
-	inventoryConfig   = flag.String("inv_config", "../testdata/inventory_local.prototxt", "Devices' config files to be loaded by inventory manager")
+	port               = flag.String("port", "15006", "The port to start the Bootz server on localhost")
+	bindAddress        = flag.String("bind_address", "localhost", "The address the bootstrap, gateway, image and metrics ports listen on. Set it to an empty string to listen on every interface, e.g. in containers.")
+	adminBindAddress   = flag.String("admin_bind_address", "localhost", "The address the admin port listens on. Addresses other than loopback ones, such as an empty string to listen on every interface, require admin_client_ca.")
+	dhcpIntf           = flag.String("dhcp_intf", "", "Network interface to use for dhcp server.")
+	artifactDirectory  = flag.String("artifact_dir", "../testdata/", "The relative directory, or zip archive, to look into for certificates, private keys and OVs.")
+	inventoryConfig    = flag.String("inv_config", "../testdata/inventory_local.prototxt", "Devices' config files to be loaded by inventory manager")
+	httpPort           = flag.String("http_port", "", "The port to serve the HTTP/JSON gateway on localhost. The gateway is disabled if unset.")
+	rateLimitPerIP     = flag.Float64("rate_limit_per_ip", 0, "Sustained bootstrap requests per second allowed from a client IP. 0 disables the limit.")
+	rateLimitPerSerial = flag.Float64("rate_limit_per_serial", 0, "Sustained bootstrap requests per second allowed for a serial number. 0 disables the limit.")
+	ocKeyURI           = flag.String("oc_key_uri", "", "URI of the OC private key in a key store, e.g. file:///etc/bootz/oc_priv.pem. If set, oc_priv.pem is not read from artifact_dir.")
+	pdcKeyURI          = flag.String("pdc_key_uri", "", "URI of the PDC private key in a key store. If set, pdc_priv.pem is not read from artifact_dir.")
+	rateLimitBurst     = flag.Int("rate_limit_burst", 10, "Bootstrap requests allowed above the sustained rate limits.")
+	webhookURLs        = flag.String("webhook_urls", "", "Comma separated URLs to POST bootstrap events to as JSON. Webhooks are disabled if unset.")
+	secondStagePort    = flag.String("second_stage_gnmi_port", "", "The gNMI port of devices to push their second stage config to once they report a successful bootstrap. Second stage configs are not pushed if unset.")
+	secondStageCACert  = flag.String("second_stage_ca_cert", "", "The path to a PEM encoded CA certificate verifying the gNMI servers of devices. The system roots are used if unset.")
+	secondStageUser    = flag.String("second_stage_username", "", "The username authenticating second stage config pushes.")
+	secondStagePass    = flag.String("second_stage_password", "", "The password authenticating second stage config pushes.")
+	osInstallPort      = flag.String("os_install_gnoi_port", "", "The gNOI port of devices to install their intended image on when they report a successful bootstrap running another version. Connections are secured and authenticated as second stage config pushes. Versions are not converged if unset.")
+	rebootPort         = flag.String("reboot_gnoi_port", "", "The gNOI port of devices to reboot when the TriggerRebootstrap admin RPC asks for it. Connections are secured and authenticated as second stage config pushes. Devices are not rebooted if unset.")
+	adminPort          = flag.String("admin_port", "", "The port to serve the admin API on, at admin_bind_address. The admin API is disabled if unset, as it is never served on the bootstrap port.")
+	adminCert          = flag.String("admin_cert", "", "The path to a PEM encoded TLS certificate for the admin port. The PDC derived TLS certificate is used if unset.")
+	adminKey           = flag.String("admin_key", "", "The path to the PEM encoded private key of admin_cert.")
+	adminClientCA      = flag.String("admin_client_ca", "", "The path to a PEM encoded CA certificate operators' client certificates must be signed by to call the admin API. Client certificates are not required if unset.")
+	restoreSnapshot    = flag.String("restore_snapshot", "", "The path to a binary encoded snapshot, as returned by the ExportSnapshot admin RPC, to restore the chassis and device states of at startup.")
+	dryRun             = flag.Bool("dry_run", false, "Whether to serve placeholders instead of the configs, credentials and images of bootstrap data, while resolving, validating and signing requests as usual.")
+	generateArtifacts  = flag.Bool("generate_artifacts", false, "Whether to generate an ephemeral vendor CA, PDC, OC, TLS certificate and OVs for the inventory in memory instead of reading them from artifact_dir.")
+	imageDir           = flag.String("image_dir", "", "The directory of software images to serve over HTTPS to bootstrapping devices. The image server is disabled if unset.")
+	imagePort          = flag.String("image_port", "15007", "The port to serve the images of image_dir on localhost.")
+	imageBaseURL       = flag.String("image_base_url", "", "The URL devices reach the image server at, e.g. https://bootz.example.com:15007. Software image URLs starting with it are signed when served. Defaults to https://localhost:<image_port>.")
+	imageURLTTL        = flag.Duration("image_url_ttl", time.Hour, "How long the signed image URLs served to devices are valid for.")
+	imageURLKeyFile    = flag.String("image_url_key_file", "", "The path to a file holding the HMAC key of at least 32 bytes signing image URLs, shared by the replicas of the server. A key is generated at startup if unset.")
+	clusterID          = flag.String("cluster_id", "", "The ID the server campaigns for the leadership of its cluster with, naming it to admins sending inventory changes to other instances, e.g. the address of its admin API. Defaults to <hostname>:<admin_port>, or <hostname>:<port> if admin_port is unset.")
+	unixSocket         = flag.String("unix_socket", "", "The path of a unix domain socket to also serve the bootstrap service on, e.g. for relays running on the same host. The socket is not served if unset.")
+	reloadOnChange     = flag.Bool("reload_on_change", false, "Whether to reload the inventory, the security artifacts and the admin TLS certificate when their files change, e.g. when the Kubernetes ConfigMaps and Secrets they are mounted from are updated.")
+	webhookEvents      = flag.String("webhook_events", "requested,succeeded,failed", "Comma separated bootstrap events to POST to the webhook URLs, out of requested, served, rejected, initiated, succeeded and failed.")
+	faultInjection     = flag.String("fault_injection", "", "The path to a text encoded entity.FaultInjection of latency, dropped responses and corrupted signatures to inject in bootstrap responses, for testing the error handling of clients. Never set it in production.")
+	logFormat          = flag.String("log_format", log.FormatGlog, "The format of the log output: glog to log through glog, configured with its own flags, or text or json to log a structured record per message to stderr, e.g. for ELK or Loki.")
+	metricsPort        = flag.String("metrics_port", "", "The port to serve the Prometheus metrics of the RPCs on localhost over plain HTTP, at /metrics. Metrics are not served if unset.")
+	artifactSync       = flag.String("artifact_sync", "", "The path to a text encoded entity.ArtifactSync of S3 and GCS buckets to mirror to local directories at startup and at its refresh interval, e.g. the ownership vouchers, images and configs of a central artifact store. Nothing is mirrored if unset.")
+	bundleFile         = flag.String("bundle", "", "The path to a provisioning bundle built by mkbundle, e.g. for air-gapped sites. It's verified with bundle_signer_cert and extracted to a temporary directory before use, and its inventory is loaded instead of inv_config. No bundle is loaded if unset.")
+	bundleSignerCert   = flag.String("bundle_signer_cert", "", "The path to the PEM encoded certificate of the key the bundle is signed with.")
+	logLevel           = flag.String("log_level", "info", "Comma separated log levels out of debug, info, warn, error and vN, each optionally prefixed with a module and =, e.g. info,service=debug. Modules are the packages of the server, and log at the level without a module unless set. Levels can be changed at runtime through the admin API.")
 )
 
+// bootstrapService is the name health checks use to query the bootstrap service.
+const bootstrapService = "bootz.proto.Bootstrap"
+
 type server struct {
-	serv *grpc.Server
-	lis  net.Listener
+	serv   *grpc.Server
+	health *health.Server
+	lis    net.Listener
+	// unixLis is the unix domain socket serv is also served on, if enabled.
+	unixLis net.Listener
+	// adminServ serves the admin API on adminLis, if it is split from the bootstrap service.
+	adminServ *grpc.Server
+	adminLis  net.Listener
+	// gw serves the HTTP/JSON gateway on gwLis, if enabled.
+	gw    *http.Server
+	gwLis net.Listener
+	// images serves the software images of image_dir on imagesLis, if enabled.
+	images    *http.Server
+	imagesLis net.Listener
+	// metrics serves the metrics of the RPCs on metricsLis, if enabled.
+	metrics    *http.Server
+	metricsLis net.Listener
+	// stopWebhooks stops notifying the webhooks, if enabled.
+	stopWebhooks func()
+	// stopAlerts stops alerting on bootstrap failures, if enabled.
+	stopAlerts func()
+	// stopNetBoxSync stops importing chassis from NetBox, if enabled.
+	stopNetBoxSync func()
+	// stopMasaSync stops requesting missing ownership vouchers from the MASA, if enabled.
+	stopMasaSync func()
+	// stopArtifactSync stops mirroring the buckets of the artifact store, if enabled.
+	stopArtifactSync func()
+	// stopElection steps down from the leadership of the cluster, if clustered.
+	stopElection func()
+	// stopWatch stops reloading the files the server is configured from, if enabled.
+	stopWatch func()
 }
 
-// readKeyPair reads the cert/key pair from the specified artifacts directory.
+// readKeyPair reads the cert/key pair from the root of the artifacts file system.
 // Certs must have the format {name}_pub.pem and keys must have the format {name}_priv.pem
-func readKeypair(name string) (*service.KeyPair, error) {
-	cert, err := os.ReadFile(filepath.Join(*artifactDirectory, fmt.Sprintf("%v_pub.pem", name)))
+// If keyURI is set, the private key is kept in the key store it identifies instead.
+func readKeypair(fsys fs.FS, name, keyURI string) (*service.KeyPair, error) {
+	cert, err := fs.ReadFile(fsys, fmt.Sprintf("%v_pub.pem", name))
 	if err != nil {
 		return nil, fmt.Errorf("unable to read %v cert: %v", name, err)
 	}
-	privateKey, err := os.ReadFile(filepath.Join(*artifactDirectory, fmt.Sprintf("%v_priv.pem", name)))
+	if keyURI != "" {
+		signer, err := keystore.Open(keyURI)
+		if err != nil {
+			return nil, fmt.Errorf("unable to open %v key: %v", name, err)
+		}
+		return &service.KeyPair{
+			Cert:   string(cert),
+			Signer: signer,
+		}, nil
+	}
+	privateKey, err := fs.ReadFile(fsys, fmt.Sprintf("%v_priv.pem", name))
 	if err != nil {
 		return nil, fmt.Errorf("unable to read %v key: %v", name, err)
 	}
@@ -68,16 +182,16 @@ func readKeypair(name string) (*service.KeyPair, error) {
 	}, nil
 }
 
-// readOVs discovers and reads all available OVs in the artifacts directory.
-func readOVs() (service.OVList, error) {
+// readOVs discovers and reads all available OVs in the root of the artifacts file system.
+func readOVs(fsys fs.FS) (service.OVList, error) {
 	ovs := make(service.OVList)
-	files, err := os.ReadDir(*artifactDirectory)
+	files, err := fs.ReadDir(fsys, ".")
 	if err != nil {
 		return nil, fmt.Errorf("unable to list files in artifact directory: %v", err)
 	}
 	for _, f := range files {
 		if strings.HasPrefix(f.Name(), "ov") {
-			bytes, err := os.ReadFile(filepath.Join(*artifactDirectory, f.Name()))
+			bytes, err := fs.ReadFile(fsys, f.Name())
 			if err != nil {
 				return nil, err
 			}
@@ -94,28 +208,28 @@ func readOVs() (service.OVList, error) {
 
 // generateServerTLSCert creates a new TLS keypair from the PDC.
 func generateServerTLSCert(pdc *service.KeyPair) (*tls.Certificate, error) {
-	tlsCert, err := tls.X509KeyPair([]byte(pdc.Cert), []byte(pdc.PrivateKey))
+	tlsCert, err := pdc.TLSCertificate()
 	if err != nil {
 		return nil, fmt.Errorf("unable to generate Server TLS Certificate from PDC %v", err)
 	}
-	return &tlsCert, err
+	return tlsCert, err
 }
 
-// parseSecurityArtifacts reads from the specified directory to find the required keypairs and ownership vouchers.
-func parseSecurityArtifacts() (*service.SecurityArtifacts, error) {
-	oc, err := readKeypair("oc")
+// parseSecurityArtifacts reads from the artifacts file system to find the required keypairs and ownership vouchers.
+func parseSecurityArtifacts(fsys fs.FS) (*service.SecurityArtifacts, error) {
+	oc, err := readKeypair(fsys, "oc", *ocKeyURI)
 	if err != nil {
 		return nil, err
 	}
-	pdc, err := readKeypair("pdc")
+	pdc, err := readKeypair(fsys, "pdc", *pdcKeyURI)
 	if err != nil {
 		return nil, err
 	}
-	vendorCA, err := readKeypair("vendorca")
+	vendorCA, err := readKeypair(fsys, "vendorca", "")
 	if err != nil {
 		return nil, err
 	}
-	ovs, err := readOVs()
+	ovs, err := readOVs(fsys)
 	if err != nil {
 		return nil, err
 	}
@@ -132,33 +246,513 @@ func parseSecurityArtifacts() (*service.SecurityArtifacts, error) {
 	}, nil
 }
 
+// openArtifacts returns the file system holding the artifacts at path, which is either a directory
+// or a zip archive. The returned closer must be called once the artifacts are read.
+func openArtifacts(path string) (fs.FS, io.Closer, error) {
+	if strings.HasSuffix(path, ".zip") {
+		zr, err := zip.OpenReader(path)
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to open artifact archive: %v", err)
+		}
+		return zr, zr, nil
+	}
+	return os.DirFS(path), io.NopCloser(nil), nil
+}
+
 func (s *server) Start() error {
+	if s.adminServ != nil {
+		go func() {
+			if err := s.adminServ.Serve(s.adminLis); err != nil {
+				log.Errorf("Admin server stopped: %v", err)
+			}
+		}()
+	}
+	if s.gw != nil {
+		go func() {
+			if err := s.gw.ServeTLS(s.gwLis, "", ""); err != http.ErrServerClosed {
+				log.Errorf("HTTP gateway stopped: %v", err)
+			}
+		}()
+	}
+	if s.images != nil {
+		go func() {
+			if err := s.images.ServeTLS(s.imagesLis, "", ""); err != http.ErrServerClosed {
+				log.Errorf("Image server stopped: %v", err)
+			}
+		}()
+	}
+	if s.metrics != nil {
+		go func() {
+			if err := s.metrics.Serve(s.metricsLis); err != http.ErrServerClosed {
+				log.Errorf("Metrics server stopped: %v", err)
+			}
+		}()
+	}
+	if s.unixLis != nil {
+		go func() {
+			if err := s.serv.Serve(s.unixLis); err != nil {
+				log.Errorf("Server stopped serving unix socket: %v", err)
+			}
+		}()
+	}
 	return s.serv.Serve(s.lis)
 }
 
 func (s *server) Stop() {
+	// Report NOT_SERVING first so that load balancers drain the server.
+	s.health.Shutdown()
+	if s.gw != nil {
+		if err := s.gw.Shutdown(context.Background()); err != nil {
+			log.Errorf("Unable to stop HTTP gateway: %v", err)
+		}
+	}
+	if s.images != nil {
+		if err := s.images.Shutdown(context.Background()); err != nil {
+			log.Errorf("Unable to stop image server: %v", err)
+		}
+	}
 	s.serv.GracefulStop()
+	if s.adminServ != nil {
+		s.adminServ.GracefulStop()
+	}
+	if s.metrics != nil {
+		if err := s.metrics.Shutdown(context.Background()); err != nil {
+			log.Errorf("Unable to stop metrics server: %v", err)
+		}
+	}
+	if s.stopWebhooks != nil {
+		s.stopWebhooks()
+	}
+	if s.stopAlerts != nil {
+		s.stopAlerts()
+	}
+	if s.stopNetBoxSync != nil {
+		s.stopNetBoxSync()
+	}
+	if s.stopMasaSync != nil {
+		s.stopMasaSync()
+	}
+	if s.stopArtifactSync != nil {
+		s.stopArtifactSync()
+	}
+	if s.stopElection != nil {
+		s.stopElection()
+	}
+	if s.stopWatch != nil {
+		s.stopWatch()
+	}
 }
 
-// newServer creates a new Bootz gRPC server from flags.
-func newServer() (*server, error) {
-	if *port == "" {
-		return nil, fmt.Errorf("no port selected. specify with the --port flag")
+// deviceCredentials returns the credentials securing connections back to bootstrapped devices,
+// authenticating the server with its TLS certificate.
+func deviceCredentials(cert *tls.Certificate) (credentials.TransportCredentials, error) {
+	cfg := &tls.Config{Certificates: []tls.Certificate{*cert}}
+	if *secondStageCACert != "" {
+		caPEM, err := os.ReadFile(*secondStageCACert)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read second stage CA cert: %v", err)
+		}
+		cfg.RootCAs = x509.NewCertPool()
+		if !cfg.RootCAs.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("unable to parse second stage CA cert %s", *secondStageCACert)
+		}
+	}
+	if fips.Enabled() {
+		fips.ConfigureTLS(cfg)
+	}
+	return credentials.NewTLS(cfg), nil
+}
+
+// newSecondStagePusher creates a pusher applying the second stage configs of the inventory to
+// devices, authenticating the server with its TLS certificate.
+func newSecondStagePusher(em *entitymanager.InMemoryEntityManager, cert *tls.Certificate) (*secondstage.Pusher, error) {
+	creds, err := deviceCredentials(cert)
+	if err != nil {
+		return nil, err
+	}
+	log.Infof("Pushing second stage configs to gNMI port %v of bootstrapped devices", *secondStagePort)
+	return secondstage.New(em, secondstage.Options{
+		Port:        *secondStagePort,
+		Credentials: creds,
+		Username:    *secondStageUser,
+		Password:    *secondStagePass,
+	}), nil
+}
+
+// newOSInstaller creates an installer converging bootstrapped devices to the intended images of
+// the inventory, transferring the images of image_dir to devices lacking them.
+func newOSInstaller(em *entitymanager.InMemoryEntityManager, cert *tls.Certificate) (*osinstall.Installer, error) {
+	creds, err := deviceCredentials(cert)
+	if err != nil {
+		return nil, err
+	}
+	opts := osinstall.Options{
+		Port:        *osInstallPort,
+		Credentials: creds,
+		Username:    *secondStageUser,
+		Password:    *secondStagePass,
+	}
+	if *imageDir != "" {
+		opts.Images = os.DirFS(*imageDir)
+	}
+	log.Infof("Converging software versions over gNOI port %v of bootstrapped devices", *osInstallPort)
+	return osinstall.New(em, opts), nil
+}
+
+// newRebooter creates a rebooter of the devices triggered to bootstrap again, authenticating the
+// server with its TLS certificate.
+func newRebooter(cert *tls.Certificate) (*reboot.Rebooter, error) {
+	creds, err := deviceCredentials(cert)
+	if err != nil {
+		return nil, err
+	}
+	log.Infof("Rebooting devices triggered to bootstrap again over gNOI port %v", *rebootPort)
+	return reboot.New(reboot.Options{
+		Port:        *rebootPort,
+		Credentials: creds,
+		Username:    *secondStageUser,
+		Password:    *secondStagePass,
+	}), nil
+}
+
+// startWebhooks notifies the webhook URLs of the bootstrap events published by the service,
+// returning a function stopping the notifications.
+func startWebhooks(svc *service.Service) (func(), error) {
+	var stages []events.Stage
+	for _, s := range strings.Split(*webhookEvents, ",") {
+		stage := events.Stage(strings.TrimSpace(s))
+		if !events.Known(stage) {
+			return nil, fmt.Errorf("unknown webhook event %q", s)
+		}
+		stages = append(stages, stage)
+	}
+	urls := strings.Split(*webhookURLs, ",")
+	ctx, stop := context.WithCancel(context.Background())
+	ch, cancel := svc.Events().Subscribe(nil)
+	go webhook.New(urls, stages).Run(ctx, ch)
+	log.Infof("Notifying %v events to webhooks %v", stages, urls)
+	return func() {
+		cancel()
+		stop()
+	}, nil
+}
+
+// startAlerts raises the alerts of the bootstrap failures published by the service, returning a
+// function stopping the alerts.
+func startAlerts(svc *service.Service, cfg *epb.Alerting) (func(), error) {
+	n, err := alert.New(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("unable to configure alerting: %v", err)
+	}
+	ch, cancel := svc.Events().Subscribe(nil)
+	go n.Run(ch)
+	log.Infof("Alerting on bootstrap failures with %d rules", len(cfg.GetRules()))
+	return cancel, nil
+}
+
+// startSync periodically calls sync, if interval is positive, logging its errors as failures to
+// sync what. It returns a function stopping the sync, or nil.
+func startSync(what string, interval time.Duration, sync func(context.Context) error) func() {
+	if interval <= 0 {
+		return nil
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := sync(ctx); err != nil {
+					log.Warningf("Unable to sync %s: %v", what, err)
+				}
+			}
+		}
+	}()
+	log.Infof("Syncing %s every %v", what, interval)
+	return cancel
+}
+
+// leaderSync wraps sync to only run on the leader of the cluster, if clustered, sharing the
+// inventory it changes with the other instances.
+func leaderSync(em *entitymanager.InMemoryEntityManager, sync func(context.Context) error) func(context.Context) error {
+	return func(ctx context.Context) error {
+		if !em.IsLeader() {
+			return nil
+		}
+		if err := sync(ctx); err != nil {
+			return err
+		}
+		return em.PublishInventory(ctx)
+	}
+}
+
+// reloadInventory reloads the security artifacts and, on the leader of the cluster, the inventory,
+// sharing it with the other instances.
+func reloadInventory(em *entitymanager.InMemoryEntityManager) {
+	if err := em.ReloadArtifacts(); err != nil {
+		log.Errorf("Unable to reload security artifacts: %v", err)
+	}
+	// Followers receive the inventory of the leader of the cluster instead.
+	if em.IsLeader() {
+		if err := em.ReloadInventory(*inventoryConfig); err != nil {
+			log.Errorf("Unable to reload inventory: %v", err)
+		} else if err := em.PublishInventory(context.Background()); err != nil {
+			log.Warningf("Unable to publish the reloaded inventory: %v", err)
+		}
+	}
+}
+
+// syncArtifacts returns a sync mirroring the buckets of the artifact store, and reloading the
+// inventory and the security artifacts when files changed.
+func syncArtifacts(em *entitymanager.InMemoryEntityManager, syncer *artifactsync.Syncer) func(context.Context) error {
+	return func(ctx context.Context) error {
+		changed, err := syncer.Sync(ctx)
+		if changed {
+			reloadInventory(em)
+		}
+		return err
+	}
+}
+
+// watchFiles reloads the inventory, the security artifacts and the TLS certificates when the
+// files they are read from change, e.g. when the Kubernetes ConfigMaps and Secrets they are
+// mounted from are updated. The admin certificate is nil unless read from admin_cert. It returns
+// a function stopping the reloads.
+func watchFiles(em *entitymanager.InMemoryEntityManager, bootstrapCert, adminTLSCert *kube.Certificate) (func(), error) {
+	dirs := map[string]bool{filepath.Dir(*inventoryConfig): true}
+	if d := em.GetArtifactDir(); d != "" {
+		dirs[d] = true
+	}
+	if !*generateArtifacts && !strings.HasSuffix(*artifactDirectory, ".zip") {
+		dirs[*artifactDirectory] = true
+	}
+	if adminTLSCert != nil {
+		dirs[filepath.Dir(*adminCert)] = true
+		dirs[filepath.Dir(*adminKey)] = true
+	}
+	var watched []string
+	for d := range dirs {
+		watched = append(watched, d)
+	}
+	reload := func() {
+		reloadInventory(em)
+		if !*generateArtifacts {
+			if fsys, closer, err := openArtifacts(*artifactDirectory); err != nil {
+				log.Errorf("Unable to reload the bootstrap TLS certificate: %v", err)
+			} else {
+				defer closer.Close()
+				if sa, err := parseSecurityArtifacts(fsys); err != nil {
+					log.Errorf("Unable to reload the bootstrap TLS certificate: %v", err)
+				} else {
+					bootstrapCert.Set(sa.TLSKeypair)
+				}
+			}
+		}
+		if adminTLSCert != nil {
+			if cert, err := tls.LoadX509KeyPair(*adminCert, *adminKey); err != nil {
+				log.Errorf("Unable to reload admin TLS certificate: %v", err)
+			} else {
+				adminTLSCert.Set(&cert)
+			}
+		}
+		log.Infof("Reloaded configuration files, changes to the CAs verifying client certificates apply after a restart")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	if err := kube.Watch(ctx, watched, reload); err != nil {
+		cancel()
+		return nil, err
+	}
+	return cancel, nil
+}
+
+// listenUnix listens on the unix domain socket at path, removing the socket a previous server
+// left behind, if any. The socket is removed when the listener is closed.
+func listenUnix(path string) (net.Listener, error) {
+	if fi, err := os.Stat(path); err == nil && fi.Mode()&fs.ModeSocket != 0 {
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("unable to remove stale unix socket: %v", err)
+		}
+	}
+	lis, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("error listening on unix socket: %v", err)
+	}
+	return lis, nil
+}
+
+// instanceID returns the ID of the server in its cluster from flags.
+func instanceID() (string, error) {
+	if *clusterID != "" {
+		return *clusterID, nil
+	}
+	host, err := os.Hostname()
+	if err != nil {
+		return "", fmt.Errorf("unable to determine the cluster ID: %v", err)
+	}
+	if *adminPort != "" {
+		return net.JoinHostPort(host, *adminPort), nil
+	}
+	return net.JoinHostPort(host, *port), nil
+}
+
+// newImageSigner returns the signer of the URLs of the image server from flags.
+func newImageSigner() (*imageserver.Signer, error) {
+	key, err := imageserver.NewKey()
+	if err != nil {
+		return nil, err
+	}
+	if *imageURLKeyFile != "" {
+		if key, err = os.ReadFile(*imageURLKeyFile); err != nil {
+			return nil, fmt.Errorf("unable to read image URL key: %v", err)
+		}
+	}
+	return imageserver.NewSigner(key, imageServerURL(), *imageURLTTL)
+}
+
+// imageServerURL returns the URL devices reach the image server at.
+func imageServerURL() string {
+	if *imageBaseURL != "" {
+		return *imageBaseURL
+	}
+	return "https://localhost:" + *imagePort
+}
+
+// newEntityManager creates the entity manager and the server's security artifacts from flags.
+func newEntityManager() (*entitymanager.InMemoryEntityManager, *service.SecurityArtifacts, error) {
+	if *generateArtifacts {
+		log.Infof("Setting up entities with generated security artifacts")
+		em, err := entitymanager.NewWithGeneratedArtifacts(*inventoryConfig)
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to initiate inventory manager %v", err)
+		}
+		sa := em.SecurityArtifacts()
+		// Devices need the vendor CA to verify the generated OVs.
+		log.Infof("Generated vendor CA, pass it to devices to verify the OVs:\n%s", sa.VendorCA.Cert)
+		return em, sa, nil
 	}
 	if *artifactDirectory == "" {
-		return nil, fmt.Errorf("no artifact directory selected. specify with the --artifact_dir flag")
+		return nil, nil, fmt.Errorf("no artifact directory selected. specify with the --artifact_dir flag")
 	}
 
 	log.Infof("Setting up server security artifacts: OC, OVs, PDC, VendorCA")
-	sa, err := parseSecurityArtifacts()
+	fsys, closer, err := openArtifacts(*artifactDirectory)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	defer closer.Close()
+	sa, err := parseSecurityArtifacts(fsys)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	log.Infof("Setting up entities")
 	em, err := entitymanager.New(*inventoryConfig)
 	if err != nil {
-		return nil, fmt.Errorf("unable to initiate inventory manager %v", err)
+		return nil, nil, fmt.Errorf("unable to initiate inventory manager %v", err)
+	}
+	return em, sa, nil
+}
+
+// isLoopback reports whether listening on addr only accepts connections of the loopback interface.
+func isLoopback(addr string) bool {
+	if addr == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(addr)
+	return ip != nil && ip.IsLoopback()
+}
+
+// adminTLSConfig returns the TLS config of the admin port. It presents admin_cert, or the bootstrap
+// TLS certificate if unset, and requires client certificates signed by admin_client_ca, if set.
+func adminTLSConfig(bootstrapCert *tls.Certificate) (*tls.Config, error) {
+	cfg := &tls.Config{Certificates: []tls.Certificate{*bootstrapCert}}
+	if *adminCert != "" || *adminKey != "" {
+		cert, err := tls.LoadX509KeyPair(*adminCert, *adminKey)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load admin TLS certificate: %v", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	if *adminClientCA != "" {
+		caPEM, err := os.ReadFile(*adminClientCA)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read admin client CA cert: %v", err)
+		}
+		cfg.ClientCAs = x509.NewCertPool()
+		if !cfg.ClientCAs.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("unable to parse admin client CA cert %s", *adminClientCA)
+		}
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	if fips.Enabled() {
+		fips.ConfigureTLS(cfg)
+	}
+	return cfg, nil
+}
+
+// openBundle verifies and extracts the provisioning bundle, and points inv_config to its
+// inventory.
+func openBundle() error {
+	if *bundleSignerCert == "" {
+		return fmt.Errorf("bundle_signer_cert must be set to verify the bundle")
+	}
+	data, err := os.ReadFile(*bundleSignerCert)
+	if err != nil {
+		return fmt.Errorf("unable to read bundle signer certificate: %v", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return fmt.Errorf("unable to decode bundle signer certificate")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return fmt.Errorf("unable to parse bundle signer certificate: %v", err)
+	}
+	dir, err := os.MkdirTemp("", "bootz-bundle-")
+	if err != nil {
+		return err
+	}
+	m, err := bundle.Open(*bundleFile, dir, cert)
+	if err != nil {
+		os.RemoveAll(dir)
+		return err
+	}
+	*inventoryConfig = filepath.Join(dir, m.Inventory)
+	log.Infof("Loaded bundle %s created at %v with %d files, extracted to %s", *bundleFile, m.Created, len(m.Files), dir)
+	if *imageDir != "" {
+		for _, img := range m.MissingImages(os.DirFS(*imageDir), imageServerURL()) {
+			log.Warningf("Image %s %s of the bundle is missing from %s: %s", img.Name, img.Version, *imageDir, img.URL)
+		}
+	}
+	return nil
+}
+
+// newServer creates a new Bootz gRPC server from flags.
+func newServer() (*server, error) {
+	if *port == "" {
+		return nil, fmt.Errorf("no port selected. specify with the --port flag")
+	}
+	if *bundleFile != "" {
+		if err := openBundle(); err != nil {
+			return nil, err
+		}
+	}
+	syncer, err := artifactsync.Load(*artifactSync)
+	if err != nil {
+		return nil, err
+	}
+	if syncer != nil {
+		// The inventory may reference the mirrored files, so they are synced before it is loaded.
+		if _, err := syncer.Sync(context.Background()); err != nil {
+			log.Errorf("Unable to sync artifacts, starting with the files mirrored before: %v", err)
+		}
+	}
+	em, sa, err := newEntityManager()
+	if err != nil {
+		return nil, err
 	}
 
 	if *dhcpIntf != "" {
@@ -167,31 +761,251 @@ func newServer() (*server, error) {
 		}
 	}
 
+	if *restoreSnapshot != "" {
+		if err := em.RestoreSnapshotFile(*restoreSnapshot); err != nil {
+			return nil, err
+		}
+	}
+
 	c := service.New(em)
+	if err := c.Features().Load(em.GetFeatureFlags()); err != nil {
+		return nil, fmt.Errorf("unable to load feature flags: %v", err)
+	}
+	c.SetPeerIdentityCheck(em.GetPeerIdentityCheck())
+	c.SetChassisDescriptorValidation(em.GetChassisDescriptorValidation())
+	if err := c.SetVendorQuirks(em.GetVendorQuirks()); err != nil {
+		return nil, fmt.Errorf("unable to load vendor quirks: %v", err)
+	}
+	c.SetTimeouts(em.GetTimeouts())
+	if err := c.SetRedirects(em.GetRedirects()); err != nil {
+		return nil, fmt.Errorf("unable to load redirects: %v", err)
+	}
+	if err := c.SetURLRewrites(em.GetURLRewrites()); err != nil {
+		return nil, fmt.Errorf("unable to load URL rewrites: %v", err)
+	}
+	if err := c.SetPolicies(em.GetBootstrapPolicies()); err != nil {
+		return nil, fmt.Errorf("unable to load bootstrap policies: %v", err)
+	}
+	c.SetQuarantine(em.GetQuarantine())
+	c.SetAdmissionControl(em.GetAdmissionControl())
+	c.SetSigningWorkers(em.GetSigningWorkers())
+	if *dryRun {
+		log.Warningf("Dry run: bootstrap data is served with placeholders instead of configs and credentials")
+		c.SetDryRun(true)
+	}
+	if *secondStagePort != "" {
+		pusher, err := newSecondStagePusher(em, sa.TLSKeypair)
+		if err != nil {
+			return nil, err
+		}
+		c.AddStatusHook(pusher.OnStatus)
+	}
+	if *osInstallPort != "" {
+		installer, err := newOSInstaller(em, sa.TLSKeypair)
+		if err != nil {
+			return nil, err
+		}
+		c.AddStatusHook(installer.OnStatus)
+	}
+	var stopWebhooks func()
+	if *webhookURLs != "" {
+		if stopWebhooks, err = startWebhooks(c); err != nil {
+			return nil, err
+		}
+	}
+	var stopAlerts func()
+	if cfg := em.GetAlerting(); cfg != nil {
+		if stopAlerts, err = startAlerts(c, cfg); err != nil {
+			return nil, err
+		}
+	}
 
 	trustBundle := x509.NewCertPool()
 	if !trustBundle.AppendCertsFromPEM([]byte(sa.PDC.Cert)) {
 		return nil, fmt.Errorf("unable to add PDC cert to trust pool")
 	}
+	// Devices present certificates issued by their vendor, which are verified if given.
+	clientAuth, clientCAs := tls.NoClientCert, (*x509.CertPool)(nil)
+	if em.GetPeerIdentityCheck() != epb.PeerIdentityCheck_PEER_IDENTITY_CHECK_NONE {
+		clientCAs = x509.NewCertPool()
+		if sa.VendorCA == nil || !clientCAs.AppendCertsFromPEM([]byte(sa.VendorCA.Cert)) {
+			return nil, fmt.Errorf("peer identity check requires the vendor CA certificate")
+		}
+		clientAuth = tls.VerifyClientCertIfGiven
+	}
 	tls := &tls.Config{
 		Certificates: []tls.Certificate{*sa.TLSKeypair},
 		RootCAs:      trustBundle,
+		ClientAuth:   clientAuth,
+		ClientCAs:    clientCAs,
+	}
+	if fips.Enabled() {
+		fips.ConfigureTLS(tls)
+	}
+	bootstrapCert := kube.NewCertificate(sa.TLSKeypair)
+	if *reloadOnChange {
+		bootstrapCert.Serve(tls)
 	}
 	log.Infof("Creating server...")
-	s := grpc.NewServer(grpc.Creds(credentials.NewTLS(tls)))
+	limiter := ratelimit.New(ratelimit.Limits{
+		PerIP:     *rateLimitPerIP,
+		PerSerial: *rateLimitPerSerial,
+		Burst:     *rateLimitBurst,
+	})
+	authChain, err := auth.New(em.GetAuthenticators())
+	if err != nil {
+		return nil, fmt.Errorf("unable to configure authenticators: %v", err)
+	}
+	reqLogger, err := reqlog.New(em.GetRequestLogging())
+	if err != nil {
+		return nil, fmt.Errorf("unable to configure request logging: %v", err)
+	}
+	faults, err := faultinject.Load(*faultInjection)
+	if err != nil {
+		return nil, err
+	}
+	rpcMetrics := metrics.New()
+	// The HTTP gateway serves requests through the same interceptors.
+	unaryInterceptors := []grpc.UnaryServerInterceptor{rpcMetrics.UnaryServerInterceptor, reqLogger.UnaryServerInterceptor, authChain.UnaryServerInterceptor, limiter.UnaryServerInterceptor, faults.UnaryServerInterceptor}
+	s := grpc.NewServer(grpc.Creds(credentials.NewTLS(tls)),
+		grpc.ChainUnaryInterceptor(unaryInterceptors...),
+		grpc.ChainStreamInterceptor(rpcMetrics.StreamServerInterceptor, reqLogger.StreamServerInterceptor, authChain.StreamServerInterceptor, limiter.StreamServerInterceptor, faults.StreamServerInterceptor))
 	bpb.RegisterBootstrapServer(s, c)
+	adminSvc := admin.New(c, em.Revocations(), em.Experiments(), em.States())
+	adminSvc.SetSnapshotter(em)
+	adminSvc.SetInventory(em)
+	admins := em.DomainAdmins()
+	if len(admins) > 0 && (*adminPort == "" || *adminClientCA == "") {
+		return nil, fmt.Errorf("admin identities require admin_port and admin_client_ca, as admins are identified by their verified client certificate")
+	}
+	adminSvc.SetDomains(em, admins)
+	adminSvc.SetLeadership(em)
+	adminSvc.SetTokens(em.Tokens())
+	adminSvc.SetRetirements(em)
+	if *rebootPort != "" {
+		rebooter, err := newRebooter(sa.TLSKeypair)
+		if err != nil {
+			return nil, err
+		}
+		adminSvc.SetRebooter(rebooter)
+	}
+	id, err := instanceID()
+	if err != nil {
+		return nil, err
+	}
+	hs := health.NewServer()
+	hs.SetServingStatus(bootstrapService, hpb.HealthCheckResponse_SERVING)
+	hpb.RegisterHealthServer(s, hs)
+	reflection.Register(s)
 
-	lis, err := net.Listen("tcp", fmt.Sprintf("localhost:%v", *port))
+	lis, err := net.Listen("tcp", net.JoinHostPort(*bindAddress, *port))
 	if err != nil {
 		return nil, fmt.Errorf("error listening on port: %v", err)
 	}
+	srv := &server{
+		serv:           s,
+		health:         hs,
+		lis:            lis,
+		stopWebhooks:   stopWebhooks,
+		stopAlerts:     stopAlerts,
+		stopNetBoxSync: startSync("chassis from NetBox", em.NetBoxSyncInterval(), leaderSync(em, em.SyncNetBox)),
+		stopMasaSync:   startSync("ownership vouchers from the MASA", em.MasaSyncInterval(), leaderSync(em, em.SyncMasa)),
+		stopElection:   em.StartLeaderElection(id),
+	}
+	if syncer != nil {
+		srv.stopArtifactSync = startSync("artifacts from object storage", syncer.Interval(), syncArtifacts(em, syncer))
+	}
+	if *unixSocket != "" {
+		if srv.unixLis, err = listenUnix(*unixSocket); err != nil {
+			return nil, err
+		}
+		log.Infof("Serving unix socket %s", *unixSocket)
+	}
+	// The admin API is never served on the bootstrap port, which any device reaching the server
+	// may call without a client certificate.
+	var adminTLSCert *kube.Certificate
+	if *adminPort == "" {
+		log.Infof("Admin API disabled, as admin_port is unset")
+	} else {
+		if !isLoopback(*adminBindAddress) && *adminClientCA == "" {
+			return nil, fmt.Errorf("admin_client_ca must be set to serve the admin API beyond the loopback interface, on %q", *adminBindAddress)
+		}
+		adminTLS, err := adminTLSConfig(sa.TLSKeypair)
+		if err != nil {
+			return nil, err
+		}
+		if *reloadOnChange {
+			if *adminCert != "" {
+				adminTLSCert = kube.NewCertificate(&adminTLS.Certificates[0])
+				adminTLSCert.Serve(adminTLS)
+			} else {
+				bootstrapCert.Serve(adminTLS)
+			}
+		}
+		srv.adminServ = grpc.NewServer(grpc.Creds(credentials.NewTLS(adminTLS)),
+			grpc.ChainUnaryInterceptor(rpcMetrics.UnaryServerInterceptor, reqLogger.UnaryServerInterceptor),
+			grpc.ChainStreamInterceptor(rpcMetrics.StreamServerInterceptor, reqLogger.StreamServerInterceptor))
+		apb.RegisterAdminServer(srv.adminServ, adminSvc)
+		hpb.RegisterHealthServer(srv.adminServ, hs)
+		reflection.Register(srv.adminServ)
+		srv.adminLis, err = net.Listen("tcp", net.JoinHostPort(*adminBindAddress, *adminPort))
+		if err != nil {
+			return nil, fmt.Errorf("error listening on admin port: %v", err)
+		}
+		log.Infof("Admin API listening on %s", srv.adminLis.Addr())
+	}
+	if *httpPort != "" {
+		srv.gwLis, err = net.Listen("tcp", net.JoinHostPort(*bindAddress, *httpPort))
+		if err != nil {
+			return nil, fmt.Errorf("error listening on HTTP port: %v", err)
+		}
+		srv.gw = &http.Server{
+			Handler:   gateway.New(c, unaryInterceptors...),
+			TLSConfig: tls,
+		}
+		log.Infof("HTTP gateway listening on %s", srv.gwLis.Addr())
+	}
+	if *imageDir != "" {
+		signer, err := newImageSigner()
+		if err != nil {
+			return nil, err
+		}
+		c.SetURLSigner(signer)
+		srv.imagesLis, err = net.Listen("tcp", net.JoinHostPort(*bindAddress, *imagePort))
+		if err != nil {
+			return nil, fmt.Errorf("error listening on image port: %v", err)
+		}
+		srv.images = &http.Server{
+			Handler:   signer.Handler(os.DirFS(*imageDir)),
+			TLSConfig: tls,
+		}
+		log.Infof("Image server listening on %s, serving %s with URLs valid for %v", srv.imagesLis.Addr(), *imageDir, *imageURLTTL)
+	}
+	if *metricsPort != "" {
+		srv.metricsLis, err = net.Listen("tcp", net.JoinHostPort(*bindAddress, *metricsPort))
+		if err != nil {
+			return nil, fmt.Errorf("error listening on metrics port: %v", err)
+		}
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", rpcMetrics)
+		srv.metrics = &http.Server{Handler: mux}
+		log.Infof("Metrics listening on %s", srv.metricsLis.Addr())
+	}
+	if *reloadOnChange {
+		if srv.stopWatch, err = watchFiles(em, bootstrapCert, adminTLSCert); err != nil {
+			return nil, err
+		}
+	}
 	log.Infof("Server ready and listening on %s", lis.Addr())
 	log.Infof("=============================================================================")
-	return &server{serv: s, lis: lis}, nil
+	return srv, nil
 }
 
 func main() {
 	flag.Parse()
+	if err := log.Configure(*logFormat, *logLevel); err != nil {
+		log.Exit(err)
+	}
 
 	log.Infof("=============================================================================")
 	log.Infof("=========================== BootZ Server Emulator ===========================")
@@ -15,15 +15,200 @@
 package main
 
 import (
+	"context"
+	"crypto/tls"
 	"flag"
+	"path/filepath"
 	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openconfig/bootz/server/service"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/encoding/gzip"
+
+	hpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
 // TestStartup tests that a gRPC server can be created with the default flags.
 func TestStartup(t *testing.T) {
 	flag.Parse()
-	_, err := newServer()
+	s, err := newServer()
+	if err != nil {
+		t.Fatalf("newServer() err = %v, want nil", err)
+	}
+	defer s.lis.Close()
+	services := s.serv.GetServiceInfo()
+	for _, name := range []string{"grpc.health.v1.Health", "grpc.reflection.v1alpha.ServerReflection"} {
+		if _, ok := services[name]; !ok {
+			t.Errorf("newServer() does not register %s", name)
+		}
+	}
+	if _, ok := services["admin.Admin"]; ok {
+		t.Errorf("newServer() registers admin.Admin on the bootstrap port")
+	}
+}
+
+func TestCompression(t *testing.T) {
+	flag.Parse()
+	s, err := newServer()
+	if err != nil {
+		t.Fatalf("newServer() err = %v, want nil", err)
+	}
+	go s.Start()
+	defer s.Stop()
+	conn, err := grpc.Dial(s.lis.Addr().String(),
+		grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{InsecureSkipVerify: true})),
+		grpc.WithDefaultCallOptions(grpc.UseCompressor(gzip.Name)))
+	if err != nil {
+		t.Fatalf("Dial() err = %v, want nil", err)
+	}
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if _, err := hpb.NewHealthClient(conn).Check(ctx, &hpb.HealthCheckRequest{Service: bootstrapService}); err != nil {
+		t.Errorf("Check() with gzip compression err = %v, want nil", err)
+	}
+}
+
+func TestUnixSocket(t *testing.T) {
+	flag.Parse()
+	defer func(p string) { *unixSocket = p }(*unixSocket)
+	*unixSocket = filepath.Join(t.TempDir(), "bootz.sock")
+	s, err := newServer()
+	if err != nil {
+		t.Fatalf("newServer() err = %v, want nil", err)
+	}
+	go s.Start()
+	defer s.Stop()
+	conn, err := grpc.Dial("unix://"+*unixSocket,
+		grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{InsecureSkipVerify: true})))
+	if err != nil {
+		t.Fatalf("Dial() err = %v, want nil", err)
+	}
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if _, err := hpb.NewHealthClient(conn).Check(ctx, &hpb.HealthCheckRequest{Service: bootstrapService}); err != nil {
+		t.Errorf("Check() over unix socket err = %v, want nil", err)
+	}
+}
+
+func TestStartupSplitAdmin(t *testing.T) {
+	flag.Parse()
+	defer func(p string) { *adminPort = p }(*adminPort)
+	*adminPort = "0"
+	s, err := newServer()
 	if err != nil {
 		t.Fatalf("newServer() err = %v, want nil", err)
 	}
+	defer s.lis.Close()
+	defer s.adminLis.Close()
+	const adminService = "admin.Admin"
+	if _, ok := s.serv.GetServiceInfo()[adminService]; ok {
+		t.Errorf("newServer() registers %s on the bootstrap port", adminService)
+	}
+	if _, ok := s.adminServ.GetServiceInfo()[adminService]; !ok {
+		t.Errorf("newServer() does not register %s on the admin port", adminService)
+	}
+}
+
+func TestIsLoopback(t *testing.T) {
+	tests := []struct {
+		addr string
+		want bool
+	}{
+		{addr: "localhost", want: true},
+		{addr: "127.0.0.1", want: true},
+		{addr: "::1", want: true},
+		{addr: "", want: false},
+		{addr: "0.0.0.0", want: false},
+		{addr: "192.0.2.1", want: false},
+		{addr: "bootz.example.com", want: false},
+	}
+	for _, test := range tests {
+		if got := isLoopback(test.addr); got != test.want {
+			t.Errorf("isLoopback(%q) = %v, want %v", test.addr, got, test.want)
+		}
+	}
+}
+
+func TestAdminTLSConfig(t *testing.T) {
+	defer func(ca string) { *adminClientCA = ca }(*adminClientCA)
+	bootstrapCert, err := tls.LoadX509KeyPair("../testdata/pdc_pub.pem", "../testdata/pdc_priv.pem")
+	if err != nil {
+		t.Fatalf("unable to load PDC: %v", err)
+	}
+	tests := []struct {
+		desc           string
+		clientCA       string
+		wantClientAuth tls.ClientAuthType
+		wantErr        bool
+	}{{
+		desc:           "No client CA",
+		wantClientAuth: tls.NoClientCert,
+	}, {
+		desc:           "Client CA",
+		clientCA:       "../testdata/vendorca_pub.pem",
+		wantClientAuth: tls.RequireAndVerifyClientCert,
+	}, {
+		desc:     "Missing client CA",
+		clientCA: "../testdata/missing.pem",
+		wantErr:  true,
+	}, {
+		desc:     "Invalid client CA",
+		clientCA: "../testdata/image.txt",
+		wantErr:  true,
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			*adminClientCA = test.clientCA
+			cfg, err := adminTLSConfig(&bootstrapCert)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("adminTLSConfig() err = %v, want error %v", err, test.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if cfg.ClientAuth != test.wantClientAuth {
+				t.Errorf("adminTLSConfig() client auth = %v, want %v", cfg.ClientAuth, test.wantClientAuth)
+			}
+		})
+	}
+}
+
+func TestReadOVs(t *testing.T) {
+	tests := []struct {
+		desc    string
+		fsys    fstest.MapFS
+		want    service.OVList
+		wantErr bool
+	}{{
+		desc: "Success",
+		fsys: fstest.MapFS{
+			"ov_123A.txt": {Data: []byte("ov-a")},
+			"ov_123B.txt": {Data: []byte("ov-b")},
+			"oc_pub.pem":  {Data: []byte("cert")},
+		},
+		want: service.OVList{"123A": "ov-a", "123B": "ov-b"},
+	}, {
+		desc: "No OVs",
+		fsys: fstest.MapFS{
+			"oc_pub.pem": {Data: []byte("cert")},
+		},
+		wantErr: true,
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			got, err := readOVs(test.fsys)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("readOVs() err = %v, want error %v", err, test.wantErr)
+			}
+			if !cmp.Equal(got, test.want) {
+				t.Errorf("readOVs() = %v, want %v", got, test.want)
+			}
+		})
+	}
 }
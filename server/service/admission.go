@@ -0,0 +1,111 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/status"
+
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+	log "github.com/openconfig/bootz/server/logging"
+)
+
+// defaultBusyRetry is the interval devices refused a slot are asked to wait before retrying by default.
+const defaultBusyRetry = 30 * time.Second
+
+// admission bounds the bootstrap requests served at the same time, overall and per site.
+type admission struct {
+	// slots holds a token per request in flight, or is nil if unlimited.
+	slots   chan struct{}
+	perSite int
+	timeout time.Duration
+	retry   time.Duration
+
+	mu sync.Mutex
+	// sites holds the slots of each site, created on first use.
+	sites map[string]chan struct{}
+}
+
+// SetAdmissionControl bounds the bootstrap requests served at the same time, or lifts the bounds
+// if cfg is nil. It must be called before the service serves requests.
+func (s *Service) SetAdmissionControl(cfg *epb.AdmissionControl) {
+	if cfg.GetMaxInFlight() == 0 && cfg.GetMaxInFlightPerSite() == 0 {
+		s.admission = nil
+		return
+	}
+	a := &admission{
+		perSite: int(cfg.GetMaxInFlightPerSite()),
+		timeout: cfg.GetQueueTimeout().AsDuration(),
+		retry:   defaultBusyRetry,
+		sites:   map[string]chan struct{}{},
+	}
+	if n := cfg.GetMaxInFlight(); n > 0 {
+		a.slots = make(chan struct{}, n)
+	}
+	if d := cfg.GetRetryInterval(); d != nil {
+		a.retry = d.AsDuration()
+	}
+	s.admission = a
+}
+
+// admit waits for a slot among the requests in flight, returning a function releasing it. It
+// returns an error asking the device to retry later if no slot frees up within the queue timeout.
+func (a *admission) admit(ctx context.Context) (func(), error) {
+	if a == nil || a.slots == nil {
+		return func() {}, nil
+	}
+	return a.acquire(ctx, a.slots, "server")
+}
+
+// admitSite waits for a slot among the requests in flight of the chassis of site, as admit.
+func (a *admission) admitSite(ctx context.Context, site string) (func(), error) {
+	if a == nil || a.perSite == 0 || site == "" {
+		return func() {}, nil
+	}
+	a.mu.Lock()
+	slots, ok := a.sites[site]
+	if !ok {
+		slots = make(chan struct{}, a.perSite)
+		a.sites[site] = slots
+	}
+	a.mu.Unlock()
+	return a.acquire(ctx, slots, "site "+site)
+}
+
+// acquire waits for a slot of slots until the queue timeout, or until ctx is done.
+func (a *admission) acquire(ctx context.Context, slots chan struct{}, what string) (func(), error) {
+	release := func() { <-slots }
+	select {
+	case slots <- struct{}{}:
+		return release, nil
+	default:
+	}
+	if a.timeout > 0 {
+		t := time.NewTimer(a.timeout)
+		defer t.Stop()
+		select {
+		case slots <- struct{}{}:
+			return release, nil
+		case <-ctx.Done():
+			return nil, status.FromContextError(ctx.Err()).Err()
+		case <-t.C:
+		}
+	}
+	log.Warningf("Refused a bootstrap request as %s is serving %d requests already", what, cap(slots))
+	return nil, RetryLater(a.retry, "%s busy with %d bootstrap requests, retry later", what, cap(slots))
+}
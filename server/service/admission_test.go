@@ -0,0 +1,86 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+)
+
+func TestAdmission(t *testing.T) {
+	s := &Service{}
+	s.SetAdmissionControl(&epb.AdmissionControl{
+		MaxInFlight:        2,
+		MaxInFlightPerSite: 1,
+		QueueTimeout:       durationpb.New(50 * time.Millisecond),
+		RetryInterval:      durationpb.New(time.Minute),
+	})
+	ctx := context.Background()
+
+	release, err := s.admission.admitSite(ctx, "lab1")
+	if err != nil {
+		t.Fatalf("admitSite(lab1) err = %v, want nil", err)
+	}
+	// Chassis without a site are not limited per site.
+	for i := 0; i < 3; i++ {
+		if _, err := s.admission.admitSite(ctx, ""); err != nil {
+			t.Fatalf("admitSite() without a site err = %v, want nil", err)
+		}
+	}
+	if _, err := s.admission.admitSite(ctx, "lab2"); err != nil {
+		t.Errorf("admitSite(lab2) err = %v, want nil", err)
+	}
+	_, err = s.admission.admitSite(ctx, "lab1")
+	if status.Code(err) != codes.Unavailable {
+		t.Fatalf("admitSite(lab1) of a busy site err = %v, want code %v", err, codes.Unavailable)
+	}
+	if got, _ := RetryDelay(err); got != time.Minute {
+		t.Errorf("admitSite(lab1) retry delay = %v, want %v", got, time.Minute)
+	}
+	// Queued requests are admitted once a slot frees up.
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		release()
+	}()
+	if _, err := s.admission.admitSite(ctx, "lab1"); err != nil {
+		t.Errorf("admitSite(lab1) queued for a released slot err = %v, want nil", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := s.admission.admit(ctx); err != nil {
+			t.Fatalf("admit() err = %v, want nil", err)
+		}
+	}
+	if _, err := s.admission.admit(ctx); status.Code(err) != codes.Unavailable {
+		t.Errorf("admit() beyond max_in_flight err = %v, want code %v", err, codes.Unavailable)
+	}
+	cctx, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := s.admission.admit(cctx); status.Code(err) != codes.Canceled {
+		t.Errorf("admit() of a canceled request err = %v, want code %v", err, codes.Canceled)
+	}
+
+	s.SetAdmissionControl(nil)
+	if _, err := s.admission.admit(ctx); err != nil {
+		t.Errorf("admit() without admission control err = %v, want nil", err)
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"sort"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	bpb "github.com/openconfig/bootz/proto/bootz"
+)
+
+// PlatformCapabilities summarizes the capabilities reported by all devices
+// of a single platform, identified by manufacturer and part number.
+type PlatformCapabilities struct {
+	Manufacturer string
+	PartNumber   string
+	// Devices is the number of devices of this platform which reported capabilities.
+	Devices int
+	// Features counts the devices reporting each feature.
+	Features map[string]int
+	// ArtifactTypes counts the devices reporting support for each artifact type.
+	ArtifactTypes map[bpb.ArtifactType]int
+}
+
+type reportedCapabilities struct {
+	partNumber string
+	caps       *bpb.DeviceCapabilities
+}
+
+// capabilityRegistry stores the latest capabilities reported by each device.
+type capabilityRegistry struct {
+	mu        sync.Mutex
+	byChassis map[EntityLookup]*reportedCapabilities
+}
+
+func newCapabilityRegistry() *capabilityRegistry {
+	return &capabilityRegistry{
+		byChassis: map[EntityLookup]*reportedCapabilities{},
+	}
+}
+
+// capabilityKey returns the key used to store capabilities for a chassis.
+// Modular chassis may omit the chassis serial, in which case the serial of
+// the first control card is used instead.
+func capabilityKey(desc *bpb.ChassisDescriptor) EntityLookup {
+	serial := desc.GetSerialNumber()
+	if serial == "" && len(desc.GetControlCards()) > 0 {
+		serial = desc.GetControlCards()[0].GetSerialNumber()
+	}
+	return EntityLookup{
+		Manufacturer: desc.GetManufacturer(),
+		SerialNumber: serial,
+	}
+}
+
+func (r *capabilityRegistry) set(desc *bpb.ChassisDescriptor, caps *bpb.DeviceCapabilities) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.byChassis[capabilityKey(desc)] = &reportedCapabilities{
+		partNumber: desc.GetPartNumber(),
+		caps:       proto.Clone(caps).(*bpb.DeviceCapabilities),
+	}
+}
+
+// get returns the capabilities reported for the chassis, or nil if the device never reported any.
+func (r *capabilityRegistry) get(desc *bpb.ChassisDescriptor) *bpb.DeviceCapabilities {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if rc, ok := r.byChassis[capabilityKey(desc)]; ok {
+		return rc.caps
+	}
+	return nil
+}
+
+func (r *capabilityRegistry) stats() []*PlatformCapabilities {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	type platform struct{ manufacturer, partNumber string }
+	platforms := map[platform]*PlatformCapabilities{}
+	for l, rc := range r.byChassis {
+		key := platform{l.Manufacturer, rc.partNumber}
+		pc, ok := platforms[key]
+		if !ok {
+			pc = &PlatformCapabilities{
+				Manufacturer:  l.Manufacturer,
+				PartNumber:    rc.partNumber,
+				Features:      map[string]int{},
+				ArtifactTypes: map[bpb.ArtifactType]int{},
+			}
+			platforms[key] = pc
+		}
+		pc.Devices++
+		for _, f := range rc.caps.GetFeatures() {
+			pc.Features[f]++
+		}
+		for _, t := range rc.caps.GetArtifactTypes() {
+			pc.ArtifactTypes[t]++
+		}
+	}
+	var stats []*PlatformCapabilities
+	for _, pc := range platforms {
+		stats = append(stats, pc)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Manufacturer != stats[j].Manufacturer {
+			return stats[i].Manufacturer < stats[j].Manufacturer
+		}
+		return stats[i].PartNumber < stats[j].PartNumber
+	})
+	return stats
+}
+
+// supportsArtifact reports whether the device advertised support for the artifact type.
+// Devices which did not list any artifact types are assumed to support all of them.
+func supportsArtifact(caps *bpb.DeviceCapabilities, t bpb.ArtifactType) bool {
+	if len(caps.GetArtifactTypes()) == 0 {
+		return true
+	}
+	for _, st := range caps.GetArtifactTypes() {
+		if st == t {
+			return true
+		}
+	}
+	return false
+}
+
+// tailorResponse removes the config artifacts the device reported it cannot consume: the vendor
+// config, the OpenConfig config and the gnmi.SetRequest of the boot config. Security artifacts
+// are never removed, since serving a device without its credentials, pathz or authz policies or
+// certificates would weaken it; if the device reported it cannot consume one which is to be
+// served, a FailedPrecondition error is returned instead.
+func tailorResponse(caps *bpb.DeviceCapabilities, resp *bpb.BootstrapDataResponse) error {
+	if caps == nil || resp == nil {
+		return nil
+	}
+	if bc := resp.GetBootConfig(); bc != nil {
+		if !supportsArtifact(caps, bpb.ArtifactType_ARTIFACT_TYPE_VENDOR_CONFIG) {
+			bc.VendorConfig = nil
+		}
+		if !supportsArtifact(caps, bpb.ArtifactType_ARTIFACT_TYPE_OC_CONFIG) {
+			bc.OcConfig = nil
+		}
+		if !supportsArtifact(caps, bpb.ArtifactType_ARTIFACT_TYPE_GNMI_SET_REQUEST) {
+			bc.GnmiSetRequest = nil
+		}
+	}
+	for _, a := range []struct {
+		t      bpb.ArtifactType
+		served bool
+	}{
+		{bpb.ArtifactType_ARTIFACT_TYPE_CREDENTIALS, resp.GetCredentials() != nil},
+		{bpb.ArtifactType_ARTIFACT_TYPE_PATHZ, resp.GetPathz() != nil},
+		{bpb.ArtifactType_ARTIFACT_TYPE_AUTHZ, resp.GetAuthz() != nil},
+		{bpb.ArtifactType_ARTIFACT_TYPE_CERTZ, resp.GetCertificates() != nil},
+	} {
+		if a.served && !supportsArtifact(caps, a.t) {
+			return status.Errorf(codes.FailedPrecondition, "control card %v reported it cannot consume %v, which it must be served", resp.GetSerialNum(), a.t)
+		}
+	}
+	return nil
+}
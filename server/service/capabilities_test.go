@@ -0,0 +1,127 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	bpb "github.com/openconfig/bootz/proto/bootz"
+)
+
+func TestTailorResponse(t *testing.T) {
+	full := func() *bpb.BootstrapDataResponse {
+		return &bpb.BootstrapDataResponse{
+			SerialNum: "123A",
+			BootConfig: &bpb.BootConfig{
+				VendorConfig:   []byte("hostname test"),
+				OcConfig:       []byte("{}"),
+				GnmiSetRequest: []byte{0x01},
+			},
+			Credentials: &bpb.Credentials{},
+		}
+	}
+	tests := []struct {
+		desc     string
+		caps     *bpb.DeviceCapabilities
+		want     *bpb.BootstrapDataResponse
+		wantCode codes.Code
+	}{{
+		desc: "No capabilities reported",
+		want: full(),
+	}, {
+		desc: "All artifact types supported",
+		caps: &bpb.DeviceCapabilities{Features: []string{"ownership-voucher"}},
+		want: full(),
+	}, {
+		desc: "Only OC config and credentials supported",
+		caps: &bpb.DeviceCapabilities{
+			ArtifactTypes: []bpb.ArtifactType{bpb.ArtifactType_ARTIFACT_TYPE_OC_CONFIG, bpb.ArtifactType_ARTIFACT_TYPE_CREDENTIALS},
+		},
+		want: &bpb.BootstrapDataResponse{
+			SerialNum: "123A",
+			BootConfig: &bpb.BootConfig{
+				OcConfig: []byte("{}"),
+			},
+			Credentials: &bpb.Credentials{},
+		},
+	}, {
+		desc: "Only gNMI SetRequest and credentials supported",
+		caps: &bpb.DeviceCapabilities{
+			ArtifactTypes: []bpb.ArtifactType{bpb.ArtifactType_ARTIFACT_TYPE_GNMI_SET_REQUEST, bpb.ArtifactType_ARTIFACT_TYPE_CREDENTIALS},
+		},
+		want: &bpb.BootstrapDataResponse{
+			SerialNum: "123A",
+			BootConfig: &bpb.BootConfig{
+				GnmiSetRequest: []byte{0x01},
+			},
+			Credentials: &bpb.Credentials{},
+		},
+	}, {
+		desc: "Served credentials not supported",
+		caps: &bpb.DeviceCapabilities{
+			ArtifactTypes: []bpb.ArtifactType{bpb.ArtifactType_ARTIFACT_TYPE_OC_CONFIG},
+		},
+		wantCode: codes.FailedPrecondition,
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			got := full()
+			err := tailorResponse(test.caps, got)
+			if status.Code(err) != test.wantCode {
+				t.Fatalf("tailorResponse() err = %v, want code %v", err, test.wantCode)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(test.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("tailorResponse() diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCapabilityStats(t *testing.T) {
+	r := newCapabilityRegistry()
+	r.set(&bpb.ChassisDescriptor{Manufacturer: "Cisco", PartNumber: "8808", SerialNumber: "123"}, &bpb.DeviceCapabilities{
+		Features:      []string{"ownership-voucher"},
+		ArtifactTypes: []bpb.ArtifactType{bpb.ArtifactType_ARTIFACT_TYPE_OC_CONFIG},
+	})
+	r.set(&bpb.ChassisDescriptor{Manufacturer: "Cisco", PartNumber: "8808", ControlCards: []*bpb.ControlCard{{SerialNumber: "456A"}}}, &bpb.DeviceCapabilities{
+		Features: []string{"ownership-voucher"},
+	})
+	r.set(&bpb.ChassisDescriptor{Manufacturer: "Arista", PartNumber: "7280", SerialNumber: "789"}, &bpb.DeviceCapabilities{})
+
+	want := []*PlatformCapabilities{{
+		Manufacturer:  "Arista",
+		PartNumber:    "7280",
+		Devices:       1,
+		Features:      map[string]int{},
+		ArtifactTypes: map[bpb.ArtifactType]int{},
+	}, {
+		Manufacturer:  "Cisco",
+		PartNumber:    "8808",
+		Devices:       2,
+		Features:      map[string]int{"ownership-voucher": 2},
+		ArtifactTypes: map[bpb.ArtifactType]int{bpb.ArtifactType_ARTIFACT_TYPE_OC_CONFIG: 1},
+	}}
+	if diff := cmp.Diff(want, r.stats()); diff != "" {
+		t.Errorf("stats() diff (-want, +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/openconfig/gnmi/errlist"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	bpb "github.com/openconfig/bootz/proto/bootz"
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+	log "github.com/openconfig/bootz/server/logging"
+)
+
+// SetChassisDescriptorValidation sets how the chassis descriptors of requests are validated. It
+// must be called before the service serves requests.
+func (s *Service) SetChassisDescriptorValidation(mode epb.ChassisDescriptorValidation) {
+	s.descriptorValidation = mode
+}
+
+// validateDescriptor returns every inconsistency of the chassis descriptor, or nil if it is valid.
+func validateDescriptor(desc *bpb.ChassisDescriptor) error {
+	var errs errlist.List
+	if strings.TrimSpace(desc.GetManufacturer()) == "" {
+		errs.Add(fmt.Errorf("manufacturer is empty"))
+	}
+	serials := map[string]string{}
+	if sn := desc.GetSerialNumber(); strings.TrimSpace(sn) != "" {
+		serials[sn] = "chassis"
+	} else if len(desc.GetControlCards()) == 0 {
+		errs.Add(fmt.Errorf("serial number of fixed chassis is empty"))
+	}
+	slots := map[int32]int{}
+	for i, cc := range desc.GetControlCards() {
+		if slot := cc.GetSlot(); slot < 0 {
+			errs.Add(fmt.Errorf("control card %d has negative slot %d", i, slot))
+		} else if j, ok := slots[slot]; ok {
+			errs.Add(fmt.Errorf("control cards %d and %d are both in slot %d", j, i, slot))
+		} else {
+			slots[slot] = i
+		}
+		sn := cc.GetSerialNumber()
+		if strings.TrimSpace(sn) == "" {
+			errs.Add(fmt.Errorf("serial number of control card %d is empty", i))
+			continue
+		}
+		if other, ok := serials[sn]; ok {
+			errs.Add(fmt.Errorf("serial number %q of control card %d is also the serial number of %s", sn, i, other))
+			continue
+		}
+		serials[sn] = fmt.Sprintf("control card %d", i)
+	}
+	return errs.Err()
+}
+
+// checkDescriptor validates the chassis descriptor of a request, logging or rejecting invalid
+// descriptors depending on the configured mode.
+func (s *Service) checkDescriptor(desc *bpb.ChassisDescriptor) error {
+	if s.descriptorValidation == epb.ChassisDescriptorValidation_CHASSIS_DESCRIPTOR_VALIDATION_NONE {
+		return nil
+	}
+	err := validateDescriptor(desc)
+	if err == nil {
+		return nil
+	}
+	if s.descriptorValidation == epb.ChassisDescriptorValidation_CHASSIS_DESCRIPTOR_VALIDATION_LOG_ONLY {
+		log.Warningf("Invalid chassis descriptor for %v chassis %v: %v", desc.GetManufacturer(), desc.GetSerialNumber(), err)
+		return nil
+	}
+	return status.Errorf(codes.InvalidArgument, "invalid chassis descriptor: %v", err)
+}
@@ -0,0 +1,122 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"testing"
+
+	"github.com/h-fam/errdiff"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	bpb "github.com/openconfig/bootz/proto/bootz"
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+)
+
+func TestValidateDescriptor(t *testing.T) {
+	tests := []struct {
+		desc    string
+		chassis *bpb.ChassisDescriptor
+		wantErr string
+	}{{
+		desc:    "Fixed chassis",
+		chassis: &bpb.ChassisDescriptor{Manufacturer: "Cisco", SerialNumber: "123"},
+	}, {
+		desc: "Modular chassis without serial number",
+		chassis: &bpb.ChassisDescriptor{Manufacturer: "Cisco", ControlCards: []*bpb.ControlCard{
+			{SerialNumber: "123A", Slot: 1},
+			{SerialNumber: "123B", Slot: 2},
+		}},
+	}, {
+		desc:    "No manufacturer",
+		chassis: &bpb.ChassisDescriptor{Manufacturer: " ", SerialNumber: "123"},
+		wantErr: "manufacturer is empty",
+	}, {
+		desc:    "Fixed chassis without serial number",
+		chassis: &bpb.ChassisDescriptor{Manufacturer: "Cisco"},
+		wantErr: "serial number of fixed chassis is empty",
+	}, {
+		desc: "Control card without serial number",
+		chassis: &bpb.ChassisDescriptor{Manufacturer: "Cisco", SerialNumber: "123", ControlCards: []*bpb.ControlCard{
+			{SerialNumber: "123A", Slot: 1},
+			{Slot: 2},
+		}},
+		wantErr: "serial number of control card 1 is empty",
+	}, {
+		desc: "Negative slot",
+		chassis: &bpb.ChassisDescriptor{Manufacturer: "Cisco", ControlCards: []*bpb.ControlCard{
+			{SerialNumber: "123A", Slot: -1},
+		}},
+		wantErr: "control card 0 has negative slot -1",
+	}, {
+		desc: "Shared slot",
+		chassis: &bpb.ChassisDescriptor{Manufacturer: "Cisco", ControlCards: []*bpb.ControlCard{
+			{SerialNumber: "123A", Slot: 1},
+			{SerialNumber: "123B", Slot: 1},
+		}},
+		wantErr: "control cards 0 and 1 are both in slot 1",
+	}, {
+		desc: "Duplicate control card serial numbers",
+		chassis: &bpb.ChassisDescriptor{Manufacturer: "Cisco", ControlCards: []*bpb.ControlCard{
+			{SerialNumber: "123A", Slot: 1},
+			{SerialNumber: "123A", Slot: 2},
+		}},
+		wantErr: `serial number "123A" of control card 1 is also the serial number of control card 0`,
+	}, {
+		desc: "Control card with the chassis serial number",
+		chassis: &bpb.ChassisDescriptor{Manufacturer: "Cisco", SerialNumber: "123", ControlCards: []*bpb.ControlCard{
+			{SerialNumber: "123", Slot: 1},
+		}},
+		wantErr: `serial number "123" of control card 0 is also the serial number of chassis`,
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			err := validateDescriptor(test.chassis)
+			if diff := errdiff.Substring(err, test.wantErr); diff != "" {
+				t.Errorf("validateDescriptor() %s", diff)
+			}
+		})
+	}
+}
+
+func TestCheckDescriptor(t *testing.T) {
+	invalid := &bpb.ChassisDescriptor{SerialNumber: "123"}
+	tests := []struct {
+		desc     string
+		mode     epb.ChassisDescriptorValidation
+		wantCode codes.Code
+	}{{
+		desc:     "Validation disabled",
+		mode:     epb.ChassisDescriptorValidation_CHASSIS_DESCRIPTOR_VALIDATION_NONE,
+		wantCode: codes.OK,
+	}, {
+		desc:     "Invalid descriptor logged only",
+		mode:     epb.ChassisDescriptorValidation_CHASSIS_DESCRIPTOR_VALIDATION_LOG_ONLY,
+		wantCode: codes.OK,
+	}, {
+		desc:     "Invalid descriptor rejected",
+		mode:     epb.ChassisDescriptorValidation_CHASSIS_DESCRIPTOR_VALIDATION_STRICT,
+		wantCode: codes.InvalidArgument,
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := New(nil)
+			s.SetChassisDescriptorValidation(test.mode)
+			if got := status.Code(s.checkDescriptor(invalid)); got != test.wantCode {
+				t.Errorf("checkDescriptor() code = %v, want %v", got, test.wantCode)
+			}
+		})
+	}
+}
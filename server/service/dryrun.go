@@ -0,0 +1,60 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"google.golang.org/protobuf/types/known/structpb"
+
+	bpb "github.com/openconfig/bootz/proto/bootz"
+)
+
+// dryRunPlaceholder replaces the secrets and configs of bootstrap data in dry-run mode.
+const dryRunPlaceholder = "bootz-dry-run"
+
+// SetDryRun enables the dry-run mode, in which bootstrap requests are resolved, validated and
+// signed as usual, but the configs, credentials and images of the responses are replaced with
+// placeholders. It must be called before the service serves requests.
+func (s *Service) SetDryRun(enabled bool) {
+	s.dryRun = enabled
+}
+
+// dryRunResponse replaces the configs, credentials, boot password hash and intended image of r
+// with placeholders, keeping the fields devices need to validate the response.
+func dryRunResponse(r *bpb.BootstrapDataResponse) {
+	if r.GetBootPasswordHash() != "" {
+		r.BootPasswordHash = dryRunPlaceholder
+	}
+	if r.GetIntendedImage() != nil {
+		r.IntendedImage = &bpb.SoftwareImage{Name: dryRunPlaceholder}
+	}
+	if r.GetCredentials() != nil {
+		r.Credentials = &bpb.Credentials{}
+	}
+	if bc := r.GetBootConfig(); bc != nil {
+		placeholder := func(b []byte) []byte {
+			if len(b) == 0 {
+				return nil
+			}
+			return []byte(dryRunPlaceholder)
+		}
+		r.BootConfig = &bpb.BootConfig{
+			Metadata:       &structpb.Struct{Fields: map[string]*structpb.Value{"dry_run": structpb.NewBoolValue(true)}},
+			VendorConfig:   placeholder(bc.GetVendorConfig()),
+			OcConfig:       placeholder(bc.GetOcConfig()),
+			GnmiSetRequest: placeholder(bc.GetGnmiSetRequest()),
+			Encoding:       bc.GetEncoding(),
+		}
+	}
+}
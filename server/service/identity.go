@@ -0,0 +1,118 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	bpb "github.com/openconfig/bootz/proto/bootz"
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+	log "github.com/openconfig/bootz/server/logging"
+)
+
+// SetPeerIdentityCheck sets how the TLS client certificate of a device is checked against its
+// chassis descriptor. It must be called before the service serves requests.
+func (s *Service) SetPeerIdentityCheck(mode epb.PeerIdentityCheck) {
+	s.peerIdentity = mode
+}
+
+// peerCertificate returns the leaf TLS client certificate of the peer of the request, or nil if
+// the peer did not present one.
+func peerCertificate(ctx context.Context) *x509.Certificate {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.PeerCertificates) == 0 {
+		return nil
+	}
+	return info.State.PeerCertificates[0]
+}
+
+// certIdentities returns the identities a device certificate may carry its serial number in.
+func certIdentities(cert *x509.Certificate) []string {
+	var ids []string
+	for _, id := range append([]string{cert.Subject.SerialNumber, cert.Subject.CommonName}, cert.DNSNames...) {
+		if id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// matchPeerIdentity returns an error unless an identity of cert is the serial number of the
+// chassis or of one of its control cards.
+func matchPeerIdentity(cert *x509.Certificate, desc *bpb.ChassisDescriptor) error {
+	serials := map[string]bool{}
+	if sn := desc.GetSerialNumber(); sn != "" {
+		serials[sn] = true
+	}
+	for _, cc := range desc.GetControlCards() {
+		if sn := cc.GetSerialNumber(); sn != "" {
+			serials[sn] = true
+		}
+	}
+	ids := certIdentities(cert)
+	for _, id := range ids {
+		if serials[id] {
+			return nil
+		}
+	}
+	return fmt.Errorf("client certificate identities %q do not match chassis %v or its control cards", ids, desc.GetSerialNumber())
+}
+
+// checkPeerIdentity checks the TLS client certificate of the peer of the request against the
+// chassis descriptor, logging or rejecting mismatches depending on the configured mode.
+func (s *Service) checkPeerIdentity(ctx context.Context, desc *bpb.ChassisDescriptor) error {
+	if s.peerIdentity == epb.PeerIdentityCheck_PEER_IDENTITY_CHECK_NONE {
+		return nil
+	}
+	var err error
+	if cert := peerCertificate(ctx); cert == nil {
+		err = fmt.Errorf("no client certificate presented")
+	} else {
+		err = matchPeerIdentity(cert, desc)
+	}
+	if err == nil {
+		return nil
+	}
+	if s.peerIdentity == epb.PeerIdentityCheck_PEER_IDENTITY_CHECK_LOG_ONLY {
+		log.Warningf("Peer identity check failed for %v chassis %v: %v", desc.GetManufacturer(), desc.GetSerialNumber(), err)
+		return nil
+	}
+	return status.Errorf(codes.PermissionDenied, "peer identity check failed: %v", err)
+}
+
+// peerAuthenticated reports whether the peer of the request presented a TLS client certificate
+// which was verified against the client CA and names the chassis or one of its control cards.
+func peerAuthenticated(ctx context.Context, desc *bpb.ChassisDescriptor) bool {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return false
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.VerifiedChains) == 0 || len(info.State.VerifiedChains[0]) == 0 {
+		return false
+	}
+	return matchPeerIdentity(info.State.VerifiedChains[0][0], desc) == nil
+}
@@ -0,0 +1,70 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+
+	bpb "github.com/openconfig/bootz/proto/bootz"
+	log "github.com/openconfig/bootz/server/logging"
+)
+
+// inProcessBufferSize is the size of the in-memory buffers of the connections of InProcess.
+const inProcessBufferSize = 1 << 20
+
+// InProcess serves the bootstrap service over in-memory connections, so that integration tests
+// and relays running in the same process reach it without TCP.
+type InProcess struct {
+	serv *grpc.Server
+	lis  *bufconn.Listener
+}
+
+// ServeInProcess serves the service on a new gRPC server created with opts until Stop is called.
+func (s *Service) ServeInProcess(opts ...grpc.ServerOption) *InProcess {
+	p := &InProcess{
+		serv: grpc.NewServer(opts...),
+		lis:  bufconn.Listen(inProcessBufferSize),
+	}
+	bpb.RegisterBootstrapServer(p.serv, s)
+	go func() {
+		if err := p.serv.Serve(p.lis); err != nil {
+			log.Errorf("In-process server stopped: %v", err)
+		}
+	}()
+	return p
+}
+
+// Server returns the gRPC server, e.g. to register other services on it.
+func (p *InProcess) Server() *grpc.Server {
+	return p.serv
+}
+
+// Dial connects to the service with opts, which must set transport credentials matching those of
+// the server, e.g. insecure.NewCredentials() if it has none.
+func (p *InProcess) Dial(ctx context.Context, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	opts = append([]grpc.DialOption{grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+		return p.lis.DialContext(ctx)
+	})}, opts...)
+	return grpc.DialContext(ctx, "passthrough:///bootz", opts...)
+}
+
+// Stop stops the server, closing the connections.
+func (p *InProcess) Stop() {
+	p.serv.Stop()
+}
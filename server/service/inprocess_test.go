@@ -0,0 +1,50 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	bpb "github.com/openconfig/bootz/proto/bootz"
+)
+
+func TestServeInProcess(t *testing.T) {
+	p := New(&slowEntityManager{}).ServeInProcess()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	conn, err := p.Dial(ctx, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Dial() err = %v, want nil", err)
+	}
+	defer conn.Close()
+	client := bpb.NewBootstrapClient(conn)
+	if _, err := client.ReportStatus(ctx, &bpb.ReportStatusRequest{
+		States: []*bpb.ControlCardState{{SerialNumber: "123A"}},
+	}); err != nil {
+		t.Errorf("ReportStatus() err = %v, want nil", err)
+	}
+
+	p.Stop()
+	if _, err := client.ReportStatus(ctx, &bpb.ReportStatusRequest{}); status.Code(err) != codes.Unavailable {
+		t.Errorf("ReportStatus() after Stop() err = %v, want code %v", err, codes.Unavailable)
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/openconfig/bootz/server/policy"
+
+	bpb "github.com/openconfig/bootz/proto/bootz"
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+)
+
+// SetPolicies sets the policies bootstrap requests must be allowed by. It must be called before
+// the service serves requests.
+func (s *Service) SetPolicies(cfgs []*epb.BootstrapPolicy) error {
+	p, err := policy.New(cfgs)
+	if err != nil {
+		return err
+	}
+	s.policies = p
+	return nil
+}
+
+// checkPolicies returns an error if the policies deny the bootstrap request of the chassis.
+func (s *Service) checkPolicies(ctx context.Context, req *bpb.GetBootstrapDataRequest, chassis *ChassisEntity) error {
+	if len(s.policies) == 0 {
+		return nil
+	}
+	desc := req.GetChassisDescriptor()
+	return s.policies.Allow(ctx, &policy.Request{
+		SerialNumber:        requestingSerial(req),
+		ChassisSerialNumber: desc.GetSerialNumber(),
+		Manufacturer:        desc.GetManufacturer(),
+		PartNumber:          desc.GetPartNumber(),
+		SourceIP:            peerHost(ctx),
+		Tenant:              chassis.Tenant,
+		Site:                chassis.Site,
+		BootMode:            chassis.BootMode.String(),
+		Time:                time.Now(),
+	})
+}
@@ -0,0 +1,180 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	bpb "github.com/openconfig/bootz/proto/bootz"
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+	log "github.com/openconfig/bootz/server/logging"
+)
+
+const (
+	// defaultQuarantineRetry is the interval held devices are asked to wait before retrying by default.
+	defaultQuarantineRetry = 5 * time.Minute
+	// defaultMaxPending is the maximum number of devices pending approval by default.
+	defaultMaxPending = 1000
+)
+
+// PendingDevice is an unknown device held in quarantine pending approval.
+type PendingDevice struct {
+	// ChassisDescriptor is the chassis descriptor of the last request of the device.
+	ChassisDescriptor *bpb.ChassisDescriptor
+	// SourceAddress is the address the last request was received from.
+	SourceAddress string
+	FirstSeen     time.Time
+	LastSeen      time.Time
+	Requests      int
+}
+
+// pendingKey identifies a pending device.
+type pendingKey struct {
+	manufacturer string
+	serial       string
+}
+
+// descriptorKey returns the key of the device of desc: its chassis serial number, or the serial
+// number of its first control card if the chassis reports none.
+func descriptorKey(desc *bpb.ChassisDescriptor) pendingKey {
+	serial := desc.GetSerialNumber()
+	if serial == "" && len(desc.GetControlCards()) > 0 {
+		serial = desc.GetControlCards()[0].GetSerialNumber()
+	}
+	return pendingKey{manufacturer: desc.GetManufacturer(), serial: serial}
+}
+
+// quarantine holds the unknown devices pending approval.
+type quarantine struct {
+	mu      sync.Mutex
+	retry   time.Duration
+	max     int
+	devices map[pendingKey]*PendingDevice
+	// dropped counts the requests of unknown devices refused because the quarantine was full.
+	dropped int
+}
+
+// SetQuarantine holds unknown devices in quarantine pending approval instead of rejecting them,
+// or rejects them again if cfg is nil. It must be called before the service serves requests.
+func (s *Service) SetQuarantine(cfg *epb.Quarantine) {
+	if cfg == nil {
+		s.quarantine = nil
+		return
+	}
+	q := &quarantine{
+		retry:   defaultQuarantineRetry,
+		max:     defaultMaxPending,
+		devices: map[pendingKey]*PendingDevice{},
+	}
+	if d := cfg.GetRetryInterval(); d != nil {
+		q.retry = d.AsDuration()
+	}
+	if n := cfg.GetMaxPending(); n > 0 {
+		q.max = int(n)
+	}
+	s.quarantine = q
+}
+
+// hold records a request of an unknown device, and returns an error asking it to retry later. If
+// the queue is full the device is not held, but still asked to retry later when room may be free.
+func (q *quarantine) hold(desc *bpb.ChassisDescriptor, addr string) error {
+	key := descriptorKey(desc)
+	now := time.Now()
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	d, ok := q.devices[key]
+	if !ok {
+		if len(q.devices) >= q.max {
+			q.dropped++
+			log.Warningf("Quarantine is full, rejecting %v chassis %v (%d requests rejected)", key.manufacturer, key.serial, q.dropped)
+			return RetryLater(q.retry, "quarantine is full with %d devices pending approval", len(q.devices))
+		}
+		d = &PendingDevice{FirstSeen: now}
+		q.devices[key] = d
+		log.Infof("Holding unknown %v chassis %v in quarantine pending approval", key.manufacturer, key.serial)
+	}
+	d.ChassisDescriptor = proto.Clone(desc).(*bpb.ChassisDescriptor)
+	d.SourceAddress = addr
+	d.LastSeen = now
+	d.Requests++
+	return RetryLater(q.retry, "%v chassis %v is pending approval", key.manufacturer, key.serial)
+}
+
+// PendingDevices returns the devices held in quarantine, oldest first.
+func (s *Service) PendingDevices() []PendingDevice {
+	q := s.quarantine
+	if q == nil {
+		return nil
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	var out []PendingDevice
+	for _, d := range q.devices {
+		out = append(out, *d)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].FirstSeen.Equal(out[j].FirstSeen) {
+			return out[i].FirstSeen.Before(out[j].FirstSeen)
+		}
+		return out[i].ChassisDescriptor.GetSerialNumber() < out[j].ChassisDescriptor.GetSerialNumber()
+	})
+	return out
+}
+
+// DroppedRequests returns the number of requests of unknown devices not held because the
+// quarantine was full.
+func (s *Service) DroppedRequests() int {
+	q := s.quarantine
+	if q == nil {
+		return 0
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.dropped
+}
+
+// PendingDevice returns the device with the manufacturer and serial number held in quarantine.
+func (s *Service) PendingDevice(manufacturer, serial string) (PendingDevice, bool) {
+	q := s.quarantine
+	if q == nil {
+		return PendingDevice{}, false
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	d, ok := q.devices[pendingKey{manufacturer: manufacturer, serial: serial}]
+	if !ok {
+		return PendingDevice{}, false
+	}
+	return *d, true
+}
+
+// ReleasePendingDevice removes the device with the manufacturer and serial number from the
+// quarantine, once it is approved or rejected. It reports whether the device was held.
+func (s *Service) ReleasePendingDevice(manufacturer, serial string) bool {
+	q := s.quarantine
+	if q == nil {
+		return false
+	}
+	key := pendingKey{manufacturer: manufacturer, serial: serial}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	_, ok := q.devices[key]
+	delete(q.devices, key)
+	return ok
+}
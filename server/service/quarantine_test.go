@@ -0,0 +1,99 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	bpb "github.com/openconfig/bootz/proto/bootz"
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+)
+
+func TestQuarantine(t *testing.T) {
+	s := &Service{}
+	s.SetQuarantine(&epb.Quarantine{RetryInterval: durationpb.New(time.Minute), MaxPending: 2})
+	fixed := &bpb.ChassisDescriptor{Manufacturer: "Cisco", SerialNumber: "123"}
+	modular := &bpb.ChassisDescriptor{Manufacturer: "Cisco", ControlCards: []*bpb.ControlCard{{SerialNumber: "456A"}}}
+	overflow := &bpb.ChassisDescriptor{Manufacturer: "Cisco", SerialNumber: "789"}
+
+	tests := []struct {
+		desc      string
+		chassis   *bpb.ChassisDescriptor
+		wantCode  codes.Code
+		wantDelay time.Duration
+	}{{
+		desc:      "Fixed chassis",
+		chassis:   fixed,
+		wantCode:  codes.Unavailable,
+		wantDelay: time.Minute,
+	}, {
+		desc:      "Modular chassis",
+		chassis:   modular,
+		wantCode:  codes.Unavailable,
+		wantDelay: time.Minute,
+	}, {
+		desc:      "Retrying chassis",
+		chassis:   fixed,
+		wantCode:  codes.Unavailable,
+		wantDelay: time.Minute,
+	}, {
+		desc:      "Full quarantine",
+		chassis:   overflow,
+		wantCode:  codes.Unavailable,
+		wantDelay: time.Minute,
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			err := s.quarantine.hold(test.chassis, "192.0.2.1")
+			if got := status.Code(err); got != test.wantCode {
+				t.Errorf("hold() err code = %v, want %v", got, test.wantCode)
+			}
+			if got, _ := RetryDelay(err); got != test.wantDelay {
+				t.Errorf("hold() retry delay = %v, want %v", got, test.wantDelay)
+			}
+		})
+	}
+
+	pending := s.PendingDevices()
+	if len(pending) != 2 {
+		t.Fatalf("PendingDevices() returned %d devices, want 2", len(pending))
+	}
+	if _, ok := s.PendingDevice("Cisco", "789"); ok {
+		t.Errorf("PendingDevice(%q) = true, want device refused by the full quarantine", "789")
+	}
+	if got := s.DroppedRequests(); got != 1 {
+		t.Errorf("DroppedRequests() = %d, want 1", got)
+	}
+	if d, ok := s.PendingDevice("Cisco", "123"); !ok || d.Requests != 2 || d.SourceAddress != "192.0.2.1" {
+		t.Errorf("PendingDevice(%q) = %+v, %v, want 2 requests from 192.0.2.1", "123", d, ok)
+	}
+	if _, ok := s.PendingDevice("Cisco", "456A"); !ok {
+		t.Errorf("PendingDevice(%q) = false, want modular chassis held by its control card", "456A")
+	}
+	if !s.ReleasePendingDevice("Cisco", "123") {
+		t.Errorf("ReleasePendingDevice() = false, want true")
+	}
+	if s.ReleasePendingDevice("Cisco", "123") {
+		t.Errorf("ReleasePendingDevice() of released device = true, want false")
+	}
+	if err := s.quarantine.hold(overflow, "192.0.2.1"); status.Code(err) != codes.Unavailable {
+		t.Errorf("hold() after release err = %v, want Unavailable", err)
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"github.com/openconfig/bootz/server/quirks"
+	"google.golang.org/protobuf/proto"
+
+	bpb "github.com/openconfig/bootz/proto/bootz"
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+)
+
+// SetVendorQuirks sets the quirks normalizing the chassis descriptors of vendors deviating from
+// the specification. It must be called before the service serves requests.
+func (s *Service) SetVendorQuirks(q []*epb.VendorQuirks) error {
+	a, err := quirks.New(q)
+	if err != nil {
+		return err
+	}
+	s.quirks = a
+	return nil
+}
+
+// adaptRequest returns the request with its chassis descriptor normalized with the quirks of
+// its vendor. The request itself is returned if the descriptor needs no normalization.
+func (s *Service) adaptRequest(req *bpb.GetBootstrapDataRequest) *bpb.GetBootstrapDataRequest {
+	desc := s.quirks.Adapt(req.GetChassisDescriptor())
+	if desc == req.GetChassisDescriptor() {
+		return req
+	}
+	adapted := proto.Clone(req).(*bpb.GetBootstrapDataRequest)
+	adapted.ChassisDescriptor = desc
+	return adapted
+}
@@ -0,0 +1,97 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	bpb "github.com/openconfig/bootz/proto/bootz"
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+)
+
+// redirectRule redirects the devices matching all of its criteria to other bootz servers.
+type redirectRule struct {
+	subnets        []*net.IPNet
+	serialPrefixes []string
+	redirect       *bpb.RedirectInfo
+}
+
+// matches reports whether a device connecting from ip with the chassis serial number matches the rule.
+func (r *redirectRule) matches(ip net.IP, serial string) bool {
+	if len(r.subnets) > 0 && !subnetsContain(r.subnets, ip) {
+		return false
+	}
+	if len(r.serialPrefixes) > 0 {
+		found := false
+		for _, p := range r.serialPrefixes {
+			if strings.HasPrefix(serial, p) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// SetRedirects sets the rules redirecting devices to other bootz servers. It must be called
+// before the service serves requests.
+func (s *Service) SetRedirects(rules []*epb.RedirectRule) error {
+	var parsed []*redirectRule
+	for i, r := range rules {
+		if len(r.GetSourceSubnets()) == 0 && len(r.GetSerialPrefixes()) == 0 {
+			return fmt.Errorf("redirect rule %d sets neither source subnets nor serial prefixes", i)
+		}
+		if len(r.GetServers()) == 0 {
+			return fmt.Errorf("redirect rule %d has no servers", i)
+		}
+		rule := &redirectRule{
+			serialPrefixes: r.GetSerialPrefixes(),
+			redirect:       &bpb.RedirectInfo{Servers: r.GetServers()},
+		}
+		for _, c := range r.GetSourceSubnets() {
+			_, n, err := net.ParseCIDR(c)
+			if err != nil {
+				return fmt.Errorf("redirect rule %d: invalid source subnet %q: %v", i, c, err)
+			}
+			rule.subnets = append(rule.subnets, n)
+		}
+		for _, srv := range r.GetServers() {
+			if _, _, err := net.SplitHostPort(srv.GetAddress()); err != nil {
+				return fmt.Errorf("redirect rule %d: invalid server address %q: %v", i, srv.GetAddress(), err)
+			}
+		}
+		parsed = append(parsed, rule)
+	}
+	s.redirects = parsed
+	return nil
+}
+
+// redirect returns the servers the device making the request is redirected to, or nil if it
+// is served by this server.
+func (s *Service) redirect(ctx context.Context, desc *bpb.ChassisDescriptor) *bpb.RedirectInfo {
+	ip := net.ParseIP(peerHost(ctx))
+	for _, r := range s.redirects {
+		if r.matches(ip, desc.GetSerialNumber()) {
+			return r.redirect
+		}
+	}
+	return nil
+}
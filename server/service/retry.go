@@ -0,0 +1,73 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/openconfig/gnmi/errlist"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// RetryLater returns an Unavailable error asking the device to come back after delay, for
+// devices which are known but whose artifacts are not staged yet. The delay is carried in a
+// RetryInfo detail so devices back off instead of retrying immediately.
+func RetryLater(delay time.Duration, format string, a ...any) error {
+	st := status.New(codes.Unavailable, fmt.Sprintf(format, a...))
+	withDelay, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(delay)})
+	if err != nil {
+		return st.Err()
+	}
+	return withDelay.Err()
+}
+
+// RetryDelay returns the delay an error returned by RetryLater asks to wait before retrying.
+func RetryDelay(err error) (time.Duration, bool) {
+	for _, d := range status.Convert(err).Details() {
+		if ri, ok := d.(*errdetails.RetryInfo); ok {
+			return ri.GetRetryDelay().AsDuration(), true
+		}
+	}
+	return 0, false
+}
+
+// retryOrErr returns the error of the list asking for the longest delay if every error of
+// the list asks the device to retry later, so devices back off until all their control cards
+// can be served. Otherwise it returns err.
+func retryOrErr(err error) error {
+	list, ok := err.(errlist.Errors)
+	if !ok {
+		return err
+	}
+	var retry error
+	var longest time.Duration
+	for _, e := range list.Errors() {
+		delay, ok := RetryDelay(e)
+		if !ok {
+			return err
+		}
+		if retry == nil || delay > longest {
+			retry, longest = e, delay
+		}
+	}
+	if retry == nil {
+		return err
+	}
+	return retry
+}
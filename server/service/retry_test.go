@@ -0,0 +1,80 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/openconfig/gnmi/errlist"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRetryLater(t *testing.T) {
+	err := RetryLater(time.Minute, "artifacts of chassis %s are not staged yet", "123")
+	if status.Code(err) != codes.Unavailable {
+		t.Errorf("RetryLater() code = %v, want %v", status.Code(err), codes.Unavailable)
+	}
+	if got, ok := RetryDelay(err); !ok || got != time.Minute {
+		t.Errorf("RetryDelay() = %v, %v, want %v, true", got, ok, time.Minute)
+	}
+	if _, ok := RetryDelay(status.Errorf(codes.Unavailable, "down")); ok {
+		t.Errorf("RetryDelay() of an error without retry info returned a delay, want none")
+	}
+}
+
+func TestRetryOrErr(t *testing.T) {
+	short := RetryLater(time.Minute, "short")
+	long := RetryLater(time.Hour, "long")
+	fatal := status.Errorf(codes.NotFound, "not found")
+	tests := []struct {
+		desc      string
+		errs      []error
+		wantCode  codes.Code
+		wantDelay time.Duration
+	}{{
+		desc:      "Single retry",
+		errs:      []error{short},
+		wantCode:  codes.Unavailable,
+		wantDelay: time.Minute,
+	}, {
+		desc:      "Longest retry",
+		errs:      []error{short, long},
+		wantCode:  codes.Unavailable,
+		wantDelay: time.Hour,
+	}, {
+		desc:     "Retry and failure",
+		errs:     []error{short, fatal},
+		wantCode: codes.Unknown,
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			var errs errlist.List
+			errs.Add(test.errs...)
+			err := retryOrErr(errs.Err())
+			if status.Code(err) != test.wantCode {
+				t.Fatalf("retryOrErr() err = %v, want code %v", err, test.wantCode)
+			}
+			if got, _ := RetryDelay(err); got != test.wantDelay {
+				t.Errorf("retryOrErr() delay = %v, want %v", got, test.wantDelay)
+			}
+		})
+	}
+	if err := retryOrErr(fmt.Errorf("plain")); err.Error() != "plain" {
+		t.Errorf("retryOrErr() of a plain error = %v, want it unchanged", err)
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+
+	bpb "github.com/openconfig/bootz/proto/bootz"
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+	log "github.com/openconfig/bootz/server/logging"
+)
+
+// urlRewriteRule rewrites the image URLs served to the devices matching all of its criteria.
+type urlRewriteRule struct {
+	subnets     []*net.IPNet
+	sites       map[string]bool
+	prefix      string
+	replacement string
+}
+
+// matches reports whether a device connecting from ip with its chassis at site matches the rule.
+func (r *urlRewriteRule) matches(ip net.IP, site string) bool {
+	if len(r.subnets) > 0 && !subnetsContain(r.subnets, ip) {
+		return false
+	}
+	return len(r.sites) == 0 || r.sites[site]
+}
+
+// subnetsContain reports whether ip is in one of the subnets.
+func subnetsContain(subnets []*net.IPNet, ip net.IP) bool {
+	for _, n := range subnets {
+		if ip != nil && n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// SetURLRewrites sets the rules rewriting the software image URLs served to devices. It must be
+// called before the service serves requests.
+func (s *Service) SetURLRewrites(rules []*epb.UrlRewriteRule) error {
+	var parsed []*urlRewriteRule
+	for i, r := range rules {
+		if len(r.GetSourceSubnets()) == 0 && len(r.GetSites()) == 0 {
+			return fmt.Errorf("URL rewrite rule %d sets neither source subnets nor sites", i)
+		}
+		if r.GetUrlPrefix() == "" {
+			return fmt.Errorf("URL rewrite rule %d has no URL prefix", i)
+		}
+		if u, err := url.Parse(r.GetReplacement()); err != nil || u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("URL rewrite rule %d: invalid replacement %q", i, r.GetReplacement())
+		}
+		rule := &urlRewriteRule{
+			sites:       map[string]bool{},
+			prefix:      r.GetUrlPrefix(),
+			replacement: r.GetReplacement(),
+		}
+		for _, site := range r.GetSites() {
+			rule.sites[site] = true
+		}
+		for _, c := range r.GetSourceSubnets() {
+			_, n, err := net.ParseCIDR(c)
+			if err != nil {
+				return fmt.Errorf("URL rewrite rule %d: invalid source subnet %q: %v", i, c, err)
+			}
+			rule.subnets = append(rule.subnets, n)
+		}
+		parsed = append(parsed, rule)
+	}
+	s.urlRewrites = parsed
+	return nil
+}
+
+// URLSigner signs the image URLs served to devices, e.g. those of the built-in image server.
+type URLSigner interface {
+	// SignURL returns the signed URL, or false if the URL is not signed by the signer.
+	SignURL(url string) (string, bool)
+}
+
+// SetURLSigner sets the signer of the image URLs served to devices, which is applied after the
+// URL rewrites. It must be called before the service serves requests.
+func (s *Service) SetURLSigner(signer URLSigner) {
+	s.urlSigner = signer
+}
+
+// rewriteURLs rewrites the image URLs of the responses to a device connecting from ip with its
+// chassis at site, then signs them. The first rule matching the device and the URL applies.
+func (s *Service) rewriteURLs(ip net.IP, site string, responses []*bpb.BootstrapDataResponse) {
+	for _, resp := range responses {
+		img := resp.GetIntendedImage()
+		if img.GetUrl() == "" {
+			continue
+		}
+		u := img.GetUrl()
+		for _, r := range s.urlRewrites {
+			if r.matches(ip, site) && strings.HasPrefix(u, r.prefix) {
+				u = r.replacement + strings.TrimPrefix(u, r.prefix)
+				log.Infof("Rewriting image URL %v of control card %v to %v", img.GetUrl(), resp.GetSerialNum(), u)
+				break
+			}
+		}
+		if s.urlSigner != nil {
+			if signed, ok := s.urlSigner.SignURL(u); ok {
+				u = signed
+			}
+		}
+		if u != img.GetUrl() {
+			// The image may be shared with the inventory, so it is replaced instead of modified.
+			resp.IntendedImage = proto.Clone(img).(*bpb.SoftwareImage)
+			resp.IntendedImage.Url = u
+		}
+	}
+}
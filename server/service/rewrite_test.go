@@ -0,0 +1,191 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/h-fam/errdiff"
+	"google.golang.org/grpc/peer"
+
+	bpb "github.com/openconfig/bootz/proto/bootz"
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+)
+
+// imageEntityManager serves the same image to every control card of chassis at site.
+type imageEntityManager struct {
+	EntityManager
+	site  string
+	image *bpb.SoftwareImage
+}
+
+func (m *imageEntityManager) ResolveChassis(context.Context, *EntityLookup, string) (*ChassisEntity, error) {
+	return &ChassisEntity{BootMode: bpb.BootMode_BOOT_MODE_INSECURE, Site: m.site}, nil
+}
+
+func (m *imageEntityManager) ValidateControlCards(context.Context, *EntityLookup, []*bpb.ControlCard) error {
+	return nil
+}
+
+func (m *imageEntityManager) GetBootstrapData(_ context.Context, _ *EntityLookup, cc *bpb.ControlCard) (*bpb.BootstrapDataResponse, error) {
+	return &bpb.BootstrapDataResponse{SerialNum: cc.GetSerialNumber(), IntendedImage: m.image}, nil
+}
+
+func (m *imageEntityManager) CheckBootstrapToken(context.Context, string, string, bool) (bool, error) {
+	return false, nil
+}
+
+func (m *imageEntityManager) RecordSeen(context.Context, string, string) {}
+
+func TestRewriteURLs(t *testing.T) {
+	const url = "https://images.example.com/ios/9.1.bin"
+	rules := []*epb.UrlRewriteRule{{
+		SourceSubnets: []string{"10.1.0.0/16"},
+		Sites:         []string{"par1"},
+		UrlPrefix:     "https://images.example.com/",
+		Replacement:   "https://mirror.par1.example.com/images/",
+	}, {
+		SourceSubnets: []string{"10.0.0.0/8"},
+		UrlPrefix:     "https://images.example.com/",
+		Replacement:   "https://mirror.eu.example.com/",
+	}, {
+		Sites:       []string{"nyc1"},
+		UrlPrefix:   "https://other.example.com/",
+		Replacement: "https://mirror.us.example.com/",
+	}}
+	tests := []struct {
+		desc string
+		addr string
+		site string
+		want string
+	}{{
+		desc: "First matching rule",
+		addr: "10.1.2.3",
+		site: "par1",
+		want: "https://mirror.par1.example.com/images/ios/9.1.bin",
+	}, {
+		desc: "Site of another rule",
+		addr: "10.1.2.3",
+		site: "lon1",
+		want: "https://mirror.eu.example.com/ios/9.1.bin",
+	}, {
+		desc: "URL without the prefix of the matching rule",
+		addr: "192.0.2.1",
+		site: "nyc1",
+		want: url,
+	}, {
+		desc: "No matching rule",
+		addr: "192.0.2.1",
+		site: "par1",
+		want: url,
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			image := &bpb.SoftwareImage{Name: "ios", Version: "9.1", Url: url}
+			s := New(&imageEntityManager{site: test.site, image: image})
+			if err := s.SetURLRewrites(rules); err != nil {
+				t.Fatalf("SetURLRewrites() err = %v, want nil", err)
+			}
+			ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(test.addr), Port: 4242}})
+			resp, err := s.GetBootstrapData(ctx, &bpb.GetBootstrapDataRequest{
+				ChassisDescriptor: &bpb.ChassisDescriptor{
+					Manufacturer: "Cisco",
+					SerialNumber: "123",
+					ControlCards: []*bpb.ControlCard{{SerialNumber: "123A"}, {SerialNumber: "123B"}},
+				},
+			})
+			if err != nil {
+				t.Fatalf("GetBootstrapData() err = %v, want nil", err)
+			}
+			for _, r := range resp.GetSignedResponse().GetResponses() {
+				if got := r.GetIntendedImage().GetUrl(); got != test.want {
+					t.Errorf("image URL of control card %s = %q, want %q", r.GetSerialNum(), got, test.want)
+				}
+			}
+			if image.GetUrl() != url {
+				t.Errorf("image URL of the inventory = %q, want it unchanged", image.GetUrl())
+			}
+		})
+	}
+}
+
+// prefixSigner signs the URLs starting with its prefix.
+type prefixSigner string
+
+func (p prefixSigner) SignURL(u string) (string, bool) {
+	if !strings.HasPrefix(u, string(p)) {
+		return u, false
+	}
+	return u + "?token=signed", true
+}
+
+// Tests that image URLs are signed once rewritten.
+func TestURLSigner(t *testing.T) {
+	image := &bpb.SoftwareImage{Url: "https://images.example.com/ios/9.1.bin"}
+	s := New(&imageEntityManager{site: "par1", image: image})
+	if err := s.SetURLRewrites([]*epb.UrlRewriteRule{{
+		Sites:       []string{"par1"},
+		UrlPrefix:   "https://images.example.com/",
+		Replacement: "https://bootz.par1.example.com/images/",
+	}}); err != nil {
+		t.Fatalf("SetURLRewrites() err = %v, want nil", err)
+	}
+	s.SetURLSigner(prefixSigner("https://bootz.par1.example.com/"))
+	resp, err := s.GetBootstrapData(context.Background(), &bpb.GetBootstrapDataRequest{
+		ChassisDescriptor: &bpb.ChassisDescriptor{Manufacturer: "Cisco", SerialNumber: "123"},
+	})
+	if err != nil {
+		t.Fatalf("GetBootstrapData() err = %v, want nil", err)
+	}
+	want := "https://bootz.par1.example.com/images/ios/9.1.bin?token=signed"
+	if got := resp.GetSignedResponse().GetResponses()[0].GetIntendedImage().GetUrl(); got != want {
+		t.Errorf("image URL = %q, want %q", got, want)
+	}
+}
+
+func TestSetURLRewrites(t *testing.T) {
+	tests := []struct {
+		desc    string
+		rule    *epb.UrlRewriteRule
+		wantErr string
+	}{{
+		desc:    "No criteria",
+		rule:    &epb.UrlRewriteRule{UrlPrefix: "https://a.example.com/", Replacement: "https://b.example.com/"},
+		wantErr: "neither source subnets nor sites",
+	}, {
+		desc:    "No prefix",
+		rule:    &epb.UrlRewriteRule{Sites: []string{"par1"}, Replacement: "https://b.example.com/"},
+		wantErr: "no URL prefix",
+	}, {
+		desc:    "Relative replacement",
+		rule:    &epb.UrlRewriteRule{Sites: []string{"par1"}, UrlPrefix: "https://a.example.com/", Replacement: "/images/"},
+		wantErr: "invalid replacement",
+	}, {
+		desc:    "Invalid subnet",
+		rule:    &epb.UrlRewriteRule{SourceSubnets: []string{"10.1.0.0"}, UrlPrefix: "https://a.example.com/", Replacement: "https://b.example.com/"},
+		wantErr: "invalid source subnet",
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			err := New(nil).SetURLRewrites([]*epb.UrlRewriteRule{test.rule})
+			if s := errdiff.Substring(err, test.wantErr); s != "" {
+				t.Errorf("SetURLRewrites() %s", s)
+			}
+		})
+	}
+}
@@ -17,15 +17,29 @@ package service
 
 import (
 	"context"
+	"crypto"
 	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"time"
 
+	"github.com/openconfig/bootz/server/events"
+	"github.com/openconfig/bootz/server/features"
+	"github.com/openconfig/bootz/server/keystore"
+	"github.com/openconfig/bootz/server/policy"
+	"github.com/openconfig/bootz/server/quirks"
 	"github.com/openconfig/gnmi/errlist"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 
-	log "github.com/golang/glog"
 	bpb "github.com/openconfig/bootz/proto/bootz"
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+	log "github.com/openconfig/bootz/server/logging"
 )
 
 // OVList is a mapping of control card serial number to ownership voucher.
@@ -33,15 +47,71 @@ type OVList map[string]string
 
 // KeyPair is a struct containing PEM-encoded certificates and private keys.
 type KeyPair struct {
+	// Cert holds the certificate of the key pair, optionally followed by the intermediate
+	// certificates chaining it to its root.
 	Cert       string
 	PrivateKey string
+	// Signer, if set, signs with a private key held outside the server, e.g. in a KMS or HSM.
+	// PrivateKey is unset in this case.
+	Signer crypto.Signer
+}
+
+// PrivateKeySigner returns the signer of the key pair, parsing PrivateKey if Signer is unset.
+func (k *KeyPair) PrivateKeySigner() (crypto.Signer, error) {
+	if k.Signer != nil {
+		return k.Signer, nil
+	}
+	return keystore.ParsePrivateKey([]byte(k.PrivateKey))
+}
+
+// CertChain parses the certificates of the key pair, starting with the certificate of the key pair
+// followed by its intermediates.
+func (k *KeyPair) CertChain() ([]*x509.Certificate, error) {
+	var chain []*x509.Certificate
+	rest := []byte(k.Cert)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			return nil, fmt.Errorf("unexpected PEM block of type %q in certificate chain", block.Type)
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse certificate %d of chain: %v", len(chain), err)
+		}
+		chain = append(chain, cert)
+	}
+	if len(chain) == 0 {
+		return nil, fmt.Errorf("unable to decode certificate")
+	}
+	return chain, nil
+}
+
+// TLSCertificate returns a TLS certificate presenting the certificate of the key pair.
+func (k *KeyPair) TLSCertificate() (*tls.Certificate, error) {
+	if k.Signer == nil {
+		cert, err := tls.X509KeyPair([]byte(k.Cert), []byte(k.PrivateKey))
+		return &cert, err
+	}
+	block, _ := pem.Decode([]byte(k.Cert))
+	if block == nil {
+		return nil, fmt.Errorf("unable to decode certificate")
+	}
+	return &tls.Certificate{
+		Certificate: [][]byte{block.Bytes},
+		PrivateKey:  k.Signer,
+	}, nil
 }
 
 // SecurityArtifacts contains all KeyPairs and OVs needed for the Bootz Server.
 // Currently, RSA is the only encryption standard supported by these artifacts.
 type SecurityArtifacts struct {
-	// The Ownership Certificate is an x509 certificate/private key pair signed by the PDC.
-	// The certificate is presented to the device during bootstrapping and is used to validate the Ownership Voucher.
+	// The Ownership Certificate is an x509 certificate/private key pair signed by the PDC, directly or through
+	// intermediate CAs whose certificates follow the OC in the certificate chain.
+	// The chain is presented to the device during bootstrapping and is used to validate the Ownership Voucher.
 	OC *KeyPair
 	// The Pinned Domain Certificate is an x509 certificate/private key pair which acts as a certificate authority on the owner's side.
 	// This certificate is included in OVs and is also used to generate a server TLS Cert in this implementation.
@@ -59,29 +129,151 @@ type SecurityArtifacts struct {
 type EntityLookup struct {
 	Manufacturer string
 	SerialNumber string
+	// PartNumber is optional. When set, it must match the part number of the resolved chassis.
+	// It is not part of the key the chassis is stored under.
+	PartNumber string
 }
 
 // ChassisEntity provides the mode that the system is currently
 // configured.
 type ChassisEntity struct {
 	BootMode bpb.BootMode
+	// Site and Tenant scope the feature flags applied to the chassis.
+	Site   string
+	Tenant string
 }
 
+// minNonceLength is the minimum decoded nonce length accepted when strict nonces are enforced.
+const minNonceLength = 16
+
 // EntityManager maintains the entities and their states.
 type EntityManager interface {
-	ResolveChassis(*EntityLookup, string) (*ChassisEntity, error)
-	GetBootstrapData(*EntityLookup, *bpb.ControlCard) (*bpb.BootstrapDataResponse, error)
-	SetStatus(*bpb.ReportStatusRequest) error
-	Sign(*bpb.GetBootstrapDataResponse, *EntityLookup, string) error
+	ResolveChassis(context.Context, *EntityLookup, string) (*ChassisEntity, error)
+	GetBootstrapData(context.Context, *EntityLookup, *bpb.ControlCard) (*bpb.BootstrapDataResponse, error)
+	SetStatus(context.Context, *bpb.ReportStatusRequest) error
+	Sign(context.Context, *bpb.GetBootstrapDataResponse, *EntityLookup, string, []bpb.SignatureAlgorithm) error
+	ValidateControlCards(context.Context, *EntityLookup, []*bpb.ControlCard) error
+	OwnershipVoucher(context.Context, *EntityLookup, string) ([]byte, error)
+	RecordModules(context.Context, *EntityLookup, string, []*bpb.Module, []*bpb.Module) error
+	AcceptRequestCounter(context.Context, string, uint64) error
+	CheckRequestCounter(context.Context, string, uint64) error
+	CheckBootstrapToken(context.Context, string, string, bool) (bool, error)
+	RecordSeen(context.Context, string, string)
 }
 
 // Service represents the server and entity manager.
 type Service struct {
 	bpb.UnimplementedBootstrapServer
-	em EntityManager
+	em       EntityManager
+	caps     *capabilityRegistry
+	features *features.Set
+	staging  *stagingRegistry
+	events   *events.Broker
+	hooks    []StatusHook
+	// peerIdentity is how TLS client certificates are checked against chassis descriptors.
+	peerIdentity epb.PeerIdentityCheck
+	// quirks normalizes the chassis descriptors of vendors deviating from the specification.
+	quirks *quirks.Adapter
+	// descriptorValidation is how strictly the chassis descriptors of requests are validated.
+	descriptorValidation epb.ChassisDescriptorValidation
+	redirects            []*redirectRule
+	// policies are the policies bootstrap requests must be allowed by.
+	policies policy.Set
+	// quarantine holds unknown devices pending approval, if set.
+	quarantine *quarantine
+	// admission bounds the bootstrap requests served at the same time, if set.
+	admission *admission
+	// signing bounds the responses signed at the same time, if set.
+	signing     *signingPool
+	urlRewrites []*urlRewriteRule
+	urlSigner   URLSigner
+	// dryRun replaces the secrets and configs of bootstrap data with placeholders.
+	dryRun bool
+	// timeouts bound the stages of the bootstrap RPCs.
+	timeouts map[stage]time.Duration
 }
 
+// StatusHook is called with each status report accepted by the service and the address of the
+// reporting device. Hooks are called on the request path and must not block.
+type StatusHook func(req *bpb.ReportStatusRequest, addr string)
+
+// AddStatusHook registers a hook called on status reports. Hooks must be added before the
+// service serves requests.
+func (s *Service) AddStatusHook(h StatusHook) {
+	s.hooks = append(s.hooks, h)
+}
+
+// peerHost returns the host of the peer of the request, or an empty string if unknown.
+func peerHost(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
+
+// seen records a request of the device with the given serial as its last contact, whatever its
+// outcome.
+func (s *Service) seen(ctx context.Context, serial string) {
+	if serial != "" {
+		s.em.RecordSeen(ctx, serial, peerHost(ctx))
+	}
+}
+
+// GetBootstrapData returns the signed bootstrap data of every control card of a chassis, or of a
+// fixed chassis, publishing the progress of the request to the subscribers of the status events.
 func (s *Service) GetBootstrapData(ctx context.Context, req *bpb.GetBootstrapDataRequest) (*bpb.GetBootstrapDataResponse, error) {
+	req = s.adaptRequest(req)
+	defer s.seen(ctx, requestingSerial(req))
+	desc := req.GetChassisDescriptor()
+	s.publish(desc, events.Requested, "")
+	resp, err := s.getBootstrapData(ctx, req)
+	if err == nil {
+		err = s.checkMessageSize(desc, resp)
+	}
+	if err != nil {
+		s.publish(desc, events.Rejected, err.Error())
+		return nil, err
+	}
+	s.publish(desc, events.Served, "")
+	return resp, nil
+}
+
+// checkMessageSize rejects responses larger than the maximum message size the device advertised.
+func (s *Service) checkMessageSize(desc *bpb.ChassisDescriptor, resp *bpb.GetBootstrapDataResponse) error {
+	if limit := s.caps.get(desc).GetMaxMessageSize(); limit > 0 {
+		if size := uint64(proto.Size(resp)); size > limit {
+			return status.Errorf(codes.ResourceExhausted, "bootstrap response of %d bytes exceeds device maximum message size of %d bytes, use GetBootstrapDataStream instead", size, limit)
+		}
+	}
+	return nil
+}
+
+// publish publishes an event for every control card of the chassis, or for the chassis itself
+// if it is a fixed form factor device.
+func (s *Service) publish(desc *bpb.ChassisDescriptor, stage events.Stage, msg string) {
+	e := events.Event{
+		ChassisSerialNumber: desc.GetSerialNumber(),
+		Manufacturer:        desc.GetManufacturer(),
+		Stage:               stage,
+		Message:             msg,
+	}
+	if len(desc.GetControlCards()) == 0 {
+		e.SerialNumber = desc.GetSerialNumber()
+		s.events.Publish(e)
+		return
+	}
+	for _, cc := range desc.GetControlCards() {
+		e.SerialNumber = cc.GetSerialNumber()
+		s.events.Publish(e)
+	}
+}
+
+func (s *Service) getBootstrapData(ctx context.Context, req *bpb.GetBootstrapDataRequest) (*bpb.GetBootstrapDataResponse, error) {
 	log.Infof("=============================================================================")
 	log.Infof("==================== Received request for bootstrap data ====================")
 	log.Infof("=============================================================================")
@@ -93,21 +285,104 @@ func (s *Service) GetBootstrapData(ctx context.Context, req *bpb.GetBootstrapDat
 		ccSerial = chassisDesc.GetControlCards()[0].GetSerialNumber()
 	}
 	log.Infof("Requesting for %v chassis %v", chassisDesc.GetManufacturer(), chassisDesc.GetSerialNumber())
+	if err := s.checkDescriptor(chassisDesc); err != nil {
+		return nil, err
+	}
+	if err := s.checkPeerIdentity(ctx, chassisDesc); err != nil {
+		return nil, err
+	}
+	release, err := s.admission.admit(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
 	lookup := &EntityLookup{
 		Manufacturer: chassisDesc.GetManufacturer(),
 		SerialNumber: chassisDesc.GetSerialNumber(),
+		PartNumber:   chassisDesc.GetPartNumber(),
 	}
 	// Validate the chassis can be serviced
-	chassis, err := s.em.ResolveChassis(lookup, ccSerial)
+	resolveCtx, cancel := s.stageContext(ctx, stageResolveChassis)
+	defer cancel()
+	chassis, err := s.em.ResolveChassis(resolveCtx, lookup, ccSerial)
+	if err := stageError(resolveCtx, stageResolveChassis); err != nil {
+		return nil, err
+	}
+	if status.Code(err) == codes.NotFound && s.quarantine != nil {
+		if err := s.quarantine.hold(chassisDesc, peerHost(ctx)); err != nil {
+			return nil, err
+		}
+	}
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "failed to resolve chassis to inventory %+v, err: %v", chassisDesc, err)
 	}
-	log.Infof("Verified server can resolve chassis")
+	releaseSite, err := s.admission.admitSite(ctx, chassis.Site)
+	if err != nil {
+		return nil, err
+	}
+	defer releaseSite()
+	err = s.em.ValidateControlCards(resolveCtx, lookup, chassisDesc.GetControlCards())
+	if err := stageError(resolveCtx, stageResolveChassis); err != nil {
+		return nil, err
+	}
+	if err != nil {
+		return nil, err
+	}
+	log.Infof("Verified server can resolve chassis and all control cards")
+	if len(chassisDesc.GetLineCards()) > 0 || len(chassisDesc.GetFabricModules()) > 0 {
+		// The modules are only tracked for reconciliation, so failing to record them is not fatal.
+		if err := s.em.RecordModules(resolveCtx, lookup, ccSerial, chassisDesc.GetLineCards(), chassisDesc.GetFabricModules()); err != nil {
+			log.Warningf("Unable to record the modules of %v chassis %v: %v", chassisDesc.GetManufacturer(), chassisDesc.GetSerialNumber(), err)
+		}
+	}
 
 	// If chassis can only be booted into secure mode then return error
 	if chassis.BootMode == bpb.BootMode_BOOT_MODE_SECURE && req.GetNonce() == "" {
 		return nil, status.Errorf(codes.InvalidArgument, "chassis requires secure boot only")
 	}
+	if req.GetNonce() == "" {
+		log.Warningf("=============================================================================")
+		log.Warningf("INSECURE BOOT: serving %v chassis %v (control card %v) without signature or ownership voucher", chassisDesc.GetManufacturer(), chassisDesc.GetSerialNumber(), ccSerial)
+		log.Warningf("=============================================================================")
+	}
+	scope := features.Scope{Tenant: chassis.Tenant, Site: chassis.Site}
+	if s.features.Enabled(features.RequireSignedResponse, scope) && req.GetNonce() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "server requires signed responses, a nonce must be provided")
+	}
+	if s.features.Enabled(features.StrictNonce, scope) && req.GetNonce() != "" {
+		if err := validateNonce(req.GetNonce()); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid nonce: %v", err)
+		}
+	}
+	requireToken := s.features.Enabled(features.RequireBootstrapToken, scope)
+	tokenVerified, err := s.em.CheckBootstrapToken(resolveCtx, requestingSerial(req), bootstrapToken(ctx, req), requireToken)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkPolicies(ctx, req, chassis); err != nil {
+		log.Warningf("Denied bootstrap request of %v chassis %v: %v", chassisDesc.GetManufacturer(), chassisDesc.GetSerialNumber(), err)
+		return nil, err
+	}
+	// The counter is only advanced once the request passed every other check, and only by
+	// requests authenticated with a bootstrap token or a verified client certificate, so requests
+	// which anyone could send can't move it ahead of the device.
+	if s.features.Enabled(features.ReplayProtection, scope) {
+		accept := s.em.CheckRequestCounter
+		if tokenVerified || peerAuthenticated(ctx, chassisDesc) {
+			accept = s.em.AcceptRequestCounter
+		}
+		if err := accept(resolveCtx, requestingSerial(req), req.GetRequestCounter()); err != nil {
+			return nil, err
+		}
+	}
+
+	if redirect := s.redirect(ctx, chassisDesc); redirect != nil {
+		log.Infof("Redirecting %v chassis %v to %v", chassisDesc.GetManufacturer(), chassisDesc.GetSerialNumber(), redirect.GetServers())
+		return s.signedResponse(ctx, req, lookup, &bpb.BootstrapDataSigned{
+			Redirect: redirect,
+			Nonce:    req.GetNonce(),
+		})
+	}
 
 	// Iterate over the control cards and fetch data for each card.
 	var errs errlist.List
@@ -115,9 +390,11 @@ func (s *Service) GetBootstrapData(ctx context.Context, req *bpb.GetBootstrapDat
 	log.Infof("=============================================================================")
 	log.Infof("==================== Fetching data for each control card ====================")
 	log.Infof("=============================================================================")
+	dataCtx, cancel := s.stageContext(ctx, stageBootstrapData)
+	defer cancel()
 	var responses []*bpb.BootstrapDataResponse
 	for _, v := range chassisDesc.GetControlCards() {
-		bootdata, err := s.em.GetBootstrapData(lookup, v)
+		bootdata, err := s.em.GetBootstrapData(dataCtx, lookup, v)
 		if err != nil {
 			errs.Add(err)
 			log.Infof("Error occurred while retrieving data for Serial Number %v", v.SerialNumber)
@@ -125,7 +402,7 @@ func (s *Service) GetBootstrapData(ctx context.Context, req *bpb.GetBootstrapDat
 		responses = append(responses, bootdata)
 	}
 	if fixedChasis {
-		bootdata, err := s.em.GetBootstrapData(lookup, nil)
+		bootdata, err := s.em.GetBootstrapData(dataCtx, lookup, nil)
 		if err != nil {
 			errs.Add(err)
 			log.Infof("Error occurred while retrieving data for fixed chassis with serail number %v", lookup.SerialNumber)
@@ -133,17 +410,74 @@ func (s *Service) GetBootstrapData(ctx context.Context, req *bpb.GetBootstrapDat
 		responses = append(responses, bootdata)
 	}
 
+	if err := stageError(dataCtx, stageBootstrapData); err != nil {
+		return nil, err
+	}
 	if errs.Err() != nil {
-		return nil, errs.Err()
+		return nil, retryOrErr(errs.Err())
 	}
 	log.Infof("Successfully fetched data for each control card")
 	log.Infof("=============================================================================")
 
+	for _, r := range responses {
+		if v := runningVersion(chassisDesc, r.GetSerialNum()); v != "" && v == r.GetIntendedImage().GetVersion() {
+			log.Infof("Control card %v already runs version %v, omitting the intended image", r.GetSerialNum(), v)
+			r.IntendedImage = nil
+		}
+	}
+	s.rewriteURLs(net.ParseIP(peerHost(ctx)), chassis.Site, responses)
+
+	caps := s.caps.get(chassisDesc)
+	if caps != nil {
+		log.Infof("Tailoring response to reported device capabilities")
+		for _, r := range responses {
+			if err := tailorResponse(caps, r); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if s.dryRun {
+		log.Infof("Dry run: serving placeholders instead of the configs and credentials of %v chassis %v", chassisDesc.GetManufacturer(), chassisDesc.GetSerialNumber())
+		for _, r := range responses {
+			dryRunResponse(r)
+		}
+	}
+
 	nonce := req.GetNonce()
-	signedResponse := &bpb.BootstrapDataSigned{
+	if nonce != "" {
+		// Each control card gets its own OV so that the standby can validate its response too.
+		for _, r := range responses {
+			ov, err := s.em.OwnershipVoucher(dataCtx, lookup, r.GetSerialNum())
+			if err := stageError(dataCtx, stageBootstrapData); err != nil {
+				return nil, err
+			}
+			if err != nil {
+				return nil, ownershipVoucherError(err)
+			}
+			r.OwnershipVoucher = ov
+		}
+	}
+	return s.signedResponse(ctx, req, lookup, &bpb.BootstrapDataSigned{
 		Responses: responses,
 		Nonce:     nonce,
+	})
+}
+
+// requestingSerial returns the serial of the control card sending the request, or of the fixed
+// chassis.
+func requestingSerial(req *bpb.GetBootstrapDataRequest) string {
+	if serial := req.GetControlCardState().GetSerialNumber(); serial != "" {
+		return serial
+	}
+	if ccs := req.GetChassisDescriptor().GetControlCards(); len(ccs) > 0 {
+		return ccs[0].GetSerialNumber()
 	}
+	return req.GetChassisDescriptor().GetSerialNumber()
+}
+
+// signedResponse serializes the bootstrap data, signing it if the request carries a nonce.
+func (s *Service) signedResponse(ctx context.Context, req *bpb.GetBootstrapDataRequest, lookup *EntityLookup, signedResponse *bpb.BootstrapDataSigned) (*bpb.GetBootstrapDataResponse, error) {
 	log.Infof("Serializing the response...")
 	signedResponseBytes, err := proto.Marshal(signedResponse)
 	if err != nil {
@@ -159,12 +493,20 @@ func (s *Service) GetBootstrapData(ctx context.Context, req *bpb.GetBootstrapDat
 	log.Infof("Response set")
 
 	// Sign the response if Nonce is provided.
-	if nonce != "" {
+	if signedResponse.GetNonce() != "" {
 		log.Infof("=============================================================================")
 		log.Infof("====================== Signing the response with nonce ======================")
 		log.Infof("=============================================================================")
-		if err := s.em.Sign(resp, lookup, req.GetControlCardState().GetSerialNumber()); err != nil {
-			return nil, status.Errorf(codes.Internal, "failed to sign bootz response")
+		signCtx, cancel := s.stageContext(ctx, stageSign)
+		defer cancel()
+		err := s.signing.run(signCtx, func() error {
+			return s.em.Sign(signCtx, resp, lookup, req.GetControlCardState().GetSerialNumber(), req.GetSignatureAlgorithms())
+		})
+		if err := stageError(signCtx, stageSign); err != nil {
+			return nil, err
+		}
+		if err != nil {
+			return nil, ownershipVoucherError(err)
 		}
 		log.Infof("Signed with nonce")
 	}
@@ -172,11 +514,147 @@ func (s *Service) GetBootstrapData(ctx context.Context, req *bpb.GetBootstrapDat
 	return resp, nil
 }
 
+// runningVersion returns the software version a control card, or a fixed chassis, reports running.
+func runningVersion(desc *bpb.ChassisDescriptor, serial string) string {
+	for _, cc := range desc.GetControlCards() {
+		if cc.GetSerialNumber() == serial {
+			return cc.GetSoftwareVersion()
+		}
+	}
+	if len(desc.GetControlCards()) == 0 && desc.GetSerialNumber() == serial {
+		return desc.GetSoftwareVersion()
+	}
+	return ""
+}
+
+// ownershipVoucherError surfaces ownership transfer to a different domain and revoked vouchers
+// with their own error codes, and hides the details of any other signing failure.
+func ownershipVoucherError(err error) error {
+	switch status.Code(err) {
+	case codes.FailedPrecondition, codes.PermissionDenied:
+		return err
+	}
+	return status.Errorf(codes.Internal, "failed to sign bootz response")
+}
+
+// validateNonce checks that the nonce is base64 encoded and carries enough entropy.
+func validateNonce(nonce string) error {
+	decoded, err := base64.StdEncoding.DecodeString(nonce)
+	if err != nil {
+		return err
+	}
+	if len(decoded) < minNonceLength {
+		return fmt.Errorf("nonce is %d bytes, want at least %d", len(decoded), minNonceLength)
+	}
+	return nil
+}
+
 func (s *Service) ReportStatus(ctx context.Context, req *bpb.ReportStatusRequest) (*bpb.EmptyResponse, error) {
 	log.Infof("=============================================================================")
 	log.Infof("========================== Status report received ===========================")
 	log.Infof("=============================================================================")
-	return &bpb.EmptyResponse{}, s.em.SetStatus(req)
+	defer func() {
+		for _, st := range req.GetStates() {
+			s.seen(ctx, st.GetSerialNumber())
+		}
+	}()
+	if req.GetStagingBundleId() != "" {
+		if err := s.staging.reconcile(req); err != nil {
+			return nil, err
+		}
+		log.Infof("Reconciled status report with staging bundle %v", req.GetStagingBundleId())
+	}
+	statusCtx, cancel := s.stageContext(ctx, stageReportStatus)
+	defer cancel()
+	err := s.em.SetStatus(statusCtx, req)
+	if err := stageError(statusCtx, stageReportStatus); err != nil {
+		return nil, err
+	}
+	if err != nil {
+		return nil, err
+	}
+	stage := reportedStage(req.GetStatus())
+	for _, st := range req.GetStates() {
+		s.events.Publish(events.Event{
+			SerialNumber: st.GetSerialNumber(),
+			Stage:        stage,
+			Message:      req.GetStatusMessage(),
+		})
+	}
+	addr := peerHost(ctx)
+	for _, h := range s.hooks {
+		h(req, addr)
+	}
+	return &bpb.EmptyResponse{}, nil
+}
+
+// reportedStage returns the stage of a device reporting the bootstrap status.
+func reportedStage(st bpb.ReportStatusRequest_BootstrapStatus) events.Stage {
+	switch st {
+	case bpb.ReportStatusRequest_BOOTSTRAP_STATUS_SUCCESS:
+		return events.Succeeded
+	case bpb.ReportStatusRequest_BOOTSTRAP_STATUS_FAILURE:
+		return events.Failed
+	}
+	return events.Initiated
+}
+
+// Events returns the broker publishing the bootstrap status transitions of devices.
+func (s *Service) Events() *events.Broker {
+	return s.events
+}
+
+// ReportCapabilities records the capabilities advertised by a device ahead of its bootstrap request.
+// The capabilities change what the device is served, so only reports authenticated with the
+// bootstrap token of the device or a verified client certificate naming it are recorded.
+func (s *Service) ReportCapabilities(ctx context.Context, req *bpb.ReportCapabilitiesRequest) (*bpb.EmptyResponse, error) {
+	chassisDesc := s.quirks.Adapt(req.GetChassisDescriptor())
+	log.Infof("Received capabilities for %v chassis %v: %v", chassisDesc.GetManufacturer(), chassisDesc.GetSerialNumber(), req.GetCapabilities())
+	if err := s.checkDescriptor(chassisDesc); err != nil {
+		return nil, err
+	}
+	ccSerial := ""
+	if len(chassisDesc.GetControlCards()) >= 1 {
+		ccSerial = chassisDesc.GetControlCards()[0].GetSerialNumber()
+	}
+	seenSerial := ccSerial
+	if seenSerial == "" {
+		seenSerial = chassisDesc.GetSerialNumber()
+	}
+	defer s.seen(ctx, seenSerial)
+	lookup := &EntityLookup{
+		Manufacturer: chassisDesc.GetManufacturer(),
+		SerialNumber: chassisDesc.GetSerialNumber(),
+		PartNumber:   chassisDesc.GetPartNumber(),
+	}
+	resolveCtx, cancel := s.stageContext(ctx, stageResolveChassis)
+	defer cancel()
+	_, err := s.em.ResolveChassis(resolveCtx, lookup, ccSerial)
+	if err := stageError(resolveCtx, stageResolveChassis); err != nil {
+		return nil, err
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to resolve chassis to inventory %+v, err: %v", chassisDesc, err)
+	}
+	tokenVerified, err := s.em.CheckBootstrapToken(resolveCtx, seenSerial, metadataToken(ctx), false)
+	if err != nil {
+		return nil, err
+	}
+	if !tokenVerified && !peerAuthenticated(ctx, chassisDesc) {
+		return nil, status.Errorf(codes.Unauthenticated, "capabilities of %v chassis %v must be reported with a bootstrap token or a verified client certificate", chassisDesc.GetManufacturer(), chassisDesc.GetSerialNumber())
+	}
+	s.caps.set(chassisDesc, req.GetCapabilities())
+	return &bpb.EmptyResponse{}, nil
+}
+
+// CapabilityStats returns a per-platform summary of the capabilities reported by devices.
+func (s *Service) CapabilityStats() []*PlatformCapabilities {
+	return s.caps.stats()
+}
+
+// Features returns the feature flags of the service.
+func (s *Service) Features() *features.Set {
+	return s.features
 }
 
 // SetDeviceConfiguration is a public API for allowing the device configuration to be set for each device the
@@ -188,6 +666,11 @@ func (s *Service) SetDeviceConfiguration(ctx context.Context) error {
 // New creates a new service.
 func New(em EntityManager) *Service {
 	return &Service{
-		em: em,
+		em:       em,
+		caps:     newCapabilityRegistry(),
+		features: features.NewSet(),
+		staging:  newStagingRegistry(),
+		events:   events.NewBroker(),
+		signing:  newSigningPool(0),
 	}
 }
@@ -0,0 +1,172 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"os"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	bpb "github.com/openconfig/bootz/proto/bootz"
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+)
+
+func TestCertChain(t *testing.T) {
+	oc, err := os.ReadFile("../../testdata/oc_pub.pem")
+	if err != nil {
+		t.Fatalf("unable to read OC: %v", err)
+	}
+	vendorCA, err := os.ReadFile("../../testdata/vendorca_pub.pem")
+	if err != nil {
+		t.Fatalf("unable to read vendor CA: %v", err)
+	}
+	key, err := os.ReadFile("../../testdata/oc_priv.pem")
+	if err != nil {
+		t.Fatalf("unable to read OC key: %v", err)
+	}
+	tests := []struct {
+		desc    string
+		cert    string
+		wantLen int
+		wantErr bool
+	}{{
+		desc:    "Single certificate",
+		cert:    string(oc),
+		wantLen: 1,
+	}, {
+		desc:    "Certificate with intermediate",
+		cert:    string(oc) + string(vendorCA),
+		wantLen: 2,
+	}, {
+		desc:    "No certificate",
+		cert:    "",
+		wantErr: true,
+	}, {
+		desc:    "Private key in chain",
+		cert:    string(oc) + string(key),
+		wantErr: true,
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			chain, err := (&KeyPair{Cert: test.cert}).CertChain()
+			if (err != nil) != test.wantErr {
+				t.Fatalf("CertChain() err = %v, want error %v", err, test.wantErr)
+			}
+			if len(chain) != test.wantLen {
+				t.Errorf("CertChain() returned %d certificates, want %d", len(chain), test.wantLen)
+			}
+		})
+	}
+}
+
+func TestCheckPeerIdentity(t *testing.T) {
+	desc := &bpb.ChassisDescriptor{
+		Manufacturer: "Cisco",
+		SerialNumber: "123",
+		ControlCards: []*bpb.ControlCard{{SerialNumber: "123A"}, {SerialNumber: "123B"}},
+	}
+	withCert := func(cert *x509.Certificate) context.Context {
+		return peer.NewContext(context.Background(), &peer.Peer{
+			AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}},
+		})
+	}
+	tests := []struct {
+		desc     string
+		mode     epb.PeerIdentityCheck
+		ctx      context.Context
+		wantCode codes.Code
+	}{{
+		desc:     "Check disabled",
+		mode:     epb.PeerIdentityCheck_PEER_IDENTITY_CHECK_NONE,
+		ctx:      withCert(&x509.Certificate{Subject: pkix.Name{SerialNumber: "456"}}),
+		wantCode: codes.OK,
+	}, {
+		desc:     "Matching subject serial number",
+		mode:     epb.PeerIdentityCheck_PEER_IDENTITY_CHECK_ENFORCE,
+		ctx:      withCert(&x509.Certificate{Subject: pkix.Name{SerialNumber: "123"}}),
+		wantCode: codes.OK,
+	}, {
+		desc:     "Matching control card common name",
+		mode:     epb.PeerIdentityCheck_PEER_IDENTITY_CHECK_ENFORCE,
+		ctx:      withCert(&x509.Certificate{Subject: pkix.Name{CommonName: "123B"}}),
+		wantCode: codes.OK,
+	}, {
+		desc:     "Matching DNS SAN",
+		mode:     epb.PeerIdentityCheck_PEER_IDENTITY_CHECK_ENFORCE,
+		ctx:      withCert(&x509.Certificate{Subject: pkix.Name{CommonName: "device"}, DNSNames: []string{"123A"}}),
+		wantCode: codes.OK,
+	}, {
+		desc:     "Mismatching certificate",
+		mode:     epb.PeerIdentityCheck_PEER_IDENTITY_CHECK_ENFORCE,
+		ctx:      withCert(&x509.Certificate{Subject: pkix.Name{SerialNumber: "456", CommonName: "456"}}),
+		wantCode: codes.PermissionDenied,
+	}, {
+		desc:     "No client certificate",
+		mode:     epb.PeerIdentityCheck_PEER_IDENTITY_CHECK_ENFORCE,
+		ctx:      context.Background(),
+		wantCode: codes.PermissionDenied,
+	}, {
+		desc:     "Mismatch logged only",
+		mode:     epb.PeerIdentityCheck_PEER_IDENTITY_CHECK_LOG_ONLY,
+		ctx:      withCert(&x509.Certificate{Subject: pkix.Name{SerialNumber: "456"}}),
+		wantCode: codes.OK,
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := New(nil)
+			s.SetPeerIdentityCheck(test.mode)
+			if got := status.Code(s.checkPeerIdentity(test.ctx, desc)); got != test.wantCode {
+				t.Errorf("checkPeerIdentity() code = %v, want %v", got, test.wantCode)
+			}
+		})
+	}
+}
+
+func TestAdaptRequest(t *testing.T) {
+	s := New(nil)
+	if err := s.SetVendorQuirks([]*epb.VendorQuirks{{}}); err == nil {
+		t.Errorf("SetVendorQuirks() of quirks without manufacturers err = nil, want an error")
+	}
+	if err := s.SetVendorQuirks([]*epb.VendorQuirks{{Manufacturers: []string{"CISCO SYSTEMS"}, CanonicalManufacturer: "Cisco"}}); err != nil {
+		t.Fatalf("SetVendorQuirks() err = %v, want nil", err)
+	}
+	req := &bpb.GetBootstrapDataRequest{
+		ChassisDescriptor:   &bpb.ChassisDescriptor{Manufacturer: "Cisco Systems", SerialNumber: "123"},
+		Nonce:               "nonce",
+		SignatureAlgorithms: []bpb.SignatureAlgorithm{bpb.SignatureAlgorithm_SIGNATURE_ALGORITHM_CMS_DETACHED},
+	}
+	got := s.adaptRequest(req)
+	if got.GetChassisDescriptor().GetManufacturer() != "Cisco" {
+		t.Errorf("adaptRequest() manufacturer = %q, want %q", got.GetChassisDescriptor().GetManufacturer(), "Cisco")
+	}
+	if got.GetNonce() != req.GetNonce() || len(got.GetSignatureAlgorithms()) != 1 {
+		t.Errorf("adaptRequest() = %v, want the nonce and signature algorithms of %v", got, req)
+	}
+	if req.GetChassisDescriptor().GetManufacturer() != "Cisco Systems" {
+		t.Errorf("adaptRequest() modified the request to %v", req)
+	}
+	conforming := &bpb.GetBootstrapDataRequest{ChassisDescriptor: &bpb.ChassisDescriptor{Manufacturer: "Arista", SerialNumber: "456"}}
+	if got := s.adaptRequest(conforming); got != conforming {
+		t.Errorf("adaptRequest() of a conforming request = %v, want the request itself", got)
+	}
+}
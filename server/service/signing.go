@@ -0,0 +1,70 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"runtime"
+
+	"google.golang.org/grpc/status"
+)
+
+// signingPool bounds the responses signed at the same time, so a burst of requests with large
+// payloads or large keys leaves CPU for the other RPCs of the server.
+type signingPool struct {
+	// workers holds a token per signature in progress.
+	workers chan struct{}
+}
+
+// newSigningPool returns a pool signing up to n responses at the same time, or as many as there
+// are CPUs if n is 0.
+func newSigningPool(n int) *signingPool {
+	if n <= 0 {
+		n = runtime.NumCPU()
+	}
+	return &signingPool{workers: make(chan struct{}, n)}
+}
+
+// SetSigningWorkers sets the number of responses signed at the same time, or the number of CPUs
+// if n is 0. It must be called before the service serves requests.
+func (s *Service) SetSigningWorkers(n uint32) {
+	s.signing = newSigningPool(int(n))
+}
+
+// run waits for a worker and calls sign on it, until ctx is done. Requests whose deadline passes
+// while they wait are not signed at all. As signing cannot be interrupted, a request whose
+// deadline passes while it is signed returns right away, but keeps its worker until sign returns.
+func (p *signingPool) run(ctx context.Context, sign func() error) error {
+	if p == nil {
+		return sign()
+	}
+	select {
+	case p.workers <- struct{}{}:
+	case <-ctx.Done():
+		return status.FromContextError(ctx.Err()).Err()
+	}
+	// The result channel is buffered so the worker never blocks on an abandoned request.
+	done := make(chan error, 1)
+	go func() {
+		defer func() { <-p.workers }()
+		done <- sign()
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return status.FromContextError(ctx.Err()).Err()
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestSigningPool(t *testing.T) {
+	p := newSigningPool(1)
+	ctx := context.Background()
+
+	want := errors.New("sign failed")
+	if err := p.run(ctx, func() error { return want }); err != want {
+		t.Errorf("run() err = %v, want %v", err, want)
+	}
+
+	// A signature outliving its deadline keeps the only worker busy.
+	unblock := make(chan struct{})
+	dctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if err := p.run(dctx, func() error { <-unblock; return nil }); status.Code(err) != codes.DeadlineExceeded {
+		t.Fatalf("run() of a slow signature err = %v, want code %v", err, codes.DeadlineExceeded)
+	}
+	qctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	signed := false
+	if err := p.run(qctx, func() error { signed = true; return nil }); status.Code(err) != codes.DeadlineExceeded {
+		t.Errorf("run() queued behind a busy worker err = %v, want code %v", err, codes.DeadlineExceeded)
+	}
+	if signed {
+		t.Errorf("run() signed a request whose deadline passed while queued")
+	}
+
+	// The worker is released once the abandoned signature completes.
+	close(unblock)
+	if err := p.run(ctx, func() error { return nil }); err != nil {
+		t.Errorf("run() once the worker is released err = %v, want nil", err)
+	}
+
+	var nilPool *signingPool
+	if err := nilPool.run(ctx, func() error { return nil }); err != nil {
+		t.Errorf("run() without a pool err = %v, want nil", err)
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	bpb "github.com/openconfig/bootz/proto/bootz"
+	log "github.com/openconfig/bootz/server/logging"
+)
+
+// StagingRecord is the server's record of a generated staging bundle.
+type StagingRecord struct {
+	BundleID          string
+	ChassisDescriptor *bpb.ChassisDescriptor
+	CreatedAt         time.Time
+	// Status is the last status the device reported for the bundle.
+	Status     bpb.ReportStatusRequest_BootstrapStatus
+	ReportedAt time.Time
+}
+
+// stagingRegistry keeps the records of all generated staging bundles.
+type stagingRegistry struct {
+	mu      sync.Mutex
+	bundles map[string]*StagingRecord
+}
+
+func newStagingRegistry() *stagingRegistry {
+	return &stagingRegistry{
+		bundles: map[string]*StagingRecord{},
+	}
+}
+
+// newBundleID returns a random bundle ID which is also a valid strict nonce.
+func newBundleID() (string, error) {
+	b := make([]byte, minNonceLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(b), nil
+}
+
+func (r *stagingRegistry) add(id string, desc *bpb.ChassisDescriptor) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.bundles[id] = &StagingRecord{
+		BundleID:          id,
+		ChassisDescriptor: proto.Clone(desc).(*bpb.ChassisDescriptor),
+		CreatedAt:         time.Now(),
+	}
+}
+
+// reconcile checks a status report against the bundle it references and records the reported status.
+func (r *stagingRegistry) reconcile(req *bpb.ReportStatusRequest) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rec, ok := r.bundles[req.GetStagingBundleId()]
+	if !ok {
+		return status.Errorf(codes.NotFound, "unknown staging bundle %q", req.GetStagingBundleId())
+	}
+	serials := map[string]bool{}
+	if len(rec.ChassisDescriptor.GetControlCards()) == 0 {
+		serials[rec.ChassisDescriptor.GetSerialNumber()] = true
+	}
+	for _, cc := range rec.ChassisDescriptor.GetControlCards() {
+		serials[cc.GetSerialNumber()] = true
+	}
+	for _, s := range req.GetStates() {
+		if !serials[s.GetSerialNumber()] {
+			return status.Errorf(codes.InvalidArgument, "staging bundle %q was not generated for control card %v", rec.BundleID, s.GetSerialNumber())
+		}
+	}
+	rec.Status = req.GetStatus()
+	rec.ReportedAt = time.Now()
+	return nil
+}
+
+func (r *stagingRegistry) list() []StagingRecord {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []StagingRecord
+	for _, rec := range r.bundles {
+		out = append(out, *rec)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].CreatedAt.Equal(out[j].CreatedAt) {
+			return out[i].CreatedAt.Before(out[j].CreatedAt)
+		}
+		return out[i].BundleID < out[j].BundleID
+	})
+	return out
+}
+
+// GenerateStagingBundle produces a signed bootstrap response for a chassis which has no
+// network path to the server. The response is signed with the bundle ID as nonce, and the
+// device is expected to report the bundle ID back once it can reach the server.
+func (s *Service) GenerateStagingBundle(ctx context.Context, desc *bpb.ChassisDescriptor) (*bpb.StagingBundle, error) {
+	desc = s.quirks.Adapt(desc)
+	id, err := newBundleID()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to generate staging bundle ID: %v", err)
+	}
+	activeSerial := desc.GetSerialNumber()
+	if len(desc.GetControlCards()) > 0 {
+		activeSerial = desc.GetControlCards()[0].GetSerialNumber()
+	}
+	resp, err := s.getBootstrapData(ctx, &bpb.GetBootstrapDataRequest{
+		ChassisDescriptor: desc,
+		ControlCardState: &bpb.ControlCardState{
+			SerialNumber: activeSerial,
+			Status:       bpb.ControlCardState_CONTROL_CARD_STATUS_NOT_INITIALIZED,
+		},
+		Nonce: id,
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.staging.add(id, desc)
+	log.Infof("Generated staging bundle %v for %v chassis %v", id, desc.GetManufacturer(), activeSerial)
+	return &bpb.StagingBundle{
+		BundleId:          id,
+		ChassisDescriptor: desc,
+		Response:          resp,
+	}, nil
+}
+
+// StagingBundles returns the records of all generated staging bundles, oldest first.
+func (s *Service) StagingBundles() []StagingRecord {
+	return s.staging.list()
+}
@@ -0,0 +1,93 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	bpb "github.com/openconfig/bootz/proto/bootz"
+)
+
+func TestReconcileStagingBundle(t *testing.T) {
+	r := newStagingRegistry()
+	r.add("modular", &bpb.ChassisDescriptor{
+		Manufacturer: "Cisco",
+		SerialNumber: "123",
+		ControlCards: []*bpb.ControlCard{
+			{SerialNumber: "123A"},
+			{SerialNumber: "123B"},
+		},
+	})
+	r.add("fixed", &bpb.ChassisDescriptor{
+		Manufacturer: "Cisco",
+		SerialNumber: "456",
+	})
+	tests := []struct {
+		desc     string
+		bundleID string
+		serials  []string
+		wantCode codes.Code
+	}{{
+		desc:     "Modular chassis",
+		bundleID: "modular",
+		serials:  []string{"123A", "123B"},
+	}, {
+		desc:     "Fixed chassis",
+		bundleID: "fixed",
+		serials:  []string{"456"},
+	}, {
+		desc:     "Unknown bundle",
+		bundleID: "unknown",
+		serials:  []string{"123A"},
+		wantCode: codes.NotFound,
+	}, {
+		desc:     "Control card not in bundle",
+		bundleID: "modular",
+		serials:  []string{"123A", "123C"},
+		wantCode: codes.InvalidArgument,
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			req := &bpb.ReportStatusRequest{
+				Status:          bpb.ReportStatusRequest_BOOTSTRAP_STATUS_SUCCESS,
+				StagingBundleId: test.bundleID,
+			}
+			for _, s := range test.serials {
+				req.States = append(req.States, &bpb.ControlCardState{SerialNumber: s})
+			}
+			if got := status.Code(r.reconcile(req)); got != test.wantCode {
+				t.Errorf("reconcile() err code = %v, want %v", got, test.wantCode)
+			}
+		})
+	}
+	for _, rec := range r.list() {
+		if rec.Status != bpb.ReportStatusRequest_BOOTSTRAP_STATUS_SUCCESS || rec.ReportedAt.IsZero() {
+			t.Errorf("bundle %q has status %v reported at %v, want a recorded success", rec.BundleID, rec.Status, rec.ReportedAt)
+		}
+	}
+}
+
+func TestNewBundleID(t *testing.T) {
+	id, err := newBundleID()
+	if err != nil {
+		t.Fatalf("newBundleID() err = %v, want nil", err)
+	}
+	if err := validateNonce(id); err != nil {
+		t.Errorf("newBundleID() = %q is not a valid strict nonce: %v", id, err)
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/openconfig/bootz/server/events"
+	log "github.com/openconfig/bootz/server/logging"
+
+	bpb "github.com/openconfig/bootz/proto/bootz"
+)
+
+const (
+	// defaultChunkSize is the size of the streamed chunks, well below the default 4 MiB message
+	// size limit of gRPC.
+	defaultChunkSize = 1 << 20
+	// chunkOverhead bounds the size a chunk message adds to its data.
+	chunkOverhead = 32
+)
+
+// chunkSize returns the size of the data of each chunk streamed to the device, fitting the maximum
+// message size the device advertised.
+func (s *Service) chunkSize(desc *bpb.ChassisDescriptor) (int, error) {
+	limit := s.caps.get(desc).GetMaxMessageSize()
+	if limit == 0 || limit >= defaultChunkSize+chunkOverhead {
+		return defaultChunkSize, nil
+	}
+	if limit <= chunkOverhead {
+		return 0, status.Errorf(codes.ResourceExhausted, "device maximum message size of %d bytes is too small to stream bootstrap data", limit)
+	}
+	return int(limit - chunkOverhead), nil
+}
+
+// GetBootstrapDataStream streams the signed bootstrap data of GetBootstrapData in chunks of its
+// serialized response, so that the response may exceed the maximum message size.
+func (s *Service) GetBootstrapDataStream(req *bpb.GetBootstrapDataRequest, stream bpb.Bootstrap_GetBootstrapDataStreamServer) error {
+	req = s.adaptRequest(req)
+	defer s.seen(stream.Context(), requestingSerial(req))
+	desc := req.GetChassisDescriptor()
+	s.publish(desc, events.Requested, "")
+	if err := s.streamBootstrapData(req, stream); err != nil {
+		s.publish(desc, events.Rejected, err.Error())
+		return err
+	}
+	s.publish(desc, events.Served, "")
+	return nil
+}
+
+func (s *Service) streamBootstrapData(req *bpb.GetBootstrapDataRequest, stream bpb.Bootstrap_GetBootstrapDataStreamServer) error {
+	size, err := s.chunkSize(req.GetChassisDescriptor())
+	if err != nil {
+		return err
+	}
+	resp, err := s.getBootstrapData(stream.Context(), req)
+	if err != nil {
+		return err
+	}
+	data, err := proto.Marshal(resp)
+	if err != nil {
+		return status.Errorf(codes.Internal, "unable to serialize bootstrap response: %v", err)
+	}
+	total := uint64(len(data))
+	for len(data) > 0 {
+		n := size
+		if n > len(data) {
+			n = len(data)
+		}
+		if err := stream.Send(&bpb.BootstrapDataChunk{Data: data[:n], TotalSize: total}); err != nil {
+			return err
+		}
+		data = data[n:]
+	}
+	log.Infof("Streamed bootstrap response of %d bytes in chunks of up to %d bytes", total, size)
+	return nil
+}
@@ -0,0 +1,68 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+)
+
+// stage is a stage of the bootstrap RPCs bounded by a timeout.
+type stage string
+
+const (
+	stageResolveChassis stage = "resolve chassis"
+	stageBootstrapData  stage = "bootstrap data"
+	stageSign           stage = "sign"
+	stageReportStatus   stage = "report status"
+)
+
+// SetTimeouts sets the timeouts of the stages of the bootstrap RPCs. It must be called before
+// the service serves requests.
+func (s *Service) SetTimeouts(t *epb.Timeouts) {
+	s.timeouts = map[stage]time.Duration{
+		stageResolveChassis: t.GetResolveChassis().AsDuration(),
+		stageBootstrapData:  t.GetBootstrapData().AsDuration(),
+		stageSign:           t.GetSign().AsDuration(),
+		stageReportStatus:   t.GetReportStatus().AsDuration(),
+	}
+}
+
+// stageContext returns the context of a stage of a request, bounded by the timeout of the stage
+// if one is set.
+func (s *Service) stageContext(ctx context.Context, st stage) (context.Context, context.CancelFunc) {
+	if d := s.timeouts[st]; d > 0 {
+		return context.WithTimeout(ctx, d)
+	}
+	return context.WithCancel(ctx)
+}
+
+// stageError returns an error naming the stage if its context is done, or nil. It takes
+// precedence over the error returned by the stage, which is typically the context error itself.
+func stageError(ctx context.Context, st stage) error {
+	switch ctx.Err() {
+	case nil:
+		return nil
+	case context.DeadlineExceeded:
+		return status.Errorf(codes.DeadlineExceeded, "%s stage exceeded its deadline", st)
+	default:
+		return status.Errorf(codes.Canceled, "%s stage was canceled", st)
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	bpb "github.com/openconfig/bootz/proto/bootz"
+	epb "github.com/openconfig/bootz/server/entitymanager/proto/entity"
+)
+
+// slowEntityManager blocks in the calls of slow until their context is done.
+type slowEntityManager struct {
+	EntityManager
+	slow string
+}
+
+func (m *slowEntityManager) wait(ctx context.Context, call string) error {
+	if call != m.slow {
+		return nil
+	}
+	<-ctx.Done()
+	return status.FromContextError(ctx.Err()).Err()
+}
+
+func (m *slowEntityManager) ResolveChassis(ctx context.Context, _ *EntityLookup, _ string) (*ChassisEntity, error) {
+	return &ChassisEntity{BootMode: bpb.BootMode_BOOT_MODE_INSECURE}, m.wait(ctx, "ResolveChassis")
+}
+
+func (m *slowEntityManager) ValidateControlCards(ctx context.Context, _ *EntityLookup, _ []*bpb.ControlCard) error {
+	return m.wait(ctx, "ValidateControlCards")
+}
+
+func (m *slowEntityManager) GetBootstrapData(ctx context.Context, _ *EntityLookup, cc *bpb.ControlCard) (*bpb.BootstrapDataResponse, error) {
+	return &bpb.BootstrapDataResponse{SerialNum: cc.GetSerialNumber()}, m.wait(ctx, "GetBootstrapData")
+}
+
+func (m *slowEntityManager) SetStatus(ctx context.Context, _ *bpb.ReportStatusRequest) error {
+	return m.wait(ctx, "SetStatus")
+}
+
+func (m *slowEntityManager) CheckBootstrapToken(context.Context, string, string, bool) (bool, error) {
+	return false, nil
+}
+
+func (m *slowEntityManager) RecordSeen(context.Context, string, string) {}
+
+func TestStageTimeouts(t *testing.T) {
+	timeouts := &epb.Timeouts{
+		ResolveChassis: durationpb.New(10 * time.Millisecond),
+		BootstrapData:  durationpb.New(10 * time.Millisecond),
+		ReportStatus:   durationpb.New(10 * time.Millisecond),
+	}
+	getBootstrapData := func(s *Service) error {
+		_, err := s.GetBootstrapData(context.Background(), &bpb.GetBootstrapDataRequest{
+			ChassisDescriptor: &bpb.ChassisDescriptor{
+				Manufacturer: "Cisco",
+				SerialNumber: "123",
+				ControlCards: []*bpb.ControlCard{{SerialNumber: "123A"}},
+			},
+			ControlCardState: &bpb.ControlCardState{SerialNumber: "123A"},
+		})
+		return err
+	}
+	reportStatus := func(s *Service) error {
+		_, err := s.ReportStatus(context.Background(), &bpb.ReportStatusRequest{
+			States: []*bpb.ControlCardState{{SerialNumber: "123A"}},
+		})
+		return err
+	}
+	tests := []struct {
+		desc     string
+		slow     string
+		call     func(*Service) error
+		wantCode codes.Code
+	}{{
+		desc: "No slow stage",
+		call: getBootstrapData,
+	}, {
+		desc:     "Slow chassis resolution",
+		slow:     "ResolveChassis",
+		call:     getBootstrapData,
+		wantCode: codes.DeadlineExceeded,
+	}, {
+		desc:     "Slow control card validation",
+		slow:     "ValidateControlCards",
+		call:     getBootstrapData,
+		wantCode: codes.DeadlineExceeded,
+	}, {
+		desc:     "Slow bootstrap data",
+		slow:     "GetBootstrapData",
+		call:     getBootstrapData,
+		wantCode: codes.DeadlineExceeded,
+	}, {
+		desc:     "Slow status report",
+		slow:     "SetStatus",
+		call:     reportStatus,
+		wantCode: codes.DeadlineExceeded,
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			s := New(&slowEntityManager{slow: test.slow})
+			s.SetTimeouts(timeouts)
+			if err := test.call(s); status.Code(err) != test.wantCode {
+				t.Errorf("call err = %v, want code %v", err, test.wantCode)
+			}
+		})
+	}
+}
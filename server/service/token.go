@@ -0,0 +1,44 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+
+	"google.golang.org/grpc/metadata"
+
+	bpb "github.com/openconfig/bootz/proto/bootz"
+)
+
+// TokenMetadata is the metadata key bootstrap tokens may be presented in, e.g. by a DHCP relay
+// presenting the token a field technician entered, instead of in the request.
+const TokenMetadata = "x-bootz-bootstrap-token"
+
+// bootstrapToken returns the bootstrap token presented with the request, if any.
+func bootstrapToken(ctx context.Context, req *bpb.GetBootstrapDataRequest) string {
+	if t := req.GetBootstrapToken(); t != "" {
+		return t
+	}
+	return metadataToken(ctx)
+}
+
+// metadataToken returns the bootstrap token sent in the TokenMetadata key of the request metadata.
+func metadataToken(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if v := md.Get(TokenMetadata); len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
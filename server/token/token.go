@@ -0,0 +1,161 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package token tracks the one-time bootstrap tokens issued by operators.
+//
+// A token is bound to the serial number of a control card or fixed chassis,
+// which must present it to be served bootstrap data, guarding against devices
+// spoofing the serial number of another in insecure boot mode. Only the hash
+// of tokens is kept.
+package token
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultTTL is how long tokens are valid by default.
+const DefaultTTL = 7 * 24 * time.Hour
+
+// tokenBytes is the number of random bytes of a token.
+const tokenBytes = 20
+
+// encoding encodes tokens, so they can be typed by field technicians.
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// Entry describes an issued token.
+type Entry struct {
+	SerialNumber string
+	// Hash is the SHA-256 hash of the token.
+	Hash      []byte
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+}
+
+// List holds the tokens not used yet. It is safe for concurrent use.
+type List struct {
+	mu     sync.RWMutex
+	tokens map[string]*Entry
+	now    func() time.Time
+}
+
+// New returns an empty token list.
+func New() *List {
+	return &List{
+		tokens: map[string]*Entry{},
+		now:    time.Now,
+	}
+}
+
+// Issue returns a new token for serial valid for ttl, or DefaultTTL if 0, replacing any token
+// issued before.
+func (l *List) Issue(serial string, ttl time.Duration) (string, Entry, error) {
+	if serial == "" {
+		return "", Entry{}, fmt.Errorf("no serial number provided")
+	}
+	if ttl < 0 {
+		return "", Entry{}, fmt.Errorf("negative TTL %v", ttl)
+	}
+	if ttl == 0 {
+		ttl = DefaultTTL
+	}
+	b := make([]byte, tokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", Entry{}, fmt.Errorf("unable to generate token: %v", err)
+	}
+	token := encoding.EncodeToString(b)
+	hash := sha256.Sum256([]byte(token))
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	e := &Entry{
+		SerialNumber: serial,
+		Hash:         hash[:],
+		IssuedAt:     now,
+		ExpiresAt:    now.Add(ttl),
+	}
+	l.tokens[serial] = e
+	return token, *e, nil
+}
+
+// Issued reports whether a token was issued for serial and not used or revoked yet, even if
+// it expired.
+func (l *List) Issued(serial string) bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	_, ok := l.tokens[serial]
+	return ok
+}
+
+// Verify returns the entry of the token of serial if token is valid.
+func (l *List) Verify(serial, token string) (Entry, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	e, ok := l.tokens[serial]
+	if !ok {
+		return Entry{}, fmt.Errorf("no token issued for serial# %s", serial)
+	}
+	if token == "" {
+		return Entry{}, fmt.Errorf("no token presented")
+	}
+	hash := sha256.Sum256([]byte(token))
+	if subtle.ConstantTimeCompare(hash[:], e.Hash) != 1 {
+		return Entry{}, fmt.Errorf("token does not match the token issued for serial# %s", serial)
+	}
+	if !l.now().Before(e.ExpiresAt) {
+		return Entry{}, fmt.Errorf("token of serial# %s expired at %v", serial, e.ExpiresAt.Format(time.RFC3339))
+	}
+	return *e, nil
+}
+
+// Invalidate drops the token of serial, once it is used or revoked. It reports whether a token
+// was issued for serial.
+func (l *List) Invalidate(serial string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, ok := l.tokens[serial]
+	delete(l.tokens, serial)
+	return ok
+}
+
+// Entries returns all tokens sorted by serial number.
+func (l *List) Entries() []Entry {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	var out []Entry
+	for _, e := range l.tokens {
+		out = append(out, *e)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].SerialNumber < out[j].SerialNumber
+	})
+	return out
+}
+
+// Restore replaces the tokens with entries, e.g. restored from a backup.
+func (l *List) Restore(entries []Entry) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.tokens = map[string]*Entry{}
+	for _, e := range entries {
+		e := e
+		l.tokens[e.SerialNumber] = &e
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package token
+
+import (
+	"testing"
+	"time"
+
+	"github.com/h-fam/errdiff"
+)
+
+func TestVerify(t *testing.T) {
+	now := time.Now()
+	l := New()
+	l.now = func() time.Time { return now }
+	if _, _, err := l.Issue("", 0); err == nil {
+		t.Errorf("Issue() with empty serial err = nil, want error")
+	}
+	token, e, err := l.Issue("123A", time.Hour)
+	if err != nil {
+		t.Fatalf("Issue() err = %v, want nil", err)
+	}
+	if !e.ExpiresAt.Equal(now.Add(time.Hour)) {
+		t.Errorf("Issue() expires at %v, want %v", e.ExpiresAt, now.Add(time.Hour))
+	}
+	tests := []struct {
+		desc    string
+		serial  string
+		token   string
+		at      time.Time
+		wantErr string
+	}{{
+		desc:   "Valid token",
+		serial: "123A",
+		token:  token,
+		at:     now,
+	}, {
+		desc:    "Token of another serial",
+		serial:  "123B",
+		token:   token,
+		at:      now,
+		wantErr: "no token issued",
+	}, {
+		desc:    "No token presented",
+		serial:  "123A",
+		at:      now,
+		wantErr: "no token presented",
+	}, {
+		desc:    "Wrong token",
+		serial:  "123A",
+		token:   token + "X",
+		at:      now,
+		wantErr: "does not match",
+	}, {
+		desc:    "Expired token",
+		serial:  "123A",
+		token:   token,
+		at:      now.Add(time.Hour),
+		wantErr: "expired",
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			l.now = func() time.Time { return test.at }
+			_, err := l.Verify(test.serial, test.token)
+			if diff := errdiff.Substring(err, test.wantErr); diff != "" {
+				t.Errorf("Verify() %s", diff)
+			}
+		})
+	}
+}
+
+func TestInvalidate(t *testing.T) {
+	l := New()
+	first, _, err := l.Issue("123A", 0)
+	if err != nil {
+		t.Fatalf("Issue() err = %v, want nil", err)
+	}
+	second, _, err := l.Issue("123A", 0)
+	if err != nil {
+		t.Fatalf("Issue() err = %v, want nil", err)
+	}
+	if _, err := l.Verify("123A", first); err == nil {
+		t.Errorf("Verify() of replaced token err = nil, want error")
+	}
+	if _, err := l.Verify("123A", second); err != nil {
+		t.Errorf("Verify() err = %v, want nil", err)
+	}
+	if !l.Invalidate("123A") {
+		t.Errorf("Invalidate() = false, want true")
+	}
+	if l.Issued("123A") {
+		t.Errorf("Issued() of used token = true, want false")
+	}
+	if _, err := l.Verify("123A", second); err == nil {
+		t.Errorf("Verify() of used token err = nil, want error")
+	}
+	if l.Invalidate("123A") {
+		t.Errorf("Invalidate() of used token = true, want false")
+	}
+}
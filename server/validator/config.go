@@ -0,0 +1,255 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	bpb "github.com/openconfig/bootz/proto/bootz"
+)
+
+// commandTimeout bounds the run time of a command validator.
+const commandTimeout = 30 * time.Second
+
+// Artifact returns the serialized artifact of type t carried by resp, or nil if resp does
+// not carry it or the artifact type has no single serialized form.
+func Artifact(resp *bpb.BootstrapDataResponse, t bpb.ArtifactType) []byte {
+	switch t {
+	case bpb.ArtifactType_ARTIFACT_TYPE_VENDOR_CONFIG:
+		return resp.GetBootConfig().GetVendorConfig()
+	case bpb.ArtifactType_ARTIFACT_TYPE_OC_CONFIG:
+		return resp.GetBootConfig().GetOcConfig()
+	case bpb.ArtifactType_ARTIFACT_TYPE_GNMI_SET_REQUEST:
+		return resp.GetBootConfig().GetGnmiSetRequest()
+	case bpb.ArtifactType_ARTIFACT_TYPE_AUTHZ:
+		if p := resp.GetAuthz().GetPolicy(); p != "" {
+			return []byte(p)
+		}
+	}
+	return nil
+}
+
+// checkArtifactType returns an error if artifacts of type t can not be passed to a config validator.
+func checkArtifactType(t bpb.ArtifactType) error {
+	switch t {
+	case bpb.ArtifactType_ARTIFACT_TYPE_VENDOR_CONFIG, bpb.ArtifactType_ARTIFACT_TYPE_OC_CONFIG,
+		bpb.ArtifactType_ARTIFACT_TYPE_GNMI_SET_REQUEST, bpb.ArtifactType_ARTIFACT_TYPE_AUTHZ:
+		return nil
+	}
+	return fmt.Errorf("artifact type %v can not be validated by a config validator", t)
+}
+
+// commandValidator runs an external command with the artifact on its standard input.
+type commandValidator struct {
+	artifactType bpb.ArtifactType
+	argv         []string
+}
+
+// Command returns a validator running argv with the artifact of type t on its standard
+// input, e.g. a vendor's config linter. The artifact is rejected if the command exits
+// with a non-zero status, and the command's output is returned as the error.
+func Command(t bpb.ArtifactType, argv ...string) (Validator, error) {
+	if err := checkArtifactType(t); err != nil {
+		return nil, err
+	}
+	if len(argv) == 0 || argv[0] == "" {
+		return nil, fmt.Errorf("command validator of %v has no command", t)
+	}
+	return &commandValidator{artifactType: t, argv: argv}, nil
+}
+
+// Validate runs the command against the artifact of resp.
+func (v *commandValidator) Validate(resp *bpb.BootstrapDataResponse) error {
+	artifact := Artifact(resp, v.artifactType)
+	if len(artifact) == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), commandTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, v.argv[0], v.argv[1:]...)
+	cmd.Stdin = bytes.NewReader(artifact)
+	out, err := cmd.CombinedOutput()
+	if err == nil {
+		return nil
+	}
+	if msg := strings.TrimSpace(string(out)); msg != "" {
+		return fmt.Errorf("%s rejected the artifact: %s", v.argv[0], msg)
+	}
+	return fmt.Errorf("%s rejected the artifact: %v", v.argv[0], err)
+}
+
+// schema is a JSON schema. Only the type, enum, required, properties,
+// additionalProperties and items keywords are supported.
+type schema struct {
+	Type                 any                `json:"type"`
+	Enum                 []any              `json:"enum"`
+	Required             []string           `json:"required"`
+	Properties           map[string]*schema `json:"properties"`
+	AdditionalProperties *bool              `json:"additionalProperties"`
+	Items                *schema            `json:"items"`
+}
+
+// schemaValidator checks that the artifact is a JSON document matching a schema.
+type schemaValidator struct {
+	artifactType bpb.ArtifactType
+	schema       *schema
+}
+
+// JSONSchema returns a validator checking that the artifact of type t is a JSON document
+// matching the JSON schema. Only the type, enum, required, properties, additionalProperties
+// and items keywords are checked; other keywords are ignored.
+func JSONSchema(t bpb.ArtifactType, data []byte) (Validator, error) {
+	if err := checkArtifactType(t); err != nil {
+		return nil, err
+	}
+	s := &schema{}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("unable to parse JSON schema: %v", err)
+	}
+	return &schemaValidator{artifactType: t, schema: s}, nil
+}
+
+// Validate checks the artifact of resp against the schema.
+func (v *schemaValidator) Validate(resp *bpb.BootstrapDataResponse) error {
+	artifact := Artifact(resp, v.artifactType)
+	if len(artifact) == 0 {
+		return nil
+	}
+	var doc any
+	if err := json.Unmarshal(artifact, &doc); err != nil {
+		return fmt.Errorf("artifact is not valid JSON: %v", err)
+	}
+	return v.schema.check("", doc)
+}
+
+// jsonType returns the JSON schema type of a decoded JSON value.
+func jsonType(v any) string {
+	switch x := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		if x == float64(int64(x)) {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+// types returns the types allowed by the schema, which may be given as a string or a list.
+func (s *schema) types() []string {
+	switch t := s.Type.(type) {
+	case string:
+		return []string{t}
+	case []any:
+		var out []string
+		for _, e := range t {
+			if str, ok := e.(string); ok {
+				out = append(out, str)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+// check reports the first violation of the schema by the value at path.
+func (s *schema) check(path string, v any) error {
+	if s == nil {
+		return nil
+	}
+	where := path
+	if where == "" {
+		where = "/"
+	}
+	if types := s.types(); len(types) > 0 {
+		got := jsonType(v)
+		ok := false
+		for _, t := range types {
+			if t == got || (t == "number" && got == "integer") {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return fmt.Errorf("%s is of type %s, want %s", where, got, strings.Join(types, " or "))
+		}
+	}
+	if len(s.Enum) > 0 {
+		found := false
+		for _, e := range s.Enum {
+			if jsonEqual(e, v) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("%s is not one of the allowed values", where)
+		}
+	}
+	switch x := v.(type) {
+	case map[string]any:
+		for _, r := range s.Required {
+			if _, ok := x[r]; !ok {
+				return fmt.Errorf("%s is missing required property %q", where, r)
+			}
+		}
+		keys := make([]string, 0, len(x))
+		for k := range x {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			ps, ok := s.Properties[k]
+			if !ok {
+				if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+					return fmt.Errorf("%s has unexpected property %q", where, k)
+				}
+				continue
+			}
+			if err := ps.check(path+"/"+k, x[k]); err != nil {
+				return err
+			}
+		}
+	case []any:
+		for i, e := range x {
+			if err := s.Items.check(fmt.Sprintf("%s/%d", path, i), e); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// jsonEqual reports whether two decoded JSON values are equal.
+func jsonEqual(a, b any) bool {
+	ja, errA := json.Marshal(a)
+	jb, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(ja, jb)
+}
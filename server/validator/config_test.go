@@ -0,0 +1,134 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"testing"
+
+	"github.com/openconfig/gnmi/errdiff"
+
+	bpb "github.com/openconfig/bootz/proto/bootz"
+)
+
+func TestCommand(t *testing.T) {
+	v, err := Command(bpb.ArtifactType_ARTIFACT_TYPE_VENDOR_CONFIG, "sh", "-c", `grep -q '^hostname ' || { echo "no hostname"; exit 1; }`)
+	if err != nil {
+		t.Fatalf("Command() err = %v, want nil", err)
+	}
+	tests := []struct {
+		desc    string
+		config  string
+		wantErr string
+	}{{
+		desc:   "Accepted config",
+		config: "hostname device\n",
+	}, {
+		desc:    "Rejected config",
+		config:  "interface eth0\n",
+		wantErr: "sh rejected the artifact: no hostname",
+	}, {
+		desc: "No config",
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			resp := &bpb.BootstrapDataResponse{BootConfig: &bpb.BootConfig{VendorConfig: []byte(test.config)}}
+			if s := errdiff.Substring(v.Validate(resp), test.wantErr); s != "" {
+				t.Errorf("Validate() %s", s)
+			}
+		})
+	}
+
+	if _, err := Command(bpb.ArtifactType_ARTIFACT_TYPE_VENDOR_CONFIG); err == nil {
+		t.Errorf("Command() without command err = nil, want error")
+	}
+	if _, err := Command(bpb.ArtifactType_ARTIFACT_TYPE_CERTZ, "true"); err == nil {
+		t.Errorf("Command() of certz artifacts err = nil, want error")
+	}
+}
+
+func TestJSONSchema(t *testing.T) {
+	v, err := JSONSchema(bpb.ArtifactType_ARTIFACT_TYPE_OC_CONFIG, []byte(`{
+		"type": "object",
+		"required": ["system"],
+		"additionalProperties": false,
+		"properties": {
+			"system": {
+				"type": "object",
+				"properties": {
+					"hostname": {"type": "string"},
+					"mode": {"enum": ["lab", "prod"]}
+				}
+			},
+			"interfaces": {
+				"type": "array",
+				"items": {"type": "object", "required": ["name"]}
+			},
+			"mtu": {"type": ["integer", "null"]}
+		}
+	}`))
+	if err != nil {
+		t.Fatalf("JSONSchema() err = %v, want nil", err)
+	}
+	tests := []struct {
+		desc    string
+		config  string
+		wantErr string
+	}{{
+		desc:   "Matching config",
+		config: `{"system": {"hostname": "device", "mode": "lab"}, "interfaces": [{"name": "eth0"}], "mtu": 9000}`,
+	}, {
+		desc: "No config",
+	}, {
+		desc:    "Invalid JSON",
+		config:  `{"system":`,
+		wantErr: "not valid JSON",
+	}, {
+		desc:    "Missing required property",
+		config:  `{"interfaces": []}`,
+		wantErr: `/ is missing required property "system"`,
+	}, {
+		desc:    "Unexpected property",
+		config:  `{"system": {}, "vlans": []}`,
+		wantErr: `/ has unexpected property "vlans"`,
+	}, {
+		desc:    "Wrong type",
+		config:  `{"system": {"hostname": 1}}`,
+		wantErr: "/system/hostname is of type integer, want string",
+	}, {
+		desc:    "Value not in enum",
+		config:  `{"system": {"mode": "test"}}`,
+		wantErr: "/system/mode is not one of the allowed values",
+	}, {
+		desc:    "Invalid array item",
+		config:  `{"system": {}, "interfaces": [{"name": "eth0"}, {}]}`,
+		wantErr: `/interfaces/1 is missing required property "name"`,
+	}, {
+		desc:    "Number instead of integer",
+		config:  `{"system": {}, "mtu": 1.5}`,
+		wantErr: "/mtu is of type number, want integer or null",
+	}}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			resp := &bpb.BootstrapDataResponse{BootConfig: &bpb.BootConfig{OcConfig: []byte(test.config)}}
+			if s := errdiff.Substring(v.Validate(resp), test.wantErr); s != "" {
+				t.Errorf("Validate() %s", s)
+			}
+		})
+	}
+
+	if _, err := JSONSchema(bpb.ArtifactType_ARTIFACT_TYPE_OC_CONFIG, []byte(`{"type":`)); err == nil {
+		t.Errorf("JSONSchema() of an invalid schema err = nil, want error")
+	}
+}
@@ -0,0 +1,235 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package validator checks the artifacts of a bootstrap response before they
+// are served, so invalid artifacts are caught by the server rather than on the device.
+//
+// Validators are registered per artifact type. A registry created with
+// NewRegistry holds the built-in validators, and custom validators can be
+// added next to them with Register.
+package validator
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/openconfig/gnmi/errlist"
+	"google.golang.org/protobuf/proto"
+
+	bpb "github.com/openconfig/bootz/proto/bootz"
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+	cpb "github.com/openconfig/gnsi/certz"
+)
+
+// Validator checks one artifact of a bootstrap response. A validator must
+// accept responses which do not carry its artifact.
+type Validator interface {
+	Validate(resp *bpb.BootstrapDataResponse) error
+}
+
+// Func adapts a function to a Validator.
+type Func func(resp *bpb.BootstrapDataResponse) error
+
+// Validate calls f(resp).
+func (f Func) Validate(resp *bpb.BootstrapDataResponse) error {
+	return f(resp)
+}
+
+// Registry holds the validators of each artifact type. It is safe for concurrent use.
+type Registry struct {
+	mu         sync.RWMutex
+	validators map[bpb.ArtifactType][]Validator
+}
+
+// NewRegistry returns a registry holding the built-in validators.
+func NewRegistry() *Registry {
+	r := &Registry{
+		validators: map[bpb.ArtifactType][]Validator{},
+	}
+	r.Register(bpb.ArtifactType_ARTIFACT_TYPE_OC_CONFIG, Func(validateOCConfig))
+	r.Register(bpb.ArtifactType_ARTIFACT_TYPE_GNMI_SET_REQUEST, Func(validateGNMISetRequest))
+	r.Register(bpb.ArtifactType_ARTIFACT_TYPE_AUTHZ, Func(validateAuthz))
+	r.Register(bpb.ArtifactType_ARTIFACT_TYPE_CERTZ, Func(validateCertz))
+	r.Register(bpb.ArtifactType_ARTIFACT_TYPE_CREDENTIALS, Func(validateCredentials))
+	return r
+}
+
+// Register adds v to the validators of artifact type t. Validators run in registration order.
+func (r *Registry) Register(t bpb.ArtifactType, v Validator) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.validators[t] = append(r.validators[t], v)
+}
+
+// Validate runs all registered validators against resp and returns their combined errors.
+func (r *Registry) Validate(resp *bpb.BootstrapDataResponse) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var types []bpb.ArtifactType
+	for t := range r.validators {
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool {
+		return types[i] < types[j]
+	})
+	var errs errlist.List
+	for _, t := range types {
+		for _, v := range r.validators[t] {
+			if err := v.Validate(resp); err != nil {
+				errs.Add(fmt.Errorf("%v: %v", t, err))
+			}
+		}
+	}
+	return errs.Err()
+}
+
+func validateOCConfig(resp *bpb.BootstrapDataResponse) error {
+	oc := resp.GetBootConfig().GetOcConfig()
+	if len(oc) == 0 {
+		return nil
+	}
+	if !json.Valid(oc) {
+		return fmt.Errorf("OC config is not valid JSON")
+	}
+	return nil
+}
+
+func validateGNMISetRequest(resp *bpb.BootstrapDataResponse) error {
+	data := resp.GetBootConfig().GetGnmiSetRequest()
+	if len(data) == 0 {
+		return nil
+	}
+	req := &gpb.SetRequest{}
+	if err := proto.Unmarshal(data, req); err != nil {
+		return fmt.Errorf("gNMI SetRequest can not be parsed: %v", err)
+	}
+	if len(req.GetDelete())+len(req.GetReplace())+len(req.GetUpdate()) == 0 {
+		return fmt.Errorf("gNMI SetRequest has no operation")
+	}
+	return nil
+}
+
+func validateAuthz(resp *bpb.BootstrapDataResponse) error {
+	authz := resp.GetAuthz()
+	if authz == nil {
+		return nil
+	}
+	if authz.GetVersion() == "" {
+		return fmt.Errorf("authz policy has no version")
+	}
+	if authz.GetPolicy() == "" {
+		return fmt.Errorf("authz policy %s is empty", authz.GetVersion())
+	}
+	if !json.Valid([]byte(authz.GetPolicy())) {
+		return fmt.Errorf("authz policy %s is not valid JSON", authz.GetVersion())
+	}
+	return nil
+}
+
+func validateCertz(resp *bpb.BootstrapDataResponse) error {
+	for i, e := range resp.GetCertificates().GetEntities() {
+		if e.GetVersion() == "" {
+			return fmt.Errorf("certz entity %d has no version", i)
+		}
+		switch {
+		case e.GetCertificateChain() != nil:
+			if err := validateChain(e.GetCertificateChain()); err != nil {
+				return fmt.Errorf("certz entity %s: %v", e.GetVersion(), err)
+			}
+		case e.GetTrustBundle() != nil:
+			if err := validateChain(e.GetTrustBundle()); err != nil {
+				return fmt.Errorf("certz entity %s: %v", e.GetVersion(), err)
+			}
+		case e.GetCertificateRevocationListBundle() != nil, e.GetAuthenticationPolicy() != nil:
+			// CRLs and authentication policies are passed through as is.
+		default:
+			return fmt.Errorf("certz entity %s carries no artifact", e.GetVersion())
+		}
+	}
+	return nil
+}
+
+// validateChain checks that every certificate of the chain can be parsed.
+func validateChain(chain *cpb.CertificateChain) error {
+	for c := chain; c != nil; c = c.GetParent() {
+		cert := c.GetCertificate()
+		if len(cert.GetCertificate()) == 0 {
+			return fmt.Errorf("certificate chain holds an empty certificate")
+		}
+		der := cert.GetCertificate()
+		switch cert.GetEncoding() {
+		case cpb.CertificateEncoding_CERTIFICATE_ENCODING_PEM, cpb.CertificateEncoding_CERTIFICATE_ENCODING_CRT:
+			block, _ := pem.Decode(der)
+			if block == nil {
+				return fmt.Errorf("certificate is not PEM encoded")
+			}
+			der = block.Bytes
+		case cpb.CertificateEncoding_CERTIFICATE_ENCODING_DER:
+		default:
+			return fmt.Errorf("unsupported certificate encoding %v", cert.GetEncoding())
+		}
+		if _, err := x509.ParseCertificate(der); err != nil {
+			return fmt.Errorf("unable to parse certificate: %v", err)
+		}
+	}
+	return nil
+}
+
+func validateCredentials(resp *bpb.BootstrapDataResponse) error {
+	creds := resp.GetCredentials()
+	for _, req := range creds.GetCredentials() {
+		for _, c := range req.GetCredentials() {
+			if c.GetAccount() == "" {
+				return fmt.Errorf("authorized keys have no account")
+			}
+			for _, k := range c.GetAuthorizedKeys() {
+				if len(k.GetAuthorizedKey()) == 0 {
+					return fmt.Errorf("account %s has an empty authorized key", c.GetAccount())
+				}
+			}
+		}
+	}
+	for _, req := range creds.GetUsers() {
+		for _, p := range req.GetPolicies() {
+			if p.GetAccount() == "" {
+				return fmt.Errorf("authorized users policy has no account")
+			}
+		}
+	}
+	for _, req := range creds.GetPasswords() {
+		for _, a := range req.GetAccounts() {
+			if a.GetAccount() == "" {
+				return fmt.Errorf("password has no account")
+			}
+			if a.GetPassword() == nil {
+				return fmt.Errorf("account %s has no password", a.GetAccount())
+			}
+		}
+	}
+	for _, req := range creds.GetServerKeys() {
+		if len(req.GetAuthArtifacts()) == 0 {
+			return fmt.Errorf("server keys %s carry no key", req.GetVersion())
+		}
+		for _, a := range req.GetAuthArtifacts() {
+			if len(a.GetPrivateKey()) == 0 {
+				return fmt.Errorf("server keys %s have an empty private key", req.GetVersion())
+			}
+		}
+	}
+	return nil
+}
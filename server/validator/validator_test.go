@@ -0,0 +1,199 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/openconfig/gnmi/errdiff"
+	"google.golang.org/protobuf/proto"
+
+	bpb "github.com/openconfig/bootz/proto/bootz"
+	gpb "github.com/openconfig/gnmi/proto/gnmi"
+	apb "github.com/openconfig/gnsi/authz"
+	cpb "github.com/openconfig/gnsi/certz"
+	credpb "github.com/openconfig/gnsi/credentialz"
+)
+
+func selfSignedCert(t *testing.T) []byte {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("unable to create certificate: %v", err)
+	}
+	return der
+}
+
+func TestValidate(t *testing.T) {
+	der := selfSignedCert(t)
+	setRequest, err := proto.Marshal(&gpb.SetRequest{
+		Update: []*gpb.Update{{Path: &gpb.Path{Elem: []*gpb.PathElem{{Name: "system"}}}}},
+	})
+	if err != nil {
+		t.Fatalf("unable to marshal SetRequest: %v", err)
+	}
+	emptySetRequest, err := proto.Marshal(&gpb.SetRequest{Prefix: &gpb.Path{Target: "device"}})
+	if err != nil {
+		t.Fatalf("unable to marshal SetRequest: %v", err)
+	}
+	certz := func(encoding cpb.CertificateEncoding, cert []byte) *cpb.UploadRequest {
+		return &cpb.UploadRequest{
+			Entities: []*cpb.Entity{{
+				Version: "1",
+				Entity: &cpb.Entity_CertificateChain{
+					CertificateChain: &cpb.CertificateChain{
+						Certificate: &cpb.Certificate{
+							Type:        cpb.CertificateType_CERTIFICATE_TYPE_X509,
+							Encoding:    encoding,
+							Certificate: cert,
+						},
+					},
+				},
+			}},
+		}
+	}
+	tests := []struct {
+		desc    string
+		resp    *bpb.BootstrapDataResponse
+		wantErr string
+	}{{
+		desc: "No artifacts",
+		resp: &bpb.BootstrapDataResponse{},
+	}, {
+		desc: "Valid artifacts",
+		resp: &bpb.BootstrapDataResponse{
+			BootConfig: &bpb.BootConfig{OcConfig: []byte(`{"system": {}}`), GnmiSetRequest: setRequest},
+			Authz:      &apb.UploadRequest{Version: "1", Policy: `{"name": "policy"}`},
+			Certificates: certz(cpb.CertificateEncoding_CERTIFICATE_ENCODING_PEM,
+				pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+			Credentials: &bpb.Credentials{
+				Credentials: []*credpb.AuthorizedKeysRequest{{
+					Credentials: []*credpb.AccountCredentials{{
+						Account: "admin",
+						AuthorizedKeys: []*credpb.AccountCredentials_AuthorizedKey{{
+							AuthorizedKey: []byte("ssh-ed25519 AAAA"),
+						}},
+					}},
+				}},
+			},
+		},
+	}, {
+		desc:    "Invalid OC config",
+		resp:    &bpb.BootstrapDataResponse{BootConfig: &bpb.BootConfig{OcConfig: []byte("system {}")}},
+		wantErr: "ARTIFACT_TYPE_OC_CONFIG: OC config is not valid JSON",
+	}, {
+		desc:    "Invalid gNMI SetRequest",
+		resp:    &bpb.BootstrapDataResponse{BootConfig: &bpb.BootConfig{GnmiSetRequest: []byte("system {}")}},
+		wantErr: "ARTIFACT_TYPE_GNMI_SET_REQUEST: gNMI SetRequest can not be parsed",
+	}, {
+		desc:    "Empty gNMI SetRequest",
+		resp:    &bpb.BootstrapDataResponse{BootConfig: &bpb.BootConfig{GnmiSetRequest: emptySetRequest}},
+		wantErr: "ARTIFACT_TYPE_GNMI_SET_REQUEST: gNMI SetRequest has no operation",
+	}, {
+		desc:    "Authz policy without version",
+		resp:    &bpb.BootstrapDataResponse{Authz: &apb.UploadRequest{Policy: "{}"}},
+		wantErr: "ARTIFACT_TYPE_AUTHZ: authz policy has no version",
+	}, {
+		desc:    "Invalid authz policy",
+		resp:    &bpb.BootstrapDataResponse{Authz: &apb.UploadRequest{Version: "1", Policy: "allow all"}},
+		wantErr: "not valid JSON",
+	}, {
+		desc:    "DER certificate",
+		resp:    &bpb.BootstrapDataResponse{Certificates: certz(cpb.CertificateEncoding_CERTIFICATE_ENCODING_DER, der)},
+		wantErr: "",
+	}, {
+		desc:    "Certificate not PEM encoded",
+		resp:    &bpb.BootstrapDataResponse{Certificates: certz(cpb.CertificateEncoding_CERTIFICATE_ENCODING_PEM, der)},
+		wantErr: "ARTIFACT_TYPE_CERTZ: certz entity 1: certificate is not PEM encoded",
+	}, {
+		desc:    "Unparsable certificate",
+		resp:    &bpb.BootstrapDataResponse{Certificates: certz(cpb.CertificateEncoding_CERTIFICATE_ENCODING_DER, []byte("cert"))},
+		wantErr: "unable to parse certificate",
+	}, {
+		desc: "Certz entity without artifact",
+		resp: &bpb.BootstrapDataResponse{Certificates: &cpb.UploadRequest{
+			Entities: []*cpb.Entity{{Version: "1"}},
+		}},
+		wantErr: "carries no artifact",
+	}, {
+		desc: "Password without account",
+		resp: &bpb.BootstrapDataResponse{Credentials: &bpb.Credentials{
+			Passwords: []*credpb.PasswordRequest{{
+				Accounts: []*credpb.PasswordRequest_Account{{Password: &credpb.PasswordRequest_Password{}}},
+			}},
+		}},
+		wantErr: "ARTIFACT_TYPE_CREDENTIALS: password has no account",
+	}, {
+		desc: "Server keys without private key",
+		resp: &bpb.BootstrapDataResponse{Credentials: &bpb.Credentials{
+			ServerKeys: []*credpb.ServerKeysRequest{{
+				Version:       "1",
+				AuthArtifacts: []*credpb.ServerKeysRequest_AuthenticationArtifacts{{Certificate: []byte("cert")}},
+			}},
+		}},
+		wantErr: "ARTIFACT_TYPE_CREDENTIALS: server keys 1 have an empty private key",
+	}}
+	r := NewRegistry()
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			err := r.Validate(test.resp)
+			if s := errdiff.Substring(err, test.wantErr); s != "" {
+				t.Errorf("Validate() %s", s)
+			}
+		})
+	}
+}
+
+func TestRegister(t *testing.T) {
+	r := NewRegistry()
+	r.Register(bpb.ArtifactType_ARTIFACT_TYPE_VENDOR_CONFIG, Func(func(resp *bpb.BootstrapDataResponse) error {
+		if len(resp.GetBootConfig().GetVendorConfig()) == 0 {
+			return fmt.Errorf("vendor config is required")
+		}
+		return nil
+	}))
+	resp := &bpb.BootstrapDataResponse{
+		BootConfig: &bpb.BootConfig{OcConfig: []byte("system {}")},
+	}
+	err := r.Validate(resp)
+	for _, want := range []string{"OC config is not valid JSON", "ARTIFACT_TYPE_VENDOR_CONFIG: vendor config is required"} {
+		if s := errdiff.Substring(err, want); s != "" {
+			t.Errorf("Validate() %s", s)
+		}
+	}
+	resp.BootConfig = &bpb.BootConfig{VendorConfig: []byte("hostname test")}
+	if err := r.Validate(resp); err != nil {
+		t.Errorf("Validate() err = %v, want nil", err)
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package webhook notifies external systems, such as chat, inventory or
+// ticketing tools, of bootstrap events by POSTing them as JSON to configured URLs.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/openconfig/bootz/server/events"
+	log "github.com/openconfig/bootz/server/logging"
+)
+
+const (
+	// maxInFlight bounds the number of notifications being delivered at the same time.
+	maxInFlight = 16
+	// attempts is the number of times a notification is sent before it is dropped.
+	attempts = 3
+	// timeout bounds each attempt to deliver a notification.
+	timeout = 10 * time.Second
+)
+
+// DefaultStages are the stages notified if none are configured: bootstrap requests and reported outcomes.
+var DefaultStages = []events.Stage{events.Requested, events.Succeeded, events.Failed}
+
+// Payload is the JSON body POSTed for each event.
+type Payload struct {
+	SerialNumber        string    `json:"serial_number"`
+	ChassisSerialNumber string    `json:"chassis_serial_number,omitempty"`
+	Vendor              string    `json:"vendor,omitempty"`
+	Event               string    `json:"event"`
+	Status              string    `json:"status,omitempty"`
+	Time                time.Time `json:"time"`
+	// Text summarizes the event, so the payload can be posted to chat incoming webhooks as is.
+	Text string `json:"text"`
+}
+
+func newPayload(e events.Event) Payload {
+	text := fmt.Sprintf("bootz: device %s %s", e.SerialNumber, e.Stage)
+	if e.Message != "" {
+		text += ": " + e.Message
+	}
+	return Payload{
+		SerialNumber:        e.SerialNumber,
+		ChassisSerialNumber: e.ChassisSerialNumber,
+		Vendor:              e.Manufacturer,
+		Event:               string(e.Stage),
+		Status:              e.Message,
+		Time:                e.Time,
+		Text:                text,
+	}
+}
+
+// Notifier POSTs events to webhook URLs.
+type Notifier struct {
+	urls   []string
+	stages map[events.Stage]bool
+	client *http.Client
+	// backoff is the wait before the first retry, doubled on each further retry.
+	backoff time.Duration
+}
+
+// New returns a notifier POSTing the events of the given stages to urls. The default stages
+// are notified if none are given.
+func New(urls []string, stages []events.Stage) *Notifier {
+	if len(stages) == 0 {
+		stages = DefaultStages
+	}
+	n := &Notifier{
+		urls:    urls,
+		stages:  map[events.Stage]bool{},
+		client:  &http.Client{Timeout: timeout},
+		backoff: time.Second,
+	}
+	for _, s := range stages {
+		n.stages[s] = true
+	}
+	return n
+}
+
+// Run notifies the events received from ch until it is closed. Notifications being delivered are
+// abandoned, without further retries, once ctx is done.
+func (n *Notifier) Run(ctx context.Context, ch <-chan events.Event) {
+	sem := make(chan struct{}, maxInFlight)
+	var wg sync.WaitGroup
+	for e := range ch {
+		if !n.stages[e.Stage] {
+			continue
+		}
+		body, err := json.Marshal(newPayload(e))
+		if err != nil {
+			log.Errorf("Unable to encode webhook payload: %v", err)
+			continue
+		}
+		for _, url := range n.urls {
+			sem <- struct{}{}
+			wg.Add(1)
+			go func(url string, e events.Event) {
+				defer wg.Done()
+				defer func() { <-sem }()
+				if err := n.post(ctx, url, body); err != nil {
+					log.Warningf("Dropped %v webhook of serial# %v to %v: %v", e.Stage, e.SerialNumber, url, err)
+				}
+			}(url, e)
+		}
+	}
+	wg.Wait()
+}
+
+// post sends the body to url, retrying failed attempts with exponential backoff until ctx is done.
+func (n *Notifier) post(ctx context.Context, url string, body []byte) error {
+	var err error
+	backoff := n.backoff
+	for i := 0; i < attempts; i++ {
+		if i > 0 {
+			t := time.NewTimer(backoff)
+			select {
+			case <-ctx.Done():
+				t.Stop()
+				return fmt.Errorf("%v, last error: %v", ctx.Err(), err)
+			case <-t.C:
+			}
+			backoff *= 2
+		}
+		var req *http.Request
+		req, err = http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		var resp *http.Response
+		resp, err = n.client.Do(req)
+		if ctx.Err() != nil {
+			return err
+		}
+		if err != nil {
+			continue
+		}
+		resp.Body.Close()
+		if resp.StatusCode/100 == 2 {
+			return nil
+		}
+		err = fmt.Errorf("unexpected HTTP status %s", resp.Status)
+		if resp.StatusCode/100 == 4 && resp.StatusCode != http.StatusTooManyRequests {
+			// The request won't succeed without a change to the webhook configuration.
+			return err
+		}
+	}
+	return err
+}
@@ -0,0 +1,118 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/openconfig/bootz/server/events"
+)
+
+func TestRun(t *testing.T) {
+	var mu sync.Mutex
+	var got []Payload
+	attemptsBySerial := map[string]int{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var p Payload
+		if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
+			t.Errorf("webhook received an invalid payload: %v", err)
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		attemptsBySerial[p.SerialNumber]++
+		switch {
+		case p.SerialNumber == "flaky" && attemptsBySerial[p.SerialNumber] == 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		case p.SerialNumber == "rejected":
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		got = append(got, p)
+	}))
+	defer srv.Close()
+
+	n := New([]string{srv.URL}, nil)
+	n.backoff = time.Millisecond
+	now := time.Unix(0, 0).UTC()
+	ch := make(chan events.Event, 10)
+	ch <- events.Event{SerialNumber: "123A", ChassisSerialNumber: "123", Manufacturer: "Cisco", Stage: events.Requested, Time: now}
+	ch <- events.Event{SerialNumber: "123A", Stage: events.Served, Time: now}
+	ch <- events.Event{SerialNumber: "flaky", Stage: events.Failed, Message: "image install failed", Time: now}
+	ch <- events.Event{SerialNumber: "rejected", Stage: events.Succeeded, Time: now}
+	close(ch)
+	n.Run(context.Background(), ch)
+
+	want := []Payload{{
+		SerialNumber:        "123A",
+		ChassisSerialNumber: "123",
+		Vendor:              "Cisco",
+		Event:               "requested",
+		Time:                now,
+		Text:                "bootz: device 123A requested",
+	}, {
+		SerialNumber: "flaky",
+		Event:        "failed",
+		Status:       "image install failed",
+		Time:         now,
+		Text:         "bootz: device flaky failed: image install failed",
+	}}
+	mu.Lock()
+	defer mu.Unlock()
+	// Notifications are delivered concurrently.
+	sortBySerial := cmpopts.SortSlices(func(a, b Payload) bool { return a.SerialNumber < b.SerialNumber })
+	if diff := cmp.Diff(want, got, sortBySerial); diff != "" {
+		t.Errorf("Run() delivered unexpected payloads (-want +got):\n%s", diff)
+	}
+	if attemptsBySerial["rejected"] != 1 {
+		t.Errorf("Run() sent a payload rejected by the webhook %d times, want 1", attemptsBySerial["rejected"])
+	}
+}
+
+func TestPostCanceled(t *testing.T) {
+	attempted := make(chan struct{}, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case attempted <- struct{}{}:
+		default:
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	n := New([]string{srv.URL}, nil)
+	n.backoff = time.Hour
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- n.post(ctx, srv.URL, []byte("{}")) }()
+	<-attempted
+	cancel()
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Errorf("post() err = nil, want error once canceled")
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatalf("post() still retrying after its context was canceled")
+	}
+}
@@ -17,6 +17,7 @@ package main
 
 import (
 	"bytes"
+	"crypto/ed25519"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
@@ -32,6 +33,11 @@ import (
 
 	log "github.com/golang/glog"
 	ownershipvoucher "github.com/openconfig/bootz/common/ownership_voucher"
+	"golang.org/x/crypto/ssh"
+	"google.golang.org/protobuf/encoding/prototext"
+
+	bpb "github.com/openconfig/bootz/proto/bootz"
+	credpb "github.com/openconfig/gnsi/credentialz"
 )
 
 var (
@@ -39,6 +45,8 @@ var (
 	owner              = flag.String("owner", "", "The name of the organization that owns the emulated device.")
 	controlCardSerials = flag.String("serials", "", "Comma-separated list of control card serials to generate OVs for.")
 	serverName         = flag.String("server_name", "localhost", "The DNS name of the Certificate Authority.")
+	accounts           = flag.String("accounts", "", "Comma-separated list of accounts to generate initial SSH credentials for.")
+	ocIntermediates    = flag.Int("oc_intermediates", 0, "The number of intermediate CAs between the PDC and the OC. The OC is the same as the PDC if 0.")
 )
 
 const (
@@ -51,7 +59,13 @@ const (
 // newCertificateAuthority creates a new CA for the chosen organization.
 // It returns a self-signed CA certificate as the first value, the associated private key as the second and any error as the third.
 func newCertificateAuthority(commonName string, org string) (*x509.Certificate, *rsa.PrivateKey, error) {
-	// Create the certificate authority.
+	return newCertificate(commonName, org, true, nil, nil)
+}
+
+// newCertificate creates a new certificate for the chosen organization, which is a CA if isCA is set.
+// The certificate is signed by the parent CA, or self-signed if parent is nil.
+// It returns the certificate as the first value, the associated private key as the second and any error as the third.
+func newCertificate(commonName string, org string, isCA bool, parent *x509.Certificate, parentKey *rsa.PrivateKey) (*x509.Certificate, *rsa.PrivateKey, error) {
 	ca := &x509.Certificate{
 		DNSNames:     []string{*serverName},
 		SerialNumber: big.NewInt(int64(time.Now().Year())),
@@ -64,19 +78,25 @@ func newCertificateAuthority(commonName string, org string) (*x509.Certificate,
 		},
 		NotBefore:             time.Now(),
 		NotAfter:              time.Now().AddDate(10, 0, 0),
-		IsCA:                  true,
+		IsCA:                  isCA,
 		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth},
-		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		KeyUsage:              x509.KeyUsageDigitalSignature,
 		BasicConstraintsValid: true,
 	}
+	if isCA {
+		ca.KeyUsage |= x509.KeyUsageCertSign
+	}
 
 	// Generate an RSA 4096 bit pub/private key pair.
 	caPrivateKey, err := rsa.GenerateKey(rand.Reader, 4096)
 	if err != nil {
 		return nil, nil, err
 	}
-	// Generate the self-signed cert.
-	certBytes, err := x509.CreateCertificate(rand.Reader, ca, ca, &caPrivateKey.PublicKey, caPrivateKey)
+	if parent == nil {
+		parent, parentKey = ca, caPrivateKey
+	}
+	// Generate the cert.
+	certBytes, err := x509.CreateCertificate(rand.Reader, ca, parent, &caPrivateKey.PublicKey, parentKey)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -106,6 +126,65 @@ func writeFile(contents []byte, filename string) error {
 	return os.WriteFile(filename, contents, 0666)
 }
 
+// newSSHKey creates an Ed25519 SSH key pair.
+// It returns the public key in authorized_keys format as the first value, the OpenSSH encoded private key as the second and any error as the third.
+func newSSHKey(comment string) ([]byte, []byte, error) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	sshPub, err := ssh.NewPublicKey(pub)
+	if err != nil {
+		return nil, nil, err
+	}
+	block, err := ssh.MarshalPrivateKey(priv, comment)
+	if err != nil {
+		return nil, nil, err
+	}
+	return ssh.MarshalAuthorizedKey(sshPub), pem.EncodeToMemory(block), nil
+}
+
+// newCredentials creates the initial credentials of a device: an SSH key pair for each account,
+// whose public key is authorized for the account, and an SSH host key.
+// The private keys of the accounts and the public host key are written to the current directory.
+func newCredentials(accounts []string) (*bpb.Credentials, error) {
+	now := uint64(time.Now().Unix())
+	authorized := &credpb.AuthorizedKeysRequest{}
+	for _, a := range accounts {
+		pub, priv, err := newSSHKey(a)
+		if err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(fmt.Sprintf("ssh_%v_priv", a), priv, 0600); err != nil {
+			return nil, err
+		}
+		authorized.Credentials = append(authorized.Credentials, &credpb.AccountCredentials{
+			Account: a,
+			AuthorizedKeys: []*credpb.AccountCredentials_AuthorizedKey{{
+				AuthorizedKey: bytes.TrimSpace(pub),
+				KeyType:       credpb.KeyType_KEY_TYPE_ED25519,
+			}},
+			Version:   "1",
+			CreatedOn: now,
+		})
+	}
+	hostPub, hostPriv, err := newSSHKey("bootz host key")
+	if err != nil {
+		return nil, err
+	}
+	if err := writeFile(hostPub, "ssh_host_pub"); err != nil {
+		return nil, err
+	}
+	return &bpb.Credentials{
+		Credentials: []*credpb.AuthorizedKeysRequest{authorized},
+		ServerKeys: []*credpb.ServerKeysRequest{{
+			AuthArtifacts: []*credpb.ServerKeysRequest_AuthenticationArtifacts{{PrivateKey: hostPriv}},
+			Version:       "1",
+			CreatedOn:     now,
+		}},
+	}, nil
+}
+
 func main() {
 	flag.Parse()
 	if *vendor == "" {
@@ -161,14 +240,35 @@ func main() {
 		log.Exit(err)
 	}
 
-	// For the purpose of this emulator, the OC is the same as the PDC.
-	// Real implementations may instead have the OC as a separate certificate signed by the PDC.
+	// For the purpose of this emulator, the OC is the same as the PDC by default.
+	// Real implementations may instead have the OC as a separate certificate signed by the PDC,
+	// directly or through intermediate CAs whose certificates follow the OC in oc_pub.pem.
 	fmt.Printf("Generating %v OC cert and private key\n", *owner)
 	oc, ocPriv := pdc, pdcPriv
+	var intermediatesPem []byte
+	if *ocIntermediates > 0 {
+		for i := 1; i <= *ocIntermediates; i++ {
+			ca, caPriv, err := newCertificate(fmt.Sprintf("Device Owner Intermediate CA %d", i), *owner, true, oc, ocPriv)
+			if err != nil {
+				log.Exitf("unable to generate intermediate CA: %v", err)
+			}
+			caPem, err := pemEncode(ca.Raw, "CERTIFICATE")
+			if err != nil {
+				log.Exit(err)
+			}
+			// The issuer of each certificate follows it in the chain.
+			intermediatesPem = append(caPem, intermediatesPem...)
+			oc, ocPriv = ca, caPriv
+		}
+		if oc, ocPriv, err = newCertificate("Ownership Certificate", *owner, false, oc, ocPriv); err != nil {
+			log.Exitf("unable to generate OC: %v", err)
+		}
+	}
 	ocPem, err := pemEncode(oc.Raw, "CERTIFICATE")
 	if err != nil {
 		log.Exit(err)
 	}
+	ocPem = append(ocPem, intermediatesPem...)
 	if err := writeFile(ocPem, "oc_pub.pem"); err != nil {
 		log.Exit(err)
 	}
@@ -193,6 +293,22 @@ func main() {
 		}
 	}
 
+	// Generate initial credentials.
+	if *accounts != "" {
+		fmt.Printf("Generating initial credentials for accounts %v\n", *accounts)
+		creds, err := newCredentials(strings.Split(*accounts, ","))
+		if err != nil {
+			log.Exitf("unable to create credentials: %v", err)
+		}
+		text, err := prototext.MarshalOptions{Multiline: true}.Marshal(creds)
+		if err != nil {
+			log.Exit(err)
+		}
+		if err := writeFile(text, "credentials.prototext"); err != nil {
+			log.Exit(err)
+		}
+	}
+
 	// Generate a image file.
 	if err := writeFile([]byte("ABCDEF"), "image.txt"); err != nil {
 		log.Exitf("Error when generating image file: %v", err)